@@ -0,0 +1,312 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/term"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+)
+
+// agentsSubcommands are the `bv agents` actions, in help order.
+var agentsSubcommands = []string{"status", "install", "update", "remove"}
+
+// agentsStdin and agentsInteractive are swapped out by tests.
+var (
+	agentsStdin       io.Reader = os.Stdin
+	agentsInteractive           = func() bool {
+		return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+	}
+)
+
+// agentFileStatus is one file in the `bv agents status --json` report.
+type agentFileStatus struct {
+	Path           string `json:"path"`
+	Status         string `json:"status"` // current, modified, missing, outdated, wrong-variant
+	BlurbVersion   int    `json:"blurb_version,omitempty"`
+	Legacy         bool   `json:"legacy,omitempty"`
+	Modified       bool   `json:"modified,omitempty"` // blurb was edited by hand
+	Target         string `json:"target,omitempty"`
+	ExpectedTarget string `json:"expected_target"`
+}
+
+// runAgentsCommand implements `bv agents <status|install|update|remove>`.
+// Returns the process exit code: 0 on success, 1 on errors, 2 on usage errors.
+func runAgentsCommand(args []string, stdout, stderr io.Writer) int {
+	usage := func() {
+		fmt.Fprintln(stderr, "Usage: bv agents status [--json] [--dir DIR]")
+		fmt.Fprintln(stderr, "       bv agents install [--file FILE | --recursive] [--dry-run | --yes] [--dir DIR]")
+		fmt.Fprintln(stderr, "       bv agents update [--file FILE] [--merge] [--dry-run | --yes] [--dir DIR]")
+		fmt.Fprintln(stderr, "       bv agents remove [--file FILE] [--dry-run | --yes] [--dir DIR]")
+		fmt.Fprintln(stderr, "\nManage the bv instructions blurb in AGENTS.md, CLAUDE.md, and other agent")
+		fmt.Fprintln(stderr, "files. --dry-run prints the change as a unified diff without writing it. In a")
+		fmt.Fprintln(stderr, "terminal, changes are shown and confirmed before writing unless --yes is given.")
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+	switch args[0] {
+	case "status":
+		return runAgentsStatus(args[1:], stdout, stderr)
+	case "install", "update", "remove":
+		return runAgentsChange(args[0], args[1:], stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		usage()
+		return 0
+	}
+	fmt.Fprintf(stderr, "bv agents: unknown subcommand %q\n", args[0])
+	usage()
+	return 2
+}
+
+// runAgentsStatus reports the blurb state of every agent file in the project.
+func runAgentsStatus(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("agents status", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "Print the report as JSON")
+	dir := fs.String("dir", ".", "Project directory")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv agents status [--json] [--dir DIR]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	statuses := []agentFileStatus{}
+	for _, d := range agents.DetectAgentFiles(*dir) {
+		statuses = append(statuses, newAgentFileStatus(d))
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(statuses); err != nil {
+			fmt.Fprintf(stderr, "Error encoding JSON: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	if len(statuses) == 0 {
+		fmt.Fprintf(stdout, "No agent file in %s. Run `bv agents install` to create %s.\n",
+			*dir, filepath.Base(agents.GetPreferredAgentFilePath(*dir)))
+		return 0
+	}
+	for _, s := range statuses {
+		fmt.Fprintf(stdout, "%-40s %s\n", s.Path, describeAgentFileStatus(s))
+	}
+	return 0
+}
+
+// newAgentFileStatus summarizes a detection for the status report.
+func newAgentFileStatus(d agents.AgentFileDetection) agentFileStatus {
+	s := agentFileStatus{
+		Path:           d.FilePath,
+		BlurbVersion:   d.BlurbVersion,
+		Legacy:         d.HasLegacyBlurb,
+		Modified:       d.BlurbModified,
+		ExpectedTarget: string(agents.TargetForFile(d.FilePath)),
+	}
+	if d.HasBlurb && !d.HasLegacyBlurb {
+		s.Target = string(d.BlurbTarget)
+	}
+	switch {
+	case d.NeedsBlurb():
+		s.Status = "missing"
+	case d.HasLegacyBlurb || d.BlurbVersion < agents.BlurbVersion:
+		s.Status = "outdated"
+	case d.HasWrongVariant():
+		s.Status = "wrong-variant"
+	case d.BlurbModified:
+		s.Status = "modified"
+	default:
+		s.Status = "current"
+	}
+	return s
+}
+
+// describeAgentFileStatus is the human-readable form of s.Status.
+func describeAgentFileStatus(s agentFileStatus) string {
+	switch s.Status {
+	case "missing":
+		return "no bv blurb"
+	case "outdated":
+		if s.Legacy {
+			return "legacy bv blurb (run `bv agents update`)"
+		}
+		return fmt.Sprintf("outdated bv blurb v%d, current v%d (run `bv agents update`)", s.BlurbVersion, agents.BlurbVersion)
+	case "wrong-variant":
+		return fmt.Sprintf("%s blurb, expected %s (run `bv agents update`)", s.Target, s.ExpectedTarget)
+	case "modified":
+		return fmt.Sprintf("bv blurb (v%d, %s) edited by hand (run `bv agents update --merge` to refresh it and keep your additions)", s.BlurbVersion, s.Target)
+	}
+	return fmt.Sprintf("current bv blurb (v%d, %s)", s.BlurbVersion, s.Target)
+}
+
+// runAgentsChange implements install, update, and remove. Without --file,
+// install targets the preferred agent file (creating AGENTS.md if there is
+// none), while update and remove apply to every detected agent file.
+// install --recursive also covers nested AGENTS.md/CLAUDE.md files.
+func runAgentsChange(action string, args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("agents "+action, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	file := fs.String("file", "", "Agent file to change (default: the detected agent files)")
+	dryRun := fs.Bool("dry-run", false, "Print the change as a unified diff without writing it")
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt")
+	fs.BoolVar(yes, "y", false, "Shorthand for --yes")
+	dir := fs.String("dir", ".", "Project directory")
+	recursive, merge := new(bool), new(bool)
+	usageLine := fmt.Sprintf("Usage: bv agents %s [--file FILE] [--dry-run | --yes] [--dir DIR]", action)
+	if action == "install" {
+		fs.BoolVar(recursive, "recursive", false, "Also install or update the blurb in every nested AGENTS.md/CLAUDE.md (skips git-ignored files)")
+		usageLine = "Usage: bv agents install [--file FILE | --recursive] [--dry-run | --yes] [--dir DIR]"
+	}
+	if action == "update" {
+		fs.BoolVar(merge, "merge", false, "Also refresh hand-edited blurbs, keeping sections you added")
+		usageLine = "Usage: bv agents update [--file FILE] [--merge] [--dry-run | --yes] [--dir DIR]"
+	}
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, usageLine)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 || (*recursive && *file != "") {
+		fs.Usage()
+		return 2
+	}
+
+	var paths []string
+	switch {
+	case *recursive:
+		// planned below
+	case *file != "":
+		paths = []string{*file}
+	case action == "install":
+		if d := agents.DetectAgentFile(*dir); d.Found() {
+			paths = []string{d.FilePath}
+		} else {
+			paths = []string{agents.GetPreferredAgentFilePath(*dir)}
+		}
+	default:
+		for _, d := range agents.DetectAgentFiles(*dir) {
+			paths = append(paths, d.FilePath)
+		}
+	}
+
+	plan := map[string]func(string) (agents.Change, error){
+		"install": agents.PlanEnsure,
+		"update":  agents.PlanUpdate,
+		"remove":  agents.PlanRemove,
+	}[action]
+	if *merge {
+		plan = agents.PlanMerge
+	}
+
+	var planned []agents.Change
+	if *recursive {
+		var err error
+		if planned, err = agents.PlanEnsureRecursive(*dir); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+	for _, path := range paths {
+		c, err := plan(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		planned = append(planned, c)
+	}
+	var changes []agents.Change
+	for _, c := range planned {
+		if c.Changed() {
+			changes = append(changes, c)
+		}
+	}
+
+	if len(changes) == 0 {
+		if *recursive {
+			fmt.Fprintf(stdout, "Nothing to do: all %d agent file(s) have the current blurb.\n", len(planned))
+			return 0
+		}
+		fmt.Fprintln(stdout, "Nothing to do.")
+		return 0
+	}
+	for _, c := range changes {
+		for _, heading := range c.Overwritten {
+			fmt.Fprintf(stderr, "Warning: %s: your edits to %q conflict with bv's update and will be replaced\n", c.Path, sectionName(heading))
+		}
+	}
+	if *dryRun {
+		for _, c := range changes {
+			fmt.Fprint(stdout, c.Diff())
+		}
+		return 0
+	}
+
+	// A person at a terminal sees the diff and confirms; scripts and agents
+	// (no terminal) and --yes go straight to writing.
+	if !*yes && agentsInteractive() {
+		for _, c := range changes {
+			fmt.Fprint(stdout, c.Diff())
+		}
+		fmt.Fprintf(stdout, "\nApply these changes to %d file(s)? [y/N]: ", len(changes))
+		response, _ := bufio.NewReader(agentsStdin).ReadString('\n')
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "y" && response != "yes" {
+			fmt.Fprintln(stdout, "No changes made")
+			return 0
+		}
+	}
+
+	for _, c := range changes {
+		if err := c.Apply(); err != nil {
+			fmt.Fprintf(stderr, "Error: %s: %v\n", c.Path, err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "%s: %s\n", c.Path, agentChangeVerb[c.Action])
+	}
+	if *recursive {
+		fmt.Fprintf(stdout, "\n%d agent file(s) changed, %d already current.\n", len(changes), len(planned)-len(changes))
+	}
+	return 0
+}
+
+// sectionName names a blurb section in messages; the untitled section
+// before the first heading is the intro.
+func sectionName(heading string) string {
+	if heading == "" {
+		return "intro"
+	}
+	return strings.TrimLeft(heading, "# ")
+}
+
+// agentChangeVerb describes an applied change.
+var agentChangeVerb = map[agents.Action]string{
+	agents.ActionCreate: "created with the bv blurb",
+	agents.ActionAppend: "added the bv blurb",
+	agents.ActionUpdate: "updated the bv blurb",
+	agents.ActionRemove: "removed the bv blurb",
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+)
+
+func TestRunAgentsCommand_DryRunThenInstall(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# Agents\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runAgentsCommand([]string{"install", "--dry-run", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "@@ ") || !strings.Contains(stdout.String(), "+"+agents.BlurbStartMarker) {
+		t.Errorf("dry run should print a unified diff, got:\n%s", stdout.String())
+	}
+	if content, _ := os.ReadFile(path); string(content) != "# Agents\n" {
+		t.Fatal("dry run must not modify the file")
+	}
+
+	stdout.Reset()
+	if code := runAgentsCommand([]string{"install", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("install exit code %d, stderr=%q", code, stderr.String())
+	}
+	if ok, _ := agents.VerifyBlurbPresent(path); !ok {
+		t.Fatal("install should add the blurb")
+	}
+
+	stdout.Reset()
+	if code := runAgentsCommand([]string{"status", "--json", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("status exit code %d", code)
+	}
+	var statuses []agentFileStatus
+	if err := json.Unmarshal(stdout.Bytes(), &statuses); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	if len(statuses) != 1 || statuses[0].Status != "current" {
+		t.Errorf("want one current file, got %+v", statuses)
+	}
+
+	stdout.Reset()
+	if code := runAgentsCommand([]string{"remove", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("remove exit code %d", code)
+	}
+	if content, _ := os.ReadFile(path); agents.ContainsAnyBlurb(string(content)) {
+		t.Error("remove should take the blurb out")
+	}
+}
+
+func TestRunAgentsCommand_Usage(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	for _, args := range [][]string{nil, {"bogus"}, {"status", "extra"}} {
+		if code := runAgentsCommand(args, &stdout, &stderr); code != 2 {
+			t.Errorf("%v: exit code %d, want 2", args, code)
+		}
+	}
+}
+
+func TestRunAgentsCommand_ConfirmsInTerminal(t *testing.T) {
+	origStdin, origInteractive := agentsStdin, agentsInteractive
+	t.Cleanup(func() { agentsStdin, agentsInteractive = origStdin, origInteractive })
+	agentsInteractive = func() bool { return true }
+
+	dir := t.TempDir()
+	path := filepath.Join(dir, "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# Agents\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	agentsStdin = strings.NewReader("n\n")
+	if code := runAgentsCommand([]string{"install", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "+"+agents.BlurbStartMarker) || !strings.Contains(stdout.String(), "[y/N]") {
+		t.Errorf("expected diff and prompt, got:\n%s", stdout.String())
+	}
+	if content, _ := os.ReadFile(path); string(content) != "# Agents\n" {
+		t.Fatal("declining must leave the file alone")
+	}
+
+	agentsStdin = strings.NewReader("y\n")
+	if code := runAgentsCommand([]string{"install", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	if ok, _ := agents.VerifyBlurbPresent(path); !ok {
+		t.Fatal("confirming should apply the change")
+	}
+
+	// --yes skips the prompt; stdin is never read.
+	agentsStdin = strings.NewReader("")
+	stdout.Reset()
+	if code := runAgentsCommand([]string{"remove", "--yes", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	if strings.Contains(stdout.String(), "[y/N]") {
+		t.Error("--yes should not prompt")
+	}
+	if content, _ := os.ReadFile(path); agents.ContainsAnyBlurb(string(content)) {
+		t.Error("remove --yes should take the blurb out")
+	}
+}
+
+func TestRunAgentsCommand_Recursive(t *testing.T) {
+	dir := t.TempDir()
+	for _, rel := range []string{"AGENTS.md", "svc/a/AGENTS.md", "svc/b/CLAUDE.md"} {
+		path := filepath.Join(dir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("# "+rel+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runAgentsCommand([]string{"install", "--recursive", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "3 agent file(s) changed, 0 already current.") {
+		t.Errorf("expected summary, got:\n%s", stdout.String())
+	}
+	if ok, _ := agents.VerifyBlurbPresent(filepath.Join(dir, "svc", "b", "CLAUDE.md")); !ok {
+		t.Error("nested CLAUDE.md should get the blurb")
+	}
+
+	stdout.Reset()
+	if code := runAgentsCommand([]string{"install", "--recursive", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	if !strings.Contains(stdout.String(), "all 3 agent file(s) have the current blurb") {
+		t.Errorf("second run should be a no-op, got:\n%s", stdout.String())
+	}
+
+	if code := runAgentsCommand([]string{"install", "--recursive", "--file", "AGENTS.md"}, &stdout, &stderr); code != 2 {
+		t.Errorf("--recursive with --file: exit code %d, want 2", code)
+	}
+	if code := runAgentsCommand([]string{"update", "--recursive"}, &stdout, &stderr); code != 2 {
+		t.Errorf("--recursive is install-only: exit code %d, want 2", code)
+	}
+}
+
+func TestRunAgentsCommand_StatusReportsModified(t *testing.T) {
+	dir := t.TempDir()
+	edited := strings.Replace(agents.AgentBlurb, "### Key Concepts", "### Our Rules\n\n- Lint first\n\n### Key Concepts", 1)
+	if err := os.WriteFile(filepath.Join(dir, "AGENTS.md"), []byte("# Agents\n\n"+edited+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runAgentsCommand([]string{"status", "--json", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	var statuses []agentFileStatus
+	if err := json.Unmarshal(stdout.Bytes(), &statuses); err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 1 || statuses[0].Status != "modified" || !statuses[0].Modified {
+		t.Errorf("want a modified file, got %+v", statuses)
+	}
+
+	stdout.Reset()
+	if code := runAgentsCommand([]string{"status", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d", code)
+	}
+	if !strings.Contains(stdout.String(), "update --merge") {
+		t.Errorf("status should point at update --merge, got %q", stdout.String())
+	}
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"bytes"
+	"compress/gzip"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// archivePlan splits a beads file into the lines that stay and the closed
+// issues that move to an archive. Lines are kept byte for byte.
+type archivePlan struct {
+	Keep       []byte
+	Archive    []byte
+	Archived   []string // IDs moved, in file order
+	Referenced []string // Old enough, but still a dependency of an issue that stays
+}
+
+// runArchiveCommand implements `bv archive`. Returns the process exit code:
+// 0 on success (including nothing to archive), 1 if the files can't be read
+// or written, 2 on usage errors.
+func runArchiveCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("archive", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	before := fs.String("before", "", "Archive issues closed before this date (YYYY-MM-DD, required)")
+	dryRun := fs.Bool("dry-run", false, "Report what would be archived without writing")
+	dir := fs.String("dir", ".", "Project directory")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv archive --before YYYY-MM-DD [--dry-run] [--dir DIR]")
+		fmt.Fprintln(stderr, "\nMove issues closed before a date out of the beads file into")
+		fmt.Fprintln(stderr, ".beads/archive/*.jsonl.gz. Closed issues that a remaining issue still")
+		fmt.Fprintln(stderr, "depends on stay. Use --include-archive to analyze them again.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	cutoff, err := time.Parse("2006-01-02", *before)
+	if fs.NArg() > 0 || err != nil {
+		fs.Usage()
+		return 2
+	}
+
+	projectDir, err := filepath.Abs(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	beadsDir, err := loader.GetBeadsDir(projectDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	plan := planArchive(data, cutoff)
+	if len(plan.Referenced) > 0 {
+		fmt.Fprintf(stdout, "Keeping %d closed issues that remaining issues depend on\n", len(plan.Referenced))
+	}
+	if len(plan.Archived) == 0 {
+		fmt.Fprintf(stdout, "No closed issues before %s to archive\n", *before)
+		return 0
+	}
+	archivePath := nextArchivePath(beadsDir, *before)
+	rel := func(p string) string {
+		if r, err := filepath.Rel(projectDir, p); err == nil {
+			return r
+		}
+		return p
+	}
+	if *dryRun {
+		fmt.Fprintf(stdout, "Would archive %d closed issues to %s\n", len(plan.Archived), rel(archivePath))
+		return 0
+	}
+
+	var gz bytes.Buffer
+	zw := gzip.NewWriter(&gz)
+	if _, err := zw.Write(plan.Archive); err != nil {
+		fmt.Fprintf(stderr, "Error compressing archive: %v\n", err)
+		return 1
+	}
+	if err := zw.Close(); err != nil {
+		fmt.Fprintf(stderr, "Error compressing archive: %v\n", err)
+		return 1
+	}
+	// The archive goes first: if rewriting the beads file then fails, the
+	// issues are in both places, and the live copies win on load.
+	if err := (agents.Change{Path: archivePath, Action: agents.ActionCreate, After: gz.String()}).Apply(); err != nil {
+		fmt.Fprintf(stderr, "Error writing %s: %v\n", archivePath, err)
+		return 1
+	}
+	if err := (agents.Change{Path: path, Action: agents.ActionUpdate, Before: string(data), After: string(plan.Keep)}).Apply(); err != nil {
+		fmt.Fprintf(stderr, "Error writing %s: %v\n", path, err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Archived %d closed issues to %s\n", len(plan.Archived), rel(archivePath))
+	fmt.Fprintln(stdout, "Note: bd's database still has them; a bd export rewrites them into the beads file.")
+	return 0
+}
+
+// planArchive picks the closed issues in data whose closed_at (or, without
+// one, updated_at) is before cutoff. An issue that stays can't lose a
+// dependency target, so closed issues referenced by one stay too, along with
+// whatever they reference in turn. Lines that don't parse stay.
+func planArchive(data []byte, cutoff time.Time) archivePlan {
+	type entry struct {
+		line  []byte
+		issue *model.Issue
+	}
+	var entries []entry
+	for rest := bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")); len(rest) > 0; {
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i+1], rest[i+1:]
+		} else {
+			line, rest = append(append([]byte(nil), rest...), '\n'), nil
+		}
+		e := entry{line: line}
+		var issue model.Issue
+		if body := bytes.TrimSpace(line); len(body) > 0 && json.Unmarshal(body, &issue) == nil {
+			e.issue = &issue
+		}
+		entries = append(entries, e)
+	}
+
+	old := func(issue *model.Issue) bool {
+		if issue == nil || !model.NormalizeStatus(issue.Status).IsClosed() {
+			return false
+		}
+		closed := issue.UpdatedAt
+		if issue.ClosedAt != nil {
+			closed = *issue.ClosedAt
+		}
+		return !closed.IsZero() && closed.Before(cutoff)
+	}
+	candidate := make(map[string]bool)
+	for _, e := range entries {
+		if old(e.issue) {
+			candidate[e.issue.ID] = true
+		}
+	}
+
+	// Keep referenced candidates until nothing that stays points at one
+	var plan archivePlan
+	for changed := true; changed; {
+		changed = false
+		for _, e := range entries {
+			if e.issue == nil || candidate[e.issue.ID] {
+				continue
+			}
+			for _, dep := range e.issue.Dependencies {
+				if dep != nil && candidate[dep.DependsOnID] {
+					delete(candidate, dep.DependsOnID)
+					plan.Referenced = append(plan.Referenced, dep.DependsOnID)
+					changed = true
+				}
+			}
+		}
+	}
+
+	var keep, archive bytes.Buffer
+	if bytes.HasPrefix(data, []byte("\xef\xbb\xbf")) {
+		keep.WriteString("\xef\xbb\xbf")
+	}
+	for _, e := range entries {
+		if e.issue != nil && candidate[e.issue.ID] {
+			archive.Write(e.line)
+			plan.Archived = append(plan.Archived, e.issue.ID)
+			continue
+		}
+		keep.Write(e.line)
+	}
+	plan.Keep, plan.Archive = keep.Bytes(), archive.Bytes()
+	return plan
+}
+
+// nextArchivePath names a new archive for the cutoff date, numbering it if
+// that cutoff was used before.
+func nextArchivePath(beadsDir, before string) string {
+	base := filepath.Join(loader.ArchiveDir(beadsDir), "closed-before-"+before)
+	path := base + loader.ArchiveExt
+	for n := 2; ; n++ {
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			return path
+		}
+		path = base + "-" + strconv.Itoa(n) + loader.ArchiveExt
+	}
+}
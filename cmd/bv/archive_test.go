@@ -0,0 +1,90 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestPlanArchive(t *testing.T) {
+	lines := []string{
+		`{"id":"a-1","title":"Old","status":"closed","issue_type":"task","closed_at":"2023-05-01T00:00:00Z"}`,
+		`{"id":"a-2","title":"Old, no closed_at","status":"Closed","issue_type":"task","updated_at":"2023-06-01T00:00:00Z"}`,
+		`{"id":"a-3","title":"Recent","status":"closed","issue_type":"task","closed_at":"2024-03-01T00:00:00Z"}`,
+		`{"id":"a-4","title":"Old but needed","status":"closed","issue_type":"task","closed_at":"2023-01-01T00:00:00Z"}`,
+		`{"id":"a-5","title":"Needed by a-4","status":"closed","issue_type":"task","closed_at":"2023-01-01T00:00:00Z"}`,
+		`{"id":"a-6","title":"Open","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-4","type":"blocks"}]}`,
+		`not json`,
+	}
+	lines[3] = strings.TrimSuffix(lines[3], "}") + `,"dependencies":[{"depends_on_id":"a-5","type":"related"}]}`
+	data := strings.Join(lines, "\n") // No trailing newline
+
+	plan := planArchive([]byte(data), time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	if !reflect.DeepEqual(plan.Archived, []string{"a-1", "a-2"}) {
+		t.Errorf("archived = %v", plan.Archived)
+	}
+	if !reflect.DeepEqual(plan.Referenced, []string{"a-4", "a-5"}) {
+		t.Errorf("referenced = %v", plan.Referenced)
+	}
+	if want := lines[0] + "\n" + lines[1] + "\n"; string(plan.Archive) != want {
+		t.Errorf("archive = %q", plan.Archive)
+	}
+	if want := strings.Join(lines[2:], "\n") + "\n"; string(plan.Keep) != want {
+		t.Errorf("keep = %q", plan.Keep)
+	}
+}
+
+func TestRunArchiveCommand(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	content := `{"id":"a-1","title":"Old","status":"closed","issue_type":"task","closed_at":"2023-05-01T00:00:00Z"}` + "\n" +
+		`{"id":"a-2","title":"Open","status":"open","issue_type":"task"}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := runArchiveCommand(append(args, "--dir", dir), &stdout, &stderr)
+		return code, stdout.String() + stderr.String()
+	}
+
+	if code, _ := run("--before", "January"); code != 2 {
+		t.Errorf("bad date = %d, want 2", code)
+	}
+	if code, out := run("--before", "2024-01-01", "--dry-run"); code != 0 || !strings.Contains(out, "Would archive 1 closed issues to .beads/archive/closed-before-2024-01-01.jsonl.gz") {
+		t.Fatalf("dry run = %d %q", code, out)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Fatal("--dry-run wrote the file")
+	}
+
+	if code, out := run("--before", "2024-01-01"); code != 0 || !strings.Contains(out, "Archived 1 closed issues") {
+		t.Fatalf("archive = %d %q", code, out)
+	}
+	live, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(live) != 1 || live[0].ID != "a-2" {
+		t.Fatalf("live issues after archive: %+v %v", live, err)
+	}
+	archived, err := loader.LoadArchivedIssues(beadsDir, loader.ParseOptions{})
+	if err != nil || len(archived) != 1 || archived[0].ID != "a-1" {
+		t.Fatalf("archived issues: %+v %v", archived, err)
+	}
+
+	if code, out := run("--before", "2024-01-01"); code != 0 || !strings.Contains(out, "No closed issues") {
+		t.Errorf("second run = %d %q", code, out)
+	}
+	if got := nextArchivePath(beadsDir, "2024-01-01"); filepath.Base(got) != "closed-before-2024-01-01-2.jsonl.gz" {
+		t.Errorf("next archive path = %s", got)
+	}
+}
@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// robotSchemaVersion is the version of the robot JSON contract as a whole.
+// Bump it when a field is removed or changes meaning; new fields don't
+// need a bump.
+const robotSchemaVersion = 1
+
+// capabilitiesSchemaVersion is the version of the --robot-capabilities
+// payload itself, so clients can parse it before trusting anything else.
+const capabilitiesSchemaVersion = 1
+
+// robotCapabilities is the --robot-capabilities payload: what this bv binary
+// supports, so orchestrators can adapt to older and newer versions without
+// parsing --robot-help.
+type robotCapabilities struct {
+	SchemaVersion    int                    `json:"schema_version"`
+	Version          string                 `json:"version"`
+	RobotCommands    []robotCapability      `json:"robot_commands"`
+	RobotFilters     []string               `json:"robot_filters"` // flags that narrow any robot output
+	SchemaVersions   map[string]int         `json:"schema_versions"`
+	Features         map[string]bool        `json:"features"`
+	InsightsVariants []robotInsightsVariant `json:"insights_variants"`
+	Deprecations     []robotDeprecation     `json:"deprecations"`
+	ExitCodes        []robotExitCode        `json:"exit_codes"`
+}
+
+// robotCapability is one --robot-* command flag.
+type robotCapability struct {
+	Flag        string `json:"flag"`
+	TakesValue  bool   `json:"takes_value"`
+	Description string `json:"description"`
+}
+
+// robotInsightsVariant is a way of asking for graph insights.
+type robotInsightsVariant struct {
+	Name  string `json:"name"`
+	Flags string `json:"flags"`
+}
+
+// robotFilterFlags are --robot-* flags that modify other robot commands
+// rather than producing output of their own.
+var robotFilterFlags = map[string]bool{
+	"robot-min-confidence": true,
+	"robot-max-results":    true,
+	"robot-by-label":       true,
+	"robot-by-assignee":    true,
+}
+
+// robotFeatures are optional subsystems compiled into this binary. A
+// client should treat a feature missing from the map as unsupported.
+var robotFeatures = map[string]bool{
+	"phase2_analysis":       true, // PageRank, betweenness, HITS, ... (triage waits for them)
+	"analysis_cache":        true, // phase 2 results served from disk between runs
+	"as_of":                 true, // --as-of on every robot command
+	"label_scope":           true, // --label subgraph analysis
+	"hybrid_search":         true, // --search-mode hybrid
+	"workspaces":            true, // --workspace multi-repo loading
+	"history_correlation":   true, // --robot-history and correlation feedback
+	"sprints":               true,
+	"forecast":              true,
+	"drift_baseline":        true, // --save-baseline / --check-drift
+	"agents_command":        true, // bv agents status|install|update|remove
+	"agents_recursive":      true, // bv agents install --recursive
+	"agents_blurb_merge":    true, // bv agents update --merge
+	"sessions":              true, // --robot-next --agent-id/--claim and .bv/sessions.json
+	"time_log":              true, // claim sessions timed in .bv/timelog.jsonl
+	"local_notes":           true, // --robot-show --with-notes: .beads/.bv-notes/ as local_notes
+	"code_refs":             true, // --robot-show code_refs: commits and branches mentioning the issue
+	"likely_files":          true, // --robot-show likely_files; --robot-impact counts them as conflicts
+	"code_owners":           true, // CODEOWNERS: --robot-show owners, --robot-list --owner, triage suggested_assignee
+	"code_age":              true, // git blame age of likely files in risk: breakdown.code_age, risk_signals.code_age_days
+	"suggested_reviewers":   true, // triage suggested_reviewers for issues labeled needs-review/in-review/review
+	"worktree_claims":       true, // --robot-worktrees; claims record branch/worktree; --robot-next warnings
+	"git_hooks":             true, // bv hooks install|status|remove|run with .bv/git-hooks.yaml
+	"ci_summary":            true, // bv ci-summary job summary with .bv/ci.yaml policies
+	"sarif":                 true, // bv lint --format sarif: lint, dangling deps, cycles, alerts at issue lines
+	"junit":                 true, // bv lint and bv ci-summary --format junit: one test case per check
+	"org_report":            true, // bv org-report --repos: cross-repo health comparison as JSON and HTML
+	"batch_show":            true, // --robot-show --ids a,b,c
+	"exit_codes":            true, // exit_codes contract; --exit-code for data conditions
+	"errors_json":           true, // --errors-json / --quiet stderr modes
+	"analyzer_plugins":      true, // bv-analyzer-* results under plugins in --robot-insights --plugins
+	"custom_rules":          true, // .bv/rules.yaml score adjustments and alert rules
+	"instance_coordination": true, // advisory lock and instance registry (--robot-status)
+}
+
+// robotInsightsVariants lists the ways to request graph insights.
+var robotInsightsVariants = []robotInsightsVariant{
+	{Name: "full", Flags: "--robot-insights"},
+	{Name: "label_scoped", Flags: "--robot-insights --label <label>"},
+	{Name: "historical", Flags: "--robot-insights --as-of <ref>"},
+	{Name: "force_full", Flags: "--robot-insights --force-full-analysis"},
+	{Name: "label_health", Flags: "--robot-label-health"},
+	{Name: "label_flow", Flags: "--robot-label-flow"},
+}
+
+// buildRobotCapabilities describes the robot surface registered on fs,
+// commands and filters in flag name order.
+func buildRobotCapabilities(fs *flag.FlagSet) robotCapabilities {
+	caps := robotCapabilities{
+		SchemaVersion: capabilitiesSchemaVersion,
+		Version:       version.Version,
+		RobotCommands: []robotCapability{},
+		RobotFilters:  []string{},
+		SchemaVersions: map[string]int{
+			"robot":         robotSchemaVersion,
+			"robot_min":     minRobotSchemaVersion, // oldest schema --compat accepts
+			"capabilities":  capabilitiesSchemaVersion,
+			"sqlite_export": export.SchemaVersion,
+			"agent_blurb":   agents.BlurbVersion,
+		},
+		Features:         robotFeatures,
+		InsightsVariants: robotInsightsVariants,
+		Deprecations:     robotDeprecationNotices(),
+		ExitCodes:        robotExitCodes,
+	}
+	fs.VisitAll(func(f *flag.Flag) {
+		if !strings.HasPrefix(f.Name, "robot-") || strings.HasPrefix(f.Usage, deprecatedUsagePrefix) {
+			return // deprecated aliases are listed under deprecations
+		}
+		if robotFilterFlags[f.Name] {
+			caps.RobotFilters = append(caps.RobotFilters, "--"+f.Name)
+			return
+		}
+		caps.RobotCommands = append(caps.RobotCommands, robotCapability{
+			Flag:        "--" + f.Name,
+			TakesValue:  !isBoolFlag(f),
+			Description: f.Usage,
+		})
+	})
+	return caps
+}
+
+func isBoolFlag(f *flag.Flag) bool {
+	b, ok := f.Value.(interface{ IsBoolFlag() bool })
+	return ok && b.IsBoolFlag()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"os/exec"
+	"testing"
+)
+
+func TestBuildRobotCapabilities(t *testing.T) {
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	fs.Bool("robot-triage", false, "Output unified triage")
+	fs.String("robot-forecast", "", "Output ETA forecast")
+	fs.Int("robot-max-results", 0, "Limit robot output count")
+	fs.Bool("help", false, "Show help")
+
+	caps := buildRobotCapabilities(fs)
+	want := []robotCapability{
+		{Flag: "--robot-forecast", TakesValue: true, Description: "Output ETA forecast"},
+		{Flag: "--robot-triage", TakesValue: false, Description: "Output unified triage"},
+	}
+	if len(caps.RobotCommands) != len(want) {
+		t.Fatalf("robot_commands = %+v, want %+v", caps.RobotCommands, want)
+	}
+	for i := range want {
+		if caps.RobotCommands[i] != want[i] {
+			t.Errorf("robot_commands[%d] = %+v, want %+v", i, caps.RobotCommands[i], want[i])
+		}
+	}
+	if len(caps.RobotFilters) != 1 || caps.RobotFilters[0] != "--robot-max-results" {
+		t.Errorf("robot_filters = %v", caps.RobotFilters)
+	}
+	if caps.SchemaVersions["robot"] != robotSchemaVersion {
+		t.Errorf("schema_versions = %v", caps.SchemaVersions)
+	}
+	if !caps.Features["phase2_analysis"] {
+		t.Errorf("features = %v", caps.Features)
+	}
+}
+
+func TestRobotCapabilitiesWithoutBeads(t *testing.T) {
+	exe := buildTestBinary(t)
+	cmd := exec.Command(exe, "--robot-capabilities")
+	cmd.Dir = t.TempDir() // no .beads directory
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-capabilities failed: %v, out=%s", err, out)
+	}
+	var payload struct {
+		SchemaVersion int    `json:"schema_version"`
+		Version       string `json:"version"`
+		RobotCommands []struct {
+			Flag string `json:"flag"`
+		} `json:"robot_commands"`
+		Deprecations []any `json:"deprecations"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json: %v\n%s", err, out)
+	}
+	if payload.SchemaVersion != capabilitiesSchemaVersion || payload.Version == "" {
+		t.Errorf("header: %+v", payload)
+	}
+	found := false
+	for _, c := range payload.RobotCommands {
+		if c.Flag == "--robot-capabilities" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("robot_commands missing --robot-capabilities: %s", out)
+	}
+	if payload.Deprecations == nil {
+		t.Errorf("deprecations should be [] not null: %s", out)
+	}
+}
@@ -0,0 +1,350 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ci"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/junit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/plural"
+)
+
+// Caps on the markdown summary's lists; JSON has everything.
+const (
+	ciSummaryTracks     = 5
+	ciSummaryTrackItems = 3
+)
+
+// ciHealth is the graph's health at the base revision and now.
+type ciHealth struct {
+	Trend   string              `json:"trend"` // "improving", "degrading", or "stable"
+	Base    baseline.GraphStats `json:"base"`
+	Current baseline.GraphStats `json:"current"`
+}
+
+// ciSummaryReport is the `bv ci-summary` result.
+type ciSummaryReport struct {
+	GeneratedAt  time.Time              `json:"generated_at"`
+	Base         string                 `json:"base"`                    // As given or detected
+	BaseSource   string                 `json:"base_source"`             // --base, or the CI variable it came from
+	BaseRevision string                 `json:"base_revision,omitempty"` // Empty when the beads file is younger than the base
+	DataHash     string                 `json:"data_hash"`
+	Passed       bool                   `json:"passed"`
+	Policies     ci.Result              `json:"policies"`
+	Health       ciHealth               `json:"health"`
+	Changes      analysis.DiffSummary   `json:"changes"`
+	NewAlerts    []drift.Alert          `json:"new_alerts"`
+	Plan         analysis.ExecutionPlan `json:"plan"`
+	TopPicks     []digestPick           `json:"top_picks"`
+}
+
+// runCISummaryCommand implements `bv ci-summary`. Returns the process exit
+// code: the policies' failure_exit_code (default 1) when an enforced policy
+// is violated, 1 if the data or git history can't be read, 2 on usage
+// errors.
+func runCISummaryCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("ci-summary", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	base := fs.String("base", "", "Revision to compare with (default: the pull/merge request's base from the CI environment, else HEAD~1)")
+	format := fs.String("format", "markdown", "Output format: markdown, json, or junit")
+	output := fs.String("output", "", "File to write (default: markdown is appended to $GITHUB_STEP_SUMMARY when set; else stdout; - for stdout)")
+	dir := fs.String("dir", ".", "Project directory (a git repository)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv ci-summary [--base REV] [--format markdown|json|junit] [--output FILE] [--dir DIR]")
+		fmt.Fprintln(stderr, "\nSummarize project hygiene for a CI job: health deltas, new alerts and the")
+		fmt.Fprintln(stderr, "current plan since the base revision. Fails the job when a policy in")
+		fmt.Fprintf(stderr, ".bv/%s set to enforce is violated.\n", ci.ConfigFilename)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 || (*format != "markdown" && *format != "json" && *format != "junit") {
+		fs.Usage()
+		return 2
+	}
+
+	projectDir, err := filepath.Abs(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	cfg, err := ci.LoadConfig(projectDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	baseRev, source := *base, "--base"
+	if baseRev == "" {
+		baseRev, source = ciBaseRevision(os.Getenv)
+	}
+	report, err := buildCISummary(projectDir, baseRev, cfg, time.Now())
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	report.BaseSource = source
+
+	var content []byte
+	stepSummary := ""
+	switch *format {
+	case "json":
+		if content, err = json.MarshalIndent(report, "", "  "); err != nil {
+			fmt.Fprintf(stderr, "Error encoding summary: %v\n", err)
+			return 1
+		}
+		content = append(content, '\n')
+	case "junit":
+		var buf bytes.Buffer
+		if err := junit.Write(&buf, "bv", ciSummaryJUnit(report.Policies)); err != nil {
+			fmt.Fprintf(stderr, "Error encoding summary: %v\n", err)
+			return 1
+		}
+		content = buf.Bytes()
+	default:
+		content = []byte(renderCISummaryMarkdown(report))
+		stepSummary = os.Getenv("GITHUB_STEP_SUMMARY")
+	}
+	if err := writeCISummary(content, *output, stepSummary, stdout); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if os.Getenv("GITHUB_ACTIONS") == "true" {
+		writeGitHubAnnotations(stdout, report.Policies)
+	}
+	if !report.Passed {
+		return cfg.FailureExitCode
+	}
+	return 0
+}
+
+// ciBaseRevision picks the revision to compare with from the CI
+// environment: the pull or merge request's target, then the commit before
+// a pushed range, then HEAD~1. source names the variable it came from.
+func ciBaseRevision(getenv func(string) string) (rev, source string) {
+	if ref := getenv("GITHUB_BASE_REF"); ref != "" {
+		return "origin/" + ref, "GITHUB_BASE_REF"
+	}
+	for _, name := range []string{"CI_MERGE_REQUEST_DIFF_BASE_SHA", "CI_COMMIT_BEFORE_SHA"} {
+		// GitLab sets the before SHA to zeros for a new branch
+		if sha := getenv(name); strings.Trim(sha, "0") != "" {
+			return sha, name
+		}
+	}
+	return "HEAD~1", "default"
+}
+
+// buildCISummary compares the working copy with baseRev and judges the
+// result against cfg's policies.
+func buildCISummary(projectDir, baseRev string, cfg ci.Config, now time.Time) (*ciSummaryReport, error) {
+	digest, snaps, err := buildDigest(projectDir, baseRev, now)
+	if err != nil {
+		return nil, fmt.Errorf("comparing with %s: %w (CI checkouts need the base's history, e.g. fetch-depth: 0)", baseRev, err)
+	}
+	data, err := os.ReadFile(snaps.BeadsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ciSummaryReport{
+		GeneratedAt:  now.UTC(),
+		Base:         baseRev,
+		BaseRevision: digest.FromRevision,
+		DataHash:     digest.DataHash,
+		Health: ciHealth{
+			Trend:   digest.Summary.HealthTrend,
+			Base:    ciGraphStats(snaps.Before),
+			Current: ciGraphStats(snaps.Current),
+		},
+		Changes:   digest.Summary,
+		NewAlerts: digest.NewAlerts,
+		Plan:      analysis.NewAnalyzer(snaps.Current).GetExecutionPlan(),
+		TopPicks:  digest.TopPicks,
+	}
+	report.Policies = ci.Evaluate(cfg, ci.Input{
+		Lint:        lint.Lint(data).Findings,
+		NewCycles:   digest.NewCycles,
+		NewAlerts:   digest.NewAlerts,
+		HealthTrend: digest.Summary.HealthTrend,
+		Base:        report.Health.Base,
+		Current:     report.Health.Current,
+	})
+	report.Passed = !report.Policies.Failed
+	return report, nil
+}
+
+func ciGraphStats(issues []model.Issue) baseline.GraphStats {
+	if len(issues) == 0 {
+		return baseline.GraphStats{}
+	}
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	return graphStatsFor(issues, analyzer, &stats)
+}
+
+// writeCISummary writes content to output: a file, "-" for stdout, or, when
+// empty, appended to the GitHub Actions step summary file if there is one.
+func writeCISummary(content []byte, output, stepSummary string, stdout io.Writer) error {
+	switch {
+	case output == "-" || (output == "" && stepSummary == ""):
+		_, err := stdout.Write(content)
+		return err
+	case output != "":
+		return os.WriteFile(output, content, 0o644)
+	}
+	// Other steps write to the same file; append to it
+	f, err := os.OpenFile(stepSummary, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening GITHUB_STEP_SUMMARY: %w", err)
+	}
+	if _, err := f.Write(content); err != nil {
+		f.Close()
+		return fmt.Errorf("writing GITHUB_STEP_SUMMARY: %w", err)
+	}
+	return f.Close()
+}
+
+// writeGitHubAnnotations prints each violation as a GitHub Actions workflow
+// command, so it shows on the run and the pull request.
+func writeGitHubAnnotations(w io.Writer, result ci.Result) {
+	for _, v := range result.Violations {
+		level := "warning"
+		if v.Mode == ci.ModeEnforce {
+			level = "error"
+		}
+		// Workflow commands end at a newline; % must be escaped first
+		msg := strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(v.Message)
+		fmt.Fprintf(w, "::%s title=bv %s::%s\n", level, v.Policy, msg)
+	}
+}
+
+// renderCISummaryMarkdown renders the summary as a job summary.
+func renderCISummaryMarkdown(r *ciSummaryReport) string {
+	var b strings.Builder
+	if r.Passed {
+		b.WriteString("## ✅ bv: project hygiene passed\n\n")
+	} else {
+		b.WriteString("## ❌ bv: project hygiene failed\n\n")
+	}
+	base := fmt.Sprintf("`%s`", r.Base)
+	if r.BaseRevision != "" {
+		sha := r.BaseRevision
+		if len(sha) > 12 {
+			sha = sha[:12]
+		}
+		base += fmt.Sprintf(" (`%s`)", sha)
+	}
+	fmt.Fprintf(&b, "Compared with %s · %d closed · %d new · %d reopened · %d modified\n",
+		base, r.Changes.IssuesClosed, r.Changes.IssuesAdded, r.Changes.IssuesReopened, r.Changes.IssuesModified)
+	if r.BaseRevision == "" {
+		b.WriteString("\n_The beads file didn't exist at the base; every issue counts as new._\n")
+	}
+
+	b.WriteString("\n### Policies\n\n| Policy | Mode | Result |\n| --- | --- | --- |\n")
+	for _, policy := range ci.Policies {
+		mode, n := r.Policies.Modes[policy], r.Policies.Count(policy)
+		result := "✅ pass"
+		switch {
+		case mode == ci.ModeOff:
+			result = "not checked"
+		case n > 0 && mode == ci.ModeEnforce:
+			result = fmt.Sprintf("❌ %d %s", n, plural.Word(n, "violation"))
+		case n > 0:
+			result = fmt.Sprintf("⚠️ %d %s", n, plural.Word(n, "warning"))
+		}
+		fmt.Fprintf(&b, "| %s | %s | %s |\n", policy, mode, result)
+	}
+	if len(r.Policies.Violations) > 0 {
+		b.WriteString("\n")
+		for i, v := range r.Policies.Violations {
+			if i == digestListLimit {
+				fmt.Fprintf(&b, "- …and %d more\n", len(r.Policies.Violations)-digestListLimit)
+				break
+			}
+			mark := "⚠️"
+			if v.Mode == ci.ModeEnforce {
+				mark = "❌"
+			}
+			fmt.Fprintf(&b, "- %s **%s**: %s\n", mark, v.Policy, v.Message)
+		}
+	}
+
+	trend := map[string]string{"improving": "↑", "degrading": "↓"}[r.Health.Trend]
+	if trend == "" {
+		trend = "→"
+	}
+	fmt.Fprintf(&b, "\n### Health: %s %s\n\n| Metric | Base | Now | Δ |\n| --- | ---: | ---: | ---: |\n", trend, r.Health.Trend)
+	row := func(name string, before, now int) {
+		fmt.Fprintf(&b, "| %s | %d | %d | %+d |\n", name, before, now, now-before)
+	}
+	bs, cs := r.Health.Base, r.Health.Current
+	row("Open", bs.OpenCount, cs.OpenCount)
+	row("Blocked", bs.BlockedCount, cs.BlockedCount)
+	row("Actionable", bs.ActionableCount, cs.ActionableCount)
+	row("Closed", bs.ClosedCount, cs.ClosedCount)
+	row("Dependency cycles", bs.CycleCount, cs.CycleCount)
+	row("Dependencies", bs.EdgeCount, cs.EdgeCount)
+
+	if len(r.NewAlerts) > 0 {
+		fmt.Fprintf(&b, "\n### New alerts (%d)\n", len(r.NewAlerts))
+		for i, a := range r.NewAlerts {
+			if i == digestListLimit {
+				fmt.Fprintf(&b, "- …and %d more\n", len(r.NewAlerts)-digestListLimit)
+				break
+			}
+			fmt.Fprintf(&b, "- **%s** %s\n", a.Severity, a.Message)
+		}
+	}
+
+	p := r.Plan
+	fmt.Fprintf(&b, "\n### Plan\n\n%d actionable in %d %s · %d blocked\n", p.TotalActionable, len(p.Tracks), plural.Word(len(p.Tracks), "track"), p.TotalBlocked)
+	if p.Summary.HighestImpact != "" {
+		fmt.Fprintf(&b, "\nStart with `%s`: %s\n", p.Summary.HighestImpact, p.Summary.ImpactReason)
+	}
+	if len(p.Tracks) > 0 {
+		b.WriteString("\n| Track | Next up |\n| --- | --- |\n")
+		for i, track := range p.Tracks {
+			if i == ciSummaryTracks {
+				fmt.Fprintf(&b, "| …and %d more | |\n", len(p.Tracks)-ciSummaryTracks)
+				break
+			}
+			var items []string
+			for j, item := range track.Items {
+				if j == ciSummaryTrackItems {
+					items = append(items, fmt.Sprintf("+%d more", len(track.Items)-ciSummaryTrackItems))
+					break
+				}
+				items = append(items, fmt.Sprintf("`%s` %s", item.ID, strings.ReplaceAll(item.Title, "|", "\\|")))
+			}
+			fmt.Fprintf(&b, "| %s | %s |\n", track.TrackID, strings.Join(items, "<br>"))
+		}
+	}
+
+	if len(r.TopPicks) > 0 {
+		b.WriteString("\n### Top picks\n")
+		for _, pick := range r.TopPicks {
+			line := fmt.Sprintf("- `%s` %s (score %.2f", pick.ID, pick.Title, pick.Score)
+			if pick.Unblocks > 0 {
+				line += fmt.Sprintf(", unblocks %d", pick.Unblocks)
+			}
+			b.WriteString(line + ")\n")
+		}
+	}
+	return b.String()
+}
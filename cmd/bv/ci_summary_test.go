@@ -0,0 +1,122 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+)
+
+func TestCIBaseRevision(t *testing.T) {
+	tests := []struct {
+		env         map[string]string
+		rev, source string
+	}{
+		{map[string]string{"GITHUB_BASE_REF": "main"}, "origin/main", "GITHUB_BASE_REF"},
+		{map[string]string{"CI_MERGE_REQUEST_DIFF_BASE_SHA": "abc123", "CI_COMMIT_BEFORE_SHA": "def456"}, "abc123", "CI_MERGE_REQUEST_DIFF_BASE_SHA"},
+		{map[string]string{"CI_COMMIT_BEFORE_SHA": "def456"}, "def456", "CI_COMMIT_BEFORE_SHA"},
+		{map[string]string{"CI_COMMIT_BEFORE_SHA": "0000000000000000000000000000000000000000"}, "HEAD~1", "default"},
+		{nil, "HEAD~1", "default"},
+	}
+	for _, tt := range tests {
+		rev, source := ciBaseRevision(func(k string) string { return tt.env[k] })
+		if rev != tt.rev || source != tt.source {
+			t.Errorf("%v: got %s from %s, want %s from %s", tt.env, rev, source, tt.rev, tt.source)
+		}
+	}
+}
+
+func TestRunCISummaryCommand(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("BEADS_DIR", "")
+	t.Setenv("GITHUB_BASE_REF", "")
+	t.Setenv("CI_MERGE_REQUEST_DIFF_BASE_SHA", "")
+	t.Setenv("CI_COMMIT_BEFORE_SHA", "")
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commitBeads := func(lines ...string) {
+		t.Helper()
+		path := filepath.Join(dir, ".beads", "issues.jsonl")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".beads")
+		git("commit", "-qm", "beads")
+	}
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := runCISummaryCommand(append(args, "--dir", dir), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	git("init", "-q", "-b", "main")
+	commitBeads(
+		`{"id":"a-1","title":"One","status":"open","issue_type":"task"}`,
+		`{"id":"a-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-1","type":"blocks"}]}`,
+	)
+	commitBeads(
+		`{"id":"a-1","title":"One","status":"closed","issue_type":"task"}`,
+		`{"id":"a-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-1","type":"blocks"}]}`,
+		`{"id":"a-3","title":"Three","status":"open","issue_type":"task"}`,
+	)
+
+	// On GitHub Actions the markdown is appended to the step summary
+	summary := filepath.Join(t.TempDir(), "summary.md")
+	if err := os.WriteFile(summary, []byte("earlier step\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_STEP_SUMMARY", summary)
+	code, stdout, stderr := run()
+	data, _ := os.ReadFile(summary)
+	if code != 0 || stdout != "" || !strings.HasPrefix(string(data), "earlier step\n## ✅ bv: project hygiene passed") ||
+		!strings.Contains(string(data), "| Closed | 0 | 1 | +1 |") || !strings.Contains(string(data), "| new-cycles | enforce | ✅ pass |") {
+		t.Fatalf("passing run = %d, stdout %q, stderr %q, summary:\n%s", code, stdout, stderr, data)
+	}
+
+	commitBeads(
+		`{"id":"a-1","title":"One","status":"closed","issue_type":"task"}`,
+		`{"id":"a-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-3","type":"blocks"}]}`,
+		`{"id":"a-3","title":"Three","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-2","type":"blocks"}]}`,
+	)
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "ci.yaml"), []byte("policies:\n  new-cycles: enforce\nfailure_exit_code: 3\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("GITHUB_ACTIONS", "true")
+	code, stdout, _ = run("--format", "json", "--output", "-")
+	var report ciSummaryReport
+	if err := json.Unmarshal([]byte(stdout[:strings.Index(stdout, "\n::")]), &report); err != nil {
+		t.Fatalf("json: %v\n%s", err, stdout)
+	}
+	if code != 3 || report.Passed || report.Policies.Count("new-cycles") != 1 || report.BaseSource != "default" || report.Health.Current.CycleCount != 1 {
+		t.Errorf("failing run = %d: %+v", code, report)
+	}
+	if !strings.Contains(stdout, "::error title=bv new-cycles::new dependency cycle: ") {
+		t.Errorf("no GitHub annotation:\n%s", stdout)
+	}
+
+	if code, _, _ := run("--base", "no-such-ref"); code != 1 {
+		t.Errorf("unknown base = %d, want 1", code)
+	}
+	if code, _, _ := run("--format", "html"); code != 2 {
+		t.Errorf("bad format = %d, want 2", code)
+	}
+}
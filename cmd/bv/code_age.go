@@ -0,0 +1,36 @@
+package main
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// codeLastChanged maps open issues to when their likely files last changed,
+// by git blame in the repository at root, for code-age risk (see
+// analysis.AnalysisConfig.CodeLastChanged). xref may be nil, leaving only
+// the files issues declare. Outside git the map is nil.
+func codeLastChanged(root string, issues []model.Issue, xref *correlation.XRefReport) map[string]time.Time {
+	hints := make(map[string][]correlation.FileHint)
+	for _, issue := range issues {
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		if h := correlation.FileHints(issue, xref.For(issue.ID), correlation.DefaultFileHintLimit); len(h) > 0 {
+			hints[issue.ID] = h
+		}
+	}
+	if len(hints) == 0 {
+		return nil
+	}
+	ages, err := correlation.CodeAges(root, hints, 0)
+	if err != nil {
+		return nil
+	}
+	lastChanged := make(map[string]time.Time, len(ages))
+	for id, age := range ages {
+		lastChanged[id] = age.LastChanged
+	}
+	return lastChanged
+}
@@ -0,0 +1,289 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// minRobotSchemaVersion is the oldest robot schema --compat can pin.
+const minRobotSchemaVersion = 1
+
+// deprecatedUsagePrefix starts the usage text of deprecated flag aliases so
+// help and completion can tell them apart.
+const deprecatedUsagePrefix = "Deprecated: "
+
+// robotDeprecation announces a robot flag or output field that is going
+// away, and what replaced it.
+type robotDeprecation struct {
+	Flag            string `json:"flag,omitempty"`
+	Field           string `json:"field,omitempty"` // dotted path in the output
+	Replacement     string `json:"replacement"`
+	Since           string `json:"since"`                       // bv version that deprecated it
+	RemovedInSchema int    `json:"removed_in_schema,omitempty"` // robot schema that drops it
+	Note            string `json:"note,omitempty"`
+}
+
+// robotFlagAlias maps a deprecated robot flag onto the flag that replaced
+// it. The alias shares the replacement's value, so the rest of main only
+// ever checks the current name.
+type robotFlagAlias struct {
+	Old, New        string
+	Since           string
+	RemovedInSchema int
+	Note            string
+}
+
+// robotFlagAliases are the deprecated robot flags bv still accepts.
+var robotFlagAliases = []robotFlagAlias{
+	{
+		Old: "robot-sprint", New: "robot-sprint-list",
+		Since: "v0.12.1", RemovedInSchema: 2,
+		Note: "Name from the sprint design notes; use --robot-sprint-show <id> for one sprint",
+	},
+}
+
+// robotFieldRename records an output field renamed in robot schema Since.
+// Old and New are dotted paths with the same parent. Clients pinned with
+// --compat to an older schema get the field under its old name as well.
+type robotFieldRename struct {
+	Old, New string
+	Since    int
+	Version  string // bv version that renamed it
+}
+
+// robotFieldRenames lists output fields renamed since robot schema 1.
+var robotFieldRenames = []robotFieldRename{}
+
+// robotDeprecationNotices lists every deprecated flag and field, for
+// --robot-capabilities.
+func robotDeprecationNotices() []robotDeprecation {
+	notices := []robotDeprecation{}
+	for _, a := range robotFlagAliases {
+		notices = append(notices, a.notice())
+	}
+	for _, r := range robotFieldRenames {
+		notices = append(notices, robotDeprecation{
+			Field:           r.Old,
+			Replacement:     r.New,
+			Since:           r.Version,
+			RemovedInSchema: r.Since,
+		})
+	}
+	return notices
+}
+
+func (a robotFlagAlias) notice() robotDeprecation {
+	return robotDeprecation{
+		Flag:            "--" + a.Old,
+		Replacement:     "--" + a.New,
+		Since:           a.Since,
+		RemovedInSchema: a.RemovedInSchema,
+		Note:            a.Note,
+	}
+}
+
+// registerRobotFlagAliases registers each deprecated alias on fs. It must
+// run after the replacement flags are defined.
+func registerRobotFlagAliases(fs *flag.FlagSet) {
+	for _, a := range robotFlagAliases {
+		target := fs.Lookup(a.New)
+		if target == nil {
+			panic("robot flag alias " + a.Old + " targets unknown flag " + a.New)
+		}
+		fs.Var(target.Value, a.Old, deprecatedUsagePrefix+"use --"+a.New)
+	}
+}
+
+// robotCompatState is what robot output must account for in this run: the
+// schema pinned with --compat and the deprecated flags that were used.
+type robotCompatState struct {
+	pinned  int // 0 when --compat wasn't given
+	notices []robotDeprecation
+}
+
+// robotCompat is set once from the command line by setupRobotCompat.
+var robotCompat robotCompatState
+
+// robotCompatMeta is the meta block added to robot output when a run pins a
+// schema or uses deprecated flags.
+type robotCompatMeta struct {
+	RobotSchemaVersion int                `json:"robot_schema_version"`
+	Compat             int                `json:"compat,omitempty"`
+	Deprecations       []robotDeprecation `json:"deprecations"`
+}
+
+// parseCompatVersion parses a --compat value: a robot schema version as
+// reported by --robot-capabilities, with or without a leading "v".
+func parseCompatVersion(s string) (int, error) {
+	v, err := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(s), "v"))
+	if err != nil {
+		return 0, fmt.Errorf("invalid --compat %q: want a robot schema version such as %d", s, robotSchemaVersion)
+	}
+	if v > robotSchemaVersion {
+		return 0, fmt.Errorf("--compat=%d needs a newer bv: this binary speaks robot schema %d", v, robotSchemaVersion)
+	}
+	if v < minRobotSchemaVersion {
+		return 0, fmt.Errorf("--compat=%d is no longer supported: the oldest robot schema is %d", v, minRobotSchemaVersion)
+	}
+	return v, nil
+}
+
+// setupRobotCompat validates --compat and records the deprecated flags set
+// on fs, warning about each on stderr. It runs after fs is parsed.
+func setupRobotCompat(fs *flag.FlagSet, compat string, stderr io.Writer) (robotCompatState, error) {
+	var state robotCompatState
+	if compat != "" {
+		v, err := parseCompatVersion(compat)
+		if err != nil {
+			return state, err
+		}
+		state.pinned = v
+	}
+	aliases := make(map[string]robotFlagAlias, len(robotFlagAliases))
+	for _, a := range robotFlagAliases {
+		aliases[a.Old] = a
+	}
+	fs.Visit(func(f *flag.Flag) {
+		a, ok := aliases[f.Name]
+		if !ok {
+			return
+		}
+		state.notices = append(state.notices, a.notice())
+		fmt.Fprintf(stderr, "Warning: --%s is deprecated; use --%s instead\n", a.Old, a.New)
+	})
+	return state, nil
+}
+
+// meta returns the compatibility meta block, or nil if there is nothing to
+// report.
+func (s robotCompatState) meta() *robotCompatMeta {
+	if s.pinned == 0 && len(s.notices) == 0 {
+		return nil
+	}
+	notices := s.notices
+	if notices == nil {
+		notices = []robotDeprecation{}
+	}
+	return &robotCompatMeta{
+		RobotSchemaVersion: robotSchemaVersion,
+		Compat:             s.pinned,
+		Deprecations:       notices,
+	}
+}
+
+// renames returns the field renames a client pinned to s.pinned predates.
+func (s robotCompatState) renames() []robotFieldRename {
+	if s.pinned == 0 {
+		return nil
+	}
+	var out []robotFieldRename
+	for _, r := range robotFieldRenames {
+		if s.pinned < r.Since {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// shape applies the compatibility state to a robot payload: old field
+// names for a pinned schema, and the meta block. Payloads that aren't JSON
+// objects are returned unchanged. Without renames, the meta block is
+// spliced in ahead of the payload's own fields so their order is kept.
+func (s robotCompatState) shape(v any) (any, error) {
+	meta := s.meta()
+	if meta == nil {
+		return v, nil
+	}
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	if len(raw) == 0 || raw[0] != '{' {
+		return v, nil
+	}
+
+	renames := s.renames()
+	var top map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &top); err != nil {
+		return nil, err
+	}
+	if _, hasMeta := top["meta"]; !hasMeta && len(renames) == 0 {
+		metaJSON, err := json.Marshal(meta)
+		if err != nil {
+			return nil, err
+		}
+		var b bytes.Buffer
+		b.WriteString(`{"meta":`)
+		b.Write(metaJSON)
+		if rest := bytes.TrimSpace(raw[1:]); len(rest) > 0 && rest[0] != '}' {
+			b.WriteByte(',')
+		}
+		b.Write(raw[1:])
+		return json.RawMessage(b.Bytes()), nil
+	}
+
+	// Slow path: decode the whole payload, keeping numbers exact.
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+	var tree map[string]any
+	if err := dec.Decode(&tree); err != nil {
+		return nil, err
+	}
+	for _, r := range renames {
+		applyFieldRename(tree, r)
+	}
+	metaFields := map[string]any{
+		"robot_schema_version": meta.RobotSchemaVersion,
+		"deprecations":         meta.Deprecations,
+	}
+	if meta.Compat != 0 {
+		metaFields["compat"] = meta.Compat
+	}
+	if existing, ok := tree["meta"].(map[string]any); ok {
+		for k, v := range metaFields {
+			existing[k] = v
+		}
+	} else {
+		tree["meta"] = metaFields
+	}
+	return tree, nil
+}
+
+// applyFieldRename copies the value at r.New to r.Old in tree, if the path
+// exists.
+func applyFieldRename(tree map[string]any, r robotFieldRename) {
+	oldPath := strings.Split(r.Old, ".")
+	newPath := strings.Split(r.New, ".")
+	parent := tree
+	for _, key := range newPath[:len(newPath)-1] {
+		next, ok := parent[key].(map[string]any)
+		if !ok {
+			return
+		}
+		parent = next
+	}
+	if v, ok := parent[newPath[len(newPath)-1]]; ok {
+		parent[oldPath[len(oldPath)-1]] = v
+	}
+}
+
+// robotEncoder encodes robot output, shaping each payload for the run's
+// compatibility state (see robotCompatState.shape).
+type robotEncoder struct {
+	*json.Encoder
+}
+
+// Encode writes v as JSON with the compatibility meta block, if any.
+func (e *robotEncoder) Encode(v any) error {
+	shaped, err := robotCompat.shape(v)
+	if err != nil {
+		return err
+	}
+	return e.Encoder.Encode(shaped)
+}
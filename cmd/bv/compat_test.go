@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+)
+
+func TestParseCompatVersion(t *testing.T) {
+	for _, in := range []string{"1", "v1", " 1 "} {
+		if v, err := parseCompatVersion(in); err != nil || v != 1 {
+			t.Errorf("parseCompatVersion(%q) = %d, %v", in, v, err)
+		}
+	}
+	for _, in := range []string{"", "x", "0", "99"} {
+		if _, err := parseCompatVersion(in); err == nil {
+			t.Errorf("parseCompatVersion(%q) should fail", in)
+		}
+	}
+}
+
+func TestRobotFlagAliasSetsReplacement(t *testing.T) {
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	sprintList := fs.Bool("robot-sprint-list", false, "Output sprints as JSON")
+	registerRobotFlagAliases(fs)
+	if err := fs.Parse([]string{"--robot-sprint"}); err != nil {
+		t.Fatal(err)
+	}
+	if !*sprintList {
+		t.Fatal("--robot-sprint should set --robot-sprint-list")
+	}
+
+	var stderr bytes.Buffer
+	state, err := setupRobotCompat(fs, "", &stderr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(state.notices) != 1 || state.notices[0].Flag != "--robot-sprint" || state.notices[0].Replacement != "--robot-sprint-list" {
+		t.Errorf("notices = %+v", state.notices)
+	}
+	if !strings.Contains(stderr.String(), "--robot-sprint is deprecated") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+}
+
+func TestRobotCompatShape(t *testing.T) {
+	payload := struct {
+		Zeta  string `json:"zeta"`
+		Alpha int    `json:"alpha"`
+	}{"z", 1}
+
+	// Nothing to report: the payload goes through untouched
+	if got, err := (robotCompatState{}).shape(payload); err != nil || got != any(payload) {
+		t.Fatalf("shape without compat state = %v, %v", got, err)
+	}
+
+	state := robotCompatState{pinned: 1}
+	got, err := state.shape(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out, err := json.Marshal(got)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"meta":{"robot_schema_version":1,"compat":1,"deprecations":[]},"zeta":"z","alpha":1}`
+	if string(out) != want {
+		t.Errorf("shape = %s\nwant   %s", out, want)
+	}
+
+	// Non-objects are left alone
+	if got, err := state.shape([]int{1}); err != nil || len(got.([]int)) != 1 {
+		t.Errorf("shape([]int) = %v, %v", got, err)
+	}
+}
+
+func TestRobotCompatShapeRenamesAndExistingMeta(t *testing.T) {
+	saved := robotFieldRenames
+	defer func() { robotFieldRenames = saved }()
+	robotFieldRenames = []robotFieldRename{{Old: "triage.top", New: "triage.top_pick", Since: 2, Version: "v9.9.9"}}
+
+	payload := map[string]any{
+		"meta":   map[string]any{"version": "x"},
+		"triage": map[string]any{"top_pick": "A-1"},
+	}
+	got, err := (robotCompatState{pinned: 1}).shape(payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree := got.(map[string]any)
+	triage := tree["triage"].(map[string]any)
+	if triage["top"] != "A-1" || triage["top_pick"] != "A-1" {
+		t.Errorf("triage = %v, want both old and new names", triage)
+	}
+	meta := tree["meta"].(map[string]any)
+	if meta["version"] != "x" || meta["compat"] != 1 {
+		t.Errorf("meta = %v, want existing keys merged with compat", meta)
+	}
+
+	// A client on the renaming schema doesn't get the old name
+	if renames := (robotCompatState{pinned: 2}).renames(); len(renames) != 0 {
+		t.Errorf("renames for schema 2 = %v", renames)
+	}
+}
@@ -0,0 +1,367 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// completionSubcommands are the positional subcommands bv understands, in
+// the order shells should offer them.
+var completionSubcommands = []struct{ name, desc string }{
+	{"print", "Render a TUI view as static text"},
+	{"completion", "Generate shell completion script"},
+	{"self-update", "Update bv to the latest release"},
+	{"doctor", "Check the environment and collect diagnostics"},
+	{"agents", "Manage the bv blurb in AGENTS.md and other agent files"},
+	{"repl", "Answer line-based queries from data kept in memory"},
+	{"digest", "Summarize what changed since a point in time"},
+	{"lint", "Check the beads file and repair safe problems"},
+	{"hooks", "Install git hooks that check the beads data"},
+	{"ci-summary", "Write a CI job summary and enforce hygiene policies"},
+	{"org-report", "Compare the health of several repositories"},
+	{"new", "Create an issue without bd"},
+	{"archive", "Move old closed issues to .beads/archive"},
+	{"generate", "Generate a synthetic dataset for demos and tests"},
+	{"url-handler", "Register bv to open bv:// issue links"},
+}
+
+// completionSubcommandArgs lists the fixed first argument of each subcommand.
+var completionSubcommandArgs = map[string][]string{
+	"print":       printViews,
+	"completion":  completionShells,
+	"agents":      agentsSubcommands,
+	"hooks":       hooksSubcommands,
+	"url-handler": urlHandlerSubcommands,
+}
+
+var completionShells = []string{"bash", "zsh", "fish", "powershell"}
+
+// issueIDFlags lists the flags whose value is an issue ID (or, for --ids
+// and --focus, a comma-separated list that usually holds them). Keep it in
+// sync when adding ID-taking flags.
+var issueIDFlags = map[string]bool{
+	"bead-history":         true,
+	"feedback-accept":      true,
+	"feedback-ignore":      true,
+	"focus":                true,
+	"goto":                 true,
+	"graph-root":           true,
+	"ids":                  true,
+	"robot-blocker-chain":  true,
+	"robot-causality":      true,
+	"robot-forecast":       true,
+	"robot-impact-network": true,
+	"robot-reach":          true,
+	"robot-related":        true,
+	"robot-show":           true,
+	"suggest-bead":         true,
+}
+
+// completionFlag is one flag as seen by the completion generators.
+type completionFlag struct {
+	Name    string
+	Usage   string
+	IsBool  bool
+	IssueID bool // value completes from the current beads file
+}
+
+// completionFlags collects every flag registered on fs, sorted by name,
+// leaving out deprecated aliases.
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		if strings.HasPrefix(f.Usage, deprecatedUsagePrefix) {
+			return
+		}
+		cf := completionFlag{Name: f.Name, Usage: strings.TrimSpace(f.Usage)}
+		if bf, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && bf.IsBoolFlag() {
+			cf.IsBool = true
+		}
+		cf.IssueID = !cf.IsBool && issueIDFlags[f.Name]
+		flags = append(flags, cf)
+	})
+	return flags
+}
+
+// runCompletionCommand implements `bv completion <shell>`. The hidden
+// `bv completion __ids` form prints "ID<TAB>title" for each issue and is what
+// the generated scripts call for dynamic issue-ID completion.
+func runCompletionCommand(args []string, fs *flag.FlagSet, stdout, stderr io.Writer) int {
+	if len(args) != 1 {
+		fmt.Fprintf(stderr, "Usage: bv completion <%s>\n", strings.Join(completionShells, "|"))
+		return 2
+	}
+
+	flags := completionFlags(fs)
+	switch args[0] {
+	case "bash":
+		writeBashCompletion(stdout, flags)
+	case "zsh":
+		writeZshCompletion(stdout, flags)
+	case "fish":
+		writeFishCompletion(stdout, flags)
+	case "powershell", "pwsh":
+		writePowerShellCompletion(stdout, flags)
+	case "__ids":
+		return writeCompletionIssueIDs(stdout)
+	default:
+		fmt.Fprintf(stderr, "Error: unsupported shell %q (want %s)\n", args[0], strings.Join(completionShells, ", "))
+		return 2
+	}
+	return 0
+}
+
+// writeCompletionIssueIDs prints the current project's issues for shell
+// completion. Errors are silent: completion should never spam the prompt.
+func writeCompletionIssueIDs(w io.Writer) int {
+	issues, err := loader.LoadIssues("")
+	if err != nil {
+		return 1
+	}
+	clean := strings.NewReplacer("\t", " ", "\n", " ", "\r", " ")
+	for _, issue := range issues {
+		fmt.Fprintf(w, "%s\t%s\n", issue.ID, clean.Replace(issue.Title))
+	}
+	return 0
+}
+
+func issueIDFlagNames(flags []completionFlag) []string {
+	var names []string
+	for _, f := range flags {
+		if f.IssueID {
+			names = append(names, f.Name)
+		}
+	}
+	return names
+}
+
+func subcommandNames() []string {
+	names := make([]string, len(completionSubcommands))
+	for i, sc := range completionSubcommands {
+		names[i] = sc.name
+	}
+	return names
+}
+
+func writeBashCompletion(w io.Writer, flags []completionFlag) {
+	var all []string
+	for _, f := range flags {
+		all = append(all, "--"+f.Name)
+	}
+	var idCases []string
+	for _, name := range issueIDFlagNames(flags) {
+		idCases = append(idCases, "--"+name, "-"+name)
+	}
+
+	fmt.Fprintf(w, `# bash completion for bv
+# Install: bv completion bash > /etc/bash_completion.d/bv  (or source it from ~/.bashrc)
+
+_bv() {
+    local cur prev
+    cur="${COMP_WORDS[COMP_CWORD]}"
+    prev="${COMP_WORDS[COMP_CWORD-1]}"
+    # --flag=value: bash splits on '=' so the flag is two words back.
+    if [[ "$prev" == "=" && $COMP_CWORD -ge 2 ]]; then
+        prev="${COMP_WORDS[COMP_CWORD-2]}"
+    elif [[ "$cur" == "=" ]]; then
+        cur=""
+    fi
+
+    case "$prev" in
+        %s)
+            COMPREPLY=( $(compgen -W "$(bv completion __ids 2>/dev/null | cut -f1)" -- "$cur") )
+            return
+            ;;
+`, strings.Join(idCases, "|"))
+	for _, sc := range completionSubcommands {
+		if vals := completionSubcommandArgs[sc.name]; len(vals) > 0 {
+			fmt.Fprintf(w, `        %s)
+            COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+            return
+            ;;
+`, sc.name, strings.Join(vals, " "))
+		}
+	}
+	fmt.Fprintf(w, `    esac
+
+    if [[ $COMP_CWORD -eq 1 && "$cur" != -* ]]; then
+        COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+        return
+    fi
+    COMPREPLY=( $(compgen -W "%s" -- "$cur") )
+}
+
+complete -o default -F _bv bv
+`, strings.Join(subcommandNames(), " "), strings.Join(all, " "))
+}
+
+// zshEscape escapes text for use inside a single-quoted _arguments spec.
+func zshEscape(s string) string {
+	return strings.NewReplacer(`'`, `'\''`, `[`, `\[`, `]`, `\]`, `:`, `\:`).Replace(s)
+}
+
+func writeZshCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprint(w, `#compdef bv
+# Install: bv completion zsh > "${fpath[1]}/_bv"  (or source it from ~/.zshrc)
+
+_bv_ids() {
+    local -a ids
+    ids=(${(f)"$(bv completion __ids 2>/dev/null | sed -e 's/:/\\:/g' -e $'s/\t/:/')"})
+    _describe -t issues 'issue' ids
+}
+
+_bv() {
+    if (( CURRENT == 2 )) && [[ "${words[2]}" != -* ]]; then
+        local -a cmds
+        cmds=(
+`)
+	for _, sc := range completionSubcommands {
+		fmt.Fprintf(w, "            '%s:%s'\n", sc.name, zshEscape(sc.desc))
+	}
+	fmt.Fprint(w, `        )
+        _describe -t commands 'command' cmds
+        return
+    fi
+
+    case "${words[2]}" in
+`)
+	for _, sc := range completionSubcommands {
+		if vals := completionSubcommandArgs[sc.name]; len(vals) > 0 {
+			fmt.Fprintf(w, "        %s) _arguments '2:%s:(%s)'; return ;;\n", sc.name, sc.name, strings.Join(vals, " "))
+		}
+	}
+	fmt.Fprint(w, `    esac
+
+    _arguments -s \
+`)
+	for i, f := range flags {
+		spec := fmt.Sprintf("'--%s[%s]'", f.Name, zshEscape(f.Usage))
+		switch {
+		case f.IssueID:
+			spec = fmt.Sprintf("'--%s=[%s]:issue:_bv_ids'", f.Name, zshEscape(f.Usage))
+		case !f.IsBool:
+			spec = fmt.Sprintf("'--%s=[%s]:value: '", f.Name, zshEscape(f.Usage))
+		}
+		sep := " \\\n"
+		if i == len(flags)-1 {
+			sep = "\n"
+		}
+		fmt.Fprintf(w, "        %s%s", spec, sep)
+	}
+	fmt.Fprint(w, `}
+
+if [ "$funcstack[1]" = "_bv" ]; then
+    _bv "$@"
+else
+    compdef _bv bv
+fi
+`)
+}
+
+// fishEscape escapes text for use inside a single-quoted fish string.
+func fishEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s)
+}
+
+func writeFishCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprint(w, `# fish completion for bv
+# Install: bv completion fish > ~/.config/fish/completions/bv.fish
+
+complete -c bv -f
+`)
+	for _, sc := range completionSubcommands {
+		fmt.Fprintf(w, "complete -c bv -n __fish_use_subcommand -a %s -d '%s'\n", sc.name, fishEscape(sc.desc))
+	}
+	for _, sc := range completionSubcommands {
+		if vals := completionSubcommandArgs[sc.name]; len(vals) > 0 {
+			fmt.Fprintf(w, "complete -c bv -n '__fish_seen_subcommand_from %s' -a '%s'\n", sc.name, strings.Join(vals, " "))
+		}
+	}
+	for _, f := range flags {
+		line := fmt.Sprintf("complete -c bv -l %s -d '%s'", f.Name, fishEscape(f.Usage))
+		switch {
+		case f.IssueID:
+			line += " -x -a '(bv completion __ids 2>/dev/null)'"
+		case !f.IsBool:
+			line += " -r"
+		}
+		fmt.Fprintln(w, line)
+	}
+}
+
+// psEscape escapes text for use inside a single-quoted PowerShell string.
+func psEscape(s string) string {
+	if s == "" {
+		return " "
+	}
+	return strings.ReplaceAll(s, "'", "''")
+}
+
+func writePowerShellCompletion(w io.Writer, flags []completionFlag) {
+	fmt.Fprint(w, `# PowerShell completion for bv
+# Install: bv completion powershell | Out-String | Invoke-Expression  (add to $PROFILE)
+
+Register-ArgumentCompleter -Native -CommandName bv -ScriptBlock {
+    param($wordToComplete, $commandAst, $cursorPosition)
+
+    $elements = @($commandAst.CommandElements | ForEach-Object { $_.ToString() })
+    if ($wordToComplete -eq '') { $prev = $elements[-1] } else { $prev = $elements[-2] }
+
+    $idFlags = @(`)
+	ids := issueIDFlagNames(flags)
+	for i, name := range ids {
+		if i > 0 {
+			fmt.Fprint(w, ", ")
+		}
+		fmt.Fprintf(w, "'--%s', '-%s'", name, name)
+	}
+	fmt.Fprint(w, `)
+    if ($idFlags -contains $prev) {
+        bv completion __ids 2>$null | ForEach-Object {
+            $parts = $_ -split "`+"`t"+`", 2
+            $tip = if ($parts.Count -gt 1 -and $parts[1]) { $parts[1] } else { $parts[0] }
+            if ($parts[0] -like "$wordToComplete*") {
+                [System.Management.Automation.CompletionResult]::new($parts[0], $parts[0], 'ParameterValue', $tip)
+            }
+        }
+        return
+    }
+
+    $subArgs = @{
+`)
+	for _, sc := range completionSubcommands {
+		if vals := completionSubcommandArgs[sc.name]; len(vals) > 0 {
+			fmt.Fprintf(w, "        '%s' = @('%s')\n", sc.name, strings.Join(vals, "', '"))
+		}
+	}
+	fmt.Fprint(w, `    }
+    if ($subArgs.ContainsKey($prev)) {
+        $subArgs[$prev] | Where-Object { $_ -like "$wordToComplete*" } | ForEach-Object {
+            [System.Management.Automation.CompletionResult]::new($_, $_, 'ParameterValue', $_)
+        }
+        return
+    }
+
+    $candidates = @()
+    if ($elements.Count -eq 1 -or ($elements.Count -eq 2 -and $wordToComplete -ne '')) {
+`)
+	for _, sc := range completionSubcommands {
+		fmt.Fprintf(w, "        $candidates += ,@('%s', '%s')\n", sc.name, psEscape(sc.desc))
+	}
+	fmt.Fprint(w, `    }
+`)
+	for _, f := range flags {
+		fmt.Fprintf(w, "    $candidates += ,@('--%s', '%s')\n", f.Name, psEscape(f.Usage))
+	}
+	fmt.Fprint(w, `
+    $candidates | Where-Object { $_[0] -like "$wordToComplete*" } | ForEach-Object {
+        $type = if ($_[0].StartsWith('-')) { 'ParameterName' } else { 'Command' }
+        [System.Management.Automation.CompletionResult]::new($_[0], $_[0], $type, $_[1])
+    }
+}
+`)
+}
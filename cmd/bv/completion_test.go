@@ -0,0 +1,103 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func newCompletionTestFlags() *flag.FlagSet {
+	fs := flag.NewFlagSet("bv", flag.ContinueOnError)
+	fs.Bool("robot-triage", false, "Output unified triage as JSON")
+	fs.String("graph-format", "json", "Graph output format: json, dot, mermaid")
+	fs.String("graph-root", "", "Subgraph from specific root issue ID")
+	fs.String("bead-history", "", "Show history for specific bead ID")
+	fs.String("forecast-sprint", "", "Filter forecast by sprint ID")
+	fs.String("robot-show", "", "Output one issue as JSON with metrics")
+	fs.String("ids", "", "Comma-separated issue IDs for a batch --robot-show")
+	return fs
+}
+
+func TestCompletionFlags_ClassifiesIssueIDFlags(t *testing.T) {
+	got := map[string]completionFlag{}
+	for _, f := range completionFlags(newCompletionTestFlags()) {
+		got[f.Name] = f
+	}
+	if !got["robot-triage"].IsBool || got["robot-triage"].IssueID {
+		t.Errorf("robot-triage: %+v", got["robot-triage"])
+	}
+	for _, name := range []string{"graph-root", "bead-history", "robot-show", "ids"} {
+		if !got[name].IssueID {
+			t.Errorf("%s should complete issue IDs", name)
+		}
+	}
+	for _, name := range []string{"graph-format", "forecast-sprint"} {
+		if got[name].IssueID || got[name].IsBool {
+			t.Errorf("%s: %+v", name, got[name])
+		}
+	}
+}
+
+func TestRunCompletionCommand_Shells(t *testing.T) {
+	fs := newCompletionTestFlags()
+	wants := map[string][]string{
+		"bash":       {"complete -o default -F _bv bv", "--bead-history|-bead-history|--graph-root|-graph-root|--ids|-ids|--robot-show|-robot-show)", "--robot-triage"},
+		"zsh":        {"#compdef bv", "'--graph-root=[Subgraph from specific root issue ID]:issue:_bv_ids'", "'--robot-triage[Output unified triage as JSON]'"},
+		"fish":       {"complete -c bv -l graph-root", "-x -a '(bv completion __ids 2>/dev/null)'", "-a 'board tree graph insights history'"},
+		"powershell": {"Register-ArgumentCompleter -Native -CommandName bv", "'--graph-root', '-graph-root'", "$candidates += ,@('--graph-format'"},
+	}
+	for shell, want := range wants {
+		var out, errOut bytes.Buffer
+		if code := runCompletionCommand([]string{shell}, fs, &out, &errOut); code != 0 {
+			t.Fatalf("%s: exit %d: %s", shell, code, errOut.String())
+		}
+		for _, w := range want {
+			if !strings.Contains(out.String(), w) {
+				t.Errorf("%s completion missing %q", shell, w)
+			}
+		}
+	}
+
+	var out, errOut bytes.Buffer
+	if code := runCompletionCommand([]string{"tcsh"}, fs, &out, &errOut); code != 2 {
+		t.Errorf("unsupported shell: exit %d, want 2", code)
+	}
+}
+
+func TestBashCompletion_Syntax(t *testing.T) {
+	bash, err := exec.LookPath("bash")
+	if err != nil {
+		t.Skip("bash not available")
+	}
+	var out bytes.Buffer
+	runCompletionCommand([]string{"bash"}, newCompletionTestFlags(), &out, &bytes.Buffer{})
+	cmd := exec.Command(bash, "-n")
+	cmd.Stdin = &out
+	if msg, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("bash -n: %v\n%s", err, msg)
+	}
+}
+
+func TestRunCompletionCommand_IssueIDs(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"bv-1","title":"Tab\there","status":"open","priority":1,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}` + "\n"
+	if err := os.WriteFile(filepath.Join(dir, ".beads", "issues.jsonl"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	var out bytes.Buffer
+	if code := runCompletionCommand([]string{"__ids"}, newCompletionTestFlags(), &out, &bytes.Buffer{}); code != 0 {
+		t.Fatalf("exit %d", code)
+	}
+	if got := out.String(); got != "bv-1\tTab here\n" {
+		t.Errorf("__ids output = %q", got)
+	}
+}
@@ -0,0 +1,247 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/jsonnorm"
+)
+
+// --verify-determinism runs the analysis pipeline several times under
+// different GOMAXPROCS settings and compares the JSON each run produces.
+// Agents depend on identical input giving identical output; this catches
+// map-order leaks, racy parallel metrics, and unstable tie-breaking.
+
+// determinismFloatDigits is the precision floats are compared at. Some graph
+// metrics sum over unordered node sets, which perturbs the last bit or two;
+// that noise is not a divergence, but any change in ranking order still is.
+const determinismFloatDigits = 10
+
+// determinismVolatileKeys are JSON keys whose values legitimately change
+// between runs (wall-clock times, timings) and are dropped before hashing.
+var determinismVolatileKeys = map[string]bool{
+	"generated_at":    true,
+	"compute_time_ms": true,
+	"ms":              true,
+}
+
+// determinismReport is the --verify-determinism output.
+type determinismReport struct {
+	Runs          int                    `json:"runs"`
+	Deterministic bool                   `json:"deterministic"`
+	Hash          string                 `json:"hash"` // hash of the first run
+	Results       []determinismRun       `json:"results"`
+	Divergence    *determinismDivergence `json:"divergence,omitempty"`
+}
+
+// determinismRun is one pipeline run.
+type determinismRun struct {
+	Run        int               `json:"run"`
+	GOMAXPROCS int               `json:"gomaxprocs"`
+	Hash       string            `json:"hash"`
+	Sections   map[string]string `json:"sections"` // section -> hash
+}
+
+// determinismDivergence describes the first field that differs from run 1.
+type determinismDivergence struct {
+	Run      int    `json:"run"`
+	Section  string `json:"section"`
+	Path     string `json:"path"`
+	Expected any    `json:"expected"`
+	Got      any    `json:"got"`
+}
+
+// determinismProcs picks the GOMAXPROCS value for run i, alternating between
+// serial and parallel execution. Parallel runs use at least 4 procs so
+// goroutines interleave even on single-core machines.
+func determinismProcs(i, numCPU int) int {
+	parallel := max(numCPU, 4)
+	schedule := []int{1, parallel, 2, (parallel + 1) / 2}
+	return schedule[i%len(schedule)]
+}
+
+// runDeterminismCheck runs the pipeline `runs` times and compares outputs.
+// Analysis caches are bypassed so every run recomputes from scratch.
+func runDeterminismCheck(issues []model.Issue, runs int, forceFull bool) (determinismReport, error) {
+	if runs < 2 {
+		runs = 2
+	}
+	analysis.SetCacheBypass(true)
+	defer analysis.SetCacheBypass(false)
+	orig := runtime.GOMAXPROCS(0)
+	defer runtime.GOMAXPROCS(orig)
+
+	// A fixed clock keeps time-dependent scoring identical across runs.
+	now := time.Now()
+	report := determinismReport{Runs: runs, Deterministic: true}
+	var first map[string]any
+	for i := 0; i < runs; i++ {
+		procs := determinismProcs(i, runtime.NumCPU())
+		runtime.GOMAXPROCS(procs)
+		sections, err := determinismPipeline(issues, forceFull, now)
+		if err != nil {
+			return report, err
+		}
+		run := determinismRun{Run: i + 1, GOMAXPROCS: procs, Sections: make(map[string]string)}
+		for name, v := range sections {
+			run.Sections[name] = determinismHash(v)
+		}
+		run.Hash = determinismHash(sections)
+		report.Results = append(report.Results, run)
+
+		if i == 0 {
+			first = sections
+			report.Hash = run.Hash
+			continue
+		}
+		if report.Divergence == nil && run.Hash != report.Hash {
+			report.Deterministic = false
+			report.Divergence = firstDivergence(first, sections)
+			report.Divergence.Run = run.Run
+		}
+	}
+	return report, nil
+}
+
+// determinismPipeline produces the normalized JSON of each robot output
+// section for one run.
+func determinismPipeline(issues []model.Issue, forceFull bool, now time.Time) (map[string]any, error) {
+	cfg := analysis.ConfigForSize(len(issues), countEdges(issues))
+	if forceFull {
+		cfg = analysis.FullAnalysisConfig()
+	}
+	analyzer := analysis.NewAnalyzer(issues)
+	analyzer.SetConfig(&cfg)
+	stats := analyzer.Analyze()
+
+	raw := map[string]any{
+		"insights": stats.GenerateInsights(50),
+		"metrics": map[string]any{
+			"pagerank":      stats.PageRank(),
+			"betweenness":   stats.Betweenness(),
+			"eigenvector":   stats.Eigenvector(),
+			"hubs":          stats.Hubs(),
+			"authorities":   stats.Authorities(),
+			"critical_path": stats.CriticalPathScore(),
+			"core_number":   stats.CoreNumber(),
+			"slack":         stats.Slack(),
+			"topo_order":    stats.TopologicalOrder,
+		},
+		"plan":   analyzer.GetExecutionPlan(),
+		"triage": analysis.ComputeTriageWithOptionsAndTime(issues, analysis.TriageOptions{WaitForPhase2: true}, now),
+	}
+
+	sections := make(map[string]any, len(raw))
+	for name, v := range raw {
+		data, err := json.Marshal(v)
+		if err != nil {
+			return nil, fmt.Errorf("encoding %s: %w", name, err)
+		}
+		var decoded any
+		if err := json.Unmarshal(data, &decoded); err != nil {
+			return nil, fmt.Errorf("decoding %s: %w", name, err)
+		}
+		sections[name] = normalizeDeterminismJSON(decoded)
+	}
+	return sections, nil
+}
+
+// normalizeDeterminismJSON removes determinismVolatileKeys from decoded JSON
+// and rounds floats to determinismFloatDigits significant digits.
+func normalizeDeterminismJSON(v any) any {
+	return jsonnorm.Normalize(v, jsonnorm.Options{DropKeys: determinismVolatileKeys, FloatDigits: determinismFloatDigits})
+}
+
+// determinismHash hashes canonical JSON (encoding/json sorts map keys).
+func determinismHash(v any) string {
+	data, _ := json.Marshal(v)
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:8])
+}
+
+// firstDivergence walks both runs in sorted key order and reports the first
+// differing leaf.
+func firstDivergence(want, got map[string]any) *determinismDivergence {
+	names := make([]string, 0, len(want))
+	for name := range want {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if path, a, b, ok := diffJSON(name, want[name], got[name]); ok {
+			return &determinismDivergence{Section: name, Path: path, Expected: a, Got: b}
+		}
+	}
+	return &determinismDivergence{Section: "(unknown)"}
+}
+
+// diffJSON returns the path and values of the first difference between a
+// and b, or ok=false when they are equal.
+func diffJSON(path string, a, b any) (string, any, any, bool) {
+	switch av := a.(type) {
+	case map[string]any:
+		bv, isMap := b.(map[string]any)
+		if !isMap {
+			return path, a, b, true
+		}
+		keys := make(map[string]bool, len(av)+len(bv))
+		for k := range av {
+			keys[k] = true
+		}
+		for k := range bv {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			if p, x, y, ok := diffJSON(path+"."+k, av[k], bv[k]); ok {
+				return p, x, y, true
+			}
+		}
+		return "", nil, nil, false
+	case []any:
+		bv, isSlice := b.([]any)
+		if !isSlice {
+			return path, a, b, true
+		}
+		for i := 0; i < len(av) && i < len(bv); i++ {
+			if p, x, y, ok := diffJSON(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i]); ok {
+				return p, x, y, true
+			}
+		}
+		if len(av) != len(bv) {
+			return path + ".length", len(av), len(bv), true
+		}
+		return "", nil, nil, false
+	default:
+		if !reflect.DeepEqual(a, b) {
+			return path, a, b, true
+		}
+		return "", nil, nil, false
+	}
+}
+
+// determinismSummary is the one-line stderr summary.
+func determinismSummary(r determinismReport) string {
+	if r.Deterministic {
+		return fmt.Sprintf("determinism: OK (%d runs, hash %s)", r.Runs, r.Hash)
+	}
+	d := r.Divergence
+	var b strings.Builder
+	fmt.Fprintf(&b, "determinism: DIVERGED at run %d: %s\n", d.Run, d.Path)
+	fmt.Fprintf(&b, "  run 1: %v\n", d.Expected)
+	fmt.Fprintf(&b, "  run %d: %v", d.Run, d.Got)
+	return b.String()
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestRunDeterminismCheck(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Root", Status: model.StatusOpen, Priority: 1},
+		{ID: "B", Title: "Mid", Status: model.StatusOpen, Priority: 2, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Leaf", Status: model.StatusInProgress, Priority: 2, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+	report, err := runDeterminismCheck(issues, 3, false)
+	if err != nil {
+		t.Fatalf("runDeterminismCheck: %v", err)
+	}
+	if !report.Deterministic || report.Divergence != nil {
+		t.Fatalf("expected deterministic output, got %+v", report.Divergence)
+	}
+	if len(report.Results) != 3 {
+		t.Fatalf("expected 3 runs, got %d", len(report.Results))
+	}
+	if report.Results[0].GOMAXPROCS != 1 || report.Results[1].GOMAXPROCS < 4 {
+		t.Errorf("GOMAXPROCS not varied: %+v", report.Results)
+	}
+	for _, section := range []string{"insights", "metrics", "plan", "triage"} {
+		if report.Results[0].Sections[section] == "" {
+			t.Errorf("missing section hash %q", section)
+		}
+	}
+}
+
+func TestFirstDivergence(t *testing.T) {
+	want := map[string]any{
+		"plan":   map[string]any{"tracks": []any{"a", "b"}},
+		"triage": map[string]any{"top": []any{map[string]any{"id": "X", "score": 0.5}}},
+	}
+	got := map[string]any{
+		"plan":   map[string]any{"tracks": []any{"a", "b"}},
+		"triage": map[string]any{"top": []any{map[string]any{"id": "Y", "score": 0.5}}},
+	}
+	d := firstDivergence(want, got)
+	if d.Section != "triage" || d.Path != "triage.top[0].id" || d.Expected != "X" || d.Got != "Y" {
+		t.Fatalf("unexpected divergence: %+v", d)
+	}
+
+	got["plan"] = map[string]any{"tracks": []any{"a"}}
+	if d := firstDivergence(want, got); d.Path != "plan.tracks.length" {
+		t.Fatalf("expected length divergence, got %+v", d)
+	}
+}
+
+func TestNormalizeDeterminismJSON(t *testing.T) {
+	v := normalizeDeterminismJSON(map[string]any{
+		"generated_at": "2026-01-01T00:00:00Z",
+		"status":       map[string]any{"state": "computed", "ms": 1.5},
+		"value":        0.9115466608943957,
+	}).(map[string]any)
+	if _, ok := v["generated_at"]; ok {
+		t.Error("generated_at should be stripped")
+	}
+	if _, ok := v["status"].(map[string]any)["ms"]; ok {
+		t.Error("nested ms timing should be stripped")
+	}
+	other := normalizeDeterminismJSON(0.911546660894396).(float64)
+	if v["value"].(float64) != other {
+		t.Errorf("last-bit float noise not normalized: %v vs %v", v["value"], other)
+	}
+	if !strings.HasPrefix(determinismSummary(determinismReport{Runs: 2, Deterministic: true, Hash: "abc"}), "determinism: OK") {
+		t.Error("unexpected OK summary")
+	}
+}
@@ -0,0 +1,151 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+// --errors-json and --quiet are applied to everything bv writes to stderr,
+// from main's own messages to package warnings and log records, by swapping
+// os.Stderr (and the standard logger's output) for a pipe that is filtered
+// line by line. Call sites keep writing plain "Error: ..." / "Warning: ..."
+// text.
+
+// diagLine is one --errors-json record.
+type diagLine struct {
+	Time    string `json:"time"`
+	Level   string `json:"level"` // error, warning, or info
+	Message string `json:"message"`
+}
+
+// diagFilter rewrites stderr lines for --errors-json and --quiet.
+type diagFilter struct {
+	json  bool // emit diagLine records
+	quiet bool // drop everything below error
+	now   func() time.Time
+	last  string // level of the previous line, for indented continuations
+}
+
+// line converts one line of stderr output; ok is false if it is dropped.
+func (f *diagFilter) line(s string) (out string, ok bool) {
+	s = strings.TrimRight(s, "\r\n")
+	if strings.TrimSpace(s) == "" {
+		return "", !f.json && !f.quiet
+	}
+	// Structured log records (--log-format json) are already JSON lines
+	if f.json && strings.HasPrefix(s, "{") && json.Valid([]byte(s)) {
+		return s, true
+	}
+
+	level, msg := classifyDiag(s)
+	if strings.HasPrefix(s, " ") || strings.HasPrefix(s, "\t") {
+		level = f.last // "  - repo-name" under "Warning: 2 repos failed to load"
+	}
+	f.last = level
+	if f.quiet && level != "error" {
+		return "", false
+	}
+	if !f.json {
+		return s, true
+	}
+	data, err := json.Marshal(diagLine{
+		Time:    f.now().UTC().Format(time.RFC3339),
+		Level:   level,
+		Message: strings.TrimSpace(msg),
+	})
+	if err != nil {
+		return "", false
+	}
+	return string(data), true
+}
+
+// classifyDiag infers a stderr line's level from bv's "Error"/"Warning"
+// prefixes and strips a "Error: " or "Warning: " label.
+func classifyDiag(s string) (level, msg string) {
+	t := strings.TrimSpace(s)
+	lower := strings.ToLower(t)
+	switch {
+	case strings.HasPrefix(lower, "error"):
+		level = "error"
+	case strings.HasPrefix(lower, "warning"):
+		level = "warning"
+	default:
+		return "info", t
+	}
+	if i := strings.Index(t, ": "); i >= 0 && !strings.Contains(t[:i], " ") {
+		return level, t[i+2:]
+	}
+	return level, t
+}
+
+// stopDiagnostics flushes the stderr filter and restores os.Stderr; exit
+// calls it, and main defers it for a normal return. It is a no-op unless
+// startDiagnostics ran.
+var stopDiagnostics = func() {}
+
+// startDiagnostics routes os.Stderr through f until stopDiagnostics.
+func startDiagnostics(f *diagFilter) error {
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	if f.now == nil {
+		f.now = time.Now
+	}
+	real := os.Stderr
+	os.Stderr = w
+	log.SetOutput(w)
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		copyDiagnostics(real, r, f)
+	}()
+	stopDiagnostics = func() {
+		stopDiagnostics = func() {}
+		os.Stderr = real
+		log.SetOutput(real)
+		w.Close()
+		<-done
+		r.Close()
+	}
+	return nil
+}
+
+func copyDiagnostics(dst io.Writer, src io.Reader, f *diagFilter) {
+	br := bufio.NewReader(src)
+	for {
+		s, err := br.ReadString('\n')
+		if s != "" {
+			if out, ok := f.line(s); ok {
+				fmt.Fprintln(dst, out)
+			}
+		}
+		if err != nil {
+			return
+		}
+	}
+}
+
+// diagFlagsFromArgs finds --errors-json and --quiet before flag parsing, so
+// the filter also covers flag errors. Parsing still validates them.
+func diagFlagsFromArgs(args []string) (errorsJSON, quiet bool) {
+	for _, a := range args {
+		if a == "--" {
+			break
+		}
+		switch strings.TrimLeft(a, "-") {
+		case "errors-json", "errors-json=true", "errors-json=1":
+			errorsJSON = true
+		case "quiet", "quiet=true", "quiet=1":
+			quiet = true
+		}
+	}
+	return errorsJSON, quiet
+}
@@ -0,0 +1,142 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDiagFilterLine(t *testing.T) {
+	fixed := func() time.Time { return time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC) }
+	tests := []struct {
+		name   string
+		filter diagFilter
+		in     []string
+		want   []string
+	}{
+		{
+			name:   "json",
+			filter: diagFilter{json: true, now: fixed},
+			in:     []string{"Warning: update config: bad channel\n", "Error loading beads: gone\n", "Loaded 3 issues\n", "\n"},
+			want: []string{
+				`{"time":"2026-01-02T03:04:05Z","level":"warning","message":"update config: bad channel"}`,
+				`{"time":"2026-01-02T03:04:05Z","level":"error","message":"Error loading beads: gone"}`,
+				`{"time":"2026-01-02T03:04:05Z","level":"info","message":"Loaded 3 issues"}`,
+			},
+		},
+		{
+			name:   "json passes log records through",
+			filter: diagFilter{json: true, now: fixed},
+			in:     []string{`{"time":"x","level":"DEBUG","msg":"m"}` + "\n"},
+			want:   []string{`{"time":"x","level":"DEBUG","msg":"m"}`},
+		},
+		{
+			name:   "quiet keeps errors and their continuation lines",
+			filter: diagFilter{quiet: true},
+			in:     []string{"Warning: 2 repos failed to load\n", "  - api\n", "Error: boom\n", "  details\n", "\n"},
+			want:   []string{"Error: boom", "  details"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := tt.filter
+			var got []string
+			for _, s := range tt.in {
+				if out, ok := f.line(s); ok {
+					got = append(got, out)
+				}
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("got\n%s\nwant\n%s", strings.Join(got, "\n"), strings.Join(tt.want, "\n"))
+			}
+		})
+	}
+}
+
+func TestDiagFlagsFromArgs(t *testing.T) {
+	j, q := diagFlagsFromArgs([]string{"--robot-next", "-errors-json", "--quiet=true"})
+	if !j || !q {
+		t.Errorf("got json=%v quiet=%v, want both", j, q)
+	}
+	j, q = diagFlagsFromArgs([]string{"--robot-next", "--", "--quiet"})
+	if j || q {
+		t.Errorf("flags after -- must not count: json=%v quiet=%v", j, q)
+	}
+}
+
+func TestStartDiagnostics_FiltersLogRecords(t *testing.T) {
+	out, err := os.CreateTemp(t.TempDir(), "stderr")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer out.Close()
+	realStderr := os.Stderr
+	os.Stderr = out
+	defer func() { os.Stderr = realStderr; log.SetOutput(realStderr) }()
+
+	if err := startDiagnostics(&diagFilter{json: true}); err != nil {
+		t.Fatal(err)
+	}
+	log.SetFlags(0)
+	defer log.SetFlags(log.LstdFlags)
+	log.Print("Warning: from the standard logger")
+	stopDiagnostics()
+
+	data, err := os.ReadFile(out.Name())
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rec diagLine
+	if err := json.Unmarshal(bytes.TrimSpace(data), &rec); err != nil {
+		t.Fatalf("log record not filtered to JSON: %q (%v)", data, err)
+	}
+	if rec.Level != "warning" || rec.Message != "from the standard logger" {
+		t.Errorf("got %+v", rec)
+	}
+	if log.Writer() != out {
+		t.Error("stopDiagnostics should restore the standard logger's output")
+	}
+}
+
+func TestRobotErrorsJSONFromBinary(t *testing.T) {
+	exe := buildTestBinary(t)
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"A-1","title":"One","status":"open","priority":1,"issue_type":"task"}` + "\nnot json\n"
+	if err := os.WriteFile(filepath.Join(dir, ".beads", "issues.jsonl"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(exe, "--robot-next", "--errors-json", "--robot-sprint-list")
+	cmd.Dir = dir
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout, cmd.Stderr = &stdout, &stderr
+	if err := cmd.Run(); err != nil {
+		t.Fatalf("run: %v\n%s", err, stderr.String())
+	}
+	if !json.Valid(stdout.Bytes()) {
+		t.Errorf("stdout is not JSON:\n%s", stdout.String())
+	}
+	lines := strings.Split(strings.TrimSpace(stderr.String()), "\n")
+	sawParseWarning := false
+	for _, l := range lines {
+		var rec diagLine
+		if err := json.Unmarshal([]byte(l), &rec); err != nil {
+			t.Fatalf("stderr line is not JSON: %q", l)
+		}
+		if rec.Level == "warning" && strings.Contains(rec.Message, "line 2") {
+			sawParseWarning = true
+		}
+	}
+	if !sawParseWarning {
+		t.Errorf("expected the parse warning on stderr, got:\n%s", stderr.String())
+	}
+}
@@ -0,0 +1,308 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// digestListLimit caps each list in the markdown digest; JSON has everything.
+const digestListLimit = 15
+
+// digestIssue is one issue in a digest list.
+type digestIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+}
+
+// digestPick is a current top pick, marked when it wasn't one at the start
+// of the window.
+type digestPick struct {
+	analysis.TopPick
+	New bool `json:"new"`
+}
+
+// digestReport is what changed in a window: the `bv digest` result.
+type digestReport struct {
+	GeneratedAt  time.Time            `json:"generated_at"`
+	Since        string               `json:"since"`
+	SinceTime    *time.Time           `json:"since_time,omitempty"`    // Set when --since is a duration
+	FromRevision string               `json:"from_revision,omitempty"` // Empty when the beads file is younger than the window
+	DataHash     string               `json:"data_hash"`
+	Summary      analysis.DiffSummary `json:"summary"`
+	Closed       []digestIssue        `json:"closed"`
+	New          []digestIssue        `json:"new"`
+	Reopened     []digestIssue        `json:"reopened"`
+	Modified     []digestIssue        `json:"modified"`
+	NewCycles    [][]string           `json:"new_cycles"`
+	NewAlerts    []drift.Alert        `json:"new_alerts"` // Alerts now that weren't raised at the start of the window
+	TopPicks     []digestPick         `json:"top_picks"`
+}
+
+// runDigestCommand implements `bv digest`. Returns the process exit code: 0
+// on success, 1 if the data or git history can't be read, 2 on usage errors.
+func runDigestCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("digest", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	since := fs.String("since", "24h", "Window start: a duration (24h, 7d, 2w) or a git revision or date")
+	format := fs.String("format", "markdown", "Output format: markdown or json")
+	dir := fs.String("dir", ".", "Project directory (a git repository)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv digest [--since 24h] [--format markdown|json] [--dir DIR]")
+		fmt.Fprintln(stderr, "\nSummarize what changed since a point in git history: closed, new, reopened")
+		fmt.Fprintln(stderr, "and modified issues, new cycles, new alerts, and the current top picks.")
+		fmt.Fprintln(stderr, "The markdown is ready to post to a chat channel.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 || (*format != "markdown" && *format != "json") {
+		fs.Usage()
+		return 2
+	}
+
+	projectDir, err := filepath.Abs(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	report, _, err := buildDigest(projectDir, *since, time.Now())
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error encoding digest: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	fmt.Fprint(stdout, renderDigestMarkdown(report))
+	return 0
+}
+
+// parseDigestWindow parses a --since duration: a Go duration ("36h"), days
+// ("7d") or weeks ("2w"). ok is false for anything else, which is then
+// taken as a git revision or date.
+func parseDigestWindow(s string) (time.Duration, bool) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if n, found := strings.CutSuffix(s, suffix); found {
+			if v, err := strconv.Atoi(n); err == nil && v > 0 {
+				return time.Duration(v) * unit, true
+			}
+			return 0, false
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return d, true
+	}
+	return 0, false
+}
+
+// digestSnapshots are the issue sets a digest compared.
+type digestSnapshots struct {
+	BeadsPath string        // The working copy's beads file
+	Before    []model.Issue // At the report's FromRevision
+	Current   []model.Issue // In the working copy
+}
+
+// buildDigest compares the beads file at since with the working copy.
+func buildDigest(projectDir, since string, now time.Time) (*digestReport, digestSnapshots, error) {
+	var snaps digestSnapshots
+	beadsDir, err := loader.GetBeadsDir(projectDir)
+	if err != nil {
+		return nil, snaps, err
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil, snaps, err
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		return nil, snaps, err
+	}
+	snaps.BeadsPath, snaps.Current = path, issues
+
+	report := &digestReport{GeneratedAt: now.UTC(), Since: since, DataHash: analysis.ComputeDataHash(issues)}
+	gitLoader := loader.NewGitLoader(projectDir)
+	if window, ok := parseDigestWindow(since); ok {
+		start := now.Add(-window).UTC()
+		report.SinceTime = &start
+		report.FromRevision, err = gitLoader.RevisionBefore(start)
+	} else {
+		report.FromRevision, err = gitLoader.ResolveRevision(since)
+	}
+	if err != nil {
+		return nil, snaps, fmt.Errorf("digest needs git history: %w", err)
+	}
+
+	// Before the first commit, everything is new
+	var before []model.Issue
+	if report.FromRevision != "" {
+		if before, err = gitLoader.LoadAt(report.FromRevision); err != nil {
+			return nil, snaps, fmt.Errorf("loading issues at %s: %w", report.FromRevision, err)
+		}
+	}
+	snaps.Before = before
+
+	var fromTime time.Time
+	if report.SinceTime != nil {
+		fromTime = *report.SinceTime
+	}
+	diff := analysis.CompareSnapshots(analysis.NewSnapshotAt(before, fromTime, report.FromRevision), analysis.NewSnapshot(issues))
+	report.Summary = diff.Summary
+	report.Closed = digestIssues(diff.ClosedIssues)
+	report.New = digestIssues(diff.NewIssues)
+	report.Reopened = digestIssues(diff.ReopenedIssues)
+	report.Modified = []digestIssue{}
+	for _, m := range diff.ModifiedIssues {
+		report.Modified = append(report.Modified, digestIssue{ID: m.IssueID, Title: m.Title, Status: string(m.NewIssue.Status), Priority: m.NewIssue.Priority})
+	}
+	report.NewCycles = diff.NewCycles
+	if report.NewCycles == nil {
+		report.NewCycles = [][]string{}
+	}
+
+	report.NewAlerts = []drift.Alert{}
+	if current, err := computeAlerts(issues, projectDir, baseline.DefaultPath(projectDir), true); err == nil {
+		raised := make(map[string]bool)
+		if len(before) > 0 {
+			if old, err := computeAlerts(before, projectDir, baseline.DefaultPath(projectDir), true); err == nil {
+				for _, a := range old.Alerts {
+					raised[digestAlertKey(a)] = true
+				}
+			}
+		}
+		for _, a := range current.Alerts {
+			if !raised[digestAlertKey(a)] {
+				report.NewAlerts = append(report.NewAlerts, a)
+			}
+		}
+	}
+
+	wasPick := make(map[string]bool)
+	if len(before) > 0 {
+		for _, p := range analysis.ComputeTriage(before).QuickRef.TopPicks {
+			wasPick[p.ID] = true
+		}
+	}
+	report.TopPicks = []digestPick{}
+	for _, p := range analysis.ComputeTriage(issues).QuickRef.TopPicks {
+		report.TopPicks = append(report.TopPicks, digestPick{TopPick: p, New: !wasPick[p.ID]})
+	}
+	return report, snaps, nil
+}
+
+func digestIssues(issues []model.Issue) []digestIssue {
+	out := make([]digestIssue, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, digestIssue{ID: issue.ID, Title: issue.Title, Status: string(issue.Status), Priority: issue.Priority})
+	}
+	return out
+}
+
+// digestAlertKey identifies an alert across snapshots; messages carry counts
+// that change, so they aren't part of it.
+func digestAlertKey(a drift.Alert) string {
+	return strings.Join([]string{string(a.Type), a.IssueID, a.Label, a.Rule}, "|")
+}
+
+// renderDigestMarkdown renders a digest as markdown for chat.
+func renderDigestMarkdown(r *digestReport) string {
+	var b strings.Builder
+	window := r.Since
+	if r.SinceTime != nil {
+		window = fmt.Sprintf("%s (%s)", r.Since, r.SinceTime.Format("2006-01-02 15:04 UTC"))
+	}
+	fmt.Fprintf(&b, "## bv digest: changes since %s\n\n", window)
+
+	trend := map[string]string{"improving": "↑", "degrading": "↓"}[r.Summary.HealthTrend]
+	if trend == "" {
+		trend = "→"
+	}
+	fmt.Fprintf(&b, "**Health:** %s %s · %d closed · %d new · %d reopened · %d modified\n",
+		trend, r.Summary.HealthTrend, len(r.Closed), len(r.New), len(r.Reopened), len(r.Modified))
+	if r.FromRevision == "" {
+		b.WriteString("\n_No git history that old; every issue counts as new._\n")
+	}
+
+	issueList := func(title string, issues []digestIssue, withPriority bool) {
+		if len(issues) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n### %s (%d)\n", title, len(issues))
+		for i, issue := range issues {
+			if i == digestListLimit {
+				fmt.Fprintf(&b, "- …and %d more\n", len(issues)-digestListLimit)
+				break
+			}
+			if withPriority {
+				fmt.Fprintf(&b, "- `%s` %s (P%d)\n", issue.ID, issue.Title, issue.Priority)
+			} else {
+				fmt.Fprintf(&b, "- `%s` %s\n", issue.ID, issue.Title)
+			}
+		}
+	}
+	issueList("Closed", r.Closed, false)
+	issueList("New", r.New, true)
+	issueList("Reopened", r.Reopened, false)
+
+	if len(r.NewCycles) > 0 {
+		fmt.Fprintf(&b, "\n### New dependency cycles (%d)\n", len(r.NewCycles))
+		for _, c := range r.NewCycles {
+			fmt.Fprintf(&b, "- %s\n", strings.Join(c, " → "))
+		}
+	}
+
+	if len(r.NewAlerts) > 0 {
+		fmt.Fprintf(&b, "\n### New alerts (%d)\n", len(r.NewAlerts))
+		for i, a := range r.NewAlerts {
+			if i == digestListLimit {
+				fmt.Fprintf(&b, "- …and %d more\n", len(r.NewAlerts)-digestListLimit)
+				break
+			}
+			fmt.Fprintf(&b, "- **%s** %s\n", a.Severity, a.Message)
+		}
+	}
+
+	if len(r.TopPicks) > 0 {
+		b.WriteString("\n### Top picks\n")
+		for _, p := range r.TopPicks {
+			line := fmt.Sprintf("- `%s` %s (score %.2f", p.ID, p.Title, p.Score)
+			if p.Unblocks > 0 {
+				line += fmt.Sprintf(", unblocks %d", p.Unblocks)
+			}
+			line += ")"
+			if p.New {
+				line += " · new"
+			}
+			if len(p.Reasons) > 0 {
+				line += " — " + p.Reasons[0]
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
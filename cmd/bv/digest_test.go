@@ -0,0 +1,56 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDigestWindow(t *testing.T) {
+	tests := []struct {
+		in   string
+		want time.Duration
+		ok   bool
+	}{
+		{"24h", 24 * time.Hour, true},
+		{"90m", 90 * time.Minute, true},
+		{"7d", 7 * 24 * time.Hour, true},
+		{"2w", 14 * 24 * time.Hour, true},
+		{"0d", 0, false},
+		{"HEAD~3", 0, false},
+		{"main", 0, false},
+		{"feature-wd", 0, false},
+		{"2025-01-02", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := parseDigestWindow(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("parseDigestWindow(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestRenderDigestMarkdown(t *testing.T) {
+	since := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	r := &digestReport{Since: "24h", SinceTime: &since}
+	r.Summary.HealthTrend = "improving"
+	r.Closed = []digestIssue{{ID: "A", Title: "Alpha"}}
+	for i := 0; i < digestListLimit+2; i++ {
+		r.New = append(r.New, digestIssue{ID: "N", Title: "New", Priority: 2})
+	}
+	r.NewCycles = [][]string{{"X", "Y"}}
+
+	md := renderDigestMarkdown(r)
+	for _, want := range []string{
+		"## bv digest: changes since 24h (2025-03-01 09:00 UTC)",
+		"**Health:** ↑ improving · 1 closed · 17 new · 0 reopened · 0 modified",
+		"_No git history that old; every issue counts as new._",
+		"### Closed (1)\n- `A` Alpha\n",
+		"- …and 2 more",
+		"### New dependency cycles (1)\n- X → Y",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("missing %q in:\n%s", want, md)
+		}
+	}
+}
@@ -0,0 +1,378 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/diagnostics"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// doctorStatus is the outcome of a single doctor check.
+type doctorStatus string
+
+const (
+	doctorOK   doctorStatus = "ok"
+	doctorWarn doctorStatus = "warn"
+	doctorFail doctorStatus = "fail"
+)
+
+// doctorCheck is one line of the `bv doctor` report.
+type doctorCheck struct {
+	Name   string       `json:"name"`
+	Status doctorStatus `json:"status"`
+	Detail string       `json:"detail"`
+	Fix    string       `json:"fix,omitempty"`
+}
+
+// doctorReport is the full `bv doctor` result (the --json output).
+type doctorReport struct {
+	Version string        `json:"version"`
+	OK      bool          `json:"ok"` // false if any check failed
+	Checks  []doctorCheck `json:"checks"`
+}
+
+// doctorLookPath and doctorStdout are swapped out by tests.
+var (
+	doctorLookPath = exec.LookPath
+	doctorStdout   = os.Stdout
+)
+
+// runDoctorCommand implements `bv doctor`. Returns the process exit code:
+// 0 when nothing failed (warnings allowed), 1 otherwise.
+func runDoctorCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("doctor", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "Print the report as JSON")
+	bundle := fs.Bool("bundle", false, "Write a redacted diagnostics bundle for bug reports and print its path")
+	output := fs.String("output", "", "Bundle file to write (default: a new file in the temp directory)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv doctor [--json] [--bundle [--output FILE]]")
+		fmt.Fprintln(stderr, "\nCheck bd, the beads file, the instance lock, the terminal, and the agent")
+		fmt.Fprintln(stderr, "file, and suggest fixes for anything that looks wrong.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	if *bundle {
+		path, err := diagnostics.Write(diagnostics.NewBundle("on-demand"), *output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "Diagnostics bundle written to %s\n", path)
+		fmt.Fprintln(stdout, "Review it, then attach it to your bug report. Paths, usernames, hostnames, emails, and secrets are redacted.")
+		return 0
+	}
+
+	report := runDoctorChecks()
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			fmt.Fprintf(stderr, "Error encoding JSON: %v\n", err)
+			return 1
+		}
+	} else {
+		writeDoctorReport(stdout, report)
+	}
+	if !report.OK {
+		return 1
+	}
+	return 0
+}
+
+// runDoctorChecks runs every check against the current directory.
+func runDoctorChecks() doctorReport {
+	report := doctorReport{Version: version.Version, OK: true}
+	add := func(c doctorCheck) {
+		report.Checks = append(report.Checks, c)
+		if c.Status == doctorFail {
+			report.OK = false
+		}
+	}
+
+	add(checkBd())
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		add(doctorCheck{Name: "beads file", Status: doctorFail, Detail: err.Error()})
+	} else {
+		add(checkBeadsFile(beadsDir))
+		add(checkInstanceLock(beadsDir))
+	}
+	add(checkTerminalColor())
+	add(checkTerminalUnicode())
+	add(checkAgentFile(beadsDir))
+	return report
+}
+
+// checkBd looks for the bd CLI, which bv shells out to for writes.
+func checkBd() doctorCheck {
+	c := doctorCheck{Name: "bd"}
+	path, err := doctorLookPath("bd")
+	if err != nil {
+		c.Status = doctorWarn
+		c.Detail = "bd not found in PATH (bv can still read issues, but can't create or update them)"
+		c.Fix = "Install beads: https://github.com/steveyegge/beads"
+		return c
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
+	defer cancel()
+	out, err := exec.CommandContext(ctx, path, "--version").Output()
+	if err != nil {
+		c.Status = doctorWarn
+		c.Detail = fmt.Sprintf("%s found but `bd --version` failed: %v", path, err)
+		c.Fix = "Reinstall bd or check that it runs from this shell"
+		return c
+	}
+	c.Status = doctorOK
+	c.Detail = fmt.Sprintf("%s (%s)", strings.TrimSpace(firstLine(string(out))), path)
+	return c
+}
+
+// checkBeadsFile finds and parses the beads JSONL file, reporting malformed
+// lines, duplicate IDs, and dependencies on issues that don't exist.
+func checkBeadsFile(beadsDir string) doctorCheck {
+	c := doctorCheck{Name: "beads file"}
+	var warnings []string
+	path, err := loader.FindJSONLPathWithWarnings(beadsDir, func(msg string) {
+		warnings = append(warnings, msg)
+	})
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = err.Error()
+		c.Fix = "Run `bd init` in your project, or set BEADS_DIR to an existing .beads directory"
+		return c
+	}
+
+	issues, err := loader.LoadIssuesFromFileWithOptions(path, loader.ParseOptions{
+		WarningHandler: func(msg string) { warnings = append(warnings, msg) },
+	})
+	if err != nil {
+		c.Status = doctorFail
+		c.Detail = fmt.Sprintf("%s: %v", path, err)
+		c.Fix = "Check file permissions, or restore the file from git"
+		return c
+	}
+
+	ids := make(map[string]int, len(issues))
+	for _, issue := range issues {
+		ids[issue.ID]++
+	}
+	var dupes, dangling int
+	for id, n := range ids {
+		if n > 1 {
+			dupes++
+			warnings = append(warnings, fmt.Sprintf("duplicate issue ID %s (%d copies)", id, n))
+		}
+	}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID != "" && ids[dep.DependsOnID] == 0 {
+				dangling++
+			}
+		}
+	}
+	if dangling > 0 {
+		warnings = append(warnings, fmt.Sprintf("%d dependencies point at missing issues", dangling))
+	}
+
+	c.Detail = fmt.Sprintf("%s (%d issues)", path, len(issues))
+	if len(warnings) == 0 {
+		c.Status = doctorOK
+		return c
+	}
+	c.Status = doctorWarn
+	c.Detail += "; " + summarizeWarnings(warnings, 3)
+	if dupes > 0 {
+		c.Fix = "Remove the duplicate lines (usually left behind by a bad merge)"
+	} else {
+		c.Fix = "Fix or remove the reported lines (`bd` rewrites the file on the next change)"
+	}
+	return c
+}
+
+// checkInstanceLock reports the .bv.lock state without acquiring it.
+func checkInstanceLock(beadsDir string) doctorCheck {
+	c := doctorCheck{Name: "instance lock"}
+	status := instance.Inspect(beadsDir)
+	switch status.State {
+	case instance.LockStateNone:
+		c.Status = doctorOK
+		c.Detail = "no other bv instance is running"
+	case instance.LockStateHeld:
+		c.Status = doctorOK
+		c.Detail = fmt.Sprintf("held by PID %d since %s", status.Info.PID, status.Info.StartedAt.Format(time.RFC3339))
+	case instance.LockStateStale:
+		c.Status = doctorWarn
+		c.Detail = fmt.Sprintf("stale lock from PID %d, which is no longer running", status.Info.PID)
+		c.Fix = fmt.Sprintf("Harmless: the next bv takes it over. To clear it now: rm %s", status.Path)
+	case instance.LockStateCorrupt:
+		c.Status = doctorWarn
+		c.Detail = fmt.Sprintf("%s is unreadable or not a bv lock file", status.Path)
+		c.Fix = fmt.Sprintf("rm %s", status.Path)
+	}
+	return c
+}
+
+// checkTerminalColor reports the color profile the TUI will render with.
+func checkTerminalColor() doctorCheck {
+	c := doctorCheck{Name: "terminal color"}
+	if !term.IsTerminal(int(doctorStdout.Fd())) {
+		c.Status = doctorOK
+		c.Detail = "stdout is not a terminal (run bv doctor directly in your terminal to check colors)"
+		return c
+	}
+	profile := termenv.NewOutput(doctorStdout).EnvColorProfile()
+	switch profile {
+	case termenv.TrueColor:
+		c.Status, c.Detail = doctorOK, "truecolor"
+	case termenv.ANSI256:
+		c.Status, c.Detail = doctorOK, "256 colors"
+		c.Fix = "For the full theme, use a truecolor terminal or set COLORTERM=truecolor"
+	case termenv.ANSI:
+		c.Status, c.Detail = doctorWarn, "16 colors only"
+		c.Fix = "Set TERM=xterm-256color (and COLORTERM=truecolor if your terminal supports it)"
+	default:
+		c.Status, c.Detail = doctorWarn, "no color"
+		c.Fix = "Unset NO_COLOR, or set TERM to a color-capable value such as xterm-256color"
+		if os.Getenv("NO_COLOR") != "" {
+			c.Detail += " (NO_COLOR is set)"
+		}
+	}
+	return c
+}
+
+// checkTerminalUnicode checks that the locale is UTF-8; otherwise emoji and
+// box-drawing characters render as garbage.
+func checkTerminalUnicode() doctorCheck {
+	c := doctorCheck{Name: "terminal unicode"}
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			locale = v
+			break
+		}
+	}
+	normalized := strings.ToLower(strings.ReplaceAll(locale, "-", ""))
+	if strings.Contains(normalized, "utf8") {
+		c.Status = doctorOK
+		c.Detail = "UTF-8 locale (" + locale + ")"
+		return c
+	}
+	c.Status = doctorWarn
+	if locale == "" {
+		c.Detail = "no locale set"
+	} else {
+		c.Detail = "non-UTF-8 locale (" + locale + ")"
+	}
+	c.Fix = "export LANG=en_US.UTF-8, or run bv --ascii to avoid emoji and box drawing"
+	return c
+}
+
+// checkAgentFile reports whether the project's agent files (AGENTS.md,
+// CLAUDE.md, ...) carry the current, unedited bv blurb. It uses the same
+// statuses as `bv agents status` and reports the first file that needs
+// attention.
+func checkAgentFile(beadsDir string) doctorCheck {
+	c := doctorCheck{Name: "agent file"}
+	projectDir := "."
+	if beadsDir != "" {
+		projectDir = filepath.Dir(beadsDir)
+	}
+	detections := agents.DetectAgentFiles(projectDir)
+	if len(detections) == 0 {
+		c.Status = doctorOK
+		c.Detail = "no agent file (AGENTS.md, CLAUDE.md, .cursorrules, ...) in " + projectDir
+		c.Fix = "Optional: run `bv agents install` so coding agents use bv"
+		return c
+	}
+	for _, d := range detections {
+		s := newAgentFileStatus(d)
+		name := filepath.Base(s.Path)
+		switch s.Status {
+		case "missing":
+			c.Detail = name + " has no bv instructions"
+			c.Fix = "bv agents install --file " + s.Path
+		case "outdated":
+			c.Detail = fmt.Sprintf("%s has an outdated bv blurb (v%d, current v%d)", name, s.BlurbVersion, agents.BlurbVersion)
+			if s.Legacy {
+				c.Detail = name + " has a legacy bv blurb"
+			}
+			c.Fix = "bv agents update"
+		case "wrong-variant":
+			c.Detail = fmt.Sprintf("%s has the %s bv blurb instead of the %s variant", name, s.Target, s.ExpectedTarget)
+			c.Fix = "bv agents update"
+		case "modified":
+			c.Detail = name + " has a hand-edited bv blurb"
+			c.Fix = "bv agents update --merge (refreshes bv's sections and keeps your additions)"
+		default:
+			continue
+		}
+		c.Status = doctorWarn
+		return c
+	}
+	c.Status = doctorOK
+	c.Detail = fmt.Sprintf("%d agent file(s) with the current bv blurb (v%d)", len(detections), agents.BlurbVersion)
+	return c
+}
+
+// writeDoctorReport prints the human-readable report.
+func writeDoctorReport(w io.Writer, report doctorReport) {
+	fmt.Fprintf(w, "bv doctor (%s)\n\n", report.Version)
+	for _, c := range report.Checks {
+		icon := "✓"
+		switch c.Status {
+		case doctorWarn:
+			icon = "!"
+		case doctorFail:
+			icon = "✗"
+		}
+		fmt.Fprintf(w, "  %s %-16s %s\n", icon, c.Name, c.Detail)
+		if c.Fix != "" && c.Status != doctorOK {
+			fmt.Fprintf(w, "    %-16s → %s\n", "", c.Fix)
+		}
+	}
+	if !report.OK {
+		fmt.Fprintln(w, "\nSome checks failed. Run `bv doctor --bundle` to attach diagnostics to a bug report.")
+	}
+}
+
+func firstLine(s string) string {
+	if i := strings.IndexByte(s, '\n'); i >= 0 {
+		return s[:i]
+	}
+	return s
+}
+
+// summarizeWarnings joins the first n warnings and counts the rest.
+func summarizeWarnings(warnings []string, n int) string {
+	if len(warnings) <= n {
+		return strings.Join(warnings, "; ")
+	}
+	return fmt.Sprintf("%s; and %d more", strings.Join(warnings[:n], "; "), len(warnings)-n)
+}
@@ -0,0 +1,132 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+)
+
+func TestRunDoctorCommand_Bundle(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "diag.json")
+	var stdout, stderr bytes.Buffer
+	if code := runDoctorCommand([]string{"--bundle", "--output", out}, &stdout, &stderr); code != 0 {
+		t.Fatalf("exit code %d, stderr=%q", code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), out) {
+		t.Errorf("expected bundle path in output, got %q", stdout.String())
+	}
+	if _, err := os.Stat(out); err != nil {
+		t.Errorf("bundle not written: %v", err)
+	}
+}
+
+func stubDoctorLookPath(t *testing.T) {
+	t.Helper()
+	orig := doctorLookPath
+	doctorLookPath = func(string) (string, error) { return "", errors.New("not found") }
+	t.Cleanup(func() { doctorLookPath = orig })
+}
+
+func TestRunDoctorCommand_JSON(t *testing.T) {
+	stubDoctorLookPath(t)
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := `{"id":"bv-1","title":"One","status":"open","issue_type":"task","priority":1,"dependencies":[{"issue_id":"bv-1","depends_on_id":"bv-404","type":"blocks"}]}
+{"id":"bv-1","title":"Dup","status":"open","issue_type":"task","priority":1}
+not json
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("BEADS_DIR", beadsDir)
+	t.Setenv("LANG", "C")
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_CTYPE", "")
+
+	var stdout, stderr bytes.Buffer
+	code := runDoctorCommand([]string{"--json"}, &stdout, &stderr)
+	if code != 0 {
+		t.Fatalf("warnings alone should exit 0, got %d (stderr=%q)", code, stderr.String())
+	}
+
+	var report doctorReport
+	if err := json.Unmarshal(stdout.Bytes(), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout.String())
+	}
+	checks := make(map[string]doctorCheck)
+	for _, c := range report.Checks {
+		checks[c.Name] = c
+	}
+
+	if c := checks["bd"]; c.Status != doctorWarn || c.Fix == "" {
+		t.Errorf("bd check = %+v, want warn with fix", c)
+	}
+	beads := checks["beads file"]
+	if beads.Status != doctorWarn {
+		t.Errorf("beads file check = %+v, want warn", beads)
+	}
+	for _, want := range []string{"malformed", "duplicate issue ID bv-1", "missing issues"} {
+		if !strings.Contains(beads.Detail, want) {
+			t.Errorf("beads file detail %q missing %q", beads.Detail, want)
+		}
+	}
+	if c := checks["instance lock"]; c.Status != doctorOK {
+		t.Errorf("instance lock check = %+v, want ok", c)
+	}
+	if c := checks["terminal unicode"]; c.Status != doctorWarn || !strings.Contains(c.Fix, "--ascii") {
+		t.Errorf("terminal unicode check = %+v, want warn suggesting --ascii", c)
+	}
+	if _, err := os.Stat(filepath.Join(beadsDir, ".bv.lock")); err == nil {
+		t.Error("doctor must not create the instance lock")
+	}
+}
+
+func TestRunDoctorCommand_MissingBeadsFails(t *testing.T) {
+	stubDoctorLookPath(t)
+	t.Setenv("BEADS_DIR", filepath.Join(t.TempDir(), "nope"))
+
+	var stdout, stderr bytes.Buffer
+	if code := runDoctorCommand(nil, &stdout, &stderr); code != 1 {
+		t.Errorf("exit code = %d, want 1", code)
+	}
+	out := stdout.String()
+	if !strings.Contains(out, "✗ beads file") || !strings.Contains(out, "bd init") {
+		t.Errorf("expected failed beads check with fix hint, got:\n%s", out)
+	}
+}
+
+func TestCheckAgentFile_Drift(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if c := checkAgentFile(beadsDir); c.Status != doctorOK || !strings.Contains(c.Fix, "bv agents install") {
+		t.Errorf("no agent file: %+v", c)
+	}
+
+	claude := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(claude, []byte("# Rules\n\n"+agents.BlurbFor(agents.TargetGeneric)+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c := checkAgentFile(beadsDir); c.Status != doctorWarn || !strings.Contains(c.Detail, "variant") || c.Fix != "bv agents update" {
+		t.Errorf("wrong variant: %+v", c)
+	}
+
+	edited := strings.Replace(agents.BlurbFor(agents.TargetClaude), "### Best Practices", "### Best Practices (ours)", 1)
+	if err := os.WriteFile(claude, []byte("# Rules\n\n"+edited+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if c := checkAgentFile(beadsDir); c.Status != doctorWarn || !strings.Contains(c.Fix, "--merge") {
+		t.Errorf("modified blurb: %+v", c)
+	}
+}
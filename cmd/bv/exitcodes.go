@@ -0,0 +1,105 @@
+package main
+
+import (
+	"os"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Exit codes of the main bv command. Robot clients branch on them without
+// parsing output, so a code never changes meaning; new conditions get new
+// numbers. Subcommands (bv doctor, bv agents, ...) document their own.
+//
+// Usage errors keep the 2 bv (and the flag package) has always exited with,
+// which they share with missing data: in both cases nothing was analyzed,
+// and stderr says which.
+const (
+	exitOK                = 0
+	exitError             = 1 // any failure without a more specific code
+	exitNoData            = 2 // no beads data file was found
+	exitUsage             = 2 // invalid flags or flag combination
+	exitParseErrors       = 3 // --exit-code: lines of the data file were skipped
+	exitCycles            = 4 // --exit-code: the dependency graph has cycles
+	exitNothingActionable = 5 // --exit-code: no open issue is free of blockers
+	exitLockConflict      = 6 // another bv holds the repository lock
+)
+
+// robotExitCode documents one exit code in --robot-capabilities.
+type robotExitCode struct {
+	Code    int    `json:"code"`
+	Name    string `json:"name"`
+	Meaning string `json:"meaning"`
+	OptIn   bool   `json:"opt_in,omitempty"` // only reported with --exit-code
+}
+
+// robotExitCodes is the exit code contract, in code order.
+var robotExitCodes = []robotExitCode{
+	{exitOK, "ok", "Success", false},
+	{exitError, "error", "Failure without a more specific code", false},
+	{exitNoData, "no_data", "No beads data file found (run bd init, or set BEADS_DIR)", false},
+	{exitUsage, "usage", "Invalid flags or flag combination", false},
+	{exitParseErrors, "parse_errors", "The data file has lines bv skipped as malformed or invalid", true},
+	{exitCycles, "cycles", "The dependency graph has cycles", true},
+	{exitNothingActionable, "nothing_actionable", "No open issue is free of open blockers", true},
+	{exitLockConflict, "lock_conflict", "Another bv holds the repository lock; retry or raise BV_LOCK_TIMEOUT_S", false},
+}
+
+// exitCondition, once --exit-code is given, computes the code a successful
+// exit reports instead of 0; see exit. It runs at exit so it can reuse the
+// analysis the robot command already did.
+var exitCondition func() int
+
+// exit tears down everything main set up (redacted stdout, profiles, the
+// instance registration, the repository lock, diagnostics) before exiting.
+// main uses it instead of os.Exit so that teardown also runs on early
+// returns from robot commands. A successful exit reports the --exit-code
+// condition instead, if any.
+func exit(code int) {
+	if code == exitOK && exitCondition != nil {
+		code = exitCondition()
+	}
+	stopRedactingStdout()
+	stopProfiling()
+	unregisterInstance()
+	unlockRepo()
+	stopDiagnostics()
+	os.Exit(code)
+}
+
+// conditionExitCode checks issues for the conditions --exit-code reports.
+// The first that applies wins: data problems before graph problems before
+// an empty queue. parseErrors is the number of lines skipped on load.
+func conditionExitCode(issues []model.Issue, parseErrors int) int {
+	if parseErrors > 0 {
+		return exitParseErrors
+	}
+	if hasCycles(issues) {
+		return exitCycles
+	}
+	if len(analysis.NewAnalyzer(issues).GetActionableIssues()) == 0 {
+		return exitNothingActionable
+	}
+	return exitOK
+}
+
+// hasCycles reports whether the dependency graph of issues has a cycle. The
+// robot command's own analysis of the same data answers it when it got that
+// far; otherwise only cycle detection is run.
+func hasCycles(issues []model.Issue) bool {
+	if stats, ok := analysis.RobotStatsFor(analysis.ComputeDataHash(issues)); ok {
+		if len(stats.Cycles()) > 0 {
+			return true
+		}
+		if stats.Status().Cycles.State == "computed" {
+			return false
+		}
+	}
+	stats := analysis.NewAnalyzer(issues).AnalyzeWithConfig(analysis.AnalysisConfig{
+		ComputeCycles:    true,
+		CyclesTimeout:    500 * time.Millisecond,
+		MaxCyclesToStore: 1,
+	})
+	return len(stats.Cycles()) > 0
+}
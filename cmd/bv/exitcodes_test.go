@@ -0,0 +1,87 @@
+package main
+
+import (
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestConditionExitCode(t *testing.T) {
+	blocks := func(id, on string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+	}
+	tests := []struct {
+		name        string
+		issues      []model.Issue
+		parseErrors int
+		want        int
+	}{
+		{"clean", []model.Issue{{ID: "A", Status: model.StatusOpen}}, 0, exitOK},
+		{"parse errors win", []model.Issue{
+			{ID: "A", Status: model.StatusOpen, Dependencies: blocks("A", "B")},
+			{ID: "B", Status: model.StatusOpen, Dependencies: blocks("B", "A")},
+		}, 2, exitParseErrors},
+		{"cycle", []model.Issue{
+			{ID: "A", Status: model.StatusOpen, Dependencies: blocks("A", "B")},
+			{ID: "B", Status: model.StatusOpen, Dependencies: blocks("B", "A")},
+			{ID: "C", Status: model.StatusOpen},
+		}, 0, exitCycles},
+		{"no issues", nil, 0, exitNothingActionable},
+		{"everything closed", []model.Issue{{ID: "A", Status: model.StatusClosed}}, 0, exitNothingActionable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := conditionExitCode(tt.issues, tt.parseErrors); got != tt.want {
+				t.Errorf("conditionExitCode = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRobotExitCodesFromBinary(t *testing.T) {
+	exe := buildTestBinary(t)
+	withData := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(withData, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"A-1","title":"One","status":"open","priority":1,"issue_type":"task"}` + "\nnot json\n"
+	if err := os.WriteFile(filepath.Join(withData, ".beads", "issues.jsonl"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		dir  string
+		args []string
+		want int
+	}{
+		{"no data file", t.TempDir(), []string{"--robot-next"}, exitNoData},
+		{"parse errors are opt-in", withData, []string{"--robot-next"}, exitOK},
+		{"parse errors", withData, []string{"--robot-next", "--exit-code"}, exitParseErrors},
+		{"exit-code needs a robot command", withData, []string{"--exit-code"}, exitUsage},
+		{"unknown flag", withData, []string{"--robot-nope"}, exitUsage},
+		{"help", withData, []string{"-h"}, exitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := exec.Command(exe, tt.args...)
+			cmd.Dir = tt.dir
+			cmd.Env = append(os.Environ(), "BEADS_DIR=")
+			err := cmd.Run()
+			code := 0
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				code = exitErr.ExitCode()
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if code != tt.want {
+				t.Errorf("exit code = %d, want %d", code, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+// robotFrontier is the --robot-frontier payload.
+type robotFrontier struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	Agent       string `json:"agent,omitempty"`
+	analysis.Frontier
+}
+
+// frontierClaims returns the live bv session claims (issue ID -> agent) for
+// the project, dropping ones the beads data has overtaken.
+func frontierClaims(issues []model.Issue, now time.Time) map[string]string {
+	beadsDir, _ := loader.GetBeadsDir("")
+	claims, err := session.Load(session.DefaultPath(filepath.Dir(beadsDir)))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; ignoring recorded claims\n", err)
+		return nil
+	}
+	index := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		index[issue.ID] = issue
+	}
+	claims.Prune(index, now)
+	return claims.Holders()
+}
+
+// buildRobotFrontier computes --robot-frontier for agent.
+func buildRobotFrontier(issues []model.Issue, agent string, claims map[string]string, now time.Time) robotFrontier {
+	return robotFrontier{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Agent:       agent,
+		Frontier:    analysis.ComputeFrontier(issues, analysis.FrontierOptions{Agent: agent, Claims: claims}),
+	}
+}
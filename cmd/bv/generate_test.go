@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+func TestRunGenerateCommand(t *testing.T) {
+	dir := t.TempDir()
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := runGenerateCommand(args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, printed, _ := run("--nodes", "30", "--shape", "dense", "--seed", "3")
+	if code != 0 || strings.Count(printed, "\n") != 30 {
+		t.Fatalf("stdout run: code %d, %d lines", code, strings.Count(printed, "\n"))
+	}
+
+	if code, out, errOut := run("--nodes", "30", "--shape", "dense", "--seed", "3", "--out", dir); code != 0 {
+		t.Fatalf("code %d: %s%s", code, out, errOut)
+	}
+	path := filepath.Join(dir, ".beads", "issues.jsonl")
+	written, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(written) != printed {
+		t.Error("file differs from the stdout output for the same flags")
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil || len(issues) != 30 {
+		t.Fatalf("loaded %d issues: %v", len(issues), err)
+	}
+
+	if code, _, errOut := run("--nodes", "10", "--out", dir); code != 1 || !strings.Contains(errOut, "--force") {
+		t.Errorf("overwrite without --force: code %d, %s", code, errOut)
+	}
+	if code, _, _ := run("--nodes", "10", "--out", dir, "--force"); code != 0 {
+		t.Errorf("--force: code %d", code)
+	}
+	if code, _, _ := run("--shape", "star"); code != 2 {
+		t.Errorf("unknown shape: code %d, want 2", code)
+	}
+}
@@ -0,0 +1,383 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/githooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// githooksStdin is what `bv hooks run pre-push` reads the pushed refs from;
+// tests replace it.
+var githooksStdin io.Reader = os.Stdin
+
+// hooksSubcommands are the `bv hooks` subcommands, for completion.
+var hooksSubcommands = []string{"install", "status", "remove", "run"}
+
+// runHooksCommand implements `bv hooks`. Returns the process exit code.
+func runHooksCommand(args []string, stdout, stderr io.Writer) int {
+	usage := func() {
+		fmt.Fprintln(stderr, "Usage: bv hooks install [--hook NAME] [--enforce CHECKS] [--dry-run] [--dir DIR]")
+		fmt.Fprintln(stderr, "       bv hooks status [--json] [--dir DIR]")
+		fmt.Fprintln(stderr, "       bv hooks remove [--hook NAME] [--dir DIR]")
+		fmt.Fprintln(stderr, "       bv hooks run pre-commit|pre-push [--json] [--dir DIR]")
+		fmt.Fprintln(stderr, "\nInstall git pre-commit and pre-push hooks that check the beads data: bv lint")
+		fmt.Fprintln(stderr, "findings, new dependency cycles, and stale claims. .bv/git-hooks.yaml sets")
+		fmt.Fprintln(stderr, "which checks each hook runs and whether they block (enforce) or warn (advisory).")
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+	switch args[0] {
+	case "install":
+		return runHooksInstall(args[1:], stdout, stderr)
+	case "status":
+		return runHooksStatus(args[1:], stdout, stderr)
+	case "remove":
+		return runHooksRemove(args[1:], stdout, stderr)
+	case "run":
+		return runHooksRun(args[1:], stdout, stderr)
+	case "-h", "-help", "--help", "help":
+		usage()
+		return 0
+	}
+	fmt.Fprintf(stderr, "bv hooks: unknown subcommand %q\n", args[0])
+	usage()
+	return 2
+}
+
+// parseHookNames parses --hook: one hook name, or "" for both.
+func parseHookNames(name string) ([]string, error) {
+	if name == "" {
+		return githooks.Hooks, nil
+	}
+	for _, h := range githooks.Hooks {
+		if h == name {
+			return []string{h}, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown hook %q (want %s)", name, strings.Join(githooks.Hooks, " or "))
+}
+
+func runHooksInstall(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("hooks install", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	hookName := fs.String("hook", "", "Install only this hook (pre-commit or pre-push)")
+	enforce := fs.String("enforce", "", "Checks that block pushes, comma-separated: lint, cycles, stale-claims (written to a new .bv/git-hooks.yaml)")
+	dryRun := fs.Bool("dry-run", false, "Print what would be written without writing it")
+	dir := fs.String("dir", ".", "Project directory")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv hooks install [--hook NAME] [--enforce CHECKS] [--dry-run] [--dir DIR]")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	hooks, err := parseHookNames(*hookName)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	enforced, err := githooks.ParseChecks(*enforce)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	hooksDir, err := githooks.HooksDir(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	// The configuration is the user's once written; --enforce only shapes
+	// a new one
+	configPath := githooks.ConfigPath(*dir)
+	var newConfig string
+	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+		cfg := githooks.DefaultConfig()
+		cfg.Enforce(githooks.PrePush, enforced...)
+		newConfig = cfg.Render()
+	} else if len(enforced) > 0 {
+		fmt.Fprintf(stderr, "Warning: %s exists; edit it to change which checks are enforced\n", configPath)
+	}
+
+	if *dryRun {
+		if newConfig != "" {
+			fmt.Fprintf(stdout, "Would write %s:\n%s\n", configPath, newConfig)
+		}
+		for _, s := range githooks.Status(hooksDir, hooks) {
+			switch s.State {
+			case githooks.StateManaged:
+				fmt.Fprintf(stdout, "%s: already installed\n", s.Path)
+			case githooks.StateForeign:
+				fmt.Fprintf(stdout, "%s: exists and wasn't written by bv; would refuse\n", s.Path)
+			default:
+				fmt.Fprintf(stdout, "Would write %s:\n%s\n", s.Path, githooks.Script(s.Hook))
+			}
+		}
+		return 0
+	}
+
+	written, err := githooks.Install(hooksDir, hooks)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if newConfig != "" {
+		if err := os.MkdirAll(filepath.Dir(configPath), 0o755); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(configPath, []byte(newConfig), 0o644); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "%s: created\n", configPath)
+	}
+	if len(written) == 0 {
+		fmt.Fprintln(stdout, "Hooks already installed.")
+	}
+	for _, s := range written {
+		fmt.Fprintf(stdout, "%s: installed\n", s.Path)
+	}
+	return 0
+}
+
+func runHooksStatus(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("hooks status", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "Print the status as JSON")
+	dir := fs.String("dir", ".", "Project directory")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	hooksDir, err := githooks.HooksDir(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	cfg, err := githooks.LoadConfig(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	statuses := githooks.Status(hooksDir, githooks.Hooks)
+	if *asJSON {
+		out := struct {
+			Hooks  []githooks.HookStatus `json:"hooks"`
+			Config githooks.Config       `json:"config"`
+		}{statuses, cfg}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(stderr, "Error encoding JSON: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+	for _, s := range statuses {
+		var checks []string
+		for _, check := range githooks.Checks {
+			if mode := cfg.Mode(s.Hook, check); mode != githooks.ModeOff {
+				checks = append(checks, fmt.Sprintf("%s (%s)", check, mode))
+			}
+		}
+		fmt.Fprintf(stdout, "%-10s %-8s %s\n", s.Hook, s.State, strings.Join(checks, ", "))
+	}
+	return 0
+}
+
+func runHooksRemove(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("hooks remove", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	hookName := fs.String("hook", "", "Remove only this hook (pre-commit or pre-push)")
+	dir := fs.String("dir", ".", "Project directory")
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	hooks, err := parseHookNames(*hookName)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+	hooksDir, err := githooks.HooksDir(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	removed, err := githooks.Remove(hooksDir, hooks)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if len(removed) == 0 {
+		fmt.Fprintln(stdout, "No bv hooks installed.")
+	}
+	for _, s := range removed {
+		fmt.Fprintf(stdout, "%s: removed\n", s.Path)
+	}
+	return 0
+}
+
+// runHooksRun is what the installed hooks call. It exits 1 when an enforced
+// check finds something, blocking the commit or push.
+func runHooksRun(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprintln(stderr, "Usage: bv hooks run pre-commit|pre-push [--json] [--dir DIR]")
+		return 2
+	}
+	hook := args[0]
+	if _, err := parseHookNames(hook); err != nil || hook == "" {
+		fmt.Fprintf(stderr, "Error: unknown hook %q\n", hook)
+		return 2
+	}
+	fs := flag.NewFlagSet("hooks run", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	asJSON := fs.Bool("json", false, "Print the result as JSON")
+	dir := fs.String("dir", ".", "Project directory")
+	// git passes pre-push the remote's name and URL; they aren't needed
+	if err := fs.Parse(args[1:]); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+
+	cfg, err := githooks.LoadConfig(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "bv %s: %v\n", hook, err)
+		return 1
+	}
+	inputs, err := hookInputs(hook, *dir, time.Now())
+	if err != nil {
+		// Without beads data there is nothing to check
+		fmt.Fprintf(stderr, "bv %s: skipping beads checks: %v\n", hook, err)
+		return 0
+	}
+	result := githooks.Result{Hook: hook, Findings: []githooks.Finding{}}
+	seen := make(map[string]bool)
+	for _, in := range inputs {
+		r := githooks.Run(hook, cfg, in)
+		for _, f := range r.Findings {
+			if key := string(f.Check) + "\x00" + f.Message; !seen[key] {
+				seen[key] = true
+				result.Findings = append(result.Findings, f)
+			}
+		}
+		result.Blocked = result.Blocked || r.Blocked
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(result); err != nil {
+			fmt.Fprintf(stderr, "Error encoding JSON: %v\n", err)
+			return 1
+		}
+	} else {
+		for _, f := range result.Findings {
+			level := "warning"
+			if f.Mode == githooks.ModeEnforce {
+				level = "error"
+			}
+			fmt.Fprintf(stderr, "bv %s: %s: %s: %s\n", hook, level, f.Check, f.Message)
+		}
+		if result.Blocked {
+			fmt.Fprintf(stderr, "bv %s: blocked by enforced checks (.bv/%s); git --no-verify skips them\n", hook, githooks.ConfigFilename)
+		}
+	}
+	if result.Blocked {
+		return 1
+	}
+	return 0
+}
+
+// hookInputs loads what hook checks. pre-commit checks the working tree's
+// beads file against HEAD. pre-push checks each pushed commit's beads data
+// against the remote's, or the mainline's for a new branch; lint always
+// reads the working tree.
+func hookInputs(hook, dir string, now time.Time) ([]githooks.Input, error) {
+	beadsDir, err := loader.GetBeadsDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := path
+	if rel, err := filepath.Rel(dir, path); err == nil {
+		name = rel
+	}
+	git := loader.NewGitLoader(dir)
+	loadAt := func(rev string) ([]model.Issue, bool) {
+		if rev == "" {
+			return nil, false
+		}
+		issues, err := git.LoadAt(rev)
+		return issues, err == nil
+	}
+
+	if hook == githooks.PreCommit {
+		issues, err := loader.LoadIssuesFromFile(path)
+		if err != nil {
+			return nil, err
+		}
+		base, known := loadAt("HEAD")
+		return []githooks.Input{{Beads: data, BeadsName: name, Issues: issues, Base: base, BaseKnown: known, Now: now}}, nil
+	}
+
+	// pre-push reads "<local ref> <local sha> <remote ref> <remote sha>"
+	var inputs []githooks.Input
+	scanner := bufio.NewScanner(githooksStdin)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) != 4 || strings.Trim(fields[1], "0") == "" {
+			continue // Malformed, or deleting the remote ref
+		}
+		issues, ok := loadAt(fields[1])
+		if !ok {
+			continue // No beads data in the pushed commit
+		}
+		baseRev := fields[3]
+		if strings.Trim(baseRev, "0") == "" {
+			baseRev = correlation.Mainline(dir)
+		}
+		base, known := loadAt(baseRev)
+		inputs = append(inputs, githooks.Input{Beads: data, BeadsName: name, Issues: issues, Base: base, BaseKnown: known, Now: now})
+	}
+	if len(inputs) == 0 {
+		// Nothing pushed carries beads data; still lint the working tree
+		inputs = append(inputs, githooks.Input{Beads: data, BeadsName: name, Now: now})
+	}
+	return inputs, nil
+}
@@ -0,0 +1,82 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunHooksCommand(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	git := func(args ...string) string {
+		t.Helper()
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = dir
+		out, err := cmd.CombinedOutput()
+		if err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+		return strings.TrimSpace(string(out))
+	}
+	beads := filepath.Join(dir, ".beads", "issues.jsonl")
+	commitBeads := func(lines ...string) string {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(beads), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(beads, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".beads")
+		git("commit", "-qm", "beads")
+		return git("rev-parse", "HEAD")
+	}
+	run := func(stdin string, args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		githooksStdin = strings.NewReader(stdin)
+		defer func() { githooksStdin = os.Stdin }()
+		code := runHooksCommand(append(args, "--dir", dir), &stdout, &stderr)
+		return code, stdout.String() + stderr.String()
+	}
+
+	git("init", "-q", "-b", "main")
+	base := commitBeads(
+		`{"id":"a-1","title":"One","status":"open","issue_type":"task"}`,
+		`{"id":"a-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-1","type":"blocks"}]}`,
+	)
+
+	if code, out := run("", "install", "--enforce", "cycles"); code != 0 || !strings.Contains(out, "pre-push: installed") || !strings.Contains(out, "git-hooks.yaml: created") {
+		t.Fatalf("install = %d:\n%s", code, out)
+	}
+	if code, out := run("", "status"); code != 0 || !strings.Contains(out, "managed") || !strings.Contains(out, "cycles (enforce)") {
+		t.Errorf("status = %d:\n%s", code, out)
+	}
+
+	head := commitBeads(
+		`{"id":"a-1","title":"One","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-2","type":"blocks"}]}`,
+		`{"id":"a-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-1","type":"blocks"}]}`,
+	)
+	push := "refs/heads/main " + head + " refs/heads/main " + base + "\n"
+	if code, out := run(push, "run", "pre-push"); code != 1 || !strings.Contains(out, "error: cycles: new dependency cycle:") {
+		t.Errorf("pushing a new cycle = %d:\n%s", code, out)
+	}
+	// The remote already has the cycle
+	push = "refs/heads/main " + head + " refs/heads/main " + head + "\n"
+	if code, out := run(push, "run", "pre-push"); code != 0 || out != "" {
+		t.Errorf("pushing an existing cycle = %d:\n%s", code, out)
+	}
+
+	if code, out := run("", "remove"); code != 0 || !strings.Contains(out, "pre-commit: removed") {
+		t.Errorf("remove = %d:\n%s", code, out)
+	}
+	if code, _ := run("", "run", "post-merge"); code != 2 {
+		t.Errorf("unknown hook = %d, want 2", code)
+	}
+}
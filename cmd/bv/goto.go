@@ -0,0 +1,143 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/deeplink"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
+)
+
+// urlHandlerSubcommands are the `bv url-handler` actions, in help order.
+var urlHandlerSubcommands = []string{"install", "remove"}
+
+// urlHandlerExecutable locates the bv binary the handler runs; tests
+// replace it.
+var urlHandlerExecutable = os.Executable
+
+// gotoProject returns the project --goto should open instead of the
+// current one. A link's ?repo= wins. Otherwise the current project is kept
+// when it has the issue, and failing that the most recently opened project
+// (the Ctrl+O list) that has it is used: links opened from a browser start
+// bv in the home directory.
+func gotoProject(target deeplink.Target, recentPath string) (uistate.Project, bool) {
+	if target.Repo != "" {
+		return uistate.Project{Path: target.Repo}, true
+	}
+	if beadsDir, err := loader.GetBeadsDir(""); err == nil {
+		if beadsPath, err := loader.FindJSONLPath(beadsDir); err == nil && projectHasIssue(beadsPath, target.IssueID) {
+			return uistate.Project{}, false
+		}
+	}
+	if recentPath == "" {
+		return uistate.Project{}, false
+	}
+	recent, err := uistate.LoadRecent(recentPath)
+	if err != nil {
+		return uistate.Project{}, false
+	}
+	for _, p := range recent.Projects {
+		if projectHasIssue(p.BeadsPath, target.IssueID) {
+			return p, true
+		}
+	}
+	return uistate.Project{}, false
+}
+
+// projectHasIssue reports whether the issues file at beadsPath holds issueID.
+func projectHasIssue(beadsPath, issueID string) bool {
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		return false
+	}
+	for _, issue := range issues {
+		if issue.ID == issueID {
+			return true
+		}
+	}
+	return false
+}
+
+// openGotoProject makes p the current project for the rest of the run. A
+// project from a link has no BeadsPath: its data is in .beads.
+func openGotoProject(p uistate.Project) error {
+	if err := os.Chdir(p.Path); err != nil {
+		return err
+	}
+	// BEADS_DIR would keep pointing at the old project otherwise
+	if _, ok := os.LookupEnv(loader.BeadsDirEnvVar); ok {
+		beadsDir := filepath.Join(p.Path, ".beads")
+		if p.BeadsPath != "" {
+			beadsDir = filepath.Dir(p.BeadsPath)
+		}
+		_ = os.Setenv(loader.BeadsDirEnvVar, beadsDir)
+	}
+	return nil
+}
+
+// runURLHandlerCommand implements `bv url-handler <install|remove>`.
+// Returns the process exit code: 0 on success, 1 on errors, 2 on usage errors.
+func runURLHandlerCommand(args []string, stdout, stderr io.Writer) int {
+	usage := func() {
+		fmt.Fprintln(stderr, "Usage: bv url-handler install")
+		fmt.Fprintln(stderr, "       bv url-handler remove")
+		fmt.Fprintln(stderr, "\nRegister bv to open bv://issue/<id> links for this user, so links pasted in")
+		fmt.Fprintln(stderr, "chat or pull requests open bv on the issue (bv --goto). Add ?repo=<path> to a")
+		fmt.Fprintln(stderr, "link to name the project; otherwise bv looks in recently opened projects.")
+	}
+	if len(args) == 0 {
+		usage()
+		return 2
+	}
+	switch args[0] {
+	case "install", "remove":
+	case "-h", "-help", "--help", "help":
+		usage()
+		return 0
+	default:
+		fmt.Fprintf(stderr, "bv url-handler: unknown subcommand %q\n", args[0])
+		usage()
+		return 2
+	}
+	fs := flag.NewFlagSet("url-handler "+args[0], flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = usage
+	if err := fs.Parse(args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if args[0] == "remove" {
+		where, err := deeplink.Uninstall()
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		fmt.Fprintf(stdout, "Removed the bv:// link handler (%s)\n", where)
+		return 0
+	}
+
+	exe, err := urlHandlerExecutable()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: locating the bv binary: %v\n", err)
+		return 1
+	}
+	if resolved, err := filepath.EvalSymlinks(exe); err == nil {
+		exe = resolved
+	}
+	where, err := deeplink.Install(exe)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	fmt.Fprintf(stdout, "Installed the bv:// link handler: %s\n", where)
+	fmt.Fprintf(stdout, "Links like %s://issue/<id> now open bv on the issue\n", deeplink.Scheme)
+	return 0
+}
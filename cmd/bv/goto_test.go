@@ -0,0 +1,86 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/deeplink"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
+)
+
+// writeGotoProject creates a project whose beads data holds ids.
+func writeGotoProject(t *testing.T, ids ...string) (string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	var lines []string
+	for _, id := range ids {
+		lines = append(lines, `{"id":"`+id+`","title":"`+id+`","status":"open","priority":1,"issue_type":"task"}`)
+	}
+	beadsPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(beadsPath, []byte(strings.Join(lines, "\n")+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir, beadsPath
+}
+
+func TestGotoProject(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	here, _ := writeGotoProject(t, "here-1")
+	api, apiBeads := writeGotoProject(t, "api-1")
+	t.Chdir(here)
+
+	recentPath := filepath.Join(t.TempDir(), "recent.json")
+	if err := uistate.RecordOpen(recentPath, apiBeads, time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, ok := gotoProject(deeplink.Target{IssueID: "here-1"}, recentPath); ok {
+		t.Error("the current project has here-1; bv shouldn't move")
+	}
+	p, ok := gotoProject(deeplink.Target{IssueID: "api-1"}, recentPath)
+	if !ok || p.BeadsPath != apiBeads {
+		t.Errorf("api-1 should open the recent api project, got %+v, %v", p, ok)
+	}
+	if _, ok := gotoProject(deeplink.Target{IssueID: "nowhere-1"}, recentPath); ok {
+		t.Error("an issue no project has shouldn't move bv")
+	}
+	p, ok = gotoProject(deeplink.Target{IssueID: "here-1", Repo: api}, recentPath)
+	if !ok || p.Path != api {
+		t.Errorf("a link's repo should win, got %+v, %v", p, ok)
+	}
+
+	if err := openGotoProject(uistate.Project{Path: api}); err != nil {
+		t.Fatal(err)
+	}
+	if wd, _ := os.Getwd(); !sameDir(wd, api) {
+		t.Errorf("working directory = %s, want %s", wd, api)
+	}
+}
+
+func sameDir(a, b string) bool {
+	ra, _ := filepath.EvalSymlinks(a)
+	rb, _ := filepath.EvalSymlinks(b)
+	return ra == rb
+}
+
+func TestURLHandlerCommandUsage(t *testing.T) {
+	var out, errOut bytes.Buffer
+	if code := runURLHandlerCommand(nil, &out, &errOut); code != 2 {
+		t.Errorf("no subcommand: exit %d", code)
+	}
+	errOut.Reset()
+	if code := runURLHandlerCommand([]string{"register"}, &out, &errOut); code != 2 || !strings.Contains(errOut.String(), `unknown subcommand "register"`) {
+		t.Errorf("unknown subcommand: exit %d, %q", code, errOut.String())
+	}
+	if code := runURLHandlerCommand([]string{"--help"}, &out, &errOut); code != 0 {
+		t.Errorf("--help: exit %d", code)
+	}
+}
@@ -0,0 +1,348 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// activeRegistration is this process's entry in .bv.instances.json, removed
+// by exit(). The TUI registers itself from ui.NewModel instead.
+var activeRegistration *instance.Registration
+
+// registerInstance records this process in the beads directory's instance
+// registry so other bv processes (and --robot-status) can see it. Failure is
+// silent: a read-only checkout just means this process goes unlisted.
+func registerInstance(mode instance.Mode) {
+	if activeRegistration != nil {
+		return
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return
+	}
+	if _, err := os.Stat(beadsDir); err != nil {
+		return
+	}
+	reg, err := instance.Register(beadsDir, mode, false)
+	if err != nil {
+		return
+	}
+	reg.Start(0)
+	activeRegistration = reg
+}
+
+// unregisterInstance removes this process from the registry. Entries left
+// behind by a crash are pruned once their PID is gone.
+func unregisterInstance() {
+	if activeRegistration == nil {
+		return
+	}
+	_ = activeRegistration.Close()
+	activeRegistration = nil
+}
+
+// signalCacheUpdates makes every write to the shared analysis disk cache
+// rewrite the cache signal file in beadsDir, so live TUIs on the same repo
+// notice that a robot command has analyzed newer data. The signal names the
+// version of beadsPath this process loaded, so it is called right after
+// loading. The write itself runs under the exclusive repository lock (see
+// lockForCacheWrite).
+func signalCacheUpdates(beadsDir, beadsPath string) {
+	var dataFile *instance.FileStamp
+	if stamp, err := instance.StatFile(beadsPath); err == nil {
+		dataFile = &stamp
+	}
+	analysis.SetDiskCacheWriteGuard(lockForCacheWrite)
+	analysis.SetDiskCacheWriteHook(func(dataHash string) {
+		_ = instance.SignalCacheUpdate(beadsDir, dataHash, commandPurpose(), dataFile)
+	})
+}
+
+// cacheWriteLockTimeout bounds the wait for the exclusive lock before a
+// disk-cache write. The write is optional, so a busy repository just skips
+// it rather than stalling the command for the full lock timeout.
+const cacheWriteLockTimeout = 2 * time.Second
+
+// lockForCacheWrite upgrades a read-only robot command's shared repository
+// lock to exclusive for the duration of a disk-cache write, then returns to
+// shared. A command that already holds the exclusive lock writes as is. The
+// write runs on the analysis goroutine, so repoLockMu is held throughout.
+func lockForCacheWrite() (func(), error) {
+	repoLockMu.Lock()
+	if repoLock != nil && repoLock.Access() == instance.AccessExclusive {
+		return repoLockMu.Unlock, nil
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		repoLockMu.Unlock()
+		return nil, err
+	}
+	wasShared := repoLock != nil
+	restore := func() {
+		if wasShared {
+			_ = lockRepoLocked(instance.AccessShared, commandPurpose())
+		}
+		repoLockMu.Unlock()
+	}
+	unlockRepoLocked()
+	lock, err := instance.AcquireExclusive(beadsDir, commandPurpose()+" (cache write)", cacheWriteLockTimeout)
+	if err != nil {
+		restore()
+		return nil, err
+	}
+	return func() {
+		_ = lock.Release()
+		restore()
+	}, nil
+}
+
+// repoLock is the shared or exclusive repository lock this process holds,
+// released by exit(). repoLockMu guards it: disk-cache writes swap it from
+// the analysis goroutine.
+var (
+	repoLockMu sync.Mutex
+	repoLock   *instance.RWLock
+)
+
+// defaultRepoLockTimeout bounds how long bv waits for the repository lock.
+const defaultRepoLockTimeout = 10 * time.Second
+
+// repoLockTimeout returns BV_LOCK_TIMEOUT_S (seconds) or the default.
+func repoLockTimeout() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("BV_LOCK_TIMEOUT_S")); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second
+	}
+	return defaultRepoLockTimeout
+}
+
+// lockRepo takes the repository lock in the requested mode. Read-only robot
+// commands take it shared so any number can run at once; commands that write
+// repository state take it exclusive. A shared lock already held by this
+// process is given up before upgrading, since the process would otherwise
+// wait on itself.
+//
+// Only a timeout is an error: if the lock can't be created at all (read-only
+// checkout), bv carries on unlocked as it always has.
+func lockRepo(access instance.Access, purpose string) error {
+	repoLockMu.Lock()
+	defer repoLockMu.Unlock()
+	return lockRepoLocked(access, purpose)
+}
+
+// lockRepoLocked is lockRepo for callers holding repoLockMu.
+func lockRepoLocked(access instance.Access, purpose string) error {
+	if repoLock != nil {
+		if repoLock.Access() == access || repoLock.Access() == instance.AccessExclusive {
+			return nil
+		}
+		unlockRepoLocked()
+	}
+	beadsDir, err := loader.GetBeadsDir("")
+	if err != nil {
+		return nil
+	}
+	if _, err := os.Stat(beadsDir); err != nil {
+		return nil
+	}
+	acquire := instance.AcquireShared
+	if access == instance.AccessExclusive {
+		acquire = instance.AcquireExclusive
+	}
+	lock, err := acquire(beadsDir, purpose, repoLockTimeout())
+	if err != nil {
+		if errors.Is(err, instance.ErrLockBusy) {
+			return fmt.Errorf("%w\nAnother bv is using this repository; retry, or raise BV_LOCK_TIMEOUT_S to wait longer", err)
+		}
+		return nil
+	}
+	repoLock = lock
+	return nil
+}
+
+// mustLockRepo is lockRepo for main: a timeout is fatal.
+func mustLockRepo(access instance.Access, purpose string) {
+	if err := lockRepo(access, purpose); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		if errors.Is(err, instance.ErrLockBusy) {
+			exit(exitLockConflict)
+		}
+		exit(exitError)
+	}
+}
+
+// unlockRepo releases the repository lock if held.
+func unlockRepo() {
+	repoLockMu.Lock()
+	defer repoLockMu.Unlock()
+	unlockRepoLocked()
+}
+
+// unlockRepoLocked is unlockRepo for callers holding repoLockMu.
+func unlockRepoLocked() {
+	if repoLock == nil {
+		return
+	}
+	_ = repoLock.Release()
+	repoLock = nil
+}
+
+// commandPurpose describes this invocation for lock holder listings.
+func commandPurpose() string {
+	purpose := []rune(strings.Join(os.Args[1:], " "))
+	if len(purpose) > 60 {
+		return string(purpose[:57]) + "..."
+	}
+	return string(purpose)
+}
+
+// robotStatus is the --robot-status output.
+type robotStatus struct {
+	GeneratedAt   string                  `json:"generated_at"`
+	BeadsDir      string                  `json:"beads_dir"`
+	Healthy       bool                    `json:"healthy"` // data file found and parsed
+	Lock          instance.LockStatus     `json:"lock"`
+	InstanceCount int                     `json:"instance_count"`
+	Instances     []instance.InstanceInfo `json:"instances"`
+	Data          robotStatusData         `json:"data"`
+	Cache         robotStatusCache        `json:"cache"`
+	Analysis      robotStatusAnalysis     `json:"analysis"`
+}
+
+// robotStatusData describes the beads data file.
+type robotStatusData struct {
+	Path       string `json:"path,omitempty"`
+	SizeBytes  int64  `json:"size_bytes"`
+	ModifiedAt string `json:"modified_at,omitempty"`
+	IssueCount int    `json:"issue_count"`
+	DataHash   string `json:"data_hash,omitempty"`
+	Error      string `json:"error,omitempty"`
+}
+
+// robotStatusCache describes the on-disk robot analysis cache for the
+// current data.
+type robotStatusCache struct {
+	Path             string                `json:"path,omitempty"`
+	Fresh            bool                  `json:"fresh"`   // full results for this data and config are cached
+	Entries          int                   `json:"entries"` // cached results for this data, any config
+	CachedAt         string                `json:"cached_at,omitempty"`
+	AgeSeconds       int64                 `json:"age_seconds,omitempty"`
+	ExpiresInSeconds int64                 `json:"expires_in_seconds,omitempty"`
+	LastSignal       *instance.CacheSignal `json:"last_signal,omitempty"`
+	SignalMatches    bool                  `json:"signal_matches_data"` // last signal was about this data
+	Error            string                `json:"error,omitempty"`
+}
+
+// robotStatusAnalysis reports how quickly analysis queries can be answered.
+// Phase 1 (degrees, topological order) is computed on demand in
+// milliseconds; phase 2 (PageRank, betweenness, ...) is "cached" when a
+// robot command can serve it from disk, "cold" when the next one computes it.
+type robotStatusAnalysis struct {
+	ConfigHash     string               `json:"config_hash,omitempty"`
+	Phase1         string               `json:"phase1"`
+	Phase2         string               `json:"phase2"`
+	SkippedMetrics []robotSkippedMetric `json:"skipped_metrics,omitempty"`
+}
+
+// robotSkippedMetric is a phase 2 metric the size-based config turns off.
+type robotSkippedMetric struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+}
+
+// buildRobotStatus inspects the lock, instance registry, data file, and
+// analysis cache for beadsDir without registering, taking anything over, or
+// running analysis. Only a registry read failure is an error; data and cache
+// problems are reported in the output so health checks can see them.
+func buildRobotStatus(beadsDir string) (robotStatus, error) {
+	instances, err := instance.ListInstances(beadsDir)
+	if err != nil {
+		return robotStatus{}, err
+	}
+	if instances == nil {
+		instances = []instance.InstanceInfo{}
+	}
+	status := robotStatus{
+		GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+		BeadsDir:      beadsDir,
+		Lock:          instance.Inspect(beadsDir),
+		InstanceCount: len(instances),
+		Instances:     instances,
+		Analysis:      robotStatusAnalysis{Phase1: "unavailable", Phase2: "unavailable"},
+	}
+
+	issues, ok := statusData(beadsDir, &status.Data)
+	if !ok {
+		return status, nil
+	}
+	status.Healthy = true
+	status.Analysis.Phase1 = "ready"
+	status.Analysis.Phase2 = "cold"
+
+	config := analysis.NewAnalyzer(issues).Config()
+	configHash := analysis.ComputeConfigHash(&config)
+	status.Analysis.ConfigHash = configHash
+	for _, m := range config.SkippedMetrics() {
+		status.Analysis.SkippedMetrics = append(status.Analysis.SkippedMetrics, robotSkippedMetric{Name: m.Name, Reason: m.Reason})
+	}
+
+	path, entries, err := analysis.InspectDiskCache(status.Data.DataHash)
+	status.Cache.Path = path
+	if err != nil {
+		status.Cache.Error = err.Error()
+	}
+	status.Cache.Entries = len(entries)
+	for _, e := range entries {
+		if e.ConfigHash != configHash {
+			continue
+		}
+		age := time.Since(e.CreatedAt)
+		status.Cache.Fresh = true
+		status.Cache.CachedAt = e.CreatedAt.UTC().Format(time.RFC3339)
+		status.Cache.AgeSeconds = int64(age.Seconds())
+		status.Cache.ExpiresInSeconds = int64((analysis.DiskCacheMaxAge - age).Seconds())
+		status.Analysis.Phase2 = "cached"
+		break
+	}
+	if sig, err := instance.ReadCacheSignal(beadsDir); err == nil {
+		status.Cache.LastSignal = &sig
+		status.Cache.SignalMatches = sig.DataHash == status.Data.DataHash
+	}
+	return status, nil
+}
+
+// statusData fills data from the beads data file and returns its issues.
+// It reports false, with data.Error set, if the file can't be found or parsed.
+func statusData(beadsDir string, data *robotStatusData) ([]model.Issue, bool) {
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		data.Error = err.Error()
+		return nil, false
+	}
+	data.Path = path
+	info, err := os.Stat(path)
+	if err != nil {
+		data.Error = err.Error()
+		return nil, false
+	}
+	data.SizeBytes = info.Size()
+	data.ModifiedAt = info.ModTime().UTC().Format(time.RFC3339)
+
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		data.Error = err.Error()
+		return nil, false
+	}
+	data.IssueCount = len(issues)
+	data.DataHash = analysis.ComputeDataHash(issues)
+	return issues, true
+}
@@ -0,0 +1,79 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ci"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/junit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/sarif"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/plural"
+)
+
+// lintJUnit is bv lint's checks as test cases: a check fails when it found
+// an error or a warning; notes only show in its output.
+func lintJUnit(checks []sarif.Rule, results []sarif.Result) junit.Suite {
+	byRule := make(map[string][]sarif.Result)
+	for _, r := range results {
+		byRule[r.RuleID] = append(byRule[r.RuleID], r)
+	}
+	cases := make([]junit.Case, 0, len(checks))
+	for _, check := range checks {
+		c := junit.Case{Name: check.ID, Classname: "bv.lint"}
+		var failing, notes []string
+		for _, r := range byRule[check.ID] {
+			line := r.Message.Text
+			if len(r.Locations) > 0 {
+				loc := r.Locations[0].PhysicalLocation
+				if loc.Region != nil {
+					line = fmt.Sprintf("%s:%d: %s", loc.ArtifactLocation.URI, loc.Region.StartLine, line)
+				}
+			}
+			if r.Level == sarif.LevelNote {
+				notes = append(notes, line)
+			} else {
+				failing = append(failing, line)
+			}
+		}
+		if len(failing) > 0 {
+			c.Failure = &junit.Failure{
+				Message: fmt.Sprintf("%d %s: %s", len(failing), plural.Word(len(failing), "finding"), check.ShortDescription.Text),
+				Type:    check.ID,
+				Text:    strings.Join(failing, "\n"),
+			}
+		}
+		c.SystemOut = strings.Join(notes, "\n")
+		cases = append(cases, c)
+	}
+	return junit.NewSuite("bv lint", cases)
+}
+
+// ciSummaryJUnit is the CI policies as test cases: enforced policies with
+// violations fail, advisory ones pass with their violations in the output,
+// and policies that are off are skipped.
+func ciSummaryJUnit(result ci.Result) junit.Suite {
+	cases := make([]junit.Case, 0, len(ci.Policies))
+	for _, policy := range ci.Policies {
+		c := junit.Case{Name: string(policy), Classname: "bv.ci-summary"}
+		var messages []string
+		for _, v := range result.Violations {
+			if v.Policy == policy {
+				messages = append(messages, v.Message)
+			}
+		}
+		switch mode := result.Modes[policy]; {
+		case mode == ci.ModeOff:
+			c.Skipped = &junit.Skipped{Message: "off in .bv/" + ci.ConfigFilename}
+		case len(messages) > 0 && mode == ci.ModeEnforce:
+			c.Failure = &junit.Failure{
+				Message: fmt.Sprintf("%d %s", len(messages), plural.Word(len(messages), "violation")),
+				Type:    string(policy),
+				Text:    strings.Join(messages, "\n"),
+			}
+		default:
+			c.SystemOut = strings.Join(messages, "\n")
+		}
+		cases = append(cases, c)
+	}
+	return junit.NewSuite("bv ci-summary", cases)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ci"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/sarif"
+)
+
+func TestLintJUnit(t *testing.T) {
+	checks := []sarif.Rule{{ID: "status-case"}, {ID: "dependency-cycle"}, {ID: "rule/fyi"}}
+	results := []sarif.Result{
+		{RuleID: "dependency-cycle", Level: sarif.LevelError, Message: sarif.Message{Text: "Dependency cycle: a → b → a"}, Locations: []sarif.Location{sarif.LineLocation(".beads/issues.jsonl", 2)}},
+		{RuleID: "rule/fyi", Level: sarif.LevelNote, Message: sarif.Message{Text: "a: FYI"}, Locations: []sarif.Location{sarif.LineLocation(".beads/issues.jsonl", 1)}},
+	}
+	suite := lintJUnit(checks, results)
+	if suite.Tests != 3 || suite.Failures != 1 {
+		t.Fatalf("suite = %+v", suite)
+	}
+	if c := suite.Cases[1]; c.Failure == nil || c.Failure.Text != ".beads/issues.jsonl:2: Dependency cycle: a → b → a" {
+		t.Errorf("cycle case = %+v", c)
+	}
+	if c := suite.Cases[2]; c.Failure != nil || c.SystemOut != ".beads/issues.jsonl:1: a: FYI" {
+		t.Errorf("notes fail the check: %+v", c)
+	}
+}
+
+func TestCISummaryJUnit(t *testing.T) {
+	cfg := ci.DefaultConfig()
+	cfg.Policies[ci.PolicyHealth] = ci.ModeOff
+	result := ci.Evaluate(cfg, ci.Input{
+		NewCycles:   [][]string{{"a", "b", "a"}},
+		HealthTrend: "degrading",
+	})
+	result.Violations = append(result.Violations, ci.Violation{Policy: ci.PolicyBlockedGrowth, Mode: ci.ModeAdvisory, Message: "blocked grew"})
+	suite := ciSummaryJUnit(result)
+	if suite.Tests != len(ci.Policies) || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Fatalf("suite = %+v", suite)
+	}
+	byName := make(map[string]int)
+	for i, c := range suite.Cases {
+		byName[c.Name] = i
+	}
+	if c := suite.Cases[byName["new-cycles"]]; c.Failure == nil || c.Failure.Message != "1 violation" {
+		t.Errorf("new-cycles = %+v", c)
+	}
+	if c := suite.Cases[byName["blocked-growth"]]; c.Failure != nil || c.SystemOut != "blocked grew" {
+		t.Errorf("advisory violations fail the case: %+v", c)
+	}
+}
@@ -0,0 +1,157 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/junit"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/sarif"
+)
+
+// lintOutput is the `bv lint --json` result.
+type lintOutput struct {
+	Path  string `json:"path"`
+	Fixed bool   `json:"fixed"` // --fix rewrote the file
+	Diff  string `json:"diff,omitempty"`
+	lint.Report
+}
+
+// runLintCommand implements `bv lint`. Returns the process exit code: 0 when
+// the file is clean (or --fix repaired it), 1 when findings remain or the
+// file can't be read or written, 2 on usage errors.
+func runLintCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("lint", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fix := fs.Bool("fix", false, "Repair the findings in place and print the diff")
+	dryRun := fs.Bool("dry-run", false, "With --fix, print the diff without writing")
+	jsonOut := fs.Bool("json", false, "Output JSON (same as --format json)")
+	format := fs.String("format", "text", "Output format: text, json, sarif, or junit")
+	dir := fs.String("dir", ".", "Project directory")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv lint [--fix [--dry-run]] [--format text|json|sarif|junit] [--dir DIR]")
+		fmt.Fprintln(stderr, "\nCheck the beads file for problems bv can repair safely: duplicate")
+		fmt.Fprintln(stderr, "dependencies, self-dependencies, status casing and missing updated_at.")
+		fmt.Fprintln(stderr, "--fix rewrites only the affected lines, atomically, and prints a diff.")
+		fmt.Fprintln(stderr, "--format sarif also reports dangling dependencies, cycles and alerts, at")
+		fmt.Fprintln(stderr, "the lines of the issues they concern, for code scanning. --format junit")
+		fmt.Fprintln(stderr, "reports the same checks as JUnit XML test cases.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if *jsonOut {
+		*format = "json"
+	}
+	report := *format == "sarif" || *format == "junit"
+	if fs.NArg() > 0 || (*dryRun && !*fix) || (*format != "text" && *format != "json" && !report) || (report && *fix) {
+		fs.Usage()
+		return 2
+	}
+
+	projectDir, err := filepath.Abs(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	beadsDir, err := loader.GetBeadsDir(projectDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	out := lintOutput{Path: path, Report: lint.Lint(data)}
+	remaining := len(out.Report.Findings)
+	if report {
+		issues, err := loader.LoadIssuesFromFile(path)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+		if *format == "sarif" {
+			err = sarif.Write(stdout, lintSARIF(projectDir, path, data, out.Report, issues))
+		} else {
+			err = junit.Write(stdout, "bv", lintJUnit(lintChecks(projectDir, path, data, out.Report, issues)))
+		}
+		if err != nil {
+			fmt.Fprintf(stderr, "Error encoding %s: %v\n", *format, err)
+			return 1
+		}
+		if remaining > 0 {
+			return 1
+		}
+		return 0
+	}
+	if *fix && remaining > 0 {
+		change := agents.Change{Path: path, Action: agents.ActionUpdate, Before: string(data), After: string(out.Report.Fixed)}
+		out.Diff = change.Diff()
+		if !*dryRun {
+			if err := change.Apply(); err != nil {
+				fmt.Fprintf(stderr, "Error writing %s: %v\n", path, err)
+				return 1
+			}
+			out.Fixed = true
+		}
+		// Findings on lines lint had to skip can't be fixed
+		remaining = len(lint.Lint(out.Report.Fixed).Findings)
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(out); err != nil {
+			fmt.Fprintf(stderr, "Error encoding lint report: %v\n", err)
+			return 1
+		}
+	} else {
+		rel := path
+		if r, err := filepath.Rel(projectDir, path); err == nil {
+			rel = r
+		}
+		for _, f := range out.Report.Findings {
+			fmt.Fprintf(stdout, "%s:%d: %s: %s [%s]\n", rel, f.Line, f.IssueID, f.Message, f.Rule)
+		}
+		for _, s := range out.Report.Skipped {
+			fmt.Fprintf(stdout, "%s:%d: skipped: %s\n", rel, s.Line, s.Reason())
+		}
+		switch {
+		case len(out.Report.Findings) == 0:
+			fmt.Fprintf(stdout, "%s: no problems found\n", rel)
+		case *fix:
+			fmt.Fprint(stdout, out.Diff)
+			verb := "Fixed"
+			if *dryRun {
+				verb = "Would fix"
+			}
+			fmt.Fprintf(stdout, "%s %d of %d problems in %s\n", verb, len(out.Report.Findings)-remaining, len(out.Report.Findings), rel)
+		default:
+			fmt.Fprintf(stdout, "%d problems; run bv lint --fix to repair them\n", len(out.Report.Findings))
+		}
+	}
+
+	if remaining > 0 || (*fix && *dryRun && len(out.Report.Findings) > 0) {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/sarif"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// Rule IDs of the findings bv lint --format sarif adds to lint's own.
+const (
+	sarifRuleMalformed = "malformed-line"
+	sarifRuleDangling  = "dangling-dependency"
+	sarifRuleCycle     = "dependency-cycle"
+)
+
+// lintSARIFRules describes the fixed rules; alert rules are added as they
+// occur.
+var lintSARIFRules = []sarif.Rule{
+	sarifRule(string(lint.RuleDuplicateDependency), "A dependency is listed more than once", sarif.LevelWarning, "Run bv lint --fix to drop the duplicates."),
+	sarifRule(string(lint.RuleSelfDependency), "An issue depends on itself", sarif.LevelWarning, "Run bv lint --fix to drop the self-dependency."),
+	sarifRule(string(lint.RuleStatusCase), "A status has stray case or whitespace", sarif.LevelWarning, "Run bv lint --fix to normalize the status."),
+	sarifRule(string(lint.RuleMissingUpdatedAt), "An issue has created_at but no updated_at", sarif.LevelWarning, "Run bv lint --fix to set updated_at from created_at."),
+	sarifRule(sarifRuleMalformed, "A line isn't a valid issue, so bv and bd skip it", sarif.LevelError, "Repair the JSON, or restore the line from git."),
+	sarifRule(sarifRuleDangling, "A dependency points at an issue that doesn't exist", sarif.LevelError, "Remove the dependency (bd dep remove), or restore the missing issue."),
+	sarifRule(sarifRuleCycle, "Issues depend on each other in a cycle, so none of them can start", sarif.LevelError, "Remove one dependency of the cycle (bd dep remove)."),
+}
+
+func sarifRule(id, description string, level sarif.Level, help string) sarif.Rule {
+	return sarif.Rule{
+		ID:                   id,
+		ShortDescription:     sarif.Message{Text: description},
+		Help:                 &sarif.Message{Text: help},
+		DefaultConfiguration: &sarif.RuleDefaults{Level: level},
+	}
+}
+
+// lintSARIF reports report's findings, the beads file's dangling
+// dependencies and cycles, and the alerts (including .bv/rules.yaml alert
+// rules) raised on its issues, each at the line of the issue it concerns.
+// data is the file at path; issues are its valid issues.
+func lintSARIF(projectDir, path string, data []byte, report lint.Report, issues []model.Issue) sarif.Log {
+	checks, results := lintChecks(projectDir, path, data, report, issues)
+	return sarif.NewLog(sarif.Driver{
+		Name:           "bv",
+		Version:        version.Version,
+		InformationURI: "https://github.com/Dicklesworthstone/beads_viewer",
+		Rules:          checks,
+	}, results)
+}
+
+// lintChecks runs the checks lintSARIF reports. checks are every one that
+// ran, each .bv/rules.yaml alert rule included, whether or not it found
+// anything.
+func lintChecks(projectDir, path string, data []byte, report lint.Report, issues []model.Issue) (checks []sarif.Rule, results []sarif.Result) {
+	uri := path
+	if rel, err := filepath.Rel(projectDir, path); err == nil {
+		uri = rel
+	}
+	uri = filepath.ToSlash(uri)
+	lines := lint.IssueLines(data)
+	at := func(issueID string) sarif.Location { return sarif.LineLocation(uri, lines[issueID]) }
+	result := func(rule string, level sarif.Level, issueID, text, fingerprint string, locations ...sarif.Location) sarif.Result {
+		return sarif.Result{
+			RuleID:              rule,
+			Level:               level,
+			Message:             sarif.Message{Text: text},
+			Locations:           locations,
+			PartialFingerprints: map[string]string{"bvFinding/v1": rule + ":" + issueID + ":" + fingerprint},
+		}
+	}
+
+	for _, f := range report.Findings {
+		results = append(results, result(string(f.Rule), sarif.LevelWarning, f.IssueID, fmt.Sprintf("%s: %s", f.IssueID, f.Message), f.Message, sarif.LineLocation(uri, f.Line)))
+	}
+	for _, s := range report.Skipped {
+		results = append(results, result(sarifRuleMalformed, sarif.LevelError, "", fmt.Sprintf("Line %d skipped: %s", s.Line, s.Reason()), fmt.Sprint(s.Line), sarif.LineLocation(uri, s.Line)))
+	}
+
+	known := make(map[string]bool, len(issues))
+	for _, issue := range issues {
+		known[issue.ID] = true
+	}
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.DependsOnID != "" && !known[dep.DependsOnID] {
+				results = append(results, result(sarifRuleDangling, sarif.LevelError, issue.ID,
+					fmt.Sprintf("%s depends on %s, which doesn't exist", issue.ID, dep.DependsOnID), dep.DependsOnID, at(issue.ID)))
+			}
+		}
+	}
+
+	if len(issues) > 0 {
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		for _, cycle := range stats.Cycles() {
+			nodes := cycle
+			if len(nodes) > 1 && nodes[0] == nodes[len(nodes)-1] {
+				nodes = nodes[:len(nodes)-1]
+			}
+			if len(nodes) == 0 {
+				continue
+			}
+			r := result(sarifRuleCycle, sarif.LevelError, nodes[0], "Dependency cycle: "+strings.Join(append(append([]string(nil), nodes...), nodes[0]), " → "), strings.Join(sortedCopy(nodes), ","), at(nodes[0]))
+			for i, id := range nodes[1:] {
+				loc := at(id)
+				loc.ID = i + 1
+				loc.Message = &sarif.Message{Text: id}
+				r.RelatedLocations = append(r.RelatedLocations, loc)
+			}
+			results = append(results, r)
+		}
+	}
+
+	checks = append([]sarif.Rule(nil), lintSARIFRules...)
+	added := make(map[string]bool)
+	addRule := func(rule sarif.Rule) {
+		if !added[rule.ID] {
+			added[rule.ID] = true
+			checks = append(checks, rule)
+		}
+	}
+	if set, err := rules.Load(projectDir); err == nil && set != nil {
+		for _, r := range set.Alerts {
+			addRule(alertRuleSARIF(r.Name, drift.Severity(r.Severity)))
+		}
+	}
+	if alerts, err := computeAlerts(issues, projectDir, baseline.DefaultPath(projectDir), true); err == nil {
+		for _, a := range alerts.Alerts {
+			// Cycles are reported above; alerts on the whole graph have no line
+			if a.Type == drift.AlertNewCycle || a.IssueID == "" || lines[a.IssueID] == 0 {
+				continue
+			}
+			rule := sarifRule("alert/"+string(a.Type), "bv alert: "+strings.ReplaceAll(string(a.Type), "_", " "), alertSARIFLevel(a.Severity), "See bv --robot-alerts for details.")
+			if a.Type == drift.AlertRule {
+				rule = alertRuleSARIF(a.Rule, a.Severity)
+			}
+			addRule(rule)
+			results = append(results, result(rule.ID, alertSARIFLevel(a.Severity), a.IssueID, a.Message, "", at(a.IssueID)))
+		}
+	}
+	return checks, results
+}
+
+// alertRuleSARIF describes a .bv/rules.yaml alert rule.
+func alertRuleSARIF(name string, severity drift.Severity) sarif.Rule {
+	return sarifRule("rule/"+name, "Alert rule "+name+" from .bv/rules.yaml", alertSARIFLevel(severity), "See bv --robot-alerts for details.")
+}
+
+func alertSARIFLevel(s drift.Severity) sarif.Level {
+	switch s {
+	case drift.SeverityCritical:
+		return sarif.LevelError
+	case drift.SeverityWarning:
+		return sarif.LevelWarning
+	}
+	return sarif.LevelNote
+}
+
+func sortedCopy(ids []string) []string {
+	out := append([]string(nil), ids...)
+	sort.Strings(out)
+	return out
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/sarif"
+)
+
+func TestRunLintCommand(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	content := `{"id":"a-1","title":"One","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"a-2","title":"Two","status":"Closed","issue_type":"task","dependencies":[{"depends_on_id":"a-2","type":"blocks"}]}` + "\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (int, string) {
+		var stdout, stderr bytes.Buffer
+		code := runLintCommand(append(args, "--dir", dir), &stdout, &stderr)
+		return code, stdout.String() + stderr.String()
+	}
+
+	code, out := run()
+	if code != 1 || !strings.Contains(out, ".beads/issues.jsonl:2: a-2: status \"Closed\" should be \"closed\" [status-case]") || !strings.Contains(out, "2 problems") {
+		t.Fatalf("lint = %d:\n%s", code, out)
+	}
+
+	code, out = run("--fix", "--dry-run")
+	if code != 1 || !strings.Contains(out, `+{"id":"a-2",`) || !strings.Contains(out, "Would fix 2 of 2") {
+		t.Fatalf("dry run = %d:\n%s", code, out)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Fatal("--dry-run wrote the file")
+	}
+
+	code, out = run("--fix")
+	if code != 0 || !strings.Contains(out, "Fixed 2 of 2") {
+		t.Fatalf("fix = %d:\n%s", code, out)
+	}
+	data, _ := os.ReadFile(path)
+	lines := strings.Split(string(data), "\n")
+	if lines[0] != strings.Split(content, "\n")[0] || !strings.Contains(lines[1], `"status":"closed"`) || strings.Contains(lines[1], "dependencies") {
+		t.Errorf("fixed file:\n%s", data)
+	}
+
+	if code, out = run(); code != 0 || !strings.Contains(out, "no problems found") {
+		t.Errorf("after fix = %d:\n%s", code, out)
+	}
+	if code, _ = run("--dry-run"); code != 2 {
+		t.Errorf("--dry-run without --fix = %d, want 2", code)
+	}
+}
+
+func TestRunLintCommandSARIF(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	write := func(path, content string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write(filepath.Join(dir, ".beads", "issues.jsonl"),
+		`{"id":"a-1","title":"One","status":"open","priority":2,"issue_type":"task","dependencies":[{"depends_on_id":"a-2","type":"blocks"}]}`+"\n"+
+			`{"id":"a-2","title":"Two","status":"open","priority":2,"issue_type":"task","dependencies":[{"depends_on_id":"a-1","type":"blocks"}]}`+"\n"+
+			`{"id":"a-3","title":"Three","status":"open","priority":0,"issue_type":"bug","dependencies":[{"depends_on_id":"gone-1","type":"blocks"}]}`+"\n")
+	write(filepath.Join(dir, ".bv", "rules.yaml"), "alerts:\n  - name: open-p0\n    when: 'priority == 0'\n    severity: critical\n    message: P0 is open\n")
+
+	var stdout, stderr bytes.Buffer
+	if code := runLintCommand([]string{"--format", "sarif", "--dir", dir}, &stdout, &stderr); code != 0 {
+		t.Fatalf("sarif = %d: %s", code, stderr.String())
+	}
+	var log sarif.Log
+	if err := json.Unmarshal(stdout.Bytes(), &log); err != nil {
+		t.Fatalf("%v\n%s", err, stdout.String())
+	}
+	var got []string
+	for _, r := range log.Runs[0].Results {
+		got = append(got, fmt.Sprintf("%s@%d", r.RuleID, r.Locations[0].PhysicalLocation.Region.StartLine))
+	}
+	want := "dangling-dependency@3 dependency-cycle@1 rule/open-p0@3"
+	if strings.Join(got, " ") != want {
+		t.Errorf("results = %v, want %s", got, want)
+	}
+	if uri := log.Runs[0].Results[0].Locations[0].PhysicalLocation.ArtifactLocation.URI; uri != ".beads/issues.jsonl" {
+		t.Errorf("uri = %q", uri)
+	}
+	if rules := log.Runs[0].Tool.Driver.Rules; len(rules) != 3 || rules[2].ID != "rule/open-p0" {
+		t.Errorf("rules = %+v", rules)
+	}
+
+	stdout.Reset()
+	if code := runLintCommand([]string{"--format", "junit", "--dir", dir}, &stdout, &stderr); code != 0 ||
+		!strings.Contains(stdout.String(), `<testsuite name="bv lint" tests="8" failures="3" skipped="0">`) {
+		t.Errorf("junit = %d:\n%s", code, stdout.String())
+	}
+
+	if code := runLintCommand([]string{"--format", "sarif", "--fix", "--dir", dir}, &stdout, &stderr); code != 2 {
+		t.Errorf("--format sarif --fix = %d, want 2", code)
+	}
+}
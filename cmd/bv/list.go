@@ -0,0 +1,430 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
+)
+
+// defaultListLimit is the --robot-list page size when --limit isn't given.
+const defaultListLimit = 50
+
+// robotListRequest is --robot-list's flags.
+type robotListRequest struct {
+	Query  string
+	Label  string
+	Owner  string // CODEOWNERS owner of the issues' likely files
+	Status string // comma-separated statuses
+	Sort   string
+	Limit  int // 0 = everything
+	Cursor string
+	Fields string // comma-separated field names or a preset
+}
+
+// robotList is the --robot-list payload.
+type robotList struct {
+	GeneratedAt string           `json:"generated_at"`
+	DataHash    string           `json:"data_hash"`
+	AsOf        string           `json:"as_of,omitempty"`
+	AsOfCommit  string           `json:"as_of_commit,omitempty"`
+	Filters     robotListFilters `json:"filters"`
+	Sort        string           `json:"sort"`
+	Fields      []string         `json:"fields"`
+	Total       int              `json:"total"` // matches across all pages
+	Count       int              `json:"count"`
+	Issues      []robotListIssue `json:"issues"`
+	NextCursor  string           `json:"next_cursor,omitempty"` // absent on the last page
+}
+
+type robotListFilters struct {
+	Query    string   `json:"query,omitempty"`
+	Label    string   `json:"label,omitempty"`
+	Owner    string   `json:"owner,omitempty"`
+	Statuses []string `json:"statuses,omitempty"`
+}
+
+// listField renders one --fields column for an issue.
+type listField struct {
+	name  string
+	value func(e listEntry) any
+}
+
+// listEntry is an issue with what --robot-list computes about it.
+type listEntry struct {
+	issue     *model.Issue
+	score     float64
+	blockedBy []string
+	blocks    []string
+	owners    []string
+	shape     analysis.DependencyShape
+}
+
+// listFields are the selectable columns, in output order.
+var listFields = []listField{
+	{"id", func(e listEntry) any { return e.issue.ID }},
+	{"title", func(e listEntry) any { return e.issue.Title }},
+	{"status", func(e listEntry) any { return e.issue.Status }},
+	{"priority", func(e listEntry) any { return e.issue.Priority }},
+	{"issue_type", func(e listEntry) any { return e.issue.IssueType }},
+	{"assignee", func(e listEntry) any { return e.issue.Assignee }},
+	{"owners", func(e listEntry) any { return nonNilStrings(e.owners) }},
+	{"labels", func(e listEntry) any { return nonNilStrings(e.issue.Labels) }},
+	{"score", func(e listEntry) any { return e.score }},
+	{"blocked_by", func(e listEntry) any { return nonNilStrings(e.blockedBy) }},
+	{"blocks", func(e listEntry) any { return nonNilStrings(e.blocks) }},
+	{"depth", func(e listEntry) any { return e.shape.Depth }},
+	{"dependents", func(e listEntry) any { return e.shape.Dependents }},
+	{"dependencies", func(e listEntry) any { return e.shape.Dependencies }},
+	{"created_at", func(e listEntry) any { return e.issue.CreatedAt }},
+	{"updated_at", func(e listEntry) any { return e.issue.UpdatedAt }},
+	{"closed_at", func(e listEntry) any { return e.issue.ClosedAt }},
+	{"due_date", func(e listEntry) any { return e.issue.DueDate }},
+	{"estimated_minutes", func(e listEntry) any { return e.issue.EstimatedMinutes }},
+	{"comment_count", func(e listEntry) any { return len(e.issue.Comments) }},
+	{"source_repo", func(e listEntry) any { return e.issue.SourceRepo }},
+	{"description", func(e listEntry) any { return e.issue.Description }},
+	{"design", func(e listEntry) any { return e.issue.Design }},
+	{"acceptance_criteria", func(e listEntry) any { return e.issue.AcceptanceCriteria }},
+	{"notes", func(e listEntry) any { return e.issue.Notes }},
+}
+
+// listFieldPresets are named --fields sets.
+var listFieldPresets = map[string][]string{
+	"minimal": {"id", "title", "status", "priority"},
+	"default": {"id", "title", "status", "priority", "issue_type", "assignee", "labels", "score", "blocked_by", "updated_at"},
+	"full":    nil, // every field
+}
+
+// listSortKeys maps each --sort key to the value items are ordered by,
+// largest first; ties are broken by ID ascending.
+var listSortKeys = map[string]func(e listEntry) float64{
+	"score":        func(e listEntry) float64 { return e.score },
+	"priority":     func(e listEntry) float64 { return -float64(e.issue.Priority) }, // P0 first
+	"updated":      func(e listEntry) float64 { return float64(e.issue.UpdatedAt.UnixMilli()) },
+	"created":      func(e listEntry) float64 { return float64(e.issue.CreatedAt.UnixMilli()) },
+	"depth":        func(e listEntry) float64 { return float64(e.shape.Depth) },
+	"dependents":   func(e listEntry) float64 { return float64(e.shape.Dependents) },
+	"dependencies": func(e listEntry) float64 { return float64(e.shape.Dependencies) },
+	"id":           func(e listEntry) float64 { return 0 },
+}
+
+// robotListIssue is one issue with only the requested fields, encoded in
+// field order.
+type robotListIssue struct {
+	fields []listField
+	entry  listEntry
+}
+
+func (r robotListIssue) MarshalJSON() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteByte('{')
+	for i, f := range r.fields {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+		v, err := json.Marshal(f.value(r.entry))
+		if err != nil {
+			return nil, err
+		}
+		fmt.Fprintf(&b, "%q:", f.name)
+		b.Write(v)
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// listCursor is the position after the last issue of a page. Paging by
+// position (sort value, ID) instead of offset keeps pages stable when
+// issues are added or closed between calls.
+type listCursor struct {
+	Query string  `json:"q"` // fingerprint of the filters and sort
+	Key   float64 `json:"k"`
+	ID    string  `json:"id"`
+}
+
+func encodeListCursor(c listCursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeListCursor(s string) (listCursor, error) {
+	var c listCursor
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err == nil {
+		err = json.Unmarshal(data, &c)
+	}
+	if err != nil {
+		return c, fmt.Errorf("invalid --cursor %q", s)
+	}
+	return c, nil
+}
+
+// listQueryFingerprint identifies the filters and sort a cursor belongs to.
+func listQueryFingerprint(req robotListRequest, statuses []string) string {
+	sum := sha256.Sum256([]byte(strings.Join([]string{req.Query, req.Label, req.Owner, strings.Join(statuses, ","), req.Sort}, "\x00")))
+	return hex.EncodeToString(sum[:4])
+}
+
+// parseListFields resolves --fields to columns: a preset name or a comma
+// list of field names.
+func parseListFields(spec string) ([]listField, error) {
+	if spec == "" {
+		spec = "default"
+	}
+	byName := make(map[string]listField, len(listFields))
+	for _, f := range listFields {
+		byName[f.name] = f
+	}
+	if names, ok := listFieldPresets[spec]; ok {
+		if names == nil {
+			return listFields, nil
+		}
+		spec = strings.Join(names, ",")
+	}
+	var fields []listField
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(spec, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		f, ok := byName[name]
+		if !ok {
+			valid := make([]string, len(listFields))
+			for i, f := range listFields {
+				valid[i] = f.name
+			}
+			return nil, fmt.Errorf("unknown field %q for --fields (presets: minimal, default, full; fields: %s)", name, strings.Join(valid, ", "))
+		}
+		seen[name] = true
+		fields = append(fields, f)
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("--fields selects no fields")
+	}
+	return fields, nil
+}
+
+// parseListStatuses validates --status. Empty means every status but
+// tombstone.
+func parseListStatuses(spec string) ([]string, error) {
+	var statuses []string
+	for _, s := range strings.Split(spec, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		if !model.Status(s).IsValid() {
+			return nil, fmt.Errorf("unknown status %q for --status", s)
+		}
+		statuses = append(statuses, s)
+	}
+	sort.Strings(statuses)
+	return statuses, nil
+}
+
+// matchesListQuery reports whether every whitespace-separated term of
+// query appears, case-insensitively, in the issue's ID, title,
+// description, notes, or labels.
+func matchesListQuery(issue *model.Issue, query string) bool {
+	terms := strings.Fields(strings.ToLower(query))
+	if len(terms) == 0 {
+		return true
+	}
+	text := strings.ToLower(strings.Join([]string{issue.ID, issue.Title, issue.Description, issue.Notes, strings.Join(issue.Labels, " ")}, "\n"))
+	for _, term := range terms {
+		if !strings.Contains(text, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// buildRobotList filters, sorts, and pages issues for --robot-list. scores
+// holds triage scores by issue ID; issues without one score 0. owners holds
+// CODEOWNERS owners by issue ID (see issueOwners).
+func buildRobotList(issues []model.Issue, scores map[string]float64, owners map[string][]string, req robotListRequest) (robotList, error) {
+	if req.Sort == "" {
+		req.Sort = "score"
+	}
+	sortKey, ok := listSortKeys[req.Sort]
+	if !ok {
+		return robotList{}, fmt.Errorf("unknown --sort %q (want score, priority, updated, created, depth, dependents, dependencies, or id)", req.Sort)
+	}
+	fields, err := parseListFields(req.Fields)
+	if err != nil {
+		return robotList{}, err
+	}
+	statuses, err := parseListStatuses(req.Status)
+	if err != nil {
+		return robotList{}, err
+	}
+	fingerprint := listQueryFingerprint(req, statuses)
+	var after *listCursor
+	if req.Cursor != "" {
+		c, err := decodeListCursor(req.Cursor)
+		if err != nil {
+			return robotList{}, err
+		}
+		if c.Query != fingerprint {
+			return robotList{}, fmt.Errorf("--cursor belongs to a different query; repeat the filters and --sort it was issued with")
+		}
+		after = &c
+	}
+
+	wantStatus := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		wantStatus[s] = true
+	}
+	open := make(map[string]bool, len(issues))
+	for i := range issues {
+		if !issues[i].Status.IsClosed() && !issues[i].Status.IsTombstone() {
+			open[issues[i].ID] = true
+		}
+	}
+	blocks := make(map[string][]string)
+	for i := range issues {
+		for _, dep := range issues[i].Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				blocks[dep.DependsOnID] = append(blocks[dep.DependsOnID], issues[i].ID)
+			}
+		}
+	}
+
+	shapes := analysis.ComputeDependencyShapes(issues)
+
+	var entries []listEntry
+	for i := range issues {
+		issue := &issues[i]
+		if len(wantStatus) == 0 && issue.Status.IsTombstone() {
+			continue
+		}
+		if len(wantStatus) > 0 && !wantStatus[string(issue.Status)] {
+			continue
+		}
+		if req.Label != "" && !hasLabel(*issue, req.Label) {
+			continue
+		}
+		if req.Owner != "" && !codeowners.Match(owners[issue.ID], req.Owner) {
+			continue
+		}
+		if !matchesListQuery(issue, req.Query) {
+			continue
+		}
+		e := listEntry{issue: issue, score: scores[issue.ID], blocks: blocks[issue.ID], owners: owners[issue.ID], shape: shapes[issue.ID]}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && open[dep.DependsOnID] {
+				e.blockedBy = append(e.blockedBy, dep.DependsOnID)
+			}
+		}
+		entries = append(entries, e)
+	}
+	sort.SliceStable(entries, func(i, j int) bool {
+		ki, kj := sortKey(entries[i]), sortKey(entries[j])
+		if ki != kj {
+			return ki > kj
+		}
+		return entries[i].issue.ID < entries[j].issue.ID
+	})
+
+	out := robotList{
+		Filters: robotListFilters{Query: req.Query, Label: req.Label, Owner: req.Owner, Statuses: statuses},
+		Sort:    req.Sort,
+		Total:   len(entries),
+		Issues:  []robotListIssue{},
+	}
+	for _, f := range fields {
+		out.Fields = append(out.Fields, f.name)
+	}
+
+	start := 0
+	if after != nil {
+		start = sort.Search(len(entries), func(i int) bool {
+			k := sortKey(entries[i])
+			return k < after.Key || k == after.Key && entries[i].issue.ID > after.ID
+		})
+	}
+	end := len(entries)
+	if req.Limit > 0 && start+req.Limit < end {
+		end = start + req.Limit
+	}
+	for _, e := range entries[start:end] {
+		out.Issues = append(out.Issues, robotListIssue{fields: fields, entry: e})
+	}
+	out.Count = len(out.Issues)
+	if end < len(entries) {
+		last := entries[end-1]
+		out.NextCursor = encodeListCursor(listCursor{Query: fingerprint, Key: sortKey(last), ID: last.issue.ID})
+	}
+	return out, nil
+}
+
+// listNeedsScores reports whether req sorts by or shows triage scores, which
+// are the expensive part of --robot-list.
+func listNeedsScores(req robotListRequest) bool {
+	if req.Sort == "" || req.Sort == "score" {
+		return true
+	}
+	fields, err := parseListFields(req.Fields)
+	if err != nil {
+		return false
+	}
+	for _, f := range fields {
+		if f.name == "score" {
+			return true
+		}
+	}
+	return false
+}
+
+// listNeedsOwners reports whether req filters on or shows CODEOWNERS
+// owners, which costs a scan of the git history.
+func listNeedsOwners(req robotListRequest) bool {
+	if req.Owner != "" {
+		return true
+	}
+	fields, err := parseListFields(req.Fields)
+	if err != nil {
+		return false
+	}
+	for _, f := range fields {
+		if f.name == "owners" {
+			return true
+		}
+	}
+	return false
+}
+
+// listScores ranks every issue with triage for --robot-list, adjusted by
+// the project's score rules.
+func listScores(issues []model.Issue, ruleSet *rules.Set) map[string]float64 {
+	triage := analysis.ComputeTriageWithOptions(issues, analysis.TriageOptions{
+		TopN:          len(issues),
+		WaitForPhase2: true,
+		UseFastConfig: true,
+		ScoreRules:    ruleSet,
+	})
+	scores := make(map[string]float64, len(triage.Recommendations))
+	for _, rec := range triage.Recommendations {
+		scores[rec.ID] = rec.Score
+	}
+	return scores
+}
+
+func nonNilStrings(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
@@ -0,0 +1,135 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func listTestIssues() []model.Issue {
+	day := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	return []model.Issue{
+		{ID: "A-1", Title: "Fix login", Status: model.StatusOpen, Priority: 2, Labels: []string{"auth"}, UpdatedAt: day.Add(1 * time.Hour)},
+		{ID: "A-2", Title: "Login page redesign", Status: model.StatusInProgress, Priority: 1, UpdatedAt: day.Add(3 * time.Hour)},
+		{ID: "A-3", Title: "Audit logging", Status: model.StatusClosed, Priority: 0, UpdatedAt: day.Add(2 * time.Hour)},
+		{ID: "A-4", Title: "Session tokens", Status: model.StatusOpen, Priority: 1, Labels: []string{"auth"},
+			Dependencies: []*model.Dependency{{IssueID: "A-4", DependsOnID: "A-1", Type: model.DepBlocks}}},
+		{ID: "A-5", Title: "Deleted", Status: model.StatusTombstone},
+	}
+}
+
+func listIDs(t *testing.T, out robotList) []string {
+	t.Helper()
+	data, err := json.Marshal(out.Issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var items []map[string]any
+	if err := json.Unmarshal(data, &items); err != nil {
+		t.Fatal(err)
+	}
+	ids := make([]string, len(items))
+	for i, item := range items {
+		ids[i], _ = item["id"].(string)
+	}
+	return ids
+}
+
+func TestBuildRobotListFiltersAndSorts(t *testing.T) {
+	scores := map[string]float64{"A-1": 0.9, "A-2": 0.5, "A-4": 0.7}
+	owners := map[string][]string{"A-2": {"@org/web"}, "A-4": {"@org/auth", "@ann"}}
+	tests := []struct {
+		name string
+		req  robotListRequest
+		want string
+	}{
+		{"score, tombstones hidden", robotListRequest{}, "A-1 A-4 A-2 A-3"},
+		{"priority with ID tiebreak", robotListRequest{Sort: "priority"}, "A-3 A-2 A-4 A-1"},
+		{"updated", robotListRequest{Sort: "updated"}, "A-2 A-3 A-1 A-4"},
+		{"status filter", robotListRequest{Status: "open,in_progress", Sort: "id"}, "A-1 A-2 A-4"},
+		{"label filter", robotListRequest{Label: "auth"}, "A-1 A-4"},
+		{"query matches every word", robotListRequest{Query: "LOGIN page"}, "A-2"},
+		{"owner filter, @ optional", robotListRequest{Owner: "ann"}, "A-4"},
+		{"dependents", robotListRequest{Sort: "dependents"}, "A-1 A-2 A-3 A-4"},
+		{"dependencies", robotListRequest{Sort: "dependencies"}, "A-4 A-1 A-2 A-3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := buildRobotList(listTestIssues(), scores, owners, tt.req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := strings.Join(listIDs(t, out), " "); got != tt.want {
+				t.Errorf("ids = %s, want %s", got, tt.want)
+			}
+			if out.Total != out.Count || out.NextCursor != "" {
+				t.Errorf("single page: total=%d count=%d next=%q", out.Total, out.Count, out.NextCursor)
+			}
+		})
+	}
+}
+
+func TestBuildRobotListFields(t *testing.T) {
+	out, err := buildRobotList(listTestIssues(), nil, nil, robotListRequest{Sort: "id", Fields: "id,blocked_by,blocks", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := json.Marshal(out.Issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"id":"A-1","blocked_by":[],"blocks":["A-4"]}]`; string(data) != want {
+		t.Errorf("issues = %s, want %s", data, want)
+	}
+
+	out, err = buildRobotList(listTestIssues(), nil, nil, robotListRequest{Sort: "depth", Fields: "id,depth,dependents,dependencies", Limit: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err = json.Marshal(out.Issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `[{"id":"A-4","depth":1,"dependents":0,"dependencies":1},{"id":"A-1","depth":0,"dependents":1,"dependencies":0}]`; string(data) != want {
+		t.Errorf("issues = %s, want %s", data, want)
+	}
+
+	for _, bad := range []robotListRequest{{Fields: "id,bogus"}, {Sort: "size"}, {Status: "done"}, {Cursor: "!!"}} {
+		if _, err := buildRobotList(listTestIssues(), nil, nil, bad); err == nil {
+			t.Errorf("%+v: want an error", bad)
+		}
+	}
+}
+
+func TestBuildRobotListCursorPagination(t *testing.T) {
+	issues := listTestIssues()
+	req := robotListRequest{Sort: "id", Limit: 2}
+	page1, err := buildRobotList(issues, nil, nil, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(listIDs(t, page1), " "); got != "A-1 A-2" || page1.Total != 4 || page1.NextCursor == "" {
+		t.Fatalf("page 1 = %s total=%d next=%q", got, page1.Total, page1.NextCursor)
+	}
+
+	// An issue added before the cursor doesn't shift the next page
+	issues = append(issues, model.Issue{ID: "A-0", Title: "New", Status: model.StatusOpen})
+	req.Cursor = page1.NextCursor
+	page2, err := buildRobotList(issues, nil, nil, req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(listIDs(t, page2), " "); got != "A-3 A-4" || page2.NextCursor != "" {
+		t.Errorf("page 2 = %s next=%q", got, page2.NextCursor)
+	}
+
+	// Cursors only continue the query they came from
+	req.Sort = "priority"
+	if _, err := buildRobotList(issues, nil, nil, req); err == nil || !strings.Contains(err.Error(), "different query") {
+		t.Errorf("cursor reused with another sort: err = %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/debug"
+)
+
+// configureLogging applies --log-level / --log-format / --log-file on top of
+// the BV_LOG_* environment (which pkg/debug already applied at startup).
+// Empty arguments leave the environment's choice in place.
+func configureLogging(level, format, file string) error {
+	if level == "" && format == "" && file == "" {
+		return nil
+	}
+	opts, err := debug.OptionsFromEnv()
+	if err != nil {
+		return err
+	}
+	if level != "" {
+		l, subsystems, err := debug.ParseLevelSpec(level)
+		if err != nil {
+			return fmt.Errorf("--log-level: %w", err)
+		}
+		opts.Level, opts.Subsystems = l, subsystems
+	}
+	switch strings.ToLower(format) {
+	case "":
+	case "text":
+		opts.JSON = false
+	case "json":
+		opts.JSON = true
+	default:
+		return fmt.Errorf("--log-format: unknown format %q (want text or json)", format)
+	}
+	if file != "" {
+		opts.File = file
+	}
+	return debug.Configure(opts)
+}
@@ -12,6 +12,7 @@ import (
 	"os/exec"
 	"os/signal"
 	"path/filepath"
+	"runtime/debug"
 	"sort"
 	"strconv"
 	"strings"
@@ -21,20 +22,28 @@ import (
 	json "github.com/goccy/go-json"
 
 	"golang.org/x/term"
-	"gopkg.in/yaml.v3"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/deeplink"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/diagnostics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notes"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/pins"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
@@ -44,6 +53,9 @@ import (
 )
 
 func main() {
+	// Write a redacted diagnostics bundle if anything panics.
+	defer diagnostics.RecoverAndReport()
+
 	help := flag.Bool("help", false, "Show help")
 	versionFlag := flag.Bool("version", false, "Show version")
 	// Update flags (bv-182)
@@ -52,6 +64,9 @@ func main() {
 	rollbackFlag := flag.Bool("rollback", false, "Rollback to the previous version (from backup)")
 	yesFlag := flag.Bool("yes", false, "Skip confirmation prompts (use with --update)")
 	exportFile := flag.String("export-md", "", "Export issues to a Markdown file (e.g., report.md)")
+	redact := flag.Bool("redact", false, "Hash titles, descriptions and people's names in all outputs and exports, keeping IDs and structure (salt with BV_REDACT_SALT)")
+	includeArchive := flag.Bool("include-archive", false, "Also load closed issues moved to .beads/archive by bv archive (for history-aware analyses)")
+	exportICS := flag.String("export-ics", "", "Export forecast epic milestones and critical path checkpoints to an iCalendar file (e.g., plan.ics)")
 	robotHelp := flag.Bool("robot-help", false, "Show AI agent help")
 	robotInsights := flag.Bool("robot-insights", false, "Output graph analysis and insights as JSON for AI agents")
 	robotPlan := flag.Bool("robot-plan", false, "Output dependency-respecting execution plan as JSON for AI agents")
@@ -60,17 +75,48 @@ func main() {
 	robotTriageByTrack := flag.Bool("robot-triage-by-track", false, "Group triage recommendations by execution track (bv-87)")
 	robotTriageByLabel := flag.Bool("robot-triage-by-label", false, "Group triage recommendations by label (bv-87)")
 	robotNext := flag.Bool("robot-next", false, "Output only the top pick recommendation as JSON (minimal triage)")
+	agentID := flag.String("agent-id", os.Getenv("BV_AGENT_ID"), "Agent identity for --robot-next and --robot-frontier: resume its own claims, skip other agents' (default: BV_AGENT_ID)")
+	nextClaim := flag.Bool("claim", false, "With --robot-next, claim the pick through bd and record it in .bv/sessions.json")
+	robotFrontierFlag := flag.Bool("robot-frontier", false, "Output the ready frontier as JSON: open issues with every blocker closed, not claimed by others, grouped by priority")
+	robotWorktreesFlag := flag.Bool("robot-worktrees", false, "Output the repository's git worktrees as JSON with the beads claimed in each and claims on different branches that touch the same files")
+	robotOnboard := flag.Bool("robot-onboard", false, "Output a one-shot orientation for a new agent session as JSON (project, top pick, claims, AGENTS.md conventions, robot commands)")
+	// Issue listing for agents
+	robotListFlag := flag.Bool("robot-list", false, "Output issues as JSON with filters, sorting, and cursor pagination (see --query, --status, --label, --sort, --limit, --cursor, --fields)")
+	listQuery := flag.String("query", "", "Text filter for --robot-list: every word must appear in the ID, title, description, notes, or labels")
+	listStatus := flag.String("status", "", "Comma-separated statuses for --robot-list (default: all but tombstone)")
+	listSort := flag.String("sort", "score", "Sort key for --robot-list: score, priority, updated, created, depth, dependents, dependencies, or id")
+	listLimit := flag.Int("limit", defaultListLimit, "Page size for --robot-list (0 = no limit)")
+	listCursorFlag := flag.String("cursor", "", "Continue --robot-list after a previous page (its next_cursor)")
+	listFieldsFlag := flag.String("fields", "default", "Fields for --robot-list: minimal, default, full, or a comma-separated list")
+	listOwner := flag.String("owner", "", "CODEOWNERS owner for --robot-list: only issues whose likely files they own")
+	robotShowID := flag.String("robot-show", "", "Output one issue as JSON with metrics, dependency chains, what-if delta, alerts, and suggested commands")
+	robotShowIDs := flag.String("ids", "", "Comma-separated issue IDs for a batch --robot-show in one run; unknown IDs get error entries")
+	showNotes := flag.Bool("with-notes", false, "With --robot-show, include each issue's local notes from .beads/.bv-notes/ as local_notes")
 	robotDiff := flag.Bool("robot-diff", false, "Output diff as JSON (use with --diff-since)")
 	robotRecipes := flag.Bool("robot-recipes", false, "Output available recipes as JSON for AI agents")
 	robotLabelHealth := flag.Bool("robot-label-health", false, "Output label health metrics as JSON for AI agents")
 	robotLabelFlow := flag.Bool("robot-label-flow", false, "Output cross-label dependency flow as JSON for AI agents")
 	robotLabelAttention := flag.Bool("robot-label-attention", false, "Output attention-ranked labels as JSON for AI agents")
+	robotLabels := flag.Bool("robot-labels", false, "Output label taxonomy analytics (usage, co-occurrence, slow labels, typo variants) as JSON")
+	robotEpics := flag.Bool("robot-epics", false, "Output per-epic progress and traffic-light health as JSON")
+	robotStale := flag.Bool("robot-stale", false, "Output unfinished issues untouched for --stale-days, grouped by type with cleanup actions, as JSON")
+	staleDays := flag.Int("stale-days", analysis.DefaultStaleSweepDays, "Days without activity before --robot-stale lists an issue")
+	robotReach := flag.String("robot-reach", "", "Output the issues reachable from an issue ID along blocking dependencies (see --direction, --depth) as JSON")
+	reachDirection := flag.String("direction", analysis.ReachDown, "Direction for --robot-reach: down (what it transitively blocks) or up (what blocks it)")
+	reachDepth := flag.Int("depth", 0, "Maximum dependency depth for --robot-reach (0 = unlimited)")
+	robotHealthHistory := flag.Bool("robot-health-history", false, "Output graph health (density, depth, cycles, orphans) per git revision of the beads file as JSON")
+	healthRevisions := flag.Int("health-revisions", analysis.DefaultHealthHistoryRevisions, "Number of beads file revisions for --robot-health-history and --robot-blocked-time")
+	robotBlockedTime := flag.Bool("robot-blocked-time", false, "Output time each issue spent blocked over recent git revisions of the beads file, totaled by label and epic, as JSON")
+	wipLimit := flag.Int("wip-limit", -1, "Default per-owner WIP limit for --robot-plan (overrides default_wip_limit in .bv/owners.yaml; 0 = unlimited)")
+	robotOrder := flag.Bool("robot-order", false, "Output a topological execution order of open issues, in parallel waves and as one flat list, as JSON")
 	attentionLimit := flag.Int("attention-limit", 5, "Limit number of labels in --robot-label-attention output")
 	robotAlerts := flag.Bool("robot-alerts", false, "Output alerts (drift + proactive) as JSON for AI agents")
 	robotMetrics := flag.Bool("robot-metrics", false, "Output performance metrics (timing, cache, memory) as JSON")
+	robotCapabilitiesFlag := flag.Bool("robot-capabilities", false, "Output this binary's version, robot commands, schema versions, features, and deprecations as JSON")
+	robotStatusFlag := flag.Bool("robot-status", false, "Output instance, data file, and analysis cache state for this repo as JSON")
 	// Smart suggestions (bv-180)
-	robotSuggest := flag.Bool("robot-suggest", false, "Output smart suggestions (duplicates, dependencies, labels, cycles) as JSON")
-	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle")
+	robotSuggest := flag.Bool("robot-suggest", false, "Output smart suggestions (duplicates, dependencies, labels, cycles, epic proposals, redundant dependencies) as JSON")
+	suggestType := flag.String("suggest-type", "", "Filter suggestions by type: duplicate, dependency, label, cycle, epic, redundant")
 	suggestConfidence := flag.Float64("suggest-confidence", 0.0, "Minimum confidence for suggestions (0.0-1.0)")
 	suggestBead := flag.String("suggest-bead", "", "Filter suggestions for specific bead ID")
 	// Graph export (bv-136)
@@ -78,6 +124,7 @@ func main() {
 	graphFormat := flag.String("graph-format", "json", "Graph output format: json, dot, mermaid")
 	graphRoot := flag.String("graph-root", "", "Subgraph from specific root issue ID")
 	graphDepth := flag.Int("graph-depth", 0, "Max depth for subgraph (0 = unlimited)")
+	graphCondense := flag.Bool("graph-condense", false, "Collapse each dependency cycle into one node in --robot-graph (json, dot)")
 	// Graph snapshot export (bv-94)
 	exportGraph := flag.String("export-graph", "", "Export graph: .html for interactive, .png/.svg for static (auto-names if empty)")
 	graphPreset := flag.String("graph-preset", "compact", "Graph layout preset: compact (default) or roomy")
@@ -89,10 +136,13 @@ func main() {
 	robotByAssignee := flag.String("robot-by-assignee", "", "Filter robot outputs by assignee (exact match)")
 	// Label subgraph scoping (bv-122)
 	labelScope := flag.String("label", "", "Scope analysis to label's subgraph (affects --robot-insights, --robot-plan, --robot-priority)")
+	// PageRank personalization
+	focusSpec := flag.String("focus", "", "Personalize PageRank toward issue IDs (epics include descendants), priorities like P0, or labels; comma-separated (affects --robot-insights, --robot-plan, --robot-priority, --robot-triage)")
 	alertSeverity := flag.String("severity", "", "Filter robot alerts by severity (info|warning|critical)")
 	alertType := flag.String("alert-type", "", "Filter robot alerts by alert type (e.g., stale_issue)")
 	alertLabel := flag.String("alert-label", "", "Filter robot alerts by label match")
 	recipeName := flag.String("recipe", "", "Apply named recipe (e.g., triage, actionable, high-impact)")
+	gotoFlag := flag.String("goto", "", "Open the TUI on an issue, by ID or bv://issue/<id> link, with its parents and children expanded in the tree view")
 	recipeShort := flag.String("r", "", "Shorthand for --recipe")
 	semanticQuery := flag.String("search", "", "Semantic search query (vector-based; builds/updates index on first run)")
 	robotSearch := flag.Bool("robot-search", false, "Output semantic search results as JSON for AI agents (use with --search)")
@@ -103,8 +153,14 @@ func main() {
 	diffSince := flag.String("diff-since", "", "Show changes since historical point (commit SHA, branch, tag, or date)")
 	asOf := flag.String("as-of", "", "View state at point in time (commit SHA, branch, tag, or date)")
 	forceFullAnalysis := flag.Bool("force-full-analysis", false, "Compute all metrics regardless of graph size (may be slow for large graphs)")
-	profileStartup := flag.Bool("profile-startup", false, "Output detailed startup timing profile for diagnostics")
+	pluginsFlag := flag.Bool("plugins", false, "Run bv-analyzer-* plugins on PATH for --robot-insights")
+	profileStartup := flag.Bool("profile-startup", false, "Output per-stage startup timing profile (load, analysis, triage, first frame) for diagnostics")
 	profileJSON := flag.Bool("profile-json", false, "Output profile in JSON format (use with --profile-startup)")
+	verifyDeterminism := flag.Bool("verify-determinism", false, "Run the analysis pipeline repeatedly (varying GOMAXPROCS) and report any output divergence as JSON")
+	determinismRuns := flag.Int("determinism-runs", 5, "Number of runs for --verify-determinism")
+	cpuProfile := flag.String("cpuprofile", "", "Write a CPU profile to this file (inspect with go tool pprof)")
+	memProfile := flag.String("memprofile", "", "Write a heap profile to this file on exit")
+	pprofAddr := flag.String("pprof-addr", "", "Serve net/http/pprof on this address in TUI/preview/watch modes (e.g. localhost:6060)")
 	noHooks := flag.Bool("no-hooks", false, "Skip running hooks during export")
 	workspaceConfig := flag.String("workspace", "", "Load issues from workspace config file (.bv/workspace.yaml)")
 	repoFilter := flag.String("repo", "", "Filter issues by repository prefix (e.g., 'api-' or 'api')")
@@ -186,13 +242,110 @@ func main() {
 	watchExport := flag.Bool("watch-export", false, "Watch for beads changes and auto-regenerate export (use with --export-pages)")
 	pagesWizard := flag.Bool("pages", false, "Launch interactive Pages deployment wizard")
 	// Debug rendering flag (for diagnosing TUI issues)
-	debugRender := flag.String("debug-render", "", "Render a view and output to file (views: insights, board)")
+	debugRender := flag.String("debug-render", "", "Render a view and output to file (views: insights, board, history, tree, graph)")
 	debugWidth := flag.Int("debug-width", 180, "Width for debug render")
 	debugHeight := flag.Int("debug-height", 50, "Height for debug render")
 	// Experimental background snapshot worker (bv-o11l)
 	backgroundMode := flag.Bool("background-mode", false, "Enable experimental background snapshot loading (TUI only)")
 	noBackgroundMode := flag.Bool("no-background-mode", false, "Disable experimental background snapshot loading (TUI only)")
-	flag.Parse()
+	// Accessibility: ASCII-only icons instead of emoji
+	asciiMode := flag.Bool("ascii", false, "Use ASCII tags ([BUG], [FEAT]) instead of emoji icons (also BV_ASCII=1 or ui.ascii in config)")
+	// Structured logging (also BV_LOG_LEVEL / BV_LOG_FORMAT / BV_LOG_FILE)
+	logLevel := flag.String("log-level", "", "Log level: debug, info, warn, error, or off, with optional per-subsystem overrides (e.g. info,loader=debug)")
+	logFormat := flag.String("log-format", "", "Log format: text or json")
+	logFile := flag.String("log-file", "", "Write logs to this file instead of stderr, rotating at 5 MiB ('auto' = user cache dir)")
+	// Robot compatibility: schema pin and deprecated flag aliases
+	compatFlag := flag.String("compat", "", "Pin robot output to a robot schema version (see --robot-capabilities); adds a meta block")
+	exitCodeFlag := flag.Bool("exit-code", false, "Robot commands exit 3 (parse errors), 4 (cycles), or 5 (nothing actionable) instead of 0")
+	errorsJSON := flag.Bool("errors-json", false, "Robot commands write stderr as JSON lines {time,level,message}, including warnings")
+	quiet := flag.Bool("quiet", false, "Robot commands write only errors to stderr")
+	registerRobotFlagAliases(flag.CommandLine)
+
+	// Update-check channel, frequency, and offline opt-out (env / config.yaml).
+	updateCheckCfg, updateCheckWarnings := resolveUpdateCheckConfig()
+	updater.SetCheckConfig(updateCheckCfg)
+
+	// Message catalog locale (BV_LANG / ui.locale / system locale), set before
+	// anything renders text or robot usage hints.
+	localeWarnings := setupLocale()
+
+	// Subcommands are dispatched before flag parsing; everything else is flags.
+	// Completion runs here, after registration, so it sees every flag.
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "completion":
+			exit(runCompletionCommand(os.Args[2:], flag.CommandLine, os.Stdout, os.Stderr))
+		case "self-update":
+			warnUpdateConfig(updateCheckWarnings)
+			exit(runSelfUpdateCommand(os.Args[2:], os.Stderr))
+		case "doctor":
+			exit(runDoctorCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "agents":
+			exit(runAgentsCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "repl":
+			exit(runReplCommand(os.Args[2:], os.Stdin, os.Stdout, os.Stderr))
+		case "digest":
+			exit(runDigestCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "lint":
+			exit(runLintCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "hooks":
+			exit(runHooksCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "ci-summary":
+			exit(runCISummaryCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "org-report":
+			exit(runOrgReportCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "new":
+			exit(runNewCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "archive":
+			exit(runArchiveCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "generate":
+			exit(runGenerateCommand(os.Args[2:], os.Stdout, os.Stderr))
+		case "url-handler":
+			exit(runURLHandlerCommand(os.Args[2:], os.Stdout, os.Stderr))
+		}
+	}
+
+	// --errors-json/--quiet filter stderr from here on, so flag errors and
+	// deprecation warnings are covered too.
+	diagJSON, diagQuiet := diagFlagsFromArgs(os.Args[1:])
+	if diagJSON || diagQuiet {
+		if err := startDiagnostics(&diagFilter{json: diagJSON, quiet: diagQuiet}); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: filtering stderr: %v\n", err)
+		}
+		// exit() flushes the filter too; this covers a normal return. The
+		// closure picks up the stopDiagnostics startDiagnostics installed.
+		defer func() { stopDiagnostics() }()
+		// Report flag errors on one line instead of a usage dump
+		flag.CommandLine.SetOutput(io.Discard)
+	}
+
+	// Parse errors exit through exit() rather than the flag package, keeping
+	// its code 2, so profiles and locks are released on the way out.
+	flag.CommandLine.Init(os.Args[0], flag.ContinueOnError)
+	if err := flag.CommandLine.Parse(os.Args[1:]); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			exit(exitOK)
+		}
+		if diagJSON || diagQuiet {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		}
+		exit(exitUsage)
+	}
+
+	compat, err := setupRobotCompat(flag.CommandLine, *compatFlag, os.Stderr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(exitUsage)
+	}
+	robotCompat = compat
+	if *nextClaim && !*robotNext {
+		fmt.Fprintln(os.Stderr, "Error: --claim only works with --robot-next")
+		exit(exitUsage)
+	}
+	if *showNotes && *robotShowID == "" && *robotShowIDs == "" {
+		fmt.Fprintln(os.Stderr, "Error: --with-notes only works with --robot-show or --ids")
+		exit(exitUsage)
+	}
 
 	// Ensure static export flags are retained even when build tags strip features in some environments.
 	_ = exportPages
@@ -227,13 +380,28 @@ func main() {
 		*robotTriageByTrack ||
 		*robotTriageByLabel ||
 		*robotNext ||
+		*robotFrontierFlag ||
+		*robotWorktreesFlag ||
+		*robotOnboard ||
+		*robotListFlag ||
+		*robotShowID != "" ||
+		*robotShowIDs != "" ||
 		*robotDiff ||
 		*robotRecipes ||
 		*robotLabelHealth ||
 		*robotLabelFlow ||
 		*robotLabelAttention ||
+		*robotLabels ||
+		*robotEpics ||
+		*robotStale ||
+		*robotReach != "" ||
+		*robotHealthHistory ||
+		*robotBlockedTime ||
+		*robotOrder ||
 		*robotAlerts ||
 		*robotMetrics ||
+		*robotCapabilitiesFlag ||
+		*robotStatusFlag ||
 		*robotSuggest ||
 		*robotGraph ||
 		*robotSearch ||
@@ -254,6 +422,7 @@ func main() {
 		*robotByLabel != "" ||
 		*robotByAssignee != "" ||
 		*robotCapacity ||
+		*verifyDeterminism ||
 		// When stdout is non-TTY, --diff-since auto-enables JSON output. Mark this
 		// as robot mode early so parsers keep stdout JSON clean.
 		(*diffSince != "" && !stdoutIsTTY)
@@ -264,16 +433,118 @@ func main() {
 		envRobot = true
 	}
 
+	// --goto takes an issue ID or a bv:// link. Links opened from a browser
+	// start bv anywhere, so move to the project holding the issue first.
+	var gotoID string
+	if *gotoFlag != "" {
+		if robotMode {
+			fmt.Fprintln(os.Stderr, "Error: --goto opens the TUI and can't be combined with robot flags")
+			exit(exitUsage)
+		}
+		target, err := deeplink.Parse(*gotoFlag)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --goto: %v\n", err)
+			exit(exitUsage)
+		}
+		gotoID = target.IssueID
+		if *workspaceConfig == "" && *asOf == "" {
+			recentPath, _ := uistate.RecentPath()
+			if project, ok := gotoProject(target, recentPath); ok {
+				if err := openGotoProject(project); err != nil {
+					fmt.Fprintf(os.Stderr, "Error: --goto: opening %s: %v\n", project.Path, err)
+					exit(exitError)
+				}
+			}
+		}
+	}
+	if *exitCodeFlag && !robotMode {
+		fmt.Fprintln(os.Stderr, "Error: --exit-code only works with robot commands")
+		exit(exitUsage)
+	}
+	if (*errorsJSON || *quiet) && !robotMode {
+		fmt.Fprintln(os.Stderr, "Error: --errors-json and --quiet only work with robot commands")
+		exit(exitUsage)
+	}
 	// Handle -r shorthand
 	if *recipeShort != "" && *recipeName == "" {
 		*recipeName = *recipeShort
 	}
 
+	if *errorsJSON && *logFormat == "" {
+		*logFormat = "json" // log records join the JSON lines as they are
+	}
+	if err := configureLogging(*logLevel, *logFormat, *logFile); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+
+	if err := startProfiling(*cpuProfile, *memProfile); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		exit(1)
+	}
+	defer stopProfiling()
+	// Live pprof endpoints only make sense for long-running modes; one-shot
+	// robot commands should use --cpuprofile/--memprofile instead.
+	if *pprofAddr != "" {
+		if robotMode {
+			fmt.Fprintln(os.Stderr, "Warning: --pprof-addr is ignored in robot mode; use --cpuprofile/--memprofile")
+		} else if addr, err := startPprofServer(*pprofAddr); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+		} else {
+			fmt.Fprintf(os.Stderr, "pprof: serving http://%s/debug/pprof/\n", addr)
+		}
+	}
+
+	// Accessibility mode: swap emoji for ASCII tags across all views, also
+	// automatically on terminals that can't draw emoji (legacy Windows
+	// console, Linux virtual console, non-UTF-8 locale).
+	termCaps := ui.ProbeTerminal()
+	ui.SetTerminalCaps(termCaps)
+	ui.SetASCIIIcons(resolveASCIIMode(*asciiMode, termCaps))
+	// Relative or absolute timestamps, and the zone for absolute ones.
+	timeFormat, timeFormatWarnings := resolveTimeFormat()
+	timefmt.Set(timeFormat)
+
+	// `bv print` renders TUI views, so it is dispatched after the icon setup
+	// above for --ascii / BV_ASCII / ui.ascii to apply to it.
+	if flag.NArg() > 0 && flag.Arg(0) == "print" {
+		exit(runPrintCommand(flag.Args()[1:], os.Stdout, os.Stderr))
+	}
+	// Terminal title and OSC 9 notifications for new critical alerts.
+	ui.SetTerminalIntegration(resolveTerminalIntegration())
+	// Vim-style counts, gg and marks in the list, board and tree.
+	ui.SetVimKeys(resolveVimKeys())
+	keyWarnings := setupKeyRemap()
+	warnUpdateConfig(updateCheckWarnings)
+	for _, w := range localeWarnings {
+		fmt.Fprintf(os.Stderr, "Warning: locale: %s\n", w)
+	}
+	for _, w := range timeFormatWarnings {
+		fmt.Fprintf(os.Stderr, "Warning: time format: %s\n", w)
+	}
+	for _, w := range keyWarnings {
+		fmt.Fprintf(os.Stderr, "Warning: keys: %s\n", w)
+	}
+
 	if *help {
 		fmt.Println("Usage: bv [options]")
+		fmt.Println("       bv print <board|tree|graph|insights|history> [--width N] [--color auto|always|never] [--ascii]")
+		fmt.Println("       bv completion <bash|zsh|fish|powershell>")
+		fmt.Println("       bv self-update [--check] [--yes] [--channel stable|beta]")
+		fmt.Println("       bv doctor [--json] [--bundle [--output FILE]]")
+		fmt.Println("       bv agents <status|install|update|remove> [--file FILE] [--dry-run]")
+		fmt.Println("       bv repl [--dir DIR]")
+		fmt.Println("       bv digest [--since 24h] [--format markdown|json] [--dir DIR]")
+		fmt.Println("       bv lint [--fix [--dry-run]] [--format text|json|sarif|junit] [--dir DIR]")
+		fmt.Println("       bv hooks <install|status|remove|run> [--hook NAME] [--enforce CHECKS] [--dry-run] [--dir DIR]")
+		fmt.Println("       bv ci-summary [--base REV] [--format markdown|json|junit] [--output FILE] [--dir DIR]")
+		fmt.Println("       bv org-report --repos FILE [--output FILE] [--html FILE]")
+		fmt.Println("       bv new --title TITLE [--type T] [--priority N] [--dep [TYPE:]ID]... [--label L]... [--print|--bd]")
+		fmt.Println("       bv archive --before YYYY-MM-DD [--dry-run] [--dir DIR]")
+		fmt.Println("       bv generate [--nodes N] [--shape chain|dense|cyclic|layered] [--seed S] [--out DIR]")
+		fmt.Println("       bv url-handler <install|remove>")
 		fmt.Println("\nA TUI viewer for beads issue tracker.")
 		flag.PrintDefaults()
-		os.Exit(0)
+		exit(0)
 	}
 
 	if *robotHelp {
@@ -291,6 +562,9 @@ func main() {
 		fmt.Println("      - items: Actionable issues sorted by priority within each track")
 		fmt.Println("      - unblocks: Issues that become actionable when this item is done")
 		fmt.Println("      - summary: Highlights highest-impact item to work on first")
+		fmt.Println("      - owner: Track owner from .bv/owners.yaml labels, else the most common assignee")
+		fmt.Println("      - wip: With WIP limits (--wip-limit or .bv/owners.yaml), per-owner load, whether")
+		fmt.Println("        the plan is feasible, and the items that overflow")
 		fmt.Println("")
 		fmt.Println("  --robot-insights")
 		fmt.Println("      Outputs a JSON object containing deep graph analysis.")
@@ -316,7 +590,12 @@ func main() {
 		fmt.Println("      Key sections:")
 		fmt.Println("      - meta: Generation timestamp, data stats")
 		fmt.Println("      - quick_ref: At-a-glance summary (open/actionable/blocked counts, top 3 picks)")
-		fmt.Println("      - recommendations: Ranked actionable items with scores and reasoning")
+		fmt.Println("      - recommendations: Ranked actionable items with scores and reasoning; with a CODEOWNERS")
+		fmt.Println("        file, owners of each item's likely files and, when unassigned, a suggested_assignee")
+		fmt.Println("        breakdown.code_age: the part of risk from how long the item's likely files have gone")
+		fmt.Println("        unchanged (git blame); risk_signals.code_age_days has the age. Also in --robot-priority.")
+		fmt.Println("        Items labeled needs-review, in-review, or review get suggested_reviewers[{reviewer,score,")
+		fmt.Println("        load,rationale}]: file owners and recent committers, less those with work in progress.")
 		fmt.Println("      - quick_wins: Low-complexity, high-impact items")
 		fmt.Println("      - blockers_to_clear: Items that unblock the most downstream work")
 		fmt.Println("      - project_health: Counts, graph metrics, overall status")
@@ -326,6 +605,122 @@ func main() {
 		fmt.Println("      Minimal triage: returns only the single top recommendation.")
 		fmt.Println("      Output includes: id, title, score, reasons, claim_command, show_command")
 		fmt.Println("      Use when you just need to know \"what should I work on next?\"")
+		fmt.Println("      --agent-id <name> (or BV_AGENT_ID): returns the agent's own in-progress or claimed issue")
+		fmt.Println("        first (resumed: true), and skips issues assigned to or claimed by other agents.")
+		fmt.Println("      --robot-by-label <label>: only picks issues with that label.")
+		fmt.Println("      --claim: runs the claim_command under the exclusive repository lock, re-reading the beads")
+		fmt.Println("        file first, and records the claim in .bv/sessions.json so other agents skip it before bd")
+		fmt.Println("        flushes. Adds claim{command,executed,output,error}; exits 1 if bd fails.")
+		fmt.Println("")
+		fmt.Println("  --robot-frontier [--agent-id <name>]")
+		fmt.Println("      What can be started right now: open issues (not in_progress) whose blockers are all closed and")
+		fmt.Println("      that no one else holds, by assignee or by a bv claim in .bv/sessions.json. Without an agent ID,")
+		fmt.Println("      any assignment or claim counts as someone else's.")
+		fmt.Println("      Fields: total, groups[{priority,count,issues[{id,title,issue_type,priority,labels,assignee,")
+		fmt.Println("              unblocks}]}] P0 first, most unblocks first within a group; blocked, in_progress, and")
+		fmt.Println("              claimed_by_others count the open issues left out.")
+		fmt.Println("")
+		fmt.Println("  --robot-worktrees")
+		fmt.Println("      Parallel agents in git worktrees: each worktree (path, head, branch, detached, current) with the")
+		fmt.Println("      live bv claims recorded in its .bv/sessions.json (issue_id, title, status, agent, branch,")
+		fmt.Println("      claimed_at, files). conflicts[{files,claims,warning}] pairs claims on different branches whose")
+		fmt.Println("      likely files meet. --robot-next adds branch and the same warnings for its pick; --claim")
+		fmt.Println("      records the branch and worktree with the claim.")
+		fmt.Println("")
+		fmt.Println("  --robot-list")
+		fmt.Println("      Enumerate issues without parsing triage. Filters: --query \"words\" (all must match ID, title,")
+		fmt.Println("      description, notes, or labels), --status open,in_progress, --label <label>, --owner <owner>")
+		fmt.Println("      (CODEOWNERS owner of the issue's likely files, @ optional; field owners shows them).")
+		fmt.Println("      --sort score|priority|updated|created|depth|dependents|dependencies|id (default score; ties by ID;")
+		fmt.Println("      depth is the longest blocking chain below an issue, dependents/dependencies its direct fan-in/out).")
+		fmt.Println("      --fields minimal|default|full or a list such as id,title,blocked_by,description.")
+		fmt.Println("      Pages hold --limit issues (default 50, 0 = all); pass next_cursor back as --cursor with the")
+		fmt.Println("      same filters and sort. Cursors are positions, so pages stay stable as issues change.")
+		fmt.Println("      Fields: filters, sort, fields, total, count, issues[], next_cursor (absent on the last page).")
+		fmt.Println("")
+		fmt.Println("  --robot-show <id>")
+		fmt.Println("      Everything about one issue in a single call.")
+		fmt.Println("      Fields: issue (all bd fields), metrics{score,rank,breakdown,pagerank,betweenness,critical_path,...},")
+		fmt.Println("              blocked_by / blocks{total,issues[{id,title,status,priority,depth}],truncated} (open issues,")
+		fmt.Println("              transitive, nearest first, capped at 20), what_if (null when closed), alerts about the issue,")
+		fmt.Println("              commands[{command,when}] suited to its state. Exits 1 if the issue doesn't exist.")
+		fmt.Println("      code_refs: commits on any branch whose message mentions the issue, branches named after it,")
+		fmt.Println("        last_author, last_touched, and merge_status (merged|partial|unmerged|none) against the")
+		fmt.Println("        mainline (origin's default branch, else main/master). Absent outside a git repository.")
+		fmt.Println("      likely_files[{path,source,commits}]: files the work will likely touch - globs from \"Files:\"")
+		fmt.Println("        lines in the issue (source declared), then files its commits changed (source commits).")
+		fmt.Println("      owners: who owns those files per CODEOWNERS, most files first. Absent without the file.")
+		fmt.Println("      --ids <id,id,...>: batch mode, with or without --robot-show, for a whole plan in one process.")
+		fmt.Println("        Fields: requested, found, results[] in request order; each is {id, ...the fields above} or")
+		fmt.Println("        {id, error} for an unknown ID. Exits 0 even when some IDs are unknown.")
+		fmt.Println("      --with-notes: add local_notes, the user's own notes on the issue from .beads/.bv-notes/")
+		fmt.Println("        (\"\" when none). They are local to the checkout and not part of the beads data.")
+		fmt.Println("")
+		fmt.Println("  bv repl")
+		fmt.Println("      For many queries in a row: loads and analyzes once, then reads one command per stdin line")
+		fmt.Println("      (triage, show ID..., whatif ID, query [label=|status=|sort=|limit=|cursor=|fields=] words,")
+		fmt.Println("      reload, help, quit) and writes one JSON line {command,ok,data_hash,result|error} per command.")
+		fmt.Println("      The beads file is re-read whenever it changes.")
+		fmt.Println("")
+		fmt.Println("  bv digest [--since 24h|7d|<rev>] [--format markdown|json]")
+		fmt.Println("      What changed since a point in git history, as one shareable report: closed, new, reopened")
+		fmt.Println("      and modified issues, new cycles, alerts not raised at the start, and the current top picks")
+		fmt.Println("      (marked new when they weren't top picks then). Markdown by default, for chat; json for tools.")
+		fmt.Println("")
+		fmt.Println("  bv lint [--fix [--dry-run]] [--format text|json|sarif|junit]")
+		fmt.Println("      Finds problems in the beads file that are safe to repair: duplicate dependency entries,")
+		fmt.Println("      self-dependencies, status casing (\"Open \") and missing updated_at. --fix rewrites just the")
+		fmt.Println("      affected lines (atomically, keeping fields bv doesn't know) and prints a unified diff.")
+		fmt.Println("      Exits 1 while problems remain. --format sarif adds dangling dependencies, cycles and alerts")
+		fmt.Println("      (including .bv/rules.yaml alert rules), each at its issue's line, for GitHub code scanning.")
+		fmt.Println("      --format junit reports the same checks as JUnit XML test cases.")
+		fmt.Println("")
+		fmt.Println("  bv hooks install [--hook pre-commit|pre-push] [--enforce lint,cycles,stale-claims] [--dry-run]")
+		fmt.Println("      Installs git hooks that check the beads data: pre-commit runs bv lint; pre-push also reports")
+		fmt.Println("      dependency cycles the pushed commits introduce and in-progress issues untouched for 14 days.")
+		fmt.Println("      .bv/git-hooks.yaml sets each check to enforce (blocks), advisory (warns) or off; --enforce")
+		fmt.Println("      seeds it. Never overwrites a hook bv didn't write. Also: bv hooks status [--json], bv hooks remove.")
+		fmt.Println("")
+		fmt.Println("  bv ci-summary [--base REV] [--format markdown|json|junit] [--output FILE]")
+		fmt.Println("      A CI job summary: policy results, health deltas since the base, new alerts and the current")
+		fmt.Println("      plan. Appends markdown to $GITHUB_STEP_SUMMARY when set (stdout otherwise) and annotates")
+		fmt.Println("      violations on GitHub Actions. The base defaults to the pull/merge request target")
+		fmt.Println("      (GITHUB_BASE_REF, CI_MERGE_REQUEST_DIFF_BASE_SHA), else HEAD~1. .bv/ci.yaml sets each policy")
+		fmt.Println("      (lint, new-cycles, new-alerts, health, blocked-growth) to enforce, advisory or off; an")
+		fmt.Println("      enforced violation exits with failure_exit_code (default 1). --format junit reports each")
+		fmt.Println("      policy as a JUnit XML test case (off policies are skipped).")
+		fmt.Println("")
+		fmt.Println("  bv org-report --repos repos.txt [--output report.json] [--html report.html]")
+		fmt.Println("      Compares projects side by side: open, blocked and actionable counts, dependency cycles,")
+		fmt.Println("      velocity (closed in 7/30 days, 8 weekly buckets), alerts and a healthy/warning/critical grade")
+		fmt.Println("      per repository. repos.txt lists a directory or git URL per line, optionally followed by a")
+		fmt.Println("      name; URLs are shallow-cloned into a temporary directory. JSON goes to stdout or --output;")
+		fmt.Println("      --html also writes a self-contained page. Exits 1 if a repository couldn't be read.")
+		fmt.Println("")
+		fmt.Println("  bv new --title TITLE [--type bug] [--priority 1] [--dep bv-12] [--dep parent-child:bv-3] [--label api]")
+		fmt.Println("      Creates an issue where bd isn't available (e.g. a sandbox): checks the type, priority, ID and")
+		fmt.Println("      every dependency against the loaded issues, then appends one well-formed line to the beads file.")
+		fmt.Println("      IDs are generated bd-style from the project prefix unless --id is given.")
+		fmt.Println("        --print   Print the JSONL line instead of writing it")
+		fmt.Println("        --bd      Validate, then run bd create with the same fields")
+		fmt.Println("")
+		fmt.Println("  bv archive --before YYYY-MM-DD [--dry-run]")
+		fmt.Println("      Moves issues closed before the date out of the beads file into .beads/archive/*.jsonl.gz")
+		fmt.Println("      so large repos load faster. Closed issues a remaining issue depends on stay put.")
+		fmt.Println("      --include-archive loads the archives back in for history-aware analyses")
+		fmt.Println("      (velocity, forecasts, burndown, history), e.g. bv --robot-forecast all --include-archive")
+		fmt.Println("")
+		fmt.Println("  bv generate [--nodes 100] [--shape chain|dense|cyclic|layered] [--seed 42] [--out DIR]")
+		fmt.Println("      Writes a synthetic dataset with realistic titles, labels, estimates and statuses to")
+		fmt.Println("      DIR/.beads/issues.jsonl (or stdout). The same flags always give the same file, so a")
+		fmt.Println("      performance report can name its dataset instead of attaching it.")
+		fmt.Println("")
+		fmt.Println("  --robot-onboard")
+		fmt.Println("      One-shot orientation for a fresh agent session. Run it first.")
+		fmt.Println("      Fields: project{name,issue_prefix,beads_dir,issue/open/actionable/blocked/in_progress/closed counts},")
+		fmt.Println("              top_pick{id,title,score,reasons,claim_command,show_command}, claims[{id,title,assignee,priority,updated_at}],")
+		fmt.Println("              conventions{file,has_bv_blurb,headings,rules} from AGENTS.md (bv's blurb excluded),")
+		fmt.Println("              commands[{command,when}], next_steps")
 		fmt.Println("")
 		fmt.Println("  --search \"query\" [--robot-search]")
 		fmt.Println("      Semantic vector search over issue titles/descriptions.")
@@ -388,7 +783,8 @@ func main() {
 		fmt.Println("      Critical for agents: check before making changes to avoid conflicts.")
 		fmt.Println("      Key sections:")
 		fmt.Println("      - risk_level: low/medium/high/critical based on open beads")
-		fmt.Println("      - affected_beads: Beads touching these files with relevance scores")
+		fmt.Println("      - affected_beads: Beads touching these files with relevance scores; open and in-progress")
+		fmt.Println("        beads whose likely files (see --robot-show) match list the patterns in file_hints")
 		fmt.Println("      - warnings: Actionable warnings about potential conflicts")
 		fmt.Println("      - summary: Human-readable impact summary")
 		fmt.Println("      Input: Comma-separated file paths")
@@ -499,6 +895,20 @@ func main() {
 		fmt.Println("      Generates a readable status report with Mermaid.js visualizations.")
 		fmt.Println("      Runs pre-export and post-export hooks if configured in .bv/hooks.yaml")
 		fmt.Println("")
+		fmt.Println("  --export-ics <file>")
+		fmt.Println("      Writes an iCalendar file of forecast milestones for calendar apps:")
+		fmt.Println("      one all-day event per epic completion and per critical path checkpoint.")
+		fmt.Println("      Dates come from a critical path schedule of per-issue ETAs.")
+		fmt.Println("        --forecast-agents=N   Parallel agents assumed for each ETA (default: 1)")
+		fmt.Println("      Example: bv --export-ics plan.ics --forecast-agents=2")
+		fmt.Println("")
+		fmt.Println("  --redact")
+		fmt.Println("      Replaces titles, descriptions, notes, comments and people's names with stable hashes in every")
+		fmt.Println("      output and export, keeping IDs, labels, dates and dependencies, so datasets can be shared in bug")
+		fmt.Println("      reports. Salt the hashes with BV_REDACT_SALT. In robot output, git commit authors, messages and")
+		fmt.Println("      file paths become hashes too.")
+		fmt.Println("      Example: bv --robot-triage --redact")
+		fmt.Println("")
 		fmt.Println("  --no-hooks")
 		fmt.Println("      Skip running hooks during export. Useful for CI or quick exports.")
 		fmt.Println("")
@@ -537,6 +947,41 @@ func main() {
 		fmt.Println("      Output: {recipes: [{name, description, source}]}")
 		fmt.Println("      Sources: 'builtin', 'user' (~/.config/bv/recipes.yaml), 'project' (.bv/recipes.yaml)")
 		fmt.Println("")
+		fmt.Println("  --robot-capabilities")
+		fmt.Println("      Version handshake: what this bv binary supports, without loading any issues.")
+		fmt.Println("      Fields: schema_version, version, robot_commands[{flag,takes_value,description}], robot_filters,")
+		fmt.Println("              schema_versions{robot,capabilities,sqlite_export,agent_blurb}, features{name: bool},")
+		fmt.Println("              insights_variants[{name,flags}], deprecations[{flag,replacement,since,removal_in,note}],")
+		fmt.Println("              exit_codes[{code,name,meaning,opt_in}].")
+		fmt.Println("      A feature missing from features is unsupported; schema_versions.robot changes only on breaking edits.")
+		fmt.Println("")
+		fmt.Println("  --compat <schema>")
+		fmt.Println("      Pin robot output to a robot schema version (schema_versions.robot from --robot-capabilities).")
+		fmt.Println("      Fields renamed after that schema are also written under their old names. Exits 2 if this")
+		fmt.Println("      binary can't speak the schema. Pinned runs, and runs using a deprecated flag, get a top-level")
+		fmt.Println("      meta{robot_schema_version,compat,deprecations[{flag|field,replacement,since,removed_in_schema,note}]}.")
+		fmt.Println("      Deprecated flags still work and warn on stderr.")
+		fmt.Println("")
+		fmt.Println("  Exit codes (exit_codes in --robot-capabilities)")
+		fmt.Println("      0 ok, 1 other error, 2 no beads data file or invalid flags, 6 repository lock held by")
+		fmt.Println("      another bv. With --exit-code, a run that would exit 0 exits 3 if data file lines were")
+		fmt.Println("      skipped as malformed, else 4 if the dependency graph has cycles, else 5 if no open issue is")
+		fmt.Println("      free of blockers. Output is written as usual either way.")
+		fmt.Println("")
+		fmt.Println("  --errors-json / --quiet")
+		fmt.Println("      stdout carries only the JSON payload; diagnostics go to stderr. --errors-json writes every")
+		fmt.Println("      stderr line as {time,level,message} (level: error|warning|info), including data file parse")
+		fmt.Println("      warnings and config notices, and switches logs to JSON. --quiet keeps only errors.")
+		fmt.Println("")
+		fmt.Println("  --robot-status")
+		fmt.Println("      Health check before heavier queries: instances, data file, and analysis cache as JSON.")
+		fmt.Println("      Fields: healthy, lock{state,info}, instance_count, instances[{pid,mode,primary,started_at,heartbeat}],")
+		fmt.Println("              data{path,size_bytes,modified_at,issue_count,data_hash,error},")
+		fmt.Println("              cache{fresh,entries,cached_at,age_seconds,expires_in_seconds,last_signal},")
+		fmt.Println("              analysis{config_hash,phase1,phase2,skipped_metrics}.")
+		fmt.Println("      phase2 is 'cached' when robot commands can answer from the disk cache, 'cold' otherwise.")
+		fmt.Println("      Modes: tui, serve, robot. Entries with a dead PID or stale heartbeat are omitted.")
+		fmt.Println("")
 		fmt.Println("  --robot-label-health")
 		fmt.Println("      Outputs label health metrics as JSON (velocity, freshness, flow, criticality).")
 		fmt.Println("      Includes label summaries, detailed metrics, and cross-label dependencies.")
@@ -554,12 +999,69 @@ func main() {
 		fmt.Println("      Key fields: rank, label, attention_score, normalized_score, reason, blocked_count, stale_count.")
 		fmt.Println("      Use to identify which labels need the most focus based on centrality and health factors.")
 		fmt.Println("")
+		fmt.Println("  --robot-labels")
+		fmt.Println("      Label taxonomy analytics for keeping large beads files tidy.")
+		fmt.Println("      Fields: total_labels, labeled_issues, unlabeled_issues, singletons, usage[{label,count,open,")
+		fmt.Println("              closed,share}], pairs[{a,b,count,jaccard}], clusters[{labels,issues}],")
+		fmt.Println("              median_cycle_days, slow_labels[{label,samples,median_days,ratio}],")
+		fmt.Println("              variants[{canonical,variants,reason,issues}].")
+		fmt.Println("      clusters join labels whose pairs overlap 30%+; slow_labels have 3+ closed issues with a")
+		fmt.Println("      median cycle time 1.5x the overall one; variants are spelling (case, separators, plurals)")
+		fmt.Println("      or similar (one edit apart) matches, with the most used label as canonical.")
+		fmt.Println("")
+		fmt.Println("  --robot-epics")
+		fmt.Println("      Per-epic progress for PM-level reporting. Epics are issues of type epic or with parent-child")
+		fmt.Println("      children; counts cover all descendants. Worst health first.")
+		fmt.Println("      Fields: summary{epics,green,yellow,red}, epics[{id,title,status,total,open,in_progress,blocked,")
+		fmt.Println("              closed,percent_complete,critical_path_length,critical_path,last_activity,stale_days,")
+		fmt.Println("              health,reasons}]. health is green, yellow, or red: red at 30+ days without activity or")
+		fmt.Println("              half the open work blocked; yellow at 14+ days, any blocked work, or a critical path of 5+.")
+		fmt.Println("")
+		fmt.Println("  --robot-stale [--stale-days=N]")
+		fmt.Println("      Stale-issue sweep: unfinished issues with no update in N days (default 30), grouped by type,")
+		fmt.Println("      largest group first, longest idle first within a group.")
+		fmt.Println("      Fields: threshold_days, total, groups[{issue_type,count,issues[{id,title,status,priority,")
+		fmt.Println("              assignee,last_activity,idle_days,suggested_action,commands}]}].")
+		fmt.Println("      suggested_action is ping (assigned: ask the owner via the notify hooks in .bv/hooks.yaml),")
+		fmt.Println("      close (unassigned and P3+, or idle twice the threshold), or deprioritize. commands holds the")
+		fmt.Println("      bd command for close and deprioritize. The TUI's Z key runs the same sweep with batch actions.")
+		fmt.Println("")
+		fmt.Println("  --robot-reach <id> [--direction=down|up] [--depth=N]")
+		fmt.Println("      Transitive impact set along blocking dependencies: down lists everything the issue blocks,")
+		fmt.Println("      directly or not; up lists everything it waits on. --depth limits the walk (default: no limit).")
+		fmt.Println("      Fields: issue_id, direction, max_depth, count, open_count, by_depth[], truncated,")
+		fmt.Println("              issues[{id,title,status,priority,depth,via}], nearest first.")
+		fmt.Println("      Example: bv --robot-reach bv-123 --direction down --depth 3")
+		fmt.Println("")
+		fmt.Println("  --robot-health-history [--health-revisions=N]")
+		fmt.Println("      Composite graph health score (0-100) for the last N git revisions of the beads file")
+		fmt.Println("      (default 20), oldest first, from cycle count, orphan ratio, average depth and density.")
+		fmt.Println("      Fields: current{score,issues,edges,density,edges_per_issue,avg_depth,cycle_count,orphan_ratio},")
+		fmt.Println("              points[{revision,timestamp,message,...same fields}], score_change, skipped.")
+		fmt.Println("      Example: bv --robot-health-history | jq '.points[] | [.timestamp, .score]'")
+		fmt.Println("")
+		fmt.Println("  --robot-blocked-time [--health-revisions=N]")
+		fmt.Println("      Days each issue spent blocked (status blocked, or waiting on an open blocker) over the last")
+		fmt.Println("      N git revisions of the beads file (default 20), worst first, totaled by label and by epic.")
+		fmt.Println("      Fields: since, revisions, skipped, total_blocked_days,")
+		fmt.Println("              issues[{id,title,status,blocked_days,currently_blocked,labels,epic}],")
+		fmt.Println("              by_label[{key,blocked_days,issues}], by_epic[{key,title,blocked_days,issues}].")
+		fmt.Println("      Example: bv --robot-blocked-time --health-revisions 100 | jq '.by_epic[:5]'")
+		fmt.Println("")
+		fmt.Println("  --robot-order")
+		fmt.Println("      Deterministic topological order of open issues. Wave 1 has no open blockers; each later")
+		fmt.Println("      wave waits only on earlier ones. Ties are broken by impact score, then priority, then ID.")
+		fmt.Println("      Members of a dependency cycle share a wave and carry the cycle's ID.")
+		fmt.Println("      Fields: count, wave_count, cycle_count, waves[{wave,ids}],")
+		fmt.Println("              order[{position,wave,id,title,status,priority,score,cycle}].")
+		fmt.Println("      Example: bv --robot-order | jq -r '.order[].id'  # single-agent work queue")
+		fmt.Println("")
 		fmt.Println("  --robot-alerts")
 		fmt.Println("      Outputs drift + proactive alerts as JSON (staleness, cascades, density, cycles).")
 		fmt.Println("      Filters: --severity=<info|warning|critical>, --alert-type=<type>, --alert-label=<label>")
 		fmt.Println("      Fields: type, severity, message, issue_id, label, detected_at, details[].")
 		fmt.Println("")
-		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid] [--graph-root=ID] [--graph-depth=N]")
+		fmt.Println("  --robot-graph [--graph-format=json|dot|mermaid] [--graph-root=ID] [--graph-depth=N] [--graph-condense]")
 		fmt.Println("      Outputs dependency graph in specified format (default: JSON adjacency).")
 		fmt.Println("      Formats:")
 		fmt.Println("        - json: Adjacency list with nodes[], edges[], metadata")
@@ -569,6 +1071,8 @@ func main() {
 		fmt.Println("        --label LABEL: Filter to issues with specific label")
 		fmt.Println("        --graph-root ID: Extract subgraph starting from root issue")
 		fmt.Println("        --graph-depth N: Limit subgraph depth (0 = unlimited)")
+		fmt.Println("        --graph-condense: Collapse each cycle (strongly connected component) into one node;")
+		fmt.Println("          json gets condensation{nodes[{id,members,cyclic}],edges[{from,to,weight}]}, dot a DAG")
 		fmt.Println("      Fields: format, graph (string for dot/mermaid), nodes, edges, filters_applied, explanation")
 		fmt.Println("      Example: bv --robot-graph --graph-format=dot --label=api > api-deps.dot")
 		fmt.Println("")
@@ -616,10 +1120,20 @@ func main() {
 		fmt.Println("        Per-feature: status (available|pending|skipped|error), items, usage hints")
 		fmt.Println("        Config: caps for deterministic output (topk<=5, paths<=5, path_len<=50, etc.)")
 		fmt.Println("        Quick jq: jq '.advanced_insights.cycle_break'   # cycle break suggestions")
+		fmt.Println("      workload: per-assignee balance when issues have assignees. assignees[{assignee,open,in_progress,")
+		fmt.Println("        blocked,blocked_ratio,estimated_minutes,unestimated,load_factor,status}] heaviest first;")
+		fmt.Println("        status is overloaded (>1.5x the mean effort), starved (<0.5x), or balanced.")
+		fmt.Println("        suggested_reassignments[{issue_id,title,from,to,estimated_minutes,reason}] move unstarted,")
+		fmt.Println("        unblocked work, least important first. Unestimated issues count at estimate_fill_minutes.")
+		fmt.Println("      plugins: with --plugins, one entry per bv-analyzer-* executable on PATH {name,path,ok,error,")
+		fmt.Println("        duration_ms,output{scores,findings[{issue_id,severity,message}],data}}. Absent without")
+		fmt.Println("        --plugins or when none are installed; BV_PLUGIN_TIMEOUT_S bounds each run (default 30).")
 		fmt.Println("")
 		fmt.Println("  --robot-plan")
 		fmt.Println("      Execution tracks grouped for parallel work. Includes data_hash, analysis_config, status.")
 		fmt.Println("      plan.tracks[].items[].unblocks shows what completes next; summary.highest_impact surfaces best unblocker.")
+		fmt.Println("      plan.tracks[].owner comes from .bv/owners.yaml labels or assignees; --wip-limit=N (or")
+		fmt.Println("      default_wip_limit/wip_limit in owners.yaml) adds plan.wip{feasible,owners[],overflow[]}.")
 		fmt.Println("")
 		fmt.Println("  --robot-priority")
 		fmt.Println("      Priority recommendations with explanations. Includes data_hash, analysis_config, status.")
@@ -640,6 +1154,15 @@ func main() {
 		fmt.Println("      Includes label_scope and label_context in output with health metrics.")
 		fmt.Println("      Example: bv --robot-insights --label api")
 		fmt.Println("")
+		fmt.Println("  PageRank Focus:")
+		fmt.Println("      --focus SPEC                  Personalize PageRank toward a seed set")
+		fmt.Println("      SPEC is comma-separated issue IDs (an epic brings its open descendants),")
+		fmt.Println("      priorities (P0-P4, open issues) or labels. Centrality then reflects what the")
+		fmt.Println("      seeds depend on. Affects: --robot-insights, --robot-plan, --robot-priority,")
+		fmt.Println("      --robot-triage and --robot-next; recorded as pagerank_focus {spec, seeds}")
+		fmt.Println("      and analysis_config.PageRankFocus.")
+		fmt.Println("      Example: bv --robot-triage --focus P0")
+		fmt.Println("")
 		fmt.Println("  --robot-triage / --robot-next")
 		fmt.Println("      Unified triage (mega command) or single top pick. QuickRef includes top picks, quick_wins, blockers_to_clear.")
 		fmt.Println("")
@@ -655,6 +1178,19 @@ func main() {
 		fmt.Println("      Provides recommendations based on timing analysis.")
 		fmt.Println("      Use with --profile-json for machine-readable output.")
 		fmt.Println("")
+		fmt.Println("  --verify-determinism [--determinism-runs N]")
+		fmt.Println("      Runs insights, plan, triage, and raw metrics N times (default 5),")
+		fmt.Println("      alternating GOMAXPROCS, and compares hashes of the JSON output.")
+		fmt.Println("      Prints the first mismatching field and exits 1 on divergence.")
+		fmt.Println("")
+		fmt.Println("  --cpuprofile FILE, --memprofile FILE")
+		fmt.Println("      Write CPU / heap profiles for go tool pprof (works with any mode).")
+		fmt.Println("      Example: bv --cpuprofile cpu.out --robot-triage")
+		fmt.Println("")
+		fmt.Println("  --pprof-addr ADDR")
+		fmt.Println("      Serve net/http/pprof while the TUI, --preview-pages, or --watch-export runs.")
+		fmt.Println("      A bare port binds to localhost. Example: bv --pprof-addr :6060")
+		fmt.Println("")
 		fmt.Println("  --workspace CONFIG")
 		fmt.Println("      Load issues from workspace configuration file.")
 		fmt.Println("      Path: typically .bv/workspace.yaml")
@@ -717,82 +1253,37 @@ func main() {
 		fmt.Println("      - density_warning_pct: 50    # Warn if density +50%")
 		fmt.Println("      - blocked_increase_threshold: 5   # Warn if 5+ more blocked")
 		fmt.Println("      Run 'bv --baseline-info' to see current baseline state.")
-		os.Exit(0)
+		fmt.Println("")
+		fmt.Println("  Custom Rules (.bv/rules.yaml)")
+		fmt.Println("      score: [{name, when, adjust}]  adds adjust to matching open issues' impact scores;")
+		fmt.Println("             matches are listed in breakdown.rules and summed in breakdown.rule_adjustment.")
+		fmt.Println("      alerts: [{name, when, severity, message}]  raises type=rule alerts in --robot-alerts.")
+		fmt.Println("      when: '\"security\" in labels && priority <= 1'  (see README: Custom Rules)")
+		exit(0)
 	}
 
 	if *versionFlag {
 		fmt.Printf("bv %s\n", version.Version)
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --check-update (bv-182)
 	if *checkUpdateFlag {
-		available, newVersion, releaseURL, err := updater.CheckUpdateAvailable()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
-			os.Exit(1)
-		}
-		if available {
-			fmt.Printf("New version available: %s (current: %s)\n", newVersion, version.Version)
-			fmt.Printf("Download: %s\n", releaseURL)
-			fmt.Println("\nRun 'bv --update' to update automatically")
-		} else {
-			fmt.Printf("bv is up to date (version %s)\n", version.Version)
-		}
-		os.Exit(0)
+		exit(runCheckUpdate())
 	}
 
 	// Handle --update (bv-182)
 	if *updateFlag {
-		release, err := updater.GetLatestRelease()
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error fetching release info: %v\n", err)
-			os.Exit(1)
-		}
-
-		// Check if update is needed
-		available, newVersion, _, _ := updater.CheckUpdateAvailable()
-		if !available {
-			fmt.Printf("bv is already up to date (version %s)\n", version.Version)
-			os.Exit(0)
-		}
-
-		// Confirm unless --yes is provided
-		if !*yesFlag {
-			fmt.Printf("Update bv from %s to %s? [Y/n]: ", version.Version, newVersion)
-			var response string
-			fmt.Scanln(&response)
-			response = strings.ToLower(strings.TrimSpace(response))
-			if response != "" && response != "y" && response != "yes" {
-				fmt.Println("Update cancelled")
-				os.Exit(0)
-			}
-		}
-
-		result, err := updater.PerformUpdate(release, *yesFlag)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Update failed: %v\n", err)
-			if result != nil && result.BackupPath != "" {
-				fmt.Fprintf(os.Stderr, "Backup preserved at: %s\n", result.BackupPath)
-			}
-			os.Exit(1)
-		}
-
-		fmt.Println(result.Message)
-		if result.BackupPath != "" {
-			fmt.Printf("Backup saved to: %s\n", result.BackupPath)
-			fmt.Println("Run 'bv --rollback' to restore if needed")
-		}
-		os.Exit(0)
+		exit(runSelfUpdate(*yesFlag))
 	}
 
 	// Handle --rollback (bv-182)
 	if *rollbackFlag {
 		if err := updater.Rollback(); err != nil {
 			fmt.Fprintf(os.Stderr, "Rollback failed: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle feedback commands (bv-90)
@@ -800,30 +1291,34 @@ func main() {
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
+		}
+
+		if !*feedbackShow {
+			mustLockRepo(instance.AccessExclusive, commandPurpose())
 		}
 
 		feedback, err := analysis.LoadFeedback(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading feedback: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if *feedbackReset {
 			feedback.Reset()
 			if err := feedback.Save(beadsDir); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			fmt.Println("Feedback data reset to defaults.")
-			os.Exit(0)
+			exit(0)
 		}
 
 		if *feedbackShow {
 			feedbackJSON := feedback.ToJSON()
 			data, _ := json.MarshalIndent(feedbackJSON, "", "  ")
 			fmt.Println(string(data))
-			os.Exit(0)
+			exit(0)
 		}
 
 		// For accept/ignore, we need to get the issue's score breakdown
@@ -839,7 +1334,7 @@ func main() {
 			issues, err := loader.LoadIssues("")
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error loading issues: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			// Find the issue
@@ -853,7 +1348,7 @@ func main() {
 
 			if foundIssue == nil {
 				fmt.Fprintf(os.Stderr, "Issue not found: %s\n", issueID)
-				os.Exit(1)
+				exit(1)
 			}
 
 			// Compute impact score for the issue to get breakdown
@@ -872,17 +1367,17 @@ func main() {
 
 			if err := feedback.RecordFeedback(issueID, action, score, breakdown); err != nil {
 				fmt.Fprintf(os.Stderr, "Error recording feedback: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			if err := feedback.Save(beadsDir); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			fmt.Printf("Recorded %s feedback for %s (score: %.3f)\n", action, issueID, score)
 			fmt.Println(feedback.Summary())
-			os.Exit(0)
+			exit(0)
 		}
 	}
 
@@ -913,29 +1408,67 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding recipes: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
+	}
+
+	// Handle --robot-capabilities (static; needs no beads directory)
+	if *robotCapabilitiesFlag {
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(buildRobotCapabilities(flag.CommandLine)); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding capabilities: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	// Handle --robot-status (inspects state only; never locks, registers, or analyzes)
+	if *robotStatusFlag {
+		beadsDir, err := loader.GetBeadsDir("")
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error locating beads directory: %v\n", err)
+			exit(1)
+		}
+		status, err := buildRobotStatus(beadsDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading instance registry: %v\n", err)
+			exit(1)
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(status); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding status: %v\n", err)
+			exit(1)
+		}
+		exit(0)
 	}
 
 	// Get project directory for baseline operations (moved up to allow info check without loading issues)
 	projectDir, _ := os.Getwd()
 	baselinePath := baseline.DefaultPath(projectDir)
 
+	// Team score rules (.bv/rules.yaml) adjust every impact score. A broken
+	// rules file shouldn't lock anyone out of bv, so it only warns.
+	ruleSet, err := rules.Load(projectDir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; continuing without rules\n", err)
+		ruleSet = nil
+	}
+
 	// Handle --baseline-info
 	if *baselineInfo {
 		if !baseline.Exists(baselinePath) {
 			fmt.Println("No baseline found.")
 			fmt.Println("Create one with: bv --save-baseline \"description\"")
-			os.Exit(0)
+			exit(0)
 		}
 		bl, err := baseline.Load(baselinePath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Print(bl.Summary())
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Validate recipe name if provided (before loading issues)
@@ -949,7 +1482,7 @@ func main() {
 				r := recipeLoader.Get(name)
 				fmt.Fprintf(os.Stderr, "  %-15s %s\n", name, r.Description)
 			}
-			os.Exit(1)
+			exit(1)
 		}
 	}
 
@@ -959,6 +1492,7 @@ func main() {
 	var beadsPath string
 	var workspaceInfo *workspace.LoadSummary
 	var asOfResolved string // Resolved commit SHA when using --as-of (for robot output metadata)
+	parseErrors := 0        // data file lines skipped as malformed (single-repo loads only)
 
 	if *asOf != "" {
 		// Time-travel mode: load historical issues from git
@@ -969,13 +1503,13 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		gitLoader := loader.NewGitLoader(cwd)
 		issues, err = gitLoader.LoadAt(*asOf)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *asOf, err)
-			os.Exit(1)
+			exit(1)
 		}
 		// Resolve to commit SHA for metadata
 		asOfResolved, _ = gitLoader.ResolveRevision(*asOf)
@@ -993,7 +1527,7 @@ func main() {
 		loadedIssues, results, err := workspace.LoadAllFromConfig(context.Background(), *workspaceConfig)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading workspace: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		issues = loadedIssues
 		summary := workspace.Summarize(results)
@@ -1016,17 +1550,48 @@ func main() {
 		workspaceRoot := filepath.Dir(filepath.Dir(*workspaceConfig))
 		_ = loader.EnsureBVInGitignore(workspaceRoot)
 	} else {
-		// Load from single repo (original behavior)
+		// Load from single repo (original behavior), counting skipped lines
+		// for --exit-code
+		parseOpts := loader.ParseOptions{WarningHandler: func(msg string) {
+			parseErrors++
+			if !envRobot || *errorsJSON {
+				fmt.Fprintf(os.Stderr, "Warning: %s\n", msg)
+			}
+		}}
 		var err error
-		issues, err = loader.LoadIssues("")
+		issues, err = loader.LoadIssuesWithOptions("", parseOpts)
+		if err != nil && shouldOfferOnboarding(err, robotMode) {
+			// First run: offer to locate or initialize beads data instead of bailing out.
+			if dir, ok := runOnboarding(); ok {
+				_ = os.Setenv(loader.BeadsDirEnvVar, dir)
+				issues, err = loader.LoadIssuesWithOptions("", parseOpts)
+			} else {
+				exit(0)
+			}
+		}
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
 			fmt.Fprintln(os.Stderr, "Make sure you are in a project initialized with 'bd init'.")
-			os.Exit(1)
+			if errors.Is(err, loader.ErrNoData) {
+				exit(exitNoData)
+			}
+			exit(exitError)
 		}
 		// Get beads file path for live reload (respects BEADS_DIR env var)
 		beadsDir, _ := loader.GetBeadsDir("")
 		beadsPath, _ = loader.FindJSONLPath(beadsDir)
+		if *includeArchive {
+			archived, err := loader.LoadArchivedIssues(beadsDir, parseOpts)
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error loading archives: %v\n", err)
+				exit(exitError)
+			}
+			issues = loader.MergeArchived(issues, archived)
+		}
+		if robotMode {
+			mustLockRepo(instance.AccessShared, commandPurpose())
+			signalCacheUpdates(beadsDir, beadsPath)
+		}
 
 		// Automatically ensure .bv/ is in .gitignore to prevent polluting git
 		// with search indexes, baselines, and other bv-specific files.
@@ -1036,10 +1601,31 @@ func main() {
 	}
 	loadDuration := time.Since(loadStart)
 
+	// --redact rewrites the issues once, so every output and export below
+	// sees only hashes. Live reload would bring the originals back.
+	redactor := model.Redactor{Salt: os.Getenv("BV_REDACT_SALT")}
+	if *redact {
+		if robotMode {
+			// Git-derived data doesn't go through the issues; scrub it from
+			// whatever robot output prints it
+			if err := startRedactingStdout(newOutputScrubber(redactor, issues, projectDir)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --redact: %v\n", err)
+				exit(exitError)
+			}
+			defer stopRedactingStdout()
+		}
+		issues = redactor.Issues(issues)
+		beadsPath = ""
+	}
+
 	// Apply --repo filter if specified
 	if *repoFilter != "" {
 		issues = filterByRepo(issues, *repoFilter)
 	}
+	if *exitCodeFlag {
+		conditionIssues := issues
+		exitCondition = func() int { return conditionExitCode(conditionIssues, parseErrors) }
+	}
 
 	issuesForSearch := issues
 
@@ -1077,40 +1663,58 @@ func main() {
 		}
 	}
 
+	// PageRank personalization: resolve --focus against the (scoped) issues
+	var pageRankFocus *analysis.PageRankFocus
+	if *focusSpec != "" {
+		focus, err := analysis.ResolvePageRankFocus(issues, *focusSpec)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --focus: %v\n", err)
+			exit(1)
+		}
+		pageRankFocus = &focus
+	}
+	// withFocus applies --focus to an analysis configuration.
+	withFocus := func(cfg analysis.AnalysisConfig) analysis.AnalysisConfig {
+		if pageRankFocus != nil {
+			cfg.PageRankFocus = pageRankFocus.Seeds
+		}
+		return cfg
+	}
+
 	// Handle semantic search CLI (bv-9gf.3)
 	if *robotSearch && *semanticQuery == "" {
 		fmt.Fprintln(os.Stderr, "Error: --robot-search requires --search \"query\"")
-		os.Exit(1)
+		exit(1)
 	}
 	if *semanticQuery != "" {
 		embedCfg := search.EmbeddingConfigFromEnv()
 		searchCfg, err := search.SearchConfigFromEnv()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		searchCfg, err = applySearchConfigOverrides(searchCfg, *searchMode, *searchPreset, *searchWeights)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		embedder, err := search.NewEmbedderFromConfig(embedCfg)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		projectDir, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		indexPath := search.DefaultIndexPath(projectDir, embedCfg)
 		idx, loaded, err := search.LoadOrNewVectorIndex(indexPath, embedder.Dim())
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		docs := search.DocumentsFromIssues(issuesForSearch)
@@ -1124,12 +1728,12 @@ func main() {
 		syncStats, err := search.SyncVectorIndex(ctx, idx, embedder, docs, 64)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error building semantic index: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		if !loaded || syncStats.Changed() {
 			if err := idx.Save(indexPath); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving semantic index: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
@@ -1139,7 +1743,7 @@ func main() {
 				err = fmt.Errorf("embedder returned %d vectors for query", len(qvecs))
 			}
 			fmt.Fprintf(os.Stderr, "Error embedding query: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		limit := *searchLimit
@@ -1153,7 +1757,7 @@ func main() {
 		results, err := idx.SearchTopK(qvecs[0], fetchLimit)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error searching index: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		results = search.ApplyShortQueryLexicalBoost(results, *semanticQuery, docs)
 		if isLikelyIssueID(*semanticQuery) {
@@ -1172,7 +1776,7 @@ func main() {
 			weights, presetName, err := resolveSearchWeights(searchCfg)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			weights = weights.Normalize()
 			weights = search.AdjustWeightsForQuery(weights, *semanticQuery)
@@ -1182,14 +1786,14 @@ func main() {
 			cache := search.NewMetricsCache(search.NewAnalyzerMetricsLoader(issuesForSearch))
 			if err := cache.Refresh(); err != nil {
 				fmt.Fprintf(os.Stderr, "Error computing hybrid metrics: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			scorer := search.NewHybridScorer(weights, cache)
 			hybridResults, err = buildHybridScores(results, scorer)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error scoring hybrid results: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			if isLikelyIssueID(*semanticQuery) {
 				hybridResults = promoteExactHybridResult(*semanticQuery, hybridResults)
@@ -1249,9 +1853,9 @@ func main() {
 
 			if err := writeRobotSearchOutput(os.Stdout, out); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding robot-search: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 
 		// Human-readable output
@@ -1267,25 +1871,26 @@ func main() {
 				fmt.Printf("%.4f\t%s\t%s\n", r.Score, r.IssueID, titleByID[r.IssueID])
 			}
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --pages wizard (bv-10g)
 	if *pagesWizard {
-		if err := runPagesWizard(issues, beadsPath); err != nil {
+		if err := runPagesWizard(issues, beadsPath, ruleSet); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --preview-pages (before export since it doesn't need analysis)
 	if *previewPages != "" {
+		registerInstance(instance.ModeServe)
 		if err := runPreviewServer(*previewPages); err != nil {
 			fmt.Fprintf(os.Stderr, "Error starting preview server: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --export-pages (bv-73f) with optional --watch-export (bv-55)
@@ -1348,7 +1953,7 @@ func main() {
 
 			// Compute triage
 			fmt.Println("  → Generating triage data...")
-			triage := analysis.ComputeTriage(exportIssues)
+			triage := analysis.ComputeTriageWithOptions(exportIssues, analysis.TriageOptions{ScoreRules: ruleSet})
 
 			// Extract dependencies
 			var deps []*model.Dependency
@@ -1395,7 +2000,7 @@ func main() {
 			}
 
 			// Export history data for time-travel feature (bv-z38b)
-			if *pagesIncludeHistory {
+			if *pagesIncludeHistory && !*redact {
 				fmt.Println("  → Generating time-travel history data...")
 				if historyReport, err := generateHistoryForExport(allIssues); err == nil && historyReport != nil {
 					historyPath := filepath.Join(*exportPages, "data", "history.json")
@@ -1431,11 +2036,12 @@ func main() {
 		// Initial export
 		if err := doExport(issues); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Watch mode (bv-55): monitor .beads/ for changes and auto-regenerate
 		if *watchExport {
+			registerInstance(instance.ModeServe)
 			cwd, _ := os.Getwd()
 			issuesFile := filepath.Join(cwd, ".beads", "issues.jsonl")
 
@@ -1456,12 +2062,12 @@ func main() {
 			)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error creating watcher: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			if err := w.Start(); err != nil {
 				fmt.Fprintf(os.Stderr, "Error starting watcher: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			defer w.Stop()
 
@@ -1480,12 +2086,15 @@ func main() {
 						fmt.Printf("  → Error reloading issues: %v\n", err)
 						continue
 					}
+					if *redact {
+						freshIssues = redactor.Issues(freshIssues)
+					}
 					if err := doExport(freshIssues); err != nil {
 						fmt.Printf("  → Export error: %v\n", err)
 					}
 				case <-sigCh:
 					fmt.Println("\nStopping watch mode...")
-					os.Exit(0)
+					exit(0)
 				}
 			}
 		}
@@ -1498,7 +2107,7 @@ func main() {
 		fmt.Println("")
 		fmt.Println("Or open in browser:")
 		fmt.Printf("  open %s/index.html\n", *exportPages)
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-label-health
@@ -1527,9 +2136,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding label health: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-label-flow (can be used stand-alone to avoid full health computation)
@@ -1556,9 +2165,268 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding label flow: %v\n", err)
-			os.Exit(1)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotEpics {
+		epics := analysis.ComputeEpicHealth(issues, time.Now().UTC())
+		output := struct {
+			GeneratedAt string                `json:"generated_at"`
+			DataHash    string                `json:"data_hash"`
+			AsOf        string                `json:"as_of,omitempty"`
+			AsOfCommit  string                `json:"as_of_commit,omitempty"`
+			Summary     map[string]int        `json:"summary"`
+			Epics       []analysis.EpicHealth `json:"epics"`
+			UsageHints  []string              `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			Summary: map[string]int{
+				"epics":                   len(epics),
+				analysis.EpicHealthGreen:  0,
+				analysis.EpicHealthYellow: 0,
+				analysis.EpicHealthRed:    0,
+			},
+			Epics: epics,
+			UsageHints: []string{
+				"jq '.epics[] | select(.health == \"red\") | {id, title, reasons}' - Epics in trouble",
+				"jq '.epics[] | {id, percent_complete, open}' - Progress at a glance",
+				"jq '.epics[] | select(.critical_path_length > 3) | .critical_path' - Long blocking chains",
+			},
+		}
+		if output.Epics == nil {
+			output.Epics = []analysis.EpicHealth{}
+		}
+		for _, e := range epics {
+			output.Summary[e.Health]++
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-epics: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotLabels {
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			AsOf        string `json:"as_of,omitempty"`
+			AsOfCommit  string `json:"as_of_commit,omitempty"`
+			analysis.LabelTaxonomy
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+			DataHash:      dataHash,
+			AsOf:          *asOf,
+			AsOfCommit:    asOfResolved,
+			LabelTaxonomy: analysis.ComputeLabelTaxonomy(issues),
+			UsageHints: []string{
+				"jq '.variants[] | {canonical, variants}' - Probable typo variants to merge",
+				"jq '.slow_labels[] | {label, median_days, ratio}' - Labels whose issues take longest to close",
+				"jq '.clusters[].labels' - Labels that usually travel together",
+				"jq '.singletons' - Labels used only once",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-labels: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotStale {
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			AsOf        string `json:"as_of,omitempty"`
+			AsOfCommit  string `json:"as_of_commit,omitempty"`
+			analysis.StaleSweep
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			StaleSweep:  analysis.ComputeStaleSweep(issues, time.Now().UTC(), *staleDays),
+			UsageHints: []string{
+				"jq '.groups[] | {issue_type, count}' - Stale issues per type",
+				"jq -r '.groups[].issues[] | select(.suggested_action == \"close\") | .commands.close' - Close commands to review and run",
+				"jq '.groups[].issues[] | select(.suggested_action == \"ping\") | {id, assignee, idle_days}' - Owners to ask",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-stale: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotReach != "" {
+		if *reachDirection != analysis.ReachUp && *reachDirection != analysis.ReachDown {
+			fmt.Fprintf(os.Stderr, "Error: --direction must be up or down, got %q\n", *reachDirection)
+			exit(exitUsage)
+		}
+		reach, err := analysis.ComputeReach(issues, *robotReach, *reachDirection, *reachDepth)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			AsOf        string `json:"as_of,omitempty"`
+			AsOfCommit  string `json:"as_of_commit,omitempty"`
+			analysis.ReachResult
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			AsOf:        *asOf,
+			AsOfCommit:  asOfResolved,
+			ReachResult: reach,
+			UsageHints: []string{
+				"jq '.count' - Size of the impact set",
+				"jq -r '.issues[] | select(.status != \"closed\") | .id' - Open issues in the set",
+				"jq '.issues | group_by(.depth) | map({depth: .[0].depth, ids: map(.id)})' - Issues per level",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-reach: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotHealthHistory {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			exit(1)
+		}
+		gitLoader := loader.NewGitLoader(cwd)
+		revisions, err := gitLoader.ListRevisions(*healthRevisions)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		healthRevs := make([]analysis.HealthRevision, len(revisions))
+		for i, rev := range revisions {
+			healthRevs[i] = analysis.HealthRevision{SHA: rev.SHA, Timestamp: rev.Timestamp, Message: rev.Message}
+		}
+		history, err := analysis.ComputeHealthHistory(healthRevs, gitLoader.LoadAt)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		output := struct {
+			GeneratedAt string               `json:"generated_at"`
+			DataHash    string               `json:"data_hash"`
+			Current     analysis.HealthScore `json:"current"`
+			analysis.HealthHistory
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt:   time.Now().UTC().Format(time.RFC3339),
+			DataHash:      dataHash,
+			Current:       analysis.ComputeHealthScore(issues),
+			HealthHistory: history,
+			UsageHints: []string{
+				"jq '.points[] | [.timestamp, .score]' - Score per revision",
+				"jq '.score_change' - Change from the oldest to the newest revision",
+				"jq '[.points[] | select(.cycle_count > 0) | .revision]' - Revisions with dependency cycles",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-health-history: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotBlockedTime {
+		cwd, err := os.Getwd()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
+			exit(1)
+		}
+		gitLoader := loader.NewGitLoader(cwd)
+		revisions, err := gitLoader.ListRevisions(*healthRevisions)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		blockedRevs := make([]analysis.HealthRevision, len(revisions))
+		for i, rev := range revisions {
+			blockedRevs[i] = analysis.HealthRevision{SHA: rev.SHA, Timestamp: rev.Timestamp, Message: rev.Message}
+		}
+		blocked, err := analysis.ComputeBlockedTime(blockedRevs, gitLoader.LoadAt, issues, time.Now())
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(1)
+		}
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			analysis.BlockedTime
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+			DataHash:    dataHash,
+			BlockedTime: blocked,
+			UsageHints: []string{
+				"jq '.issues[:10] | map({id, blocked_days})' - Worst offenders",
+				"jq '.by_label[:5]' - Labels losing the most time to blockers",
+				"jq '[.issues[] | select(.currently_blocked)] | length' - Issues still blocked",
+			},
 		}
-		os.Exit(0)
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-blocked-time: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotOrder {
+		scores := make(map[string]float64, len(issues))
+		for _, s := range analysis.NewAnalyzer(issues).ComputeImpactScores() {
+			scores[s.IssueID] = s.Score
+		}
+		order := analysis.ComputeExecutionOrder(issues, scores)
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			AsOf        string `json:"as_of,omitempty"`
+			AsOfCommit  string `json:"as_of_commit,omitempty"`
+			analysis.ExecutionOrder
+			UsageHints []string `json:"usage_hints"`
+		}{
+			GeneratedAt:    time.Now().UTC().Format(time.RFC3339),
+			DataHash:       dataHash,
+			AsOf:           *asOf,
+			AsOfCommit:     asOfResolved,
+			ExecutionOrder: order,
+			UsageHints: []string{
+				"jq -r '.order[].id' - Work queue for a single agent, in order",
+				"jq '.waves[0].ids' - Issues that can start now, in parallel",
+				"jq '[.order[] | select(.cycle)] | group_by(.cycle)' - Cycles that need breaking first",
+			},
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-order: %v\n", err)
+			exit(1)
+		}
+		exit(0)
 	}
 
 	// Handle --robot-label-attention (bv-121)
@@ -1640,9 +2508,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding label attention: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-graph (bv-136)
@@ -1666,21 +2534,38 @@ func main() {
 			Label:    *labelScope,
 			Root:     *graphRoot,
 			Depth:    *graphDepth,
+			Condense: *graphCondense,
 			DataHash: dataHash,
 		}
 
 		result, err := export.ExportGraph(issues, &stats, config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error exporting graph: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(result); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding graph: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
+	}
+
+	// Handle --export-ics - forecast milestones as calendar events
+	if *exportICS != "" {
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		forecast := analysis.ForecastMilestones(issues, &stats, *forecastAgents, time.Now())
+		if err := export.SaveICSToFile(forecast, "bv milestones", *exportICS); err != nil {
+			fmt.Fprintf(os.Stderr, "Error exporting calendar: %v\n", err)
+			exit(1)
+		}
+		fmt.Printf("Exported %d epic milestones and %d critical path checkpoints to %s\n",
+			len(forecast.Epics), len(forecast.CriticalPath), *exportICS)
+		if len(forecast.Issues) > 0 {
+			fmt.Printf("Forecast finish: %s (%d agent(s))\n", forecast.Finish.Format("2006-01-02"), forecast.Agents)
+		}
+		exit(0)
 	}
 
 	// Handle --export-graph (bv-94) - PNG/SVG/HTML export
@@ -1705,7 +2590,7 @@ func main() {
 
 		if len(exportIssues) == 0 {
 			fmt.Fprintf(os.Stderr, "No issues to export (check filters)\n")
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Get project name from current directory
@@ -1720,7 +2605,7 @@ func main() {
 			}
 
 			// Compute triage for the graph export
-			triageOpts := analysis.TriageOptions{WaitForPhase2: true}
+			triageOpts := analysis.TriageOptions{WaitForPhase2: true, ScoreRules: ruleSet}
 			triage := analysis.ComputeTriageWithOptions(exportIssues, triageOpts)
 
 			opts := export.InteractiveGraphOptions{
@@ -1739,10 +2624,10 @@ func main() {
 			outputPath, err := export.GenerateInteractiveGraphHTML(opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error exporting interactive graph: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			fmt.Printf("✓ Interactive graph exported to %s (%d nodes, %d edges)\n", outputPath, len(exportIssues), stats.EdgeCount)
-			os.Exit(0)
+			exit(0)
 		}
 
 		// Static PNG/SVG export (use .html for better interactive graphs)
@@ -1758,79 +2643,21 @@ func main() {
 		err := export.SaveGraphSnapshot(opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error exporting graph snapshot: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fmt.Printf("✓ Graph exported to %s (%d nodes) - tip: use .html for interactive graphs\n", *exportGraph, len(exportIssues))
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-alerts (drift + proactive)
 	if *robotAlerts {
-		driftConfig, err := drift.LoadConfig(projectDir)
+		driftResult, err := computeAlerts(issues, projectDir, baselinePath, envRobot)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading drift config: %v\n", err)
-			os.Exit(1)
-		}
-
-		analyzer := analysis.NewAnalyzer(issues)
-		stats := analyzer.Analyze()
-
-		openCount, closedCount, blockedCount := 0, 0, 0
-		for _, issue := range issues {
-			switch issue.Status {
-			case model.StatusClosed:
-				closedCount++
-			case model.StatusBlocked:
-				blockedCount++
-			case model.StatusOpen, model.StatusInProgress:
-				openCount++
-			default:
-				// Ignore tombstones and any unknown statuses for summary counts.
-			}
-		}
-		actionableCount := len(analyzer.GetActionableIssues())
-		cycles := stats.Cycles()
-		curStats := baseline.GraphStats{
-			NodeCount:       stats.NodeCount,
-			EdgeCount:       stats.EdgeCount,
-			Density:         stats.Density,
-			OpenCount:       openCount,
-			ClosedCount:     closedCount,
-			BlockedCount:    blockedCount,
-			CycleCount:      len(cycles),
-			ActionableCount: actionableCount,
+			exit(1)
 		}
 
-		// Default behavior (no baseline): drift comparisons are suppressed by using
-		// baseline=current for stats, while still allowing cycle/staleness/cascade alerts.
-		bl := &baseline.Baseline{Stats: curStats}
-		cur := &baseline.Baseline{Stats: curStats, Cycles: cycles}
-
-		// If a baseline exists, compare against it for real drift deltas.
-		if baseline.Exists(baselinePath) {
-			loaded, err := baseline.Load(baselinePath)
-			if err != nil {
-				if !envRobot {
-					fmt.Fprintf(os.Stderr, "Warning: Error loading baseline: %v\n", err)
-				}
-			} else {
-				bl = loaded
-				topMetrics := baseline.TopMetrics{
-					PageRank:     buildMetricItems(stats.PageRank(), 10),
-					Betweenness:  buildMetricItems(stats.Betweenness(), 10),
-					CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
-					Hubs:         buildMetricItems(stats.Hubs(), 10),
-					Authorities:  buildMetricItems(stats.Authorities(), 10),
-				}
-				cur = &baseline.Baseline{Stats: curStats, TopMetrics: topMetrics, Cycles: cycles}
-			}
-		}
-
-		calc := drift.NewCalculator(bl, cur, driftConfig)
-		calc.SetIssues(issues)
-		driftResult := calc.Calculate()
-
 		// Apply optional filters
 		filtered := driftResult.Alerts[:0]
 		for _, a := range driftResult.Alerts {
@@ -1892,9 +2719,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding alerts: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-suggest (bv-180)
@@ -1913,11 +2740,15 @@ func main() {
 			config.FilterType = analysis.SuggestionLabelSuggestion
 		case "cycle", "cycles":
 			config.FilterType = analysis.SuggestionCycleWarning
+		case "epic", "epics":
+			config.FilterType = analysis.SuggestionEpicCluster
+		case "redundant":
+			config.FilterType = analysis.SuggestionRedundantDependency
 		case "":
 			// All types
 		default:
-			fmt.Fprintf(os.Stderr, "Invalid suggest-type: %s (use: duplicate, dependency, label, cycle)\n", *suggestType)
-			os.Exit(1)
+			fmt.Fprintf(os.Stderr, "Invalid suggest-type: %s (use: duplicate, dependency, label, cycle, epic, redundant)\n", *suggestType)
+			exit(1)
 		}
 
 		output := analysis.GenerateRobotSuggestOutput(issues, config, dataHash)
@@ -1925,19 +2756,39 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding suggestions: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --profile-startup
 	if *profileStartup {
 		runProfileStartup(issues, loadDuration, *profileJSON, *forceFullAnalysis)
-		os.Exit(0)
+		exit(0)
+	}
+
+	// Handle --verify-determinism
+	if *verifyDeterminism {
+		report, err := runDeterminismCheck(issues, *determinismRuns, *forceFullAnalysis)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error verifying determinism: %v\n", err)
+			exit(1)
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(report); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding determinism report: %v\n", err)
+			exit(1)
+		}
+		fmt.Fprintln(os.Stderr, determinismSummary(report))
+		if !report.Deterministic {
+			exit(1)
+		}
+		exit(0)
 	}
 
 	// Handle --save-baseline
 	if *saveBaseline != "" {
+		mustLockRepo(instance.AccessExclusive, commandPurpose())
 		analyzer := analysis.NewAnalyzer(issues)
 		if *forceFullAnalysis {
 			cfg := analysis.FullAnalysisConfig()
@@ -1990,12 +2841,12 @@ func main() {
 
 		if err := bl.Save(baselinePath); err != nil {
 			fmt.Fprintf(os.Stderr, "Error saving baseline: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fmt.Printf("Baseline saved to %s\n", baselinePath)
 		fmt.Print(bl.Summary())
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --check-drift
@@ -2003,13 +2854,13 @@ func main() {
 		if !baseline.Exists(baselinePath) {
 			fmt.Fprintln(os.Stderr, "Error: No baseline found.")
 			fmt.Fprintln(os.Stderr, "Create one with: bv --save-baseline \"description\"")
-			os.Exit(1)
+			exit(1)
 		}
 
 		bl, err := baseline.Load(baselinePath)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading baseline: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Run analysis on current issues
@@ -2095,18 +2946,18 @@ func main() {
 			output.Baseline.CreatedAt = bl.CreatedAt.Format(time.RFC3339)
 			output.Baseline.CommitSHA = bl.CommitSHA
 
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding drift result: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else {
 			// Human-readable output
 			fmt.Print(result.Summary())
 		}
 
-		os.Exit(result.ExitCode())
+		exit(result.ExitCode())
 	}
 
 	if *robotInsights {
@@ -2115,6 +2966,10 @@ func main() {
 			cfg := analysis.FullAnalysisConfig()
 			analyzer.SetConfig(&cfg)
 		}
+		if pageRankFocus != nil {
+			cfg := withFocus(analyzer.Config())
+			analyzer.SetConfig(&cfg)
+		}
 		stats := analyzer.Analyze()
 		// Generate top 50 lists for summary, but full stats are included in the struct
 		insights := stats.GenerateInsights(50)
@@ -2221,6 +3076,13 @@ func main() {
 		// Generate advanced insights with canonical structure (bv-181)
 		advancedInsights := analyzer.GenerateAdvancedInsights(analysis.DefaultAdvancedInsightsConfig())
 
+		// Team-specific analyzers installed as bv-analyzer-* executables.
+		// They run arbitrary code from PATH, so only when asked to.
+		var pluginResults []plugins.Result
+		if *pluginsFlag {
+			pluginResults = runAnalyzerPlugins(issues, &stats, dataHash)
+		}
+
 		output := struct {
 			GeneratedAt    string                  `json:"generated_at"`
 			DataHash       string                  `json:"data_hash"`
@@ -2230,10 +3092,13 @@ func main() {
 			Status         analysis.MetricStatus   `json:"status"`
 			LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
 			LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+			PageRankFocus  *analysis.PageRankFocus `json:"pagerank_focus,omitempty"`
 			analysis.Insights
 			FullStats        interface{}                `json:"full_stats"`
 			TopWhatIfs       []analysis.WhatIfEntry     `json:"top_what_ifs,omitempty"`      // Issues with highest downstream impact (bv-83)
 			AdvancedInsights *analysis.AdvancedInsights `json:"advanced_insights,omitempty"` // bv-181: Canonical advanced features
+			Workload         *analysis.WorkloadReport   `json:"workload,omitempty"`          // Per-assignee balance; absent without assignees
+			Plugins          []plugins.Result           `json:"plugins,omitempty"`           // bv-analyzer-* results
 			UsageHints       []string                   `json:"usage_hints"`                 // bv-84: Agent-friendly hints
 		}{
 			GeneratedAt:      time.Now().UTC().Format(time.RFC3339),
@@ -2244,10 +3109,13 @@ func main() {
 			Status:           stats.Status(),
 			LabelScope:       *labelScope,
 			LabelContext:     labelScopeContext,
+			PageRankFocus:    pageRankFocus,
 			Insights:         insights,
 			FullStats:        fullStats,
 			TopWhatIfs:       topWhatIfs,
 			AdvancedInsights: advancedInsights,
+			Workload:         analysis.ComputeWorkload(issues),
+			Plugins:          pluginResults,
 			UsageHints: []string{
 				"jq '.Bottlenecks[:5] | map(.ID)' - Top 5 bottleneck IDs",
 				"jq '.CriticalPath[:3]' - Top 3 critical path items",
@@ -2259,15 +3127,17 @@ func main() {
 				"jq '.Cycles | length' - Count of detected cycles",
 				"jq '.advanced_insights.cycle_break' - Cycle break suggestions (bv-181)",
 				"BV_INSIGHTS_MAP_LIMIT=50 bv --robot-insights - Reduce map sizes",
+				"jq '.workload.suggested_reassignments' - Moves that even out assignee load",
+				"jq '.plugins[] | {name, ok, error}' - Analyzer plugin runs",
 			},
 		}
 
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding insights: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	if *robotPlan {
@@ -2294,8 +3164,22 @@ func main() {
 			cfg.CyclesSkipReason = skipReason
 		}
 
+		cfg = withFocus(cfg)
+
 		plan := analyzer.GetExecutionPlan()
 
+		// Track owners and WIP limits (.bv/owners.yaml). Like rules, a broken
+		// file only warns.
+		ownership, err := analysis.LoadOwnershipConfig(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; continuing without owners\n", err)
+			ownership = analysis.OwnershipConfig{}
+		}
+		if *wipLimit >= 0 {
+			ownership.DefaultWIPLimit = *wipLimit
+		}
+		plan.ApplyOwnership(issues, ownership)
+
 		stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
 		stats.WaitForPhase2()
 		status := stats.Status()
@@ -2310,6 +3194,7 @@ func main() {
 			Status         analysis.MetricStatus   `json:"status"`
 			LabelScope     string                  `json:"label_scope,omitempty"`   // bv-122: Label filter applied
 			LabelContext   *analysis.LabelHealth   `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+			PageRankFocus  *analysis.PageRankFocus `json:"pagerank_focus,omitempty"`
 			Plan           analysis.ExecutionPlan  `json:"plan"`
 			UsageHints     []string                `json:"usage_hints"` // bv-84: Agent-friendly hints
 		}{
@@ -2321,6 +3206,7 @@ func main() {
 			Status:         status,
 			LabelScope:     *labelScope,
 			LabelContext:   labelScopeContext,
+			PageRankFocus:  pageRankFocus,
 			Plan:           plan,
 			UsageHints: []string{
 				"jq '.plan.tracks | length' - Number of parallel execution tracks",
@@ -2328,15 +3214,16 @@ func main() {
 				"jq '.plan.tracks[].items[] | select(.unblocks | length > 0)' - Items that unblock others",
 				"jq '.plan.summary' - High-level execution summary",
 				"jq '[.plan.tracks[].items[]] | length' - Total items across all tracks",
+				"jq '.plan.wip.overflow' - Items over their owner's WIP limit (with --wip-limit or .bv/owners.yaml)",
 			},
 		}
 
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding execution plan: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	if *robotPriority {
@@ -2345,6 +3232,10 @@ func main() {
 		if *forceFullAnalysis {
 			cfg = analysis.FullAnalysisConfig()
 		}
+		cfg = withFocus(cfg)
+		cfg.ScoreRules = ruleSet
+		priorityXRef, _ := correlation.CrossReference(projectDir, issueIDsOf(issues), correlation.XRefOptions{})
+		cfg.CodeLastChanged = codeLastChanged(projectDir, issues, priorityXRef)
 		analyzer.SetConfig(&cfg)
 		stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), cfg)
 		stats.WaitForPhase2()
@@ -2422,6 +3313,7 @@ func main() {
 			Status            analysis.MetricStatus                     `json:"status"`
 			LabelScope        string                                    `json:"label_scope,omitempty"`   // bv-122: Label filter applied
 			LabelContext      *analysis.LabelHealth                     `json:"label_context,omitempty"` // bv-122: Health context for scoped label
+			PageRankFocus     *analysis.PageRankFocus                   `json:"pagerank_focus,omitempty"`
 			Recommendations   []analysis.EnhancedPriorityRecommendation `json:"recommendations"`
 			FieldDescriptions map[string]string                         `json:"field_descriptions"`
 			Filters           struct {
@@ -2445,6 +3337,7 @@ func main() {
 			Status:            status,
 			LabelScope:        *labelScope,
 			LabelContext:      labelScopeContext,
+			PageRankFocus:     pageRankFocus,
 			Recommendations:   recommendations,
 			FieldDescriptions: analysis.DefaultFieldDescriptions(),
 			Usage: []string{
@@ -2468,18 +3361,158 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding priority recommendations: %v\n", err)
-			os.Exit(1)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotListFlag {
+		req := robotListRequest{
+			Query:  *listQuery,
+			Label:  *labelScope,
+			Owner:  *listOwner,
+			Status: *listStatus,
+			Sort:   *listSort,
+			Limit:  *listLimit,
+			Cursor: *listCursorFlag,
+			Fields: *listFieldsFlag,
+		}
+		var scores map[string]float64
+		if listNeedsScores(req) {
+			scores = listScores(issues, ruleSet)
+		}
+		var owners map[string][]string
+		if listNeedsOwners(req) {
+			owners = codeOwnersFor(projectDir, issues)
+		}
+		output, err := buildRobotList(issues, scores, owners, req)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			exit(exitUsage)
+		}
+		output.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+		output.DataHash = dataHash
+		output.AsOf = *asOf
+		output.AsOfCommit = asOfResolved
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-list: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotWorktreesFlag {
+		worktrees, err := correlation.Worktrees(projectDir)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --robot-worktrees needs a git repository: %v\n", err)
+			exit(1)
+		}
+		now := time.Now()
+		claims := loadBranchClaims(worktrees, now)
+		xref, _ := correlation.CrossReference(projectDir, claimIssueIDs(claims), correlation.XRefOptions{})
+		attachClaimHints(claims, xref)
+		output := buildRobotWorktrees(worktrees, claims, now)
+		output.DataHash = dataHash
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-worktrees: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotFrontierFlag {
+		now := time.Now()
+		output := buildRobotFrontier(issues, *agentID, frontierClaims(issues, now), now)
+		output.DataHash = dataHash
+		output.AsOf = *asOf
+		output.AsOfCommit = asOfResolved
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-frontier: %v\n", err)
+			exit(1)
+		}
+		exit(0)
+	}
+
+	if *robotShowID != "" || *robotShowIDs != "" {
+		alerts := []drift.Alert{}
+		if result, err := computeAlerts(issues, projectDir, baselinePath, true); err == nil {
+			alerts = result.Alerts
+		}
+		beadsDir, _ := loader.GetBeadsDir("")
+		builder := newRobotShowBuilder(issues, alerts, bdCommand(beadsDir), ruleSet)
+		builder.xref, _ = correlation.CrossReference(projectDir, issueIDsOf(issues), correlation.XRefOptions{})
+		builder.owners = loadCodeOwners(projectDir)
+		builder.setCodeLastChanged(codeLastChanged(projectDir, issues, builder.xref))
+		if *showNotes {
+			builder.notesDir = notes.Dir(beadsDir)
+		}
+		if *redact {
+			builder.redactor = &redactor
+		}
+		var output any
+		if *robotShowIDs != "" {
+			batch := builder.batch(parseShowIDs(*robotShowID, *robotShowIDs))
+			batch.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+			batch.DataHash = dataHash
+			batch.AsOf = *asOf
+			batch.AsOfCommit = asOfResolved
+			output = batch
+		} else {
+			detail, ok := builder.detail(*robotShowID)
+			if !ok {
+				fmt.Fprintf(os.Stderr, "Issue not found: %s\n", *robotShowID)
+				exit(1)
+			}
+			output = robotShow{
+				GeneratedAt:     time.Now().UTC().Format(time.RFC3339),
+				DataHash:        dataHash,
+				AsOf:            *asOf,
+				AsOfCommit:      asOfResolved,
+				robotShowDetail: detail,
+			}
+		}
+		encoder := newRobotEncoder(os.Stdout)
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-show: %v\n", err)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
-	if *robotTriage || *robotNext || *robotTriageByTrack || *robotTriageByLabel {
+	if *robotTriage || *robotNext || *robotOnboard || *robotTriageByTrack || *robotTriageByLabel {
 		// bv-87: Support track/label-aware grouping for multi-agent coordination
 		opts := analysis.TriageOptions{
 			GroupByTrack:  *robotTriageByTrack,
 			GroupByLabel:  *robotTriageByLabel,
 			WaitForPhase2: true,  // Triage needs full graph metrics
 			UseFastConfig: true,  // Use minimal Phase 2 config for robot mode (bv-t1js)
+			Focus:         pageRankFocus,
+			ScoreRules:    ruleSet,
+		}
+		if *robotNext && (*agentID != "" || *robotByLabel != "" || *nextClaim) {
+			// Rank everything: the agent's pick may be far down the list
+			opts.TopN = len(issues)
+		}
+		// Issues starred in the TUI get their own section
+		if pinsBeadsDir, err := loader.GetBeadsDir(""); err == nil {
+			if state, err := pins.Load(pins.DefaultPath(pinsBeadsDir)); err == nil {
+				opts.Pinned = state.Pinned
+			}
+		}
+		// Suggest owners per CODEOWNERS as assignees, and weigh how long the
+		// code each issue touches has gone unchanged into its risk
+		triageXRef, _ := correlation.CrossReference(projectDir, issueIDsOf(issues), correlation.XRefOptions{})
+		opts.Owners = issueOwners(loadCodeOwners(projectDir), issues, triageXRef)
+		opts.CodeLastChanged = codeLastChanged(projectDir, issues, triageXRef)
+		opts.FileAuthors = reviewFileAuthors(projectDir, issues, triageXRef)
+		if *redact {
+			// Reviewer and assignee suggestions name people from git and
+			// CODEOWNERS, in fields and in reason text
+			opts.Owners = redactOwners(redactor, opts.Owners)
+			opts.FileAuthors = redactFileAuthors(redactor, opts.FileAuthors)
 		}
 		triage := analysis.ComputeTriageWithOptions(issues, opts)
 
@@ -2492,65 +3525,30 @@ func main() {
 			}
 		}
 
-		if *robotNext {
-			// Minimal output: just the top pick
-			if len(triage.QuickRef.TopPicks) == 0 {
-				output := struct {
-					GeneratedAt string `json:"generated_at"`
-					DataHash    string `json:"data_hash"`
-					AsOf        string `json:"as_of,omitempty"`
-					AsOfCommit  string `json:"as_of_commit,omitempty"`
-					Message     string `json:"message"`
-				}{
-					GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-					DataHash:    dataHash,
-					AsOf:        *asOf,
-					AsOfCommit:  asOfResolved,
-					Message:     "No actionable items available",
-				}
-				encoder := json.NewEncoder(os.Stdout)
-				encoder.SetIndent("", "  ")
-				if err := encoder.Encode(output); err != nil {
-					fmt.Fprintf(os.Stderr, "Error encoding robot-next: %v\n", err)
-					os.Exit(1)
-				}
-				os.Exit(0)
+		if *robotOnboard {
+			beadsDir, err := loader.GetBeadsDir("")
+			if err != nil {
+				fmt.Fprintf(os.Stderr, "Error locating beads directory: %v\n", err)
+				exit(1)
 			}
-
-			top := triage.QuickRef.TopPicks[0]
-			output := struct {
-				GeneratedAt string   `json:"generated_at"`
-				DataHash    string   `json:"data_hash"`
-				AsOf        string   `json:"as_of,omitempty"`
-				AsOfCommit  string   `json:"as_of_commit,omitempty"`
-				ID          string   `json:"id"`
-				Title       string   `json:"title"`
-				Score       float64  `json:"score"`
-				Reasons     []string `json:"reasons"`
-				Unblocks    int      `json:"unblocks"`
-				ClaimCmd    string   `json:"claim_command"`
-				ShowCmd     string   `json:"show_command"`
-			}{
-				GeneratedAt: time.Now().UTC().Format(time.RFC3339),
-				DataHash:    dataHash,
-				AsOf:        *asOf,
-				AsOfCommit:  asOfResolved,
-				ID:          top.ID,
-				Title:       top.Title,
-				Score:       top.Score,
-				Reasons:     top.Reasons,
-				Unblocks:    top.Unblocks,
-				ClaimCmd:    fmt.Sprintf("bd update %s --status=in_progress", top.ID),
-				ShowCmd:     fmt.Sprintf("bd show %s", top.ID),
-			}
-
-			encoder := json.NewEncoder(os.Stdout)
-			encoder.SetIndent("", "  ")
-			if err := encoder.Encode(output); err != nil {
-				fmt.Fprintf(os.Stderr, "Error encoding robot-next: %v\n", err)
-				os.Exit(1)
+			encoder := newRobotEncoder(os.Stdout)
+			if err := encoder.Encode(buildRobotOnboard(issues, triage, beadsDir, dataHash)); err != nil {
+				fmt.Fprintf(os.Stderr, "Error encoding robot-onboard: %v\n", err)
+				exit(1)
 			}
-			os.Exit(0)
+			exit(0)
+		}
+
+		if *robotNext {
+			exit(runRobotNext(issues, triage, robotNextRequest{
+				Options:    nextOptions{Agent: *agentID, Label: *robotByLabel},
+				Claim:      *nextClaim,
+				BeadsPath:  beadsPath,
+				RepoFilter: *repoFilter,
+				DataHash:   dataHash,
+				AsOf:       *asOf,
+				AsOfCommit: asOfResolved,
+			}))
 		}
 
 		// Full triage output with usage hints
@@ -2576,6 +3574,7 @@ func main() {
 				"jq '.triage.recommendations[] | select(.type == \"bug\")' - Bug-focused recommendations",
 				"jq '.triage.quick_ref.top_picks[] | select(.unblocks > 2)' - High-impact picks",
 				"jq '.triage.quick_wins' - Low-effort, high-impact items",
+				"jq '.triage.pinned' - Issues starred in the TUI (*)",
 				"--robot-next - Get only the single top recommendation",
 				"--robot-triage-by-track - Group by execution track for multi-agent coordination",
 				"--robot-triage-by-label - Group by label for area-focused agents",
@@ -2587,21 +3586,21 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding robot-triage: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --priority-brief flag (bv-96)
 	if *priorityBrief != "" {
 		fmt.Printf("Generating priority brief to %s...\n", *priorityBrief)
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptions(issues, analysis.TriageOptions{ScoreRules: ruleSet})
 
 		// Marshal triage to JSON for the export function
 		triageJSON, err := json.Marshal(triage)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error marshaling triage data: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Generate the brief
@@ -2610,17 +3609,17 @@ func main() {
 		brief, err := export.GeneratePriorityBriefFromTriageJSON(triageJSON, config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating priority brief: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Write to file
 		if err := os.WriteFile(*priorityBrief, []byte(brief), 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing priority brief: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fmt.Printf("Done! Priority brief saved to %s\n", *priorityBrief)
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --agent-brief flag (bv-131)
@@ -2630,19 +3629,19 @@ func main() {
 		// Create output directory
 		if err := os.MkdirAll(*agentBrief, 0755); err != nil {
 			fmt.Fprintf(os.Stderr, "Error creating directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Generate triage data
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptions(issues, analysis.TriageOptions{ScoreRules: ruleSet})
 		triageJSON, err := json.MarshalIndent(triage, "", "  ")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error marshaling triage: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		if err := os.WriteFile(filepath.Join(*agentBrief, "triage.json"), triageJSON, 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing triage.json: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println("  → triage.json")
 
@@ -2653,11 +3652,11 @@ func main() {
 		insightsJSON, err := json.MarshalIndent(insights, "", "  ")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error marshaling insights: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		if err := os.WriteFile(filepath.Join(*agentBrief, "insights.json"), insightsJSON, 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing insights.json: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println("  → insights.json")
 
@@ -2667,11 +3666,11 @@ func main() {
 		brief, err := export.GeneratePriorityBriefFromTriageJSON(triageJSON, config)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating brief: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		if err := os.WriteFile(filepath.Join(*agentBrief, "brief.md"), []byte(brief), 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing brief.md: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println("  → brief.md")
 
@@ -2679,7 +3678,7 @@ func main() {
 		helpers := generateJQHelpers()
 		if err := os.WriteFile(filepath.Join(*agentBrief, "helpers.md"), []byte(helpers), 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing helpers.md: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println("  → helpers.md")
 
@@ -2700,17 +3699,17 @@ func main() {
 		metaJSON, _ := json.MarshalIndent(meta, "", "  ")
 		if err := os.WriteFile(filepath.Join(*agentBrief, "meta.json"), metaJSON, 0644); err != nil {
 			fmt.Fprintf(os.Stderr, "Error writing meta.json: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		fmt.Println("  → meta.json")
 
 		fmt.Printf("\nDone! Agent brief bundle saved to %s/\n", *agentBrief)
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --emit-script flag (bv-89)
 	if *emitScript {
-		triage := analysis.ComputeTriage(issues)
+		triage := analysis.ComputeTriageWithOptions(issues, analysis.TriageOptions{ScoreRules: ruleSet})
 
 		// Determine script limit
 		limit := *scriptLimit
@@ -2779,7 +3778,7 @@ func main() {
 		}
 
 		fmt.Print(sb.String())
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-history flag
@@ -2787,25 +3786,25 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Validate repository
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Resolve beads file path (bv-history fix, respects BEADS_DIR)
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Build correlator options
@@ -2819,7 +3818,7 @@ func main() {
 			since, err := recipe.ParseRelativeTime(*historySince, time.Now())
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error parsing --history-since: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			if !since.IsZero() {
 				opts.Since = &since
@@ -2841,7 +3840,7 @@ func main() {
 		report, err := correlator.GenerateReport(beadInfos, opts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Apply confidence filter if specified
@@ -2870,9 +3869,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(report); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle correlation audit commands (bv-e1u6)
@@ -2880,25 +3879,25 @@ func main() {
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		feedbackStore := correlation.NewFeedbackStore(beadsDir)
 		if err := feedbackStore.Load(); err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading feedback: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Handle --robot-correlation-stats
 		if *robotCorrelationStats {
 			stats := feedbackStore.GetStats()
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(stats); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding stats: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 
 		// Parse SHA:beadID format
@@ -2915,19 +3914,19 @@ func main() {
 			commitSHA, beadID, err := parseCorrelationArg(*robotExplainCorrelation)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			// Generate history report to find the correlation
 			cwd, err := os.Getwd()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			beadsPath, err := loader.FindJSONLPath(beadsDir)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			correlator := correlation.NewCorrelator(cwd, beadsPath)
 
@@ -2944,14 +3943,14 @@ func main() {
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			// Find the specific commit
 			history, ok := report.Histories[beadID]
 			if !ok {
 				fmt.Fprintf(os.Stderr, "Bead not found: %s\n", beadID)
-				os.Exit(1)
+				exit(1)
 			}
 
 			var targetCommit *correlation.CorrelatedCommit
@@ -2964,7 +3963,7 @@ func main() {
 
 			if targetCommit == nil {
 				fmt.Fprintf(os.Stderr, "Commit %s not found in bead %s correlations\n", commitSHA, beadID)
-				os.Exit(1)
+				exit(1)
 			}
 
 			// Generate explanation
@@ -2976,13 +3975,13 @@ func main() {
 				explanation.Recommendation = fmt.Sprintf("Already has feedback: %s", fb.Type)
 			}
 
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(explanation); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding explanation: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 
 		// Handle --robot-confirm-correlation
@@ -2990,7 +3989,7 @@ func main() {
 			commitSHA, beadID, err := parseCorrelationArg(*robotConfirmCorrelation)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			feedbackBy := *correlationFeedbackBy
@@ -3002,12 +4001,12 @@ func main() {
 			cwd, err := os.Getwd()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			beadsPath, err := loader.FindJSONLPath(beadsDir)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			correlator := correlation.NewCorrelator(cwd, beadsPath)
 
@@ -3020,7 +4019,7 @@ func main() {
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			var originalConf float64
@@ -3036,7 +4035,7 @@ func main() {
 
 			if err := feedbackStore.Confirm(commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			result := map[string]interface{}{
@@ -3047,13 +4046,13 @@ func main() {
 				"reason":    *correlationFeedbackReason,
 				"orig_conf": originalConf,
 			}
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(result); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding result: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 
 		// Handle --robot-reject-correlation
@@ -3061,7 +4060,7 @@ func main() {
 			commitSHA, beadID, err := parseCorrelationArg(*robotRejectCorrelation)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			feedbackBy := *correlationFeedbackBy
@@ -3073,12 +4072,12 @@ func main() {
 			cwd, err := os.Getwd()
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			beadsPath, err := loader.FindJSONLPath(beadsDir)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			correlator := correlation.NewCorrelator(cwd, beadsPath)
 
@@ -3091,7 +4090,7 @@ func main() {
 			report, err := correlator.GenerateReport(beadInfos, opts)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error generating report: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			var originalConf float64
@@ -3107,7 +4106,7 @@ func main() {
 
 			if err := feedbackStore.Reject(commitSHA, beadID, feedbackBy, originalConf, *correlationFeedbackReason); err != nil {
 				fmt.Fprintf(os.Stderr, "Error saving feedback: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 
 			result := map[string]interface{}{
@@ -3118,13 +4117,13 @@ func main() {
 				"reason":    *correlationFeedbackReason,
 				"orig_conf": originalConf,
 			}
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(result); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding result: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
-			os.Exit(0)
+			exit(0)
 		}
 	}
 
@@ -3133,25 +4132,25 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Validate repository
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Get beads path
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Convert issues to BeadInfo
@@ -3173,7 +4172,7 @@ func main() {
 		report, err := correlator.GenerateReport(beadInfos, correlatorOpts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Detect orphans using OrphanDetector
@@ -3184,7 +4183,7 @@ func main() {
 		orphanReport, err := detector.DetectOrphans(extractOpts)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error detecting orphans: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Filter by minimum score
@@ -3209,9 +4208,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(orphanReport); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding orphan report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-file-beads and --robot-file-hotspots flags (bv-hmib)
@@ -3219,25 +4218,25 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Validate repository
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Resolve beads file path
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Convert issues to BeadInfo for correlator
@@ -3257,7 +4256,7 @@ func main() {
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Create file lookup
@@ -3284,7 +4283,7 @@ func main() {
 
 			if err := encoder.Encode(output); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding hotspots: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else {
 			// Output file-beads lookup
@@ -3315,10 +4314,10 @@ func main() {
 
 			if err := encoder.Encode(output); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding file beads: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-impact flag (bv-19pq)
@@ -3326,23 +4325,23 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3360,10 +4359,14 @@ func main() {
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fileLookup := correlation.NewFileLookup(report)
+		// Planned work conflicts too: the files open issues declare or their
+		// commits on any branch changed
+		xref, _ := correlation.CrossReference(cwd, issueIDsOf(issues), correlation.XRefOptions{})
+		fileLookup.SetFileHints(correlation.IssueFileHints(issues, xref))
 		files := strings.Split(*robotImpact, ",")
 		for i := range files {
 			files[i] = strings.TrimSpace(files[i])
@@ -3396,9 +4399,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding impact analysis: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-file-relations flag (bv-7a2f)
@@ -3406,29 +4409,29 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3446,7 +4449,7 @@ func main() {
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		fileLookup := correlation.NewFileLookup(report)
@@ -3473,9 +4476,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding file relations: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-related flag (bv-jtdl)
@@ -3483,29 +4486,29 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3523,7 +4526,7 @@ func main() {
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Build dependency graph from issues
@@ -3546,7 +4549,7 @@ func main() {
 		result := report.FindRelatedWork(*robotRelatedWork, opts)
 		if result == nil {
 			fmt.Fprintf(os.Stderr, "Bead not found in history: %s\n", *robotRelatedWork)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Add data hash to output
@@ -3563,9 +4566,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding related work: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-blocker-chain flag (bv-nlo0)
@@ -3573,13 +4576,13 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		an := analysis.NewAnalyzer(issues)
@@ -3587,7 +4590,7 @@ func main() {
 
 		if result == nil {
 			fmt.Fprintf(os.Stderr, "Issue not found: %s\n", *robotBlockerChain)
-			os.Exit(1)
+			exit(1)
 		}
 
 		type BlockerChainOutput struct {
@@ -3608,9 +4611,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding blocker chain: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-impact-network flag (bv-48kr)
@@ -3619,26 +4622,26 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Find beads path
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Load issues
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Convert to BeadInfo slice
@@ -3658,7 +4661,7 @@ func main() {
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Build impact network
@@ -3686,9 +4689,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(result); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding impact network: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-causality flag (bv-j74w)
@@ -3696,29 +4699,29 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if err := correlation.ValidateRepository(cwd); err != nil {
 			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		issues, err := loader.LoadIssues(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading beads: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadsDir, err := loader.GetBeadsDir("")
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting beads directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		beadsPath, err := loader.FindJSONLPath(beadsDir)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error finding beads file: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		beadInfos := make([]correlation.BeadInfo, len(issues))
@@ -3736,7 +4739,7 @@ func main() {
 		})
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error generating history report: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Build blocker titles map for better descriptions
@@ -3753,15 +4756,15 @@ func main() {
 		result := report.BuildCausalityChain(*robotCausality, opts)
 		if result == nil {
 			fmt.Fprintf(os.Stderr, "Bead not found: %s\n", *robotCausality)
-			os.Exit(1)
+			exit(1)
 		}
 
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(result); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding causality result: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-sprint-list and --robot-sprint-show flags (bv-156)
@@ -3769,13 +4772,13 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		sprints, err := loader.LoadSprints(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		if *robotSprintShow != "" {
@@ -3789,14 +4792,14 @@ func main() {
 			}
 			if found == nil {
 				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *robotSprintShow)
-				os.Exit(1)
+				exit(1)
 			}
 			// Output single sprint as JSON
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(found); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding sprint: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else {
 			// Output all sprints as JSON
@@ -3809,14 +4812,14 @@ func main() {
 				SprintCount: len(sprints),
 				Sprints:     sprints,
 			}
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding sprints: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-burndown flag (bv-159)
@@ -3824,13 +4827,13 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		sprints, err := loader.LoadSprints(cwd)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading sprints: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Find the target sprint
@@ -3845,7 +4848,7 @@ func main() {
 			}
 			if targetSprint == nil {
 				fmt.Fprintf(os.Stderr, "No active sprint found\n")
-				os.Exit(1)
+				exit(1)
 			}
 		} else {
 			// Find sprint by ID
@@ -3857,7 +4860,7 @@ func main() {
 			}
 			if targetSprint == nil {
 				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *robotBurndown)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
@@ -3875,9 +4878,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(burndown); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding burndown: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-forecast flag (bv-158)
@@ -3885,7 +4888,7 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Build graph stats for depth calculation
@@ -3910,7 +4913,7 @@ func main() {
 			}
 			if sprintBeadIDs == nil {
 				fmt.Fprintf(os.Stderr, "Sprint not found: %s\n", *forecastSprint)
-				os.Exit(1)
+				exit(1)
 			}
 		}
 
@@ -3977,7 +4980,7 @@ func main() {
 			eta, err := analysis.EstimateETAForIssue(issues, &graphStats, *robotForecast, agents, now)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 			forecasts = append(forecasts, eta)
 		}
@@ -4031,9 +5034,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if outputErr = encoder.Encode(output); outputErr != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding forecast: %v\n", outputErr)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-capacity flag (bv-160)
@@ -4236,9 +5239,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding capacity: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --robot-metrics flag (bv-84tp)
@@ -4247,9 +5250,9 @@ func main() {
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding metrics: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --diff-since flag
@@ -4262,7 +5265,7 @@ func main() {
 		cwd, err := os.Getwd()
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error getting current directory: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		gitLoader := loader.NewGitLoader(cwd)
@@ -4271,7 +5274,7 @@ func main() {
 		historicalIssues, err := gitLoader.LoadAt(*diffSince)
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Error loading issues at %s: %v\n", *diffSince, err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Get revision info for timestamp
@@ -4307,17 +5310,17 @@ func main() {
 				Diff:             diff,
 			}
 
-			encoder := json.NewEncoder(os.Stdout)
+			encoder := newRobotEncoder(os.Stdout)
 			encoder.SetIndent("", "  ")
 			if err := encoder.Encode(output); err != nil {
 				fmt.Fprintf(os.Stderr, "Error encoding diff: %v\n", err)
-				os.Exit(1)
+				exit(1)
 			}
 		} else {
 			// Human-readable output
 			printDiffSummary(diff, *diffSince)
 		}
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Handle --as-of flag for TUI mode (robot commands already handled above with historical data)
@@ -4330,9 +5333,15 @@ func main() {
 		// Launch TUI with historical issues (already loaded, no live reload)
 		m := ui.NewModel(issues, activeRecipe, "")
 		defer m.Stop()
+		if gotoID != "" {
+			if err := m.GotoIssue(gotoID); err != nil {
+				fmt.Fprintf(os.Stderr, "Error: --goto: %v at %s\n", err, *asOf)
+				exit(exitError)
+			}
+		}
 		if err := runTUIProgram(m); err != nil {
 			fmt.Printf("Error running beads viewer: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 		return
 	}
@@ -4359,7 +5368,7 @@ func main() {
 				// Run pre-export hooks
 				if err := executor.RunPreExport(); err != nil {
 					fmt.Printf("Error: pre-export hook failed: %v\n", err)
-					os.Exit(1)
+					exit(1)
 				}
 			}
 		}
@@ -4367,7 +5376,7 @@ func main() {
 		// Perform the export
 		if err := export.SaveMarkdownToFile(issues, *exportFile); err != nil {
 			fmt.Printf("Error exporting: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 
 		// Run post-export hooks
@@ -4384,12 +5393,12 @@ func main() {
 		}
 
 		fmt.Println("Done!")
-		os.Exit(0)
+		exit(0)
 	}
 
 	if len(issues) == 0 {
 		fmt.Println("No issues found. Create some with 'bd create'!")
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Apply recipe filters and sorting if specified
@@ -4403,7 +5412,7 @@ func main() {
 	// - env var overrides user config file
 	if *backgroundMode && *noBackgroundMode {
 		fmt.Fprintln(os.Stderr, "Error: --background-mode and --no-background-mode are mutually exclusive")
-		os.Exit(2)
+		exit(exitUsage)
 	}
 	if *backgroundMode {
 		_ = os.Setenv("BV_BACKGROUND_MODE", "1")
@@ -4434,23 +5443,30 @@ func main() {
 		})
 	}
 
+	if gotoID != "" {
+		if err := m.GotoIssue(gotoID); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: --goto: %v\n", err)
+			exit(exitError)
+		}
+	}
+
 	// Debug render mode - output a view to file and exit
 	if *debugRender != "" {
 		output := m.RenderDebugView(*debugRender, *debugWidth, *debugHeight)
 		fmt.Println(output)
-		os.Exit(0)
+		exit(0)
 	}
 
 	// Run Program
 	if err := runTUIProgram(m); err != nil {
 		fmt.Printf("Error running beads viewer: %v\n", err)
-		os.Exit(1)
+		exit(1)
 	}
 }
 
 func runTUIProgram(m ui.Model) error {
 	p := tea.NewProgram(
-		m,
+		panicReportingModel{m},
 		tea.WithAltScreen(),
 		tea.WithMouseCellMotion(),
 		tea.WithoutSignalHandler(),
@@ -4508,7 +5524,36 @@ func runTUIProgram(m ui.Model) error {
 		}
 	}
 
-	_, err := p.Run()
+	// Put the user's window title back when we exit.
+	ui.SaveTerminalTitle()
+	defer ui.RestoreTerminalTitle()
+
+	final, err := p.Run()
+	if err != nil && errors.Is(err, tea.ErrProgramPanic) {
+		// Bubble Tea has restored the terminal by now, so the notice is visible.
+		path := diagnostics.CrashBundlePath()
+		if path == "" {
+			// The panic happened in a command goroutine, which Bubble Tea
+			// recovers itself; the stack was printed above.
+			b := diagnostics.NewBundle("crash")
+			b.Panic = "panic in a background command (see stack trace printed above)"
+			path, _ = diagnostics.Write(b, "")
+		}
+		if path != "" {
+			diagnostics.PrintCrashNotice(os.Stderr, path)
+		}
+		return err
+	}
+
+	// Reopen where the user left off next time
+	if final, ok := final.(panicReportingModel); ok {
+		if final, ok := final.Model.(ui.Model); ok {
+			if err := final.SaveUIState(); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: could not save UI state: %v\n", err)
+			}
+		}
+	}
+
 	if err != nil && errors.Is(err, tea.ErrProgramKilled) {
 		if err == tea.ErrProgramKilled || errors.Is(err, tea.ErrInterrupted) {
 			return nil
@@ -4517,6 +5562,38 @@ func runTUIProgram(m ui.Model) error {
 	return err
 }
 
+// panicReportingModel wraps the TUI model so a panic in Init, Update, or View
+// writes a crash bundle before Bubble Tea's own recovery restores the
+// terminal and turns it into ErrProgramPanic.
+type panicReportingModel struct {
+	tea.Model
+}
+
+func (m panicReportingModel) Init() tea.Cmd {
+	defer capturePanic()
+	return m.Model.Init()
+}
+
+func (m panicReportingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	defer capturePanic()
+	next, cmd := m.Model.Update(msg)
+	return panicReportingModel{next}, cmd
+}
+
+func (m panicReportingModel) View() string {
+	defer capturePanic()
+	return m.Model.View()
+}
+
+// capturePanic records a crash bundle and re-panics. Must be deferred
+// directly so recover sees the panic.
+func capturePanic() {
+	if r := recover(); r != nil {
+		_, _ = diagnostics.CapturePanic(r, debug.Stack())
+		panic(r)
+	}
+}
+
 // countEdges counts blocking dependencies for config sizing
 func countEdges(issues []model.Issue) int {
 	count := 0
@@ -4531,26 +5608,8 @@ func countEdges(issues []model.Issue) int {
 }
 
 func loadBackgroundModeFromUserConfig() (bool, bool) {
-	homeDir, err := os.UserHomeDir()
-	if err != nil || homeDir == "" {
-		return false, false
-	}
-	configPath := filepath.Join(homeDir, ".config", "bv", "config.yaml")
-
-	data, err := os.ReadFile(configPath)
-	if err != nil {
-		return false, false
-	}
-
-	var cfg struct {
-		Experimental struct {
-			BackgroundMode *bool `yaml:"background_mode"`
-		} `yaml:"experimental"`
-	}
-	if err := yaml.Unmarshal(data, &cfg); err != nil {
-		return false, false
-	}
-	if cfg.Experimental.BackgroundMode == nil {
+	cfg, ok := loadUserConfig()
+	if !ok || cfg.Experimental.BackgroundMode == nil {
 		return false, false
 	}
 	return *cfg.Experimental.BackgroundMode, true
@@ -4942,6 +6001,20 @@ func applyRecipeSort(issues []model.Issue, r *recipe.Recipe) []model.Issue {
 	return issues
 }
 
+// profileStage is one timed step of startup, in execution order.
+type profileStage struct {
+	Name     string        `json:"name"`
+	Duration time.Duration `json:"duration"`
+	Skipped  bool          `json:"skipped,omitempty"`
+	TimedOut bool          `json:"timed_out,omitempty"`
+}
+
+// profileFirstFrameSize is the terminal size used to time the first render.
+const (
+	profileFirstFrameWidth  = 120
+	profileFirstFrameHeight = 40
+)
+
 // runProfileStartup runs profiled startup analysis and outputs results
 func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOutput bool, forceFullAnalysis bool) {
 	// Get actual beads path (respects BEADS_DIR)
@@ -4951,6 +6024,15 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 		dataPath = beadsDir // fallback
 	}
 
+	// Cache counters cover only the profiled run.
+	for _, c := range metrics.AllCacheMetrics() {
+		c.Reset()
+	}
+
+	// Split load into read and parse by re-reading the data file; fall back to
+	// the combined load time (workspace mode, missing file).
+	stages := profileLoadStages(dataPath, loadDuration)
+
 	// Time analyzer construction
 	buildStart := time.Now()
 	analyzer := analysis.NewAnalyzer(issues)
@@ -4971,10 +6053,19 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 	}
 
 	// Run profiled analysis
-	_, profile := analyzer.AnalyzeWithProfile(config)
+	stats, profile := analyzer.AnalyzeWithProfile(config)
 
 	// Add load and build durations to profile
 	profile.BuildGraph = buildDuration
+	stages = append(stages, analysisStages(profile)...)
+
+	// Triage and the first TUI frame are what a user waits for after Phase 1.
+	triageStart := time.Now()
+	analysis.ComputeTriageFromAnalyzer(analyzer, stats, issues, analysis.TriageOptions{WaitForPhase2: true}, time.Now())
+	stages = append(stages, profileStage{Name: "triage", Duration: time.Since(triageStart)})
+	stages = append(stages, profileStage{Name: "render_first_frame", Duration: timeFirstFrame(issues)})
+
+	caches := metrics.AllCacheStats()
 
 	// Calculate total including load
 	totalWithLoad := loadDuration + profile.Total
@@ -4986,6 +6077,8 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			DataPath        string                   `json:"data_path"`
 			LoadJSONL       string                   `json:"load_jsonl"`
 			Profile         *analysis.StartupProfile `json:"profile"`
+			Stages          []profileStage           `json:"stages"`
+			Cache           []metrics.CacheStats     `json:"cache"`
 			TotalWithLoad   string                   `json:"total_with_load"`
 			Recommendations []string                 `json:"recommendations"`
 		}{
@@ -4993,18 +6086,95 @@ func runProfileStartup(issues []model.Issue, loadDuration time.Duration, jsonOut
 			DataPath:        dataPath,
 			LoadJSONL:       loadDuration.String(),
 			Profile:         profile,
+			Stages:          stages,
+			Cache:           caches,
 			TotalWithLoad:   totalWithLoad.String(),
 			Recommendations: generateProfileRecommendations(profile, loadDuration, totalWithLoad),
 		}
+		if output.Cache == nil {
+			output.Cache = []metrics.CacheStats{}
+		}
 
 		encoder := newRobotEncoder(os.Stdout)
 		if err := encoder.Encode(output); err != nil {
 			fmt.Fprintf(os.Stderr, "Error encoding profile: %v\n", err)
-			os.Exit(1)
+			exit(1)
 		}
 	} else {
 		// Human-readable output
 		printProfileReport(profile, loadDuration, totalWithLoad)
+		printStageBreakdown(stages, caches)
+	}
+}
+
+// profileLoadStages times reading and parsing the data file separately.
+func profileLoadStages(dataPath string, loadDuration time.Duration) []profileStage {
+	readStart := time.Now()
+	data, err := os.ReadFile(dataPath)
+	readDuration := time.Since(readStart)
+	if err != nil {
+		return []profileStage{{Name: "load", Duration: loadDuration}}
+	}
+	parseStart := time.Now()
+	if _, err := loader.ParseIssues(bytes.NewReader(data)); err != nil {
+		return []profileStage{{Name: "load", Duration: loadDuration}}
+	}
+	return []profileStage{
+		{Name: "read", Duration: readDuration},
+		{Name: "parse", Duration: time.Since(parseStart)},
+	}
+}
+
+// analysisStages flattens the analysis profile into stages.
+func analysisStages(p *analysis.StartupProfile) []profileStage {
+	cfg := p.Config
+	return []profileStage{
+		{Name: "build_graph", Duration: p.BuildGraph},
+		{Name: "degree", Duration: p.Degree},
+		{Name: "topo_sort", Duration: p.TopoSort},
+		{Name: "pagerank", Duration: p.PageRank, Skipped: !cfg.ComputePageRank, TimedOut: p.PageRankTO},
+		{Name: "betweenness", Duration: p.Betweenness, Skipped: !cfg.ComputeBetweenness, TimedOut: p.BetweennessTO},
+		{Name: "eigenvector", Duration: p.Eigenvector, Skipped: !cfg.ComputeEigenvector},
+		{Name: "hits", Duration: p.HITS, Skipped: !cfg.ComputeHITS, TimedOut: p.HITSTO},
+		{Name: "critical_path", Duration: p.CriticalPath, Skipped: !cfg.ComputeCriticalPath},
+		{Name: "cycles", Duration: p.Cycles, Skipped: !cfg.ComputeCycles, TimedOut: p.CyclesTO},
+		{Name: "kcore", Duration: p.KCore},
+		{Name: "articulation", Duration: p.Articulation},
+		{Name: "slack", Duration: p.Slack},
+	}
+}
+
+// timeFirstFrame builds the TUI model and renders one frame off-screen.
+func timeFirstFrame(issues []model.Issue) time.Duration {
+	start := time.Now()
+	m := ui.NewModel(issues, nil, "")
+	updated, _ := m.Update(tea.WindowSizeMsg{Width: profileFirstFrameWidth, Height: profileFirstFrameHeight})
+	_ = updated.View()
+	return time.Since(start)
+}
+
+// printStageBreakdown prints every stage in order plus cache activity.
+func printStageBreakdown(stages []profileStage, caches []metrics.CacheStats) {
+	fmt.Println()
+	fmt.Println("Stages:")
+	for _, st := range stages {
+		switch {
+		case st.Skipped:
+			fmt.Printf("  %-20s [Skipped]\n", st.Name+":")
+		case st.TimedOut:
+			fmt.Printf("  %-20s %v (TIMEOUT)\n", st.Name+":", formatDuration(st.Duration))
+		default:
+			fmt.Printf("  %-20s %v\n", st.Name+":", formatDuration(st.Duration))
+		}
+	}
+	fmt.Println()
+	fmt.Println("Caches:")
+	if len(caches) == 0 {
+		fmt.Println("  (no cache activity)")
+		return
+	}
+	for _, c := range caches {
+		fmt.Printf("  %-20s %d hit / %d miss (%.0f%%)\n", c.Name+":", c.Hits, c.Misses, c.HitRate*100)
 	}
 }
 
@@ -5215,6 +6385,84 @@ func filterByRepo(issues []model.Issue, repoFilter string) []model.Issue {
 	return result
 }
 
+// graphStatsFor summarizes issues' graph for drift comparison; analyzer
+// and stats are their analysis.
+func graphStatsFor(issues []model.Issue, analyzer *analysis.Analyzer, stats *analysis.GraphStats) baseline.GraphStats {
+	openCount, closedCount, blockedCount := 0, 0, 0
+	for _, issue := range issues {
+		switch issue.Status {
+		case model.StatusClosed:
+			closedCount++
+		case model.StatusBlocked:
+			blockedCount++
+		case model.StatusOpen, model.StatusInProgress:
+			openCount++
+		default:
+			// Ignore tombstones and any unknown statuses for summary counts.
+		}
+	}
+	return baseline.GraphStats{
+		NodeCount:       stats.NodeCount,
+		EdgeCount:       stats.EdgeCount,
+		Density:         stats.Density,
+		OpenCount:       openCount,
+		ClosedCount:     closedCount,
+		BlockedCount:    blockedCount,
+		CycleCount:      len(stats.Cycles()),
+		ActionableCount: len(analyzer.GetActionableIssues()),
+	}
+}
+
+// computeAlerts runs drift detection and the proactive checks (cycles,
+// staleness, blocking cascades, ...) over issues. Without a saved baseline
+// the current stats stand in for it, so only the proactive alerts fire.
+func computeAlerts(issues []model.Issue, projectDir, baselinePath string, quiet bool) (*drift.Result, error) {
+	driftConfig, err := drift.LoadConfig(projectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+
+	cycles := stats.Cycles()
+	curStats := graphStatsFor(issues, analyzer, &stats)
+
+	// Default behavior (no baseline): drift comparisons are suppressed by using
+	// baseline=current for stats, while still allowing cycle/staleness/cascade alerts.
+	bl := &baseline.Baseline{Stats: curStats}
+	cur := &baseline.Baseline{Stats: curStats, Cycles: cycles}
+
+	// If a baseline exists, compare against it for real drift deltas.
+	if baseline.Exists(baselinePath) {
+		loaded, err := baseline.Load(baselinePath)
+		if err != nil {
+			if !quiet {
+				fmt.Fprintf(os.Stderr, "Warning: Error loading baseline: %v\n", err)
+			}
+		} else {
+			bl = loaded
+			topMetrics := baseline.TopMetrics{
+				PageRank:     buildMetricItems(stats.PageRank(), 10),
+				Betweenness:  buildMetricItems(stats.Betweenness(), 10),
+				CriticalPath: buildMetricItems(stats.CriticalPathScore(), 10),
+				Hubs:         buildMetricItems(stats.Hubs(), 10),
+				Authorities:  buildMetricItems(stats.Authorities(), 10),
+			}
+			cur = &baseline.Baseline{Stats: curStats, TopMetrics: topMetrics, Cycles: cycles}
+		}
+	}
+
+	ruleSet, err := rules.Load(projectDir)
+	if err != nil && !quiet {
+		fmt.Fprintf(os.Stderr, "Warning: %v; continuing without rule alerts\n", err)
+	}
+	calc := drift.NewCalculator(bl, cur, driftConfig)
+	calc.SetIssues(issues)
+	calc.SetRules(ruleSet)
+	return calc.Calculate(), nil
+}
+
 // buildMetricItems converts a metrics map to a sorted slice of MetricItems
 func buildMetricItems(metrics map[string]float64, limit int) []baseline.MetricItem {
 	if len(metrics) == 0 {
@@ -5741,7 +6989,7 @@ func runPreviewServer(dir string) error {
 }
 
 // runPagesWizard runs the interactive deployment wizard (bv-10g).
-func runPagesWizard(issues []model.Issue, beadsPath string) error {
+func runPagesWizard(issues []model.Issue, beadsPath string, ruleSet *rules.Set) error {
 	wizard := export.NewWizard(beadsPath)
 
 	// Run interactive wizard to collect configuration
@@ -5793,7 +7041,7 @@ func runPagesWizard(issues []model.Issue, beadsPath string) error {
 
 	// Compute triage
 	fmt.Println("  -> Generating triage data...")
-	triage := analysis.ComputeTriage(exportIssues)
+	triage := analysis.ComputeTriageWithOptions(exportIssues, analysis.TriageOptions{ScoreRules: ruleSet})
 
 	// Extract dependencies
 	var deps []*model.Dependency
@@ -6525,10 +7773,10 @@ func generateHistoryForExport(issues []model.Issue) (*TimeTravelHistory, error)
 // newRobotEncoder creates a JSON encoder for robot mode output.
 // By default, output is compact (no indentation) for performance.
 // Set BV_PRETTY_JSON=1 to enable pretty-printing for human readability.
-func newRobotEncoder(w io.Writer) *json.Encoder {
+func newRobotEncoder(w io.Writer) *robotEncoder {
 	encoder := json.NewEncoder(w)
 	if os.Getenv("BV_PRETTY_JSON") == "1" {
 		encoder.SetIndent("", "  ")
 	}
-	return encoder
+	return &robotEncoder{Encoder: encoder}
 }
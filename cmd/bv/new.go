@@ -0,0 +1,295 @@
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"flag"
+	"fmt"
+	"io"
+	"math/big"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// newIDAlphabet is what bd draws hash ID suffixes from.
+const newIDAlphabet = "0123456789abcdefghijklmnopqrstuvwxyz"
+
+// newIssueIDPattern is the shape of an issue ID: prefix, dash, suffix.
+var newIssueIDPattern = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9_.-]*-[A-Za-z0-9_.]+$`)
+
+// newBdRunner runs bd create for --bd; tests replace it.
+var newBdRunner = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// listFlag collects a repeatable flag; each value may also be comma-separated.
+type listFlag []string
+
+func (l *listFlag) String() string { return strings.Join(*l, ",") }
+
+func (l *listFlag) Set(v string) error {
+	for _, part := range strings.Split(v, ",") {
+		if part = strings.TrimSpace(part); part != "" {
+			*l = append(*l, part)
+		}
+	}
+	return nil
+}
+
+// newIssueRequest is what `bv new` was asked to create.
+type newIssueRequest struct {
+	ID          string
+	Title       string
+	Type        string
+	Priority    int
+	Description string
+	Assignee    string
+	Labels      []string
+	Deps        []string // ID (blocks) or type:ID
+}
+
+// runNewCommand implements `bv new`. Returns the process exit code: 0 on
+// success, 1 if the issue doesn't validate or can't be written, 2 on usage
+// errors.
+func runNewCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("new", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	var req newIssueRequest
+	var labels, deps listFlag
+	fs.StringVar(&req.Title, "title", "", "Issue title (required)")
+	fs.StringVar(&req.Type, "type", string(model.TypeTask), "Issue type: bug, feature, task, epic or chore")
+	fs.IntVar(&req.Priority, "priority", 2, "Priority, 0 (highest) to 4")
+	fs.StringVar(&req.Description, "description", "", "Issue description")
+	fs.StringVar(&req.Assignee, "assignee", "", "Assignee")
+	fs.StringVar(&req.ID, "id", "", "Explicit issue ID (default: generated with the project prefix)")
+	fs.Var(&labels, "label", "Label (repeatable, or comma-separated)")
+	fs.Var(&deps, "dep", "Dependency: ID (blocks) or TYPE:ID, e.g. parent-child:bv-12 (repeatable)")
+	printOnly := fs.Bool("print", false, "Print the JSONL line instead of writing it")
+	useBd := fs.Bool("bd", false, "Create the issue with bd create instead of writing the file")
+	dir := fs.String("dir", ".", "Project directory")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv new --title TITLE [--type bug] [--priority N] [--dep ID]... [--label L]... [--print|--bd]")
+		fmt.Fprintln(stderr, "\nCreate an issue without bd: validate it against the loaded issues, then append")
+		fmt.Fprintln(stderr, "one well-formed line to the beads file (or print it, or hand it to bd create).")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 || strings.TrimSpace(req.Title) == "" || (*printOnly && *useBd) {
+		fs.Usage()
+		return 2
+	}
+	req.Labels, req.Deps = labels, deps
+
+	projectDir, err := filepath.Abs(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	beadsDir, err := loader.GetBeadsDir(projectDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	existing, _ := model.ParseIssues(data)
+
+	prefix := agents.LoadProjectInfo(beadsDir).IssuePrefix
+	if prefix == "" {
+		prefix = filepath.Base(projectDir)
+	}
+	issue, warnings, err := buildNewIssue(req, existing, prefix, time.Now())
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	for _, w := range warnings {
+		fmt.Fprintf(stderr, "Warning: %s\n", w)
+	}
+
+	if *useBd {
+		bd := bdCommand(beadsDir)
+		output, err := newBdRunner(bd, newBdCreateArgs(issue, req.ID != "")...)
+		stdout.Write(output)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %s create failed: %v\n", bd, err)
+			return 1
+		}
+		return 0
+	}
+
+	line, err := model.MarshalIssues([]model.Issue{issue})
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *printOnly {
+		stdout.Write(line)
+		return 0
+	}
+
+	after := data
+	if len(after) > 0 && !bytes.HasSuffix(after, []byte("\n")) {
+		after = append(append([]byte(nil), after...), '\n')
+	}
+	change := agents.Change{Path: path, Action: agents.ActionUpdate, Before: string(data), After: string(after) + string(line)}
+	if err := change.Apply(); err != nil {
+		fmt.Fprintf(stderr, "Error writing %s: %v\n", path, err)
+		return 1
+	}
+	rel := path
+	if r, err := filepath.Rel(projectDir, path); err == nil {
+		rel = r
+	}
+	fmt.Fprintf(stdout, "Created %s in %s\n", issue.ID, rel)
+	return 0
+}
+
+// buildNewIssue validates req against the existing issues and returns the
+// issue to create. Dependencies must name existing, non-tombstoned issues;
+// blocking on an issue that is already closed is allowed but warned about.
+func buildNewIssue(req newIssueRequest, existing []model.Issue, prefix string, now time.Time) (model.Issue, []string, error) {
+	byID := make(map[string]*model.Issue, len(existing))
+	for i := range existing {
+		byID[existing[i].ID] = &existing[i]
+	}
+
+	issueType := model.IssueType(strings.ToLower(strings.TrimSpace(req.Type)))
+	if !issueType.IsKnownType() {
+		return model.Issue{}, nil, fmt.Errorf("unknown issue type %q (want bug, feature, task, epic or chore)", req.Type)
+	}
+	if req.Priority < 0 || req.Priority > 4 {
+		return model.Issue{}, nil, fmt.Errorf("priority %d is out of range 0-4", req.Priority)
+	}
+
+	id := strings.TrimSpace(req.ID)
+	if id == "" {
+		var err error
+		if id, err = newIssueID(prefix, byID); err != nil {
+			return model.Issue{}, nil, err
+		}
+	} else {
+		if !newIssueIDPattern.MatchString(id) {
+			return model.Issue{}, nil, fmt.Errorf("invalid issue ID %q (want PREFIX-SUFFIX)", id)
+		}
+		if byID[id] != nil {
+			return model.Issue{}, nil, fmt.Errorf("issue %s already exists", id)
+		}
+		if p := id[:strings.LastIndex(id, "-")]; prefix != "" && p != prefix {
+			return model.Issue{}, nil, fmt.Errorf("issue ID %s doesn't use the project prefix %q", id, prefix)
+		}
+	}
+
+	now = now.UTC()
+	issue := model.Issue{
+		ID:          id,
+		Title:       strings.TrimSpace(req.Title),
+		Description: req.Description,
+		Status:      model.StatusOpen,
+		Priority:    req.Priority,
+		IssueType:   issueType,
+		Assignee:    req.Assignee,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Labels:      req.Labels,
+	}
+
+	var warnings []string
+	seen := make(map[string]bool)
+	for _, spec := range req.Deps {
+		depType, target := model.DepBlocks, spec
+		if t, rest, ok := strings.Cut(spec, ":"); ok {
+			depType, target = model.DependencyType(t), rest
+		}
+		if !depType.IsValid() {
+			return model.Issue{}, nil, fmt.Errorf("dependency %q: unknown type %q (want blocks, related, parent-child or discovered-from)", spec, depType)
+		}
+		blocker := byID[target]
+		if blocker == nil || blocker.Status.IsTombstone() {
+			return model.Issue{}, nil, fmt.Errorf("dependency %q: no issue %s", spec, target)
+		}
+		key := string(depType) + ":" + target
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		if depType.IsBlocking() && blocker.Status.IsClosed() {
+			warnings = append(warnings, fmt.Sprintf("%s is already closed, so it won't block %s", target, id))
+		}
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: target, Type: depType, CreatedAt: now})
+	}
+	return issue, warnings, nil
+}
+
+// newIssueID generates an unused bd-style hash ID, prefix-xxxx, lengthening
+// the suffix if short ones keep colliding.
+func newIssueID(prefix string, taken map[string]*model.Issue) (string, error) {
+	if prefix == "" {
+		return "", fmt.Errorf("can't generate an ID without an issue prefix; pass --id")
+	}
+	base := big.NewInt(int64(len(newIDAlphabet)))
+	for length := 4; length <= 8; length++ {
+		for attempt := 0; attempt < 10; attempt++ {
+			suffix := make([]byte, length)
+			for i := range suffix {
+				n, err := rand.Int(rand.Reader, base)
+				if err != nil {
+					return "", err
+				}
+				suffix[i] = newIDAlphabet[n.Int64()]
+			}
+			if id := prefix + "-" + string(suffix); taken[id] == nil {
+				return id, nil
+			}
+		}
+	}
+	return "", fmt.Errorf("no free issue ID with prefix %q", prefix)
+}
+
+// newBdCreateArgs is the bd create invocation that creates issue. The ID is
+// passed only if the user chose it; otherwise bd generates its own.
+func newBdCreateArgs(issue model.Issue, explicitID bool) []string {
+	args := []string{"create", issue.Title, "--type", string(issue.IssueType), "--priority", strconv.Itoa(issue.Priority)}
+	if explicitID {
+		args = append(args, "--id", issue.ID)
+	}
+	if issue.Description != "" {
+		args = append(args, "--description", issue.Description)
+	}
+	if issue.Assignee != "" {
+		args = append(args, "--assignee", issue.Assignee)
+	}
+	if len(issue.Labels) > 0 {
+		args = append(args, "--labels", strings.Join(issue.Labels, ","))
+	}
+	if len(issue.Dependencies) > 0 {
+		var deps []string
+		for _, dep := range issue.Dependencies {
+			deps = append(deps, string(dep.Type)+":"+dep.DependsOnID)
+		}
+		args = append(args, "--deps", strings.Join(deps, ","))
+	}
+	return args
+}
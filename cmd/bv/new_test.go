@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildNewIssue(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	existing := []model.Issue{
+		{ID: "bv-1", Status: model.StatusOpen},
+		{ID: "bv-2", Status: model.StatusClosed},
+		{ID: "bv-3", Status: model.StatusTombstone},
+	}
+	build := func(req newIssueRequest) (model.Issue, []string, error) {
+		if req.Title == "" {
+			req.Title = "Fix it"
+		}
+		if req.Type == "" {
+			req.Type = "task"
+		}
+		return buildNewIssue(req, existing, "bv", now)
+	}
+
+	issue, warnings, err := build(newIssueRequest{Type: "Bug", Priority: 1, Deps: []string{"bv-1", "parent-child:bv-2", "blocks:bv-1", "bv-2"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(issue.ID, "bv-") || len(issue.ID) != 7 || issue.IssueType != model.TypeBug || issue.Status != model.StatusOpen || !issue.CreatedAt.Equal(now) {
+		t.Errorf("issue = %+v", issue)
+	}
+	var deps []string
+	for _, d := range issue.Dependencies {
+		deps = append(deps, string(d.Type)+":"+d.DependsOnID)
+	}
+	if want := []string{"blocks:bv-1", "parent-child:bv-2", "blocks:bv-2"}; !reflect.DeepEqual(deps, want) {
+		t.Errorf("deps = %v, want %v", deps, want)
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "bv-2 is already closed") {
+		t.Errorf("warnings = %v", warnings)
+	}
+
+	for _, tt := range []struct {
+		req  newIssueRequest
+		want string
+	}{
+		{newIssueRequest{Type: "story"}, "unknown issue type"},
+		{newIssueRequest{Priority: 5}, "out of range"},
+		{newIssueRequest{ID: "bv-1"}, "already exists"},
+		{newIssueRequest{ID: "nodash"}, "invalid issue ID"},
+		{newIssueRequest{ID: "other-9"}, "project prefix"},
+		{newIssueRequest{Deps: []string{"bv-404"}}, "no issue bv-404"},
+		{newIssueRequest{Deps: []string{"bv-3"}}, "no issue bv-3"},
+		{newIssueRequest{Deps: []string{"needs:bv-1"}}, "unknown type"},
+	} {
+		if _, _, err := build(tt.req); err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("%+v: err = %v, want %q", tt.req, err, tt.want)
+		}
+	}
+	if issue, _, err := build(newIssueRequest{ID: "bv-new"}); err != nil || issue.ID != "bv-new" {
+		t.Errorf("explicit ID: %v %v", issue.ID, err)
+	}
+}
+
+func TestRunNewCommand(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".beads", "issues.jsonl")
+	content := `{"id":"bv-1","title":"One","status":"open","issue_type":"task"}` // No trailing newline
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := runNewCommand(append(args, "--dir", dir), &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	code, out, _ := run("--title", "Printed", "--print")
+	if code != 0 || !strings.HasPrefix(out, `{"id":"bv-`) || !strings.Contains(out, `"title":"Printed"`) {
+		t.Fatalf("--print = %d %q", code, out)
+	}
+	if data, _ := os.ReadFile(path); string(data) != content {
+		t.Fatal("--print wrote the file")
+	}
+
+	code, out, errOut := run("--title", "Login fails", "--type", "bug", "--id", "bv-2", "--dep", "bv-1", "--label", "auth,ui")
+	if code != 0 || out != "Created bv-2 in .beads/issues.jsonl\n" {
+		t.Fatalf("new = %d %q %q", code, out, errOut)
+	}
+	data, _ := os.ReadFile(path)
+	issues, errs := model.ParseIssues(data)
+	if len(errs) != 0 || len(issues) != 2 || issues[1].ID != "bv-2" || len(issues[1].Dependencies) != 1 || !reflect.DeepEqual(issues[1].Labels, []string{"auth", "ui"}) {
+		t.Fatalf("file after new: %s (errs %v)", data, errs)
+	}
+
+	if code, _, errOut = run("--title", "Dup", "--id", "bv-2"); code != 1 || !strings.Contains(errOut, "already exists") {
+		t.Errorf("duplicate ID = %d %q", code, errOut)
+	}
+	if code, _, _ = run("--type", "bug"); code != 2 {
+		t.Errorf("missing title = %d, want 2", code)
+	}
+
+	var gotArgs []string
+	orig := newBdRunner
+	newBdRunner = func(name string, args ...string) ([]byte, error) {
+		gotArgs = append([]string{name}, args...)
+		return []byte("Created issue: bv-zz\n"), nil
+	}
+	defer func() { newBdRunner = orig }()
+	code, out, _ = run("--title", "Via bd", "--priority", "1", "--dep", "parent-child:bv-2", "--bd")
+	want := []string{"bd", "create", "Via bd", "--type", "task", "--priority", "1", "--deps", "parent-child:bv-2"}
+	if code != 0 || !reflect.DeepEqual(gotArgs, want) || out != "Created issue: bv-zz\n" {
+		t.Errorf("--bd = %d %q, args %v", code, out, gotArgs)
+	}
+}
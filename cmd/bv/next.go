@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+// nextOptions narrows --robot-next to what one agent may take.
+type nextOptions struct {
+	Agent string // --agent-id; empty for an anonymous caller
+	Label string // --robot-by-label
+}
+
+// robotNextRequest is what runRobotNext needs from main's flags and load.
+type robotNextRequest struct {
+	Options    nextOptions
+	Claim      bool
+	BeadsPath  string // live beads file; empty for --as-of and workspaces
+	RepoFilter string
+	DataHash   string
+	AsOf       string
+	AsOfCommit string
+}
+
+// robotNext is the --robot-next payload when there is something to do.
+type robotNext struct {
+	GeneratedAt string          `json:"generated_at"`
+	DataHash    string          `json:"data_hash"`
+	AsOf        string          `json:"as_of,omitempty"`
+	AsOfCommit  string          `json:"as_of_commit,omitempty"`
+	Agent       string          `json:"agent,omitempty"`
+	ID          string          `json:"id"`
+	Title       string          `json:"title"`
+	Score       float64         `json:"score"`
+	Reasons     []string        `json:"reasons"`
+	Unblocks    int             `json:"unblocks"`
+	Resumed     bool            `json:"resumed,omitempty"`  // the agent already holds this issue
+	Branch      string          `json:"branch,omitempty"`   // git branch the agent works on
+	Warnings    []string        `json:"warnings,omitempty"` // claims on other branches touching the same files
+	ClaimCmd    string          `json:"claim_command"`
+	ShowCmd     string          `json:"show_command"`
+	Claim       *robotNextClaim `json:"claim,omitempty"` // set with --claim
+}
+
+// robotNextClaim reports the bd command --claim ran.
+type robotNextClaim struct {
+	Command  string `json:"command"`
+	Executed bool   `json:"executed"`
+	Output   string `json:"output,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// nextClaimRunner runs the bd claim command; tests replace it.
+var nextClaimRunner = func(name string, args ...string) ([]byte, error) {
+	return exec.Command(name, args...).CombinedOutput()
+}
+
+// pickNext returns the recommendation --robot-next should hand to the agent.
+// An agent that already holds claims gets the best ranked of them back
+// (resumed) instead of new work, so it finishes what it started. Otherwise
+// the pick is the best open, unblocked recommendation that nobody else has
+// claimed, in bd or in the session store, and that has opts.Label. recs are
+// in rank order; issues and claims are the current state.
+func pickNext(recs []analysis.Recommendation, issues map[string]model.Issue, claims *session.Store, opts nextOptions) (rec analysis.Recommendation, resumed, ok bool) {
+	if opts.Agent != "" {
+		held := make(map[string]bool)
+		for id, issue := range issues {
+			if issue.Status == model.StatusInProgress && issue.Assignee == opts.Agent {
+				held[id] = true
+			}
+		}
+		for _, c := range claims.ClaimsBy(opts.Agent) {
+			held[c.IssueID] = true
+		}
+		for _, r := range recs {
+			if held[r.ID] && hasLabel(issues[r.ID], opts.Label) {
+				return r, true, true
+			}
+		}
+		// Held issues can fall outside the ranked list; take the oldest
+		// claim, then any in-progress issue, in ID order for stability.
+		for _, c := range claims.ClaimsBy(opts.Agent) {
+			if issue, ok := issues[c.IssueID]; ok && hasLabel(issue, opts.Label) {
+				return recommendationFor(issue), true, true
+			}
+		}
+		for _, id := range sortedIssueIDs(held) {
+			if issue, ok := issues[id]; ok && hasLabel(issue, opts.Label) {
+				return recommendationFor(issue), true, true
+			}
+		}
+	}
+
+	for _, r := range recs {
+		issue, ok := issues[r.ID]
+		if !ok || issue.Status != model.StatusOpen || len(r.BlockedBy) > 0 {
+			continue
+		}
+		if opts.Agent != "" && issue.Assignee != "" && issue.Assignee != opts.Agent {
+			continue
+		}
+		if holder, claimed := claims.Holder(r.ID); claimed && (opts.Agent == "" || holder.Agent != opts.Agent) {
+			continue
+		}
+		if !hasLabel(issue, opts.Label) {
+			continue
+		}
+		return r, false, true
+	}
+	return analysis.Recommendation{}, false, false
+}
+
+// recommendationFor is a minimal recommendation for an issue the ranking
+// didn't include.
+func recommendationFor(issue model.Issue) analysis.Recommendation {
+	return analysis.Recommendation{
+		ID:       issue.ID,
+		Title:    issue.Title,
+		Status:   string(issue.Status),
+		Priority: issue.Priority,
+		Labels:   issue.Labels,
+		Reasons:  []string{"Already claimed by you"},
+	}
+}
+
+func hasLabel(issue model.Issue, label string) bool {
+	if label == "" {
+		return true
+	}
+	for _, l := range issue.Labels {
+		if l == label {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedIssueIDs(set map[string]bool) []string {
+	ids := make([]string, 0, len(set))
+	for id := range set {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// bdCommand is how to invoke bd for the project in beadsDir: the path
+// recorded by the project's agent setup, or bd from PATH.
+func bdCommand(beadsDir string) string {
+	if info := agents.LoadProjectInfo(beadsDir); info.BdPath != "" {
+		return info.BdPath
+	}
+	return "bd"
+}
+
+// nextClaimArgs is the bd update that claims issueID for agent.
+func nextClaimArgs(issueID, agent string) []string {
+	args := []string{"update", issueID, "--status=in_progress"}
+	if agent != "" {
+		args = append(args, "--assignee="+agent)
+	}
+	return args
+}
+
+// claimNext runs bd to claim out's issue and, if bd succeeds, records the
+// claim, with the branch and worktree it is worked in, in the store at
+// storePath. The caller holds the exclusive repository lock, so the
+// read-modify-write of the store can't interleave with another claimer's.
+func claimNext(out *robotNext, bd, agent string, checkout correlation.Checkout, claims *session.Store, storePath string, now time.Time) error {
+	args := nextClaimArgs(out.ID, agent)
+	command := bd + " " + strings.Join(args, " ")
+	out.Claim = &robotNextClaim{Command: command}
+
+	output, err := nextClaimRunner(bd, args...)
+	out.Claim.Output = strings.TrimSpace(string(output))
+	if err != nil {
+		out.Claim.Error = err.Error()
+		return fmt.Errorf("claiming %s: %w", out.ID, err)
+	}
+	out.Claim.Executed = true
+
+	claims.Add(session.Claim{
+		IssueID:   out.ID,
+		Agent:     agent,
+		ClaimedAt: now.UTC(),
+		Command:   command,
+		Branch:    checkout.Branch,
+		Worktree:  checkout.Worktree,
+	})
+	if err := claims.Save(storePath); err != nil {
+		// bd has the claim; only the early-visibility record is lost
+		out.Claim.Error = err.Error()
+	}
+	return nil
+}
+
+// runRobotNext implements --robot-next: pick the agent's next issue from
+// triage and, with --claim, claim it. It returns the exit code.
+func runRobotNext(issues []model.Issue, triage analysis.TriageResult, req robotNextRequest) int {
+	beadsDir, _ := loader.GetBeadsDir("")
+	storePath := session.DefaultPath(filepath.Dir(beadsDir))
+	if req.Claim {
+		if req.BeadsPath == "" {
+			fmt.Fprintln(os.Stderr, "Error: --claim needs the live beads file; it can't be combined with --as-of or --workspace")
+			return exitUsage
+		}
+		// Claimers serialize on the exclusive lock. Re-read the beads file
+		// under it: another agent may have claimed since it was loaded.
+		mustLockRepo(instance.AccessExclusive, commandPurpose())
+		fresh, err := loader.LoadIssuesFromFile(req.BeadsPath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reloading beads: %v\n", err)
+			return 1
+		}
+		if req.RepoFilter != "" {
+			fresh = filterByRepo(fresh, req.RepoFilter)
+		}
+		issues = fresh
+	}
+
+	index := make(map[string]model.Issue, len(issues))
+	for _, issue := range issues {
+		index[issue.ID] = issue
+	}
+	claims, err := session.Load(storePath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; ignoring recorded claims\n", err)
+		claims = &session.Store{Claims: []session.Claim{}}
+	}
+	now := time.Now()
+	finished := claims.Finished(index, now)
+	claims.Prune(index, now)
+
+	encoder := newRobotEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	rec, resumed, ok := pickNext(triage.Recommendations, index, claims, req.Options)
+	if !ok {
+		output := struct {
+			GeneratedAt string `json:"generated_at"`
+			DataHash    string `json:"data_hash"`
+			AsOf        string `json:"as_of,omitempty"`
+			AsOfCommit  string `json:"as_of_commit,omitempty"`
+			Agent       string `json:"agent,omitempty"`
+			Message     string `json:"message"`
+		}{
+			GeneratedAt: now.UTC().Format(time.RFC3339),
+			DataHash:    req.DataHash,
+			AsOf:        req.AsOf,
+			AsOfCommit:  req.AsOfCommit,
+			Agent:       req.Options.Agent,
+			Message:     "No actionable items available",
+		}
+		if err := encoder.Encode(output); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding robot-next: %v\n", err)
+			return 1
+		}
+		return 0
+	}
+
+	bd := bdCommand(beadsDir)
+	out := robotNext{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		DataHash:    req.DataHash,
+		AsOf:        req.AsOf,
+		AsOfCommit:  req.AsOfCommit,
+		Agent:       req.Options.Agent,
+		ID:          rec.ID,
+		Title:       rec.Title,
+		Score:       rec.Score,
+		Reasons:     rec.Reasons,
+		Unblocks:    len(rec.UnblocksIDs),
+		Resumed:     resumed,
+		ClaimCmd:    bd + " " + strings.Join(nextClaimArgs(rec.ID, req.Options.Agent), " "),
+		ShowCmd:     fmt.Sprintf("%s show %s", bd, rec.ID),
+	}
+	// In git, say where the work happens and whether another branch's
+	// claims touch the same files
+	checkout, gitErr := correlation.CurrentCheckout("")
+	if gitErr == nil {
+		out.Branch = checkout.Branch
+		out.Warnings = checkoutClaimWarnings("", index[rec.ID], req.Options.Agent, checkout, now)
+	}
+	code := 0
+	if req.Claim && !resumed {
+		if err := claimNext(&out, bd, req.Options.Agent, checkout, claims, storePath, now); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			code = 1
+		} else if out.Claim.Error == "" {
+			// The saved store no longer has the closed issues' claims
+			if err := session.AppendWork(session.TimeLogPath(filepath.Dir(beadsDir)), finished...); err != nil {
+				fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+			}
+		}
+	}
+	if err := encoder.Encode(out); err != nil {
+		fmt.Fprintf(os.Stderr, "Error encoding robot-next: %v\n", err)
+		return 1
+	}
+	return code
+}
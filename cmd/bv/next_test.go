@@ -0,0 +1,120 @@
+package main
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+func TestPickNext(t *testing.T) {
+	issues := map[string]model.Issue{
+		"A-1": {ID: "A-1", Status: model.StatusOpen, Labels: []string{"api"}},
+		"A-2": {ID: "A-2", Status: model.StatusInProgress, Assignee: "bob"},
+		"A-3": {ID: "A-3", Status: model.StatusOpen, Assignee: "carol"},
+		"A-4": {ID: "A-4", Status: model.StatusOpen},
+		"A-5": {ID: "A-5", Status: model.StatusOpen, Labels: []string{"ui"}},
+		"A-6": {ID: "A-6", Status: model.StatusOpen, Labels: []string{"ui"}},
+	}
+	recs := []analysis.Recommendation{
+		{ID: "A-0", Score: 9}, // not in the current data
+		{ID: "A-2", Score: 8},
+		{ID: "A-3", Score: 7},
+		{ID: "A-4", Score: 6, BlockedBy: []string{"A-1"}},
+		{ID: "A-1", Score: 5},
+		{ID: "A-5", Score: 4},
+		{ID: "A-6", Score: 3},
+	}
+	claims := &session.Store{Claims: []session.Claim{{IssueID: "A-5", Agent: "dave"}}}
+
+	tests := []struct {
+		name        string
+		opts        nextOptions
+		wantID      string
+		wantResumed bool
+	}{
+		{"anonymous skips in-progress and blocked", nextOptions{}, "A-3", false},
+		{"agent skips others' assignments", nextOptions{Agent: "erin"}, "A-1", false},
+		{"agent resumes its in-progress issue", nextOptions{Agent: "bob"}, "A-2", true},
+		{"agent resumes its session claim", nextOptions{Agent: "dave"}, "A-5", true},
+		{"label filter skips claimed issues", nextOptions{Agent: "erin", Label: "ui"}, "A-6", false},
+		{"label filter applies to resumed work", nextOptions{Agent: "bob", Label: "api"}, "A-1", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, resumed, ok := pickNext(recs, issues, claims, tt.opts)
+			if !ok || rec.ID != tt.wantID || resumed != tt.wantResumed {
+				t.Errorf("pickNext = %s, resumed=%v, ok=%v; want %s, resumed=%v", rec.ID, resumed, ok, tt.wantID, tt.wantResumed)
+			}
+		})
+	}
+
+	if _, _, ok := pickNext(recs, issues, claims, nextOptions{Label: "missing"}); ok {
+		t.Error("no issue has the label; want no pick")
+	}
+}
+
+func TestPickNextResumesClaimOutsideRanking(t *testing.T) {
+	issues := map[string]model.Issue{
+		"A-1": {ID: "A-1", Title: "Ranked", Status: model.StatusOpen},
+		"A-9": {ID: "A-9", Title: "Mine", Status: model.StatusInProgress, Assignee: "bob"},
+	}
+	recs := []analysis.Recommendation{{ID: "A-1"}}
+	rec, resumed, ok := pickNext(recs, issues, &session.Store{}, nextOptions{Agent: "bob"})
+	if !ok || !resumed || rec.ID != "A-9" || rec.Title != "Mine" {
+		t.Errorf("pickNext = %+v, resumed=%v, ok=%v", rec, resumed, ok)
+	}
+}
+
+func TestClaimNext(t *testing.T) {
+	saved := nextClaimRunner
+	defer func() { nextClaimRunner = saved }()
+
+	var ran []string
+	nextClaimRunner = func(name string, args ...string) ([]byte, error) {
+		ran = append([]string{name}, args...)
+		return []byte("updated\n"), nil
+	}
+	storePath := filepath.Join(t.TempDir(), ".bv", "sessions.json")
+	claims := &session.Store{}
+	out := &robotNext{ID: "A-1"}
+	now := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	if err := claimNext(out, "/opt/bd", "alice", correlation.Checkout{Worktree: "/src/app", Branch: "feature/x"}, claims, storePath, now); err != nil {
+		t.Fatal(err)
+	}
+	want := "/opt/bd update A-1 --status=in_progress --assignee=alice"
+	if strings.Join(ran, " ") != want || out.Claim.Command != want {
+		t.Errorf("ran %q, reported %q; want %q", ran, out.Claim.Command, want)
+	}
+	if !out.Claim.Executed || out.Claim.Output != "updated" || out.Claim.Error != "" {
+		t.Errorf("claim = %+v", out.Claim)
+	}
+	stored, err := session.Load(storePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c, ok := stored.Holder("A-1"); !ok || c.Agent != "alice" || !c.ClaimedAt.Equal(now) || c.Branch != "feature/x" || c.Worktree != "/src/app" {
+		t.Errorf("stored claim = %+v, %v", c, ok)
+	}
+
+	// A failed bd run is reported and not recorded
+	nextClaimRunner = func(string, ...string) ([]byte, error) {
+		return []byte("no such issue"), errors.New("exit status 1")
+	}
+	out = &robotNext{ID: "A-2"}
+	if err := claimNext(out, "bd", "", correlation.Checkout{}, claims, storePath, now); err == nil {
+		t.Fatal("want an error when bd fails")
+	}
+	if out.Claim.Executed || out.Claim.Output != "no such issue" || out.Claim.Error == "" {
+		t.Errorf("claim = %+v", out.Claim)
+	}
+	if _, ok := claims.Holder("A-2"); ok {
+		t.Error("failed claim should not be recorded")
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// maxOnboardRules caps how many AGENTS.md rules --robot-onboard repeats.
+const maxOnboardRules = 40
+
+// robotOnboard is the --robot-onboard payload: everything a fresh agent
+// session needs to start working without exploring bv first.
+type robotOnboard struct {
+	GeneratedAt string              `json:"generated_at"`
+	DataHash    string              `json:"data_hash"`
+	Project     robotOnboardProject `json:"project"`
+	TopPick     *robotOnboardPick   `json:"top_pick"` // null when nothing is actionable
+	Claims      []robotOnboardClaim `json:"claims"`   // in-progress issues
+	Conventions *robotConventions   `json:"conventions"`
+	Commands    []robotCommandHint  `json:"commands"`
+	NextSteps   []string            `json:"next_steps"`
+}
+
+type robotOnboardProject struct {
+	Name            string `json:"name"`
+	IssuePrefix     string `json:"issue_prefix,omitempty"`
+	BeadsDir        string `json:"beads_dir"`
+	IssueCount      int    `json:"issue_count"`
+	OpenCount       int    `json:"open_count"`
+	ActionableCount int    `json:"actionable_count"`
+	BlockedCount    int    `json:"blocked_count"`
+	InProgressCount int    `json:"in_progress_count"`
+	ClosedCount     int    `json:"closed_count"`
+}
+
+type robotOnboardPick struct {
+	ID       string   `json:"id"`
+	Title    string   `json:"title"`
+	Score    float64  `json:"score"`
+	Reasons  []string `json:"reasons"`
+	Unblocks int      `json:"unblocks"`
+	ClaimCmd string   `json:"claim_command"`
+	ShowCmd  string   `json:"show_command"`
+}
+
+type robotOnboardClaim struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Assignee  string    `json:"assignee,omitempty"`
+	Priority  int       `json:"priority"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// robotConventions is the project's agent file, minus bv's own blurb.
+type robotConventions struct {
+	File        string   `json:"file"`
+	HasBvBlurb  bool     `json:"has_bv_blurb"`
+	Headings    []string `json:"headings"`
+	Rules       []string `json:"rules"`
+	Truncated   bool     `json:"truncated,omitempty"`
+	InstallHint string   `json:"install_hint,omitempty"`
+}
+
+// robotCommandHint tells an agent when a robot command is worth running.
+type robotCommandHint struct {
+	Command string `json:"command"`
+	When    string `json:"when"`
+}
+
+// robotCommandHints is bv's robot surface in the order an agent tends to
+// need it.
+var robotCommandHints = []robotCommandHint{
+	{"bv --robot-next", "Pick the single best issue to work on right now"},
+	{"bv --robot-triage", "Full triage: ranked recommendations, quick wins, blockers to clear, project health"},
+	{"bv --robot-plan", "Parallel execution tracks when several agents split the work"},
+	{"bv --robot-triage-by-track", "Each agent takes the top pick of its own track without collisions"},
+	{"bv --robot-show <id>", "Everything about one issue: metrics, dependency chains, what-if, alerts"},
+	{"bv --robot-blocker-chain <id>", "Understand why an issue is blocked and what to finish first"},
+	{"bv --robot-impact <paths>", "Before editing files: which open issues touch them"},
+	{"bv --robot-file-beads <path>", "History of issues that changed a file"},
+	{"bv --robot-search --search <query>", "Find existing issues before creating a duplicate"},
+	{"bv --robot-suggest", "Likely duplicates, missing dependencies, and cycles to fix"},
+	{"bv --robot-insights", "Graph metrics (PageRank, betweenness, critical path) for deeper analysis"},
+	{"bv --robot-priority", "Issues whose priority looks wrong given their impact"},
+	{"bv --robot-alerts", "Stale issues, blocking cascades, and other drift signals"},
+	{"bv --robot-diff --diff-since <ref>", "What changed since a commit or date, e.g. since your last session"},
+	{"bv --robot-history", "Which commits implemented which issues"},
+	{"bv --robot-forecast <id|all>", "ETA estimates for planning"},
+	{"bv --robot-status", "Health check: data file, running instances, and whether analysis is cached"},
+	{"bv --robot-help", "Every robot flag with its output fields"},
+}
+
+// buildRobotOnboard assembles the --robot-onboard payload. triage must come
+// from the same issues.
+func buildRobotOnboard(issues []model.Issue, triage analysis.TriageResult, beadsDir, dataHash string) robotOnboard {
+	info := agents.LoadProjectInfo(beadsDir)
+	out := robotOnboard{
+		GeneratedAt: time.Now().UTC().Format(time.RFC3339),
+		DataHash:    dataHash,
+		Project: robotOnboardProject{
+			Name:            info.Name,
+			IssuePrefix:     info.IssuePrefix,
+			BeadsDir:        beadsDir,
+			IssueCount:      len(issues),
+			OpenCount:       triage.QuickRef.OpenCount,
+			ActionableCount: triage.QuickRef.ActionableCount,
+			BlockedCount:    triage.QuickRef.BlockedCount,
+			InProgressCount: triage.QuickRef.InProgressCount,
+		},
+		Claims:   []robotOnboardClaim{},
+		Commands: robotCommandHints,
+	}
+
+	bd := "bd"
+	if info.BdPath != "" {
+		bd = info.BdPath
+	}
+	if len(triage.QuickRef.TopPicks) > 0 {
+		top := triage.QuickRef.TopPicks[0]
+		out.TopPick = &robotOnboardPick{
+			ID:       top.ID,
+			Title:    top.Title,
+			Score:    top.Score,
+			Reasons:  top.Reasons,
+			Unblocks: top.Unblocks,
+			ClaimCmd: fmt.Sprintf("%s update %s --status=in_progress", bd, top.ID),
+			ShowCmd:  fmt.Sprintf("%s show %s", bd, top.ID),
+		}
+	}
+
+	for _, issue := range issues {
+		switch issue.Status {
+		case model.StatusClosed:
+			out.Project.ClosedCount++
+		case model.StatusInProgress:
+			out.Claims = append(out.Claims, robotOnboardClaim{
+				ID:        issue.ID,
+				Title:     issue.Title,
+				Assignee:  issue.Assignee,
+				Priority:  issue.Priority,
+				UpdatedAt: issue.UpdatedAt,
+			})
+		}
+	}
+	sort.Slice(out.Claims, func(i, j int) bool {
+		return out.Claims[i].UpdatedAt.After(out.Claims[j].UpdatedAt)
+	})
+
+	out.Conventions = onboardConventions(filepath.Dir(beadsDir))
+	out.NextSteps = onboardNextSteps(out, bd)
+	return out
+}
+
+// onboardConventions reads the project's agent file, or returns nil if
+// there is none.
+func onboardConventions(projectDir string) *robotConventions {
+	d := agents.DetectAgentFile(projectDir)
+	if !d.Found() {
+		return nil
+	}
+	c := agents.ExtractConventions(d.Content, maxOnboardRules)
+	conv := &robotConventions{
+		File:       d.FilePath,
+		HasBvBlurb: d.HasBlurb,
+		Headings:   c.Headings,
+		Rules:      c.Rules,
+		Truncated:  c.Truncated,
+	}
+	if !d.HasBlurb {
+		conv.InstallHint = "bv agents install"
+	}
+	return conv
+}
+
+// onboardNextSteps suggests what to do first given the payload.
+func onboardNextSteps(o robotOnboard, bd string) []string {
+	var steps []string
+	if o.Conventions != nil {
+		steps = append(steps, "Read "+filepath.Base(o.Conventions.File)+" in full; conventions.rules is only a summary")
+	}
+	if len(o.Claims) > 0 {
+		steps = append(steps, fmt.Sprintf("%d issue(s) are already in progress; don't take one another agent has claimed", len(o.Claims)))
+	}
+	if o.TopPick != nil {
+		steps = append(steps, "Claim the top pick: "+o.TopPick.ClaimCmd)
+	} else {
+		steps = append(steps, "Nothing is actionable; run `bv --robot-triage` to see what's blocking")
+	}
+	steps = append(steps, "When done: "+bd+" close <id>, then "+bd+" sync")
+	return steps
+}
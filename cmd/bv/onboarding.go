@@ -0,0 +1,51 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"golang.org/x/term"
+)
+
+// shouldOfferOnboarding reports whether a failed load should open the
+// first-run wizard instead of exiting. Only a missing beads directory or file
+// qualifies: parse and permission errors are reported as-is, since the wizard
+// would wrongly claim no data exists. The wizard needs a real terminal on
+// both ends and must never run for agents, tests, or piped output.
+func shouldOfferOnboarding(err error, robotMode bool) bool {
+	if !errors.Is(err, loader.ErrNoData) || robotMode || os.Getenv("BV_TEST_MODE") != "" || os.Getenv("BV_NO_ONBOARDING") != "" {
+		return false
+	}
+	return term.IsTerminal(int(os.Stdin.Fd())) && term.IsTerminal(int(os.Stdout.Fd()))
+}
+
+// runOnboarding runs the first-run wizard and returns the beads directory the
+// user picked (or initialized). ok is false when the user quit.
+func runOnboarding() (beadsDir string, ok bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", false
+	}
+	theme := ui.DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	p := tea.NewProgram(ui.NewOnboardingModel(cwd, theme), tea.WithAltScreen())
+	final, err := p.Run()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error running setup wizard: %v\n", err)
+		return "", false
+	}
+	m, isOnboarding := final.(ui.OnboardingModel)
+	if !isOnboarding {
+		return "", false
+	}
+	res := m.Result()
+	if res.BeadsDir == "" {
+		return "", false
+	}
+	return res.BeadsDir, true
+}
@@ -0,0 +1,184 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"golang.org/x/sync/errgroup"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/orgreport"
+)
+
+// orgReportParallel caps concurrent clones and analyses.
+const orgReportParallel = 4
+
+// runOrgReportCommand implements `bv org-report`. Returns the process exit
+// code: 0 when every repository was read, 1 when one couldn't be (the
+// report still lists it) or the report can't be written, 2 on usage errors.
+func runOrgReportCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("org-report", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	reposFile := fs.String("repos", "", "File listing the repositories: a directory or git URL per line, optionally followed by a name")
+	output := fs.String("output", "", "File to write the JSON report to (default: stdout)")
+	htmlOut := fs.String("html", "", "Also write the report as an HTML page to this file")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv org-report --repos FILE [--output FILE] [--html FILE]")
+		fmt.Fprintln(stderr, "\nCompare the health of several projects: open, blocked and actionable")
+		fmt.Fprintln(stderr, "counts, dependency cycles, velocity and alerts, one row per repository.")
+		fmt.Fprintln(stderr, "Git URLs are shallow-cloned into a temporary directory; relative paths")
+		fmt.Fprintln(stderr, "are resolved against the list's directory. Lines starting with # are")
+		fmt.Fprintln(stderr, "ignored.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 || *reposFile == "" {
+		fs.Usage()
+		return 2
+	}
+
+	f, err := os.Open(*reposFile)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	sources, err := orgreport.ParseRepos(f)
+	f.Close()
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %s: %v\n", *reposFile, err)
+		return 1
+	}
+	listDir, err := filepath.Abs(filepath.Dir(*reposFile))
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	cloneDir, err := os.MkdirTemp("", "bv-org-report-")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	defer os.RemoveAll(cloneDir)
+
+	now := time.Now()
+	repos := make([]orgreport.Repo, len(sources))
+	var g errgroup.Group
+	g.SetLimit(orgReportParallel)
+	for i, src := range sources {
+		i, src := i, src
+		g.Go(func() error {
+			repos[i] = orgReportRepo(src, listDir, filepath.Join(cloneDir, fmt.Sprint(i)), now)
+			return nil
+		})
+	}
+	_ = g.Wait()
+	report := orgreport.NewReport(repos, now)
+
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error encoding report: %v\n", err)
+		return 1
+	}
+	content = append(content, '\n')
+	if *output == "" || *output == "-" {
+		_, err = stdout.Write(content)
+	} else {
+		err = os.WriteFile(*output, content, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	if *htmlOut != "" {
+		var buf bytes.Buffer
+		if err := orgreport.WriteHTML(&buf, report); err != nil {
+			fmt.Fprintf(stderr, "Error rendering HTML report: %v\n", err)
+			return 1
+		}
+		if err := os.WriteFile(*htmlOut, buf.Bytes(), 0o644); err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 1
+		}
+	}
+
+	for _, r := range report.Repos {
+		if r.Error != "" {
+			fmt.Fprintf(stderr, "%s: %s\n", r.Name, r.Error)
+		}
+	}
+	if report.Totals.Failed > 0 {
+		return 1
+	}
+	return 0
+}
+
+// orgReportRepo reads src's beads file, cloning it into cloneDir first if
+// it's a git URL, and analyzes it. Failures are recorded in the row.
+func orgReportRepo(src orgreport.Source, listDir, cloneDir string, now time.Time) orgreport.Repo {
+	repo := orgreport.Repo{Name: src.Name, Source: src.Location}
+	dir := src.Location
+	switch {
+	case orgreport.IsRemote(dir):
+		cmd := exec.Command("git", "clone", "--quiet", "--depth", "1", "--", dir, cloneDir)
+		// Fail instead of waiting for credentials nobody will type
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			repo.Error = fmt.Sprintf("git clone: %v: %s", err, strings.TrimSpace(string(out)))
+			return repo
+		}
+		dir = cloneDir
+	case !filepath.IsAbs(dir):
+		dir = filepath.Join(listDir, dir)
+	}
+
+	// Each repository's own .beads, whatever BEADS_DIR says
+	path, err := loader.FindJSONLPath(filepath.Join(dir, ".beads"))
+	if err != nil {
+		repo.Error = err.Error()
+		return repo
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		repo.Error = err.Error()
+		return repo
+	}
+
+	repo.Issues = len(issues)
+	repo.Velocity = *analysis.ComputeProjectVelocity(issues, now, orgreport.VelocityWeeks)
+	staleBefore := now.Add(-analysis.DefaultStaleThresholdDays * 24 * time.Hour)
+	for _, issue := range issues {
+		if issue.Status.IsOpen() && !issue.UpdatedAt.IsZero() && issue.UpdatedAt.Before(staleBefore) {
+			repo.StaleCount++
+		}
+	}
+	if len(issues) == 0 {
+		return repo
+	}
+	analyzer := analysis.NewAnalyzer(issues)
+	stats := analyzer.Analyze()
+	repo.Stats = graphStatsFor(issues, analyzer, &stats)
+	repo.Cycles = stats.Cycles()
+	repo.HasCycles = len(repo.Cycles) > 0
+	alerts, err := computeAlerts(issues, dir, baseline.DefaultPath(dir), true)
+	if err != nil {
+		repo.Error = fmt.Sprintf("alerts: %v", err)
+		return repo
+	}
+	repo.Alerts = orgreport.AlertCounts{Critical: alerts.CriticalCount, Warning: alerts.WarningCount, Info: alerts.InfoCount}
+	return repo
+}
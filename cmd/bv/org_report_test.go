@@ -0,0 +1,107 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/orgreport"
+)
+
+func TestRunOrgReportCommand(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	t.Setenv("BEADS_DIR", "")
+	root := t.TempDir()
+	writeBeads := func(dir string, lines ...string) {
+		t.Helper()
+		path := filepath.Join(root, dir, ".beads", "issues.jsonl")
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	writeBeads("api",
+		`{"id":"a-1","title":"One","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-2","type":"blocks"}]}`,
+		`{"id":"a-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-1","type":"blocks"}]}`,
+	)
+	writeBeads("web",
+		`{"id":"w-1","title":"One","status":"open","issue_type":"task"}`,
+		`{"id":"w-2","title":"Two","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"w-1","type":"blocks"}]}`,
+		`{"id":"w-3","title":"Three","status":"closed","issue_type":"task"}`,
+	)
+	// web is reported from a clone
+	web := filepath.Join(root, "web")
+	for _, args := range [][]string{{"init", "-q"}, {"add", ".beads"}, {"commit", "-qm", "beads"}} {
+		cmd := exec.Command("git", append([]string{"-c", "user.name=t", "-c", "user.email=t@example.com"}, args...)...)
+		cmd.Dir = web
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	list := filepath.Join(root, "repos.txt")
+	writeList := func(lines ...string) {
+		t.Helper()
+		if err := os.WriteFile(list, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	run := func(args ...string) (int, string, string) {
+		var stdout, stderr bytes.Buffer
+		code := runOrgReportCommand(args, &stdout, &stderr)
+		return code, stdout.String(), stderr.String()
+	}
+
+	writeList("# team repos", "api", "file://"+filepath.ToSlash(web)+" Web app", "missing")
+	page := filepath.Join(root, "report.html")
+	code, stdout, stderr := run("--repos", list, "--html", page)
+	if code != 1 {
+		t.Fatalf("exit %d with a missing repo, want 1; stderr: %s", code, stderr)
+	}
+	if !strings.Contains(stderr, "missing: ") {
+		t.Errorf("stderr doesn't name the missing repo: %s", stderr)
+	}
+	var report orgreport.Report
+	if err := json.Unmarshal([]byte(stdout), &report); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, stdout)
+	}
+	if len(report.Repos) != 3 {
+		t.Fatalf("got %d repos, want 3", len(report.Repos))
+	}
+	api, webRepo, missing := report.Repos[0], report.Repos[1], report.Repos[2]
+	if api.Name != "api" || !api.HasCycles || api.Health != "critical" || api.Stats.OpenCount != 2 {
+		t.Errorf("api = %+v, want an open cycle graded critical", api)
+	}
+	if webRepo.Name != "Web app" || webRepo.Error != "" || webRepo.HasCycles || webRepo.Issues != 3 || webRepo.Stats.ActionableCount != 1 || webRepo.Stats.ClosedCount != 1 {
+		t.Errorf("cloned web = %+v", webRepo)
+	}
+	if missing.Error == "" {
+		t.Errorf("missing repo has no error: %+v", missing)
+	}
+	if report.Totals.Failed != 1 || report.Totals.WithCycles != 1 {
+		t.Errorf("totals = %+v", report.Totals)
+	}
+	html, err := os.ReadFile(page)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(html), "Web app") {
+		t.Error("HTML report doesn't list the cloned repo")
+	}
+
+	writeList("api")
+	if code, _, stderr := run("--repos", list, "--output", filepath.Join(root, "report.json")); code != 0 {
+		t.Errorf("exit %d with every repo read, want 0; stderr: %s", code, stderr)
+	}
+	if code, _, _ := run(); code != 2 {
+		t.Errorf("exit %d without --repos, want 2", code)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// loadCodeOwners reads the CODEOWNERS file of the repository at root, or
+// returns nil when there is none. Like rules, a broken file only warns.
+func loadCodeOwners(root string) *codeowners.File {
+	owners, err := codeowners.Load(root)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v; continuing without CODEOWNERS\n", err)
+		return nil
+	}
+	return owners
+}
+
+// issueOwners maps issue IDs to the owners of their likely files. xref may
+// be nil, leaving only the files issues declare. Issues without owners are
+// absent; the map is nil without a CODEOWNERS file.
+func issueOwners(owners *codeowners.File, issues []model.Issue, xref *correlation.XRefReport) map[string][]string {
+	if owners == nil {
+		return nil
+	}
+	byID := make(map[string][]string)
+	for _, issue := range issues {
+		if o := owners.IssueOwners(issue, xref.For(issue.ID)); len(o) > 0 {
+			byID[issue.ID] = o
+		}
+	}
+	return byID
+}
+
+// codeOwnersFor is issueOwners for the repository at root, scanning its
+// history for linked commits only when it has a CODEOWNERS file.
+func codeOwnersFor(root string, issues []model.Issue) map[string][]string {
+	owners := loadCodeOwners(root)
+	if owners == nil {
+		return nil
+	}
+	xref, _ := correlation.CrossReference(root, issueIDsOf(issues), correlation.XRefOptions{})
+	return issueOwners(owners, issues, xref)
+}
+
+// redactOwner is owner under --redact: the same pseudonym as an assignee
+// or commit author of that name, so "@alice" and "alice" still agree.
+func redactOwner(r model.Redactor, owner string) string {
+	return r.Person(strings.TrimPrefix(owner, "@"))
+}
+
+// redactOwners returns byID with every owner redacted.
+func redactOwners(r model.Redactor, byID map[string][]string) map[string][]string {
+	if byID == nil {
+		return nil
+	}
+	out := make(map[string][]string, len(byID))
+	for id, owners := range byID {
+		redacted := make([]string, len(owners))
+		for i, o := range owners {
+			redacted[i] = redactOwner(r, o)
+		}
+		out[id] = redacted
+	}
+	return out
+}
@@ -0,0 +1,47 @@
+package main
+
+import (
+	"context"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/plugins"
+)
+
+// pluginTimeout returns BV_PLUGIN_TIMEOUT_S (seconds) or the default.
+func pluginTimeout() time.Duration {
+	if n, err := strconv.Atoi(os.Getenv("BV_PLUGIN_TIMEOUT_S")); err == nil && n > 0 {
+		return time.Duration(n) * time.Second
+	}
+	return plugins.DefaultTimeout
+}
+
+// runAnalyzerPlugins runs the bv-analyzer-* executables on PATH over issues
+// for --robot-insights. It returns nil when none are installed.
+func runAnalyzerPlugins(issues []model.Issue, stats *analysis.GraphStats, dataHash string) []plugins.Result {
+	found := plugins.Discover(os.Getenv("PATH"))
+	if len(found) == 0 {
+		return nil
+	}
+	analyzers := make([]plugins.Analyzer, len(found))
+	for i, a := range found {
+		analyzers[i] = a
+	}
+	in := plugins.Input{
+		DataHash: dataHash,
+		Issues:   issues,
+		Metrics: map[string]map[string]float64{
+			"pagerank":      stats.PageRank(),
+			"betweenness":   stats.Betweenness(),
+			"eigenvector":   stats.Eigenvector(),
+			"hubs":          stats.Hubs(),
+			"authorities":   stats.Authorities(),
+			"critical_path": stats.CriticalPathScore(),
+			"slack":         stats.Slack(),
+		},
+	}
+	return plugins.Run(context.Background(), analyzers, in, pluginTimeout())
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"testing"
+)
+
+func TestRobotInsightsPluginsAreOptIn(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts here")
+	}
+	exe := buildTestBinary(t)
+	repo := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(repo, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"A-1","title":"One","status":"open","priority":1,"issue_type":"task"}` + "\n"
+	if err := os.WriteFile(filepath.Join(repo, ".beads", "issues.jsonl"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	binDir := t.TempDir()
+	marker := filepath.Join(t.TempDir(), "ran")
+	script := "#!/bin/sh\ntouch " + marker + "\necho '{}'\n"
+	if err := os.WriteFile(filepath.Join(binDir, "bv-analyzer-probe"), []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) map[string]json.RawMessage {
+		t.Helper()
+		cmd := exec.Command(exe, args...)
+		cmd.Dir = repo
+		cmd.Env = append(os.Environ(), "BEADS_DIR=", "BV_CACHE_DIR="+t.TempDir(),
+			"PATH="+binDir+string(os.PathListSeparator)+os.Getenv("PATH"))
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%v: %v", args, err)
+		}
+		var doc map[string]json.RawMessage
+		if err := json.Unmarshal(out, &doc); err != nil {
+			t.Fatalf("%v: decoding output: %v", args, err)
+		}
+		return doc
+	}
+
+	if doc := run("--robot-insights"); doc["plugins"] != nil {
+		t.Errorf("plugins ran without --plugins: %s", doc["plugins"])
+	}
+	if _, err := os.Stat(marker); !os.IsNotExist(err) {
+		t.Fatal("plugin executable ran without --plugins")
+	}
+
+	doc := run("--robot-insights", "--plugins")
+	var results []struct {
+		Name string `json:"name"`
+		OK   bool   `json:"ok"`
+	}
+	if err := json.Unmarshal(doc["plugins"], &results); err != nil || len(results) != 1 || results[0].Name != "probe" || !results[0].OK {
+		t.Errorf("--plugins: got %s (%v)", doc["plugins"], err)
+	}
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/muesli/termenv"
+	"golang.org/x/term"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+)
+
+// printViews lists the views `bv print` can render.
+var printViews = []string{"board", "tree", "graph", "insights", "history"}
+
+// runPrintCommand implements `bv print <view>`: it renders a TUI view once,
+// headlessly, and writes it to stdout. Useful for tmux popups, CI summaries,
+// and screenshots. Returns the process exit code.
+func runPrintCommand(args []string, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("print", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	width := fs.Int("width", 0, "Render width in columns (default: terminal width, or 120 when piped)")
+	height := fs.Int("height", 0, "Render height in rows (default: terminal height, or 50 when piped)")
+	color := fs.String("color", "auto", "ANSI styling: auto, always, or never")
+	ascii := fs.Bool("ascii", false, "Use ASCII tags instead of emoji icons")
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: bv print <%s> [--width N] [--height N] [--color auto|always|never] [--ascii]\n\n", strings.Join(printViews, "|"))
+		fmt.Fprintln(stderr, "Render a TUI view as static text to stdout.")
+		fs.PrintDefaults()
+	}
+
+	// Allow the view name before or after the flags.
+	var view string
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		view, args = args[0], args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if view == "" && fs.NArg() > 0 {
+		view = fs.Arg(0)
+	}
+	if !isPrintView(view) {
+		if view == "" {
+			fmt.Fprintln(stderr, "Error: missing view name")
+		} else {
+			fmt.Fprintf(stderr, "Error: unknown view %q\n", view)
+		}
+		fs.Usage()
+		return 2
+	}
+
+	if *ascii {
+		ui.SetASCIIIcons(true)
+	}
+
+	profile, err := printColorProfile(*color, stdout)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 2
+	}
+
+	w, h := *width, *height
+	if f, ok := stdout.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		if tw, th, err := term.GetSize(int(f.Fd())); err == nil {
+			if w <= 0 {
+				w = tw
+			}
+			if h <= 0 {
+				h = th
+			}
+		}
+	}
+	if w <= 0 {
+		w = 120
+	}
+	if h <= 0 {
+		h = 50
+	}
+
+	issues, err := loader.LoadIssues("")
+	if err != nil {
+		fmt.Fprintf(stderr, "Error loading beads: %v\n", err)
+		return 1
+	}
+
+	m := ui.NewModel(issues, nil, "")
+	defer m.Stop()
+	m.SetColorProfile(profile)
+
+	fmt.Fprintln(stdout, m.RenderDebugView(view, w, h))
+	return 0
+}
+
+func isPrintView(view string) bool {
+	for _, v := range printViews {
+		if v == view {
+			return true
+		}
+	}
+	return false
+}
+
+// printColorProfile resolves --color. "auto" keeps colors only when stdout is
+// a terminal and NO_COLOR is unset.
+func printColorProfile(mode string, stdout io.Writer) (termenv.Profile, error) {
+	switch mode {
+	case "always":
+		return termenv.ANSI256, nil
+	case "never":
+		return termenv.Ascii, nil
+	case "auto":
+		if os.Getenv("NO_COLOR") != "" {
+			return termenv.Ascii, nil
+		}
+		if f, ok := stdout.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+			return termenv.NewOutput(f).EnvColorProfile(), nil
+		}
+		return termenv.Ascii, nil
+	default:
+		return termenv.Ascii, fmt.Errorf("invalid --color %q (want auto, always, or never)", mode)
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+)
+
+func TestRunPrintCommand(t *testing.T) {
+	dir := t.TempDir()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"bv-1","title":"Root epic","status":"open","priority":1,"issue_type":"epic","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z"}
+{"id":"bv-2","title":"Child task","status":"in_progress","priority":2,"issue_type":"task","created_at":"2025-01-01T00:00:00Z","updated_at":"2025-01-01T00:00:00Z","dependencies":[{"issue_id":"bv-2","depends_on_id":"bv-1","type":"parent-child"}]}
+`
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+
+	t.Run("tree plain", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		if code := runPrintCommand([]string{"tree", "--width", "80", "--height", "10"}, &out, &errOut); code != 0 {
+			t.Fatalf("exit %d: %s", code, errOut.String())
+		}
+		if !strings.Contains(out.String(), "bv-1") || !strings.Contains(out.String(), "bv-2") {
+			t.Errorf("tree output missing issues:\n%s", out.String())
+		}
+		if strings.Contains(out.String(), "\x1b[") {
+			t.Errorf("expected no ANSI escapes when piped, got:\n%q", out.String())
+		}
+	})
+
+	t.Run("board color always", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		if code := runPrintCommand([]string{"board", "--color=always", "--width=100"}, &out, &errOut); code != 0 {
+			t.Fatalf("exit %d: %s", code, errOut.String())
+		}
+		if !strings.Contains(out.String(), "\x1b[") {
+			t.Error("expected ANSI escapes with --color=always")
+		}
+	})
+
+	t.Run("flags before view", func(t *testing.T) {
+		var out, errOut bytes.Buffer
+		if code := runPrintCommand([]string{"--width", "80", "graph"}, &out, &errOut); code != 0 {
+			t.Fatalf("exit %d: %s", code, errOut.String())
+		}
+	})
+
+	t.Run("ascii", func(t *testing.T) {
+		t.Cleanup(func() { ui.SetASCIIIcons(false) })
+		var out, errOut bytes.Buffer
+		if code := runPrintCommand([]string{"board", "--ascii", "--width", "120"}, &out, &errOut); code != 0 {
+			t.Fatalf("exit %d: %s", code, errOut.String())
+		}
+		if !strings.Contains(out.String(), "[OPEN]") {
+			t.Errorf("expected ASCII column tags, got:\n%s", out.String())
+		}
+	})
+
+	t.Run("bad input", func(t *testing.T) {
+		for _, args := range [][]string{{}, {"nope"}, {"board", "--color", "rainbow"}} {
+			var out, errOut bytes.Buffer
+			if code := runPrintCommand(args, &out, &errOut); code != 2 {
+				t.Errorf("args %v: exit %d, want 2", args, code)
+			}
+		}
+	})
+}
@@ -218,4 +218,19 @@ func TestRunProfileStartupJSON(t *testing.T) {
 	if payload["profile"] == nil {
 		t.Fatalf("expected profile field in output")
 	}
+	stages, _ := payload["stages"].([]any)
+	names := make(map[string]bool)
+	for _, st := range stages {
+		if m, ok := st.(map[string]any); ok {
+			names[m["name"].(string)] = true
+		}
+	}
+	for _, want := range []string{"build_graph", "pagerank", "cycles", "triage", "render_first_frame"} {
+		if !names[want] {
+			t.Errorf("stages missing %q: %v", want, stages)
+		}
+	}
+	if _, ok := payload["cache"].([]any); !ok {
+		t.Errorf("expected cache array in output, got %T", payload["cache"])
+	}
 }
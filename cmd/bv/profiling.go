@@ -0,0 +1,132 @@
+package main
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"os"
+	"runtime"
+	runtimepprof "runtime/pprof"
+	"sync"
+)
+
+// Runtime profiling for slowness reports: --cpuprofile / --memprofile write
+// standard pprof files, and --pprof-addr serves net/http/pprof while a
+// long-running mode (TUI, --preview-pages, --watch-export) is up:
+//
+//	bv --cpuprofile cpu.out --robot-triage
+//	go tool pprof cpu.out
+//
+//	bv --pprof-addr localhost:6060
+//	go tool pprof http://localhost:6060/debug/pprof/profile?seconds=10
+
+// profiler owns the active profile outputs so they can be flushed from every
+// exit path.
+type profiler struct {
+	mu      sync.Mutex
+	cpuFile *os.File
+	memPath string
+	stopped bool
+}
+
+var activeProfiler = &profiler{}
+
+// startProfiling begins CPU profiling (if cpuPath is set) and remembers
+// memPath for a heap profile at exit.
+func startProfiling(cpuPath, memPath string) error {
+	p := activeProfiler
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.memPath = memPath
+	if cpuPath == "" {
+		return nil
+	}
+	f, err := os.Create(cpuPath)
+	if err != nil {
+		return fmt.Errorf("--cpuprofile: %w", err)
+	}
+	if err := runtimepprof.StartCPUProfile(f); err != nil {
+		f.Close()
+		return fmt.Errorf("--cpuprofile: %w", err)
+	}
+	p.cpuFile = f
+	return nil
+}
+
+// stopProfiling flushes the CPU profile and writes the heap profile. Safe to
+// call more than once; only the first call does anything.
+func stopProfiling() {
+	p := activeProfiler
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.stopped {
+		return
+	}
+	p.stopped = true
+	if p.cpuFile != nil {
+		runtimepprof.StopCPUProfile()
+		p.cpuFile.Close()
+	}
+	if p.memPath != "" {
+		if err := writeHeapProfile(p.memPath); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: --memprofile: %v\n", err)
+		}
+	}
+}
+
+func writeHeapProfile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	runtime.GC() // up-to-date allocation statistics
+	return runtimepprof.WriteHeapProfile(f)
+}
+
+// pprofListenAddr normalizes --pprof-addr. A bare port (":6060" or "6060")
+// binds to loopback; profiling data should not be reachable from the network
+// unless the user names a host explicitly.
+func pprofListenAddr(addr string) (string, error) {
+	if _, _, err := net.SplitHostPort(addr); err != nil {
+		addr = ":" + addr
+	}
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil || port == "" {
+		return "", fmt.Errorf("--pprof-addr: invalid address %q (want host:port or :port)", addr)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// pprofMux serves the net/http/pprof handlers on their usual paths, on a
+// dedicated mux so they never leak onto the preview server.
+func pprofMux() *http.ServeMux {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/debug/pprof/", pprof.Index)
+	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
+	mux.HandleFunc("/debug/pprof/profile", pprof.Profile)
+	mux.HandleFunc("/debug/pprof/symbol", pprof.Symbol)
+	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
+	return mux
+}
+
+// startPprofServer listens on addr and serves pprof in the background.
+// Returns the bound address (useful when the port is 0).
+func startPprofServer(addr string) (string, error) {
+	listenAddr, err := pprofListenAddr(addr)
+	if err != nil {
+		return "", err
+	}
+	ln, err := net.Listen("tcp", listenAddr)
+	if err != nil {
+		return "", fmt.Errorf("--pprof-addr: %w", err)
+	}
+	go func() {
+		_ = http.Serve(ln, pprofMux())
+	}()
+	return ln.Addr().String(), nil
+}
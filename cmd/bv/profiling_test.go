@@ -0,0 +1,76 @@
+package main
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPprofListenAddr(t *testing.T) {
+	tests := []struct {
+		in, want string
+		wantErr  bool
+	}{
+		{in: ":6060", want: "127.0.0.1:6060"},
+		{in: "6060", want: "127.0.0.1:6060"},
+		{in: "localhost:6060", want: "localhost:6060"},
+		{in: "0.0.0.0:7070", want: "0.0.0.0:7070"},
+		{in: "localhost:", wantErr: true},
+	}
+	for _, tt := range tests {
+		got, err := pprofListenAddr(tt.in)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("pprofListenAddr(%q) = %q, want error", tt.in, got)
+			}
+			continue
+		}
+		if err != nil || got != tt.want {
+			t.Errorf("pprofListenAddr(%q) = %q, %v; want %q", tt.in, got, err, tt.want)
+		}
+	}
+}
+
+func TestStartPprofServer(t *testing.T) {
+	addr, err := startPprofServer("127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("startPprofServer: %v", err)
+	}
+	resp, err := http.Get("http://" + addr + "/debug/pprof/goroutine?debug=1")
+	if err != nil {
+		t.Fatalf("GET goroutine profile: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+	if resp.StatusCode != http.StatusOK || !strings.Contains(string(body), "goroutine profile") {
+		t.Errorf("unexpected response %d: %.200s", resp.StatusCode, body)
+	}
+}
+
+func TestStartStopProfiling(t *testing.T) {
+	orig := activeProfiler
+	activeProfiler = &profiler{}
+	defer func() { activeProfiler = orig }()
+
+	dir := t.TempDir()
+	cpu := filepath.Join(dir, "cpu.out")
+	mem := filepath.Join(dir, "mem.out")
+	if err := startProfiling(cpu, mem); err != nil {
+		t.Fatalf("startProfiling: %v", err)
+	}
+	stopProfiling()
+	stopProfiling() // second call is a no-op
+
+	for _, path := range []string{cpu, mem} {
+		info, err := os.Stat(path)
+		if err != nil {
+			t.Fatalf("profile not written: %v", err)
+		}
+		if info.Size() == 0 {
+			t.Errorf("%s is empty", path)
+		}
+	}
+}
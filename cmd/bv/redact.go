@@ -0,0 +1,130 @@
+package main
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// --redact hashes the issues as they load, but robot outputs also carry
+// data that never passes through an issue: commit authors, messages and
+// file paths from git, and issue text from older revisions of the beads
+// file. Rather than redact each of those outputs, robot mode sends stdout
+// through a Scrubber that knows every such value, so an output added later
+// is covered without doing anything.
+
+// redactedStdout owns the stdout pipe so it can be drained from every exit
+// path.
+type redactedStdout struct {
+	mu   sync.Mutex
+	orig *os.File
+	w    *os.File
+	done chan struct{}
+}
+
+var activeRedaction = &redactedStdout{}
+
+// newOutputScrubber returns a Scrubber for the issues, as loaded before
+// redaction, and the git history and CODEOWNERS owners of the repository
+// in dir.
+func newOutputScrubber(r model.Redactor, issues []model.Issue, dir string) *model.Scrubber {
+	s := r.NewScrubber()
+	for _, issue := range issues {
+		s.Text(issue.Title)
+		s.Text(issue.Description)
+		s.Text(issue.Design)
+		s.Text(issue.AcceptanceCriteria)
+		s.Text(issue.Notes)
+		s.Person(issue.Assignee)
+		for _, c := range issue.Comments {
+			if c != nil {
+				s.Person(c.Author)
+				s.Text(c.Text)
+			}
+		}
+	}
+	addGitHistory(s, dir)
+	if owners, err := codeowners.Load(dir); err == nil && owners != nil {
+		for _, rule := range owners.Rules {
+			for _, o := range rule.Owners {
+				s.Person(strings.TrimPrefix(o, "@"))
+			}
+		}
+	}
+	return s
+}
+
+// addGitHistory registers the authors, committers, messages and touched
+// paths of every commit in the repository at dir. Outside a repository
+// there is nothing to add.
+func addGitHistory(s *model.Scrubber, dir string) {
+	cmd := exec.Command("git", "-c", "core.quotepath=off", "log", "--all", "--name-only",
+		"--format=%x1e%an%x00%ae%x00%cn%x00%ce%x00%B%x00")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return
+	}
+	for _, rec := range strings.Split(string(out), "\x1e") {
+		fields := strings.SplitN(rec, "\x00", 6)
+		if len(fields) < 6 {
+			continue
+		}
+		for _, person := range fields[:4] {
+			s.Person(person)
+		}
+		s.Text(strings.TrimSpace(fields[4]))
+		for _, path := range strings.Split(fields[5], "\n") {
+			s.Path(strings.TrimSpace(path))
+		}
+	}
+}
+
+// startRedactingStdout replaces os.Stdout with a pipe whose lines are
+// scrubbed by s before they reach the real stdout.
+func startRedactingStdout(s *model.Scrubber) error {
+	p := activeRedaction
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	r, w, err := os.Pipe()
+	if err != nil {
+		return err
+	}
+	p.orig, p.w, p.done = os.Stdout, w, make(chan struct{})
+	os.Stdout = w
+	go func(orig *os.File, done chan struct{}) {
+		defer close(done)
+		br := bufio.NewReader(r)
+		for {
+			line, err := br.ReadString('\n')
+			if line != "" {
+				_, _ = io.WriteString(orig, s.Line(line))
+			}
+			if err != nil {
+				return
+			}
+		}
+	}(p.orig, p.done)
+	return nil
+}
+
+// stopRedactingStdout flushes everything written so far and restores the
+// real stdout. Safe to call more than once.
+func stopRedactingStdout() {
+	p := activeRedaction
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.w == nil {
+		return
+	}
+	_ = p.w.Close()
+	<-p.done
+	os.Stdout = p.orig
+	p.w = nil
+}
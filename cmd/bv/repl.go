@@ -0,0 +1,325 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	json "github.com/goccy/go-json"
+	"golang.org/x/term"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
+)
+
+// replCommands are the commands `bv repl` accepts, in help order.
+var replCommands = []robotCommandHint{
+	{"triage", "Ranked recommendations, quick wins, and blockers (as --robot-triage)"},
+	{"show ID [ID...]", "Everything about one issue, or a batch (as --robot-show / --ids)"},
+	{"whatif ID", "What closing an open issue would unblock"},
+	{"query [key=value...] [words]", "Filtered issue list (as --robot-list); keys: label, status, sort, limit, cursor, fields"},
+	{"reload", "Re-read the beads file and .bv/rules.yaml now (the beads file is also re-read whenever it changes)"},
+	{"help", "This list"},
+	{"quit", "End the session (EOF works too)"},
+}
+
+// replResponse is the JSON line `bv repl` writes for each command.
+type replResponse struct {
+	Command  string `json:"command"`
+	OK       bool   `json:"ok"`
+	DataHash string `json:"data_hash,omitempty"`
+	Result   any    `json:"result,omitempty"`
+	Error    string `json:"error,omitempty"`
+}
+
+// replSession is the state `bv repl` keeps between commands: the loaded
+// issues and what has been computed from them so far. Results are computed
+// on first use and dropped when the beads file changes.
+type replSession struct {
+	dir       string    // project directory, for alerts and the baseline
+	stderr    io.Writer // for warnings, such as a broken rules file
+	beadsDir  string
+	beadsPath string
+	modTime   time.Time
+	size      int64
+
+	issues   []model.Issue
+	dataHash string
+	rules    *rules.Set // score rules; nil if .bv/rules.yaml is missing or broken
+
+	triage *analysis.TriageResult
+	scores map[string]float64 // every issue ranked, for query
+	show   *robotShowBuilder
+}
+
+// runReplCommand implements `bv repl`. Returns the process exit code: 0 at
+// quit or end of input, 1 if the beads file can't be loaded at startup.
+func runReplCommand(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	fs := flag.NewFlagSet("repl", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	dir := fs.String("dir", ".", "Project directory")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv repl [--dir DIR]")
+		fmt.Fprintln(stderr, "\nKeep the beads data and its analysis loaded and answer one command per")
+		fmt.Fprintln(stderr, "input line with one JSON line. Type 'help' for the commands.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+
+	projectDir, err := filepath.Abs(*dir)
+	if err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+	s := &replSession{dir: projectDir, stderr: stderr}
+	if err := s.load(); err != nil {
+		fmt.Fprintf(stderr, "Error: %v\n", err)
+		return 1
+	}
+
+	prompt := ""
+	if f, ok := stdin.(*os.File); ok && term.IsTerminal(int(f.Fd())) {
+		prompt = "bv> "
+	}
+	enc := json.NewEncoder(stdout)
+	scanner := bufio.NewScanner(stdin)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for {
+		fmt.Fprint(stderr, prompt)
+		if !scanner.Scan() {
+			break
+		}
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		words := strings.Fields(line)
+		if words[0] == "quit" || words[0] == "exit" {
+			return 0
+		}
+		if err := enc.Encode(s.run(words[0], words[1:])); err != nil {
+			fmt.Fprintf(stderr, "Error encoding response: %v\n", err)
+			return 1
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		fmt.Fprintf(stderr, "Error reading input: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// run executes one command against the session.
+func (s *replSession) run(cmd string, args []string) replResponse {
+	resp := replResponse{Command: cmd}
+	fail := func(format string, a ...any) replResponse {
+		resp.Error = fmt.Sprintf(format, a...)
+		resp.DataHash = s.dataHash
+		return resp
+	}
+
+	var err error
+	if cmd == "reload" {
+		err = s.load()
+	} else {
+		err = s.refresh()
+	}
+	if err != nil {
+		// Keep answering from the last good load
+		return fail("reloading beads: %v", err)
+	}
+
+	switch cmd {
+	case "help":
+		resp.Result = replCommands
+	case "reload":
+		resp.Result = map[string]any{"issues": len(s.issues), "path": s.beadsPath}
+	case "triage":
+		if len(args) > 0 {
+			return fail("triage takes no arguments")
+		}
+		resp.Result = s.triageResult()
+	case "show":
+		ids := parseShowIDs(args...)
+		switch len(ids) {
+		case 0:
+			return fail("usage: show ID [ID...]")
+		case 1:
+			detail, ok := s.showBuilder().detail(ids[0])
+			if !ok {
+				return fail("issue not found: %s", ids[0])
+			}
+			resp.Result = detail
+		default:
+			batch := s.showBuilder().batch(ids)
+			batch.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+			batch.DataHash = s.dataHash
+			resp.Result = batch
+		}
+	case "whatif":
+		if len(args) != 1 {
+			return fail("usage: whatif ID")
+		}
+		if _, ok := s.showBuilder().index[args[0]]; !ok {
+			return fail("issue not found: %s", args[0])
+		}
+		delta := s.showBuilder().analyzer.WhatIf(args[0])
+		if delta == nil {
+			return fail("issue %s is already closed", args[0])
+		}
+		resp.Result = delta
+	case "query", "list":
+		req, err := parseReplQuery(args)
+		if err != nil {
+			return fail("%v", err)
+		}
+		var scores map[string]float64
+		if listNeedsScores(req) {
+			scores = s.listScores()
+		}
+		list, err := buildRobotList(s.issues, scores, nil, req)
+		if err != nil {
+			return fail("%v", err)
+		}
+		list.GeneratedAt = time.Now().UTC().Format(time.RFC3339)
+		list.DataHash = s.dataHash
+		resp.Result = list
+	default:
+		return fail("unknown command %q (try 'help')", cmd)
+	}
+	resp.OK = true
+	resp.DataHash = s.dataHash
+	return resp
+}
+
+// load reads the beads file and drops everything computed from the old data.
+func (s *replSession) load() error {
+	beadsDir, err := loader.GetBeadsDir(s.dir)
+	if err != nil {
+		return err
+	}
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return err
+	}
+	issues, err := loader.LoadIssuesFromFile(path)
+	if err != nil {
+		return err
+	}
+	ruleSet, err := rules.Load(s.dir)
+	if err != nil {
+		fmt.Fprintf(s.stderr, "Warning: %v; continuing without rules\n", err)
+		ruleSet = nil
+	}
+	*s = replSession{
+		dir:       s.dir,
+		stderr:    s.stderr,
+		beadsDir:  beadsDir,
+		beadsPath: path,
+		modTime:   info.ModTime(),
+		size:      info.Size(),
+		issues:    issues,
+		dataHash:  analysis.ComputeDataHash(issues),
+		rules:     ruleSet,
+	}
+	return nil
+}
+
+// refresh reloads if the beads file changed since the last load, so answers
+// never lag behind bd writes.
+func (s *replSession) refresh() error {
+	info, err := os.Stat(s.beadsPath)
+	if err == nil && info.ModTime().Equal(s.modTime) && info.Size() == s.size {
+		return nil
+	}
+	return s.load()
+}
+
+func (s *replSession) triageResult() analysis.TriageResult {
+	if s.triage == nil {
+		triage := analysis.ComputeTriageWithOptions(s.issues, analysis.TriageOptions{
+			WaitForPhase2: true,
+			UseFastConfig: true,
+			ScoreRules:    s.rules,
+		})
+		s.triage = &triage
+	}
+	return *s.triage
+}
+
+func (s *replSession) listScores() map[string]float64 {
+	if s.scores == nil {
+		s.scores = listScores(s.issues, s.rules)
+	}
+	return s.scores
+}
+
+func (s *replSession) showBuilder() *robotShowBuilder {
+	if s.show == nil {
+		alerts := []drift.Alert{}
+		if result, err := computeAlerts(s.issues, s.dir, baseline.DefaultPath(s.dir), true); err == nil {
+			alerts = result.Alerts
+		}
+		s.show = newRobotShowBuilder(s.issues, alerts, bdCommand(s.beadsDir), s.rules)
+	}
+	return s.show
+}
+
+// parseReplQuery turns query arguments into a --robot-list request: known
+// key=value pairs set the matching flag, every other word is search text.
+func parseReplQuery(args []string) (robotListRequest, error) {
+	req := robotListRequest{Limit: defaultListLimit}
+	var words []string
+	for _, a := range args {
+		key, value, ok := strings.Cut(a, "=")
+		if !ok {
+			words = append(words, a)
+			continue
+		}
+		switch key {
+		case "label":
+			req.Label = value
+		case "status":
+			req.Status = value
+		case "sort":
+			req.Sort = value
+		case "cursor":
+			req.Cursor = value
+		case "fields":
+			req.Fields = value
+		case "limit":
+			n, err := strconv.Atoi(value)
+			if err != nil || n < 0 {
+				return req, fmt.Errorf("invalid limit %q", value)
+			}
+			req.Limit = n
+		default:
+			words = append(words, a)
+		}
+	}
+	req.Query = strings.Join(words, " ")
+	return req, nil
+}
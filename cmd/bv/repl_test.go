@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+func writeReplBeads(t *testing.T, dir, content string) {
+	t.Helper()
+	beadsDir := filepath.Join(dir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func decodeReplResponses(t *testing.T, out string) []map[string]any {
+	t.Helper()
+	var resps []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var r map[string]any
+		if err := json.Unmarshal([]byte(line), &r); err != nil {
+			t.Fatalf("response is not one JSON line: %v\n%s", err, line)
+		}
+		resps = append(resps, r)
+	}
+	return resps
+}
+
+const replTestBeads = `{"id":"A-1","title":"Root login fix","status":"open","priority":1,"issue_type":"task"}
+{"id":"A-2","title":"Mid","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"A-2","depends_on_id":"A-1","type":"blocks"}]}
+{"id":"A-3","title":"Done","status":"closed","priority":3,"issue_type":"task"}
+`
+
+func TestReplCommands(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	writeReplBeads(t, dir, replTestBeads)
+
+	input := strings.Join([]string{
+		"# comments and blank lines are skipped",
+		"",
+		"triage",
+		"show A-2",
+		"show A-1,A-9",
+		"show A-9",
+		"whatif A-1",
+		"whatif A-3",
+		"query login",
+		"query status=closed fields=minimal",
+		"query limit=x",
+		"frobnicate",
+		"quit",
+		"help",
+	}, "\n")
+	var stdout, stderr bytes.Buffer
+	if code := runReplCommand([]string{"--dir", dir}, strings.NewReader(input), &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d, stderr: %s", code, stderr.String())
+	}
+	resps := decodeReplResponses(t, stdout.String())
+	if len(resps) != 10 {
+		t.Fatalf("got %d responses, want 10 (nothing after quit):\n%s", len(resps), stdout.String())
+	}
+
+	wantOK := []bool{true, true, true, false, true, false, true, true, false, false}
+	hash := resps[0]["data_hash"]
+	for i, r := range resps {
+		if r["ok"] != wantOK[i] {
+			t.Errorf("response %d (%v): ok = %v, want %v: %v", i, r["command"], r["ok"], wantOK[i], r)
+		}
+		if r["data_hash"] != hash || hash == "" {
+			t.Errorf("response %d: data_hash = %v, want %v", i, r["data_hash"], hash)
+		}
+	}
+
+	show := resps[1]["result"].(map[string]any)
+	if show["issue"].(map[string]any)["id"] != "A-2" || show["blocked_by"].(map[string]any)["total"] != float64(1) {
+		t.Errorf("show A-2 = %v", show)
+	}
+	batch := resps[2]["result"].(map[string]any)
+	if batch["requested"] != float64(2) || batch["found"] != float64(1) {
+		t.Errorf("batch show = %v", batch)
+	}
+	if resps[3]["error"] != "issue not found: A-9" {
+		t.Errorf("unknown show error = %v", resps[3]["error"])
+	}
+	if got := resps[4]["result"].(map[string]any)["direct_unblocks"]; got != float64(1) {
+		t.Errorf("whatif A-1 direct_unblocks = %v", got)
+	}
+	if list := resps[6]["result"].(map[string]any); list["total"] != float64(1) {
+		t.Errorf("query login = %v", list)
+	}
+	if list := resps[7]["result"].(map[string]any); list["total"] != float64(1) {
+		t.Errorf("query status=closed = %v", list)
+	}
+	if !strings.Contains(resps[9]["error"].(string), "unknown command") {
+		t.Errorf("unknown command error = %v", resps[9]["error"])
+	}
+}
+
+func TestReplReloadsChangedData(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	writeReplBeads(t, dir, replTestBeads)
+
+	// Drive the session a line at a time so the file can change in between
+	inR, inW := io.Pipe()
+	outR, outW := io.Pipe()
+	done := make(chan int)
+	go func() {
+		done <- runReplCommand([]string{"--dir", dir}, inR, outW, io.Discard)
+		outW.Close()
+	}()
+	lines := make(chan map[string]any)
+	go func() {
+		dec := json.NewDecoder(outR)
+		for {
+			var r map[string]any
+			if dec.Decode(&r) != nil {
+				close(lines)
+				return
+			}
+			lines <- r
+		}
+	}()
+	ask := func(cmd string) map[string]any {
+		t.Helper()
+		if _, err := io.WriteString(inW, cmd+"\n"); err != nil {
+			t.Fatal(err)
+		}
+		select {
+		case r := <-lines:
+			return r
+		case <-time.After(10 * time.Second):
+			t.Fatalf("no response to %q", cmd)
+			return nil
+		}
+	}
+
+	before := ask("show A-1")
+	writeReplBeads(t, dir, replTestBeads+`{"id":"A-4","title":"New","status":"open","priority":1,"issue_type":"task"}`+"\n")
+	after := ask("show A-4")
+	if after["ok"] != true {
+		t.Errorf("issue written mid-session not seen: %v", after)
+	}
+	if before["data_hash"] == after["data_hash"] {
+		t.Error("data_hash didn't change after the beads file did")
+	}
+	if r := ask("reload"); r["ok"] != true || r["result"].(map[string]any)["issues"] != float64(4) {
+		t.Errorf("reload = %v", r)
+	}
+	inW.Close()
+	if code := <-done; code != 0 {
+		t.Errorf("exit %d at end of input", code)
+	}
+}
+
+func TestReplNoData(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	var stderr bytes.Buffer
+	if code := runReplCommand([]string{"--dir", t.TempDir()}, strings.NewReader("triage\n"), io.Discard, &stderr); code != 1 {
+		t.Errorf("exit %d without a beads file, want 1", code)
+	}
+	if !strings.HasPrefix(stderr.String(), "Error: ") {
+		t.Errorf("stderr = %q", stderr.String())
+	}
+	if code := runReplCommand([]string{"extra"}, strings.NewReader(""), io.Discard, io.Discard); code != 2 {
+		t.Errorf("exit %d for a stray argument, want 2", code)
+	}
+}
+
+func TestReplBrokenRulesWarns(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	dir := t.TempDir()
+	writeReplBeads(t, dir, replTestBeads)
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "rules.yaml"), []byte("score: [1"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+	if code := runReplCommand([]string{"--dir", dir}, strings.NewReader("triage\n"), &stdout, &stderr); code != 0 {
+		t.Fatalf("exit %d with a broken rules file, want 0; stderr: %s", code, stderr.String())
+	}
+	if !strings.Contains(stderr.String(), "continuing without rules") {
+		t.Errorf("stderr = %q, want a warning", stderr.String())
+	}
+	if resps := decodeReplResponses(t, stdout.String()); resps[0]["ok"] != true {
+		t.Errorf("triage = %v", resps[0])
+	}
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// reviewFileAuthors maps issues in review (see analysis.IsInReview) to who
+// committed to their likely files lately, for reviewer suggestions. xref may
+// be nil, leaving only the files issues declare. Outside git the map is nil.
+func reviewFileAuthors(root string, issues []model.Issue, xref *correlation.XRefReport) map[string][]correlation.FileAuthor {
+	hints := make(map[string][]correlation.FileHint)
+	for i := range issues {
+		if !analysis.IsInReview(&issues[i]) {
+			continue
+		}
+		if h := correlation.FileHints(issues[i], xref.For(issues[i].ID), correlation.DefaultFileHintLimit); len(h) > 0 {
+			hints[issues[i].ID] = h
+		}
+	}
+	if len(hints) == 0 {
+		return nil
+	}
+	since := time.Now().Add(-correlation.DefaultFileAuthorWindow)
+	authors, err := correlation.FileAuthors(root, hints, since, 0)
+	if err != nil {
+		return nil
+	}
+	return authors
+}
+
+// redactFileAuthors returns authors with names and emails redacted, for
+// reviewer suggestions under --redact.
+func redactFileAuthors(r model.Redactor, authors map[string][]correlation.FileAuthor) map[string][]correlation.FileAuthor {
+	if authors == nil {
+		return nil
+	}
+	out := make(map[string][]correlation.FileAuthor, len(authors))
+	for id, list := range authors {
+		redacted := make([]correlation.FileAuthor, len(list))
+		for i, a := range list {
+			a.Name, a.Email = r.Person(a.Name), redactEmail(r, a.Email)
+			redacted[i] = a
+		}
+		out[id] = redacted
+	}
+	return out
+}
+
+// redactEmail redacts both halves of an email separately, so its local part
+// still matches the same handle in CODEOWNERS (see redactOwner).
+func redactEmail(r model.Redactor, email string) string {
+	local, domain, ok := strings.Cut(email, "@")
+	if !ok {
+		return r.Person(email)
+	}
+	return r.Person(local) + "@" + r.Person(domain)
+}
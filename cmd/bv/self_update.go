@@ -0,0 +1,132 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// runSelfUpdateCommand implements `bv self-update`, the subcommand form of
+// --update / --check-update / --rollback. Returns the process exit code.
+func runSelfUpdateCommand(args []string, stderr io.Writer) int {
+	fs := flag.NewFlagSet("self-update", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	yes := fs.Bool("yes", false, "Skip the confirmation prompt")
+	fs.BoolVar(yes, "y", false, "Shorthand for --yes")
+	check := fs.Bool("check", false, "Only check whether a newer release exists")
+	rollback := fs.Bool("rollback", false, "Restore the binary saved by the previous update")
+	channel := fs.String("channel", "", "Release channel for this run: stable or beta (default: updates.channel / BV_UPDATE_CHANNEL)")
+	fs.Usage = func() {
+		fmt.Fprintln(stderr, "Usage: bv self-update [--check] [--yes] [--rollback] [--channel stable|beta]")
+		fmt.Fprintln(stderr, "\nDownload the latest release for this platform, verify it against the")
+		fmt.Fprintln(stderr, "release checksums, and atomically replace the running executable.")
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(args); err != nil {
+		if err == flag.ErrHelp {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fs.Usage()
+		return 2
+	}
+	if *channel != "" {
+		ch, err := updater.ParseChannel(*channel)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error: %v\n", err)
+			return 2
+		}
+		cfg := updater.CurrentCheckConfig()
+		cfg.Channel = ch
+		updater.SetCheckConfig(cfg)
+	}
+
+	switch {
+	case *check:
+		return runCheckUpdate()
+	case *rollback:
+		if err := updater.Rollback(); err != nil {
+			fmt.Fprintf(stderr, "Rollback failed: %v\n", err)
+			return 1
+		}
+		return 0
+	default:
+		return runSelfUpdate(*yes)
+	}
+}
+
+// runCheckUpdate reports whether a newer release is available (bv-182).
+func runCheckUpdate() int {
+	available, newVersion, releaseURL, err := updater.CheckUpdateAvailable()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error checking for updates: %v\n", err)
+		return 1
+	}
+	if available {
+		fmt.Printf("New version available: %s (current: %s)%s\n", newVersion, version.Version, channelSuffix())
+		fmt.Printf("Download: %s\n", releaseURL)
+		fmt.Println("\nRun 'bv self-update' to update automatically")
+	} else {
+		fmt.Printf("bv is up to date (version %s)\n", version.Version)
+	}
+	return 0
+}
+
+// runSelfUpdate downloads, verifies, and installs the latest release (bv-182).
+func runSelfUpdate(yes bool) int {
+	release, err := updater.GetLatestRelease()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error fetching release info: %v\n", err)
+		return 1
+	}
+
+	// Check if update is needed
+	available, newVersion, _, _ := updater.CheckUpdateAvailable()
+	if !available {
+		fmt.Printf("bv is already up to date (version %s)\n", version.Version)
+		return 0
+	}
+
+	// Confirm unless --yes is provided
+	if !yes {
+		fmt.Printf("Update bv from %s to %s%s? [Y/n]: ", version.Version, newVersion, channelSuffix())
+		var response string
+		fmt.Scanln(&response)
+		response = strings.ToLower(strings.TrimSpace(response))
+		if response != "" && response != "y" && response != "yes" {
+			fmt.Println("Update cancelled")
+			return 0
+		}
+	}
+
+	result, err := updater.PerformUpdate(release, yes)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Update failed: %v\n", err)
+		if result != nil && result.BackupPath != "" {
+			fmt.Fprintf(os.Stderr, "Backup preserved at: %s\n", result.BackupPath)
+		}
+		return 1
+	}
+
+	fmt.Println(result.Message)
+	if result.BackupPath != "" {
+		fmt.Printf("Backup saved to: %s\n", result.BackupPath)
+		fmt.Println("Run 'bv self-update --rollback' to restore if needed")
+	}
+	return 0
+}
+
+// channelSuffix labels non-default channels in user-facing messages.
+func channelSuffix() string {
+	if ch := updater.CurrentCheckConfig().Channel; ch != updater.ChannelStable {
+		return fmt.Sprintf(" [%s channel]", ch)
+	}
+	return ""
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestRunSelfUpdateCommand_BadArgs(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{"help", []string{"--help"}, 0},
+		{"unknown flag", []string{"--bogus"}, 2},
+		{"positional", []string{"v1.2.3"}, 2},
+		{"bad channel", []string{"--channel", "nightly"}, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stderr bytes.Buffer
+			if got := runSelfUpdateCommand(tt.args, &stderr); got != tt.want {
+				t.Fatalf("exit code = %d, want %d", got, tt.want)
+			}
+			if stderr.Len() == 0 {
+				t.Error("expected usage or error on stderr")
+			}
+		})
+	}
+}
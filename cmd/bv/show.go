@@ -0,0 +1,450 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notes"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
+)
+
+// showChainLimit caps each dependency chain in --robot-show; the total is
+// still reported.
+const showChainLimit = 20
+
+// robotShow is the --robot-show payload: one issue with everything an agent
+// would otherwise piece together from several robot commands.
+type robotShow struct {
+	GeneratedAt string `json:"generated_at"`
+	DataHash    string `json:"data_hash"`
+	AsOf        string `json:"as_of,omitempty"`
+	AsOfCommit  string `json:"as_of_commit,omitempty"`
+	robotShowDetail
+}
+
+// robotShowDetail is the per-issue part of --robot-show, shared with batches.
+type robotShowDetail struct {
+	Issue     model.Issue           `json:"issue"`
+	Metrics   robotShowMetrics      `json:"metrics"`
+	BlockedBy robotShowChain        `json:"blocked_by"` // open blockers, transitively
+	Blocks    robotShowChain        `json:"blocks"`     // open issues waiting on this one, transitively
+	WhatIf    *analysis.WhatIfDelta `json:"what_if"`    // null for closed issues
+	Alerts    []drift.Alert         `json:"alerts"`
+	Commands  []robotCommandHint    `json:"commands"`
+
+	// CodeRefs are the commits and branches that mention the issue, on any
+	// branch; absent outside a git repository.
+	CodeRefs *correlation.IssueXRef `json:"code_refs,omitempty"`
+
+	// LikelyFiles are the files the issue's work is likely to touch: those
+	// its "Files:" lines declare, then those its commits changed.
+	LikelyFiles []correlation.FileHint `json:"likely_files"`
+
+	// Owners own the likely files per CODEOWNERS, most files first; absent
+	// without a CODEOWNERS file.
+	Owners []string `json:"owners,omitempty"`
+
+	// LocalNotes is set with --with-notes: the user's scratchpad from
+	// .beads/.bv-notes/, local to the checkout, never beads data.
+	LocalNotes *string `json:"local_notes,omitempty"`
+}
+
+// robotShowBatch is the --robot-show payload for --ids: one result per
+// requested ID, in request order.
+type robotShowBatch struct {
+	GeneratedAt string            `json:"generated_at"`
+	DataHash    string            `json:"data_hash"`
+	AsOf        string            `json:"as_of,omitempty"`
+	AsOfCommit  string            `json:"as_of_commit,omitempty"`
+	Requested   int               `json:"requested"`
+	Found       int               `json:"found"`
+	Results     []robotShowResult `json:"results"`
+}
+
+// robotShowResult is one ID of a batch: the issue's detail, or an error.
+type robotShowResult struct {
+	ID     string
+	Error  string
+	Detail *robotShowDetail // nil when Error is set
+}
+
+// MarshalJSON writes the detail's fields inline after the ID, so a batch
+// entry reads like a --robot-show payload.
+func (r robotShowResult) MarshalJSON() ([]byte, error) {
+	if r.Detail == nil {
+		return json.Marshal(struct {
+			ID    string `json:"id"`
+			Error string `json:"error"`
+		}{r.ID, r.Error})
+	}
+	return json.Marshal(struct {
+		ID string `json:"id"`
+		robotShowDetail
+	}{r.ID, *r.Detail})
+}
+
+// robotShowMetrics are the graph metrics and impact score for one issue.
+type robotShowMetrics struct {
+	Score             float64                  `json:"score"`          // composite impact score, 0 when closed
+	Rank              int                      `json:"rank,omitempty"` // 1-based among open issues by score
+	OpenCount         int                      `json:"open_count"`
+	Breakdown         *analysis.ScoreBreakdown `json:"breakdown,omitempty"`
+	PageRank          float64                  `json:"pagerank"`
+	Betweenness       float64                  `json:"betweenness"`
+	Eigenvector       float64                  `json:"eigenvector"`
+	Hub               float64                  `json:"hub"`
+	Authority         float64                  `json:"authority"`
+	CriticalPath      float64                  `json:"critical_path"`
+	CoreNumber        int                      `json:"core_number"`
+	Slack             float64                  `json:"slack"`
+	ArticulationPoint bool                     `json:"articulation_point"`
+	InDegree          int                      `json:"in_degree"`     // issues that depend on this one
+	OutDegree         int                      `json:"out_degree"`    // issues this one depends on
+	BlockerDepth      int                      `json:"blocker_depth"` // -1 when in a cycle
+}
+
+// robotShowChain is one direction of an issue's dependency chain, nearest
+// issues first.
+type robotShowChain struct {
+	Total     int                   `json:"total"`
+	Issues    []robotShowChainEntry `json:"issues"`
+	Truncated bool                  `json:"truncated,omitempty"`
+}
+
+type robotShowChainEntry struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Depth    int    `json:"depth"` // 1 = direct
+}
+
+// robotShowBuilder computes --robot-show details. The graph analysis and
+// scores are shared by every ID, so a batch costs one analysis.
+type robotShowBuilder struct {
+	index      map[string]*model.Issue
+	analyzer   *analysis.Analyzer
+	stats      analysis.GraphStats
+	scores     []analysis.ImpactScore
+	blockers   map[string][]string
+	dependents map[string][]string
+	alerts     []drift.Alert
+	bd         string
+	xref       *correlation.XRefReport // Commit and branch mentions; nil outside git
+	owners     *codeowners.File        // nil without a CODEOWNERS file
+	notesDir   string                  // Local notes to include; "" leaves them out
+	redactor   *model.Redactor         // --redact: hash the git-derived fields; nil keeps them
+}
+
+// newRobotShowBuilder analyzes issues for --robot-show. alerts are the
+// project's alerts; each detail keeps those about its issue. Scores are
+// adjusted by ruleSet, which may be nil.
+func newRobotShowBuilder(issues []model.Issue, alerts []drift.Alert, bd string, ruleSet *rules.Set) *robotShowBuilder {
+	b := &robotShowBuilder{
+		index:      make(map[string]*model.Issue, len(issues)),
+		analyzer:   analysis.NewAnalyzer(issues),
+		dependents: dependentsOf(issues),
+		alerts:     alerts,
+		bd:         bd,
+	}
+	for i := range issues {
+		b.index[issues[i].ID] = &issues[i]
+	}
+	b.blockers = blockersOf(b.index)
+	cfg := b.analyzer.Config()
+	cfg.ScoreRules = ruleSet
+	b.analyzer.SetConfig(&cfg)
+	b.stats = b.analyzer.Analyze()
+	b.scores = b.analyzer.ComputeImpactScoresFromStats(&b.stats, time.Now())
+	return b
+}
+
+// setCodeLastChanged rescores with code-age risk (see codeLastChanged); a
+// nil map leaves the scores as they are.
+func (b *robotShowBuilder) setCodeLastChanged(lastChanged map[string]time.Time) {
+	if lastChanged == nil {
+		return
+	}
+	cfg := b.analyzer.Config()
+	cfg.CodeLastChanged = lastChanged
+	b.analyzer.SetConfig(&cfg)
+	b.scores = b.analyzer.ComputeImpactScoresFromStats(&b.stats, time.Now())
+}
+
+// detail returns the --robot-show detail for id, or false if id isn't
+// among the issues.
+func (b *robotShowBuilder) detail(id string) (robotShowDetail, bool) {
+	issue, ok := b.index[id]
+	if !ok {
+		return robotShowDetail{}, false
+	}
+	out := robotShowDetail{
+		Issue:     *issue,
+		Metrics:   b.metrics(id),
+		BlockedBy: showChain(id, b.index, b.blockers),
+		Blocks:    showChain(id, b.index, b.dependents),
+		WhatIf:    b.analyzer.WhatIf(id),
+		Alerts:    []drift.Alert{},
+	}
+	for _, a := range b.alerts {
+		if alertMentions(a, id) {
+			out.Alerts = append(out.Alerts, a)
+		}
+	}
+	out.Commands = showCommands(*issue, out.BlockedBy, b.bd)
+	if b.xref != nil {
+		out.CodeRefs = b.xref.For(id)
+		if out.CodeRefs == nil {
+			out.CodeRefs = &correlation.IssueXRef{
+				Commits:     []correlation.XRefCommit{},
+				Branches:    []correlation.XRefBranch{},
+				MergeStatus: correlation.MergeStatusNone,
+			}
+		}
+	}
+	out.LikelyFiles = correlation.FileHints(*issue, b.xref.For(id), 0)
+	out.Owners = b.owners.IssueOwners(*issue, b.xref.For(id))
+	if b.notesDir != "" {
+		text, _ := notes.Load(b.notesDir, id)
+		out.LocalNotes = &text
+	}
+	if b.redactor != nil {
+		b.redactDetail(&out)
+	}
+	return out, true
+}
+
+// redactDetail hashes what the detail took from git and CODEOWNERS rather
+// than from the (already redacted) issue: commit authors, subjects and
+// paths, branch names, and owners. Owners and likely files are computed
+// from the real paths first, so they still match.
+func (b *robotShowBuilder) redactDetail(out *robotShowDetail) {
+	r := *b.redactor
+	if refs := out.CodeRefs; refs != nil {
+		c := *refs
+		c.LastAuthor = r.Person(c.LastAuthor)
+		c.Commits = make([]correlation.XRefCommit, len(refs.Commits))
+		for i, commit := range refs.Commits {
+			commit.Author = r.Person(commit.Author)
+			commit.Subject = r.Text(commit.Subject)
+			commit.Files = make([]string, len(refs.Commits[i].Files))
+			for j, f := range refs.Commits[i].Files {
+				commit.Files[j] = r.Path(f)
+			}
+			c.Commits[i] = commit
+		}
+		c.Branches = make([]correlation.XRefBranch, len(refs.Branches))
+		for i, branch := range refs.Branches {
+			branch.Name = r.Text(branch.Name)
+			branch.LastAuthor = r.Person(branch.LastAuthor)
+			c.Branches[i] = branch
+		}
+		out.CodeRefs = &c
+	}
+	for i := range out.LikelyFiles {
+		out.LikelyFiles[i].Path = r.Path(out.LikelyFiles[i].Path)
+	}
+	for i := range out.Owners {
+		out.Owners[i] = redactOwner(r, out.Owners[i])
+	}
+}
+
+// batch returns one result per ID, in order, with an error entry for each
+// ID that doesn't exist.
+func (b *robotShowBuilder) batch(ids []string) robotShowBatch {
+	out := robotShowBatch{Requested: len(ids), Results: make([]robotShowResult, 0, len(ids))}
+	for _, id := range ids {
+		d, ok := b.detail(id)
+		if !ok {
+			out.Results = append(out.Results, robotShowResult{ID: id, Error: "issue not found"})
+			continue
+		}
+		out.Found++
+		out.Results = append(out.Results, robotShowResult{ID: id, Detail: &d})
+	}
+	return out
+}
+
+func (b *robotShowBuilder) metrics(id string) robotShowMetrics {
+	stats := &b.stats
+	m := robotShowMetrics{
+		PageRank:     stats.GetPageRankScore(id),
+		Betweenness:  stats.GetBetweennessScore(id),
+		Eigenvector:  stats.GetEigenvectorScore(id),
+		Hub:          stats.GetHubScore(id),
+		Authority:    stats.GetAuthorityScore(id),
+		CriticalPath: stats.GetCriticalPathScore(id),
+		InDegree:     stats.InDegree[id],
+		OutDegree:    stats.OutDegree[id],
+		BlockerDepth: b.analyzer.GetBlockerDepth(id),
+		OpenCount:    len(b.scores),
+	}
+	m.CoreNumber, _ = stats.CoreNumberValue(id)
+	m.Slack, _ = stats.SlackValue(id)
+	m.ArticulationPoint, _ = stats.IsArticulationPoint(id)
+	for i := range b.scores {
+		if b.scores[i].IssueID == id {
+			m.Score = b.scores[i].Score
+			m.Rank = i + 1
+			m.Breakdown = &b.scores[i].Breakdown
+			break
+		}
+	}
+	return m
+}
+
+// issueIDsOf returns the IDs of issues, in order.
+func issueIDsOf(issues []model.Issue) []string {
+	ids := make([]string, len(issues))
+	for i := range issues {
+		ids[i] = issues[i].ID
+	}
+	return ids
+}
+
+// parseShowIDs splits a comma-separated --ids list, dropping blanks and
+// repeats but keeping the caller's order.
+func parseShowIDs(list ...string) []string {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, l := range list {
+		for _, id := range strings.Split(l, ",") {
+			id = strings.TrimSpace(id)
+			if id == "" || seen[id] {
+				continue
+			}
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// blockersOf maps each issue to the open issues blocking it.
+func blockersOf(index map[string]*model.Issue) map[string][]string {
+	edges := make(map[string][]string)
+	for id, issue := range index {
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, ok := index[dep.DependsOnID]; ok && isOpenForShow(blocker) {
+				edges[id] = append(edges[id], dep.DependsOnID)
+			}
+		}
+	}
+	return edges
+}
+
+// dependentsOf maps each issue to the issues its completion helps unblock.
+func dependentsOf(issues []model.Issue) map[string][]string {
+	edges := make(map[string][]string)
+	for _, issue := range issues {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				edges[dep.DependsOnID] = append(edges[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	return edges
+}
+
+// showChain walks edges breadth-first from id, keeping open issues only,
+// in depth then ID order. Cycles back to visited issues are skipped.
+func showChain(id string, index map[string]*model.Issue, edges map[string][]string) robotShowChain {
+	chain := robotShowChain{Issues: []robotShowChainEntry{}}
+	visited := map[string]bool{id: true}
+	frontier := []string{id}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var next []string
+		for _, from := range frontier {
+			for _, to := range edges[from] {
+				issue, ok := index[to]
+				if visited[to] || !ok || !isOpenForShow(issue) {
+					continue
+				}
+				visited[to] = true
+				next = append(next, to)
+			}
+		}
+		sort.Strings(next)
+		for _, nid := range next {
+			chain.Total++
+			if len(chain.Issues) == showChainLimit {
+				chain.Truncated = true
+				continue
+			}
+			issue := index[nid]
+			chain.Issues = append(chain.Issues, robotShowChainEntry{
+				ID:       issue.ID,
+				Title:    issue.Title,
+				Status:   string(issue.Status),
+				Priority: issue.Priority,
+				Depth:    depth,
+			})
+		}
+		frontier = next
+	}
+	return chain
+}
+
+func isOpenForShow(issue *model.Issue) bool {
+	return !issue.Status.IsClosed() && !issue.Status.IsTombstone()
+}
+
+// alertMentions reports whether an alert is about issue id: it names the
+// issue, or its details mention the ID as a whole word.
+func alertMentions(a drift.Alert, id string) bool {
+	if a.IssueID == id {
+		return true
+	}
+	for _, d := range a.Details {
+		for _, word := range strings.FieldsFunc(d, isNotIDRune) {
+			if word == id {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func isNotIDRune(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' && r != '.'
+}
+
+// showCommands suggests what to run next for issue, depending on its state.
+func showCommands(issue model.Issue, blockedBy robotShowChain, bd string) []robotCommandHint {
+	cmds := []robotCommandHint{
+		{fmt.Sprintf("%s show %s", bd, issue.ID), "Full bd record, including comments"},
+	}
+	switch {
+	case issue.Status.IsClosed() || issue.Status.IsTombstone():
+		cmds = append(cmds, robotCommandHint{fmt.Sprintf("%s reopen %s", bd, issue.ID), "Reopen it if the work isn't finished"})
+	case blockedBy.Total > 0:
+		nearest := blockedBy.Issues[0].ID
+		cmds = append(cmds,
+			robotCommandHint{"bv --robot-blocker-chain " + issue.ID, "Walk the chain down to the root blockers"},
+			robotCommandHint{"bv --robot-show " + nearest, "Inspect the nearest open blocker"},
+		)
+	case issue.Status == model.StatusInProgress:
+		cmds = append(cmds, robotCommandHint{fmt.Sprintf("%s close %s", bd, issue.ID), "Close it once the work is done"})
+	default:
+		cmds = append(cmds, robotCommandHint{
+			bd + " " + strings.Join(nextClaimArgs(issue.ID, ""), " "), "Claim it"})
+	}
+	cmds = append(cmds,
+		robotCommandHint{"bv --robot-related " + issue.ID, "Issues sharing files or commits with this one"},
+		robotCommandHint{"bv --robot-forecast " + issue.ID, "ETA estimate"},
+	)
+	return cmds
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notes"
+)
+
+func blockedBy(id string, blockers ...string) []*model.Dependency {
+	deps := make([]*model.Dependency, len(blockers))
+	for i, b := range blockers {
+		deps[i] = &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks}
+	}
+	return deps
+}
+
+func chainIDs(c robotShowChain) string {
+	ids := make([]string, len(c.Issues))
+	for i, e := range c.Issues {
+		ids[i] = fmt.Sprintf("%s@%d", e.ID, e.Depth)
+	}
+	return strings.Join(ids, " ")
+}
+
+func TestBuildRobotShowChains(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "R", Title: "Root", Status: model.StatusOpen},
+		{ID: "D", Title: "Done", Status: model.StatusClosed},
+		{ID: "M", Title: "Mid", Status: model.StatusOpen, Dependencies: blockedBy("M", "R", "D")},
+		{ID: "L1", Title: "Leaf", Status: model.StatusBlocked, Dependencies: blockedBy("L1", "M")},
+		{ID: "L2", Title: "Leaf", Status: model.StatusOpen, Dependencies: blockedBy("L2", "L1")},
+		{ID: "X", Title: "Closed dependent", Status: model.StatusClosed, Dependencies: blockedBy("X", "M")},
+	}
+	builder := newRobotShowBuilder(issues, nil, "bd", nil)
+	out, ok := builder.detail("M")
+	if !ok {
+		t.Fatal("issue M not found")
+	}
+	if got := chainIDs(out.BlockedBy); got != "R@1" {
+		t.Errorf("blocked_by = %s, want only the open blocker", got)
+	}
+	if got := chainIDs(out.Blocks); got != "L1@1 L2@2" {
+		t.Errorf("blocks = %s, want open dependents transitively", got)
+	}
+	if out.WhatIf == nil || out.WhatIf.DirectUnblocks != 1 {
+		t.Errorf("what_if = %+v", out.WhatIf)
+	}
+	if out.Metrics.Rank == 0 || out.Metrics.Breakdown == nil || out.Metrics.OpenCount != 4 {
+		t.Errorf("metrics = %+v", out.Metrics)
+	}
+	if out.Commands[1].Command != "bv --robot-blocker-chain M" || out.Commands[2].Command != "bv --robot-show R" {
+		t.Errorf("commands for a blocked issue = %+v", out.Commands)
+	}
+
+	if _, ok := builder.detail("missing"); ok {
+		t.Error("expected unknown issue to be reported")
+	}
+	closed, _ := builder.detail("D")
+	if closed.WhatIf != nil || closed.Metrics.Rank != 0 || closed.Commands[1].Command != "bd reopen D" {
+		t.Errorf("closed issue: what_if=%v rank=%d commands=%+v", closed.WhatIf, closed.Metrics.Rank, closed.Commands)
+	}
+}
+
+func TestBuildRobotShowChainCapAndCycle(t *testing.T) {
+	issues := []model.Issue{{ID: "HUB", Title: "Hub", Status: model.StatusOpen, Dependencies: blockedBy("HUB", "C")}}
+	issues = append(issues, model.Issue{ID: "C", Title: "Cycle", Status: model.StatusOpen, Dependencies: blockedBy("C", "HUB")})
+	for i := 0; i < showChainLimit+5; i++ {
+		id := fmt.Sprintf("N%02d", i)
+		issues = append(issues, model.Issue{ID: id, Title: id, Status: model.StatusOpen, Dependencies: blockedBy(id, "HUB")})
+	}
+	out, _ := newRobotShowBuilder(issues, nil, "bd", nil).detail("HUB")
+	if out.Blocks.Total != showChainLimit+6 || len(out.Blocks.Issues) != showChainLimit || !out.Blocks.Truncated {
+		t.Errorf("blocks: total=%d shown=%d truncated=%v", out.Blocks.Total, len(out.Blocks.Issues), out.Blocks.Truncated)
+	}
+	if got := chainIDs(out.BlockedBy); got != "C@1" {
+		t.Errorf("blocked_by = %s, want the cycle walked once", got)
+	}
+}
+
+func TestBuildRobotShowAlertsAndClaim(t *testing.T) {
+	issues := []model.Issue{{ID: "A-1", Title: "One", Status: model.StatusOpen}}
+	alerts := []drift.Alert{
+		{Type: drift.AlertStaleIssue, IssueID: "A-1"},
+		{Type: drift.AlertNewCycle, Details: []string{"A-1 → A-2 → A-1"}},
+		{Type: drift.AlertNewCycle, Details: []string{"A-10 → A-2 → A-10"}},
+		{Type: drift.AlertStaleIssue, IssueID: "A-2"},
+	}
+	out, _ := newRobotShowBuilder(issues, alerts, "/opt/bd", nil).detail("A-1")
+	if len(out.Alerts) != 2 {
+		t.Errorf("alerts = %+v, want the two about A-1", out.Alerts)
+	}
+	if got := out.Commands[1].Command; got != "/opt/bd update A-1 --status=in_progress" {
+		t.Errorf("claim command = %q", got)
+	}
+}
+
+func TestRobotShowBatch(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A-1", Title: "One", Status: model.StatusOpen},
+		{ID: "A-2", Title: "Two", Status: model.StatusOpen, Dependencies: blockedBy("A-2", "A-1")},
+	}
+	ids := parseShowIDs("A-2", " nope, A-2,,A-1 ")
+	if got := strings.Join(ids, " "); got != "A-2 nope A-1" {
+		t.Fatalf("parseShowIDs = %s", got)
+	}
+	batch := newRobotShowBuilder(issues, nil, "bd", nil).batch(ids)
+	if batch.Requested != 3 || batch.Found != 2 || len(batch.Results) != 3 {
+		t.Fatalf("batch = requested %d found %d results %d", batch.Requested, batch.Found, len(batch.Results))
+	}
+
+	data, err := json.Marshal(batch.Results)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var results []map[string]any
+	if err := json.Unmarshal(data, &results); err != nil {
+		t.Fatal(err)
+	}
+	if results[0]["id"] != "A-2" || results[0]["issue"] == nil || results[0]["error"] != nil {
+		t.Errorf("results[0] = %v, want A-2's detail inline", results[0])
+	}
+	if results[1]["id"] != "nope" || results[1]["error"] != "issue not found" || results[1]["issue"] != nil {
+		t.Errorf("results[1] = %v, want only an error", results[1])
+	}
+	if results[2]["id"] != "A-1" {
+		t.Errorf("results[2] = %v, want request order kept", results[2])
+	}
+}
+
+func TestBuildRobotShowLocalNotes(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A-1", Title: "One", Status: model.StatusOpen},
+		{ID: "A-2", Title: "Two", Status: model.StatusOpen},
+	}
+	builder := newRobotShowBuilder(issues, nil, "bd", nil)
+	out, _ := builder.detail("A-1")
+	data, _ := json.Marshal(out)
+	if strings.Contains(string(data), "local_notes") {
+		t.Errorf("local_notes without --with-notes: %s", data)
+	}
+
+	builder.notesDir = notes.Dir(t.TempDir())
+	if err := notes.Save(builder.notesDir, "A-1", "ask about the cache"); err != nil {
+		t.Fatal(err)
+	}
+	if out, _ := builder.detail("A-1"); out.LocalNotes == nil || *out.LocalNotes != "ask about the cache\n" {
+		t.Errorf("A-1 local_notes = %v", out.LocalNotes)
+	}
+	if out, _ := builder.detail("A-2"); out.LocalNotes == nil || *out.LocalNotes != "" {
+		t.Errorf("A-2 local_notes = %v, want empty", out.LocalNotes)
+	}
+}
+
+func TestBuildRobotShowCodeRefs(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A-1", Title: "One", Status: model.StatusOpen},
+		{ID: "A-2", Title: "Two", Status: model.StatusOpen, Description: "Files: pkg/api/**"},
+	}
+	builder := newRobotShowBuilder(issues, nil, "bd", nil)
+	if out, _ := builder.detail("A-1"); out.CodeRefs != nil || out.LikelyFiles == nil || len(out.LikelyFiles) != 0 {
+		t.Errorf("without a scan: code_refs = %+v, likely_files = %#v", out.CodeRefs, out.LikelyFiles)
+	}
+	want := []correlation.FileHint{{Path: "pkg/api/**", Source: correlation.FileHintDeclared}}
+	if out, _ := builder.detail("A-2"); !reflect.DeepEqual(out.LikelyFiles, want) {
+		t.Errorf("A-2 likely_files = %+v, want %+v", out.LikelyFiles, want)
+	}
+
+	builder.xref = &correlation.XRefReport{Issues: map[string]*correlation.IssueXRef{
+		"A-1": {
+			Commits: []correlation.XRefCommit{{SHA: "abc1234def", ShortSHA: "abc1234", Author: "Ann", Subject: "Fix A-1", Merged: true,
+				Files: []string{"pkg/cache.go"}}},
+			Branches:    []correlation.XRefBranch{},
+			LastAuthor:  "Ann",
+			MergeStatus: correlation.MergeStatusMerged,
+		},
+	}}
+	out, _ := builder.detail("A-1")
+	data, _ := json.Marshal(out)
+	for _, want := range []string{`"code_refs":{`, `"short_sha":"abc1234"`, `"merge_status":"merged"`,
+		`"likely_files":[{"path":"pkg/cache.go","source":"commits","commits":1}]`} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("A-1 lacks %s: %s", want, data)
+		}
+	}
+	if out, _ := builder.detail("A-2"); out.CodeRefs == nil || out.CodeRefs.MergeStatus != correlation.MergeStatusNone || out.CodeRefs.Commits == nil {
+		t.Errorf("A-2 code_refs = %+v, want an empty entry", out.CodeRefs)
+	}
+}
+
+func TestBuildRobotShowRedactsCodeRefs(t *testing.T) {
+	issues := []model.Issue{{ID: "A-1", Title: "redacted-1", Status: model.StatusOpen}}
+	builder := newRobotShowBuilder(issues, nil, "bd", nil)
+	xref := &correlation.IssueXRef{
+		Commits: []correlation.XRefCommit{{SHA: "abc1234def", ShortSHA: "abc1234", Author: "Ann Secret", Subject: "Wire the Zanzibar cache",
+			Files: []string{"pkg/zanzibar.go"}}},
+		Branches:    []correlation.XRefBranch{{Name: "ann/zanzibar", LastAuthor: "Ann Secret"}},
+		LastAuthor:  "Ann Secret",
+		MergeStatus: correlation.MergeStatusMerged,
+	}
+	builder.xref = &correlation.XRefReport{Issues: map[string]*correlation.IssueXRef{"A-1": xref}}
+	r := model.Redactor{Salt: "s"}
+	builder.redactor = &r
+
+	out, _ := builder.detail("A-1")
+	data, _ := json.Marshal(out)
+	for _, secret := range []string{"Ann", "Secret", "Zanzibar", "zanzibar"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("redacted detail leaks %q: %s", secret, data)
+		}
+	}
+	if c := out.CodeRefs.Commits[0]; c.ShortSHA != "abc1234" || c.Author != r.Person("Ann Secret") || c.Files[0] != r.Path("pkg/zanzibar.go") {
+		t.Errorf("commit = %+v, want the SHA kept and the rest hashed", c)
+	}
+	if len(out.LikelyFiles) != 1 || out.LikelyFiles[0].Path != r.Path("pkg/zanzibar.go") {
+		t.Errorf("likely_files = %+v", out.LikelyFiles)
+	}
+	if xref.Commits[0].Author != "Ann Secret" || xref.Commits[0].Files[0] != "pkg/zanzibar.go" {
+		t.Error("redaction modified the shared cross-reference")
+	}
+}
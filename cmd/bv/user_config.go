@@ -0,0 +1,281 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
+)
+
+// userConfig mirrors ~/.config/bv/config.yaml. Every field is a pointer so
+// callers can tell "unset" apart from an explicit false/zero value and apply
+// the usual precedence: CLI flag > environment variable > config file.
+type userConfig struct {
+	Experimental struct {
+		BackgroundMode *bool `yaml:"background_mode"`
+	} `yaml:"experimental"`
+	UI struct {
+		ASCII         *bool   `yaml:"ascii"`
+		TerminalTitle *bool   `yaml:"terminal_title"`
+		Notify        *bool   `yaml:"notify"`
+		Locale        *string `yaml:"locale"`
+		TimeFormat    *string `yaml:"time_format"`
+		Timezone      *string `yaml:"timezone"`
+		VimKeys       *bool   `yaml:"vim_keys"`
+	} `yaml:"ui"`
+	Updates struct {
+		Channel       *string `yaml:"channel"`
+		CheckInterval *string `yaml:"check_interval"`
+		Check         *bool   `yaml:"check"`
+	} `yaml:"updates"`
+	// Keys binds TUI actions (see the keymap in pkg/ui) to other keys.
+	Keys map[string]keyList `yaml:"keys"`
+}
+
+// keyList is an action's keys in config.yaml: `board_view: B` or
+// `move_down: [n, down]`.
+type keyList []string
+
+// UnmarshalYAML accepts a single key as well as a list.
+func (k *keyList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*k = keyList{node.Value}
+		return nil
+	}
+	var keys []string
+	if err := node.Decode(&keys); err != nil {
+		return err
+	}
+	*k = keys
+	return nil
+}
+
+// userConfigPath returns the path of the per-user bv config file.
+func userConfigPath() (string, bool) {
+	homeDir, err := os.UserHomeDir()
+	if err != nil || homeDir == "" {
+		return "", false
+	}
+	return filepath.Join(homeDir, ".config", "bv", "config.yaml"), true
+}
+
+// loadUserConfig reads the per-user config file. A missing or malformed file
+// yields ok=false; config is always optional.
+func loadUserConfig() (userConfig, bool) {
+	var cfg userConfig
+	configPath, ok := userConfigPath()
+	if !ok {
+		return cfg, false
+	}
+	data, err := os.ReadFile(configPath)
+	if err != nil {
+		return cfg, false
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return userConfig{}, false
+	}
+	return cfg, true
+}
+
+// envBool parses a boolean-ish environment variable. ok is false when the
+// variable is unset, empty, or not a recognized value.
+func envBool(name string) (value bool, ok bool) {
+	v := strings.TrimSpace(os.Getenv(name))
+	switch strings.ToLower(v) {
+	case "1", "true", "yes", "on":
+		return true, true
+	case "0", "false", "no", "off":
+		return false, true
+	}
+	return false, false
+}
+
+// resolveASCIIMode decides whether the TUI should use ASCII-only icons.
+// Precedence: --ascii flag, then BV_ASCII, then `ui.ascii` in config.yaml,
+// then the terminal probe: ASCII if the terminal can't show emoji.
+func resolveASCIIMode(flagSet bool, caps ui.TerminalCaps) bool {
+	if flagSet {
+		return true
+	}
+	if v, ok := envBool("BV_ASCII"); ok {
+		return v
+	}
+	if cfg, ok := loadUserConfig(); ok && cfg.UI.ASCII != nil {
+		return *cfg.UI.ASCII
+	}
+	return !caps.Emoji
+}
+
+// resolveLocale picks the locale for TUI text, alert messages, and robot
+// usage hints. Precedence: BV_LANG, then `ui.locale` in config.yaml, then
+// the system locale (LC_ALL, LC_MESSAGES, LANG).
+func resolveLocale() string {
+	if v := strings.TrimSpace(os.Getenv("BV_LANG")); v != "" {
+		return v
+	}
+	if cfg, ok := loadUserConfig(); ok && cfg.UI.Locale != nil && *cfg.UI.Locale != "" {
+		return *cfg.UI.Locale
+	}
+	for _, name := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if v := os.Getenv(name); v != "" {
+			return v
+		}
+	}
+	return i18n.DefaultLocale
+}
+
+// setupLocale loads the translation catalogs in ~/.config/bv/locales
+// (<locale>.yaml files mapping message keys to text) and switches to the
+// resolved locale. Catalog problems are returned as warnings.
+func setupLocale() []string {
+	var warnings []string
+	if homeDir, err := os.UserHomeDir(); err == nil && homeDir != "" {
+		if err := i18n.LoadDir(filepath.Join(homeDir, ".config", "bv", "locales")); err != nil {
+			warnings = append(warnings, err.Error())
+		}
+	}
+	i18n.SetLocale(resolveLocale())
+	return warnings
+}
+
+// resolveTimeFormat builds the timestamp options for the TUI from
+// BV_TIME_FORMAT / BV_TIMEZONE, falling back to `ui.time_format` /
+// `ui.timezone` in config.yaml. Invalid values are reported as warnings and
+// the defaults (relative, local zone) kept.
+func resolveTimeFormat() (timefmt.Options, []string) {
+	opts := timefmt.Options{Style: timefmt.StyleRelative, Location: time.Local}
+	var warnings []string
+	file, fileOK := loadUserConfig()
+
+	style := os.Getenv("BV_TIME_FORMAT")
+	if style == "" && fileOK && file.UI.TimeFormat != nil {
+		style = *file.UI.TimeFormat
+	}
+	if s, err := timefmt.ParseStyle(style); err == nil {
+		opts.Style = s
+	} else {
+		warnings = append(warnings, err.Error())
+	}
+
+	zone := os.Getenv("BV_TIMEZONE")
+	if zone == "" && fileOK && file.UI.Timezone != nil {
+		zone = *file.UI.Timezone
+	}
+	if loc, err := timefmt.LoadLocation(zone); err == nil {
+		opts.Location = loc
+	} else {
+		warnings = append(warnings, err.Error())
+	}
+	return opts, warnings
+}
+
+// setupKeyRemap applies the `keys` section of config.yaml. Unknown actions,
+// invalid keys and conflicts reject the whole section, keeping the default
+// keys; each problem is returned as a warning.
+func setupKeyRemap() []string {
+	cfg, ok := loadUserConfig()
+	if !ok || len(cfg.Keys) == 0 {
+		return nil
+	}
+	remap := make(map[string][]string, len(cfg.Keys))
+	for action, keys := range cfg.Keys {
+		remap[action] = keys
+	}
+	err := ui.SetKeyRemap(remap)
+	if err == nil {
+		return nil
+	}
+	warnings := strings.Split(err.Error(), "\n")
+	return append(warnings, "using the default keys")
+}
+
+// resolveTerminalIntegration decides whether the TUI sets the terminal title
+// (default on) and emits OSC 9 notifications for new critical alerts on live
+// reload (default off). Precedence: BV_TERM_TITLE / BV_NOTIFY, then
+// `ui.terminal_title` / `ui.notify` in config.yaml.
+func resolveTerminalIntegration() (title, notify bool) {
+	title, notify = true, false
+	cfg, cfgOK := loadUserConfig()
+	if v, ok := envBool("BV_TERM_TITLE"); ok {
+		title = v
+	} else if cfgOK && cfg.UI.TerminalTitle != nil {
+		title = *cfg.UI.TerminalTitle
+	}
+	if v, ok := envBool("BV_NOTIFY"); ok {
+		notify = v
+	} else if cfgOK && cfg.UI.Notify != nil {
+		notify = *cfg.UI.Notify
+	}
+	return title, notify
+}
+
+// resolveVimKeys decides whether the TUI takes vim-style counts, gg and
+// marks (default off). Precedence: BV_VIM_KEYS, then `ui.vim_keys` in
+// config.yaml.
+func resolveVimKeys() bool {
+	if v, ok := envBool("BV_VIM_KEYS"); ok {
+		return v
+	}
+	if cfg, ok := loadUserConfig(); ok && cfg.UI.VimKeys != nil {
+		return *cfg.UI.VimKeys
+	}
+	return false
+}
+
+// resolveUpdateCheckConfig builds the update-check configuration from
+// BV_UPDATE_CHANNEL / BV_UPDATE_INTERVAL / BV_UPDATE_CHECK, falling back to
+// the `updates` section of config.yaml. Invalid values are reported as
+// warnings and otherwise ignored so a typo never blocks startup.
+func resolveUpdateCheckConfig() (updater.CheckConfig, []string) {
+	cfg := updater.DefaultCheckConfig()
+	var warnings []string
+	file, fileOK := loadUserConfig()
+
+	channel := os.Getenv("BV_UPDATE_CHANNEL")
+	if channel == "" && fileOK && file.Updates.Channel != nil {
+		channel = *file.Updates.Channel
+	}
+	if channel != "" {
+		if ch, err := updater.ParseChannel(channel); err == nil {
+			cfg.Channel = ch
+		} else {
+			warnings = append(warnings, err.Error())
+		}
+	}
+
+	interval := os.Getenv("BV_UPDATE_INTERVAL")
+	if interval == "" && fileOK && file.Updates.CheckInterval != nil {
+		interval = *file.Updates.CheckInterval
+	}
+	if interval != "" {
+		if strings.EqualFold(strings.TrimSpace(interval), "never") {
+			cfg.Disabled = true
+		} else if d, err := updater.ParseCheckInterval(interval); err == nil {
+			cfg.Interval = d
+		} else {
+			warnings = append(warnings, err.Error())
+		}
+	}
+
+	if v, ok := envBool("BV_UPDATE_CHECK"); ok {
+		cfg.Disabled = cfg.Disabled || !v
+	} else if fileOK && file.Updates.Check != nil && !*file.Updates.Check {
+		cfg.Disabled = true
+	}
+	return cfg, warnings
+}
+
+// warnUpdateConfig prints update-config warnings to stderr.
+func warnUpdateConfig(warnings []string) {
+	for _, w := range warnings {
+		fmt.Fprintf(os.Stderr, "Warning: update config: %s\n", w)
+	}
+}
@@ -0,0 +1,235 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
+)
+
+func writeUserConfig(t *testing.T, content string) {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	dir := filepath.Join(home, ".config", "bv")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "config.yaml"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+var fullTerminal = ui.TerminalCaps{UTF8: true, Emoji: true}
+
+func TestResolveASCIIMode_Precedence(t *testing.T) {
+	writeUserConfig(t, "ui:\n  ascii: true\n")
+
+	t.Setenv("BV_ASCII", "")
+	if !resolveASCIIMode(false, fullTerminal) {
+		t.Error("expected config ui.ascii=true to enable ASCII mode")
+	}
+
+	t.Setenv("BV_ASCII", "0")
+	if resolveASCIIMode(false, fullTerminal) {
+		t.Error("expected BV_ASCII=0 to override config")
+	}
+
+	if !resolveASCIIMode(true, fullTerminal) {
+		t.Error("expected --ascii to override BV_ASCII=0")
+	}
+}
+
+func TestResolveASCIIMode_DefaultOff(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BV_ASCII", "")
+	if resolveASCIIMode(false, fullTerminal) {
+		t.Error("expected ASCII mode off without flag, env, or config")
+	}
+}
+
+func TestResolveASCIIMode_TerminalFallback(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BV_ASCII", "")
+	conhost := ui.TerminalCaps{Name: "conhost", UTF8: true, LegacyConsole: true}
+	if !resolveASCIIMode(false, conhost) {
+		t.Error("expected ASCII mode on a terminal without emoji")
+	}
+
+	t.Setenv("BV_ASCII", "0")
+	if resolveASCIIMode(false, conhost) {
+		t.Error("expected BV_ASCII=0 to override the terminal probe")
+	}
+
+	t.Setenv("BV_ASCII", "")
+	writeUserConfig(t, "ui:\n  ascii: false\n")
+	if resolveASCIIMode(false, conhost) {
+		t.Error("expected ui.ascii=false to override the terminal probe")
+	}
+}
+
+func TestResolveLocale(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	for _, name := range []string{"BV_LANG", "LC_ALL", "LC_MESSAGES"} {
+		t.Setenv(name, "")
+	}
+	t.Setenv("LANG", "fr_FR.UTF-8")
+	if got := resolveLocale(); got != "fr_FR.UTF-8" {
+		t.Errorf("system locale: got %q", got)
+	}
+
+	writeUserConfig(t, "ui:\n  locale: de\n")
+	if got := resolveLocale(); got != "de" {
+		t.Errorf("config: got %q, want de", got)
+	}
+
+	t.Setenv("BV_LANG", "pt-BR")
+	if got := resolveLocale(); got != "pt-BR" {
+		t.Errorf("env override: got %q, want pt-BR", got)
+	}
+}
+
+func TestResolveTimeFormat(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BV_TIME_FORMAT", "")
+	t.Setenv("BV_TIMEZONE", "")
+	if opts, warnings := resolveTimeFormat(); opts.Style != timefmt.StyleRelative || opts.Location != time.Local || len(warnings) > 0 {
+		t.Errorf("defaults: %+v %v", opts, warnings)
+	}
+
+	writeUserConfig(t, "ui:\n  time_format: absolute\n  timezone: UTC\n")
+	if opts, _ := resolveTimeFormat(); opts.Style != timefmt.StyleAbsolute || opts.Location != time.UTC {
+		t.Errorf("config: %+v", opts)
+	}
+
+	t.Setenv("BV_TIME_FORMAT", "relative")
+	t.Setenv("BV_TIMEZONE", "Nowhere/Special")
+	opts, warnings := resolveTimeFormat()
+	if opts.Style != timefmt.StyleRelative {
+		t.Errorf("env override: %+v", opts)
+	}
+	if opts.Location != time.Local || len(warnings) != 1 {
+		t.Errorf("bad zone: %+v %v, want local with a warning", opts, warnings)
+	}
+}
+
+func TestSetupKeyRemap(t *testing.T) {
+	t.Cleanup(func() { _ = ui.SetKeyRemap(nil) })
+	writeUserConfig(t, "keys:\n  board_view: F9\n  move_down: [ctrl+n, down]\n")
+	if warnings := setupKeyRemap(); len(warnings) > 0 {
+		t.Fatalf("valid remap: %v", warnings)
+	}
+
+	writeUserConfig(t, "keys:\n  board_view: g\n  bogus: x\n")
+	warnings := setupKeyRemap()
+	if len(warnings) != 3 {
+		t.Fatalf("warnings = %q, want unknown action, conflict, and the fallback note", warnings)
+	}
+	if !strings.Contains(warnings[0], `unknown action "bogus"`) ||
+		!strings.Contains(warnings[1], `"g" is bound to both board_view and graph_view`) {
+		t.Errorf("warnings = %q", warnings)
+	}
+}
+
+func TestResolveTerminalIntegration(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BV_TERM_TITLE", "")
+	t.Setenv("BV_NOTIFY", "")
+	if title, notify := resolveTerminalIntegration(); !title || notify {
+		t.Errorf("defaults: title=%v notify=%v, want true/false", title, notify)
+	}
+
+	writeUserConfig(t, "ui:\n  terminal_title: false\n  notify: true\n")
+	if title, notify := resolveTerminalIntegration(); title || !notify {
+		t.Errorf("config: title=%v notify=%v, want false/true", title, notify)
+	}
+
+	t.Setenv("BV_TERM_TITLE", "1")
+	t.Setenv("BV_NOTIFY", "off")
+	if title, notify := resolveTerminalIntegration(); !title || notify {
+		t.Errorf("env override: title=%v notify=%v, want true/false", title, notify)
+	}
+}
+
+func TestResolveVimKeys(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BV_VIM_KEYS", "")
+	if resolveVimKeys() {
+		t.Error("vim keys should default to off")
+	}
+
+	writeUserConfig(t, "ui:\n  vim_keys: true\n")
+	if !resolveVimKeys() {
+		t.Error("config: vim keys should be on")
+	}
+
+	t.Setenv("BV_VIM_KEYS", "0")
+	if resolveVimKeys() {
+		t.Error("env override: vim keys should be off")
+	}
+}
+
+func TestResolveUpdateCheckConfig(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("BV_UPDATE_CHANNEL", "")
+	t.Setenv("BV_UPDATE_INTERVAL", "")
+	t.Setenv("BV_UPDATE_CHECK", "")
+	cfg, warnings := resolveUpdateCheckConfig()
+	if cfg != updater.DefaultCheckConfig() || len(warnings) != 0 {
+		t.Errorf("defaults: cfg=%+v warnings=%v", cfg, warnings)
+	}
+
+	writeUserConfig(t, "updates:\n  channel: beta\n  check_interval: 7d\n")
+	cfg, _ = resolveUpdateCheckConfig()
+	if cfg.Channel != updater.ChannelBeta || cfg.Interval != 7*24*time.Hour || cfg.Disabled {
+		t.Errorf("config: %+v", cfg)
+	}
+
+	t.Setenv("BV_UPDATE_CHANNEL", "stable")
+	t.Setenv("BV_UPDATE_CHECK", "0")
+	cfg, _ = resolveUpdateCheckConfig()
+	if cfg.Channel != updater.ChannelStable || !cfg.Disabled {
+		t.Errorf("env override: %+v", cfg)
+	}
+
+	t.Setenv("BV_UPDATE_CHECK", "")
+	t.Setenv("BV_UPDATE_INTERVAL", "never")
+	if cfg, _ = resolveUpdateCheckConfig(); !cfg.Disabled {
+		t.Errorf("interval never should disable the check: %+v", cfg)
+	}
+
+	t.Setenv("BV_UPDATE_INTERVAL", "")
+	t.Setenv("BV_UPDATE_CHANNEL", "nightly")
+	cfg, warnings = resolveUpdateCheckConfig()
+	if cfg.Channel != updater.ChannelStable || len(warnings) != 1 {
+		t.Errorf("invalid channel should warn and fall back to stable: cfg=%+v warnings=%v", cfg, warnings)
+	}
+}
+
+func TestEnvBool(t *testing.T) {
+	tests := []struct {
+		value  string
+		want   bool
+		wantOK bool
+	}{
+		{"1", true, true},
+		{"TRUE", true, true},
+		{"on", true, true},
+		{"0", false, true},
+		{"no", false, true},
+		{"", false, false},
+		{"maybe", false, false},
+	}
+	for _, tt := range tests {
+		t.Setenv("BV_TEST_BOOL", tt.value)
+		got, ok := envBool("BV_TEST_BOOL")
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("envBool(%q) = (%v, %v), want (%v, %v)", tt.value, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
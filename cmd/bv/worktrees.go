@@ -0,0 +1,268 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+// robotWorktrees is the --robot-worktrees payload.
+type robotWorktrees struct {
+	GeneratedAt string          `json:"generated_at"`
+	DataHash    string          `json:"data_hash"`
+	Worktrees   []worktreeEntry `json:"worktrees"`
+	// Claims recorded without a worktree in a store shared by several
+	// (BEADS_DIR), so bv can't tell where they are worked
+	Unattributed []worktreeClaim `json:"unattributed_claims,omitempty"`
+	Conflicts    []claimConflict `json:"conflicts"`
+}
+
+// worktreeEntry is a worktree and the beads claimed in it.
+type worktreeEntry struct {
+	correlation.Worktree
+	Claims []worktreeClaim `json:"claims"`
+}
+
+// worktreeClaim is a live claim with where it is worked and the files its
+// bead is likely to touch.
+type worktreeClaim struct {
+	IssueID   string    `json:"issue_id"`
+	Title     string    `json:"title,omitempty"`
+	Status    string    `json:"status,omitempty"`
+	Agent     string    `json:"agent,omitempty"`
+	Branch    string    `json:"branch,omitempty"`
+	Worktree  string    `json:"worktree,omitempty"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	Files     []string  `json:"files,omitempty"`
+}
+
+// claimConflict is two beads claimed on different branches whose likely
+// files meet: the branches will conflict when they merge.
+type claimConflict struct {
+	Files   []string        `json:"files"`
+	Claims  []worktreeClaim `json:"claims"`
+	Warning string          `json:"warning"`
+}
+
+// branchClaim is a claim with its worktree and branch filled in, the bead as
+// the claiming worktree sees it, and the bead's file hints.
+type branchClaim struct {
+	session.Claim
+	issue model.Issue
+	hints []correlation.FileHint
+}
+
+// loadBranchClaims reads the session store of every worktree, once per
+// store, keeping the claims that store's own beads data hasn't overtaken.
+// A claim recorded before claims carried their worktree belongs to the
+// worktree holding the store, if the store is inside one.
+func loadBranchClaims(worktrees []correlation.Worktree, now time.Time) []branchClaim {
+	var claims []branchClaim
+	seen := make(map[string]bool)
+	for _, wt := range worktrees {
+		if wt.Bare {
+			continue
+		}
+		beadsDir, err := loader.GetBeadsDir(wt.Path)
+		if err != nil {
+			continue
+		}
+		storePath := session.DefaultPath(filepath.Dir(beadsDir))
+		if seen[storePath] {
+			continue
+		}
+		seen[storePath] = true
+		store, err := session.Load(storePath)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: %v; ignoring claims in %s\n", err, wt.Path)
+			continue
+		}
+		if len(store.Claims) == 0 {
+			continue
+		}
+		index := make(map[string]model.Issue)
+		if path, err := loader.FindJSONLPath(beadsDir); err == nil {
+			if issues, err := loader.LoadIssuesFromFile(path); err == nil {
+				for _, issue := range issues {
+					index[issue.ID] = issue
+				}
+			}
+		}
+		store.Prune(index, now)
+		owner := worktreeContaining(worktrees, storePath)
+		for _, c := range store.Claims {
+			if c.Worktree == "" && owner != nil {
+				c.Worktree = owner.Path
+				c.Branch = owner.Branch
+			}
+			claims = append(claims, branchClaim{Claim: c, issue: index[c.IssueID]})
+		}
+	}
+	sort.SliceStable(claims, func(i, j int) bool {
+		return claims[i].ClaimedAt.Before(claims[j].ClaimedAt)
+	})
+	return claims
+}
+
+// worktreeContaining returns the innermost worktree path is in, or nil.
+func worktreeContaining(worktrees []correlation.Worktree, path string) *correlation.Worktree {
+	var best *correlation.Worktree
+	for i := range worktrees {
+		rel, err := filepath.Rel(worktrees[i].Path, path)
+		if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if best == nil || len(worktrees[i].Path) > len(best.Path) {
+			best = &worktrees[i]
+		}
+	}
+	return best
+}
+
+// attachClaimHints fills in each claim's likely files. xref may be nil,
+// leaving only the files beads declare.
+func attachClaimHints(claims []branchClaim, xref *correlation.XRefReport) {
+	for i := range claims {
+		issue := claims[i].issue
+		issue.ID = claims[i].IssueID
+		claims[i].hints = correlation.FileHints(issue, xref.For(issue.ID), correlation.DefaultFileHintLimit)
+	}
+}
+
+func (c branchClaim) entry() worktreeClaim {
+	files := make([]string, len(c.hints))
+	for i, h := range c.hints {
+		files[i] = h.Path
+	}
+	return worktreeClaim{
+		IssueID:   c.IssueID,
+		Title:     c.issue.Title,
+		Status:    string(c.issue.Status),
+		Agent:     c.Agent,
+		Branch:    c.Branch,
+		Worktree:  c.Worktree,
+		ClaimedAt: c.ClaimedAt,
+		Files:     files,
+	}
+}
+
+// describe names a claim for warnings: "bv-1 (alice on feature/x)".
+func (c branchClaim) describe() string {
+	agent := c.Agent
+	if agent == "" {
+		agent = "an anonymous agent"
+	}
+	branch := c.Branch
+	if branch == "" {
+		branch = "a detached HEAD"
+	}
+	return fmt.Sprintf("%s (%s on %s)", c.IssueID, agent, branch)
+}
+
+// conflictBetween reports whether a and b, claimed on different branches,
+// touch the same files.
+func conflictBetween(a, b branchClaim) (claimConflict, bool) {
+	sameBranch := a.Branch != "" && a.Branch == b.Branch
+	sameCheckout := a.Branch == "" && b.Branch == "" && a.Worktree == b.Worktree
+	if a.IssueID == b.IssueID || sameBranch || sameCheckout {
+		return claimConflict{}, false
+	}
+	files := correlation.HintsOverlap(a.hints, b.hints)
+	if len(files) == 0 {
+		return claimConflict{}, false
+	}
+	return claimConflict{
+		Files:   files,
+		Claims:  []worktreeClaim{a.entry(), b.entry()},
+		Warning: fmt.Sprintf("%s and %s both touch %s; their branches will conflict", a.describe(), b.describe(), strings.Join(files, ", ")),
+	}, true
+}
+
+// claimConflicts returns every pair of claims that conflict, oldest claims
+// first.
+func claimConflicts(claims []branchClaim) []claimConflict {
+	conflicts := []claimConflict{}
+	for i := range claims {
+		for j := i + 1; j < len(claims); j++ {
+			if c, ok := conflictBetween(claims[i], claims[j]); ok {
+				conflicts = append(conflicts, c)
+			}
+		}
+	}
+	return conflicts
+}
+
+// buildRobotWorktrees groups claims under their worktrees.
+func buildRobotWorktrees(worktrees []correlation.Worktree, claims []branchClaim, now time.Time) robotWorktrees {
+	out := robotWorktrees{
+		GeneratedAt: now.UTC().Format(time.RFC3339),
+		Worktrees:   make([]worktreeEntry, len(worktrees)),
+		Conflicts:   claimConflicts(claims),
+	}
+	for i, wt := range worktrees {
+		out.Worktrees[i] = worktreeEntry{Worktree: wt, Claims: []worktreeClaim{}}
+	}
+	for _, c := range claims {
+		placed := false
+		for i := range out.Worktrees {
+			if c.Worktree != "" && correlation.SamePath(out.Worktrees[i].Path, c.Worktree) {
+				out.Worktrees[i].Claims = append(out.Worktrees[i].Claims, c.entry())
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			out.Unattributed = append(out.Unattributed, c.entry())
+		}
+	}
+	return out
+}
+
+// checkoutClaimWarnings returns warnings for issue, about to be worked by
+// agent at checkout, against claims on other branches touching its files.
+func checkoutClaimWarnings(root string, issue model.Issue, agent string, checkout correlation.Checkout, now time.Time) []string {
+	worktrees, err := correlation.Worktrees(root)
+	if err != nil {
+		return nil
+	}
+	claims := loadBranchClaims(worktrees, now)
+	others := claims[:0]
+	for _, c := range claims {
+		if c.IssueID != issue.ID && c.Branch != checkout.Branch {
+			others = append(others, c)
+		}
+	}
+	if len(others) == 0 {
+		return nil
+	}
+	mine := branchClaim{
+		Claim: session.Claim{IssueID: issue.ID, Agent: agent, Branch: checkout.Branch, Worktree: checkout.Worktree},
+		issue: issue,
+	}
+	all := append([]branchClaim{mine}, others...)
+	xref, _ := correlation.CrossReference(root, claimIssueIDs(all), correlation.XRefOptions{})
+	attachClaimHints(all, xref)
+	var warnings []string
+	for _, other := range all[1:] {
+		if c, ok := conflictBetween(all[0], other); ok {
+			warnings = append(warnings, c.Warning)
+		}
+	}
+	return warnings
+}
+
+func claimIssueIDs(claims []branchClaim) []string {
+	ids := make([]string, len(claims))
+	for i, c := range claims {
+		ids[i] = c.IssueID
+	}
+	return ids
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+func testBranchClaim(id, agent, branch, worktree string, files ...string) branchClaim {
+	c := branchClaim{
+		Claim: session.Claim{IssueID: id, Agent: agent, Branch: branch, Worktree: worktree},
+		issue: model.Issue{ID: id, Title: "Title " + id},
+	}
+	for _, f := range files {
+		c.hints = append(c.hints, correlation.FileHint{Path: f, Source: correlation.FileHintDeclared})
+	}
+	return c
+}
+
+func TestClaimConflicts(t *testing.T) {
+	claims := []branchClaim{
+		testBranchClaim("bv-1", "alice", "main", "/src/app", "pkg/auth/token.go"),
+		testBranchClaim("bv-2", "bob", "feature/b", "/src/app-b", "pkg/auth/**"),
+		testBranchClaim("bv-3", "carol", "main", "/src/app", "pkg/auth/session.go"),
+		testBranchClaim("bv-4", "", "", "/src/app-c", "pkg/ui/model.go"),
+		testBranchClaim("bv-5", "dee", "feature/d", "/src/app-d", "pkg/ui/model.go"),
+	}
+	conflicts := claimConflicts(claims)
+	var got []string
+	for _, c := range conflicts {
+		got = append(got, c.Claims[0].IssueID+"/"+c.Claims[1].IssueID+":"+strings.Join(c.Files, ","))
+	}
+	// bv-1 and bv-3 share a branch; bv-4 is detached elsewhere
+	want := "bv-1/bv-2:pkg/auth/token.go bv-2/bv-3:pkg/auth/session.go bv-4/bv-5:pkg/ui/model.go"
+	if strings.Join(got, " ") != want {
+		t.Errorf("conflicts = %q, want %s", got, want)
+	}
+	if w := conflicts[2].Warning; w != "bv-4 (an anonymous agent on a detached HEAD) and bv-5 (dee on feature/d) both touch pkg/ui/model.go; their branches will conflict" {
+		t.Errorf("warning = %q", w)
+	}
+}
+
+func TestBuildRobotWorktrees(t *testing.T) {
+	worktrees := []correlation.Worktree{
+		{Path: "/src/app", Branch: "main", Current: true},
+		{Path: "/src/app-b", Branch: "feature/b"},
+	}
+	claims := []branchClaim{
+		testBranchClaim("bv-1", "alice", "main", "/src/app", "pkg/a.go"),
+		testBranchClaim("bv-2", "bob", "feature/b", "/src/app-b", "pkg/b.go"),
+		testBranchClaim("bv-3", "carol", "", "", "pkg/c.go"),
+	}
+	out := buildRobotWorktrees(worktrees, claims, time.Now())
+	if len(out.Worktrees) != 2 || len(out.Worktrees[0].Claims) != 1 || out.Worktrees[1].Claims[0].IssueID != "bv-2" {
+		t.Fatalf("worktrees = %+v", out.Worktrees)
+	}
+	if len(out.Unattributed) != 1 || out.Unattributed[0].IssueID != "bv-3" {
+		t.Errorf("unattributed = %+v", out.Unattributed)
+	}
+	if out.Conflicts == nil || len(out.Conflicts) != 0 {
+		t.Errorf("conflicts = %+v, want none (not null)", out.Conflicts)
+	}
+}
+
+func TestLoadBranchClaims(t *testing.T) {
+	t.Setenv("BEADS_DIR", "")
+	root := t.TempDir()
+	app, other := filepath.Join(root, "app"), filepath.Join(root, "app-b")
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	write := func(path, data string) {
+		t.Helper()
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The other worktree's own data shows its claim in progress
+	write(filepath.Join(app, ".beads", "issues.jsonl"), `{"id":"bv-1","title":"One","status":"open","issue_type":"task"}`+"\n")
+	write(filepath.Join(other, ".beads", "issues.jsonl"), `{"id":"bv-1","title":"One","status":"in_progress","issue_type":"task"}`+"\n")
+	write(filepath.Join(other, ".bv", "sessions.json"), `{"claims":[{"issue_id":"bv-1","agent":"bob","claimed_at":"2026-05-01T00:00:00Z","command":"bd"}]}`)
+
+	worktrees := []correlation.Worktree{{Path: app, Branch: "main"}, {Path: other, Branch: "feature/b"}}
+	claims := loadBranchClaims(worktrees, now)
+	if len(claims) != 1 {
+		t.Fatalf("claims = %+v", claims)
+	}
+	if c := claims[0]; c.Branch != "feature/b" || c.Worktree != other || c.issue.Status != model.StatusInProgress {
+		t.Errorf("claim = %+v, issue %+v", c.Claim, c.issue)
+	}
+}
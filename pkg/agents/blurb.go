@@ -4,7 +4,6 @@
 package agents
 
 import (
-	"regexp"
 	"strconv"
 	"strings"
 )
@@ -19,46 +18,63 @@ const BlurbStartMarker = "<!-- bv-agent-instructions-v1 -->"
 // BlurbEndMarker marks the end of injected agent instructions.
 const BlurbEndMarker = "<!-- end-bv-agent-instructions -->"
 
-// AgentBlurb contains the instructions to be appended to AGENTS.md files.
-const AgentBlurb = `<!-- bv-agent-instructions-v1 -->
+// blurbTemplate renders the agent instructions for one BlurbTarget and
+// project; see blurbVariants for the per-tool data and ProjectInfo for the
+// project placeholders. The generic rendering is AgentBlurb.
+const blurbTemplate = `<!-- bv-agent-instructions-v1 -->
+{{- if .Marker}}
+{{.Marker}}
+{{- end}}
+{{- if .Record}}
+{{.Record}}
+{{- end}}
 
 ---
 
 ## Beads Workflow Integration
 
-This project uses [beads_viewer](https://github.com/Dicklesworthstone/beads_viewer) for issue tracking. Issues are stored in ` + "`" + `.beads/` + "`" + ` and tracked in git.
+{{if .Project.Name}}**{{.Project.Name}}** uses{{else}}This project uses{{end}} [beads_viewer](https://github.com/Dicklesworthstone/beads_viewer) for issue tracking. Issues are stored in ` + "`" + `.beads/` + "`" + ` and tracked in git.
+{{- if .Project.IssuePrefix}} Issue IDs look like ` + "`" + `{{.Project.IssuePrefix}}-42` + "`" + `.{{end}}
+{{- if .RunHint}}
+
+{{.RunHint}}
+{{- end}}
 
 ### Essential Commands
 
 ` + "```" + `bash
-# View issues (launches TUI - avoid in automated sessions)
+# {{.TUIHint}}
 bv
 
 # CLI commands for agents (use these instead)
-bd ready              # Show issues ready to work (no blockers)
-bd list --status=open # All open issues
-bd show <id>          # Full issue details with dependencies
-bd create --title="..." --type=task --priority=2
-bd update <id> --status=in_progress
-bd close <id> --reason="Completed"
-bd close <id1> <id2>  # Close multiple issues at once
-bd sync               # Commit and push changes
+{{.Bd}} ready              # Show issues ready to work (no blockers)
+{{.Bd}} list --status=open # All open issues
+{{.Bd}} show <id>          # Full issue details with dependencies
+{{.Bd}} create --title="..." --type=task --priority=2
+{{.Bd}} update <id> --status=in_progress
+{{.Bd}} close <id> --reason="Completed"
+{{.Bd}} close <id1> <id2>  # Close multiple issues at once
+{{.Bd}} sync               # Commit and push changes
 ` + "```" + `
 
 ### Workflow Pattern
 
-1. **Start**: Run ` + "`" + `bd ready` + "`" + ` to find actionable work
-2. **Claim**: Use ` + "`" + `bd update <id> --status=in_progress` + "`" + `
+1. **Start**: Run ` + "`" + `{{.Entry}}` + "`" + ` to find actionable work
+2. **Claim**: Use ` + "`" + `{{.Bd}} update <id> --status=in_progress` + "`" + `
 3. **Work**: Implement the task
-4. **Complete**: Use ` + "`" + `bd close <id>` + "`" + `
-5. **Sync**: Always run ` + "`" + `bd sync` + "`" + ` at session end
+4. **Complete**: Use ` + "`" + `{{.Bd}} close <id>` + "`" + `
+5. **Sync**: Always run ` + "`" + `{{.Bd}} sync` + "`" + ` at session end
 
 ### Key Concepts
 
-- **Dependencies**: Issues can block other issues. ` + "`" + `bd ready` + "`" + ` shows only unblocked work.
+- **Dependencies**: Issues can block other issues. ` + "`" + `{{.Bd}} ready` + "`" + ` shows only unblocked work.
 - **Priority**: P0=critical, P1=high, P2=medium, P3=low, P4=backlog (use numbers, not words)
 - **Types**: task, bug, feature, epic, question, docs
-- **Blocking**: ` + "`" + `bd dep add <issue> <depends-on>` + "`" + ` to add dependencies
+- **Blocking**: ` + "`" + `{{.Bd}} dep add <issue> <depends-on>` + "`" + ` to add dependencies
+{{- if .Compact}}
+
+**Before ending any session:** commit code, run ` + "`" + `{{.Bd}} sync` + "`" + `, and ` + "`" + `git push` + "`" + `.
+{{- else}}
 
 ### Session Protocol
 
@@ -67,33 +83,39 @@ bd sync               # Commit and push changes
 ` + "```" + `bash
 git status              # Check what changed
 git add <files>         # Stage code changes
-bd sync                 # Commit beads changes
+{{.Bd}} sync                 # Commit beads changes
 git commit -m "..."     # Commit code
-bd sync                 # Commit any new beads changes
+{{.Bd}} sync                 # Commit any new beads changes
 git push                # Push to remote
 ` + "```" + `
 
 ### Best Practices
 
-- Check ` + "`" + `bd ready` + "`" + ` at session start to find available work
+- Check ` + "`" + `{{.Entry}}` + "`" + ` at session start to find available work
 - Update status as you work (in_progress → closed)
-- Create new issues with ` + "`" + `bd create` + "`" + ` when you discover tasks
+- Create new issues with ` + "`" + `{{.Bd}} create` + "`" + ` when you discover tasks
 - Use descriptive titles and set appropriate priority/type
-- Always ` + "`" + `bd sync` + "`" + ` before ending session
+- Always ` + "`" + `{{.Bd}} sync` + "`" + ` before ending session
+{{- end}}
 
 <!-- end-bv-agent-instructions -->`
 
-// SupportedAgentFiles lists the filenames that can contain agent instructions.
+// AgentBlurb contains the instructions appended to AGENTS.md and other
+// files without a tool-specific variant.
+var AgentBlurb = BlurbFor(TargetGeneric)
+
+// SupportedAgentFiles lists the files that can contain agent instructions,
+// as slash-separated paths relative to the project root.
 var SupportedAgentFiles = []string{
 	"AGENTS.md",
 	"CLAUDE.md",
 	"agents.md",
 	"claude.md",
+	".cursorrules",
+	".windsurfrules",
+	".github/copilot-instructions.md",
 }
 
-// blurbVersionRegex extracts the version number from a blurb marker.
-var blurbVersionRegex = regexp.MustCompile(`<!-- bv-agent-instructions-v(\d+) -->`)
-
 // LegacyBlurbPatterns are markers that identify the old blurb format (pre-v1, no HTML markers).
 var LegacyBlurbPatterns = []string{
 	"### Using bv as an AI sidecar",
@@ -102,38 +124,24 @@ var LegacyBlurbPatterns = []string{
 	"bv already computes the hard parts",
 }
 
-// legacyBlurbStartPattern matches the beginning of the legacy blurb.
-var legacyBlurbStartPattern = regexp.MustCompile(`(?m)^#{2,3}\s*Using bv as an AI sidecar`)
-
-// legacyBlurbEndPattern matches content near the end of the legacy blurb.
-// Uses non-capturing group to make the entire triple-backtick sequence optional.
-var legacyBlurbEndPattern = regexp.MustCompile(`(?m)bv already computes the hard parts[^\n]*(?:\n*` + "```" + `)?\n*`)
-
-// legacyBlurbNextSectionPattern matches the start of a new section after the legacy blurb.
-// Used as fallback when the end pattern isn't found.
-var legacyBlurbNextSectionPattern = regexp.MustCompile(`(?m)^#{1,2}\s+[^#]`)
-
 // ContainsBlurb checks if the content already contains a beads_viewer agent blurb.
 func ContainsBlurb(content string) bool {
-	return strings.Contains(content, "<!-- bv-agent-instructions-v")
+	return strings.Contains(content, blurbStartPrefix)
 }
 
 // ContainsLegacyBlurb checks if the content contains the old-format blurb (pre-v1, no HTML markers).
 // Requires all 4 legacy patterns to match to avoid false positives on content that
 // merely references robot flags (like the current AGENTS.md documentation).
 func ContainsLegacyBlurb(content string) bool {
-	if !legacyBlurbStartPattern.MatchString(content) {
-		return false
-	}
-	matchCount := 0
+	// Require all patterns - the key differentiator is "bv already computes the hard parts"
+	// which only appears in the legacy blurb, not in current documentation.
+	// They are plain substrings, so check them before the regex.
 	for _, pattern := range LegacyBlurbPatterns {
-		if strings.Contains(content, pattern) {
-			matchCount++
+		if !strings.Contains(content, pattern) {
+			return false
 		}
 	}
-	// Require all patterns - the key differentiator is "bv already computes the hard parts"
-	// which only appears in the legacy blurb, not in current documentation
-	return matchCount == len(LegacyBlurbPatterns)
+	return legacyBlurbStartPattern.get().MatchString(content)
 }
 
 // ContainsAnyBlurb checks if the content contains either the current or legacy blurb format.
@@ -143,7 +151,10 @@ func ContainsAnyBlurb(content string) bool {
 
 // GetBlurbVersion extracts the version number from existing blurb content.
 func GetBlurbVersion(content string) int {
-	matches := blurbVersionRegex.FindStringSubmatch(content)
+	if !strings.Contains(content, blurbStartPrefix) {
+		return 0
+	}
+	matches := blurbVersionRegex.get().FindStringSubmatch(content)
 	if len(matches) < 2 {
 		return 0
 	}
@@ -165,20 +176,14 @@ func NeedsUpdate(content string) bool {
 	return GetBlurbVersion(content) < BlurbVersion
 }
 
-// AppendBlurb appends the agent blurb to the given content.
+// AppendBlurb appends the generic agent blurb to the given content.
 func AppendBlurb(content string) string {
-	if !strings.HasSuffix(content, "\n") {
-		content += "\n"
-	}
-	content += "\n"
-	content += AgentBlurb
-	content += "\n"
-	return content
+	return AppendBlurbFor(content, TargetGeneric)
 }
 
 // RemoveBlurb removes an existing blurb from the content.
 func RemoveBlurb(content string) string {
-	startIdx := strings.Index(content, "<!-- bv-agent-instructions-v")
+	startIdx := strings.Index(content, blurbStartPrefix)
 	if startIdx == -1 {
 		return content
 	}
@@ -201,18 +206,18 @@ func RemoveLegacyBlurb(content string) string {
 	if !ContainsLegacyBlurb(content) {
 		return content
 	}
-	startLoc := legacyBlurbStartPattern.FindStringIndex(content)
+	startLoc := legacyBlurbStartPattern.get().FindStringIndex(content)
 	if startLoc == nil {
 		return content
 	}
 	startIdx := startLoc[0]
-	endLoc := legacyBlurbEndPattern.FindStringIndex(content[startIdx:])
+	endLoc := legacyBlurbEndPattern.get().FindStringIndex(content[startIdx:])
 	var endIdx int
 	if endLoc != nil {
 		endIdx = startIdx + endLoc[1]
 	} else {
 		// Fallback: find the next major section heading
-		nextLoc := legacyBlurbNextSectionPattern.FindStringIndex(content[startIdx+10:])
+		nextLoc := legacyBlurbNextSectionPattern.get().FindStringIndex(content[startIdx+10:])
 		if nextLoc != nil {
 			endIdx = startIdx + 10 + nextLoc[0]
 		} else {
@@ -231,9 +236,7 @@ func RemoveLegacyBlurb(content string) string {
 	return content[:startIdx] + content[endIdx:]
 }
 
-// UpdateBlurb replaces an existing blurb with the current version.
+// UpdateBlurb replaces an existing blurb with the current generic version.
 func UpdateBlurb(content string) string {
-	content = RemoveLegacyBlurb(content)
-	content = RemoveBlurb(content)
-	return AppendBlurb(content)
+	return UpdateBlurbFor(content, TargetGeneric)
 }
@@ -227,6 +227,10 @@ func TestSupportedAgentFiles(t *testing.T) {
 		"CLAUDE.md": true,
 		"agents.md": true,
 		"claude.md": true,
+
+		".cursorrules":                    true,
+		".windsurfrules":                  true,
+		".github/copilot-instructions.md": true,
 	}
 
 	for _, file := range SupportedAgentFiles {
@@ -0,0 +1,73 @@
+package agents
+
+import (
+	"strings"
+)
+
+// Conventions is what an agent file says about working in the project,
+// outside bv's own blurb: its section headings and its list-item rules.
+type Conventions struct {
+	Headings  []string `json:"headings"`
+	Rules     []string `json:"rules"`
+	Truncated bool     `json:"truncated,omitempty"` // more than maxRules rules
+}
+
+// ExtractConventions pulls the headings and bullet or numbered list items out
+// of an agent file's content, skipping the bv blurb and code blocks. At most
+// maxRules rules are returned.
+func ExtractConventions(content string, maxRules int) Conventions {
+	_, content, _ = splitFrontmatter(RemoveLegacyBlurb(content))
+	// Cut the blurb out at line boundaries; RemoveBlurb would join the
+	// lines around it.
+	if start := strings.Index(content, "<!-- bv-agent-instructions-v"); start != -1 {
+		if end := strings.Index(content[start:], BlurbEndMarker); end != -1 {
+			content = content[:start] + "\n" + content[start+end+len(BlurbEndMarker):]
+		}
+	}
+
+	c := Conventions{Headings: []string{}, Rules: []string{}}
+	inFence := false
+	for _, line := range strings.Split(content, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			inFence = !inFence
+			continue
+		}
+		if inFence || trimmed == "" {
+			continue
+		}
+		if strings.HasPrefix(trimmed, "#") {
+			if heading := strings.TrimSpace(strings.TrimLeft(trimmed, "#")); heading != "" {
+				c.Headings = append(c.Headings, heading)
+			}
+			continue
+		}
+		rule, ok := listItem(trimmed)
+		if !ok {
+			continue
+		}
+		if len(c.Rules) >= maxRules {
+			c.Truncated = true
+			continue
+		}
+		c.Rules = append(c.Rules, rule)
+	}
+	return c
+}
+
+// listItem returns the text of a markdown list item ("- x", "* x", "1. x").
+func listItem(line string) (string, bool) {
+	for _, bullet := range []string{"- ", "* ", "+ "} {
+		if strings.HasPrefix(line, bullet) {
+			return strings.TrimSpace(line[len(bullet):]), true
+		}
+	}
+	digits := 0
+	for digits < len(line) && line[digits] >= '0' && line[digits] <= '9' {
+		digits++
+	}
+	if digits > 0 && strings.HasPrefix(line[digits:], ". ") {
+		return strings.TrimSpace(line[digits+2:]), true
+	}
+	return "", false
+}
@@ -0,0 +1,26 @@
+package agents
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestExtractConventions(t *testing.T) {
+	content := "---\napplyTo: \"**\"\n---\n# Rules\n\n- Use tabs\n* No globals\n1. Test first\n\nProse is skipped.\n\n```go\n- inside code\n```\n\n" +
+		AgentBlurb + "\n\n## After\n\n- Last rule\n"
+
+	c := ExtractConventions(content, 10)
+	if got := strings.Join(c.Headings, "|"); got != "Rules|After" {
+		t.Errorf("headings = %q (blurb headings must be skipped)", got)
+	}
+	if got := strings.Join(c.Rules, "|"); got != "Use tabs|No globals|Test first|Last rule" {
+		t.Errorf("rules = %q", got)
+	}
+	if c.Truncated {
+		t.Error("should not be truncated")
+	}
+
+	if c := ExtractConventions(content, 2); len(c.Rules) != 2 || !c.Truncated {
+		t.Errorf("maxRules: got %d rules, truncated=%v", len(c.Rules), c.Truncated)
+	}
+}
@@ -3,6 +3,7 @@ package agents
 import (
 	"os"
 	"path/filepath"
+	"sync"
 )
 
 // AgentFileDetection contains the result of detecting an agent config file.
@@ -22,6 +23,13 @@ type AgentFileDetection struct {
 	// BlurbVersion is the version of the blurb found (0 if none or legacy)
 	BlurbVersion int
 
+	// BlurbTarget is the variant of the blurb found (generic if none)
+	BlurbTarget BlurbTarget
+
+	// BlurbModified indicates the current-version blurb was edited by hand
+	// (see IsBlurbModified)
+	BlurbModified bool
+
 	// Content is the file content (populated if file was read)
 	Content string
 }
@@ -37,44 +45,83 @@ func (d AgentFileDetection) NeedsBlurb() bool {
 }
 
 // NeedsUpgrade returns true if the file has an older version of the blurb
-// (either legacy format or outdated versioned blurb).
+// (either legacy format or outdated versioned blurb), or a current blurb
+// written for a different tool than the file is for.
 func (d AgentFileDetection) NeedsUpgrade() bool {
 	if d.HasLegacyBlurb {
 		return true
 	}
-	return d.HasBlurb && d.BlurbVersion < BlurbVersion
+	return d.HasBlurb && (d.BlurbVersion < BlurbVersion || d.HasWrongVariant())
+}
+
+// HasWrongVariant returns true if the file's blurb is another tool's variant,
+// such as the generic blurb in a CLAUDE.md written before variants existed.
+func (d AgentFileDetection) HasWrongVariant() bool {
+	got := d.BlurbTarget
+	if got == "" {
+		got = TargetGeneric
+	}
+	return d.HasBlurb && !d.HasLegacyBlurb && got != TargetForFile(d.FilePath)
 }
 
-// DetectAgentFile looks for AGENTS.md or CLAUDE.md in the given directory.
-// It checks AGENTS.md first (preferred), then falls back to CLAUDE.md.
+// DetectAgentFile looks for a supported agent file in the given directory.
+// It checks AGENTS.md first (preferred), then CLAUDE.md, then the lowercase
+// variants and tool-specific rule files in SupportedAgentFiles order.
 // The function reads the file content to check for existing blurb markers.
 func DetectAgentFile(workDir string) AgentFileDetection {
-	// Try each supported file in order of preference
-	for _, filename := range SupportedAgentFiles {
-		// Only check uppercase variants first (AGENTS.md, CLAUDE.md)
-		if filename[0] < 'A' || filename[0] > 'Z' {
+	if found := DetectAgentFiles(workDir); len(found) > 0 {
+		return found[0]
+	}
+	return AgentFileDetection{}
+}
+
+// DetectAgentFiles returns every supported agent file in workDir, in the
+// order DetectAgentFile prefers them. Names that resolve to the same file
+// (AGENTS.md and agents.md on a case-insensitive filesystem) are reported once.
+func DetectAgentFiles(workDir string) []AgentFileDetection {
+	var found []AgentFileDetection
+	var seen []os.FileInfo
+	for _, filename := range agentFilesByPreference() {
+		filePath := filepath.Join(workDir, filepath.FromSlash(filename))
+		detection := checkAgentFile(filePath, filename)
+		if !detection.Found() {
 			continue
 		}
-
-		filePath := filepath.Join(workDir, filename)
-		if detection := checkAgentFile(filePath, filename); detection.Found() {
-			return detection
+		info, err := os.Stat(filePath)
+		if err != nil {
+			continue
 		}
+		duplicate := false
+		for _, s := range seen {
+			if os.SameFile(s, info) {
+				duplicate = true
+				break
+			}
+		}
+		if duplicate {
+			continue
+		}
+		seen = append(seen, info)
+		found = append(found, detection)
 	}
+	return found
+}
 
-	// Try lowercase variants as fallback
+// agentFilesByPreference returns SupportedAgentFiles with the uppercase
+// variants (AGENTS.md, CLAUDE.md) first.
+func agentFilesByPreference() []string {
+	ordered := make([]string, 0, len(SupportedAgentFiles))
 	for _, filename := range SupportedAgentFiles {
 		if filename[0] >= 'A' && filename[0] <= 'Z' {
-			continue
+			ordered = append(ordered, filename)
 		}
-
-		filePath := filepath.Join(workDir, filename)
-		if detection := checkAgentFile(filePath, filename); detection.Found() {
-			return detection
+	}
+	for _, filename := range SupportedAgentFiles {
+		if filename[0] < 'A' || filename[0] > 'Z' {
+			ordered = append(ordered, filename)
 		}
 	}
-
-	return AgentFileDetection{}
+	return ordered
 }
 
 // checkAgentFile checks a specific file path for agent configuration.
@@ -96,6 +143,14 @@ func checkAgentFile(filePath, fileType string) AgentFileDetection {
 	}
 
 	contentStr := string(content)
+	if !mayContainBlurb(content) {
+		return AgentFileDetection{
+			FilePath:    filePath,
+			FileType:    fileType,
+			BlurbTarget: TargetGeneric,
+			Content:     contentStr,
+		}
+	}
 	hasLegacy := ContainsLegacyBlurb(contentStr)
 
 	return AgentFileDetection{
@@ -104,6 +159,8 @@ func checkAgentFile(filePath, fileType string) AgentFileDetection {
 		HasBlurb:       ContainsAnyBlurb(contentStr),
 		HasLegacyBlurb: hasLegacy,
 		BlurbVersion:   GetBlurbVersion(contentStr),
+		BlurbTarget:    GetBlurbTarget(contentStr),
+		BlurbModified:  IsBlurbModified(contentStr, filePath),
 		Content:        contentStr,
 	}
 }
@@ -112,12 +169,12 @@ func checkAgentFile(filePath, fileType string) AgentFileDetection {
 // and walking up the directory tree. This is useful for finding a project-level
 // AGENTS.md when running from a subdirectory.
 // maxLevels limits how many parent directories to check (0 = current only).
+// The levels are checked concurrently; the nearest detection wins.
 func DetectAgentFileInParents(workDir string, maxLevels int) AgentFileDetection {
+	var dirs []string
 	currentDir := workDir
 	for i := 0; i <= maxLevels; i++ {
-		if detection := DetectAgentFile(currentDir); detection.Found() {
-			return detection
-		}
+		dirs = append(dirs, currentDir)
 
 		// Move to parent directory
 		parentDir := filepath.Dir(currentDir)
@@ -128,6 +185,25 @@ func DetectAgentFileInParents(workDir string, maxLevels int) AgentFileDetection
 		currentDir = parentDir
 	}
 
+	detections := make([]AgentFileDetection, len(dirs))
+	sem := make(chan struct{}, walkConcurrency)
+	var wg sync.WaitGroup
+	for i, dir := range dirs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+			detections[i] = DetectAgentFile(dir)
+		}()
+	}
+	wg.Wait()
+
+	for _, detection := range detections {
+		if detection.Found() {
+			return detection
+		}
+	}
 	return AgentFileDetection{}
 }
 
@@ -135,7 +211,7 @@ func DetectAgentFileInParents(workDir string, maxLevels int) AgentFileDetection
 // This is a quick check without reading file content.
 func AgentFileExists(workDir string) bool {
 	for _, filename := range SupportedAgentFiles {
-		filePath := filepath.Join(workDir, filename)
+		filePath := filepath.Join(workDir, filepath.FromSlash(filename))
 		if info, err := os.Stat(filePath); err == nil && !info.IsDir() {
 			return true
 		}
@@ -330,3 +330,49 @@ Use bv instead of parsing beads.jsonl.
 		t.Error("Expected HasBlurb to be false - no current or legacy blurb markers")
 	}
 }
+
+func TestDetectAgentFile_ToolRuleFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	copilot := filepath.Join(tmpDir, ".github", "copilot-instructions.md")
+	if err := os.MkdirAll(filepath.Dir(copilot), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(copilot, []byte("# Copilot\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	detection := DetectAgentFile(tmpDir)
+	if detection.FilePath != copilot || detection.FileType != ".github/copilot-instructions.md" {
+		t.Fatalf("expected nested copilot file, got %+v", detection)
+	}
+	if !AgentFileExists(tmpDir) {
+		t.Error("AgentFileExists should see nested files")
+	}
+
+	cursorrules := filepath.Join(tmpDir, ".cursorrules")
+	if err := os.WriteFile(cursorrules, []byte("Be terse.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	agentsMD := filepath.Join(tmpDir, "AGENTS.md")
+	if err := os.WriteFile(agentsMD, []byte("# Agents\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	all := DetectAgentFiles(tmpDir)
+	var paths []string
+	for _, d := range all {
+		paths = append(paths, d.FilePath)
+	}
+	want := []string{agentsMD, cursorrules, copilot}
+	if len(paths) != len(want) {
+		t.Fatalf("DetectAgentFiles = %v, want %v", paths, want)
+	}
+	for i := range want {
+		if paths[i] != want[i] {
+			t.Errorf("DetectAgentFiles[%d] = %s, want %s", i, paths[i], want[i])
+		}
+	}
+	if DetectAgentFile(tmpDir).FilePath != agentsMD {
+		t.Error("AGENTS.md should still be preferred")
+	}
+}
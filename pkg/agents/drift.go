@@ -0,0 +1,191 @@
+package agents
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// extractBlurb returns the blurb in content, from its start marker through
+// its end marker, with LF line endings. It returns "" if content has no
+// complete blurb.
+func extractBlurb(content string) string {
+	start := strings.Index(content, blurbStartPrefix)
+	if start == -1 {
+		return ""
+	}
+	end := strings.Index(content[start:], BlurbEndMarker)
+	if end == -1 {
+		return ""
+	}
+	return strings.ReplaceAll(content[start:start+end+len(BlurbEndMarker)], "\r\n", "\n")
+}
+
+// BlurbHash is the content hash of a blurb, insensitive to line endings.
+func BlurbHash(blurb string) string {
+	sum := sha256.Sum256([]byte(strings.ReplaceAll(blurb, "\r\n", "\n")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// knownBlurbs returns every rendering of the current blurb version bv could
+// have written as blurb to filePath: each target's variant, plain and with
+// project values. A blurb that records its project values is rendered with
+// those, so a config changed since install doesn't count as a hand edit;
+// older blurbs without a record fall back to the file's current values.
+func knownBlurbs(blurb, filePath string) []string {
+	project, ok := recordedProject(blurb)
+	if !ok {
+		project = projectInfoForFile(filePath)
+	}
+	var known []string
+	for target := range blurbVariants {
+		known = append(known, BlurbFor(target))
+		if project == (ProjectInfo{}) {
+			continue
+		}
+		if b, err := BlurbForProject(target, project); err == nil {
+			known = append(known, b)
+		}
+	}
+	return known
+}
+
+// IsBlurbModified reports whether the blurb in content, the agent file at
+// filePath, was edited by hand: its hash matches none of the renderings bv
+// writes. Only current-version blurbs can be checked; older and legacy
+// blurbs, and content without a blurb, report false.
+func IsBlurbModified(content, filePath string) bool {
+	blurb := extractBlurb(content)
+	if blurb == "" || GetBlurbVersion(blurb) != BlurbVersion {
+		return false
+	}
+	hash := BlurbHash(blurb)
+	for _, known := range knownBlurbs(blurb, filePath) {
+		if BlurbHash(known) == hash {
+			return false
+		}
+	}
+	return true
+}
+
+// closestKnownBlurb returns the rendering bv most likely wrote before blurb
+// was edited: the known rendering with the most lines in common.
+func closestKnownBlurb(blurb, filePath string) string {
+	ours := difflib.SplitLines(blurb)
+	best, bestRatio := BlurbFor(TargetForFile(filePath)), -1.0
+	for _, known := range knownBlurbs(blurb, filePath) {
+		ratio := difflib.NewMatcher(difflib.SplitLines(known), ours).Ratio()
+		if ratio > bestRatio {
+			best, bestRatio = known, ratio
+		}
+	}
+	return best
+}
+
+// blurbSection is one "##"/"###" section of a blurb body. The section before
+// the first heading has an empty heading.
+type blurbSection struct {
+	heading string
+	text    string
+}
+
+// splitBlurbSections splits the body between a blurb's marker lines into
+// sections at markdown headings outside code fences.
+func splitBlurbSections(body string) []blurbSection {
+	sections := []blurbSection{{}}
+	inFence := false
+	for _, line := range strings.SplitAfter(body, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if !inFence && (strings.HasPrefix(line, "## ") || strings.HasPrefix(line, "### ")) {
+			sections = append(sections, blurbSection{heading: trimmed})
+		}
+		sections[len(sections)-1].text += line
+	}
+	return sections
+}
+
+// blurbBody returns the text between a blurb's start marker line and its
+// end marker.
+func blurbBody(blurb string) string {
+	_, rest, _ := strings.Cut(blurb, "\n")
+	return strings.TrimSuffix(rest, BlurbEndMarker)
+}
+
+// MergeBlurb three-way merges blurb sections: base is the blurb bv wrote,
+// ours the hand-edited copy in the file, and theirs the blurb bv writes now.
+// Sections the user added (headings bv never wrote) are kept where they
+// were. Known sections take theirs unless only the user changed them; when
+// both sides changed a section, theirs wins and its heading is returned in
+// overwritten so the caller can tell the user.
+func MergeBlurb(base, ours, theirs string) (merged string, overwritten []string) {
+	index := func(sections []blurbSection) map[string]string {
+		m := make(map[string]string, len(sections))
+		for _, s := range sections {
+			m[s.heading] = s.text
+		}
+		return m
+	}
+	baseSections := index(splitBlurbSections(blurbBody(base)))
+	theirSections := splitBlurbSections(blurbBody(theirs))
+	theirIndex := index(theirSections)
+	ourSections := splitBlurbSections(blurbBody(ours))
+	ourIndex := index(ourSections)
+
+	// Sections only the user has, grouped under the nearest preceding
+	// section that is still in theirs.
+	extras := make(map[string][]string)
+	anchor := ""
+	for _, s := range ourSections {
+		if _, known := theirIndex[s.heading]; known {
+			anchor = s.heading
+			continue
+		}
+		if baseText, inBase := baseSections[s.heading]; inBase && baseText == s.text {
+			continue // bv dropped this section and the user never touched it
+		}
+		extras[anchor] = append(extras[anchor], s.text)
+	}
+
+	var b strings.Builder
+	b.WriteString(BlurbStartMarker + "\n")
+	for _, s := range theirSections {
+		baseText, inBase := baseSections[s.heading]
+		ourText, inOurs := ourIndex[s.heading]
+		switch {
+		case inBase && !inOurs && baseText == s.text:
+			// Deleted by the user and unchanged by bv: leave it out
+		case !inOurs || ourText == baseText || ourText == s.text:
+			b.WriteString(s.text)
+		case inBase && baseText == s.text:
+			b.WriteString(ourText)
+		default:
+			b.WriteString(s.text)
+			overwritten = append(overwritten, s.heading)
+		}
+		for _, extra := range extras[s.heading] {
+			b.WriteString(extra)
+		}
+	}
+	b.WriteString(BlurbEndMarker)
+	return b.String(), overwritten
+}
+
+// mergedContent is content with its hand-edited blurb merged into the
+// current variant for filePath (see MergeBlurb).
+func mergedContent(filePath, content string) (string, []string, error) {
+	ours := extractBlurb(content)
+	theirs, err := blurbForFile(filePath)
+	if err != nil {
+		return "", nil, err
+	}
+	base := closestKnownBlurb(ours, filePath)
+	merged, overwritten := MergeBlurb(base, ours, theirs)
+	stripped := RemoveBlurb(RemoveLegacyBlurb(content))
+	out, err := insertBlurbText(stripped, merged)
+	return out, overwritten, err
+}
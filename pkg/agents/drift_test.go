@@ -0,0 +1,151 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const ourRules = "### Our Rules\n\n- Run `make lint` before closing an issue\n\n"
+
+// addSection inserts section before the heading in blurb.
+func addSection(blurb, before, section string) string {
+	return strings.Replace(blurb, before, section+before, 1)
+}
+
+func TestIsBlurbModified(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CLAUDE.md")
+	claude := BlurbFor(TargetClaude)
+
+	for name, content := range map[string]string{
+		"pristine":           "# Claude\n\n" + claude + "\n",
+		"crlf":               strings.ReplaceAll("# Claude\n\n"+claude+"\n", "\n", "\r\n"),
+		"other variant":      "# Claude\n\n" + AgentBlurb + "\n",
+		"edits outside":      "# Claude\n\nMine.\n\n" + claude + "\n\nMore of mine.\n",
+		"no blurb":           "# Claude\n",
+		"unterminated blurb": BlurbStartMarker + "\nhalf",
+	} {
+		if IsBlurbModified(content, path) {
+			t.Errorf("%s: reported as modified", name)
+		}
+	}
+
+	edited := "# Claude\n\n" + addSection(claude, "### Key Concepts", ourRules) + "\n"
+	if !IsBlurbModified(edited, path) {
+		t.Error("added section inside the markers should count as modified")
+	}
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if d := checkAgentFile(path, "CLAUDE.md"); !d.BlurbModified || d.NeedsUpgrade() {
+		t.Errorf("hand-edited current blurb: modified=%v needsUpgrade=%v", d.BlurbModified, d.NeedsUpgrade())
+	}
+}
+
+func TestIsBlurbModified_ConfigChangedSinceInstall(t *testing.T) {
+	dir := writeBeadsProject(t, "issue-prefix: acme\nbv-agents:\n  bd-path: ./tools/bd\n", "")
+	path := filepath.Join(dir, "AGENTS.md")
+	if err := EnsureBlurbInFile(path); err != nil {
+		t.Fatal(err)
+	}
+
+	// The project config changes after install: the blurb still matches
+	// what bv wrote, so it is outdated for the project, not hand-edited.
+	config := "issue-prefix: acme\nbv-agents:\n  bd-path: ./bin/bd\n  entry-command: ./bin/bd ready --json\n"
+	if err := os.WriteFile(filepath.Join(dir, ".beads", "config.yaml"), []byte(config), 0644); err != nil {
+		t.Fatal(err)
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if IsBlurbModified(string(content), path) {
+		t.Error("config change after install reported as a hand edit")
+	}
+
+	edited := strings.Replace(string(content), "### Essential Commands", "### Essential Commands (ours)", 1)
+	if !IsBlurbModified(edited, path) {
+		t.Error("hand edit to a project blurb not detected")
+	}
+}
+
+func TestMergeBlurb(t *testing.T) {
+	base := BlurbFor(TargetGeneric)
+	theirs := BlurbFor(TargetClaude)
+
+	if merged, overwritten := MergeBlurb(base, base, theirs); merged != theirs || overwritten != nil {
+		t.Fatalf("unedited blurb should merge to theirs exactly (overwritten %v)", overwritten)
+	}
+
+	// A section the user added survives in place; bv's changes still land.
+	ours := addSection(base, "### Key Concepts", ourRules)
+	merged, overwritten := MergeBlurb(base, ours, theirs)
+	if !strings.Contains(merged, ourRules+"### Key Concepts") {
+		t.Errorf("user section lost or moved:\n%s", merged)
+	}
+	if !strings.Contains(merged, "Bash tool") || GetBlurbTarget(merged) != TargetClaude {
+		t.Error("bv's update should apply around the user's section")
+	}
+	if len(overwritten) != 0 {
+		t.Errorf("no conflicts expected, got %v", overwritten)
+	}
+
+	// An edit to a section bv didn't change is kept.
+	ours = strings.Replace(base, "- Use descriptive titles", "- Titles start with a verb", 1)
+	if merged, _ := MergeBlurb(base, ours, theirs); !strings.Contains(merged, "Titles start with a verb") {
+		t.Error("edit to an unchanged section should be kept")
+	}
+
+	// Both sides changed the same section: bv wins and reports it.
+	ours = strings.Replace(base, "bv\n\n# CLI commands", "bv --help\n\n# CLI commands", 1)
+	merged, overwritten = MergeBlurb(base, ours, theirs)
+	if strings.Contains(merged, "bv --help") || len(overwritten) != 1 || overwritten[0] != "### Essential Commands" {
+		t.Errorf("conflict: overwritten=%v", overwritten)
+	}
+}
+
+func TestPlanMerge(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "CLAUDE.md")
+	// Generic blurb in CLAUDE.md, hand-edited: the upgrade keeps the edit.
+	edited := "# Claude\n\n" + addSection(AgentBlurb, "### Key Concepts", ourRules) + "\n"
+	if err := os.WriteFile(path, []byte(edited), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := PlanUpdate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Action != ActionUpdate || !strings.Contains(c.After, ourRules) || GetBlurbTarget(c.After) != TargetClaude {
+		t.Fatalf("upgrade of an edited blurb should merge, got:\n%s", c.After)
+	}
+	if err := c.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Now current but still edited: update leaves it, merge has nothing to refresh.
+	if c, _ := PlanUpdate(path); c.Changed() {
+		t.Error("update should leave an edited current blurb alone")
+	}
+	if c, _ := PlanMerge(path); c.Changed() {
+		t.Errorf("merge with no upstream change should be a no-op:\n%s", c.Diff())
+	}
+
+	// Once the project config changes, merge refreshes bv's sections and
+	// keeps the user's.
+	beadsDir := filepath.Join(filepath.Dir(path), ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte("bv-agents:\n  bd-path: ./tools/bd\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err = PlanMerge(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Action != ActionUpdate || !strings.Contains(c.After, "./tools/bd sync") || !strings.Contains(c.After, ourRules) {
+		t.Errorf("merge should apply the new config and keep the user's section:\n%s", c.After)
+	}
+}
@@ -5,74 +5,87 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"strings"
 )
 
-// AppendBlurbToFile appends the agent blurb to the specified file.
+// AppendBlurbToFile appends the agent blurb to the specified file, using the
+// variant for the file's tool (see TargetForFile).
 // Uses atomic write to prevent corruption.
 func AppendBlurbToFile(filePath string) error {
-	// Read existing content
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("read file: %w", err)
-	}
-
-	// Append blurb using the string function
-	newContent := AppendBlurb(string(content))
-
-	// Write atomically
-	if err := atomicWrite(filePath, []byte(newContent)); err != nil {
-		return fmt.Errorf("write file: %w", err)
-	}
-
-	return nil
+	return applyPlan(filePath, ActionAppend)
 }
 
-// UpdateBlurbInFile replaces an existing blurb with the current version.
+// UpdateBlurbInFile replaces an existing blurb with the current version of
+// the file's variant.
 // Uses atomic write to prevent corruption.
 func UpdateBlurbInFile(filePath string) error {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("read file: %w", err)
-	}
-
-	newContent := UpdateBlurb(string(content))
-
-	if err := atomicWrite(filePath, []byte(newContent)); err != nil {
-		return fmt.Errorf("write file: %w", err)
-	}
-
-	return nil
+	return applyPlan(filePath, ActionUpdate)
 }
 
 // RemoveBlurbFromFile removes the agent blurb from the specified file.
 // Uses atomic write to prevent corruption.
 func RemoveBlurbFromFile(filePath string) error {
-	content, err := os.ReadFile(filePath)
-	if err != nil {
-		return fmt.Errorf("read file: %w", err)
-	}
+	return applyPlan(filePath, ActionRemove)
+}
 
-	newContent := RemoveBlurb(string(content))
+// CreateAgentFile creates a new agent file (usually AGENTS.md) with the blurb
+// variant for its tool, plus any frontmatter the tool needs. Missing parent
+// directories (.github/, .cursor/rules/) are created.
+// The file is created with standard permissions (0644).
+func CreateAgentFile(filePath string) error {
+	return applyPlan(filePath, ActionCreate)
+}
 
-	if err := atomicWrite(filePath, []byte(newContent)); err != nil {
-		return fmt.Errorf("write file: %w", err)
+// applyPlan writes the change DiffPreview shows for filePath and action.
+func applyPlan(filePath string, action Action) error {
+	c, err := planFor(filePath, action)
+	if err != nil {
+		return err
 	}
+	return c.Apply()
+}
 
-	return nil
+// newAgentFileContent is the content CreateAgentFile writes to filePath.
+func newAgentFileContent(filePath string) (string, error) {
+	blurb, err := blurbForFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	content := defaultFrontmatter(filePath)
+	if content != "" {
+		content += "\n"
+	}
+	return content + "# AI Agent Instructions\n\n" + blurb + "\n", nil
 }
 
-// CreateAgentFile creates a new AGENTS.md file with the blurb content.
-// The file is created with standard permissions (0644).
-func CreateAgentFile(filePath string) error {
-	// Create with just the blurb (no existing content)
-	content := "# AI Agent Instructions\n\n" + AgentBlurb + "\n"
+// blurbForFile renders the blurb variant for filePath with the values of
+// the beads project it belongs to.
+func blurbForFile(filePath string) (string, error) {
+	return BlurbForProject(TargetForFile(filePath), projectInfoForFile(filePath))
+}
 
-	// Write atomically
-	if err := atomicWrite(filePath, []byte(content)); err != nil {
-		return fmt.Errorf("create file: %w", err)
+// appendedContent is content with the blurb variant for filePath inserted.
+// An empty rule file that needs frontmatter to be loaded gets it first.
+func appendedContent(filePath, content string) (string, error) {
+	if strings.TrimSpace(content) == "" {
+		content = defaultFrontmatter(filePath)
 	}
+	blurb, err := blurbForFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	return insertBlurbText(content, blurb)
+}
 
-	return nil
+// updatedContent is content with any existing blurb replaced by the current
+// variant for filePath.
+func updatedContent(filePath, content string) (string, error) {
+	blurb, err := blurbForFile(filePath)
+	if err != nil {
+		return "", err
+	}
+	stripped := RemoveBlurb(RemoveLegacyBlurb(content))
+	return insertBlurbText(stripped, blurb)
 }
 
 // VerifyBlurbPresent checks that the blurb was successfully added to a file.
@@ -152,7 +165,7 @@ func atomicWrite(filePath string, content []byte) error {
 
 // EnsureBlurb ensures the blurb is present in an agent file.
 // If the file exists without blurb, appends it.
-// If the file has an old version, updates it.
+// If the file has an old version, or another tool's variant, updates it.
 // If the file doesn't exist, creates it.
 func EnsureBlurb(workDir string) error {
 	detection := DetectAgentFile(workDir)
@@ -163,16 +176,16 @@ func EnsureBlurb(workDir string) error {
 		return CreateAgentFile(filePath)
 	}
 
-	if detection.NeedsBlurb() {
-		// File exists but no blurb - append
-		return AppendBlurbToFile(detection.FilePath)
-	}
+	return EnsureBlurbInFile(detection.FilePath)
+}
 
-	if detection.NeedsUpgrade() {
-		// File has old blurb - update
-		return UpdateBlurbInFile(detection.FilePath)
+// EnsureBlurbInFile is EnsureBlurb for a specific agent file, such as
+// .cursorrules in a project that also has AGENTS.md. The file is created if
+// it doesn't exist.
+func EnsureBlurbInFile(filePath string) error {
+	change, err := PlanEnsure(filePath)
+	if err != nil {
+		return err
 	}
-
-	// Already has current blurb
-	return nil
+	return change.Apply()
 }
@@ -124,6 +124,29 @@ func TestRemoveBlurbFromFile(t *testing.T) {
 	}
 }
 
+func TestRemoveBlurbFromFileLegacy(t *testing.T) {
+	filePath := filepath.Join(t.TempDir(), "AGENTS.md")
+	content := "# My AGENTS.md\n\n" + LegacyBlurbContent + "\n"
+	if err := os.WriteFile(filePath, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RemoveBlurbFromFile(filePath); err != nil {
+		t.Fatal(err)
+	}
+
+	newContent, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ContainsAnyBlurb(string(newContent)) {
+		t.Errorf("legacy blurb should have been removed, got %q", newContent)
+	}
+	if !strings.Contains(string(newContent), "# My AGENTS.md") {
+		t.Error("Header should still be present")
+	}
+}
+
 func TestCreateAgentFile(t *testing.T) {
 	tmpDir := t.TempDir()
 	filePath := filepath.Join(tmpDir, "AGENTS.md")
@@ -0,0 +1,77 @@
+package agents
+
+import (
+	"errors"
+	"path/filepath"
+	"strings"
+)
+
+// ErrUnterminatedFrontmatter is returned when a file opens a frontmatter
+// block that never closes. Appending to it would put the blurb, and its
+// "---" rule, inside the frontmatter.
+var ErrUnterminatedFrontmatter = errors.New("frontmatter block is not closed")
+
+// splitFrontmatter splits a leading "---" delimited frontmatter block (as
+// used by Cursor .mdc rules and Copilot .instructions.md files) from the
+// rest of content. ok is false if the block never closes.
+func splitFrontmatter(content string) (frontmatter, body string, ok bool) {
+	first, rest, found := strings.Cut(content, "\n")
+	if !found || strings.TrimRight(first, "\r") != "---" {
+		return "", content, true
+	}
+	offset := len(first) + 1
+	for rest != "" {
+		line, next, _ := strings.Cut(rest, "\n")
+		end := offset + len(line)
+		if next != "" || strings.HasSuffix(rest, "\n") {
+			end++
+		}
+		if strings.TrimRight(line, "\r") == "---" {
+			return content[:end], content[end:], true
+		}
+		offset = end
+		rest = next
+	}
+	return "", content, false
+}
+
+// lineEnding returns the line ending content uses: "\r\n" if its first line
+// ends that way, otherwise "\n".
+func lineEnding(content string) string {
+	if i := strings.IndexByte(content, '\n'); i > 0 && content[i-1] == '\r' {
+		return "\r\n"
+	}
+	return "\n"
+}
+
+// defaultFrontmatter returns the frontmatter a new agent file at filePath
+// needs for its tool to load it on every request, or "" if none.
+func defaultFrontmatter(filePath string) string {
+	name := strings.ToLower(filepath.Base(filePath))
+	dir := strings.ToLower(filepath.ToSlash(filepath.Dir(filePath)))
+	switch {
+	case strings.HasSuffix(name, ".mdc"):
+		return "---\ndescription: Beads issue tracking workflow (bd and bv)\nglobs:\nalwaysApply: true\n---\n"
+	case strings.HasSuffix(name, ".instructions.md"):
+		return "---\napplyTo: \"**\"\n---\n"
+	case strings.HasSuffix(dir, ".windsurf/rules"):
+		return "---\ntrigger: always_on\n---\n"
+	}
+	return ""
+}
+
+// InsertBlurb adds the blurb variant for target to content without
+// disturbing frontmatter: the blurb goes after any leading frontmatter
+// block, and content whose frontmatter never closes is rejected. The blurb
+// takes content's line endings.
+func InsertBlurb(content string, target BlurbTarget) (string, error) {
+	return insertBlurbText(content, BlurbFor(target))
+}
+
+// insertBlurbText is InsertBlurb for an already rendered blurb.
+func insertBlurbText(content, blurb string) (string, error) {
+	if _, _, ok := splitFrontmatter(content); !ok {
+		return "", ErrUnterminatedFrontmatter
+	}
+	return appendBlurbText(content, blurb), nil
+}
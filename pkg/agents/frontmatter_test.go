@@ -0,0 +1,145 @@
+package agents
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSplitFrontmatter(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		frontmatter string
+		ok          bool
+	}{
+		{"none", "# Rules\n", "", true},
+		{"empty", "", "", true},
+		{"closed", "---\nalwaysApply: true\n---\nbody\n", "---\nalwaysApply: true\n---\n", true},
+		{"closed at EOF", "---\na: b\n---", "---\na: b\n---", true},
+		{"crlf", "---\r\na: b\r\n---\r\nbody", "---\r\na: b\r\n---\r\n", true},
+		{"unterminated", "---\nalwaysApply: true\nbody\n", "", false},
+		{"rule not at top", "# Rules\n\n---\n", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fm, body, ok := splitFrontmatter(tt.content)
+			if ok != tt.ok || fm != tt.frontmatter {
+				t.Fatalf("got (%q, ok=%v), want (%q, ok=%v)", fm, ok, tt.frontmatter, tt.ok)
+			}
+			if ok && fm+body != tt.content {
+				t.Errorf("frontmatter + body must reassemble the content")
+			}
+		})
+	}
+}
+
+func TestInsertBlurb(t *testing.T) {
+	content := "---\ndescription: house rules\nalwaysApply: true\n---\n\nUse tabs.\n"
+	got, err := InsertBlurb(content, TargetCursor)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(got, "---\ndescription: house rules\nalwaysApply: true\n---\n\nUse tabs.\n") {
+		t.Errorf("frontmatter and body must stay in front of the blurb:\n%s", got)
+	}
+	if fm, _, _ := splitFrontmatter(got); fm != "---\ndescription: house rules\nalwaysApply: true\n---\n" {
+		t.Errorf("frontmatter changed after insert: %q", fm)
+	}
+
+	if _, err := InsertBlurb("---\nalwaysApply: true\n", TargetCursor); !errors.Is(err, ErrUnterminatedFrontmatter) {
+		t.Errorf("unterminated frontmatter: err = %v", err)
+	}
+
+	crlf, err := InsertBlurb("# Rules\r\nBe nice.\r\n", TargetGeneric)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Count(crlf, "\n") != strings.Count(crlf, "\r\n") {
+		t.Error("blurb should adopt the file's CRLF line endings")
+	}
+	if RemoveBlurb(crlf) != "# Rules\r\nBe nice." {
+		t.Errorf("CRLF blurb should remove cleanly, got %q", RemoveBlurb(crlf))
+	}
+}
+
+func TestCreateAgentFile_NestedAndFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+
+	copilot := filepath.Join(dir, ".github", "copilot-instructions.md")
+	if err := CreateAgentFile(copilot); err != nil {
+		t.Fatalf("nested path: %v", err)
+	}
+	content, _ := os.ReadFile(copilot)
+	if strings.HasPrefix(string(content), "---") || GetBlurbTarget(string(content)) != TargetCopilot {
+		t.Errorf("copilot-instructions.md: want copilot blurb without frontmatter, got:\n%.200s", content)
+	}
+
+	mdc := filepath.Join(dir, ".cursor", "rules", "beads.mdc")
+	if err := CreateAgentFile(mdc); err != nil {
+		t.Fatal(err)
+	}
+	content, _ = os.ReadFile(mdc)
+	fm, _, ok := splitFrontmatter(string(content))
+	if !ok || !strings.Contains(fm, "alwaysApply: true") {
+		t.Errorf(".mdc rule needs alwaysApply frontmatter, got:\n%.200s", content)
+	}
+	if GetBlurbTarget(string(content)) != TargetCursor {
+		t.Error(".mdc rule should carry the cursor variant")
+	}
+}
+
+func TestAppendBlurbToFile_Frontmatter(t *testing.T) {
+	dir := t.TempDir()
+
+	// An empty .instructions.md gets the frontmatter Copilot needs.
+	empty := filepath.Join(dir, "bv.instructions.md")
+	if err := os.WriteFile(empty, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendBlurbToFile(empty); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(empty)
+	if !strings.HasPrefix(string(content), "---\napplyTo: \"**\"\n---\n") {
+		t.Errorf("expected applyTo frontmatter, got:\n%.100s", content)
+	}
+
+	// A broken frontmatter block is left alone.
+	broken := filepath.Join(dir, ".cursorrules")
+	original := "---\nalwaysApply: true\n"
+	if err := os.WriteFile(broken, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendBlurbToFile(broken); !errors.Is(err, ErrUnterminatedFrontmatter) {
+		t.Errorf("err = %v, want ErrUnterminatedFrontmatter", err)
+	}
+	if content, _ := os.ReadFile(broken); string(content) != original {
+		t.Error("file must be unchanged when insertion is refused")
+	}
+}
+
+func TestEnsureBlurbInFile_UpgradeKeepsFrontmatter(t *testing.T) {
+	dir := t.TempDir()
+	rule := filepath.Join(dir, ".windsurf", "rules", "beads.md")
+	if err := os.MkdirAll(filepath.Dir(rule), 0755); err != nil {
+		t.Fatal(err)
+	}
+	// Generic blurb under existing frontmatter, as an older bv would append it.
+	if err := os.WriteFile(rule, []byte(AppendBlurb("---\ntrigger: always_on\n---\n")), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnsureBlurbInFile(rule); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(rule)
+	if !strings.HasPrefix(string(content), "---\ntrigger: always_on\n---\n") {
+		t.Errorf("frontmatter must stay first:\n%.120s", content)
+	}
+	if GetBlurbTarget(string(content)) != TargetWindsurf || strings.Count(string(content), BlurbStartMarker) != 1 {
+		t.Errorf("expected a single windsurf blurb:\n%s", content)
+	}
+}
@@ -0,0 +1,69 @@
+package agents
+
+import (
+	"bytes"
+	"regexp"
+	"sync"
+)
+
+// Marker substrings. Every regex in the registry below can only match
+// content containing one of them, so callers check for the marker with a
+// plain substring scan first: most agent files bv scans have no blurb.
+const (
+	blurbStartPrefix   = "<!-- bv-agent-instructions-v"
+	blurbTargetPrefix  = "<!-- bv-agent-target: "
+	blurbProjectPrefix = "<!-- bv-agent-project: "
+	// legacyBlurbMarker is the LegacyBlurbPatterns entry that only the
+	// legacy blurb contains.
+	legacyBlurbMarker = "bv already computes the hard parts"
+)
+
+// lazyRegexp is a regular expression compiled on first use, so commands
+// that never look at agent files don't pay for compiling the registry.
+type lazyRegexp struct {
+	expr string
+	once sync.Once
+	re   *regexp.Regexp
+}
+
+func lazyRegex(expr string) *lazyRegexp {
+	return &lazyRegexp{expr: expr}
+}
+
+// get returns the compiled expression. Expressions are constants, so a
+// compile error is a bug in bv and panics like regexp.MustCompile.
+func (l *lazyRegexp) get() *regexp.Regexp {
+	l.once.Do(func() { l.re = regexp.MustCompile(l.expr) })
+	return l.re
+}
+
+// The package's regex registry.
+var (
+	// blurbVersionRegex extracts the version number from a blurb marker.
+	blurbVersionRegex = lazyRegex(`<!-- bv-agent-instructions-v(\d+) -->`)
+
+	// blurbTargetRegex extracts the target recorded by a variant's marker.
+	blurbTargetRegex = lazyRegex(`<!-- bv-agent-target: ([a-z]+) -->`)
+
+	// blurbProjectRegex extracts the encoded values from a projectRecord line.
+	blurbProjectRegex = lazyRegex(`<!-- bv-agent-project: ([A-Za-z0-9_-]+) -->`)
+
+	// legacyBlurbStartPattern matches the beginning of the legacy blurb.
+	legacyBlurbStartPattern = lazyRegex(`(?m)^#{2,3}\s*Using bv as an AI sidecar`)
+
+	// legacyBlurbEndPattern matches content near the end of the legacy blurb.
+	// Uses non-capturing group to make the entire triple-backtick sequence optional.
+	legacyBlurbEndPattern = lazyRegex(`(?m)bv already computes the hard parts[^\n]*(?:\n*` + "```" + `)?\n*`)
+
+	// legacyBlurbNextSectionPattern matches the start of a new section after the legacy blurb.
+	// Used as fallback when the end pattern isn't found.
+	legacyBlurbNextSectionPattern = lazyRegex(`(?m)^#{1,2}\s+[^#]`)
+)
+
+// mayContainBlurb reports whether content could hold a current or legacy
+// blurb. False means it certainly doesn't, and none of the blurb checks
+// need to run.
+func mayContainBlurb(content []byte) bool {
+	return bytes.Contains(content, []byte(blurbStartPrefix)) ||
+		bytes.Contains(content, []byte(legacyBlurbMarker))
+}
@@ -0,0 +1,77 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLazyRegexCompilesOnFirstUse(t *testing.T) {
+	re := lazyRegex(`v(\d+)`)
+	if re.re != nil {
+		t.Fatal("compiled before first use")
+	}
+	if m := re.get().FindStringSubmatch("v12"); len(m) != 2 || m[1] != "12" {
+		t.Fatalf("match = %q", m)
+	}
+	if first := re.re; re.get() != first {
+		t.Error("recompiled on second use")
+	}
+}
+
+func TestMayContainBlurb(t *testing.T) {
+	legacy := "### Using bv as an AI sidecar\n--robot-insights --robot-plan\nbv already computes the hard parts\n"
+	tests := []struct {
+		name    string
+		content string
+		want    bool
+	}{
+		{"plain file", "# Project\n\nSome rules for agents.\n", false},
+		{"mentions robot flags", "Run bv --robot-insights or --robot-plan.\n", false},
+		{"current blurb", AppendBlurb("# Project\n"), true},
+		{"claude variant", AppendBlurbFor("", TargetClaude), true},
+		{"legacy blurb", legacy, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mayContainBlurb([]byte(tt.content)); got != tt.want {
+				t.Errorf("mayContainBlurb = %t, want %t", got, tt.want)
+			}
+			// Whenever the fast path says no, the full checks must agree
+			if !tt.want && (ContainsAnyBlurb(tt.content) || GetBlurbVersion(tt.content) != 0 ||
+				GetBlurbTarget(tt.content) != TargetGeneric) {
+				t.Error("fast path rejected content the full checks accept")
+			}
+		})
+	}
+}
+
+func TestCheckAgentFileWithoutMarkers(t *testing.T) {
+	dir := t.TempDir()
+	content := "# Agents\n\n" + strings.Repeat("Follow the style guide.\n", 1000)
+	path := filepath.Join(dir, "AGENTS.md")
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	got := checkAgentFile(path, "AGENTS.md")
+	want := AgentFileDetection{FilePath: path, FileType: "AGENTS.md", BlurbTarget: TargetGeneric, Content: content}
+	if got != want {
+		t.Errorf("checkAgentFile = %+v, want %+v", got, want)
+	}
+	if !got.NeedsBlurb() || got.NeedsUpgrade() {
+		t.Error("file without a blurb should need one and no upgrade")
+	}
+}
+
+func BenchmarkCheckAgentFileWithoutMarkers(b *testing.B) {
+	path := filepath.Join(b.TempDir(), "AGENTS.md")
+	content := "# Agents\n\n" + strings.Repeat("## Section\n\nFollow the style guide. Run the tests.\n\n", 5000)
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		b.Fatal(err)
+	}
+	b.ReportAllocs()
+	for b.Loop() {
+		checkAgentFile(path, "AGENTS.md")
+	}
+}
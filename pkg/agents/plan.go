@@ -0,0 +1,245 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+)
+
+// Action is what a Change does to an agent file.
+type Action string
+
+const (
+	ActionNone   Action = "none"   // file is already as requested
+	ActionCreate Action = "create" // new file with the blurb
+	ActionAppend Action = "append" // blurb added to an existing file
+	ActionUpdate Action = "update" // outdated or wrong-variant blurb replaced
+	ActionRemove Action = "remove" // blurb taken out
+)
+
+// Change is a proposed edit to one agent file. Plans compute it without
+// touching disk, so it can be previewed (Diff) before it is applied.
+type Change struct {
+	Path   string
+	Action Action
+	Before string // current content; "" if the file doesn't exist
+	After  string // content after Apply
+
+	// Overwritten lists blurb sections whose hand edits the update replaces
+	// because bv changed them too (see MergeBlurb).
+	Overwritten []string
+}
+
+// PlanEnsure plans what EnsureBlurbInFile would do to filePath: create it,
+// append the blurb, upgrade an old blurb, or nothing.
+func PlanEnsure(filePath string) (Change, error) {
+	before, exists, err := readAgentFile(filePath)
+	if err != nil {
+		return Change{}, err
+	}
+	c := Change{Path: filePath, Before: before, After: before, Action: ActionNone}
+	if !exists {
+		c.Action = ActionCreate
+		if c.After, err = newAgentFileContent(filePath); err != nil {
+			return Change{}, fmt.Errorf("%s: %w", filePath, err)
+		}
+		return c, nil
+	}
+
+	detection := checkAgentFile(filePath, filepath.Base(filePath))
+	switch {
+	case detection.NeedsBlurb():
+		c.Action = ActionAppend
+		c.After, err = appendedContent(filePath, before)
+	case detection.NeedsUpgrade():
+		c.Action = ActionUpdate
+		c.After, c.Overwritten, err = upgradedContent(filePath, before, detection)
+	}
+	if err != nil {
+		return Change{}, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return c, nil
+}
+
+// PlanUpdate plans replacing an outdated or wrong-variant blurb in filePath.
+// Files without a blurb, or with the current one, are left alone.
+func PlanUpdate(filePath string) (Change, error) {
+	before, exists, err := readAgentFile(filePath)
+	if err != nil {
+		return Change{}, err
+	}
+	c := Change{Path: filePath, Before: before, After: before, Action: ActionNone}
+	if !exists {
+		return c, nil
+	}
+	if detection := checkAgentFile(filePath, filepath.Base(filePath)); detection.NeedsUpgrade() {
+		c.Action = ActionUpdate
+		if c.After, c.Overwritten, err = upgradedContent(filePath, before, detection); err != nil {
+			return Change{}, fmt.Errorf("%s: %w", filePath, err)
+		}
+	}
+	return c, nil
+}
+
+// PlanMerge is PlanUpdate that also refreshes hand-edited blurbs that are
+// otherwise current: bv's sections are brought up to date and the user's
+// additions are kept (see MergeBlurb).
+func PlanMerge(filePath string) (Change, error) {
+	c, err := PlanUpdate(filePath)
+	if err != nil || c.Action != ActionNone || c.Before == "" {
+		return c, err
+	}
+	if detection := checkAgentFile(filePath, filepath.Base(filePath)); detection.BlurbModified {
+		c.Action = ActionUpdate
+		if c.After, c.Overwritten, err = mergedContent(filePath, c.Before); err != nil {
+			return Change{}, fmt.Errorf("%s: %w", filePath, err)
+		}
+	}
+	return c, nil
+}
+
+// upgradedContent replaces an outdated or wrong-variant blurb, merging when
+// the old blurb was edited by hand so the edits survive the upgrade.
+func upgradedContent(filePath, content string, detection AgentFileDetection) (string, []string, error) {
+	if detection.BlurbModified {
+		return mergedContent(filePath, content)
+	}
+	out, err := updatedContent(filePath, content)
+	return out, nil, err
+}
+
+// PlanRemove plans removing the blurb, current or legacy, from filePath.
+func PlanRemove(filePath string) (Change, error) {
+	before, exists, err := readAgentFile(filePath)
+	if err != nil {
+		return Change{}, err
+	}
+	c := Change{Path: filePath, Before: before, After: before, Action: ActionNone}
+	if !exists || !ContainsAnyBlurb(before) {
+		return c, nil
+	}
+	c.Action = ActionRemove
+	c.After = RemoveBlurb(RemoveLegacyBlurb(before))
+	return c, nil
+}
+
+// DiffPreview returns the unified diff that the mutation for action would
+// make to filePath, without writing anything: ActionCreate previews
+// CreateAgentFile, ActionAppend AppendBlurbToFile, ActionUpdate
+// UpdateBlurbInFile, and ActionRemove RemoveBlurbFromFile. Those functions
+// write exactly the previewed content. The diff is empty if the file would
+// not change.
+func DiffPreview(filePath string, action Action) (string, error) {
+	c, err := planFor(filePath, action)
+	if err != nil {
+		return "", err
+	}
+	return c.Diff(), nil
+}
+
+// planFor plans action on filePath unconditionally, unlike PlanEnsure which
+// first checks what the file needs. All actions but create need the file to
+// exist.
+func planFor(filePath string, action Action) (Change, error) {
+	before, exists, err := readAgentFile(filePath)
+	if err != nil {
+		return Change{}, err
+	}
+	c := Change{Path: filePath, Action: action, Before: before}
+	if action == ActionCreate {
+		if c.After, err = newAgentFileContent(filePath); err != nil {
+			return Change{}, fmt.Errorf("%s: %w", filePath, err)
+		}
+		return c, nil
+	}
+	if !exists {
+		return Change{}, fmt.Errorf("read file: %w", &os.PathError{Op: "open", Path: filePath, Err: os.ErrNotExist})
+	}
+	switch action {
+	case ActionAppend:
+		c.After, err = appendedContent(filePath, before)
+	case ActionUpdate:
+		c.After, err = updatedContent(filePath, before)
+	case ActionRemove:
+		c.After = RemoveBlurb(RemoveLegacyBlurb(before))
+	default:
+		c.Action, c.After = ActionNone, before
+	}
+	if err != nil {
+		return Change{}, fmt.Errorf("%s: %w", filePath, err)
+	}
+	return c, nil
+}
+
+// Changed reports whether applying c would modify anything.
+func (c Change) Changed() bool {
+	return c.Action != ActionNone && c.Before != c.After
+}
+
+// Diff returns c as a unified diff (empty if nothing changes). New files
+// are diffed against /dev/null, and relative paths get git's a/ and b/
+// prefixes.
+func (c Change) Diff() string {
+	if !c.Changed() {
+		return ""
+	}
+	from, to := filepath.ToSlash(c.Path), filepath.ToSlash(c.Path)
+	if !filepath.IsAbs(c.Path) {
+		from, to = "a/"+from, "b/"+to
+	}
+	if c.Action == ActionCreate {
+		from = "/dev/null"
+	}
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        diffLines(c.Before),
+		B:        diffLines(c.After),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+	if err != nil {
+		return ""
+	}
+	return diff
+}
+
+// Apply writes c.After to c.Path atomically, creating parent directories for
+// new files. It does nothing for ActionNone.
+func (c Change) Apply() error {
+	if !c.Changed() {
+		return nil
+	}
+	if c.Action == ActionCreate {
+		if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+			return fmt.Errorf("create file: %w", err)
+		}
+	}
+	if err := atomicWrite(c.Path, []byte(c.After)); err != nil {
+		return fmt.Errorf("write file: %w", err)
+	}
+	return nil
+}
+
+// readAgentFile reads filePath, reporting whether it exists.
+func readAgentFile(filePath string) (string, bool, error) {
+	content, err := os.ReadFile(filePath)
+	if os.IsNotExist(err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("read file: %w", err)
+	}
+	return string(content), true, nil
+}
+
+// diffLines splits content into newline-terminated lines for difflib. An
+// unterminated last line is treated as terminated.
+func diffLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	return difflib.SplitLines(strings.TrimSuffix(content, "\n"))
+}
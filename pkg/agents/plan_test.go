@@ -0,0 +1,143 @@
+package agents
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPlanEnsure(t *testing.T) {
+	dir := t.TempDir()
+
+	// Missing file: create, diffed against /dev/null, nothing written yet.
+	created := filepath.Join(dir, "AGENTS.md")
+	c, err := PlanEnsure(created)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Action != ActionCreate || !strings.HasPrefix(c.Diff(), "--- /dev/null\n+++ "+filepath.ToSlash(created)+"\n") {
+		t.Fatalf("want create diff against /dev/null, got %s:\n%s", c.Action, c.Diff())
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Fatal("planning must not write the file")
+	}
+
+	// Existing file: append keeps the old lines as context.
+	existing := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(existing, []byte("# Claude\n\nRules.\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	c, err = PlanEnsure(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	diff := c.Diff()
+	if c.Action != ActionAppend || !strings.Contains(diff, "\n Rules.\n") || !strings.Contains(diff, "\n+"+BlurbStartMarker+"\n") {
+		t.Fatalf("unexpected append diff (%s):\n%s", c.Action, diff)
+	}
+	if err := c.Apply(); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(existing)
+	if string(content) != c.After {
+		t.Error("Apply should write c.After")
+	}
+
+	// Applied: nothing left to do.
+	c, err = PlanEnsure(existing)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Changed() || c.Diff() != "" {
+		t.Errorf("current blurb should need no change, got %s", c.Action)
+	}
+}
+
+func TestPlanUpdateAndRemove(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "CLAUDE.md")
+	if err := os.WriteFile(path, []byte("# Claude\n\n"+AgentBlurb+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err := PlanUpdate(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Action != ActionUpdate || GetBlurbTarget(c.After) != TargetClaude {
+		t.Fatalf("generic blurb in CLAUDE.md should update to the claude variant, got %s", c.Action)
+	}
+
+	c, err = PlanRemove(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Action != ActionRemove || ContainsAnyBlurb(c.After) || !strings.HasPrefix(c.After, "# Claude") {
+		t.Fatalf("remove should keep the rest of the file, got %q", c.After)
+	}
+	if err := c.Apply(); err != nil {
+		t.Fatal(err)
+	}
+
+	for name, plan := range map[string]func(string) (Change, error){"update": PlanUpdate, "remove": PlanRemove} {
+		c, err := plan(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if c.Changed() {
+			t.Errorf("%s: file without a blurb should be left alone", name)
+		}
+		if c, _ := plan(filepath.Join(dir, "missing.md")); c.Changed() {
+			t.Errorf("%s: missing file should be left alone", name)
+		}
+	}
+}
+
+func TestDiffPreviewMatchesWrite(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "AGENTS.md")
+	original := "# Agents\n\nBe terse.\n"
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	diff, err := DiffPreview(path, ActionAppend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "\n+"+BlurbStartMarker+"\n") {
+		t.Fatalf("append preview should add the blurb:\n%s", diff)
+	}
+	if content, _ := os.ReadFile(path); string(content) != original {
+		t.Fatal("DiffPreview must not write")
+	}
+
+	// The written file is exactly the previewed one.
+	c, err := planFor(path, ActionAppend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendBlurbToFile(path); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(path); string(content) != c.After {
+		t.Error("AppendBlurbToFile wrote something other than the preview")
+	}
+
+	diff, err = DiffPreview(path, ActionRemove)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(diff, "\n-"+BlurbStartMarker+"\n") {
+		t.Errorf("remove preview should drop the blurb:\n%s", diff)
+	}
+
+	if _, err := DiffPreview(filepath.Join(dir, "missing.md"), ActionAppend); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("append to a missing file: err = %v", err)
+	}
+	if diff, err := DiffPreview(filepath.Join(dir, "missing.md"), ActionCreate); err != nil || !strings.HasPrefix(diff, "--- /dev/null") {
+		t.Errorf("create preview: %v\n%s", err, diff)
+	}
+}
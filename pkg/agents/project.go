@@ -0,0 +1,126 @@
+package agents
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// ProjectInfo holds the project-specific values rendered into the blurb, so
+// the instructions name the repo and its commands instead of generic ones.
+// The zero value renders the generic blurb.
+type ProjectInfo struct {
+	// Name is the project's display name.
+	Name string `json:"name,omitempty"`
+
+	// IssuePrefix is the prefix of the project's issue IDs ("bv" for bv-42).
+	IssuePrefix string `json:"issue_prefix,omitempty"`
+
+	// BdPath is how agents should invoke bd, e.g. "./tools/bd" (default "bd").
+	BdPath string `json:"bd_path,omitempty"`
+
+	// EntryCommand is what agents run first to find work
+	// (default "<BdPath> ready").
+	EntryCommand string `json:"entry_command,omitempty"`
+}
+
+// projectConfig is the part of .beads/config.yaml bv reads for ProjectInfo:
+// bd's own issue-prefix, plus an optional bv-agents section.
+type projectConfig struct {
+	IssuePrefix string `yaml:"issue-prefix"`
+	Agents      struct {
+		ProjectName  string `yaml:"project-name"`
+		BdPath       string `yaml:"bd-path"`
+		EntryCommand string `yaml:"entry-command"`
+	} `yaml:"bv-agents"`
+}
+
+// maxPrefixScanLines bounds how much of the issues file is read to infer
+// the issue prefix when config.yaml doesn't set one.
+const maxPrefixScanLines = 50
+
+// LoadProjectInfo resolves ProjectInfo for the project whose beads
+// directory is beadsDir. The name defaults to the project directory's name
+// and the issue prefix to the one the existing issues use. A missing or
+// unreadable config leaves the defaults in place.
+func LoadProjectInfo(beadsDir string) ProjectInfo {
+	var cfg projectConfig
+	if data, err := os.ReadFile(filepath.Join(beadsDir, "config.yaml")); err == nil {
+		_ = yaml.Unmarshal(data, &cfg)
+	}
+
+	info := ProjectInfo{
+		Name:         strings.TrimSpace(cfg.Agents.ProjectName),
+		IssuePrefix:  strings.TrimSpace(cfg.IssuePrefix),
+		BdPath:       strings.TrimSpace(cfg.Agents.BdPath),
+		EntryCommand: strings.TrimSpace(cfg.Agents.EntryCommand),
+	}
+	if info.Name == "" {
+		if abs, err := filepath.Abs(filepath.Dir(beadsDir)); err == nil {
+			info.Name = filepath.Base(abs)
+		}
+	}
+	if info.IssuePrefix == "" {
+		info.IssuePrefix = inferIssuePrefix(beadsDir)
+	}
+	return info
+}
+
+// inferIssuePrefix returns the prefix of the first issue ID in the beads
+// file, or "" if there is none.
+func inferIssuePrefix(beadsDir string) string {
+	path, err := loader.FindJSONLPath(beadsDir)
+	if err != nil {
+		return ""
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 0, 64*1024), 10*1024*1024)
+	for i := 0; i < maxPrefixScanLines && scanner.Scan(); i++ {
+		var issue struct {
+			ID string `json:"id"`
+		}
+		if json.Unmarshal(scanner.Bytes(), &issue) != nil {
+			continue
+		}
+		if idx := strings.LastIndex(issue.ID, "-"); idx > 0 {
+			return issue.ID[:idx]
+		}
+	}
+	return ""
+}
+
+// projectInfoForFile resolves ProjectInfo for an agent file by finding the
+// beads directory of the project it belongs to. Rule files can sit a few
+// levels down (.cursor/rules/beads.mdc), so parents are searched too.
+// Files outside a beads project get the zero ProjectInfo.
+func projectInfoForFile(filePath string) ProjectInfo {
+	abs, err := filepath.Abs(filePath)
+	if err != nil {
+		return ProjectInfo{}
+	}
+	dir := filepath.Dir(abs)
+	for i := 0; i < 4; i++ {
+		beadsDir := filepath.Join(dir, ".beads")
+		if info, err := os.Stat(beadsDir); err == nil && info.IsDir() {
+			return LoadProjectInfo(beadsDir)
+		}
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			break
+		}
+		dir = parent
+	}
+	return ProjectInfo{}
+}
@@ -0,0 +1,109 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeBeadsProject(t *testing.T, config, issues string) string {
+	t.Helper()
+	projectDir := filepath.Join(t.TempDir(), "acme-api")
+	beadsDir := filepath.Join(projectDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if config != "" {
+		if err := os.WriteFile(filepath.Join(beadsDir, "config.yaml"), []byte(config), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(issues), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectDir
+}
+
+func TestLoadProjectInfo(t *testing.T) {
+	issues := "not json\n{\"id\":\"acme-api-7\",\"title\":\"x\"}\n"
+
+	// Defaults come from the directory name and the existing issue IDs.
+	dir := writeBeadsProject(t, "# issue-prefix: \"\"\n", issues)
+	info := LoadProjectInfo(filepath.Join(dir, ".beads"))
+	if info != (ProjectInfo{Name: "acme-api", IssuePrefix: "acme-api"}) {
+		t.Errorf("defaults: got %+v", info)
+	}
+
+	dir = writeBeadsProject(t, `issue-prefix: "acme"
+bv-agents:
+  project-name: Acme API
+  bd-path: ./tools/bd
+  entry-command: ./tools/bd ready --json
+`, issues)
+	info = LoadProjectInfo(filepath.Join(dir, ".beads"))
+	want := ProjectInfo{Name: "Acme API", IssuePrefix: "acme", BdPath: "./tools/bd", EntryCommand: "./tools/bd ready --json"}
+	if info != want {
+		t.Errorf("config: got %+v, want %+v", info, want)
+	}
+}
+
+func TestBlurbForProject(t *testing.T) {
+	if plain, err := BlurbForProject(TargetClaude, ProjectInfo{}); err != nil || plain != BlurbFor(TargetClaude) {
+		t.Errorf("zero ProjectInfo must render the plain variant (err %v)", err)
+	}
+
+	b, err := BlurbForProject(TargetGeneric, ProjectInfo{Name: "Acme API", IssuePrefix: "acme", BdPath: "./tools/bd"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"**Acme API** uses [beads_viewer]",
+		"Issue IDs look like `acme-42`.",
+		"./tools/bd ready              # Show issues ready to work",
+		"Run `./tools/bd ready` to find actionable work",
+		"`./tools/bd sync`",
+	} {
+		if !strings.Contains(b, want) {
+			t.Errorf("missing %q", want)
+		}
+	}
+	if strings.Contains(b, "\nbd ") {
+		t.Error("bd commands should use the configured path")
+	}
+	if !ContainsBlurb(b) || GetBlurbTarget(b) != TargetGeneric || RemoveBlurb("# A\n\n"+b) != "# A" {
+		t.Error("templated blurb must keep its markers")
+	}
+
+	entry, err := BlurbForProject(TargetGeneric, ProjectInfo{EntryCommand: "bv --robot-triage"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(entry, "Run `bv --robot-triage` to find actionable work") || !strings.Contains(entry, "bd ready              #") {
+		t.Error("entry command should only replace the session-start step")
+	}
+}
+
+func TestEnsureBlurbInFile_UsesProjectConfig(t *testing.T) {
+	dir := writeBeadsProject(t, "issue-prefix: acme\nbv-agents:\n  bd-path: ./tools/bd\n", "")
+
+	// A nested rule file still finds the project's .beads.
+	rule := filepath.Join(dir, ".cursor", "rules", "beads.mdc")
+	if err := EnsureBlurbInFile(rule); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(rule)
+	if !strings.Contains(string(content), "**acme-api** uses") || !strings.Contains(string(content), "`acme-42`") ||
+		!strings.Contains(string(content), "./tools/bd close <id>") {
+		t.Errorf("rule file should carry the project's values:\n%s", content)
+	}
+
+	// Files outside a beads project keep the plain blurb.
+	plain := filepath.Join(t.TempDir(), "AGENTS.md")
+	if err := EnsureBlurbInFile(plain); err != nil {
+		t.Fatal(err)
+	}
+	if content, _ := os.ReadFile(plain); !strings.Contains(string(content), AgentBlurb) {
+		t.Error("no beads project: expected the generic blurb")
+	}
+}
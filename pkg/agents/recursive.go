@@ -0,0 +1,150 @@
+package agents
+
+import (
+	"bytes"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// nestedAgentFileNames are the agent files looked for below the repo root.
+// Tool rule files (.cursorrules, ...) only apply at the root, so they are
+// not searched for.
+var nestedAgentFileNames = []string{"AGENTS.md", "CLAUDE.md", "agents.md", "claude.md"}
+
+// skippedWalkDirs are directories the non-git fallback never descends into.
+var skippedWalkDirs = map[string]bool{"node_modules": true, "vendor": true}
+
+// FindNestedAgentFiles returns every AGENTS.md and CLAUDE.md (either case)
+// under root, sorted by path. In a git repository, files ignored by
+// .gitignore are skipped; outside one, the tree is walked in parallel (see
+// walkAgentFiles), skipping what its .gitignore files ignore as well as
+// hidden directories, node_modules, and vendor.
+func FindNestedAgentFiles(root string) ([]string, error) {
+	paths, ok := gitAgentFiles(root)
+	if !ok {
+		var err error
+		if paths, err = walkAgentFiles(root); err != nil {
+			return nil, err
+		}
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// gitAgentFiles lists agent files that git tracks or would track (untracked
+// but not ignored). ok is false if root isn't in a git work tree.
+func gitAgentFiles(root string) (paths []string, ok bool) {
+	args := []string{"-C", root, "ls-files", "-z", "--cached", "--others", "--exclude-standard", "--"}
+	for _, name := range nestedAgentFileNames {
+		args = append(args, "*"+name)
+	}
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return nil, false
+	}
+	seen := make(map[string]bool)
+	for _, rel := range bytes.Split(out, []byte{0}) {
+		if len(rel) == 0 || seen[string(rel)] || !isNestedAgentFileName(filepath.Base(string(rel))) {
+			continue
+		}
+		seen[string(rel)] = true
+		path := filepath.Join(root, filepath.FromSlash(string(rel)))
+		// Tracked files deleted from the work tree are still listed
+		if info, err := os.Stat(path); err == nil && !info.IsDir() {
+			paths = append(paths, path)
+		}
+	}
+	return paths, true
+}
+
+func isNestedAgentFileName(name string) bool {
+	for _, n := range nestedAgentFileNames {
+		if name == n {
+			return true
+		}
+	}
+	return false
+}
+
+// PlanEnsureRecursive plans EnsureBlurbInFile for the root's agent file and
+// for one agent file in each directory below root that has one, preferring
+// AGENTS.md over CLAUDE.md as DetectAgentFile does. The root's file is
+// created if missing; nested directories without an agent file are left
+// alone. Changes that turn out to be ActionNone are included so callers can
+// report what was already current.
+func PlanEnsureRecursive(root string) ([]Change, error) {
+	found, err := FindNestedAgentFiles(root)
+	if err != nil {
+		return nil, err
+	}
+
+	// Pick the preferred file per directory
+	rank := make(map[string]int)
+	for i, name := range agentFilesByPreference() {
+		rank[name] = i
+	}
+	primary := make(map[string]string)
+	var dirs []string
+	for _, path := range found {
+		dir := filepath.Dir(path)
+		cur, ok := primary[dir]
+		if !ok {
+			dirs = append(dirs, dir)
+		}
+		if !ok || rank[filepath.Base(path)] < rank[filepath.Base(cur)] {
+			primary[dir] = path
+		}
+	}
+
+	// The root follows EnsureBlurb: its detected agent file (which may be a
+	// tool rule file), or a new AGENTS.md.
+	rootFile := GetPreferredAgentFilePath(root)
+	if d := DetectAgentFile(root); d.Found() {
+		rootFile = d.FilePath
+	}
+	paths := []string{rootFile}
+	for _, dir := range dirs {
+		if !sameDir(dir, root) {
+			paths = append(paths, primary[dir])
+		}
+	}
+
+	changes := make([]Change, 0, len(paths))
+	for _, path := range paths {
+		c, err := PlanEnsure(path)
+		if err != nil {
+			return nil, err
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
+
+// EnsureBlurbRecursive is EnsureBlurb for a monorepo: it installs or updates
+// the blurb in the root agent file and in every nested AGENTS.md/CLAUDE.md
+// (see PlanEnsureRecursive). It returns every planned change, applied or
+// already current, for a summary report. On a write error, the changes
+// applied so far are returned with the error.
+func EnsureBlurbRecursive(root string) ([]Change, error) {
+	changes, err := PlanEnsureRecursive(root)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range changes {
+		if err := c.Apply(); err != nil {
+			return changes[:i], err
+		}
+	}
+	return changes, nil
+}
+
+func sameDir(a, b string) bool {
+	if filepath.Clean(a) == filepath.Clean(b) {
+		return true
+	}
+	ai, errA := os.Stat(a)
+	bi, errB := os.Stat(b)
+	return errA == nil && errB == nil && os.SameFile(ai, bi)
+}
@@ -0,0 +1,258 @@
+package agents
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func writeFiles(t *testing.T, root string, files map[string]string) {
+	t.Helper()
+	for rel, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func relPaths(t *testing.T, root string, paths []string) []string {
+	t.Helper()
+	var rels []string
+	for _, p := range paths {
+		rel, err := filepath.Rel(root, p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rels = append(rels, filepath.ToSlash(rel))
+	}
+	return rels
+}
+
+func TestFindNestedAgentFiles_Walk(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"AGENTS.md":                      "# root\n",
+		"services/api/CLAUDE.md":         "# api\n",
+		"services/web/AGENTS.md":         "# web\n",
+		"node_modules/pkg/AGENTS.md":     "# dep\n",
+		".hidden/AGENTS.md":              "# hidden\n",
+		"services/web/NOT-AGENTS.md.bak": "",
+	})
+	got, err := FindNestedAgentFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "AGENTS.md services/api/CLAUDE.md services/web/AGENTS.md"
+	if strings.Join(relPaths(t, root, got), " ") != want {
+		t.Errorf("got %v, want %s", relPaths(t, root, got), want)
+	}
+}
+
+func TestFindNestedAgentFiles_Gitignore(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	root := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", root).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	writeFiles(t, root, map[string]string{
+		".gitignore":          "build/\n",
+		"pkg/a/AGENTS.md":     "# a\n",
+		"build/out/AGENTS.md": "# generated\n",
+	})
+	got, err := FindNestedAgentFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rels := relPaths(t, root, got); len(rels) != 1 || rels[0] != "pkg/a/AGENTS.md" {
+		t.Errorf("ignored files must be skipped, got %v", rels)
+	}
+}
+
+func TestEnsureBlurbRecursive(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"services/api/AGENTS.md": "# api\n",
+		"services/api/CLAUDE.md": "@AGENTS.md\n",
+		"services/web/CLAUDE.md": "# web\n\n" + AgentBlurb + "\n",
+		"libs/util/README.md":    "# not an agent file\n",
+	})
+
+	changes, err := EnsureBlurbRecursive(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := make(map[string]Action)
+	for _, c := range changes {
+		rel, _ := filepath.Rel(root, c.Path)
+		got[filepath.ToSlash(rel)] = c.Action
+	}
+	want := map[string]Action{
+		"AGENTS.md":              ActionCreate, // root gets one, as with EnsureBlurb
+		"services/api/AGENTS.md": ActionAppend, // preferred over CLAUDE.md in the same dir
+		"services/web/CLAUDE.md": ActionUpdate, // generic blurb in CLAUDE.md
+	}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for path, action := range want {
+		if got[path] != action {
+			t.Errorf("%s: %s, want %s", path, got[path], action)
+		}
+	}
+	if content, _ := os.ReadFile(filepath.Join(root, "services", "api", "CLAUDE.md")); string(content) != "@AGENTS.md\n" {
+		t.Error("only one agent file per directory should be touched")
+	}
+
+	// Second run: everything is current.
+	changes, err = EnsureBlurbRecursive(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range changes {
+		if c.Changed() {
+			t.Errorf("%s should be current, got %s", c.Path, c.Action)
+		}
+	}
+}
+
+func TestWalkAgentFiles_GitignoreOutsideGit(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		".gitignore":                  "build/\n*.gen/\n/tmp\n",
+		"AGENTS.md":                   "# root\n",
+		"build/AGENTS.md":             "# generated\n",
+		"pkg/build/AGENTS.md":         "# also generated\n",
+		"pkg/x.gen/AGENTS.md":         "# generated\n",
+		"tmp/AGENTS.md":               "# anchored\n",
+		"pkg/tmp/AGENTS.md":           "# not anchored here\n",
+		"pkg/.gitignore":              "CLAUDE.md\n",
+		"pkg/CLAUDE.md":               "# ignored by pkg/.gitignore\n",
+		"pkg/keep/.gitignore":         "!CLAUDE.md\n",
+		"pkg/keep/CLAUDE.md":          "# re-included\n",
+		"services/api/AGENTS.md":      "# api\n",
+		"services/api/docs/CLAUDE.md": "# docs\n",
+	})
+	got, err := walkAgentFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "AGENTS.md pkg/keep/CLAUDE.md pkg/tmp/AGENTS.md services/api/AGENTS.md services/api/docs/CLAUDE.md"
+	if rels := strings.Join(relPaths(t, root, got), " "); rels != want {
+		t.Errorf("got %s\nwant %s", rels, want)
+	}
+}
+
+func TestWalkAgentFiles_Symlinks(t *testing.T) {
+	root := t.TempDir()
+	writeFiles(t, root, map[string]string{
+		"a/AGENTS.md":   "# a\n",
+		"a/b/AGENTS.md": "# b\n",
+	})
+	outside := t.TempDir()
+	writeFiles(t, outside, map[string]string{"lib/AGENTS.md": "# lib\n"})
+	links := map[string]string{
+		"a/b/loop":    filepath.Join(root, "a"), // back up the tree
+		"a/self":      ".",
+		"alias":       filepath.Join(root, "a", "b"), // a second path to a/b
+		"vendored":    filepath.Join(outside, "lib"),
+		"a/CLAUDE.md": "AGENTS.md", // symlinked file: not reported
+	}
+	for link, target := range links {
+		if err := os.Symlink(target, filepath.Join(root, filepath.FromSlash(link))); err != nil {
+			t.Skipf("symlinks not supported: %v", err)
+		}
+	}
+
+	done := make(chan struct{})
+	var got []string
+	var err error
+	go func() {
+		defer close(done)
+		got, err = walkAgentFiles(root)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("walk did not terminate: symlink loop")
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "a/AGENTS.md a/b/AGENTS.md vendored/AGENTS.md"
+	if rels := strings.Join(relPaths(t, root, got), " "); rels != want {
+		t.Errorf("got %s\nwant %s", rels, want)
+	}
+}
+
+func TestWalkAgentFiles_ManyDirectories(t *testing.T) {
+	root := t.TempDir()
+	files := make(map[string]string)
+	var want []string
+	for i := range 200 {
+		rel := fmt.Sprintf("pkg%03d/sub/AGENTS.md", i)
+		files[rel] = "# pkg\n"
+		want = append(want, rel)
+	}
+	writeFiles(t, root, files)
+	got, err := walkAgentFiles(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rels := relPaths(t, root, got); strings.Join(rels, " ") != strings.Join(want, " ") {
+		t.Errorf("got %d paths, want %d in order", len(rels), len(want))
+	}
+	if _, err := walkAgentFiles(filepath.Join(root, "missing")); err == nil {
+		t.Error("missing root should be an error")
+	}
+}
+
+func TestParseIgnoreRule(t *testing.T) {
+	tests := []struct {
+		pattern string
+		path    string
+		isDir   bool
+		want    bool
+	}{
+		{"build", "build", true, true},
+		{"build", "a/b/build", false, true},
+		{"build/", "a/build", false, false},
+		{"/build", "a/build", true, false},
+		{"a/*.md", "a/x.md", false, true},
+		{"a/*.md", "a/b/x.md", false, false},
+		{"**/gen", "x/y/gen", true, true},
+		{"a/**/z", "a/z", true, true},
+		{"a/**/z", "a/b/c/z", true, true},
+		{"a/**", "a/b/c", false, true},
+		{"file?.txt", "file1.txt", false, true},
+		{"[!a]x", "bx", false, true},
+		{"[!a]x", "ax", false, false},
+		{`\#hash`, "#hash", false, true},
+		{"out", "output", true, false},
+	}
+	for _, tt := range tests {
+		rule, ok := parseIgnoreRule(tt.pattern)
+		if !ok {
+			t.Errorf("%q did not parse", tt.pattern)
+			continue
+		}
+		got := ignored([]ignoreFile{{rules: []ignoreRule{rule}}}, tt.path, tt.isDir)
+		if got != tt.want {
+			t.Errorf("%q on %q (dir=%t) = %t, want %t", tt.pattern, tt.path, tt.isDir, got, tt.want)
+		}
+	}
+	for _, line := range []string{"", "   ", "# comment", "/"} {
+		if _, ok := parseIgnoreRule(line); ok {
+			t.Errorf("%q should not be a rule", line)
+		}
+	}
+}
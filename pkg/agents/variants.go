@@ -0,0 +1,228 @@
+package agents
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// BlurbTarget identifies the coding tool an agent file is written for. Each
+// target gets a variant of the blurb with that tool's way of running
+// commands; all variants share the same markers, so detection, update, and
+// removal work on any of them.
+type BlurbTarget string
+
+const (
+	TargetGeneric  BlurbTarget = "generic"  // AGENTS.md and unknown files
+	TargetClaude   BlurbTarget = "claude"   // CLAUDE.md (Claude Code)
+	TargetCursor   BlurbTarget = "cursor"   // .cursorrules, .cursor/rules/*.mdc
+	TargetWindsurf BlurbTarget = "windsurf" // .windsurfrules, .windsurf/rules/*.md
+	TargetCopilot  BlurbTarget = "copilot"  // .github/copilot-instructions.md
+)
+
+// blurbVariant is the template data for one BlurbTarget.
+type blurbVariant struct {
+	// Marker records the target inside the blurb so a file holding another
+	// target's variant can be detected and upgraded. Empty for generic,
+	// which keeps AgentBlurb identical to what older bv versions wrote.
+	Marker string
+
+	// RunHint tells the agent how its tool runs shell commands.
+	RunHint string
+
+	// TUIHint is the comment on the bare `bv` line.
+	TUIHint string
+
+	// Compact drops the longer sections for tools that cap rule file size.
+	Compact bool
+}
+
+const defaultTUIHint = "View issues (launches TUI - avoid in automated sessions)"
+
+var blurbVariants = map[BlurbTarget]blurbVariant{
+	TargetGeneric: {},
+	TargetClaude: {
+		RunHint: "**Claude Code:** run these with the Bash tool. Bare `bv` opens an interactive TUI that blocks the tool call; " +
+			"use `bv --robot-triage` when you need bv's analysis as JSON. Users can run a command themselves by prefixing it with `!` at the prompt (e.g. `!bd ready`).",
+		TUIHint: "Interactive TUI - never run from the Bash tool",
+	},
+	TargetCursor: {
+		RunHint: "**Cursor:** in Agent mode, run these with the terminal tool. Bare `bv` opens an interactive TUI that never returns; " +
+			"use `bv --robot-triage` when you need bv's analysis as JSON.",
+	},
+	TargetWindsurf: {
+		RunHint: "**Windsurf:** Cascade runs these in the terminal. Bare `bv` opens an interactive TUI that never returns; " +
+			"use `bv --robot-triage` when you need bv's analysis as JSON.",
+		// Windsurf truncates rule files at 6,000 characters
+		Compact: true,
+	},
+	TargetCopilot: {
+		RunHint: "**GitHub Copilot:** in agent mode, run these in the integrated terminal; in chat, suggest the command for the user to run. " +
+			"Bare `bv` opens an interactive TUI; use `bv --robot-triage` when you need bv's analysis as JSON.",
+	},
+}
+
+var parsedBlurbTemplate = template.Must(template.New("blurb").Parse(blurbTemplate))
+
+// BlurbFor renders the blurb variant for target. Unknown targets get the
+// generic blurb.
+func BlurbFor(target BlurbTarget) string {
+	if b, ok := plainBlurbs[target]; ok {
+		return b
+	}
+	return plainBlurbs[TargetGeneric]
+}
+
+// plainBlurbs holds every variant rendered without project values. They
+// only depend on the built-in template, so a failure is a bug in bv, caught
+// at startup like a template parse error.
+var plainBlurbs = func() map[BlurbTarget]string {
+	m := make(map[BlurbTarget]string, len(blurbVariants))
+	for target := range blurbVariants {
+		b, err := BlurbForProject(target, ProjectInfo{})
+		if err != nil {
+			panic("agents: rendering blurb: " + err.Error())
+		}
+		m[target] = b
+	}
+	return m
+}()
+
+// blurbData is what blurbTemplate is executed with.
+type blurbData struct {
+	blurbVariant
+	Project ProjectInfo
+	Record  string // records Project in the blurb; see projectRecord
+	Bd      string // command that runs bd
+	Entry   string // command agents start a session with
+}
+
+// BlurbForProject renders the blurb variant for target with project's
+// values filled in. Empty fields fall back to the generic wording, so
+// BlurbForProject(target, ProjectInfo{}) is BlurbFor(target).
+func BlurbForProject(target BlurbTarget, project ProjectInfo) (string, error) {
+	v, ok := blurbVariants[target]
+	if !ok {
+		target, v = TargetGeneric, blurbVariants[TargetGeneric]
+	}
+	if target != TargetGeneric {
+		v.Marker = blurbTargetPrefix + string(target) + " -->"
+	}
+	if v.TUIHint == "" {
+		v.TUIHint = defaultTUIHint
+	}
+	data := blurbData{blurbVariant: v, Project: project, Bd: project.BdPath, Entry: project.EntryCommand}
+	if data.Bd == "" {
+		data.Bd = "bd"
+	}
+	if data.Entry == "" {
+		data.Entry = data.Bd + " ready"
+	}
+	if project != (ProjectInfo{}) {
+		record, err := projectRecord(project)
+		if err != nil {
+			return "", fmt.Errorf("rendering blurb: %w", err)
+		}
+		data.Record = record
+	}
+	var b strings.Builder
+	if err := parsedBlurbTemplate.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("rendering blurb: %w", err)
+	}
+	return b.String(), nil
+}
+
+// projectRecord is the marker line that records the project values a blurb
+// was rendered with, so drift detection can re-render exactly what bv wrote
+// even after the project config changes. The values are base64-encoded
+// JSON: raw values could contain "--", which would end the HTML comment.
+func projectRecord(project ProjectInfo) (string, error) {
+	data, err := json.Marshal(project)
+	if err != nil {
+		return "", err
+	}
+	return blurbProjectPrefix + base64.RawURLEncoding.EncodeToString(data) + " -->", nil
+}
+
+// recordedProject returns the project values recorded in blurb, and false
+// if it has no (readable) record: plain blurbs and those written before
+// bv recorded them.
+func recordedProject(blurb string) (ProjectInfo, bool) {
+	if !strings.Contains(blurb, blurbProjectPrefix) {
+		return ProjectInfo{}, false
+	}
+	m := blurbProjectRegex.get().FindStringSubmatch(blurb)
+	if m == nil {
+		return ProjectInfo{}, false
+	}
+	data, err := base64.RawURLEncoding.DecodeString(m[1])
+	if err != nil {
+		return ProjectInfo{}, false
+	}
+	var project ProjectInfo
+	if err := json.Unmarshal(data, &project); err != nil {
+		return ProjectInfo{}, false
+	}
+	return project, true
+}
+
+// TargetForFile picks the blurb variant for an agent file from its name and
+// location. Anything unrecognized, including AGENTS.md, is generic.
+func TargetForFile(filePath string) BlurbTarget {
+	name := strings.ToLower(filepath.Base(filePath))
+	dir := strings.ToLower(filepath.ToSlash(filepath.Dir(filePath)))
+	switch {
+	case name == "claude.md":
+		return TargetClaude
+	case name == ".cursorrules", strings.HasSuffix(name, ".mdc"):
+		return TargetCursor
+	case name == ".windsurfrules", strings.HasSuffix(dir, ".windsurf/rules"):
+		return TargetWindsurf
+	case name == "copilot-instructions.md", strings.HasSuffix(name, ".instructions.md"):
+		return TargetCopilot
+	}
+	return TargetGeneric
+}
+
+// GetBlurbTarget reports which variant the blurb in content is. Blurbs
+// without a target marker, including those written before variants existed,
+// are generic.
+func GetBlurbTarget(content string) BlurbTarget {
+	if !strings.Contains(content, blurbTargetPrefix) {
+		return TargetGeneric
+	}
+	if m := blurbTargetRegex.get().FindStringSubmatch(content); m != nil {
+		return BlurbTarget(m[1])
+	}
+	return TargetGeneric
+}
+
+// AppendBlurbFor appends the blurb variant for target to content, matching
+// content's line endings. Files that may carry frontmatter should go through
+// InsertBlurb instead.
+func AppendBlurbFor(content string, target BlurbTarget) string {
+	return appendBlurbText(content, BlurbFor(target))
+}
+
+// appendBlurbText appends a rendered blurb to content, matching content's
+// line endings.
+func appendBlurbText(content, blurb string) string {
+	nl := lineEnding(content)
+	if !strings.HasSuffix(content, "\n") {
+		content += nl
+	}
+	content += nl
+	content += strings.ReplaceAll(blurb, "\n", nl)
+	content += nl
+	return content
+}
+
+// UpdateBlurbFor replaces an existing blurb with the current variant for target.
+func UpdateBlurbFor(content string, target BlurbTarget) string {
+	content = RemoveLegacyBlurb(content)
+	content = RemoveBlurb(content)
+	return AppendBlurbFor(content, target)
+}
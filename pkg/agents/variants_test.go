@@ -0,0 +1,108 @@
+package agents
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlurbForVariants(t *testing.T) {
+	if AgentBlurb != BlurbFor(TargetGeneric) {
+		t.Error("AgentBlurb should be the generic variant")
+	}
+	if strings.Contains(AgentBlurb, "bv-agent-target") {
+		t.Error("generic blurb must not carry a target marker (keeps older files current)")
+	}
+	if BlurbFor("unknown-tool") != AgentBlurb {
+		t.Error("unknown targets should fall back to the generic blurb")
+	}
+
+	hints := map[BlurbTarget]string{
+		TargetClaude:   "Bash tool",
+		TargetCursor:   "Agent mode",
+		TargetWindsurf: "Cascade",
+		TargetCopilot:  "integrated terminal",
+	}
+	for target, hint := range hints {
+		b := BlurbFor(target)
+		if !strings.HasPrefix(b, BlurbStartMarker) || !strings.HasSuffix(b, BlurbEndMarker) {
+			t.Errorf("%s: variant must keep the shared markers", target)
+		}
+		if GetBlurbTarget(b) != target {
+			t.Errorf("%s: GetBlurbTarget = %s", target, GetBlurbTarget(b))
+		}
+		if !strings.Contains(b, hint) {
+			t.Errorf("%s: missing tool-specific hint %q", target, hint)
+		}
+		if !strings.Contains(b, "bd ready") || !strings.Contains(b, "bd sync") {
+			t.Errorf("%s: missing essential commands", target)
+		}
+		if RemoveBlurb("# Rules\n\n"+b+"\n") != "# Rules" {
+			t.Errorf("%s: RemoveBlurb left content behind", target)
+		}
+	}
+
+	windsurf := BlurbFor(TargetWindsurf)
+	if len(windsurf) > 6000/2 || strings.Contains(windsurf, "### Session Protocol") {
+		t.Errorf("windsurf variant should be compact, got %d bytes", len(windsurf))
+	}
+}
+
+func TestTargetForFile(t *testing.T) {
+	tests := map[string]BlurbTarget{
+		"AGENTS.md":                               TargetGeneric,
+		"/repo/agents.md":                         TargetGeneric,
+		"/repo/CLAUDE.md":                         TargetClaude,
+		"claude.md":                               TargetClaude,
+		"/repo/.cursorrules":                      TargetCursor,
+		"/repo/.cursor/rules/beads.mdc":           TargetCursor,
+		"/repo/.windsurfrules":                    TargetWindsurf,
+		"/repo/.windsurf/rules/beads.md":          TargetWindsurf,
+		".github/copilot-instructions.md":         TargetCopilot,
+		".github/instructions/bv.instructions.md": TargetCopilot,
+		"README.md":                               TargetGeneric,
+	}
+	for path, want := range tests {
+		if got := TargetForFile(filepath.FromSlash(path)); got != want {
+			t.Errorf("TargetForFile(%q) = %s, want %s", path, got, want)
+		}
+	}
+}
+
+func TestEnsureBlurb_PicksVariantByFile(t *testing.T) {
+	dir := t.TempDir()
+	claudePath := filepath.Join(dir, "CLAUDE.md")
+	// CLAUDE.md written by an older bv carries the generic blurb.
+	if err := os.WriteFile(claudePath, []byte("# Claude\n\n"+AgentBlurb+"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	d := DetectAgentFile(dir)
+	if !d.HasWrongVariant() || !d.NeedsUpgrade() {
+		t.Fatalf("generic blurb in CLAUDE.md should need an upgrade: %+v", d)
+	}
+	if err := EnsureBlurb(dir); err != nil {
+		t.Fatal(err)
+	}
+	content, _ := os.ReadFile(claudePath)
+	if GetBlurbTarget(string(content)) != TargetClaude || strings.Count(string(content), BlurbStartMarker) != 1 {
+		t.Fatalf("expected exactly one claude blurb, got:\n%s", content)
+	}
+	if !strings.HasPrefix(string(content), "# Claude\n") {
+		t.Error("existing content must be preserved")
+	}
+	if d := DetectAgentFile(dir); d.NeedsUpgrade() {
+		t.Error("claude variant in CLAUDE.md should be current")
+	}
+
+	// A fresh project gets AGENTS.md with the generic blurb.
+	fresh := t.TempDir()
+	if err := EnsureBlurb(fresh); err != nil {
+		t.Fatal(err)
+	}
+	content, _ = os.ReadFile(filepath.Join(fresh, "AGENTS.md"))
+	if !strings.Contains(string(content), AgentBlurb) {
+		t.Error("new AGENTS.md should carry the generic blurb")
+	}
+}
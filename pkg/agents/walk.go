@@ -0,0 +1,290 @@
+package agents
+
+import (
+	"bufio"
+	"bytes"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// walkConcurrency bounds the directories read at once by walkAgentFiles
+// and DetectAgentFileInParents. Directory reads wait on the filesystem more
+// than the CPU, so it is a multiple of GOMAXPROCS.
+var walkConcurrency = 4 * runtime.GOMAXPROCS(0)
+
+// agentWalker finds agent files under a directory tree with up to
+// walkConcurrency directories read in parallel. It is the fallback for
+// trees outside git: it honors the .gitignore files it finds, follows
+// symlinked directories, and skips hidden directories, node_modules, and
+// vendor.
+type agentWalker struct {
+	sem chan struct{}
+	wg  sync.WaitGroup
+
+	mu       sync.Mutex
+	paths    []string
+	followed bool // a symlinked directory was walked
+}
+
+// walkAgentFiles is the fallback for directories outside git. Paths are
+// sorted, and a file reachable through several symlinked directories is
+// reported once, under its first path.
+func walkAgentFiles(root string) ([]string, error) {
+	info, err := os.Stat(root)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		return nil, &os.PathError{Op: "walk", Path: root, Err: os.ErrInvalid}
+	}
+	entries, err := os.ReadDir(root)
+	if err != nil {
+		return nil, err
+	}
+	w := &agentWalker{sem: make(chan struct{}, walkConcurrency)}
+	w.walk(root, "", entries, nil, []os.FileInfo{info})
+	w.wg.Wait()
+
+	sort.Strings(w.paths)
+	if w.followed {
+		w.paths = dedupeFiles(w.paths)
+	}
+	return w.paths, nil
+}
+
+// walk visits the entries of dir, which is rel below the root. ignores are
+// the .gitignore files of dir's ancestors, and ancestors the directories
+// on the way down from the root, dir included, to detect symlink loops.
+func (w *agentWalker) walk(dir, rel string, entries []os.DirEntry, ignores []ignoreFile, ancestors []os.FileInfo) {
+	if rules := readIgnoreFile(filepath.Join(dir, ".gitignore")); rules != nil {
+		// Full slice expression: siblings must not share the appended element
+		ignores = append(ignores[:len(ignores):len(ignores)], ignoreFile{base: rel, rules: rules})
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		childRel := name
+		if rel != "" {
+			childRel = rel + "/" + name
+		}
+		path := filepath.Join(dir, name)
+
+		mode := entry.Type()
+		var info os.FileInfo
+		if mode&os.ModeSymlink != 0 {
+			target, err := os.Stat(path)
+			if err != nil || !target.IsDir() {
+				continue // dangling, or a symlinked file: see below
+			}
+			if isAncestor(target, ancestors) {
+				continue // symlink loop
+			}
+			info, mode = target, os.ModeDir
+		}
+
+		if mode.IsDir() {
+			if strings.HasPrefix(name, ".") || skippedWalkDirs[name] || ignored(ignores, childRel, true) {
+				continue
+			}
+			if info == nil {
+				var err error
+				if info, err = entry.Info(); err != nil {
+					continue
+				}
+			} else {
+				w.mu.Lock()
+				w.followed = true
+				w.mu.Unlock()
+			}
+			w.descend(path, childRel, ignores, append(ancestors[:len(ancestors):len(ancestors)], info))
+			continue
+		}
+
+		// Symlinked agent files usually point at another agent file
+		// (CLAUDE.md -> AGENTS.md), which gets the blurb itself.
+		if mode.IsRegular() && isNestedAgentFileName(name) && !ignored(ignores, childRel, false) {
+			w.mu.Lock()
+			w.paths = append(w.paths, path)
+			w.mu.Unlock()
+		}
+	}
+}
+
+// descend walks a subdirectory, on a new goroutine if a slot is free and
+// inline otherwise, so the walk never waits on itself.
+func (w *agentWalker) descend(dir, rel string, ignores []ignoreFile, ancestors []os.FileInfo) {
+	run := func() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return // unreadable subdirectory
+		}
+		w.walk(dir, rel, entries, ignores, ancestors)
+	}
+	select {
+	case w.sem <- struct{}{}:
+		w.wg.Add(1)
+		go func() {
+			defer w.wg.Done()
+			defer func() { <-w.sem }()
+			run()
+		}()
+	default:
+		run()
+	}
+}
+
+func isAncestor(dir os.FileInfo, ancestors []os.FileInfo) bool {
+	for _, a := range ancestors {
+		if os.SameFile(dir, a) {
+			return true
+		}
+	}
+	return false
+}
+
+// dedupeFiles drops the paths that resolve to the same file as an earlier
+// path.
+func dedupeFiles(paths []string) []string {
+	seen := make(map[string]bool, len(paths))
+	kept := paths[:0]
+	for _, path := range paths {
+		real, err := filepath.EvalSymlinks(path)
+		if err != nil {
+			real = path
+		}
+		if !seen[real] {
+			seen[real] = true
+			kept = append(kept, path)
+		}
+	}
+	return kept
+}
+
+// ignoreFile is a parsed .gitignore, at base below the walk root.
+type ignoreFile struct {
+	base  string
+	rules []ignoreRule
+}
+
+// ignoreRule is one .gitignore pattern.
+type ignoreRule struct {
+	re      *regexp.Regexp // matches paths relative to the .gitignore's directory
+	negate  bool
+	dirOnly bool
+}
+
+// ignored reports whether the .gitignore files in ignores, outermost first,
+// exclude rel. As in git, deeper files take precedence, and within a file
+// the last matching pattern wins.
+func ignored(ignores []ignoreFile, rel string, isDir bool) bool {
+	for i := len(ignores) - 1; i >= 0; i-- {
+		f := ignores[i]
+		sub := rel
+		if f.base != "" {
+			sub = strings.TrimPrefix(rel, f.base+"/")
+		}
+		for j := len(f.rules) - 1; j >= 0; j-- {
+			r := f.rules[j]
+			if r.dirOnly && !isDir {
+				continue
+			}
+			if r.re.MatchString(sub) {
+				return !r.negate
+			}
+		}
+	}
+	return false
+}
+
+// readIgnoreFile parses the .gitignore at path. It returns nil if there is
+// none or it has no patterns.
+func readIgnoreFile(path string) []ignoreRule {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil
+	}
+	var rules []ignoreRule
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		if r, ok := parseIgnoreRule(scanner.Text()); ok {
+			rules = append(rules, r)
+		}
+	}
+	return rules
+}
+
+// parseIgnoreRule compiles one .gitignore line. ok is false for blank
+// lines, comments, and patterns that don't compile.
+func parseIgnoreRule(line string) (rule ignoreRule, ok bool) {
+	line = strings.TrimRight(strings.TrimSuffix(line, "\r"), " \t")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return rule, false
+	}
+	if strings.HasPrefix(line, "!") {
+		rule.negate = true
+		line = line[1:]
+	} else if strings.HasPrefix(line, `\`) {
+		line = line[1:] // \# and \! escape a leading # or !
+	}
+	if strings.HasSuffix(line, "/") {
+		rule.dirOnly = true
+		line = strings.TrimRight(line, "/")
+	}
+	if line == "" {
+		return rule, false
+	}
+
+	// A slash anywhere but the end anchors the pattern to the .gitignore's
+	// directory; otherwise it matches a name at any depth.
+	var expr strings.Builder
+	expr.WriteString("^")
+	if strings.Contains(line, "/") {
+		line = strings.TrimPrefix(line, "/")
+	} else {
+		expr.WriteString("(?:.*/)?")
+	}
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case strings.HasPrefix(line[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case line[i:] == "**":
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(line[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := line[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + strings.ReplaceAll(class, `\`, `\\`) + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(line):
+			i++
+			expr.WriteString(regexp.QuoteMeta(line[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(line[i : i+1]))
+		}
+	}
+	// A matched directory excludes everything in it
+	expr.WriteString("(?:/.*)?$")
+
+	re, err := regexp.Compile(expr.String())
+	if err != nil {
+		return rule, false
+	}
+	rule.re = re
+	return rule, true
+}
@@ -5,6 +5,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -184,6 +185,10 @@ type CycleBreakResult struct {
 	CycleCount  int              `json:"cycle_count"` // Total cycles detected
 	HowToUse    string           `json:"how_to_use"`
 	Advisory    string           `json:"advisory"` // Important warning text
+
+	// FeedbackArcSet is the alternative strategy: remove all of these edges
+	// at once and no cycle is left, including ones not in CycleCount.
+	FeedbackArcSet *FeedbackArcSetResult `json:"feedback_arc_set,omitempty"`
 }
 
 // CycleBreakItem represents one cycle break suggestion.
@@ -196,18 +201,19 @@ type CycleBreakItem struct {
 	Rationale  string `json:"rationale"`  // Why this edge is suggested
 }
 
-// DefaultUsageHints returns agent-friendly guidance for each feature.
+// DefaultUsageHints returns agent-friendly guidance for each feature, in
+// the current locale (see pkg/i18n). The map keys stay the same.
 func DefaultUsageHints() map[string]string {
-	return map[string]string{
-		"topk_set":      "Best k issues to complete for max downstream unlock. Work these in order.",
-		"coverage_set":  "Small vertex cover touching all dependency edges. Use for breadth coverage.",
-		"k_paths":       "K-shortest critical paths. Focus on issues appearing in multiple paths.",
-		"parallel_cut":  "Issues that enable parallel work. Complete to maximize team throughput.",
-		"parallel_gain": "Parallelization improvement from completing each issue.",
-		"cycle_break":   "Structural fix suggestions. Apply BEFORE working on cycle members.",
+	hints := make(map[string]string, len(usageHintFeatures))
+	for _, feature := range usageHintFeatures {
+		hints[feature] = i18n.T("robot.hint." + feature)
 	}
+	return hints
 }
 
+// usageHintFeatures are the keys of DefaultUsageHints.
+var usageHintFeatures = []string{"topk_set", "coverage_set", "k_paths", "parallel_cut", "parallel_gain", "cycle_break"}
+
 // GenerateAdvancedInsights creates the advanced insights structure with current data.
 // Features that aren't yet implemented return status=pending.
 func (a *Analyzer) GenerateAdvancedInsights(config AdvancedInsightsConfig) *AdvancedInsights {
@@ -319,6 +325,38 @@ func (a *Analyzer) generateCycleBreakSuggestions(limit int) *CycleBreakResult {
 		})
 	}
 
+	// Feedback arc set - one set of removals that untangles every cycle
+	arcs := a.feedbackArcSet()
+	fas := &FeedbackArcSetResult{
+		Edges:       make([]CycleBreakItem, 0, len(arcs)),
+		Size:        len(arcs),
+		Approximate: true,
+		HowToUse:    "Remove every edge in the set to make the graph acyclic. Lowest collateral first.",
+	}
+	for _, e := range arcs {
+		key := edgeKey{from: e.from, to: e.to}
+		item := CycleBreakItem{
+			EdgeFrom:   e.from,
+			EdgeTo:     e.to,
+			Impact:     len(edgeFreq[key]),
+			Collateral: a.countDependents(e.to),
+			InCycles:   edgeFreq[key],
+			Rationale:  "Part of a small edge set whose removal makes the graph acyclic.",
+		}
+		fas.TotalCollateral += item.Collateral
+		fas.Edges = append(fas.Edges, item)
+	}
+	sort.Slice(fas.Edges, func(i, j int) bool {
+		ei, ej := fas.Edges[i], fas.Edges[j]
+		if ei.Collateral != ej.Collateral {
+			return ei.Collateral < ej.Collateral
+		}
+		if ei.EdgeFrom != ej.EdgeFrom {
+			return ei.EdgeFrom < ej.EdgeFrom
+		}
+		return ei.EdgeTo < ej.EdgeTo
+	})
+
 	capped := len(ranked) > limit
 	return &CycleBreakResult{
 		Status: FeatureStatus{
@@ -327,10 +365,11 @@ func (a *Analyzer) generateCycleBreakSuggestions(limit int) *CycleBreakResult {
 			Capped:  capped,
 			Limited: len(ranked),
 		},
-		Suggestions: suggestions,
-		CycleCount:  len(cycles),
-		HowToUse:    DefaultUsageHints()["cycle_break"],
-		Advisory:    "Structural fix—apply cycle breaks BEFORE executing dependents.",
+		Suggestions:    suggestions,
+		CycleCount:     len(cycles),
+		HowToUse:       DefaultUsageHints()["cycle_break"],
+		Advisory:       "Structural fix—apply cycle breaks BEFORE executing dependents.",
+		FeedbackArcSet: fas,
 	}
 }
 
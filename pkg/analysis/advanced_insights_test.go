@@ -1131,3 +1131,67 @@ func TestParallelCutDiamondNoGain(t *testing.T) {
 		t.Errorf("expected gain 1, got %d", insights.ParallelCut.Suggestions[0].ParallelGain)
 	}
 }
+
+func TestCycleBreakFeedbackArcSet(t *testing.T) {
+	dep := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	// Two cycles share the edge C -> A: A -> B -> C -> A and A -> D -> C -> A.
+	// A separate two-cycle X <-> Y. X has a dependent, Y has none.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: dep("B", "D")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: dep("C")},
+		{ID: "C", Status: model.StatusOpen, Dependencies: dep("A")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: dep("C")},
+		{ID: "X", Status: model.StatusOpen, Dependencies: dep("Y")},
+		{ID: "Y", Status: model.StatusOpen, Dependencies: dep("X")},
+		{ID: "Z", Status: model.StatusOpen, Dependencies: dep("X")},
+	}
+
+	insights := NewAnalyzer(issues).GenerateAdvancedInsights(DefaultAdvancedInsightsConfig())
+	fas := insights.CycleBreak.FeedbackArcSet
+	if fas == nil {
+		t.Fatal("expected feedback arc set")
+	}
+	if fas.Size != 2 || len(fas.Edges) != 2 {
+		t.Fatalf("expected 2 edges, got %+v", fas.Edges)
+	}
+
+	// Removing the set must leave no cycle.
+	removed := make(map[[2]string]bool)
+	for _, e := range fas.Edges {
+		removed[[2]string{e.EdgeFrom, e.EdgeTo}] = true
+	}
+	var acyclic []model.Issue
+	for _, issue := range issues {
+		kept := issue
+		kept.Dependencies = nil
+		for _, d := range issue.Dependencies {
+			if !removed[[2]string{issue.ID, d.DependsOnID}] {
+				kept.Dependencies = append(kept.Dependencies, d)
+			}
+		}
+		acyclic = append(acyclic, kept)
+	}
+	if cb := NewAnalyzer(acyclic).GenerateAdvancedInsights(DefaultAdvancedInsightsConfig()).CycleBreak; cb.CycleCount != 0 {
+		t.Errorf("cycles left after removing the set: %d", cb.CycleCount)
+	}
+
+	// X has two dependents (Y, Z) and Y one (X), so of the X <-> Y pair the
+	// set should cut X -> Y, the edge with less collateral.
+	if fas.Edges[0].Collateral > fas.Edges[1].Collateral {
+		t.Errorf("edges not ranked by collateral: %+v", fas.Edges)
+	}
+	for _, e := range fas.Edges {
+		if e.EdgeFrom == "Y" && e.EdgeTo == "X" {
+			t.Errorf("expected X -> Y (collateral 1) over Y -> X (collateral 2), got %+v", fas.Edges)
+		}
+	}
+	if fas.TotalCollateral != fas.Edges[0].Collateral+fas.Edges[1].Collateral {
+		t.Errorf("total collateral = %d", fas.TotalCollateral)
+	}
+}
@@ -0,0 +1,201 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// BlockedIssueTime is how long one issue spent blocked.
+type BlockedIssueTime struct {
+	ID               string   `json:"id"`
+	Title            string   `json:"title"`
+	Status           string   `json:"status"`
+	BlockedDays      float64  `json:"blocked_days"`
+	CurrentlyBlocked bool     `json:"currently_blocked"`
+	Labels           []string `json:"labels,omitempty"`
+	Epic             string   `json:"epic,omitempty"` // Nearest parent-child ancestor that is an epic
+}
+
+// BlockedTimeGroup totals blocked time for a label or an epic.
+type BlockedTimeGroup struct {
+	Key         string  `json:"key"` // Label name or epic ID
+	Title       string  `json:"title,omitempty"`
+	BlockedDays float64 `json:"blocked_days"`
+	Issues      int     `json:"issues"`
+}
+
+// BlockedTime is the time issues spent blocked, reconstructed from past
+// revisions of the beads file. Each revision's state is taken to hold until
+// the next one; the current issues cover the time since the newest revision.
+type BlockedTime struct {
+	Since            time.Time          `json:"since"` // Oldest revision; nothing before it is counted
+	Revisions        int                `json:"revisions"`
+	Skipped          int                `json:"skipped"` // Revisions that failed to load
+	TotalBlockedDays float64            `json:"total_blocked_days"`
+	Issues           []BlockedIssueTime `json:"issues"`   // Worst first
+	ByLabel          []BlockedTimeGroup `json:"by_label"` // Worst first
+	ByEpic           []BlockedTimeGroup `json:"by_epic"`  // Worst first
+}
+
+// isBlockedIn reports whether issue is blocked in the snapshot byID: open
+// with status blocked or with an open blocking dependency.
+func isBlockedIn(issue *model.Issue, byID map[string]*model.Issue) bool {
+	if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+		return false
+	}
+	return issue.Status == model.StatusBlocked || hasOpenBlocker(issue, byID)
+}
+
+// ComputeBlockedTime adds up how long each issue was blocked over revisions,
+// loading each with load (typically a git loader's LoadAt), and totals it by
+// label and by epic using the issues' current labels and parents. Revisions
+// that fail to load are skipped and counted, their time going to the revision
+// before them; if all of them fail, the last error is returned.
+func ComputeBlockedTime(revisions []HealthRevision, load func(sha string) ([]model.Issue, error), current []model.Issue, now time.Time) (BlockedTime, error) {
+	revs := append([]HealthRevision(nil), revisions...)
+	sort.SliceStable(revs, func(i, j int) bool { return revs[i].Timestamp.Before(revs[j].Timestamp) })
+
+	result := BlockedTime{Since: now, Issues: []BlockedIssueTime{}, ByLabel: []BlockedTimeGroup{}, ByEpic: []BlockedTimeGroup{}}
+	type snapshot struct {
+		at     time.Time
+		issues []model.Issue
+	}
+	var snapshots []snapshot
+	var lastErr error
+	for _, rev := range revs {
+		issues, err := load(rev.SHA)
+		if err != nil {
+			result.Skipped++
+			lastErr = err
+			continue
+		}
+		snapshots = append(snapshots, snapshot{at: rev.Timestamp, issues: issues})
+	}
+	if len(snapshots) == 0 && lastErr != nil {
+		return result, fmt.Errorf("no revision of the beads file could be loaded: %w", lastErr)
+	}
+	result.Revisions = len(snapshots)
+	if len(snapshots) > 0 {
+		result.Since = snapshots[0].at
+		// The working copy may be ahead of the newest revision
+		snapshots[len(snapshots)-1].issues = current
+	}
+
+	blocked := make(map[string]time.Duration)
+	for i, snap := range snapshots {
+		end := now
+		if i+1 < len(snapshots) {
+			end = snapshots[i+1].at
+		}
+		span := end.Sub(snap.at)
+		if span <= 0 {
+			continue
+		}
+		byID := make(map[string]*model.Issue, len(snap.issues))
+		for j := range snap.issues {
+			byID[snap.issues[j].ID] = &snap.issues[j]
+		}
+		for j := range snap.issues {
+			if isBlockedIn(&snap.issues[j], byID) {
+				blocked[snap.issues[j].ID] += span
+			}
+		}
+	}
+
+	byID := make(map[string]*model.Issue, len(current))
+	for i := range current {
+		byID[current[i].ID] = &current[i]
+	}
+	days := func(d time.Duration) float64 { return math.Round(d.Hours()/24*10) / 10 }
+
+	labels := make(map[string]*BlockedTimeGroup)
+	epics := make(map[string]*BlockedTimeGroup)
+	addTo := func(groups map[string]*BlockedTimeGroup, key, title string, d time.Duration) {
+		g := groups[key]
+		if g == nil {
+			g = &BlockedTimeGroup{Key: key, Title: title}
+			groups[key] = g
+		}
+		g.BlockedDays += d.Hours() / 24
+		g.Issues++
+	}
+
+	var total time.Duration
+	for id, d := range blocked {
+		issue := byID[id]
+		if issue == nil || issue.Status.IsTombstone() {
+			continue
+		}
+		total += d
+		item := BlockedIssueTime{
+			ID:               id,
+			Title:            issue.Title,
+			Status:           string(issue.Status),
+			BlockedDays:      days(d),
+			CurrentlyBlocked: isBlockedIn(issue, byID),
+			Labels:           issue.Labels,
+		}
+		if epic := nearestEpic(issue, byID); epic != nil {
+			item.Epic = epic.ID
+			addTo(epics, epic.ID, epic.Title, d)
+		}
+		for _, l := range issue.Labels {
+			addTo(labels, l, "", d)
+		}
+		result.Issues = append(result.Issues, item)
+	}
+	result.TotalBlockedDays = days(total)
+
+	sort.Slice(result.Issues, func(i, j int) bool {
+		if result.Issues[i].BlockedDays != result.Issues[j].BlockedDays {
+			return result.Issues[i].BlockedDays > result.Issues[j].BlockedDays
+		}
+		return result.Issues[i].ID < result.Issues[j].ID
+	})
+	result.ByLabel = sortedBlockedGroups(labels)
+	result.ByEpic = sortedBlockedGroups(epics)
+	return result, nil
+}
+
+// nearestEpic follows parent-child links up from issue to the first epic.
+// Without an epic among its ancestors, the direct parent stands in for one.
+func nearestEpic(issue *model.Issue, byID map[string]*model.Issue) *model.Issue {
+	parentOf := func(i *model.Issue) *model.Issue {
+		for _, dep := range i.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild && dep.DependsOnID != i.ID {
+				if p := byID[dep.DependsOnID]; p != nil {
+					return p
+				}
+			}
+		}
+		return nil
+	}
+	first := parentOf(issue)
+	seen := map[string]bool{issue.ID: true}
+	for p := first; p != nil && !seen[p.ID]; p = parentOf(p) {
+		if p.IssueType == model.TypeEpic {
+			return p
+		}
+		seen[p.ID] = true
+	}
+	return first
+}
+
+func sortedBlockedGroups(groups map[string]*BlockedTimeGroup) []BlockedTimeGroup {
+	out := make([]BlockedTimeGroup, 0, len(groups))
+	for _, g := range groups {
+		g.BlockedDays = math.Round(g.BlockedDays*10) / 10
+		out = append(out, *g)
+	}
+	sort.Slice(out, func(i, j int) bool {
+		if out[i].BlockedDays != out[j].BlockedDays {
+			return out[i].BlockedDays > out[j].BlockedDays
+		}
+		return out[i].Key < out[j].Key
+	})
+	return out
+}
@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeBlockedTime(t *testing.T) {
+	day := 24 * time.Hour
+	t0 := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	now := t0.Add(10 * day)
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	child := &model.Dependency{DependsOnID: "EPIC", Type: model.DepParentChild}
+
+	// r1 (day 0): B waits on A. r2 (day 4): C set to blocked. r3 (day 6): A
+	// closes, unblocking B. Currently C is still blocked.
+	snaps := map[string][]model.Issue{
+		"r1": {
+			{ID: "A", Status: model.StatusOpen},
+			{ID: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+			{ID: "C", Status: model.StatusOpen},
+		},
+		"r2": {
+			{ID: "A", Status: model.StatusOpen},
+			{ID: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+			{ID: "C", Status: model.StatusBlocked},
+		},
+		"r3": {
+			{ID: "A", Status: model.StatusClosed},
+			{ID: "B", Status: model.StatusOpen, Dependencies: blocks("A")},
+			{ID: "C", Status: model.StatusBlocked},
+		},
+	}
+	current := []model.Issue{
+		{ID: "EPIC", Title: "Launch", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "A", Status: model.StatusClosed},
+		{ID: "B", Status: model.StatusOpen, Labels: []string{"api"}, Dependencies: append(blocks("A"), child)},
+		{ID: "C", Status: model.StatusBlocked, Labels: []string{"api", "ui"}, Dependencies: []*model.Dependency{child}},
+	}
+	revisions := []HealthRevision{
+		{SHA: "r3", Timestamp: t0.Add(6 * day)},
+		{SHA: "bad", Timestamp: t0.Add(5 * day)},
+		{SHA: "r1", Timestamp: t0},
+		{SHA: "r2", Timestamp: t0.Add(4 * day)},
+	}
+	load := func(sha string) ([]model.Issue, error) {
+		if issues, ok := snaps[sha]; ok {
+			return issues, nil
+		}
+		return nil, errors.New("bad revision")
+	}
+
+	got, err := ComputeBlockedTime(revisions, load, current, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Revisions != 3 || got.Skipped != 1 || !got.Since.Equal(t0) {
+		t.Errorf("revisions=%d skipped=%d since=%v", got.Revisions, got.Skipped, got.Since)
+	}
+	// B: days 0-6 (6d). C: days 4-10 (6d).
+	if got.TotalBlockedDays != 12 || len(got.Issues) != 2 {
+		t.Fatalf("total=%v issues=%+v", got.TotalBlockedDays, got.Issues)
+	}
+	b, c := got.Issues[0], got.Issues[1]
+	if b.ID != "B" || b.BlockedDays != 6 || b.CurrentlyBlocked || b.Epic != "EPIC" {
+		t.Errorf("B = %+v", b)
+	}
+	if c.ID != "C" || c.BlockedDays != 6 || !c.CurrentlyBlocked {
+		t.Errorf("C = %+v", c)
+	}
+	if len(got.ByLabel) != 2 || got.ByLabel[0] != (BlockedTimeGroup{Key: "api", BlockedDays: 12, Issues: 2}) || got.ByLabel[1].Key != "ui" {
+		t.Errorf("by label = %+v", got.ByLabel)
+	}
+	if len(got.ByEpic) != 1 || got.ByEpic[0] != (BlockedTimeGroup{Key: "EPIC", Title: "Launch", BlockedDays: 12, Issues: 2}) {
+		t.Errorf("by epic = %+v", got.ByEpic)
+	}
+
+	if _, err := ComputeBlockedTime([]HealthRevision{{SHA: "bad"}}, load, current, now); err == nil {
+		t.Error("expected an error when no revision loads")
+	}
+}
@@ -12,8 +12,10 @@ import (
 	"sort"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
@@ -534,17 +536,25 @@ func ComputeConfigHash(config *AnalysisConfig) string {
 		return "dynamic"
 	}
 	h := sha256.New()
-	// Using %#v is stable enough for configuration struct
-	h.Write([]byte(fmt.Sprintf("%#v", *config)))
+	// Using %#v is stable enough for configuration struct; the rules pointer
+	// and code ages aren't, and don't affect the cached metrics anyway
+	c := *config
+	c.ScoreRules = nil
+	c.CodeLastChanged = nil
+	h.Write([]byte(fmt.Sprintf("%#v", c)))
 	return hex.EncodeToString(h.Sum(nil))[:16]
 }
 
-// CachedAnalyzer wraps an Analyzer with caching support.
+// CachedAnalyzer wraps an Analyzer with caching support. Like Analyzer, it
+// is safe for concurrent use; WasCacheHit then reports on whichever
+// AnalyzeAsync call finished last.
 type CachedAnalyzer struct {
 	*Analyzer
-	cache      *Cache
-	issues     []model.Issue
-	dataHash   string // Hash of the issue data
+	cache    *Cache
+	issues   []model.Issue
+	dataHash string // Hash of the issue data
+
+	mu         sync.Mutex
 	configHash string // Hash of the configuration
 	cacheHit   bool   // Set by AnalyzeAsync to track if it was a cache hit
 }
@@ -568,29 +578,39 @@ func NewCachedAnalyzer(issues []model.Issue, cache *Cache) *CachedAnalyzer {
 
 // SetConfig updates the analyzer configuration and the configuration hash.
 func (ca *CachedAnalyzer) SetConfig(config *AnalysisConfig) {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
 	ca.Analyzer.SetConfig(config)
 	ca.configHash = ComputeConfigHash(config)
 }
 
 // AnalyzeAsync returns cached stats if available, otherwise computes and caches.
 func (ca *CachedAnalyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
-	// Combined key: dataHash|configHash
+	// Combined key: dataHash|configHash. The config and its hash are read
+	// together so a concurrent SetConfig can't pair one with the other.
+	ca.mu.Lock()
 	fullHash := ca.dataHash + "|" + ca.configHash
+	config := ca.Analyzer.Config()
+	ca.mu.Unlock()
 
 	// Check cache first
 	if stats, ok := ca.cache.GetByHash(fullHash); ok {
-		ca.cacheHit = true
+		metrics.GraphCache.Hit()
+		ca.setCacheHit(true)
 		return stats
 	}
 
 	// Cache miss - compute fresh
-	ca.cacheHit = false
-	stats := ca.Analyzer.AnalyzeAsync(ctx)
+	metrics.GraphCache.Miss()
+	ca.setCacheHit(false)
+	stats := ca.Analyzer.AnalyzeAsyncWithConfig(ctx, config)
 
-	// Store in cache when Phase 2 completes
+	// Store in cache when Phase 2 completes (not if it was canceled)
 	go func() {
 		stats.WaitForPhase2()
-		ca.cache.SetByHash(fullHash, stats)
+		if stats.IsPhase2Ready() {
+			ca.cache.SetByHash(fullHash, stats)
+		}
 	}()
 
 	return stats
@@ -602,23 +622,7 @@ func (ca *CachedAnalyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
 func (ca *CachedAnalyzer) Analyze() GraphStats {
 	stats := ca.AnalyzeAsync(context.Background())
 	stats.WaitForPhase2()
-	return GraphStats{
-		OutDegree:         stats.OutDegree,
-		InDegree:          stats.InDegree,
-		TopologicalOrder:  stats.TopologicalOrder,
-		Density:           stats.Density,
-		NodeCount:         stats.NodeCount,
-		EdgeCount:         stats.EdgeCount,
-		Config:            stats.Config,
-		pageRank:          stats.pageRank,
-		betweenness:       stats.betweenness,
-		eigenvector:       stats.eigenvector,
-		hubs:              stats.hubs,
-		authorities:       stats.authorities,
-		criticalPathScore: stats.criticalPathScore,
-		cycles:            stats.cycles,
-		phase2Ready:       true,
-	}
+	return stats.snapshot()
 }
 
 // DataHash returns the computed hash for the analyzer's issue data.
@@ -628,9 +632,17 @@ func (ca *CachedAnalyzer) DataHash() string {
 
 // WasCacheHit returns true if the last AnalyzeAsync call was a cache hit.
 func (ca *CachedAnalyzer) WasCacheHit() bool {
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
 	return ca.cacheHit
 }
 
+func (ca *CachedAnalyzer) setCacheHit(hit bool) {
+	ca.mu.Lock()
+	ca.cacheHit = hit
+	ca.mu.Unlock()
+}
+
 type robotAnalysisDiskCacheFile struct {
 	Version int                                    `json:"version"`
 	Entries map[string]robotAnalysisDiskCacheEntry `json:"entries"`
@@ -713,8 +725,72 @@ func (b graphStatsCacheBlob) toGraphStats() *GraphStats {
 	return stats
 }
 
+// cacheBypass forces every analysis to recompute, skipping the in-memory and
+// on-disk result caches. Determinism verification needs this: a cache hit
+// would trivially reproduce the previous run.
+var cacheBypass atomic.Bool
+
+// SetCacheBypass enables or disables bypassing the analysis result caches.
+func SetCacheBypass(on bool) {
+	cacheBypass.Store(on)
+}
+
+// diskCacheWriteHook, if set, runs after a robot analysis result is written
+// to the on-disk cache, so other bv processes can be told to refresh.
+var diskCacheWriteHook atomic.Pointer[func(dataHash string)]
+
+// SetDiskCacheWriteHook registers fn to run after each on-disk cache write.
+// Pass nil to remove it.
+func SetDiskCacheWriteHook(fn func(dataHash string)) {
+	if fn == nil {
+		diskCacheWriteHook.Store(nil)
+		return
+	}
+	diskCacheWriteHook.Store(&fn)
+}
+
+// diskCacheWriteGuard, if set, runs before a robot analysis result is
+// written to the on-disk cache. It returns a release func to call once the
+// write is done; an error skips the write (the cache is only an optimization).
+var diskCacheWriteGuard atomic.Pointer[func() (func(), error)]
+
+// SetDiskCacheWriteGuard registers fn to wrap each on-disk cache write, e.g.
+// to hold a cross-process write lock. Pass nil to remove it.
+func SetDiskCacheWriteGuard(fn func() (release func(), err error)) {
+	if fn == nil {
+		diskCacheWriteGuard.Store(nil)
+		return
+	}
+	diskCacheWriteGuard.Store(&fn)
+}
+
+// robotStatsEntry is a robot analysis result and the data it describes.
+type robotStatsEntry struct {
+	dataHash string
+	stats    *GraphStats
+}
+
+// lastRobotStats is the latest robot analysis result in this process, read
+// from the disk cache or computed, so later checks in the same run (bv
+// --exit-code) can reuse it instead of analyzing again.
+var lastRobotStats atomic.Pointer[robotStatsEntry]
+
+func rememberRobotStats(dataHash string, stats *GraphStats) {
+	lastRobotStats.Store(&robotStatsEntry{dataHash: dataHash, stats: stats})
+}
+
+// RobotStatsFor returns this process's latest completed robot analysis, if
+// it was of the data with dataHash.
+func RobotStatsFor(dataHash string) (*GraphStats, bool) {
+	e := lastRobotStats.Load()
+	if e == nil || e.dataHash != dataHash || !e.stats.IsPhase2Ready() {
+		return nil, false
+	}
+	return e.stats, true
+}
+
 func robotDiskCacheEnabled() bool {
-	return os.Getenv("BV_ROBOT") == "1"
+	return os.Getenv("BV_ROBOT") == "1" && !cacheBypass.Load()
 }
 
 func robotAnalysisDiskCachePath(create bool) (string, error) {
@@ -847,6 +923,61 @@ func getRobotDiskCachedStats(fullKey string) (*GraphStats, bool) {
 	return entry.Result.toGraphStats(), true
 }
 
+// DiskCacheMaxAge is how long robot analysis results stay in the on-disk
+// cache before they are pruned.
+const DiskCacheMaxAge = robotAnalysisDiskCacheMaxAge
+
+// DiskCacheEntry describes one result in the on-disk robot analysis cache.
+type DiskCacheEntry struct {
+	DataHash   string    `json:"data_hash"`
+	ConfigHash string    `json:"config_hash"`
+	CreatedAt  time.Time `json:"created_at"`
+	AccessedAt time.Time `json:"accessed_at"`
+}
+
+// InspectDiskCache returns the path of the on-disk robot analysis cache and
+// its unexpired entries for dataHash (all entries if dataHash is empty). It
+// does not create the file, prune it, or update access times, so it is safe
+// for status checks. A missing cache file yields no entries and no error.
+func InspectDiskCache(dataHash string) (string, []DiskCacheEntry, error) {
+	path, err := robotAnalysisDiskCachePath(false)
+	if err != nil {
+		return "", nil, err
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return path, nil, nil
+		}
+		return path, nil, err
+	}
+	defer f.Close()
+
+	if err := lockFile(f); err != nil {
+		return path, nil, err
+	}
+	cf := readRobotDiskCacheLocked(f)
+	_ = unlockFile(f)
+
+	pruneRobotDiskCacheEntries(time.Now(), cf.Entries)
+	var entries []DiskCacheEntry
+	for _, e := range cf.Entries {
+		if dataHash != "" && e.DataHash != dataHash {
+			continue
+		}
+		entries = append(entries, DiskCacheEntry{
+			DataHash:   e.DataHash,
+			ConfigHash: e.ConfigHash,
+			CreatedAt:  e.CreatedAt,
+			AccessedAt: e.AccessedAt,
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	return path, entries, nil
+}
+
 func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphStats) {
 	if !robotDiskCacheEnabled() {
 		return
@@ -889,6 +1020,14 @@ func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphS
 		return
 	}
 
+	if guard := diskCacheWriteGuard.Load(); guard != nil {
+		release, err := (*guard)()
+		if err != nil {
+			return
+		}
+		defer release()
+	}
+
 	path, err := robotAnalysisDiskCachePath(true)
 	if err != nil {
 		return
@@ -922,5 +1061,10 @@ func putRobotDiskCachedStats(fullKey, dataHash, configHash string, stats *GraphS
 	}
 
 	evictRobotDiskCacheLRU(cf.Entries)
-	_ = writeRobotDiskCacheLocked(f, cf)
+	if err := writeRobotDiskCacheLocked(f, cf); err != nil {
+		return
+	}
+	if hook := diskCacheWriteHook.Load(); hook != nil {
+		(*hook)(dataHash)
+	}
 }
@@ -3,6 +3,7 @@ package analysis_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -479,6 +480,124 @@ func TestRobotDiskCache_WritesAndHits(t *testing.T) {
 	}
 }
 
+func TestRobotDiskCache_WriteHook(t *testing.T) {
+	t.Setenv("BV_ROBOT", "1")
+	t.Setenv("BV_CACHE_DIR", t.TempDir())
+
+	var hooked []string
+	analysis.SetDiskCacheWriteHook(func(dataHash string) {
+		hooked = append(hooked, dataHash)
+	})
+	defer analysis.SetDiskCacheWriteHook(nil)
+
+	issues := []model.Issue{
+		{ID: "H1", Status: model.StatusOpen},
+		{ID: "H2", Status: model.StatusOpen},
+	}
+	an := analysis.NewAnalyzer(issues)
+	an.AnalyzeAsyncWithConfig(context.Background(), analysis.ConfigForSize(2, 0)).WaitForPhase2()
+
+	if len(hooked) != 1 || hooked[0] != analysis.ComputeDataHash(issues) {
+		t.Fatalf("expected one hook call with the data hash, got %v", hooked)
+	}
+}
+
+func TestRobotDiskCache_WriteGuard(t *testing.T) {
+	t.Setenv("BV_ROBOT", "1")
+	cacheDir := t.TempDir()
+	t.Setenv("BV_CACHE_DIR", cacheDir)
+
+	guarded, released := 0, 0
+	analysis.SetDiskCacheWriteGuard(func() (func(), error) {
+		guarded++
+		return nil, errors.New("lock busy")
+	})
+	defer analysis.SetDiskCacheWriteGuard(nil)
+
+	issues := []model.Issue{{ID: "G1", Status: model.StatusOpen}}
+	an := analysis.NewAnalyzer(issues)
+	an.AnalyzeAsyncWithConfig(context.Background(), analysis.ConfigForSize(1, 0)).WaitForPhase2()
+
+	if guarded != 1 {
+		t.Fatalf("expected one guard call, got %d", guarded)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "analysis_cache.json")); !os.IsNotExist(err) {
+		t.Fatalf("expected no cache write when the guard fails, stat err = %v", err)
+	}
+
+	analysis.SetDiskCacheWriteGuard(func() (func(), error) {
+		return func() { released++ }, nil
+	})
+	an = analysis.NewAnalyzer(issues)
+	an.AnalyzeAsyncWithConfig(context.Background(), analysis.ConfigForSize(1, 0)).WaitForPhase2()
+
+	if released != 1 {
+		t.Fatalf("expected the guard to be released once, got %d", released)
+	}
+	if _, err := os.Stat(filepath.Join(cacheDir, "analysis_cache.json")); err != nil {
+		t.Fatalf("expected a cache write once the guard succeeds: %v", err)
+	}
+}
+
+func TestRobotStatsFor(t *testing.T) {
+	t.Setenv("BV_ROBOT", "1")
+	t.Setenv("BV_CACHE_DIR", t.TempDir())
+
+	issues := []model.Issue{
+		{ID: "R1", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "R2", Type: model.DepBlocks}}},
+		{ID: "R2", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "R1", Type: model.DepBlocks}}},
+	}
+	stats := analysis.NewAnalyzer(issues).AnalyzeAsync(context.Background())
+	stats.WaitForPhase2()
+
+	got, ok := analysis.RobotStatsFor(analysis.ComputeDataHash(issues))
+	if !ok || got != stats {
+		t.Fatalf("expected the completed analysis back, got ok=%v", ok)
+	}
+	if len(got.Cycles()) == 0 {
+		t.Error("expected the reused analysis to report the cycle")
+	}
+	if _, ok := analysis.RobotStatsFor(analysis.ComputeDataHash(issues[:1])); ok {
+		t.Error("analysis of other data must not be returned")
+	}
+}
+
+func TestInspectDiskCache(t *testing.T) {
+	t.Setenv("BV_ROBOT", "1")
+	cacheDir := t.TempDir()
+	t.Setenv("BV_CACHE_DIR", cacheDir)
+
+	issues := []model.Issue{{ID: "S1", Status: model.StatusOpen}}
+	dataHash := analysis.ComputeDataHash(issues)
+
+	path, entries, err := analysis.InspectDiskCache(dataHash)
+	if err != nil || len(entries) != 0 {
+		t.Fatalf("empty cache: entries=%v err=%v", entries, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Error("InspectDiskCache must not create the cache file")
+	}
+
+	an := analysis.NewAnalyzer(issues)
+	config := an.Config()
+	an.AnalyzeAsyncWithConfig(context.Background(), config).WaitForPhase2()
+	before, _ := os.ReadFile(path)
+
+	_, entries, err = analysis.InspectDiskCache(dataHash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 1 || entries[0].ConfigHash != analysis.ComputeConfigHash(&config) {
+		t.Fatalf("expected one entry for the analyzer config, got %+v", entries)
+	}
+	if _, other, _ := analysis.InspectDiskCache("other"); len(other) != 0 {
+		t.Errorf("entries for another data hash: %+v", other)
+	}
+	if after, _ := os.ReadFile(path); string(after) != string(before) {
+		t.Error("InspectDiskCache must not rewrite the cache file")
+	}
+}
+
 func TestRobotDiskCache_EvictsToMaxEntries(t *testing.T) {
 	t.Setenv("BV_ROBOT", "1")
 	cacheDir := t.TempDir()
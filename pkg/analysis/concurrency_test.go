@@ -0,0 +1,191 @@
+package analysis
+
+import (
+	"context"
+	"math"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/datagen"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"pgregory.net/rapid"
+)
+
+// These tests are meant to be run with -race (CI does); without it they
+// still check that concurrent use gives the same answers as sequential use.
+
+func genDataset(t *rapid.T) []model.Issue {
+	issues, err := datagen.Generate(datagen.Config{
+		Nodes: rapid.IntRange(1, 60).Draw(t, "nodes"),
+		Shape: rapid.SampledFrom(datagen.Shapes).Draw(t, "shape"),
+		Seed:  rapid.Int64().Draw(t, "seed"),
+	})
+	if err != nil {
+		t.Fatalf("generate: %v", err)
+	}
+	return issues
+}
+
+func sameMetrics(t interface{ Fatalf(string, ...any) }, want, got *GraphStats) {
+	if len(want.TopologicalOrder) != len(got.TopologicalOrder) {
+		t.Fatalf("topological order: %d vs %d", len(want.TopologicalOrder), len(got.TopologicalOrder))
+	}
+	for i := range want.TopologicalOrder {
+		if want.TopologicalOrder[i] != got.TopologicalOrder[i] {
+			t.Fatalf("topological order differs at %d", i)
+		}
+	}
+	if w, g := len(want.Cycles()), len(got.Cycles()); w != g {
+		t.Fatalf("cycles: %d vs %d", w, g)
+	}
+	want.PageRankAll(func(id string, score float64) bool {
+		if v, _ := got.PageRankValue(id); math.Abs(v-score) > 1e-9 {
+			t.Fatalf("pagerank[%s]: %v vs %v", id, score, v)
+		}
+		return true
+	})
+	want.CriticalPathAll(func(id string, score float64) bool {
+		if v, _ := got.CriticalPathValue(id); v != score {
+			t.Fatalf("critical path[%s]: %v vs %v", id, score, v)
+		}
+		return true
+	})
+}
+
+// Concurrent analyses of one Analyzer, with readers polling during Phase 2,
+// config changes and canceled runs mixed in, agree with a sequential run.
+func TestConcurrency_AnalyzeProperty(t *testing.T) {
+	rapid.Check(t, func(rt *rapid.T) {
+		issues := genDataset(rt)
+		want := NewAnalyzer(issues).Analyze()
+
+		a := NewAnalyzer(issues)
+		config := a.Config()
+		var wg sync.WaitGroup
+		results := make(chan *GraphStats, 8)
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				switch i % 4 {
+				case 0:
+					a.SetConfig(&config)
+				case 1:
+					ctx, cancel := context.WithCancel(context.Background())
+					cancel()
+					a.AnalyzeAsync(ctx).WaitForPhase2()
+					return
+				}
+				stats := a.AnalyzeAsync(context.Background())
+				for !stats.IsPhase2Ready() {
+					stats.PageRankValue(issues[0].ID)
+					stats.Status()
+					stats.Cycles()
+					time.Sleep(time.Microsecond)
+				}
+				stats.WaitForPhase2()
+				results <- stats
+			}(i)
+		}
+		wg.Wait()
+		close(results)
+		for got := range results {
+			sameMetrics(rt, &want, got)
+		}
+	})
+}
+
+func TestConcurrency_CanceledAnalysisIsNotShared(t *testing.T) {
+	issues, _ := datagen.Generate(datagen.Config{Nodes: 40, Shape: datagen.ShapeLayered, Seed: 3})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	canceled := NewAnalyzer(issues).AnalyzeAsync(ctx)
+	canceled.WaitForPhase2()
+	if canceled.IsPhase2Ready() {
+		t.Fatal("phase 2 of a canceled analysis reported ready")
+	}
+	if st := canceled.Status(); st.PageRank.State != "canceled" || st.Cycles.State != "canceled" {
+		t.Errorf("status after cancel = %+v", st)
+	}
+
+	// The same graph analyzed again must not get the abandoned stats
+	fresh := NewAnalyzer(issues).AnalyzeAsync(context.Background())
+	if fresh == canceled {
+		t.Fatal("canceled stats were served from the cache")
+	}
+	fresh.WaitForPhase2()
+	if !fresh.IsPhase2Ready() {
+		t.Fatal("fresh analysis not ready after WaitForPhase2")
+	}
+}
+
+func TestConcurrency_WaitForPhase2Context(t *testing.T) {
+	stats := &GraphStats{phase2Done: make(chan struct{})}
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := stats.WaitForPhase2Context(ctx); err != context.DeadlineExceeded {
+		t.Errorf("err = %v, want deadline exceeded", err)
+	}
+	close(stats.phase2Done)
+	if err := stats.WaitForPhase2Context(context.Background()); err != nil {
+		t.Errorf("err = %v after phase 2", err)
+	}
+}
+
+func TestConcurrency_CachedAnalyzer(t *testing.T) {
+	issues, _ := datagen.Generate(datagen.Config{Nodes: 50, Shape: datagen.ShapeDense, Seed: 5})
+	cache := NewCache(time.Minute)
+	ca := NewCachedAnalyzer(issues, cache)
+	config := ca.Config()
+
+	// A canceled run must not populate the cache
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	ca.AnalyzeAsync(ctx).WaitForPhase2()
+	time.Sleep(10 * time.Millisecond) // let the store goroutine run
+	if stats, ok := cache.GetByHash(ca.DataHash() + "|dynamic"); ok && !stats.IsPhase2Ready() {
+		t.Fatal("canceled stats were cached")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if i%4 == 0 {
+				ca.SetConfig(&config)
+			}
+			stats := ca.AnalyzeAsync(context.Background())
+			ca.WasCacheHit()
+			stats.WaitForPhase2()
+			if !stats.IsPhase2Ready() {
+				t.Error("stats not ready after WaitForPhase2")
+			}
+			snap := ca.Analyze()
+			if snap.NodeCount != len(issues) {
+				t.Errorf("snapshot has %d nodes", snap.NodeCount)
+			}
+		}(i)
+	}
+	wg.Wait()
+}
+
+func TestConcurrency_Phase2ProgressIsMonotonic(t *testing.T) {
+	issues, _ := datagen.Generate(datagen.Config{Nodes: 80, Shape: datagen.ShapeLayered, Seed: 7})
+	stats := NewAnalyzer(issues).AnalyzeAsync(context.Background())
+	last := 0
+	for !stats.IsPhase2Ready() {
+		done, total := stats.Phase2Progress()
+		if done < last || done > total {
+			t.Fatalf("progress %d/%d after %d", done, total, last)
+		}
+		last = done
+		time.Sleep(time.Microsecond)
+	}
+	if done, total := stats.Phase2Progress(); done != total || total == 0 {
+		t.Errorf("progress after Phase 2 = %d/%d", done, total)
+	}
+}
@@ -0,0 +1,94 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// CondensedNode is one strongly connected component of the blocking graph.
+// An issue that isn't on any cycle is a component by itself and keeps its ID.
+type CondensedNode struct {
+	ID      string   `json:"id"`      // The member's ID, or "scc-N" for a cycle
+	Members []string `json:"members"` // Sorted issue IDs
+	Cyclic  bool     `json:"cyclic"`  // More than one member
+}
+
+// CondensedEdge connects two components: some member of From depends on
+// some member of To.
+type CondensedEdge struct {
+	From   string `json:"from"`
+	To     string `json:"to"`
+	Weight int    `json:"weight"` // Dependency edges collapsed into this one
+}
+
+// Condensation is the blocking graph with every strongly connected component
+// collapsed into a single node. It is always a DAG.
+type Condensation struct {
+	Nodes       []CondensedNode   `json:"nodes"` // Sorted by ID
+	Edges       []CondensedEdge   `json:"edges"` // Sorted by From, then To
+	CyclicCount int               `json:"cyclic_count"`
+	Component   map[string]string `json:"-"` // Issue ID -> node ID
+}
+
+// Condense collapses the strongly connected components of the blocking graph.
+// Cycles are numbered scc-1, scc-2, ... in order of their smallest member ID,
+// skipping any name an issue already has so node IDs stay unique.
+func (a *Analyzer) Condense() *Condensation {
+	c := &Condensation{Component: make(map[string]string, len(a.nodeToID))}
+
+	var comps [][]string
+	for _, scc := range topo.TarjanSCC(a.g) {
+		members := make([]string, 0, len(scc))
+		for _, n := range scc {
+			members = append(members, a.nodeToID[n.ID()])
+		}
+		sort.Strings(members)
+		comps = append(comps, members)
+	}
+	sort.Slice(comps, func(i, j int) bool { return comps[i][0] < comps[j][0] })
+
+	seq := 0
+	for _, members := range comps {
+		node := CondensedNode{ID: members[0], Members: members}
+		if len(members) > 1 {
+			c.CyclicCount++
+			for {
+				seq++
+				node.ID = fmt.Sprintf("scc-%d", seq)
+				if _, taken := a.idToNode[node.ID]; !taken {
+					break
+				}
+			}
+			node.Cyclic = true
+		}
+		for _, id := range members {
+			c.Component[id] = node.ID
+		}
+		c.Nodes = append(c.Nodes, node)
+	}
+	sort.Slice(c.Nodes, func(i, j int) bool { return c.Nodes[i].ID < c.Nodes[j].ID })
+
+	type edgeKey struct{ from, to string }
+	weights := make(map[edgeKey]int)
+	edges := a.g.Edges()
+	for edges.Next() {
+		e := edges.Edge()
+		from := c.Component[a.nodeToID[e.From().ID()]]
+		to := c.Component[a.nodeToID[e.To().ID()]]
+		if from != to {
+			weights[edgeKey{from, to}]++
+		}
+	}
+	for k, w := range weights {
+		c.Edges = append(c.Edges, CondensedEdge{From: k.from, To: k.to, Weight: w})
+	}
+	sort.Slice(c.Edges, func(i, j int) bool {
+		if c.Edges[i].From != c.Edges[j].From {
+			return c.Edges[i].From < c.Edges[j].From
+		}
+		return c.Edges[i].To < c.Edges[j].To
+	})
+	return c
+}
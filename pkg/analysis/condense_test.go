@@ -0,0 +1,71 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestCondense(t *testing.T) {
+	dep := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	// B <-> C is one cycle, D -> E -> F -> D another; A waits on both
+	// cycles, G waits on D, and H is related to A only.
+	issues := []model.Issue{
+		{ID: "A", Dependencies: dep("B", "C", "E")},
+		{ID: "B", Dependencies: dep("C")},
+		{ID: "C", Dependencies: dep("B")},
+		{ID: "D", Dependencies: dep("E")},
+		{ID: "E", Dependencies: dep("F")},
+		{ID: "F", Dependencies: dep("D")},
+		{ID: "G", Dependencies: dep("D")},
+		{ID: "H", Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+	}
+
+	c := NewAnalyzer(issues).Condense()
+	want := []CondensedNode{
+		{ID: "A", Members: []string{"A"}},
+		{ID: "G", Members: []string{"G"}},
+		{ID: "H", Members: []string{"H"}},
+		{ID: "scc-1", Members: []string{"B", "C"}, Cyclic: true},
+		{ID: "scc-2", Members: []string{"D", "E", "F"}, Cyclic: true},
+	}
+	if !reflect.DeepEqual(c.Nodes, want) {
+		t.Errorf("nodes = %+v", c.Nodes)
+	}
+	wantEdges := []CondensedEdge{
+		{From: "A", To: "scc-1", Weight: 2},
+		{From: "A", To: "scc-2", Weight: 1},
+		{From: "G", To: "scc-2", Weight: 1},
+	}
+	if !reflect.DeepEqual(c.Edges, wantEdges) {
+		t.Errorf("edges = %+v", c.Edges)
+	}
+	if c.CyclicCount != 2 || c.Component["E"] != "scc-2" || c.Component["A"] != "A" {
+		t.Errorf("cyclic=%d component=%v", c.CyclicCount, c.Component)
+	}
+}
+
+func TestCondenseSkipsTakenNames(t *testing.T) {
+	// An issue named scc-1 waits on the B <-> C cycle
+	issues := []model.Issue{
+		{ID: "scc-1", Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "C", Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+
+	c := NewAnalyzer(issues).Condense()
+	if c.Component["B"] != "scc-2" || c.Component["scc-1"] != "scc-1" {
+		t.Fatalf("component = %v", c.Component)
+	}
+	wantEdges := []CondensedEdge{{From: "scc-1", To: "scc-2", Weight: 1}}
+	if !reflect.DeepEqual(c.Edges, wantEdges) {
+		t.Errorf("edges = %+v", c.Edges)
+	}
+}
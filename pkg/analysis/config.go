@@ -5,6 +5,8 @@ import (
 	"strconv"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 )
 
 // AnalysisConfig controls which metrics to compute and their timeouts.
@@ -22,6 +24,9 @@ type AnalysisConfig struct {
 	ComputePageRank    bool
 	PageRankTimeout    time.Duration
 	PageRankSkipReason string
+	// PageRankFocus personalizes PageRank toward these issue IDs (see
+	// ResolvePageRankFocus); empty means the usual uniform teleport.
+	PageRankFocus []string `json:",omitempty"`
 
 	// HITS (Hubs and Authorities)
 	ComputeHITS    bool
@@ -45,6 +50,15 @@ type AnalysisConfig struct {
 	ComputeKCore       bool // k-core decomposition
 	ComputeArticulation bool // Articulation points
 	ComputeSlack       bool // Scheduling slack
+
+	// ScoreRules adjust every impact score (see rules.Set.Adjust). They
+	// don't change graph metrics, so ComputeConfigHash ignores them.
+	ScoreRules *rules.Set `json:"-"`
+
+	// CodeLastChanged is when each issue's likely files last changed, by
+	// git blame (see ApplyCodeAge). Issues without files are absent. Like
+	// ScoreRules it only affects impact scores.
+	CodeLastChanged map[string]time.Time `json:"-"`
 }
 
 // DefaultConfig returns the default analysis configuration.
@@ -0,0 +1,107 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DependencyShape is where an issue sits in the blocking graph, in the
+// numbers people ask for when scanning a list.
+type DependencyShape struct {
+	Depth        int // longest chain of blocking dependencies below the issue
+	Dependents   int // issues directly blocked by this one (fan-in)
+	Dependencies int // issues this one directly depends on (fan-out)
+}
+
+// ComputeDependencyShapes returns the DependencyShape of every issue,
+// counting blocking dependencies between existing, non-tombstoned issues
+// regardless of status. Chains through a cycle stop where they would
+// revisit an issue.
+func ComputeDependencyShapes(issues []model.Issue) map[string]DependencyShape {
+	exists := make(map[string]bool, len(issues))
+	for i := range issues {
+		if !issues[i].Status.IsTombstone() {
+			exists[issues[i].ID] = true
+		}
+	}
+	deps := make(map[string][]string, len(issues))
+	shapes := make(map[string]DependencyShape, len(issues))
+	for i := range issues {
+		issue := &issues[i]
+		if !exists[issue.ID] {
+			continue
+		}
+		seen := make(map[string]bool, len(issue.Dependencies))
+		for _, dep := range issue.Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || dep.DependsOnID == issue.ID ||
+				!exists[dep.DependsOnID] || seen[dep.DependsOnID] {
+				continue
+			}
+			seen[dep.DependsOnID] = true
+			deps[issue.ID] = append(deps[issue.ID], dep.DependsOnID)
+		}
+	}
+	for id, targets := range deps {
+		s := shapes[id]
+		s.Dependencies = len(targets)
+		shapes[id] = s
+		for _, t := range targets {
+			s := shapes[t]
+			s.Dependents++
+			shapes[t] = s
+		}
+	}
+
+	// Iterative DFS so deep chains can't overflow the stack
+	const (
+		unvisited = iota
+		inProgress
+		done
+	)
+	state := make(map[string]int, len(exists))
+	depth := make(map[string]int, len(exists))
+	type frame struct {
+		id   string
+		next int
+	}
+	roots := make([]string, 0, len(exists))
+	for id := range exists {
+		roots = append(roots, id)
+	}
+	sort.Strings(roots) // cycles are cut in the same place every run
+	for _, root := range roots {
+		if state[root] != unvisited {
+			continue
+		}
+		stack := []frame{{id: root}}
+		state[root] = inProgress
+		for len(stack) > 0 {
+			top := &stack[len(stack)-1]
+			if top.next < len(deps[top.id]) {
+				child := deps[top.id][top.next]
+				top.next++
+				if state[child] == unvisited {
+					state[child] = inProgress
+					stack = append(stack, frame{id: child})
+				}
+				continue
+			}
+			best := 0
+			for _, child := range deps[top.id] {
+				if state[child] == done && depth[child]+1 > best {
+					best = depth[child] + 1
+				}
+			}
+			depth[top.id] = best
+			state[top.id] = done
+			stack = stack[:len(stack)-1]
+		}
+	}
+	for id := range exists {
+		s := shapes[id]
+		s.Depth = depth[id]
+		shapes[id] = s
+	}
+	return shapes
+}
@@ -0,0 +1,49 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeDependencyShapes(t *testing.T) {
+	blocks := func(id string, on ...string) model.Issue {
+		issue := model.Issue{ID: id, Status: model.StatusOpen}
+		for _, o := range on {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: o, Type: model.DepBlocks})
+		}
+		return issue
+	}
+	issues := []model.Issue{
+		blocks("A"),
+		blocks("B", "A"),
+		blocks("C", "B", "A", "A", "missing"), // duplicate and dangling edges ignored
+		blocks("D", "C"),
+		{ID: "E", Status: model.StatusClosed, Dependencies: []*model.Dependency{
+			{IssueID: "E", DependsOnID: "D", Type: model.DepBlocks},
+			{IssueID: "E", DependsOnID: "A", Type: model.DepRelated},
+			{IssueID: "E", DependsOnID: "T", Type: model.DepBlocks},
+		}},
+		{ID: "T", Status: model.StatusTombstone},
+		blocks("X", "Y"), // cycle
+		blocks("Y", "X"),
+	}
+	shapes := ComputeDependencyShapes(issues)
+	want := map[string]DependencyShape{
+		"A": {Depth: 0, Dependents: 2, Dependencies: 0},
+		"B": {Depth: 1, Dependents: 1, Dependencies: 1},
+		"C": {Depth: 2, Dependents: 1, Dependencies: 2},
+		"D": {Depth: 3, Dependents: 1, Dependencies: 1},
+		"E": {Depth: 4, Dependents: 0, Dependencies: 1},
+		"X": {Depth: 1, Dependents: 1, Dependencies: 1},
+		"Y": {Depth: 0, Dependents: 1, Dependencies: 1},
+	}
+	for id, w := range want {
+		if got := shapes[id]; got != w {
+			t.Errorf("%s = %+v, want %+v", id, got, w)
+		}
+	}
+	if _, ok := shapes["T"]; ok {
+		t.Error("tombstones should have no shape")
+	}
+}
@@ -0,0 +1,315 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// EpicClusterConfig configures epic cluster detection
+type EpicClusterConfig struct {
+	// MinSimilarity is the average-linkage cosine similarity two groups
+	// need to merge; issue titles are short, so modest overlap counts
+	// Default: 0.15
+	MinSimilarity float64
+
+	// MinClusterSize is the smallest group worth an epic
+	// Default: 3
+	MinClusterSize int
+
+	// MaxIssues caps the loose issues clustered; clustering is quadratic
+	// in memory, so larger repos cluster their highest priority issues
+	// Default: 2000
+	MaxIssues int
+
+	// MaxSuggestions limits the number of proposed epics
+	// Default: 10
+	MaxSuggestions int
+}
+
+// DefaultEpicClusterConfig returns sensible defaults
+func DefaultEpicClusterConfig() EpicClusterConfig {
+	return EpicClusterConfig{
+		MinSimilarity:  0.15,
+		MinClusterSize: 3,
+		MaxIssues:      2000,
+		MaxSuggestions: 10,
+	}
+}
+
+// EpicCluster is a group of loose issues that read like one piece of work
+type EpicCluster struct {
+	IssueIDs            []string `json:"issue_ids"`
+	RepresentativeID    string   `json:"representative_id"`
+	RepresentativeTitle string   `json:"representative_title"`
+	TopTerms            []string `json:"top_terms"`
+	Cohesion            float64  `json:"cohesion"` // mean pairwise similarity
+}
+
+// DetectEpicClusters proposes epics for repos with many loose tasks. Open
+// issues that are neither epics nor children of one are compared by TF-IDF
+// over their title (weighted double) and description keywords, and joined
+// by agglomerative average-linkage clustering. Each group of at least
+// MinClusterSize becomes a suggestion whose target is the member most
+// similar to the rest, a stand-in title for the epic.
+func DetectEpicClusters(issues []model.Issue, config EpicClusterConfig) []Suggestion {
+	clusters := ClusterLooseIssues(issues, config)
+	if len(clusters) > config.MaxSuggestions {
+		clusters = clusters[:config.MaxSuggestions]
+	}
+
+	suggestions := make([]Suggestion, 0, len(clusters))
+	for _, c := range clusters {
+		confidence := math.Min(0.95, 0.3+c.Cohesion)
+		sug := NewSuggestion(
+			SuggestionEpicCluster,
+			c.RepresentativeID,
+			fmt.Sprintf("Group %d issues under an epic like %q", len(c.IssueIDs), c.RepresentativeTitle),
+			fmt.Sprintf("%.0f%% mean text similarity; shared terms: %s", c.Cohesion*100, strings.Join(c.TopTerms, ", ")),
+			roundTo(confidence, 2),
+		).WithAction(fmt.Sprintf("bd create %q --type=epic", c.RepresentativeTitle)).
+			WithMetadata("issue_ids", c.IssueIDs).
+			WithMetadata("representative_title", c.RepresentativeTitle).
+			WithMetadata("top_terms", c.TopTerms).
+			WithMetadata("cohesion", c.Cohesion)
+		suggestions = append(suggestions, sug)
+	}
+	return suggestions
+}
+
+// ClusterLooseIssues returns the clusters behind DetectEpicClusters,
+// largest first.
+func ClusterLooseIssues(issues []model.Issue, config EpicClusterConfig) []EpicCluster {
+	loose := looseIssues(issues)
+	if config.MaxIssues > 0 && len(loose) > config.MaxIssues {
+		sort.SliceStable(loose, func(i, j int) bool { return loose[i].Priority < loose[j].Priority })
+		loose = loose[:config.MaxIssues]
+	}
+	if len(loose) < max(2, config.MinClusterSize) {
+		return nil
+	}
+
+	vectors := tfidfVectors(loose)
+	n := len(loose)
+	sim := make([][]float64, n)
+	for i := range sim {
+		sim[i] = make([]float64, n)
+	}
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			s := cosine(vectors[i], vectors[j])
+			sim[i][j], sim[j][i] = s, s
+		}
+	}
+
+	members := agglomerate(sim, config.MinSimilarity)
+	var clusters []EpicCluster
+	for _, group := range members {
+		if len(group) < config.MinClusterSize {
+			continue
+		}
+		clusters = append(clusters, describeCluster(group, loose, vectors))
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].IssueIDs) != len(clusters[j].IssueIDs) {
+			return len(clusters[i].IssueIDs) > len(clusters[j].IssueIDs)
+		}
+		if clusters[i].Cohesion != clusters[j].Cohesion {
+			return clusters[i].Cohesion > clusters[j].Cohesion
+		}
+		return clusters[i].RepresentativeID < clusters[j].RepresentativeID
+	})
+	return clusters
+}
+
+// looseIssues are open issues that are not epics and have no parent.
+func looseIssues(issues []model.Issue) []*model.Issue {
+	var out []*model.Issue
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() || issue.IssueType == model.TypeEpic {
+			continue
+		}
+		hasParent := false
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild {
+				hasParent = true
+				break
+			}
+		}
+		if !hasParent {
+			out = append(out, issue)
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+// tfidfVectors builds unit-length TF-IDF vectors; title keywords count
+// twice, description keywords once.
+func tfidfVectors(issues []*model.Issue) []map[string]float64 {
+	tf := make([]map[string]float64, len(issues))
+	df := make(map[string]int)
+	for i, issue := range issues {
+		tf[i] = make(map[string]float64)
+		for _, w := range extractKeywords(issue.Title, "") {
+			tf[i][w] += 2
+		}
+		for _, w := range extractKeywords("", issue.Description) {
+			tf[i][w]++
+		}
+		for w := range tf[i] {
+			df[w]++
+		}
+	}
+	n := float64(len(issues))
+	for i := range tf {
+		var norm float64
+		for w, f := range tf[i] {
+			weight := f * math.Log(n/float64(df[w]))
+			tf[i][w] = weight
+			norm += weight * weight
+		}
+		if norm > 0 {
+			norm = math.Sqrt(norm)
+			for w := range tf[i] {
+				tf[i][w] /= norm
+			}
+		}
+	}
+	return tf
+}
+
+func cosine(a, b map[string]float64) float64 {
+	if len(a) > len(b) {
+		a, b = b, a
+	}
+	var dot float64
+	for w, x := range a {
+		dot += x * b[w]
+	}
+	return dot
+}
+
+// agglomerate runs average-linkage clustering over the similarity matrix
+// (which it overwrites) until no two groups reach minSim, returning the
+// groups as index lists. Each active group caches its best partner so a
+// merge only rescans the groups that pointed at the merged pair.
+func agglomerate(sim [][]float64, minSim float64) [][]int {
+	n := len(sim)
+	active := make([]bool, n)
+	size := make([]int, n)
+	members := make([][]int, n)
+	best := make([]int, n)
+	for i := range sim {
+		active[i] = true
+		size[i] = 1
+		members[i] = []int{i}
+	}
+	findBest := func(i int) {
+		best[i] = -1
+		for j := 0; j < n; j++ {
+			if j != i && active[j] && (best[i] < 0 || sim[i][j] > sim[i][best[i]]) {
+				best[i] = j
+			}
+		}
+	}
+	for i := range sim {
+		findBest(i)
+	}
+
+	for {
+		a := -1
+		for i := 0; i < n; i++ {
+			if active[i] && best[i] >= 0 && (a < 0 || sim[i][best[i]] > sim[a][best[a]]) {
+				a = i
+			}
+		}
+		if a < 0 || sim[a][best[a]] < minSim {
+			break
+		}
+		b := best[a]
+		// Merge b into a (Lance-Williams update for average linkage)
+		for k := 0; k < n; k++ {
+			if k == a || k == b || !active[k] {
+				continue
+			}
+			s := (float64(size[a])*sim[a][k] + float64(size[b])*sim[b][k]) / float64(size[a]+size[b])
+			sim[a][k], sim[k][a] = s, s
+		}
+		active[b] = false
+		size[a] += size[b]
+		members[a] = append(members[a], members[b]...)
+		members[b] = nil
+
+		findBest(a)
+		for k := 0; k < n; k++ {
+			if !active[k] || k == a {
+				continue
+			}
+			switch {
+			case best[k] == a || best[k] == b:
+				findBest(k)
+			case sim[k][a] > sim[k][best[k]]:
+				best[k] = a
+			}
+		}
+	}
+
+	var out [][]int
+	for i := 0; i < n; i++ {
+		if active[i] {
+			sort.Ints(members[i])
+			out = append(out, members[i])
+		}
+	}
+	return out
+}
+
+// describeCluster names a group by its medoid and its heaviest terms.
+func describeCluster(group []int, issues []*model.Issue, vectors []map[string]float64) EpicCluster {
+	c := EpicCluster{IssueIDs: make([]string, len(group))}
+	for i, idx := range group {
+		c.IssueIDs[i] = issues[idx].ID
+	}
+
+	medoid, bestAvg, total, pairs := group[0], -1.0, 0.0, 0
+	for _, i := range group {
+		sum := 0.0
+		for _, j := range group {
+			if i != j {
+				sum += cosine(vectors[i], vectors[j])
+			}
+		}
+		if avg := sum / float64(len(group)-1); avg > bestAvg {
+			medoid, bestAvg = i, avg
+		}
+		total += sum
+		pairs += len(group) - 1
+	}
+	c.RepresentativeID = issues[medoid].ID
+	c.RepresentativeTitle = issues[medoid].Title
+	c.Cohesion = roundTo(total/float64(pairs), 3)
+
+	weights := make(map[string]float64)
+	for _, i := range group {
+		for w, x := range vectors[i] {
+			weights[w] += x
+		}
+	}
+	terms := make([]string, 0, len(weights))
+	for w := range weights {
+		terms = append(terms, w)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if weights[terms[i]] != weights[terms[j]] {
+			return weights[terms[i]] > weights[terms[j]]
+		}
+		return terms[i] < terms[j]
+	})
+	c.TopTerms = truncateStringSlice(terms, 3)
+	return c
+}
@@ -0,0 +1,74 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestClusterLooseIssues(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A1", Title: "OAuth login token refresh fails", Status: model.StatusOpen},
+		{ID: "A2", Title: "OAuth login redirect loop", Status: model.StatusOpen},
+		{ID: "A3", Title: "Expired OAuth token breaks login", Status: model.StatusOpen},
+		{ID: "A4", Title: "OAuth login page styling", Status: model.StatusOpen, Dependencies: []*model.Dependency{
+			{IssueID: "A4", DependsOnID: "E", Type: model.DepParentChild}, // already under an epic
+		}},
+		{ID: "B1", Title: "Export report to PDF", Status: model.StatusOpen, Description: "PDF export for the weekly report"},
+		{ID: "B2", Title: "PDF report export loses tables", Status: model.StatusOpen},
+		{ID: "B3", Title: "Report export PDF fonts", Status: model.StatusInProgress},
+		{ID: "B4", Title: "Export report PDF header", Status: model.StatusClosed},
+		{ID: "C1", Title: "Upgrade Go toolchain", Status: model.StatusOpen},
+		{ID: "C2", Title: "Flaky websocket test", Status: model.StatusOpen},
+		{ID: "E", Title: "OAuth epic", IssueType: model.TypeEpic, Status: model.StatusOpen},
+	}
+	clusters := ClusterLooseIssues(issues, DefaultEpicClusterConfig())
+	var got [][]string
+	for _, c := range clusters {
+		got = append(got, c.IssueIDs)
+	}
+	// Same size, so the tighter report/PDF group comes first
+	if want := [][]string{{"B1", "B2", "B3"}, {"A1", "A2", "A3"}}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("clusters = %v, want %v", got, want)
+	}
+	for _, c := range clusters {
+		if c.Cohesion <= 0 || c.Cohesion > 1 || len(c.TopTerms) == 0 || c.RepresentativeTitle == "" {
+			t.Errorf("cluster = %+v", c)
+		}
+	}
+	if terms := clusters[1].TopTerms; terms[0] != "login" && terms[0] != "oauth" {
+		t.Errorf("oauth cluster top terms = %v", terms)
+	}
+
+	sugs := DetectEpicClusters(issues, DefaultEpicClusterConfig())
+	if len(sugs) != 2 || sugs[0].Type != SuggestionEpicCluster || sugs[0].ActionCommand == "" {
+		t.Fatalf("suggestions = %+v", sugs)
+	}
+	if ids := sugs[1].Metadata["issue_ids"].([]string); !reflect.DeepEqual(ids, []string{"A1", "A2", "A3"}) {
+		t.Errorf("issue_ids = %v", ids)
+	}
+}
+
+func TestClusterLooseIssuesTooFew(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Same words here", Status: model.StatusOpen},
+		{ID: "B", Title: "Same words here", Status: model.StatusOpen},
+	}
+	if got := ClusterLooseIssues(issues, DefaultEpicClusterConfig()); got != nil {
+		t.Errorf("two issues can't make a cluster of three: %+v", got)
+	}
+}
+
+func TestAgglomerate(t *testing.T) {
+	sim := [][]float64{
+		{0, 0.9, 0.5, 0},
+		{0.9, 0, 0.1, 0},
+		{0.5, 0.1, 0, 0},
+		{0, 0, 0, 0},
+	}
+	// 0+1 merge at 0.9; {0,1} to 2 averages (0.5+0.1)/2 = 0.3, above 0.25.
+	if got := agglomerate(sim, 0.25); !reflect.DeepEqual(got, [][]int{{0, 1, 2}, {3}}) {
+		t.Errorf("groups = %v", got)
+	}
+}
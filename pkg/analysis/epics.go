@@ -0,0 +1,256 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Epic health ratings, worst last.
+const (
+	EpicHealthGreen  = "green"
+	EpicHealthYellow = "yellow"
+	EpicHealthRed    = "red"
+)
+
+// Thresholds for the epic health rating.
+const (
+	EpicStaleYellowDays   = 14 // no child activity for this long is yellow
+	EpicStaleRedDays      = 30 // ... and this long is red
+	EpicLongPathYellow    = 5  // an open blocking chain this long is yellow
+	EpicBlockedRedPercent = 50 // this share of remaining work blocked is red
+)
+
+// EpicHealth summarizes progress on one epic for PM-level reporting. Counts
+// cover the epic's descendants through parent-child dependencies, nested
+// epics included; tombstoned children are ignored.
+type EpicHealth struct {
+	ID              string  `json:"id"`
+	Title           string  `json:"title"`
+	Status          string  `json:"status"`
+	Total           int     `json:"total"`
+	Open            int     `json:"open"` // not closed, in progress and blocked included
+	InProgress      int     `json:"in_progress"`
+	Blocked         int     `json:"blocked"` // blocked status or an open blocker
+	Closed          int     `json:"closed"`
+	PercentComplete float64 `json:"percent_complete"`
+	// CriticalPath is the longest chain of open children where each blocks
+	// the next, first blocker first.
+	CriticalPathLength int       `json:"critical_path_length"`
+	CriticalPath       []string  `json:"critical_path,omitempty"`
+	LastActivity       time.Time `json:"last_activity"`
+	StaleDays          int       `json:"stale_days"` // days since LastActivity
+	Health             string    `json:"health"`
+	Reasons            []string  `json:"reasons,omitempty"`
+}
+
+// ComputeEpicHealth reports on every epic: issues of type epic and any issue
+// with parent-child children. Results are ordered worst health first, then
+// by ID.
+func ComputeEpicHealth(issues []model.Issue, now time.Time) []EpicHealth {
+	byID := make(map[string]*model.Issue, len(issues))
+	children := make(map[string][]string)
+	for i := range issues {
+		issue := &issues[i]
+		byID[issue.ID] = issue
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild && dep.DependsOnID != issue.ID {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	var result []EpicHealth
+	for i := range issues {
+		epic := &issues[i]
+		if epic.Status.IsTombstone() || (epic.IssueType != model.TypeEpic && len(children[epic.ID]) == 0) {
+			continue
+		}
+		result = append(result, epicHealth(epic, epicDescendants(epic.ID, children, byID), byID, now))
+	}
+
+	rank := map[string]int{EpicHealthRed: 0, EpicHealthYellow: 1, EpicHealthGreen: 2}
+	sort.Slice(result, func(i, j int) bool {
+		if rank[result[i].Health] != rank[result[j].Health] {
+			return rank[result[i].Health] < rank[result[j].Health]
+		}
+		return result[i].ID < result[j].ID
+	})
+	return result
+}
+
+// epicDescendants returns the existing, non-tombstoned issues under id, in
+// ID order. Parent-child cycles are cut.
+func epicDescendants(id string, children map[string][]string, byID map[string]*model.Issue) []*model.Issue {
+	seen := map[string]bool{id: true}
+	var out []*model.Issue
+	queue := []string{id}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		for _, child := range children[cur] {
+			if seen[child] {
+				continue
+			}
+			seen[child] = true
+			queue = append(queue, child)
+			if issue, ok := byID[child]; ok && !issue.Status.IsTombstone() {
+				out = append(out, issue)
+			}
+		}
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].ID < out[j].ID })
+	return out
+}
+
+func epicHealth(epic *model.Issue, members []*model.Issue, byID map[string]*model.Issue, now time.Time) EpicHealth {
+	h := EpicHealth{
+		ID:           epic.ID,
+		Title:        epic.Title,
+		Status:       string(epic.Status),
+		Total:        len(members),
+		LastActivity: epic.UpdatedAt,
+	}
+	open := make(map[string]*model.Issue)
+	for _, m := range members {
+		if m.UpdatedAt.After(h.LastActivity) {
+			h.LastActivity = m.UpdatedAt
+		}
+		if m.ClosedAt != nil && m.ClosedAt.After(h.LastActivity) {
+			h.LastActivity = *m.ClosedAt
+		}
+		if m.Status.IsClosed() {
+			h.Closed++
+			continue
+		}
+		h.Open++
+		open[m.ID] = m
+		if m.Status == model.StatusInProgress {
+			h.InProgress++
+		}
+		if m.Status == model.StatusBlocked || hasOpenBlocker(m, byID) {
+			h.Blocked++
+		}
+	}
+	if h.Total > 0 {
+		h.PercentComplete = math.Round(float64(h.Closed)*1000/float64(h.Total)) / 10
+	} else if epic.Status.IsClosed() {
+		h.PercentComplete = 100
+	}
+	h.CriticalPath = longestOpenChain(open)
+	h.CriticalPathLength = len(h.CriticalPath)
+	if !h.LastActivity.IsZero() && now.After(h.LastActivity) {
+		h.StaleDays = int(now.Sub(h.LastActivity).Hours() / 24)
+	}
+	h.Health, h.Reasons = rateEpic(h, epic.Status.IsClosed())
+	return h
+}
+
+// hasOpenBlocker reports whether any of issue's blocking dependencies is
+// still open.
+func hasOpenBlocker(issue *model.Issue, byID map[string]*model.Issue) bool {
+	for _, dep := range issue.Dependencies {
+		if dep == nil || !dep.Type.IsBlocking() {
+			continue
+		}
+		if blocker, ok := byID[dep.DependsOnID]; ok && !blocker.Status.IsClosed() && !blocker.Status.IsTombstone() {
+			return true
+		}
+	}
+	return false
+}
+
+// longestOpenChain returns the longest blocking chain within open, blocker
+// first. Ties go to the chain starting at the lowest ID; cycles are cut.
+func longestOpenChain(open map[string]*model.Issue) []string {
+	// next[id] lists the open issues id blocks
+	next := make(map[string][]string)
+	for id, issue := range open {
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && dep.DependsOnID != id {
+				if _, ok := open[dep.DependsOnID]; ok {
+					next[dep.DependsOnID] = append(next[dep.DependsOnID], id)
+				}
+			}
+		}
+	}
+	ids := make([]string, 0, len(open))
+	for id := range open {
+		ids = append(ids, id)
+		sort.Strings(next[id])
+	}
+	sort.Strings(ids)
+
+	memo := make(map[string][]string)
+	visiting := make(map[string]bool)
+	var walk func(id string) []string
+	walk = func(id string) []string {
+		if chain, ok := memo[id]; ok {
+			return chain
+		}
+		visiting[id] = true
+		var best []string
+		for _, n := range next[id] {
+			if visiting[n] {
+				continue
+			}
+			if chain := walk(n); len(chain) > len(best) {
+				best = chain
+			}
+		}
+		visiting[id] = false
+		chain := append([]string{id}, best...)
+		memo[id] = chain
+		return chain
+	}
+
+	var longest []string
+	for _, id := range ids {
+		if chain := walk(id); len(chain) > len(longest) {
+			longest = chain
+		}
+	}
+	return longest
+}
+
+// rateEpic assigns the traffic-light rating and the reasons behind it.
+// Finished epics are green; otherwise staleness and blocked work drive it.
+func rateEpic(h EpicHealth, closed bool) (string, []string) {
+	if h.Open == 0 {
+		if h.Total == 0 && !closed {
+			return EpicHealthYellow, []string{"no child issues"}
+		}
+		return EpicHealthGreen, nil
+	}
+	var red, yellow []string
+	switch {
+	case h.StaleDays >= EpicStaleRedDays:
+		red = append(red, fmt.Sprintf("no activity for %d days", h.StaleDays))
+	case h.StaleDays >= EpicStaleYellowDays:
+		yellow = append(yellow, fmt.Sprintf("no activity for %d days", h.StaleDays))
+	}
+	if h.Blocked > 0 {
+		reason := fmt.Sprintf("%d of %d open issues blocked", h.Blocked, h.Open)
+		if h.Blocked*100 >= h.Open*EpicBlockedRedPercent {
+			red = append(red, reason)
+		} else {
+			yellow = append(yellow, reason)
+		}
+	}
+	if h.CriticalPathLength >= EpicLongPathYellow {
+		yellow = append(yellow, fmt.Sprintf("critical path of %d issues", h.CriticalPathLength))
+	}
+	if closed {
+		yellow = append(yellow, fmt.Sprintf("epic closed with %d open issues", h.Open))
+	}
+	switch {
+	case len(red) > 0:
+		return EpicHealthRed, append(red, yellow...)
+	case len(yellow) > 0:
+		return EpicHealthYellow, yellow
+	}
+	return EpicHealthGreen, nil
+}
@@ -0,0 +1,95 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func epicChild(id, parent string, status model.Status, updated time.Time, blockers ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: id, Status: status, IssueType: model.TypeTask, UpdatedAt: updated}
+	if parent != "" {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: parent, Type: model.DepParentChild})
+	}
+	for _, b := range blockers {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+	}
+	return issue
+}
+
+func TestComputeEpicHealth(t *testing.T) {
+	now := time.Date(2026, 3, 31, 0, 0, 0, 0, time.UTC)
+	recent := now.Add(-2 * 24 * time.Hour)
+	old := now.Add(-40 * 24 * time.Hour)
+
+	issues := []model.Issue{
+		{ID: "E-1", Title: "Checkout", Status: model.StatusOpen, IssueType: model.TypeEpic, UpdatedAt: old},
+		epicChild("A", "E-1", model.StatusClosed, recent),
+		epicChild("B", "E-1", model.StatusInProgress, recent),
+		epicChild("C", "E-1", model.StatusOpen, recent, "B"),
+		epicChild("D", "E-1", model.StatusOpen, recent),
+		epicChild("S-1", "E-1", model.StatusOpen, recent), // nested epic
+		epicChild("S-1a", "S-1", model.StatusClosed, recent),
+		epicChild("S-1b", "S-1", model.StatusClosed, recent),
+
+		// Plain parent, stale, everything blocked
+		{ID: "P", Title: "Parent", Status: model.StatusOpen, IssueType: model.TypeFeature, UpdatedAt: old},
+		epicChild("P1", "P", model.StatusBlocked, old),
+		epicChild("P2", "P", model.StatusOpen, old, "P1"),
+
+		{ID: "E-empty", Status: model.StatusOpen, IssueType: model.TypeEpic, UpdatedAt: recent},
+		{ID: "E-gone", Status: model.StatusTombstone, IssueType: model.TypeEpic},
+	}
+
+	got := ComputeEpicHealth(issues, now)
+	var ids []string
+	for _, e := range got {
+		ids = append(ids, e.ID+":"+e.Health)
+	}
+	want := []string{"P:red", "E-1:yellow", "E-empty:yellow", "S-1:green"}
+	if !reflect.DeepEqual(ids, want) {
+		t.Fatalf("epics = %v, want %v", ids, want)
+	}
+
+	e1 := got[1]
+	if e1.Total != 7 || e1.Open != 4 || e1.Closed != 3 || e1.InProgress != 1 || e1.Blocked != 1 {
+		t.Errorf("E-1 counts = %+v", e1)
+	}
+	if e1.PercentComplete != 42.9 {
+		t.Errorf("E-1 percent = %v, want 42.9", e1.PercentComplete)
+	}
+	if !reflect.DeepEqual(e1.CriticalPath, []string{"B", "C"}) || e1.CriticalPathLength != 2 {
+		t.Errorf("E-1 critical path = %v", e1.CriticalPath)
+	}
+	if e1.StaleDays != 2 || !e1.LastActivity.Equal(recent) {
+		t.Errorf("E-1 staleness = %d days since %v", e1.StaleDays, e1.LastActivity)
+	}
+	if !reflect.DeepEqual(e1.Reasons, []string{"1 of 4 open issues blocked"}) {
+		t.Errorf("E-1 reasons = %v", e1.Reasons)
+	}
+
+	p := got[0]
+	if p.Blocked != 2 || p.StaleDays != 40 || len(p.Reasons) != 2 {
+		t.Errorf("P = %+v", p)
+	}
+	if got[3].PercentComplete != 100 || got[3].Reasons != nil {
+		t.Errorf("S-1 = %+v", got[3])
+	}
+}
+
+func TestLongestOpenChainCycle(t *testing.T) {
+	open := map[string]*model.Issue{}
+	for _, issue := range []model.Issue{
+		epicChild("A", "", model.StatusOpen, time.Time{}, "C"),
+		epicChild("B", "", model.StatusOpen, time.Time{}, "A"),
+		epicChild("C", "", model.StatusOpen, time.Time{}, "B"),
+	} {
+		issue := issue
+		open[issue.ID] = &issue
+	}
+	if chain := longestOpenChain(open); len(chain) != 3 || chain[0] != "A" {
+		t.Errorf("chain = %v, want all three starting at A", chain)
+	}
+}
@@ -0,0 +1,212 @@
+package analysis
+
+import (
+	"sort"
+
+	"gonum.org/v1/gonum/graph/topo"
+)
+
+// FeedbackArcSetResult is an alternative to per-edge cycle breaking: a small
+// set of edges whose removal together leaves the dependency graph acyclic.
+type FeedbackArcSetResult struct {
+	Edges           []CycleBreakItem `json:"edges"`            // Lowest collateral first
+	Size            int              `json:"size"`             // Edges to remove
+	TotalCollateral int              `json:"total_collateral"` // Sum of Collateral over Edges
+	Approximate     bool             `json:"approximate"`      // Heuristic, not guaranteed minimum
+	HowToUse        string           `json:"how_to_use"`
+}
+
+// fasEdge is a dependency edge from an issue to the issue it depends on.
+type fasEdge struct{ from, to string }
+
+// feedbackArcSet approximates a minimum feedback arc set of the blocking
+// graph. Each strongly connected component is ordered with the Eades-Lin-Smyth
+// greedy heuristic and the edges pointing backwards in that order are taken;
+// then every taken edge that can be put back without closing a cycle is put
+// back, and the rest are swapped for cheaper edges on the cycles they break
+// where that works, so the edges kept in the graph are the ones with the most
+// collateral.
+func (a *Analyzer) feedbackArcSet() []fasEdge {
+	var removed []fasEdge
+	for _, scc := range topo.TarjanSCC(a.g) {
+		if len(scc) < 2 {
+			continue
+		}
+		members := make(map[string]bool, len(scc))
+		ids := make([]string, 0, len(scc))
+		for _, n := range scc {
+			id := a.nodeToID[n.ID()]
+			members[id] = true
+			ids = append(ids, id)
+		}
+		sort.Strings(ids)
+
+		out := make(map[string][]string, len(ids))
+		in := make(map[string][]string, len(ids))
+		for _, id := range ids {
+			from := a.g.From(a.idToNode[id])
+			for from.Next() {
+				to := a.nodeToID[from.Node().ID()]
+				if members[to] {
+					out[id] = append(out[id], to)
+					in[to] = append(in[to], id)
+				}
+			}
+			sort.Strings(out[id])
+		}
+
+		pos := make(map[string]int, len(ids))
+		for i, id := range eadesOrder(ids, out, in) {
+			pos[id] = i
+		}
+		var back []fasEdge
+		for _, from := range ids {
+			for _, to := range out[from] {
+				if pos[from] > pos[to] {
+					back = append(back, fasEdge{from, to})
+				}
+			}
+		}
+		removed = append(removed, a.pruneFeedbackArcs(back, out)...)
+	}
+	return removed
+}
+
+// eadesOrder orders the nodes so that few edges point backwards: sinks go to
+// the end, sources to the front, and otherwise the node with the largest
+// out-degree minus in-degree goes to the front.
+func eadesOrder(ids []string, out, in map[string][]string) []string {
+	left := make(map[string]bool, len(ids))
+	outdeg := make(map[string]int, len(ids))
+	indeg := make(map[string]int, len(ids))
+	for _, id := range ids {
+		left[id] = true
+		outdeg[id] = len(out[id])
+		indeg[id] = len(in[id])
+	}
+	take := func(id string) {
+		delete(left, id)
+		for _, to := range out[id] {
+			indeg[to]--
+		}
+		for _, from := range in[id] {
+			outdeg[from]--
+		}
+	}
+
+	var front, back []string
+	for len(left) > 0 {
+		progress := false
+		for _, id := range ids {
+			if left[id] && outdeg[id] == 0 {
+				back = append(back, id)
+				take(id)
+				progress = true
+			}
+		}
+		for _, id := range ids {
+			if left[id] && indeg[id] == 0 {
+				front = append(front, id)
+				take(id)
+				progress = true
+			}
+		}
+		if progress {
+			continue
+		}
+		best := ""
+		for _, id := range ids {
+			if left[id] && (best == "" || outdeg[id]-indeg[id] > outdeg[best]-indeg[best]) {
+				best = id
+			}
+		}
+		front = append(front, best)
+		take(best)
+	}
+	for i := len(back) - 1; i >= 0; i-- {
+		front = append(front, back[i])
+	}
+	return front
+}
+
+// pruneFeedbackArcs drops edges from removed that aren't needed: an edge can
+// stay if, with the other removed edges gone, its target can't reach its
+// source. An edge that is needed is swapped for the cheapest edge on the path
+// back to its source whose removal breaks every such path instead.
+func (a *Analyzer) pruneFeedbackArcs(removed []fasEdge, out map[string][]string) []fasEdge {
+	sort.Slice(removed, func(i, j int) bool {
+		ci, cj := a.countDependents(removed[i].to), a.countDependents(removed[j].to)
+		if ci != cj {
+			return ci > cj
+		}
+		if removed[i].from != removed[j].from {
+			return removed[i].from < removed[j].from
+		}
+		return removed[i].to < removed[j].to
+	})
+	cut := make(map[fasEdge]bool, len(removed))
+	for _, e := range removed {
+		cut[e] = true
+	}
+	// path returns the edges of a path from -> target avoiding cut edges,
+	// or nil if there is none.
+	path := func(from, target string) []fasEdge {
+		parent := map[string]string{from: ""}
+		queue := []string{from}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			if id == target {
+				var edges []fasEdge
+				for id != from {
+					edges = append(edges, fasEdge{parent[id], id})
+					id = parent[id]
+				}
+				return edges
+			}
+			for _, to := range out[id] {
+				if _, seen := parent[to]; !seen && !cut[fasEdge{id, to}] {
+					parent[to] = id
+					queue = append(queue, to)
+				}
+			}
+		}
+		return nil
+	}
+
+	var kept []fasEdge
+	for _, e := range removed {
+		delete(cut, e)
+		back := path(e.to, e.from)
+		if back == nil {
+			continue
+		}
+		sort.Slice(back, func(i, j int) bool {
+			ci, cj := a.countDependents(back[i].to), a.countDependents(back[j].to)
+			if ci != cj {
+				return ci < cj
+			}
+			if back[i].from != back[j].from {
+				return back[i].from < back[j].from
+			}
+			return back[i].to < back[j].to
+		})
+		swap := e
+		for _, f := range back {
+			if a.countDependents(f.to) >= a.countDependents(e.to) {
+				break
+			}
+			cut[f] = true
+			if path(e.to, e.from) == nil {
+				swap = f
+				break
+			}
+			delete(cut, f)
+		}
+		if swap == e {
+			cut[e] = true
+		}
+		kept = append(kept, swap)
+	}
+	return kept
+}
@@ -0,0 +1,140 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// FrontierOptions says whose frontier to compute.
+type FrontierOptions struct {
+	// Agent is the caller. Issues assigned to or claimed by it stay in the
+	// frontier; with no agent, any assignment or claim takes an issue out.
+	Agent string
+	// Claims maps issue IDs to the agent holding a bv session claim on them.
+	Claims map[string]string
+}
+
+// FrontierIssue is one issue that can be started right now.
+type FrontierIssue struct {
+	ID        string   `json:"id"`
+	Title     string   `json:"title"`
+	IssueType string   `json:"issue_type"`
+	Priority  int      `json:"priority"`
+	Labels    []string `json:"labels,omitempty"`
+	Assignee  string   `json:"assignee,omitempty"`
+	Unblocks  int      `json:"unblocks"` // open issues waiting directly on this one
+}
+
+// FrontierGroup is the frontier at one priority.
+type FrontierGroup struct {
+	Priority int             `json:"priority"`
+	Count    int             `json:"count"`
+	Issues   []FrontierIssue `json:"issues"`
+}
+
+// Frontier is the ready frontier: open issues whose blockers are all
+// closed and that nobody else has claimed, grouped by priority. Unlike the
+// ready filter it leaves out in-progress work, so everything here is
+// startable.
+type Frontier struct {
+	Total  int             `json:"total"`
+	Groups []FrontierGroup `json:"groups"`
+	// Why the remaining open issues are not on the frontier
+	Blocked         int `json:"blocked"`
+	InProgress      int `json:"in_progress"`
+	ClaimedByOthers int `json:"claimed_by_others"`
+}
+
+// IDs returns the frontier's issue IDs in group order.
+func (f Frontier) IDs() []string {
+	ids := make([]string, 0, f.Total)
+	for _, g := range f.Groups {
+		for _, issue := range g.Issues {
+			ids = append(ids, issue.ID)
+		}
+	}
+	return ids
+}
+
+// ComputeFrontier finds the issues the caller can start now. Groups run
+// from P0 down; within a group, issues that unblock the most come first,
+// then by ID.
+func ComputeFrontier(issues []model.Issue, opts FrontierOptions) Frontier {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	unblocks := make(map[string]int)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() {
+				unblocks[dep.DependsOnID]++
+			}
+		}
+	}
+
+	f := Frontier{Groups: []FrontierGroup{}}
+	byPriority := make(map[int][]FrontierIssue)
+	for i := range issues {
+		issue := &issues[i]
+		switch issue.Status {
+		case model.StatusOpen:
+		case model.StatusInProgress:
+			f.InProgress++
+			continue
+		case model.StatusBlocked:
+			f.Blocked++
+			continue
+		default:
+			continue
+		}
+		if hasOpenBlocker(issue, byID) {
+			f.Blocked++
+			continue
+		}
+		if claimedByOther(issue, opts) {
+			f.ClaimedByOthers++
+			continue
+		}
+		byPriority[issue.Priority] = append(byPriority[issue.Priority], FrontierIssue{
+			ID:        issue.ID,
+			Title:     issue.Title,
+			IssueType: string(issue.IssueType),
+			Priority:  issue.Priority,
+			Labels:    issue.Labels,
+			Assignee:  issue.Assignee,
+			Unblocks:  unblocks[issue.ID],
+		})
+	}
+
+	priorities := make([]int, 0, len(byPriority))
+	for p := range byPriority {
+		priorities = append(priorities, p)
+	}
+	sort.Ints(priorities)
+	for _, p := range priorities {
+		group := byPriority[p]
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].Unblocks != group[j].Unblocks {
+				return group[i].Unblocks > group[j].Unblocks
+			}
+			return group[i].ID < group[j].ID
+		})
+		f.Groups = append(f.Groups, FrontierGroup{Priority: p, Count: len(group), Issues: group})
+		f.Total += len(group)
+	}
+	return f
+}
+
+func claimedByOther(issue *model.Issue, opts FrontierOptions) bool {
+	if issue.Assignee != "" && (opts.Agent == "" || issue.Assignee != opts.Agent) {
+		return true
+	}
+	holder, ok := opts.Claims[issue.ID]
+	return ok && (opts.Agent == "" || holder != opts.Agent)
+}
@@ -0,0 +1,52 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeFrontier(t *testing.T) {
+	issues := []model.Issue{
+		assigned("A", "", model.StatusOpen, 1, 0),
+		assigned("B", "", model.StatusOpen, 1, 0, "A"), // blocked by open A
+		assigned("C", "", model.StatusOpen, 1, 0, "D"), // blocker closed
+		assigned("D", "", model.StatusClosed, 0, 0),
+		assigned("E", "", model.StatusInProgress, 0, 0),
+		assigned("F", "bob", model.StatusOpen, 0, 0),
+		assigned("G", "me", model.StatusOpen, 2, 0),
+		assigned("H", "", model.StatusOpen, 0, 0), // claimed by bob below
+		assigned("I", "", model.StatusOpen, 0, 0),
+		assigned("J", "", model.StatusBlocked, 2, 0),
+		assigned("K", "", model.StatusOpen, 1, 0, "A", "I"), // keeps I's unblocks at 1
+	}
+	claims := map[string]string{"H": "bob", "I": "me"}
+
+	f := ComputeFrontier(issues, FrontierOptions{Agent: "me", Claims: claims})
+	if want := []string{"I", "A", "C", "G"}; !reflect.DeepEqual(f.IDs(), want) {
+		t.Fatalf("ids = %v, want %v", f.IDs(), want)
+	}
+	if f.Total != 4 || len(f.Groups) != 3 || f.Groups[1].Count != 2 {
+		t.Errorf("frontier = %+v", f)
+	}
+	if a := f.Groups[1].Issues[0]; a.ID != "A" || a.Unblocks != 2 {
+		t.Errorf("A = %+v, want 2 unblocks", a)
+	}
+	if f.Blocked != 3 || f.InProgress != 1 || f.ClaimedByOthers != 2 {
+		t.Errorf("counts = blocked %d, in progress %d, claimed %d", f.Blocked, f.InProgress, f.ClaimedByOthers)
+	}
+
+	// Without an agent, every assignment and claim belongs to someone else.
+	anon := ComputeFrontier(issues, FrontierOptions{Claims: claims})
+	if want := []string{"A", "C"}; !reflect.DeepEqual(anon.IDs(), want) {
+		t.Errorf("anonymous ids = %v, want %v", anon.IDs(), want)
+	}
+}
+
+func TestComputeFrontierEmpty(t *testing.T) {
+	f := ComputeFrontier(nil, FrontierOptions{})
+	if f.Total != 0 || f.Groups == nil || len(f.IDs()) != 0 {
+		t.Errorf("empty frontier = %+v", f)
+	}
+}
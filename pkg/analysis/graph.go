@@ -9,8 +9,10 @@ import (
 	"math"
 	"sort"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 
 	"gonum.org/v1/gonum/graph"
@@ -62,6 +64,14 @@ type StartupProfile struct {
 // immediately and can be read without synchronization after AnalyzeAsync returns.
 // Phase 2 fields (centrality metrics, cycles) are computed in background and
 // must be accessed via thread-safe accessor methods.
+//
+// Thread safety: one GraphStats may be handed to several callers (the
+// in-memory caches share it), so treat the exported maps and slices as
+// read-only. Every method is safe to call from any goroutine, during or after
+// Phase 2. Phase 2 results are published all at once: a reader sees either
+// none of them or all of them. If the context passed to AnalyzeAsync is
+// canceled, Phase 2 stops early, WaitForPhase2 returns, IsPhase2Ready stays
+// false and the pending metrics report the state "canceled".
 type GraphStats struct {
 	// Phase 1 - Available immediately after AnalyzeAsync returns (read-only after init)
 	OutDegree        map[string]int // Number of dependencies this issue has (edges out)
@@ -78,6 +88,7 @@ type GraphStats struct {
 	mu                sync.RWMutex
 	phase2Ready       bool
 	phase2Done        chan struct{} // Closed when Phase 2 completes
+	phase2Steps       int           // Phase 2 steps finished, for progress display
 	pageRank          map[string]float64
 	betweenness       map[string]float64
 	eigenvector       map[string]float64
@@ -179,13 +190,102 @@ func betweennessReason(cfg AnalysisConfig, isApprox bool) string {
 	return ""
 }
 
-// WaitForPhase2 blocks until Phase 2 computation completes.
+// phase2StepCount is the number of steps Phase2Progress counts: PageRank,
+// betweenness, eigenvector, HITS, critical path, cycles, k-core with
+// articulation points, and slack.
+const phase2StepCount = 8
+
+// Phase2Progress reports how many Phase 2 steps have finished, out of total.
+// Skipped metrics count as finished; done equals total once Phase 2 is ready.
+func (s *GraphStats) Phase2Progress() (done, total int) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.phase2Ready {
+		return phase2StepCount, phase2StepCount
+	}
+	return s.phase2Steps, phase2StepCount
+}
+
+// stepDone records that one more Phase 2 step finished.
+func (s *GraphStats) stepDone() {
+	s.mu.Lock()
+	s.phase2Steps++
+	s.mu.Unlock()
+}
+
+// WaitForPhase2 blocks until Phase 2 computation completes or is canceled.
 func (s *GraphStats) WaitForPhase2() {
 	if s.phase2Done != nil {
 		<-s.phase2Done
 	}
 }
 
+// WaitForPhase2Context is WaitForPhase2 for callers that may stop waiting:
+// it returns ctx's error if ctx is done first. Phase 2 keeps running.
+func (s *GraphStats) WaitForPhase2Context(ctx context.Context) error {
+	if s.phase2Done == nil {
+		return nil
+	}
+	select {
+	case <-s.phase2Done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// markCanceled records that Phase 2 stopped before finishing.
+func (s *GraphStats) markCanceled() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, e := range []*statusEntry{
+		&s.status.PageRank, &s.status.Betweenness, &s.status.Eigenvector,
+		&s.status.HITS, &s.status.Critical, &s.status.Cycles,
+		&s.status.KCore, &s.status.Articulation, &s.status.Slack,
+	} {
+		if e.State == "pending" {
+			*e = statusEntry{State: "canceled", Reason: "analysis canceled"}
+		}
+	}
+}
+
+// snapshot returns a copy of s, for the synchronous Analyze variants. The
+// copy shares s's maps, which are never written once published.
+func (s *GraphStats) snapshot() GraphStats {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return GraphStats{
+		OutDegree:         s.OutDegree,
+		InDegree:          s.InDegree,
+		TopologicalOrder:  s.TopologicalOrder,
+		Density:           s.Density,
+		NodeCount:         s.NodeCount,
+		EdgeCount:         s.EdgeCount,
+		Config:            s.Config,
+		pageRank:          s.pageRank,
+		betweenness:       s.betweenness,
+		eigenvector:       s.eigenvector,
+		hubs:              s.hubs,
+		authorities:       s.authorities,
+		criticalPathScore: s.criticalPathScore,
+		pageRankRank:      s.pageRankRank,
+		betweennessRank:   s.betweennessRank,
+		eigenvectorRank:   s.eigenvectorRank,
+		hubsRank:          s.hubsRank,
+		authoritiesRank:   s.authoritiesRank,
+		criticalPathRank:  s.criticalPathRank,
+		inDegreeRank:      s.inDegreeRank,
+		outDegreeRank:     s.outDegreeRank,
+		coreNumber:        s.coreNumber,
+		articulation:      s.articulation,
+		slack:             s.slack,
+		cycles:            s.cycles,
+		phase2Ready:       s.phase2Ready,
+		phase2Steps:       s.phase2Steps,
+		status:            s.status,
+	}
+}
+
 // GetPageRankScore returns the PageRank score for a single issue.
 // Returns 0 if Phase 2 is not yet complete or if the issue is not found.
 func (s *GraphStats) GetPageRankScore(id string) float64 {
@@ -951,19 +1051,29 @@ func pruneIncrementalGraphStatsCacheLocked(now time.Time) {
 	}
 }
 
-// Analyzer encapsulates the graph logic
+// Analyzer encapsulates the graph logic.
+//
+// Thread safety: the graph is built by NewAnalyzer and never modified, so
+// any number of goroutines may analyze with one Analyzer at once, and
+// SetConfig may race with them (an analysis uses the config current when it
+// starts).
 type Analyzer struct {
 	g        *simple.DirectedGraph
 	idToNode map[string]int64
 	nodeToID map[int64]string
 	issueMap map[string]model.Issue
-	config   *AnalysisConfig // Optional custom config, nil means use size-based defaults
+	config   atomic.Pointer[AnalysisConfig] // Optional custom config, nil means use size-based defaults
 }
 
 // SetConfig sets a custom analysis configuration.
 // Pass nil to use size-based automatic configuration.
 func (a *Analyzer) SetConfig(config *AnalysisConfig) {
-	a.config = config
+	if config == nil {
+		a.config.Store(nil)
+		return
+	}
+	c := *config
+	a.config.Store(&c)
 }
 
 func (a *Analyzer) graphStructureHash() string {
@@ -1087,15 +1197,16 @@ func NewAnalyzer(issues []model.Issue) *Analyzer {
 // If SetConfig was called, uses that config. Otherwise uses ConfigForSize() to
 // automatically select appropriate algorithms based on graph size.
 func (a *Analyzer) AnalyzeAsync(ctx context.Context) *GraphStats {
-	var config AnalysisConfig
-	if a.config != nil {
-		config = *a.config
-	} else {
-		nodeCount := len(a.issueMap)
-		edgeCount := a.g.Edges().Len()
-		config = ConfigForSize(nodeCount, edgeCount)
+	return a.AnalyzeAsyncWithConfig(ctx, a.Config())
+}
+
+// Config returns the configuration AnalyzeAsync uses: the one passed to
+// SetConfig, or ConfigForSize for this graph.
+func (a *Analyzer) Config() AnalysisConfig {
+	if c := a.config.Load(); c != nil {
+		return *c
 	}
-	return a.AnalyzeAsyncWithConfig(ctx, config)
+	return ConfigForSize(len(a.issueMap), a.g.Edges().Len())
 }
 
 // AnalyzeAsyncWithConfig performs graph analysis with a custom configuration.
@@ -1106,11 +1217,13 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 
 	configHash := ComputeConfigHash(&config)
 	incCacheKey := ""
-	if !robotDiskCacheEnabled() {
+	if !robotDiskCacheEnabled() && !cacheBypass.Load() {
 		incCacheKey = a.graphStructureHash() + "|" + configHash
 		if cached, ok := getIncrementalGraphStatsCache(incCacheKey); ok {
+			metrics.GraphCache.Hit()
 			return cached
 		}
+		metrics.GraphCache.Miss()
 	}
 
 	var robotCacheKey, dataHash string
@@ -1123,8 +1236,11 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 		robotCacheKey = dataHash + "|" + configHash
 
 		if cached, ok := getRobotDiskCachedStats(robotCacheKey); ok {
+			metrics.DiskCache.Hit()
+			rememberRobotStats(dataHash, cached)
 			return cached
 		}
+		metrics.DiskCache.Miss()
 	}
 
 	stats := &GraphStats{
@@ -1177,12 +1293,16 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 	// Phase 1: Fast metrics (degree centrality, topo sort, density)
 	a.computePhase1(stats)
 
-	if incCacheKey != "" {
+	// Share the stats while Phase 2 runs only if nothing can cancel it;
+	// otherwise another caller could be handed metrics that never arrive.
+	// Cancelable runs are cached once Phase 2 has finished.
+	if incCacheKey != "" && ctx.Done() == nil {
 		putIncrementalGraphStatsCache(incCacheKey, stats)
+		incCacheKey = ""
 	}
 
 	// Phase 2: Expensive metrics in background goroutine
-	go a.computePhase2(ctx, stats, config, robotCacheKey, dataHash, configHash)
+	go a.computePhase2(ctx, stats, config, incCacheKey, robotCacheKey, dataHash, configHash)
 
 	return stats
 }
@@ -1192,71 +1312,25 @@ func (a *Analyzer) AnalyzeAsyncWithConfig(ctx context.Context, config AnalysisCo
 func (a *Analyzer) Analyze() GraphStats {
 	stats := a.AnalyzeAsync(context.Background())
 	stats.WaitForPhase2()
-	// Return a copy with public fields populated for backward compatibility
-	return GraphStats{
-		OutDegree:         stats.OutDegree,
-		InDegree:          stats.InDegree,
-		TopologicalOrder:  stats.TopologicalOrder,
-		Density:           stats.Density,
-		NodeCount:         stats.NodeCount,
-		EdgeCount:         stats.EdgeCount,
-		Config:            stats.Config,
-		pageRank:          stats.pageRank,
-		betweenness:       stats.betweenness,
-		eigenvector:       stats.eigenvector,
-		hubs:              stats.hubs,
-		authorities:       stats.authorities,
-		criticalPathScore: stats.criticalPathScore,
-		pageRankRank:      stats.pageRankRank,
-		betweennessRank:   stats.betweennessRank,
-		eigenvectorRank:   stats.eigenvectorRank,
-		hubsRank:          stats.hubsRank,
-		authoritiesRank:   stats.authoritiesRank,
-		criticalPathRank:  stats.criticalPathRank,
-		inDegreeRank:      stats.inDegreeRank,
-		outDegreeRank:     stats.outDegreeRank,
-		coreNumber:        stats.coreNumber,
-		articulation:      stats.articulation,
-		slack:             stats.slack,
-		cycles:            stats.cycles,
-		phase2Ready:       true,
-		status:            stats.status,
-	}
+	return stats.snapshot()
 }
 
 // AnalyzeWithConfig performs synchronous graph analysis with a custom configuration.
 func (a *Analyzer) AnalyzeWithConfig(config AnalysisConfig) GraphStats {
 	stats := a.AnalyzeAsyncWithConfig(context.Background(), config)
 	stats.WaitForPhase2()
-	return GraphStats{
-		OutDegree:         stats.OutDegree,
-		InDegree:          stats.InDegree,
-		TopologicalOrder:  stats.TopologicalOrder,
-		Density:           stats.Density,
-		NodeCount:         stats.NodeCount,
-		EdgeCount:         stats.EdgeCount,
-		Config:            stats.Config,
-		pageRank:          stats.pageRank,
-		betweenness:       stats.betweenness,
-		eigenvector:       stats.eigenvector,
-		hubs:              stats.hubs,
-		authorities:       stats.authorities,
-		criticalPathScore: stats.criticalPathScore,
-		pageRankRank:      stats.pageRankRank,
-		betweennessRank:   stats.betweennessRank,
-		eigenvectorRank:   stats.eigenvectorRank,
-		hubsRank:          stats.hubsRank,
-		authoritiesRank:   stats.authoritiesRank,
-		criticalPathRank:  stats.criticalPathRank,
-		inDegreeRank:      stats.inDegreeRank,
-		outDegreeRank:     stats.outDegreeRank,
-		coreNumber:        stats.coreNumber,
-		articulation:      stats.articulation,
-		slack:             stats.slack,
-		cycles:            stats.cycles,
-		phase2Ready:       true,
-		status:            stats.status,
-	}
+	return stats.snapshot()
+}
+
+// stableTopoSort is topo.Sort with ties broken by issue ID, so the
+// topological order (which robot output exposes) does not depend on map
+// iteration order.
+func (a *Analyzer) stableTopoSort() ([]graph.Node, error) {
+	return topo.SortStabilized(a.g, func(nodes []graph.Node) {
+		sort.Slice(nodes, func(i, j int) bool {
+			return a.nodeToID[nodes[i].ID()] < a.nodeToID[nodes[j].ID()]
+		})
+	})
 }
 
 // AnalyzeWithProfile performs synchronous graph analysis and returns detailed timing profile.
@@ -1333,7 +1407,7 @@ func (a *Analyzer) computePhase1WithProfile(stats *GraphStats, profile *StartupP
 
 	// Topological Sort
 	topoStart := time.Now()
-	sorted, err := topo.Sort(a.g)
+	sorted, err := a.stableTopoSort()
 	if err == nil {
 		for i := len(sorted) - 1; i >= 0; i-- {
 			stats.TopologicalOrder = append(stats.TopologicalOrder, a.nodeToID[sorted[i].ID()])
@@ -1370,13 +1444,22 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 	if ctx.Err() == nil && config.ComputePageRank {
 		prStart := time.Now()
 		prDone := make(chan map[int64]float64, 1)
+		var seeds map[int64]bool
+		if len(config.PageRankFocus) > 0 {
+			seeds = make(map[int64]bool, len(config.PageRankFocus))
+			for _, id := range config.PageRankFocus {
+				if node, ok := a.idToNode[id]; ok {
+					seeds[node] = true
+				}
+			}
+		}
 		go func() {
 			defer func() {
 				if r := recover(); r != nil {
 					// Panic -> implicitly causes timeout in parent
 				}
 			}()
-			prDone <- computePageRank(a.g, 0.85, 1e-6)
+			prDone <- computePersonalizedPageRank(a.g, 0.85, 1e-6, seeds)
 		}()
 
 		timer := time.NewTimer(config.PageRankTimeout)
@@ -1401,6 +1484,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		}
 		profile.PageRank = time.Since(prStart)
 	}
+	stats.stepDone()
 
 	// Betweenness
 	if ctx.Err() == nil && config.ComputeBetweenness {
@@ -1446,6 +1530,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		}
 		profile.Betweenness = time.Since(bwStart)
 	}
+	stats.stepDone()
 
 	// Eigenvector
 	if ctx.Err() == nil && config.ComputeEigenvector {
@@ -1455,6 +1540,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		}
 		profile.Eigenvector = time.Since(evStart)
 	}
+	stats.stepDone()
 
 	// HITS
 	if ctx.Err() == nil && config.ComputeHITS && a.g.Edges().Len() > 0 {
@@ -1485,6 +1571,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		}
 		profile.HITS = time.Since(hitsStart)
 	}
+	stats.stepDone()
 
 	// Critical Path
 	if ctx.Err() == nil && config.ComputeCriticalPath {
@@ -1495,6 +1582,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		}
 		profile.CriticalPath = time.Since(cpStart)
 	}
+	stats.stepDone()
 
 	// Cycles
 	if ctx.Err() == nil && config.ComputeCycles {
@@ -1558,6 +1646,7 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		}
 		profile.Cycles = time.Since(cyclesStart)
 	}
+	stats.stepDone()
 
 	// Check cancellation before advanced signals
 	if ctx.Err() != nil {
@@ -1572,12 +1661,14 @@ func (a *Analyzer) computePhase2WithProfile(ctx context.Context, stats *GraphSta
 		profile.KCore = time.Since(kcoreStart)
 		profile.Articulation = 0 // Computed together with k-core
 	}
+	stats.stepDone()
 
 	if config.ComputeSlack {
 		slackStart := time.Now()
 		localSlack = a.computeSlack(stats.TopologicalOrder)
 		profile.Slack = time.Since(slackStart)
 	}
+	stats.stepDone()
 
 	// Compute ranks (background optimization)
 	localPageRankRank := computeFloatRanks(localPageRank)
@@ -1664,7 +1755,7 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 	// Topological Sort (execution order)
 	// Note: In our graph model, edge u -> v means u depends on v, so we reverse
 	// topo.Sort's output to get dependencies-first ordering.
-	sorted, err := topo.Sort(a.g)
+	sorted, err := a.stableTopoSort()
 	if err == nil {
 		for i := len(sorted) - 1; i >= 0; i-- {
 			stats.TopologicalOrder = append(stats.TopologicalOrder, a.nodeToID[sorted[i].ID()])
@@ -1686,7 +1777,7 @@ func (a *Analyzer) computePhase1(stats *GraphStats) {
 // computePhase2 calculates expensive metrics in background.
 // Computes to local variables first, then atomically assigns under lock.
 // Respects the config to skip expensive algorithms for large graphs.
-func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config AnalysisConfig, cacheKey, dataHash, configHash string) {
+func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config AnalysisConfig, incCacheKey, cacheKey, dataHash, configHash string) {
 	defer close(stats.phase2Done)
 
 	// Recover from panics to prevent crashing the entire application
@@ -1719,8 +1810,16 @@ func (a *Analyzer) computePhase2(ctx context.Context, stats *GraphStats, config
 	dummyProfile := &StartupProfile{}
 	a.computePhase2WithProfile(ctx, stats, config, dummyProfile)
 
+	if !stats.IsPhase2Ready() {
+		stats.markCanceled()
+		return
+	}
+	if incCacheKey != "" {
+		putIncrementalGraphStatsCache(incCacheKey, stats)
+	}
 	if cacheKey != "" {
 		putRobotDiskCachedStats(cacheKey, dataHash, configHash, stats)
+		rememberRobotStats(dataHash, stats)
 	}
 }
 
@@ -2314,6 +2413,14 @@ func (a *Analyzer) countBlockedBy(issueID string) int {
 // It uses a deterministic power iteration with damping factor damp and terminates
 // when the L2 norm of the delta is below tol (or after a hard iteration cap).
 func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
+	return computePersonalizedPageRank(g, damp, tol, nil)
+}
+
+// computePersonalizedPageRank is computePageRank with the random surfer
+// teleporting only to the seed nodes (and dangling mass redistributed to
+// them), so rank concentrates on what the seeds depend on. Seeds missing
+// from g are ignored; with no usable seeds it is plain PageRank.
+func computePersonalizedPageRank(g graph.Directed, damp, tol float64, seeds map[int64]bool) map[int64]float64 {
 	nodes := graph.NodesOf(g.Nodes())
 	sort.Slice(nodes, func(i, j int) bool { return nodes[i].ID() < nodes[j].ID() })
 	if len(nodes) == 0 {
@@ -2377,11 +2484,26 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 	}
 	next := make([]float64, len(nodes))
 
-	base := (1 - damp) / n
+	teleport := make([]float64, len(nodes))
+	seeded := 0
+	for i, node := range nodes {
+		if seeds[node.ID()] {
+			teleport[i] = 1
+			seeded++
+		}
+	}
+	for i := range teleport {
+		if seeded == 0 {
+			teleport[i] = uniform
+		} else {
+			teleport[i] /= float64(seeded)
+		}
+	}
+
 	const maxIterations = 1000
 	for iter := 0; iter < maxIterations; iter++ {
 		for i := range next {
-			next[i] = base
+			next[i] = (1 - damp) * teleport[i]
 		}
 
 		dangling := 0.0
@@ -2397,9 +2519,8 @@ func computePageRank(g graph.Directed, damp, tol float64) map[int64]float64 {
 			}
 		}
 		if dangling != 0 {
-			add := damp * dangling / n
 			for i := range next {
-				next[i] += add
+				next[i] += damp * dangling * teleport[i]
 			}
 		}
 
@@ -0,0 +1,136 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultHealthHistoryRevisions is how many beads file revisions a health
+// history covers by default.
+const DefaultHealthHistoryRevisions = 20
+
+// HealthScore is a composite health score for one snapshot of the blocking
+// graph, with the measures it is built from.
+//
+// Score is 0-100, a weighted sum of four parts that are each 1 when healthy:
+//   - cycles (35%): 1/(1+CycleCount)
+//   - orphans (25%): 1-OrphanRatio
+//   - depth (20%): 1/(1+AvgDepth/5), long chains serialize work
+//   - clutter (20%): 1 up to 1.5 dependencies per issue, then 1.5/EdgesPerIssue
+type HealthScore struct {
+	Score         float64 `json:"score"`
+	Issues        int     `json:"issues"` // Not tombstoned
+	Edges         int     `json:"edges"`  // Blocking dependencies between them
+	Density       float64 `json:"density"`
+	EdgesPerIssue float64 `json:"edges_per_issue"`
+	AvgDepth      float64 `json:"avg_depth"`    // Mean longest blocking chain below an issue
+	CycleCount    int     `json:"cycle_count"`  // Strongly connected components with 2+ issues
+	OrphanRatio   float64 `json:"orphan_ratio"` // Open issues with no blocking links at all
+}
+
+// ComputeHealthScore scores the blocking graph of issues.
+func ComputeHealthScore(issues []model.Issue) HealthScore {
+	var h HealthScore
+	shapes := ComputeDependencyShapes(issues)
+	h.Issues = len(shapes)
+	if h.Issues == 0 {
+		h.Score = 100
+		return h
+	}
+
+	depthSum, open, orphans := 0, 0, 0
+	for i := range issues {
+		shape, ok := shapes[issues[i].ID]
+		if !ok {
+			continue
+		}
+		h.Edges += shape.Dependencies
+		depthSum += shape.Depth
+		if !issues[i].Status.IsClosed() {
+			open++
+			if shape.Dependencies == 0 && shape.Dependents == 0 {
+				orphans++
+			}
+		}
+	}
+	n := float64(h.Issues)
+	if h.Issues > 1 {
+		h.Density = float64(h.Edges) / (n * (n - 1))
+	}
+	h.EdgesPerIssue = float64(h.Edges) / n
+	h.AvgDepth = float64(depthSum) / n
+	if open > 0 {
+		h.OrphanRatio = float64(orphans) / float64(open)
+	}
+	h.CycleCount = NewAnalyzer(issues).Condense().CyclicCount
+
+	clutter := 1.0
+	if h.EdgesPerIssue > 1.5 {
+		clutter = 1.5 / h.EdgesPerIssue
+	}
+	score := 0.35/(1+float64(h.CycleCount)) +
+		0.25*(1-h.OrphanRatio) +
+		0.20/(1+h.AvgDepth/5) +
+		0.20*clutter
+	h.Score = math.Round(score*1000) / 10
+	return h
+}
+
+// HealthRevision identifies one revision of the beads file in git.
+type HealthRevision struct {
+	SHA       string
+	Timestamp time.Time
+	Message   string
+}
+
+// HealthPoint is the graph health at one revision.
+type HealthPoint struct {
+	Revision  string    `json:"revision"`
+	Timestamp time.Time `json:"timestamp"`
+	Message   string    `json:"message,omitempty"`
+	HealthScore
+}
+
+// HealthHistory is graph health over past revisions of the beads file.
+type HealthHistory struct {
+	Points      []HealthPoint `json:"points"`       // Oldest first
+	ScoreChange float64       `json:"score_change"` // Last point minus first
+	Skipped     int           `json:"skipped"`      // Revisions that failed to load
+}
+
+// ComputeHealthHistory scores the graph at each revision, loading the issues
+// with load (typically a git loader's LoadAt). Revisions that fail to load
+// are skipped and counted; if all of them fail, the last error is returned.
+func ComputeHealthHistory(revisions []HealthRevision, load func(sha string) ([]model.Issue, error)) (HealthHistory, error) {
+	h := HealthHistory{Points: []HealthPoint{}}
+	var lastErr error
+	for _, rev := range revisions {
+		issues, err := load(rev.SHA)
+		if err != nil {
+			h.Skipped++
+			lastErr = err
+			continue
+		}
+		h.Points = append(h.Points, HealthPoint{
+			Revision:    rev.SHA,
+			Timestamp:   rev.Timestamp,
+			Message:     rev.Message,
+			HealthScore: ComputeHealthScore(issues),
+		})
+	}
+	if len(h.Points) == 0 && lastErr != nil {
+		return h, fmt.Errorf("no revision of the beads file could be loaded: %w", lastErr)
+	}
+	sort.SliceStable(h.Points, func(i, j int) bool {
+		return h.Points[i].Timestamp.Before(h.Points[j].Timestamp)
+	})
+	if len(h.Points) > 1 {
+		change := h.Points[len(h.Points)-1].Score - h.Points[0].Score
+		h.ScoreChange = math.Round(change*10) / 10
+	}
+	return h, nil
+}
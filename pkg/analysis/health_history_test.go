@@ -0,0 +1,81 @@
+package analysis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeHealthScore(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	// A -> B -> C chain, D <-> E cycle, F open orphan, G closed orphan
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Dependencies: blocks("B")},
+		{ID: "B", Status: model.StatusOpen, Dependencies: blocks("C")},
+		{ID: "C", Status: model.StatusOpen},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blocks("E")},
+		{ID: "E", Status: model.StatusOpen, Dependencies: blocks("D")},
+		{ID: "F", Status: model.StatusOpen},
+		{ID: "G", Status: model.StatusClosed},
+	}
+
+	h := ComputeHealthScore(issues)
+	if h.Issues != 7 || h.Edges != 4 || h.CycleCount != 1 {
+		t.Errorf("issues=%d edges=%d cycles=%d", h.Issues, h.Edges, h.CycleCount)
+	}
+	if want := 1.0 / 6; h.OrphanRatio != want {
+		t.Errorf("orphan ratio = %v, want %v", h.OrphanRatio, want)
+	}
+	if h.Score <= 0 || h.Score >= 100 {
+		t.Errorf("score = %v", h.Score)
+	}
+
+	// Breaking the cycle improves the score
+	issues[4].Dependencies = nil
+	if better := ComputeHealthScore(issues); better.CycleCount != 0 || better.Score <= h.Score {
+		t.Errorf("expected a higher score without the cycle: %v vs %v", better.Score, h.Score)
+	}
+
+	if empty := ComputeHealthScore(nil); empty.Score != 100 {
+		t.Errorf("empty graph score = %v", empty.Score)
+	}
+}
+
+func TestComputeHealthHistory(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := map[string][]model.Issue{
+		"new": {{ID: "A", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}}, {ID: "B", Status: model.StatusOpen}},
+		"old": {{ID: "A", Status: model.StatusOpen}, {ID: "B", Status: model.StatusOpen}},
+	}
+	load := func(sha string) ([]model.Issue, error) {
+		if issues, ok := snapshots[sha]; ok {
+			return issues, nil
+		}
+		return nil, errors.New("not in git")
+	}
+	// git log order: newest first
+	revs := []HealthRevision{
+		{SHA: "new", Timestamp: now},
+		{SHA: "broken", Timestamp: now.Add(-time.Hour)},
+		{SHA: "old", Timestamp: now.Add(-2 * time.Hour)},
+	}
+
+	h, err := ComputeHealthHistory(revs, load)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.Points) != 2 || h.Points[0].Revision != "old" || h.Points[1].Revision != "new" || h.Skipped != 1 {
+		t.Fatalf("points = %+v skipped=%d", h.Points, h.Skipped)
+	}
+	if h.ScoreChange <= 0 {
+		t.Errorf("linking the orphans should raise the score, change = %v", h.ScoreChange)
+	}
+
+	if _, err := ComputeHealthHistory(revs[1:2], load); err == nil {
+		t.Error("expected an error when no revision loads")
+	}
+}
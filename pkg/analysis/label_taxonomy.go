@@ -0,0 +1,403 @@
+package analysis
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Label taxonomy thresholds.
+const (
+	LabelPairMinCount      = 2   // co-occurrences before a pair is reported
+	LabelClusterMinJaccard = 0.3 // pair overlap that links two labels into a cluster
+	LabelMaxPairs          = 20  // pairs reported, most frequent first
+	LabelCycleMinSamples   = 3   // closed issues a label needs for a cycle-time verdict
+	LabelSlowCycleRatio    = 1.5 // label median vs overall median that counts as slow
+	labelSimilarMinLength  = 5   // shorter labels are too easy to confuse by one edit
+)
+
+// Label variant reasons.
+const (
+	LabelVariantSpelling = "spelling" // same once case, separators, and plurals are ignored
+	LabelVariantSimilar  = "similar"  // one edit apart
+)
+
+// LabelUsage is how often one label is used.
+type LabelUsage struct {
+	Label  string  `json:"label"`
+	Count  int     `json:"count"`
+	Open   int     `json:"open"`
+	Closed int     `json:"closed"`
+	Share  float64 `json:"share"` // fraction of all issues carrying the label
+}
+
+// LabelPair is two labels seen on the same issues.
+type LabelPair struct {
+	A       string  `json:"a"`
+	B       string  `json:"b"`
+	Count   int     `json:"count"`
+	Jaccard float64 `json:"jaccard"` // issues with both / issues with either
+}
+
+// LabelCluster is a group of labels that travel together.
+type LabelCluster struct {
+	Labels []string `json:"labels"` // most used first
+	Issues int      `json:"issues"` // issues carrying any of them
+}
+
+// LabelCycleTime is a label whose issues take notably long to close.
+type LabelCycleTime struct {
+	Label      string  `json:"label"`
+	Samples    int     `json:"samples"`
+	MedianDays float64 `json:"median_days"`
+	Ratio      float64 `json:"ratio"` // MedianDays over the overall median
+}
+
+// LabelVariant is a set of labels that probably mean the same thing.
+type LabelVariant struct {
+	Canonical string   `json:"canonical"` // the most used spelling
+	Variants  []string `json:"variants"`
+	Reason    string   `json:"reason"`
+	Issues    int      `json:"issues"`
+}
+
+// LabelTaxonomy is the label usage report behind --robot-labels.
+type LabelTaxonomy struct {
+	TotalLabels     int              `json:"total_labels"`
+	LabeledIssues   int              `json:"labeled_issues"`
+	UnlabeledIssues int              `json:"unlabeled_issues"`
+	Singletons      []string         `json:"singletons"` // labels used exactly once
+	Usage           []LabelUsage     `json:"usage"`
+	Pairs           []LabelPair      `json:"pairs"`
+	Clusters        []LabelCluster   `json:"clusters"`
+	MedianCycleDays float64          `json:"median_cycle_days"` // over all closed issues
+	SlowLabels      []LabelCycleTime `json:"slow_labels"`
+	Variants        []LabelVariant   `json:"variants"`
+}
+
+// ComputeLabelTaxonomy analyzes label usage across non-tombstoned issues:
+// frequency, co-occurrence, cycle time by label, and probable typo
+// variants.
+func ComputeLabelTaxonomy(issues []model.Issue) LabelTaxonomy {
+	tax := LabelTaxonomy{
+		Singletons: []string{},
+		Usage:      []LabelUsage{},
+		Pairs:      []LabelPair{},
+		Clusters:   []LabelCluster{},
+		SlowLabels: []LabelCycleTime{},
+		Variants:   []LabelVariant{},
+	}
+	usage := make(map[string]*LabelUsage)
+	pairs := make(map[[2]string]int)
+	cycleDays := make(map[string][]float64)
+	var allCycleDays []float64
+	var labelSets [][]string
+	total := 0
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		total++
+		labels := uniqueLabels(issue.Labels)
+		if len(labels) == 0 {
+			tax.UnlabeledIssues++
+			continue
+		}
+		tax.LabeledIssues++
+		labelSets = append(labelSets, labels)
+
+		days := -1.0
+		if issue.Status.IsClosed() && issue.ClosedAt != nil && !issue.CreatedAt.IsZero() {
+			days = issue.ClosedAt.Sub(issue.CreatedAt).Hours() / 24
+			allCycleDays = append(allCycleDays, days)
+		}
+		for j, l := range labels {
+			u := usage[l]
+			if u == nil {
+				u = &LabelUsage{Label: l}
+				usage[l] = u
+			}
+			u.Count++
+			if issue.Status.IsClosed() {
+				u.Closed++
+			} else {
+				u.Open++
+			}
+			if days >= 0 {
+				cycleDays[l] = append(cycleDays[l], days)
+			}
+			for _, other := range labels[j+1:] {
+				pairs[[2]string{l, other}]++ // labels are sorted, so l < other
+			}
+		}
+	}
+
+	for _, u := range usage {
+		if total > 0 {
+			u.Share = roundTo(float64(u.Count)/float64(total), 3)
+		}
+		tax.Usage = append(tax.Usage, *u)
+		if u.Count == 1 {
+			tax.Singletons = append(tax.Singletons, u.Label)
+		}
+	}
+	sort.Slice(tax.Usage, func(i, j int) bool {
+		if tax.Usage[i].Count != tax.Usage[j].Count {
+			return tax.Usage[i].Count > tax.Usage[j].Count
+		}
+		return tax.Usage[i].Label < tax.Usage[j].Label
+	})
+	sort.Strings(tax.Singletons)
+	tax.TotalLabels = len(tax.Usage)
+
+	var linked []LabelPair
+	for key, n := range pairs {
+		if n < LabelPairMinCount {
+			continue
+		}
+		a, b := usage[key[0]].Count, usage[key[1]].Count
+		p := LabelPair{A: key[0], B: key[1], Count: n, Jaccard: roundTo(float64(n)/float64(a+b-n), 3)}
+		tax.Pairs = append(tax.Pairs, p)
+		if p.Jaccard >= LabelClusterMinJaccard {
+			linked = append(linked, p)
+		}
+	}
+	sort.Slice(tax.Pairs, func(i, j int) bool {
+		pi, pj := tax.Pairs[i], tax.Pairs[j]
+		if pi.Count != pj.Count {
+			return pi.Count > pj.Count
+		}
+		if pi.Jaccard != pj.Jaccard {
+			return pi.Jaccard > pj.Jaccard
+		}
+		return pi.A+"\x00"+pi.B < pj.A+"\x00"+pj.B
+	})
+	if len(tax.Pairs) > LabelMaxPairs {
+		tax.Pairs = tax.Pairs[:LabelMaxPairs]
+	}
+	tax.Clusters = labelClusters(linked, usage, labelSets)
+
+	overall := medianFloat(allCycleDays)
+	tax.MedianCycleDays = roundTo(overall, 1)
+	if overall > 0 {
+		for l, days := range cycleDays {
+			if len(days) < LabelCycleMinSamples {
+				continue
+			}
+			median := medianFloat(days)
+			ratio := median / overall
+			if ratio >= LabelSlowCycleRatio {
+				tax.SlowLabels = append(tax.SlowLabels, LabelCycleTime{
+					Label: l, Samples: len(days), MedianDays: roundTo(median, 1), Ratio: roundTo(ratio, 2),
+				})
+			}
+		}
+		sort.Slice(tax.SlowLabels, func(i, j int) bool {
+			if tax.SlowLabels[i].Ratio != tax.SlowLabels[j].Ratio {
+				return tax.SlowLabels[i].Ratio > tax.SlowLabels[j].Ratio
+			}
+			return tax.SlowLabels[i].Label < tax.SlowLabels[j].Label
+		})
+	}
+
+	tax.Variants = labelVariants(tax.Usage)
+	return tax
+}
+
+// uniqueLabels returns labels sorted, without blanks or duplicates.
+func uniqueLabels(labels []string) []string {
+	out := make([]string, 0, len(labels))
+	for _, l := range labels {
+		if l != "" {
+			out = append(out, l)
+		}
+	}
+	sort.Strings(out)
+	n := 0
+	for i, l := range out {
+		if i == 0 || l != out[n-1] {
+			out[n] = l
+			n++
+		}
+	}
+	return out[:n]
+}
+
+// labelClusters joins labels linked by strong pairs into connected
+// components, largest first.
+func labelClusters(linked []LabelPair, usage map[string]*LabelUsage, labelSets [][]string) []LabelCluster {
+	parent := make(map[string]string)
+	var find func(string) string
+	find = func(l string) string {
+		if p, ok := parent[l]; ok && p != l {
+			parent[l] = find(p)
+			return parent[l]
+		}
+		parent[l] = l
+		return l
+	}
+	for _, p := range linked {
+		parent[find(p.A)] = find(p.B)
+	}
+	members := make(map[string][]string)
+	for l := range parent {
+		root := find(l)
+		members[root] = append(members[root], l)
+	}
+
+	clusters := []LabelCluster{}
+	for _, labels := range members {
+		if len(labels) < 2 {
+			continue
+		}
+		sort.Slice(labels, func(i, j int) bool {
+			if usage[labels[i]].Count != usage[labels[j]].Count {
+				return usage[labels[i]].Count > usage[labels[j]].Count
+			}
+			return labels[i] < labels[j]
+		})
+		in := make(map[string]bool, len(labels))
+		for _, l := range labels {
+			in[l] = true
+		}
+		c := LabelCluster{Labels: labels}
+		for _, set := range labelSets {
+			for _, l := range set {
+				if in[l] {
+					c.Issues++
+					break
+				}
+			}
+		}
+		clusters = append(clusters, c)
+	}
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Labels) != len(clusters[j].Labels) {
+			return len(clusters[i].Labels) > len(clusters[j].Labels)
+		}
+		if clusters[i].Issues != clusters[j].Issues {
+			return clusters[i].Issues > clusters[j].Issues
+		}
+		return clusters[i].Labels[0] < clusters[j].Labels[0]
+	})
+	return clusters
+}
+
+// labelVariants groups labels that normalize to the same spelling, then
+// joins groups whose spellings are one edit apart. usage must be sorted
+// most used first; the first label of a group becomes its canonical form.
+func labelVariants(usage []LabelUsage) []LabelVariant {
+	var keys []string
+	groups := make(map[string][]LabelUsage)
+	for _, u := range usage {
+		k := normalizeLabel(u.Label)
+		if _, ok := groups[k]; !ok {
+			keys = append(keys, k)
+		}
+		groups[k] = append(groups[k], u)
+	}
+
+	merged := make(map[string]bool)
+	variants := []LabelVariant{}
+	for i, k := range keys {
+		if merged[k] {
+			continue
+		}
+		members := groups[k]
+		reason := LabelVariantSpelling
+		for _, other := range keys[i+1:] {
+			if !merged[other] && similarLabels(k, other) {
+				members = append(members, groups[other]...)
+				merged[other] = true
+				reason = LabelVariantSimilar
+			}
+		}
+		if len(members) < 2 {
+			continue
+		}
+		sort.SliceStable(members, func(a, b int) bool { return members[a].Count > members[b].Count })
+		v := LabelVariant{Canonical: members[0].Label, Reason: reason}
+		for _, m := range members {
+			v.Issues += m.Count
+			if m.Label != v.Canonical {
+				v.Variants = append(v.Variants, m.Label)
+			}
+		}
+		sort.Strings(v.Variants)
+		variants = append(variants, v)
+	}
+	return variants
+}
+
+// normalizeLabel folds case, drops separators, and strips a plural s, so
+// "Back-End", "back_end", and "backends" all become "backend".
+func normalizeLabel(label string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(label) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		}
+	}
+	s := b.String()
+	if len(s) > 3 && strings.HasSuffix(s, "s") && !strings.HasSuffix(s, "ss") {
+		s = s[:len(s)-1]
+	}
+	return s
+}
+
+// similarLabels reports normalized labels one edit apart. Labels with
+// digits are left alone: v1 and v2 are different labels, not typos.
+func similarLabels(a, b string) bool {
+	if len(a) < labelSimilarMinLength || len(b) < labelSimilarMinLength ||
+		strings.ContainsAny(a+b, "0123456789") {
+		return false
+	}
+	return editDistance(a, b, 1) <= 1
+}
+
+// editDistance is the Levenshtein distance between a and b, giving up
+// early (and returning limit+1) once it must exceed limit.
+func editDistance(a, b string, limit int) int {
+	ra, rb := []rune(a), []rune(b)
+	if d := len(ra) - len(rb); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev := make([]int, len(rb)+1)
+	cur := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			cur[j] = min(prev[j]+1, cur[j-1]+1, prev[j-1]+cost)
+			best = min(best, cur[j])
+		}
+		if best > limit {
+			return limit + 1
+		}
+		prev, cur = cur, prev
+	}
+	return prev[len(rb)]
+}
+
+func medianFloat(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
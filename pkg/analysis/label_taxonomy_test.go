@@ -0,0 +1,86 @@
+package analysis
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeLabelTaxonomy(t *testing.T) {
+	created := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	closed := func(id string, days int, labels ...string) model.Issue {
+		at := created.Add(time.Duration(days) * 24 * time.Hour)
+		return model.Issue{ID: id, Status: model.StatusClosed, CreatedAt: created, ClosedAt: &at, Labels: labels}
+	}
+	open := func(id string, labels ...string) model.Issue {
+		return model.Issue{ID: id, Status: model.StatusOpen, CreatedAt: created, Labels: labels}
+	}
+	issues := []model.Issue{
+		closed("C1", 2, "backend", "api"),
+		closed("C2", 2, "backend", "api"),
+		closed("C3", 2, "frontend"),
+		closed("D1", 20, "database"),
+		closed("D2", 30, "database", "database"), // duplicate label counts once
+		closed("D3", 25, "database"),
+		open("O1", "backend", "api"),
+		open("O2", "back-end"),
+		open("O3", "Frontend", "ui"),
+		open("O4", "fronend"),
+		open("O5", "v1"),
+		open("O6", "v2"),
+		open("O7"),
+		{ID: "T", Status: model.StatusTombstone, Labels: []string{"ghost"}},
+	}
+	tax := ComputeLabelTaxonomy(issues)
+
+	if tax.TotalLabels != 10 || tax.LabeledIssues != 12 || tax.UnlabeledIssues != 1 {
+		t.Errorf("totals = %d labels, %d labeled, %d unlabeled", tax.TotalLabels, tax.LabeledIssues, tax.UnlabeledIssues)
+	}
+	if top := tax.Usage[0]; top.Label != "api" || top.Count != 3 || top.Open != 1 || top.Closed != 2 || top.Share != 0.231 {
+		t.Errorf("top usage = %+v", top)
+	}
+	if want := []string{"Frontend", "back-end", "fronend", "frontend", "ui", "v1", "v2"}; !reflect.DeepEqual(tax.Singletons, want) {
+		t.Errorf("singletons = %v, want %v", tax.Singletons, want)
+	}
+
+	if len(tax.Pairs) != 1 || tax.Pairs[0] != (LabelPair{A: "api", B: "backend", Count: 3, Jaccard: 1}) {
+		t.Errorf("pairs = %+v", tax.Pairs)
+	}
+	if len(tax.Clusters) != 1 || !reflect.DeepEqual(tax.Clusters[0].Labels, []string{"api", "backend"}) || tax.Clusters[0].Issues != 3 {
+		t.Errorf("clusters = %+v", tax.Clusters)
+	}
+
+	// Overall median of 2,2,2,20,25,30 is 11 days; database's 25 is 2.27x.
+	if tax.MedianCycleDays != 11 {
+		t.Errorf("median cycle days = %v", tax.MedianCycleDays)
+	}
+	if want := []LabelCycleTime{{Label: "database", Samples: 3, MedianDays: 25, Ratio: 2.27}}; !reflect.DeepEqual(tax.SlowLabels, want) {
+		t.Errorf("slow labels = %+v, want %+v", tax.SlowLabels, want)
+	}
+
+	var got []string
+	for _, v := range tax.Variants {
+		got = append(got, v.Reason+":"+v.Canonical+"<-"+strings.Join(v.Variants, ","))
+	}
+	want := []string{"spelling:backend<-back-end", "similar:Frontend<-fronend,frontend"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("variants = %v, want %v", got, want)
+	}
+}
+
+func TestNormalizeLabel(t *testing.T) {
+	for in, want := range map[string]string{
+		"Back-End":  "backend",
+		"back_ends": "backend",
+		"bugs":      "bug",
+		"css":       "css",
+		"address":   "address",
+	} {
+		if got := normalizeLabel(in); got != want {
+			t.Errorf("normalizeLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
@@ -0,0 +1,212 @@
+package analysis
+
+import (
+	"sort"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ScheduledIssue is one open issue placed on the forecast schedule.
+type ScheduledIssue struct {
+	ID       string    `json:"id"`
+	Title    string    `json:"title"`
+	Days     float64   `json:"days"` // Own estimated duration
+	Start    time.Time `json:"start"`
+	Finish   time.Time `json:"finish"`
+	Critical bool      `json:"critical"`
+	Cycle    string    `json:"cycle,omitempty"` // Condensed cycle ID when the issue is on a dependency cycle
+}
+
+// EpicMilestone is the forecast completion of an epic's remaining work.
+type EpicMilestone struct {
+	ID        string    `json:"id"`
+	Title     string    `json:"title"`
+	Open      int       `json:"open"` // Open descendants still to finish
+	Finish    time.Time `json:"finish"`
+	LastIssue string    `json:"last_issue"` // The descendant that finishes last
+}
+
+// MilestoneForecast is a critical path schedule of the open issues.
+type MilestoneForecast struct {
+	Start        time.Time        `json:"start"`
+	Finish       time.Time        `json:"finish"` // When the last open issue is forecast to finish
+	Agents       int              `json:"agents"`
+	Issues       []ScheduledIssue `json:"issues"`        // By finish, then ID
+	Epics        []EpicMilestone  `json:"epics"`         // By finish, then ID
+	CriticalPath []string         `json:"critical_path"` // Blocker first
+}
+
+// ForecastMilestones schedules the open issues with the critical path method:
+// each issue starts once its last open blocker finishes and takes its own ETA
+// duration (see EstimateETAForIssue). Work is assumed to run in parallel
+// wherever the dependencies allow. An epic's milestone is the finish of its
+// last open descendant; epics with children count as containers and take no
+// time themselves. The issues on a dependency cycle are scheduled as one
+// block that takes as long as all of them together.
+//
+// The critical path runs back from the issue that finishes last, through the
+// blocker that finishes latest at each step.
+func ForecastMilestones(issues []model.Issue, stats *GraphStats, agents int, now time.Time) MilestoneForecast {
+	if agents <= 0 {
+		agents = 1
+	}
+	result := MilestoneForecast{Start: now, Finish: now, Agents: agents, Issues: []ScheduledIssue{}, Epics: []EpicMilestone{}, CriticalPath: []string{}}
+
+	byID := make(map[string]*model.Issue, len(issues))
+	children := make(map[string][]string)
+	var open []model.Issue
+	for i := range issues {
+		issue := &issues[i]
+		byID[issue.ID] = issue
+		if !isClosedLikeStatus(issue.Status) {
+			open = append(open, *issue)
+		}
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild && dep.DependsOnID != issue.ID {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+	if len(open) == 0 {
+		return result
+	}
+
+	days := make(map[string]float64, len(open))
+	for _, issue := range open {
+		if len(children[issue.ID]) > 0 {
+			continue
+		}
+		if eta, err := EstimateETAForIssue(issues, stats, issue.ID, agents, now); err == nil {
+			days[issue.ID] = eta.EstimatedDays
+		}
+	}
+
+	cond := NewAnalyzer(open).Condense()
+	nodes := make(map[string]*CondensedNode, len(cond.Nodes))
+	for i := range cond.Nodes {
+		nodes[cond.Nodes[i].ID] = &cond.Nodes[i]
+	}
+	remaining := make(map[string]int, len(cond.Nodes))
+	dependents := make(map[string][]string)
+	blockers := make(map[string][]string)
+	for _, e := range cond.Edges {
+		remaining[e.From]++
+		dependents[e.To] = append(dependents[e.To], e.From)
+		blockers[e.From] = append(blockers[e.From], e.To)
+	}
+
+	// Forward pass over the condensation in topological order
+	start := make(map[string]time.Time, len(cond.Nodes))
+	finish := make(map[string]time.Time, len(cond.Nodes))
+	var ready []string
+	for _, n := range cond.Nodes {
+		if remaining[n.ID] == 0 {
+			ready = append(ready, n.ID)
+		}
+	}
+	for len(ready) > 0 {
+		nodeID := ready[0]
+		ready = ready[1:]
+		s := now
+		for _, b := range blockers[nodeID] {
+			if finish[b].After(s) {
+				s = finish[b]
+			}
+		}
+		var d float64
+		for _, id := range nodes[nodeID].Members {
+			d += days[id]
+		}
+		start[nodeID], finish[nodeID] = s, s.Add(durationDays(d))
+		for _, dep := range dependents[nodeID] {
+			remaining[dep]--
+			if remaining[dep] == 0 {
+				ready = append(ready, dep)
+			}
+		}
+	}
+
+	// Walk the critical path back from the latest finish
+	later := func(a, b string) bool {
+		if !finish[a].Equal(finish[b]) {
+			return finish[a].After(finish[b])
+		}
+		return a < b
+	}
+	last := ""
+	for _, n := range cond.Nodes {
+		if last == "" || later(n.ID, last) {
+			last = n.ID
+		}
+	}
+	critical := make(map[string]bool)
+	var path []string
+	for nodeID := last; nodeID != ""; {
+		critical[nodeID] = true
+		path = append(append([]string(nil), nodes[nodeID].Members...), path...)
+		next := ""
+		for _, b := range blockers[nodeID] {
+			if next == "" || later(b, next) {
+				next = b
+			}
+		}
+		nodeID = next
+	}
+	result.CriticalPath = append(result.CriticalPath, path...)
+	result.Finish = finish[last]
+
+	scheduled := make(map[string]*ScheduledIssue, len(open))
+	for _, issue := range open {
+		nodeID := cond.Component[issue.ID]
+		item := ScheduledIssue{
+			ID:       issue.ID,
+			Title:    issue.Title,
+			Days:     days[issue.ID],
+			Start:    start[nodeID],
+			Finish:   finish[nodeID],
+			Critical: critical[nodeID],
+		}
+		if nodes[nodeID].Cyclic {
+			item.Cycle = nodeID
+		}
+		result.Issues = append(result.Issues, item)
+	}
+	sort.Slice(result.Issues, func(i, j int) bool {
+		if !result.Issues[i].Finish.Equal(result.Issues[j].Finish) {
+			return result.Issues[i].Finish.Before(result.Issues[j].Finish)
+		}
+		return result.Issues[i].ID < result.Issues[j].ID
+	})
+	for i := range result.Issues {
+		scheduled[result.Issues[i].ID] = &result.Issues[i]
+	}
+
+	for i := range issues {
+		epic := &issues[i]
+		if isClosedLikeStatus(epic.Status) || (epic.IssueType != model.TypeEpic && len(children[epic.ID]) == 0) {
+			continue
+		}
+		m := EpicMilestone{ID: epic.ID, Title: epic.Title}
+		for _, child := range epicDescendants(epic.ID, children, byID) {
+			s := scheduled[child.ID]
+			if s == nil {
+				continue
+			}
+			m.Open++
+			if m.LastIssue == "" || s.Finish.After(m.Finish) {
+				m.Finish, m.LastIssue = s.Finish, s.ID
+			}
+		}
+		if m.Open > 0 {
+			result.Epics = append(result.Epics, m)
+		}
+	}
+	sort.Slice(result.Epics, func(i, j int) bool {
+		if !result.Epics[i].Finish.Equal(result.Epics[j].Finish) {
+			return result.Epics[i].Finish.Before(result.Epics[j].Finish)
+		}
+		return result.Epics[i].ID < result.Epics[j].ID
+	})
+	return result
+}
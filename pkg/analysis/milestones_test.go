@@ -0,0 +1,67 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestForecastMilestones(t *testing.T) {
+	now := time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC)
+	minutes := func(m int) *int { return &m }
+	blocks := func(id string) *model.Dependency { return &model.Dependency{DependsOnID: id, Type: model.DepBlocks} }
+	child := &model.Dependency{DependsOnID: "EPIC", Type: model.DepParentChild}
+
+	// A → B → D is the long chain; C is quick and independent; P and Q block
+	// each other.
+	issues := []model.Issue{
+		{ID: "EPIC", Title: "Launch", IssueType: model.TypeEpic, Status: model.StatusOpen},
+		{ID: "A", Status: model.StatusOpen, EstimatedMinutes: minutes(2400), Dependencies: []*model.Dependency{child, blocks("X")}},
+		{ID: "B", Status: model.StatusInProgress, EstimatedMinutes: minutes(2400), Dependencies: []*model.Dependency{child, blocks("A")}},
+		{ID: "C", Status: model.StatusOpen, EstimatedMinutes: minutes(60), Dependencies: []*model.Dependency{child}},
+		{ID: "D", Status: model.StatusOpen, EstimatedMinutes: minutes(2400), Dependencies: []*model.Dependency{blocks("B")}},
+		{ID: "P", Status: model.StatusOpen, EstimatedMinutes: minutes(60), Dependencies: []*model.Dependency{blocks("Q")}},
+		{ID: "Q", Status: model.StatusOpen, EstimatedMinutes: minutes(60), Dependencies: []*model.Dependency{blocks("P")}},
+		{ID: "X", Status: model.StatusClosed},
+	}
+
+	f := ForecastMilestones(issues, nil, 0, now)
+	if f.Agents != 1 || len(f.Issues) != 7 {
+		t.Fatalf("agents=%d issues=%+v", f.Agents, f.Issues)
+	}
+	got := make(map[string]ScheduledIssue)
+	for _, s := range f.Issues {
+		got[s.ID] = s
+	}
+
+	a, b, d := got["A"], got["B"], got["D"]
+	if !a.Start.Equal(now) || !b.Start.Equal(a.Finish) || !d.Start.Equal(b.Finish) || !f.Finish.Equal(d.Finish) {
+		t.Errorf("chain not scheduled back to back: A=%+v B=%+v D=%+v finish=%v", a, b, d, f.Finish)
+	}
+	if a.Days <= 0 || got["EPIC"].Days != 0 || !got["EPIC"].Finish.Equal(now) {
+		t.Errorf("durations: A=%v EPIC=%+v", a.Days, got["EPIC"])
+	}
+	if p, q := got["P"], got["Q"]; p.Cycle == "" || p.Cycle != q.Cycle || !p.Finish.Equal(q.Finish) || !p.Finish.Equal(now.Add(durationDays(p.Days+q.Days))) {
+		t.Errorf("cycle: P=%+v Q=%+v", p, q)
+	}
+
+	if want := []string{"A", "B", "D"}; !reflect.DeepEqual(f.CriticalPath, want) {
+		t.Errorf("critical path = %v, want %v", f.CriticalPath, want)
+	}
+	if !a.Critical || got["C"].Critical || got["P"].Critical {
+		t.Errorf("critical flags: A=%v C=%v P=%v", a.Critical, got["C"].Critical, got["P"].Critical)
+	}
+
+	if len(f.Epics) != 1 {
+		t.Fatalf("epics = %+v", f.Epics)
+	}
+	if m := f.Epics[0]; m.ID != "EPIC" || m.Open != 3 || m.LastIssue != "B" || !m.Finish.Equal(b.Finish) {
+		t.Errorf("epic milestone = %+v", m)
+	}
+
+	if empty := ForecastMilestones([]model.Issue{{ID: "X", Status: model.StatusClosed}}, nil, 2, now); len(empty.Issues) != 0 || !empty.Finish.Equal(now) {
+		t.Errorf("nothing open: %+v", empty)
+	}
+}
@@ -0,0 +1,142 @@
+package analysis
+
+import (
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// OrderItem is one open issue in an execution order.
+type OrderItem struct {
+	Position int     `json:"position"` // 1-based place in the flat order
+	Wave     int     `json:"wave"`     // 1-based wave
+	ID       string  `json:"id"`
+	Title    string  `json:"title"`
+	Status   string  `json:"status"`
+	Priority int     `json:"priority"`
+	Score    float64 `json:"score"`
+	Cycle    string  `json:"cycle,omitempty"` // Condensed cycle ID when the issue is on a dependency cycle
+}
+
+// OrderWave is a set of open issues whose open blockers are all in earlier
+// waves, so they can be worked on in parallel.
+type OrderWave struct {
+	Wave int      `json:"wave"`
+	IDs  []string `json:"ids"` // In flat order
+}
+
+// ExecutionOrder is a topological ordering of the open issues, partitioned
+// into waves and flattened into one list.
+type ExecutionOrder struct {
+	Count      int         `json:"count"`
+	WaveCount  int         `json:"wave_count"`
+	CycleCount int         `json:"cycle_count"` // Cycles placed as a block inside one wave
+	Waves      []OrderWave `json:"waves"`
+	Order      []OrderItem `json:"order"`
+}
+
+// ComputeExecutionOrder orders the open issues so every issue comes after
+// the open issues it is blocked by. Wave 1 holds the issues with no open
+// blockers; each later wave holds the issues whose deepest open blocker is in
+// the wave before it. Within a wave, issues are sorted by score (highest
+// first), then priority, then ID, so the result is deterministic.
+//
+// Closed blockers count as done and missing ones are ignored. A dependency
+// cycle has no valid order, so its members share a wave, are listed together
+// and are marked with the cycle's ID.
+func ComputeExecutionOrder(issues []model.Issue, scores map[string]float64) ExecutionOrder {
+	var open []model.Issue
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		if !isClosedLikeStatus(issues[i].Status) {
+			open = append(open, issues[i])
+			byID[issues[i].ID] = &issues[i]
+		}
+	}
+	result := ExecutionOrder{Waves: []OrderWave{}, Order: []OrderItem{}}
+	if len(open) == 0 {
+		return result
+	}
+
+	cond := NewAnalyzer(open).Condense()
+	result.CycleCount = cond.CyclicCount
+	nodes := make(map[string]*CondensedNode, len(cond.Nodes))
+	for i := range cond.Nodes {
+		nodes[cond.Nodes[i].ID] = &cond.Nodes[i]
+	}
+
+	// Kahn's algorithm over the condensation, from the issues with no open
+	// blockers up to the ones that wait on them.
+	remaining := make(map[string]int, len(cond.Nodes))
+	dependents := make(map[string][]string)
+	for _, e := range cond.Edges {
+		remaining[e.From]++
+		dependents[e.To] = append(dependents[e.To], e.From)
+	}
+	var ready []string
+	for _, n := range cond.Nodes {
+		if remaining[n.ID] == 0 {
+			ready = append(ready, n.ID)
+		}
+	}
+
+	less := func(a, b string) bool {
+		if scores[a] != scores[b] {
+			return scores[a] > scores[b]
+		}
+		if byID[a].Priority != byID[b].Priority {
+			return byID[a].Priority < byID[b].Priority
+		}
+		return a < b
+	}
+	// A cycle ranks by its best member
+	lead := func(nodeID string) string {
+		best := ""
+		for _, id := range nodes[nodeID].Members {
+			if best == "" || less(id, best) {
+				best = id
+			}
+		}
+		return best
+	}
+
+	for wave := 1; len(ready) > 0; wave++ {
+		sort.Slice(ready, func(i, j int) bool { return less(lead(ready[i]), lead(ready[j])) })
+		w := OrderWave{Wave: wave}
+		var next []string
+		for _, nodeID := range ready {
+			node := nodes[nodeID]
+			members := append([]string(nil), node.Members...)
+			sort.Slice(members, func(i, j int) bool { return less(members[i], members[j]) })
+			for _, id := range members {
+				issue := byID[id]
+				item := OrderItem{
+					Position: len(result.Order) + 1,
+					Wave:     wave,
+					ID:       id,
+					Title:    issue.Title,
+					Status:   string(issue.Status),
+					Priority: issue.Priority,
+					Score:    scores[id],
+				}
+				if node.Cyclic {
+					item.Cycle = node.ID
+				}
+				result.Order = append(result.Order, item)
+				w.IDs = append(w.IDs, id)
+			}
+			for _, dep := range dependents[nodeID] {
+				remaining[dep]--
+				if remaining[dep] == 0 {
+					next = append(next, dep)
+				}
+			}
+		}
+		result.Waves = append(result.Waves, w)
+		ready = next
+	}
+
+	result.Count = len(result.Order)
+	result.WaveCount = len(result.Waves)
+	return result
+}
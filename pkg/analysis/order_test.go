@@ -0,0 +1,61 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeExecutionOrder(t *testing.T) {
+	blocks := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	// X is closed, so B is ready. C and D form a cycle waiting on A and B;
+	// E waits on the cycle.
+	issues := []model.Issue{
+		{ID: "X", Status: model.StatusClosed},
+		{ID: "A", Status: model.StatusOpen, Priority: 2},
+		{ID: "B", Status: model.StatusOpen, Priority: 1, Dependencies: blocks("X")},
+		{ID: "C", Status: model.StatusOpen, Dependencies: blocks("A", "D")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: blocks("B", "C")},
+		{ID: "E", Status: model.StatusInProgress, Dependencies: blocks("D", "gone")},
+		{ID: "F", Status: model.StatusOpen, Priority: 1},
+	}
+	scores := map[string]float64{"A": 0.9, "D": 0.5, "C": 0.4}
+
+	order := ComputeExecutionOrder(issues, scores)
+
+	var flat []string
+	for _, item := range order.Order {
+		flat = append(flat, item.ID)
+	}
+	if want := []string{"A", "B", "F", "D", "C", "E"}; !reflect.DeepEqual(flat, want) {
+		t.Errorf("order = %v, want %v", flat, want)
+	}
+	wantWaves := []OrderWave{
+		{Wave: 1, IDs: []string{"A", "B", "F"}},
+		{Wave: 2, IDs: []string{"D", "C"}},
+		{Wave: 3, IDs: []string{"E"}},
+	}
+	if !reflect.DeepEqual(order.Waves, wantWaves) {
+		t.Errorf("waves = %+v", order.Waves)
+	}
+	if order.Count != 6 || order.WaveCount != 3 || order.CycleCount != 1 {
+		t.Errorf("counts = %d issues, %d waves, %d cycles", order.Count, order.WaveCount, order.CycleCount)
+	}
+	if c := order.Order[3]; c.Cycle == "" || c.Position != 4 || c.Wave != 2 || order.Order[4].Cycle != c.Cycle {
+		t.Errorf("cycle member = %+v", c)
+	}
+	if order.Order[0].Cycle != "" {
+		t.Errorf("A marked as on a cycle: %+v", order.Order[0])
+	}
+
+	if empty := ComputeExecutionOrder(nil, nil); empty.Count != 0 || empty.Waves == nil || empty.Order == nil {
+		t.Errorf("empty order = %+v", empty)
+	}
+}
@@ -0,0 +1,107 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// PageRankFocus records how PageRank was personalized: the --focus spec as
+// given and the issue IDs it resolved to.
+type PageRankFocus struct {
+	Spec  string   `json:"spec"`
+	Seeds []string `json:"seeds"`
+}
+
+// ResolvePageRankFocus turns a comma-separated focus spec into PageRank
+// seeds. Each term is tried in turn as:
+//
+//   - an issue ID, which seeds that issue plus, for an epic or any issue
+//     with parent-child children, its open descendants;
+//   - a priority such as P0, which seeds every open issue at it;
+//   - a label (case-insensitive), which seeds every open issue carrying it.
+//
+// A term matching nothing is an error so a typo does not silently fall back
+// to plain PageRank.
+func ResolvePageRankFocus(issues []model.Issue, spec string) (PageRankFocus, error) {
+	byID := make(map[string]*model.Issue, len(issues))
+	children := make(map[string][]string)
+	for i := range issues {
+		issue := &issues[i]
+		byID[issue.ID] = issue
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type == model.DepParentChild && dep.DependsOnID != issue.ID {
+				children[dep.DependsOnID] = append(children[dep.DependsOnID], issue.ID)
+			}
+		}
+	}
+
+	seeds := make(map[string]bool)
+	terms := 0
+	for _, term := range strings.Split(spec, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		terms++
+		matched := 0
+		if issue, ok := byID[term]; ok {
+			seeds[issue.ID] = true
+			matched++
+			for _, d := range epicDescendants(issue.ID, children, byID) {
+				if !d.Status.IsClosed() {
+					seeds[d.ID] = true
+				}
+			}
+		} else if p, ok := parseFocusPriority(term); ok {
+			for i := range issues {
+				if issues[i].Priority == p && isFocusCandidate(&issues[i]) {
+					seeds[issues[i].ID] = true
+					matched++
+				}
+			}
+		} else {
+			for i := range issues {
+				if isFocusCandidate(&issues[i]) && hasLabelFold(&issues[i], term) {
+					seeds[issues[i].ID] = true
+					matched++
+				}
+			}
+		}
+		if matched == 0 {
+			return PageRankFocus{}, fmt.Errorf("focus %q matches no issue ID, open priority, or label", term)
+		}
+	}
+	if terms == 0 {
+		return PageRankFocus{}, fmt.Errorf("empty focus")
+	}
+
+	focus := PageRankFocus{Spec: spec, Seeds: make([]string, 0, len(seeds))}
+	for id := range seeds {
+		focus.Seeds = append(focus.Seeds, id)
+	}
+	sort.Strings(focus.Seeds)
+	return focus, nil
+}
+
+func parseFocusPriority(term string) (int, bool) {
+	if len(term) != 2 || (term[0] != 'P' && term[0] != 'p') || term[1] < '0' || term[1] > '4' {
+		return 0, false
+	}
+	return int(term[1] - '0'), true
+}
+
+func isFocusCandidate(issue *model.Issue) bool {
+	return !issue.Status.IsClosed() && !issue.Status.IsTombstone()
+}
+
+func hasLabelFold(issue *model.Issue, label string) bool {
+	for _, l := range issue.Labels {
+		if strings.EqualFold(l, label) {
+			return true
+		}
+	}
+	return false
+}
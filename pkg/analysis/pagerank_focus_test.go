@@ -0,0 +1,87 @@
+package analysis
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func focusIssues() []model.Issue {
+	child := func(id, parent string) model.Issue {
+		return model.Issue{ID: id, Title: id, Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: id, DependsOnID: parent, Type: model.DepParentChild}}}
+	}
+	return []model.Issue{
+		{ID: "E", Title: "epic", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeEpic},
+		child("E1", "E"),
+		child("E2", "E1"),
+		{ID: "E3", Title: "done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "E3", DependsOnID: "E", Type: model.DepParentChild}}},
+		{ID: "P", Title: "urgent", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug, Labels: []string{"API"}},
+		{ID: "Q", Title: "old urgent", Status: model.StatusClosed, Priority: 0, IssueType: model.TypeBug},
+		{ID: "R", Title: "api work", Status: model.StatusOpen, Priority: 3, IssueType: model.TypeTask, Labels: []string{"api"}},
+	}
+}
+
+func TestResolvePageRankFocus(t *testing.T) {
+	issues := focusIssues()
+	tests := []struct {
+		spec string
+		want []string
+	}{
+		{"E", []string{"E", "E1", "E2"}}, // open descendants, nested included
+		{"E2", []string{"E2"}},
+		{"p0", []string{"P"}},
+		{"api", []string{"P", "R"}},
+		{"R, p0", []string{"P", "R"}},
+	}
+	for _, tt := range tests {
+		focus, err := ResolvePageRankFocus(issues, tt.spec)
+		if err != nil {
+			t.Fatalf("%q: %v", tt.spec, err)
+		}
+		if !reflect.DeepEqual(focus.Seeds, tt.want) || focus.Spec != tt.spec {
+			t.Errorf("%q: got %+v, want seeds %v", tt.spec, focus, tt.want)
+		}
+	}
+
+	for _, spec := range []string{"nope", "P4", " , "} {
+		if _, err := ResolvePageRankFocus(issues, spec); err == nil {
+			t.Errorf("%q: expected an error", spec)
+		}
+	}
+}
+
+func TestPageRankFocusShiftsRank(t *testing.T) {
+	// Two identical chains: A1 -> A0 and B1 -> B0
+	blocked := func(id, blocker string) model.Issue {
+		issue := model.Issue{ID: id, Title: id, Status: model.StatusOpen, IssueType: model.TypeTask}
+		if blocker != "" {
+			issue.Dependencies = []*model.Dependency{{IssueID: id, DependsOnID: blocker, Type: model.DepBlocks}}
+		}
+		return issue
+	}
+	issues := []model.Issue{blocked("A0", ""), blocked("A1", "A0"), blocked("B0", ""), blocked("B1", "B0")}
+
+	rank := func(focus []string) map[string]float64 {
+		cfg := DefaultConfig()
+		cfg.PageRankFocus = focus
+		stats := NewAnalyzer(issues).AnalyzeAsyncWithConfig(context.Background(), cfg)
+		stats.WaitForPhase2()
+		return stats.PageRank()
+	}
+
+	plain := rank(nil)
+	if diff := plain["A0"] - plain["B0"]; diff > 1e-9 || diff < -1e-9 {
+		t.Fatalf("unfocused ranks differ: %v", plain)
+	}
+	focused := rank([]string{"B1"})
+	if focused["B0"] <= focused["A0"] || focused["B1"] <= focused["A1"] {
+		t.Errorf("focus on B1 should lift its chain: %v", focused)
+	}
+	if focused["A0"] != 0 || focused["A1"] != 0 {
+		t.Errorf("issues unreachable from the seeds should get no rank: %v", focused)
+	}
+}
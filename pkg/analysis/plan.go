@@ -20,6 +20,10 @@ type ExecutionTrack struct {
 	TrackID string     `json:"track_id"`
 	Items   []PlanItem `json:"items"`
 	Reason  string     `json:"reason"` // Why these are grouped
+
+	// Set by ApplyOwnership
+	Owner       string `json:"owner,omitempty"`
+	OwnerSource string `json:"owner_source,omitempty"` // "config" (owners.yaml labels) or "assignee"
 }
 
 // ExecutionPlan is the complete work plan with parallel tracks
@@ -28,6 +32,7 @@ type ExecutionPlan struct {
 	TotalActionable int              `json:"total_actionable"`
 	TotalBlocked    int              `json:"total_blocked"`
 	Summary         PlanSummary      `json:"summary"`
+	WIP             *WIPReport       `json:"wip,omitempty"` // Set by ApplyOwnership when WIP limits are configured
 }
 
 // PlanSummary provides quick insights about the plan
@@ -0,0 +1,250 @@
+package analysis
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"gopkg.in/yaml.v3"
+)
+
+// OwnershipConfigFilename is the plan ownership config in .bv/
+const OwnershipConfigFilename = "owners.yaml"
+
+// OwnershipConfig assigns execution plan tracks to owners and caps how much
+// each owner works on at once. It is read from .bv/owners.yaml:
+//
+//	default_wip_limit: 3
+//	owners:
+//	  alice:
+//	    wip_limit: 2
+//	    labels: [frontend, ui]
+type OwnershipConfig struct {
+	// DefaultWIPLimit applies to owners without their own limit; 0 is unlimited
+	DefaultWIPLimit int `yaml:"default_wip_limit" json:"default_wip_limit"`
+
+	Owners map[string]OwnerConfig `yaml:"owners" json:"owners,omitempty"`
+}
+
+// OwnerConfig is one owner in OwnershipConfig.
+type OwnerConfig struct {
+	// WIPLimit caps the owner's in-progress plus planned items; 0 falls back to the default
+	WIPLimit int `yaml:"wip_limit" json:"wip_limit,omitempty"`
+
+	// Labels claim every track whose items carry them
+	Labels []string `yaml:"labels" json:"labels,omitempty"`
+}
+
+// OwnershipConfigPath returns the ownership config path for a project
+func OwnershipConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", OwnershipConfigFilename)
+}
+
+// LoadOwnershipConfig loads .bv/owners.yaml.
+// Returns an empty config if the file doesn't exist.
+func LoadOwnershipConfig(projectDir string) (OwnershipConfig, error) {
+	var cfg OwnershipConfig
+	data, err := os.ReadFile(OwnershipConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return cfg, fmt.Errorf("reading ownership config: %w", err)
+	}
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, fmt.Errorf("parsing ownership config: %w", err)
+	}
+	if cfg.DefaultWIPLimit < 0 {
+		return cfg, fmt.Errorf("invalid ownership config: default_wip_limit must be >= 0")
+	}
+	for name, owner := range cfg.Owners {
+		if owner.WIPLimit < 0 {
+			return cfg, fmt.Errorf("invalid ownership config: wip_limit for %s must be >= 0", name)
+		}
+	}
+	return cfg, nil
+}
+
+// WIPLimit returns the limit for owner, 0 meaning unlimited.
+func (c OwnershipConfig) WIPLimit(owner string) int {
+	if o, ok := c.Owners[owner]; ok && o.WIPLimit > 0 {
+		return o.WIPLimit
+	}
+	return c.DefaultWIPLimit
+}
+
+// hasLimits reports whether any owner is limited.
+func (c OwnershipConfig) hasLimits() bool {
+	if c.DefaultWIPLimit > 0 {
+		return true
+	}
+	for _, o := range c.Owners {
+		if o.WIPLimit > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// OwnerLoad is one owner's work against their WIP limit.
+type OwnerLoad struct {
+	Owner      string   `json:"owner"`
+	Limit      int      `json:"limit"`       // 0 means unlimited
+	InProgress int      `json:"in_progress"` // Assigned in-progress issues, in the plan or not
+	Planned    int      `json:"planned"`     // Open plan items that fit under the limit
+	Overflow   int      `json:"overflow"`    // Open plan items that don't
+	Tracks     []string `json:"tracks"`
+}
+
+// WIPOverflow is a plan item its owner has no room for.
+type WIPOverflow struct {
+	ID      string `json:"id"`
+	TrackID string `json:"track_id"`
+	Owner   string `json:"owner"`
+}
+
+// WIPReport checks an execution plan against per-owner WIP limits.
+type WIPReport struct {
+	Feasible bool          `json:"feasible"` // Nothing overflows and no owner is already over their limit
+	Owners   []OwnerLoad   `json:"owners"`   // Sorted by owner
+	Overflow []WIPOverflow `json:"overflow"` // In track and item order
+}
+
+// ApplyOwnership assigns each track an owner and, when cfg sets any WIP limit,
+// checks the plan against the limits.
+//
+// A track goes to the configured owner whose labels appear on the most of its
+// items; failing that, to the most common assignee among its items. Tracks
+// with neither stay unowned and unlimited. Each owner's in-progress issues
+// count against their limit first, whether or not they are in the plan; open
+// items then fill the remaining room in track order, and the rest overflow.
+func (p *ExecutionPlan) ApplyOwnership(issues []model.Issue, cfg OwnershipConfig) {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	var configured []string
+	for name := range cfg.Owners {
+		configured = append(configured, name)
+	}
+	sort.Strings(configured)
+
+	for t := range p.Tracks {
+		track := &p.Tracks[t]
+		track.Owner, track.OwnerSource = "", ""
+
+		best, bestCount := "", 0
+		for _, name := range configured {
+			labels := make(map[string]bool)
+			for _, l := range cfg.Owners[name].Labels {
+				labels[l] = true
+			}
+			count := 0
+			for _, item := range track.Items {
+				if issue := byID[item.ID]; issue != nil {
+					for _, l := range issue.Labels {
+						if labels[l] {
+							count++
+							break
+						}
+					}
+				}
+			}
+			if count > bestCount {
+				best, bestCount = name, count
+			}
+		}
+		if best != "" {
+			track.Owner, track.OwnerSource = best, "config"
+			continue
+		}
+
+		assignees := make(map[string]int)
+		for _, item := range track.Items {
+			if issue := byID[item.ID]; issue != nil && issue.Assignee != "" {
+				assignees[issue.Assignee]++
+			}
+		}
+		for name, count := range assignees {
+			if count > bestCount || (count == bestCount && name < best) {
+				best, bestCount = name, count
+			}
+		}
+		if best != "" {
+			track.Owner, track.OwnerSource = best, "assignee"
+		}
+	}
+
+	p.WIP = nil
+	if !cfg.hasLimits() {
+		return
+	}
+
+	loads := make(map[string]*OwnerLoad)
+	load := func(owner string) *OwnerLoad {
+		if l := loads[owner]; l != nil {
+			return l
+		}
+		l := &OwnerLoad{Owner: owner, Limit: cfg.WIPLimit(owner), Tracks: []string{}}
+		loads[owner] = l
+		return l
+	}
+
+	// In-progress work comes first: it is already taking up the owner's time
+	counted := make(map[string]bool)
+	for _, track := range p.Tracks {
+		if track.Owner == "" {
+			continue
+		}
+		l := load(track.Owner)
+		l.Tracks = append(l.Tracks, track.TrackID)
+		for _, item := range track.Items {
+			if model.Status(item.Status) == model.StatusInProgress {
+				l.InProgress++
+				counted[item.ID] = true
+			}
+		}
+	}
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status != model.StatusInProgress || issue.Assignee == "" || counted[issue.ID] {
+			continue
+		}
+		if _, known := loads[issue.Assignee]; known || cfg.Owners[issue.Assignee].WIPLimit > 0 {
+			load(issue.Assignee).InProgress++
+		}
+	}
+
+	report := &WIPReport{Owners: []OwnerLoad{}, Overflow: []WIPOverflow{}}
+	for _, track := range p.Tracks {
+		if track.Owner == "" {
+			continue
+		}
+		l := loads[track.Owner]
+		for _, item := range track.Items {
+			if model.Status(item.Status) == model.StatusInProgress {
+				continue
+			}
+			if l.Limit > 0 && l.InProgress+l.Planned >= l.Limit {
+				l.Overflow++
+				report.Overflow = append(report.Overflow, WIPOverflow{ID: item.ID, TrackID: track.TrackID, Owner: track.Owner})
+				continue
+			}
+			l.Planned++
+		}
+	}
+	for _, l := range loads {
+		report.Owners = append(report.Owners, *l)
+	}
+	sort.Slice(report.Owners, func(i, j int) bool { return report.Owners[i].Owner < report.Owners[j].Owner })
+	report.Feasible = len(report.Overflow) == 0
+	for _, l := range report.Owners {
+		if l.Limit > 0 && l.InProgress > l.Limit {
+			report.Feasible = false
+		}
+	}
+	p.WIP = report
+}
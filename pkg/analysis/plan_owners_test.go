@@ -0,0 +1,113 @@
+package analysis_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestApplyOwnership(t *testing.T) {
+	// Tracks: A and B (labeled ui) on their own, C and D (bob's, joined via X)
+	// together, and E unowned. carol's C2 is in progress but blocked, so it is
+	// outside the plan.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Priority: 1, Labels: []string{"ui"}},
+		{ID: "B", Status: model.StatusOpen, Priority: 2, Labels: []string{"ui"}, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+		{ID: "A2", Status: model.StatusOpen, Priority: 2, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "B2", Status: model.StatusOpen, Priority: 3, Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "C", Status: model.StatusInProgress, Priority: 1, Assignee: "bob"},
+		{ID: "D", Status: model.StatusOpen, Priority: 2, Assignee: "bob", Dependencies: []*model.Dependency{{DependsOnID: "X", Type: model.DepBlocks}}},
+		{ID: "C2", Status: model.StatusInProgress, Assignee: "carol", Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "X", Status: model.StatusClosed, Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "E", Status: model.StatusOpen},
+	}
+	plan := analysis.NewAnalyzer(issues).GetExecutionPlan()
+
+	owners := func() map[string]string {
+		m := make(map[string]string)
+		for _, tr := range plan.Tracks {
+			for _, item := range tr.Items {
+				m[item.ID] = tr.Owner + "/" + tr.OwnerSource
+			}
+		}
+		return m
+	}
+
+	cfg := analysis.OwnershipConfig{Owners: map[string]analysis.OwnerConfig{"alice": {Labels: []string{"ui"}}}}
+	plan.ApplyOwnership(issues, cfg)
+	want := map[string]string{"A": "alice/config", "B": "alice/config", "C": "bob/assignee", "D": "bob/assignee", "E": "/"}
+	if got := owners(); !reflect.DeepEqual(got, want) {
+		t.Errorf("owners = %v, want %v", got, want)
+	}
+	if plan.WIP != nil {
+		t.Errorf("WIP report without limits: %+v", plan.WIP)
+	}
+
+	cfg.DefaultWIPLimit = 1
+	cfg.Owners["carol"] = analysis.OwnerConfig{WIPLimit: 3}
+	plan.ApplyOwnership(issues, cfg)
+	if plan.WIP == nil || plan.WIP.Feasible {
+		t.Fatalf("expected an infeasible WIP report, got %+v", plan.WIP)
+	}
+	var overflow []string
+	for _, o := range plan.WIP.Overflow {
+		overflow = append(overflow, o.ID+"@"+o.Owner)
+	}
+	if want := []string{"B@alice", "D@bob"}; !reflect.DeepEqual(overflow, want) {
+		t.Errorf("overflow = %v, want %v", overflow, want)
+	}
+	loads := make(map[string]analysis.OwnerLoad)
+	for _, l := range plan.WIP.Owners {
+		loads[l.Owner] = l
+	}
+	if l := loads["bob"]; l.Limit != 1 || l.InProgress != 1 || l.Planned != 0 || l.Overflow != 1 {
+		t.Errorf("bob = %+v", l)
+	}
+	if l := loads["carol"]; l.Limit != 3 || l.InProgress != 1 || len(l.Tracks) != 0 {
+		t.Errorf("carol = %+v", l)
+	}
+
+	cfg.DefaultWIPLimit = 2
+	plan.ApplyOwnership(issues, cfg)
+	if !plan.WIP.Feasible || len(plan.WIP.Overflow) != 0 {
+		t.Errorf("expected a feasible plan at limit 2, got %+v", plan.WIP)
+	}
+}
+
+func TestLoadOwnershipConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := analysis.LoadOwnershipConfig(dir)
+	if err != nil || cfg.DefaultWIPLimit != 0 || cfg.Owners != nil {
+		t.Fatalf("missing file: %+v, %v", cfg, err)
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	path := analysis.OwnershipConfigPath(dir)
+	yaml := "default_wip_limit: 3\nowners:\n  alice:\n    wip_limit: 2\n    labels: [ui]\n  bob: {}\n"
+	if err := os.WriteFile(path, []byte(yaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = analysis.LoadOwnershipConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.WIPLimit("alice") != 2 || cfg.WIPLimit("bob") != 3 || cfg.WIPLimit("nobody") != 3 {
+		t.Errorf("limits = %d %d %d", cfg.WIPLimit("alice"), cfg.WIPLimit("bob"), cfg.WIPLimit("nobody"))
+	}
+	if !reflect.DeepEqual(cfg.Owners["alice"].Labels, []string{"ui"}) {
+		t.Errorf("alice labels = %v", cfg.Owners["alice"].Labels)
+	}
+
+	if err := os.WriteFile(path, []byte("owners:\n  alice:\n    wip_limit: -1\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := analysis.LoadOwnershipConfig(dir); err == nil {
+		t.Error("expected an error for a negative wip_limit")
+	}
+}
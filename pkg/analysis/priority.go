@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 )
 
 // ImpactScore represents the composite priority score for an issue
@@ -48,6 +49,14 @@ type ScoreBreakdown struct {
 
 	// Detailed risk signals (bv-82)
 	RiskSignals *RiskSignals `json:"risk_signals,omitempty"`
+
+	// CodeAge is the part of Risk that comes from how long the issue's likely
+	// files have gone unchanged (RiskSignals.CodeAge); 0 without files
+	CodeAge float64 `json:"code_age,omitempty"`
+
+	// Score rules from .bv/rules.yaml that matched, already added to the score
+	RuleAdjustment float64         `json:"rule_adjustment,omitempty"`
+	Rules          []rules.Applied `json:"rules,omitempty"`
 }
 
 // Weights for composite score (total = 1.0)
@@ -137,6 +146,10 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 	// Compute median estimated minutes for issues without estimates
 	medianMinutes := a.computeMedianEstimatedMinutes()
 
+	// Project score rules, applied on top of the weighted components
+	scoreRules := a.Config().ScoreRules
+	codeLastChanged := a.Config().CodeLastChanged
+
 	// Compute impact scores from stats
 	var scores []ImpactScore
 
@@ -165,6 +178,9 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 
 		// Compute risk signals (bv-82)
 		riskSignals := ComputeRiskSignals(&issue, stats, a.issueMap, now)
+		if lastChanged, ok := codeLastChanged[id]; ok {
+			ApplyCodeAge(&riskSignals, lastChanged, now)
+		}
 
 		// Compute weighted score
 		breakdown := ScoreBreakdown{
@@ -176,6 +192,7 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 			TimeToImpact:  timeToImpactNorm * WeightTimeToImpact,
 			Urgency:       urgencyNorm * WeightUrgency,
 			Risk:          riskSignals.CompositeRisk * WeightRisk,
+			CodeAge:       riskSignals.CodeAge * CodeAgeRiskWeight * WeightRisk,
 
 			PageRankNorm:      prNorm,
 			BetweennessNorm:   bwNorm,
@@ -202,6 +219,11 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 			breakdown.Urgency +
 			breakdown.Risk
 
+		if scoreRules != nil && len(scoreRules.Score) > 0 {
+			breakdown.RuleAdjustment, breakdown.Rules = scoreRules.Adjust(scoreRuleVars(&issue, &breakdown, score, now))
+			score += breakdown.RuleAdjustment
+		}
+
 		scores = append(scores, ImpactScore{
 			IssueID:   id,
 			Title:     issue.Title,
@@ -223,6 +245,22 @@ func (a *Analyzer) ComputeImpactScoresFromStats(stats *GraphStats, now time.Time
 	return scores
 }
 
+// scoreRuleVars is what a score rule sees: the issue, and its score and
+// normalized components before rules.
+func scoreRuleVars(issue *model.Issue, b *ScoreBreakdown, score float64, now time.Time) map[string]any {
+	vars := rules.IssueEnv(issue, now)
+	vars["score"] = score
+	vars["pagerank"] = b.PageRankNorm
+	vars["betweenness"] = b.BetweennessNorm
+	vars["blocker_ratio"] = b.BlockerRatioNorm
+	vars["staleness"] = b.StalenessNorm
+	vars["priority_boost"] = b.PriorityBoostNorm
+	vars["time_to_impact"] = b.TimeToImpactNorm
+	vars["urgency"] = b.UrgencyNorm
+	vars["risk"] = b.RiskNorm
+	return vars
+}
+
 // ComputeImpactScore returns the impact score for a single issue
 func (a *Analyzer) ComputeImpactScore(issueID string) *ImpactScore {
 	scores := a.ComputeImpactScores()
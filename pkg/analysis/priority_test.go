@@ -6,6 +6,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 )
 
 func TestComputeImpactScoresEmpty(t *testing.T) {
@@ -34,6 +35,45 @@ func TestComputeImpactScoresSkipsClosed(t *testing.T) {
 	}
 }
 
+func TestComputeImpactScoresRules(t *testing.T) {
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "A", Title: "Plain", Status: model.StatusOpen, Priority: 2, UpdatedAt: now},
+		{ID: "B", Title: "Secure", Status: model.StatusOpen, Priority: 2, UpdatedAt: now, Labels: []string{"security"}},
+	}
+	base := analysis.NewAnalyzer(issues).ComputeImpactScoresAt(now)
+
+	set, err := rules.Parse([]byte("score:\n  - name: security\n    when: '\"security\" in labels'\n    adjust: 0.2\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	an := analysis.NewAnalyzer(issues)
+	config := an.Config()
+	plain := config
+	config.ScoreRules = set
+	an.SetConfig(&config)
+	if analysis.ComputeConfigHash(&config) != analysis.ComputeConfigHash(&plain) {
+		t.Error("score rules should not change the config hash")
+	}
+	scores := an.ComputeImpactScoresAt(now)
+
+	if scores[0].IssueID != "B" {
+		t.Fatalf("expected the boosted issue first, got %s", scores[0].IssueID)
+	}
+	b := scores[0].Breakdown
+	if b.RuleAdjustment != 0.2 || len(b.Rules) != 1 || b.Rules[0].Name != "security" {
+		t.Errorf("breakdown rules = %v %+v", b.RuleAdjustment, b.Rules)
+	}
+	for _, s := range base {
+		if s.IssueID == "B" && scores[0].Score-s.Score < 0.1999 {
+			t.Errorf("score %v -> %v, want +0.2", s.Score, scores[0].Score)
+		}
+	}
+	if scores[1].Breakdown.RuleAdjustment != 0 || scores[1].Breakdown.Rules != nil {
+		t.Errorf("unmatched issue got rules: %+v", scores[1].Breakdown.Rules)
+	}
+}
+
 func TestComputeImpactScoresPriorityBoost(t *testing.T) {
 	now := time.Now()
 	issues := []model.Issue{
@@ -0,0 +1,117 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Reach directions.
+const (
+	ReachUp   = "up"   // what the issue waits on: its blockers, their blockers, ...
+	ReachDown = "down" // what waits on the issue: everything it transitively blocks
+)
+
+// ReachIssue is one issue in a reachability set.
+type ReachIssue struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	Status   string `json:"status"`
+	Priority int    `json:"priority"`
+	Depth    int    `json:"depth"` // blocking edges from the queried issue
+	Via      string `json:"via"`   // the issue it was reached through
+}
+
+// ReachResult is the set of issues reachable from IssueID along blocking
+// dependencies in Direction, nearest first.
+type ReachResult struct {
+	IssueID   string       `json:"issue_id"`
+	Direction string       `json:"direction"`
+	MaxDepth  int          `json:"max_depth"` // 0 means unlimited
+	Count     int          `json:"count"`
+	OpenCount int          `json:"open_count"` // not closed or tombstoned
+	ByDepth   []int        `json:"by_depth"`   // ByDepth[i] issues at depth i+1
+	Truncated bool         `json:"truncated"`  // more issues lie beyond MaxDepth
+	Issues    []ReachIssue `json:"issues"`
+}
+
+// ComputeReach walks blocking dependencies from id, up to its ancestors
+// (blockers) or down to its descendants (what it blocks), breadth first and
+// at most maxDepth edges deep (maxDepth <= 0 means no limit). Each issue is
+// listed once, at its shortest depth; the queried issue itself is left out
+// even when a cycle leads back to it. Dependencies on issues that aren't
+// loaded are skipped.
+func ComputeReach(issues []model.Issue, id, direction string, maxDepth int) (ReachResult, error) {
+	if direction != ReachUp && direction != ReachDown {
+		return ReachResult{}, fmt.Errorf("direction must be %s or %s, got %q", ReachUp, ReachDown, direction)
+	}
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	if byID[id] == nil {
+		return ReachResult{}, fmt.Errorf("issue not found: %s", id)
+	}
+	if maxDepth < 0 {
+		maxDepth = 0
+	}
+
+	// next[x] are the issues one blocking edge from x in direction
+	next := make(map[string][]string)
+	for i := range issues {
+		for _, dep := range issues[i].Dependencies {
+			if dep == nil || !dep.Type.IsBlocking() || byID[dep.DependsOnID] == nil {
+				continue
+			}
+			from, to := issues[i].ID, dep.DependsOnID
+			if direction == ReachDown {
+				from, to = to, from
+			}
+			next[from] = append(next[from], to)
+		}
+	}
+
+	result := ReachResult{IssueID: id, Direction: direction, MaxDepth: maxDepth, ByDepth: []int{}, Issues: []ReachIssue{}}
+	seen := map[string]bool{id: true}
+	frontier := []string{id}
+	for depth := 1; len(frontier) > 0; depth++ {
+		var level []ReachIssue
+		for _, from := range frontier {
+			for _, to := range next[from] {
+				if seen[to] {
+					continue
+				}
+				if maxDepth > 0 && depth > maxDepth {
+					result.Truncated = true
+					break
+				}
+				seen[to] = true
+				issue := byID[to]
+				level = append(level, ReachIssue{
+					ID:       to,
+					Title:    issue.Title,
+					Status:   string(issue.Status),
+					Priority: issue.Priority,
+					Depth:    depth,
+					Via:      from,
+				})
+			}
+		}
+		if len(level) == 0 {
+			break
+		}
+		sort.Slice(level, func(i, j int) bool { return level[i].ID < level[j].ID })
+		frontier = frontier[:0]
+		for _, r := range level {
+			frontier = append(frontier, r.ID)
+			if s := byID[r.ID].Status; !s.IsClosed() && !s.IsTombstone() {
+				result.OpenCount++
+			}
+		}
+		result.Issues = append(result.Issues, level...)
+		result.ByDepth = append(result.ByDepth, len(level))
+	}
+	result.Count = len(result.Issues)
+	return result, nil
+}
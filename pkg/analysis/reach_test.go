@@ -0,0 +1,61 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeReach(t *testing.T) {
+	blocks := func(id string) []*model.Dependency {
+		return []*model.Dependency{{DependsOnID: id, Type: model.DepBlocks}}
+	}
+	// A blocks B and C; B and C block D; D blocks E, which loops back to B.
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen},
+		{ID: "B", Status: model.StatusOpen, Dependencies: append(blocks("A"), &model.Dependency{DependsOnID: "E", Type: model.DepBlocks})},
+		{ID: "C", Status: model.StatusClosed, Dependencies: blocks("A")},
+		{ID: "D", Status: model.StatusOpen, Dependencies: append(blocks("B"), blocks("C")...)},
+		{ID: "E", Status: model.StatusOpen, Dependencies: append(blocks("D"), &model.Dependency{DependsOnID: "gone", Type: model.DepBlocks})},
+		{ID: "F", Status: model.StatusOpen, Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepRelated}}},
+	}
+	ids := func(r ReachResult) []string {
+		var out []string
+		for _, i := range r.Issues {
+			out = append(out, i.ID)
+		}
+		return out
+	}
+
+	down, err := ComputeReach(issues, "A", ReachDown, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := ids(down); !reflect.DeepEqual(got, []string{"B", "C", "D", "E"}) {
+		t.Errorf("down = %v", got)
+	}
+	if down.Count != 4 || down.OpenCount != 3 || !reflect.DeepEqual(down.ByDepth, []int{2, 1, 1}) || down.Truncated {
+		t.Errorf("down counts = %+v", down)
+	}
+	if d := down.Issues[2]; d.Depth != 2 || d.Via != "B" {
+		t.Errorf("D reached at depth %d via %s, want 2 via B", d.Depth, d.Via)
+	}
+
+	limited, _ := ComputeReach(issues, "A", ReachDown, 2)
+	if got := ids(limited); !reflect.DeepEqual(got, []string{"B", "C", "D"}) || !limited.Truncated {
+		t.Errorf("depth 2 = %v truncated=%v", got, limited.Truncated)
+	}
+
+	up, _ := ComputeReach(issues, "D", ReachUp, 0)
+	if got := ids(up); !reflect.DeepEqual(got, []string{"B", "C", "A", "E"}) {
+		t.Errorf("up = %v", got)
+	}
+
+	if _, err := ComputeReach(issues, "X", ReachUp, 0); err == nil {
+		t.Error("unknown issue should be an error")
+	}
+	if _, err := ComputeReach(issues, "A", "sideways", 0); err == nil {
+		t.Error("bad direction should be an error")
+	}
+}
@@ -0,0 +1,157 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// RedundantDependencyConfig configures redundant dependency detection
+type RedundantDependencyConfig struct {
+	// MaxSuggestions is the maximum number of redundant edges to report
+	// Default: 25
+	MaxSuggestions int
+
+	// IncludeCommands adds a `bd dep remove` action to each suggestion
+	// Default: true
+	IncludeCommands bool
+}
+
+// DefaultRedundantDependencyConfig returns sensible defaults
+func DefaultRedundantDependencyConfig() RedundantDependencyConfig {
+	return RedundantDependencyConfig{
+		MaxSuggestions:  25,
+		IncludeCommands: true,
+	}
+}
+
+// DetectRedundantDependencies finds blocking dependencies already implied by
+// a longer path (A → C when A → B → C exists), i.e. the edges a transitive
+// reduction would drop. They add nothing to the order of work but inflate
+// edge counts and density.
+//
+// Dependency cycles are left to cycle warnings: the graph is condensed first
+// and only edges between different components are considered, so removing
+// every reported edge at once never changes what waits on what.
+func DetectRedundantDependencies(issues []model.Issue, config RedundantDependencyConfig) []Suggestion {
+	if len(issues) < 3 {
+		return nil
+	}
+
+	cond := NewAnalyzer(issues).Condense()
+	succ := make(map[string][]string, len(cond.Nodes))
+	for _, e := range cond.Edges {
+		succ[e.From] = append(succ[e.From], e.To)
+	}
+
+	type redundantEdge struct {
+		from, to string
+		via      []string // Condensed path from → ... → to of length >= 2
+	}
+	var found []redundantEdge
+
+	for _, issue := range issues {
+		var deps []string
+		for _, dep := range issue.Dependencies {
+			if dep != nil && dep.Type.IsBlocking() && dep.DependsOnID != issue.ID {
+				deps = append(deps, dep.DependsOnID)
+			}
+		}
+		if len(deps) == 0 {
+			continue
+		}
+		from := cond.Component[issue.ID]
+		if len(succ[from]) < 2 {
+			continue
+		}
+		longer := longerPaths(from, succ)
+		for _, dep := range deps {
+			to, ok := cond.Component[dep]
+			if !ok || to == from {
+				continue
+			}
+			if via := longer[to]; via != nil {
+				found = append(found, redundantEdge{from: issue.ID, to: dep, via: via})
+			}
+		}
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].from != found[j].from {
+			return found[i].from < found[j].from
+		}
+		return found[i].to < found[j].to
+	})
+
+	var suggestions []Suggestion
+	for _, r := range found {
+		if config.MaxSuggestions > 0 && len(suggestions) >= config.MaxSuggestions {
+			break
+		}
+		// The ends of the condensed path may be cycles; name the issues instead
+		path := append([]string{r.from}, r.via[1:len(r.via)-1]...)
+		path = append(path, r.to)
+
+		sug := NewSuggestion(
+			SuggestionRedundantDependency,
+			r.from,
+			fmt.Sprintf("Redundant dependency: %s → %s", r.from, r.to),
+			fmt.Sprintf("%s already waits on %s through %s", r.from, r.to, strings.Join(path, " → ")),
+			0.9,
+		).WithRelatedBead(r.to).
+			WithMetadata("via", path).
+			WithMetadata("path_length", len(path)-1)
+		if config.IncludeCommands {
+			sug = sug.WithAction(fmt.Sprintf("bd dep remove %s %s", r.from, r.to))
+		}
+		suggestions = append(suggestions, sug)
+	}
+
+	return suggestions
+}
+
+// longerPaths finds every node reachable from start by a path of two or more
+// edges in the acyclic graph succ, with the shortest such path to each.
+func longerPaths(start string, succ map[string][]string) map[string][]string {
+	parent := make(map[string]string)
+	var queue []string
+	for _, first := range succ[start] {
+		for _, next := range succ[first] {
+			if _, seen := parent[next]; !seen {
+				parent[next] = first
+				queue = append(queue, next)
+			}
+		}
+	}
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+		for _, next := range succ[id] {
+			if _, seen := parent[next]; !seen {
+				parent[next] = id
+				queue = append(queue, next)
+			}
+		}
+	}
+
+	paths := make(map[string][]string, len(parent))
+	for id := range parent {
+		path := []string{id}
+		for cur := id; ; {
+			p := parent[cur]
+			path = append(path, p)
+			if _, ok := parent[p]; !ok {
+				break
+			}
+			cur = p
+		}
+		path = append(path, start)
+		for i, j := 0, len(path)-1; i < j; i, j = i+1, j-1 {
+			path[i], path[j] = path[j], path[i]
+		}
+		paths[id] = path
+	}
+	return paths
+}
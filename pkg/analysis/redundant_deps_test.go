@@ -0,0 +1,58 @@
+package analysis
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestDetectRedundantDependencies(t *testing.T) {
+	dep := func(ids ...string) []*model.Dependency {
+		var deps []*model.Dependency
+		for _, id := range ids {
+			deps = append(deps, &model.Dependency{DependsOnID: id, Type: model.DepBlocks})
+		}
+		return deps
+	}
+	// A -> B -> C -> D with shortcuts A -> C and A -> D. E -> G is related
+	// only, so not a shortcut. Z -> X and Z -> Y both lead into the X <-> Y
+	// cycle, which is left to cycle warnings.
+	issues := []model.Issue{
+		{ID: "A", Dependencies: dep("B", "C", "D")},
+		{ID: "B", Dependencies: dep("C")},
+		{ID: "C", Dependencies: dep("D")},
+		{ID: "D"},
+		{ID: "E", Dependencies: append(dep("F"), &model.Dependency{DependsOnID: "G", Type: model.DepRelated})},
+		{ID: "F", Dependencies: dep("G")},
+		{ID: "G"},
+		{ID: "X", Dependencies: dep("Y")},
+		{ID: "Y", Dependencies: dep("X")},
+		{ID: "Z", Dependencies: dep("X", "Y")},
+	}
+
+	got := DetectRedundantDependencies(issues, DefaultRedundantDependencyConfig())
+	if len(got) != 2 {
+		t.Fatalf("expected 2 redundant edges, got %d: %+v", len(got), got)
+	}
+	want := []struct{ from, to, cmd string }{
+		{"A", "C", "bd dep remove A C"},
+		{"A", "D", "bd dep remove A D"},
+	}
+	for i, w := range want {
+		s := got[i]
+		if s.Type != SuggestionRedundantDependency || s.TargetBead != w.from || s.RelatedBead != w.to || s.ActionCommand != w.cmd {
+			t.Errorf("suggestion %d = %+v, want %s -> %s", i, s, w.from, w.to)
+		}
+	}
+	if via := got[0].Metadata["via"].([]string); len(via) != 3 || via[1] != "B" {
+		t.Errorf("A -> C should be explained by A -> B -> C, got %v", via)
+	}
+
+	config := DefaultRedundantDependencyConfig()
+	config.IncludeCommands = false
+	config.MaxSuggestions = 1
+	got = DetectRedundantDependencies(issues, config)
+	if len(got) != 1 || got[0].ActionCommand != "" {
+		t.Errorf("expected one suggestion without a command, got %+v", got)
+	}
+}
@@ -0,0 +1,179 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/plural"
+)
+
+// ReviewLabels mark an issue as waiting for review
+var ReviewLabels = []string{"needs-review", "in-review", "review"}
+
+// MaxSuggestedReviewers caps the reviewers suggested per issue
+const MaxSuggestedReviewers = 3
+
+// Reviewer scoring: owning the files counts most, recent commits to them
+// next, and each issue a candidate already has in progress counts against
+// them.
+const (
+	reviewerOwnerScore       = 1.0
+	reviewerCoOwnerScore     = 0.7 // Owners after the first
+	reviewerCommitScore      = 1.0 // For the most commits among the candidates
+	reviewerLoadPenalty      = 0.25
+	reviewerMaxLoadPenalized = 4
+)
+
+// ReviewerSuggestion is a suggested reviewer for an issue in review
+type ReviewerSuggestion struct {
+	Reviewer  string   `json:"reviewer"`
+	Score     float64  `json:"score"`
+	Load      int      `json:"load"` // Issues they have in progress
+	Rationale []string `json:"rationale"`
+}
+
+// IsInReview reports whether the issue is open and carries a review label
+func IsInReview(issue *model.Issue) bool {
+	if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+		return false
+	}
+	for _, label := range issue.Labels {
+		for _, review := range ReviewLabels {
+			if strings.EqualFold(label, review) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// reviewerCandidate gathers one person's signals for one issue
+type reviewerCandidate struct {
+	name    string   // Owner handle when they own files, else author name
+	aliases []string // Every name they go by, normalized
+	owner   string
+	first   bool // First owner listed
+	commits int
+}
+
+func (c *reviewerCandidate) is(name string) bool {
+	key := normalizeReviewer(name)
+	for _, alias := range c.aliases {
+		if alias == key {
+			return true
+		}
+	}
+	return false
+}
+
+// normalizeReviewer folds @handles, names, and emails to comparable keys
+func normalizeReviewer(name string) string {
+	return strings.ToLower(strings.TrimPrefix(strings.TrimSpace(name), "@"))
+}
+
+// SuggestReviewers ranks reviewers for an issue from the owners of its
+// files, who recently committed to them, and how much each already has in
+// progress across issues. The assignee, who did the work, is left out.
+func SuggestReviewers(issue *model.Issue, issues map[string]model.Issue, owners []string, authors []correlation.FileAuthor) []ReviewerSuggestion {
+	var candidates []*reviewerCandidate
+	find := func(names ...string) *reviewerCandidate {
+		for _, c := range candidates {
+			for _, n := range names {
+				if n != "" && c.is(n) {
+					return c
+				}
+			}
+		}
+		return nil
+	}
+	for i, owner := range owners {
+		if find(owner) != nil {
+			continue
+		}
+		candidates = append(candidates, &reviewerCandidate{
+			name:    owner,
+			aliases: []string{normalizeReviewer(owner)},
+			owner:   owner,
+			first:   i == 0,
+		})
+	}
+	for _, a := range authors {
+		local, _, _ := strings.Cut(a.Email, "@")
+		c := find(a.Email, a.Name, local)
+		if c == nil {
+			c = &reviewerCandidate{name: a.Name}
+			candidates = append(candidates, c)
+		}
+		for _, alias := range []string{a.Email, a.Name, local} {
+			if alias != "" && !c.is(alias) {
+				c.aliases = append(c.aliases, normalizeReviewer(alias))
+			}
+		}
+		c.commits += a.Commits
+	}
+	eligible := func(c *reviewerCandidate) bool {
+		return issue.Assignee == "" || !c.is(issue.Assignee)
+	}
+	maxCommits := 0
+	for _, c := range candidates {
+		if eligible(c) && c.commits > maxCommits {
+			maxCommits = c.commits
+		}
+	}
+
+	var suggestions []ReviewerSuggestion
+	for _, c := range candidates {
+		if !eligible(c) {
+			continue
+		}
+		s := ReviewerSuggestion{Reviewer: c.name}
+		if c.owner != "" {
+			if c.first {
+				s.Score += reviewerOwnerScore
+			} else {
+				s.Score += reviewerCoOwnerScore
+			}
+			s.Rationale = append(s.Rationale, "owns its files (CODEOWNERS)")
+		}
+		if c.commits > 0 {
+			s.Score += reviewerCommitScore * float64(c.commits) / float64(maxCommits)
+			s.Rationale = append(s.Rationale, fmt.Sprintf("%d recent %s to its files", c.commits, plural.Word(c.commits, "commit")))
+		}
+		for _, other := range issues {
+			if other.ID != issue.ID && other.Status == model.StatusInProgress && other.Assignee != "" && c.is(other.Assignee) {
+				s.Load++
+			}
+		}
+		if s.Load > 0 {
+			s.Score -= reviewerLoadPenalty * float64(min(s.Load, reviewerMaxLoadPenalized))
+			s.Rationale = append(s.Rationale, fmt.Sprintf("%d %s in progress", s.Load, plural.Word(s.Load, "issue")))
+		}
+		suggestions = append(suggestions, s)
+	}
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].Score > suggestions[j].Score
+	})
+	if len(suggestions) > MaxSuggestedReviewers {
+		suggestions = suggestions[:MaxSuggestedReviewers]
+	}
+	return suggestions
+}
+
+// suggestReviewers fills in reviewers for each recommendation in review
+func suggestReviewers(recs []Recommendation, analyzer *Analyzer, owners map[string][]string, authors map[string][]correlation.FileAuthor) {
+	for i := range recs {
+		rec := &recs[i]
+		issue := analyzer.GetIssue(rec.ID)
+		if issue == nil || !IsInReview(issue) {
+			continue
+		}
+		rec.SuggestedReviewers = SuggestReviewers(issue, analyzer.issueMap, owners[rec.ID], authors[rec.ID])
+		if len(rec.SuggestedReviewers) > 0 {
+			top := rec.SuggestedReviewers[0]
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("🔍 In review; suggest %s: %s", top.Reviewer, strings.Join(top.Rationale, ", ")))
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package analysis
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestIsInReview(t *testing.T) {
+	tests := []struct {
+		issue model.Issue
+		want  bool
+	}{
+		{model.Issue{Status: model.StatusInProgress, Labels: []string{"auth", "Needs-Review"}}, true},
+		{model.Issue{Status: model.StatusOpen, Labels: []string{"review"}}, true},
+		{model.Issue{Status: model.StatusClosed, Labels: []string{"needs-review"}}, false},
+		{model.Issue{Status: model.StatusOpen, Labels: []string{"reviewed"}}, false},
+	}
+	for _, tt := range tests {
+		if got := IsInReview(&tt.issue); got != tt.want {
+			t.Errorf("IsInReview(%s %v) = %v", tt.issue.Status, tt.issue.Labels, got)
+		}
+	}
+}
+
+func TestSuggestReviewers(t *testing.T) {
+	issue := model.Issue{ID: "bv-1", Status: model.StatusInProgress, Assignee: "dee", Labels: []string{"needs-review"}}
+	issues := map[string]model.Issue{
+		"bv-1": issue,
+		"bv-2": {ID: "bv-2", Status: model.StatusInProgress, Assignee: "@Ann"},
+		"bv-3": {ID: "bv-3", Status: model.StatusInProgress, Assignee: "ann"},
+		"bv-5": {ID: "bv-5", Status: model.StatusInProgress, Assignee: "Ann Lee"},
+		"bv-4": {ID: "bv-4", Status: model.StatusClosed, Assignee: "bob"},
+	}
+	owners := []string{"@ann", "@org/security"}
+	authors := []correlation.FileAuthor{
+		{Name: "Bob Smith", Email: "bob@example.com", Commits: 4},
+		{Name: "Ann Lee", Email: "ann@example.com", Commits: 2},
+		{Name: "Dee", Email: "dee@example.com", Commits: 6},
+	}
+	got := SuggestReviewers(&issue, issues, owners, authors)
+	var names []string
+	for _, s := range got {
+		names = append(names, s.Reviewer)
+	}
+	// Ann owns the files but has three issues in progress; Dee did the work
+	if want := "Bob Smith @ann @org/security"; strings.Join(names, " ") != want {
+		t.Fatalf("reviewers = %q, want %s", names, want)
+	}
+	ann := got[1]
+	if ann.Load != 3 || len(ann.Rationale) != 3 || ann.Rationale[1] != "2 recent commits to its files" {
+		t.Errorf("ann = %+v", ann)
+	}
+	if got[0].Rationale[0] != "4 recent commits to its files" || got[0].Load != 0 {
+		t.Errorf("bob = %+v", got[0])
+	}
+}
+
+func TestComputeTriage_SuggestedReviewers(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Token refresh", Status: model.StatusInProgress, Priority: 1, Assignee: "dee", Labels: []string{"needs-review"}, CreatedAt: now, UpdatedAt: now},
+		{ID: "bv-2", Title: "Other", Status: model.StatusOpen, Priority: 2, CreatedAt: now, UpdatedAt: now},
+	}
+	opts := TriageOptions{
+		Owners: map[string][]string{"bv-1": {"@ann"}, "bv-2": {"@ann"}},
+		FileAuthors: map[string][]correlation.FileAuthor{"bv-1": {
+			{Name: "Bob", Email: "bob@example.com", Commits: 3},
+			{Name: "Ann", Email: "ann@example.com", Commits: 1},
+		}},
+	}
+	result := ComputeTriageWithOptionsAndTime(issues, opts, now)
+	for _, rec := range result.Recommendations {
+		switch rec.ID {
+		case "bv-1":
+			if len(rec.SuggestedReviewers) != 2 || rec.SuggestedReviewers[0].Reviewer != "@ann" {
+				t.Errorf("bv-1 reviewers = %+v", rec.SuggestedReviewers)
+			}
+			if !strings.Contains(strings.Join(rec.Reasons, "\n"), "🔍 In review; suggest @ann: owns its files (CODEOWNERS), 1 recent commit to its files") {
+				t.Errorf("bv-1 reasons = %q", rec.Reasons)
+			}
+		case "bv-2":
+			if rec.SuggestedReviewers != nil {
+				t.Errorf("bv-2 isn't in review: %+v", rec.SuggestedReviewers)
+			}
+		}
+	}
+}
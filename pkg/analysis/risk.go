@@ -1,6 +1,7 @@
 package analysis
 
 import (
+	"fmt"
 	"math"
 	"time"
 
@@ -24,6 +25,12 @@ type RiskSignals struct {
 	// StatusRisk indicates risk from current status (blocked = higher risk)
 	StatusRisk float64 `json:"status_risk"`
 
+	// CodeAge is how long the issue's likely files have gone unchanged,
+	// from git blame (0 = just changed, 1 = a year or more). Only set when
+	// the issue has files in the repository; see ApplyCodeAge.
+	CodeAge     float64 `json:"code_age,omitempty"`
+	CodeAgeDays *int    `json:"code_age_days,omitempty"`
+
 	// CompositeRisk is the weighted combination of all risk signals (0-1)
 	CompositeRisk float64 `json:"composite_risk"`
 
@@ -49,6 +56,13 @@ func DefaultRiskWeights() RiskWeights {
 	}
 }
 
+// CodeAgeRiskWeight is the share of CompositeRisk that code age takes,
+// for issues that have it; the other signals make up the rest.
+const CodeAgeRiskWeight = 0.30
+
+// codeAgeFullDays is how long code must go unchanged for full code-age risk
+const codeAgeFullDays = 365
+
 // ComputeRiskSignals calculates risk metrics for a single issue
 func ComputeRiskSignals(
 	issue *model.Issue,
@@ -98,6 +112,21 @@ func ComputeRiskSignalsWithWeights(
 	return signals
 }
 
+// ApplyCodeAge blends the age of the issue's code, last changed at
+// lastChanged, into signals. Code nobody has touched in a year is riskier
+// to change than code under active work: fewer people remember it, and
+// its tests have had less exercise.
+func ApplyCodeAge(signals *RiskSignals, lastChanged, now time.Time) {
+	days := int(now.Sub(lastChanged).Hours() / 24)
+	if days < 0 {
+		days = 0
+	}
+	signals.CodeAgeDays = &days
+	signals.CodeAge = math.Min(float64(days)/codeAgeFullDays, 1.0)
+	signals.CompositeRisk = signals.CompositeRisk*(1-CodeAgeRiskWeight) + signals.CodeAge*CodeAgeRiskWeight
+	signals.Explanation = generateRiskExplanation(*signals)
+}
+
 // computeFanVariance measures variance in blocker In-Degrees (upstream stability variance)
 func computeFanVariance(issue *model.Issue, stats *GraphStats) float64 {
 	// Collect In-Degrees of blocking dependencies
@@ -267,6 +296,9 @@ func computeStatusRisk(issue *model.Issue, now time.Time) float64 {
 // generateRiskExplanation creates a human-readable risk assessment
 func generateRiskExplanation(signals RiskSignals) string {
 	if signals.CompositeRisk < 0.2 {
+		if signals.CodeAgeDays != nil && signals.CodeAge <= 0.5 {
+			return "Low risk - stable dependency structure, recently changed code"
+		}
 		return "Low risk - stable dependency structure"
 	}
 
@@ -284,6 +316,9 @@ func generateRiskExplanation(signals RiskSignals) string {
 	if signals.StatusRisk > 0.5 {
 		explanations = append(explanations, "status indicates potential blockers")
 	}
+	if signals.CodeAge > 0.5 && signals.CodeAgeDays != nil {
+		explanations = append(explanations, fmt.Sprintf("code untouched for %d days", *signals.CodeAgeDays))
+	}
 
 	if len(explanations) == 0 {
 		return "Moderate risk"
@@ -1,6 +1,8 @@
 package analysis
 
 import (
+	"math"
+	"strings"
 	"testing"
 	"time"
 
@@ -422,3 +424,56 @@ func TestImpactScore_IncludesRisk(t *testing.T) {
 		t.Errorf("score %f doesn't match sum of components %f", score.Score, expectedComponents)
 	}
 }
+
+func TestApplyCodeAge(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	cold := RiskSignals{CompositeRisk: 0.2}
+	ApplyCodeAge(&cold, now.AddDate(-2, 0, 0), now)
+	if cold.CodeAge != 1.0 || *cold.CodeAgeDays != 730 {
+		t.Errorf("two-year-old code: age=%f days=%d", cold.CodeAge, *cold.CodeAgeDays)
+	}
+	if want := 0.2*(1-CodeAgeRiskWeight) + CodeAgeRiskWeight; math.Abs(cold.CompositeRisk-want) > 1e-9 {
+		t.Errorf("composite = %f, want %f", cold.CompositeRisk, want)
+	}
+	if !strings.Contains(cold.Explanation, "code untouched for 730 days") {
+		t.Errorf("explanation = %q", cold.Explanation)
+	}
+
+	hot := RiskSignals{CompositeRisk: 0.2}
+	ApplyCodeAge(&hot, now.Add(-time.Hour), now)
+	if hot.CodeAge != 0 || *hot.CodeAgeDays != 0 || hot.CompositeRisk >= 0.2 {
+		t.Errorf("code changed today: age=%f composite=%f", hot.CodeAge, hot.CompositeRisk)
+	}
+}
+
+func TestImpactScore_CodeAge(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "cold", Title: "Cold", Status: model.StatusOpen, Priority: 0, CreatedAt: now, UpdatedAt: now},
+		{ID: "hot", Title: "Hot", Status: model.StatusOpen, Priority: 0, CreatedAt: now, UpdatedAt: now},
+		{ID: "none", Title: "No files", Status: model.StatusOpen, Priority: 0, CreatedAt: now, UpdatedAt: now},
+	}
+	analyzer := NewAnalyzer(issues)
+	cfg := analyzer.Config()
+	cfg.CodeLastChanged = map[string]time.Time{"cold": now.AddDate(-1, -1, 0), "hot": now}
+	analyzer.SetConfig(&cfg)
+	stats := analyzer.Analyze()
+
+	byID := make(map[string]ImpactScore)
+	for _, s := range analyzer.ComputeImpactScoresFromStats(&stats, now) {
+		byID[s.IssueID] = s
+	}
+	if got := byID["cold"].Breakdown.CodeAge; math.Abs(got-CodeAgeRiskWeight*WeightRisk) > 1e-9 {
+		t.Errorf("cold code_age contribution = %f", got)
+	}
+	if byID["hot"].Breakdown.CodeAge != 0 || byID["hot"].Breakdown.RiskSignals.CodeAgeDays == nil {
+		t.Errorf("hot code should have an age but no contribution: %+v", byID["hot"].Breakdown.RiskSignals)
+	}
+	if byID["none"].Breakdown.RiskSignals.CodeAgeDays != nil {
+		t.Error("an issue without files has no code age")
+	}
+	if byID["cold"].Score <= byID["hot"].Score {
+		t.Errorf("cold code should score higher: %f <= %f", byID["cold"].Score, byID["hot"].Score)
+	}
+}
@@ -0,0 +1,159 @@
+package analysis
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultStaleSweepDays is how long an unfinished issue can go untouched
+// before the stale sweep lists it.
+const DefaultStaleSweepDays = 30
+
+// Stale sweep actions.
+const (
+	StaleActionClose        = "close"        // close as stale
+	StaleActionDeprioritize = "deprioritize" // drop one priority level
+	StaleActionPing         = "ping"         // ask the assignee through the notify hooks
+)
+
+// StaleIssue is one issue the sweep found untouched.
+type StaleIssue struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	IssueType    string    `json:"issue_type"`
+	Status       string    `json:"status"`
+	Priority     int       `json:"priority"`
+	Assignee     string    `json:"assignee,omitempty"`
+	LastActivity time.Time `json:"last_activity"`
+	IdleDays     int       `json:"idle_days"`
+	Suggested    string    `json:"suggested_action"`
+	// bd commands for the actions bd carries out, keyed by action
+	Commands map[string]string `json:"commands"`
+}
+
+// StaleGroup is the stale issues of one type.
+type StaleGroup struct {
+	IssueType string       `json:"issue_type"`
+	Count     int          `json:"count"`
+	Issues    []StaleIssue `json:"issues"`
+}
+
+// StaleSweep lists unfinished issues untouched for at least ThresholdDays,
+// grouped by type, largest group first.
+type StaleSweep struct {
+	ThresholdDays int          `json:"threshold_days"`
+	Total         int          `json:"total"`
+	Groups        []StaleGroup `json:"groups"`
+}
+
+// ComputeStaleSweep finds unfinished issues whose last update (or creation,
+// if never updated) is at least days old. Within a group the longest idle
+// come first. days <= 0 means DefaultStaleSweepDays.
+func ComputeStaleSweep(issues []model.Issue, now time.Time, days int) StaleSweep {
+	if days <= 0 {
+		days = DefaultStaleSweepDays
+	}
+	sweep := StaleSweep{ThresholdDays: days, Groups: []StaleGroup{}}
+	byType := make(map[string][]StaleIssue)
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsClosed() || issue.Status.IsTombstone() {
+			continue
+		}
+		last := issue.UpdatedAt
+		if last.IsZero() {
+			last = issue.CreatedAt
+		}
+		if last.IsZero() {
+			continue
+		}
+		idle := int(now.Sub(last).Hours() / 24)
+		if idle < days {
+			continue
+		}
+		s := StaleIssue{
+			ID:           issue.ID,
+			Title:        issue.Title,
+			IssueType:    string(issue.IssueType),
+			Status:       string(issue.Status),
+			Priority:     issue.Priority,
+			Assignee:     issue.Assignee,
+			LastActivity: last,
+			IdleDays:     idle,
+			Commands:     make(map[string]string),
+		}
+		s.Suggested = suggestStaleAction(s, days)
+		for _, action := range []string{StaleActionClose, StaleActionDeprioritize} {
+			if args := StaleActionArgs(s, action); args != nil {
+				s.Commands[action] = bdCommandLine(args)
+			}
+		}
+		byType[s.IssueType] = append(byType[s.IssueType], s)
+		sweep.Total++
+	}
+
+	for issueType, group := range byType {
+		sort.Slice(group, func(i, j int) bool {
+			if group[i].IdleDays != group[j].IdleDays {
+				return group[i].IdleDays > group[j].IdleDays
+			}
+			return group[i].ID < group[j].ID
+		})
+		sweep.Groups = append(sweep.Groups, StaleGroup{IssueType: issueType, Count: len(group), Issues: group})
+	}
+	sort.Slice(sweep.Groups, func(i, j int) bool {
+		if sweep.Groups[i].Count != sweep.Groups[j].Count {
+			return sweep.Groups[i].Count > sweep.Groups[j].Count
+		}
+		return sweep.Groups[i].IssueType < sweep.Groups[j].IssueType
+	})
+	return sweep
+}
+
+// suggestStaleAction picks the default action: someone owns the issue, so
+// ask them; nobody does and it is low priority or has sat twice the
+// threshold, so close it; otherwise let it sink a priority level.
+func suggestStaleAction(s StaleIssue, days int) string {
+	switch {
+	case s.Assignee != "":
+		return StaleActionPing
+	case s.Priority >= 3 || s.IdleDays >= 2*days:
+		return StaleActionClose
+	default:
+		return StaleActionDeprioritize
+	}
+}
+
+// StaleActionArgs returns the bd arguments that apply action to s, or nil
+// when bd has nothing to do: pings go through the notify hooks, and a P4
+// issue can't be deprioritized further.
+func StaleActionArgs(s StaleIssue, action string) []string {
+	switch action {
+	case StaleActionClose:
+		return []string{"close", s.ID, "--reason", fmt.Sprintf("stale: no activity in %d days", s.IdleDays)}
+	case StaleActionDeprioritize:
+		if s.Priority >= 4 {
+			return nil
+		}
+		return []string{"update", s.ID, fmt.Sprintf("--priority=%d", s.Priority+1)}
+	default:
+		return nil
+	}
+}
+
+// bdCommandLine renders bd args as a shell command, quoting arguments
+// that contain spaces.
+func bdCommandLine(args []string) string {
+	line := "bd"
+	for _, a := range args {
+		if strings.ContainsAny(a, " \t") {
+			a = fmt.Sprintf("%q", a)
+		}
+		line += " " + a
+	}
+	return line
+}
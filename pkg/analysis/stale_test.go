@@ -0,0 +1,59 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeStaleSweep(t *testing.T) {
+	now := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	ago := func(days int) time.Time { return now.Add(-time.Duration(days) * 24 * time.Hour) }
+	issues := []model.Issue{
+		{ID: "B1", IssueType: model.TypeBug, Status: model.StatusOpen, Priority: 1, UpdatedAt: ago(40)},
+		{ID: "B2", IssueType: model.TypeBug, Status: model.StatusOpen, Priority: 3, UpdatedAt: ago(31)},
+		{ID: "B3", IssueType: model.TypeBug, Status: model.StatusOpen, Priority: 1, UpdatedAt: ago(5)},
+		{ID: "T1", IssueType: model.TypeTask, Status: model.StatusInProgress, Priority: 2, Assignee: "alice", UpdatedAt: ago(90)},
+		{ID: "T2", IssueType: model.TypeTask, Status: model.StatusClosed, UpdatedAt: ago(90)},
+		{ID: "T3", IssueType: model.TypeTask, Status: model.StatusOpen, Priority: 1, CreatedAt: ago(70)}, // never updated
+		{ID: "T4", IssueType: model.TypeTask, Status: model.StatusOpen, Priority: 4, UpdatedAt: ago(30)},
+	}
+	sweep := ComputeStaleSweep(issues, now, 0)
+	if sweep.ThresholdDays != DefaultStaleSweepDays || sweep.Total != 5 {
+		t.Fatalf("sweep = %+v", sweep)
+	}
+
+	var got []string
+	for _, g := range sweep.Groups {
+		for _, s := range g.Issues {
+			got = append(got, g.IssueType+"/"+s.ID+":"+s.Suggested)
+		}
+	}
+	want := []string{
+		"task/T1:ping", "task/T3:close", "task/T4:close", // three tasks first, longest idle first
+		"bug/B1:deprioritize", "bug/B2:close",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("sweep = %v, want %v", got, want)
+	}
+
+	t4 := sweep.Groups[0].Issues[2]
+	if _, ok := t4.Commands[StaleActionDeprioritize]; ok {
+		t.Errorf("P4 issue should have no deprioritize command: %v", t4.Commands)
+	}
+	if got := t4.Commands[StaleActionClose]; got != `bd close T4 --reason "stale: no activity in 30 days"` {
+		t.Errorf("close command = %q", got)
+	}
+	if got := sweep.Groups[1].Issues[0].Commands[StaleActionDeprioritize]; got != "bd update B1 --priority=2" {
+		t.Errorf("deprioritize command = %q", got)
+	}
+	if StaleActionArgs(t4, StaleActionPing) != nil {
+		t.Error("pings are not bd commands")
+	}
+
+	if empty := ComputeStaleSweep(issues, now, 365); empty.Total != 0 || empty.Groups == nil {
+		t.Errorf("nothing is a year old: %+v", empty)
+	}
+}
@@ -21,6 +21,12 @@ type SuggestAllConfig struct {
 	// Cycles warning config
 	Cycles CycleWarningConfig
 
+	// EpicClusters proposal config
+	EpicClusters EpicClusterConfig
+
+	// RedundantDependencies detection config
+	RedundantDependencies RedundantDependencyConfig
+
 	// EnableDuplicates enables duplicate detection
 	EnableDuplicates bool
 
@@ -33,6 +39,12 @@ type SuggestAllConfig struct {
 	// EnableCycles enables cycle warnings
 	EnableCycles bool
 
+	// EnableEpicClusters enables epic proposals from text clustering
+	EnableEpicClusters bool
+
+	// EnableRedundantDependencies enables transitive-reduction cleanup suggestions
+	EnableRedundantDependencies bool
+
 	// MinConfidence filters suggestions below this threshold
 	MinConfidence float64
 
@@ -49,16 +61,20 @@ type SuggestAllConfig struct {
 // DefaultSuggestAllConfig returns sensible defaults with all features enabled
 func DefaultSuggestAllConfig() SuggestAllConfig {
 	return SuggestAllConfig{
-		Duplicates:         DefaultDuplicateConfig(),
-		Dependencies:       DefaultDependencySuggestionConfig(),
-		Labels:             DefaultLabelSuggestionConfig(),
-		Cycles:             DefaultCycleWarningConfig(),
-		EnableDuplicates:   true,
-		EnableDependencies: true,
-		EnableLabels:       true,
-		EnableCycles:       true,
-		MinConfidence:      0.0,
-		MaxSuggestions:     50,
+		Duplicates:                  DefaultDuplicateConfig(),
+		Dependencies:                DefaultDependencySuggestionConfig(),
+		Labels:                      DefaultLabelSuggestionConfig(),
+		Cycles:                      DefaultCycleWarningConfig(),
+		EpicClusters:                DefaultEpicClusterConfig(),
+		RedundantDependencies:       DefaultRedundantDependencyConfig(),
+		EnableDuplicates:            true,
+		EnableDependencies:          true,
+		EnableLabels:                true,
+		EnableCycles:                true,
+		EnableEpicClusters:          true,
+		EnableRedundantDependencies: true,
+		MinConfidence:               0.0,
+		MaxSuggestions:              50,
 	}
 }
 
@@ -87,6 +103,16 @@ func GenerateAllSuggestions(issues []model.Issue, config SuggestAllConfig, dataH
 		allSuggestions = append(allSuggestions, cycles...)
 	}
 
+	if config.EnableEpicClusters && (config.FilterType == "" || config.FilterType == SuggestionEpicCluster) {
+		epics := DetectEpicClusters(issues, config.EpicClusters)
+		allSuggestions = append(allSuggestions, epics...)
+	}
+
+	if config.EnableRedundantDependencies && (config.FilterType == "" || config.FilterType == SuggestionRedundantDependency) {
+		redundant := DetectRedundantDependencies(issues, config.RedundantDependencies)
+		allSuggestions = append(allSuggestions, redundant...)
+	}
+
 	// Apply filters
 	filtered := make([]Suggestion, 0, len(allSuggestions))
 	for _, sug := range allSuggestions {
@@ -156,7 +182,10 @@ func GenerateRobotSuggestOutput(issues []model.Issue, config SuggestAllConfig, d
 			"jq '.suggestions.suggestions[] | select(.confidence >= 0.8)' - High-confidence only",
 			"jq '.suggestions.stats.by_type' - Count by suggestion type",
 			"jq '.suggestions.suggestions[].action_command' - All action commands",
+			"jq '.suggestions.suggestions[] | select(.type==\"epic_cluster\") | .metadata.issue_ids' - Issues to group under proposed epics",
 			"--suggest-type=dependency - Filter to dependency suggestions",
+			"--suggest-type=epic - Only epic proposals from text clustering",
+			"--suggest-type=redundant - Dependencies implied by longer paths, with bd dep remove commands",
 			"--suggest-confidence=0.7 - Minimum confidence threshold",
 			"--suggest-bead=<id> - Suggestions for specific bead",
 		},
@@ -22,6 +22,12 @@ const (
 
 	// SuggestionCycleWarning warns about potential dependency cycles
 	SuggestionCycleWarning SuggestionType = "cycle_warning"
+
+	// SuggestionEpicCluster proposes grouping similar loose issues under an epic
+	SuggestionEpicCluster SuggestionType = "epic_cluster"
+
+	// SuggestionRedundantDependency flags a dependency implied by a longer path
+	SuggestionRedundantDependency SuggestionType = "redundant_dependency"
 )
 
 // Suggestion represents a smart recommendation for project hygiene
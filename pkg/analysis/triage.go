@@ -7,7 +7,10 @@ import (
 	"sort"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 )
 
 func isClosedLikeStatus(status model.Status) bool {
@@ -19,6 +22,7 @@ func isClosedLikeStatus(status model.Status) bool {
 type TriageResult struct {
 	Meta            TriageMeta       `json:"meta"`
 	QuickRef        QuickRef         `json:"quick_ref"`
+	Pinned          []PinnedItem     `json:"pinned,omitempty"` // Issues the user starred in bv, whatever their score
 	Recommendations []Recommendation `json:"recommendations"`
 	QuickWins       []QuickWin       `json:"quick_wins"`
 	BlockersToClear []BlockerItem    `json:"blockers_to_clear"`
@@ -39,6 +43,8 @@ type TriageMeta struct {
 	Phase2Ready   bool      `json:"phase2_ready"`
 	IssueCount    int       `json:"issue_count"`
 	ComputeTimeMs int64     `json:"compute_time_ms"`
+	// PageRankFocus is set when PageRank was personalized toward a seed set
+	PageRankFocus *PageRankFocus `json:"pagerank_focus,omitempty"`
 }
 
 // QuickRef provides at-a-glance summary for fast decisions
@@ -73,6 +79,26 @@ type Recommendation struct {
 	Reasons     []string       `json:"reasons"`
 	UnblocksIDs []string       `json:"unblocks_ids,omitempty"`
 	BlockedBy   []string       `json:"blocked_by,omitempty"`
+
+	// Owners own the issue's likely files per CODEOWNERS (TriageOptions.Owners);
+	// SuggestedAssignee is the first of them, for an unassigned issue
+	Owners            []string `json:"owners,omitempty"`
+	SuggestedAssignee string   `json:"suggested_assignee,omitempty"`
+
+	// SuggestedReviewers rank who should review an issue in review (see
+	// IsInReview), best first, each with its rationale
+	SuggestedReviewers []ReviewerSuggestion `json:"suggested_reviewers,omitempty"`
+}
+
+// PinnedItem is an issue the user starred in the TUI
+type PinnedItem struct {
+	ID         string   `json:"id"`
+	Title      string   `json:"title"`
+	Status     string   `json:"status"`
+	Priority   int      `json:"priority"`
+	Score      float64  `json:"score"`
+	Actionable bool     `json:"actionable"`
+	BlockedBy  []string `json:"blocked_by,omitempty"`
 }
 
 // QuickWin represents a low-effort, high-impact item
@@ -299,6 +325,31 @@ type TriageOptions struct {
 	// bv-87: Track/label-aware recommendation grouping for multi-agent coordination
 	GroupByTrack bool // Group recommendations by execution track (connected component)
 	GroupByLabel bool // Group recommendations by primary label
+
+	// Focus personalizes PageRank toward its seeds (see ResolvePageRankFocus)
+	Focus *PageRankFocus
+
+	// ScoreRules adjust impact scores (see AnalysisConfig.ScoreRules).
+	// ComputeTriageFromAnalyzer takes them from the analyzer's config instead.
+	ScoreRules *rules.Set
+
+	// Pinned lists the issue IDs the user starred (pkg/pins), in pin order,
+	// for the pinned section
+	Pinned []string
+
+	// Owners maps issue IDs to the owners of their likely files, most files
+	// first (see codeowners.File.IssueOwners), for assignee suggestions
+	Owners map[string][]string
+
+	// CodeLastChanged feeds code age into risk (see
+	// AnalysisConfig.CodeLastChanged); ComputeTriageFromAnalyzer takes it
+	// from the analyzer's config instead.
+	CodeLastChanged map[string]time.Time
+
+	// FileAuthors maps issue IDs to who recently committed to their likely
+	// files (see correlation.FileAuthors), for reviewer suggestions on
+	// issues in review
+	FileAuthors map[string][]correlation.FileAuthor
 }
 
 // TrackRecommendationGroup groups recommendations by execution track (bv-87)
@@ -331,12 +382,17 @@ func ComputeTriageWithOptionsAndTime(issues []model.Issue, opts TriageOptions, n
 	analyzer := NewAnalyzer(issues)
 
 	// Use fast config for triage-only analysis (bv-t1js optimization)
-	var stats *GraphStats
+	config := analyzer.Config()
 	if opts.UseFastConfig {
-		stats = analyzer.AnalyzeAsyncWithConfig(context.Background(), TriageConfig())
-	} else {
-		stats = analyzer.AnalyzeAsync(context.Background())
+		config = TriageConfig()
+	}
+	if opts.Focus != nil {
+		config.PageRankFocus = opts.Focus.Seeds
 	}
+	config.ScoreRules = opts.ScoreRules
+	config.CodeLastChanged = opts.CodeLastChanged
+	analyzer.SetConfig(&config)
+	stats := analyzer.AnalyzeAsyncWithConfig(context.Background(), config)
 
 	// Triage requires advanced metrics (PageRank, etc.) for scoring.
 	// If requested, wait for Phase 2 to complete.
@@ -395,6 +451,8 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	// Build recommendations using enhanced scores (bv-148)
 	// Pass triageCtx instead of analyzer for cached blocker lookups (bv-k4az)
 	recommendations := buildRecommendationsFromTriageScores(triageScores, triageCtx, opts.TopN)
+	suggestAssignees(recommendations, analyzer, opts.Owners)
+	suggestReviewers(recommendations, analyzer, opts.Owners, opts.FileAuthors)
 
 	// Build quick wins
 	quickWins := buildQuickWins(impactScores, unblocksMap, opts.QuickWinN)
@@ -405,6 +463,8 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 	// Build top picks for quick ref
 	topPicks := buildTopPicks(recommendations, 3)
 
+	pinned := buildPinned(opts.Pinned, triageScores, triageCtx)
+
 	// Determine top issue for commands
 	topID := ""
 	if len(recommendations) > 0 {
@@ -431,6 +491,7 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 			Phase2Ready:   stats.IsPhase2Ready(),
 			IssueCount:    len(issues),
 			ComputeTimeMs: elapsed.Milliseconds(),
+			PageRankFocus: opts.Focus,
 		},
 		QuickRef: QuickRef{
 			OpenCount:       counts.Open,
@@ -439,6 +500,7 @@ func ComputeTriageFromAnalyzer(analyzer *Analyzer, stats *GraphStats, issues []m
 			InProgressCount: counts.ByStatus["in_progress"],
 			TopPicks:        topPicks,
 		},
+		Pinned:                 pinned,
 		Recommendations:        recommendations,
 		QuickWins:              quickWins,
 		BlockersToClear:        blockersToClear,
@@ -643,6 +705,22 @@ func buildRecommendationsFromTriageScores(scores []TriageScore, ctx *TriageConte
 	return recommendations
 }
 
+// suggestAssignees fills in each recommendation's owners, and for an
+// unassigned issue suggests its first owner as the assignee.
+func suggestAssignees(recs []Recommendation, analyzer *Analyzer, owners map[string][]string) {
+	for i := range recs {
+		rec := &recs[i]
+		rec.Owners = owners[rec.ID]
+		if len(rec.Owners) == 0 {
+			continue
+		}
+		if issue := analyzer.GetIssue(rec.ID); issue != nil && issue.Assignee == "" {
+			rec.SuggestedAssignee = rec.Owners[0]
+			rec.Reasons = append(rec.Reasons, fmt.Sprintf("👤 Unassigned; %s owns its files (CODEOWNERS)", rec.Owners[0]))
+		}
+	}
+}
+
 // buildQuickWins finds low-complexity, high-impact items
 func buildQuickWins(scores []ImpactScore, unblocksMap map[string][]string, limit int) []QuickWin {
 	// Quick wins: high score but likely simple (no deep dependency chains)
@@ -774,6 +852,35 @@ func buildBlockersToClear(analyzer *Analyzer, unblocksMap map[string][]string, l
 	return result
 }
 
+// buildPinned lists the pinned issues in pin order with their triage
+// scores. IDs no longer in the data are skipped.
+func buildPinned(ids []string, scores []TriageScore, ctx *TriageContext) []PinnedItem {
+	if len(ids) == 0 {
+		return nil
+	}
+	scoreByID := make(map[string]float64, len(scores))
+	for _, s := range scores {
+		scoreByID[s.IssueID] = s.TriageScore
+	}
+	var pinned []PinnedItem
+	for _, id := range ids {
+		issue := ctx.GetIssue(id)
+		if issue == nil {
+			continue
+		}
+		pinned = append(pinned, PinnedItem{
+			ID:         id,
+			Title:      issue.Title,
+			Status:     string(issue.Status),
+			Priority:   issue.Priority,
+			Score:      scoreByID[id],
+			Actionable: ctx.IsActionable(id),
+			BlockedBy:  ctx.OpenBlockers(id),
+		})
+	}
+	return pinned
+}
+
 // buildBlockersToClearWithContext finds items that block the most downstream work.
 // This version uses TriageContext for cached actionable lookups and open blockers.
 func buildBlockersToClearWithContext(ctx *TriageContext, unblocksMap map[string][]string, limit int) []BlockerItem {
@@ -1258,13 +1365,13 @@ func GenerateTriageReasons(ctx TriageReasonContext) TriageReasons {
 
 	// 4. Staleness alert
 	if ctx.DaysSinceUpdate > 14 {
-		reason := fmt.Sprintf("🕐 No activity in %d days - may need review", ctx.DaysSinceUpdate)
+		reason := i18n.T("triage.reason.stale", ctx.DaysSinceUpdate)
 		reasons = append(reasons, reason)
 		if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
 			actionHint = "Check if this is stuck and needs help"
 		}
 	} else if ctx.DaysSinceUpdate > 7 {
-		reason := fmt.Sprintf("📅 Last updated %d days ago", ctx.DaysSinceUpdate)
+		reason := i18n.T("triage.reason.last_updated", ctx.DaysSinceUpdate)
 		reasons = append(reasons, reason)
 		if ctx.Issue != nil && ctx.Issue.Status == model.StatusInProgress {
 			actionHint = "Continue work on this issue"
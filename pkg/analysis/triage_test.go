@@ -2,6 +2,7 @@ package analysis
 
 import (
 	"context"
+	"strings"
 	"testing"
 	"time"
 
@@ -217,6 +218,55 @@ func TestComputeTriageWithOptions(t *testing.T) {
 	}
 }
 
+func TestComputeTriage_Pinned(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "pin-a", Title: "Blocker", Status: model.StatusOpen, Priority: 1},
+		{ID: "pin-b", Title: "Blocked", Status: model.StatusOpen, Priority: 2,
+			Dependencies: []*model.Dependency{{IssueID: "pin-b", DependsOnID: "pin-a", Type: model.DepBlocks}}},
+		{ID: "pin-c", Title: "Done", Status: model.StatusClosed, Priority: 3},
+	}
+
+	if triage := ComputeTriage(issues); triage.Pinned != nil {
+		t.Errorf("no pins: expected no pinned section, got %+v", triage.Pinned)
+	}
+
+	triage := ComputeTriageWithOptions(issues, TriageOptions{Pinned: []string{"pin-b", "gone", "pin-c"}})
+	if len(triage.Pinned) != 2 {
+		t.Fatalf("expected 2 pinned (unknown IDs skipped), got %+v", triage.Pinned)
+	}
+	b, c := triage.Pinned[0], triage.Pinned[1]
+	if b.ID != "pin-b" || b.Actionable || len(b.BlockedBy) != 1 || b.BlockedBy[0] != "pin-a" {
+		t.Errorf("pin-b should come first, blocked by pin-a: %+v", b)
+	}
+	if c.ID != "pin-c" || c.Status != "closed" || c.Actionable {
+		t.Errorf("pin-c: %+v", c)
+	}
+}
+
+func TestComputeTriage_SuggestedAssignee(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "own-a", Title: "Unassigned", Status: model.StatusOpen, Priority: 1},
+		{ID: "own-b", Title: "Taken", Status: model.StatusOpen, Priority: 1, Assignee: "bob"},
+		{ID: "own-c", Title: "Unowned", Status: model.StatusOpen, Priority: 1},
+	}
+	owners := map[string][]string{"own-a": {"@org/auth", "@ann"}, "own-b": {"@org/auth"}}
+	triage := ComputeTriageWithOptions(issues, TriageOptions{Owners: owners})
+
+	recs := make(map[string]Recommendation)
+	for _, rec := range triage.Recommendations {
+		recs[rec.ID] = rec
+	}
+	if a := recs["own-a"]; a.SuggestedAssignee != "@org/auth" || len(a.Owners) != 2 || !strings.Contains(strings.Join(a.Reasons, "\n"), "@org/auth owns its files") {
+		t.Errorf("own-a: %+v", a)
+	}
+	if b := recs["own-b"]; b.SuggestedAssignee != "" || len(b.Owners) != 1 {
+		t.Errorf("own-b is assigned, nothing to suggest: %+v", b)
+	}
+	if c := recs["own-c"]; c.SuggestedAssignee != "" || c.Owners != nil {
+		t.Errorf("own-c has no owners: %+v", c)
+	}
+}
+
 func TestTriageRecommendation_Action(t *testing.T) {
 	// Issue in progress for a long time should suggest review
 	issues := []model.Issue{
@@ -264,3 +264,13 @@ func (a *Analyzer) TopWhatIfDeltas(n int) []WhatIfEntry {
 
 	return results[:n]
 }
+
+// WhatIf returns the what-if delta for completing one issue, or nil if the
+// issue is unknown or already closed.
+func (a *Analyzer) WhatIf(issueID string) *WhatIfDelta {
+	issue, ok := a.issueMap[issueID]
+	if !ok || isClosedLikeStatus(issue.Status) {
+		return nil
+	}
+	return a.computeWhatIfDelta(issueID)
+}
@@ -278,6 +278,33 @@ func TestTopWhatIfDeltas_SkipsTombstone(t *testing.T) {
 	}
 }
 
+func TestWhatIf_SingleIssue(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "A", Title: "Blocker", Status: model.StatusOpen},
+		{
+			ID:     "B",
+			Title:  "Blocked work",
+			Status: model.StatusBlocked,
+			Dependencies: []*model.Dependency{
+				{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+			},
+		},
+		{ID: "C", Title: "Done", Status: model.StatusClosed},
+	}
+
+	analyzer := NewAnalyzer(issues)
+	delta := analyzer.WhatIf("A")
+	if delta == nil || delta.DirectUnblocks != 1 || delta.BlockedReduction != 1 {
+		t.Fatalf("WhatIf(A) = %+v, want one direct unblock of a blocked issue", delta)
+	}
+	if analyzer.WhatIf("C") != nil {
+		t.Error("expected nil delta for a closed issue")
+	}
+	if analyzer.WhatIf("missing") != nil {
+		t.Error("expected nil delta for an unknown issue")
+	}
+}
+
 func TestGenerateEnhancedRecommendations_CappedAt10(t *testing.T) {
 	now := time.Now()
 	var issues []model.Issue
@@ -0,0 +1,230 @@
+package analysis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Workload balance states for one assignee.
+const (
+	WorkloadBalanced   = "balanced"
+	WorkloadOverloaded = "overloaded"
+	WorkloadStarved    = "starved"
+)
+
+// Workload balance thresholds, relative to the team's mean effort.
+const (
+	WorkloadOverloadFactor = 1.5 // above this multiple of the mean is overloaded
+	WorkloadStarveFactor   = 0.5 // below this multiple of the mean is starved
+	DefaultEstimateMinutes = 60  // effort counted for unestimated issues when nothing is estimated
+)
+
+// AssigneeLoad is one assignee's open work. Effort is estimated minutes,
+// with unestimated issues counted at the median estimate.
+type AssigneeLoad struct {
+	Assignee         string  `json:"assignee"`
+	Open             int     `json:"open"` // not closed, in progress and blocked included
+	InProgress       int     `json:"in_progress"`
+	Blocked          int     `json:"blocked"` // blocked status or an open blocker
+	BlockedRatio     float64 `json:"blocked_ratio"`
+	EstimatedMinutes int     `json:"estimated_minutes"`
+	Unestimated      int     `json:"unestimated"`
+	LoadFactor       float64 `json:"load_factor"` // effort over the team mean; 1 is average
+	Status           string  `json:"status"`
+}
+
+// Reassignment suggests moving one issue from an overloaded assignee to a
+// starved one.
+type Reassignment struct {
+	IssueID          string `json:"issue_id"`
+	Title            string `json:"title"`
+	From             string `json:"from"`
+	To               string `json:"to"`
+	EstimatedMinutes int    `json:"estimated_minutes"`
+	Reason           string `json:"reason"`
+}
+
+// WorkloadReport is the team workload balance: per-assignee load, the
+// imbalances found, and reassignments that would reduce them.
+type WorkloadReport struct {
+	Assignees           []AssigneeLoad `json:"assignees"`
+	UnassignedOpen      int            `json:"unassigned_open"`
+	MeanMinutes         int            `json:"mean_minutes"`
+	Overloaded          []string       `json:"overloaded,omitempty"`
+	Starved             []string       `json:"starved,omitempty"`
+	Reassignments       []Reassignment `json:"suggested_reassignments,omitempty"`
+	EstimateFillMinutes int            `json:"estimate_fill_minutes"` // effort used for unestimated issues
+}
+
+// ComputeWorkload analyzes how open work is spread across assignees. Anyone
+// assigned a non-tombstoned issue, closed ones included, is on the team, so
+// people who have run out of work show up as starved. Returns nil when no
+// issue has an assignee.
+func ComputeWorkload(issues []model.Issue) *WorkloadReport {
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+
+	fill := DefaultEstimateMinutes
+	var estimates []int
+	for i := range issues {
+		if e := issues[i].EstimatedMinutes; e != nil && *e > 0 {
+			estimates = append(estimates, *e)
+		}
+	}
+	if len(estimates) > 0 {
+		sort.Ints(estimates)
+		fill = estimates[len(estimates)/2]
+	}
+
+	loads := make(map[string]*AssigneeLoad)
+	movable := make(map[string][]*model.Issue) // open, unblocked, not started
+	report := &WorkloadReport{EstimateFillMinutes: fill}
+	for i := range issues {
+		issue := &issues[i]
+		if issue.Status.IsTombstone() {
+			continue
+		}
+		if issue.Assignee == "" {
+			if !issue.Status.IsClosed() {
+				report.UnassignedOpen++
+			}
+			continue
+		}
+		load := loads[issue.Assignee]
+		if load == nil {
+			load = &AssigneeLoad{Assignee: issue.Assignee}
+			loads[issue.Assignee] = load
+		}
+		if issue.Status.IsClosed() {
+			continue
+		}
+		load.Open++
+		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+			load.EstimatedMinutes += *issue.EstimatedMinutes
+		} else {
+			load.Unestimated++
+			load.EstimatedMinutes += fill
+		}
+		blocked := issue.Status == model.StatusBlocked || hasOpenBlocker(issue, byID)
+		switch {
+		case blocked:
+			load.Blocked++
+		case issue.Status == model.StatusInProgress:
+			load.InProgress++
+		case issue.Status == model.StatusOpen:
+			movable[issue.Assignee] = append(movable[issue.Assignee], issue)
+		}
+	}
+	if len(loads) == 0 {
+		return nil
+	}
+
+	total := 0
+	for _, load := range loads {
+		total += load.EstimatedMinutes
+	}
+	mean := float64(total) / float64(len(loads))
+	report.MeanMinutes = int(math.Round(mean))
+	for _, load := range loads {
+		if load.Open > 0 {
+			load.BlockedRatio = roundTo(float64(load.Blocked)/float64(load.Open), 2)
+		}
+		if mean > 0 {
+			load.LoadFactor = roundTo(float64(load.EstimatedMinutes)/mean, 2)
+		}
+		report.Assignees = append(report.Assignees, *load)
+	}
+	sort.Slice(report.Assignees, func(i, j int) bool {
+		a, b := report.Assignees[i], report.Assignees[j]
+		if a.EstimatedMinutes != b.EstimatedMinutes {
+			return a.EstimatedMinutes > b.EstimatedMinutes
+		}
+		return a.Assignee < b.Assignee
+	})
+
+	// Only a team of two or more can be out of balance
+	for i := range report.Assignees {
+		load := &report.Assignees[i]
+		switch {
+		case len(loads) < 2 || mean == 0:
+			load.Status = WorkloadBalanced
+		case float64(load.EstimatedMinutes) > mean*WorkloadOverloadFactor && load.Open > 1:
+			load.Status = WorkloadOverloaded
+			report.Overloaded = append(report.Overloaded, load.Assignee)
+		case float64(load.EstimatedMinutes) < mean*WorkloadStarveFactor:
+			load.Status = WorkloadStarved
+			report.Starved = append(report.Starved, load.Assignee)
+		default:
+			load.Status = WorkloadBalanced
+		}
+	}
+	report.Reassignments = suggestReassignments(report, movable, mean, fill)
+	return report
+}
+
+// suggestReassignments moves not-yet-started, unblocked issues from each
+// overloaded assignee, least important first, to whoever has the least
+// work, until the giver is back at the mean or a move would just swap the
+// imbalance.
+func suggestReassignments(report *WorkloadReport, movable map[string][]*model.Issue, mean float64, fill int) []Reassignment {
+	if len(report.Overloaded) == 0 || len(report.Starved) == 0 {
+		return nil
+	}
+	effort := make(map[string]int, len(report.Assignees))
+	for _, load := range report.Assignees {
+		effort[load.Assignee] = load.EstimatedMinutes
+	}
+	minutes := func(issue *model.Issue) int {
+		if issue.EstimatedMinutes != nil && *issue.EstimatedMinutes > 0 {
+			return *issue.EstimatedMinutes
+		}
+		return fill
+	}
+
+	var moves []Reassignment
+	for _, from := range report.Overloaded {
+		candidates := append([]*model.Issue(nil), movable[from]...)
+		sort.Slice(candidates, func(i, j int) bool {
+			if candidates[i].Priority != candidates[j].Priority {
+				return candidates[i].Priority > candidates[j].Priority
+			}
+			return candidates[i].ID < candidates[j].ID
+		})
+		for _, issue := range candidates {
+			if float64(effort[from]) <= mean {
+				break
+			}
+			to := report.Starved[0]
+			for _, s := range report.Starved[1:] {
+				if effort[s] < effort[to] || (effort[s] == effort[to] && s < to) {
+					to = s
+				}
+			}
+			m := minutes(issue)
+			if effort[to]+m >= effort[from] {
+				continue
+			}
+			effort[from] -= m
+			effort[to] += m
+			moves = append(moves, Reassignment{
+				IssueID:          issue.ID,
+				Title:            issue.Title,
+				From:             from,
+				To:               to,
+				EstimatedMinutes: m,
+				Reason:           fmt.Sprintf("%s has %.1fx the mean load; %s has capacity", from, float64(effort[from]+m)/mean, to),
+			})
+		}
+	}
+	return moves
+}
+
+func roundTo(v float64, places int) float64 {
+	p := math.Pow(10, float64(places))
+	return math.Round(v*p) / p
+}
@@ -0,0 +1,80 @@
+package analysis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func assigned(id, who string, status model.Status, priority, minutes int, blockers ...string) model.Issue {
+	issue := model.Issue{ID: id, Title: id, Status: status, Priority: priority, Assignee: who}
+	if minutes > 0 {
+		issue.EstimatedMinutes = &minutes
+	}
+	for _, b := range blockers {
+		issue.Dependencies = append(issue.Dependencies, &model.Dependency{IssueID: id, DependsOnID: b, Type: model.DepBlocks})
+	}
+	return issue
+}
+
+func TestComputeWorkload(t *testing.T) {
+	issues := []model.Issue{
+		assigned("A1", "alice", model.StatusInProgress, 0, 240),
+		assigned("A2", "alice", model.StatusOpen, 1, 120),
+		assigned("A3", "alice", model.StatusOpen, 3, 60),
+		assigned("A4", "alice", model.StatusOpen, 2, 0), // counted at the median, 120
+		assigned("A5", "alice", model.StatusOpen, 3, 60, "X"),
+		assigned("B1", "bob", model.StatusOpen, 1, 120),
+		assigned("B2", "bob", model.StatusBlocked, 1, 120),
+		assigned("C1", "carol", model.StatusClosed, 1, 120),
+		{ID: "X", Status: model.StatusOpen},
+		{ID: "T", Status: model.StatusTombstone, Assignee: "ghost"},
+	}
+	report := ComputeWorkload(issues)
+	if report == nil {
+		t.Fatal("expected a report")
+	}
+	if report.EstimateFillMinutes != 120 || report.UnassignedOpen != 1 || report.MeanMinutes != 280 {
+		t.Errorf("report = %+v", report)
+	}
+
+	var names []string
+	for _, a := range report.Assignees {
+		names = append(names, a.Assignee+":"+a.Status)
+	}
+	if want := []string{"alice:overloaded", "bob:balanced", "carol:starved"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("assignees = %v, want %v", names, want)
+	}
+	alice := report.Assignees[0]
+	if alice.Open != 5 || alice.InProgress != 1 || alice.Blocked != 1 || alice.Unestimated != 1 ||
+		alice.EstimatedMinutes != 600 || alice.BlockedRatio != 0.2 || alice.LoadFactor != 2.14 {
+		t.Errorf("alice = %+v", alice)
+	}
+	if bob := report.Assignees[1]; bob.Blocked != 1 || bob.BlockedRatio != 0.5 {
+		t.Errorf("bob = %+v", bob)
+	}
+
+	// Least important unstarted, unblocked work moves first: A3 (P3), then
+	// A4 (P2); A5 is blocked and A1 already started.
+	var moves []string
+	for _, r := range report.Reassignments {
+		moves = append(moves, r.IssueID+"->"+r.To)
+	}
+	if want := []string{"A3->carol", "A4->carol", "A2->carol"}; !reflect.DeepEqual(moves, want) {
+		t.Errorf("moves = %v, want %v", moves, want)
+	}
+}
+
+func TestComputeWorkloadEdgeCases(t *testing.T) {
+	if report := ComputeWorkload([]model.Issue{{ID: "A", Status: model.StatusOpen}}); report != nil {
+		t.Errorf("no assignees: report = %+v, want nil", report)
+	}
+	solo := ComputeWorkload([]model.Issue{
+		assigned("A", "alice", model.StatusOpen, 1, 600),
+		assigned("B", "alice", model.StatusOpen, 1, 600),
+	})
+	if solo.Assignees[0].Status != WorkloadBalanced || solo.Reassignments != nil {
+		t.Errorf("a team of one is never out of balance: %+v", solo)
+	}
+}
@@ -0,0 +1,281 @@
+// Package ci evaluates the project-hygiene policies `bv ci-summary` gates
+// CI jobs on. .bv/ci.yaml sets each policy to block the job (enforce), only
+// report (advisory), or not run (off):
+//
+//	policies:
+//	  lint: enforce
+//	  new-cycles: enforce
+//	  new-alerts: enforce
+//	  health: advisory
+//	  blocked-growth: advisory
+//	alert_severity: critical
+//	max_blocked_growth: 0
+//	failure_exit_code: 1
+package ci
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+)
+
+// ConfigFilename is the CI policy file's name under .bv/.
+const ConfigFilename = "ci.yaml"
+
+// Mode is what a policy's violations do.
+type Mode string
+
+const (
+	ModeEnforce  Mode = "enforce"  // Violations fail the job
+	ModeAdvisory Mode = "advisory" // Violations are reported only
+	ModeOff      Mode = "off"      // The policy isn't checked
+)
+
+// Policy names one hygiene policy.
+type Policy string
+
+const (
+	// PolicyLint is violated by bv lint findings in the beads file
+	PolicyLint Policy = "lint"
+	// PolicyNewCycles is violated by dependency cycles the base didn't have
+	PolicyNewCycles Policy = "new-cycles"
+	// PolicyNewAlerts is violated by alerts of at least AlertSeverity that
+	// the base didn't raise, other than cycle alerts
+	PolicyNewAlerts Policy = "new-alerts"
+	// PolicyHealth is violated when the health trend since the base is degrading
+	PolicyHealth Policy = "health"
+	// PolicyBlockedGrowth is violated when more than MaxBlockedGrowth issues
+	// became blocked since the base
+	PolicyBlockedGrowth Policy = "blocked-growth"
+)
+
+// Policies lists every policy in report order.
+var Policies = []Policy{PolicyLint, PolicyNewCycles, PolicyNewAlerts, PolicyHealth, PolicyBlockedGrowth}
+
+// Config is .bv/ci.yaml. A policy it doesn't list is off.
+type Config struct {
+	Policies         map[Policy]Mode `yaml:"policies" json:"policies"`
+	AlertSeverity    drift.Severity  `yaml:"alert_severity" json:"alert_severity"`         // Least severe new alert that violates new-alerts
+	MaxBlockedGrowth int             `yaml:"max_blocked_growth" json:"max_blocked_growth"` // Blocked issues the base can gain before blocked-growth is violated
+	FailureExitCode  int             `yaml:"failure_exit_code" json:"failure_exit_code"`   // Exit code when an enforced policy is violated
+}
+
+// DefaultConfig fails the job on lint findings, new cycles and new critical
+// alerts, and reports a degrading trend and growth in blocked issues.
+func DefaultConfig() Config {
+	return Config{
+		Policies: map[Policy]Mode{
+			PolicyLint:          ModeEnforce,
+			PolicyNewCycles:     ModeEnforce,
+			PolicyNewAlerts:     ModeEnforce,
+			PolicyHealth:        ModeAdvisory,
+			PolicyBlockedGrowth: ModeAdvisory,
+		},
+		AlertSeverity:   drift.SeverityCritical,
+		FailureExitCode: 1,
+	}
+}
+
+// ConfigPath returns the CI policy file path for a project.
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// LoadConfig reads the project's CI policies. A missing file is the default
+// configuration; settings the file leaves out keep their defaults, except
+// that policies it lists replace the default policies.
+func LoadConfig(projectDir string) (Config, error) {
+	cfg := DefaultConfig()
+	path := ConfigPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return cfg, nil
+		}
+		return Config{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var file Config
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return Config{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if file.Policies != nil {
+		cfg.Policies = file.Policies
+	}
+	if file.AlertSeverity != "" {
+		cfg.AlertSeverity = file.AlertSeverity
+	}
+	if file.MaxBlockedGrowth != 0 {
+		cfg.MaxBlockedGrowth = file.MaxBlockedGrowth
+	}
+	if file.FailureExitCode != 0 {
+		cfg.FailureExitCode = file.FailureExitCode
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate reports unknown policies, modes and severities, and exit codes
+// that wouldn't fail a job.
+func (c Config) Validate() error {
+	for policy, mode := range c.Policies {
+		if !knownPolicy(policy) {
+			return fmt.Errorf("unknown policy %q (want one of %s)", policy, joinPolicies(Policies))
+		}
+		switch mode {
+		case ModeEnforce, ModeAdvisory, ModeOff:
+		default:
+			return fmt.Errorf("%s: unknown mode %q (want enforce, advisory, or off)", policy, mode)
+		}
+	}
+	if severityRank(c.AlertSeverity) < 0 {
+		return fmt.Errorf("unknown alert_severity %q (want info, warning, or critical)", c.AlertSeverity)
+	}
+	if c.MaxBlockedGrowth < 0 {
+		return fmt.Errorf("max_blocked_growth must not be negative")
+	}
+	if c.FailureExitCode < 1 || c.FailureExitCode > 125 {
+		return fmt.Errorf("failure_exit_code must be between 1 and 125, got %d", c.FailureExitCode)
+	}
+	return nil
+}
+
+// Mode returns policy's mode; unlisted policies are off.
+func (c Config) Mode(policy Policy) Mode {
+	if mode, ok := c.Policies[policy]; ok {
+		return mode
+	}
+	return ModeOff
+}
+
+func knownPolicy(policy Policy) bool {
+	for _, p := range Policies {
+		if p == policy {
+			return true
+		}
+	}
+	return false
+}
+
+func joinPolicies(policies []Policy) string {
+	names := make([]string, len(policies))
+	for i, p := range policies {
+		names[i] = string(p)
+	}
+	return strings.Join(names, ", ")
+}
+
+// severityRank orders severities; -1 for an unknown one.
+func severityRank(s drift.Severity) int {
+	switch s {
+	case drift.SeverityInfo:
+		return 0
+	case drift.SeverityWarning:
+		return 1
+	case drift.SeverityCritical:
+		return 2
+	}
+	return -1
+}
+
+// Input is what the policies judge: the current state and what changed
+// since the base revision.
+type Input struct {
+	Lint        []lint.Finding
+	NewCycles   [][]string
+	NewAlerts   []drift.Alert
+	HealthTrend string              // "improving", "degrading", or "stable"
+	Base        baseline.GraphStats // At the base revision
+	Current     baseline.GraphStats
+}
+
+// Violation is one breach of a policy. IssueID and Line locate it when it
+// concerns one issue; Line is the beads file line, when known.
+type Violation struct {
+	Policy  Policy `json:"policy"`
+	Mode    Mode   `json:"mode"`
+	IssueID string `json:"issue_id,omitempty"`
+	Line    int    `json:"line,omitempty"`
+	Message string `json:"message"`
+}
+
+// Result is the policies' verdict.
+type Result struct {
+	Modes      map[Policy]Mode `json:"modes"` // Every policy's mode, off included
+	Violations []Violation     `json:"violations"`
+	Failed     bool            `json:"failed"` // An enforced policy was violated
+}
+
+// Count returns the number of policy's violations.
+func (r Result) Count(policy Policy) int {
+	n := 0
+	for _, v := range r.Violations {
+		if v.Policy == policy {
+			n++
+		}
+	}
+	return n
+}
+
+// Evaluate checks in against the configured policies.
+func Evaluate(cfg Config, in Input) Result {
+	result := Result{Modes: make(map[Policy]Mode, len(Policies)), Violations: []Violation{}}
+	for _, policy := range Policies {
+		mode := cfg.Mode(policy)
+		result.Modes[policy] = mode
+		if mode == ModeOff {
+			continue
+		}
+		for _, v := range check(policy, cfg, in) {
+			v.Policy, v.Mode = policy, mode
+			result.Violations = append(result.Violations, v)
+			if mode == ModeEnforce {
+				result.Failed = true
+			}
+		}
+	}
+	return result
+}
+
+func check(policy Policy, cfg Config, in Input) []Violation {
+	var violations []Violation
+	switch policy {
+	case PolicyLint:
+		for _, f := range in.Lint {
+			violations = append(violations, Violation{IssueID: f.IssueID, Line: f.Line, Message: fmt.Sprintf("%s: %s [%s]", f.IssueID, f.Message, f.Rule)})
+		}
+	case PolicyNewCycles:
+		for _, cycle := range in.NewCycles {
+			v := Violation{Message: "new dependency cycle: " + strings.Join(cycle, " → ")}
+			if len(cycle) > 0 {
+				v.IssueID = cycle[0]
+			}
+			violations = append(violations, v)
+		}
+	case PolicyNewAlerts:
+		min := severityRank(cfg.AlertSeverity)
+		for _, a := range in.NewAlerts {
+			// new-cycles judges cycles on its own
+			if a.Type != drift.AlertNewCycle && severityRank(a.Severity) >= min {
+				violations = append(violations, Violation{IssueID: a.IssueID, Message: fmt.Sprintf("new %s alert: %s", a.Severity, a.Message)})
+			}
+		}
+	case PolicyHealth:
+		if in.HealthTrend == "degrading" {
+			violations = append(violations, Violation{Message: "health is degrading since the base revision"})
+		}
+	case PolicyBlockedGrowth:
+		if growth := in.Current.BlockedCount - in.Base.BlockedCount; growth > cfg.MaxBlockedGrowth {
+			violations = append(violations, Violation{Message: fmt.Sprintf("blocked issues grew by %d (from %d to %d; allowed %d)", growth, in.Base.BlockedCount, in.Current.BlockedCount, cfg.MaxBlockedGrowth)})
+		}
+	}
+	return violations
+}
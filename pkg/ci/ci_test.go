@@ -0,0 +1,94 @@
+package ci
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+)
+
+func writeConfig(t *testing.T, dir, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mode(PolicyLint) != ModeEnforce || cfg.Mode(PolicyHealth) != ModeAdvisory || cfg.FailureExitCode != 1 || cfg.AlertSeverity != drift.SeverityCritical {
+		t.Errorf("missing file = %+v, want the default", cfg)
+	}
+
+	writeConfig(t, dir, "policies:\n  new-cycles: advisory\n  health: enforce\nfailure_exit_code: 9\n")
+	if cfg, err = LoadConfig(dir); err != nil {
+		t.Fatal(err)
+	}
+	// Listed policies replace the defaults; other settings keep theirs
+	if cfg.Mode(PolicyLint) != ModeOff || cfg.Mode(PolicyHealth) != ModeEnforce || cfg.FailureExitCode != 9 || cfg.AlertSeverity != drift.SeverityCritical {
+		t.Errorf("partial file = %+v", cfg)
+	}
+
+	for _, bad := range []string{
+		"policies:\n  typos: enforce\n",
+		"policies:\n  lint: fail\n",
+		"alert_severity: urgent\n",
+		"failure_exit_code: 300\n",
+		"max_blocked_growth: -1\n",
+	} {
+		writeConfig(t, dir, bad)
+		if _, err := LoadConfig(dir); err == nil {
+			t.Errorf("%q: want an error", bad)
+		}
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	in := Input{
+		Lint:      []lint.Finding{{Line: 3, IssueID: "a-1", Rule: lint.RuleStatusCase, Message: `status "Open" should be "open"`}},
+		NewCycles: [][]string{{"a-1", "a-2", "a-1"}},
+		NewAlerts: []drift.Alert{
+			{Type: drift.AlertNewCycle, Severity: drift.SeverityCritical, Message: "1 new cycle(s) detected"},
+			{Type: drift.AlertStaleIssue, Severity: drift.SeverityWarning, IssueID: "a-4", Message: "a-4 is stale"},
+			{Type: drift.AlertRule, Severity: drift.SeverityCritical, IssueID: "a-5", Message: "P0 idle"},
+		},
+		HealthTrend: "degrading",
+		Base:        baseline.GraphStats{BlockedCount: 2},
+		Current:     baseline.GraphStats{BlockedCount: 4},
+	}
+
+	result := Evaluate(DefaultConfig(), in)
+	var got []string
+	for _, v := range result.Violations {
+		got = append(got, string(v.Policy)+"/"+string(v.Mode)+"/"+v.IssueID)
+	}
+	want := "lint/enforce/a-1 new-cycles/enforce/a-1 new-alerts/enforce/a-5 health/advisory/ blocked-growth/advisory/"
+	if strings.Join(got, " ") != want || !result.Failed {
+		t.Errorf("violations = %v (failed %v), want %s", got, result.Failed, want)
+	}
+	if result.Violations[0].Line != 3 || result.Count(PolicyNewAlerts) != 1 {
+		t.Errorf("lint line = %d, new-alerts = %d", result.Violations[0].Line, result.Count(PolicyNewAlerts))
+	}
+
+	// Advisory-only violations pass; a lower alert bar and more allowed
+	// growth change what's reported
+	cfg := DefaultConfig()
+	cfg.Policies = map[Policy]Mode{PolicyNewAlerts: ModeAdvisory, PolicyBlockedGrowth: ModeEnforce}
+	cfg.AlertSeverity = drift.SeverityWarning
+	cfg.MaxBlockedGrowth = 2
+	result = Evaluate(cfg, in)
+	if result.Failed || result.Count(PolicyNewAlerts) != 2 || result.Count(PolicyBlockedGrowth) != 0 || result.Modes[PolicyLint] != ModeOff {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -0,0 +1,232 @@
+// Package codeowners reads a repository's CODEOWNERS file and answers who
+// owns a path, and from an issue's likely files (see
+// correlation.FileHints), who owns the issue.
+//
+// Patterns follow GitHub's rules, which are gitignore's: the last matching
+// line wins, a pattern without a slash matches at any depth, a leading
+// slash anchors it to the repository root, a trailing slash matches a
+// directory's contents, and ** spans directories.
+package codeowners
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Locations are where Load looks for the file, in GitHub's order of
+// precedence, then GitLab's extra location.
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// Rule is one pattern line.
+type Rule struct {
+	Pattern string
+	Owners  []string // Empty for a path explicitly left unowned
+	Line    int
+
+	segments []string // Path segments to match; unanchored patterns start with **
+	dirOnly  bool     // Trailing slash: matches only what's inside
+	contents bool     // Also matches everything under a matching directory
+}
+
+// File is a parsed CODEOWNERS file.
+type File struct {
+	Path  string // Where it was read from; "" when parsed from a reader
+	Rules []Rule
+}
+
+// Load reads the CODEOWNERS file of the repository at root. It returns
+// nil and no error when the repository has none.
+func Load(root string) (*File, error) {
+	for _, loc := range Locations {
+		p := filepath.Join(root, filepath.FromSlash(loc))
+		f, err := os.Open(p)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		parsed, err := Parse(f)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", p, err)
+		}
+		parsed.Path = p
+		return parsed, nil
+	}
+	return nil, nil
+}
+
+// Parse reads CODEOWNERS rules. Comments, blank lines and GitLab section
+// headers ("[Docs]") are skipped.
+func Parse(r io.Reader) (*File, error) {
+	f := &File{}
+	scanner := bufio.NewScanner(r)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") || strings.HasPrefix(text, "[") || strings.HasPrefix(text, "^[") {
+			continue
+		}
+		fields := strings.Fields(text)
+		rule, ok := newRule(strings.TrimPrefix(fields[0], `\`), line)
+		if !ok {
+			continue
+		}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+		f.Rules = append(f.Rules, rule)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func newRule(pattern string, line int) (Rule, bool) {
+	rule := Rule{Pattern: pattern, Line: line, dirOnly: strings.HasSuffix(pattern, "/")}
+	trimmed := strings.Trim(pattern, "/")
+	if trimmed == "" {
+		return Rule{}, false
+	}
+	rule.segments = strings.Split(trimmed, "/")
+	if !strings.HasPrefix(pattern, "/") && !strings.Contains(trimmed, "/") {
+		rule.segments = append([]string{"**"}, rule.segments...)
+	}
+	// "docs/*" owns the files in docs but not those in its subdirectories
+	rule.contents = rule.dirOnly || !hasWildcard(rule.segments[len(rule.segments)-1])
+	return rule, true
+}
+
+// Owners returns the owners of the file at path, relative to the
+// repository root, or nil when no rule owns it.
+func (f *File) Owners(file string) []string {
+	return f.ownersOf(file, false)
+}
+
+func (f *File) ownersOf(p string, dir bool) []string {
+	if f == nil {
+		return nil
+	}
+	p = strings.Trim(strings.TrimPrefix(strings.ReplaceAll(p, `\`, "/"), "./"), "/")
+	if p == "" {
+		return nil
+	}
+	segments := strings.Split(p, "/")
+	for i := len(f.Rules) - 1; i >= 0; i-- {
+		if f.Rules[i].matches(segments, dir) {
+			return append([]string(nil), f.Rules[i].Owners...)
+		}
+	}
+	return nil
+}
+
+// matches reports whether the rule covers the path, or when dir is set,
+// the directory.
+func (r *Rule) matches(segments []string, dir bool) bool {
+	for n := len(segments); n > 0; n-- {
+		if n < len(segments) && !r.contents {
+			break
+		}
+		if n == len(segments) && r.dirOnly && !dir {
+			continue
+		}
+		if matchSegments(r.segments, segments[:n]) {
+			return true
+		}
+	}
+	return false
+}
+
+// IssueOwners returns the owners of the files issue's work is likely to
+// touch, those owning the most of them first. A glob hint counts as the
+// directory before its first wildcard; a hint without one and without a
+// file extension counts as a directory.
+func (f *File) IssueOwners(issue model.Issue, refs *correlation.IssueXRef) []string {
+	if f == nil {
+		return nil
+	}
+	counts := make(map[string]int)
+	var order []string
+	for _, h := range correlation.FileHints(issue, refs, 0) {
+		p, dir := hintPath(h.Path)
+		if p == "" {
+			continue
+		}
+		for _, owner := range f.ownersOf(p, dir) {
+			if counts[owner] == 0 {
+				order = append(order, owner)
+			}
+			counts[owner]++
+		}
+	}
+	sort.SliceStable(order, func(i, j int) bool { return counts[order[i]] > counts[order[j]] })
+	return order
+}
+
+// hintPath is the path a file hint is owned by, and whether it's a
+// directory. It returns "" for a glob with no literal directory.
+func hintPath(hint string) (string, bool) {
+	segments := strings.Split(hint, "/")
+	for i, s := range segments {
+		if hasWildcard(s) {
+			return strings.Join(segments[:i], "/"), true
+		}
+	}
+	return hint, !strings.Contains(path.Base(hint), ".")
+}
+
+// Match reports whether owner, with or without its leading @ and in any
+// case, is among owners.
+func Match(owners []string, owner string) bool {
+	want := strings.TrimPrefix(strings.ToLower(owner), "@")
+	for _, o := range owners {
+		if strings.TrimPrefix(strings.ToLower(o), "@") == want {
+			return true
+		}
+	}
+	return false
+}
+
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+func hasWildcard(s string) bool {
+	return strings.ContainsAny(s, "*?[")
+}
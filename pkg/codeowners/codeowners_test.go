@@ -0,0 +1,114 @@
+package codeowners
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const sample = `# Default owners
+*                 @org/core
+
+*.md              @docs-team   # prose
+/pkg/auth/        @org/security @ann
+docs/*            @writers
+**/testdata/**    @qa
+/cmd/bv/main.go
+[Frontend]
+/web/             @org/web
+`
+
+func parseSample(t *testing.T) *File {
+	t.Helper()
+	f, err := Parse(strings.NewReader(sample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return f
+}
+
+func TestOwners(t *testing.T) {
+	f := parseSample(t)
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{"go.mod", []string{"@org/core"}},
+		{"README.md", []string{"@docs-team"}},
+		{"pkg/ui/NOTES.md", []string{"@docs-team"}},
+		{"pkg/auth/token.go", []string{"@org/security", "@ann"}},
+		{"pkg/auth/oauth/client.go", []string{"@org/security", "@ann"}},
+		{"pkg/auth", []string{"@org/core"}}, // The file, not the directory
+		{"docs/guide.txt", []string{"@writers"}},
+		{"docs/api/guide.txt", []string{"@org/core"}},
+		{"pkg/ui/testdata/golden/help.txt", []string{"@qa"}},
+		{"cmd/bv/main.go", nil}, // Explicitly unowned
+		{"./web/app.ts", []string{"@org/web"}},
+	}
+	for _, tt := range tests {
+		if got := f.Owners(tt.path); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Owners(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+
+	var none *File
+	if none.Owners("go.mod") != nil {
+		t.Error("a nil File owns nothing")
+	}
+}
+
+func TestIssueOwners(t *testing.T) {
+	f := parseSample(t)
+	issue := model.Issue{ID: "bv-1", Description: "Files: pkg/auth/**, pkg/auth"}
+	refs := &correlation.IssueXRef{Commits: []correlation.XRefCommit{
+		{Files: []string{"docs/auth.txt", "go.mod"}},
+		{Files: []string{"go.mod"}},
+	}}
+	want := []string{"@org/security", "@ann", "@org/core", "@writers"}
+	if got := f.IssueOwners(issue, refs); !reflect.DeepEqual(got, want) {
+		t.Errorf("IssueOwners = %q, want %q", got, want)
+	}
+	if got := f.IssueOwners(model.Issue{Description: "Files: **/*_test.go"}, nil); len(got) != 0 {
+		t.Errorf("a glob with no directory has no owner, got %q", got)
+	}
+}
+
+func TestMatch(t *testing.T) {
+	owners := []string{"@org/Security", "ann@example.com"}
+	for _, owner := range []string{"@org/security", "org/SECURITY", "ann@example.com"} {
+		if !Match(owners, owner) {
+			t.Errorf("Match(%q) = false", owner)
+		}
+	}
+	if Match(owners, "@org") {
+		t.Error("Match(@org) = true")
+	}
+}
+
+func TestLoad(t *testing.T) {
+	root := t.TempDir()
+	if f, err := Load(root); f != nil || err != nil {
+		t.Fatalf("no CODEOWNERS: %v, %v", f, err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "CODEOWNERS"), []byte("* @root\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, ".github"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, ".github", "CODEOWNERS"), []byte("* @github\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	f, err := Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := f.Owners("x.go"); !reflect.DeepEqual(got, []string{"@github"}) || !strings.HasSuffix(f.Path, filepath.Join(".github", "CODEOWNERS")) {
+		t.Errorf(".github/CODEOWNERS should win: %q from %s", got, f.Path)
+	}
+}
@@ -0,0 +1,124 @@
+package correlation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultCodeAgeMaxFiles caps the files CodeAges blames per issue.
+const DefaultCodeAgeMaxFiles = 20
+
+// CodeAge is how recently the code an issue is likely to touch changed,
+// by git blame of the files' current lines.
+type CodeAge struct {
+	LastChanged time.Time `json:"last_changed"` // Newest line among the files
+	Files       int       `json:"files"`        // Files blamed
+	Lines       int       `json:"lines"`
+}
+
+// blamedFile is one file's blame summary.
+type blamedFile struct {
+	newest time.Time
+	lines  int
+}
+
+// CodeAges blames the likely files of each issue (hints by issue ID, see
+// FileHints) in the repository at repoPath. Globs and directories expand to
+// the tracked files they match, the first maxFiles of them per issue (0
+// means DefaultCodeAgeMaxFiles); each file is blamed once however many
+// issues touch it. Issues whose hints match no tracked file are absent.
+// Uncommitted changes count as changed now.
+func CodeAges(repoPath string, hints map[string][]FileHint, maxFiles int) (map[string]CodeAge, error) {
+	if maxFiles <= 0 {
+		maxFiles = DefaultCodeAgeMaxFiles
+	}
+	ages := make(map[string]CodeAge)
+	if len(hints) == 0 {
+		return ages, nil
+	}
+	out, err := runGitOutput(repoPath, "ls-files")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+	tracked := strings.Split(out, "\n")
+
+	blamed := make(map[string]*blamedFile)
+	for id, issueHints := range hints {
+		files := expandFileHints(issueHints, tracked, maxFiles)
+		var age CodeAge
+		for _, f := range files {
+			b, ok := blamed[f]
+			if !ok {
+				b = blameFile(repoPath, f)
+				blamed[f] = b
+			}
+			if b == nil {
+				continue
+			}
+			age.Files++
+			age.Lines += b.lines
+			if b.newest.After(age.LastChanged) {
+				age.LastChanged = b.newest
+			}
+		}
+		if age.Files > 0 {
+			ages[id] = age
+		}
+	}
+	return ages, nil
+}
+
+// expandFileHints returns the tracked files the hints match, up to max,
+// in path order.
+func expandFileHints(hints []FileHint, tracked []string, max int) []string {
+	paths := hintPaths(hints)
+	var files []string
+	for _, f := range tracked {
+		if f != "" && matchesAnyHint(paths, f) {
+			files = append(files, f)
+		}
+	}
+	sort.Strings(files)
+	if len(files) > max {
+		files = files[:max]
+	}
+	return files
+}
+
+func hintPaths(hints []FileHint) []string {
+	paths := make([]string, len(hints))
+	for i, h := range hints {
+		paths[i] = h.Path
+	}
+	return paths
+}
+
+// blameFile summarizes git blame of file, or returns nil when it can't be
+// blamed (binary, deleted in the work tree).
+func blameFile(repoPath, file string) *blamedFile {
+	out, err := runGitOutput(repoPath, "blame", "--porcelain", "--", file)
+	if err != nil {
+		return nil
+	}
+	b := &blamedFile{}
+	for _, line := range strings.Split(out, "\n") {
+		switch {
+		case strings.HasPrefix(line, "\t"):
+			b.lines++
+		case strings.HasPrefix(line, "author-time "):
+			// Porcelain lists each commit's details once, at its first line
+			if sec, err := strconv.ParseInt(strings.TrimPrefix(line, "author-time "), 10, 64); err == nil {
+				if t := time.Unix(sec, 0); t.After(b.newest) {
+					b.newest = t
+				}
+			}
+		}
+	}
+	if b.lines == 0 {
+		return nil
+	}
+	return b
+}
@@ -0,0 +1,74 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestCodeAges(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	commit := func(date string, files ...string) {
+		t.Helper()
+		for _, f := range files {
+			path := filepath.Join(dir, f)
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				t.Fatal(err)
+			}
+			data, _ := os.ReadFile(path)
+			if err := os.WriteFile(path, append(data, date+"\n"...), 0644); err != nil {
+				t.Fatal(err)
+			}
+		}
+		for _, args := range [][]string{{"add", "."}, {"commit", "-q", "-m", "Change " + date}} {
+			cmd := exec.Command("git", args...)
+			cmd.Dir = dir
+			cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Ann", "GIT_AUTHOR_EMAIL=ann@example.com",
+				"GIT_COMMITTER_NAME=Ann", "GIT_COMMITTER_EMAIL=ann@example.com",
+				"GIT_AUTHOR_DATE="+date+"T12:00:00Z", "GIT_COMMITTER_DATE="+date+"T12:00:00Z")
+			if out, err := cmd.CombinedOutput(); err != nil {
+				t.Fatalf("git %v: %v\n%s", args, err, out)
+			}
+		}
+	}
+	if out, err := exec.Command("git", "init", "-q", "-b", "main", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	commit("2024-01-10", "pkg/auth/token.go", "pkg/auth/session.go", "main.go")
+	commit("2025-03-01", "pkg/auth/session.go")
+
+	hints := map[string][]FileHint{
+		"bv-1": {{Path: "pkg/auth"}},
+		"bv-2": {{Path: "main.go"}, {Path: "pkg/auth/token.go"}},
+		"bv-3": {{Path: "pkg/missing/**"}},
+	}
+	ages, err := CodeAges(dir, hints, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d.Add(12 * time.Hour)
+	}
+	if got := ages["bv-1"]; !got.LastChanged.Equal(day("2025-03-01")) || got.Files != 2 || got.Lines != 3 {
+		t.Errorf("bv-1 = %+v", got)
+	}
+	if got := ages["bv-2"]; !got.LastChanged.Equal(day("2024-01-10")) || got.Files != 2 {
+		t.Errorf("bv-2 = %+v", got)
+	}
+	if _, ok := ages["bv-3"]; ok {
+		t.Error("an issue matching no tracked file has no age")
+	}
+
+	if ages, err := CodeAges(dir, hints, 1); err != nil || ages["bv-1"].Files != 1 {
+		t.Errorf("maxFiles 1: %+v, %v", ages["bv-1"], err)
+	}
+	if _, err := CodeAges(t.TempDir(), hints, 0); err == nil {
+		t.Error("a directory outside git should be an error")
+	}
+}
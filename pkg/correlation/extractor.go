@@ -10,6 +10,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"regexp"
+	"sort"
 	"strings"
 	"time"
 )
@@ -75,9 +76,11 @@ type commitInfo struct {
 
 // beadSnapshot represents a bead's state at a point in time
 type beadSnapshot struct {
-	ID     string
-	Status string
-	Title  string
+	ID       string
+	Status   string
+	Title    string
+	Priority *int     // nil when the line has no priority field
+	Deps     []string // depends_on_id values, sorted
 }
 
 // Extract extracts bead lifecycle events from git history
@@ -365,6 +368,7 @@ func (e *Extractor) parseDiff(diffData []byte, info commitInfo, filterBeadID str
 			event.EventType = EventCreated
 			events = append(events, event)
 		} else if hadOld && hasNew {
+			event.Details = describeChanges(oldSnap, newSnap)
 			// Check for status change
 			if oldSnap.Status != newSnap.Status {
 				event.EventType = determineStatusEvent(oldSnap.Status, newSnap.Status)
@@ -384,9 +388,13 @@ func (e *Extractor) parseDiff(diffData []byte, info commitInfo, filterBeadID str
 // parseBeadJSON extracts minimal bead info from a JSON line
 func parseBeadJSON(jsonStr string) (beadSnapshot, bool) {
 	var partial struct {
-		ID     string `json:"id"`
-		Status string `json:"status"`
-		Title  string `json:"title"`
+		ID           string `json:"id"`
+		Status       string `json:"status"`
+		Title        string `json:"title"`
+		Priority     *int   `json:"priority"`
+		Dependencies []struct {
+			DependsOnID string `json:"depends_on_id"`
+		} `json:"dependencies"`
 	}
 
 	if err := json.Unmarshal([]byte(jsonStr), &partial); err != nil {
@@ -397,13 +405,58 @@ func parseBeadJSON(jsonStr string) (beadSnapshot, bool) {
 		return beadSnapshot{}, false
 	}
 
+	var deps []string
+	for _, d := range partial.Dependencies {
+		if d.DependsOnID != "" {
+			deps = append(deps, d.DependsOnID)
+		}
+	}
+	sort.Strings(deps)
+
 	return beadSnapshot{
-		ID:     partial.ID,
-		Status: partial.Status,
-		Title:  partial.Title,
+		ID:       partial.ID,
+		Status:   partial.Status,
+		Title:    partial.Title,
+		Priority: partial.Priority,
+		Deps:     deps,
 	}, true
 }
 
+// describeChanges summarizes what changed between two snapshots of a bead,
+// e.g. "status open → closed; priority P2 → P1; +dep bv-3". Returns "" when
+// none of the tracked fields changed (description edits, timestamps, etc.).
+func describeChanges(oldSnap, newSnap beadSnapshot) string {
+	var parts []string
+	if oldSnap.Status != newSnap.Status {
+		parts = append(parts, fmt.Sprintf("status %s → %s", oldSnap.Status, newSnap.Status))
+	}
+	if oldSnap.Priority != nil && newSnap.Priority != nil && *oldSnap.Priority != *newSnap.Priority {
+		parts = append(parts, fmt.Sprintf("priority P%d → P%d", *oldSnap.Priority, *newSnap.Priority))
+	}
+	if oldSnap.Title != newSnap.Title {
+		parts = append(parts, "title changed")
+	}
+
+	oldDeps := make(map[string]bool, len(oldSnap.Deps))
+	for _, d := range oldSnap.Deps {
+		oldDeps[d] = true
+	}
+	newDeps := make(map[string]bool, len(newSnap.Deps))
+	for _, d := range newSnap.Deps {
+		newDeps[d] = true
+		if !oldDeps[d] {
+			parts = append(parts, "+dep "+d)
+		}
+	}
+	for _, d := range oldSnap.Deps {
+		if !newDeps[d] {
+			parts = append(parts, "-dep "+d)
+		}
+	}
+
+	return strings.Join(parts, "; ")
+}
+
 // determineStatusEvent determines the appropriate event type for a status transition
 func determineStatusEvent(oldStatus, newStatus string) EventType {
 	switch newStatus {
@@ -567,3 +567,24 @@ func TestReverseEvents_Single(t *testing.T) {
 		t.Error("reverseEvents of single should keep it")
 	}
 }
+
+func TestDescribeChanges(t *testing.T) {
+	oldSnap, ok := parseBeadJSON(`{"id":"bv-1","title":"A","status":"open","priority":2,"dependencies":[{"depends_on_id":"bv-2"}]}`)
+	if !ok {
+		t.Fatal("failed to parse old snapshot")
+	}
+	newSnap, ok := parseBeadJSON(`{"id":"bv-1","title":"A","status":"in_progress","priority":1,"dependencies":[{"depends_on_id":"bv-3"}]}`)
+	if !ok {
+		t.Fatal("failed to parse new snapshot")
+	}
+
+	got := describeChanges(oldSnap, newSnap)
+	want := "status open → in_progress; priority P2 → P1; +dep bv-3; -dep bv-2"
+	if got != want {
+		t.Errorf("describeChanges = %q, want %q", got, want)
+	}
+
+	if got := describeChanges(oldSnap, oldSnap); got != "" {
+		t.Errorf("describeChanges(same) = %q, want empty", got)
+	}
+}
@@ -0,0 +1,88 @@
+package correlation
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// DefaultFileAuthorWindow is how far back FileAuthors looks
+const DefaultFileAuthorWindow = 90 * 24 * time.Hour
+
+// FileAuthor is someone who recently committed to an issue's likely files
+type FileAuthor struct {
+	Name    string    `json:"name"`
+	Email   string    `json:"email"`
+	Commits int       `json:"commits"`
+	Last    time.Time `json:"last"`
+}
+
+// FileAuthors finds who committed to each issue's likely files (hints by
+// issue ID, see FileHints) since since, most commits first. Hints expand to
+// tracked files as in CodeAges, up to maxFiles per issue (0 means
+// DefaultCodeAgeMaxFiles). Issues without matching files or recent commits
+// are absent.
+func FileAuthors(repoPath string, hints map[string][]FileHint, since time.Time, maxFiles int) (map[string][]FileAuthor, error) {
+	if maxFiles <= 0 {
+		maxFiles = DefaultCodeAgeMaxFiles
+	}
+	authors := make(map[string][]FileAuthor)
+	if len(hints) == 0 {
+		return authors, nil
+	}
+	out, err := runGitOutput(repoPath, "ls-files")
+	if err != nil {
+		return nil, fmt.Errorf("git ls-files failed: %w", err)
+	}
+	tracked := strings.Split(out, "\n")
+
+	for id, issueHints := range hints {
+		files := expandFileHints(issueHints, tracked, maxFiles)
+		if len(files) == 0 {
+			continue
+		}
+		args := append([]string{"log", "--since=" + since.Format(time.RFC3339), "--format=%an%x1f%ae%x1f%aI", "--"}, files...)
+		log, err := runGitOutput(repoPath, args...)
+		if err != nil {
+			return nil, fmt.Errorf("git log failed: %w", err)
+		}
+		if a := parseFileAuthors(log); len(a) > 0 {
+			authors[id] = a
+		}
+	}
+	return authors, nil
+}
+
+// parseFileAuthors tallies "name\x1femail\x1fdate" lines by email, most
+// commits first, then most recent.
+func parseFileAuthors(log string) []FileAuthor {
+	byEmail := make(map[string]*FileAuthor)
+	var order []*FileAuthor
+	for _, line := range strings.Split(log, "\n") {
+		parts := strings.Split(line, "\x1f")
+		if len(parts) != 3 {
+			continue
+		}
+		key := strings.ToLower(parts[1])
+		a, ok := byEmail[key]
+		if !ok {
+			// Log order is newest first, so the first line names the author
+			a = &FileAuthor{Name: parts[0], Email: parts[1]}
+			if t, err := time.Parse(time.RFC3339, parts[2]); err == nil {
+				a.Last = t
+			}
+			byEmail[key] = a
+			order = append(order, a)
+		}
+		a.Commits++
+	}
+	authors := make([]FileAuthor, len(order))
+	for i, a := range order {
+		authors[i] = *a
+	}
+	sort.SliceStable(authors, func(i, j int) bool {
+		return authors[i].Commits > authors[j].Commits
+	})
+	return authors
+}
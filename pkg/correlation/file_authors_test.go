@@ -0,0 +1,25 @@
+package correlation
+
+import (
+	"testing"
+)
+
+func TestParseFileAuthors(t *testing.T) {
+	log := "Bob\x1fbob@example.com\x1f2026-03-02T10:00:00Z\n" +
+		"Ann\x1fann@example.com\x1f2026-03-01T10:00:00Z\n" +
+		"Robert\x1fBOB@example.com\x1f2026-02-01T10:00:00Z\n" +
+		"Cy\x1fcy@example.com\x1f2026-01-01T10:00:00Z\n" +
+		"Ann\x1fann@example.com\x1f2025-12-01T10:00:00Z\n" +
+		"garbage"
+	got := parseFileAuthors(log)
+	if len(got) != 3 {
+		t.Fatalf("authors = %+v", got)
+	}
+	// Ties on commits keep the most recent first; the newest name wins
+	if got[0].Name != "Bob" || got[0].Commits != 2 || got[0].Last.Month() != 3 {
+		t.Errorf("first = %+v", got[0])
+	}
+	if got[1].Name != "Ann" || got[1].Commits != 2 || got[2].Name != "Cy" {
+		t.Errorf("rest = %+v", got[1:])
+	}
+}
@@ -0,0 +1,200 @@
+package correlation
+
+import (
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// File hint sources.
+const (
+	FileHintDeclared = "declared" // A "Files:" line in the issue
+	FileHintCommits  = "commits"  // Changed by commits that mention the issue
+)
+
+// DefaultFileHintLimit caps the inferred hints FileHints returns.
+const DefaultFileHintLimit = 10
+
+// FileHint is a file, directory or glob an issue's work is likely to touch.
+type FileHint struct {
+	Path    string `json:"path"`
+	Source  string `json:"source"`            // FileHintDeclared or FileHintCommits
+	Commits int    `json:"commits,omitempty"` // Referencing commits that changed Path
+}
+
+// IssueFiles are the file hints of one issue, for conflict detection.
+type IssueFiles struct {
+	BeadID string
+	Title  string
+	Status string
+	Hints  []FileHint
+}
+
+// filesLinePattern matches a "Files: pkg/auth/**, cmd/bv/main.go" line,
+// optionally as a list item.
+var filesLinePattern = regexp.MustCompile(`(?im)^[ \t]*(?:[-*][ \t]+)?files?:[ \t]*(.+)$`)
+
+// DeclaredFiles returns the paths and globs listed on "Files:" lines of the
+// issue's description, design and notes, in order of appearance.
+func DeclaredFiles(issue model.Issue) []string {
+	var files []string
+	seen := make(map[string]bool)
+	for _, text := range []string{issue.Description, issue.Design, issue.Notes} {
+		for _, match := range filesLinePattern.FindAllStringSubmatch(text, -1) {
+			for _, field := range strings.FieldsFunc(match[1], func(r rune) bool {
+				return r == ',' || r == ' ' || r == '\t'
+			}) {
+				f := normalizePath(strings.Trim(field, "`'\""))
+				if f != "" && !seen[f] {
+					seen[f] = true
+					files = append(files, f)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// FileHints returns the files issue's work is likely to touch: those it
+// declares first, then up to limit files changed by the commits in refs,
+// most often changed first. Beads data files are never inferred, since
+// every commit that updates an issue changes them. refs may be nil; a
+// limit <= 0 means DefaultFileHintLimit.
+func FileHints(issue model.Issue, refs *IssueXRef, limit int) []FileHint {
+	if limit <= 0 {
+		limit = DefaultFileHintLimit
+	}
+	hints := []FileHint{}
+	declared := DeclaredFiles(issue)
+	for _, f := range declared {
+		hints = append(hints, FileHint{Path: f, Source: FileHintDeclared})
+	}
+	if refs == nil {
+		return hints
+	}
+
+	counts := make(map[string]int)
+	for _, c := range refs.Commits {
+		for _, f := range c.Files {
+			f = normalizePath(f)
+			if strings.HasPrefix(f, ".beads/") || strings.HasPrefix(f, ".bv/") {
+				continue
+			}
+			counts[f]++
+		}
+	}
+	inferred := make([]FileHint, 0, len(counts))
+	for f, n := range counts {
+		inferred = append(inferred, FileHint{Path: f, Source: FileHintCommits, Commits: n})
+	}
+	sort.Slice(inferred, func(i, j int) bool {
+		if inferred[i].Commits != inferred[j].Commits {
+			return inferred[i].Commits > inferred[j].Commits
+		}
+		return inferred[i].Path < inferred[j].Path
+	})
+	for _, h := range inferred {
+		if len(hints)-len(declared) == limit {
+			break
+		}
+		if matchesAnyHint(declared, h.Path) {
+			continue // Already covered by what the issue declares
+		}
+		hints = append(hints, h)
+	}
+	return hints
+}
+
+// IssueFileHints returns the file hints of each issue, for
+// FileLookup.SetFileHints. xref may be nil, leaving only declared files.
+func IssueFileHints(issues []model.Issue, xref *XRefReport) []IssueFiles {
+	out := make([]IssueFiles, 0, len(issues))
+	for _, issue := range issues {
+		out = append(out, IssueFiles{
+			BeadID: issue.ID,
+			Title:  issue.Title,
+			Status: string(issue.Status),
+			Hints:  FileHints(issue, xref.For(issue.ID), 0),
+		})
+	}
+	return out
+}
+
+// HintsOverlap returns where two issues' file hints meet, sorted: for each
+// pair of hints where one matches the other, the narrower of the two. Two
+// globs meet only when one matches the other as written, so overlaps
+// between unrelated globs such as *.go and cmd/** go unnoticed.
+func HintsOverlap(a, b []FileHint) []string {
+	seen := make(map[string]bool)
+	var overlap []string
+	for _, x := range a {
+		for _, y := range b {
+			var p string
+			switch {
+			case MatchFileGlob(x.Path, y.Path):
+				p = y.Path
+			case MatchFileGlob(y.Path, x.Path):
+				p = x.Path
+			default:
+				continue
+			}
+			if !seen[p] {
+				seen[p] = true
+				overlap = append(overlap, p)
+			}
+		}
+	}
+	sort.Strings(overlap)
+	return overlap
+}
+
+// MatchFileGlob reports whether file matches a file hint pattern: a glob in
+// which ** spans any number of directories, or a plain path, which matches
+// itself and everything under it.
+func MatchFileGlob(pattern, file string) bool {
+	pattern, file = normalizePath(pattern), normalizePath(file)
+	if pattern == "" || file == "" {
+		return false
+	}
+	if !strings.ContainsAny(pattern, "*?[") {
+		return file == pattern || strings.HasPrefix(file, pattern+"/")
+	}
+	return matchGlobSegments(strings.Split(pattern, "/"), strings.Split(file, "/"))
+}
+
+func matchGlobSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			pattern = pattern[1:]
+			if len(pattern) == 0 {
+				return true
+			}
+			for i := 0; i <= len(segments); i++ {
+				if matchGlobSegments(pattern, segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, err := path.Match(pattern[0], segments[0]); err != nil || !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+func matchesAnyHint(patterns []string, file string) bool {
+	for _, p := range patterns {
+		if MatchFileGlob(p, file) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package correlation
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestDeclaredFiles(t *testing.T) {
+	issue := model.Issue{
+		Description: "Rework token refresh.\n\nFiles: pkg/auth/**, `cmd/bv/main.go`\nprofiles: not a files line",
+		Design:      "- files: ./pkg/auth/ docs/auth.md",
+		Notes:       "File: cmd/bv/main.go",
+	}
+	want := []string{"pkg/auth/**", "cmd/bv/main.go", "pkg/auth", "docs/auth.md"}
+	if got := DeclaredFiles(issue); !reflect.DeepEqual(got, want) {
+		t.Errorf("DeclaredFiles = %q, want %q", got, want)
+	}
+	if got := DeclaredFiles(model.Issue{Description: "No hints here"}); got != nil {
+		t.Errorf("DeclaredFiles without hints = %q", got)
+	}
+}
+
+func TestFileHints(t *testing.T) {
+	issue := model.Issue{Description: "Files: pkg/auth/**"}
+	refs := &IssueXRef{Commits: []XRefCommit{
+		{Files: []string{"pkg/auth/token.go", "pkg/ui/login.go", ".beads/issues.jsonl"}},
+		{Files: []string{"pkg/ui/login.go", "README.md"}},
+		{Files: []string{"pkg/ui/login.go", "go.mod"}},
+	}}
+
+	want := []FileHint{
+		{Path: "pkg/auth/**", Source: FileHintDeclared},
+		{Path: "pkg/ui/login.go", Source: FileHintCommits, Commits: 3},
+		{Path: "README.md", Source: FileHintCommits, Commits: 1},
+	}
+	if got := FileHints(issue, refs, 2); !reflect.DeepEqual(got, want) {
+		t.Errorf("FileHints = %+v, want %+v", got, want)
+	}
+	if got := FileHints(model.Issue{}, nil, 0); got == nil || len(got) != 0 {
+		t.Errorf("FileHints with nothing = %#v, want empty", got)
+	}
+}
+
+func TestMatchFileGlob(t *testing.T) {
+	tests := []struct {
+		pattern, file string
+		want          bool
+	}{
+		{"pkg/auth/**", "pkg/auth/token.go", true},
+		{"pkg/auth/**", "pkg/auth/oauth/client.go", true},
+		{"pkg/auth/**", "pkg/authz/rules.go", false},
+		{"pkg/auth", "pkg/auth/token.go", true},
+		{"pkg/auth/", "pkg/auth/token.go", true},
+		{"pkg/auth", "pkg/authz", false},
+		{"cmd/bv/main.go", "./cmd/bv/main.go", true},
+		{"**/*_test.go", "pkg/ui/model_test.go", true},
+		{"**/*_test.go", "model_test.go", true},
+		{"pkg/*/model.go", "pkg/ui/model.go", true},
+		{"pkg/*/model.go", "pkg/ui/sub/model.go", false},
+		{"pkg/**/model.go", "pkg/ui/sub/model.go", true},
+		{"pkg/[", "pkg/[", false},
+		{"", "pkg/ui/model.go", false},
+	}
+	for _, tt := range tests {
+		if got := MatchFileGlob(tt.pattern, tt.file); got != tt.want {
+			t.Errorf("MatchFileGlob(%q, %q) = %v, want %v", tt.pattern, tt.file, got, tt.want)
+		}
+	}
+}
+
+func TestImpactAnalysisWithFileHints(t *testing.T) {
+	lookup := NewFileLookup(nil)
+	lookup.SetFileHints([]IssueFiles{
+		{BeadID: "bv-1", Title: "Token refresh", Status: "in_progress", Hints: []FileHint{
+			{Path: "pkg/auth/**", Source: FileHintDeclared},
+			{Path: "pkg/auth/token.go", Source: FileHintCommits, Commits: 2},
+		}},
+		{BeadID: "bv-2", Title: "Login page", Status: "open", Hints: []FileHint{
+			{Path: "pkg/ui/login.go", Source: FileHintCommits, Commits: 1},
+		}},
+		{BeadID: "bv-3", Title: "Old auth", Status: "closed", Hints: []FileHint{
+			{Path: "pkg/auth", Source: FileHintDeclared},
+		}},
+	})
+
+	result := lookup.ImpactAnalysis([]string{"pkg/auth/token.go", "pkg/auth/keys.go"})
+	if len(result.AffectedBeads) != 1 {
+		t.Fatalf("affected = %+v, want only bv-1", result.AffectedBeads)
+	}
+	ab := result.AffectedBeads[0]
+	if ab.BeadID != "bv-1" || ab.OverlapCount != 2 ||
+		!reflect.DeepEqual(ab.FileHints, []string{"pkg/auth/**", "pkg/auth/token.go"}) {
+		t.Errorf("bv-1 = %+v", ab)
+	}
+	if len(result.Warnings) == 0 || result.RiskLevel == "low" {
+		t.Errorf("planned in-progress work should be a conflict: %+v", result)
+	}
+}
+
+func TestHintsOverlap(t *testing.T) {
+	hints := func(paths ...string) []FileHint {
+		out := make([]FileHint, len(paths))
+		for i, p := range paths {
+			out[i] = FileHint{Path: p}
+		}
+		return out
+	}
+	tests := []struct {
+		a, b []FileHint
+		want []string
+	}{
+		{hints("pkg/auth/**", "README.md"), hints("pkg/auth/token.go", "README.md"), []string{"README.md", "pkg/auth/token.go"}},
+		{hints("pkg/auth"), hints("pkg/auth/oauth/*.go"), []string{"pkg/auth/oauth/*.go"}},
+		{hints("pkg/ui/model.go"), hints("pkg/auth/**"), nil},
+		{hints("*.go"), hints("cmd/**"), nil}, // Unrelated globs
+	}
+	for _, tt := range tests {
+		if got := HintsOverlap(tt.a, tt.b); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("HintsOverlap(%v, %v) = %q, want %q", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
@@ -4,9 +4,12 @@ package correlation
 import (
 	"fmt"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/plural"
 )
 
 // BeadReference links a bead to a file via commits.
@@ -48,6 +51,7 @@ type FileLookup struct {
 	index    *FileBeadIndex
 	beads    map[string]BeadHistory // BeadID -> history for status lookups
 	coChange *CoChangeMatrix        // Co-change matrix for related files
+	hinted   []IssueFiles           // Open work's file hints, see SetFileHints
 }
 
 // BuildFileIndex creates a file index from a history report.
@@ -158,6 +162,18 @@ func NewFileLookup(report *HistoryReport) *FileLookup {
 	}
 }
 
+// SetFileHints adds the files open and in-progress issues are likely to
+// touch (see FileHints) to ImpactAnalysis, so planned work counts as a
+// conflict before any of its commits exist. Other statuses are ignored.
+func (fl *FileLookup) SetFileHints(issues []IssueFiles) {
+	fl.hinted = fl.hinted[:0]
+	for _, issue := range issues {
+		if (issue.Status == "open" || issue.Status == "in_progress") && len(issue.Hints) > 0 {
+			fl.hinted = append(fl.hinted, issue)
+		}
+	}
+}
+
 // LookupByFile finds all beads that have touched a given file.
 // The path can be exact or a prefix (for directory lookups).
 func (fl *FileLookup) LookupByFile(path string) *FileBeadLookupResult {
@@ -563,6 +579,7 @@ type AffectedBead struct {
 	LastActivity time.Time `json:"last_activity"`
 	Relevance    float64   `json:"relevance"`
 	TotalChanges int       `json:"total_changes"`
+	FileHints    []string  `json:"file_hints,omitempty"` // Hint patterns that matched, see SetFileHints
 }
 
 // ImpactAnalysis analyzes what beads might be affected if the given files are modified.
@@ -648,6 +665,37 @@ func (fl *FileLookup) ImpactAnalysis(files []string) *ImpactResult {
 				ab.LastActivity = ref.LastTouch
 			}
 		}
+
+		for _, issue := range fl.hinted {
+			var matched []string
+			for _, h := range issue.Hints {
+				if MatchFileGlob(h.Path, filePath) {
+					matched = append(matched, h.Path)
+				}
+			}
+			if len(matched) == 0 {
+				continue
+			}
+			ab := beadMap[issue.BeadID]
+			if ab == nil {
+				ab = &AffectedBead{
+					BeadID:       issue.BeadID,
+					Title:        issue.Title,
+					Status:       issue.Status,
+					OverlapFiles: []string{},
+				}
+				beadMap[issue.BeadID] = ab
+			}
+			if len(ab.OverlapFiles) == 0 || ab.OverlapFiles[len(ab.OverlapFiles)-1] != filePath {
+				ab.OverlapFiles = append(ab.OverlapFiles, filePath)
+				ab.OverlapCount = len(ab.OverlapFiles)
+			}
+			for _, m := range matched {
+				if !slices.Contains(ab.FileHints, m) {
+					ab.FileHints = append(ab.FileHints, m)
+				}
+			}
+		}
 	}
 
 	openCount := 0
@@ -716,13 +764,13 @@ func (fl *FileLookup) ImpactAnalysis(files []string) *ImpactResult {
 	} else {
 		parts := []string{}
 		if inProgressCount > 0 {
-			parts = append(parts, fmt.Sprintf("%d %s in progress", inProgressCount, pluralize(inProgressCount, "bead")))
+			parts = append(parts, fmt.Sprintf("%d %s in progress", inProgressCount, plural.Word(inProgressCount, "bead")))
 		}
 		if openCount > 0 {
-			parts = append(parts, fmt.Sprintf("%d open %s", openCount, pluralize(openCount, "bead")))
+			parts = append(parts, fmt.Sprintf("%d open %s", openCount, plural.Word(openCount, "bead")))
 		}
 		if recentClosedCount > 0 {
-			parts = append(parts, fmt.Sprintf("%d recently closed %s", recentClosedCount, pluralize(recentClosedCount, "bead")))
+			parts = append(parts, fmt.Sprintf("%d recently closed %s", recentClosedCount, plural.Word(recentClosedCount, "bead")))
 		}
 		prefix := "Found "
 		if inProgressCount > 0 {
@@ -780,11 +828,3 @@ func containsBeadRef(refs []BeadReference, beadID string) bool {
 	}
 	return false
 }
-
-// pluralize returns the singular or plural form of a word based on count.
-func pluralize(count int, singular string) string {
-	if count == 1 {
-		return singular
-	}
-	return singular + "s"
-}
@@ -45,6 +45,7 @@ type BeadEvent struct {
 	CommitMsg   string    `json:"commit_message"`
 	Author      string    `json:"author"`
 	AuthorEmail string    `json:"author_email"`
+	Details     string    `json:"details,omitempty"` // Field-level summary, e.g. "priority P2 → P1; +dep bv-3"
 }
 
 // CorrelationMethod describes how a commit was linked to a bead
@@ -0,0 +1,96 @@
+package correlation
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Worktree is one working tree of a repository (see git-worktree).
+type Worktree struct {
+	Path     string `json:"path"`
+	Head     string `json:"head,omitempty"`   // Commit checked out
+	Branch   string `json:"branch,omitempty"` // Short name; empty when detached
+	Detached bool   `json:"detached,omitempty"`
+	Bare     bool   `json:"bare,omitempty"`
+	Current  bool   `json:"current,omitempty"` // The one repoPath is in
+}
+
+// Checkout is where a command runs: the top of its working tree and the
+// branch checked out there.
+type Checkout struct {
+	Worktree string `json:"worktree"`
+	Branch   string `json:"branch,omitempty"` // Empty when detached
+}
+
+// CurrentCheckout returns the working tree and branch of repoPath.
+func CurrentCheckout(repoPath string) (Checkout, error) {
+	top, err := runGitOutput(repoPath, "rev-parse", "--show-toplevel")
+	if err != nil {
+		return Checkout{}, fmt.Errorf("not a git working tree: %w", err)
+	}
+	c := Checkout{Worktree: filepath.Clean(top)}
+	if branch, err := runGitOutput(repoPath, "symbolic-ref", "--short", "-q", "HEAD"); err == nil {
+		c.Branch = branch
+	}
+	return c, nil
+}
+
+// Worktrees lists the working trees of the repository at repoPath, the main
+// one first, marking the one repoPath is in.
+func Worktrees(repoPath string) ([]Worktree, error) {
+	out, err := runGitOutput(repoPath, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, fmt.Errorf("git worktree list failed: %w", err)
+	}
+	worktrees := parseWorktrees(out)
+	if current, err := CurrentCheckout(repoPath); err == nil {
+		for i := range worktrees {
+			if SamePath(worktrees[i].Path, current.Worktree) {
+				worktrees[i].Current = true
+			}
+		}
+	}
+	return worktrees, nil
+}
+
+// parseWorktrees reads `git worktree list --porcelain`: blank-line separated
+// records of "worktree <path>", "HEAD <sha>", "branch refs/heads/<name>",
+// "detached", and "bare" lines.
+func parseWorktrees(out string) []Worktree {
+	var worktrees []Worktree
+	for _, record := range strings.Split(out, "\n\n") {
+		var wt Worktree
+		for _, line := range strings.Split(strings.TrimSpace(record), "\n") {
+			key, value, _ := strings.Cut(line, " ")
+			switch key {
+			case "worktree":
+				wt.Path = filepath.Clean(value)
+			case "HEAD":
+				wt.Head = value
+			case "branch":
+				wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+			case "detached":
+				wt.Detached = true
+			case "bare":
+				wt.Bare = true
+			}
+		}
+		if wt.Path != "" {
+			worktrees = append(worktrees, wt)
+		}
+	}
+	return worktrees
+}
+
+// SamePath reports whether two paths name the same directory, resolving
+// symlinks where they exist.
+func SamePath(a, b string) bool {
+	if ra, err := filepath.EvalSymlinks(a); err == nil {
+		a = ra
+	}
+	if rb, err := filepath.EvalSymlinks(b); err == nil {
+		b = rb
+	}
+	return filepath.Clean(a) == filepath.Clean(b)
+}
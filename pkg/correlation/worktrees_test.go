@@ -0,0 +1,51 @@
+package correlation
+
+import (
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestParseWorktrees(t *testing.T) {
+	out := "worktree /src/app\nHEAD 1111\nbranch refs/heads/main\n\n" +
+		"worktree /src/app-b\nHEAD 2222\nbranch refs/heads/feature/b\n\n" +
+		"worktree /src/app-c\nHEAD 3333\ndetached\n\n" +
+		"worktree /src/bare.git\nbare"
+	want := []Worktree{
+		{Path: filepath.Clean("/src/app"), Head: "1111", Branch: "main"},
+		{Path: filepath.Clean("/src/app-b"), Head: "2222", Branch: "feature/b"},
+		{Path: filepath.Clean("/src/app-c"), Head: "3333", Detached: true},
+		{Path: filepath.Clean("/src/bare.git"), Bare: true},
+	}
+	if got := parseWorktrees(out); !reflect.DeepEqual(got, want) {
+		t.Errorf("parseWorktrees = %+v, want %+v", got, want)
+	}
+}
+
+func TestCurrentCheckout(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	if out, err := exec.Command("git", "init", "-q", "-b", "trunk", dir).CombinedOutput(); err != nil {
+		t.Fatalf("git init: %v\n%s", err, out)
+	}
+	c, err := CurrentCheckout(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Branch != "trunk" || !SamePath(c.Worktree, dir) {
+		t.Errorf("checkout = %+v, want trunk at %s", c, dir)
+	}
+	worktrees, err := Worktrees(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(worktrees) != 1 || !worktrees[0].Current {
+		t.Errorf("worktrees = %+v", worktrees)
+	}
+	if _, err := CurrentCheckout(t.TempDir()); err == nil {
+		t.Error("a directory outside git should be an error")
+	}
+}
@@ -0,0 +1,363 @@
+// Package correlation provides cross-referencing of issue IDs mentioned in
+// commit messages and branch names.
+package correlation
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultXRefMaxCommits is how many commits CrossReference scans by default,
+// newest first across all branches.
+const DefaultXRefMaxCommits = 2000
+
+// Merge status of the code referencing an issue.
+const (
+	MergeStatusMerged   = "merged"   // Every commit and branch is in the mainline
+	MergeStatusUnmerged = "unmerged" // None of them is
+	MergeStatusPartial  = "partial"  // Some are
+	MergeStatusNone     = "none"     // Nothing references the issue
+)
+
+// XRefOptions controls CrossReference.
+type XRefOptions struct {
+	MaxCommits int // Commits to scan; 0 means DefaultXRefMaxCommits
+}
+
+// XRefCommit is a commit whose message mentions an issue.
+type XRefCommit struct {
+	SHA       string    `json:"sha"`
+	ShortSHA  string    `json:"short_sha"`
+	Author    string    `json:"author"`
+	Timestamp time.Time `json:"timestamp"`
+	Subject   string    `json:"subject"`
+	Merged    bool      `json:"merged"`          // Reachable from the mainline
+	Files     []string  `json:"files,omitempty"` // Paths the commit changed
+}
+
+// XRefBranch is a branch whose name mentions an issue.
+type XRefBranch struct {
+	Name       string    `json:"name"`
+	Merged     bool      `json:"merged"` // Its tip is in the mainline
+	LastAuthor string    `json:"last_author"`
+	Updated    time.Time `json:"updated"`
+}
+
+// IssueXRef is the code that references one issue.
+type IssueXRef struct {
+	Commits     []XRefCommit `json:"commits"` // Newest first
+	Branches    []XRefBranch `json:"branches"`
+	LastAuthor  string       `json:"last_author,omitempty"` // Of the newest commit or branch tip
+	LastTouched *time.Time   `json:"last_touched,omitempty"`
+	MergeStatus string       `json:"merge_status"`
+}
+
+// XRefReport maps issue IDs to the commits and branches that mention them.
+// Issues nothing mentions are absent.
+type XRefReport struct {
+	Mainline string                // The branch merge status is measured against
+	Issues   map[string]*IssueXRef // By issue ID
+}
+
+// For returns the references to issueID, or nil if there are none.
+func (r *XRefReport) For(issueID string) *IssueXRef {
+	if r == nil {
+		return nil
+	}
+	return r.Issues[issueID]
+}
+
+// CrossReference scans the commit messages and branch names of the git
+// repository at repoPath for the given issue IDs. Commits on every branch
+// count; merge status is measured against the mainline: origin's default
+// branch, else main or master, else HEAD.
+func CrossReference(repoPath string, issueIDs []string, opts XRefOptions) (*XRefReport, error) {
+	if opts.MaxCommits <= 0 {
+		opts.MaxCommits = DefaultXRefMaxCommits
+	}
+	report := &XRefReport{Issues: make(map[string]*IssueXRef)}
+	matcher := newIDMatcher(issueIDs)
+	if matcher == nil {
+		return report, nil
+	}
+	if _, err := runGitOutput(repoPath, "rev-parse", "--git-dir"); err != nil {
+		return nil, fmt.Errorf("not a git repository: %w", err)
+	}
+	report.Mainline = resolveMainline(repoPath)
+
+	commits, err := xrefCommits(repoPath, opts.MaxCommits, matcher, report)
+	if err != nil {
+		return nil, err
+	}
+	if err := xrefBranches(repoPath, matcher, report); err != nil {
+		return nil, err
+	}
+	if err := markMergedCommits(repoPath, report.Mainline, commits); err != nil {
+		return nil, err
+	}
+
+	for _, x := range report.Issues {
+		merged, total := 0, len(x.Commits)+len(x.Branches)
+		var last time.Time
+		for _, c := range x.Commits {
+			if c.Merged {
+				merged++
+			}
+			if c.Timestamp.After(last) {
+				last, x.LastAuthor = c.Timestamp, c.Author
+			}
+		}
+		for _, b := range x.Branches {
+			if b.Merged {
+				merged++
+			}
+			if b.Updated.After(last) {
+				last, x.LastAuthor = b.Updated, b.LastAuthor
+			}
+		}
+		if !last.IsZero() {
+			x.LastTouched = &last
+		}
+		switch merged {
+		case total:
+			x.MergeStatus = MergeStatusMerged
+		case 0:
+			x.MergeStatus = MergeStatusUnmerged
+		default:
+			x.MergeStatus = MergeStatusPartial
+		}
+		sort.Slice(x.Branches, func(i, j int) bool { return x.Branches[i].Name < x.Branches[j].Name })
+	}
+	return report, nil
+}
+
+// xrefCommits adds the commits mentioning issues to report. It returns the
+// commits added, so their merge status can be filled in.
+func xrefCommits(repoPath string, maxCommits int, matcher *idMatcher, report *XRefReport) ([]*XRefCommit, error) {
+	// Each record is \x1e, the header fields, \x1d, then the changed paths
+	out, err := runGitOutput(repoPath, "-c", "color.ui=false", "log", "--all", "--name-only",
+		"--max-count="+strconv.Itoa(maxCommits),
+		"--format=%x1e%H%x1f%an%x1f%aI%x1f%s%x1f%b%x1d")
+	if err != nil {
+		return nil, fmt.Errorf("git log failed: %w", err)
+	}
+	for _, record := range strings.Split(out, "\x1e") {
+		header, names, _ := strings.Cut(record, "\x1d")
+		fields := strings.Split(header, "\x1f")
+		if len(fields) != 5 {
+			continue
+		}
+		ids := matcher.find(fields[3] + "\n" + fields[4])
+		if len(ids) == 0 {
+			continue
+		}
+		when, _ := time.Parse(time.RFC3339, fields[2])
+		var files []string
+		for _, name := range strings.Split(names, "\n") {
+			if name = strings.TrimSpace(name); name != "" {
+				files = append(files, name)
+			}
+		}
+		for _, id := range ids {
+			x := report.issue(id)
+			x.Commits = append(x.Commits, XRefCommit{
+				SHA:       fields[0],
+				ShortSHA:  shortSHA(fields[0]),
+				Author:    fields[1],
+				Timestamp: when,
+				Subject:   fields[3],
+				Files:     files,
+			})
+		}
+	}
+	var added []*XRefCommit
+	for _, x := range report.Issues {
+		for i := range x.Commits {
+			added = append(added, &x.Commits[i])
+		}
+	}
+	return added, nil
+}
+
+// xrefBranches adds the local and remote branches whose names mention
+// issues to report.
+func xrefBranches(repoPath string, matcher *idMatcher, report *XRefReport) error {
+	out, err := runGitOutput(repoPath, "for-each-ref",
+		"--format=%(refname)%1f%(refname:short)%1f%(authorname)%1f%(committerdate:iso-strict)",
+		"refs/heads", "refs/remotes")
+	if err != nil {
+		return fmt.Errorf("listing branches: %w", err)
+	}
+	merged := make(map[string]bool)
+	if report.Mainline != "" {
+		if list, err := runGitOutput(repoPath, "for-each-ref", "--merged="+report.Mainline,
+			"--format=%(refname)", "refs/heads", "refs/remotes"); err == nil {
+			for _, ref := range strings.Split(list, "\n") {
+				merged[ref] = true
+			}
+		}
+	}
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "\x1f")
+		if len(fields) != 4 || strings.HasSuffix(fields[0], "/HEAD") {
+			continue
+		}
+		updated, _ := time.Parse(time.RFC3339, fields[3])
+		for _, id := range matcher.find(fields[1]) {
+			x := report.issue(id)
+			x.Branches = append(x.Branches, XRefBranch{
+				Name:       fields[1],
+				Merged:     merged[fields[0]],
+				LastAuthor: fields[2],
+				Updated:    updated,
+			})
+		}
+	}
+	return nil
+}
+
+// markMergedCommits sets Merged on the commits reachable from mainline.
+func markMergedCommits(repoPath, mainline string, commits []*XRefCommit) error {
+	if mainline == "" || len(commits) == 0 {
+		return nil
+	}
+	// Only the mainline back to the oldest referencing commit matters
+	oldest := commits[0].Timestamp
+	for _, c := range commits {
+		if c.Timestamp.Before(oldest) {
+			oldest = c.Timestamp
+		}
+	}
+	since := strconv.FormatInt(oldest.Add(-24*time.Hour).Unix(), 10)
+	out, err := runGitOutput(repoPath, "rev-list", "--since="+since, mainline)
+	if err != nil {
+		return fmt.Errorf("git rev-list failed: %w", err)
+	}
+	inMainline := make(map[string]bool)
+	for _, sha := range strings.Split(out, "\n") {
+		inMainline[sha] = true
+	}
+	for _, c := range commits {
+		c.Merged = inMainline[c.SHA]
+	}
+	return nil
+}
+
+// Mainline returns the repository's mainline branch (see resolveMainline),
+// or "" for a repository without commits.
+func Mainline(repoPath string) string {
+	return resolveMainline(repoPath)
+}
+
+// resolveMainline picks the branch that counts as merged: origin's default
+// branch, else main or master, else HEAD. It returns "" for a repository
+// without commits.
+func resolveMainline(repoPath string) string {
+	if ref, err := runGitOutput(repoPath, "symbolic-ref", "-q", "--short", "refs/remotes/origin/HEAD"); err == nil && ref != "" {
+		return ref
+	}
+	for _, name := range []string{"main", "master"} {
+		if _, err := runGitOutput(repoPath, "rev-parse", "--verify", "-q", "refs/heads/"+name); err == nil {
+			return name
+		}
+	}
+	if _, err := runGitOutput(repoPath, "rev-parse", "--verify", "-q", "HEAD"); err == nil {
+		return "HEAD"
+	}
+	return ""
+}
+
+func (r *XRefReport) issue(id string) *IssueXRef {
+	x, ok := r.Issues[id]
+	if !ok {
+		x = &IssueXRef{Commits: []XRefCommit{}, Branches: []XRefBranch{}}
+		r.Issues[id] = x
+	}
+	return x
+}
+
+// runGitOutput runs git in repoPath and returns its trimmed stdout.
+func runGitOutput(repoPath string, args ...string) (string, error) {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = repoPath
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("%s", msg)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// idMatcher finds known issue IDs in free text such as commit messages
+// and branch names ("fix/bv-12-cache"). An ID matches only as a whole
+// token: bv-1 is not found in bv-12.
+type idMatcher struct {
+	pattern *regexp.Regexp
+	ids     map[string]string // Lowercased ID -> ID
+}
+
+// newIDMatcher builds a matcher for ids, or returns nil when none can be
+// matched. IDs without a prefix ("12") are too ambiguous and are skipped.
+func newIDMatcher(ids []string) *idMatcher {
+	m := &idMatcher{ids: make(map[string]string, len(ids))}
+	prefixSet := make(map[string]bool)
+	for _, id := range ids {
+		cut := strings.LastIndex(id, "-")
+		if cut <= 0 {
+			continue
+		}
+		m.ids[strings.ToLower(id)] = id
+		prefixSet[regexp.QuoteMeta(strings.ToLower(id[:cut]))] = true
+	}
+	if len(prefixSet) == 0 {
+		return nil
+	}
+	prefixes := make([]string, 0, len(prefixSet))
+	for p := range prefixSet {
+		prefixes = append(prefixes, p)
+	}
+	// Longest first, so "my-proj" wins over "my" for my-proj-12
+	sort.Slice(prefixes, func(i, j int) bool {
+		if len(prefixes[i]) != len(prefixes[j]) {
+			return len(prefixes[i]) > len(prefixes[j])
+		}
+		return prefixes[i] < prefixes[j]
+	})
+	m.pattern = regexp.MustCompile(`(?i)(?:^|[^A-Za-z0-9])((?:` + strings.Join(prefixes, "|") + `)-[A-Za-z0-9]+(?:\.[0-9]+)*)`)
+	return m
+}
+
+// find returns the known IDs mentioned in text, in order of appearance.
+func (m *idMatcher) find(text string) []string {
+	var found []string
+	seen := make(map[string]bool)
+	for _, match := range m.pattern.FindAllStringSubmatch(text, -1) {
+		candidate := strings.ToLower(match[1])
+		for {
+			if id, ok := m.ids[candidate]; ok {
+				if !seen[id] {
+					seen[id] = true
+					found = append(found, id)
+				}
+				break
+			}
+			// bv-3.2 mentions bv-3 when there is no child issue bv-3.2
+			dot := strings.LastIndex(candidate, ".")
+			if dot < 0 {
+				break
+			}
+			candidate = candidate[:dot]
+		}
+	}
+	return found
+}
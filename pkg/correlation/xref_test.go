@@ -0,0 +1,108 @@
+package correlation
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestIDMatcherFind(t *testing.T) {
+	m := newIDMatcher([]string{"bv-1", "bv-12", "bv-3", "bv-3.2", "my-proj-7", "loose"})
+	tests := []struct {
+		text string
+		want []string
+	}{
+		{"Fix bv-12: cache", []string{"bv-12"}},
+		{"[BV-1] start, then bv-1 again", []string{"bv-1"}},
+		{"feature/bv-12-add-cache", []string{"bv-12"}},
+		{"bv-123 and xbv-1 are other things", nil},
+		{"bv-3.2 and bv-3.9", []string{"bv-3.2", "bv-3"}},
+		{"refs my-proj-7,bv-1", []string{"my-proj-7", "bv-1"}},
+		{"loose ends", nil},
+	}
+	for _, tt := range tests {
+		if got := m.find(tt.text); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("find(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+	if newIDMatcher([]string{"12", "loose"}) != nil {
+		t.Error("IDs without a prefix shouldn't build a matcher")
+	}
+}
+
+func TestCrossReference(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not available")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		t.Helper()
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "GIT_AUTHOR_NAME=Ann", "GIT_AUTHOR_EMAIL=ann@example.com",
+			"GIT_COMMITTER_NAME=Ann", "GIT_COMMITTER_EMAIL=ann@example.com")
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+	commit := func(msg string) {
+		t.Helper()
+		f := filepath.Join(dir, "log.txt")
+		data, _ := os.ReadFile(f)
+		if err := os.WriteFile(f, append(data, msg+"\n"...), 0644); err != nil {
+			t.Fatal(err)
+		}
+		git("add", ".")
+		git("commit", "-q", "-m", msg)
+	}
+
+	git("init", "-q", "-b", "main")
+	commit("Initial commit")
+	commit("Add the cache\n\nCloses bv-1")
+	git("checkout", "-q", "-b", "feature/bv-2-api")
+	commit("WIP on the API (bv-2)")
+	git("checkout", "-q", "main")
+	git("checkout", "-q", "-b", "bv-1-followup")
+	git("checkout", "-q", "main")
+
+	report, err := CrossReference(dir, []string{"bv-1", "bv-2", "bv-3"}, XRefOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if report.Mainline != "main" {
+		t.Errorf("mainline = %q", report.Mainline)
+	}
+
+	one := report.For("bv-1")
+	if one == nil || len(one.Commits) != 1 || len(one.Branches) != 1 {
+		t.Fatalf("bv-1 refs = %+v", one)
+	}
+	if c := one.Commits[0]; !c.Merged || c.Subject != "Add the cache" || c.Author != "Ann" || len(c.ShortSHA) != 7 ||
+		!reflect.DeepEqual(c.Files, []string{"log.txt"}) {
+		t.Errorf("bv-1 commit = %+v", c)
+	}
+	if b := one.Branches[0]; b.Name != "bv-1-followup" || !b.Merged {
+		t.Errorf("bv-1 branch = %+v", b)
+	}
+	if one.MergeStatus != MergeStatusMerged || one.LastAuthor != "Ann" || one.LastTouched == nil {
+		t.Errorf("bv-1 = %+v", one)
+	}
+
+	two := report.For("bv-2")
+	if two == nil || len(two.Commits) != 1 || len(two.Branches) != 1 {
+		t.Fatalf("bv-2 refs = %+v", two)
+	}
+	if two.Commits[0].Merged || two.Branches[0].Merged || two.MergeStatus != MergeStatusUnmerged {
+		t.Errorf("bv-2 is only on a feature branch: %+v", two)
+	}
+
+	if report.For("bv-3") != nil {
+		t.Error("nothing mentions bv-3")
+	}
+
+	if _, err := CrossReference(t.TempDir(), []string{"bv-1"}, XRefOptions{}); err == nil {
+		t.Error("a directory outside git should be an error")
+	}
+}
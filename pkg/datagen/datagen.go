@@ -0,0 +1,219 @@
+// Package datagen generates synthetic beads datasets of a given size and
+// dependency shape. Output is a pure function of the config: the same seed
+// always yields byte-identical issues, so a dataset can be named in a bug or
+// performance report ("bv generate --nodes 5000 --shape dense --seed 7")
+// instead of attached to it.
+package datagen
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Shape is the dependency structure of a generated dataset.
+type Shape string
+
+const (
+	// ShapeChain: every issue is blocked by the one before it.
+	ShapeChain Shape = "chain"
+	// ShapeDense: every issue is blocked by up to five issues before it.
+	ShapeDense Shape = "dense"
+	// ShapeCyclic: a ring; every issue is blocked by the next, and the last by the first.
+	ShapeCyclic Shape = "cyclic"
+	// ShapeLayered: issues in layers of about √n, each blocked by one to three
+	// issues of the layer before.
+	ShapeLayered Shape = "layered"
+)
+
+// Shapes lists the supported shapes, in the order help text shows them.
+var Shapes = []Shape{ShapeChain, ShapeDense, ShapeCyclic, ShapeLayered}
+
+// DefaultStart is when generated histories begin unless Config.Start is set.
+// It's fixed rather than time.Now so output doesn't depend on the day.
+var DefaultStart = time.Date(2025, 1, 6, 9, 0, 0, 0, time.UTC)
+
+// Config controls generation.
+type Config struct {
+	Nodes  int       // Number of issues (at least 1)
+	Shape  Shape     // Dependency structure
+	Seed   int64     // Random seed
+	Prefix string    // Issue ID prefix (default "gen")
+	Start  time.Time // Creation time of the first issue (default DefaultStart)
+}
+
+// Generate builds the dataset described by cfg. Issues come back in ID
+// order; IDs are zero-padded so that order is also creation order.
+func Generate(cfg Config) ([]model.Issue, error) {
+	if cfg.Nodes < 1 {
+		return nil, fmt.Errorf("nodes must be at least 1, got %d", cfg.Nodes)
+	}
+	blockers, err := blockersFor(cfg.Shape, cfg.Nodes, rand.New(rand.NewSource(cfg.Seed)))
+	if err != nil {
+		return nil, err
+	}
+	if cfg.Prefix == "" {
+		cfg.Prefix = "gen"
+	}
+	if cfg.Start.IsZero() {
+		cfg.Start = DefaultStart
+	}
+
+	// A second stream for content, so the shape's draws don't shift titles
+	rng := rand.New(rand.NewSource(cfg.Seed ^ 0x5eed))
+	width := len(fmt.Sprint(cfg.Nodes))
+	id := func(i int) string { return fmt.Sprintf("%s-%0*d", cfg.Prefix, width, i+1) }
+
+	issues := make([]model.Issue, cfg.Nodes)
+	created := cfg.Start.UTC()
+	for i := range issues {
+		created = created.Add(time.Duration(1+rng.Intn(12)) * time.Hour)
+		issue := newIssue(rng, id(i), created)
+		for _, b := range blockers[i] {
+			issue.Dependencies = append(issue.Dependencies, &model.Dependency{
+				IssueID:     issue.ID,
+				DependsOnID: id(b),
+				Type:        model.DepBlocks,
+				CreatedAt:   created,
+			})
+		}
+		issues[i] = issue
+	}
+	progress(rng, issues, blockers)
+	return issues, nil
+}
+
+// blockersFor returns, for each issue index, the indexes of the issues that
+// block it.
+func blockersFor(shape Shape, n int, rng *rand.Rand) ([][]int, error) {
+	blockers := make([][]int, n)
+	switch shape {
+	case ShapeChain:
+		for i := 1; i < n; i++ {
+			blockers[i] = []int{i - 1}
+		}
+	case ShapeDense:
+		for i := 1; i < n; i++ {
+			for j := 1; j <= 5 && i-j >= 0; j++ {
+				blockers[i] = append(blockers[i], i-j)
+			}
+		}
+	case ShapeCyclic:
+		if n > 1 {
+			for i := 0; i < n; i++ {
+				blockers[i] = []int{(i + 1) % n}
+			}
+		}
+	case ShapeLayered:
+		width := int(math.Ceil(math.Sqrt(float64(n))))
+		for i := width; i < n; i++ {
+			prev := (i/width - 1) * width
+			picks := rng.Perm(width)[:1+rng.Intn(min(3, width))]
+			for _, p := range picks {
+				blockers[i] = append(blockers[i], prev+p)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unknown shape %q (want %s)", shape, shapeList())
+	}
+	return blockers, nil
+}
+
+func shapeList() string {
+	names := make([]string, len(Shapes))
+	for i, s := range Shapes {
+		names[i] = string(s)
+	}
+	return strings.Join(names, ", ")
+}
+
+var (
+	components = []string{"auth service", "search index", "billing API", "session cache", "export pipeline",
+		"settings page", "sync worker", "CLI parser", "dashboard", "notification queue", "upload handler", "audit log"}
+	problems      = []string{"crash", "race condition", "memory leak", "timeout", "off-by-one error", "stale results", "panic on empty input"}
+	features      = []string{"bulk editing", "CSV export", "rate limiting", "webhooks", "SSO login", "offline mode", "dark mode", "saved filters"}
+	taskVerbs     = []string{"Refactor", "Document", "Add metrics to", "Write integration tests for", "Migrate", "Profile"}
+	choreVerbs    = []string{"Upgrade dependencies of", "Clean up logging in", "Remove dead code from", "Tidy config for"}
+	labelPool     = []string{"backend", "frontend", "api", "database", "ui", "auth", "performance", "security", "docs", "testing"}
+	assigneePool  = []string{"alice", "bob", "carol", "dave", "erin"}
+	typeWeights   = []model.IssueType{model.TypeTask, model.TypeTask, model.TypeTask, model.TypeBug, model.TypeBug, model.TypeFeature, model.TypeFeature, model.TypeChore}
+	priorityTable = []int{0, 1, 1, 2, 2, 2, 2, 3, 3, 4}
+)
+
+func pick[T any](rng *rand.Rand, from []T) T { return from[rng.Intn(len(from))] }
+
+// newIssue makes an open issue with plausible content.
+func newIssue(rng *rand.Rand, id string, created time.Time) model.Issue {
+	issueType := pick(rng, typeWeights)
+	component := pick(rng, components)
+	var title string
+	switch issueType {
+	case model.TypeBug:
+		title = fmt.Sprintf("Fix %s in %s", pick(rng, problems), component)
+	case model.TypeFeature:
+		title = fmt.Sprintf("Add %s to %s", pick(rng, features), component)
+	case model.TypeChore:
+		title = fmt.Sprintf("%s %s", pick(rng, choreVerbs), component)
+	default:
+		title = fmt.Sprintf("%s %s", pick(rng, taskVerbs), component)
+	}
+
+	labels := []string{pick(rng, labelPool)}
+	if rng.Intn(3) == 0 {
+		if extra := pick(rng, labelPool); extra != labels[0] {
+			labels = append(labels, extra)
+		}
+	}
+	minutes := 30 * (1 + rng.Intn(16)) // 30 minutes to a day
+	return model.Issue{
+		ID:               id,
+		Title:            title,
+		Description:      fmt.Sprintf("Synthetic %s for the %s.", issueType, component),
+		Status:           model.StatusOpen,
+		Priority:         pick(rng, priorityTable),
+		IssueType:        issueType,
+		EstimatedMinutes: &minutes,
+		CreatedAt:        created,
+		UpdatedAt:        created,
+		Labels:           labels,
+	}
+}
+
+// progress moves some issues along: an issue can only be closed once its
+// blockers are, and then no earlier than the last of them. About half of the
+// issues that can be closed are; of the rest with nothing open blocking them,
+// some are in progress. Issues are visited in index order, which is a
+// topological order for every shape but cyclic, where nothing on the ring
+// ever gets closed.
+func progress(rng *rand.Rand, issues []model.Issue, blockers [][]int) {
+	for i := range issues {
+		issue := &issues[i]
+		ready, start := true, issue.CreatedAt
+		for _, b := range blockers[i] {
+			if b >= i || issues[b].Status != model.StatusClosed {
+				ready = false
+			} else if issues[b].ClosedAt.After(start) {
+				start = *issues[b].ClosedAt
+			}
+		}
+		if !ready {
+			continue
+		}
+		switch r := rng.Intn(10); {
+		case r < 5:
+			closed := start.Add(time.Duration(*issue.EstimatedMinutes)*time.Minute + time.Duration(rng.Intn(72))*time.Hour)
+			issue.Status = model.StatusClosed
+			issue.ClosedAt = &closed
+			issue.UpdatedAt = closed
+			issue.Assignee = pick(rng, assigneePool)
+		case r < 7:
+			issue.Status = model.StatusInProgress
+			issue.UpdatedAt = start.Add(time.Duration(1+rng.Intn(48)) * time.Hour)
+			issue.Assignee = pick(rng, assigneePool)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package datagen
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestGenerateIsDeterministic(t *testing.T) {
+	for _, shape := range Shapes {
+		cfg := Config{Nodes: 120, Shape: shape, Seed: 7}
+		a, err := Generate(cfg)
+		if err != nil {
+			t.Fatalf("%s: %v", shape, err)
+		}
+		b, _ := Generate(cfg)
+		ja, err := model.MarshalIssues(a)
+		if err != nil {
+			t.Fatalf("%s: generated issues don't validate: %v", shape, err)
+		}
+		jb, _ := model.MarshalIssues(b)
+		if !bytes.Equal(ja, jb) {
+			t.Errorf("%s: same seed, different output", shape)
+		}
+		cfg.Seed = 8
+		c, _ := Generate(cfg)
+		if jc, _ := model.MarshalIssues(c); bytes.Equal(ja, jc) {
+			t.Errorf("%s: different seed, same output", shape)
+		}
+	}
+}
+
+func TestGenerateShapes(t *testing.T) {
+	tests := []struct {
+		shape     Shape
+		edges     int
+		hasCycles bool
+	}{
+		{ShapeChain, 99, false},
+		{ShapeDense, 5*100 - 15, false},
+		{ShapeCyclic, 100, true},
+		{ShapeLayered, -1, false},
+	}
+	for _, tt := range tests {
+		issues, err := Generate(Config{Nodes: 100, Shape: tt.shape, Seed: 1})
+		if err != nil {
+			t.Fatalf("%s: %v", tt.shape, err)
+		}
+		byID := make(map[string]*model.Issue)
+		for i := range issues {
+			byID[issues[i].ID] = &issues[i]
+		}
+		edges := 0
+		for _, issue := range issues {
+			for _, dep := range issue.Dependencies {
+				edges++
+				blocker := byID[dep.DependsOnID]
+				if blocker == nil {
+					t.Fatalf("%s: %s depends on missing %s", tt.shape, issue.ID, dep.DependsOnID)
+				}
+				if issue.Status == model.StatusClosed && (blocker.Status != model.StatusClosed || issue.ClosedAt.Before(*blocker.ClosedAt)) {
+					t.Errorf("%s: %s closed before its blocker %s", tt.shape, issue.ID, blocker.ID)
+				}
+			}
+		}
+		if tt.edges >= 0 && edges != tt.edges {
+			t.Errorf("%s: %d edges, want %d", tt.shape, edges, tt.edges)
+		}
+		if tt.shape == ShapeLayered && edges < 90 {
+			t.Errorf("layered: only %d edges", edges)
+		}
+		stats := analysis.NewAnalyzer(issues).Analyze()
+		if got := len(stats.Cycles()) > 0; got != tt.hasCycles {
+			t.Errorf("%s: has cycles = %v, want %v", tt.shape, got, tt.hasCycles)
+		}
+	}
+}
+
+func TestGenerateRejectsBadConfig(t *testing.T) {
+	if _, err := Generate(Config{Nodes: 0, Shape: ShapeChain}); err == nil {
+		t.Error("zero nodes accepted")
+	}
+	if _, err := Generate(Config{Nodes: 5, Shape: "star"}); err == nil {
+		t.Error("unknown shape accepted")
+	}
+}
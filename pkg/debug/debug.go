@@ -1,11 +1,13 @@
-// Package debug provides conditional debug logging for bv.
+// Package debug provides logging for bv: leveled, structured per-subsystem
+// loggers (see For and Configure in slog.go) and the original printf-style
+// debug helpers below, which now write through the same slog handler.
 //
 // Debug logging is enabled by setting the BV_DEBUG environment variable:
 //
 //	BV_DEBUG=1 bv --robot-triage
 //
-// When enabled, debug messages are written to stderr with timestamps.
-// When disabled (default), all debug functions are no-ops with zero overhead.
+// When enabled, debug messages are written to stderr (or the configured log
+// file). When disabled (default), the printf-style functions are no-ops.
 //
 // Usage:
 //
@@ -21,24 +23,78 @@ package debug
 import (
 	"fmt"
 	"log"
+	"log/slog"
 	"os"
+	"strings"
+	"sync"
 	"time"
 )
 
 var (
-	// enabled is true when BV_DEBUG env var is set
+	// enabled is true when the "debug" subsystem logs at debug level
+	// (BV_DEBUG, BV_LOG_LEVEL, or --log-level)
 	enabled bool
-	// logger writes to stderr with [BV_DEBUG] prefix
+	// logger is the printf-style shim over the slog handler
 	logger *log.Logger
 )
 
 func init() {
-	if os.Getenv("BV_DEBUG") != "" {
-		enabled = true
-		logger = log.New(os.Stderr, "[BV_DEBUG] ", log.Ltime|log.Lmicroseconds)
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if err := Configure(opts); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
 	}
 }
 
+// recentCapacity is how many log lines are kept in memory for diagnostics
+// bundles (see RecentLines).
+const recentCapacity = 200
+
+// recentLines is a ring buffer of the most recent debug log lines.
+var recentLines = &ringWriter{lines: make([]string, 0, recentCapacity)}
+
+// ringWriter is an io.Writer that keeps the last recentCapacity lines.
+type ringWriter struct {
+	mu    sync.Mutex
+	lines []string
+	next  int
+}
+
+func (r *ringWriter) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, line := range strings.Split(strings.TrimRight(string(p), "\n"), "\n") {
+		if len(r.lines) < recentCapacity {
+			r.lines = append(r.lines, line)
+			continue
+		}
+		r.lines[r.next] = line
+		r.next = (r.next + 1) % recentCapacity
+	}
+	return len(p), nil
+}
+
+// RecentLines returns up to n of the most recent debug log lines, oldest
+// first. Lines are only recorded while debug logging is enabled.
+func RecentLines(n int) []string {
+	r := recentLines
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	ordered := append(append([]string{}, r.lines[r.next:]...), r.lines[:r.next]...)
+	if n >= 0 && len(ordered) > n {
+		ordered = ordered[len(ordered)-n:]
+	}
+	return ordered
+}
+
+// newLogger returns a *log.Logger that emits debug-level records for the
+// "debug" subsystem through the current slog handler.
+func newLogger() *log.Logger {
+	return slog.NewLogLogger(currentHandler().WithAttrs([]slog.Attr{slog.String("subsystem", "debug")}), slog.LevelDebug)
+}
+
 // Enabled returns whether debug logging is enabled.
 func Enabled() bool {
 	return enabled
@@ -49,7 +105,7 @@ func Enabled() bool {
 func SetEnabled(e bool) {
 	enabled = e
 	if e && logger == nil {
-		logger = log.New(os.Stderr, "[BV_DEBUG] ", log.Ltime|log.Lmicroseconds)
+		logger = newLogger()
 	}
 }
 
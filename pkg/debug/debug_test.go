@@ -2,6 +2,7 @@ package debug
 
 import (
 	"bytes"
+	"fmt"
 	"log"
 	"strings"
 	"testing"
@@ -336,3 +337,35 @@ func TestLogFunc_Disabled(t *testing.T) {
 	fn := LogFunc("should not appear")
 	fn() // Should not panic
 }
+
+func TestRecentLines(t *testing.T) {
+	originalEnabled := enabled
+	originalLogger := logger
+	originalRecent := recentLines
+	defer func() {
+		enabled = originalEnabled
+		logger = originalLogger
+		recentLines = originalRecent
+	}()
+
+	recentLines = &ringWriter{}
+	enabled = true
+	logger = log.New(recentLines, "", 0)
+
+	for i := 0; i < recentCapacity+5; i++ {
+		Log("line %d", i)
+	}
+
+	all := RecentLines(-1)
+	if len(all) != recentCapacity {
+		t.Fatalf("expected %d buffered lines, got %d", recentCapacity, len(all))
+	}
+	if all[0] != "line 5" || all[len(all)-1] != fmt.Sprintf("line %d", recentCapacity+4) {
+		t.Errorf("unexpected ring order: first=%q last=%q", all[0], all[len(all)-1])
+	}
+
+	last := RecentLines(2)
+	if len(last) != 2 || last[1] != fmt.Sprintf("line %d", recentCapacity+4) {
+		t.Errorf("RecentLines(2) = %v", last)
+	}
+}
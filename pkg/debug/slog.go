@@ -0,0 +1,262 @@
+package debug
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Structured logging.
+//
+// Subsystems get their own *slog.Logger from For, and each subsystem's level
+// can be set independently:
+//
+//	BV_LOG_LEVEL=info,loader=debug,updater=off bv
+//	bv --log-level debug --log-format json --log-file auto
+//
+// The legacy printf-style API (Log, LogTiming, ...) is a shim over the same
+// handler at debug level for the "debug" subsystem, and BV_DEBUG=1 is
+// shorthand for BV_LOG_LEVEL=debug.
+
+// LevelOff disables a subsystem (or everything) entirely.
+const LevelOff = slog.Level(100)
+
+// Log file rotation limits for file output.
+const (
+	maxLogFileSize = 5 << 20 // 5 MiB
+	maxLogBackups  = 3
+)
+
+// Options configures structured logging.
+type Options struct {
+	Level      slog.Level            // default level for every subsystem
+	Subsystems map[string]slog.Level // per-subsystem overrides
+	JSON       bool                  // JSON lines instead of key=value text
+	File       string                // "" logs to stderr; "auto" uses DefaultLogPath; otherwise a file path
+}
+
+// DefaultOptions logs nothing until a level is configured.
+func DefaultOptions() Options {
+	return Options{Level: LevelOff}
+}
+
+// logState is the active logging configuration.
+type logState struct {
+	mu      sync.RWMutex
+	opts    Options
+	handler slog.Handler
+	closer  io.Closer
+}
+
+var state = &logState{opts: DefaultOptions()}
+
+// Configure installs the logging options. It is safe to call more than once;
+// a previously opened log file is closed.
+func Configure(opts Options) error {
+	var out io.Writer = stderr{}
+	var closer io.Closer
+	if opts.File != "" {
+		path := opts.File
+		if path == "auto" {
+			p, err := DefaultLogPath()
+			if err != nil {
+				return err
+			}
+			path = p
+		}
+		rf, err := openRotatingFile(path, maxLogFileSize, maxLogBackups)
+		if err != nil {
+			return fmt.Errorf("opening log file: %w", err)
+		}
+		out, closer = rf, rf
+	}
+	handler := newHandler(io.MultiWriter(out, recentLines), opts.JSON)
+
+	state.mu.Lock()
+	old := state.closer
+	state.opts = opts
+	state.handler = handler
+	state.closer = closer
+	state.mu.Unlock()
+	if old != nil {
+		old.Close()
+	}
+
+	enabled = levelFor("debug") <= slog.LevelDebug
+	if enabled {
+		logger = newLogger()
+	}
+	return nil
+}
+
+// stderr writes to whatever os.Stderr is at the time of the write, so a
+// program that swaps os.Stderr (bv --errors-json) also captures log records.
+type stderr struct{}
+
+func (stderr) Write(p []byte) (int, error) { return os.Stderr.Write(p) }
+
+func newHandler(w io.Writer, asJSON bool) slog.Handler {
+	// The handler itself accepts everything; levels are enforced per
+	// subsystem by subsystemHandler and by the shim's enabled flag.
+	opts := &slog.HandlerOptions{Level: slog.LevelDebug}
+	if asJSON {
+		return slog.NewJSONHandler(w, opts)
+	}
+	return slog.NewTextHandler(w, opts)
+}
+
+// currentHandler returns the configured handler, defaulting to text on stderr.
+func currentHandler() slog.Handler {
+	state.mu.RLock()
+	h := state.handler
+	state.mu.RUnlock()
+	if h != nil {
+		return h
+	}
+	state.mu.Lock()
+	defer state.mu.Unlock()
+	if state.handler == nil {
+		state.handler = newHandler(io.MultiWriter(stderr{}, recentLines), false)
+	}
+	return state.handler
+}
+
+// levelFor returns the effective level of a subsystem.
+func levelFor(subsystem string) slog.Level {
+	state.mu.RLock()
+	defer state.mu.RUnlock()
+	if l, ok := state.opts.Subsystems[subsystem]; ok {
+		return l
+	}
+	return state.opts.Level
+}
+
+// For returns the logger for a subsystem, e.g. debug.For("loader"). Records
+// carry a subsystem attribute and are filtered by that subsystem's level,
+// which is looked up on every call so later Configure calls take effect.
+func For(subsystem string) *slog.Logger {
+	return slog.New(&subsystemHandler{name: subsystem})
+}
+
+// subsystemHandler filters by subsystem level and forwards to the current
+// handler.
+type subsystemHandler struct {
+	name string
+	ops  []func(slog.Handler) slog.Handler // WithAttrs/WithGroup, in order
+}
+
+func (h *subsystemHandler) Enabled(_ context.Context, l slog.Level) bool {
+	return l >= levelFor(h.name)
+}
+
+func (h *subsystemHandler) Handle(ctx context.Context, r slog.Record) error {
+	target := currentHandler().WithAttrs([]slog.Attr{slog.String("subsystem", h.name)})
+	for _, op := range h.ops {
+		target = op(target)
+	}
+	return target.Handle(ctx, r)
+}
+
+func (h *subsystemHandler) with(op func(slog.Handler) slog.Handler) *subsystemHandler {
+	ops := append(append([]func(slog.Handler) slog.Handler{}, h.ops...), op)
+	return &subsystemHandler{name: h.name, ops: ops}
+}
+
+func (h *subsystemHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return h.with(func(t slog.Handler) slog.Handler { return t.WithAttrs(attrs) })
+}
+
+func (h *subsystemHandler) WithGroup(name string) slog.Handler {
+	return h.with(func(t slog.Handler) slog.Handler { return t.WithGroup(name) })
+}
+
+// ParseLevel parses a level name: debug, info, warn, error, or off.
+func ParseLevel(s string) (slog.Level, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn", "warning":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	case "off", "none":
+		return LevelOff, nil
+	}
+	return 0, fmt.Errorf("unknown log level %q (want debug, info, warn, error, or off)", s)
+}
+
+// ParseLevelSpec parses "LEVEL[,SUBSYSTEM=LEVEL...]", e.g.
+// "info,loader=debug". The default level may be omitted ("loader=debug"),
+// in which case only the named subsystems log.
+func ParseLevelSpec(spec string) (slog.Level, map[string]slog.Level, error) {
+	level := LevelOff
+	subsystems := make(map[string]slog.Level)
+	for _, part := range strings.Split(spec, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		name, lvl, hasName := strings.Cut(part, "=")
+		if !hasName {
+			l, err := ParseLevel(part)
+			if err != nil {
+				return 0, nil, err
+			}
+			level = l
+			continue
+		}
+		l, err := ParseLevel(lvl)
+		if err != nil {
+			return 0, nil, fmt.Errorf("subsystem %s: %w", strings.TrimSpace(name), err)
+		}
+		subsystems[strings.TrimSpace(name)] = l
+	}
+	return level, subsystems, nil
+}
+
+// OptionsFromEnv builds options from BV_LOG_LEVEL, BV_LOG_FORMAT, and
+// BV_LOG_FILE. BV_DEBUG (any value) forces the default level to debug.
+func OptionsFromEnv() (Options, error) {
+	opts := DefaultOptions()
+	if spec := os.Getenv("BV_LOG_LEVEL"); spec != "" {
+		level, subsystems, err := ParseLevelSpec(spec)
+		if err != nil {
+			return opts, fmt.Errorf("BV_LOG_LEVEL: %w", err)
+		}
+		opts.Level, opts.Subsystems = level, subsystems
+	}
+	if os.Getenv("BV_DEBUG") != "" {
+		opts.Level = slog.LevelDebug
+	}
+	switch strings.ToLower(os.Getenv("BV_LOG_FORMAT")) {
+	case "", "text":
+	case "json":
+		opts.JSON = true
+	default:
+		return opts, fmt.Errorf("BV_LOG_FORMAT: unknown format %q (want text or json)", os.Getenv("BV_LOG_FORMAT"))
+	}
+	opts.File = os.Getenv("BV_LOG_FILE")
+	return opts, nil
+}
+
+// DefaultLogPath is where "auto" file logging writes:
+// $BV_CACHE_DIR/logs/bv.log, defaulting to the user cache dir
+// (e.g. ~/.cache/bv/logs/bv.log).
+func DefaultLogPath() (string, error) {
+	base := os.Getenv("BV_CACHE_DIR")
+	if base == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", fmt.Errorf("getting user cache dir: %w", err)
+		}
+		base = filepath.Join(dir, "bv")
+	}
+	return filepath.Join(base, "logs", "bv.log"), nil
+}
@@ -0,0 +1,154 @@
+package debug
+
+import (
+	"encoding/json"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withLogging configures logging for a test and restores the defaults after.
+func withLogging(t *testing.T, opts Options) {
+	t.Helper()
+	originalEnabled, originalLogger := enabled, logger
+	if err := Configure(opts); err != nil {
+		t.Fatalf("Configure: %v", err)
+	}
+	t.Cleanup(func() {
+		_ = Configure(DefaultOptions())
+		enabled, logger = originalEnabled, originalLogger
+	})
+}
+
+func TestParseLevelSpec(t *testing.T) {
+	level, subs, err := ParseLevelSpec("info, loader=debug,updater=off")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if level != slog.LevelInfo || subs["loader"] != slog.LevelDebug || subs["updater"] != LevelOff {
+		t.Errorf("got level=%v subs=%v", level, subs)
+	}
+
+	level, subs, err = ParseLevelSpec("loader=warn")
+	if err != nil || level != LevelOff || subs["loader"] != slog.LevelWarn {
+		t.Errorf("subsystem-only spec: level=%v subs=%v err=%v", level, subs, err)
+	}
+
+	if _, _, err := ParseLevelSpec("verbose"); err == nil {
+		t.Error("expected error for unknown level")
+	}
+	if _, _, err := ParseLevelSpec("loader=loud"); err == nil {
+		t.Error("expected error for unknown subsystem level")
+	}
+}
+
+func TestFor_PerSubsystemLevelsAndJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bv.log")
+	withLogging(t, Options{
+		Level:      slog.LevelWarn,
+		Subsystems: map[string]slog.Level{"loader": slog.LevelDebug},
+		JSON:       true,
+		File:       path,
+	})
+
+	For("loader").Debug("loaded issues", "count", 3)
+	For("updater").Debug("should be filtered")
+	For("updater").With("channel", "beta").Warn("check failed")
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 records, got %d:\n%s", len(lines), data)
+	}
+
+	var first, second map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &first); err != nil {
+		t.Fatalf("not JSON: %v", err)
+	}
+	if err := json.Unmarshal([]byte(lines[1]), &second); err != nil {
+		t.Fatalf("not JSON: %v", err)
+	}
+	if first["subsystem"] != "loader" || first["msg"] != "loaded issues" || first["count"] != float64(3) {
+		t.Errorf("unexpected loader record: %v", first)
+	}
+	if second["subsystem"] != "updater" || second["channel"] != "beta" || second["level"] != "WARN" {
+		t.Errorf("unexpected updater record: %v", second)
+	}
+}
+
+func TestLogShim_WritesThroughSlog(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bv.log")
+	withLogging(t, Options{Level: slog.LevelDebug, File: path})
+
+	if !Enabled() {
+		t.Fatal("debug level should enable the printf shim")
+	}
+	Log("processing %d items", 7)
+
+	data, _ := os.ReadFile(path)
+	out := string(data)
+	if !strings.Contains(out, "level=DEBUG") || !strings.Contains(out, `msg="processing 7 items"`) || !strings.Contains(out, "subsystem=debug") {
+		t.Errorf("unexpected shim output: %q", out)
+	}
+}
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bv.log")
+	rf, err := openRotatingFile(path, 10, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rf.Close()
+
+	for _, chunk := range []string{"aaaaaaaa\n", "bbbbbbbb\n", "cccccccc\n", "dddddddd\n"} {
+		if _, err := rf.Write([]byte(chunk)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	read := func(p string) string {
+		data, _ := os.ReadFile(p)
+		return string(data)
+	}
+	if got := read(path); got != "dddddddd\n" {
+		t.Errorf("current log = %q", got)
+	}
+	if got := read(path + ".1"); got != "cccccccc\n" {
+		t.Errorf("backup 1 = %q", got)
+	}
+	if got := read(path + ".2"); got != "bbbbbbbb\n" {
+		t.Errorf("backup 2 = %q", got)
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("expected at most 2 backups")
+	}
+}
+
+func TestOptionsFromEnv(t *testing.T) {
+	t.Setenv("BV_DEBUG", "")
+	t.Setenv("BV_LOG_LEVEL", "error,loader=info")
+	t.Setenv("BV_LOG_FORMAT", "json")
+	t.Setenv("BV_LOG_FILE", "auto")
+	opts, err := OptionsFromEnv()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opts.Level != slog.LevelError || opts.Subsystems["loader"] != slog.LevelInfo || !opts.JSON || opts.File != "auto" {
+		t.Errorf("unexpected options: %+v", opts)
+	}
+
+	t.Setenv("BV_DEBUG", "1")
+	if opts, _ := OptionsFromEnv(); opts.Level != slog.LevelDebug {
+		t.Errorf("BV_DEBUG should force debug level, got %v", opts.Level)
+	}
+
+	t.Setenv("BV_LOG_FORMAT", "xml")
+	if _, err := OptionsFromEnv(); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,93 @@
+// Package deeplink handles bv:// links, which open bv on an issue from a
+// chat message or pull request: bv://issue/bv-123 runs `bv --goto bv-123`.
+// A link may name the project with ?repo=/path/to/checkout; without one bv
+// looks for the issue in the current and recently opened projects.
+//
+// Install registers bv as the link handler for the current user, the way
+// each OS expects: a desktop entry on Linux, a small app bundle on macOS,
+// and a registry key on Windows.
+package deeplink
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"os/exec"
+	"strings"
+)
+
+// Scheme is the URL scheme bv handles.
+const Scheme = "bv"
+
+// Target is what a --goto argument points at.
+type Target struct {
+	IssueID string
+	Repo    string // Project directory; "" to find it
+}
+
+// IsLink reports whether s is a bv:// link rather than a bare issue ID.
+func IsLink(s string) bool {
+	return strings.HasPrefix(strings.ToLower(s), Scheme+"://")
+}
+
+// Parse reads a --goto argument: a bare issue ID, or a link like
+// bv://issue/bv-123?repo=/path.
+func Parse(s string) (Target, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Target{}, errors.New("no issue given")
+	}
+	if !IsLink(s) {
+		return Target{IssueID: s}, nil
+	}
+	u, err := url.Parse(s)
+	if err != nil {
+		return Target{}, fmt.Errorf("invalid link %q: %w", s, err)
+	}
+	if u.Host != "issue" {
+		return Target{}, fmt.Errorf("invalid link %q: want %s://issue/<id>", s, Scheme)
+	}
+	id := strings.Trim(u.Path, "/")
+	if id == "" {
+		return Target{}, fmt.Errorf("invalid link %q: no issue ID", s)
+	}
+	return Target{IssueID: id, Repo: u.Query().Get("repo")}, nil
+}
+
+// IssueURL returns the link to issueID, naming repo when it isn't "".
+func IssueURL(issueID, repo string) string {
+	u := url.URL{Scheme: Scheme, Host: "issue", Path: "/" + issueID}
+	if repo != "" {
+		u.RawQuery = url.Values{"repo": {repo}}.Encode()
+	}
+	return u.String()
+}
+
+// ErrUnsupported is returned by Install and Uninstall on systems bv can't
+// register a link handler on.
+var ErrUnsupported = errors.New("registering a bv:// link handler isn't supported on this system")
+
+// Install registers exe, the bv binary, to open bv:// links for the
+// current user. It returns what it set up, for the user.
+func Install(exe string) (string, error) {
+	return install(exe)
+}
+
+// Uninstall removes the handler Install registered.
+func Uninstall() (string, error) {
+	return uninstall()
+}
+
+// lookPath and runCommand find and run helper tools; tests replace them.
+var lookPath = exec.LookPath
+
+var runCommand = func(name string, args ...string) error {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	if err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("%s: %s", name, msg)
+		}
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	return nil
+}
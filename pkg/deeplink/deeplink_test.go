@@ -0,0 +1,40 @@
+package deeplink
+
+import "testing"
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want Target
+	}{
+		{"bv-123", Target{IssueID: "bv-123"}},
+		{" bv-123\n", Target{IssueID: "bv-123"}},
+		{"bv://issue/bv-123", Target{IssueID: "bv-123"}},
+		{"BV://issue/bv-123/", Target{IssueID: "bv-123"}},
+		{"bv://issue/bv-123?repo=%2Fsrc%2Fapi", Target{IssueID: "bv-123", Repo: "/src/api"}},
+		{"bv://issue/team/api-7", Target{IssueID: "team/api-7"}},
+	}
+	for _, tt := range tests {
+		got, err := Parse(tt.in)
+		if err != nil || got != tt.want {
+			t.Errorf("Parse(%q) = %+v, %v; want %+v", tt.in, got, err, tt.want)
+		}
+	}
+
+	for _, bad := range []string{"", "bv://issue/", "bv://board/bv-1", "bv://issue/%zz"} {
+		if got, err := Parse(bad); err == nil {
+			t.Errorf("Parse(%q) = %+v, want an error", bad, got)
+		}
+	}
+}
+
+func TestIssueURL(t *testing.T) {
+	if got := IssueURL("bv-123", ""); got != "bv://issue/bv-123" {
+		t.Errorf("IssueURL = %q", got)
+	}
+	link := IssueURL("team/api-7", "/home/me/my project")
+	got, err := Parse(link)
+	if err != nil || got != (Target{IssueID: "team/api-7", Repo: "/home/me/my project"}) {
+		t.Errorf("Parse(IssueURL()) = %+v, %v (link %q)", got, err, link)
+	}
+}
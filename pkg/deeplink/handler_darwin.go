@@ -0,0 +1,85 @@
+//go:build darwin
+
+package deeplink
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// appName is the app bundle Install builds in ~/Applications. macOS only
+// routes URL schemes to apps, so a small AppleScript app receives the link
+// and runs bv on it in Terminal.
+const appName = "bv Links.app"
+
+const (
+	plistBuddy = "/usr/libexec/PlistBuddy"
+	lsregister = "/System/Library/Frameworks/CoreServices.framework/Frameworks/LaunchServices.framework/Support/lsregister"
+)
+
+func appPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, "Applications", appName), nil
+}
+
+// handlerScript is the AppleScript that opens a link in bv.
+func handlerScript(exe string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+	return strings.Join([]string{
+		"on open location theURL",
+		`	tell application "Terminal"`,
+		"		activate",
+		`		do script (quoted form of "` + r.Replace(exe) + `") & " --goto " & quoted form of theURL`,
+		"	end tell",
+		"end open location",
+	}, "\n")
+}
+
+func install(exe string) (string, error) {
+	app, err := appPath()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(filepath.Dir(app), 0755); err != nil {
+		return "", fmt.Errorf("creating directory: %w", err)
+	}
+	if err := runCommand("osacompile", "-o", app, "-e", handlerScript(exe)); err != nil {
+		return "", err
+	}
+	plist := filepath.Join(app, "Contents", "Info.plist")
+	for _, cmd := range []string{
+		"Add :CFBundleURLTypes array",
+		"Add :CFBundleURLTypes:0 dict",
+		"Add :CFBundleURLTypes:0:CFBundleURLName string bv issue link",
+		"Add :CFBundleURLTypes:0:CFBundleURLSchemes array",
+		"Add :CFBundleURLTypes:0:CFBundleURLSchemes:0 string " + Scheme,
+	} {
+		if err := runCommand(plistBuddy, "-c", cmd, plist); err != nil {
+			return "", err
+		}
+	}
+	if err := runCommand(lsregister, "-f", app); err != nil {
+		return "", err
+	}
+	return app, nil
+}
+
+func uninstall() (string, error) {
+	app, err := appPath()
+	if err != nil {
+		return "", err
+	}
+	if _, err := os.Stat(app); os.IsNotExist(err) {
+		return app, nil
+	}
+	_ = runCommand(lsregister, "-u", app)
+	if err := os.RemoveAll(app); err != nil {
+		return "", fmt.Errorf("removing %s: %w", app, err)
+	}
+	return app, nil
+}
@@ -0,0 +1,86 @@
+//go:build linux
+
+package deeplink
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// desktopFile is the desktop entry Install writes.
+const desktopFile = "bv-url-handler.desktop"
+
+// applicationsDir is where per-user desktop entries live.
+func applicationsDir() (string, error) {
+	if dir := os.Getenv("XDG_DATA_HOME"); dir != "" {
+		return filepath.Join(dir, "applications"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "share", "applications"), nil
+}
+
+// desktopEntry is the entry that runs exe on a bv:// link, in a terminal
+// since bv is a TUI.
+func desktopEntry(exe string) string {
+	return strings.Join([]string{
+		"[Desktop Entry]",
+		"Type=Application",
+		"Name=bv",
+		"Comment=Open bv:// issue links in beads_viewer",
+		"Exec=" + quoteExecArg(exe) + " --goto %u",
+		"Terminal=true",
+		"NoDisplay=true",
+		"MimeType=x-scheme-handler/" + Scheme + ";",
+		"",
+	}, "\n")
+}
+
+// quoteExecArg quotes an Exec argument as the desktop entry spec asks.
+func quoteExecArg(s string) string {
+	if !strings.ContainsAny(s, " \t\"'\\`$<>~|&;*?#()") {
+		return s
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "`", "\\`", `$`, `\$`)
+	return `"` + r.Replace(s) + `"`
+}
+
+func install(exe string) (string, error) {
+	dir, err := applicationsDir()
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("creating directory: %w", err)
+	}
+	path := filepath.Join(dir, desktopFile)
+	if err := os.WriteFile(path, []byte(desktopEntry(exe)), 0644); err != nil {
+		return "", fmt.Errorf("writing desktop entry: %w", err)
+	}
+	if _, err := lookPath("xdg-mime"); err != nil {
+		return path + " (xdg-mime not found: set it as the x-scheme-handler/bv default yourself)", nil
+	}
+	if err := runCommand("xdg-mime", "default", desktopFile, "x-scheme-handler/"+Scheme); err != nil {
+		return "", err
+	}
+	if _, err := lookPath("update-desktop-database"); err == nil {
+		_ = runCommand("update-desktop-database", dir)
+	}
+	return path, nil
+}
+
+func uninstall() (string, error) {
+	dir, err := applicationsDir()
+	if err != nil {
+		return "", err
+	}
+	path := filepath.Join(dir, desktopFile)
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return "", fmt.Errorf("removing desktop entry: %w", err)
+	}
+	return path, nil
+}
@@ -0,0 +1,62 @@
+package deeplink
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestInstallLinux(t *testing.T) {
+	t.Setenv("XDG_DATA_HOME", t.TempDir())
+	var ran [][]string
+	oldLook, oldRun := lookPath, runCommand
+	t.Cleanup(func() { lookPath, runCommand = oldLook, oldRun })
+	lookPath = func(name string) (string, error) {
+		if name == "xdg-mime" {
+			return "/usr/bin/xdg-mime", nil
+		}
+		return "", errors.New("not found")
+	}
+	runCommand = func(name string, args ...string) error {
+		ran = append(ran, append([]string{name}, args...))
+		return nil
+	}
+
+	path, err := Install("/opt/my tools/bv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`Exec="/opt/my tools/bv" --goto %u`,
+		"Terminal=true",
+		"MimeType=x-scheme-handler/bv;",
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("desktop entry lacks %q:\n%s", want, data)
+		}
+	}
+	want := [][]string{{"xdg-mime", "default", desktopFile, "x-scheme-handler/bv"}}
+	if !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %q, want %q", ran, want)
+	}
+
+	if removed, err := Uninstall(); err != nil || removed != path {
+		t.Fatalf("Uninstall = %q, %v", removed, err)
+	}
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("desktop entry left behind: %v", err)
+	}
+	if _, err := Uninstall(); err != nil {
+		t.Errorf("uninstalling twice: %v", err)
+	}
+	if filepath.Base(filepath.Dir(path)) != "applications" {
+		t.Errorf("desktop entry at %s", path)
+	}
+}
@@ -0,0 +1,11 @@
+//go:build !linux && !darwin && !windows
+
+package deeplink
+
+func install(string) (string, error) {
+	return "", ErrUnsupported
+}
+
+func uninstall() (string, error) {
+	return "", ErrUnsupported
+}
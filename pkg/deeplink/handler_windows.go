@@ -0,0 +1,29 @@
+//go:build windows
+
+package deeplink
+
+// classKey is the per-user registry key for the bv: URL protocol.
+const classKey = `HKCU\Software\Classes\` + Scheme
+
+func install(exe string) (string, error) {
+	for _, args := range [][]string{
+		{"add", classKey, "/ve", "/d", "URL:bv issue link", "/f"},
+		{"add", classKey, "/v", "URL Protocol", "/d", "", "/f"},
+		{"add", classKey + `\shell\open\command`, "/ve", "/d", `"` + exe + `" --goto "%1"`, "/f"},
+	} {
+		if err := runCommand("reg", args...); err != nil {
+			return "", err
+		}
+	}
+	return classKey, nil
+}
+
+func uninstall() (string, error) {
+	if err := runCommand("reg", "query", classKey); err != nil {
+		return classKey, nil // Not registered
+	}
+	if err := runCommand("reg", "delete", classKey, "/f"); err != nil {
+		return "", err
+	}
+	return classKey, nil
+}
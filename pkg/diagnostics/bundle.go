@@ -0,0 +1,210 @@
+// Package diagnostics builds redacted diagnostics bundles for bug reports.
+//
+// A bundle is a single JSON file holding the bv version, platform, a stack
+// trace (for crashes), a metrics snapshot, and the most recent debug log
+// lines. Anything that could identify the user or their project — home
+// directory, username, hostname, email addresses, secret-looking environment
+// values — is redacted before the bundle is written, so it can be attached to
+// a public issue as-is.
+package diagnostics
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/debug"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
+)
+
+// LogLines is how many recent debug log lines a bundle includes.
+const LogLines = 100
+
+// Bundle is the content of a diagnostics bundle.
+type Bundle struct {
+	GeneratedAt time.Time             `json:"generated_at"`
+	Reason      string                `json:"reason"` // "crash" or "on-demand"
+	Version     string                `json:"version"`
+	GoVersion   string                `json:"go_version"`
+	OS          string                `json:"os"`
+	Arch        string                `json:"arch"`
+	NumCPU      int                   `json:"num_cpu"`
+	Args        []string              `json:"args"`
+	Env         map[string]string     `json:"env,omitempty"`
+	Panic       string                `json:"panic,omitempty"`
+	Stack       string                `json:"stack,omitempty"`
+	Metrics     metrics.MetricsOutput `json:"metrics"`
+	DebugLog    []string              `json:"debug_log,omitempty"`
+	Notes       []string              `json:"notes,omitempty"`
+}
+
+// envAllowlist are the non-BV_ environment variables worth reporting: they
+// describe the terminal, not the user.
+var envAllowlist = []string{"TERM", "COLORTERM", "TERM_PROGRAM", "TERM_PROGRAM_VERSION", "LANG", "LC_ALL", "NO_COLOR", "TMUX", "EDITOR"}
+
+// NewBundle collects everything except the panic details. All free text is
+// redacted.
+func NewBundle(reason string) Bundle {
+	b := Bundle{
+		GeneratedAt: time.Now().UTC(),
+		Reason:      reason,
+		Version:     version.Version,
+		GoVersion:   runtime.Version(),
+		OS:          runtime.GOOS,
+		Arch:        runtime.GOARCH,
+		NumCPU:      runtime.NumCPU(),
+		Metrics:     metrics.GetAllMetrics(),
+		Env:         collectEnv(),
+	}
+	for _, arg := range os.Args[1:] {
+		b.Args = append(b.Args, Redact(arg))
+	}
+	for _, line := range debug.RecentLines(LogLines) {
+		b.DebugLog = append(b.DebugLog, Redact(line))
+	}
+	if !debug.Enabled() {
+		b.Notes = append(b.Notes, "debug logging was disabled; rerun with BV_DEBUG=1 to include recent log lines")
+	}
+	return b
+}
+
+// collectEnv returns the allowlisted and BV_* environment variables, with
+// secret-looking values masked and the rest redacted.
+func collectEnv() map[string]string {
+	env := make(map[string]string)
+	for _, kv := range os.Environ() {
+		name, value, ok := strings.Cut(kv, "=")
+		if !ok || value == "" {
+			continue
+		}
+		if !strings.HasPrefix(name, "BV_") && !contains(envAllowlist, name) {
+			continue
+		}
+		if secretEnvName.MatchString(name) {
+			value = "<redacted>"
+		}
+		env[name] = RedactEnvValue(value)
+	}
+	return env
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	secretEnvName = regexp.MustCompile(`(?i)(TOKEN|SECRET|PASSWORD|PASSWD|API_?KEY|CREDENTIAL)`)
+	emailPattern  = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	// secretValuePattern catches inline key=value / key: value secrets in log
+	// lines and arguments.
+	secretValuePattern = regexp.MustCompile(`(?i)\b(token|secret|password|passwd|api_?key)(\s*[=:]\s*)\S+`)
+)
+
+// redactor holds the identifying strings replaced by Redact.
+var redactor struct {
+	once sync.Once
+	home string
+	// names maps the username and hostname to their placeholders. They are
+	// only replaced as whole path segments or whole environment values:
+	// matching them anywhere would garble issue text that happens to contain
+	// a short username like "dev" or "ci".
+	names map[string]string
+}
+
+func loadRedactor() {
+	redactor.once.Do(func() {
+		if home, err := os.UserHomeDir(); err == nil && len(home) > 1 {
+			redactor.home = home
+		}
+		redactor.names = make(map[string]string)
+		if host, err := os.Hostname(); err == nil && host != "" {
+			redactor.names[host] = "<host>"
+			if short, _, ok := strings.Cut(host, "."); ok && short != "" {
+				redactor.names[short] = "<host>"
+			}
+		}
+		for _, name := range []string{"USER", "USERNAME", "LOGNAME"} {
+			if u := os.Getenv(name); u != "" {
+				redactor.names[u] = "<user>"
+			}
+		}
+	})
+}
+
+var (
+	// pathPattern matches absolute or home-relative paths in free text.
+	pathPattern = regexp.MustCompile(`(?:[A-Za-z]:)?~?[/\\][^\s"'<>|,;()]*`)
+	// pathSegment matches one component of a path.
+	pathSegment = regexp.MustCompile(`[^/\\]+`)
+)
+
+// redactPath replaces the home directory with "~" and any path segment that
+// is exactly the username or hostname with a placeholder.
+func redactPath(p string) string {
+	if redactor.home != "" {
+		p = strings.ReplaceAll(p, redactor.home, "~")
+	}
+	return pathSegment.ReplaceAllStringFunc(p, func(seg string) string {
+		if to, ok := redactor.names[seg]; ok {
+			return to
+		}
+		return seg
+	})
+}
+
+// Redact removes identifying information from s: inside paths the home
+// directory becomes "~" and username/hostname segments become placeholders;
+// email addresses and inline secrets are masked everywhere.
+func Redact(s string) string {
+	loadRedactor()
+	s = pathPattern.ReplaceAllStringFunc(s, redactPath)
+	s = emailPattern.ReplaceAllString(s, "<email>")
+	return secretValuePattern.ReplaceAllString(s, "${1}${2}<redacted>")
+}
+
+// RedactEnvValue redacts an environment variable value. A value that is
+// exactly the username or hostname is replaced outright; anything else gets
+// the same treatment as Redact.
+func RedactEnvValue(value string) string {
+	loadRedactor()
+	if to, ok := redactor.names[value]; ok {
+		return to
+	}
+	return Redact(value)
+}
+
+// Write writes the bundle as indented JSON. An empty path creates a new file
+// in the temp directory. Returns the path written.
+func Write(b Bundle, path string) (string, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("encoding bundle: %w", err)
+	}
+	if path == "" {
+		f, err := os.CreateTemp("", fmt.Sprintf("bv-%s-*.json", b.Reason))
+		if err != nil {
+			return "", fmt.Errorf("creating bundle file: %w", err)
+		}
+		defer f.Close()
+		if _, err := f.Write(data); err != nil {
+			return "", fmt.Errorf("writing bundle: %w", err)
+		}
+		return f.Name(), nil
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return "", fmt.Errorf("writing bundle: %w", err)
+	}
+	return filepath.Clean(path), nil
+}
@@ -0,0 +1,121 @@
+package diagnostics
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestRedact(t *testing.T) {
+	home, err := os.UserHomeDir()
+	if err != nil || len(home) < 2 {
+		t.Skip("no home directory")
+	}
+
+	got := Redact(filepath.Join(home, "src", "proj", ".beads", "beads.jsonl"))
+	if strings.Contains(got, home) || !strings.HasPrefix(got, "~") {
+		t.Errorf("home dir not redacted: %q", got)
+	}
+
+	got = Redact("assigned to jane.doe@example.com")
+	if strings.Contains(got, "jane.doe") || !strings.Contains(got, "<email>") {
+		t.Errorf("email not redacted: %q", got)
+	}
+
+	got = Redact("request failed token=abc123 api_key: xyz")
+	if strings.Contains(got, "abc123") || strings.Contains(got, "xyz") {
+		t.Errorf("inline secrets not redacted: %q", got)
+	}
+}
+
+func TestRedact_UserAndHostOnlyInPaths(t *testing.T) {
+	t.Setenv("USER", "dev")
+	redactor.once = sync.Once{}
+	t.Cleanup(func() { redactor.once = sync.Once{} })
+	loadRedactor()
+	redactor.home = "/Users/dev"
+	redactor.names["buildbox"] = "<host>"
+
+	got := Redact("dev branch needs review on buildbox; see /Users/dev/src/a.go and /mnt/buildbox/dev/log")
+	want := "dev branch needs review on buildbox; see ~/src/a.go and /mnt/<host>/<user>/log"
+	if got != want {
+		t.Errorf("Redact = %q, want %q", got, want)
+	}
+
+	if got := RedactEnvValue("dev"); got != "<user>" {
+		t.Errorf("RedactEnvValue(user) = %q, want <user>", got)
+	}
+	if got := RedactEnvValue("devterm"); got != "devterm" {
+		t.Errorf("RedactEnvValue(devterm) = %q, want unchanged", got)
+	}
+}
+
+func TestCollectEnv(t *testing.T) {
+	t.Setenv("BV_DEBUG", "1")
+	t.Setenv("BV_GITHUB_TOKEN", "ghp_secret")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "nope")
+
+	env := collectEnv()
+	if env["BV_DEBUG"] != "1" {
+		t.Errorf("expected BV_DEBUG to be reported, got %q", env["BV_DEBUG"])
+	}
+	if env["BV_GITHUB_TOKEN"] != "<redacted>" {
+		t.Errorf("expected token value masked, got %q", env["BV_GITHUB_TOKEN"])
+	}
+	if _, ok := env["AWS_SECRET_ACCESS_KEY"]; ok {
+		t.Error("non-BV_, non-allowlisted variables must not be reported")
+	}
+}
+
+func TestWriteBundle(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "bundle.json")
+	b := NewBundle("on-demand")
+	written, err := Write(b, path)
+	if err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	data, err := os.ReadFile(written)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Bundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("bundle is not valid JSON: %v", err)
+	}
+	if decoded.Reason != "on-demand" || decoded.Version == "" || decoded.OS == "" {
+		t.Errorf("unexpected bundle: %+v", decoded)
+	}
+}
+
+func TestCapturePanic_RecordsFirstPanicOnly(t *testing.T) {
+	t.Setenv("TMPDIR", t.TempDir())
+
+	path, err := CapturePanic(errors.New("boom"), []byte("goroutine 1 [running]:\nmain.main()"))
+	if err != nil {
+		t.Fatalf("CapturePanic: %v", err)
+	}
+	if CrashBundlePath() != path {
+		t.Errorf("CrashBundlePath() = %q, want %q", CrashBundlePath(), path)
+	}
+	again, _ := CapturePanic(errors.New("second"), nil)
+	if again != path {
+		t.Errorf("second panic wrote a new bundle %q", again)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded Bundle
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded.Reason != "crash" || decoded.Panic != "boom" || !strings.Contains(decoded.Stack, "main.main") {
+		t.Errorf("unexpected crash bundle: reason=%q panic=%q stack=%q", decoded.Reason, decoded.Panic, decoded.Stack)
+	}
+}
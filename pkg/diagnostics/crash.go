@@ -0,0 +1,60 @@
+package diagnostics
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+	"sync"
+)
+
+// crashState remembers the bundle written for the first panic so callers
+// that learn about the crash later (e.g. after Bubble Tea restores the
+// terminal) can point the user at it.
+var crashState struct {
+	mu   sync.Mutex
+	path string
+	err  error
+}
+
+// CapturePanic writes a crash bundle for a recovered panic value. Only the
+// first panic in a process is recorded; later calls return the same path.
+func CapturePanic(r any, stack []byte) (string, error) {
+	crashState.mu.Lock()
+	defer crashState.mu.Unlock()
+	if crashState.path != "" || crashState.err != nil {
+		return crashState.path, crashState.err
+	}
+	b := NewBundle("crash")
+	b.Panic = Redact(fmt.Sprint(r))
+	b.Stack = Redact(string(stack))
+	crashState.path, crashState.err = Write(b, "")
+	return crashState.path, crashState.err
+}
+
+// CrashBundlePath returns the bundle written by CapturePanic, if any.
+func CrashBundlePath() string {
+	crashState.mu.Lock()
+	defer crashState.mu.Unlock()
+	return crashState.path
+}
+
+// PrintCrashNotice tells the user where the crash bundle is.
+func PrintCrashNotice(w io.Writer, path string) {
+	fmt.Fprintf(w, "\nbv crashed. A redacted diagnostics bundle was written to:\n  %s\n", path)
+	fmt.Fprintln(w, "Please attach it to a bug report: https://github.com/Dicklesworthstone/beads_viewer/issues")
+}
+
+// RecoverAndReport is deferred at the top of main. On panic it writes a
+// crash bundle, prints its path to stderr, and re-panics so the process
+// still exits with Go's usual trace and status.
+func RecoverAndReport() {
+	r := recover()
+	if r == nil {
+		return
+	}
+	if path, err := CapturePanic(r, debug.Stack()); err == nil {
+		PrintCrashNotice(os.Stderr, path)
+	}
+	panic(r)
+}
@@ -9,7 +9,9 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 )
 
 // Severity represents the severity level of a drift alert
@@ -38,6 +40,7 @@ const (
 	AlertHighImpactUnblock  AlertType = "high_impact_unblock"
 	AlertAbandonedClaim     AlertType = "abandoned_claim"
 	AlertPotentialDuplicate AlertType = "potential_duplicate"
+	AlertRule               AlertType = "rule" // alert rule from .bv/rules.yaml
 )
 
 // Alert represents a single drift detection alert
@@ -51,6 +54,7 @@ type Alert struct {
 	Details     []string  `json:"details,omitempty"`
 	IssueID     string    `json:"issue_id,omitempty"`
 	Label       string    `json:"label,omitempty"`
+	Rule        string    `json:"rule,omitempty"` // matching rule name for rule alerts
 	DetectedAt  time.Time `json:"detected_at,omitempty"`
 
 	// Blocking cascade specific fields (bv-165)
@@ -78,6 +82,7 @@ type Calculator struct {
 	baseline *baseline.Baseline
 	current  *baseline.Baseline
 	issues   []model.Issue
+	rules    *rules.Set
 }
 
 // NewCalculator creates a drift calculator with the given baseline and current snapshot
@@ -98,6 +103,12 @@ func (c *Calculator) SetIssues(issues []model.Issue) {
 	c.issues = issues
 }
 
+// SetRules attaches the project's alert rules, checked against each open
+// attached issue.
+func (c *Calculator) SetRules(set *rules.Set) {
+	c.rules = set
+}
+
 // Calculate performs drift detection and returns results
 func (c *Calculator) Calculate() *Result {
 	result := &Result{
@@ -128,6 +139,9 @@ func (c *Calculator) Calculate() *Result {
 	// Check blocking cascades (uses current issues if provided)
 	c.checkBlockingCascade(result)
 
+	// Check project alert rules (uses current issues if provided)
+	c.checkRules(result)
+
 	// Compute summary
 	for _, alert := range result.Alerts {
 		switch alert.Severity {
@@ -174,7 +188,7 @@ func (c *Calculator) checkCycles(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertNewCycle,
 			Severity:    SeverityCritical,
-			Message:     fmt.Sprintf("%d new cycle(s) detected", len(newCycles)),
+			Message:     i18n.T("alert.new_cycles", len(newCycles)),
 			BaselineVal: float64(len(c.baseline.Cycles)),
 			CurrentVal:  float64(len(c.current.Cycles)),
 			Delta:       float64(len(newCycles)),
@@ -205,7 +219,7 @@ func (c *Calculator) checkDensity(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertDensityGrowth,
 			Severity:    SeverityWarning,
-			Message:     fmt.Sprintf("Graph density increased by %.1f%%", pctChange),
+			Message:     i18n.T("alert.density_increased", pctChange),
 			BaselineVal: blDensity,
 			CurrentVal:  curDensity,
 			Delta:       delta,
@@ -215,7 +229,7 @@ func (c *Calculator) checkDensity(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertDensityGrowth,
 			Severity:    SeverityInfo,
-			Message:     fmt.Sprintf("Graph density increased by %.1f%%", pctChange),
+			Message:     i18n.T("alert.density_increased", pctChange),
 			BaselineVal: blDensity,
 			CurrentVal:  curDensity,
 			Delta:       delta,
@@ -243,7 +257,7 @@ func (c *Calculator) checkGraphSize(result *Result) {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertNodeCountChange,
 				Severity:    SeverityInfo,
-				Message:     fmt.Sprintf("Node count changed by %+d (%.1f%%)", nodeDelta, nodePct),
+				Message:     i18n.T("alert.node_count_changed", nodeDelta, nodePct),
 				BaselineVal: float64(blNodes),
 				CurrentVal:  float64(curNodes),
 				Delta:       float64(nodeDelta),
@@ -262,7 +276,7 @@ func (c *Calculator) checkGraphSize(result *Result) {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertEdgeCountChange,
 				Severity:    SeverityInfo,
-				Message:     fmt.Sprintf("Edge count changed by %+d (%.1f%%)", edgeDelta, edgePct),
+				Message:     i18n.T("alert.edge_count_changed", edgeDelta, edgePct),
 				BaselineVal: float64(blEdges),
 				CurrentVal:  float64(curEdges),
 				Delta:       float64(edgeDelta),
@@ -287,7 +301,7 @@ func (c *Calculator) checkBlocked(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:        AlertBlockedIncrease,
 			Severity:    SeverityWarning,
-			Message:     fmt.Sprintf("Blocked issues increased by %d", delta),
+			Message:     i18n.T("alert.blocked_increased", delta),
 			BaselineVal: float64(blBlocked),
 			CurrentVal:  float64(curBlocked),
 			Delta:       float64(delta),
@@ -313,7 +327,7 @@ func (c *Calculator) checkActionable(result *Result) {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertActionableChange,
 				Severity:    SeverityWarning,
-				Message:     fmt.Sprintf("Actionable issues decreased by %d (%.1f%%)", -delta, -pct),
+				Message:     i18n.T("alert.actionable_dropped", -delta, -pct),
 				BaselineVal: float64(blAction),
 				CurrentVal:  float64(curAction),
 				Delta:       float64(delta),
@@ -323,7 +337,7 @@ func (c *Calculator) checkActionable(result *Result) {
 			result.Alerts = append(result.Alerts, Alert{
 				Type:        AlertActionableChange,
 				Severity:    SeverityInfo,
-				Message:     fmt.Sprintf("Actionable issues changed by %+d (%.1f%%)", delta, pct),
+				Message:     i18n.T("alert.actionable_changed", delta, pct),
 				BaselineVal: float64(blAction),
 				CurrentVal:  float64(curAction),
 				Delta:       float64(delta),
@@ -378,7 +392,7 @@ func (c *Calculator) checkPageRankChanges(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:       AlertPageRankChange,
 			Severity:   SeverityWarning,
-			Message:    fmt.Sprintf("%d PageRank changes detected", len(changes)),
+			Message:    i18n.T("alert.pagerank_changes", len(changes)),
 			Details:    changes,
 			DetectedAt: time.Now().UTC(),
 		})
@@ -436,7 +450,7 @@ func (c *Calculator) checkStaleness(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:       AlertStaleIssue,
 			Severity:   severity,
-			Message:    fmt.Sprintf("Issue %s inactive for %.0f days", issue.ID, days),
+			Message:    i18n.T("alert.stale_issue", issue.ID, days),
 			IssueID:    issue.ID,
 			DetectedAt: now,
 			Details: []string{
@@ -447,6 +461,31 @@ func (c *Calculator) checkStaleness(result *Result) {
 	}
 }
 
+// checkRules raises an alert for each open issue matching an alert rule.
+func (c *Calculator) checkRules(result *Result) {
+	if c.config.IsAlertDisabled(string(AlertRule)) || c.rules == nil || len(c.rules.Alerts) == 0 {
+		return
+	}
+	now := time.Now().UTC()
+	for i := range c.issues {
+		issue := &c.issues[i]
+		if issue.Status == model.StatusClosed || issue.Status == model.StatusTombstone {
+			continue
+		}
+		for _, r := range c.rules.MatchAlerts(rules.IssueEnv(issue, now)) {
+			result.Alerts = append(result.Alerts, Alert{
+				Type:       AlertRule,
+				Severity:   Severity(r.Severity),
+				Message:    fmt.Sprintf("%s: %s", issue.ID, r.Message),
+				IssueID:    issue.ID,
+				Rule:       r.Name,
+				DetectedAt: now,
+				Details:    []string{"when=" + r.When.String()},
+			})
+		}
+	}
+}
+
 // checkBlockingCascade raises alerts for issues whose completion would unblock many dependents.
 // Uses existing dependency graph; no alert if issues not provided.
 // Includes urgency scoring via downstream priority sum (bv-165).
@@ -502,7 +541,7 @@ func (c *Calculator) checkBlockingCascade(result *Result) {
 		result.Alerts = append(result.Alerts, Alert{
 			Type:                  AlertBlockingCascade,
 			Severity:              severity,
-			Message:               fmt.Sprintf("Completing %s unblocks %d downstream item(s)", iss.ID, count),
+			Message:               i18n.T("alert.blocking_cascade", iss.ID, count),
 			IssueID:               iss.ID,
 			DetectedAt:            time.Now().UTC(),
 			Details:               unblocks,
@@ -9,6 +9,7 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 	"gopkg.in/yaml.v3"
 )
 
@@ -216,6 +217,47 @@ func TestCalculatorStalenessWarningAndCritical(t *testing.T) {
 	}
 }
 
+func TestCalculatorRuleAlerts(t *testing.T) {
+	set, err := rules.Parse([]byte("alerts:\n  - name: unowned-p0\n    when: priority == 0 && assignee == \"\"\n    severity: critical\n    message: P0 without an owner\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Priority: 0, UpdatedAt: time.Now()},
+		{ID: "B", Status: model.StatusOpen, Priority: 0, Assignee: "sam", UpdatedAt: time.Now()},
+		{ID: "C", Status: model.StatusClosed, Priority: 0},
+	}
+	bl := &baseline.Baseline{Stats: baseline.GraphStats{}}
+	calc := NewCalculator(bl, bl, nil)
+	calc.SetIssues(issues)
+	calc.SetRules(set)
+
+	result := calc.Calculate()
+	var got []Alert
+	for _, a := range result.Alerts {
+		if a.Type == AlertRule {
+			got = append(got, a)
+		}
+	}
+	if len(got) != 1 || got[0].IssueID != "A" || got[0].Rule != "unowned-p0" || got[0].Severity != SeverityCritical {
+		t.Fatalf("rule alerts = %+v, want one for open issue A", got)
+	}
+	if got[0].Message != "A: P0 without an owner" || result.CriticalCount != 1 {
+		t.Errorf("message = %q, critical count = %d", got[0].Message, result.CriticalCount)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DisabledAlerts = []string{"rule"}
+	calc = NewCalculator(bl, bl, cfg)
+	calc.SetIssues(issues)
+	calc.SetRules(set)
+	for _, a := range calc.Calculate().Alerts {
+		if a.Type == AlertRule {
+			t.Fatalf("disabled rule alerts still raised: %+v", a)
+		}
+	}
+}
+
 func TestCalculatorBlockingCascade(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "A", Title: "Blocker A", Status: model.StatusOpen},
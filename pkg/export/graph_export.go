@@ -26,19 +26,21 @@ type GraphExportConfig struct {
 	Label    string            // Filter to specific label
 	Root     string            // Subgraph from specific root
 	Depth    int               // Max depth for subgraph (0 = unlimited)
+	Condense bool              // Collapse each dependency cycle into one node (json, dot)
 	DataHash string            // Hash of input data for provenance
 }
 
 // GraphExportResult contains the exported graph and metadata.
 type GraphExportResult struct {
-	Format         string            `json:"format"`
-	Graph          string            `json:"graph,omitempty"`
-	Nodes          int               `json:"nodes"`
-	Edges          int               `json:"edges"`
-	FiltersApplied map[string]string `json:"filters_applied,omitempty"`
-	Explanation    GraphExplanation  `json:"explanation"`
-	DataHash       string            `json:"data_hash,omitempty"`
-	Adjacency      *AdjacencyGraph   `json:"adjacency,omitempty"`
+	Format         string                 `json:"format"`
+	Graph          string                 `json:"graph,omitempty"`
+	Nodes          int                    `json:"nodes"`
+	Edges          int                    `json:"edges"`
+	FiltersApplied map[string]string      `json:"filters_applied,omitempty"`
+	Explanation    GraphExplanation       `json:"explanation"`
+	DataHash       string                 `json:"data_hash,omitempty"`
+	Adjacency      *AdjacencyGraph        `json:"adjacency,omitempty"`
+	Condensation   *analysis.Condensation `json:"condensation,omitempty"`
 }
 
 // GraphExplanation provides context for AI agents.
@@ -73,6 +75,10 @@ type AdjacencyEdge struct {
 
 // ExportGraph exports the dependency graph in the specified format.
 func ExportGraph(issues []model.Issue, stats *analysis.GraphStats, config GraphExportConfig) (*GraphExportResult, error) {
+	if config.Condense && config.Format == GraphFormatMermaid {
+		return nil, fmt.Errorf("condensed graphs support json and dot formats, not %s", config.Format)
+	}
+
 	// Filter issues if needed
 	filteredIssues := filterIssues(issues, config)
 
@@ -124,6 +130,11 @@ func ExportGraph(issues []model.Issue, stats *analysis.GraphStats, config GraphE
 		DataHash:       config.DataHash,
 	}
 
+	if config.Condense {
+		filtersApplied["condense"] = "scc"
+		return exportCondensed(result, filteredIssues, config), nil
+	}
+
 	switch config.Format {
 	case GraphFormatDOT:
 		graph := generateDOT(filteredIssues, issueIDs, stats)
@@ -158,6 +169,32 @@ func ExportGraph(issues []model.Issue, stats *analysis.GraphStats, config GraphE
 	return result, nil
 }
 
+// exportCondensed fills result with the condensation of issues: each
+// strongly connected component of the blocking graph becomes one node.
+func exportCondensed(result *GraphExportResult, issues []model.Issue, config GraphExportConfig) *GraphExportResult {
+	cond := analysis.NewAnalyzer(issues).Condense()
+	result.Nodes = len(cond.Nodes)
+	result.Edges = len(cond.Edges)
+
+	if config.Format == GraphFormatDOT {
+		result.Graph = generateCondensedDOT(issues, cond)
+		result.Explanation = GraphExplanation{
+			What:        "Blocking graph in Graphviz DOT format with each dependency cycle collapsed into one node",
+			HowToRender: "Save to file.dot, run: dot -Tpng file.dot -o graph.png",
+			WhenToUse:   "When cycles make the full graph unreadable and you need the overall order of work",
+		}
+		return result
+	}
+
+	result.Format = "json"
+	result.Condensation = cond
+	result.Explanation = GraphExplanation{
+		What:      "Blocking graph with each strongly connected component collapsed into one node; always a DAG",
+		WhenToUse: "When you need the order between groups of issues despite dependency cycles",
+	}
+	return result
+}
+
 // filterIssues applies label and root filters to the issue list.
 func filterIssues(issues []model.Issue, config GraphExportConfig) []model.Issue {
 	// Filter by label first
@@ -323,6 +360,58 @@ func generateDOT(issues []model.Issue, issueIDs map[string]bool, stats *analysis
 	return sb.String()
 }
 
+// generateCondensedDOT creates a DOT graph of a condensation. Cycles are drawn
+// as one node listing their members; other issues look as in generateDOT.
+func generateCondensedDOT(issues []model.Issue, cond *analysis.Condensation) string {
+	issueMap := make(map[string]model.Issue, len(issues))
+	for _, i := range issues {
+		issueMap[i.ID] = i
+	}
+
+	var sb strings.Builder
+	sb.WriteString("digraph G {\n")
+	sb.WriteString("    rankdir=LR;\n")
+	sb.WriteString("    node [shape=box, fontname=\"Helvetica\", fontsize=10];\n")
+	sb.WriteString("    edge [fontname=\"Helvetica\", fontsize=8];\n")
+	sb.WriteString("\n")
+
+	for _, n := range cond.Nodes {
+		if !n.Cyclic {
+			i := issueMap[n.ID]
+			label := fmt.Sprintf("%s\\n%s\\nP%d %s", escapeDOTString(i.ID), escapeDOTString(truncateRunes(i.Title, 30)), i.Priority, i.Status)
+			sb.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\", fillcolor=\"%s\", style=filled];\n",
+				sanitizeDOTID(n.ID), label, dotStatusColor(i.Status)))
+			continue
+		}
+		members := n.Members
+		more := ""
+		if len(members) > 8 {
+			more = fmt.Sprintf("\\n+%d more", len(members)-8)
+			members = members[:8]
+		}
+		escaped := make([]string, len(members))
+		for k, id := range members {
+			escaped[k] = escapeDOTString(id)
+		}
+		label := fmt.Sprintf("%s (cycle of %d)\\n%s%s", n.ID, len(n.Members), strings.Join(escaped, "\\n"), more)
+		sb.WriteString(fmt.Sprintf("    \"%s\" [label=\"%s\", shape=box3d, fillcolor=\"#FFE0B2\", style=filled, penwidth=2.0];\n",
+			sanitizeDOTID(n.ID), label))
+	}
+
+	sb.WriteString("\n")
+
+	for _, e := range cond.Edges {
+		attrs := "style=bold, color=\"#E53935\""
+		if e.Weight > 1 {
+			attrs += fmt.Sprintf(", label=\"%d\"", e.Weight)
+		}
+		sb.WriteString(fmt.Sprintf("    \"%s\" -> \"%s\" [%s];\n", sanitizeDOTID(e.From), sanitizeDOTID(e.To), attrs))
+	}
+
+	sb.WriteString("}\n")
+	return sb.String()
+}
+
 // dotStatusColor returns a DOT-compatible color for a status.
 func dotStatusColor(status model.Status) string {
 	switch {
@@ -193,6 +193,46 @@ func TestExportGraph_DOT_EscapesNewlinesInLabels(t *testing.T) {
 	}
 }
 
+func TestExportGraph_Condensed(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "bv-1", Title: "Root", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-2", Type: model.DepBlocks}, {DependsOnID: "bv-3", Type: model.DepBlocks}}},
+		{ID: "bv-2", Title: "Loop A", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-3", Type: model.DepBlocks}}},
+		{ID: "bv-3", Title: "Loop B", Status: model.StatusOpen,
+			Dependencies: []*model.Dependency{{DependsOnID: "bv-2", Type: model.DepBlocks}}},
+	}
+
+	dot, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatDOT, Condense: true})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if dot.Nodes != 2 || dot.Edges != 1 {
+		t.Errorf("expected 2 nodes and 1 edge, got %d and %d", dot.Nodes, dot.Edges)
+	}
+	if !strings.Contains(dot.Graph, `"scc-1" [label="scc-1 (cycle of 2)\nbv-2\nbv-3"`) {
+		t.Errorf("missing cycle node:\n%s", dot.Graph)
+	}
+	if !strings.Contains(dot.Graph, `"bv-1" -> "scc-1" [style=bold, color="#E53935", label="2"];`) {
+		t.Errorf("missing weighted edge:\n%s", dot.Graph)
+	}
+	if strings.Contains(dot.Graph, `"bv-2" -> "bv-3"`) {
+		t.Error("edges inside the cycle should be collapsed")
+	}
+
+	js, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatJSON, Condense: true})
+	if err != nil {
+		t.Fatalf("ExportGraph failed: %v", err)
+	}
+	if js.Condensation == nil || js.Adjacency != nil || js.Condensation.CyclicCount != 1 {
+		t.Errorf("expected condensation instead of adjacency, got %+v", js)
+	}
+
+	if _, err := ExportGraph(issues, nil, GraphExportConfig{Format: GraphFormatMermaid, Condense: true}); err == nil {
+		t.Error("expected an error for condensed mermaid")
+	}
+}
+
 func TestExportGraph_Mermaid(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "bv-1", Title: "First Issue", Status: model.StatusOpen, Priority: 1},
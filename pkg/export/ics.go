@@ -0,0 +1,103 @@
+package export
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// icsLineLimit is the RFC 5545 content line limit in octets, CRLF excluded.
+const icsLineLimit = 75
+
+// GenerateICS renders a milestone forecast as an iCalendar (RFC 5545) file:
+// an all-day event for each epic's forecast completion and one for each
+// checkpoint on the critical path. UIDs are derived from issue IDs, so
+// re-importing a fresh export moves the events instead of duplicating them.
+func GenerateICS(f analysis.MilestoneForecast, calendarName string, now time.Time) string {
+	var b strings.Builder
+	line := func(s string) { b.WriteString(foldICSLine(s)) }
+	stamp := now.UTC().Format("20060102T150405Z")
+
+	line("BEGIN:VCALENDAR")
+	line("VERSION:2.0")
+	line("PRODID:-//beads_viewer//bv milestones//EN")
+	line("CALSCALE:GREGORIAN")
+	line("METHOD:PUBLISH")
+	if calendarName != "" {
+		line("X-WR-CALNAME:" + escapeICSText(calendarName))
+	}
+
+	event := func(uid, summary, description, category string, day time.Time) {
+		day = day.UTC()
+		line("BEGIN:VEVENT")
+		line("UID:" + uid + "@beads-viewer")
+		line("DTSTAMP:" + stamp)
+		line("DTSTART;VALUE=DATE:" + day.Format("20060102"))
+		line("DTEND;VALUE=DATE:" + day.AddDate(0, 0, 1).Format("20060102"))
+		line("SUMMARY:" + escapeICSText(summary))
+		line("DESCRIPTION:" + escapeICSText(description))
+		line("CATEGORIES:" + escapeICSText(category))
+		line("TRANSP:TRANSPARENT")
+		line("END:VEVENT")
+	}
+
+	for _, m := range f.Epics {
+		event("epic-"+m.ID,
+			fmt.Sprintf("Epic done: %s (%s)", m.Title, m.ID),
+			fmt.Sprintf("Forecast completion of %s: %d open issues remaining, %s finishes last.", m.ID, m.Open, m.LastIssue),
+			"Milestone", m.Finish)
+	}
+
+	scheduled := make(map[string]analysis.ScheduledIssue, len(f.Issues))
+	for _, s := range f.Issues {
+		scheduled[s.ID] = s
+	}
+	for i, id := range f.CriticalPath {
+		s := scheduled[id]
+		event("critical-"+id,
+			fmt.Sprintf("Critical path %d/%d: %s (%s)", i+1, len(f.CriticalPath), s.Title, id),
+			fmt.Sprintf("Forecast to finish %s (started %s, %.1f days). Any slip here delays the whole plan.",
+				s.Finish.UTC().Format("2006-01-02"), s.Start.UTC().Format("2006-01-02"), s.Days),
+			"Critical path", s.Finish)
+	}
+
+	line("END:VCALENDAR")
+	return b.String()
+}
+
+// SaveICSToFile writes a milestone forecast to an .ics file.
+func SaveICSToFile(f analysis.MilestoneForecast, calendarName, filename string) error {
+	return os.WriteFile(filename, []byte(GenerateICS(f, calendarName, time.Now())), 0644)
+}
+
+// escapeICSText escapes a TEXT value: backslashes, commas, semicolons and
+// newlines.
+func escapeICSText(s string) string {
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	return strings.NewReplacer(`\`, `\\`, ",", `\,`, ";", `\;`, "\n", `\n`, "\r", `\n`).Replace(s)
+}
+
+// foldICSLine terminates a content line with CRLF, folding it into
+// continuation lines (starting with a space) so none exceeds the limit.
+// Multi-byte characters are never split.
+func foldICSLine(s string) string {
+	var b strings.Builder
+	limit := icsLineLimit
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		b.WriteString(s[:cut])
+		b.WriteString("\r\n ")
+		s = s[cut:]
+		limit = icsLineLimit - 1 // The leading space counts
+	}
+	b.WriteString(s)
+	b.WriteString("\r\n")
+	return b.String()
+}
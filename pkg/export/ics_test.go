@@ -0,0 +1,63 @@
+package export
+
+import (
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+func TestGenerateICS(t *testing.T) {
+	now := time.Date(2025, 3, 1, 9, 30, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	f := analysis.MilestoneForecast{
+		Issues: []analysis.ScheduledIssue{
+			{ID: "A", Title: "Schema, migrations; and backfill", Days: 2, Start: now, Finish: now.Add(2 * day), Critical: true},
+			{ID: "B", Title: strings.Repeat("ü", 60), Days: 1, Start: now.Add(2 * day), Finish: now.Add(3 * day), Critical: true},
+		},
+		Epics:        []analysis.EpicMilestone{{ID: "E", Title: "Launch", Open: 2, Finish: now.Add(3 * day), LastIssue: "B"}},
+		CriticalPath: []string{"A", "B"},
+	}
+	ics := GenerateICS(f, "Roadmap", now)
+
+	if !strings.HasPrefix(ics, "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n") || !strings.HasSuffix(ics, "END:VCALENDAR\r\n") {
+		t.Fatalf("bad calendar framing:\n%s", ics)
+	}
+	if n := strings.Count(ics, "BEGIN:VEVENT"); n != 3 {
+		t.Errorf("events = %d, want 3", n)
+	}
+	for _, want := range []string{
+		"X-WR-CALNAME:Roadmap\r\n",
+		"UID:epic-E@beads-viewer\r\n",
+		"UID:critical-A@beads-viewer\r\n",
+		"DTSTAMP:20250301T093000Z\r\n",
+		"DTSTART;VALUE=DATE:20250304\r\nDTEND;VALUE=DATE:20250305\r\n",
+		`SUMMARY:Critical path 1/2: Schema\, migrations\; and backfill (A)`,
+		"CATEGORIES:Milestone\r\n",
+	} {
+		if !strings.Contains(ics, want) {
+			t.Errorf("missing %q in:\n%s", want, ics)
+		}
+	}
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line longer than 75 octets: %q", line)
+		}
+		if !utf8.ValidString(line) {
+			t.Errorf("folding split a character: %q", line)
+		}
+	}
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	if !strings.Contains(unfolded, "SUMMARY:Critical path 2/2: "+strings.Repeat("ü", 60)+" (B)\r\n") {
+		t.Errorf("long summary did not unfold intact:\n%s", unfolded)
+	}
+}
+
+func TestEscapeICSText(t *testing.T) {
+	if got, want := escapeICSText("a\\b,c;d\r\ne"), `a\\b\,c\;d\ne`; got != want {
+		t.Errorf("escapeICSText = %q, want %q", got, want)
+	}
+}
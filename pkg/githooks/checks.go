@@ -0,0 +1,160 @@
+package githooks
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/lint"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Input is the beads data a hook checks.
+type Input struct {
+	Beads     []byte        // Beads file content, for lint
+	BeadsName string        // Its name in messages
+	Issues    []model.Issue // The state being committed or pushed
+	Base      []model.Issue // The state before it; nil when unknown
+	BaseKnown bool          // Base is meaningful (even if empty)
+	Now       time.Time
+}
+
+// Finding is one problem a check found.
+type Finding struct {
+	Check   Check  `json:"check"`
+	Mode    Mode   `json:"mode"`
+	Message string `json:"message"`
+}
+
+// Result is what a hook's checks found.
+type Result struct {
+	Hook     string    `json:"hook"`
+	Findings []Finding `json:"findings"`
+	Blocked  bool      `json:"blocked"` // An enforced check found something
+}
+
+// Run runs hook's checks on in.
+func Run(hook string, cfg Config, in Input) Result {
+	result := Result{Hook: hook, Findings: []Finding{}}
+	for _, check := range Checks {
+		mode := cfg.Mode(hook, check)
+		if mode == ModeOff {
+			continue
+		}
+		var messages []string
+		switch check {
+		case CheckLint:
+			messages = lintMessages(in)
+		case CheckCycles:
+			messages = newCycleMessages(in)
+		case CheckStaleClaims:
+			messages = staleClaimMessages(in, cfg.StaleDays)
+		}
+		for _, msg := range messages {
+			result.Findings = append(result.Findings, Finding{Check: check, Mode: mode, Message: msg})
+			if mode == ModeEnforce {
+				result.Blocked = true
+			}
+		}
+	}
+	return result
+}
+
+func lintMessages(in Input) []string {
+	if in.Beads == nil {
+		return nil
+	}
+	var messages []string
+	for _, f := range lint.Lint(in.Beads).Findings {
+		messages = append(messages, fmt.Sprintf("%s:%d: %s: %s [%s]; run bv lint --fix", in.BeadsName, f.Line, f.IssueID, f.Message, f.Rule))
+	}
+	return messages
+}
+
+// newCycleMessages reports cycles in the issues that the base doesn't have.
+// Without a base every cycle counts as new.
+func newCycleMessages(in Input) []string {
+	existing := make(map[string]bool)
+	if in.BaseKnown {
+		for _, cycle := range cyclesOf(in.Base) {
+			existing[cycleKey(cycle)] = true
+		}
+	}
+	var messages []string
+	for _, cycle := range cyclesOf(in.Issues) {
+		if !existing[cycleKey(cycle)] {
+			messages = append(messages, "new dependency cycle: "+strings.Join(closeCycle(cycle), " → "))
+		}
+	}
+	sort.Strings(messages)
+	return messages
+}
+
+func cyclesOf(issues []model.Issue) [][]string {
+	if len(issues) == 0 {
+		return nil
+	}
+	stats := analysis.NewAnalyzer(issues).Analyze()
+	return stats.Cycles()
+}
+
+// cycleKey identifies a cycle whatever node it starts from
+func cycleKey(cycle []string) string {
+	nodes := cycle
+	if len(nodes) > 1 && nodes[0] == nodes[len(nodes)-1] {
+		nodes = nodes[:len(nodes)-1]
+	}
+	sorted := append([]string(nil), nodes...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, "\x00")
+}
+
+// closeCycle returns the cycle ending where it starts, for display
+func closeCycle(cycle []string) []string {
+	if len(cycle) > 1 && cycle[0] == cycle[len(cycle)-1] {
+		return cycle
+	}
+	if len(cycle) == 0 {
+		return cycle
+	}
+	return append(append([]string(nil), cycle...), cycle[0])
+}
+
+// staleClaimMessages reports in-progress issues nobody has updated in
+// staleDays, oldest first.
+func staleClaimMessages(in Input, staleDays int) []string {
+	if staleDays <= 0 {
+		staleDays = DefaultStaleDays
+	}
+	type stale struct {
+		issue model.Issue
+		days  int
+	}
+	var found []stale
+	for _, issue := range in.Issues {
+		if issue.Status != model.StatusInProgress || issue.UpdatedAt.IsZero() {
+			continue
+		}
+		days := int(in.Now.Sub(issue.UpdatedAt).Hours() / 24)
+		if days >= staleDays {
+			found = append(found, stale{issue, days})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool {
+		if found[i].days != found[j].days {
+			return found[i].days > found[j].days
+		}
+		return found[i].issue.ID < found[j].issue.ID
+	})
+	messages := make([]string, 0, len(found))
+	for _, s := range found {
+		who := s.issue.Assignee
+		if who == "" {
+			who = "nobody"
+		}
+		messages = append(messages, fmt.Sprintf("%s is in progress (assignee: %s) but untouched for %d days; finish, update, or release it", s.issue.ID, who, s.days))
+	}
+	return messages
+}
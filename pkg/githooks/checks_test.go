@@ -0,0 +1,72 @@
+package githooks
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func blocks(id, on string) []*model.Dependency {
+	return []*model.Dependency{{IssueID: id, DependsOnID: on, Type: model.DepBlocks}}
+}
+
+func TestRunFindsNewCyclesAndStaleClaims(t *testing.T) {
+	now := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	base := []model.Issue{
+		{ID: "a", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: blocks("a", "b")},
+		{ID: "b", Status: model.StatusOpen, IssueType: model.TypeTask, Dependencies: blocks("b", "a")},
+		{ID: "c", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "d", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	pushed := append([]model.Issue(nil), base...)
+	pushed[2].Dependencies = blocks("c", "d")
+	pushed[3].Dependencies = blocks("d", "c")
+	pushed = append(pushed,
+		model.Issue{ID: "e", Status: model.StatusInProgress, IssueType: model.TypeTask, Assignee: "ann", UpdatedAt: now.AddDate(0, 0, -20)},
+		model.Issue{ID: "f", Status: model.StatusInProgress, IssueType: model.TypeTask, UpdatedAt: now.AddDate(0, 0, -2)},
+	)
+	in := Input{Issues: pushed, Base: base, BaseKnown: true, Now: now}
+
+	result := Run(PrePush, DefaultConfig(), in)
+	if result.Blocked {
+		t.Errorf("advisory findings blocked the push: %+v", result)
+	}
+	var got []string
+	for _, f := range result.Findings {
+		got = append(got, string(f.Check)+": "+f.Message)
+	}
+	if len(got) != 2 || !strings.HasPrefix(got[0], "cycles: new dependency cycle: ") || strings.Contains(got[0], "a") ||
+		!strings.HasPrefix(got[1], "stale-claims: e is in progress (assignee: ann) but untouched for 20 days") {
+		t.Errorf("findings:\n%s", strings.Join(got, "\n"))
+	}
+
+	cfg := DefaultConfig()
+	cfg.Enforce(PrePush, CheckCycles)
+	if result := Run(PrePush, cfg, in); !result.Blocked {
+		t.Error("an enforced cycle check didn't block")
+	}
+
+	// Without a base, the existing cycle counts too
+	in.BaseKnown = false
+	if result := Run(PrePush, cfg, in); len(result.Findings) != 3 {
+		t.Errorf("unknown base: %d findings, want 3", len(result.Findings))
+	}
+
+	// pre-commit only lints by default
+	if result := Run(PreCommit, DefaultConfig(), in); len(result.Findings) != 0 {
+		t.Errorf("pre-commit findings = %+v", result.Findings)
+	}
+}
+
+func TestRunLint(t *testing.T) {
+	in := Input{
+		Beads:     []byte(`{"id":"a-1","title":"One","status":"Open","issue_type":"task"}` + "\n"),
+		BeadsName: ".beads/issues.jsonl",
+	}
+	result := Run(PreCommit, DefaultConfig(), in)
+	if !result.Blocked || len(result.Findings) != 1 || !strings.HasPrefix(result.Findings[0].Message, ".beads/issues.jsonl:1: a-1: ") {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -0,0 +1,336 @@
+// Package githooks installs git hooks that check the beads data before
+// commits and pushes: bv lint findings, new dependency cycles, and stale
+// claims. .bv/git-hooks.yaml says which checks each hook runs and whether
+// a finding blocks (enforce) or only warns (advisory).
+package githooks
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigFilename is the hook configuration's file name under .bv/.
+const ConfigFilename = "git-hooks.yaml"
+
+// DefaultStaleDays is how long an in-progress issue can go without an
+// update before the stale-claims check reports it.
+const DefaultStaleDays = 14
+
+// managedMarker identifies hook scripts bv wrote, so it only ever replaces
+// or removes its own.
+const managedMarker = "# bv-managed hook"
+
+// Mode is what a check's findings do.
+type Mode string
+
+const (
+	ModeEnforce  Mode = "enforce"  // Findings block the commit or push
+	ModeAdvisory Mode = "advisory" // Findings are printed as warnings
+	ModeOff      Mode = "off"      // The check doesn't run
+)
+
+// Check names one check a hook can run.
+type Check string
+
+const (
+	// CheckLint reports bv lint findings in the beads file
+	CheckLint Check = "lint"
+	// CheckCycles reports dependency cycles the commits introduce
+	CheckCycles Check = "cycles"
+	// CheckStaleClaims reports in-progress issues untouched for StaleDays
+	CheckStaleClaims Check = "stale-claims"
+)
+
+// Checks lists every check in the order hooks run them.
+var Checks = []Check{CheckLint, CheckCycles, CheckStaleClaims}
+
+// Hook names.
+const (
+	PreCommit = "pre-commit"
+	PrePush   = "pre-push"
+)
+
+// Hooks lists the hooks bv can install.
+var Hooks = []string{PreCommit, PrePush}
+
+// Config is .bv/git-hooks.yaml: each hook's checks and their modes. A
+// check a hook doesn't list is off.
+type Config struct {
+	PreCommit map[Check]Mode `yaml:"pre-commit"`
+	PrePush   map[Check]Mode `yaml:"pre-push"`
+	StaleDays int            `yaml:"stale_days,omitempty"`
+}
+
+// DefaultConfig lints before every commit and push, and warns about new
+// cycles and stale claims on push.
+func DefaultConfig() Config {
+	return Config{
+		PreCommit: map[Check]Mode{CheckLint: ModeEnforce},
+		PrePush: map[Check]Mode{
+			CheckLint:        ModeEnforce,
+			CheckCycles:      ModeAdvisory,
+			CheckStaleClaims: ModeAdvisory,
+		},
+		StaleDays: DefaultStaleDays,
+	}
+}
+
+// ConfigPath returns the hook configuration path for a project.
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// LoadConfig reads the project's hook configuration. A missing file is the
+// default configuration.
+func LoadConfig(projectDir string) (Config, error) {
+	path := ConfigPath(projectDir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return DefaultConfig(), nil
+		}
+		return Config{}, fmt.Errorf("reading %s: %w", path, err)
+	}
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return Config{}, fmt.Errorf("parsing %s: %w", path, err)
+	}
+	if err := cfg.Validate(); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	if cfg.StaleDays <= 0 {
+		cfg.StaleDays = DefaultStaleDays
+	}
+	return cfg, nil
+}
+
+// Validate reports unknown checks and modes.
+func (c Config) Validate() error {
+	for _, hook := range Hooks {
+		for check, mode := range c.Modes(hook) {
+			if !knownCheck(check) {
+				return fmt.Errorf("%s: unknown check %q (want one of %s)", hook, check, joinChecks(Checks))
+			}
+			switch mode {
+			case ModeEnforce, ModeAdvisory, ModeOff:
+			default:
+				return fmt.Errorf("%s: %s: unknown mode %q (want enforce, advisory, or off)", hook, check, mode)
+			}
+		}
+	}
+	return nil
+}
+
+// Modes returns the checks hook runs and their modes.
+func (c Config) Modes(hook string) map[Check]Mode {
+	switch hook {
+	case PreCommit:
+		return c.PreCommit
+	case PrePush:
+		return c.PrePush
+	}
+	return nil
+}
+
+// Mode returns check's mode in hook; unlisted checks are off.
+func (c Config) Mode(hook string, check Check) Mode {
+	if mode, ok := c.Modes(hook)[check]; ok {
+		return mode
+	}
+	return ModeOff
+}
+
+// Enforce makes checks block in hook.
+func (c *Config) Enforce(hook string, checks ...Check) {
+	modes := c.Modes(hook)
+	if modes == nil {
+		modes = make(map[Check]Mode)
+		switch hook {
+		case PreCommit:
+			c.PreCommit = modes
+		case PrePush:
+			c.PrePush = modes
+		}
+	}
+	for _, check := range checks {
+		modes[check] = ModeEnforce
+	}
+}
+
+// Render returns the configuration as YAML with a comment explaining it.
+func (c Config) Render() string {
+	var b strings.Builder
+	b.WriteString("# Checks bv's git hooks run (bv hooks install). Modes:\n")
+	b.WriteString("#   enforce: findings block the commit or push (git --no-verify skips hooks)\n")
+	b.WriteString("#   advisory: findings are printed as warnings\n")
+	b.WriteString("#   off: the check doesn't run\n")
+	fmt.Fprintf(&b, "# Checks: %s\n", joinChecks(Checks))
+	for _, hook := range Hooks {
+		fmt.Fprintf(&b, "%s:\n", hook)
+		for _, check := range Checks {
+			if mode, ok := c.Modes(hook)[check]; ok {
+				fmt.Fprintf(&b, "  %s: %s\n", check, mode)
+			}
+		}
+	}
+	fmt.Fprintf(&b, "# In-progress issues untouched this many days are stale claims\n")
+	fmt.Fprintf(&b, "stale_days: %d\n", c.StaleDays)
+	return b.String()
+}
+
+// ParseChecks parses a comma-separated list of check names.
+func ParseChecks(list string) ([]Check, error) {
+	var checks []Check
+	for _, name := range strings.Split(list, ",") {
+		name = strings.TrimSpace(name)
+		if name == "" {
+			continue
+		}
+		if !knownCheck(Check(name)) {
+			return nil, fmt.Errorf("unknown check %q (want one of %s)", name, joinChecks(Checks))
+		}
+		checks = append(checks, Check(name))
+	}
+	return checks, nil
+}
+
+func knownCheck(check Check) bool {
+	for _, c := range Checks {
+		if c == check {
+			return true
+		}
+	}
+	return false
+}
+
+func joinChecks(checks []Check) string {
+	names := make([]string, len(checks))
+	for i, c := range checks {
+		names[i] = string(c)
+	}
+	return strings.Join(names, ", ")
+}
+
+// Script returns the hook script bv installs for hook. It hands the hook's
+// arguments and standard input to `bv hooks run`, and lets the commit or
+// push through when bv isn't installed.
+func Script(hook string) string {
+	return fmt.Sprintf(`#!/bin/sh
+%s: checks the beads data with bv before each %s.
+# Configure the checks in .bv/%s; remove with: bv hooks remove
+if ! command -v bv >/dev/null 2>&1; then
+	echo "bv not found; skipping beads checks" >&2
+	exit 0
+fi
+exec bv hooks run %s "$@"
+`, managedMarker, strings.TrimPrefix(hook, "pre-"), ConfigFilename, hook)
+}
+
+// IsManaged reports whether a hook script's content is one bv wrote.
+func IsManaged(content string) bool {
+	return strings.Contains(content, managedMarker)
+}
+
+// HooksDir returns the directory git runs hooks from for the repository at
+// repoPath, honoring core.hooksPath.
+func HooksDir(repoPath string) (string, error) {
+	cmd := exec.Command("git", "rev-parse", "--git-path", "hooks")
+	cmd.Dir = repoPath
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("not a git repository: %s", repoPath)
+	}
+	dir := strings.TrimSpace(string(out))
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(repoPath, dir)
+	}
+	return dir, nil
+}
+
+// State is a hook's installation state.
+type State string
+
+const (
+	StateMissing State = "missing" // No hook script
+	StateManaged State = "managed" // bv's script, current
+	StateStale   State = "stale"   // bv's script from another version
+	StateForeign State = "foreign" // Someone else's script; bv leaves it alone
+)
+
+// HookStatus is one hook script and its state.
+type HookStatus struct {
+	Hook  string `json:"hook"`
+	Path  string `json:"path"`
+	State State  `json:"state"`
+}
+
+// Status reports the state of each of hooks in hooksDir.
+func Status(hooksDir string, hooks []string) []HookStatus {
+	statuses := make([]HookStatus, 0, len(hooks))
+	for _, hook := range hooks {
+		s := HookStatus{Hook: hook, Path: filepath.Join(hooksDir, hook), State: StateMissing}
+		if data, err := os.ReadFile(s.Path); err == nil {
+			switch {
+			case string(data) == Script(hook):
+				s.State = StateManaged
+			case IsManaged(string(data)):
+				s.State = StateStale
+			default:
+				s.State = StateForeign
+			}
+		}
+		statuses = append(statuses, s)
+	}
+	sort.SliceStable(statuses, func(i, j int) bool { return statuses[i].Hook < statuses[j].Hook })
+	return statuses
+}
+
+// Install writes bv's script for each hook whose state is missing or
+// stale. It refuses, writing nothing, when any of them is foreign.
+func Install(hooksDir string, hooks []string) ([]HookStatus, error) {
+	statuses := Status(hooksDir, hooks)
+	for _, s := range statuses {
+		if s.State == StateForeign {
+			return nil, fmt.Errorf("%s already exists and wasn't written by bv; add `bv hooks run %s \"$@\"` to it yourself", s.Path, s.Hook)
+		}
+	}
+	if err := os.MkdirAll(hooksDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating %s: %w", hooksDir, err)
+	}
+	var written []HookStatus
+	for _, s := range statuses {
+		if s.State == StateManaged {
+			continue
+		}
+		if err := os.WriteFile(s.Path, []byte(Script(s.Hook)), 0o755); err != nil {
+			return written, fmt.Errorf("writing %s: %w", s.Path, err)
+		}
+		// WriteFile keeps an existing file's mode
+		if err := os.Chmod(s.Path, 0o755); err != nil {
+			return written, fmt.Errorf("making %s executable: %w", s.Path, err)
+		}
+		written = append(written, s)
+	}
+	return written, nil
+}
+
+// Remove deletes bv's scripts for hooks, leaving anyone else's in place.
+func Remove(hooksDir string, hooks []string) ([]HookStatus, error) {
+	var removed []HookStatus
+	for _, s := range Status(hooksDir, hooks) {
+		if s.State != StateManaged && s.State != StateStale {
+			continue
+		}
+		if err := os.Remove(s.Path); err != nil {
+			return removed, fmt.Errorf("removing %s: %w", s.Path, err)
+		}
+		removed = append(removed, s)
+	}
+	return removed, nil
+}
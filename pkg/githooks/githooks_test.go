@@ -0,0 +1,131 @@
+package githooks
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+	cfg, err := LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mode(PreCommit, CheckLint) != ModeEnforce || cfg.Mode(PreCommit, CheckCycles) != ModeOff || cfg.Mode(PrePush, CheckStaleClaims) != ModeAdvisory {
+		t.Errorf("missing file = %+v, want the default", cfg)
+	}
+
+	// What Render writes loads back unchanged
+	want := DefaultConfig()
+	want.Enforce(PrePush, CheckCycles)
+	want.StaleDays = 7
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(want.Render()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadConfig(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Mode(PrePush, CheckCycles) != ModeEnforce || cfg.Mode(PrePush, CheckLint) != ModeEnforce || cfg.StaleDays != 7 {
+		t.Errorf("round trip = %+v", cfg)
+	}
+
+	for _, bad := range []string{"pre-push:\n  lint: block\n", "pre-commit:\n  spelling: enforce\n", "pre-push: [lint]\n"} {
+		if err := os.WriteFile(ConfigPath(dir), []byte(bad), 0o644); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := LoadConfig(dir); err == nil {
+			t.Errorf("%q: want an error", bad)
+		}
+	}
+}
+
+func TestParseChecks(t *testing.T) {
+	checks, err := ParseChecks("lint, stale-claims,")
+	if err != nil || len(checks) != 2 || checks[0] != CheckLint || checks[1] != CheckStaleClaims {
+		t.Errorf("ParseChecks = %v, %v", checks, err)
+	}
+	if _, err := ParseChecks("lint,typos"); err == nil {
+		t.Error("unknown check: want an error")
+	}
+}
+
+func TestInstallStatusRemove(t *testing.T) {
+	dir := t.TempDir()
+	foreign := filepath.Join(dir, PreCommit)
+	if err := os.WriteFile(foreign, []byte("#!/bin/sh\nmake check\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Install(dir, Hooks); err == nil || !strings.Contains(err.Error(), "wasn't written by bv") {
+		t.Fatalf("Install over a foreign hook: err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(dir, PrePush)); !os.IsNotExist(err) {
+		t.Error("a refused Install wrote pre-push")
+	}
+
+	written, err := Install(dir, []string{PrePush})
+	if err != nil || len(written) != 1 {
+		t.Fatalf("Install = %v, %v", written, err)
+	}
+	info, err := os.Stat(written[0].Path)
+	if err != nil || info.Mode().Perm()&0o111 == 0 {
+		t.Errorf("installed hook isn't executable: %v %v", info, err)
+	}
+	if written, err = Install(dir, []string{PrePush}); err != nil || len(written) != 0 {
+		t.Errorf("reinstall = %v, %v; want nothing written", written, err)
+	}
+
+	// A script from another bv version is stale and gets replaced
+	if err := os.WriteFile(filepath.Join(dir, PrePush), []byte("#!/bin/sh\n"+managedMarker+"\nbv old\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	states := map[string]State{}
+	for _, s := range Status(dir, Hooks) {
+		states[s.Hook] = s.State
+	}
+	if states[PreCommit] != StateForeign || states[PrePush] != StateStale {
+		t.Errorf("Status = %v", states)
+	}
+
+	removed, err := Remove(dir, Hooks)
+	if err != nil || len(removed) != 1 || removed[0].Hook != PrePush {
+		t.Errorf("Remove = %v, %v", removed, err)
+	}
+	if _, err := os.Stat(foreign); err != nil {
+		t.Error("Remove deleted a foreign hook")
+	}
+}
+
+func TestScriptRuns(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("no sh")
+	}
+	dir := t.TempDir()
+	path := filepath.Join(dir, PrePush)
+	if err := os.WriteFile(path, []byte(Script(PrePush)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	// A stub bv records what the hook handed it
+	stub := "#!/bin/sh\necho \"$@\" > " + filepath.Join(dir, "args") + "\ncat > " + filepath.Join(dir, "stdin") + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "bv"), []byte(stub), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cmd := exec.Command(path, "origin", "git@example.com:repo")
+	cmd.Env = append(os.Environ(), "PATH="+dir+string(os.PathListSeparator)+os.Getenv("PATH"))
+	cmd.Stdin = strings.NewReader("refs/heads/main abc refs/heads/main def\n")
+	if out, err := cmd.CombinedOutput(); err != nil {
+		t.Fatalf("hook: %v\n%s", err, out)
+	}
+	args, _ := os.ReadFile(filepath.Join(dir, "args"))
+	stdin, _ := os.ReadFile(filepath.Join(dir, "stdin"))
+	if strings.TrimSpace(string(args)) != "hooks run pre-push origin git@example.com:repo" || !strings.Contains(string(stdin), "abc") {
+		t.Errorf("bv got args %q stdin %q", args, stdin)
+	}
+}
@@ -1,6 +1,7 @@
 // Package hooks provides a hook system for bv export automation.
 // Hooks are configured via .bv/hooks.yaml and run at specific points
-// in the export pipeline (pre-export, post-export).
+// in the export pipeline (pre-export, post-export). Notify hooks carry
+// messages to people, such as the stale sweep's pings to assignees.
 package hooks
 
 import (
@@ -21,6 +22,8 @@ const (
 	PreExport HookPhase = "pre-export"
 	// PostExport runs after export is written. Failure is logged but doesn't break export.
 	PostExport HookPhase = "post-export"
+	// Notify runs once per notification (e.g. a stale sweep ping). Failure is logged.
+	Notify HookPhase = "notify"
 )
 
 // Hook defines a single hook configuration
@@ -41,6 +44,7 @@ type Config struct {
 type HooksByPhase struct {
 	PreExport  []Hook `yaml:"pre-export,omitempty" json:"pre-export,omitempty"`
 	PostExport []Hook `yaml:"post-export,omitempty" json:"post-export,omitempty"`
+	Notify     []Hook `yaml:"notify,omitempty" json:"notify,omitempty"`
 }
 
 // ExportContext contains information passed to hooks via environment variables
@@ -61,6 +65,26 @@ func (c ExportContext) ToEnv() []string {
 	}
 }
 
+// Notification is passed to notify hooks via environment variables
+type Notification struct {
+	Kind     string // BV_NOTIFY_KIND: What prompted it, e.g. 'stale'
+	IssueID  string // BV_NOTIFY_ISSUE_ID
+	Title    string // BV_NOTIFY_TITLE: Issue title
+	Assignee string // BV_NOTIFY_ASSIGNEE: Who to notify
+	Message  string // BV_NOTIFY_MESSAGE: Human-readable message
+}
+
+// ToEnv converts the notification to environment variables
+func (n Notification) ToEnv() []string {
+	return []string{
+		fmt.Sprintf("BV_NOTIFY_KIND=%s", n.Kind),
+		fmt.Sprintf("BV_NOTIFY_ISSUE_ID=%s", n.IssueID),
+		fmt.Sprintf("BV_NOTIFY_TITLE=%s", n.Title),
+		fmt.Sprintf("BV_NOTIFY_ASSIGNEE=%s", n.Assignee),
+		fmt.Sprintf("BV_NOTIFY_MESSAGE=%s", n.Message),
+	}
+}
+
 // DefaultTimeout is the default hook execution timeout
 const DefaultTimeout = 30 * time.Second
 
@@ -126,6 +150,7 @@ func (l *Loader) Load() error {
 func (l *Loader) normalizeConfig(config *Config) {
 	config.Hooks.PreExport, l.warnings = normalizeHooks(config.Hooks.PreExport, PreExport, l.warnings)
 	config.Hooks.PostExport, l.warnings = normalizeHooks(config.Hooks.PostExport, PostExport, l.warnings)
+	config.Hooks.Notify, l.warnings = normalizeHooks(config.Hooks.Notify, Notify, l.warnings)
 }
 
 // normalizeHooks applies defaults, drops empty commands, and accumulates warnings.
@@ -144,7 +169,7 @@ func normalizeHooks(hooks []Hook, phase HookPhase, warnings []string) ([]Hook, [
 			if phase == PreExport {
 				hook.OnError = "fail" // pre-export failures cancel export by default
 			} else {
-				hook.OnError = "continue" // post-export failures don't break export by default
+				hook.OnError = "continue" // post-export and notify failures are only logged by default
 			}
 		}
 		if hook.Name == "" {
@@ -163,7 +188,7 @@ func (l *Loader) Config() *Config {
 	return l.config
 }
 
-// HasHooks returns true if any hooks are configured
+// HasHooks returns true if any export hooks are configured
 func (l *Loader) HasHooks() bool {
 	if l.config == nil {
 		return false
@@ -182,6 +207,8 @@ func (l *Loader) GetHooks(phase HookPhase) []Hook {
 		return l.config.Hooks.PreExport
 	case PostExport:
 		return l.config.Hooks.PostExport
+	case Notify:
+		return l.config.Hooks.Notify
 	default:
 		return nil
 	}
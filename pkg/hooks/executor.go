@@ -59,7 +59,7 @@ func (e *Executor) RunPreExport() error {
 
 	for _, hook := range e.config.Hooks.PreExport {
 		e.logger(fmt.Sprintf("Running pre-export hook %q: %s", hook.Name, hook.Command))
-		result := e.runHook(hook, PreExport)
+		result := e.runHook(hook, PreExport, e.context.ToEnv())
 		e.results = append(e.results, result)
 
 		if !result.Success && hook.OnError == "fail" {
@@ -80,7 +80,7 @@ func (e *Executor) RunPostExport() error {
 	var firstError error
 	for _, hook := range e.config.Hooks.PostExport {
 		e.logger(fmt.Sprintf("Running post-export hook %q: %s", hook.Name, hook.Command))
-		result := e.runHook(hook, PostExport)
+		result := e.runHook(hook, PostExport, e.context.ToEnv())
 		e.results = append(e.results, result)
 
 		if !result.Success && hook.OnError == "fail" && firstError == nil {
@@ -91,6 +91,27 @@ func (e *Executor) RunPostExport() error {
 	return firstError
 }
 
+// RunNotify executes all notify hooks for n
+// Errors are logged but don't fail (unless on_error="fail")
+func (e *Executor) RunNotify(n Notification) error {
+	if e.config == nil {
+		return nil
+	}
+
+	var firstError error
+	for _, hook := range e.config.Hooks.Notify {
+		e.logger(fmt.Sprintf("Running notify hook %q: %s", hook.Name, hook.Command))
+		result := e.runHook(hook, Notify, n.ToEnv())
+		e.results = append(e.results, result)
+
+		if !result.Success && hook.OnError == "fail" && firstError == nil {
+			firstError = fmt.Errorf("notify hook %q failed: %w", hook.Name, result.Error)
+		}
+	}
+
+	return firstError
+}
+
 // getShellCommand returns the shell and flag to use for executing commands
 func getShellCommand() (string, string) {
 	if runtime.GOOS == "windows" {
@@ -99,8 +120,9 @@ func getShellCommand() (string, string) {
 	return "sh", "-c"
 }
 
-// runHook executes a single hook with timeout and environment
-func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
+// runHook executes a single hook with timeout and environment; contextEnv
+// carries the phase's BV_* variables
+func (e *Executor) runHook(hook Hook, phase HookPhase, contextEnv []string) HookResult {
 	result := HookResult{
 		Hook:  hook,
 		Phase: phase,
@@ -123,8 +145,8 @@ func (e *Executor) runHook(hook Hook, phase HookPhase) HookResult {
 	// Build environment
 	cmd.Env = os.Environ()
 
-	// Add export context variables
-	cmd.Env = append(cmd.Env, e.context.ToEnv()...)
+	// Add context variables
+	cmd.Env = append(cmd.Env, contextEnv...)
 
 	// Add hook-specific env vars (with ${VAR} expansion from current env)
 	// Sort keys for deterministic environment order
@@ -668,3 +668,27 @@ func TestExecutorLargeStderrTruncatedInSummary(t *testing.T) {
 		t.Fatalf("expected ellipsis indicating truncation")
 	}
 }
+
+func TestRunNotify(t *testing.T) {
+	config := &Config{
+		Hooks: HooksByPhase{
+			Notify: []Hook{
+				{Name: "ping", Command: "echo $BV_NOTIFY_KIND $BV_NOTIFY_ISSUE_ID $BV_NOTIFY_ASSIGNEE $BV_EXPORT_PATH", Timeout: time.Second, OnError: "continue"},
+			},
+		},
+	}
+
+	executor := NewExecutor(config, ExportContext{ExportPath: "/not/for/notify.md"})
+	err := executor.RunNotify(Notification{Kind: "stale", IssueID: "bv-1", Assignee: "alice", Message: "still on this?"})
+	if err != nil {
+		t.Fatalf("expected success, got: %v", err)
+	}
+
+	results := executor.Results()
+	if len(results) != 1 || results[0].Phase != Notify {
+		t.Fatalf("expected one notify result, got %+v", results)
+	}
+	if results[0].Stdout != "stale bv-1 alice" {
+		t.Errorf("expected notification env only, got %q", results[0].Stdout)
+	}
+}
@@ -0,0 +1,104 @@
+package i18n
+
+// english is the built-in catalog and the fallback for every other locale.
+// Keys are grouped by where the text appears; they are part of the
+// translation file format, so rename them only with a migration note.
+var english = Catalog{
+	// TUI footer key hints: the label after each key
+	"hint.apply":               "apply",
+	"hint.attention":           "attention",
+	"hint.back":                "back",
+	"hint.bottom":              "bottom",
+	"hint.cancel":              "cancel",
+	"hint.close":               "close",
+	"hint.close_deprio_ping":   "close/deprio/ping",
+	"hint.compare":             "compare",
+	"hint.condense":            "condense",
+	"hint.copy":                "copy",
+	"hint.details":             "details",
+	"hint.diff":                "diff",
+	"hint.done":                "done",
+	"hint.drill":               "drill",
+	"hint.edit":                "edit",
+	"hint.exit_diff":           "exit diff",
+	"hint.explain":             "explain",
+	"hint.export":              "export",
+	"hint.flow":                "flow",
+	"hint.focus":               "focus",
+	"hint.help":                "help",
+	"hint.hybrid":              "hybrid",
+	"hint.jump":                "jump",
+	"hint.labels":              "labels",
+	"hint.list":                "list",
+	"hint.mark":                "mark",
+	"hint.nav":                 "nav",
+	"hint.panel":               "panel",
+	"hint.panels":              "panels",
+	"hint.preset":              "preset",
+	"hint.refresh":             "refresh",
+	"hint.repos":               "repos",
+	"hint.save":                "save",
+	"hint.scroll":              "scroll",
+	"hint.select":              "select",
+	"hint.suggested":           "suggested",
+	"hint.toggle":              "toggle",
+	"hint.triage":              "triage",
+	"hint.view":                "view",
+	"hint.views":               "views",
+	"hint.press_any_key":       "Press any key to close",
+	"hint.type_to_filter":      "type to filter",
+	"search.mode.fuzzy":        "fuzzy",
+	"search.mode.semantic":     "semantic",
+	"search.mode.semantic_idx": "semantic (indexing)",
+
+	// TUI dialogs and overlays. %s is a highlighted key.
+	"quit.title":        "Quit bv?",
+	"quit.confirm":      "Press %s or %s to quit",
+	"quit.cancel":       "Press any other key to cancel",
+	"help.no_match":     "No shortcuts match %q",
+	"help.scroll":       "j/k scroll %d%%",
+	"overlay.esc_close": "Press Esc to close",
+
+	// Timestamps (pkg/timefmt). Layouts use Go's reference time,
+	// Mon Jan 2 15:04:05 2006; keep them numeric, month names aren't
+	// translated.
+	"time.unknown":         "unknown",
+	"time.now":             "now",
+	"time.ago":             "%s ago",
+	"time.span.minutes":    "%dm",
+	"time.span.hours":      "%dh",
+	"time.span.days":       "%dd",
+	"time.span.weeks":      "%dw",
+	"time.span.months":     "%dmo",
+	"time.span.years":      "%dy",
+	"time.layout.datetime": "2006-01-02 15:04",
+	"time.layout.date":     "2006-01-02",
+	"time.layout.day":      "01-02",
+	"time.layout.clock":    "15:04",
+
+	// Triage reasons (bv --robot-triage and the TUI detail view). %d is a
+	// whole number of days: agents compare it, so it isn't rounded to a
+	// span like the timestamps above.
+	"triage.reason.stale":        "🕐 No activity in %d days - may need review",
+	"triage.reason.last_updated": "📅 Last updated %d days ago",
+
+	// Drift alerts (bv --robot-drift and the TUI alerts panel)
+	"alert.new_cycles":         "%d new cycle(s) detected",
+	"alert.density_increased":  "Graph density increased by %.1f%%",
+	"alert.node_count_changed": "Node count changed by %+d (%.1f%%)",
+	"alert.edge_count_changed": "Edge count changed by %+d (%.1f%%)",
+	"alert.blocked_increased":  "Blocked issues increased by %d",
+	"alert.actionable_dropped": "Actionable issues decreased by %d (%.1f%%)",
+	"alert.actionable_changed": "Actionable issues changed by %+d (%.1f%%)",
+	"alert.pagerank_changes":   "%d PageRank changes detected",
+	"alert.stale_issue":        "Issue %s inactive for %.0f days",
+	"alert.blocking_cascade":   "Completing %s unblocks %d downstream item(s)",
+
+	// Robot usage hints (usage_hints in bv --robot-insights)
+	"robot.hint.topk_set":      "Best k issues to complete for max downstream unlock. Work these in order.",
+	"robot.hint.coverage_set":  "Small vertex cover touching all dependency edges. Use for breadth coverage.",
+	"robot.hint.k_paths":       "K-shortest critical paths. Focus on issues appearing in multiple paths.",
+	"robot.hint.parallel_cut":  "Issues that enable parallel work. Complete to maximize team throughput.",
+	"robot.hint.parallel_gain": "Parallelization improvement from completing each issue.",
+	"robot.hint.cycle_break":   "Structural fix suggestions. Apply BEFORE working on cycle members.",
+}
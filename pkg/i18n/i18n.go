@@ -0,0 +1,193 @@
+// Package i18n is bv's message catalog. User-facing strings (TUI labels and
+// key hints, alert messages, robot usage hints) are looked up by stable
+// keys, so a team can translate them without touching the code.
+//
+// English is built in (see english). Other locales are YAML files mapping
+// keys to text, loaded with LoadDir; keys they lack fall back to English.
+// Only prose goes through the catalog: robot JSON field names, enum values,
+// and commands stay the same in every locale.
+package i18n
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultLocale is the locale of the built-in catalog.
+const DefaultLocale = "en"
+
+// Catalog maps message keys to text. Text is a fmt format when the message
+// takes arguments.
+type Catalog map[string]string
+
+var (
+	mu       sync.RWMutex
+	catalogs = map[string]Catalog{DefaultLocale: english}
+	current  = DefaultLocale
+)
+
+// T returns the text for key in the current locale, formatted with args.
+// Keys missing from the locale fall back to English, and keys missing
+// from English to the key itself, so a typo shows up in the UI instead of
+// an empty string.
+func T(key string, args ...any) string {
+	mu.RLock()
+	text, ok := catalogs[current][key]
+	if !ok {
+		text, ok = english[key]
+	}
+	mu.RUnlock()
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return text
+	}
+	return fmt.Sprintf(text, args...)
+}
+
+// Locale returns the current locale.
+func Locale() string {
+	mu.RLock()
+	defer mu.RUnlock()
+	return current
+}
+
+// Locales returns the registered locales, sorted.
+func Locales() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+	locales := make([]string, 0, len(catalogs))
+	for locale := range catalogs {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// SetLocale switches to the registered locale that best matches tag, a
+// BCP 47 tag or POSIX locale such as "de-AT" or "de_AT.UTF-8": the exact
+// locale, then its language ("de"), then English. It returns the locale
+// chosen.
+func SetLocale(tag string) string {
+	mu.Lock()
+	defer mu.Unlock()
+	current = DefaultLocale
+	tag = Normalize(tag)
+	if _, ok := catalogs[tag]; ok {
+		current = tag
+	} else if lang, _, found := strings.Cut(tag, "-"); found {
+		if _, ok := catalogs[lang]; ok {
+			current = lang
+		}
+	}
+	return current
+}
+
+// Normalize turns a locale name into the form catalogs are registered
+// under: "de_AT.UTF-8@euro" becomes "de-AT". The C and POSIX locales,
+// which name no language, normalize to "".
+func Normalize(tag string) string {
+	tag, _, _ = strings.Cut(tag, ".")
+	tag, _, _ = strings.Cut(tag, "@")
+	tag = strings.ReplaceAll(strings.TrimSpace(tag), "_", "-")
+	if tag == "C" || tag == "POSIX" {
+		return ""
+	}
+	lang, region, found := strings.Cut(tag, "-")
+	if !found {
+		return strings.ToLower(lang)
+	}
+	return strings.ToLower(lang) + "-" + strings.ToUpper(region)
+}
+
+// Register adds the messages in c to locale's catalog. A message whose
+// format verbs differ from the English one would garble its arguments, so
+// it is left out and reported in the error; the rest are registered.
+func Register(locale string, c Catalog) error {
+	locale = Normalize(locale)
+	if locale == "" {
+		return errors.New("i18n: empty locale")
+	}
+	var bad []string
+	mu.Lock()
+	defer mu.Unlock()
+	catalog := catalogs[locale]
+	if catalog == nil {
+		catalog = make(Catalog, len(c))
+		catalogs[locale] = catalog
+	}
+	for key, text := range c {
+		if en, ok := english[key]; ok && !slices.Equal(formatVerbs(en), formatVerbs(text)) {
+			bad = append(bad, key)
+			continue
+		}
+		catalog[key] = text
+	}
+	if len(bad) > 0 {
+		sort.Strings(bad)
+		return fmt.Errorf("i18n: %s: format verbs differ from English for %s", locale, strings.Join(bad, ", "))
+	}
+	return nil
+}
+
+// LoadDir registers every <locale>.yaml (or .yml) catalog in dir, such as
+// de.yaml or pt-BR.yaml. A missing dir is not an error. Files that can't be
+// read or parsed are skipped and reported in the returned error.
+func LoadDir(dir string) error {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, entry := range entries {
+		ext := filepath.Ext(entry.Name())
+		if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+			continue
+		}
+		path := filepath.Join(dir, entry.Name())
+		data, err := os.ReadFile(path)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		var c Catalog
+		if err := yaml.Unmarshal(data, &c); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+			continue
+		}
+		if err := Register(strings.TrimSuffix(entry.Name(), ext), c); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", path, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// formatVerb matches a fmt verb with its flags, width and precision.
+var formatVerb = regexp.MustCompile(`%[-+# 0]*(?:\[\d+\])?(?:\d+|\*)?(?:\.(?:\[\d+\])?(?:\d+|\*)?)?(?:\[\d+\])?[a-zA-Z%]`)
+
+// formatVerbs returns the verbs of a format string without flags and
+// widths, sorted so that translations may reorder arguments with explicit
+// indexes: "%+d (%.1f%%)" and "(%.1[2]f%%) %[1]d" both give [d f].
+func formatVerbs(format string) []byte {
+	var verbs []byte
+	for _, v := range formatVerb.FindAllString(format, -1) {
+		if c := v[len(v)-1]; c != '%' {
+			verbs = append(verbs, c)
+		}
+	}
+	slices.Sort(verbs)
+	return verbs
+}
@@ -0,0 +1,166 @@
+package i18n
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// withCatalogs restores the registered catalogs and locale after a test.
+func withCatalogs(t *testing.T) {
+	t.Helper()
+	mu.Lock()
+	saved := make(map[string]Catalog, len(catalogs))
+	for locale, c := range catalogs {
+		saved[locale] = c
+	}
+	savedCurrent := current
+	mu.Unlock()
+	t.Cleanup(func() {
+		mu.Lock()
+		catalogs, current = saved, savedCurrent
+		mu.Unlock()
+	})
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"de_AT.UTF-8@euro": "de-AT",
+		"pt-br":            "pt-BR",
+		"EN":               "en",
+		"fr_FR":            "fr-FR",
+		"C":                "",
+		"POSIX":            "",
+		"C.UTF-8":          "",
+		"":                 "",
+	}
+	for in, want := range tests {
+		if got := Normalize(in); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestSetLocaleFallsBack(t *testing.T) {
+	withCatalogs(t)
+	if err := Register("de", Catalog{"hint.nav": "Navigation"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Register("pt_BR", Catalog{"hint.nav": "navegar"}); err != nil {
+		t.Fatal(err)
+	}
+	tests := map[string]string{
+		"de_DE.UTF-8": "de",
+		"de":          "de",
+		"pt-BR":       "pt-BR",
+		"pt-PT":       DefaultLocale, // no "pt" catalog
+		"ja_JP":       DefaultLocale,
+		"C":           DefaultLocale,
+	}
+	for tag, want := range tests {
+		if got := SetLocale(tag); got != want || Locale() != want {
+			t.Errorf("SetLocale(%q) = %q (Locale %q), want %q", tag, got, Locale(), want)
+		}
+	}
+	if got := strings.Join(Locales(), " "); got != "de en pt-BR" {
+		t.Errorf("Locales = %s", got)
+	}
+}
+
+func TestTLookupAndFallback(t *testing.T) {
+	withCatalogs(t)
+	if err := Register("de", Catalog{
+		"hint.nav":          "Navigation",
+		"alert.new_cycles":  "%d neue Zyklen gefunden",
+		"alert.stale_issue": "%[1]s seit %.0[2]f Tagen inaktiv",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	SetLocale("de")
+	tests := []struct {
+		key  string
+		args []any
+		want string
+	}{
+		{"hint.nav", nil, "Navigation"},
+		{"hint.help", nil, "help"}, // not translated: English
+		{"alert.new_cycles", []any{3}, "3 neue Zyklen gefunden"},
+		{"alert.stale_issue", []any{"bv-1", 12.0}, "bv-1 seit 12 Tagen inaktiv"},
+		{"no.such.key", nil, "no.such.key"},
+	}
+	for _, tt := range tests {
+		if got := T(tt.key, tt.args...); got != tt.want {
+			t.Errorf("T(%q) = %q, want %q", tt.key, got, tt.want)
+		}
+	}
+	SetLocale("en")
+	if got := T("alert.new_cycles", 2); got != "2 new cycle(s) detected" {
+		t.Errorf("English T = %q", got)
+	}
+}
+
+func TestRegisterRejectsMismatchedVerbs(t *testing.T) {
+	withCatalogs(t)
+	err := Register("fr", Catalog{
+		"alert.new_cycles":  "%s nouveaux cycles", // %d in English
+		"alert.stale_issue": "%.0[2]f jours d'inactivité pour %[1]s",
+		"hint.nav":          "naviguer",
+	})
+	if err == nil || !strings.Contains(err.Error(), "alert.new_cycles") || strings.Contains(err.Error(), "stale_issue") {
+		t.Fatalf("Register error = %v", err)
+	}
+	SetLocale("fr")
+	if got := T("alert.new_cycles", 4); got != "4 new cycle(s) detected" {
+		t.Errorf("rejected message should fall back to English, got %q", got)
+	}
+	if got := T("hint.nav"); got != "naviguer" {
+		t.Errorf("valid messages should still register, got %q", got)
+	}
+	if err := Register("C", Catalog{}); err == nil {
+		t.Error("a locale naming no language should be rejected")
+	}
+}
+
+func TestLoadDir(t *testing.T) {
+	withCatalogs(t)
+	dir := t.TempDir()
+	files := map[string]string{
+		"es.yaml":   "hint.nav: navegar\nquit.title: \"¿Salir de bv?\"\n",
+		"it.yml":    "hint.nav: naviga\n",
+		"bad.yaml":  "hint.nav: [unclosed\n",
+		"notes.txt": "ignored",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	err := LoadDir(dir)
+	if err == nil || !strings.Contains(err.Error(), "bad.yaml") {
+		t.Errorf("LoadDir error = %v, want the bad file reported", err)
+	}
+	SetLocale("es_MX.UTF-8")
+	if got := T("quit.title"); got != "¿Salir de bv?" {
+		t.Errorf("es quit.title = %q", got)
+	}
+	SetLocale("it")
+	if got := T("hint.nav"); got != "naviga" {
+		t.Errorf("it hint.nav = %q", got)
+	}
+	if err := LoadDir(filepath.Join(dir, "missing")); err != nil {
+		t.Errorf("missing dir: %v", err)
+	}
+}
+
+func TestEnglishCatalogFormats(t *testing.T) {
+	for key, text := range english {
+		if key == "" || strings.TrimSpace(text) == "" {
+			t.Errorf("empty entry %q: %q", key, text)
+		}
+		// Every % must belong to a verb, or T would print %!(NOVERB)
+		if n := strings.Count(text, "%"); n != 2*strings.Count(text, "%%")+len(formatVerbs(text)) {
+			t.Errorf("%s: stray %% in %q", key, text)
+		}
+	}
+}
@@ -0,0 +1,44 @@
+package i18n_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+)
+
+// Robot output translates hint text only; its keys are the same in every
+// locale so agents can keep parsing it.
+func TestRobotUsageHintKeysStable(t *testing.T) {
+	english := analysis.DefaultUsageHints()
+	if err := i18n.Register("xx", i18n.Catalog{"robot.hint.topk_set": "translated"}); err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetLocale("xx")
+	t.Cleanup(func() { i18n.SetLocale(i18n.DefaultLocale) })
+
+	translated := analysis.DefaultUsageHints()
+	if translated["topk_set"] != "translated" {
+		t.Errorf("topk_set = %q, want the translation", translated["topk_set"])
+	}
+	keys := func(m map[string]string) []string {
+		var ks []string
+		for k, v := range m {
+			if v == "" {
+				t.Errorf("empty hint for %s", k)
+			}
+			ks = append(ks, k)
+		}
+		slices.Sort(ks)
+		return ks
+	}
+	if !slices.Equal(keys(english), keys(translated)) {
+		t.Errorf("keys changed with the locale: %v vs %v", keys(english), keys(translated))
+	}
+	for k, v := range english {
+		if k != "topk_set" && translated[k] != v {
+			t.Errorf("%s: untranslated hint should fall back to English", k)
+		}
+	}
+}
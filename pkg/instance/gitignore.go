@@ -0,0 +1,41 @@
+package instance
+
+import (
+	"path/filepath"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// runtimeFiles are the files this package creates in the .beads directory.
+// bd's .gitignore template doesn't list them all, so they are added to the
+// repository's .beads/.gitignore the first time one is written.
+var runtimeFiles = []string{
+	RegistryFileName,
+	RegistryFileName + registryLockSuffix,
+	RWLockFileName,
+	rwHoldersDirName + "/",
+	CacheSignalFileName,
+	heldFileName,
+}
+
+var (
+	ignoredMu   sync.Mutex
+	ignoredDirs = map[string]bool{}
+)
+
+// ignoreRuntimeFiles makes sure runtimeFiles are in beadsDir's .gitignore,
+// checking at most once per directory per process. It is best effort: a
+// .gitignore that can't be written leaves the files untracked but must not
+// stop coordination between instances.
+func ignoreRuntimeFiles(beadsDir string) {
+	key := filepath.Clean(beadsDir)
+	ignoredMu.Lock()
+	defer ignoredMu.Unlock()
+	if ignoredDirs[key] {
+		return
+	}
+	if loader.EnsureInGitignore(beadsDir, runtimeFiles...) == nil {
+		ignoredDirs[key] = true
+	}
+}
@@ -0,0 +1,29 @@
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// assertIgnored fails unless every name is a line of dir's .gitignore.
+func assertIgnored(t *testing.T, dir string, names ...string) {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(dir, ".gitignore"))
+	if err != nil {
+		t.Fatalf("reading .gitignore: %v", err)
+	}
+	lines := strings.Split(string(content), "\n")
+	for _, name := range names {
+		found := false
+		for _, line := range lines {
+			if line == name {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%s not in .gitignore:\n%s", name, content)
+		}
+	}
+}
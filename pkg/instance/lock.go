@@ -25,12 +25,17 @@ type LockInfo struct {
 }
 
 // Lock represents an instance lock for a beads directory.
-// It uses a lock file to detect multiple concurrent instances.
+// It uses a lock file to detect multiple concurrent instances. Where the
+// filesystem supports it, the primary also holds an OS file lock (see
+// LockModeAdvisory); the lock file then only describes the holder.
 type Lock struct {
 	path     string
 	lockFile *os.File
 	pid      int
 	isFirst  bool
+	holder   *LockInfo // primary's lock info as last read (secondaries only)
+	mode     LockMode
+	held     *os.File // locked sidecar while primary in advisory mode
 }
 
 // LockFileName is the name of the lock file created in .beads directory.
@@ -41,6 +46,9 @@ const LockFileName = ".bv.lock"
 // If another instance already holds the lock, it returns a Lock with isFirst=false.
 func NewLock(beadsDir string) (*Lock, error) {
 	lockPath := filepath.Join(beadsDir, LockFileName)
+	if ProbeLocking(beadsDir) == LockModeAdvisory {
+		return newAdvisoryLock(lockPath)
+	}
 
 	// Try to create lock file with exclusive access
 	file, err := os.OpenFile(lockPath, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
@@ -51,9 +59,11 @@ func NewLock(beadsDir string) (*Lock, error) {
 			lock := &Lock{
 				path:    lockPath,
 				isFirst: false,
+				mode:    LockModePIDFile,
 			}
 			if readErr == nil {
 				lock.pid = existing.PID
+				lock.holder = existing
 			}
 
 			// Check if the existing lock is stale
@@ -70,6 +80,7 @@ func NewLock(beadsDir string) (*Lock, error) {
 		lockFile: file,
 		isFirst:  true,
 		pid:      os.Getpid(),
+		mode:     LockModePIDFile,
 	}
 	if err := lock.writeLockInfo(); err != nil {
 		file.Close()
@@ -80,6 +91,51 @@ func NewLock(beadsDir string) (*Lock, error) {
 	return lock, nil
 }
 
+// newAdvisoryLock is NewLock for LockModeAdvisory: whoever holds the file
+// lock on the sidecar is primary, whatever the lock file says about PIDs.
+func newAdvisoryLock(lockPath string) (*Lock, error) {
+	staleLockMu.Lock()
+	defer staleLockMu.Unlock()
+
+	lock := &Lock{path: lockPath, mode: LockModeAdvisory}
+	held, err := acquireHeld(lockPath)
+	if err != nil {
+		return nil, fmt.Errorf("creating lock file: %w", err)
+	}
+	if held == nil {
+		if existing, err := readLockFile(lockPath); err == nil {
+			lock.pid = existing.PID
+			lock.holder = existing
+		}
+		return lock, nil
+	}
+	if _, err := lock.promoteAdvisory(held); err != nil {
+		return nil, fmt.Errorf("writing lock info: %w", err)
+	}
+	return lock, nil
+}
+
+// promoteAdvisory makes this instance primary now that it holds the sidecar
+// lock, unless the lock file names a live local process that may be a
+// primary not using advisory locks. Callers must hold staleLockMu.
+func (l *Lock) promoteAdvisory(held *os.File) (bool, error) {
+	if existing, err := readLockFile(l.path); err == nil && liveLocalHolder(existing) {
+		releaseHeld(held)
+		l.pid = existing.PID
+		l.holder = existing
+		return false, nil
+	}
+	if err := writeLockFileAtomic(l.path); err != nil {
+		releaseHeld(held)
+		return false, err
+	}
+	l.held = held
+	l.isFirst = true
+	l.pid = os.Getpid()
+	l.holder = nil
+	return true, nil
+}
+
 // writeLockInfo writes the current process info to the lock file.
 func (l *Lock) writeLockInfo() error {
 	if l.lockFile == nil {
@@ -130,6 +186,128 @@ func (l *Lock) HolderPID() int {
 	return l.pid
 }
 
+// Holder returns the primary's lock info as last read, or nil if this is
+// the primary or the lock file was unreadable.
+func (l *Lock) Holder() *LockInfo {
+	return l.holder
+}
+
+// LockChange is the outcome of Refresh.
+type LockChange int
+
+const (
+	LockUnchanged LockChange = iota // role is the same as before
+	LockPromoted                    // the primary went away and we took over
+	LockDemoted                     // another instance took the lock from us
+)
+
+// Refresh re-reads the lock file and reconciles this instance's role. A
+// secondary takes over when the primary has exited (lock file removed) or
+// died (stale lock); a primary steps down when another instance has stolen
+// the lock. Long-running instances call it periodically.
+func (l *Lock) Refresh() LockChange {
+	staleLockMu.Lock()
+	defer staleLockMu.Unlock()
+
+	if l.mode == LockModeAdvisory {
+		return l.refreshAdvisory()
+	}
+
+	existing, err := readLockFile(l.path)
+	if l.isFirst {
+		if err == nil && existing.PID != os.Getpid() {
+			if l.lockFile != nil {
+				l.lockFile.Close()
+				l.lockFile = nil
+			}
+			l.isFirst = false
+			l.pid = existing.PID
+			l.holder = existing
+			return LockDemoted
+		}
+		return LockUnchanged
+	}
+
+	switch {
+	case err == nil && isProcessAlive(existing.PID):
+		l.pid = existing.PID
+		l.holder = existing
+		return LockUnchanged
+	case os.IsNotExist(err):
+		// Primary exited cleanly; claim the lock the same way NewLock does
+		file, createErr := os.OpenFile(l.path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+		if createErr != nil {
+			return LockUnchanged
+		}
+		l.lockFile = file
+		l.isFirst = true
+		l.pid = os.Getpid()
+		l.holder = nil
+		if err := l.writeLockInfo(); err != nil {
+			file.Close()
+			os.Remove(l.path)
+			l.lockFile = nil
+			l.isFirst = false
+			return LockUnchanged
+		}
+		return LockPromoted
+	case err == nil:
+		// Primary died without cleaning up
+		if l.takeover() {
+			return LockPromoted
+		}
+	}
+	return LockUnchanged
+}
+
+// refreshAdvisory is Refresh for LockModeAdvisory. Callers must hold
+// staleLockMu.
+func (l *Lock) refreshAdvisory() LockChange {
+	existing, err := readLockFile(l.path)
+	if l.isFirst {
+		if err == nil && existing.PID != os.Getpid() {
+			releaseHeld(l.held)
+			l.held = nil
+			l.isFirst = false
+			l.pid = existing.PID
+			l.holder = existing
+			return LockDemoted
+		}
+		if l.held == nil {
+			// After Steal: pick up the file lock once the old primary lets go
+			l.held, _ = acquireHeld(l.path)
+		}
+		return LockUnchanged
+	}
+
+	held, _ := acquireHeld(l.path)
+	if held == nil {
+		if err == nil {
+			l.pid = existing.PID
+			l.holder = existing
+		}
+		return LockUnchanged
+	}
+	if promoted, _ := l.promoteAdvisory(held); promoted {
+		return LockPromoted
+	}
+	return LockUnchanged
+}
+
+// Steal makes this instance the primary even though the current holder is
+// alive. The previous primary notices on its next Refresh and steps down.
+func (l *Lock) Steal() error {
+	if l.isFirst {
+		return nil
+	}
+	staleLockMu.Lock()
+	defer staleLockMu.Unlock()
+	if !l.takeover() {
+		return fmt.Errorf("could not take over %s", l.path)
+	}
+	return nil
+}
+
 // checkStale checks if the existing lock is stale (held by a dead process)
 // and takes it over if so. Uses atomic rename to avoid TOCTOU race conditions.
 func (l *Lock) checkStale() {
@@ -153,17 +331,49 @@ func (l *Lock) checkStale() {
 	if isProcessAlive(currentPID) {
 		// Lock is held by a live process - update our record and don't take over
 		l.pid = currentPID
+		l.holder = existing
 		return
 	}
 
-	// Stale lock detected - attempt atomic takeover using rename
-	// This avoids the race condition of delete + create with O_EXCL
-	tmpPath := fmt.Sprintf("%s.%d", l.path, os.Getpid())
+	// Stale lock detected - attempt atomic takeover
+	l.takeover()
+}
+
+// takeover atomically replaces the lock file with this process's info and
+// claims ownership if the rename wins. Callers must hold staleLockMu.
+func (l *Lock) takeover() bool {
+	if err := writeLockFileAtomic(l.path); err != nil {
+		return false
+	}
+
+	// Verify we won the race by re-reading and checking our PID
+	// This handles the case where two processes both rename simultaneously
+	verifyInfo, err := readLockFile(l.path)
+	if err != nil || verifyInfo.PID != os.Getpid() {
+		// Lost the race to another process - don't claim ownership
+		return false
+	}
+
+	// Successfully took over the lock.
+	// The lock is enforced by file existence + content, so we don't need to keep
+	// the file open after takeover (it will be closed by the OS on exit anyway).
+	l.isFirst = true
+	l.pid = os.Getpid()
+	l.holder = nil
+	return true
+}
+
+// writeLockFileAtomic replaces the lock file at path with this process's
+// info via a temp file and rename, so readers never see a partial file.
+func writeLockFileAtomic(path string) error {
+	// Rename rather than delete + create with O_EXCL to avoid a race between
+	// the two steps
+	tmpPath := fmt.Sprintf("%s.%d", path, os.Getpid())
 
 	// Create temp file with our lock info
 	file, err := os.OpenFile(tmpPath, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0644)
 	if err != nil {
-		return
+		return err
 	}
 
 	hostname, _ := os.Hostname()
@@ -176,7 +386,7 @@ func (l *Lock) checkStale() {
 	if err := json.NewEncoder(file).Encode(info); err != nil {
 		file.Close()
 		os.Remove(tmpPath)
-		return
+		return err
 	}
 	file.Sync() // Ensure written to disk before rename
 	file.Close()
@@ -184,33 +394,19 @@ func (l *Lock) checkStale() {
 	// Atomic rename to take over the lock
 	// On most filesystems, rename is atomic and will overwrite the existing file.
 	// Windows does not allow rename over an existing file, so fall back to remove + rename.
-	if err := os.Rename(tmpPath, l.path); err != nil {
+	if err := os.Rename(tmpPath, path); err != nil {
 		if runtime.GOOS == "windows" {
-			if rmErr := os.Remove(l.path); rmErr == nil {
-				if err2 := os.Rename(tmpPath, l.path); err2 == nil {
+			if rmErr := os.Remove(path); rmErr == nil {
+				if err2 := os.Rename(tmpPath, path); err2 == nil {
 					// Success after Windows-safe fallback.
-					goto verify
+					return nil
 				}
 			}
 		}
 		os.Remove(tmpPath)
-		return
-	}
-
-verify:
-	// Verify we won the race by re-reading and checking our PID
-	// This handles the case where two processes both rename simultaneously
-	verifyInfo, err := readLockFile(l.path)
-	if err != nil || verifyInfo.PID != os.Getpid() {
-		// Lost the race to another process - don't claim ownership
-		return
+		return err
 	}
-
-	// Successfully took over the stale lock.
-	// The lock is enforced by file existence + content, so we don't need to keep
-	// the file open after takeover (it will be closed by the OS on exit anyway).
-	l.isFirst = true
-	l.pid = os.Getpid()
+	return nil
 }
 
 // isProcessAlive is implemented in platform-specific files:
@@ -225,15 +421,72 @@ func (l *Lock) Release() {
 		l.lockFile = nil
 	}
 
-	// Only remove the lock file if we own it
+	// Only remove the lock file if we still own it; another instance may
+	// have stolen it since we acquired it
 	if l.isFirst {
-		os.Remove(l.path)
+		if info, err := readLockFile(l.path); err == nil && info.PID == os.Getpid() {
+			os.Remove(l.path)
+		}
+	}
+
+	// Drop the file lock only after the lock file is gone, so a secondary
+	// that wins it never sees our info
+	if l.held != nil {
+		staleLockMu.Lock()
+		releaseHeld(l.held)
+		staleLockMu.Unlock()
+		l.held = nil
 	}
 
 	l.isFirst = false
 }
 
+// Mode reports which locking scheme this Lock uses.
+func (l *Lock) Mode() LockMode {
+	return l.mode
+}
+
 // Path returns the path to the lock file.
 func (l *Lock) Path() string {
 	return l.path
 }
+
+// LockState describes a lock file as seen by an observer that doesn't want
+// to acquire it.
+type LockState string
+
+const (
+	LockStateNone    LockState = "none"    // no lock file
+	LockStateHeld    LockState = "held"    // held by a live process
+	LockStateStale   LockState = "stale"   // holder is gone; next bv takes it over
+	LockStateCorrupt LockState = "corrupt" // unreadable or not valid lock JSON
+)
+
+// LockStatus is the result of Inspect.
+type LockStatus struct {
+	State LockState `json:"state"`
+	Path  string    `json:"path"`
+	Info  *LockInfo `json:"info,omitempty"`
+}
+
+// Inspect reports the state of the lock file in beadsDir without creating,
+// taking over, or removing it.
+func Inspect(beadsDir string) LockStatus {
+	status := LockStatus{Path: filepath.Join(beadsDir, LockFileName)}
+	if _, err := os.Stat(status.Path); os.IsNotExist(err) {
+		status.State = LockStateNone
+		return status
+	}
+	info, err := readLockFile(status.Path)
+	if err != nil {
+		status.State = LockStateCorrupt
+		return status
+	}
+	status.Info = info
+	if isProcessAlive(info.PID) {
+		status.State = LockStateHeld
+	} else {
+		status.State = LockStateStale
+	}
+	return status
+}
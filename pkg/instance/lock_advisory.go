@@ -0,0 +1,146 @@
+package instance
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// LockMode selects how a Lock decides who is primary.
+type LockMode string
+
+const (
+	// LockModeAdvisory holds an OS file lock (flock on Unix, LockFileEx on
+	// Windows) on a sidecar file for the lifetime of the primary. The kernel
+	// (or the NFS/SMB server) drops it when the holder dies, so liveness does
+	// not depend on PIDs, which are meaningless across hosts and get reused.
+	LockModeAdvisory LockMode = "advisory"
+
+	// LockModePIDFile is the original scheme: an O_EXCL lock file naming the
+	// holder's PID, taken over by atomic rename once that PID is dead. Used
+	// where the filesystem refuses file locks.
+	LockModePIDFile LockMode = "pidfile"
+)
+
+// LockModeEnv overrides filesystem probing: "advisory", "pidfile", or
+// "auto" (the default).
+const LockModeEnv = "BV_LOCK_MODE"
+
+// heldFileName is the sidecar that carries the advisory lock. It is never
+// removed: deleting a locked file lets a later opener lock a fresh inode
+// while an old holder still has the unlinked one. Its name differs from
+// LockFileName beyond case so the two can't collide on case-insensitive
+// filesystems.
+const heldFileName = ".bv.lock.held"
+
+var (
+	probeMu    sync.Mutex
+	probeCache = map[string]LockMode{}
+)
+
+// ProbeLocking reports which LockMode works in dir. It takes and releases an
+// exclusive file lock on the sidecar; filesystems that reject file locks
+// (ENOLCK on NFS without lockd, EOPNOTSUPP/EINVAL on some FUSE and SMB
+// mounts) fall back to LockModePIDFile. Results are cached per directory.
+// LockModeEnv, when set to a mode, skips the probe.
+func ProbeLocking(dir string) LockMode {
+	switch LockMode(strings.ToLower(strings.TrimSpace(os.Getenv(LockModeEnv)))) {
+	case LockModeAdvisory:
+		return LockModeAdvisory
+	case LockModePIDFile:
+		return LockModePIDFile
+	}
+
+	key := filepath.Clean(dir)
+	probeMu.Lock()
+	defer probeMu.Unlock()
+	if mode, ok := probeCache[key]; ok {
+		return mode
+	}
+	mode := probeDir(dir)
+	probeCache[key] = mode
+	return mode
+}
+
+func probeDir(dir string) LockMode {
+	ignoreRuntimeFiles(dir)
+	f, err := os.OpenFile(filepath.Join(dir, heldFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return LockModePIDFile
+	}
+	defer f.Close()
+	ok, err := tryLockFile(f, true)
+	if err != nil {
+		return LockModePIDFile
+	}
+	if ok {
+		unlockFile(f)
+	}
+	// Busy means another primary holds it, which proves locks work here
+	return LockModeAdvisory
+}
+
+// heldFiles are the sidecars this process has locked. Some platforms (POSIX
+// lock emulation on NFS) don't make two handles in one process conflict, so
+// the process checks its own holdings first. Matching uses os.SameFile so
+// aliases of one directory (symlinks, case variants on case-insensitive
+// filesystems) are recognized. Guarded by staleLockMu.
+var heldFiles []*os.File
+
+// acquireHeld tries to take the advisory lock for the lock file at lockPath.
+// It returns the locked sidecar, or nil if another holder has it.
+func acquireHeld(lockPath string) (*os.File, error) {
+	ignoreRuntimeFiles(filepath.Dir(lockPath))
+	f, err := os.OpenFile(filepath.Join(filepath.Dir(lockPath), heldFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening %s: %w", heldFileName, err)
+	}
+	if st, err := f.Stat(); err == nil {
+		for _, h := range heldFiles {
+			if hst, err := h.Stat(); err == nil && os.SameFile(st, hst) {
+				f.Close()
+				return nil, nil
+			}
+		}
+	}
+	ok, err := tryLockFile(f, true)
+	if err != nil || !ok {
+		f.Close()
+		return nil, err
+	}
+	heldFiles = append(heldFiles, f)
+	return f, nil
+}
+
+// releaseHeld unlocks and closes a sidecar returned by acquireHeld.
+func releaseHeld(f *os.File) {
+	if f == nil {
+		return
+	}
+	for i, h := range heldFiles {
+		if h == f {
+			heldFiles = append(heldFiles[:i], heldFiles[i+1:]...)
+			break
+		}
+	}
+	unlockFile(f)
+	f.Close()
+}
+
+// liveLocalHolder reports whether info names another live process on this
+// host. Advisory mode uses it after winning the file lock, to defer to a
+// primary that holds only the PID file: an older bv, or a Steal in progress
+// whose new primary hasn't picked up the advisory lock yet.
+func liveLocalHolder(info *LockInfo) bool {
+	if info.PID == os.Getpid() {
+		return false
+	}
+	if hostname, _ := os.Hostname(); info.Hostname != "" && !strings.EqualFold(info.Hostname, hostname) {
+		// A PID from another machine says nothing here, and that machine's
+		// lock on the sidecar is gone or we wouldn't have it
+		return false
+	}
+	return isProcessAlive(info.PID)
+}
@@ -0,0 +1,235 @@
+package instance
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"unicode"
+)
+
+var lockModes = []LockMode{LockModeAdvisory, LockModePIDFile}
+
+func TestProbeLocking(t *testing.T) {
+	local := t.TempDir()
+	if got := ProbeLocking(local); got != LockModeAdvisory {
+		t.Errorf("local temp dir: got %s, want advisory", got)
+	}
+	assertIgnored(t, local, heldFileName)
+	if got := ProbeLocking(filepath.Join(t.TempDir(), "missing")); got != LockModePIDFile {
+		t.Errorf("unusable dir: got %s, want pidfile fallback", got)
+	}
+
+	t.Setenv(LockModeEnv, "pidfile")
+	dir := t.TempDir()
+	if got := ProbeLocking(dir); got != LockModePIDFile {
+		t.Errorf("%s=pidfile: got %s", LockModeEnv, got)
+	}
+	lock, err := NewLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+	if lock.Mode() != LockModePIDFile {
+		t.Errorf("lock mode = %s, want pidfile", lock.Mode())
+	}
+	if _, err := os.Stat(filepath.Join(dir, heldFileName)); !os.IsNotExist(err) {
+		t.Error("pidfile mode should not create the advisory sidecar")
+	}
+}
+
+func TestLock_BothModes(t *testing.T) {
+	for _, mode := range lockModes {
+		t.Run(string(mode), func(t *testing.T) {
+			t.Setenv(LockModeEnv, string(mode))
+			dir := t.TempDir()
+
+			first, err := NewLock(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !first.IsFirstInstance() || first.Mode() != mode {
+				t.Fatalf("first lock: primary=%v mode=%s", first.IsFirstInstance(), first.Mode())
+			}
+			second, err := NewLock(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer second.Release()
+			if second.IsFirstInstance() || second.HolderPID() != os.Getpid() {
+				t.Fatal("second lock should be secondary naming the first")
+			}
+
+			first.Release()
+			if got := second.Refresh(); got != LockPromoted {
+				t.Fatalf("Refresh after primary release = %v, want LockPromoted", got)
+			}
+		})
+	}
+}
+
+func TestLock_StaleFromOtherHost(t *testing.T) {
+	// A live local PID recorded by another machine: only advisory mode can
+	// tell the lock is stale.
+	want := map[LockMode]bool{LockModeAdvisory: true, LockModePIDFile: false}
+	for _, mode := range lockModes {
+		t.Run(string(mode), func(t *testing.T) {
+			t.Setenv(LockModeEnv, string(mode))
+			dir := t.TempDir()
+			data, _ := json.Marshal(LockInfo{PID: os.Getppid(), StartedAt: time.Now(), Hostname: "some-other-host"})
+			if err := os.WriteFile(filepath.Join(dir, LockFileName), data, 0644); err != nil {
+				t.Fatal(err)
+			}
+			lock, err := NewLock(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer lock.Release()
+			if lock.IsFirstInstance() != want[mode] {
+				t.Errorf("primary = %v, want %v", lock.IsFirstInstance(), want[mode])
+			}
+		})
+	}
+}
+
+func TestLock_AdvisoryIgnoresRemovedLockFile(t *testing.T) {
+	t.Setenv(LockModeEnv, string(LockModeAdvisory))
+	dir := t.TempDir()
+	primary, err := NewLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer primary.Release()
+	secondary, err := NewLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer secondary.Release()
+
+	// Someone cleans up .bv.lock by hand; the primary still holds the file
+	// lock, so the secondary must not promote itself.
+	os.Remove(filepath.Join(dir, LockFileName))
+	if got := secondary.Refresh(); got != LockUnchanged {
+		t.Errorf("Refresh = %v, want LockUnchanged while the primary is alive", got)
+	}
+}
+
+func TestLock_AdvisoryStealAndDemote(t *testing.T) {
+	t.Setenv(LockModeEnv, string(LockModeAdvisory))
+	dir := t.TempDir()
+	lock, err := NewLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer lock.Release()
+
+	// Another process steals the lock by rewriting the lock file.
+	writeTestLock(t, dir, os.Getppid())
+	if got := lock.Refresh(); got != LockDemoted {
+		t.Fatalf("Refresh = %v, want LockDemoted", got)
+	}
+	if lock.held != nil {
+		t.Error("demoted lock must release the file lock")
+	}
+
+	// Until the thief picks up the file lock, a newcomer that wins it still
+	// defers to the live process named in the lock file.
+	other, err := NewLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.IsFirstInstance() || other.HolderPID() != os.Getppid() {
+		t.Error("newcomer should defer to the live holder named in the lock file")
+	}
+	other.Release()
+
+	// Steal it back: primary at once, file lock picked up on the next Refresh.
+	if err := lock.Steal(); err != nil {
+		t.Fatal(err)
+	}
+	if !lock.IsFirstInstance() {
+		t.Fatal("expected primary after Steal")
+	}
+	lock.Refresh()
+	if lock.held == nil {
+		t.Error("primary should hold the file lock after Refresh")
+	}
+}
+
+// caseAlias returns a second path for dir that differs only in case. On a
+// case-insensitive filesystem that is just the case-swapped name; elsewhere a
+// case-swapped symlink simulates it.
+func caseAlias(t *testing.T, dir string) string {
+	t.Helper()
+	base := filepath.Base(dir)
+	swapped := strings.Map(func(r rune) rune {
+		if unicode.IsUpper(r) {
+			return unicode.ToLower(r)
+		}
+		return unicode.ToUpper(r)
+	}, base)
+	alias := filepath.Join(filepath.Dir(dir), swapped)
+
+	if st, err := os.Stat(alias); err == nil {
+		if orig, err := os.Stat(dir); err == nil && os.SameFile(st, orig) {
+			return alias
+		}
+		t.Skipf("%s exists and is a different directory", alias)
+	}
+	if err := os.Symlink(base, alias); err != nil {
+		t.Skipf("cannot simulate case-insensitive alias: %v", err)
+	}
+	return alias
+}
+
+func TestLock_CaseInsensitiveStaleLock(t *testing.T) {
+	for _, mode := range lockModes {
+		t.Run(string(mode), func(t *testing.T) {
+			t.Setenv(LockModeEnv, string(mode))
+			dir := filepath.Join(t.TempDir(), ".Beads")
+			if err := os.Mkdir(dir, 0755); err != nil {
+				t.Fatal(err)
+			}
+			alias := caseAlias(t, dir)
+
+			// A crashed bv left a lock behind under one spelling...
+			data, _ := json.Marshal(LockInfo{PID: 99999999, StartedAt: time.Now().Add(-time.Hour), Hostname: "stale-host"})
+			if err := os.WriteFile(filepath.Join(dir, LockFileName), data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			// ...and the next one opens the repository under another.
+			lock, err := NewLock(alias)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !lock.IsFirstInstance() {
+				t.Fatal("expected stale lock takeover through the alias")
+			}
+			info, err := readLockFile(filepath.Join(dir, LockFileName))
+			if err != nil || info.PID != os.Getpid() {
+				t.Fatalf("lock file should name us under both spellings, got %+v, %v", info, err)
+			}
+			if entries, _ := filepath.Glob(filepath.Join(dir, LockFileName+".*[0-9]")); len(entries) != 0 {
+				t.Errorf("takeover left temp files behind: %v", entries)
+			}
+
+			// The original spelling now sees a live primary.
+			other, err := NewLock(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if other.IsFirstInstance() {
+				t.Error("second spelling must not become a second primary")
+			}
+			other.Release()
+
+			lock.Release()
+			if _, err := os.Stat(filepath.Join(dir, LockFileName)); !os.IsNotExist(err) {
+				t.Error("Release through the alias should remove the lock file")
+			}
+		})
+	}
+}
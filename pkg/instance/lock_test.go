@@ -328,3 +328,139 @@ func TestNewLock_ConcurrentStaleTakeover(t *testing.T) {
 		t.Errorf("Expected exactly 1 goroutine to be first instance, got %d", firstCount)
 	}
 }
+
+func TestInspect(t *testing.T) {
+	dir := t.TempDir()
+	lockPath := filepath.Join(dir, LockFileName)
+
+	if got := Inspect(dir); got.State != LockStateNone {
+		t.Errorf("no lock file: state = %s, want none", got.State)
+	}
+
+	lock, err := NewLock(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := Inspect(dir)
+	if got.State != LockStateHeld || got.Info == nil || got.Info.PID != os.Getpid() {
+		t.Errorf("held lock: %+v", got)
+	}
+	lock.Release()
+
+	data, _ := json.Marshal(LockInfo{PID: 999999999})
+	if err := os.WriteFile(lockPath, data, 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := Inspect(dir); got.State != LockStateStale {
+		t.Errorf("dead holder: state = %s, want stale", got.State)
+	}
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Error("Inspect must not remove or take over the lock file")
+	}
+
+	if err := os.WriteFile(lockPath, []byte("not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if got := Inspect(dir); got.State != LockStateCorrupt {
+		t.Errorf("garbage lock: state = %s, want corrupt", got.State)
+	}
+}
+
+// writeTestLock writes a lock file naming pid on this host as the holder.
+func writeTestLock(t *testing.T, dir string, pid int) {
+	t.Helper()
+	hostname, _ := os.Hostname()
+	data, err := json.Marshal(LockInfo{PID: pid, StartedAt: time.Now(), Hostname: hostname})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, LockFileName), data, 0644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLock_RefreshPromotesWhenPrimaryExits(t *testing.T) {
+	tmpDir := t.TempDir()
+	// The parent process (go test) is alive and stands in for another primary.
+	writeTestLock(t, tmpDir, os.Getppid())
+
+	lock, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatalf("NewLock failed: %v", err)
+	}
+	defer lock.Release()
+	if lock.IsFirstInstance() {
+		t.Fatal("expected secondary while parent holds the lock")
+	}
+	if h := lock.Holder(); h == nil || h.PID != os.Getppid() || h.Hostname == "" {
+		t.Errorf("unexpected holder %+v", h)
+	}
+	if got := lock.Refresh(); got != LockUnchanged {
+		t.Errorf("Refresh with live primary = %v, want LockUnchanged", got)
+	}
+
+	// Primary exits cleanly and removes its lock file.
+	os.Remove(filepath.Join(tmpDir, LockFileName))
+	if got := lock.Refresh(); got != LockPromoted {
+		t.Fatalf("Refresh after primary exit = %v, want LockPromoted", got)
+	}
+	if !lock.IsFirstInstance() || lock.Holder() != nil {
+		t.Error("promoted instance should be primary with no foreign holder")
+	}
+}
+
+func TestLock_RefreshPromotesOnStaleLock(t *testing.T) {
+	tmpDir := t.TempDir()
+	writeTestLock(t, tmpDir, os.Getppid())
+
+	lock, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatalf("NewLock failed: %v", err)
+	}
+	defer lock.Release()
+
+	// Primary crashes, leaving its lock file behind.
+	writeTestLock(t, tmpDir, 99999999)
+	if got := lock.Refresh(); got != LockPromoted {
+		t.Fatalf("Refresh after primary crash = %v, want LockPromoted", got)
+	}
+	if !lock.IsFirstInstance() {
+		t.Error("expected promotion to primary")
+	}
+}
+
+func TestLock_StealAndDemote(t *testing.T) {
+	tmpDir := t.TempDir()
+	lockPath := filepath.Join(tmpDir, LockFileName)
+	writeTestLock(t, tmpDir, os.Getppid())
+
+	lock, err := NewLock(tmpDir)
+	if err != nil {
+		t.Fatalf("NewLock failed: %v", err)
+	}
+	if err := lock.Steal(); err != nil {
+		t.Fatalf("Steal failed: %v", err)
+	}
+	if !lock.IsFirstInstance() {
+		t.Fatal("expected primary after Steal")
+	}
+	info, err := readLockFile(lockPath)
+	if err != nil || info.PID != os.Getpid() {
+		t.Fatalf("lock file should name us after Steal, got %+v, %v", info, err)
+	}
+
+	// Another instance steals it back.
+	writeTestLock(t, tmpDir, os.Getppid())
+	if got := lock.Refresh(); got != LockDemoted {
+		t.Fatalf("Refresh after losing the lock = %v, want LockDemoted", got)
+	}
+	if lock.IsFirstInstance() || lock.HolderPID() != os.Getppid() {
+		t.Error("expected demotion to secondary naming the new holder")
+	}
+
+	// Releasing a lock we no longer own must leave the new holder's file alone.
+	lock.Release()
+	if _, err := os.Stat(lockPath); err != nil {
+		t.Errorf("Release removed a lock file owned by another instance: %v", err)
+	}
+}
@@ -3,6 +3,8 @@
 package instance
 
 import (
+	"os"
+
 	"golang.org/x/sys/windows"
 )
 
@@ -25,3 +27,23 @@ func isProcessAlive(pid int) bool {
 	windows.CloseHandle(handle)
 	return true
 }
+
+// tryLockFile takes a non-blocking LockFileEx lock on f, shared or exclusive.
+// It reports false (and no error) when another process holds a conflicting lock.
+func tryLockFile(f *os.File, exclusive bool) (bool, error) {
+	flags := uint32(windows.LOCKFILE_FAIL_IMMEDIATELY)
+	if exclusive {
+		flags |= windows.LOCKFILE_EXCLUSIVE_LOCK
+	}
+	var ol windows.Overlapped
+	err := windows.LockFileEx(windows.Handle(f.Fd()), flags, 0, 1, 0, &ol)
+	if err == windows.ERROR_LOCK_VIOLATION {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func unlockFile(f *os.File) error {
+	var ol windows.Overlapped
+	return windows.UnlockFileEx(windows.Handle(f.Fd()), 0, 1, 0, &ol)
+}
@@ -0,0 +1,346 @@
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// RegistryFileName is the name of the instance registry in the .beads directory.
+// Unlike the lock file, which only names the primary, the registry lists every
+// live bv process working on the repository.
+const RegistryFileName = ".bv.instances.json"
+
+// Mode identifies what kind of bv process registered.
+type Mode string
+
+const (
+	ModeTUI   Mode = "tui"
+	ModeServe Mode = "serve"
+)
+
+// DefaultHeartbeatInterval is how often a registration refreshes its entry.
+const DefaultHeartbeatInterval = 5 * time.Second
+
+// heartbeatExpiry is how many missed heartbeats make an entry dead even if its
+// PID is still in use (PID reuse, hung process, or a holder on another host).
+const heartbeatExpiry = 3
+
+// InstanceInfo is one entry in the registry.
+type InstanceInfo struct {
+	ID        string    `json:"id"`
+	PID       int       `json:"pid"`
+	Hostname  string    `json:"hostname,omitempty"`
+	Mode      Mode      `json:"mode"`
+	Primary   bool      `json:"primary"`
+	StartedAt time.Time `json:"started_at"`
+	Heartbeat time.Time `json:"heartbeat"`
+}
+
+// registryFile is the on-disk shape of .bv.instances.json.
+type registryFile struct {
+	Instances []InstanceInfo `json:"instances"`
+}
+
+// registrySeq distinguishes registrations made by the same process.
+var registrySeq atomic.Int64
+
+// registryLockSuffix names the sidecar that carries the registry's advisory
+// lock, taken around each read-modify-write so concurrent instances don't
+// drop each other's entries.
+const registryLockSuffix = ".lock"
+
+// registryLockTimeout bounds the wait for another process's rewrite, which
+// only takes a read and a small write.
+const registryLockTimeout = 2 * time.Second
+
+// registryMu serializes registry rewrites within this process; the advisory
+// lock excludes other processes. Where the filesystem has no file locks
+// (see ProbeLocking), concurrent rewrites can still drop an entry, which its
+// owner restores on its next heartbeat.
+var registryMu sync.Mutex
+
+// Registration is this process's entry in the registry.
+type Registration struct {
+	path     string
+	interval time.Duration
+
+	mu    sync.Mutex
+	info  InstanceInfo
+	peers []InstanceInfo
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+// Register adds an entry for the current process to the registry in beadsDir
+// and returns its Registration. Call Start to keep the entry alive and Close
+// to remove it.
+func Register(beadsDir string, mode Mode, primary bool) (*Registration, error) {
+	hostname, _ := os.Hostname()
+	now := time.Now().UTC()
+	r := &Registration{
+		path:     filepath.Join(beadsDir, RegistryFileName),
+		interval: DefaultHeartbeatInterval,
+		info: InstanceInfo{
+			ID:        fmt.Sprintf("%d-%d", os.Getpid(), registrySeq.Add(1)),
+			PID:       os.Getpid(),
+			Hostname:  hostname,
+			Mode:      mode,
+			Primary:   primary,
+			StartedAt: now,
+			Heartbeat: now,
+		},
+	}
+	if err := r.Heartbeat(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Info returns a copy of this registration's entry.
+func (r *Registration) Info() InstanceInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.info
+}
+
+// SetPrimary updates whether this instance holds the lock. The change is
+// written on the next heartbeat.
+func (r *Registration) SetPrimary(primary bool) {
+	r.mu.Lock()
+	r.info.Primary = primary
+	r.mu.Unlock()
+}
+
+// Heartbeat refreshes this entry, prunes dead ones, and updates Peers.
+func (r *Registration) Heartbeat() error {
+	r.mu.Lock()
+	r.info.Heartbeat = time.Now().UTC()
+	self, interval := r.info, r.interval
+	r.mu.Unlock()
+
+	var peers []InstanceInfo
+	err := updateRegistry(r.path, interval, func(live []InstanceInfo) []InstanceInfo {
+		out := live[:0]
+		for _, inst := range live {
+			if inst.ID == self.ID {
+				continue
+			}
+			out = append(out, inst)
+			peers = append(peers, inst)
+		}
+		return append(out, self)
+	})
+	if err != nil {
+		return err
+	}
+
+	r.mu.Lock()
+	r.peers = peers
+	r.mu.Unlock()
+	return nil
+}
+
+// Peers returns the other live instances seen at the last heartbeat. It does
+// no IO, so it is safe to call from render paths.
+func (r *Registration) Peers() []InstanceInfo {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]InstanceInfo(nil), r.peers...)
+}
+
+// Start heartbeats in the background every interval (DefaultHeartbeatInterval
+// if interval <= 0) until Close. Heartbeat errors are ignored; the entry just
+// ages out if the registry stays unwritable.
+func (r *Registration) Start(interval time.Duration) {
+	if r.stop != nil {
+		return
+	}
+	r.mu.Lock()
+	if interval > 0 {
+		r.interval = interval
+	}
+	interval = r.interval
+	r.mu.Unlock()
+	r.stop = make(chan struct{})
+	r.done = make(chan struct{})
+	go func() {
+		defer close(r.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-r.stop:
+				return
+			case <-ticker.C:
+				_ = r.Heartbeat()
+			}
+		}
+	}()
+}
+
+// Close stops the heartbeat and removes this entry from the registry. The
+// registry file is deleted once it is empty.
+func (r *Registration) Close() error {
+	if r.stop != nil {
+		close(r.stop)
+		<-r.done
+		r.stop = nil
+	}
+	r.mu.Lock()
+	id, interval := r.info.ID, r.interval
+	r.mu.Unlock()
+	return updateRegistry(r.path, interval, func(live []InstanceInfo) []InstanceInfo {
+		out := live[:0]
+		for _, inst := range live {
+			if inst.ID != id {
+				out = append(out, inst)
+			}
+		}
+		return out
+	})
+}
+
+// ListInstances returns the live instances registered in beadsDir, primary
+// first and then by start time. A missing registry yields an empty list.
+func ListInstances(beadsDir string) ([]InstanceInfo, error) {
+	path := filepath.Join(beadsDir, RegistryFileName)
+	all, err := readRegistry(path)
+	if err != nil {
+		return nil, err
+	}
+	live := liveInstances(all, DefaultHeartbeatInterval, time.Now())
+	sortInstances(live)
+	return live, nil
+}
+
+// updateRegistry rewrites the registry with fn applied to its live entries.
+func updateRegistry(path string, interval time.Duration, fn func([]InstanceInfo) []InstanceInfo) error {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+
+	ignoreRuntimeFiles(filepath.Dir(path))
+	unlock, err := lockRegistry(path)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+
+	all, err := readRegistry(path)
+	if err != nil {
+		// A corrupt registry is rebuilt from scratch; every live owner
+		// re-adds itself on its next heartbeat.
+		all = nil
+	}
+	updated := fn(liveInstances(all, interval, time.Now()))
+	if len(updated) == 0 {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing instance registry: %w", err)
+		}
+		return nil
+	}
+	sortInstances(updated)
+	return writeRegistry(path, updated)
+}
+
+// lockRegistry takes the cross-process advisory lock for the registry at
+// path, waiting up to registryLockTimeout. On filesystems without file locks
+// it returns a no-op unlock.
+func lockRegistry(path string) (func(), error) {
+	noop := func() {}
+	if ProbeLocking(filepath.Dir(path)) != LockModeAdvisory {
+		return noop, nil
+	}
+	f, err := os.OpenFile(path+registryLockSuffix, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening instance registry lock: %w", err)
+	}
+	deadline := time.Now().Add(registryLockTimeout)
+	for {
+		ok, err := tryLockFile(f, true)
+		if err != nil {
+			f.Close()
+			return nil, fmt.Errorf("locking instance registry: %w", err)
+		}
+		if ok {
+			return func() {
+				unlockFile(f)
+				f.Close()
+			}, nil
+		}
+		if !time.Now().Before(deadline) {
+			f.Close()
+			return nil, fmt.Errorf("locking instance registry: %w", ErrLockBusy)
+		}
+		time.Sleep(rwPollInterval)
+	}
+}
+
+// liveInstances drops entries whose process is gone or whose heartbeat is
+// older than heartbeatExpiry intervals. Process liveness can only be checked
+// for entries from this host.
+func liveInstances(all []InstanceInfo, interval time.Duration, now time.Time) []InstanceInfo {
+	hostname, _ := os.Hostname()
+	deadline := now.Add(-heartbeatExpiry * interval)
+	live := make([]InstanceInfo, 0, len(all))
+	for _, inst := range all {
+		if inst.Heartbeat.Before(deadline) {
+			continue
+		}
+		if inst.Hostname == hostname && !isProcessAlive(inst.PID) {
+			continue
+		}
+		live = append(live, inst)
+	}
+	return live
+}
+
+func sortInstances(list []InstanceInfo) {
+	sort.SliceStable(list, func(i, j int) bool {
+		if list[i].Primary != list[j].Primary {
+			return list[i].Primary
+		}
+		if !list[i].StartedAt.Equal(list[j].StartedAt) {
+			return list[i].StartedAt.Before(list[j].StartedAt)
+		}
+		return list[i].ID < list[j].ID
+	})
+}
+
+func readRegistry(path string) ([]InstanceInfo, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading instance registry: %w", err)
+	}
+	var reg registryFile
+	if err := json.Unmarshal(data, &reg); err != nil {
+		return nil, fmt.Errorf("parsing instance registry: %w", err)
+	}
+	return reg.Instances, nil
+}
+
+// writeRegistry replaces the registry atomically via a temp file and rename.
+func writeRegistry(path string, list []InstanceInfo) error {
+	data, err := json.MarshalIndent(registryFile{Instances: list}, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing instance registry: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("writing instance registry: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,177 @@
+package instance
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRegister_ListAndClose(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	tui, err := Register(tmpDir, ModeTUI, true)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	serve, err := Register(tmpDir, ModeServe, false)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+
+	list, err := ListInstances(tmpDir)
+	if err != nil {
+		t.Fatalf("ListInstances failed: %v", err)
+	}
+	if len(list) != 2 {
+		t.Fatalf("expected 2 instances, got %d", len(list))
+	}
+	if !list[0].Primary || list[0].Mode != ModeTUI {
+		t.Errorf("expected primary TUI first, got %+v", list[0])
+	}
+	if list[1].PID != os.Getpid() || list[1].Mode != ModeServe {
+		t.Errorf("unexpected second entry %+v", list[1])
+	}
+
+	// The TUI learns about the server on its next heartbeat.
+	if err := tui.Heartbeat(); err != nil {
+		t.Fatalf("Heartbeat failed: %v", err)
+	}
+	peers := tui.Peers()
+	if len(peers) != 1 || peers[0].ID != serve.Info().ID {
+		t.Errorf("expected server as only peer, got %+v", peers)
+	}
+
+	if err := serve.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	list, _ = ListInstances(tmpDir)
+	if len(list) != 1 || list[0].ID != tui.Info().ID {
+		t.Errorf("expected only TUI after close, got %+v", list)
+	}
+
+	if err := tui.Close(); err != nil {
+		t.Fatalf("Close failed: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(tmpDir, RegistryFileName)); !os.IsNotExist(err) {
+		t.Error("expected empty registry file to be removed")
+	}
+}
+
+func TestListInstances_PrunesDeadAndExpired(t *testing.T) {
+	tmpDir := t.TempDir()
+	hostname, _ := os.Hostname()
+	now := time.Now().UTC()
+
+	entries := []InstanceInfo{
+		{ID: "live", PID: os.Getpid(), Hostname: hostname, Mode: ModeTUI, StartedAt: now, Heartbeat: now},
+		{ID: "dead", PID: 999999999, Hostname: hostname, Mode: ModeServe, StartedAt: now, Heartbeat: now},
+		{ID: "expired", PID: os.Getpid(), Hostname: hostname, Mode: ModeServe, StartedAt: now,
+			Heartbeat: now.Add(-heartbeatExpiry*DefaultHeartbeatInterval - time.Second)},
+		{ID: "remote", PID: 999999999, Hostname: hostname + "-elsewhere", Mode: ModeTUI, StartedAt: now, Heartbeat: now},
+	}
+	if err := writeRegistry(filepath.Join(tmpDir, RegistryFileName), entries); err != nil {
+		t.Fatal(err)
+	}
+
+	list, err := ListInstances(tmpDir)
+	if err != nil {
+		t.Fatalf("ListInstances failed: %v", err)
+	}
+	got := make(map[string]bool)
+	for _, inst := range list {
+		got[inst.ID] = true
+	}
+	// A remote PID can't be checked, so only its heartbeat decides.
+	if len(list) != 2 || !got["live"] || !got["remote"] {
+		t.Errorf("expected live and remote entries, got %+v", list)
+	}
+}
+
+func TestListInstances_MissingAndCorrupt(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	list, err := ListInstances(tmpDir)
+	if err != nil || len(list) != 0 {
+		t.Fatalf("expected empty list for missing registry, got %v, %v", list, err)
+	}
+
+	path := filepath.Join(tmpDir, RegistryFileName)
+	if err := os.WriteFile(path, []byte("{not json"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ListInstances(tmpDir); err == nil {
+		t.Error("expected error for corrupt registry")
+	}
+
+	// Registering rebuilds a corrupt registry.
+	reg, err := Register(tmpDir, ModeTUI, false)
+	if err != nil {
+		t.Fatalf("Register over corrupt registry failed: %v", err)
+	}
+	defer reg.Close()
+	list, err = ListInstances(tmpDir)
+	if err != nil || len(list) != 1 {
+		t.Errorf("expected rebuilt registry with 1 entry, got %v, %v", list, err)
+	}
+}
+
+func TestRegistration_StartHeartbeats(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	reg, err := Register(tmpDir, ModeServe, false)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	first := reg.Info().Heartbeat
+	reg.Start(10 * time.Millisecond)
+	defer reg.Close()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		list, _ := ListInstances(tmpDir)
+		if len(list) == 1 && list[0].Heartbeat.After(first) {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Error("heartbeat was not refreshed")
+}
+
+func TestUpdateRegistry_WaitsForOtherWriter(t *testing.T) {
+	tmpDir := t.TempDir()
+	if ProbeLocking(tmpDir) != LockModeAdvisory {
+		t.Skip("filesystem has no advisory locks")
+	}
+	path := filepath.Join(tmpDir, RegistryFileName)
+
+	// Another process's rewrite in progress, from this registry's view.
+	unlock, err := lockRegistry(path)
+	if err != nil {
+		t.Fatalf("lockRegistry failed: %v", err)
+	}
+	done := make(chan error, 1)
+	go func() {
+		_, err := Register(tmpDir, ModeTUI, false)
+		done <- err
+	}()
+	select {
+	case err := <-done:
+		t.Fatalf("Register finished while the registry was locked (err=%v)", err)
+	case <-time.After(100 * time.Millisecond):
+	}
+	unlock()
+	if err := <-done; err != nil {
+		t.Fatalf("Register failed after unlock: %v", err)
+	}
+}
+
+func TestRegister_IgnoresRegistryFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	reg, err := Register(tmpDir, ModeServe, false)
+	if err != nil {
+		t.Fatalf("Register failed: %v", err)
+	}
+	defer reg.Close()
+	assertIgnored(t, tmpDir, RegistryFileName, RegistryFileName+registryLockSuffix)
+}
@@ -0,0 +1,290 @@
+package instance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Access is the kind of repository access an RWLock grants.
+type Access string
+
+const (
+	// AccessShared is held by read-only operations (robot queries). Any
+	// number of shared holders can run together.
+	AccessShared Access = "shared"
+	// AccessExclusive is held by operations that mutate repository state
+	// (baselines, feedback, cache rebuilds). It excludes every other holder.
+	AccessExclusive Access = "exclusive"
+)
+
+// RWLockFileName is the file that shared/exclusive locks are taken on.
+// It is separate from LockFileName: the primary-instance lock says which TUI
+// owns the repo, while this one guards individual reads and writes.
+const RWLockFileName = ".bv.rwlock"
+
+// rwHoldersDirName holds one small JSON file per current holder (and a
+// pending-writer marker) so a timed-out waiter can say who it waited on.
+const rwHoldersDirName = ".bv.rwlock.d"
+
+// pendingWriterFile marks a writer waiting for readers to drain. New readers
+// wait behind it so a steady stream of robot calls can't starve a writer.
+const pendingWriterFile = "writer-pending.json"
+
+// rwPollInterval is how often a blocked acquire retries.
+const rwPollInterval = 25 * time.Millisecond
+
+// ErrLockBusy is matched (via errors.Is) by the error returned when an
+// acquire gives up waiting.
+var ErrLockBusy = errors.New("repository lock busy")
+
+// RWHolder describes a process holding (or waiting for) the RW lock.
+type RWHolder struct {
+	PID      int       `json:"pid"`
+	Hostname string    `json:"hostname,omitempty"`
+	Access   Access    `json:"access"`
+	Purpose  string    `json:"purpose,omitempty"`
+	Since    time.Time `json:"since"`
+	Pending  bool      `json:"pending,omitempty"`
+}
+
+// LockBusyError is returned when an acquire times out.
+type LockBusyError struct {
+	Want    Access
+	Purpose string
+	Waited  time.Duration
+	Holders []RWHolder // live holders at the time of the timeout, if known
+}
+
+func (e *LockBusyError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "timed out after %s waiting for %s repository lock", e.Waited.Round(time.Millisecond), e.Want)
+	if e.Purpose != "" {
+		fmt.Fprintf(&b, " (%s)", e.Purpose)
+	}
+	if len(e.Holders) == 0 {
+		return b.String()
+	}
+	parts := make([]string, 0, len(e.Holders))
+	for _, h := range e.Holders {
+		desc := fmt.Sprintf("PID %d %s", h.PID, h.Access)
+		if h.Pending {
+			desc += " (waiting)"
+		}
+		if h.Purpose != "" {
+			desc += " for " + h.Purpose
+		}
+		desc += fmt.Sprintf(" since %s", h.Since.Local().Format("15:04:05"))
+		parts = append(parts, desc)
+	}
+	fmt.Fprintf(&b, ": held by %s", strings.Join(parts, "; "))
+	return b.String()
+}
+
+func (e *LockBusyError) Is(target error) bool {
+	return target == ErrLockBusy
+}
+
+// RWLock is a held shared or exclusive repository lock. Locks are advisory
+// OS file locks, so they are released automatically if the process dies.
+type RWLock struct {
+	file       *os.File
+	holderPath string
+	access     Access
+}
+
+// rwSeq keeps holder file names unique within a process.
+var rwSeq atomic.Int64
+
+// AcquireShared takes a shared lock on beadsDir, waiting up to timeout for
+// an exclusive holder (or pending writer) to finish. purpose is a short
+// description shown to other waiters, e.g. "--robot-triage".
+func AcquireShared(beadsDir, purpose string, timeout time.Duration) (*RWLock, error) {
+	return acquireRW(beadsDir, AccessShared, purpose, timeout)
+}
+
+// AcquireExclusive takes an exclusive lock on beadsDir, waiting up to timeout
+// for all current holders to release. While it waits, new shared holders
+// queue behind it.
+func AcquireExclusive(beadsDir, purpose string, timeout time.Duration) (*RWLock, error) {
+	return acquireRW(beadsDir, AccessExclusive, purpose, timeout)
+}
+
+func acquireRW(beadsDir string, access Access, purpose string, timeout time.Duration) (*RWLock, error) {
+	ignoreRuntimeFiles(beadsDir)
+	holdersDir := filepath.Join(beadsDir, rwHoldersDirName)
+	if err := os.MkdirAll(holdersDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating lock directory: %w", err)
+	}
+	file, err := os.OpenFile(filepath.Join(beadsDir, RWLockFileName), os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("opening repository lock: %w", err)
+	}
+
+	exclusive := access == AccessExclusive
+	pendingPath := filepath.Join(holdersDir, pendingWriterFile)
+	start := time.Now()
+	deadline := start.Add(timeout)
+	claimedPending := false
+	defer func() {
+		if claimedPending {
+			os.Remove(pendingPath)
+		}
+	}()
+
+	for {
+		if exclusive && !claimedPending {
+			claimedPending = claimPendingWriter(pendingPath, purpose)
+		}
+		if exclusive || !writerPending(pendingPath) {
+			ok, err := tryLockFile(file, exclusive)
+			if err != nil {
+				file.Close()
+				return nil, fmt.Errorf("locking repository: %w", err)
+			}
+			if ok {
+				break
+			}
+		}
+		if !time.Now().Before(deadline) {
+			file.Close()
+			if claimedPending {
+				os.Remove(pendingPath)
+				claimedPending = false
+			}
+			return nil, &LockBusyError{
+				Want:    access,
+				Purpose: purpose,
+				Waited:  time.Since(start),
+				Holders: liveRWHolders(holdersDir),
+			}
+		}
+		time.Sleep(rwPollInterval)
+	}
+
+	hostname, _ := os.Hostname()
+	holder := RWHolder{PID: os.Getpid(), Hostname: hostname, Access: access, Purpose: purpose, Since: time.Now().UTC()}
+	holderPath := filepath.Join(holdersDir, fmt.Sprintf("%d-%d.json", os.Getpid(), rwSeq.Add(1)))
+	// Holder files are informational; losing one only degrades error messages.
+	_ = writeHolderFile(holderPath, holder)
+
+	return &RWLock{file: file, holderPath: holderPath, access: access}, nil
+}
+
+// Access reports whether the lock is shared or exclusive.
+func (l *RWLock) Access() Access {
+	return l.access
+}
+
+// Release drops the lock. It is safe to call more than once.
+func (l *RWLock) Release() error {
+	if l == nil || l.file == nil {
+		return nil
+	}
+	os.Remove(l.holderPath)
+	err := unlockFile(l.file)
+	if closeErr := l.file.Close(); err == nil {
+		err = closeErr
+	}
+	l.file = nil
+	return err
+}
+
+// claimPendingWriter creates the pending-writer marker, replacing one left by
+// a dead process. It reports whether this process now owns the marker.
+func claimPendingWriter(path, purpose string) bool {
+	hostname, _ := os.Hostname()
+	holder := RWHolder{PID: os.Getpid(), Hostname: hostname, Access: AccessExclusive, Purpose: purpose, Since: time.Now().UTC(), Pending: true}
+	data, err := json.Marshal(holder)
+	if err != nil {
+		return false
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_EXCL, 0644)
+	if err != nil {
+		// Only clear a marker we can read and whose writer is gone; a marker
+		// that is still being written belongs to a live writer.
+		if h, readErr := readHolderFile(path); readErr == nil && !holderAlive(h) {
+			os.Remove(path)
+		}
+		return false
+	}
+	defer f.Close()
+	if _, err := f.Write(data); err != nil {
+		os.Remove(path)
+		return false
+	}
+	return true
+}
+
+// writerPending reports whether a live writer is waiting.
+func writerPending(path string) bool {
+	h, err := readHolderFile(path)
+	if err != nil {
+		return false
+	}
+	return holderAlive(h)
+}
+
+// liveRWHolders lists holders whose process is still running, exclusive
+// holders first.
+func liveRWHolders(dir string) []RWHolder {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil
+	}
+	var holders []RWHolder
+	for _, e := range entries {
+		path := filepath.Join(dir, e.Name())
+		h, err := readHolderFile(path)
+		if err != nil {
+			continue
+		}
+		if !holderAlive(h) {
+			// Left behind by a crashed holder; its OS lock is already gone.
+			os.Remove(path)
+			continue
+		}
+		holders = append(holders, h)
+	}
+	sort.SliceStable(holders, func(i, j int) bool {
+		if holders[i].Access != holders[j].Access {
+			return holders[i].Access == AccessExclusive
+		}
+		return holders[i].Since.Before(holders[j].Since)
+	})
+	return holders
+}
+
+// holderAlive checks the holder's PID when it ran on this host. Holders on
+// other hosts are assumed alive.
+func holderAlive(h RWHolder) bool {
+	hostname, _ := os.Hostname()
+	if h.Hostname != "" && h.Hostname != hostname {
+		return true
+	}
+	return isProcessAlive(h.PID)
+}
+
+func readHolderFile(path string) (RWHolder, error) {
+	var h RWHolder
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h, err
+	}
+	err = json.Unmarshal(data, &h)
+	return h, err
+}
+
+func writeHolderFile(path string, h RWHolder) error {
+	data, err := json.Marshal(h)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0644)
+}
@@ -0,0 +1,174 @@
+package instance
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRWLock_SharedHoldersCoexist(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	a, err := AcquireShared(tmpDir, "--robot-triage", 0)
+	if err != nil {
+		t.Fatalf("first shared acquire failed: %v", err)
+	}
+	defer a.Release()
+	b, err := AcquireShared(tmpDir, "--robot-plan", 0)
+	if err != nil {
+		t.Fatalf("second shared acquire failed: %v", err)
+	}
+	defer b.Release()
+
+	if a.Access() != AccessShared || b.Access() != AccessShared {
+		t.Errorf("expected shared access, got %s and %s", a.Access(), b.Access())
+	}
+}
+
+func TestRWLock_ExclusiveWaitsForReaders(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	reader, err := AcquireShared(tmpDir, "--robot-insights", 0)
+	if err != nil {
+		t.Fatalf("shared acquire failed: %v", err)
+	}
+
+	_, err = AcquireExclusive(tmpDir, "--save-baseline", 100*time.Millisecond)
+	if !errors.Is(err, ErrLockBusy) {
+		t.Fatalf("expected ErrLockBusy, got %v", err)
+	}
+	var busy *LockBusyError
+	if !errors.As(err, &busy) {
+		t.Fatalf("expected *LockBusyError, got %T", err)
+	}
+	if busy.Want != AccessExclusive || busy.Waited < 100*time.Millisecond {
+		t.Errorf("unexpected busy error %+v", busy)
+	}
+	msg := err.Error()
+	for _, want := range []string{"exclusive repository lock", "--save-baseline", "shared for --robot-insights"} {
+		if !strings.Contains(msg, want) {
+			t.Errorf("error %q missing %q", msg, want)
+		}
+	}
+
+	// The timed-out writer must not leave readers queued behind it.
+	if _, err := os.Stat(filepath.Join(tmpDir, rwHoldersDirName, pendingWriterFile)); !os.IsNotExist(err) {
+		t.Error("pending-writer marker left behind after timeout")
+	}
+
+	// Once the reader leaves, the writer gets in.
+	done := make(chan error, 1)
+	go func() {
+		w, err := AcquireExclusive(tmpDir, "--save-baseline", 2*time.Second)
+		if err == nil {
+			w.Release()
+		}
+		done <- err
+	}()
+	time.Sleep(50 * time.Millisecond)
+	reader.Release()
+	if err := <-done; err != nil {
+		t.Fatalf("exclusive acquire after release failed: %v", err)
+	}
+}
+
+func TestRWLock_ExclusiveBlocksReaders(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	writer, err := AcquireExclusive(tmpDir, "cache rebuild", 0)
+	if err != nil {
+		t.Fatalf("exclusive acquire failed: %v", err)
+	}
+
+	_, err = AcquireShared(tmpDir, "--robot-next", 50*time.Millisecond)
+	if !errors.Is(err, ErrLockBusy) {
+		t.Fatalf("expected ErrLockBusy, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "exclusive for cache rebuild") {
+		t.Errorf("error should name the writer: %v", err)
+	}
+
+	writer.Release()
+	writer.Release() // idempotent
+	reader, err := AcquireShared(tmpDir, "--robot-next", 0)
+	if err != nil {
+		t.Fatalf("shared acquire after release failed: %v", err)
+	}
+	reader.Release()
+}
+
+func TestRWLock_PendingWriterQueuesReaders(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	reader, err := AcquireShared(tmpDir, "first", 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer reader.Release()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if w, err := AcquireExclusive(tmpDir, "writer", 300*time.Millisecond); err == nil {
+			w.Release()
+		}
+	}()
+
+	// Wait for the writer to announce itself.
+	pending := filepath.Join(tmpDir, rwHoldersDirName, pendingWriterFile)
+	deadline := time.Now().Add(time.Second)
+	for {
+		if _, err := os.Stat(pending); err == nil {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("writer never marked itself pending")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// A new reader queues behind the pending writer even though only
+	// shared locks are held.
+	if _, err := AcquireShared(tmpDir, "second", 50*time.Millisecond); !errors.Is(err, ErrLockBusy) {
+		t.Errorf("expected new reader to wait behind pending writer, got %v", err)
+	}
+	<-done
+}
+
+func TestRWLock_StalePendingMarkerIgnored(t *testing.T) {
+	tmpDir := t.TempDir()
+	dir := filepath.Join(tmpDir, rwHoldersDirName)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	hostname, _ := os.Hostname()
+	stale := RWHolder{PID: 999999999, Hostname: hostname, Access: AccessExclusive, Pending: true, Since: time.Now()}
+	if err := writeHolderFile(filepath.Join(dir, pendingWriterFile), stale); err != nil {
+		t.Fatal(err)
+	}
+
+	reader, err := AcquireShared(tmpDir, "robot", 0)
+	if err != nil {
+		t.Fatalf("stale pending marker should not block readers: %v", err)
+	}
+	reader.Release()
+
+	writer, err := AcquireExclusive(tmpDir, "writer", time.Second)
+	if err != nil {
+		t.Fatalf("stale pending marker should be replaced: %v", err)
+	}
+	writer.Release()
+}
+
+func TestRWLock_IgnoresLockFiles(t *testing.T) {
+	tmpDir := t.TempDir()
+	l, err := AcquireShared(tmpDir, "--robot-triage", 0)
+	if err != nil {
+		t.Fatalf("shared acquire failed: %v", err)
+	}
+	defer l.Release()
+	assertIgnored(t, tmpDir, RWLockFileName, rwHoldersDirName+"/")
+}
@@ -0,0 +1,102 @@
+package instance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// CacheSignalFileName is the file in the .beads directory that bv processes
+// rewrite after refreshing the shared analysis cache. Long-running instances
+// watch it (fsnotify or polling, like the beads file itself) and drop their
+// in-memory caches when another process has seen newer data.
+const CacheSignalFileName = ".bv.cache-signal"
+
+// CacheSignal is the content of the signal file.
+type CacheSignal struct {
+	PID      int       `json:"pid"`
+	Hostname string    `json:"hostname,omitempty"`
+	DataHash string    `json:"data_hash"` // of the analyzed issues, which a robot filter may have narrowed
+	Reason   string    `json:"reason,omitempty"`
+	At       time.Time `json:"at"`
+
+	// DataFile is the beads file the signaling process loaded, before any
+	// filtering. Watchers compare it with the file they loaded.
+	DataFile *FileStamp `json:"data_file,omitempty"`
+}
+
+// FileStamp identifies a version of a file by size and modification time,
+// which is cheap to compare without reading it.
+type FileStamp struct {
+	Size    int64     `json:"size"`
+	ModTime time.Time `json:"mod_time"`
+}
+
+// StatFile returns the stamp of path.
+func StatFile(path string) (FileStamp, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return FileStamp{}, err
+	}
+	return FileStamp{Size: info.Size(), ModTime: info.ModTime()}, nil
+}
+
+// Equal reports whether s and o are the same version of a file.
+func (s FileStamp) Equal(o FileStamp) bool {
+	return s.Size == o.Size && s.ModTime.Equal(o.ModTime)
+}
+
+// CacheSignalPath returns the signal file path for beadsDir.
+func CacheSignalPath(beadsDir string) string {
+	return filepath.Join(beadsDir, CacheSignalFileName)
+}
+
+// FromSelf reports whether the signal was written by the current process.
+func (s CacheSignal) FromSelf() bool {
+	hostname, _ := os.Hostname()
+	return s.PID == os.Getpid() && s.Hostname == hostname
+}
+
+// SignalCacheUpdate announces that the analysis cache now holds results for
+// dataHash, computed from the beads file version dataFile (nil if unknown).
+// The file is replaced atomically so watchers never read a partial signal.
+func SignalCacheUpdate(beadsDir, dataHash, reason string, dataFile *FileStamp) error {
+	hostname, _ := os.Hostname()
+	data, err := json.Marshal(CacheSignal{
+		PID:      os.Getpid(),
+		Hostname: hostname,
+		DataHash: dataHash,
+		Reason:   reason,
+		At:       time.Now().UTC(),
+		DataFile: dataFile,
+	})
+	if err != nil {
+		return err
+	}
+	ignoreRuntimeFiles(beadsDir)
+	path := CacheSignalPath(beadsDir)
+	tmp := fmt.Sprintf("%s.%d.tmp", path, os.Getpid())
+	if err := os.WriteFile(tmp, append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("writing cache signal: %w", err)
+	}
+	if err := os.Rename(tmp, path); err != nil {
+		os.Remove(tmp)
+		return fmt.Errorf("writing cache signal: %w", err)
+	}
+	return nil
+}
+
+// ReadCacheSignal reads the latest signal in beadsDir.
+func ReadCacheSignal(beadsDir string) (CacheSignal, error) {
+	var sig CacheSignal
+	data, err := os.ReadFile(CacheSignalPath(beadsDir))
+	if err != nil {
+		return sig, err
+	}
+	if err := json.Unmarshal(data, &sig); err != nil {
+		return sig, fmt.Errorf("parsing cache signal: %w", err)
+	}
+	return sig, nil
+}
@@ -0,0 +1,46 @@
+package instance
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+func TestCacheSignal_RoundTrip(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	if _, err := ReadCacheSignal(tmpDir); !os.IsNotExist(err) {
+		t.Fatalf("expected not-exist error before any signal, got %v", err)
+	}
+
+	stamp := &FileStamp{Size: 42, ModTime: time.Date(2026, 3, 10, 12, 0, 0, 123, time.Local)}
+	if err := SignalCacheUpdate(tmpDir, "abc123", "--robot-triage", stamp); err != nil {
+		t.Fatalf("SignalCacheUpdate failed: %v", err)
+	}
+	sig, err := ReadCacheSignal(tmpDir)
+	if err != nil {
+		t.Fatalf("ReadCacheSignal failed: %v", err)
+	}
+	if sig.DataHash != "abc123" || sig.Reason != "--robot-triage" || sig.At.IsZero() {
+		t.Errorf("unexpected signal %+v", sig)
+	}
+	if sig.DataFile == nil || !sig.DataFile.Equal(*stamp) {
+		t.Errorf("data file stamp = %+v, want %+v", sig.DataFile, stamp)
+	}
+	if !sig.FromSelf() {
+		t.Error("signal written by this process should report FromSelf")
+	}
+
+	sig.PID++
+	if sig.FromSelf() {
+		t.Error("signal from another PID should not report FromSelf")
+	}
+}
+
+func TestCacheSignal_IgnoresSignalFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	if err := SignalCacheUpdate(tmpDir, "abc", "--robot-triage", nil); err != nil {
+		t.Fatalf("SignalCacheUpdate failed: %v", err)
+	}
+	assertIgnored(t, tmpDir, CacheSignalFileName)
+}
@@ -0,0 +1,87 @@
+// Package junit writes JUnit XML test reports, the format CI systems
+// without other integrations show as test results. bv maps each of its
+// checks to a test case that passes, fails, or is skipped.
+package junit
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// Suites is a report's root element.
+type Suites struct {
+	XMLName  xml.Name `xml:"testsuites"`
+	Name     string   `xml:"name,attr,omitempty"`
+	Tests    int      `xml:"tests,attr"`
+	Failures int      `xml:"failures,attr"`
+	Skipped  int      `xml:"skipped,attr"`
+	Suites   []Suite  `xml:"testsuite"`
+}
+
+// Suite groups the test cases of one command.
+type Suite struct {
+	Name     string `xml:"name,attr"`
+	Tests    int    `xml:"tests,attr"`
+	Failures int    `xml:"failures,attr"`
+	Skipped  int    `xml:"skipped,attr"`
+	Cases    []Case `xml:"testcase"`
+}
+
+// Case is one check. It passes unless Failure or Skipped is set.
+type Case struct {
+	Name      string   `xml:"name,attr"`
+	Classname string   `xml:"classname,attr"`
+	Failure   *Failure `xml:"failure,omitempty"`
+	Skipped   *Skipped `xml:"skipped,omitempty"`
+	SystemOut string   `xml:"system-out,omitempty"` // Findings that don't fail the check
+}
+
+// Failure says why a check failed: Message is a one-line summary, Text
+// the details.
+type Failure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",chardata"`
+}
+
+// Skipped marks a check that didn't run.
+type Skipped struct {
+	Message string `xml:"message,attr,omitempty"`
+}
+
+// NewSuite returns a suite of cases with its counts filled in.
+func NewSuite(name string, cases []Case) Suite {
+	s := Suite{Name: name, Tests: len(cases), Cases: cases}
+	for _, c := range cases {
+		switch {
+		case c.Failure != nil:
+			s.Failures++
+		case c.Skipped != nil:
+			s.Skipped++
+		}
+	}
+	if s.Cases == nil {
+		s.Cases = []Case{}
+	}
+	return s
+}
+
+// Write encodes suites, with their totals, to w as an XML document.
+func Write(w io.Writer, name string, suites ...Suite) error {
+	root := Suites{Name: name, Suites: suites}
+	for _, s := range suites {
+		root.Tests += s.Tests
+		root.Failures += s.Failures
+		root.Skipped += s.Skipped
+	}
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(root); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, "\n")
+	return err
+}
@@ -0,0 +1,34 @@
+package junit
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestWrite(t *testing.T) {
+	suite := NewSuite("bv lint", []Case{
+		{Name: "pass", Classname: "bv.lint"},
+		{Name: "fail", Classname: "bv.lint", Failure: &Failure{Message: "1 finding", Text: "a.jsonl:3: <bad> & \"worse\""}},
+		{Name: "off", Classname: "bv.lint", Skipped: &Skipped{Message: "off"}},
+	})
+	if suite.Tests != 3 || suite.Failures != 1 || suite.Skipped != 1 {
+		t.Errorf("suite counts = %d/%d/%d", suite.Tests, suite.Failures, suite.Skipped)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, "bv", suite, NewSuite("empty", nil)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), xml.Header+`<testsuites name="bv" tests="3" failures="1" skipped="1">`) {
+		t.Errorf("report:\n%s", buf.String())
+	}
+	var back Suites
+	if err := xml.Unmarshal(buf.Bytes(), &back); err != nil {
+		t.Fatal(err)
+	}
+	if len(back.Suites) != 2 || back.Suites[0].Cases[1].Failure.Text != `a.jsonl:3: <bad> & "worse"` || back.Suites[0].Cases[0].Failure != nil {
+		t.Errorf("round trip = %+v", back)
+	}
+}
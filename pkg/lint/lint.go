@@ -0,0 +1,225 @@
+// Package lint checks a beads JSONL file for problems bv can repair safely
+// and computes the repaired content.
+package lint
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	json "github.com/goccy/go-json"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Rule names one kind of finding.
+type Rule string
+
+const (
+	// RuleDuplicateDependency is a dependency listed more than once
+	RuleDuplicateDependency Rule = "duplicate-dependency"
+	// RuleSelfDependency is an issue depending on itself
+	RuleSelfDependency Rule = "self-dependency"
+	// RuleStatusCase is a status with stray case or whitespace ("Open ")
+	RuleStatusCase Rule = "status-case"
+	// RuleMissingUpdatedAt is an issue with created_at but no updated_at
+	RuleMissingUpdatedAt Rule = "missing-updated-at"
+)
+
+// Finding is one problem on one line of the file. Every rule is fixable.
+type Finding struct {
+	Line    int    `json:"line"` // 1-based
+	IssueID string `json:"issue_id"`
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+// Report is the result of linting a file.
+type Report struct {
+	Findings []Finding `json:"findings"` // In line order
+	// Skipped lists lines that aren't valid issues even after the fixes;
+	// lint leaves them alone.
+	Skipped []model.ParseError `json:"skipped"`
+	// Fixed is the content with every finding repaired. Only lines with
+	// findings are rewritten, in canonical form (see model.MarshalIssues) with
+	// fields bv doesn't know kept; every other byte is unchanged.
+	Fixed []byte `json:"-"`
+}
+
+// Lint checks data, the content of a beads JSONL file.
+func Lint(data []byte) Report {
+	report := Report{Findings: []Finding{}, Skipped: []model.ParseError{}}
+	var out bytes.Buffer
+	out.Grow(len(data))
+
+	lineNum := 0
+	for rest := data; len(rest) > 0; {
+		lineNum++
+		line := rest
+		if i := bytes.IndexByte(rest, '\n'); i >= 0 {
+			line, rest = rest[:i+1], rest[i+1:]
+		} else {
+			rest = nil
+		}
+		body := bytes.TrimRight(line, "\r\n")
+		eol := line[len(body):]
+		prefix := []byte(nil)
+		if lineNum == 1 && bytes.HasPrefix(body, []byte("\xef\xbb\xbf")) {
+			prefix, body = body[:3], body[3:]
+		}
+		if len(bytes.TrimSpace(body)) == 0 {
+			out.Write(line)
+			continue
+		}
+
+		fixed, findings, perr := lintLine(body, lineNum)
+		if perr != nil {
+			report.Skipped = append(report.Skipped, *perr)
+		}
+		report.Findings = append(report.Findings, findings...)
+		if fixed == nil {
+			out.Write(line)
+			continue
+		}
+		out.Write(prefix)
+		out.Write(fixed)
+		out.Write(eol)
+	}
+	report.Fixed = out.Bytes()
+	return report
+}
+
+// IssueLines maps each issue ID in data, the content of a beads JSONL file,
+// to the 1-based line it is on. An ID on several lines maps to the first.
+func IssueLines(data []byte) map[string]int {
+	lines := make(map[string]int)
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		if i == 0 {
+			line = bytes.TrimPrefix(line, []byte("\xef\xbb\xbf"))
+		}
+		var issue struct {
+			ID string `json:"id"`
+		}
+		if len(bytes.TrimSpace(line)) == 0 || json.Unmarshal(line, &issue) != nil || issue.ID == "" {
+			continue
+		}
+		if _, seen := lines[issue.ID]; !seen {
+			lines[issue.ID] = i + 1
+		}
+	}
+	return lines
+}
+
+// lintLine checks one non-blank line. fixed is the replacement line, or nil
+// to keep it as is.
+func lintLine(body []byte, lineNum int) (fixed []byte, findings []Finding, perr *model.ParseError) {
+	var issue model.Issue
+	if err := json.Unmarshal(body, &issue); err != nil {
+		return nil, nil, &model.ParseError{Line: lineNum, Kind: model.ParseErrorMalformed, Err: err}
+	}
+	add := func(rule Rule, format string, args ...any) {
+		findings = append(findings, Finding{Line: lineNum, IssueID: issue.ID, Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if normalized := model.NormalizeStatus(issue.Status); normalized != issue.Status && normalized.IsValid() {
+		add(RuleStatusCase, "status %q should be %q", issue.Status, normalized)
+		issue.Status = normalized
+	}
+
+	var deps []*model.Dependency
+	seen := make(map[string]bool)
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if dep.DependsOnID == issue.ID {
+			add(RuleSelfDependency, "depends on itself (%s)", depType(dep.Type))
+			continue
+		}
+		key := dep.DependsOnID + "\x00" + string(depType(dep.Type))
+		if seen[key] {
+			add(RuleDuplicateDependency, "%s dependency on %s listed more than once", depType(dep.Type), dep.DependsOnID)
+			continue
+		}
+		seen[key] = true
+		deps = append(deps, dep)
+	}
+	issue.Dependencies = deps
+
+	if issue.UpdatedAt.IsZero() && !issue.CreatedAt.IsZero() {
+		add(RuleMissingUpdatedAt, "updated_at is missing; using created_at")
+		issue.UpdatedAt = issue.CreatedAt
+	}
+
+	if err := issue.Validate(); err != nil {
+		return nil, findings, &model.ParseError{Line: lineNum, Kind: model.ParseErrorInvalid, Err: err}
+	}
+	if len(findings) == 0 {
+		return nil, nil, nil
+	}
+	encoded, err := model.MarshalIssues([]model.Issue{issue})
+	if err != nil {
+		return nil, findings, &model.ParseError{Line: lineNum, Kind: model.ParseErrorInvalid, Err: err}
+	}
+	fixed, err = keepUnknownFields(bytes.TrimSuffix(encoded, []byte("\n")), body)
+	if err != nil {
+		return nil, findings, &model.ParseError{Line: lineNum, Kind: model.ParseErrorMalformed, Err: err}
+	}
+	return fixed, findings, nil
+}
+
+// depType names a dependency type; untyped legacy dependencies are blocks.
+func depType(t model.DependencyType) model.DependencyType {
+	if t == "" {
+		return model.DepBlocks
+	}
+	return t
+}
+
+// issueFields is the set of JSON keys model.Issue reads.
+var issueFields = func() map[string]bool {
+	fields := make(map[string]bool)
+	t := reflect.TypeOf(model.Issue{})
+	for i := 0; i < t.NumField(); i++ {
+		name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+		if name != "" && name != "-" {
+			fields[name] = true
+		}
+	}
+	return fields
+}()
+
+// keepUnknownFields appends the fields of original that model.Issue doesn't
+// know to encoded, in key order, so a fix never drops data written by newer
+// versions of bd.
+func keepUnknownFields(encoded, original []byte) ([]byte, error) {
+	var raw map[string]json.RawMessage
+	if err := json.Unmarshal(original, &raw); err != nil {
+		return nil, err
+	}
+	var extra []string
+	for key := range raw {
+		if !issueFields[key] {
+			extra = append(extra, key)
+		}
+	}
+	if len(extra) == 0 {
+		return encoded, nil
+	}
+	sort.Strings(extra)
+
+	out := append([]byte(nil), bytes.TrimSuffix(encoded, []byte("}"))...)
+	for _, key := range extra {
+		name, err := json.Marshal(key)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, ',')
+		out = append(out, name...)
+		out = append(out, ':')
+		out = append(out, raw[key]...)
+	}
+	return append(out, '}'), nil
+}
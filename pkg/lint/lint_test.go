@@ -0,0 +1,80 @@
+package lint
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLint(t *testing.T) {
+	clean := `{"id":"a-1","title":"Clean","status":"open","issue_type":"task","created_at":"2025-03-01T09:00:00Z","updated_at":"2025-03-01T09:00:00Z"}`
+	messy := `{"id":"a-2","title":"Messy","status":" Open ","issue_type":"task","created_at":"2025-03-01T09:00:00+01:00",` +
+		`"dependencies":[{"depends_on_id":"a-1","type":"blocks"},{"depends_on_id":"a-2","type":"related"},{"depends_on_id":"a-1","type":""}],` +
+		`"custom_field":{"keep":true}}`
+	broken := `{"id":"a-3","title":`
+	data := "\xef\xbb\xbf" + clean + "\r\n\n" + messy + "\r\n" + broken + "\n"
+
+	report := Lint([]byte(data))
+	var rules []string
+	for _, f := range report.Findings {
+		if f.Line != 3 || f.IssueID != "a-2" {
+			t.Errorf("finding on the wrong line: %+v", f)
+		}
+		rules = append(rules, string(f.Rule))
+	}
+	if got, want := strings.Join(rules, ","), "status-case,self-dependency,duplicate-dependency,missing-updated-at"; got != want {
+		t.Errorf("rules = %s, want %s", got, want)
+	}
+	if len(report.Skipped) != 1 || report.Skipped[0].Line != 4 || report.Skipped[0].Kind != model.ParseErrorMalformed {
+		t.Errorf("skipped = %+v", report.Skipped)
+	}
+
+	lines := strings.Split(string(report.Fixed), "\n")
+	if len(lines) != 5 || lines[0] != "\xef\xbb\xbf"+clean+"\r" || lines[1] != "" || lines[3] != broken {
+		t.Fatalf("untouched lines changed:\n%q", report.Fixed)
+	}
+	fixed := lines[2]
+	for _, want := range []string{
+		`"status":"open"`,
+		`"updated_at":"2025-03-01T08:00:00Z"`,
+		`"dependencies":[{"issue_id":"a-2","depends_on_id":"a-1","type":"blocks"`,
+		`,"custom_field":{"keep":true}}` + "\r",
+	} {
+		if !strings.Contains(fixed, want) {
+			t.Errorf("fixed line missing %s:\n%s", want, fixed)
+		}
+	}
+	if strings.Count(fixed, "depends_on_id") != 1 {
+		t.Errorf("dependencies not deduplicated: %s", fixed)
+	}
+
+	again := Lint(report.Fixed)
+	if len(again.Findings) != 0 || string(again.Fixed) != string(report.Fixed) {
+		t.Errorf("fixing is not idempotent: %+v", again.Findings)
+	}
+	issues, errs := model.ParseIssues(report.Fixed)
+	if len(issues) != 2 || len(errs) != 1 {
+		t.Errorf("fixed file parses to %d issues, %d errors", len(issues), len(errs))
+	}
+}
+
+func TestLintLeavesUnfixableLinesAlone(t *testing.T) {
+	// Lowercasing the status doesn't make this issue valid: it has no title
+	line := `{"id":"a-1","status":"OPEN","issue_type":"task"}`
+	report := Lint([]byte(line))
+	if string(report.Fixed) != line {
+		t.Errorf("invalid issue rewritten: %s", report.Fixed)
+	}
+	if len(report.Findings) != 1 || len(report.Skipped) != 1 || report.Skipped[0].Kind != model.ParseErrorInvalid {
+		t.Errorf("findings = %+v, skipped = %+v", report.Findings, report.Skipped)
+	}
+}
+
+func TestIssueLines(t *testing.T) {
+	data := "\xef\xbb\xbf{\"id\":\"a-1\"}\n\n{broken\n{\"id\":\"a-2\"}\r\n{\"id\":\"a-1\",\"title\":\"again\"}\n"
+	lines := IssueLines([]byte(data))
+	if len(lines) != 2 || lines["a-1"] != 1 || lines["a-2"] != 4 {
+		t.Errorf("IssueLines = %v", lines)
+	}
+}
@@ -0,0 +1,93 @@
+package loader
+
+import (
+	"compress/gzip"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ArchiveDirName is the directory under .beads where `bv archive` moves old
+// closed issues, as gzipped JSONL files.
+const ArchiveDirName = "archive"
+
+// ArchiveExt is the extension of archive files.
+const ArchiveExt = ".jsonl.gz"
+
+// ArchiveDir returns the archive directory for a beads directory.
+func ArchiveDir(beadsDir string) string {
+	return filepath.Join(beadsDir, ArchiveDirName)
+}
+
+// ArchivePaths returns the archive files in beadsDir, sorted by name.
+// A missing archive directory is treated as "no archives".
+func ArchivePaths(beadsDir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(ArchiveDir(beadsDir), "*"+ArchiveExt))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// LoadArchivedIssues reads every archive in beadsDir. Malformed lines are
+// skipped with warnings, as in the live file; an unreadable archive is an
+// error. No archives means no issues.
+func LoadArchivedIssues(beadsDir string, opts ParseOptions) ([]model.Issue, error) {
+	paths, err := ArchivePaths(beadsDir)
+	if err != nil {
+		return nil, err
+	}
+	var issues []model.Issue
+	for _, path := range paths {
+		loaded, err := loadArchive(path, opts)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, loaded...)
+	}
+	return issues, nil
+}
+
+func loadArchive(path string, opts ParseOptions) ([]model.Issue, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+	zr, err := gzip.NewReader(file)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", filepath.Base(path), err)
+	}
+	defer zr.Close()
+	issues, err := ParseIssuesWithOptions(zr, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read archive %s: %w", filepath.Base(path), err)
+	}
+	return issues, nil
+}
+
+// MergeArchived returns live followed by the archived issues whose IDs
+// aren't live. The live copy of an issue always wins, so an archived issue
+// that was reopened (and re-exported by bd) isn't counted twice.
+func MergeArchived(live, archived []model.Issue) []model.Issue {
+	if len(archived) == 0 {
+		return live
+	}
+	seen := make(map[string]bool, len(live)+len(archived))
+	merged := make([]model.Issue, 0, len(live)+len(archived))
+	for _, issue := range live {
+		seen[issue.ID] = true
+		merged = append(merged, issue)
+	}
+	for _, issue := range archived {
+		if !seen[issue.ID] {
+			seen[issue.ID] = true
+			merged = append(merged, issue)
+		}
+	}
+	return merged
+}
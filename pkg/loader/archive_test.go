@@ -0,0 +1,69 @@
+package loader
+
+import (
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func writeArchive(t *testing.T, path, content string) {
+	t.Helper()
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	zw := gzip.NewWriter(f)
+	if _, err := zw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLoadArchivedIssues(t *testing.T) {
+	beadsDir := t.TempDir()
+	issues, err := LoadArchivedIssues(beadsDir, ParseOptions{})
+	if err != nil || len(issues) != 0 {
+		t.Fatalf("no archive dir: %v, %v", issues, err)
+	}
+
+	if err := os.MkdirAll(ArchiveDir(beadsDir), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeArchive(t, filepath.Join(ArchiveDir(beadsDir), "closed-before-2024-06-01.jsonl.gz"),
+		`{"id":"a-2","title":"Two","status":"closed","issue_type":"task"}`+"\n")
+	writeArchive(t, filepath.Join(ArchiveDir(beadsDir), "closed-before-2024-01-01.jsonl.gz"),
+		`{"id":"a-1","title":"One","status":"closed","issue_type":"task"}`+"\nnot json\n")
+
+	var warnings int
+	issues, err = LoadArchivedIssues(beadsDir, ParseOptions{WarningHandler: func(string) { warnings++ }})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(issues) != 2 || issues[0].ID != "a-1" || issues[1].ID != "a-2" || warnings != 1 {
+		t.Errorf("issues = %+v, warnings = %d", issues, warnings)
+	}
+
+	if err := os.WriteFile(filepath.Join(ArchiveDir(beadsDir), "broken.jsonl.gz"), []byte("plain"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadArchivedIssues(beadsDir, ParseOptions{}); err == nil {
+		t.Error("expected an error for a corrupt archive")
+	}
+}
+
+func TestMergeArchived(t *testing.T) {
+	live := []model.Issue{{ID: "a-1", Status: model.StatusOpen}}
+	archived := []model.Issue{{ID: "a-1", Status: model.StatusClosed}, {ID: "a-2", Status: model.StatusClosed}}
+	merged := MergeArchived(live, archived)
+	if len(merged) != 2 || merged[0].Status != model.StatusOpen || merged[1].ID != "a-2" {
+		t.Errorf("merged = %+v", merged)
+	}
+}
@@ -0,0 +1,92 @@
+package loader
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// lineReaders recycles line readers of DefaultMaxBufferSize. Their buffer is
+// 10MB, which would otherwise be allocated on every load, and the TUI reloads
+// on each change to the beads file.
+var lineReaders = sync.Pool{
+	New: func() any { return bufio.NewReaderSize(nil, DefaultMaxBufferSize) },
+}
+
+// getLineReader returns a reader over r with a buffer of size bytes, and a
+// release func to call once parsing is done. Parsed issues never reference
+// the buffer: decoding copies each line.
+func getLineReader(r io.Reader, size int) (*bufio.Reader, func()) {
+	if size != DefaultMaxBufferSize {
+		return bufio.NewReaderSize(r, size), func() {}
+	}
+	reader := lineReaders.Get().(*bufio.Reader)
+	reader.Reset(r)
+	return reader, func() {
+		reader.Reset(nil)
+		lineReaders.Put(reader)
+	}
+}
+
+// lineSampleBytes is how much of a file estimateLines looks at.
+const lineSampleBytes = 64 * 1024
+
+// estimateLines estimates the number of lines in a file of size bytes from
+// the line lengths at its start, so the issue slice can be allocated once.
+// It peeks, so nothing is consumed from reader. Returns 0 if unknown.
+func estimateLines(reader *bufio.Reader, size int64) int {
+	const maxEstimate = 200_000
+	if size <= 0 {
+		return 0
+	}
+	sample, _ := reader.Peek(int(min(size, lineSampleBytes, int64(reader.Size()))))
+	if len(sample) == 0 {
+		return 0
+	}
+	lines := bytes.Count(sample, []byte{'\n'})
+	if int64(len(sample)) < size || lines == 0 {
+		// Extrapolate; a final line without a newline counts too
+		lines = int(size*int64(lines+1)/int64(len(sample))) + 1
+	} else if sample[len(sample)-1] != '\n' {
+		lines++
+	}
+	return min(lines, maxEstimate)
+}
+
+// interner makes repeated strings of one load share storage: statuses,
+// types, labels, assignees and dependency fields take a few distinct values
+// across thousands of issues.
+type interner map[string]string
+
+func (in interner) intern(s string) string {
+	if s == "" {
+		return s
+	}
+	if v, ok := in[s]; ok {
+		return v
+	}
+	in[s] = s
+	return s
+}
+
+// issue interns issue's repeated strings in place.
+func (in interner) issue(issue *model.Issue) {
+	issue.Status = model.Status(in.intern(string(issue.Status)))
+	issue.IssueType = model.IssueType(in.intern(string(issue.IssueType)))
+	issue.Assignee = in.intern(issue.Assignee)
+	issue.SourceRepo = in.intern(issue.SourceRepo)
+	for i, label := range issue.Labels {
+		issue.Labels[i] = in.intern(label)
+	}
+	for _, dep := range issue.Dependencies {
+		if dep == nil {
+			continue
+		}
+		dep.Type = model.DependencyType(in.intern(string(dep.Type)))
+		dep.DependsOnID = in.intern(dep.DependsOnID)
+		dep.CreatedBy = in.intern(dep.CreatedBy)
+	}
+}
@@ -0,0 +1,71 @@
+package loader
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"unsafe"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestEstimateLines(t *testing.T) {
+	line := strings.Repeat("x", 99) + "\n" // 100 bytes
+	tests := []struct {
+		name    string
+		content string
+		size    int64
+		want    int
+	}{
+		{"whole file sampled", strings.Repeat(line, 10), 1000, 10},
+		{"no trailing newline", strings.Repeat(line, 10) + "tail", 1004, 11},
+		{"extrapolated", strings.Repeat(line, 2000), 200_000, 2001},
+		{"empty", "", 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := bufio.NewReaderSize(strings.NewReader(tt.content), DefaultMaxBufferSize)
+			got := estimateLines(reader, tt.size)
+			if got < tt.want || got > tt.want+tt.want/10+1 {
+				t.Errorf("estimateLines = %d, want about %d", got, tt.want)
+			}
+			if rest, _ := reader.Peek(len(tt.content)); string(rest) != tt.content {
+				t.Error("estimateLines consumed input")
+			}
+		})
+	}
+}
+
+func TestLoadInternsRepeatedStrings(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "issues.jsonl")
+	content := `{"id":"a-1","title":"One","status":"open","issue_type":"task","labels":["backend"]}
+{"id":"a-2","title":"Two","status":"open","issue_type":"task","labels":["backend"],"dependencies":[{"issue_id":"a-2","depends_on_id":"a-1","type":"blocks"}]}
+`
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	for _, load := range []func(string) ([]model.Issue, error){
+		LoadIssuesFromFile,
+		func(p string) ([]model.Issue, error) {
+			pooled, err := LoadIssuesFromFilePooled(p)
+			return pooled.Issues, err
+		},
+	} {
+		issues, err := load(path)
+		if err != nil || len(issues) != 2 {
+			t.Fatalf("loaded %d issues: %v", len(issues), err)
+		}
+		same := func(a, b string) bool { return unsafe.StringData(a) == unsafe.StringData(b) }
+		if !same(issues[0].Labels[0], issues[1].Labels[0]) {
+			t.Error("label not interned")
+		}
+		if !same(string(issues[0].Status), string(issues[1].Status)) {
+			t.Error("status not interned")
+		}
+		if issues[1].Dependencies[0].DependsOnID != "a-1" {
+			t.Errorf("dependency = %+v", issues[1].Dependencies[0])
+		}
+	}
+}
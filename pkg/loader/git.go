@@ -142,6 +142,20 @@ func (g *GitLoader) ListRevisions(limit int) ([]RevisionInfo, error) {
 	return revisions, nil
 }
 
+// RevisionBefore returns the newest commit on HEAD made at or before t.
+// Unlike LoadAtDate it doesn't depend on the reflog, so it works in fresh
+// clones. Returns "" without an error when HEAD has no commit that old.
+func (g *GitLoader) RevisionBefore(t time.Time) (string, error) {
+	cmd := exec.Command("git", "rev-list", "-1", "--before="+t.Format(time.RFC3339), "HEAD")
+	cmd.Dir = g.repoPath
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("finding commit before %s: %w", t.Format(time.RFC3339), err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
 // RevisionInfo describes a git commit
 type RevisionInfo struct {
 	SHA       string    `json:"sha"`
@@ -184,6 +184,33 @@ func TestGitLoader_ResolveRevision_DateString(t *testing.T) {
 	}
 }
 
+func TestGitLoader_RevisionBefore(t *testing.T) {
+	repoDir, cleanup := setupTestGitRepo(t)
+	defer cleanup()
+
+	loader := NewGitLoader(repoDir)
+
+	dateStr := strings.TrimSpace(runGitOutput(t, repoDir, "log", "--format=%cI", "-n1", "HEAD~1"))
+	date, err := time.Parse(time.RFC3339, dateStr)
+	if err != nil {
+		t.Fatalf("parsing commit date %q: %v", dateStr, err)
+	}
+	expectedSHA := strings.TrimSpace(runGitOutput(t, repoDir, "rev-parse", "HEAD~1"))
+
+	sha, err := loader.RevisionBefore(date)
+	if err != nil {
+		t.Fatalf("RevisionBefore failed: %v", err)
+	}
+	if sha != expectedSHA {
+		t.Errorf("expected %s, got %s", expectedSHA, sha)
+	}
+
+	sha, err = loader.RevisionBefore(date.Add(-24 * time.Hour))
+	if err != nil || sha != "" {
+		t.Errorf("expected no commit before the first one, got %q, %v", sha, err)
+	}
+}
+
 func TestParseDateStringUsesLocalForDateOnly(t *testing.T) {
 	dateStr := "2025-01-02"
 	tm, ok := parseDateString(dateStr)
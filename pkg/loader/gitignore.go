@@ -1,5 +1,6 @@
 // Package loader provides issue loading and file discovery utilities.
-// This file handles automatic .gitignore management for the .bv directory.
+// This file handles automatic .gitignore management for the .bv directory
+// and bv's per-user files in the beads directory.
 package loader
 
 import (
@@ -44,6 +45,35 @@ func EnsureBVInGitignore(projectDir string) error {
 	return appendToGitignore(gitignorePath, ".bv/")
 }
 
+// EnsureInGitignore ensures that each pattern is listed in the .gitignore
+// file in dir, creating the file if needed, and appends the missing ones as
+// one block. bv uses it for per-user and runtime files it keeps next to the
+// beads data (starred issues, notes, locks), so they never end up in a
+// commit. A line naming the same path, with or without a leading or
+// trailing slash, counts as present.
+func EnsureInGitignore(dir string, patterns ...string) error {
+	gitignorePath := filepath.Join(dir, ".gitignore")
+	content, err := os.ReadFile(gitignorePath)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	present := make(map[string]bool)
+	for _, line := range strings.Split(string(content), "\n") {
+		present[strings.Trim(strings.TrimSpace(line), "/")] = true
+	}
+	var missing []string
+	for _, pattern := range patterns {
+		if want := strings.Trim(pattern, "/"); !present[want] {
+			present[want] = true
+			missing = append(missing, pattern)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	return appendToGitignore(gitignorePath, strings.Join(missing, "\n"))
+}
+
 // isBVInGitignore checks if .bv is already covered by the .gitignore file.
 // It returns true if any of these patterns are found:
 //   - .bv
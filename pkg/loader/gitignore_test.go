@@ -313,3 +313,43 @@ func TestEnsureBVInGitignore_UsesCurrentDir(t *testing.T) {
 		t.Errorf("expected .bv/ in .gitignore, got:\n%s", content)
 	}
 }
+
+func TestEnsureInGitignore(t *testing.T) {
+	dir := t.TempDir()
+	gitignorePath := filepath.Join(dir, ".gitignore")
+	if err := os.WriteFile(gitignorePath, []byte("*.db\n/.bv-notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := EnsureInGitignore(dir, ".bv-notes/"); err != nil {
+		t.Fatalf("EnsureInGitignore() error = %v", err)
+	}
+	if err := EnsureInGitignore(dir, ".bv-state.json"); err != nil {
+		t.Fatalf("EnsureInGitignore() error = %v", err)
+	}
+	if err := EnsureInGitignore(dir, ".bv-state.json"); err != nil {
+		t.Fatalf("EnsureInGitignore() second call error = %v", err)
+	}
+
+	content, err := os.ReadFile(gitignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(content), ".bv-notes"); got != 1 {
+		t.Errorf("existing /.bv-notes should count as present, got %d entries:\n%s", got, content)
+	}
+	if got := strings.Count(string(content), ".bv-state.json"); got != 1 {
+		t.Errorf("expected .bv-state.json once, got %d:\n%s", got, content)
+	}
+
+	if err := EnsureInGitignore(dir, ".bv.lock", ".bv-state.json", ".bv.rwlock.d/"); err != nil {
+		t.Fatalf("EnsureInGitignore() with several patterns error = %v", err)
+	}
+	content, err = os.ReadFile(gitignorePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasSuffix(string(content), "# bv (beads viewer) local config and caches\n.bv.lock\n.bv.rwlock.d/\n") {
+		t.Errorf("missing patterns should be appended as one block:\n%s", content)
+	}
+}
@@ -1,25 +1,41 @@
 package loader
 
 import (
-	"bufio"
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
-	json "github.com/goccy/go-json"
-
+	"github.com/Dicklesworthstone/beads_viewer/pkg/debug"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
+var logger = debug.For("loader")
+
 // BeadsDirEnvVar is the name of the environment variable for custom beads directory
 const BeadsDirEnvVar = "BEADS_DIR"
 
 // PreferredJSONLNames defines the priority order for looking up beads data files.
 var PreferredJSONLNames = []string{"issues.jsonl", "beads.jsonl", "beads.base.jsonl"}
 
+// ErrNoData matches (via errors.Is) load errors that mean there is no beads
+// data file at all, as opposed to one that exists but can't be read.
+var ErrNoData = errors.New("no beads data")
+
+// noDataError keeps a load error's message while matching ErrNoData.
+type noDataError struct {
+	msg string
+	err error
+}
+
+func (e *noDataError) Error() string        { return e.msg }
+func (e *noDataError) Unwrap() error        { return e.err }
+func (e *noDataError) Is(target error) bool { return target == ErrNoData }
+
 // GetBeadsDir returns the beads directory path, respecting BEADS_DIR env var.
 // If BEADS_DIR is set, it is used directly.
 // Otherwise, falls back to .beads in the given repoPath (or cwd if empty).
@@ -53,7 +69,11 @@ func FindJSONLPath(beadsDir string) (string, error) {
 func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (string, error) {
 	entries, err := os.ReadDir(beadsDir)
 	if err != nil {
-		return "", fmt.Errorf("failed to read beads directory: %w", err)
+		wrapped := fmt.Errorf("failed to read beads directory: %w", err)
+		if os.IsNotExist(err) {
+			return "", &noDataError{msg: wrapped.Error(), err: err}
+		}
+		return "", wrapped
 	}
 
 	var candidates []string
@@ -94,7 +114,7 @@ func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (stri
 	}
 
 	if len(candidates) == 0 {
-		return "", fmt.Errorf("no beads JSONL file found in %s", beadsDir)
+		return "", &noDataError{msg: fmt.Sprintf("no beads JSONL file found in %s", beadsDir)}
 	}
 
 	// Priority order for beads files per beads upstream:
@@ -132,6 +152,11 @@ func FindJSONLPathWithWarnings(beadsDir string, warnFunc func(msg string)) (stri
 // Respects BEADS_DIR environment variable, otherwise uses .beads in repoPath.
 // Automatically finds the correct JSONL file (issues.jsonl preferred, beads.jsonl fallback).
 func LoadIssues(repoPath string) ([]model.Issue, error) {
+	return LoadIssuesWithOptions(repoPath, ParseOptions{})
+}
+
+// LoadIssuesWithOptions is like LoadIssues with custom parse options.
+func LoadIssuesWithOptions(repoPath string, opts ParseOptions) ([]model.Issue, error) {
 	beadsDir, err := GetBeadsDir(repoPath)
 	if err != nil {
 		return nil, err
@@ -142,7 +167,7 @@ func LoadIssues(repoPath string) ([]model.Issue, error) {
 		return nil, err
 	}
 
-	return LoadIssuesFromFile(jsonlPath)
+	return LoadIssuesFromFileWithOptions(jsonlPath, opts)
 }
 
 // DefaultMaxBufferSize is the default buffer size for the scanner (10MB).
@@ -168,7 +193,7 @@ type ParseOptions struct {
 func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) ([]model.Issue, error) {
 	// Check if file exists
 	if _, err := os.Stat(path); os.IsNotExist(err) {
-		return nil, fmt.Errorf("no beads issues found at %s", path)
+		return nil, &noDataError{msg: fmt.Sprintf("no beads issues found at %s", path), err: err}
 	}
 
 	file, err := os.Open(path)
@@ -177,7 +202,14 @@ func LoadIssuesFromFileWithOptions(path string, opts ParseOptions) ([]model.Issu
 	}
 	defer file.Close()
 
-	return ParseIssuesWithOptions(file, opts)
+	start := time.Now()
+	issues, err := ParseIssuesWithOptions(file, opts)
+	if err != nil {
+		logger.Warn("parse failed", "path", path, "err", err)
+		return nil, err
+	}
+	logger.Debug("loaded issues", "path", path, "count", len(issues), "elapsed", time.Since(start))
+	return issues, nil
 }
 
 // LoadIssuesFromFileWithOptionsPooled reads issues from a file with pooling enabled.
@@ -230,24 +262,20 @@ func ParseIssuesWithOptionsPooled(r io.Reader, opts ParseOptions) (PooledIssues,
 }
 
 func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]model.Issue, []*model.Issue, error) {
+	// Determine buffer size
+	maxCapacity := opts.BufferSize
+	if maxCapacity <= 0 {
+		maxCapacity = DefaultMaxBufferSize
+	}
+
+	reader, release := getLineReader(r, maxCapacity)
+	defer release()
+
 	var issues []model.Issue
 	var poolRefs []*model.Issue
 	if f, ok := r.(*os.File); ok {
 		if info, err := f.Stat(); err == nil {
-			// Heuristic: average issue line ~2KB. Prefer conservative underestimation to
-			// avoid large over-allocations for big files.
-			const avgIssueBytes = 2 * 1024
-			const minCap = 64
-			const maxCap = 200_000
-
-			est := int(info.Size() / avgIssueBytes)
-			if est < minCap && info.Size() > 0 {
-				est = minCap
-			}
-			if est > maxCap {
-				est = maxCap
-			}
-			if est > 0 {
+			if est := estimateLines(reader, info.Size()); est > 0 {
 				issues = make([]model.Issue, 0, est)
 				if usePool {
 					poolRefs = make([]*model.Issue, 0, est)
@@ -255,14 +283,7 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 			}
 		}
 	}
-
-	// Determine buffer size
-	maxCapacity := opts.BufferSize
-	if maxCapacity <= 0 {
-		maxCapacity = DefaultMaxBufferSize
-	}
-
-	reader := bufio.NewReaderSize(r, maxCapacity)
+	strs := make(interner)
 
 	// Default warning handler prints to stderr (suppressed in robot mode).
 	warn := opts.WarningHandler
@@ -322,23 +343,14 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 
 		if usePool {
 			issue := GetIssue()
-			if err := json.Unmarshal(line, issue); err != nil {
-				PutIssue(issue)
-				// Skip malformed lines but warn
-				warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
-				continue
-			}
-
-			issue.Status = normalizeIssueStatus(issue.Status)
-
-			// Validate issue
-			if err := issue.Validate(); err != nil {
+			if perr := model.DecodeIssueLine(line, lineNum, issue); perr != nil {
 				PutIssue(issue)
-				// Skip invalid issues
-				warn(fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
+				// Skip malformed or invalid lines but warn
+				warn(fmt.Sprintf("skipping %s on line %d: %v", perr.Reason(), lineNum, perr.Err))
 				continue
 			}
 
+			strs.issue(issue)
 			if opts.IssueFilter != nil && !opts.IssueFilter(issue) {
 				PutIssue(issue)
 				continue
@@ -348,21 +360,13 @@ func parseIssuesWithOptions(r io.Reader, opts ParseOptions, usePool bool) ([]mod
 			poolRefs = append(poolRefs, issue)
 		} else {
 			var issue model.Issue
-			if err := json.Unmarshal(line, &issue); err != nil {
-				// Skip malformed lines but warn
-				warn(fmt.Sprintf("skipping malformed JSON on line %d: %v", lineNum, err))
-				continue
-			}
-
-			issue.Status = normalizeIssueStatus(issue.Status)
-
-			// Validate issue
-			if err := issue.Validate(); err != nil {
-				// Skip invalid issues
-				warn(fmt.Sprintf("skipping invalid issue on line %d: %v", lineNum, err))
+			if perr := model.DecodeIssueLine(line, lineNum, &issue); perr != nil {
+				// Skip malformed or invalid lines but warn
+				warn(fmt.Sprintf("skipping %s on line %d: %v", perr.Reason(), lineNum, perr.Err))
 				continue
 			}
 
+			strs.issue(&issue)
 			if opts.IssueFilter != nil && !opts.IssueFilter(&issue) {
 				continue
 			}
@@ -381,11 +385,3 @@ func stripBOM(b []byte) []byte {
 	}
 	return b
 }
-
-func normalizeIssueStatus(status model.Status) model.Status {
-	trimmed := strings.TrimSpace(string(status))
-	if trimmed == "" {
-		return status
-	}
-	return model.Status(strings.ToLower(trimmed))
-}
@@ -1,6 +1,7 @@
 package loader_test
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -25,6 +26,9 @@ func TestFindJSONLPath_NonExistentDirectory(t *testing.T) {
 	if !strings.Contains(err.Error(), "failed to read beads directory") {
 		t.Errorf("Expected 'failed to read beads directory' error, got: %v", err)
 	}
+	if !errors.Is(err, loader.ErrNoData) {
+		t.Errorf("Expected error to match loader.ErrNoData, got: %v", err)
+	}
 }
 
 func TestFindJSONLPath_EmptyDirectory(t *testing.T) {
@@ -36,6 +40,9 @@ func TestFindJSONLPath_EmptyDirectory(t *testing.T) {
 	if !strings.Contains(err.Error(), "no beads JSONL file found") {
 		t.Errorf("Expected 'no beads JSONL file found' error, got: %v", err)
 	}
+	if !errors.Is(err, loader.ErrNoData) {
+		t.Errorf("Expected error to match loader.ErrNoData, got: %v", err)
+	}
 }
 
 func TestFindJSONLPath_NoJSONLFiles(t *testing.T) {
@@ -588,6 +595,9 @@ func TestLoadIssuesFromFile_NonExistentFile(t *testing.T) {
 	if !strings.Contains(err.Error(), "no beads issues found") {
 		t.Errorf("Expected 'no beads issues found' error, got: %v", err)
 	}
+	if !errors.Is(err, loader.ErrNoData) {
+		t.Errorf("Expected error to match loader.ErrNoData, got: %v", err)
+	}
 }
 
 func TestLoadIssuesFromFile_EmptyFile(t *testing.T) {
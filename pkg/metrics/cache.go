@@ -102,20 +102,24 @@ type CacheStats struct {
 // Global cache metrics for various caches.
 var (
 	GraphCache   = newCacheMetric("graph_cache")
+	DiskCache    = newCacheMetric("analysis_disk_cache")
 	TriageCache  = newCacheMetric("triage_cache")
 	SearchCache  = newCacheMetric("search_cache")
 	MetricsCache = newCacheMetric("metrics_cache")
 	StyleCache   = newCacheMetric("style_cache")
+	RenderCache  = newCacheMetric("render_cache")
 )
 
 // AllCacheMetrics returns all registered cache metrics.
 func AllCacheMetrics() []*CacheMetric {
 	return []*CacheMetric{
 		GraphCache,
+		DiskCache,
 		TriageCache,
 		SearchCache,
 		MetricsCache,
 		StyleCache,
+		RenderCache,
 	}
 }
 
@@ -0,0 +1,154 @@
+package model
+
+import (
+	"fmt"
+	"sync"
+	"time"
+	"unsafe"
+
+	json "github.com/goccy/go-json"
+)
+
+// The JSONL load path decodes every issue of the file, so its allocations
+// set the cost of startup on large projects. goccy/go-json copies the bytes
+// of every value whose type has an UnmarshalJSON method before calling it;
+// time.Time has one, and a typical issue carries a handful of timestamps
+// (its own and one per dependency and comment). issueDecoder shadows those
+// fields with raw strings, which goccy decodes without copying, and parses
+// them afterwards.
+
+// rawTime is a timestamp as it appears in the JSON. unsetTime marks a field
+// that was absent or null.
+type rawTime string
+
+const unsetTime rawTime = "\x00"
+
+// issueDecoder decodes into Issue, except for the timestamp fields and the
+// dependencies and comments, whose timestamps it shadows in turn.
+type issueDecoder struct {
+	*Issue
+	CreatedAt    rawTime              `json:"created_at"`
+	UpdatedAt    rawTime              `json:"updated_at"`
+	DueDate      rawTime              `json:"due_date"`
+	ClosedAt     rawTime              `json:"closed_at"`
+	CompactedAt  rawTime              `json:"compacted_at"`
+	Dependencies []*dependencyDecoder `json:"dependencies"`
+	Comments     []*commentDecoder    `json:"comments"`
+}
+
+// dependencyDecoder holds its Dependency by value, so a dependency costs
+// one allocation; the decoded *Dependency points into it.
+type dependencyDecoder struct {
+	Dependency
+	CreatedAt rawTime `json:"created_at"`
+}
+
+type commentDecoder struct {
+	Comment
+	CreatedAt rawTime `json:"created_at"`
+}
+
+// issueDecoders recycles decoders, and with them the backing arrays of their
+// dependency and comment slices.
+var issueDecoders = sync.Pool{New: func() any { return new(issueDecoder) }}
+
+// reset clears d for reuse, dropping its references into the decoded issue
+// but keeping the capacity of its lists. The lists start non-nil so that a
+// nil list after decoding means the JSON had null.
+func (d *issueDecoder) reset() {
+	clear(d.Dependencies)
+	clear(d.Comments)
+	deps, comments := d.Dependencies[:0], d.Comments[:0]
+	if deps == nil {
+		deps = make([]*dependencyDecoder, 0, 8)
+	}
+	if comments == nil {
+		comments = make([]*commentDecoder, 0, 4)
+	}
+	*d = issueDecoder{
+		CreatedAt: unsetTime, UpdatedAt: unsetTime, DueDate: unsetTime,
+		ClosedAt: unsetTime, CompactedAt: unsetTime,
+		Dependencies: deps, Comments: comments,
+	}
+}
+
+// decodeIssue is json.Unmarshal(line, issue) with fewer allocations. As with
+// Unmarshal, fields absent from line keep their values in issue; an empty
+// dependency or comment list leaves issue's list empty but not necessarily
+// non-nil.
+func decodeIssue(line []byte, issue *Issue) error {
+	d := issueDecoders.Get().(*issueDecoder)
+	d.reset()
+	defer func() {
+		d.reset()
+		issueDecoders.Put(d)
+	}()
+	d.Issue = issue
+	if err := json.Unmarshal(line, d); err != nil {
+		return err
+	}
+
+	var err error
+	setTime := func(dst *time.Time, raw rawTime, field string) {
+		if err == nil && raw != unsetTime {
+			if perr := dst.UnmarshalText(rawBytes(raw)); perr != nil {
+				err = fmt.Errorf("%s: %w", field, perr)
+			}
+		}
+	}
+	setTimePtr := func(dst **time.Time, raw rawTime, field string) {
+		if raw == unsetTime {
+			return
+		}
+		if *dst == nil {
+			*dst = new(time.Time)
+		}
+		setTime(*dst, raw, field)
+	}
+	setTime(&issue.CreatedAt, d.CreatedAt, "created_at")
+	setTime(&issue.UpdatedAt, d.UpdatedAt, "updated_at")
+	setTimePtr(&issue.DueDate, d.DueDate, "due_date")
+	setTimePtr(&issue.ClosedAt, d.ClosedAt, "closed_at")
+	setTimePtr(&issue.CompactedAt, d.CompactedAt, "compacted_at")
+
+	// Unmarshal decodes null as nil
+	if d.Dependencies == nil {
+		issue.Dependencies = nil
+	} else {
+		deps := issue.Dependencies[:0]
+		for _, dd := range d.Dependencies {
+			if dd == nil {
+				deps = append(deps, nil)
+				continue
+			}
+			if dd.CreatedAt != "" {
+				setTime(&dd.Dependency.CreatedAt, dd.CreatedAt, "dependencies.created_at")
+			}
+			deps = append(deps, &dd.Dependency)
+		}
+		issue.Dependencies = deps
+	}
+	if d.Comments == nil {
+		issue.Comments = nil
+	} else {
+		comments := issue.Comments[:0]
+		for _, cd := range d.Comments {
+			if cd == nil {
+				comments = append(comments, nil)
+				continue
+			}
+			if cd.CreatedAt != "" {
+				setTime(&cd.Comment.CreatedAt, cd.CreatedAt, "comments.created_at")
+			}
+			comments = append(comments, &cd.Comment)
+		}
+		issue.Comments = comments
+	}
+	return err
+}
+
+// rawBytes returns the bytes of raw without copying. time.Time.UnmarshalText
+// only reads them.
+func rawBytes(raw rawTime) []byte {
+	return unsafe.Slice(unsafe.StringData(string(raw)), len(raw))
+}
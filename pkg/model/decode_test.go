@@ -0,0 +1,59 @@
+package model
+
+import (
+	"reflect"
+	"testing"
+
+	json "github.com/goccy/go-json"
+)
+
+func TestDecodeIssueMatchesUnmarshal(t *testing.T) {
+	lines := []string{
+		`{"id":"a-1","title":"T","status":"open","issue_type":"task","priority":2,"created_at":"2025-01-02T03:04:05Z","updated_at":"2025-01-03T03:04:05.123456789+02:00"}`,
+		`{"id":"a-2","title":"T","status":"closed","issue_type":"bug","closed_at":"2025-02-01T00:00:00Z","due_date":null,"labels":["x","y"],"estimated_minutes":30,"external_ref":"gh-1"}`,
+		`{"id":"a-3","title":"T","status":"open","issue_type":"task","dependencies":[{"issue_id":"a-3","depends_on_id":"a-1","type":"blocks","created_at":"2025-01-05T00:00:00Z","created_by":"me"},{"issue_id":"a-3","depends_on_id":"a-2","type":"related"}]}`,
+		`{"id":"a-4","title":"T","status":"open","issue_type":"task","comments":[{"id":7,"issue_id":"a-4","author":"ann","text":"hi","created_at":"2025-01-06T10:00:00Z"}],"dependencies":null}`,
+		`{"id":"a-5","title":"T","status":"open","issue_type":"task","compacted_at":"2025-03-01T00:00:00Z","compaction_level":1}`,
+	}
+	for _, line := range lines {
+		var want, got Issue
+		if err := json.Unmarshal([]byte(line), &want); err != nil {
+			t.Fatalf("unmarshal %s: %v", line, err)
+		}
+		if err := decodeIssue([]byte(line), &got); err != nil {
+			t.Fatalf("decodeIssue %s: %v", line, err)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("decodeIssue differs from Unmarshal for %s:\n got %+v\nwant %+v", line, got, want)
+		}
+	}
+}
+
+func TestDecodeIssueRejectsBadTimestamps(t *testing.T) {
+	for _, line := range []string{
+		`{"id":"a-1","title":"T","status":"open","issue_type":"task","created_at":"yesterday"}`,
+		`{"id":"a-1","title":"T","status":"open","issue_type":"task","closed_at":"2025-13-01T00:00:00Z"}`,
+		`{"id":"a-1","title":"T","status":"open","issue_type":"task","dependencies":[{"depends_on_id":"a-2","created_at":"soon"}]}`,
+		`{"id":"a-1","title":"T","status":"open","issue_type":"task","created_at":42}`,
+	} {
+		var issue Issue
+		if err := decodeIssue([]byte(line), &issue); err == nil {
+			t.Errorf("accepted %s", line)
+		}
+	}
+}
+
+func TestDecodeIssueLineAllocations(t *testing.T) {
+	line := []byte(`{"id":"a-3","title":"T","status":"open","issue_type":"task","created_at":"2025-01-02T03:04:05Z","updated_at":"2025-01-03T03:04:05Z","closed_at":"2025-01-04T00:00:00Z","labels":["x"],"dependencies":[{"issue_id":"a-3","depends_on_id":"a-1","type":"blocks","created_at":"2025-01-05T00:00:00Z"},{"issue_id":"a-3","depends_on_id":"a-2","type":"blocks","created_at":"2025-01-05T00:00:00Z"}]}`)
+	before := testing.AllocsPerRun(100, func() {
+		var issue Issue
+		_ = json.Unmarshal(line, &issue)
+	})
+	after := testing.AllocsPerRun(100, func() {
+		var issue Issue
+		_ = DecodeIssueLine(line, 1, &issue)
+	})
+	if after > before*0.7 {
+		t.Errorf("DecodeIssueLine: %.0f allocs, plain Unmarshal %.0f; want at least 30%% fewer", after, before)
+	}
+}
@@ -0,0 +1,72 @@
+package model_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// FuzzParseIssues exercises the pure in-memory parser.
+//
+// Run with: go test -fuzz=FuzzParseIssues -fuzztime=10m ./pkg/model/
+//
+// Beyond never panicking, it checks that every returned issue is valid, that
+// errors are reported in line order, and that the result matches the
+// streaming loader on the same bytes.
+func FuzzParseIssues(f *testing.F) {
+	seeds := []string{
+		`{"id":"bv-1","title":"Test","status":"open","issue_type":"task","priority":1}`,
+		"",
+		"   \t  ",
+		`{"id":"bv-3","title":"Incomplete`,
+		`{"id":"bv-6","title":"Test","status":"invalid_status","issue_type":"task"}`,
+		`{"title":"No ID","status":"open","issue_type":"task"}`,
+		"\xef\xbb\xbf" + `{"id":"bv-12","title":"BOM Test","status":"open","issue_type":"task"}`,
+		`{"id":"bv-15","title":"Test","status":"open","issue_type":"task","dependencies":[{"issue_id":"bv-15","depends_on_id":"bv-14","type":"blocks"}]}`,
+		`{"id":"bv-17","title":"Test","status":"open","issue_type":"task","priority":999999999999999999999999999999}`,
+		`[{"id":"bv-20"}]`,
+		`null`,
+		"\x00\x01\x02\x03",
+		`{"id":"bv-21"}{"id":"bv-22"}`,
+		`{"id":"a","title":"A","status":"open","issue_type":"task"}` + "\r\n" + `{"id":"b","title":"B","status":"CLOSED","issue_type":"bug"}`,
+	}
+	for _, s := range seeds {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		issues, errs := model.ParseIssues(data)
+
+		for i := range issues {
+			if err := issues[i].Validate(); err != nil {
+				t.Fatalf("returned invalid issue %q: %v", issues[i].ID, err)
+			}
+		}
+		for i := 1; i < len(errs); i++ {
+			if errs[i].Line <= errs[i-1].Line {
+				t.Fatalf("errors out of order: %v", errs)
+			}
+		}
+		if lines := bytes.Count(data, []byte("\n")) + 1; len(issues)+len(errs) > lines {
+			t.Fatalf("%d issues + %d errors from %d lines", len(issues), len(errs), lines)
+		}
+
+		streamed, err := loader.ParseIssuesWithOptions(bytes.NewReader(data), loader.ParseOptions{
+			WarningHandler: func(string) {},
+			BufferSize:     len(data) + 1,
+		})
+		if err != nil {
+			return
+		}
+		if len(streamed) != len(issues) {
+			t.Fatalf("loader parsed %d issues, ParseIssues %d", len(streamed), len(issues))
+		}
+		for i := range issues {
+			if streamed[i].ID != issues[i].ID || streamed[i].Status != issues[i].Status {
+				t.Fatalf("issue %d differs: loader %q/%q vs %q/%q", i, streamed[i].ID, streamed[i].Status, issues[i].ID, issues[i].Status)
+			}
+		}
+	})
+}
@@ -0,0 +1,110 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	json "github.com/goccy/go-json"
+)
+
+// ParseErrorKind classifies a line that could not become an issue.
+type ParseErrorKind string
+
+const (
+	// ParseErrorMalformed means the line is not valid JSON for an Issue.
+	ParseErrorMalformed ParseErrorKind = "malformed_json"
+	// ParseErrorInvalid means the JSON decoded but failed Issue.Validate.
+	ParseErrorInvalid ParseErrorKind = "invalid_issue"
+)
+
+// ParseError describes one skipped line of a beads JSONL file.
+type ParseError struct {
+	Line int            `json:"line"` // 1-based
+	Kind ParseErrorKind `json:"kind"`
+	Err  error          `json:"-"`
+}
+
+// Reason is the human description of Kind ("malformed JSON", "invalid issue").
+func (e ParseError) Reason() string {
+	if e.Kind == ParseErrorInvalid {
+		return "invalid issue"
+	}
+	return "malformed JSON"
+}
+
+func (e ParseError) Error() string {
+	return fmt.Sprintf("line %d: %s: %v", e.Line, e.Reason(), e.Err)
+}
+
+func (e ParseError) Unwrap() error {
+	return e.Err
+}
+
+// MarshalJSON includes the underlying error message.
+func (e ParseError) MarshalJSON() ([]byte, error) {
+	type alias ParseError
+	return json.Marshal(struct {
+		alias
+		Message string `json:"message"`
+	}{alias: alias(e), Message: fmt.Sprint(e.Err)})
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// ParseIssues parses beads JSONL content held in memory. It does no IO and
+// never fails as a whole: blank lines are skipped, a leading UTF-8 BOM is
+// ignored, and every line that cannot become a valid issue is reported in
+// the returned errors (in line order) instead.
+func ParseIssues(data []byte) ([]Issue, []ParseError) {
+	data = bytes.TrimPrefix(data, utf8BOM)
+
+	var issues []Issue
+	var errs []ParseError
+	lineNum := 0
+	for len(data) > 0 {
+		lineNum++
+		line := data
+		if i := bytes.IndexByte(data, '\n'); i >= 0 {
+			line, data = data[:i], data[i+1:]
+		} else {
+			data = nil
+		}
+		line = bytes.TrimRight(line, "\r")
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+
+		var issue Issue
+		if perr := DecodeIssueLine(line, lineNum, &issue); perr != nil {
+			errs = append(errs, *perr)
+			continue
+		}
+		issues = append(issues, issue)
+	}
+	return issues, errs
+}
+
+// DecodeIssueLine decodes one JSONL line into issue, normalizes its status,
+// and validates it. issue may be a reused (pooled) value. It returns nil on
+// success.
+func DecodeIssueLine(line []byte, lineNum int, issue *Issue) *ParseError {
+	if err := decodeIssue(line, issue); err != nil {
+		return &ParseError{Line: lineNum, Kind: ParseErrorMalformed, Err: err}
+	}
+	issue.Status = NormalizeStatus(issue.Status)
+	if err := issue.Validate(); err != nil {
+		return &ParseError{Line: lineNum, Kind: ParseErrorInvalid, Err: err}
+	}
+	return nil
+}
+
+// NormalizeStatus trims and lowercases a status ("  Open " -> "open").
+// An all-blank status is returned unchanged so validation can reject it.
+func NormalizeStatus(status Status) Status {
+	trimmed := strings.TrimSpace(string(status))
+	if trimmed == "" {
+		return status
+	}
+	return Status(strings.ToLower(trimmed))
+}
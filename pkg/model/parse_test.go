@@ -0,0 +1,69 @@
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseIssues(t *testing.T) {
+	data := "\xef\xbb\xbf" + `{"id":"a-1","title":"One","status":" Open ","issue_type":"task"}` + "\r\n" +
+		"\n" +
+		"   \t\n" +
+		`{"id":"a-2","title":"Broken` + "\n" +
+		`{"id":"","title":"No ID","status":"open","issue_type":"task"}` + "\n" +
+		`{"id":"a-3","title":"Three","status":"closed","issue_type":"bug"}`
+
+	issues, errs := ParseIssues([]byte(data))
+	if len(issues) != 2 || issues[0].ID != "a-1" || issues[1].ID != "a-3" {
+		t.Fatalf("unexpected issues: %+v", issues)
+	}
+	if issues[0].Status != StatusOpen {
+		t.Errorf("status not normalized: %q", issues[0].Status)
+	}
+	if len(errs) != 2 {
+		t.Fatalf("expected 2 parse errors, got %v", errs)
+	}
+	if errs[0].Line != 4 || errs[0].Kind != ParseErrorMalformed {
+		t.Errorf("errs[0] = %+v, want line 4 malformed", errs[0])
+	}
+	if errs[1].Line != 5 || errs[1].Kind != ParseErrorInvalid {
+		t.Errorf("errs[1] = %+v, want line 5 invalid", errs[1])
+	}
+	if !strings.HasPrefix(errs[1].Error(), "line 5: invalid issue: ") {
+		t.Errorf("Error() = %q", errs[1].Error())
+	}
+	var err error = errs[0]
+	var perr ParseError
+	if !errors.As(err, &perr) || errors.Unwrap(err) == nil {
+		t.Error("ParseError should support errors.As and Unwrap")
+	}
+}
+
+func TestParseIssuesEmpty(t *testing.T) {
+	for _, in := range []string{"", "\n\n", "\xef\xbb\xbf"} {
+		issues, errs := ParseIssues([]byte(in))
+		if len(issues) != 0 || len(errs) != 0 {
+			t.Errorf("ParseIssues(%q) = %v, %v; want nothing", in, issues, errs)
+		}
+	}
+}
+
+func TestParseErrorJSON(t *testing.T) {
+	_, errs := ParseIssues([]byte(`not json`))
+	if len(errs) != 1 {
+		t.Fatalf("expected one error, got %v", errs)
+	}
+	data, err := json.Marshal(errs)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var decoded []map[string]any
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	if decoded[0]["line"] != float64(1) || decoded[0]["kind"] != "malformed_json" || decoded[0]["message"] == "" {
+		t.Errorf("unexpected JSON: %s", data)
+	}
+}
@@ -0,0 +1,237 @@
+package model
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Redactor replaces the free text and people's names in issues with stable
+// hashes, so a dataset can be shared for a bug report without leaking what
+// the project is about. IDs, statuses, priorities, types, labels, dates and
+// dependencies are kept, so every analysis sees the same graph. The same
+// input always hashes the same way under one salt; a salt keeps short,
+// guessable values such as names from being matched against a dictionary.
+type Redactor struct {
+	Salt string
+}
+
+func (r Redactor) hash(kind, s string) string {
+	sum := sha256.Sum256([]byte(r.Salt + "\x00" + kind + "\x00" + s))
+	return hex.EncodeToString(sum[:])
+}
+
+// Text redacts a piece of free text. Empty text stays empty.
+func (r Redactor) Text(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "redacted-" + r.hash("text", s)[:12]
+}
+
+// Person redacts a name. Empty names stay empty, and the same person gets
+// the same pseudonym everywhere (assignee, comment author, ...).
+func (r Redactor) Person(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "user-" + r.hash("person", s)[:8]
+}
+
+// Path redacts a file path. Empty paths stay empty.
+func (r Redactor) Path(p string) string {
+	if p == "" {
+		return ""
+	}
+	return "path-" + r.hash("path", p)[:12]
+}
+
+// Issue returns a redacted copy of issue.
+func (r Redactor) Issue(issue Issue) Issue {
+	c := issue.Clone()
+	c.Title = r.Text(c.Title)
+	c.Description = r.Text(c.Description)
+	c.Design = r.Text(c.Design)
+	c.AcceptanceCriteria = r.Text(c.AcceptanceCriteria)
+	c.Notes = r.Text(c.Notes)
+	c.Assignee = r.Person(c.Assignee)
+	if c.ExternalRef != nil {
+		ref := r.Text(*c.ExternalRef)
+		c.ExternalRef = &ref
+	}
+	for _, dep := range c.Dependencies {
+		if dep != nil {
+			dep.CreatedBy = r.Person(dep.CreatedBy)
+		}
+	}
+	for _, comment := range c.Comments {
+		if comment != nil {
+			comment.Author = r.Person(comment.Author)
+			comment.Text = r.Text(comment.Text)
+		}
+	}
+	// Content-derived; recomputed from the redacted fields when needed
+	c.ContentHash = ""
+	return c
+}
+
+// Issues returns redacted copies of issues.
+func (r Redactor) Issues(issues []Issue) []Issue {
+	out := make([]Issue, len(issues))
+	for i := range issues {
+		out[i] = r.Issue(issues[i])
+	}
+	return out
+}
+
+// minScrubLen is the shortest value a Scrubber replaces; shorter ones
+// (initials, "wip") would match ordinary words.
+const minScrubLen = 4
+
+// Scrubber replaces known sensitive values wherever they appear in output
+// text. It covers data that reaches an output without passing through
+// Redactor.Issue, such as git authors, commit messages and file paths. A
+// value only matches as a whole run of words, so a name never eats into a
+// longer word, and JSON object keys are left alone.
+type Scrubber struct {
+	r       Redactor
+	byToken map[string][]scrubRule // keyed by the value's first word
+	sorted  bool
+}
+
+type scrubRule struct {
+	find    string
+	replace string
+}
+
+// NewScrubber returns a Scrubber that redacts with r.
+func (r Redactor) NewScrubber() *Scrubber {
+	return &Scrubber{r: r, byToken: make(map[string][]scrubRule)}
+}
+
+// Person registers a name or email to be replaced like Redactor.Person.
+func (s *Scrubber) Person(name string) {
+	s.add(name, s.r.Person(name))
+}
+
+// Text registers free text to be replaced like Redactor.Text. Each line of
+// multi-line text is registered on its own too, for outputs that print it
+// line by line.
+func (s *Scrubber) Text(text string) {
+	s.add(text, s.r.Text(text))
+	if strings.Contains(text, "\n") {
+		for _, line := range strings.Split(text, "\n") {
+			s.add(line, s.r.Text(line))
+		}
+	}
+}
+
+// Path registers a file path to be replaced like Redactor.Path.
+func (s *Scrubber) Path(p string) {
+	s.add(p, s.r.Path(p))
+}
+
+// add registers value and its JSON-escaped forms.
+func (s *Scrubber) add(value, replace string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	forms := []string{value}
+	for _, escapeHTML := range []bool{true, false} {
+		var buf bytes.Buffer
+		enc := json.NewEncoder(&buf)
+		enc.SetEscapeHTML(escapeHTML)
+		if err := enc.Encode(value); err == nil {
+			forms = append(forms, strings.TrimSuffix(strings.TrimSpace(buf.String()), "\"")[1:])
+		}
+	}
+	for _, form := range forms {
+		// Match on the words only: surrounding punctuation is kept, and
+		// matches are anchored at word boundaries
+		find := strings.TrimFunc(form, func(r rune) bool { return !isScrubWordRune(r) })
+		if len(find) < minScrubLen {
+			continue
+		}
+		first := find[:scrubWordEnd(find, 0)]
+		dup := false
+		for _, rule := range s.byToken[first] {
+			if rule.find == find {
+				dup = true
+				break
+			}
+		}
+		if !dup {
+			s.byToken[first] = append(s.byToken[first], scrubRule{find: find, replace: replace})
+			s.sorted = false
+		}
+	}
+}
+
+// Line returns line with every registered value replaced.
+func (s *Scrubber) Line(line string) string {
+	if len(s.byToken) == 0 {
+		return line
+	}
+	if !s.sorted {
+		for _, rules := range s.byToken {
+			// Longest first, so a full commit message wins over its subject
+			sort.SliceStable(rules, func(i, j int) bool { return len(rules[i].find) > len(rules[j].find) })
+		}
+		s.sorted = true
+	}
+	var out strings.Builder
+	i := 0
+	for i < len(line) {
+		r, size := utf8.DecodeRuneInString(line[i:])
+		if !isScrubWordRune(r) {
+			out.WriteString(line[i : i+size])
+			i += size
+			continue
+		}
+		end := scrubWordEnd(line, i)
+		matched := false
+		for _, rule := range s.byToken[line[i:end]] {
+			if !strings.HasPrefix(line[i:], rule.find) {
+				continue
+			}
+			after := i + len(rule.find)
+			if next, _ := utf8.DecodeRuneInString(line[after:]); after < len(line) && isScrubWordRune(next) {
+				continue
+			}
+			if i > 0 && line[i-1] == '"' && strings.HasPrefix(line[after:], "\":") {
+				continue // a JSON object key
+			}
+			out.WriteString(rule.replace)
+			i = after
+			matched = true
+			break
+		}
+		if !matched {
+			out.WriteString(line[i:end])
+			i = end
+		}
+	}
+	return out.String()
+}
+
+func isScrubWordRune(r rune) bool {
+	return r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// scrubWordEnd returns the end of the word starting at i in s.
+func scrubWordEnd(s string, i int) int {
+	for i < len(s) {
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if !isScrubWordRune(r) {
+			break
+		}
+		i += size
+	}
+	return i
+}
@@ -0,0 +1,68 @@
+package model
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRedactor(t *testing.T) {
+	ref := "JIRA-42"
+	issue := Issue{
+		ID: "a-1", Title: "Acquire Initech", Description: "Secret", Status: StatusOpen, Priority: 1,
+		IssueType: TypeTask, Assignee: "alice", Labels: []string{"finance"}, ExternalRef: &ref,
+		Dependencies: []*Dependency{{IssueID: "a-1", DependsOnID: "a-0", Type: DepBlocks, CreatedBy: "bob"}},
+		Comments:     []*Comment{{Author: "alice", Text: "Keep it quiet"}},
+	}
+	r := Redactor{Salt: "s1"}
+	got := r.Issue(issue)
+
+	if got.ID != "a-1" || got.Status != StatusOpen || got.Priority != 1 || got.Labels[0] != "finance" || got.Dependencies[0].DependsOnID != "a-0" {
+		t.Errorf("structure changed: %+v", got)
+	}
+	if !strings.HasPrefix(got.Title, "redacted-") || got.Title == got.Description || *got.ExternalRef == ref || got.Comments[0].Text == "Keep it quiet" {
+		t.Errorf("text not redacted: %+v", got)
+	}
+	if got.Design != "" {
+		t.Errorf("empty text should stay empty, got %q", got.Design)
+	}
+	if !strings.HasPrefix(got.Assignee, "user-") || got.Comments[0].Author != got.Assignee || got.Dependencies[0].CreatedBy == got.Assignee {
+		t.Errorf("people not pseudonymized consistently: assignee=%s author=%s created_by=%s", got.Assignee, got.Comments[0].Author, got.Dependencies[0].CreatedBy)
+	}
+	if issue.Title != "Acquire Initech" || issue.Comments[0].Author != "alice" {
+		t.Error("Redactor modified its input")
+	}
+
+	if again := r.Issue(issue); again.Title != got.Title {
+		t.Error("redaction is not stable")
+	}
+	if other := (Redactor{Salt: "s2"}).Issue(issue); other.Title == got.Title || other.Assignee == got.Assignee {
+		t.Error("salt has no effect")
+	}
+}
+
+func TestScrubber(t *testing.T) {
+	r := Redactor{Salt: "s1"}
+	s := r.NewScrubber()
+	s.Person("Alice Secret")
+	s.Text("update")
+	s.Text(`Wire the "Zanzibar" ledger` + "\n\nLonger body line")
+	s.Path("pkg/ledger/zanzibar.go")
+
+	tests := []struct {
+		name, in, want string
+	}{
+		{"name in prose", "In review; suggest Alice Secret.", "In review; suggest " + r.Person("Alice Secret") + "."},
+		{"word boundary", "Alice Secretary", "Alice Secretary"},
+		{"json value", `{"author":"Alice Secret"}`, `{"author":"` + r.Person("Alice Secret") + `"}`},
+		{"json key kept", `{"update":"update"}`, `{"update":"` + r.Text("update") + `"}`},
+		{"not inside a word", `"updated_at"`, `"updated_at"`},
+		{"escaped subject", `{"subject":"Wire the \"Zanzibar\" ledger"}`, `{"subject":"` + r.Text(`Wire the "Zanzibar" ledger`) + `"}`},
+		{"body line", "  Longer body line", "  " + r.Text("Longer body line")},
+		{"path", "touched pkg/ledger/zanzibar.go", "touched " + r.Path("pkg/ledger/zanzibar.go")},
+	}
+	for _, tt := range tests {
+		if got := s.Line(tt.in); got != tt.want {
+			t.Errorf("%s: Line(%q) = %q, want %q", tt.name, tt.in, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package model
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	json "github.com/goccy/go-json"
+)
+
+// CanonicalIssue returns a copy of issue in canonical form: status
+// normalized, timestamps in UTC, labels sorted and deduplicated, nil
+// dependencies and comments dropped, dependencies sorted by target and
+// type, comments by time and ID, and missing issue IDs on dependencies and
+// comments filled in. The input is not modified.
+func CanonicalIssue(issue Issue) Issue {
+	c := issue.Clone()
+	c.Status = NormalizeStatus(c.Status)
+
+	c.CreatedAt = c.CreatedAt.UTC()
+	c.UpdatedAt = c.UpdatedAt.UTC()
+	for _, t := range []*time.Time{c.DueDate, c.ClosedAt, c.CompactedAt} {
+		if t != nil {
+			*t = t.UTC()
+		}
+	}
+
+	if len(c.Labels) > 0 {
+		sort.Strings(c.Labels)
+		labels := c.Labels[:0]
+		for i, l := range c.Labels {
+			if i == 0 || l != c.Labels[i-1] {
+				labels = append(labels, l)
+			}
+		}
+		c.Labels = labels
+	}
+
+	deps := c.Dependencies[:0]
+	for _, dep := range c.Dependencies {
+		if dep == nil {
+			continue
+		}
+		if dep.IssueID == "" {
+			dep.IssueID = c.ID
+		}
+		dep.CreatedAt = dep.CreatedAt.UTC()
+		deps = append(deps, dep)
+	}
+	sort.SliceStable(deps, func(i, j int) bool {
+		if deps[i].DependsOnID != deps[j].DependsOnID {
+			return deps[i].DependsOnID < deps[j].DependsOnID
+		}
+		return deps[i].Type < deps[j].Type
+	})
+	c.Dependencies = nilIfEmpty(deps)
+
+	comments := c.Comments[:0]
+	for _, comment := range c.Comments {
+		if comment == nil {
+			continue
+		}
+		if comment.IssueID == "" {
+			comment.IssueID = c.ID
+		}
+		comment.CreatedAt = comment.CreatedAt.UTC()
+		comments = append(comments, comment)
+	}
+	sort.SliceStable(comments, func(i, j int) bool {
+		if !comments[i].CreatedAt.Equal(comments[j].CreatedAt) {
+			return comments[i].CreatedAt.Before(comments[j].CreatedAt)
+		}
+		return comments[i].ID < comments[j].ID
+	})
+	c.Comments = nilIfEmpty(comments)
+	return c
+}
+
+func nilIfEmpty[T any](s []T) []T {
+	if len(s) == 0 {
+		return nil
+	}
+	return s
+}
+
+// WriteIssues writes issues to w as canonical beads JSONL: one issue per
+// line in ID order, each in CanonicalIssue form with fields in declaration
+// order, so the same issue set always produces the same bytes and
+// ParseIssues reads it back unchanged. Every issue is validated first; an
+// invalid issue or a duplicate ID fails the write before anything is
+// written.
+func WriteIssues(w io.Writer, issues []Issue) error {
+	data, err := MarshalIssues(issues)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// MarshalIssues returns the canonical beads JSONL for issues; see
+// WriteIssues.
+func MarshalIssues(issues []Issue) ([]byte, error) {
+	canonical := make([]Issue, len(issues))
+	seen := make(map[string]bool, len(issues))
+	for i := range issues {
+		c := CanonicalIssue(issues[i])
+		if err := c.Validate(); err != nil {
+			return nil, fmt.Errorf("issue %q: %w", c.ID, err)
+		}
+		if seen[c.ID] {
+			return nil, fmt.Errorf("duplicate issue ID %q", c.ID)
+		}
+		seen[c.ID] = true
+		canonical[i] = c
+	}
+	sort.Slice(canonical, func(i, j int) bool { return canonical[i].ID < canonical[j].ID })
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for i := range canonical {
+		// Encode terminates each value with a newline
+		if err := enc.Encode(&canonical[i]); err != nil {
+			return nil, fmt.Errorf("encoding issue %q: %w", canonical[i].ID, err)
+		}
+	}
+	return buf.Bytes(), nil
+}
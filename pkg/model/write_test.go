@@ -0,0 +1,95 @@
+package model
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestMarshalIssuesRoundTrip(t *testing.T) {
+	est := 90
+	pst := time.FixedZone("PST", -8*3600)
+	closed := time.Date(2025, 3, 2, 10, 0, 0, 0, pst)
+	issues := []Issue{
+		{
+			ID: "b-2", Title: "Second <b>", Status: " In_Progress ", IssueType: TypeBug, Priority: 1,
+			EstimatedMinutes: &est,
+			CreatedAt:        time.Date(2025, 3, 1, 9, 0, 0, 0, pst),
+			UpdatedAt:        time.Date(2025, 3, 1, 9, 30, 0, 0, pst),
+			Labels:           []string{"ui", "api", "ui"},
+			Dependencies: []*Dependency{
+				{DependsOnID: "b-1", Type: DepRelated},
+				nil,
+				{DependsOnID: "a-1", Type: DepBlocks},
+			},
+			Comments: []*Comment{
+				{ID: 2, Text: "later", CreatedAt: time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)},
+				{ID: 1, Text: "earlier", CreatedAt: time.Date(2025, 3, 1, 11, 0, 0, 0, time.UTC)},
+			},
+		},
+		{ID: "a-1", Title: "First", Status: StatusClosed, IssueType: TypeTask, ClosedAt: &closed},
+	}
+	original := issues[0].Clone()
+
+	data, err := MarshalIssues(issues)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(issues[0], original) {
+		t.Error("MarshalIssues modified its input")
+	}
+
+	lines := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(lines) != 2 || !strings.HasPrefix(lines[0], `{"id":"a-1",`) || !strings.HasPrefix(lines[1], `{"id":"b-2",`) {
+		t.Fatalf("expected one line per issue in ID order:\n%s", data)
+	}
+	for _, want := range []string{
+		`"title":"Second <b>"`,
+		`"status":"in_progress"`,
+		`"created_at":"2025-03-01T17:00:00Z"`,
+		`"labels":["api","ui"]`,
+		`"closed_at":"2025-03-02T18:00:00Z"`,
+	} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("missing %s in:\n%s", want, data)
+		}
+	}
+
+	parsed, errs := ParseIssues(data)
+	if len(errs) != 0 {
+		t.Fatalf("parse errors: %v", errs)
+	}
+	b := parsed[1]
+	if b.Dependencies[0].DependsOnID != "a-1" || b.Dependencies[1].DependsOnID != "b-1" || b.Dependencies[0].IssueID != "b-2" {
+		t.Errorf("dependencies not canonical: %+v %+v", b.Dependencies[0], b.Dependencies[1])
+	}
+	if b.Comments[0].Text != "earlier" || b.Comments[1].IssueID != "b-2" {
+		t.Errorf("comments not canonical: %+v %+v", b.Comments[0], b.Comments[1])
+	}
+
+	// Writing what was read back yields the same bytes
+	var buf bytes.Buffer
+	if err := WriteIssues(&buf, parsed); err != nil {
+		t.Fatal(err)
+	}
+	if buf.String() != string(data) {
+		t.Errorf("round trip changed the output:\n%s\nvs\n%s", data, buf.String())
+	}
+}
+
+func TestMarshalIssuesRejectsInvalid(t *testing.T) {
+	valid := Issue{ID: "a-1", Title: "One", Status: StatusOpen, IssueType: TypeTask}
+	if _, err := MarshalIssues([]Issue{valid, {ID: "a-2", Status: StatusOpen, IssueType: TypeTask}}); err == nil || !strings.Contains(err.Error(), `"a-2"`) {
+		t.Errorf("expected a validation error naming a-2, got %v", err)
+	}
+	if _, err := MarshalIssues([]Issue{valid, valid}); err == nil {
+		t.Error("expected an error for duplicate IDs")
+	}
+
+	var buf bytes.Buffer
+	if err := WriteIssues(&buf, []Issue{valid, {ID: "bad"}}); err == nil || buf.Len() != 0 {
+		t.Errorf("invalid input must not be partially written: err=%v wrote %q", err, buf.String())
+	}
+}
@@ -0,0 +1,110 @@
+// Package notes keeps a user's local notes on issues: a markdown scratchpad
+// per issue in .beads/.bv-notes/, one file each. The first note adds the
+// directory to .beads/.gitignore and bd never reads it, so notes stay on the
+// checkout they were written on and never change the beads data.
+package notes
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// DirName is the notes directory's name in the beads directory.
+const DirName = ".bv-notes"
+
+// ext is the notes file extension: notes are markdown.
+const ext = ".md"
+
+// Dir returns the notes directory for a beads directory.
+func Dir(beadsDir string) string {
+	return filepath.Join(beadsDir, DirName)
+}
+
+// Path returns the notes file for issueID. IDs are escaped so that any ID
+// is a single file name.
+func Path(dir, issueID string) string {
+	return filepath.Join(dir, url.PathEscape(issueID)+ext)
+}
+
+// Load returns the notes on issueID in dir, "" if there are none.
+func Load(dir, issueID string) (string, error) {
+	data, err := os.ReadFile(Path(dir, issueID))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", nil
+		}
+		return "", fmt.Errorf("reading notes: %w", err)
+	}
+	return string(data), nil
+}
+
+// LoadAll returns every issue's notes in dir, by issue ID. A missing
+// directory has none.
+func LoadAll(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return map[string]string{}, nil
+		}
+		return nil, fmt.Errorf("reading notes: %w", err)
+	}
+	all := make(map[string]string, len(entries))
+	for _, e := range entries {
+		name := e.Name()
+		if e.IsDir() || !strings.HasSuffix(name, ext) || strings.HasPrefix(name, ".") {
+			continue
+		}
+		id, err := url.PathUnescape(strings.TrimSuffix(name, ext))
+		if err != nil {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, fmt.Errorf("reading notes: %w", err)
+		}
+		all[id] = string(data)
+	}
+	return all, nil
+}
+
+// Save replaces the notes on issueID in dir. Blank text removes them.
+// Writing notes makes sure the beads directory's .gitignore lists dir.
+func Save(dir, issueID, text string) error {
+	path := Path(dir, issueID)
+	if strings.TrimSpace(text) == "" {
+		if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("removing notes: %w", err)
+		}
+		return nil
+	}
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	if err := loader.EnsureInGitignore(filepath.Dir(dir), filepath.Base(dir)+"/"); err != nil {
+		return fmt.Errorf("ignoring notes: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".notes-*")
+	if err != nil {
+		return fmt.Errorf("writing notes: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if _, err := tmp.WriteString(text); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing notes: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing notes: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing notes: %w", err)
+	}
+	return nil
+}
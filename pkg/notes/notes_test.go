@@ -0,0 +1,64 @@
+package notes
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestSaveLoad(t *testing.T) {
+	dir := Dir(t.TempDir())
+
+	if text, err := Load(dir, "bv-1"); err != nil || text != "" {
+		t.Fatalf("no notes: %q, %v", text, err)
+	}
+	if all, err := LoadAll(dir); err != nil || len(all) != 0 {
+		t.Fatalf("no directory: %v, %v", all, err)
+	}
+
+	if err := Save(dir, "bv-1", "# Plan\n\n- try the cache"); err != nil {
+		t.Fatal(err)
+	}
+	if err := Save(dir, "team/api-7", "odd id"); err != nil {
+		t.Fatal(err)
+	}
+	if text, _ := Load(dir, "bv-1"); text != "# Plan\n\n- try the cache\n" {
+		t.Errorf("Load = %q", text)
+	}
+	all, err := LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 2 || all["team/api-7"] != "odd id\n" {
+		t.Errorf("LoadAll = %q", all)
+	}
+	if filepath.Dir(Path(dir, "team/api-7")) != dir {
+		t.Errorf("an ID with a slash left the notes directory: %s", Path(dir, "team/api-7"))
+	}
+
+	// Clearing the notes removes the file
+	if err := Save(dir, "bv-1", "  \n"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(Path(dir, "bv-1")); !os.IsNotExist(err) {
+		t.Errorf("blank notes left a file: %v", err)
+	}
+	if err := Save(dir, "bv-1", ""); err != nil {
+		t.Errorf("clearing missing notes: %v", err)
+	}
+}
+
+func TestSaveIgnoresNotesDir(t *testing.T) {
+	beadsDir := t.TempDir()
+	if err := Save(Dir(beadsDir), "bv-1", "private"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(beadsDir, ".gitignore"))
+	if err != nil {
+		t.Fatalf("first note should create .gitignore: %v", err)
+	}
+	if !strings.Contains(string(data), DirName+"/") {
+		t.Errorf(".gitignore doesn't list %s/:\n%s", DirName, data)
+	}
+}
@@ -0,0 +1,125 @@
+package orgreport
+
+import (
+	"html/template"
+	"io"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// htmlTemplate is a single self-contained page: no scripts and no
+// external assets, so it can be mailed or attached as a build artifact.
+var htmlTemplate = template.Must(template.New("org-report").Funcs(template.FuncMap{
+	"weeks":         oldestFirst,
+	"velocityWeeks": func() int { return VelocityWeeks },
+	"bar": func(closed, peak int) int {
+		if peak == 0 {
+			return 0
+		}
+		return closed * 100 / peak
+	},
+	"peak": func(weeks []analysis.VelocityWeek) int {
+		peak := 0
+		for _, w := range weeks {
+			peak = max(peak, w.Closed)
+		}
+		return peak
+	},
+}).Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="UTF-8">
+<meta name="viewport" content="width=device-width, initial-scale=1.0">
+<title>bv org report</title>
+<style>
+  :root { --bg: #0f0f1a; --panel: #1a1a2e; --line: #252545; --fg: #e8e8f0; --muted: #8888aa;
+          --green: #50fa7b; --yellow: #f1fa8c; --red: #ff5555; --purple: #bd93f9; }
+  * { box-sizing: border-box; }
+  body { margin: 0; padding: 2rem; background: var(--bg); color: var(--fg);
+         font: 14px/1.5 -apple-system, BlinkMacSystemFont, "Segoe UI", sans-serif; }
+  h1 { margin: 0 0 0.25rem; font-size: 1.5rem; }
+  .meta { color: var(--muted); margin-bottom: 1.5rem; }
+  .cards { display: grid; grid-template-columns: repeat(auto-fit, minmax(9rem, 1fr)); gap: 0.75rem; margin-bottom: 2rem; }
+  .card { background: var(--panel); border: 1px solid var(--line); border-radius: 8px; padding: 0.75rem 1rem; }
+  .card b { display: block; font-size: 1.5rem; }
+  .card span { color: var(--muted); font-size: 0.8rem; }
+  table { width: 100%; border-collapse: collapse; background: var(--panel); border-radius: 8px; overflow: hidden; }
+  th, td { padding: 0.5rem 0.75rem; border-bottom: 1px solid var(--line); text-align: right; white-space: nowrap; }
+  th { color: var(--muted); font-weight: 600; font-size: 0.8rem; text-transform: uppercase; }
+  th:first-child, td:first-child, td.text { text-align: left; }
+  .source { color: var(--muted); font-size: 0.8rem; }
+  .health { display: inline-block; padding: 0.1rem 0.5rem; border-radius: 999px; font-size: 0.8rem; font-weight: 600; color: var(--bg); }
+  .healthy { background: var(--green); }
+  .warning { background: var(--yellow); }
+  .critical, .failed { background: var(--red); }
+  .cycles { color: var(--red); font-weight: 600; }
+  .error { color: var(--red); text-align: left; white-space: normal; }
+  .spark { display: inline-flex; align-items: flex-end; gap: 2px; height: 1.5rem; vertical-align: middle; }
+  .spark i { display: block; width: 6px; min-height: 1px; background: var(--purple); }
+  footer { margin-top: 1.5rem; color: var(--muted); font-size: 0.8rem; }
+</style>
+</head>
+<body>
+<h1>Org report</h1>
+<div class="meta">{{.Totals.Repos}} repositories · generated {{.GeneratedAt.Format "2006-01-02 15:04 MST"}}</div>
+<div class="cards">
+  <div class="card"><b>{{.Totals.Healthy}}</b><span>healthy</span></div>
+  <div class="card"><b>{{.Totals.Warning}}</b><span>warning</span></div>
+  <div class="card"><b>{{.Totals.Critical}}</b><span>critical</span></div>
+  <div class="card"><b>{{.Totals.WithCycles}}</b><span>with dependency cycles</span></div>
+  <div class="card"><b>{{.Totals.Open}}</b><span>open</span></div>
+  <div class="card"><b>{{.Totals.Blocked}}</b><span>blocked</span></div>
+  <div class="card"><b>{{.Totals.ClosedLast30Days}}</b><span>closed in 30 days</span></div>
+  {{- if .Totals.Failed}}
+  <div class="card"><b>{{.Totals.Failed}}</b><span>couldn't be read</span></div>
+  {{- end}}
+</div>
+<table>
+<thead>
+<tr><th>Repository</th><th>Health</th><th>Open</th><th>Blocked</th><th>Actionable</th><th>Closed</th><th>Cycles</th>
+<th>Closed 7d</th><th>Closed 30d</th><th>Days to close</th><th>Last {{velocityWeeks}} weeks</th><th>Alerts</th><th>Stale</th></tr>
+</thead>
+<tbody>
+{{- range .Repos}}
+<tr>
+  <td><b>{{.Name}}</b><br><span class="source">{{.Source}}</span></td>
+  {{- if .Error}}
+  <td class="text"><span class="health failed">failed</span></td>
+  <td class="error" colspan="11">{{.Error}}</td>
+  {{- else}}
+  <td class="text"><span class="health {{.Health}}">{{.Health}}</span></td>
+  <td>{{.Stats.OpenCount}}</td>
+  <td>{{.Stats.BlockedCount}}</td>
+  <td>{{.Stats.ActionableCount}}</td>
+  <td>{{.Stats.ClosedCount}}</td>
+  <td{{if .HasCycles}} class="cycles"{{end}}>{{.Stats.CycleCount}}</td>
+  <td>{{.Velocity.ClosedLast7Days}}</td>
+  <td>{{.Velocity.ClosedLast30Days}}</td>
+  <td>{{printf "%.1f" .Velocity.AvgDaysToClose}}</td>
+  <td>{{$peak := peak .Velocity.Weekly}}<span class="spark">{{range weeks .Velocity.Weekly}}<i style="height: {{bar .Closed $peak}}%" title="week of {{.WeekStart.Format "2006-01-02"}}: {{.Closed}} closed"></i>{{end}}</span></td>
+  <td>{{.Alerts.Critical}} / {{.Alerts.Warning}} / {{.Alerts.Info}}</td>
+  <td>{{.StaleCount}}</td>
+  {{- end}}
+</tr>
+{{- end}}
+</tbody>
+</table>
+<footer>Alerts are critical / warning / info. Health is critical with a dependency cycle or a critical alert, warning with a warning alert or open work but nothing closed in 30 days.</footer>
+</body>
+</html>
+`))
+
+// WriteHTML renders report as an HTML page.
+func WriteHTML(w io.Writer, report Report) error {
+	return htmlTemplate.Execute(w, report)
+}
+
+// oldestFirst reverses ComputeProjectVelocity's newest-first weeks for a
+// left-to-right chart.
+func oldestFirst(weeks []analysis.VelocityWeek) []analysis.VelocityWeek {
+	out := make([]analysis.VelocityWeek, len(weeks))
+	for i, w := range weeks {
+		out[len(weeks)-1-i] = w
+	}
+	return out
+}
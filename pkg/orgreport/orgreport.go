@@ -0,0 +1,181 @@
+// Package orgreport compares the health of several projects' beads files
+// side by side: open work, dependency cycles, delivery velocity and alerts,
+// one row per repository.
+package orgreport
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+// VelocityWeeks is how many weeks of closures each repository's velocity
+// covers.
+const VelocityWeeks = 8
+
+// Source is a repository to report on: a local directory or a git URL.
+type Source struct {
+	Name     string `json:"name"`
+	Location string `json:"location"`
+}
+
+// scpLike matches git's user@host:path shorthand for ssh URLs.
+var scpLike = regexp.MustCompile(`^[\w.-]+@[\w.-]+:`)
+
+// IsRemote reports whether location is a git URL to clone rather than a
+// local directory.
+func IsRemote(location string) bool {
+	return strings.Contains(location, "://") || scpLike.MatchString(location)
+}
+
+// ParseRepos reads a repository list: one location per line, optionally
+// followed by a display name. Blank lines and lines starting with # are
+// ignored. Names default to the location's last path element, without
+// .git.
+func ParseRepos(r io.Reader) ([]Source, error) {
+	var sources []Source
+	seen := make(map[string]int)
+	scanner := bufio.NewScanner(r)
+	lineNo := 0
+	for scanner.Scan() {
+		lineNo++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		src := Source{Location: fields[0]}
+		if len(fields) > 1 {
+			src.Name = strings.Join(fields[1:], " ")
+		} else {
+			src.Name = defaultName(src.Location)
+		}
+		if src.Name == "" {
+			return nil, fmt.Errorf("line %d: can't name %q; add a name after it", lineNo, src.Location)
+		}
+		if prev, ok := seen[src.Name]; ok {
+			return nil, fmt.Errorf("line %d: name %q is already used on line %d; add a name after the location", lineNo, src.Name, prev)
+		}
+		seen[src.Name] = lineNo
+		sources = append(sources, src)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("no repositories listed")
+	}
+	return sources, nil
+}
+
+func defaultName(location string) string {
+	loc := strings.TrimRight(strings.ReplaceAll(location, `\`, "/"), "/")
+	if i := strings.LastIndex(loc, ":"); i >= 0 && !strings.Contains(loc[i:], "/") {
+		loc = loc[i+1:]
+	}
+	name := strings.TrimSuffix(path.Base(loc), ".git")
+	if name == "." || name == "/" {
+		return ""
+	}
+	return name
+}
+
+// AlertCounts tallies a repository's alerts by severity.
+type AlertCounts struct {
+	Critical int `json:"critical"`
+	Warning  int `json:"warning"`
+	Info     int `json:"info"`
+}
+
+// Repo is one repository's row of the report. Error is set, and the
+// metrics are zero, when it couldn't be cloned or read.
+type Repo struct {
+	Name       string              `json:"name"`
+	Source     string              `json:"source"`
+	Error      string              `json:"error,omitempty"`
+	Health     string              `json:"health,omitempty"` // "healthy", "warning", or "critical"
+	Issues     int                 `json:"issues"`
+	Stats      baseline.GraphStats `json:"stats"`
+	HasCycles  bool                `json:"has_cycles"`
+	Cycles     [][]string          `json:"cycles,omitempty"`
+	Velocity   analysis.Velocity   `json:"velocity"`
+	Alerts     AlertCounts         `json:"alerts"`
+	StaleCount int                 `json:"stale_count"` // Open issues not updated within the stale threshold
+}
+
+// Grade rates a repository: critical when its dependencies have a cycle
+// or it has critical alerts, warning when it has warnings or open work but
+// nothing closed in 30 days, healthy otherwise.
+func Grade(r Repo) string {
+	switch {
+	case r.HasCycles || r.Alerts.Critical > 0:
+		return analysis.HealthLevelCritical
+	case r.Alerts.Warning > 0 || (r.Stats.OpenCount+r.Stats.BlockedCount > 0 && r.Velocity.ClosedLast30Days == 0):
+		return analysis.HealthLevelWarning
+	}
+	return analysis.HealthLevelHealthy
+}
+
+// Totals sums the repositories that could be read.
+type Totals struct {
+	Repos            int `json:"repos"`
+	Failed           int `json:"failed"`
+	Healthy          int `json:"healthy"`
+	Warning          int `json:"warning"`
+	Critical         int `json:"critical"`
+	WithCycles       int `json:"with_cycles"`
+	Open             int `json:"open"`
+	Blocked          int `json:"blocked"`
+	Actionable       int `json:"actionable"`
+	ClosedLast7Days  int `json:"closed_last_7_days"`
+	ClosedLast30Days int `json:"closed_last_30_days"`
+}
+
+// Report is the cross-repository comparison.
+type Report struct {
+	GeneratedAt time.Time `json:"generated_at"`
+	Totals      Totals    `json:"totals"`
+	Repos       []Repo    `json:"repos"`
+}
+
+// NewReport grades repos and totals them, keeping their order.
+func NewReport(repos []Repo, now time.Time) Report {
+	report := Report{GeneratedAt: now.UTC(), Repos: repos}
+	if report.Repos == nil {
+		report.Repos = []Repo{}
+	}
+	t := &report.Totals
+	t.Repos = len(repos)
+	for i := range report.Repos {
+		r := &report.Repos[i]
+		if r.Error != "" {
+			t.Failed++
+			continue
+		}
+		r.Health = Grade(*r)
+		switch r.Health {
+		case analysis.HealthLevelHealthy:
+			t.Healthy++
+		case analysis.HealthLevelWarning:
+			t.Warning++
+		default:
+			t.Critical++
+		}
+		if r.HasCycles {
+			t.WithCycles++
+		}
+		t.Open += r.Stats.OpenCount
+		t.Blocked += r.Stats.BlockedCount
+		t.Actionable += r.Stats.ActionableCount
+		t.ClosedLast7Days += r.Velocity.ClosedLast7Days
+		t.ClosedLast30Days += r.Velocity.ClosedLast30Days
+	}
+	return report
+}
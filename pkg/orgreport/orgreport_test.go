@@ -0,0 +1,113 @@
+package orgreport
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
+)
+
+func TestParseRepos(t *testing.T) {
+	sources, err := ParseRepos(strings.NewReader(`
+# services
+../api
+https://github.com/acme/web.git
+git@github.com:acme/mobile.git  Mobile app
+/srv/repos/tools/
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Source{
+		{Name: "api", Location: "../api"},
+		{Name: "web", Location: "https://github.com/acme/web.git"},
+		{Name: "Mobile app", Location: "git@github.com:acme/mobile.git"},
+		{Name: "tools", Location: "/srv/repos/tools/"},
+	}
+	if len(sources) != len(want) {
+		t.Fatalf("got %+v, want %+v", sources, want)
+	}
+	for i := range want {
+		if sources[i] != want[i] {
+			t.Errorf("source %d = %+v, want %+v", i, sources[i], want[i])
+		}
+	}
+
+	if _, err := ParseRepos(strings.NewReader("a/web\nb/web\n")); err == nil || !strings.Contains(err.Error(), "line 1") {
+		t.Errorf("duplicate names: got %v, want an error pointing at line 1", err)
+	}
+	if _, err := ParseRepos(strings.NewReader("# nothing\n\n")); err == nil {
+		t.Error("empty list: want an error")
+	}
+}
+
+func TestIsRemote(t *testing.T) {
+	for location, want := range map[string]bool{
+		"https://github.com/acme/web.git": true,
+		"file:///srv/git/web":             true,
+		"git@github.com:acme/web.git":     true,
+		"../web":                          false,
+		"/srv/web":                        false,
+		`C:\src\web`:                      false,
+	} {
+		if got := IsRemote(location); got != want {
+			t.Errorf("IsRemote(%q) = %v, want %v", location, got, want)
+		}
+	}
+}
+
+func TestNewReport(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	repos := []Repo{
+		{Name: "ok", Stats: baseline.GraphStats{OpenCount: 3, ActionableCount: 2}, Velocity: analysis.Velocity{ClosedLast7Days: 1, ClosedLast30Days: 4}},
+		{Name: "cyclic", HasCycles: true, Stats: baseline.GraphStats{OpenCount: 2, BlockedCount: 1, CycleCount: 1}, Velocity: analysis.Velocity{ClosedLast30Days: 2}},
+		{Name: "idle", Stats: baseline.GraphStats{OpenCount: 5}},
+		{Name: "noisy", Alerts: AlertCounts{Warning: 2}, Velocity: analysis.Velocity{ClosedLast30Days: 1}},
+		{Name: "gone", Error: "git clone: exit status 128"},
+	}
+	report := NewReport(repos, now)
+
+	for name, want := range map[string]string{"ok": "healthy", "cyclic": "critical", "idle": "warning", "noisy": "warning", "gone": ""} {
+		for _, r := range report.Repos {
+			if r.Name == name && r.Health != want {
+				t.Errorf("%s health = %q, want %q", name, r.Health, want)
+			}
+		}
+	}
+	want := Totals{Repos: 5, Failed: 1, Healthy: 1, Warning: 2, Critical: 1, WithCycles: 1, Open: 10, Blocked: 1, Actionable: 2, ClosedLast7Days: 1, ClosedLast30Days: 7}
+	if report.Totals != want {
+		t.Errorf("totals = %+v, want %+v", report.Totals, want)
+	}
+}
+
+func TestWriteHTML(t *testing.T) {
+	now := time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)
+	report := NewReport([]Repo{
+		{Name: "<web>", Source: "https://example.com/web.git", HasCycles: true, Stats: baseline.GraphStats{CycleCount: 1},
+			Velocity: analysis.Velocity{Weekly: []analysis.VelocityWeek{{WeekStart: now, Closed: 2}, {WeekStart: now.AddDate(0, 0, -7), Closed: 4}}}},
+		{Name: "api", Error: "no beads file"},
+	}, now)
+	var buf bytes.Buffer
+	if err := WriteHTML(&buf, report); err != nil {
+		t.Fatal(err)
+	}
+	page := buf.String()
+	for _, want := range []string{
+		"&lt;web&gt;",
+		`class="health critical"`,
+		`class="health failed"`,
+		"no beads file",
+		// Oldest week first, scaled to the busiest
+		`height: 100%" title="week of 2026-02-25: 4 closed"></i><i style="height: 50%"`,
+	} {
+		if !strings.Contains(page, want) {
+			t.Errorf("HTML missing %q", want)
+		}
+	}
+	if strings.Contains(page, "<web>") {
+		t.Error("repository name isn't escaped")
+	}
+}
@@ -0,0 +1,123 @@
+// Package pins keeps the issues a user starred in bv. Stars live in
+// .beads/.bv-state.json, next to the beads data; the first save adds the
+// file to .beads/.gitignore. They are one user's bookmarks, not project
+// data, so they survive restarts and show up in every bv the user runs on
+// the checkout without reaching anyone else's.
+package pins
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// StateFilename is the per-user state file's name in the beads directory.
+const StateFilename = ".bv-state.json"
+
+// State is the per-user state bv keeps for a repository.
+type State struct {
+	Pinned []string `json:"pinned"` // Starred issue IDs, oldest first
+}
+
+// DefaultPath returns the state file path for a beads directory.
+func DefaultPath(beadsDir string) string {
+	return filepath.Join(beadsDir, StateFilename)
+}
+
+// Load reads the state at path. A missing file is an empty state.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{Pinned: []string{}}, nil
+		}
+		return nil, fmt.Errorf("reading pins: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing pins: %w", err)
+	}
+	if s.Pinned == nil {
+		s.Pinned = []string{}
+	}
+	return &s, nil
+}
+
+// Save writes the state to path, replacing it atomically, and makes sure
+// git ignores it.
+func (s *State) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := loader.EnsureInGitignore(dir, filepath.Base(path)); err != nil {
+		return fmt.Errorf("ignoring pins: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding pins: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".bv-state-*")
+	if err != nil {
+		return fmt.Errorf("writing pins: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing pins: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing pins: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing pins: %w", err)
+	}
+	return nil
+}
+
+// Has reports whether issueID is pinned.
+func (s *State) Has(issueID string) bool {
+	for _, id := range s.Pinned {
+		if id == issueID {
+			return true
+		}
+	}
+	return false
+}
+
+// Set returns the pinned IDs as a set.
+func (s *State) Set() map[string]bool {
+	set := make(map[string]bool, len(s.Pinned))
+	for _, id := range s.Pinned {
+		set[id] = true
+	}
+	return set
+}
+
+// Toggle pins issueID, or unpins it if it was pinned, and reports whether
+// it is pinned now.
+func (s *State) Toggle(issueID string) bool {
+	for i, id := range s.Pinned {
+		if id == issueID {
+			s.Pinned = append(s.Pinned[:i], s.Pinned[i+1:]...)
+			return false
+		}
+	}
+	s.Pinned = append(s.Pinned, issueID)
+	return true
+}
+
+// Toggle flips issueID in the state file at path, re-reading it first so
+// a star set by another bv on the same checkout isn't lost, and returns
+// the updated state.
+func Toggle(path, issueID string) (*State, bool, error) {
+	s, err := Load(path)
+	if err != nil {
+		return nil, false, err
+	}
+	pinned := s.Toggle(issueID)
+	if err := s.Save(path); err != nil {
+		return nil, false, err
+	}
+	return s, pinned, nil
+}
@@ -0,0 +1,81 @@
+package pins
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadMissing(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), StateFilename))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s.Pinned) != 0 || s.Has("x") {
+		t.Errorf("missing file should be empty, got %+v", s)
+	}
+}
+
+func TestToggleRoundTrip(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+
+	if _, pinned, err := Toggle(path, "bv-1"); err != nil || !pinned {
+		t.Fatalf("pin bv-1: pinned=%v err=%v", pinned, err)
+	}
+	if _, _, err := Toggle(path, "bv-2"); err != nil {
+		t.Fatal(err)
+	}
+	s, pinned, err := Toggle(path, "bv-1")
+	if err != nil || pinned {
+		t.Fatalf("unpin bv-1: pinned=%v err=%v", pinned, err)
+	}
+	if !reflect.DeepEqual(s.Pinned, []string{"bv-2"}) {
+		t.Errorf("pinned = %q", s.Pinned)
+	}
+
+	// Another process pinning in between isn't overwritten
+	other, _ := Load(path)
+	other.Toggle("bv-3")
+	if err := other.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	s, _, err = Toggle(path, "bv-4")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"bv-2", "bv-3", "bv-4"}; !reflect.DeepEqual(s.Pinned, want) {
+		t.Errorf("pinned = %q, want %q", s.Pinned, want)
+	}
+	if set := s.Set(); !set["bv-3"] || set["bv-1"] {
+		t.Errorf("set = %v", set)
+	}
+}
+
+func TestLoadCorrupt(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("corrupt file should be an error")
+	}
+	if _, _, err := Toggle(path, "bv-1"); err == nil {
+		t.Error("toggling must not overwrite a corrupt file")
+	}
+}
+
+func TestSaveIgnoresStateFile(t *testing.T) {
+	beadsDir := t.TempDir()
+	if _, _, err := Toggle(DefaultPath(beadsDir), "bv-1"); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(beadsDir, ".gitignore"))
+	if err != nil {
+		t.Fatalf("first save should create .gitignore: %v", err)
+	}
+	if !strings.Contains(string(data), StateFilename) {
+		t.Errorf(".gitignore doesn't list %s:\n%s", StateFilename, data)
+	}
+}
@@ -0,0 +1,239 @@
+// Package plugins runs analyzer plugins: external executables named
+// bv-analyzer-<name> that read the project's issues as JSON on stdin and
+// write their own scores and findings as JSON on stdout. Their results are
+// merged into --robot-insights, so teams can add their own scoring without
+// forking bv.
+package plugins
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// Prefix is the executable name prefix that marks an analyzer plugin.
+const Prefix = "bv-analyzer-"
+
+// ProtocolVersion is the version of the Input and Output documents. It is
+// sent to every plugin; bump it when a field is removed or changes meaning.
+const ProtocolVersion = 1
+
+// DefaultTimeout bounds one plugin run.
+const DefaultTimeout = 30 * time.Second
+
+// maxOutputBytes caps what bv reads from a plugin's stdout.
+const maxOutputBytes = 16 << 20
+
+// Input is the document a plugin reads from stdin.
+type Input struct {
+	ProtocolVersion int           `json:"protocol_version"`
+	DataHash        string        `json:"data_hash"`
+	Issues          []model.Issue `json:"issues"`
+	// Metrics are bv's graph metrics by name (pagerank, betweenness, ...),
+	// then issue ID, so plugins can build on them instead of recomputing.
+	Metrics map[string]map[string]float64 `json:"metrics"`
+}
+
+// Output is the document a plugin writes to stdout. Every field is optional.
+type Output struct {
+	Scores   map[string]float64 `json:"scores,omitempty"` // issue ID -> the plugin's own score
+	Findings []Finding          `json:"findings,omitempty"`
+	Data     json.RawMessage    `json:"data,omitempty"` // anything else, passed through as-is
+}
+
+// Finding is one observation a plugin reports.
+type Finding struct {
+	IssueID  string `json:"issue_id,omitempty"` // empty for project-wide findings
+	Severity string `json:"severity,omitempty"` // info, warning, or critical by convention
+	Message  string `json:"message"`
+}
+
+// Analyzer is an analyzer plugin.
+type Analyzer interface {
+	Name() string
+	Analyze(ctx context.Context, in Input) (Output, error)
+}
+
+// Result is one plugin's run, as reported in --robot-insights.
+type Result struct {
+	Name       string  `json:"name"`
+	Path       string  `json:"path,omitempty"`
+	OK         bool    `json:"ok"`
+	Error      string  `json:"error,omitempty"`
+	DurationMS int64   `json:"duration_ms"`
+	Output     *Output `json:"output,omitempty"` // nil when the run failed
+}
+
+// ExecAnalyzer is a plugin executable speaking the stdio JSON protocol.
+type ExecAnalyzer struct {
+	name string
+	Path string
+}
+
+// NewExecAnalyzer returns the plugin at path, named after its file name.
+func NewExecAnalyzer(path string) *ExecAnalyzer {
+	return &ExecAnalyzer{name: nameOf(filepath.Base(path)), Path: path}
+}
+
+// Name returns the plugin name: the file name without Prefix or extension.
+func (a *ExecAnalyzer) Name() string { return a.name }
+
+// Analyze runs the executable with in on stdin. A non-zero exit, output
+// that isn't an Output document, or ctx expiring is an error; stderr is
+// included in it.
+func (a *ExecAnalyzer) Analyze(ctx context.Context, in Input) (Output, error) {
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return Output{}, err
+	}
+	cmd := exec.CommandContext(ctx, a.Path)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Env = append(os.Environ(), fmt.Sprintf("BV_PLUGIN_PROTOCOL=%d", ProtocolVersion))
+	var stdout limitedBuffer
+	var stderr bytes.Buffer
+	stdout.max = maxOutputBytes
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if ctx.Err() != nil {
+			return Output{}, fmt.Errorf("timed out: %w", ctx.Err())
+		}
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return Output{}, fmt.Errorf("%w: %s", err, truncate(msg, 500))
+		}
+		return Output{}, err
+	}
+	if stdout.overflow {
+		return Output{}, fmt.Errorf("output exceeds %d bytes", maxOutputBytes)
+	}
+	var out Output
+	if err := json.Unmarshal(stdout.Bytes(), &out); err != nil {
+		return Output{}, fmt.Errorf("invalid output: %w", err)
+	}
+	return out, nil
+}
+
+// Discover finds analyzer plugins in the directories of path (a PATH-style
+// list; pass os.Getenv("PATH")). When two directories have a plugin of the
+// same name the earlier wins, as the shell would pick it. The result is in
+// name order.
+func Discover(path string) []*ExecAnalyzer {
+	seen := make(map[string]bool)
+	var found []*ExecAnalyzer
+	for _, dir := range filepath.SplitList(path) {
+		if dir == "" {
+			continue // an empty entry means ".", which bv never searches
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			name := nameOf(e.Name())
+			if !strings.HasPrefix(e.Name(), Prefix) || name == "" || seen[name] {
+				continue
+			}
+			full := filepath.Join(dir, e.Name())
+			if !isExecutable(full) {
+				continue
+			}
+			seen[name] = true
+			found = append(found, &ExecAnalyzer{name: name, Path: full})
+		}
+	}
+	sort.Slice(found, func(i, j int) bool { return found[i].name < found[j].name })
+	return found
+}
+
+// Run runs every analyzer concurrently, each bounded by timeout, and
+// returns their results in the analyzers' order. A failing plugin gets an
+// error result; it never fails the others.
+func Run(ctx context.Context, analyzers []Analyzer, in Input, timeout time.Duration) []Result {
+	if in.ProtocolVersion == 0 {
+		in.ProtocolVersion = ProtocolVersion
+	}
+	results := make([]Result, len(analyzers))
+	var wg sync.WaitGroup
+	for i, a := range analyzers {
+		wg.Add(1)
+		go func(i int, a Analyzer) {
+			defer wg.Done()
+			runCtx, cancel := context.WithTimeout(ctx, timeout)
+			defer cancel()
+			start := time.Now()
+			out, err := a.Analyze(runCtx, in)
+			r := Result{Name: a.Name(), DurationMS: time.Since(start).Milliseconds()}
+			if ea, ok := a.(*ExecAnalyzer); ok {
+				r.Path = ea.Path
+			}
+			if err != nil {
+				r.Error = err.Error()
+			} else {
+				r.OK = true
+				r.Output = &out
+			}
+			results[i] = r
+		}(i, a)
+	}
+	wg.Wait()
+	return results
+}
+
+// nameOf strips Prefix and, on Windows, an executable extension.
+func nameOf(file string) string {
+	name := strings.TrimPrefix(file, Prefix)
+	if runtime.GOOS == "windows" {
+		name = strings.TrimSuffix(name, filepath.Ext(name))
+	}
+	return name
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	if err != nil || info.IsDir() {
+		return false
+	}
+	if runtime.GOOS == "windows" {
+		ext := strings.ToLower(filepath.Ext(path))
+		return ext == ".exe" || ext == ".bat" || ext == ".cmd"
+	}
+	return info.Mode()&0o111 != 0
+}
+
+// limitedBuffer keeps at most max bytes and notes whether more arrived.
+type limitedBuffer struct {
+	bytes.Buffer
+	max      int
+	overflow bool
+}
+
+func (b *limitedBuffer) Write(p []byte) (int, error) {
+	if room := b.max - b.Len(); len(p) > room {
+		b.overflow = true
+		if room > 0 {
+			b.Buffer.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.Buffer.Write(p)
+}
+
+func truncate(s string, max int) string {
+	runes := []rune(s)
+	if len(runes) <= max {
+		return s
+	}
+	return string(runes[:max-3]) + "..."
+}
@@ -0,0 +1,128 @@
+package plugins
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+type fakeAnalyzer struct {
+	name string
+	out  Output
+	err  error
+	seen *Input
+}
+
+func (f *fakeAnalyzer) Name() string { return f.name }
+
+func (f *fakeAnalyzer) Analyze(ctx context.Context, in Input) (Output, error) {
+	if f.seen != nil {
+		*f.seen = in
+	}
+	return f.out, f.err
+}
+
+func TestRunKeepsOrderAndIsolatesFailures(t *testing.T) {
+	var seen Input
+	analyzers := []Analyzer{
+		&fakeAnalyzer{name: "risk", out: Output{Scores: map[string]float64{"A": 0.9}}, seen: &seen},
+		&fakeAnalyzer{name: "broken", err: errors.New("boom")},
+		&fakeAnalyzer{name: "lint", out: Output{Findings: []Finding{{IssueID: "A", Message: "no owner"}}}},
+	}
+	in := Input{DataHash: "h", Issues: []model.Issue{{ID: "A"}}}
+	results := Run(context.Background(), analyzers, in, time.Second)
+
+	if len(results) != 3 || results[0].Name != "risk" || results[1].Name != "broken" || results[2].Name != "lint" {
+		t.Fatalf("results = %+v, want one per analyzer in order", results)
+	}
+	if !results[0].OK || results[0].Output.Scores["A"] != 0.9 {
+		t.Errorf("risk = %+v", results[0])
+	}
+	if results[1].OK || results[1].Error != "boom" || results[1].Output != nil {
+		t.Errorf("broken = %+v", results[1])
+	}
+	if !results[2].OK || len(results[2].Output.Findings) != 1 {
+		t.Errorf("lint = %+v", results[2])
+	}
+	if seen.ProtocolVersion != ProtocolVersion || seen.DataHash != "h" {
+		t.Errorf("plugin saw %+v, want protocol version filled in", seen)
+	}
+}
+
+func writePlugin(t *testing.T, dir, name, script string, mode os.FileMode) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script+"\n"), mode); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestDiscover(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts here")
+	}
+	first, second := t.TempDir(), t.TempDir()
+	writePlugin(t, first, "bv-analyzer-risk", "true", 0o755)
+	writePlugin(t, second, "bv-analyzer-risk", "true", 0o755) // shadowed by first
+	writePlugin(t, second, "bv-analyzer-age", "true", 0o755)
+	writePlugin(t, second, "bv-analyzer-notexec", "true", 0o644)
+	writePlugin(t, second, "bv-other", "true", 0o755)
+	if err := os.Mkdir(filepath.Join(second, "bv-analyzer-dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	found := Discover(strings.Join([]string{first, "", filepath.Join(first, "missing"), second}, string(os.PathListSeparator)))
+	var got []string
+	for _, a := range found {
+		got = append(got, a.Name()+"@"+filepath.Dir(a.Path))
+	}
+	want := []string{"age@" + second, "risk@" + first}
+	if strings.Join(got, " ") != strings.Join(want, " ") {
+		t.Errorf("Discover = %v, want %v", got, want)
+	}
+}
+
+func TestExecAnalyzer(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("plugins are shell scripts here")
+	}
+	dir := t.TempDir()
+	in := Input{ProtocolVersion: ProtocolVersion, Issues: []model.Issue{{ID: "A-1", Title: "x"}}}
+
+	echo := NewExecAnalyzer(writePlugin(t, dir, "bv-analyzer-echo",
+		`grep -q '"id":"A-1"' && echo '{"scores":{"A-1":2},"data":{"protocol":'"$BV_PLUGIN_PROTOCOL"'},"extra":true}'`, 0o755))
+	if echo.Name() != "echo" {
+		t.Errorf("Name = %q", echo.Name())
+	}
+	out, err := echo.Analyze(context.Background(), in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out.Scores["A-1"] != 2 || string(out.Data) != `{"protocol":1}` {
+		t.Errorf("output = %+v (data %s)", out, out.Data)
+	}
+
+	failing := NewExecAnalyzer(writePlugin(t, dir, "bv-analyzer-fail", "echo 'no config' >&2; exit 3", 0o755))
+	if _, err := failing.Analyze(context.Background(), in); err == nil || !strings.Contains(err.Error(), "no config") {
+		t.Errorf("failing plugin error = %v, want its stderr", err)
+	}
+
+	garbage := NewExecAnalyzer(writePlugin(t, dir, "bv-analyzer-garbage", "echo not json", 0o755))
+	if _, err := garbage.Analyze(context.Background(), in); err == nil || !strings.Contains(err.Error(), "invalid output") {
+		t.Errorf("garbage plugin error = %v", err)
+	}
+
+	slow := NewExecAnalyzer(writePlugin(t, dir, "bv-analyzer-slow", "exec sleep 5", 0o755))
+	results := Run(context.Background(), []Analyzer{slow}, in, 100*time.Millisecond)
+	if results[0].OK || !strings.Contains(results[0].Error, "timed out") || results[0].Path != slow.Path {
+		t.Errorf("slow plugin = %+v", results[0])
+	}
+}
@@ -0,0 +1,449 @@
+package rules
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Expr is a compiled rule expression. Evaluation is a pure function of the
+// variables passed in, so the same issue always gets the same result.
+//
+// The language is deliberately small:
+//
+//	literals     1  -0.5  "text"  'text'  true  false
+//	variables    priority  labels  status ...  (see Vars)
+//	comparison   == != < <= > >=
+//	membership   "security" in labels    "auth" in title
+//	logic        && || !  and parentheses
+type Expr struct {
+	src  string
+	root node
+}
+
+// String returns the expression's source text.
+func (e *Expr) String() string { return e.src }
+
+// Compile parses src. Every variable it uses must be in vars.
+func Compile(src string, vars map[string]bool) (*Expr, error) {
+	toks, err := lex(src)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{toks: toks, vars: vars}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != tokEOF {
+		return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+	}
+	return &Expr{src: src, root: root}, nil
+}
+
+// Eval evaluates the expression. Values are float64, string, bool, or
+// []string; a variable missing from vars is an error.
+func (e *Expr) Eval(vars map[string]any) (any, error) {
+	return e.root.eval(vars)
+}
+
+// EvalBool evaluates a condition; a non-bool result is an error.
+func (e *Expr) EvalBool(vars map[string]any) (bool, error) {
+	v, err := e.Eval(vars)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return false, fmt.Errorf("condition is %s, not bool", typeName(v))
+	}
+	return b, nil
+}
+
+// --- lexer ---
+
+type tokKind int
+
+const (
+	tokEOF tokKind = iota
+	tokNum
+	tokStr
+	tokIdent
+	tokOp
+)
+
+type token struct {
+	kind tokKind
+	text string
+	num  float64
+	pos  int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokEOF:
+		return "end of expression"
+	case tokStr:
+		return strconv.Quote(t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+// twoCharOps are checked before their one-character prefixes.
+var twoCharOps = []string{"==", "!=", "<=", ">=", "&&", "||"}
+
+func lex(src string) ([]token, error) {
+	var toks []token
+	for i := 0; i < len(src); {
+		c := rune(src[i])
+		switch {
+		case unicode.IsSpace(c):
+			i++
+		case c == '"' || c == '\'':
+			j := i + 1
+			var sb strings.Builder
+			for ; j < len(src) && rune(src[j]) != c; j++ {
+				if src[j] == '\\' && j+1 < len(src) {
+					j++
+				}
+				sb.WriteByte(src[j])
+			}
+			if j >= len(src) {
+				return nil, fmt.Errorf("unterminated string at offset %d", i)
+			}
+			toks = append(toks, token{kind: tokStr, text: sb.String(), pos: i})
+			i = j + 1
+		case startsNumber(src[i:]):
+			j := i + 1
+			for j < len(src) && (unicode.IsDigit(rune(src[j])) || src[j] == '.') {
+				j++
+			}
+			n, err := strconv.ParseFloat(src[i:j], 64)
+			if err != nil {
+				return nil, fmt.Errorf("bad number %q at offset %d", src[i:j], i)
+			}
+			toks = append(toks, token{kind: tokNum, text: src[i:j], num: n, pos: i})
+			i = j
+		case unicode.IsLetter(c) || c == '_':
+			j := i
+			for j < len(src) && (unicode.IsLetter(rune(src[j])) || unicode.IsDigit(rune(src[j])) || src[j] == '_') {
+				j++
+			}
+			toks = append(toks, token{kind: tokIdent, text: src[i:j], pos: i})
+			i = j
+		default:
+			op := ""
+			for _, two := range twoCharOps {
+				if strings.HasPrefix(src[i:], two) {
+					op = two
+					break
+				}
+			}
+			if op == "" && strings.ContainsRune("()<>!", c) {
+				op = string(c)
+			}
+			if op == "" {
+				return nil, fmt.Errorf("unexpected %q at offset %d", c, i)
+			}
+			toks = append(toks, token{kind: tokOp, text: op, pos: i})
+			i += len(op)
+		}
+	}
+	return append(toks, token{kind: tokEOF, pos: len(src)}), nil
+}
+
+// startsNumber reports whether s begins with a number literal, which may
+// be negative: there is no subtraction to confuse it with.
+func startsNumber(s string) bool {
+	s = strings.TrimPrefix(s, "-")
+	if strings.HasPrefix(s, ".") {
+		s = s[1:]
+	}
+	return s != "" && s[0] >= '0' && s[0] <= '9'
+}
+
+// --- parser ---
+
+type parser struct {
+	toks []token
+	i    int
+	vars map[string]bool
+}
+
+func (p *parser) peek() token { return p.toks[p.i] }
+
+func (p *parser) next() token {
+	t := p.toks[p.i]
+	if t.kind != tokEOF {
+		p.i++
+	}
+	return t
+}
+
+// accept consumes the next token if it is one of ops (operators or "in").
+func (p *parser) accept(ops ...string) (string, bool) {
+	t := p.peek()
+	if t.kind != tokOp && t.kind != tokIdent {
+		return "", false
+	}
+	for _, op := range ops {
+		if t.text == op {
+			p.i++
+			return op, true
+		}
+	}
+	return "", false
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("||"); !ok {
+			return left, nil
+		}
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "||", left: left, right: right}
+	}
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		if _, ok := p.accept("&&"); !ok {
+			return left, nil
+		}
+		right, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		left = &binaryNode{op: "&&", left: left, right: right}
+	}
+}
+
+func (p *parser) parseNot() (node, error) {
+	if _, ok := p.accept("!"); ok {
+		operand, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryNode{op: "!", operand: operand}, nil
+	}
+	return p.parseCompare()
+}
+
+func (p *parser) parseCompare() (node, error) {
+	left, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	op, ok := p.accept("==", "!=", "<", "<=", ">", ">=", "in")
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	return &binaryNode{op: op, left: left, right: right}, nil
+}
+
+func (p *parser) parsePrimary() (node, error) {
+	t := p.next()
+	switch t.kind {
+	case tokNum:
+		return &litNode{val: t.num}, nil
+	case tokStr:
+		return &litNode{val: t.text}, nil
+	case tokOp:
+		if t.text == "(" {
+			inner, err := p.parseOr()
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := p.accept(")"); !ok {
+				return nil, fmt.Errorf("missing ) for ( at offset %d", t.pos)
+			}
+			return inner, nil
+		}
+	case tokIdent:
+		switch t.text {
+		case "true":
+			return &litNode{val: true}, nil
+		case "false":
+			return &litNode{val: false}, nil
+		}
+		if !p.vars[t.text] {
+			return nil, fmt.Errorf("unknown variable %q at offset %d", t.text, t.pos)
+		}
+		return &varNode{name: t.text}, nil
+	}
+	return nil, fmt.Errorf("unexpected %s at offset %d", t, t.pos)
+}
+
+// --- evaluation ---
+
+type node interface {
+	eval(vars map[string]any) (any, error)
+}
+
+type litNode struct{ val any }
+
+func (n *litNode) eval(map[string]any) (any, error) { return n.val, nil }
+
+type varNode struct{ name string }
+
+func (n *varNode) eval(vars map[string]any) (any, error) {
+	v, ok := vars[n.name]
+	if !ok {
+		return nil, fmt.Errorf("variable %q is not set", n.name)
+	}
+	return v, nil
+}
+
+type unaryNode struct {
+	op      string
+	operand node
+}
+
+func (n *unaryNode) eval(vars map[string]any) (any, error) {
+	v, err := n.operand.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	b, ok := v.(bool)
+	if !ok {
+		return nil, fmt.Errorf("%s needs a bool, got %s", n.op, typeName(v))
+	}
+	return !b, nil
+}
+
+type binaryNode struct {
+	op          string
+	left, right node
+}
+
+func (n *binaryNode) eval(vars map[string]any) (any, error) {
+	l, err := n.left.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+	// Logic short-circuits
+	if n.op == "&&" || n.op == "||" {
+		lb, ok := l.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s needs bools, got %s", n.op, typeName(l))
+		}
+		if (n.op == "&&") != lb {
+			return lb, nil
+		}
+		r, err := n.right.eval(vars)
+		if err != nil {
+			return nil, err
+		}
+		rb, ok := r.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%s needs bools, got %s", n.op, typeName(r))
+		}
+		return rb, nil
+	}
+	r, err := n.right.eval(vars)
+	if err != nil {
+		return nil, err
+	}
+
+	switch n.op {
+	case "in":
+		return contains(r, l)
+	case "==", "!=":
+		if typeName(l) != typeName(r) {
+			return nil, fmt.Errorf("can't compare %s with %s", typeName(l), typeName(r))
+		}
+		if _, isList := l.([]string); isList {
+			return nil, fmt.Errorf("can't compare lists")
+		}
+		return (l == r) == (n.op == "=="), nil
+	case "<", "<=", ">", ">=":
+		var c int
+		switch lv := l.(type) {
+		case float64:
+			rv, ok := r.(float64)
+			if !ok {
+				return nil, fmt.Errorf("can't compare number with %s", typeName(r))
+			}
+			c = compareFloat(lv, rv)
+		case string:
+			rv, ok := r.(string)
+			if !ok {
+				return nil, fmt.Errorf("can't compare string with %s", typeName(r))
+			}
+			c = strings.Compare(lv, rv)
+		default:
+			return nil, fmt.Errorf("%s needs numbers or strings, got %s", n.op, typeName(l))
+		}
+		switch n.op {
+		case "<":
+			return c < 0, nil
+		case "<=":
+			return c <= 0, nil
+		case ">":
+			return c > 0, nil
+		default:
+			return c >= 0, nil
+		}
+	}
+	return nil, fmt.Errorf("unknown operator %s", n.op)
+}
+
+// contains reports whether list has item, or string has item as a substring.
+func contains(haystack, item any) (any, error) {
+	s, ok := item.(string)
+	if !ok {
+		return nil, fmt.Errorf("in needs a string on the left, got %s", typeName(item))
+	}
+	switch h := haystack.(type) {
+	case []string:
+		for _, e := range h {
+			if e == s {
+				return true, nil
+			}
+		}
+		return false, nil
+	case string:
+		return strings.Contains(h, s), nil
+	}
+	return nil, fmt.Errorf("in needs a string or list on the right, got %s", typeName(haystack))
+}
+
+func compareFloat(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func typeName(v any) string {
+	switch v.(type) {
+	case float64:
+		return "number"
+	case string:
+		return "string"
+	case bool:
+		return "bool"
+	case []string:
+		return "list"
+	}
+	return fmt.Sprintf("%T", v)
+}
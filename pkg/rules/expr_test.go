@@ -0,0 +1,108 @@
+package rules
+
+import (
+	"strings"
+	"testing"
+)
+
+var testVars = map[string]bool{"priority": true, "labels": true, "title": true, "score": true}
+
+func testEnv() map[string]any {
+	return map[string]any{
+		"priority": 1.0,
+		"labels":   []string{"security", "api"},
+		"title":    "Fix Auth token refresh",
+		"score":    0.4,
+	}
+}
+
+func TestExprEval(t *testing.T) {
+	cases := []struct {
+		src  string
+		want any
+	}{
+		{`"security" in labels`, true},
+		{`"ui" in labels`, false},
+		{`"Auth" in title`, true},
+		{`priority <= 1 && score > 0.3`, true},
+		{`priority == 0 || !("api" in labels)`, false},
+		{`!(priority > 2)`, true},
+		{`score > -0.5 && priority != -1`, true},
+		{`'a' == "a"`, true},
+		{`priority`, 1.0},
+		{`"b" > "a"`, true},
+		{`true != false`, true},
+		// short-circuit: the right side would be a type error
+		{`false && priority`, false},
+		{`true || priority`, true},
+	}
+	for _, c := range cases {
+		e, err := Compile(c.src, testVars)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", c.src, err)
+			continue
+		}
+		got, err := e.Eval(testEnv())
+		if err != nil {
+			t.Errorf("Eval(%q): %v", c.src, err)
+			continue
+		}
+		if got != c.want {
+			t.Errorf("Eval(%q) = %v, want %v", c.src, got, c.want)
+		}
+	}
+}
+
+func TestExprCompileErrors(t *testing.T) {
+	cases := map[string]string{
+		`owner == "me"`:         `unknown variable "owner"`,
+		`lower(title)`:          `unknown variable "lower"`,
+		`priority + 1`:          `unexpected '+'`,
+		`priority > 1 and true`: `unexpected "and"`,
+		`priority ==`:           "unexpected end of expression",
+		`(priority == 1`:        "missing )",
+		`"unterminated`:         "unterminated string",
+		`priority @ 1`:          `unexpected '@'`,
+		`priority == 1 score`:   `unexpected "score"`,
+		`1.2.3 > priority`:      "bad number",
+		`priority == 1 == 1`:    `unexpected "=="`,
+		`!`:                     "unexpected end of expression",
+		`priority in 3 && ()`:   `unexpected ")"`,
+	}
+	for src, want := range cases {
+		_, err := Compile(src, testVars)
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Compile(%q) error = %v, want it to mention %q", src, err, want)
+		}
+	}
+}
+
+func TestExprEvalErrors(t *testing.T) {
+	cases := map[string]string{
+		`priority == "high"`: "can't compare number with string",
+		`labels == labels`:   "can't compare lists",
+		`priority && true`:   "needs bools",
+		`!priority`:          "! needs a bool",
+		`1 in labels`:        "needs a string on the left",
+		`"x" in priority`:    "string or list on the right",
+		`title < 1`:          "can't compare string with number",
+	}
+	for src, want := range cases {
+		e, err := Compile(src, testVars)
+		if err != nil {
+			t.Errorf("Compile(%q): %v", src, err)
+			continue
+		}
+		if _, err := e.Eval(testEnv()); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Eval(%q) error = %v, want it to mention %q", src, err, want)
+		}
+	}
+
+	e, _ := Compile(`priority`, testVars)
+	if _, err := e.EvalBool(testEnv()); err == nil || !strings.Contains(err.Error(), "not bool") {
+		t.Errorf("EvalBool on a number: %v", err)
+	}
+	if _, err := e.Eval(map[string]any{}); err == nil || !strings.Contains(err.Error(), "not set") {
+		t.Errorf("Eval without the variable: %v", err)
+	}
+}
@@ -0,0 +1,286 @@
+// Package rules evaluates team-defined scoring adjustments and alert rules
+// from .bv/rules.yaml:
+//
+//	score:
+//	  - name: security-first
+//	    when: '"security" in labels'
+//	    adjust: 0.2
+//	alerts:
+//	  - name: idle-p0
+//	    when: 'priority == 0 && status == "open" && updated_days > 3'
+//	    severity: warning
+//	    message: P0 untouched for 3 days
+//
+// Conditions are Expr expressions over the issue's fields (IssueVars), and
+// for score rules also its score components (ScoreVars). Evaluation depends
+// only on the issue and the analysis time, so results are deterministic.
+package rules
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ConfigFilename is the rules file's name under .bv/.
+const ConfigFilename = "rules.yaml"
+
+// ConfigPath returns the rules file path for a project.
+func ConfigPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", ConfigFilename)
+}
+
+// Config is .bv/rules.yaml as written.
+type Config struct {
+	Score  []ScoreRuleConfig `yaml:"score"`
+	Alerts []AlertRuleConfig `yaml:"alerts"`
+}
+
+// ScoreRuleConfig adds Adjust to the impact score of each open issue
+// matching When.
+type ScoreRuleConfig struct {
+	Name   string  `yaml:"name"`
+	When   string  `yaml:"when"`
+	Adjust float64 `yaml:"adjust"`
+}
+
+// AlertRuleConfig raises an alert for each open issue matching When.
+type AlertRuleConfig struct {
+	Name     string `yaml:"name"`
+	When     string `yaml:"when"`
+	Severity string `yaml:"severity"` // info (default), warning, or critical
+	Message  string `yaml:"message"`
+}
+
+// Set is a compiled rules file.
+type Set struct {
+	Score  []ScoreRule
+	Alerts []AlertRule
+}
+
+// ScoreRule is a compiled ScoreRuleConfig.
+type ScoreRule struct {
+	Name   string
+	When   *Expr
+	Adjust float64
+}
+
+// AlertRule is a compiled AlertRuleConfig.
+type AlertRule struct {
+	Name     string
+	When     *Expr
+	Severity string
+	Message  string
+}
+
+// Applied is a score rule that matched an issue, as shown in its score
+// breakdown.
+type Applied struct {
+	Name   string  `json:"name"`
+	Adjust float64 `json:"adjust"`
+}
+
+// IssueVars are the variables every rule condition can use.
+var IssueVars = map[string]bool{
+	"id":                true, // string
+	"title":             true,
+	"description":       true,
+	"status":            true,
+	"type":              true,
+	"assignee":          true,
+	"repo":              true, // source repo in a workspace, else ""
+	"priority":          true, // number, 0 = highest
+	"labels":            true, // list
+	"age_days":          true, // days since created
+	"updated_days":      true, // days since last update
+	"estimated_minutes": true, // 0 when unestimated
+	"dependencies":      true, // number of blocking dependencies
+	"has_due":           true, // bool
+	"due_days":          true, // days until due, negative when overdue, 0 without a due date
+}
+
+// ScoreVars are the extra variables score rules can use: the composite
+// score before rules and its normalized (0-1) components.
+var ScoreVars = map[string]bool{
+	"score":          true,
+	"pagerank":       true,
+	"betweenness":    true,
+	"blocker_ratio":  true,
+	"staleness":      true,
+	"priority_boost": true,
+	"time_to_impact": true,
+	"urgency":        true,
+	"risk":           true,
+}
+
+// Load reads and compiles the project's rules file. A missing file is an
+// empty Set, not an error.
+func Load(projectDir string) (*Set, error) {
+	data, err := os.ReadFile(ConfigPath(projectDir))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Set{}, nil
+		}
+		return nil, fmt.Errorf("reading rules: %w", err)
+	}
+	set, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", ConfigPath(projectDir), err)
+	}
+	return set, nil
+}
+
+// Parse compiles a rules file. Each condition is also evaluated once
+// against an empty issue, so type errors surface here rather than as rules
+// that silently never match.
+func Parse(data []byte) (*Set, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, fmt.Errorf("parsing rules: %w", err)
+	}
+	scoreVars := make(map[string]bool, len(IssueVars)+len(ScoreVars))
+	for _, vars := range []map[string]bool{IssueVars, ScoreVars} {
+		for k := range vars {
+			scoreVars[k] = true
+		}
+	}
+	probe := IssueEnv(&model.Issue{}, time.Time{})
+	probeScore := IssueEnv(&model.Issue{}, time.Time{})
+	for k := range ScoreVars {
+		probeScore[k] = 0.0
+	}
+
+	set := &Set{}
+	for i, r := range cfg.Score {
+		when, err := compileRule("score", i, r.Name, r.When, scoreVars, probeScore)
+		if err != nil {
+			return nil, err
+		}
+		set.Score = append(set.Score, ScoreRule{Name: r.Name, When: when, Adjust: r.Adjust})
+	}
+	for i, r := range cfg.Alerts {
+		when, err := compileRule("alert", i, r.Name, r.When, IssueVars, probe)
+		if err != nil {
+			return nil, err
+		}
+		switch r.Severity {
+		case "":
+			r.Severity = "info"
+		case "info", "warning", "critical":
+		default:
+			return nil, fmt.Errorf("alert rule %q: severity must be info, warning, or critical, not %q", r.Name, r.Severity)
+		}
+		if r.Message == "" {
+			r.Message = "Matches rule " + r.Name
+		}
+		set.Alerts = append(set.Alerts, AlertRule{Name: r.Name, When: when, Severity: r.Severity, Message: r.Message})
+	}
+	return set, nil
+}
+
+func compileRule(kind string, i int, name, when string, vars map[string]bool, probe map[string]any) (*Expr, error) {
+	if name == "" {
+		return nil, fmt.Errorf("%s rule #%d has no name", kind, i+1)
+	}
+	if when == "" {
+		return nil, fmt.Errorf("%s rule %q has no when condition", kind, name)
+	}
+	expr, err := Compile(when, vars)
+	if err != nil {
+		return nil, fmt.Errorf("%s rule %q: %w", kind, name, err)
+	}
+	if _, err := expr.EvalBool(probe); err != nil {
+		return nil, fmt.Errorf("%s rule %q: %w", kind, name, err)
+	}
+	return expr, nil
+}
+
+// Empty reports whether the set has no rules.
+func (s *Set) Empty() bool {
+	return s == nil || len(s.Score) == 0 && len(s.Alerts) == 0
+}
+
+// Adjust returns the total adjustment of the score rules matching vars
+// (IssueEnv plus ScoreVars), and which rules matched, in file order. A rule
+// whose condition fails to evaluate doesn't match.
+func (s *Set) Adjust(vars map[string]any) (float64, []Applied) {
+	if s == nil {
+		return 0, nil
+	}
+	var total float64
+	var applied []Applied
+	for _, r := range s.Score {
+		if ok, err := r.When.EvalBool(vars); err == nil && ok {
+			total += r.Adjust
+			applied = append(applied, Applied{Name: r.Name, Adjust: r.Adjust})
+		}
+	}
+	return total, applied
+}
+
+// MatchAlerts returns the alert rules matching vars (IssueEnv), in file
+// order.
+func (s *Set) MatchAlerts(vars map[string]any) []AlertRule {
+	if s == nil {
+		return nil
+	}
+	var matched []AlertRule
+	for _, r := range s.Alerts {
+		if ok, err := r.When.EvalBool(vars); err == nil && ok {
+			matched = append(matched, r)
+		}
+	}
+	return matched
+}
+
+// IssueEnv returns the IssueVars values for issue as of now.
+func IssueEnv(issue *model.Issue, now time.Time) map[string]any {
+	labels := issue.Labels
+	if labels == nil {
+		labels = []string{}
+	}
+	estimate := 0.0
+	if issue.EstimatedMinutes != nil {
+		estimate = float64(*issue.EstimatedMinutes)
+	}
+	deps := 0
+	for _, d := range issue.Dependencies {
+		if d != nil && d.Type.IsBlocking() {
+			deps++
+		}
+	}
+	dueDays := 0.0
+	if issue.DueDate != nil {
+		dueDays = daysBetween(now, *issue.DueDate)
+	}
+	return map[string]any{
+		"id":                issue.ID,
+		"title":             issue.Title,
+		"description":       issue.Description,
+		"status":            string(issue.Status),
+		"type":              string(issue.IssueType),
+		"assignee":          issue.Assignee,
+		"repo":              issue.SourceRepo,
+		"priority":          float64(issue.Priority),
+		"labels":            labels,
+		"age_days":          daysBetween(issue.CreatedAt, now),
+		"updated_days":      daysBetween(issue.UpdatedAt, now),
+		"estimated_minutes": estimate,
+		"dependencies":      float64(deps),
+		"has_due":           issue.DueDate != nil,
+		"due_days":          dueDays,
+	}
+}
+
+// daysBetween is the whole days from a to b; 0 when either is unset.
+func daysBetween(a, b time.Time) float64 {
+	if a.IsZero() || b.IsZero() {
+		return 0
+	}
+	return float64(int(b.Sub(a).Hours() / 24))
+}
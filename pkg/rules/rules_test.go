@@ -0,0 +1,137 @@
+package rules
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+const testRules = `
+score:
+  - name: security-first
+    when: '"security" in labels'
+    adjust: 0.2
+  - name: demote-low
+    when: priority >= 3 && score < 0.5
+    adjust: -0.1
+alerts:
+  - name: idle-p0
+    when: priority == 0 && updated_days > 3
+    severity: warning
+    message: P0 untouched for 3 days
+  - name: unowned
+    when: assignee == ""
+`
+
+func TestParseAndApply(t *testing.T) {
+	set, err := Parse([]byte(testRules))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(set.Score) != 2 || len(set.Alerts) != 2 || set.Empty() {
+		t.Fatalf("set = %+v", set)
+	}
+	if set.Alerts[1].Severity != "info" || set.Alerts[1].Message != "Matches rule unowned" {
+		t.Errorf("alert defaults = %+v", set.Alerts[1])
+	}
+
+	now := time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC)
+	issue := model.Issue{
+		ID: "A", Priority: 3, Labels: []string{"security"}, Assignee: "sam",
+		UpdatedAt: now.Add(-5 * 24 * time.Hour),
+	}
+	vars := IssueEnv(&issue, now)
+	vars["score"] = 0.3
+	total, applied := set.Adjust(vars)
+	if len(applied) != 2 || applied[0].Name != "security-first" || applied[1].Name != "demote-low" {
+		t.Errorf("applied = %+v, want both rules in file order", applied)
+	}
+	if total < 0.0999 || total > 0.1001 {
+		t.Errorf("total = %v, want 0.1", total)
+	}
+
+	issue.Priority = 0
+	issue.Assignee = ""
+	matched := set.MatchAlerts(IssueEnv(&issue, now))
+	if len(matched) != 2 || matched[0].Name != "idle-p0" {
+		t.Errorf("matched = %+v", matched)
+	}
+
+	var none *Set
+	if total, applied := none.Adjust(vars); total != 0 || applied != nil || !none.Empty() {
+		t.Error("a nil set should apply nothing")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	cases := map[string]string{
+		"score: [1":                  "parsing rules",
+		"score:\n  - when: 'true'\n": "score rule #1 has no name",
+		"score:\n  - name: x\n":      `score rule "x" has no when`,
+		"score:\n  - name: x\n    when: owner == 'me'\n":                 `unknown variable "owner"`,
+		"score:\n  - name: x\n    when: priority == 'high'\n":            "can't compare number with string",
+		"score:\n  - name: x\n    when: priority\n":                      "not bool",
+		"alerts:\n  - name: x\n    when: score > 1\n":                    `unknown variable "score"`,
+		"alerts:\n  - name: x\n    when: 'true'\n    severity: urgent\n": "severity must be",
+	}
+	for src, want := range cases {
+		if _, err := Parse([]byte(src)); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Parse(%q) error = %v, want it to mention %q", src, err, want)
+		}
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	set, err := Load(dir)
+	if err != nil || !set.Empty() {
+		t.Fatalf("missing file: set=%+v err=%v", set, err)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte("score:\n  - name: x\n    when: nope\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir); err == nil || !strings.Contains(err.Error(), "rules.yaml") {
+		t.Errorf("invalid file error = %v, want the path", err)
+	}
+}
+
+func TestIssueEnv(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+	due := now.Add(-48 * time.Hour)
+	est := 90
+	issue := model.Issue{
+		ID: "A", Status: model.StatusOpen, IssueType: model.TypeBug, Priority: 2,
+		EstimatedMinutes: &est, DueDate: &due,
+		CreatedAt: now.Add(-10 * 24 * time.Hour),
+		Dependencies: []*model.Dependency{
+			{DependsOnID: "B", Type: model.DepBlocks},
+			{DependsOnID: "C", Type: model.DepRelated},
+			nil,
+		},
+	}
+	env := IssueEnv(&issue, now)
+	want := map[string]any{
+		"status": "open", "type": "bug", "priority": 2.0, "estimated_minutes": 90.0,
+		"dependencies": 1.0, "has_due": true, "due_days": -2.0, "age_days": 10.0, "updated_days": 0.0,
+	}
+	for k, v := range want {
+		if env[k] != v {
+			t.Errorf("%s = %v, want %v", k, env[k], v)
+		}
+	}
+	if labels, ok := env["labels"].([]string); !ok || labels == nil {
+		t.Errorf("labels = %#v, want an empty list", env["labels"])
+	}
+	for k := range IssueVars {
+		if _, ok := env[k]; !ok {
+			t.Errorf("IssueEnv is missing %s", k)
+		}
+	}
+}
@@ -0,0 +1,143 @@
+// Package sarif writes SARIF 2.1.0 logs, the format GitHub code scanning
+// and other static-analysis dashboards import. It covers what bv reports:
+// one tool run with rules and results located at lines of files.
+package sarif
+
+import (
+	"io"
+	"sort"
+
+	json "github.com/goccy/go-json"
+)
+
+// Version and Schema identify the SARIF format written.
+const (
+	Version = "2.1.0"
+	Schema  = "https://json.schemastore.org/sarif-2.1.0.json"
+)
+
+// Level is a result's severity.
+type Level string
+
+const (
+	LevelError   Level = "error"
+	LevelWarning Level = "warning"
+	LevelNote    Level = "note"
+)
+
+// Log is a SARIF file.
+type Log struct {
+	Version string `json:"version"`
+	Schema  string `json:"$schema"`
+	Runs    []Run  `json:"runs"`
+}
+
+// Run is one invocation of a tool and what it found.
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+// Tool describes the analyzer.
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+// Driver is the analyzer's name, version and rules.
+type Driver struct {
+	Name           string `json:"name"`
+	Version        string `json:"version,omitempty"`
+	InformationURI string `json:"informationUri,omitempty"`
+	Rules          []Rule `json:"rules"`
+}
+
+// Rule describes one kind of result.
+type Rule struct {
+	ID                   string        `json:"id"`
+	Name                 string        `json:"name,omitempty"`
+	ShortDescription     Message       `json:"shortDescription"`
+	FullDescription      *Message      `json:"fullDescription,omitempty"`
+	Help                 *Message      `json:"help,omitempty"`
+	DefaultConfiguration *RuleDefaults `json:"defaultConfiguration,omitempty"`
+}
+
+// RuleDefaults is a rule's default level.
+type RuleDefaults struct {
+	Level Level `json:"level"`
+}
+
+// Message is plain text.
+type Message struct {
+	Text string `json:"text"`
+}
+
+// Result is one finding.
+type Result struct {
+	RuleID              string            `json:"ruleId"`
+	Level               Level             `json:"level"`
+	Message             Message           `json:"message"`
+	Locations           []Location        `json:"locations,omitempty"`
+	RelatedLocations    []Location        `json:"relatedLocations,omitempty"`
+	PartialFingerprints map[string]string `json:"partialFingerprints,omitempty"`
+}
+
+// Location is a region of a file.
+type Location struct {
+	ID               int              `json:"id,omitempty"`
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+	Message          *Message         `json:"message,omitempty"`
+}
+
+// PhysicalLocation is a file and a region in it.
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+// ArtifactLocation is a file, relative to the repository root.
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+// Region is a span of lines; StartLine is 1-based.
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// LineLocation returns a location at line of the file at uri; line 0
+// locates the whole file.
+func LineLocation(uri string, line int) Location {
+	loc := Location{PhysicalLocation: PhysicalLocation{ArtifactLocation: ArtifactLocation{URI: uri}}}
+	if line > 0 {
+		loc.PhysicalLocation.Region = &Region{StartLine: line}
+	}
+	return loc
+}
+
+// NewLog returns a log of one run of driver with results. The driver's rules
+// are narrowed to those the results use, in ID order.
+func NewLog(driver Driver, results []Result) Log {
+	used := make(map[string]bool)
+	for _, r := range results {
+		used[r.RuleID] = true
+	}
+	rules := make([]Rule, 0, len(used))
+	for _, rule := range driver.Rules {
+		if used[rule.ID] {
+			rules = append(rules, rule)
+		}
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].ID < rules[j].ID })
+	driver.Rules = rules
+	if results == nil {
+		results = []Result{}
+	}
+	return Log{Version: Version, Schema: Schema, Runs: []Run{{Tool: Tool{Driver: driver}, Results: results}}}
+}
+
+// Write encodes log to w as indented JSON.
+func Write(w io.Writer, log Log) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(log)
+}
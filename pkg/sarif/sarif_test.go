@@ -0,0 +1,33 @@
+package sarif
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestNewLog(t *testing.T) {
+	driver := Driver{Name: "bv", Rules: []Rule{{ID: "z"}, {ID: "b"}, {ID: "unused"}}}
+	log := NewLog(driver, []Result{
+		{RuleID: "z", Level: LevelError, Locations: []Location{LineLocation("a.jsonl", 3)}},
+		{RuleID: "b", Level: LevelNote, Locations: []Location{LineLocation("a.jsonl", 0)}},
+	})
+	rules := log.Runs[0].Tool.Driver.Rules
+	if len(rules) != 2 || rules[0].ID != "b" || rules[1].ID != "z" {
+		t.Errorf("rules = %+v, want b and z", rules)
+	}
+	if loc := log.Runs[0].Results[1].Locations[0]; loc.PhysicalLocation.Region != nil {
+		t.Errorf("line 0 has a region: %+v", loc)
+	}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, NewLog(Driver{Name: "bv"}, nil)); err != nil {
+		t.Fatal(err)
+	}
+	out := buf.String()
+	for _, want := range []string{`"version": "2.1.0"`, `"$schema": "` + Schema + `"`, `"results": []`, `"rules": []`} {
+		if !strings.Contains(out, want) {
+			t.Errorf("empty log lacks %s:\n%s", want, out)
+		}
+	}
+}
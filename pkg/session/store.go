@@ -0,0 +1,155 @@
+// Package session records which agent claimed which issue through bv, so
+// agents sharing a repository don't pick the same work in the window before
+// bd's JSONL export catches up with the claim.
+package session
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// DefaultFilename is the session store's file name under .bv/.
+const DefaultFilename = "sessions.json"
+
+// ClaimGrace is how long a claim is trusted while the beads data still
+// shows the issue open: long enough for bd to flush its export, short
+// enough that an abandoned claim doesn't hide the issue for good.
+const ClaimGrace = 10 * time.Minute
+
+// Claim is one issue claimed by an agent through bv.
+type Claim struct {
+	IssueID   string    `json:"issue_id"`
+	Agent     string    `json:"agent,omitempty"`
+	ClaimedAt time.Time `json:"claimed_at"`
+	Command   string    `json:"command"` // bd command that made the claim
+
+	// Where the agent works: its git branch (empty when detached or
+	// outside git) and the top of its worktree
+	Branch   string `json:"branch,omitempty"`
+	Worktree string `json:"worktree,omitempty"`
+}
+
+// Store is the set of claims recorded for a project.
+type Store struct {
+	Claims []Claim `json:"claims"`
+}
+
+// DefaultPath returns the session store path for a project.
+func DefaultPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", DefaultFilename)
+}
+
+// Load reads the store at path. A missing file is an empty store.
+func Load(path string) (*Store, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Store{Claims: []Claim{}}, nil
+		}
+		return nil, fmt.Errorf("reading session store: %w", err)
+	}
+	var s Store
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing session store: %w", err)
+	}
+	if s.Claims == nil {
+		s.Claims = []Claim{}
+	}
+	return &s, nil
+}
+
+// Save writes the store to path, replacing it atomically. Callers that
+// read, modify, and save must hold the repository's exclusive lock.
+func (s *Store) Save(path string) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return fmt.Errorf("encoding session store: %w", err)
+	}
+	tmp, err := os.CreateTemp(dir, ".sessions-*")
+	if err != nil {
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("writing session store: %w", err)
+	}
+	return nil
+}
+
+// Add records a claim, replacing any earlier claim on the same issue.
+func (s *Store) Add(c Claim) {
+	for i := range s.Claims {
+		if s.Claims[i].IssueID == c.IssueID {
+			s.Claims[i] = c
+			return
+		}
+	}
+	s.Claims = append(s.Claims, c)
+}
+
+// Holder returns the claim on issueID, if any.
+func (s *Store) Holder(issueID string) (Claim, bool) {
+	for _, c := range s.Claims {
+		if c.IssueID == issueID {
+			return c, true
+		}
+	}
+	return Claim{}, false
+}
+
+// Holders maps each claimed issue ID to the agent holding it.
+func (s *Store) Holders() map[string]string {
+	holders := make(map[string]string, len(s.Claims))
+	for _, c := range s.Claims {
+		holders[c.IssueID] = c.Agent
+	}
+	return holders
+}
+
+// ClaimsBy returns the claims made by agent, oldest first.
+func (s *Store) ClaimsBy(agent string) []Claim {
+	var out []Claim
+	for _, c := range s.Claims {
+		if c.Agent == agent {
+			out = append(out, c)
+		}
+	}
+	return out
+}
+
+// Prune drops claims the beads data has overtaken: issues that are gone or
+// closed, and issues still open (not in progress) after ClaimGrace, which
+// means the claim never landed or was undone. It reports whether anything
+// was dropped.
+func (s *Store) Prune(issues map[string]model.Issue, now time.Time) bool {
+	kept := s.Claims[:0]
+	for _, c := range s.Claims {
+		issue, ok := issues[c.IssueID]
+		switch {
+		case !ok, issue.Status.IsClosed(), issue.Status.IsTombstone():
+			continue
+		case issue.Status != model.StatusInProgress && now.Sub(c.ClaimedAt) > ClaimGrace:
+			continue
+		}
+		kept = append(kept, c)
+	}
+	changed := len(kept) != len(s.Claims)
+	s.Claims = kept
+	return changed
+}
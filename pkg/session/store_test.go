@@ -0,0 +1,83 @@
+package session
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestLoadMissingIsEmpty(t *testing.T) {
+	s, err := Load(filepath.Join(t.TempDir(), "none.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Claims == nil || len(s.Claims) != 0 {
+		t.Errorf("Claims = %#v, want empty", s.Claims)
+	}
+}
+
+func TestSaveLoadRoundTrip(t *testing.T) {
+	path := DefaultPath(t.TempDir())
+	at := time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC)
+	s := &Store{}
+	s.Add(Claim{IssueID: "A-1", Agent: "alice", ClaimedAt: at, Command: "bd update A-1"})
+	s.Add(Claim{IssueID: "A-2", Agent: "bob", ClaimedAt: at})
+	s.Add(Claim{IssueID: "A-1", Agent: "carol", ClaimedAt: at}) // replaces alice's
+	if err := s.Save(path); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got.Claims) != 2 {
+		t.Fatalf("Claims = %+v", got.Claims)
+	}
+	if c, ok := got.Holder("A-1"); !ok || c.Agent != "carol" || !c.ClaimedAt.Equal(at) {
+		t.Errorf("Holder(A-1) = %+v, %v", c, ok)
+	}
+	if by := got.ClaimsBy("bob"); len(by) != 1 || by[0].IssueID != "A-2" {
+		t.Errorf("ClaimsBy(bob) = %+v", by)
+	}
+	if _, ok := got.Holder("A-9"); ok {
+		t.Error("Holder(A-9) should be absent")
+	}
+	if h := got.Holders(); len(h) != 2 || h["A-1"] != "carol" || h["A-2"] != "bob" {
+		t.Errorf("Holders = %v", h)
+	}
+}
+
+func TestPrune(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	fresh := now.Add(-time.Minute)
+	old := now.Add(-2 * ClaimGrace)
+	s := &Store{Claims: []Claim{
+		{IssueID: "gone", ClaimedAt: fresh},
+		{IssueID: "closed", ClaimedAt: fresh},
+		{IssueID: "landed", ClaimedAt: old},    // in progress: kept however old
+		{IssueID: "pending", ClaimedAt: fresh}, // open, bd not flushed yet
+		{IssueID: "abandoned", ClaimedAt: old}, // open long after the claim
+	}}
+	issues := map[string]model.Issue{
+		"closed":    {ID: "closed", Status: model.StatusClosed},
+		"landed":    {ID: "landed", Status: model.StatusInProgress},
+		"pending":   {ID: "pending", Status: model.StatusOpen},
+		"abandoned": {ID: "abandoned", Status: model.StatusOpen},
+	}
+	if !s.Prune(issues, now) {
+		t.Error("Prune should report a change")
+	}
+	var ids []string
+	for _, c := range s.Claims {
+		ids = append(ids, c.IssueID)
+	}
+	if len(ids) != 2 || ids[0] != "landed" || ids[1] != "pending" {
+		t.Errorf("kept %v, want [landed pending]", ids)
+	}
+	if s.Prune(issues, now) {
+		t.Error("second Prune should be a no-op")
+	}
+}
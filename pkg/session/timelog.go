@@ -0,0 +1,151 @@
+package session
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// TimeLogFilename is the time log's file name under .bv/.
+const TimeLogFilename = "timelog.jsonl"
+
+// How a work session ended.
+const (
+	OutcomeClosed    = "closed"    // The issue was closed
+	OutcomeUnclaimed = "unclaimed" // The claim was given up
+)
+
+// Work is the time between claiming an issue through bv and closing or
+// unclaiming it: one line of the time log.
+type Work struct {
+	IssueID string    `json:"issue_id"`
+	Agent   string    `json:"agent,omitempty"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end"`
+	Seconds int64     `json:"seconds"`
+	Outcome string    `json:"outcome"`
+}
+
+// Logged is the time logged on one issue.
+type Logged struct {
+	Total    time.Duration
+	Sessions int
+}
+
+// TimeLogPath returns the time log path for a project.
+func TimeLogPath(projectDir string) string {
+	return filepath.Join(projectDir, ".bv", TimeLogFilename)
+}
+
+// Finish ends the work session claim c started, at end.
+func (c Claim) Finish(outcome string, end time.Time) Work {
+	if end.Before(c.ClaimedAt) {
+		end = c.ClaimedAt
+	}
+	return Work{
+		IssueID: c.IssueID,
+		Agent:   c.Agent,
+		Start:   c.ClaimedAt,
+		End:     end.UTC(),
+		Seconds: int64(end.Sub(c.ClaimedAt) / time.Second),
+		Outcome: outcome,
+	}
+}
+
+// Release drops the claim on issueID and returns it.
+func (s *Store) Release(issueID string) (Claim, bool) {
+	for i, c := range s.Claims {
+		if c.IssueID == issueID {
+			s.Claims = append(s.Claims[:i], s.Claims[i+1:]...)
+			return c, true
+		}
+	}
+	return Claim{}, false
+}
+
+// Finished returns the work sessions of claims whose issues the beads data
+// shows closed, ending when the issue closed (now if it doesn't say). Log
+// them before Prune drops the claims.
+func (s *Store) Finished(issues map[string]model.Issue, now time.Time) []Work {
+	var done []Work
+	for _, c := range s.Claims {
+		issue, ok := issues[c.IssueID]
+		if !ok || !issue.Status.IsClosed() {
+			continue
+		}
+		end := now
+		if issue.ClosedAt != nil {
+			end = *issue.ClosedAt
+		}
+		done = append(done, c.Finish(OutcomeClosed, end))
+	}
+	return done
+}
+
+// AppendWork adds work sessions to the time log at path.
+func AppendWork(path string, work ...Work) error {
+	if len(work) == 0 {
+		return nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("creating directory: %w", err)
+	}
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return fmt.Errorf("writing time log: %w", err)
+	}
+	enc := json.NewEncoder(f)
+	for _, w := range work {
+		if err := enc.Encode(w); err != nil {
+			f.Close()
+			return fmt.Errorf("writing time log: %w", err)
+		}
+	}
+	if err := f.Close(); err != nil {
+		return fmt.Errorf("writing time log: %w", err)
+	}
+	return nil
+}
+
+// LoadTimeLog reads the time log at path. A missing file is an empty log;
+// lines that don't parse, such as one cut short by a crash, are skipped.
+func LoadTimeLog(path string) ([]Work, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("reading time log: %w", err)
+	}
+	defer f.Close()
+
+	var work []Work
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var w Work
+		if json.Unmarshal(scanner.Bytes(), &w) == nil && w.IssueID != "" {
+			work = append(work, w)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading time log: %w", err)
+	}
+	return work, nil
+}
+
+// Totals sums the logged time per issue.
+func Totals(work []Work) map[string]Logged {
+	totals := make(map[string]Logged)
+	for _, w := range work {
+		t := totals[w.IssueID]
+		t.Total += time.Duration(w.Seconds) * time.Second
+		t.Sessions++
+		totals[w.IssueID] = t
+	}
+	return totals
+}
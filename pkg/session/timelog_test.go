@@ -0,0 +1,83 @@
+package session
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFinishedAndRelease(t *testing.T) {
+	now := time.Date(2026, 1, 2, 12, 0, 0, 0, time.UTC)
+	closedAt := now.Add(-10 * time.Minute)
+	s := &Store{Claims: []Claim{
+		{IssueID: "done", Agent: "alice", ClaimedAt: now.Add(-time.Hour)},
+		{IssueID: "undated", ClaimedAt: now.Add(-5 * time.Minute)},
+		{IssueID: "working", ClaimedAt: now.Add(-time.Hour)},
+	}}
+	issues := map[string]model.Issue{
+		"done":    {ID: "done", Status: model.StatusClosed, ClosedAt: &closedAt},
+		"undated": {ID: "undated", Status: model.StatusClosed},
+		"working": {ID: "working", Status: model.StatusInProgress},
+	}
+	done := s.Finished(issues, now)
+	if len(done) != 2 {
+		t.Fatalf("Finished = %+v, want done and undated", done)
+	}
+	if w := done[0]; w.IssueID != "done" || w.Agent != "alice" || w.Seconds != 50*60 || w.Outcome != OutcomeClosed {
+		t.Errorf("done = %+v, want 50m ended at close", w)
+	}
+	if w := done[1]; w.Seconds != 5*60 || !w.End.Equal(now) {
+		t.Errorf("undated = %+v, want 5m ended now", w)
+	}
+
+	c, ok := s.Release("working")
+	if !ok || c.IssueID != "working" || len(s.Claims) != 2 {
+		t.Errorf("Release = %+v, %v; claims %+v", c, ok, s.Claims)
+	}
+	if _, ok := s.Release("working"); ok {
+		t.Error("released twice")
+	}
+}
+
+func TestTimeLog(t *testing.T) {
+	path := TimeLogPath(t.TempDir())
+	if work, err := LoadTimeLog(path); err != nil || len(work) != 0 {
+		t.Fatalf("missing log: %+v, %v", work, err)
+	}
+
+	start := time.Date(2026, 1, 2, 9, 0, 0, 0, time.UTC)
+	a := Claim{IssueID: "A-1", ClaimedAt: start}
+	if err := AppendWork(path, a.Finish(OutcomeUnclaimed, start.Add(20*time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	if err := AppendWork(path,
+		a.Finish(OutcomeClosed, start.Add(time.Hour)),
+		Claim{IssueID: "B-1", ClaimedAt: start}.Finish(OutcomeClosed, start.Add(-time.Minute))); err != nil {
+		t.Fatal(err)
+	}
+	// A line cut short by a crash doesn't lose the rest
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString(`{"issue_id":"A-1","sec`)
+	f.Close()
+
+	work, err := LoadTimeLog(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	totals := Totals(work)
+	if got := totals["A-1"]; got.Total != 80*time.Minute || got.Sessions != 2 {
+		t.Errorf("A-1 = %+v, want 1h20m over 2 sessions", got)
+	}
+	if got := totals["B-1"]; got.Total != 0 || got.Sessions != 1 {
+		t.Errorf("B-1 = %+v, an end before the claim counts as 0", got)
+	}
+	if filepath.Base(path) != TimeLogFilename {
+		t.Errorf("TimeLogPath = %s", path)
+	}
+}
@@ -3,8 +3,11 @@ package testutil
 import (
 	"encoding/json"
 	"fmt"
+	"math"
 	"os"
 	"path/filepath"
+	"reflect"
+	"sort"
 	"strings"
 	"testing"
 
@@ -210,6 +213,13 @@ func NewGoldenFile(t *testing.T, dir, name string) *GoldenFile {
 	}
 }
 
+// SetUpdate forces update mode (e.g. from a test's -update flag) in addition
+// to GENERATE_GOLDEN.
+func (g *GoldenFile) SetUpdate(update bool) *GoldenFile {
+	g.update = g.update || update
+	return g
+}
+
 // Path returns the full path to the golden file.
 func (g *GoldenFile) Path() string {
 	return filepath.Join(g.dir, g.name)
@@ -278,6 +288,100 @@ func (g *GoldenFile) AssertJSON(actual interface{}) {
 	g.Assert(string(data))
 }
 
+// AssertJSONWithin compares actual as JSON against the golden file, letting
+// numbers differ by relTol (relative). Use it for scores that depend on
+// elapsed wall-clock time. In update mode it rewrites the file like
+// AssertJSON.
+func (g *GoldenFile) AssertJSONWithin(actual interface{}, relTol float64) {
+	g.t.Helper()
+
+	if g.update {
+		g.AssertJSON(actual)
+		return
+	}
+
+	path := g.Path()
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			g.t.Fatalf("golden file does not exist: %s\nRun with GENERATE_GOLDEN=1 to create it", path)
+		}
+		g.t.Fatalf("failed to read golden file: %v", err)
+	}
+	var expected interface{}
+	if err := json.Unmarshal(data, &expected); err != nil {
+		g.t.Fatalf("failed to parse golden file %s: %v", path, err)
+	}
+	actualJSON, err := json.Marshal(actual)
+	if err != nil {
+		g.t.Fatalf("failed to marshal actual value: %v", err)
+	}
+	var got interface{}
+	if err := json.Unmarshal(actualJSON, &got); err != nil {
+		g.t.Fatalf("failed to decode actual value: %v", err)
+	}
+
+	if where, ok := jsonDiff("$", expected, got, relTol); !ok {
+		g.t.Errorf("golden file %s mismatch at %s", path, where)
+	}
+}
+
+// jsonDiff reports the first difference between decoded JSON values a and
+// b, or ok=true when they match (numbers within relTol).
+func jsonDiff(path string, a, b interface{}, relTol float64) (string, bool) {
+	switch av := a.(type) {
+	case map[string]interface{}:
+		bv, isMap := b.(map[string]interface{})
+		if !isMap {
+			return fmt.Sprintf("%s: expected object, got %T", path, b), false
+		}
+		keys := make([]string, 0, len(av)+len(bv))
+		for k := range av {
+			keys = append(keys, k)
+		}
+		for k := range bv {
+			if _, ok := av[k]; !ok {
+				keys = append(keys, k)
+			}
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			if where, ok := jsonDiff(path+"."+k, av[k], bv[k], relTol); !ok {
+				return where, false
+			}
+		}
+		return "", true
+	case []interface{}:
+		bv, isSlice := b.([]interface{})
+		if !isSlice {
+			return fmt.Sprintf("%s: expected array, got %T", path, b), false
+		}
+		if len(av) != len(bv) {
+			return fmt.Sprintf("%s: expected %d elements, got %d", path, len(av), len(bv)), false
+		}
+		for i := range av {
+			if where, ok := jsonDiff(fmt.Sprintf("%s[%d]", path, i), av[i], bv[i], relTol); !ok {
+				return where, false
+			}
+		}
+		return "", true
+	case float64:
+		bf, isNum := b.(float64)
+		if !isNum {
+			return fmt.Sprintf("%s: expected %v, got %v", path, a, b), false
+		}
+		if math.Abs(av-bf) > relTol*math.Max(math.Abs(av), math.Abs(bf)) {
+			return fmt.Sprintf("%s: expected %v, got %v", path, av, bf), false
+		}
+		return "", true
+	default:
+		if !reflect.DeepEqual(a, b) {
+			return fmt.Sprintf("%s: expected %v, got %v", path, a, b), false
+		}
+		return "", true
+	}
+}
+
 // TempDir helpers
 
 // TempBeadsDir creates a temporary directory with a .beads subdirectory
@@ -0,0 +1,204 @@
+// Package timefmt formats issue timestamps for people: relative ("3d ago")
+// or absolute ("2025-01-02 15:04") in a chosen time zone, with the words and
+// layouts taken from the i18n catalog. The style is process-wide so the
+// list, detail and board always agree; the TUI toggles it.
+package timefmt
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+)
+
+// Style selects relative or absolute timestamps.
+type Style string
+
+const (
+	StyleRelative Style = "relative"
+	StyleAbsolute Style = "absolute"
+)
+
+// ParseStyle parses "relative" or "absolute" (case-insensitive). Empty
+// means relative.
+func ParseStyle(s string) (Style, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "relative":
+		return StyleRelative, nil
+	case "absolute":
+		return StyleAbsolute, nil
+	default:
+		return "", fmt.Errorf("unknown time format %q (want relative or absolute)", s)
+	}
+}
+
+// LoadLocation resolves a time zone name: "local" (or empty) for the system
+// zone, "UTC", or an IANA name such as "Europe/Berlin".
+func LoadLocation(name string) (*time.Location, error) {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+	if strings.EqualFold(name, "utc") {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", name)
+	}
+	return loc, nil
+}
+
+// Options is the process-wide formatting configuration.
+type Options struct {
+	Style Style
+	// Location is the zone absolute times are shown in; nil means local.
+	Location *time.Location
+	// Clock is the reference for relative times; nil means time.Now.
+	// Snapshot tests pin it so "3d ago" doesn't drift.
+	Clock func() time.Time
+}
+
+var current atomic.Pointer[Options]
+
+// Set replaces the formatting options.
+func Set(o Options) {
+	current.Store(&o)
+}
+
+// Current returns the options set by Set, or relative times in the local
+// zone if Set was never called.
+func Current() Options {
+	if o := current.Load(); o != nil {
+		return *o
+	}
+	return Options{Style: StyleRelative}
+}
+
+// Toggle switches between relative and absolute times and returns the new
+// options.
+func Toggle() Options {
+	o := Current()
+	if o.Style == StyleAbsolute {
+		o.Style = StyleRelative
+	} else {
+		o.Style = StyleAbsolute
+	}
+	Set(o)
+	return o
+}
+
+// Now returns the current time by the configured clock.
+func Now() time.Time {
+	if clock := Current().Clock; clock != nil {
+		return clock()
+	}
+	return time.Now()
+}
+
+// location returns the configured zone.
+func (o Options) location() *time.Location {
+	if o.Location == nil {
+		return time.Local
+	}
+	return o.Location
+}
+
+// Zone names the configured zone for status messages: "local" or the
+// location name.
+func (o Options) Zone() string {
+	if o.Location == nil || o.Location == time.Local {
+		return "local"
+	}
+	return o.Location.String()
+}
+
+// Format renders t in the current style: "3d ago" or "2025-01-02 15:04".
+func Format(t time.Time) string {
+	if Current().Style == StyleAbsolute {
+		return Absolute(t)
+	}
+	return Relative(t, Now())
+}
+
+// Short renders t in the current style for narrow columns: relative times
+// as in Format, absolute ones as the time of day for today, the month and
+// day for this year, and the year otherwise.
+func Short(t time.Time) string {
+	o := Current()
+	if o.Style != StyleAbsolute {
+		return Relative(t, Now())
+	}
+	if t.IsZero() {
+		return i18n.T("time.unknown")
+	}
+	loc := o.location()
+	t, now := t.In(loc), Now().In(loc)
+	switch {
+	case t.Year() == now.Year() && t.YearDay() == now.YearDay():
+		return t.Format(i18n.T("time.layout.clock"))
+	case t.Year() == now.Year():
+		return t.Format(i18n.T("time.layout.day"))
+	default:
+		return t.Format("2006")
+	}
+}
+
+// Absolute renders t as date and time in the configured zone. The zone
+// abbreviation is appended unless it is the local zone.
+func Absolute(t time.Time) string {
+	if t.IsZero() {
+		return i18n.T("time.unknown")
+	}
+	o := Current()
+	t = t.In(o.location())
+	s := t.Format(i18n.T("time.layout.datetime"))
+	if o.Zone() != "local" {
+		s += " " + t.Format("MST")
+	}
+	return s
+}
+
+// Date renders the calendar date of t in the configured zone.
+func Date(t time.Time) string {
+	if t.IsZero() {
+		return i18n.T("time.unknown")
+	}
+	return t.In(Current().location()).Format(i18n.T("time.layout.date"))
+}
+
+// Relative renders how long before now t was: "now", "5m ago", "3h ago",
+// "2d ago", "1w ago", "4mo ago", "2y ago". Times in the future count as
+// now.
+func Relative(t, now time.Time) string {
+	if t.IsZero() {
+		return i18n.T("time.unknown")
+	}
+	d := now.Sub(t)
+	if d < time.Minute {
+		return i18n.T("time.now")
+	}
+	return i18n.T("time.ago", Span(d))
+}
+
+// Span renders a duration at the coarsest unit that fits, rounding down:
+// "5m", "3h", "2d", "1w", "4mo", "2y". Durations under a minute are "0m".
+func Span(d time.Duration) string {
+	const day = 24 * time.Hour
+	switch {
+	case d < time.Hour:
+		return i18n.T("time.span.minutes", int(d/time.Minute))
+	case d < day:
+		return i18n.T("time.span.hours", int(d/time.Hour))
+	case d < 7*day:
+		return i18n.T("time.span.days", int(d/day))
+	case d < 30*day:
+		return i18n.T("time.span.weeks", int(d/(7*day)))
+	case d < 365*day:
+		return i18n.T("time.span.months", int(d/(30*day)))
+	default:
+		return i18n.T("time.span.years", int(d/(365*day)))
+	}
+}
@@ -0,0 +1,113 @@
+package timefmt
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func withOptions(t *testing.T, o Options) {
+	t.Helper()
+	prev := current.Load()
+	Set(o)
+	t.Cleanup(func() { current.Store(prev) })
+}
+
+func TestRelative(t *testing.T) {
+	now := time.Date(2025, 6, 15, 12, 0, 0, 0, time.UTC)
+	day := 24 * time.Hour
+	tests := []struct {
+		ago  time.Duration
+		want string
+	}{
+		{0, "now"},
+		{-time.Hour, "now"}, // future
+		{30 * time.Second, "now"},
+		{10 * time.Minute, "10m ago"},
+		{2 * time.Hour, "2h ago"},
+		{25 * time.Hour, "1d ago"},
+		{8 * day, "1w ago"},
+		{29 * day, "4w ago"},
+		{60 * day, "2mo ago"},
+		{400 * day, "1y ago"},
+	}
+	for _, tt := range tests {
+		if got := Relative(now.Add(-tt.ago), now); got != tt.want {
+			t.Errorf("Relative(-%v) = %q, want %q", tt.ago, got, tt.want)
+		}
+	}
+	if got := Relative(time.Time{}, now); got != "unknown" {
+		t.Errorf("Relative(zero) = %q, want unknown", got)
+	}
+}
+
+func TestParseStyle(t *testing.T) {
+	for in, want := range map[string]Style{"": StyleRelative, "Relative": StyleRelative, " absolute ": StyleAbsolute} {
+		if got, err := ParseStyle(in); err != nil || got != want {
+			t.Errorf("ParseStyle(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := ParseStyle("iso"); err == nil {
+		t.Error("ParseStyle(iso) succeeded")
+	}
+}
+
+func TestLoadLocation(t *testing.T) {
+	for name, want := range map[string]*time.Location{"": time.Local, "Local": time.Local, "utc": time.UTC} {
+		if got, err := LoadLocation(name); err != nil || got != want {
+			t.Errorf("LoadLocation(%q) = %v, %v", name, got, err)
+		}
+	}
+	if _, err := LoadLocation("Mars/Olympus_Mons"); err == nil {
+		t.Error("LoadLocation accepted an unknown zone")
+	}
+}
+
+func TestFormatFollowsStyle(t *testing.T) {
+	ts := time.Date(2024, 3, 5, 14, 7, 0, 0, time.UTC)
+
+	withOptions(t, Options{Style: StyleRelative})
+	if got := Format(ts); !strings.HasSuffix(got, " ago") {
+		t.Errorf("relative Format = %q", got)
+	}
+
+	withOptions(t, Options{Style: StyleAbsolute, Location: time.UTC})
+	if got := Format(ts); got != "2024-03-05 14:07 UTC" {
+		t.Errorf("absolute Format = %q", got)
+	}
+	if got := Date(ts); got != "2024-03-05" {
+		t.Errorf("Date = %q", got)
+	}
+
+	tokyo := time.FixedZone("JST", 9*3600)
+	withOptions(t, Options{Style: StyleAbsolute, Location: tokyo})
+	if got := Format(ts); got != "2024-03-05 23:07 JST" {
+		t.Errorf("Format in JST = %q", got)
+	}
+}
+
+func TestShort(t *testing.T) {
+	withOptions(t, Options{Style: StyleAbsolute, Location: time.UTC})
+	now := time.Now().UTC()
+	if got := Short(now); got != now.Format("15:04") {
+		t.Errorf("Short(today) = %q", got)
+	}
+	if got := Short(time.Date(2001, 7, 9, 0, 0, 0, 0, time.UTC)); got != "2001" {
+		t.Errorf("Short(2001) = %q", got)
+	}
+	for _, ts := range []time.Time{now, now.AddDate(0, -3, 0), now.AddDate(-5, 0, 0)} {
+		if got := Short(ts); len(got) > 6 {
+			t.Errorf("Short(%v) = %q, too wide for a board card", ts, got)
+		}
+	}
+}
+
+func TestToggle(t *testing.T) {
+	withOptions(t, Options{Style: StyleRelative, Location: time.UTC})
+	if o := Toggle(); o.Style != StyleAbsolute || o.Zone() != "UTC" {
+		t.Errorf("Toggle = %+v", o)
+	}
+	if o := Toggle(); o.Style != StyleRelative {
+		t.Errorf("second Toggle = %+v", o)
+	}
+}
@@ -0,0 +1,131 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ActivityEntry is one row of an issue's activity timeline.
+type ActivityEntry struct {
+	When    time.Time
+	Kind    string // created, claimed, closed, reopened, modified, dependency, comment
+	Summary string // Human-readable detail, may be empty
+	Actor   string // Author or commenter, may be empty
+	SHA     string // Commit that recorded the change, empty for field-derived entries
+}
+
+// BuildIssueActivity reconstructs an issue's timeline, oldest first.
+//
+// Git lifecycle events (from the correlation report) are the primary source
+// because they record who changed what and when. Fields on the issue itself
+// (created_at, closed_at, dependency and comment timestamps) fill the gaps
+// when git history is unavailable or doesn't cover the change.
+func BuildIssueActivity(issue model.Issue, hist *correlation.BeadHistory) []ActivityEntry {
+	var entries []ActivityEntry
+	seenKinds := make(map[string]bool)
+	seenDeps := make(map[string]bool)
+
+	if hist != nil {
+		for _, ev := range hist.Events {
+			kind := string(ev.EventType)
+			seenKinds[kind] = true
+			for _, part := range strings.Split(ev.Details, "; ") {
+				if strings.HasPrefix(part, "+dep ") {
+					seenDeps[strings.TrimPrefix(part, "+dep ")] = true
+				}
+			}
+			entries = append(entries, ActivityEntry{
+				When:    ev.Timestamp,
+				Kind:    kind,
+				Summary: ev.Details,
+				Actor:   ev.Author,
+				SHA:     ev.CommitSHA,
+			})
+		}
+	}
+
+	if !seenKinds[string(correlation.EventCreated)] && !issue.CreatedAt.IsZero() {
+		entries = append(entries, ActivityEntry{When: issue.CreatedAt, Kind: string(correlation.EventCreated)})
+	}
+
+	for _, dep := range issue.Dependencies {
+		if dep == nil || dep.CreatedAt.IsZero() || seenDeps[dep.DependsOnID] {
+			continue
+		}
+		rel := "depends on"
+		if dep.Type == model.DepBlocks {
+			rel = "blocked by"
+		}
+		entries = append(entries, ActivityEntry{
+			When:    dep.CreatedAt,
+			Kind:    "dependency",
+			Summary: fmt.Sprintf("%s %s", rel, dep.DependsOnID),
+			Actor:   dep.CreatedBy,
+		})
+	}
+
+	for _, c := range issue.Comments {
+		if c == nil || c.CreatedAt.IsZero() {
+			continue
+		}
+		entries = append(entries, ActivityEntry{When: c.CreatedAt, Kind: "comment", Actor: c.Author})
+	}
+
+	if issue.ClosedAt != nil && !seenKinds[string(correlation.EventClosed)] {
+		entries = append(entries, ActivityEntry{When: *issue.ClosedAt, Kind: string(correlation.EventClosed)})
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].When.Before(entries[j].When)
+	})
+	return entries
+}
+
+// activityIcon returns the icon for a timeline entry kind.
+func activityIcon(kind string) string {
+	switch kind {
+	case "dependency":
+		return "🔗"
+	case "comment":
+		return "💬"
+	default:
+		return getEventIcon(correlation.EventType(kind))
+	}
+}
+
+// renderActivityMD renders the activity timeline as markdown for the detail
+// pane. Long timelines keep the most recent entries.
+func renderActivityMD(entries []ActivityEntry, limit int) string {
+	if len(entries) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("### 🕒 Activity\n\n")
+
+	if limit > 0 && len(entries) > limit {
+		sb.WriteString(fmt.Sprintf("*… %d earlier events*\n\n", len(entries)-limit))
+		entries = entries[len(entries)-limit:]
+	}
+
+	for _, e := range entries {
+		line := fmt.Sprintf("- `%s` %s **%s**", FormatTime(e.When), activityIcon(e.Kind), e.Kind)
+		if e.Summary != "" {
+			line += " — " + e.Summary
+		}
+		if e.Actor != "" {
+			line += " by " + e.Actor
+		}
+		if len(e.SHA) >= 7 {
+			line += fmt.Sprintf(" (`%s`)", e.SHA[:7])
+		}
+		sb.WriteString(line + "\n")
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
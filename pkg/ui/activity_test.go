@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestBuildIssueActivity_FieldsOnly(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	closed := base.Add(72 * time.Hour)
+	issue := model.Issue{
+		ID:        "bv-1",
+		CreatedAt: base,
+		ClosedAt:  &closed,
+		Dependencies: []*model.Dependency{
+			{IssueID: "bv-1", DependsOnID: "bv-2", Type: model.DepBlocks, CreatedAt: base.Add(time.Hour), CreatedBy: "alice"},
+			{IssueID: "bv-1", DependsOnID: "bv-3", Type: model.DepRelated}, // no timestamp: skipped
+		},
+		Comments: []*model.Comment{
+			{Author: "bob", Text: "on it", CreatedAt: base.Add(24 * time.Hour)},
+		},
+	}
+
+	entries := BuildIssueActivity(issue, nil)
+	var kinds []string
+	for _, e := range entries {
+		kinds = append(kinds, e.Kind)
+	}
+	want := []string{"created", "dependency", "comment", "closed"}
+	if strings.Join(kinds, ",") != strings.Join(want, ",") {
+		t.Fatalf("kinds = %v, want %v", kinds, want)
+	}
+	if entries[1].Summary != "blocked by bv-2" || entries[1].Actor != "alice" {
+		t.Errorf("dependency entry = %+v", entries[1])
+	}
+}
+
+func TestBuildIssueActivity_GitEventsTakePrecedence(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	issue := model.Issue{
+		ID:        "bv-1",
+		CreatedAt: base,
+		Dependencies: []*model.Dependency{
+			{IssueID: "bv-1", DependsOnID: "bv-2", Type: model.DepBlocks, CreatedAt: base.Add(time.Hour)},
+		},
+	}
+	hist := &correlation.BeadHistory{
+		BeadID: "bv-1",
+		Events: []correlation.BeadEvent{
+			{BeadID: "bv-1", EventType: correlation.EventModified, Timestamp: base.Add(2 * time.Hour), Author: "alice", CommitSHA: "abcdef1234", Details: "priority P2 → P1; +dep bv-2"},
+			{BeadID: "bv-1", EventType: correlation.EventCreated, Timestamp: base, Author: "alice", CommitSHA: "1234567890"},
+		},
+	}
+
+	entries := BuildIssueActivity(issue, hist)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 entries (field fallbacks deduped), got %d: %+v", len(entries), entries)
+	}
+	if entries[0].Kind != "created" || entries[1].Kind != "modified" {
+		t.Errorf("unexpected order: %+v", entries)
+	}
+
+	md := renderActivityMD(entries, 10)
+	for _, want := range []string{"Activity", "priority P2 → P1", "by alice", "`abcdef1`"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("rendered activity missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestRenderActivityMD_Limit(t *testing.T) {
+	base := time.Date(2025, 1, 1, 9, 0, 0, 0, time.UTC)
+	var entries []ActivityEntry
+	for i := 0; i < 5; i++ {
+		entries = append(entries, ActivityEntry{When: base.Add(time.Duration(i) * time.Hour), Kind: "comment"})
+	}
+	md := renderActivityMD(entries, 2)
+	if !strings.Contains(md, "3 earlier events") {
+		t.Errorf("expected truncation note, got:\n%s", md)
+	}
+	if renderActivityMD(nil, 2) != "" {
+		t.Error("expected empty output for no entries")
+	}
+}
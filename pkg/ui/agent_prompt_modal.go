@@ -23,18 +23,23 @@ type AgentPromptModal struct {
 	selection int    // 0=yes, 1=no, 2=never
 	filePath  string // Which file we're offering to modify
 	fileType  string // AGENTS.md or CLAUDE.md
+	diff      string // unified diff of the change "Yes" makes (empty if unavailable)
 	result    AgentPromptResult
 	theme     Theme
 	width     int
 	height    int
 }
 
-// NewAgentPromptModal creates a new AGENTS.md prompt modal.
+// NewAgentPromptModal creates a new AGENTS.md prompt modal. The preview is
+// the diff AppendBlurbToFile would apply, so the user sees exactly what
+// accepting writes.
 func NewAgentPromptModal(filePath, fileType string, theme Theme) AgentPromptModal {
+	diff, _ := agents.DiffPreview(filePath, agents.ActionAppend)
 	return AgentPromptModal{
 		selection: 0, // Default to "Yes"
 		filePath:  filePath,
 		fileType:  fileType,
+		diff:      diff,
 		result:    AgentPromptPending,
 		theme:     theme,
 		width:     60,
@@ -147,10 +152,14 @@ func (m AgentPromptModal) View() string {
 	b.WriteString("\n\n")
 
 	// Preview
-	b.WriteString(previewHeaderStyle.Render("Preview of content to add:"))
-	b.WriteString("\n")
-
 	preview := getBlurbPreview()
+	if m.diff != "" {
+		b.WriteString(previewHeaderStyle.Render("Changes to " + m.fileType + ":"))
+		preview = getDiffPreview(m.diff)
+	} else {
+		b.WriteString(previewHeaderStyle.Render("Preview of content to add:"))
+	}
+	b.WriteString("\n")
 	b.WriteString(previewBoxStyle.Render(preview))
 	b.WriteString("\n\n")
 
@@ -204,6 +213,11 @@ func (m AgentPromptModal) FilePath() string {
 	return m.filePath
 }
 
+// Diff returns the unified diff of the change accepting the prompt makes.
+func (m AgentPromptModal) Diff() string {
+	return m.diff
+}
+
 // SetSize sets the modal dimensions.
 func (m *AgentPromptModal) SetSize(width, height int) {
 	m.width = width
@@ -240,6 +254,26 @@ func getBlurbPreview() string {
 	return strings.Join(preview, "\n") + "\n..."
 }
 
+// getDiffPreview returns the first added lines of diff, skipping the file
+// headers and the blank lines and markers that open the blurb.
+func getDiffPreview(diff string) string {
+	var preview []string
+	for _, line := range strings.Split(diff, "\n") {
+		if !strings.HasPrefix(line, "+") || strings.HasPrefix(line, "+++") {
+			continue
+		}
+		body := strings.TrimSpace(line[1:])
+		if len(preview) == 0 && (body == "" || body == "---" || strings.HasPrefix(body, "<!--")) {
+			continue
+		}
+		preview = append(preview, line)
+		if len(preview) >= 6 {
+			break
+		}
+	}
+	return strings.Join(preview, "\n") + "\n..."
+}
+
 // CenterModal returns the modal view centered in the given dimensions.
 func (m AgentPromptModal) CenterModal(termWidth, termHeight int) string {
 	modal := m.View()
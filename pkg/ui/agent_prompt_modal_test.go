@@ -1,9 +1,12 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
@@ -243,3 +246,20 @@ func TestCenterModal(t *testing.T) {
 		t.Error("Centered modal should contain title")
 	}
 }
+
+func TestAgentPromptModalShowsDiff(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "AGENTS.md")
+	if err := os.WriteFile(path, []byte("# Agents\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	theme := Theme{Renderer: lipgloss.DefaultRenderer()}
+	modal := NewAgentPromptModal(path, "AGENTS.md", theme)
+
+	if !strings.Contains(modal.Diff(), "+"+agents.BlurbStartMarker) {
+		t.Fatalf("modal should carry the diff of the append, got:\n%s", modal.Diff())
+	}
+	view := modal.View()
+	if !strings.Contains(view, "Changes to AGENTS.md") || !strings.Contains(view, "+## Beads Workflow Integration") {
+		t.Errorf("preview should show the added lines:\n%s", view)
+	}
+}
@@ -21,6 +21,7 @@ import (
 	tea "github.com/charmbracelet/bubbletea"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
@@ -1306,6 +1307,10 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	// Huge tier: default to open-only unless the recipe explicitly includes closed/tombstone.
 	loadOpenOnly := tier == datasetTierHuge && !recipeIncludesClosedStatuses(currentRecipe)
 
+	// Stamp the file before reading it: a write during the load makes the
+	// stamp stale rather than newer than the data
+	dataFile, _ := instance.StatFile(w.beadsPath)
+
 	// Load issues from file with panic recovery
 	var issues []model.Issue
 	var pooledRefs []*model.Issue
@@ -1439,6 +1444,7 @@ func (w *BackgroundWorker) buildSnapshot() *DataSnapshot {
 	// Store hash in snapshot for external access
 	if snapshot != nil {
 		snapshot.DataHash = hash
+		snapshot.DataFile = dataFile
 		snapshot.LoadWarningCount = len(loadWarnings)
 		snapshot.RecipeName = recipeID
 		snapshot.RecipeHash = recipeHash
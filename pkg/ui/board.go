@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 
 	"github.com/charmbracelet/bubbles/viewport"
 	"github.com/charmbracelet/glamour"
@@ -53,10 +54,18 @@ type BoardModel struct {
 	// false = always hide empty columns
 	showEmptyColumns *bool
 
+	// Column display order, left to right; the user can move columns with
+	// < and >, in every swimlane mode
+	columnOrder [4]int
+
 	// Inline card expansion (bv-i3ii)
 	// expandedCardID tracks which card is currently expanded inline
 	// Empty string means no card is expanded
 	expandedCardID string
+
+	// Render caching: dataHash identifies the issues shown (see renderKey)
+	dataHash string
+	render   *renderCache
 }
 
 // searchMatch holds info about a matching card (bv-yg39)
@@ -73,6 +82,9 @@ const (
 	ColClosed     = 3
 )
 
+// defaultColumnOrder shows the columns in their natural order.
+var defaultColumnOrder = [4]int{ColOpen, ColInProgress, ColBlocked, ColClosed}
+
 // SwimLaneMode determines how cards are grouped into columns (bv-wjs0)
 type SwimLaneMode int
 
@@ -176,14 +188,14 @@ func (b *BoardModel) updateActiveColumns() {
 	showEmpty := b.shouldShowEmptyColumns()
 
 	b.activeColIdx = nil
-	for i := 0; i < 4; i++ {
+	for _, i := range b.columnOrder {
 		if len(b.columns[i]) > 0 || showEmpty {
 			b.activeColIdx = append(b.activeColIdx, i)
 		}
 	}
 	// If all columns are empty (and we're hiding empty), include all columns anyway
 	if len(b.activeColIdx) == 0 {
-		b.activeColIdx = []int{ColOpen, ColInProgress, ColBlocked, ColClosed}
+		b.activeColIdx = append([]int(nil), b.columnOrder[:]...)
 	}
 	// Ensure focused column is within valid range
 	if b.focusedCol >= len(b.activeColIdx) {
@@ -327,7 +339,12 @@ func groupIssuesByMode(issues []model.Issue, mode SwimLaneMode) [4][]model.Issue
 
 // GetSwimLaneModeName returns the display name for the current swimlane mode (bv-wjs0)
 func (b *BoardModel) GetSwimLaneModeName() string {
-	switch b.swimLaneMode {
+	return swimLaneModeName(b.swimLaneMode)
+}
+
+// swimLaneModeName returns the display name for a swimlane mode.
+func swimLaneModeName(mode SwimLaneMode) string {
+	switch mode {
 	case SwimByStatus:
 		return "Status"
 	case SwimByPriority:
@@ -350,6 +367,60 @@ func (b *BoardModel) CycleSwimLaneMode() {
 	b.regroupIssues()
 }
 
+// SetSwimLaneMode switches to mode and regroups issues.
+func (b *BoardModel) SetSwimLaneMode(mode SwimLaneMode) {
+	if mode < 0 || mode >= SwimLaneModeCount || mode == b.swimLaneMode {
+		return
+	}
+	b.swimLaneMode = mode
+	b.regroupIssues()
+}
+
+// ColumnOrder returns the columns' display order, left to right.
+func (b *BoardModel) ColumnOrder() [4]int {
+	return b.columnOrder
+}
+
+// SetColumnOrder sets the columns' display order, left to right. It
+// reports false, changing nothing, unless order lists each column once.
+func (b *BoardModel) SetColumnOrder(order []int) bool {
+	if len(order) != len(b.columnOrder) {
+		return false
+	}
+	var seen [4]bool
+	for _, col := range order {
+		if col < 0 || col >= len(seen) || seen[col] {
+			return false
+		}
+		seen[col] = true
+	}
+	copy(b.columnOrder[:], order)
+	b.updateActiveColumns()
+	return true
+}
+
+// MoveFocusedColumn swaps the focused column with its visible neighbour to
+// the left (delta -1) or right (delta 1), keeping it focused. It reports
+// false if the column is already at that end.
+func (b *BoardModel) MoveFocusedColumn(delta int) bool {
+	target := b.focusedCol + delta
+	if b.focusedCol >= len(b.activeColIdx) || target < 0 || target >= len(b.activeColIdx) {
+		return false
+	}
+	moved, other := b.activeColIdx[b.focusedCol], b.activeColIdx[target]
+	for i, col := range b.columnOrder {
+		switch col {
+		case moved:
+			b.columnOrder[i] = other
+		case other:
+			b.columnOrder[i] = moved
+		}
+	}
+	b.updateActiveColumns()
+	b.focusedCol = target
+	return true
+}
+
 // regroupIssues rebuilds columns based on current swimlane mode (bv-wjs0)
 func (b *BoardModel) regroupIssues() {
 	if b.boardState != nil {
@@ -379,13 +450,13 @@ func (b *BoardModel) getColumnHeaders() ([]string, []string) {
 	switch b.swimLaneMode {
 	case SwimByPriority:
 		return []string{"P0 CRITICAL", "P1 HIGH", "P2 MEDIUM", "P3+ OTHER"},
-			[]string{"🔥", "⚡", "🔹", "💤"}
+			[]string{glyph("🔥", "[!!]"), glyph("⚡", "[!]"), glyph("🔹", "[-]"), glyph("💤", "[z]")}
 	case SwimByType:
 		return []string{"BUG", "FEATURE", "TASK", "EPIC"},
-			[]string{"🐛", "✨", "📋", "🎯"}
+			[]string{glyph("🐛", "[BUG]"), glyph("✨", "[FEAT]"), glyph("📋", "[TASK]"), glyph("🎯", "[EPIC]")}
 	default: // SwimByStatus
 		return []string{"OPEN", "IN PROGRESS", "BLOCKED", "CLOSED"},
-			[]string{"📋", "🔄", "🚫", "✅"}
+			[]string{glyph("📋", "[OPEN]"), glyph("🔄", "[WIP]"), glyph("🚫", "[BLOCKED]"), glyph("✅", "[DONE]")}
 	}
 }
 
@@ -413,10 +484,13 @@ func NewBoardModel(issues []model.Issue, theme Theme) BoardModel {
 		theme:        theme,
 		swimLaneMode: SwimByStatus, // Default mode (bv-wjs0)
 		allIssues:    issues,       // Store for regrouping (bv-wjs0)
+		columnOrder:  defaultColumnOrder,
 		blocksIndex:  buildBlocksIndex(issues),
 		issueMap:     issueMap,
 		detailVP:     viewport.New(40, 20),
 		mdRenderer:   mdRenderer,
+		dataHash:     issuesRenderHash(issues),
+		render:       newRenderCache(),
 	}
 	b.updateActiveColumns()
 	return b
@@ -430,6 +504,7 @@ func (b *BoardModel) SetIssues(issues []model.Issue) {
 
 	// Group by current swimlane mode (bv-wjs0)
 	b.columns = groupIssuesByMode(issues, b.swimLaneMode)
+	b.dataHash = issuesRenderHash(issues)
 
 	b.blocksIndex = buildBlocksIndex(issues) // Rebuild reverse dependency index (bv-1daf)
 
@@ -469,6 +544,10 @@ func (b *BoardModel) SetSnapshot(s *DataSnapshot) {
 
 	b.allIssues = s.Issues
 	b.boardState = s.BoardState
+	b.dataHash = s.DataHash
+	if b.dataHash == "" {
+		b.dataHash = issuesRenderHash(s.Issues)
+	}
 
 	if b.boardState != nil {
 		b.columns = b.boardState.ColumnsForMode(b.swimLaneMode)
@@ -904,8 +983,30 @@ func (b *BoardModel) HasExpandedCard() bool {
 	return b.expandedCardID != ""
 }
 
-// View renders the Kanban board with adaptive columns
+// View renders the Kanban board with adaptive columns, reusing the last
+// output while nothing it shows has changed.
 func (b BoardModel) View(width, height int) string {
+	return b.render.render(b.renderKey(width, height), func() string {
+		return b.renderBoard(width, height)
+	})
+}
+
+// renderKey captures everything the board's output depends on: the issues,
+// the size, the layout and navigation state, the detail panel's scroll and
+// the clock minute for relative times.
+func (b BoardModel) renderKey(width, height int) string {
+	showEmpty := "auto"
+	if b.showEmptyColumns != nil {
+		showEmpty = fmt.Sprint(*b.showEmptyColumns)
+	}
+	return fmt.Sprintf("%s|%dx%d|%d|%v|%d|%v|%s|%s|%t|%d|%t|%q|%v|%d|%s",
+		b.dataHash, width, height, b.swimLaneMode, b.activeColIdx, b.focusedCol, b.selectedRow,
+		showEmpty, b.expandedCardID, b.showDetail, b.detailVP.YOffset,
+		b.searchMode, b.searchQuery, b.searchMatches, b.searchCursor, renderClock())
+}
+
+// renderBoard renders the board without consulting the cache.
+func (b BoardModel) renderBoard(width, height int) string {
 	t := b.theme
 
 	// Calculate how many columns we're showing
@@ -1004,10 +1105,10 @@ func (b BoardModel) View(width, height int) string {
 			// Medium: add P0/P1 indicators if any exist
 			var indicators []string
 			if stats.P0Count > 0 {
-				indicators = append(indicators, fmt.Sprintf("%d🔴", stats.P0Count))
+				indicators = append(indicators, fmt.Sprintf("%d%s", stats.P0Count, glyph("🔴", "xP0")))
 			}
 			if stats.P1Count > 0 {
-				indicators = append(indicators, fmt.Sprintf("%d🟡", stats.P1Count))
+				indicators = append(indicators, fmt.Sprintf("%d%s", stats.P1Count, glyph("🟡", "xP1")))
 			}
 			if len(indicators) > 0 {
 				headerText = baseHeader + " " + strings.Join(indicators, " ")
@@ -1018,19 +1119,19 @@ func (b BoardModel) View(width, height int) string {
 			// Wide: full stats including oldest age
 			var indicators []string
 			if stats.P0Count > 0 {
-				indicators = append(indicators, fmt.Sprintf("%d🔴", stats.P0Count))
+				indicators = append(indicators, fmt.Sprintf("%d%s", stats.P0Count, glyph("🔴", "xP0")))
 			}
 			if stats.P1Count > 0 {
-				indicators = append(indicators, fmt.Sprintf("%d🟡", stats.P1Count))
+				indicators = append(indicators, fmt.Sprintf("%d%s", stats.P1Count, glyph("🟡", "xP1")))
 			}
 			// Show blocked count in In Progress column (colIdx == ColInProgress when in status mode)
 			if b.swimLaneMode == SwimByStatus && colIdx == ColInProgress && stats.BlockedCount > 0 {
-				indicators = append(indicators, fmt.Sprintf("⚠️%d", stats.BlockedCount))
+				indicators = append(indicators, fmt.Sprintf("%s%d", glyph("⚠️", "!"), stats.BlockedCount))
 			}
 			// Show oldest age with color indicator
 			if stats.OldestAge > 0 && issueCount > 0 {
 				ageStr := formatOldestAge(stats.OldestAge)
-				indicators = append(indicators, fmt.Sprintf("⏱%s", ageStr))
+				indicators = append(indicators, fmt.Sprintf("%s%s", glyph("⏱", "~"), ageStr))
 			}
 			if len(indicators) > 0 {
 				headerText = baseHeader + " " + strings.Join(indicators, " ")
@@ -1190,7 +1291,7 @@ func getAgeColor(t time.Time) lipgloss.TerminalColor {
 	if t.IsZero() {
 		return ColorMuted
 	}
-	days := int(time.Since(t).Hours() / 24)
+	days := int(timefmt.Now().Sub(t).Hours() / 24)
 	switch {
 	case days < 7:
 		return lipgloss.AdaptiveColor{Light: "#2e7d32", Dark: "#81c784"} // green
@@ -1304,7 +1405,7 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 	displayID := truncateRunesHelper(issue.ID, maxIDLen, "…")
 
 	// Age indicator with color coding: green(<7d), yellow(7-30d), red(>30d)
-	ageText := FormatTimeRel(issue.UpdatedAt)
+	ageText := formatTimeShort(issue.UpdatedAt)
 	if len(ageText) > 6 {
 		ageText = truncateRunesHelper(ageText, 6, "")
 	}
@@ -1347,10 +1448,11 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 			blockerID := truncateRunesHelper(dep.DependsOnID, 10, "…")
 			blockedStyle := t.Renderer.NewStyle().Foreground(t.Blocked)
 			// Try to get blocker title for better context
-			blockerBadge := "🚫←" + blockerID
+			blockedArrow := glyph("🚫←", "x<-")
+			blockerBadge := blockedArrow + blockerID
 			if blocker, ok := b.issueMap[dep.DependsOnID]; ok && blocker != nil {
 				titleSnippet := truncateRunesHelper(blocker.Title, 12, "…")
-				blockerBadge = fmt.Sprintf("🚫←%s (%s)", blockerID, titleSnippet)
+				blockerBadge = fmt.Sprintf("%s%s (%s)", blockedArrow, blockerID, titleSnippet)
 			}
 			meta = append(meta, blockedStyle.Render(blockerBadge))
 			break // Only show first blocker
@@ -1360,7 +1462,7 @@ func (b BoardModel) renderCard(issue model.Issue, width int, selected bool, colI
 	// Blocks count: ⚡→N (this card blocks N others) - from reverse index
 	if blockedIDs, ok := b.blocksIndex[issue.ID]; ok && len(blockedIDs) > 0 {
 		blocksStyle := t.Renderer.NewStyle().Foreground(t.Feature)
-		meta = append(meta, blocksStyle.Render(fmt.Sprintf("⚡→%d", len(blockedIDs))))
+		meta = append(meta, blocksStyle.Render(fmt.Sprintf("%s%d", glyph("⚡→", "=>"), len(blockedIDs))))
 	}
 
 	// Labels: show 2-3 label names (no "+N" count per spec)
@@ -1524,7 +1626,7 @@ func (b BoardModel) renderExpandedCard(issue model.Issue, width int, _, _ int) s
 	var labelLine string
 	if len(issue.Labels) > 0 {
 		labelStyle := t.Renderer.NewStyle().Foreground(t.InProgress)
-		labelLine = labelStyle.Render("🏷 " + strings.Join(issue.Labels, ", "))
+		labelLine = labelStyle.Render(glyph("🏷 ", "# ") + strings.Join(issue.Labels, ", "))
 	}
 
 	// ══════════════════════════════════════════════════════════════════════════
@@ -1532,7 +1634,7 @@ func (b BoardModel) renderExpandedCard(issue model.Issue, width int, _, _ int) s
 	// ══════════════════════════════════════════════════════════════════════════
 	timeStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
 	timestamps := timeStyle.Render(fmt.Sprintf("Created: %s | Updated: %s",
-		FormatTimeRel(issue.CreatedAt), FormatTimeRel(issue.UpdatedAt)))
+		FormatTime(issue.CreatedAt), FormatTime(issue.UpdatedAt)))
 
 	// ══════════════════════════════════════════════════════════════════════════
 	// ASSEMBLE CARD
@@ -1648,7 +1750,7 @@ func (b *BoardModel) renderDetailPanel(width, height int) string {
 						content.WriteString(fmt.Sprintf("- %s\n", blockedID))
 					}
 				}
-				content.WriteString(fmt.Sprintf("\n💡 Completing this would unblock %d issue(s)\n\n", len(blockedIDs)))
+				content.WriteString(fmt.Sprintf("\n%s Completing this would unblock %d issue(s)\n\n", glyph("💡", "*"), len(blockedIDs)))
 			}
 
 			// Description
@@ -1660,8 +1762,8 @@ func (b *BoardModel) renderDetailPanel(width, height int) string {
 
 			// Timestamps
 			content.WriteString("\n---\n\n")
-			content.WriteString(fmt.Sprintf("*Created: %s*\n", FormatTimeRel(issue.CreatedAt)))
-			content.WriteString(fmt.Sprintf("*Updated: %s*\n", FormatTimeRel(issue.UpdatedAt)))
+			content.WriteString(fmt.Sprintf("*Created: %s*\n", FormatTime(issue.CreatedAt)))
+			content.WriteString(fmt.Sprintf("*Updated: %s*\n", FormatTime(issue.UpdatedAt)))
 
 			// Render with markdown
 			rendered := content.String()
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// CacheSignalMsg is sent when another bv process rewrites the cache signal
+// file, i.e. it has stored fresh analysis results for this repository.
+type CacheSignalMsg struct{}
+
+// WatchCacheSignalCmd waits for the next cache signal.
+func WatchCacheSignalCmd(w *watcher.Watcher) tea.Cmd {
+	return func() tea.Msg {
+		<-w.Changed()
+		return CacheSignalMsg{}
+	}
+}
+
+// newCacheSignalWatcher watches .bv.cache-signal next to beadsPath. The file
+// need not exist yet. Returns nil if watching isn't possible; the TUI then
+// relies on its beads file watcher alone.
+func newCacheSignalWatcher(beadsPath string) *watcher.Watcher {
+	path := instance.CacheSignalPath(filepath.Dir(beadsPath))
+	w, err := watcher.NewWatcher(path, watcher.WithDebounceDuration(200*time.Millisecond))
+	if err != nil {
+		return nil
+	}
+	if err := w.Start(); err != nil {
+		return nil
+	}
+	return w
+}
+
+// cacheSignalReloadMsg asks the TUI to reload: another process has analyzed
+// a version of the beads file the TUI isn't showing.
+type cacheSignalReloadMsg struct {
+	pid int
+}
+
+// handleCacheSignal checks the new signal off the UI goroutine (see
+// checkCacheSignalCmd) and re-arms the watch.
+func (m Model) handleCacheSignal() (Model, tea.Cmd) {
+	rearm := WatchCacheSignalCmd(m.cacheSignalWatcher)
+	if m.beadsPath == "" {
+		return m, rearm
+	}
+	return m, tea.Batch(rearm, checkCacheSignalCmd(m.beadsPath, m.dataFile))
+}
+
+// checkCacheSignalCmd asks for a reload when another process signaled that
+// it analyzed the beads file as it is now, and that isn't the version
+// loaded. The signal names the whole file, so a robot command that filtered
+// its issues still matches. Signals from this process, about a version the
+// file has already moved past, or without a file stamp (older bv) are
+// ignored; the beads file watcher covers those changes.
+func checkCacheSignalCmd(beadsPath string, loaded instance.FileStamp) tea.Cmd {
+	return func() tea.Msg {
+		sig, err := instance.ReadCacheSignal(filepath.Dir(beadsPath))
+		if err != nil || sig.FromSelf() || sig.DataFile == nil || sig.DataFile.Equal(loaded) {
+			return nil
+		}
+		current, err := instance.StatFile(beadsPath)
+		if err != nil || !sig.DataFile.Equal(current) {
+			return nil
+		}
+		return cacheSignalReloadMsg{pid: sig.PID}
+	}
+}
+
+// handleCacheSignalReload drops cached analysis and reloads.
+func (m Model) handleCacheSignalReload(msg cacheSignalReloadMsg) (Model, tea.Cmd) {
+	analysis.GetGlobalCache().Invalidate()
+	m.statusMsg = fmt.Sprintf("Analysis cache updated by PID %d; reloading", msg.pid)
+	m.statusIsError = false
+
+	if m.backgroundWorker != nil {
+		m.backgroundWorker.ForceRefresh()
+		return m, nil
+	}
+	return m, func() tea.Msg { return FileChangedMsg{} }
+}
@@ -0,0 +1,88 @@
+package ui
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeForeignCacheSignal(t *testing.T, beadsDir string, dataFile instance.FileStamp) {
+	t.Helper()
+	data, _ := json.Marshal(instance.CacheSignal{PID: os.Getpid() + 1, DataHash: "filtered", At: time.Now(), DataFile: &dataFile})
+	if err := os.WriteFile(instance.CacheSignalPath(beadsDir), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestHandleCacheSignal(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	beadsPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"bv-1","title":"x","status":"open","issue_type":"task"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{{ID: "bv-1", Title: "x", Status: model.StatusOpen, IssueType: model.TypeTask}}
+	m := NewModel(issues, nil, beadsPath)
+	defer m.Stop()
+	if m.cacheSignalWatcher == nil {
+		t.Fatal("expected cache signal watcher alongside live reload")
+	}
+	if _, cmd := m.handleCacheSignal(); cmd == nil {
+		t.Fatal("watch must be re-armed")
+	}
+	check := func() tea.Msg { return checkCacheSignalCmd(beadsPath, m.dataFile)() }
+
+	// A signal about the file already on screen is ignored, even when the
+	// signaling command analyzed a filtered subset.
+	loaded, err := instance.StatFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeForeignCacheSignal(t, beadsDir, loaded)
+	if msg := check(); msg != nil {
+		t.Errorf("signal about the loaded file should not reload, got %#v", msg)
+	}
+
+	// A signal about the file as it is now, after a change the TUI hasn't
+	// loaded, reloads.
+	if err := os.WriteFile(beadsPath, []byte(`{"id":"bv-1","title":"changed","status":"open","issue_type":"task"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	current, err := instance.StatFile(beadsPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeForeignCacheSignal(t, beadsDir, current)
+	msg, ok := check().(cacheSignalReloadMsg)
+	if !ok {
+		t.Fatal("signal about newer data should ask for a reload")
+	}
+	got, cmd := m.handleCacheSignalReload(msg)
+	if !strings.Contains(got.statusMsg, "reloading") || cmd == nil {
+		t.Errorf("expected reload, got status %q", got.statusMsg)
+	}
+
+	// A signal about a version the file has moved past is left to the
+	// file watcher.
+	writeForeignCacheSignal(t, beadsDir, instance.FileStamp{Size: 1, ModTime: time.Unix(0, 0)})
+	if msg := check(); msg != nil {
+		t.Errorf("stale signal should be ignored, got %#v", msg)
+	}
+
+	// Our own signals are ignored.
+	if err := instance.SignalCacheUpdate(beadsDir, "different", "test", &current); err != nil {
+		t.Fatal(err)
+	}
+	if msg := check(); msg != nil {
+		t.Errorf("own signal should be ignored, got %#v", msg)
+	}
+}
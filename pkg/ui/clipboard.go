@@ -0,0 +1,114 @@
+package ui
+
+import (
+	"encoding/base64"
+	"fmt"
+	"os"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/atotto/clipboard"
+)
+
+// systemClipboardWrite and osc52Write are swapped out by tests.
+var (
+	systemClipboardWrite = clipboard.WriteAll
+	osc52Write           = writeOSC52
+)
+
+// isSSHSession reports whether bv is running over SSH, where the system
+// clipboard (if any) belongs to the remote host rather than the user.
+func isSSHSession() bool {
+	return os.Getenv("SSH_TTY") != "" || os.Getenv("SSH_CONNECTION") != ""
+}
+
+// osc52Sequence builds the OSC 52 escape that asks the terminal emulator to
+// set its clipboard.
+func osc52Sequence(text string) string {
+	return tmuxPassthrough("\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte(text)) + "\a")
+}
+
+// writeOSC52 copies text via the terminal emulator's clipboard.
+func writeOSC52(text string) error {
+	return terminalSequenceWrite(osc52Sequence(text))
+}
+
+// writeClipboard copies text to the clipboard. Over SSH it goes straight to
+// OSC 52 so the text lands on the user's machine; locally it uses the system
+// clipboard and falls back to OSC 52 when no clipboard utility is available.
+// Returns "osc52" or "system" to describe which path was used.
+func writeClipboard(text string) (string, error) {
+	if isSSHSession() {
+		return "osc52", osc52Write(text)
+	}
+	sysErr := systemClipboardWrite(text)
+	if sysErr == nil {
+		return "system", nil
+	}
+	if err := osc52Write(text); err != nil {
+		return "", fmt.Errorf("%v (OSC 52 fallback: %v)", sysErr, err)
+	}
+	return "osc52", nil
+}
+
+// copyWithStatus copies text and reports the outcome in the status bar.
+// label describes what was copied, e.g. "bv-12" or "bd close bv-12".
+func (m *Model) copyWithStatus(text, label string) {
+	via, err := writeClipboard(text)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
+		m.statusIsError = true
+		return
+	}
+	suffix := ""
+	if via == "osc52" {
+		suffix = " (via terminal)"
+	}
+	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard%s", label, suffix)
+	m.statusIsError = false
+}
+
+// suggestedBdCommand returns the bd command a human would most likely run
+// next for the issue: claim it if not started, close it if in progress,
+// reopen it if closed.
+func suggestedBdCommand(issue model.Issue) string {
+	switch {
+	case issue.Status == model.StatusInProgress:
+		return fmt.Sprintf("bd close %s", issue.ID)
+	case issue.Status.IsClosed():
+		return fmt.Sprintf("bd reopen %s", issue.ID)
+	default:
+		return fmt.Sprintf("bd update %s --status=in_progress", issue.ID)
+	}
+}
+
+// selectedListIssue returns the issue under the list cursor, or nil.
+func (m *Model) selectedListIssue() *model.Issue {
+	if issueItem, ok := m.list.SelectedItem().(IssueItem); ok {
+		return &issueItem.Issue
+	}
+	return nil
+}
+
+// copySelectedIssueID copies the selected issue's ID (y).
+func (m *Model) copySelectedIssueID() {
+	issue := m.selectedListIssue()
+	if issue == nil {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return
+	}
+	m.copyWithStatus(issue.ID, issue.ID)
+}
+
+// copySelectedBdCommand copies the suggested bd command for the selected
+// issue (Y).
+func (m *Model) copySelectedBdCommand() {
+	issue := m.selectedListIssue()
+	if issue == nil {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return
+	}
+	cmd := suggestedBdCommand(*issue)
+	m.copyWithStatus(cmd, "'"+cmd+"'")
+}
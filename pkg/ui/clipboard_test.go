@@ -0,0 +1,102 @@
+package ui
+
+import (
+	"encoding/base64"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// stubClipboard replaces both clipboard paths for the duration of a test and
+// returns pointers to what each one received.
+func stubClipboard(t *testing.T, sysErr error) (sys, osc *string) {
+	t.Helper()
+	origSys, origOSC := systemClipboardWrite, osc52Write
+	t.Cleanup(func() { systemClipboardWrite, osc52Write = origSys, origOSC })
+
+	sys, osc = new(string), new(string)
+	systemClipboardWrite = func(text string) error {
+		if sysErr != nil {
+			return sysErr
+		}
+		*sys = text
+		return nil
+	}
+	osc52Write = func(text string) error {
+		*osc = text
+		return nil
+	}
+	return sys, osc
+}
+
+func TestOSC52Sequence(t *testing.T) {
+	t.Setenv("TMUX", "")
+	want := "\x1b]52;c;" + base64.StdEncoding.EncodeToString([]byte("bv-1")) + "\a"
+	if got := osc52Sequence("bv-1"); got != want {
+		t.Fatalf("osc52Sequence = %q, want %q", got, want)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	got := osc52Sequence("bv-1")
+	if !strings.HasPrefix(got, "\x1bPtmux;\x1b\x1b]52;") || !strings.HasSuffix(got, "\x1b\\") {
+		t.Fatalf("tmux passthrough not applied: %q", got)
+	}
+}
+
+func TestWriteClipboard_PathSelection(t *testing.T) {
+	t.Setenv("SSH_TTY", "")
+	t.Setenv("SSH_CONNECTION", "")
+
+	sys, osc := stubClipboard(t, nil)
+	if via, err := writeClipboard("a"); err != nil || via != "system" || *sys != "a" || *osc != "" {
+		t.Fatalf("local: via=%q err=%v sys=%q osc=%q", via, err, *sys, *osc)
+	}
+
+	sys, osc = stubClipboard(t, errors.New("no clipboard utility found"))
+	if via, err := writeClipboard("b"); err != nil || via != "osc52" || *osc != "b" {
+		t.Fatalf("fallback: via=%q err=%v sys=%q osc=%q", via, err, *sys, *osc)
+	}
+
+	t.Setenv("SSH_TTY", "/dev/pts/3")
+	sys, osc = stubClipboard(t, nil)
+	if via, err := writeClipboard("c"); err != nil || via != "osc52" || *sys != "" || *osc != "c" {
+		t.Fatalf("ssh: via=%q err=%v sys=%q osc=%q", via, err, *sys, *osc)
+	}
+}
+
+func TestSuggestedBdCommand(t *testing.T) {
+	tests := []struct {
+		status model.Status
+		want   string
+	}{
+		{model.StatusOpen, "bd update bv-1 --status=in_progress"},
+		{model.StatusBlocked, "bd update bv-1 --status=in_progress"},
+		{model.StatusInProgress, "bd close bv-1"},
+		{model.StatusClosed, "bd reopen bv-1"},
+	}
+	for _, tt := range tests {
+		if got := suggestedBdCommand(model.Issue{ID: "bv-1", Status: tt.status}); got != tt.want {
+			t.Errorf("%s: got %q, want %q", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestCopySelectedIssueIDAndCommand(t *testing.T) {
+	t.Setenv("SSH_TTY", "")
+	t.Setenv("SSH_CONNECTION", "")
+	sys, _ := stubClipboard(t, nil)
+
+	m := NewModel([]model.Issue{{ID: "bv-7", Title: "x", Status: model.StatusInProgress}}, nil, "")
+
+	m.copySelectedIssueID()
+	if *sys != "bv-7" || m.statusIsError {
+		t.Fatalf("copy ID: clipboard=%q status=%q", *sys, m.statusMsg)
+	}
+
+	m.copySelectedBdCommand()
+	if *sys != "bd close bv-7" || !strings.Contains(m.statusMsg, "bd close bv-7") {
+		t.Fatalf("copy command: clipboard=%q status=%q", *sys, m.statusMsg)
+	}
+}
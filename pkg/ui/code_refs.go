@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Code references are the commits on any branch whose message mentions an
+// issue and the branches named after it (see correlation.CrossReference).
+// They load in the background at startup and after each reload, and the
+// detail pane lists them so it's clear what code already exists for an
+// issue and whether it has landed. The files those commits changed, with
+// any an issue declares on a "Files:" line, are its likely files; their
+// owners in the project's CODEOWNERS, loaded alongside, own the issue.
+
+// codeRefsDetailLimit caps the commits listed in the detail pane.
+const codeRefsDetailLimit = 5
+
+// codeRefsMsg delivers a cross-reference scan; Report is nil when the
+// project isn't in git, Owners when it has no CODEOWNERS file.
+type codeRefsMsg struct {
+	Report *correlation.XRefReport
+	Owners *codeowners.File
+}
+
+// loadCodeRefsCmd scans the project's git history for the current issues,
+// or returns nil when there is no single project to scan.
+func (m Model) loadCodeRefsCmd() tea.Cmd {
+	root := repoRootFromBeadsPath(m.beadsPath)
+	if root == "" || len(m.issues) == 0 {
+		return nil
+	}
+	ids := make([]string, len(m.issues))
+	for i := range m.issues {
+		ids[i] = m.issues[i].ID
+	}
+	return func() tea.Msg {
+		owners, _ := codeowners.Load(root)
+		report, err := correlation.CrossReference(root, ids, correlation.XRefOptions{})
+		if err != nil {
+			return codeRefsMsg{Owners: owners}
+		}
+		return codeRefsMsg{Report: report, Owners: owners}
+	}
+}
+
+// handleCodeRefs keeps a finished scan and refreshes the detail pane.
+func (m Model) handleCodeRefs(msg codeRefsMsg) (Model, tea.Cmd) {
+	m.codeRefs = msg.Report
+	m.codeOwners = msg.Owners
+	if strings.HasPrefix(m.currentFilter, "owner:") {
+		m.applyFilter()
+	}
+	if m.isSplitView || m.showDetails {
+		m.updateViewportContent()
+	}
+	return m, nil
+}
+
+// codeRefsDetail is the detail pane section on the code mentioning
+// issueID, or "" when there is none.
+func (m Model) codeRefsDetail(issueID string) string {
+	refs := m.codeRefs.For(issueID)
+	if refs == nil {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("### 🔗 Code (%s)\n", codeRefsStatusLabel(refs.MergeStatus, m.codeRefs.Mainline)))
+	if refs.LastTouched != nil {
+		sb.WriteString(fmt.Sprintf("**Last touched:** %s by %s\n\n", FormatTime(*refs.LastTouched), refs.LastAuthor))
+	}
+	if len(refs.Branches) > 0 {
+		names := make([]string, len(refs.Branches))
+		for i, b := range refs.Branches {
+			names[i] = fmt.Sprintf("`%s` %s", b.Name, mergedMark(b.Merged))
+		}
+		sb.WriteString("**Branches:** " + strings.Join(names, ", ") + "\n\n")
+	}
+	for i, c := range refs.Commits {
+		if i == codeRefsDetailLimit {
+			sb.WriteString(fmt.Sprintf("- … and %d more commits\n", len(refs.Commits)-codeRefsDetailLimit))
+			break
+		}
+		sb.WriteString(fmt.Sprintf("- %s `%s` %s — %s, %s\n",
+			mergedMark(c.Merged), c.ShortSHA, truncateString(c.Subject, 50), c.Author, FormatTime(c.Timestamp)))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// likelyFilesDetail is the detail pane section on the files issue's work is
+// likely to touch, or "" when nothing points at any.
+func (m Model) likelyFilesDetail(issue model.Issue) string {
+	hints := correlation.FileHints(issue, m.codeRefs.For(issue.ID), 0)
+	if len(hints) == 0 {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteString("### 📂 Likely Files\n")
+	if owners := m.issueOwners(issue); len(owners) > 0 {
+		sb.WriteString("**Owners:** " + strings.Join(owners, ", ") + "\n\n")
+	}
+	for _, h := range hints {
+		if h.Source == correlation.FileHintDeclared {
+			sb.WriteString(fmt.Sprintf("- `%s` (declared)\n", h.Path))
+			continue
+		}
+		unit := "commits"
+		if h.Commits == 1 {
+			unit = "commit"
+		}
+		sb.WriteString(fmt.Sprintf("- `%s` (%d %s)\n", h.Path, h.Commits, unit))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// issueOwners returns the CODEOWNERS owners of issue's likely files, most
+// files first.
+func (m Model) issueOwners(issue model.Issue) []string {
+	return m.codeOwners.IssueOwners(issue, m.codeRefs.For(issue.ID))
+}
+
+// openOwnerPicker lists the owners of the issues in the label picker, so
+// choosing one filters the list to what they own.
+func (m Model) openOwnerPicker() Model {
+	if m.codeOwners == nil {
+		m.statusMsg = "No CODEOWNERS file in this project"
+		m.statusIsError = true
+		return m
+	}
+	counts := make(map[string]int)
+	var owners []string
+	for _, issue := range m.issues {
+		for _, owner := range m.issueOwners(issue) {
+			if counts[owner] == 0 {
+				owners = append(owners, owner)
+			}
+			counts[owner]++
+		}
+	}
+	if len(owners) == 0 {
+		m.statusMsg = "CODEOWNERS owns none of the issues' likely files"
+		m.statusIsError = false
+		return m
+	}
+	m.labelPicker.SetLabels(owners, counts)
+	m.labelPicker.SetTitle("Filter by Owner")
+	m.labelPicker.Reset()
+	m.labelPicker.SetSize(m.width, m.height-1)
+	m.showLabelPicker = true
+	m.pickingOwner = true
+	m.focused = focusLabelPicker
+	return m
+}
+
+// codeRefsStatusLabel describes a merge status against mainline.
+func codeRefsStatusLabel(status, mainline string) string {
+	switch status {
+	case correlation.MergeStatusMerged:
+		return "merged into " + mainline
+	case correlation.MergeStatusPartial:
+		return "partly merged into " + mainline
+	}
+	return "not merged into " + mainline
+}
+
+func mergedMark(merged bool) string {
+	if merged {
+		return "✓"
+	}
+	return "○"
+}
@@ -0,0 +1,108 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestCodeRefsDetail(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "cr-1", Title: "Cache", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+		{ID: "cr-2", Title: "API", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Description: "Files: pkg/api/**"},
+	}
+	m := NewModel(issues, nil, "")
+	t.Cleanup(func() { m.Stop() })
+	if m.loadCodeRefsCmd() != nil {
+		t.Error("no project, nothing to scan")
+	}
+
+	when := time.Now().Add(-2 * time.Hour)
+	m, _ = m.handleCodeRefs(codeRefsMsg{Report: &correlation.XRefReport{
+		Mainline: "main",
+		Issues: map[string]*correlation.IssueXRef{
+			"cr-1": {
+				Commits: []correlation.XRefCommit{
+					{ShortSHA: "abc1234", Author: "Ann", Subject: "Add the cache", Timestamp: when, Merged: true,
+						Files: []string{"pkg/cache/cache.go", ".beads/issues.jsonl"}},
+				},
+				Branches:    []correlation.XRefBranch{{Name: "cr-1-followup", Updated: when}},
+				LastAuthor:  "Ann",
+				LastTouched: &when,
+				MergeStatus: correlation.MergeStatusPartial,
+			},
+		},
+	}})
+
+	got := m.codeRefsDetail("cr-1")
+	for _, want := range []string{"Code (partly merged into main)", "by Ann", "`cr-1-followup` ○", "✓ `abc1234` Add the cache"} {
+		if !strings.Contains(got, want) {
+			t.Errorf("detail lacks %q:\n%s", want, got)
+		}
+	}
+	if got := m.codeRefsDetail("cr-2"); got != "" {
+		t.Errorf("cr-2 has no code, got %q", got)
+	}
+
+	if got := m.likelyFilesDetail(issues[0]); !strings.Contains(got, "`pkg/cache/cache.go` (1 commit)") || strings.Contains(got, ".beads") {
+		t.Errorf("cr-1 likely files:\n%s", got)
+	}
+	if got := m.likelyFilesDetail(issues[1]); !strings.Contains(got, "`pkg/api/**` (declared)") {
+		t.Errorf("cr-2 likely files:\n%s", got)
+	}
+
+	m.showDetails = true
+	m.viewport.Height = 500
+	m.updateViewportContent()
+	view := ansi.Strip(m.viewport.View())
+	if !strings.Contains(view, "abc1234") || !strings.Contains(view, "Likely Files") {
+		t.Error("detail pane doesn't list the commit and likely files")
+	}
+}
+
+func TestOwnerFilter(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "ow-1", Title: "Tokens", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Description: "Files: pkg/auth/**"},
+		{ID: "ow-2", Title: "Docs", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask, Description: "Files: README.md"},
+		{ID: "ow-3", Title: "Other", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, "")
+	t.Cleanup(func() { m.Stop() })
+
+	m, _ = pressVim(t, m, "@")
+	if m.showLabelPicker || !strings.Contains(m.statusMsg, "No CODEOWNERS") {
+		t.Fatalf("without CODEOWNERS: picker = %v, status = %q", m.showLabelPicker, m.statusMsg)
+	}
+
+	owners, err := codeowners.Parse(strings.NewReader("*.md @docs\n/pkg/auth/ @org/security @ann\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	m, _ = m.handleCodeRefs(codeRefsMsg{Owners: owners})
+	if got := m.likelyFilesDetail(issues[0]); !strings.Contains(got, "**Owners:** @org/security, @ann") {
+		t.Errorf("ow-1 detail lacks owners:\n%s", got)
+	}
+
+	m, _ = pressVim(t, m, "@")
+	if !m.showLabelPicker || !m.pickingOwner || !strings.Contains(ansi.Strip(m.labelPicker.View()), "Filter by Owner") {
+		t.Fatal("@ should open the owner picker")
+	}
+	m, _ = pressVim(t, m, "enter") // Ties sort by name: @ann first
+	if m.currentFilter != "owner:@ann" {
+		t.Fatalf("filter = %q", m.currentFilter)
+	}
+	if items := m.list.Items(); len(items) != 1 || items[0].(IssueItem).Issue.ID != "ow-1" {
+		t.Errorf("owner filter kept %d items, want only ow-1", len(items))
+	}
+
+	m, _ = pressVim(t, m, "l")
+	if m.pickingOwner || strings.Contains(ansi.Strip(m.labelPicker.View()), "Owner") {
+		t.Error("l should open the label picker again")
+	}
+}
@@ -21,6 +21,9 @@ const (
 	ContextRepoPicker        Context = "repo-picker"
 	ContextAgentPrompt       Context = "agent-prompt"
 	ContextCassSession       Context = "cass-session"
+	ContextStaleSweep        Context = "stale-sweep"
+	ContextProjectSwitcher   Context = "project-switcher"
+	ContextNotesEditor       Context = "notes-editor"
 
 	// Views
 	ContextInsights       Context = "insights"
@@ -111,6 +114,21 @@ func (m Model) CurrentContext() Context {
 		return ContextRepoPicker
 	}
 
+	// Stale sweep overlay
+	if m.showStaleSweep {
+		return ContextStaleSweep
+	}
+
+	// Recent projects overlay
+	if m.showProjectSwitcher {
+		return ContextProjectSwitcher
+	}
+
+	// Local notes editor
+	if m.showNotesEditor {
+		return ContextNotesEditor
+	}
+
 	// === Views (based on focus or view flags) ===
 
 	// Insights panel
@@ -201,6 +219,9 @@ func (c Context) Description() string {
 		ContextRepoPicker:         "Repo picker",
 		ContextAgentPrompt:        "Agent prompt",
 		ContextCassSession:        "Cass session preview",
+		ContextStaleSweep:         "Stale sweep",
+		ContextProjectSwitcher:    "Project switcher",
+		ContextNotesEditor:        "Notes editor",
 		ContextInsights:           "Insights panel",
 		ContextFlowMatrix:         "Flow matrix",
 		ContextGraph:              "Dependency graph",
@@ -228,7 +249,8 @@ func (c Context) IsOverlay() bool {
 	case ContextLabelPicker, ContextRecipePicker, ContextHelp, ContextQuitConfirm,
 		ContextLabelHealthDetail, ContextLabelDrilldown, ContextLabelGraphAnalysis,
 		ContextTimeTravelInput, ContextAlerts, ContextRepoPicker, ContextAgentPrompt,
-		ContextCassSession:
+		ContextCassSession, ContextStaleSweep, ContextProjectSwitcher,
+		ContextNotesEditor:
 		return true
 	}
 	return false
@@ -269,6 +291,8 @@ func (c Context) TutorialPages() []int {
 		ContextLabelPicker:        {11, 3},       // Labels, Filtering
 		ContextRecipePicker:       {3, 12},       // Filtering, Advanced
 		ContextRepoPicker:         {12},          // Advanced (workspace)
+		ContextProjectSwitcher:    {12},          // Advanced (workspace)
+		ContextNotesEditor:        {4},           // Detail View
 		ContextAgentPrompt:        {16},          // AI Agent Integration
 		ContextLabelHealthDetail:  {11},          // Labels
 		ContextLabelDrilldown:     {11},          // Labels
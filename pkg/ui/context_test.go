@@ -85,6 +85,21 @@ func TestCurrentContext_Overlays(t *testing.T) {
 			setup:    func(m *Model) { m.showRepoPicker = true },
 			expected: ContextRepoPicker,
 		},
+		{
+			name:     "stale sweep",
+			setup:    func(m *Model) { m.showStaleSweep = true },
+			expected: ContextStaleSweep,
+		},
+		{
+			name:     "project switcher",
+			setup:    func(m *Model) { m.showProjectSwitcher = true },
+			expected: ContextProjectSwitcher,
+		},
+		{
+			name:     "notes editor",
+			setup:    func(m *Model) { m.showNotesEditor = true },
+			expected: ContextNotesEditor,
+		},
 	}
 
 	for _, tt := range tests {
@@ -258,6 +273,7 @@ func TestContext_IsOverlay(t *testing.T) {
 		ContextLabelPicker, ContextRecipePicker, ContextHelp, ContextQuitConfirm,
 		ContextLabelHealthDetail, ContextLabelDrilldown, ContextLabelGraphAnalysis,
 		ContextTimeTravelInput, ContextAlerts, ContextRepoPicker, ContextAgentPrompt,
+		ContextStaleSweep, ContextProjectSwitcher, ContextNotesEditor,
 	}
 
 	for _, c := range overlays {
@@ -19,6 +19,8 @@ type IssueDelegate struct {
 	PriorityHints     map[string]*analysis.PriorityRecommendation
 	WorkspaceMode     bool // When true, shows repo prefix badges
 	ShowSearchScores  bool // Show semantic/hybrid score badge when search is active
+	// DepShapes feeds the dependency column: depth, dependents (←), dependencies (→)
+	DepShapes map[string]analysis.DependencyShape
 }
 
 func (d IssueDelegate) Height() int {
@@ -58,7 +60,7 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	icon, iconColor := t.GetTypeIcon(string(i.Issue.IssueType))
 	idStr := i.Issue.ID
 	title := i.Issue.Title
-	ageStr := FormatTimeRel(i.Issue.CreatedAt)
+	ageStr := formatTimeShort(i.Issue.CreatedAt)
 	commentCount := len(i.Issue.Comments)
 
 	// Measure actual icon display width (emojis vary: 1-2 cells)
@@ -68,6 +70,14 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	rightWidth := 0
 	var rightParts []string
 
+	// Dependency depth and fan-in/fan-out, e.g. "d3 ←2 →1"
+	if width > 80 && d.DepShapes != nil {
+		shape := d.DepShapes[i.Issue.ID]
+		depStr := fmt.Sprintf("d%-2d ←%-2d →%-2d", shape.Depth, shape.Dependents, shape.Dependencies)
+		rightParts = append(rightParts, t.MutedText.Render(depStr))
+		rightWidth += lipgloss.Width(depStr) + 1
+	}
+
 	// Show Age and Comments only if we have reasonable width
 	if width > 60 {
 		// Age - with subtle styling (using pre-computed style)
@@ -76,7 +86,7 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 		// Comments with icon - use lipgloss.Width for accurate emoji measurement
 		if commentCount > 0 {
-			commentStr := fmt.Sprintf("💬%d", commentCount)
+			commentStr := fmt.Sprintf("%s%d", commentIcon(), commentCount)
 			rightParts = append(rightParts, t.InfoText.Render(commentStr))
 			rightWidth += lipgloss.Width(commentStr) + 1 // +1 for spacing
 		} else {
@@ -125,6 +135,11 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftFixedWidth += lipgloss.Width(repoBadge) + 1
 	}
 
+	// Star marker
+	if i.Pinned {
+		leftFixedWidth += lipgloss.Width(pinIcon()) + 1
+	}
+
 	// Priority badge (polished)
 	prioBadge := RenderPriorityBadge(i.Issue.Priority)
 	prioBadgeWidth := lipgloss.Width(prioBadge)
@@ -137,9 +152,9 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 
 	// Triage indicator width (bv-151) - use lipgloss.Width for accurate emoji measurement
 	if i.IsQuickWin {
-		leftFixedWidth += lipgloss.Width("⭐") + 1 // emoji + space
+		leftFixedWidth += lipgloss.Width(quickWinIcon()) + 1 // emoji + space
 	} else if i.IsBlocker && i.UnblocksCount > 0 {
-		leftFixedWidth += lipgloss.Width(fmt.Sprintf("🔓%d", i.UnblocksCount)) + 1 // emoji+count + space
+		leftFixedWidth += lipgloss.Width(fmt.Sprintf("%s%d", unblocksIcon(), i.UnblocksCount)) + 1 // emoji+count + space
 	} else if i.UnblocksCount > 0 {
 		leftFixedWidth += lipgloss.Width(fmt.Sprintf("↪%d", i.UnblocksCount)) + 1 // arrow+count + space
 	}
@@ -203,6 +218,12 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 		leftSide.WriteString(" ")
 	}
 
+	// Star marker (*)
+	if i.Pinned {
+		leftSide.WriteString(pinIcon())
+		leftSide.WriteString(" ")
+	}
+
 	// Type icon with color
 	leftSide.WriteString(t.Renderer.NewStyle().Foreground(iconColor).Render(icon))
 	leftSide.WriteString(" ")
@@ -228,9 +249,9 @@ func (d IssueDelegate) Render(w io.Writer, m list.Model, index int, listItem lis
 	// Triage indicators (bv-151): Quick win ⭐ and Unblocks count 🔓 - using pre-computed styles
 	triageIndicator := ""
 	if i.IsQuickWin {
-		triageIndicator = t.TriageStar.Render("⭐")
+		triageIndicator = t.TriageStar.Render(quickWinIcon())
 	} else if i.IsBlocker && i.UnblocksCount > 0 {
-		triageIndicator = t.TriageUnblocks.Render(fmt.Sprintf("🔓%d", i.UnblocksCount))
+		triageIndicator = t.TriageUnblocks.Render(fmt.Sprintf("%s%d", unblocksIcon(), i.UnblocksCount))
 	} else if i.UnblocksCount > 0 {
 		triageIndicator = t.TriageUnblocksAlt.Render(fmt.Sprintf("↪%d", i.UnblocksCount))
 	}
@@ -139,3 +139,27 @@ func TestIssueDelegate_RenderNarrow(t *testing.T) {
 		t.Fatalf("narrow output should hide comments count: %q", out)
 	}
 }
+
+func TestIssueDelegate_RenderDependencyColumn(t *testing.T) {
+	item := newTestIssueItem("TASK-7")
+	theme := DefaultTheme(lipgloss.NewRenderer(os.Stdout))
+	delegate := IssueDelegate{
+		Theme:     theme,
+		DepShapes: map[string]analysis.DependencyShape{"TASK-7": {Depth: 3, Dependents: 2, Dependencies: 1}},
+	}
+	l := list.New([]list.Item{item}, delegate, 0, 0)
+
+	var buf bytes.Buffer
+	l.SetWidth(120)
+	delegate.Render(&buf, l, 0, item)
+	if out := buf.String(); !strings.Contains(out, "d3  ←2  →1") {
+		t.Fatalf("render output missing dependency column: %q", out)
+	}
+
+	buf.Reset()
+	l.SetWidth(70)
+	delegate.Render(&buf, l, 0, item)
+	if out := buf.String(); strings.Contains(out, "←2") {
+		t.Fatalf("narrow render should drop the dependency column: %q", out)
+	}
+}
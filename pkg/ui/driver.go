@@ -0,0 +1,257 @@
+package ui
+
+import (
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+)
+
+// Driver runs a Model without a terminal: it feeds it key presses and
+// window sizes, runs the commands Update returns, and renders frames on
+// demand. Integration tests and scripts use it to walk through a scenario
+// (filter, open an issue, run a stale sweep) and check what the TUI showed
+// and which bd commands it ran, with no PTY involved.
+//
+// A Driver is not safe for concurrent use. Commands run in goroutines as
+// they do under bubbletea; their messages are delivered to the model on
+// the caller's goroutine, inside Send, SendKeys, Run and WaitFor.
+type Driver struct {
+	model Model
+
+	msgs    chan tea.Msg
+	pending atomic.Int64 // commands still running
+	settle  time.Duration
+	quit    bool
+	wg      sync.WaitGroup
+}
+
+// defaultDriverSettle is how long Send waits for a running command to
+// report before it returns; ticks and file watches never finish, so Send
+// can't simply wait for all of them.
+const defaultDriverSettle = 50 * time.Millisecond
+
+// NewDriver sizes m to width×height and waits for its phase 2 analysis,
+// so the first frame already shows PageRank, critical path and the like.
+// Init's background work (update check, file watching, history loading)
+// is not started; pass m.Init() to Run to start it. Use m.SetBdRunner
+// before NewDriver to record or fake the bd commands the TUI runs.
+func NewDriver(m Model, width, height int) *Driver {
+	d := &Driver{
+		model:  m,
+		msgs:   make(chan tea.Msg, 64),
+		settle: defaultDriverSettle,
+	}
+	d.Send(WaitForPhase2Cmd(m.analysis)())
+	d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+	return d
+}
+
+// SetSettle changes how long Send waits for running commands to report
+// before returning. Raise it for commands slower than the 50ms default.
+func (d *Driver) SetSettle(settle time.Duration) {
+	d.settle = settle
+}
+
+// Send delivers msg to the model and processes the messages its commands
+// produce until none arrives within the settle time.
+func (d *Driver) Send(msg tea.Msg) {
+	d.update(msg)
+	d.drain()
+}
+
+// Run executes cmd as if Update had returned it and processes the result
+// like Send.
+func (d *Driver) Run(cmd tea.Cmd) {
+	d.exec(cmd)
+	d.drain()
+}
+
+// SendKeys presses keys in order. Each key is a single character ("j",
+// "/", "?") or a bubbletea key name as matched in Update: "enter", "esc",
+// "tab", "backspace", "up", "ctrl+t", "space", "alt+x". Unknown names are
+// an error, reported before any key is sent.
+func (d *Driver) SendKeys(keys ...string) error {
+	msgs := make([]tea.KeyMsg, 0, len(keys))
+	for _, k := range keys {
+		msg, err := parseKey(k)
+		if err != nil {
+			return err
+		}
+		msgs = append(msgs, msg)
+	}
+	for _, msg := range msgs {
+		d.Send(msg)
+	}
+	return nil
+}
+
+// Type presses a key for each character of text, as when typing a filter.
+func (d *Driver) Type(text string) {
+	for _, r := range text {
+		key := tea.Key{Type: tea.KeyRunes, Runes: []rune{r}}
+		if r == ' ' {
+			key.Type = tea.KeySpace
+		}
+		d.Send(tea.KeyMsg(key))
+	}
+}
+
+// Resize sends a window size change.
+func (d *Driver) Resize(width, height int) {
+	d.Send(tea.WindowSizeMsg{Width: width, Height: height})
+}
+
+// WaitFor processes messages until cond holds or timeout passes, and
+// reports whether cond held. Use it for results of slow commands, such as
+// the status line after a stale sweep finishes.
+func (d *Driver) WaitFor(cond func(*Driver) bool, timeout time.Duration) bool {
+	deadline := time.NewTimer(timeout)
+	defer deadline.Stop()
+	for !cond(d) {
+		select {
+		case msg := <-d.msgs:
+			d.update(msg)
+		case <-deadline.C:
+			return cond(d)
+		}
+	}
+	return true
+}
+
+// CurrentView names what the TUI is showing: "list", "detail", "board",
+// "filter", "stale-sweep" and so on (see Context).
+func (d *Driver) CurrentView() string {
+	return string(d.model.CurrentContext())
+}
+
+// CapturedFrame renders the current frame as plain text, without ANSI
+// styling.
+func (d *Driver) CapturedFrame() string {
+	return ansi.Strip(d.model.View())
+}
+
+// Model returns the driven model.
+func (d *Driver) Model() Model {
+	return d.model
+}
+
+// Quit reports whether the model asked to quit (tea.Quit).
+func (d *Driver) Quit() bool {
+	return d.quit
+}
+
+// Close stops the model's background work and waits up to timeout for
+// running commands to return. It reports whether they all did; ticks and
+// watches left running are abandoned.
+func (d *Driver) Close(timeout time.Duration) bool {
+	d.model.Stop()
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+	for {
+		select {
+		case <-done:
+			return true
+		case <-d.msgs:
+			// Drop late results so their senders can return
+		case <-time.After(timeout):
+			return false
+		}
+	}
+}
+
+// update applies msg, expanding batches and sequences the way the
+// bubbletea runtime does.
+func (d *Driver) update(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case nil:
+		return
+	case tea.QuitMsg:
+		d.quit = true
+		return
+	case tea.BatchMsg:
+		for _, cmd := range msg {
+			d.exec(cmd)
+		}
+		return
+	}
+	if cmds, ok := sequenceCmds(msg); ok {
+		d.execSequence(cmds)
+		return
+	}
+	next, cmd := d.model.Update(msg)
+	d.model = next.(Model)
+	d.exec(cmd)
+}
+
+// exec runs cmd in the background and queues its message.
+func (d *Driver) exec(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	d.pending.Add(1)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		// Count down only once the message is queued, so drain never
+		// sees an empty queue with nothing running while it's in flight
+		d.msgs <- cmd()
+		d.pending.Add(-1)
+	}()
+}
+
+// execSequence runs cmds one after another, queueing each message before
+// starting the next command.
+func (d *Driver) execSequence(cmds []tea.Cmd) {
+	d.pending.Add(1)
+	d.wg.Add(1)
+	go func() {
+		defer d.wg.Done()
+		for _, cmd := range cmds {
+			if cmd != nil {
+				d.msgs <- cmd()
+			}
+		}
+		d.pending.Add(-1)
+	}()
+}
+
+// drain processes queued messages until nothing is running, or nothing
+// arrives within the settle time.
+func (d *Driver) drain() {
+	for {
+		select {
+		case msg := <-d.msgs:
+			d.update(msg)
+			continue
+		default:
+		}
+		if d.pending.Load() == 0 {
+			return
+		}
+		select {
+		case msg := <-d.msgs:
+			d.update(msg)
+		case <-time.After(d.settle):
+			return
+		}
+	}
+}
+
+var cmdSliceType = reflect.TypeOf([]tea.Cmd(nil))
+
+// sequenceCmds unpacks tea.Sequence's message, whose type bubbletea
+// doesn't export.
+func sequenceCmds(msg tea.Msg) ([]tea.Cmd, bool) {
+	v := reflect.ValueOf(msg)
+	if v.Kind() != reflect.Slice || !v.Type().ConvertibleTo(cmdSliceType) {
+		return nil, false
+	}
+	return v.Convert(cmdSliceType).Interface().([]tea.Cmd), true
+}
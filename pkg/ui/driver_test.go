@@ -0,0 +1,101 @@
+package ui
+
+import (
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Filter to a stale bug, open it, close it from the stale sweep, and check
+// that bd was asked to close it.
+func TestDriverScenario(t *testing.T) {
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "drv-1", Title: "Login fails with expired token", IssueType: model.TypeBug, Status: model.StatusOpen, Priority: 1,
+			CreatedAt: now.Add(-60 * 24 * time.Hour), UpdatedAt: now.Add(-45 * 24 * time.Hour)},
+		{ID: "drv-2", Title: "Dark mode for settings", IssueType: model.TypeFeature, Status: model.StatusOpen, Priority: 2,
+			CreatedAt: now.Add(-3 * 24 * time.Hour), UpdatedAt: now.Add(-time.Hour)},
+	}
+	var mu sync.Mutex
+	var ran []string
+	m := NewModel(issues, nil, "")
+	m.SetBdRunner(func(dir string, args ...string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		ran = append(ran, strings.Join(args, " "))
+		return nil, nil
+	})
+	d := NewDriver(m, 80, 30)
+	defer d.Close(time.Second)
+
+	if got := d.CurrentView(); got != "list" {
+		t.Fatalf("initial view = %q, want list", got)
+	}
+	if err := d.SendKeys("/"); err != nil {
+		t.Fatal(err)
+	}
+	d.Type("login")
+	if got := d.CurrentView(); got != "filter" {
+		t.Errorf("view while filtering = %q, want filter", got)
+	}
+	if frame := d.CapturedFrame(); !strings.Contains(frame, "drv-1") || strings.Contains(frame, "drv-2") {
+		t.Errorf("filtered list should show drv-1 only:\n%s", frame)
+	}
+
+	if err := d.SendKeys("enter", "enter"); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.CurrentView(); got != "detail" {
+		t.Fatalf("view after enter = %q, want detail", got)
+	}
+	if frame := d.CapturedFrame(); !strings.Contains(frame, "Login fails with expired token") {
+		t.Errorf("detail should show the issue:\n%s", frame)
+	}
+
+	if err := d.SendKeys("esc", "Z"); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.CurrentView(); got != "stale-sweep" {
+		t.Fatalf("view after Z = %q, want stale-sweep", got)
+	}
+	if err := d.SendKeys("x", "y"); err != nil {
+		t.Fatal(err)
+	}
+	if !d.WaitFor(func(d *Driver) bool { return strings.Contains(d.CapturedFrame(), "Stale sweep: 1 done") }, 5*time.Second) {
+		t.Fatalf("no sweep result in the status line:\n%s", d.CapturedFrame())
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if want := "close drv-1 --reason stale: no activity in 45 days"; len(ran) != 1 || ran[0] != want {
+		t.Errorf("bd ran %q, want [%q]", ran, want)
+	}
+	if d.Quit() {
+		t.Error("driver reports quit")
+	}
+}
+
+func TestDriverQuit(t *testing.T) {
+	d := NewDriver(NewModel(nil, nil, ""), 80, 24)
+	defer d.Close(time.Second)
+
+	if err := d.SendKeys("j", "nosuchkey"); err == nil {
+		t.Error("unknown key name should be an error")
+	}
+	if err := d.SendKeys("esc"); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.CurrentView(); got != "quit-confirm" || d.Quit() {
+		t.Fatalf("after esc: view %q, quit %v; want the quit confirmation", got, d.Quit())
+	}
+	if !strings.Contains(d.CapturedFrame(), "Quit bv?") {
+		t.Errorf("frame should show the quit confirmation:\n%s", d.CapturedFrame())
+	}
+	d.Send(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if !d.Quit() {
+		t.Error("confirming should quit")
+	}
+}
@@ -0,0 +1,314 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Focus mode: M claims the selected issue through bd, the same way
+// --robot-next --claim does, and starts a session timer in the footer.
+// Unclaiming, or the issue showing up closed, ends the session and logs
+// its time to .bv/timelog.jsonl (see pkg/session). The detail pane shows
+// the total logged on an issue.
+
+// focusTimer is this agent's claims made through bv and the time logged.
+type focusTimer struct {
+	agent       string                    // BV_AGENT_ID; "" for a human
+	claimsPath  string                    // .bv/sessions.json; "" without a project
+	timeLogPath string                    // .bv/timelog.jsonl
+	claims      []session.Claim           // This agent's claims, oldest first
+	logged      map[string]session.Logged // By issue ID
+	ticking     bool                      // A focusTickMsg is pending
+}
+
+// newFocusTimer locates the session store and time log for the project
+// holding beadsPath.
+func newFocusTimer(beadsPath string) focusTimer {
+	f := focusTimer{agent: os.Getenv("BV_AGENT_ID")}
+	if root := repoRootFromBeadsPath(beadsPath); root != "" {
+		f.claimsPath = session.DefaultPath(root)
+		f.timeLogPath = session.TimeLogPath(root)
+	}
+	return f
+}
+
+// claimOn returns this agent's claim on issueID, if any.
+func (f focusTimer) claimOn(issueID string) (session.Claim, bool) {
+	for _, c := range f.claims {
+		if c.IssueID == issueID {
+			return c, true
+		}
+	}
+	return session.Claim{}, false
+}
+
+// focusTickMsg redraws the running timer.
+type focusTickMsg struct{}
+
+func focusTickCmd() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg {
+		return focusTickMsg{}
+	})
+}
+
+// claimDoneMsg reports the bd command run to claim or unclaim an issue.
+type claimDoneMsg struct {
+	IssueID  string
+	Claim    bool // false for unclaim
+	Command  string
+	Checkout correlation.Checkout // Where the claim is worked; zero outside git
+	Err      error
+}
+
+// claimArgs is the bd update that claims issueID for agent, or gives it up.
+func claimArgs(issueID, agent string, claim bool) []string {
+	if !claim {
+		return []string{"update", issueID, "--status=open"}
+	}
+	args := []string{"update", issueID, "--status=in_progress"}
+	if agent != "" {
+		args = append(args, "--assignee="+agent)
+	}
+	return args
+}
+
+// claimCmd runs bd in dir to claim or unclaim issueID. A nil run means
+// staleBdRunner.
+func claimCmd(dir, issueID, agent string, claim bool, run BdRunner) tea.Cmd {
+	return func() tea.Msg {
+		if run == nil {
+			run = staleBdRunner
+		}
+		args := claimArgs(issueID, agent, claim)
+		msg := claimDoneMsg{IssueID: issueID, Claim: claim, Command: "bd " + strings.Join(args, " ")}
+		if out, err := run(dir, args...); err != nil {
+			msg.Err = fmt.Errorf("%s", firstLine(string(out), err))
+		} else if claim {
+			msg.Checkout, _ = correlation.CurrentCheckout(dir)
+		}
+		return msg
+	}
+}
+
+// settleClaims reloads the claims and time log, first logging the sessions
+// of claims whose issues are now closed. A secondary instance only reads.
+func (m *Model) settleClaims() {
+	f := &m.focus
+	if f.claimsPath == "" {
+		return
+	}
+	store, err := session.Load(f.claimsPath)
+	if err != nil {
+		return
+	}
+	if !m.readOnly {
+		index := make(map[string]model.Issue, len(m.issues))
+		for _, issue := range m.issues {
+			index[issue.ID] = issue
+		}
+		now := time.Now()
+		finished := store.Finished(index, now)
+		if store.Prune(index, now) {
+			if err := store.Save(f.claimsPath); err == nil {
+				_ = session.AppendWork(f.timeLogPath, finished...)
+			}
+		}
+	}
+	f.claims = store.ClaimsBy(f.agent)
+	m.loadLoggedTime()
+}
+
+// loadLoggedTime re-reads the time log totals.
+func (m *Model) loadLoggedTime() {
+	work, err := session.LoadTimeLog(m.focus.timeLogPath)
+	if err != nil {
+		return
+	}
+	m.focus.logged = session.Totals(work)
+}
+
+// toggleClaim claims issue for this agent, or unclaims it if the agent
+// already holds it.
+func (m *Model) toggleClaim(issue *model.Issue) tea.Cmd {
+	if issue == nil {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return nil
+	}
+	if m.focus.claimsPath == "" {
+		m.statusMsg = "Claims need a beads project"
+		m.statusIsError = true
+		return nil
+	}
+	if _, mine := m.focus.claimOn(issue.ID); mine {
+		m.statusMsg = fmt.Sprintf("Unclaiming %s…", issue.ID)
+		m.statusIsError = false
+		return claimCmd(repoRootFromBeadsPath(m.beadsPath), issue.ID, m.focus.agent, false, m.bdRunner)
+	}
+	if issue.Status.IsClosed() {
+		m.statusMsg = fmt.Sprintf("%s is closed", issue.ID)
+		m.statusIsError = true
+		return nil
+	}
+	if store, err := session.Load(m.focus.claimsPath); err == nil {
+		if c, ok := store.Holder(issue.ID); ok && c.Agent != m.focus.agent {
+			m.statusMsg = fmt.Sprintf("%s is claimed by %s", issue.ID, claimHolderName(c.Agent))
+			m.statusIsError = true
+			return nil
+		}
+	}
+	m.statusMsg = fmt.Sprintf("Claiming %s…", issue.ID)
+	m.statusIsError = false
+	return claimCmd(repoRootFromBeadsPath(m.beadsPath), issue.ID, m.focus.agent, true, m.bdRunner)
+}
+
+// claimHolderName names who holds a claim for the status bar.
+func claimHolderName(agent string) string {
+	if agent == "" {
+		return "a human session"
+	}
+	return agent
+}
+
+// handleClaimDone records a claim bd accepted and starts the timer, or
+// logs the session an unclaim ended.
+func (m Model) handleClaimDone(msg claimDoneMsg) (Model, tea.Cmd) {
+	if msg.Err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %s failed: %v", msg.Command, msg.Err)
+		m.statusIsError = true
+		return m, nil
+	}
+	f := &m.focus
+	store, err := session.Load(f.claimsPath)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	now := time.Now()
+	var work []session.Work
+	if msg.Claim {
+		store.Add(session.Claim{
+			IssueID:   msg.IssueID,
+			Agent:     f.agent,
+			ClaimedAt: now.UTC(),
+			Command:   msg.Command,
+			Branch:    msg.Checkout.Branch,
+			Worktree:  msg.Checkout.Worktree,
+		})
+	} else if c, ok := store.Release(msg.IssueID); ok {
+		work = append(work, c.Finish(session.OutcomeUnclaimed, now))
+	}
+	if err := store.Save(f.claimsPath); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	if err := session.AppendWork(f.timeLogPath, work...); err != nil {
+		m.statusMsg = fmt.Sprintf("❌ %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	f.claims = store.ClaimsBy(f.agent)
+	m.loadLoggedTime()
+	m.updateViewportContent()
+
+	m.statusIsError = false
+	if !msg.Claim {
+		m.statusMsg = fmt.Sprintf("Unclaimed %s", msg.IssueID)
+		if len(work) > 0 {
+			m.statusMsg += fmt.Sprintf(": logged %s", formatLoggedTime(time.Duration(work[0].Seconds)*time.Second))
+		}
+		return m, nil
+	}
+	m.statusMsg = fmt.Sprintf("Claimed %s: timer started", msg.IssueID)
+	if f.ticking {
+		return m, nil
+	}
+	f.ticking = true
+	return m, focusTickCmd()
+}
+
+// handleFocusTick keeps the timer ticking while a claim is running.
+func (m Model) handleFocusTick() (Model, tea.Cmd) {
+	if len(m.focus.claims) == 0 {
+		m.focus.ticking = false
+		return m, nil
+	}
+	return m, focusTickCmd()
+}
+
+// focusTimerSection renders the footer timer for the latest claim, or ""
+// when this agent holds none.
+func (m Model) focusTimerSection() string {
+	claims := m.focus.claims
+	if len(claims) == 0 {
+		return ""
+	}
+	latest := claims[len(claims)-1]
+	text := fmt.Sprintf("⏱ %s %s", latest.IssueID, formatTimer(time.Since(latest.ClaimedAt)))
+	if len(claims) > 1 {
+		text += fmt.Sprintf(" +%d", len(claims)-1)
+	}
+	return lipgloss.NewStyle().
+		Background(ColorBgHighlight).
+		Foreground(ColorWarning).
+		Bold(true).
+		Padding(0, 1).
+		Render(text)
+}
+
+// focusDetail is the detail pane line on time logged for issueID, or ""
+// when there is none and no timer running.
+func (m Model) focusDetail(issueID string) string {
+	logged := m.focus.logged[issueID]
+	_, running := m.focus.claimOn(issueID)
+	if logged.Sessions == 0 && !running {
+		return ""
+	}
+	line := "**⏱ Time logged:** "
+	if logged.Sessions == 0 {
+		line += "none yet"
+	} else {
+		line += formatLoggedTime(logged.Total)
+		if logged.Sessions > 1 {
+			line += fmt.Sprintf(" over %d sessions", logged.Sessions)
+		}
+	}
+	if running {
+		line += " · timer running (`M` to stop)"
+	}
+	return line + "\n\n"
+}
+
+// formatTimer renders a running timer: "4:05", "1:02:03".
+func formatTimer(d time.Duration) string {
+	if d < 0 {
+		d = 0
+	}
+	s := int(d / time.Second)
+	if s >= 3600 {
+		return fmt.Sprintf("%d:%02d:%02d", s/3600, s/60%60, s%60)
+	}
+	return fmt.Sprintf("%d:%02d", s/60, s%60)
+}
+
+// formatLoggedTime renders logged time: "<1m", "25m", "3h 05m".
+func formatLoggedTime(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	return fmt.Sprintf("%dh %02dm", int(d/time.Hour), int(d/time.Minute)%60)
+}
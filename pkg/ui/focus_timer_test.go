@@ -0,0 +1,90 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+func TestFocusTimer(t *testing.T) {
+	t.Setenv("BV_AGENT_ID", "")
+	beadsPath := writeTestRepo(t)
+	root := filepath.Dir(filepath.Dir(beadsPath))
+	issues := []model.Issue{
+		{ID: "ft-1", Title: "First", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+		{ID: "ft-2", Title: "Second", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, beadsPath)
+	t.Cleanup(func() { m.Stop() })
+	var ran []string
+	m.SetBdRunner(func(dir string, args ...string) ([]byte, error) {
+		ran = append(ran, strings.Join(args, " "))
+		if dir != root {
+			t.Errorf("bd ran in %s, want %s", dir, root)
+		}
+		return nil, nil
+	})
+	claim := func(id string) {
+		t.Helper()
+		for i, item := range m.list.Items() {
+			if item.(IssueItem).Issue.ID == id {
+				m.list.Select(i)
+			}
+		}
+		pressed, cmd := pressVim(t, m, "M")
+		m = pressed
+		if cmd == nil {
+			t.Fatalf("M on %s ran nothing: %s", id, m.statusMsg)
+		}
+		next, _ := m.Update(cmd())
+		m = next.(Model)
+	}
+
+	claim("ft-1")
+	if len(ran) != 1 || ran[0] != "update ft-1 --status=in_progress" {
+		t.Fatalf("bd calls %q", ran)
+	}
+	if c, ok := m.focus.claimOn("ft-1"); !ok || c.Agent != "" {
+		t.Fatalf("claims %+v", m.focus.claims)
+	}
+	if footer := m.focusTimerSection(); !strings.Contains(footer, "⏱ ft-1 0:0") {
+		t.Errorf("footer timer %q", footer)
+	}
+	if !strings.Contains(m.focusDetail("ft-1"), "timer running") {
+		t.Errorf("detail %q", m.focusDetail("ft-1"))
+	}
+
+	// Unclaiming logs the session
+	claim("ft-2")
+	claim("ft-2")
+	if ran[2] != "update ft-2 --status=open" {
+		t.Errorf("unclaim ran %q", ran[2])
+	}
+	if _, ok := m.focus.claimOn("ft-2"); ok || m.focus.logged["ft-2"].Sessions != 1 {
+		t.Errorf("ft-2 still claimed or not logged: %+v", m.focus)
+	}
+
+	// The issue closing ends the session at its close time
+	claimed, _ := m.focus.claimOn("ft-1")
+	closedAt := claimed.ClaimedAt.Add(90 * time.Minute)
+	m.issues[0].Status, m.issues[0].ClosedAt = model.StatusClosed, &closedAt
+	m.settleClaims()
+	if len(m.focus.claims) != 0 || m.focusTimerSection() != "" {
+		t.Errorf("claims after close: %+v", m.focus.claims)
+	}
+	if got := m.focusDetail("ft-1"); !strings.Contains(got, "1h 30m") {
+		t.Errorf("detail %q, want 1h 30m logged", got)
+	}
+	work, err := session.LoadTimeLog(session.TimeLogPath(root))
+	if err != nil || len(work) != 2 || work[1].Outcome != session.OutcomeClosed {
+		t.Errorf("time log %+v, %v", work, err)
+	}
+	if _, err := os.Stat(session.DefaultPath(root)); err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,55 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/session"
+)
+
+// computeFrontier is the ready frontier behind the "frontier" filter, for
+// the same agent (BV_AGENT_ID) and bv session claims --robot-frontier uses.
+func computeFrontier(issues []model.Issue) analysis.Frontier {
+	opts := analysis.FrontierOptions{Agent: os.Getenv("BV_AGENT_ID")}
+	if cwd, err := os.Getwd(); err == nil {
+		if claims, err := session.Load(session.DefaultPath(cwd)); err == nil {
+			index := make(map[string]model.Issue, len(issues))
+			for _, issue := range issues {
+				index[issue.ID] = issue
+			}
+			claims.Prune(index, time.Now())
+			opts.Claims = claims.Holders()
+		}
+	}
+	return analysis.ComputeFrontier(issues, opts)
+}
+
+// frontierSet returns the frontier's issue IDs when the frontier filter is
+// active, else nil.
+func (m *Model) frontierSet() map[string]bool {
+	if m.currentFilter != "frontier" {
+		return nil
+	}
+	ids := computeFrontier(m.issues).IDs()
+	set := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+// frontierStatus summarizes the frontier by priority for the status bar.
+func frontierStatus(f analysis.Frontier) string {
+	if f.Total == 0 {
+		return fmt.Sprintf("Frontier: nothing startable (%d blocked, %d in progress, %d claimed)", f.Blocked, f.InProgress, f.ClaimedByOthers)
+	}
+	parts := make([]string, len(f.Groups))
+	for i, g := range f.Groups {
+		parts[i] = fmt.Sprintf("P%d %d", g.Priority, g.Count)
+	}
+	return fmt.Sprintf("Frontier: %d startable now (%s)", f.Total, strings.Join(parts, " • "))
+}
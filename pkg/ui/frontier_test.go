@@ -0,0 +1,48 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestFrontierFilter(t *testing.T) {
+	t.Setenv("BV_AGENT_ID", "")
+	issues := []model.Issue{
+		{ID: "A", Title: "Start me", Status: model.StatusOpen, Priority: 1},
+		{ID: "B", Title: "Waiting", Status: model.StatusOpen, Priority: 0, Dependencies: []*model.Dependency{
+			{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks},
+		}},
+		{ID: "C", Title: "Underway", Status: model.StatusInProgress},
+		{ID: "D", Title: "Taken", Status: model.StatusOpen, Assignee: "bob"},
+	}
+	m := NewModel(issues, nil, "")
+	m.height = 30
+	m.width = 80
+	m.focused = focusList
+
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("R")})
+	if m.currentFilter != "frontier" {
+		t.Fatalf("expected filter 'frontier', got %s", m.currentFilter)
+	}
+	items := m.list.Items()
+	if len(items) != 1 || items[0].(IssueItem).Issue.ID != "A" {
+		t.Fatalf("frontier items = %v, want only A", items)
+	}
+	if want := "Frontier: 1 startable now (P1 1)"; m.statusMsg != want {
+		t.Errorf("status = %q, want %q", m.statusMsg, want)
+	}
+	m.statusMsg = ""
+	if footer := m.renderFooter(); !strings.Contains(footer, "FRONTIER") {
+		t.Errorf("footer missing FRONTIER: %s", footer)
+	}
+
+	// The ready filter still mixes in in-progress work.
+	m = m.handleListKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if len(m.list.Items()) <= 1 {
+		t.Errorf("ready filter should be broader than the frontier, got %d items", len(m.list.Items()))
+	}
+}
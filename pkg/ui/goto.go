@@ -0,0 +1,51 @@
+package ui
+
+import "fmt"
+
+// GotoIssue opens the TUI on issueID for `bv --goto`: the issue selected
+// in the list and board, and the tree view showing its neighborhood, with
+// its parents and children expanded. Filters that would hide the issue are
+// cleared. It overrides the view restored from the last session.
+func (m *Model) GotoIssue(issueID string) error {
+	if _, ok := m.issueMap[issueID]; !ok {
+		return fmt.Errorf("issue %s not found", issueID)
+	}
+
+	if !m.selectListIssue(issueID) {
+		m.clearAllFilters()
+		if !m.selectListIssue(issueID) {
+			m.activeRepos = nil
+			m.applyFilter()
+			m.selectListIssue(issueID)
+		}
+	}
+	m.board.SelectIssueByID(issueID)
+
+	m.clearAttentionOverlay()
+	m.isGraphView = false
+	m.isBoardView = false
+	m.isActionableView = false
+	m.isHistoryView = false
+	if m.snapshot != nil {
+		m.tree.BuildFromSnapshot(m.snapshot)
+	} else {
+		m.tree.Build(m.issues)
+	}
+	m.tree.SetSize(m.width, m.height-2)
+	m.tree.Reveal(issueID)
+	m.focused = focusTree
+	m.updateViewportContent()
+	return nil
+}
+
+// selectListIssue selects issueID in the list, reporting whether the
+// current filter shows it.
+func (m *Model) selectListIssue(issueID string) bool {
+	for i, item := range m.list.Items() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == issueID {
+			m.list.Select(i)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,53 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestGotoIssue(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "gt-1", Title: "Epic", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeEpic},
+		{ID: "gt-2", Title: "Task", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "gt-2", DependsOnID: "gt-1", Type: model.DepParentChild}}},
+		{ID: "gt-3", Title: "Subtask", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "gt-3", DependsOnID: "gt-2", Type: model.DepParentChild}}},
+		{ID: "gt-4", Title: "Leaf", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "gt-4", DependsOnID: "gt-3", Type: model.DepParentChild}}},
+	}
+	m := NewModel(issues, nil, "")
+	t.Cleanup(func() { m.Stop() })
+	m.currentFilter = "open"
+	m.applyFilter()
+
+	if err := m.GotoIssue("gt-3"); err != nil {
+		t.Fatal(err)
+	}
+	if m.FocusState() != "tree" {
+		t.Errorf("focus = %s, want the tree view", m.FocusState())
+	}
+	if m.currentFilter != "all" {
+		t.Errorf("the open filter hiding gt-3 should be cleared, got %q", m.currentFilter)
+	}
+	if sel, ok := m.list.SelectedItem().(IssueItem); !ok || sel.Issue.ID != "gt-3" {
+		t.Errorf("list selection = %v", m.list.SelectedItem())
+	}
+	if got := m.tree.GetSelectedID(); got != "gt-3" {
+		t.Errorf("tree selection = %q", got)
+	}
+	// Parents and children are on screen
+	visible := map[string]bool{}
+	for _, node := range m.tree.flatList {
+		visible[node.Issue.ID] = true
+	}
+	for _, id := range []string{"gt-1", "gt-2", "gt-3", "gt-4"} {
+		if !visible[id] {
+			t.Errorf("%s not visible in the tree", id)
+		}
+	}
+
+	if err := m.GotoIssue("gt-99"); err == nil {
+		t.Error("an unknown issue should be an error")
+	}
+}
@@ -38,6 +38,15 @@ type GraphModel struct {
 	rankCriticalPath map[string]int
 	rankInDegree     map[string]int
 	rankOutDegree    map[string]int
+
+	// Condensed mode: each dependency cycle shown as one node (nil = off)
+	condensed       *condensedGraph
+	condensedIdx    int
+	condensedScroll int
+
+	// Render caching: dataHash identifies the issues shown (see renderKey)
+	dataHash string
+	render   *renderCache
 }
 
 // NewGraphModel creates a new graph view from issues
@@ -46,6 +55,8 @@ func NewGraphModel(issues []model.Issue, insights *analysis.Insights, theme Them
 		issues:   issues,
 		insights: insights,
 		theme:    theme,
+		dataHash: issuesRenderHash(issues),
+		render:   newRenderCache(),
 	}
 	g.rebuildGraph()
 	return g
@@ -67,6 +78,10 @@ func (g *GraphModel) SetSnapshot(snapshot *DataSnapshot) {
 	g.issues = snapshot.Issues
 	g.issueMap = snapshot.IssueMap
 	g.insights = &snapshot.Insights
+	g.dataHash = snapshot.DataHash
+	if g.dataHash == "" {
+		g.dataHash = issuesRenderHash(g.issues)
+	}
 
 	if g.issueMap == nil {
 		g.issueMap = make(map[string]*model.Issue, len(g.issues))
@@ -109,6 +124,20 @@ func (g *GraphModel) SetSnapshot(snapshot *DataSnapshot) {
 	if g.selectedIdx >= len(g.sortedIDs) {
 		g.selectedIdx = 0
 	}
+	g.recondense()
+}
+
+// recondense rebuilds the condensed graph after the issues change, keeping
+// the selection on the node that holds the same first issue.
+func (g *GraphModel) recondense() {
+	if g.condensed == nil {
+		return
+	}
+	var selectedID string
+	if issue := g.SelectedIssue(); issue != nil {
+		selectedID = issue.ID
+	}
+	g.condense(selectedID)
 }
 
 // SetIssues updates the graph data preserving the selected issue if possible
@@ -121,6 +150,7 @@ func (g *GraphModel) SetIssues(issues []model.Issue, insights *analysis.Insights
 
 	g.issues = issues
 	g.insights = insights
+	g.dataHash = issuesRenderHash(issues)
 	g.rebuildGraph()
 
 	// Restore selection
@@ -143,6 +173,7 @@ func (g *GraphModel) SetIssues(issues []model.Issue, insights *analysis.Insights
 			}
 		}
 	}
+	g.recondense()
 }
 
 func (g *GraphModel) rebuildGraph() {
@@ -220,6 +251,10 @@ func (g *GraphModel) computeRankings() {
 
 // Navigation
 func (g *GraphModel) MoveUp() {
+	if g.condensed != nil {
+		g.moveCondensed(-1)
+		return
+	}
 	if g.selectedIdx > 0 {
 		g.selectedIdx--
 		g.ensureVisible()
@@ -227,6 +262,10 @@ func (g *GraphModel) MoveUp() {
 }
 
 func (g *GraphModel) MoveDown() {
+	if g.condensed != nil {
+		g.moveCondensed(1)
+		return
+	}
 	if g.selectedIdx < len(g.sortedIDs)-1 {
 		g.selectedIdx++
 		g.ensureVisible()
@@ -237,6 +276,10 @@ func (g *GraphModel) MoveLeft()  { g.MoveUp() }
 func (g *GraphModel) MoveRight() { g.MoveDown() }
 
 func (g *GraphModel) PageUp() {
+	if g.condensed != nil {
+		g.moveCondensed(-10)
+		return
+	}
 	g.selectedIdx -= 10
 	if g.selectedIdx < 0 {
 		g.selectedIdx = 0
@@ -245,6 +288,10 @@ func (g *GraphModel) PageUp() {
 }
 
 func (g *GraphModel) PageDown() {
+	if g.condensed != nil {
+		g.moveCondensed(10)
+		return
+	}
 	if len(g.sortedIDs) == 0 {
 		return
 	}
@@ -261,6 +308,12 @@ func (g *GraphModel) ScrollRight() {}
 func (g *GraphModel) ensureVisible() {}
 
 func (g *GraphModel) SelectedIssue() *model.Issue {
+	if g.condensed != nil {
+		if len(g.condensed.order) == 0 {
+			return nil
+		}
+		return g.issueMap[g.condensedMembers(g.condensed.order[g.condensedIdx])[0]]
+	}
 	if len(g.sortedIDs) == 0 {
 		return nil
 	}
@@ -270,6 +323,19 @@ func (g *GraphModel) SelectedIssue() *model.Issue {
 
 // SelectByID selects an issue by its ID (bv-xf4p)
 func (g *GraphModel) SelectByID(id string) bool {
+	if g.condensed != nil {
+		nodeID, ok := g.condensed.cond.Component[id]
+		if !ok {
+			return false
+		}
+		for i, orderID := range g.condensed.order {
+			if orderID == nodeID {
+				g.condensedIdx = i
+				return true
+			}
+		}
+		return false
+	}
 	for i, sortedID := range g.sortedIDs {
 		if sortedID == id {
 			g.selectedIdx = i
@@ -281,15 +347,40 @@ func (g *GraphModel) SelectByID(id string) bool {
 }
 
 func (g *GraphModel) TotalCount() int {
+	if g.condensed != nil {
+		return len(g.condensed.order)
+	}
 	return len(g.sortedIDs)
 }
 
-// View renders the visual graph view
+// View renders the visual graph view, reusing the last output while nothing
+// it shows has changed.
 func (g *GraphModel) View(width, height int) string {
 	g.width = width
 	g.height = height
+	return g.render.render(g.renderKey(), g.renderGraph)
+}
+
+// renderKey captures everything the graph's output depends on: the issues,
+// the metrics (Phase 2 landing replaces the rank maps rather than filling
+// them in), the ordering, the size and the selection.
+func (g *GraphModel) renderKey() string {
+	return fmt.Sprintf("%s|%p|%p|%p|%p|%d|%dx%d|%d|%d|%p|%d|%d",
+		g.dataHash, g.insights, g.rankPageRank, g.rankCriticalPath, g.sortedIDs, len(g.sortedIDs),
+		g.width, g.height, g.selectedIdx, g.scrollOffset,
+		g.condensed, g.condensedIdx, g.condensedScroll)
+}
+
+// renderGraph renders the graph at g.width x g.height without consulting
+// the cache.
+func (g *GraphModel) renderGraph() string {
+	width, height := g.width, g.height
 	t := g.theme
 
+	if g.condensed != nil {
+		return g.renderCondensed(width, height, t)
+	}
+
 	if len(g.sortedIDs) == 0 {
 		return t.Renderer.NewStyle().
 			Width(width).
@@ -343,7 +434,7 @@ func (g *GraphModel) renderNodeList(width, height int, t Theme) string {
 		Bold(true).
 		Foreground(t.Primary).
 		Width(width)
-	lines = append(lines, headerStyle.Render(fmt.Sprintf("📊 Nodes (%d)", len(g.sortedIDs))))
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("%s Nodes (%d)", glyph("📊", "#"), len(g.sortedIDs))))
 	lines = append(lines, strings.Repeat("─", width))
 
 	visibleItems := height - 4
@@ -447,7 +538,7 @@ func (g *GraphModel) renderVisualGraph(id string, issue *model.Issue, width, hei
 		Foreground(t.Secondary).
 		Italic(true)
 	sections = append(sections, "")
-	sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • g: back to list"))
+	sections = append(sections, navStyle.Render("j/k: navigate • enter: view details • c: condense cycles • g: back to list"))
 
 	return strings.Join(sections, "\n")
 }
@@ -570,7 +661,7 @@ func (g *GraphModel) renderNodeBox(id string, boxWidth int, t Theme, isEgo bool)
 			title = truncateRunesHelper(issue.Title, boxWidth-4, "…")
 		}
 	} else {
-		statusIcon = "❓"
+		statusIcon = glyph("❓", "[?]")
 		statusColor = t.Secondary
 		displayID = smartTruncateID(id, boxWidth-4)
 		title = "(not in filter)"
@@ -712,7 +803,7 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 		Padding(0, 2).
 		Width(width - 4)
 
-	panelTitle := panelHeaderStyle.Render("📊 GRAPH METRICS")
+	panelTitle := panelHeaderStyle.Render(glyph("📊", "#") + " GRAPH METRICS")
 
 	if g.insights == nil || g.insights.Stats == nil {
 		noDataStyle := t.Renderer.NewStyle().
@@ -875,6 +966,9 @@ func (g *GraphModel) renderMetricsPanel(id string, width int, t Theme) string {
 // Helper functions
 
 func getStatusIcon(status model.Status) string {
+	if ASCIIIcons() {
+		return asciiStatusTag(string(status))
+	}
 	switch {
 	case isClosedLikeStatus(status):
 		return "✅"
@@ -919,6 +1013,9 @@ func getStatusColor(status model.Status, t Theme) lipgloss.AdaptiveColor {
 }
 
 func getPriorityIcon(priority int) string {
+	if ASCIIIcons() {
+		return asciiPriorityTag(priority)
+	}
 	switch priority {
 	case 1:
 		return "🔥"
@@ -934,6 +1031,9 @@ func getPriorityIcon(priority int) string {
 }
 
 func getTypeIcon(itype model.IssueType) string {
+	if ASCIIIcons() {
+		return asciiTypeTag(string(itype))
+	}
 	switch itype {
 	case model.TypeBug:
 		return "🐛"
@@ -0,0 +1,271 @@
+package ui
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+
+	"github.com/charmbracelet/lipgloss"
+)
+
+// condensedGraph is the graph view with every dependency cycle collapsed
+// into one node, which keeps heavily cycled graphs readable.
+type condensedGraph struct {
+	cond    *analysis.Condensation
+	order   []string // Node IDs: cycles first, largest first, then by ID
+	nodes   map[string]*analysis.CondensedNode
+	waitsOn map[string][]analysis.CondensedEdge // Edges out of a node
+	blocks  map[string][]analysis.CondensedEdge // Edges into a node
+}
+
+func newCondensedGraph(cond *analysis.Condensation) *condensedGraph {
+	c := &condensedGraph{
+		cond:    cond,
+		nodes:   make(map[string]*analysis.CondensedNode, len(cond.Nodes)),
+		waitsOn: make(map[string][]analysis.CondensedEdge),
+		blocks:  make(map[string][]analysis.CondensedEdge),
+	}
+	for i := range cond.Nodes {
+		n := &cond.Nodes[i]
+		c.nodes[n.ID] = n
+		c.order = append(c.order, n.ID)
+	}
+	sort.SliceStable(c.order, func(i, j int) bool {
+		return len(c.nodes[c.order[i]].Members) > len(c.nodes[c.order[j]].Members)
+	})
+	for _, e := range cond.Edges {
+		c.waitsOn[e.From] = append(c.waitsOn[e.From], e)
+		c.blocks[e.To] = append(c.blocks[e.To], e)
+	}
+	return c
+}
+
+// ToggleCondensed switches between the issue graph and the condensed graph,
+// keeping the selection on the same issue or the cycle containing it.
+// It reports whether the condensed graph is now shown.
+func (g *GraphModel) ToggleCondensed() bool {
+	if g.condensed != nil {
+		selected := g.SelectedIssue()
+		g.condensed = nil
+		if selected != nil {
+			g.SelectByID(selected.ID)
+		}
+		return false
+	}
+	var selectedID string
+	if issue := g.SelectedIssue(); issue != nil {
+		selectedID = issue.ID
+	}
+	g.condense(selectedID)
+	return true
+}
+
+// IsCondensed reports whether the condensed graph is shown.
+func (g *GraphModel) IsCondensed() bool {
+	return g.condensed != nil
+}
+
+// condense rebuilds the condensed graph from the current issues and selects
+// the node containing selectedID.
+func (g *GraphModel) condense(selectedID string) {
+	g.condensed = newCondensedGraph(analysis.NewAnalyzer(g.issues).Condense())
+	g.condensedIdx = 0
+	g.condensedScroll = 0
+	if nodeID, ok := g.condensed.cond.Component[selectedID]; ok {
+		for i, id := range g.condensed.order {
+			if id == nodeID {
+				g.condensedIdx = i
+				break
+			}
+		}
+	}
+}
+
+// condensedMembers returns the issues in a condensed node.
+func (g *GraphModel) condensedMembers(nodeID string) []string {
+	if g.condensed == nil {
+		return []string{nodeID}
+	}
+	if n := g.condensed.nodes[nodeID]; n != nil {
+		return n.Members
+	}
+	return []string{nodeID}
+}
+
+func (g *GraphModel) moveCondensed(delta int) {
+	g.condensedIdx += delta
+	if g.condensedIdx >= len(g.condensed.order) {
+		g.condensedIdx = len(g.condensed.order) - 1
+	}
+	if g.condensedIdx < 0 {
+		g.condensedIdx = 0
+	}
+}
+
+// renderCondensed renders the condensed graph: the node list on the left and
+// the selected node with what it waits on and what it blocks on the right.
+func (g *GraphModel) renderCondensed(width, height int, t Theme) string {
+	c := g.condensed
+	if len(c.order) == 0 {
+		return t.Renderer.NewStyle().
+			Width(width).
+			Height(height).
+			Align(lipgloss.Center, lipgloss.Center).
+			Foreground(t.Secondary).
+			Render("No issues to display")
+	}
+
+	listWidth := 28
+	if width < 120 {
+		listWidth = 24
+	}
+	detailWidth := width - listWidth - 3
+	if width < 80 {
+		return g.renderCondensedNode(c.order[g.condensedIdx], width, height, t)
+	}
+
+	sepHeight := height - 2
+	if sepHeight < 1 {
+		sepHeight = 1
+	}
+	separator := t.Renderer.NewStyle().
+		Foreground(t.Secondary).
+		Render(strings.Repeat("│\n", sepHeight))
+
+	return lipgloss.JoinHorizontal(lipgloss.Top,
+		g.renderCondensedList(listWidth, height-2, t),
+		separator,
+		g.renderCondensedNode(c.order[g.condensedIdx], detailWidth, height-2, t))
+}
+
+func (g *GraphModel) renderCondensedList(width, height int, t Theme) string {
+	c := g.condensed
+	var lines []string
+	headerStyle := t.Renderer.NewStyle().
+		Bold(true).
+		Foreground(t.Primary).
+		Width(width)
+	lines = append(lines, headerStyle.Render(fmt.Sprintf("%s Condensed (%d, %d cycles)", glyph("🔁", "@"), len(c.order), c.cond.CyclicCount)))
+	lines = append(lines, strings.Repeat("─", width))
+
+	visibleItems := height - 4
+	if visibleItems < 1 {
+		visibleItems = 1
+	}
+	startIdx := g.condensedScroll
+	if g.condensedIdx < startIdx {
+		startIdx = g.condensedIdx
+	} else if g.condensedIdx >= startIdx+visibleItems {
+		startIdx = g.condensedIdx - visibleItems + 1
+	}
+	g.condensedScroll = startIdx
+	endIdx := startIdx + visibleItems
+	if endIdx > len(c.order) {
+		endIdx = len(c.order)
+	}
+
+	for i := startIdx; i < endIdx; i++ {
+		n := c.nodes[c.order[i]]
+		var line string
+		style := t.Renderer.NewStyle().Width(width)
+		if n.Cyclic {
+			line = fmt.Sprintf("%s %s ×%d", glyph("🔁", "@"), n.ID, len(n.Members))
+			style = style.Foreground(t.Blocked)
+		} else if issue := g.issueMap[n.ID]; issue != nil {
+			line = fmt.Sprintf("%s %s", getStatusIcon(issue.Status), smartTruncateID(n.ID, width-4))
+			style = style.Foreground(getStatusColor(issue.Status, t))
+		}
+		if i == g.condensedIdx {
+			style = style.Bold(true).Foreground(t.Primary).Background(t.Highlight)
+		}
+		lines = append(lines, style.Render(line))
+	}
+
+	if len(c.order) > visibleItems {
+		lines = append(lines, t.Renderer.NewStyle().
+			Foreground(t.Secondary).
+			Italic(true).
+			Width(width).
+			Align(lipgloss.Center).
+			Render(fmt.Sprintf("(%d-%d of %d)", startIdx+1, endIdx, len(c.order))))
+	}
+	return strings.Join(lines, "\n")
+}
+
+func (g *GraphModel) renderCondensedNode(nodeID string, width, height int, t Theme) string {
+	c := g.condensed
+	n := c.nodes[nodeID]
+	var sections []string
+
+	headerStyle := t.Renderer.NewStyle().Bold(true).Foreground(t.Feature)
+	secondary := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	edgeLines := func(edges []analysis.CondensedEdge, other func(analysis.CondensedEdge) string) []string {
+		var lines []string
+		for i, e := range edges {
+			if i >= 8 {
+				lines = append(lines, secondary.Italic(true).Render(fmt.Sprintf("  +%d more", len(edges)-8)))
+				break
+			}
+			lines = append(lines, "  "+g.condensedNodeLabel(other(e), width-12)+secondary.Render(fmt.Sprintf(" (%d)", e.Weight)))
+		}
+		return lines
+	}
+
+	if waits := c.waitsOn[nodeID]; len(waits) > 0 {
+		sections = append(sections, headerStyle.Render("▲ WAITS ON ▲"))
+		sections = append(sections, edgeLines(waits, func(e analysis.CondensedEdge) string { return e.To })...)
+		sections = append(sections, "")
+	}
+
+	if n.Cyclic {
+		boxLines := []string{fmt.Sprintf("%s %s: cycle of %d issues", glyph("🔁", "@"), n.ID, len(n.Members))}
+		limit := height - 16
+		if limit < 3 {
+			limit = 3
+		}
+		for i, id := range n.Members {
+			if i >= limit {
+				boxLines = append(boxLines, fmt.Sprintf("+%d more", len(n.Members)-limit))
+				break
+			}
+			boxLines = append(boxLines, g.condensedNodeLabel(id, width-10))
+		}
+		boxWidth := width - 4
+		if boxWidth > 60 {
+			boxWidth = 60
+		}
+		sections = append(sections, t.Renderer.NewStyle().
+			Border(lipgloss.DoubleBorder()).
+			BorderForeground(t.Blocked).
+			Width(boxWidth).
+			Padding(0, 1).
+			Render(strings.Join(boxLines, "\n")))
+	} else if issue := g.issueMap[nodeID]; issue != nil {
+		sections = append(sections, g.renderEgoNode(nodeID, issue, width, t))
+	}
+
+	if blocks := c.blocks[nodeID]; len(blocks) > 0 {
+		sections = append(sections, "")
+		sections = append(sections, headerStyle.Render("▼ BLOCKS ▼"))
+		sections = append(sections, edgeLines(blocks, func(e analysis.CondensedEdge) string { return e.From })...)
+	}
+
+	sections = append(sections, "")
+	sections = append(sections, secondary.Italic(true).Render("j/k: navigate • enter: view details • c: expand cycles • g: back to list"))
+	return strings.Join(sections, "\n")
+}
+
+// condensedNodeLabel is a one-line label for a condensed node or an issue.
+func (g *GraphModel) condensedNodeLabel(id string, width int) string {
+	if n := g.condensed.nodes[id]; n != nil && n.Cyclic {
+		return fmt.Sprintf("%s %s (cycle of %d)", glyph("🔁", "@"), n.ID, len(n.Members))
+	}
+	issue := g.issueMap[id]
+	if issue == nil {
+		return id
+	}
+	return truncateRunesHelper(fmt.Sprintf("%s %s %s", getStatusIcon(issue.Status), id, issue.Title), width, "…")
+}
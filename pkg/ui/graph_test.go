@@ -2,6 +2,7 @@ package ui_test
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -158,6 +159,55 @@ func TestGraphModelCycleDetection(t *testing.T) {
 	}
 }
 
+// TestGraphModelCondensed verifies the cycle-collapsing condensed mode
+func TestGraphModelCondensed(t *testing.T) {
+	theme := createTheme()
+
+	// Cycle A -> B -> C -> A, and D waits on the cycle
+	issues := []model.Issue{
+		{ID: "A", Title: "A", Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+		{ID: "B", Title: "B", Dependencies: []*model.Dependency{{DependsOnID: "C", Type: model.DepBlocks}}},
+		{ID: "C", Title: "C", Dependencies: []*model.Dependency{{DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "D", Title: "D", Dependencies: []*model.Dependency{{DependsOnID: "B", Type: model.DepBlocks}}},
+	}
+
+	g := ui.NewGraphModel(issues, nil, theme)
+	g.SelectByID("D")
+	if !g.ToggleCondensed() || !g.IsCondensed() {
+		t.Fatal("expected condensed mode on")
+	}
+	if g.TotalCount() != 2 {
+		t.Errorf("Expected 2 condensed nodes, got %d", g.TotalCount())
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "D" {
+		t.Errorf("Expected selection to stay on D, got %v", sel)
+	}
+
+	if out := g.View(120, 30); !strings.Contains(out, "Condensed (2, 1 cycles)") || !strings.Contains(out, "WAITS ON") {
+		t.Errorf("condensed view of D missing header or blockers:\n%s", out)
+	}
+	g.SelectByID("B")
+	if out := g.View(120, 30); !strings.Contains(out, "scc-1: cycle of 3 issues") {
+		t.Errorf("condensed view of the cycle missing its box:\n%s", out)
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "A" {
+		t.Errorf("Expected the cycle's first member A, got %v", sel)
+	}
+
+	// Reloading keeps condensed mode and the selected cycle
+	g.SetIssues(issues, nil)
+	if !g.IsCondensed() || g.SelectedIssue().ID != "A" {
+		t.Error("Expected condensed mode and selection to survive SetIssues")
+	}
+
+	if g.ToggleCondensed() || g.TotalCount() != 4 {
+		t.Errorf("Expected the full graph back, got %d nodes", g.TotalCount())
+	}
+	if sel := g.SelectedIssue(); sel == nil || sel.ID != "A" {
+		t.Errorf("Expected selection on A after expanding, got %v", sel)
+	}
+}
+
 // TestGraphModelNavigation verifies node navigation
 func TestGraphModelNavigation(t *testing.T) {
 	theme := createTheme()
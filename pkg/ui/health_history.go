@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// healthHistoryMsg carries the graph health history of the beads file and
+// the blocked time over the same revisions.
+type healthHistoryMsg struct {
+	history analysis.HealthHistory
+	blocked analysis.BlockedTime
+	err     error
+}
+
+// loadHealthHistoryCmd scores the graph and adds up blocked time at recent
+// git revisions of the beads file in repoPath, off the UI goroutine. current
+// stands in for the newest revision.
+func loadHealthHistoryCmd(repoPath string, current []model.Issue) tea.Cmd {
+	return func() tea.Msg {
+		gitLoader := loader.NewGitLoader(repoPath)
+		revisions, err := gitLoader.ListRevisions(analysis.DefaultHealthHistoryRevisions)
+		if err != nil {
+			return healthHistoryMsg{err: err}
+		}
+		healthRevs := make([]analysis.HealthRevision, len(revisions))
+		for i, rev := range revisions {
+			healthRevs[i] = analysis.HealthRevision{SHA: rev.SHA, Timestamp: rev.Timestamp, Message: rev.Message}
+		}
+		// Both passes read the same revisions; load each one once
+		loaded := make(map[string][]model.Issue, len(revisions))
+		load := func(sha string) ([]model.Issue, error) {
+			if issues, ok := loaded[sha]; ok {
+				return issues, nil
+			}
+			issues, err := gitLoader.LoadAt(sha)
+			if err == nil {
+				loaded[sha] = issues
+			}
+			return issues, err
+		}
+		history, err := analysis.ComputeHealthHistory(healthRevs, load)
+		if err != nil {
+			return healthHistoryMsg{err: err}
+		}
+		blocked, err := analysis.ComputeBlockedTime(healthRevs, load, current, time.Now())
+		return healthHistoryMsg{history: history, blocked: blocked, err: err}
+	}
+}
+
+// handleHealthHistory caches the loaded history and blocked time and shows
+// them in the insights view. Without git history there is no trend and no
+// blocked time; the current score still shows.
+func (m Model) handleHealthHistory(msg healthHistoryMsg) Model {
+	m.healthHistoryLoading = false
+	history, blocked := msg.history, msg.blocked
+	if msg.err != nil {
+		history, blocked = analysis.HealthHistory{}, analysis.BlockedTime{}
+	}
+	m.healthHistory = &history
+	m.blockedTime = &blocked
+	if m.focused == focusInsights {
+		m.insightsPanel.SetHealth(analysis.ComputeHealthScore(m.issues), m.healthHistory)
+		m.insightsPanel.SetBlockedTime(m.blockedTime)
+	}
+	return m
+}
+
+// insightsHealthCmd sets the current graph health on the insights view and
+// starts loading its history the first time it's needed.
+func (m *Model) insightsHealthCmd() tea.Cmd {
+	m.insightsPanel.SetHealth(analysis.ComputeHealthScore(m.issues), m.healthHistory)
+	m.insightsPanel.SetBlockedTime(m.blockedTime)
+	if m.healthHistory != nil || m.healthHistoryLoading || m.beadsPath == "" {
+		return nil
+	}
+	m.healthHistoryLoading = true
+	return loadHealthHistoryCmd(repoRootFromBeadsPath(m.beadsPath), m.issues)
+}
@@ -2,74 +2,90 @@ package ui
 
 import (
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/mattn/go-runewidth"
 )
 
 // FormatTimeRel returns a relative time string (e.g., "2h ago", "3d ago")
 func FormatTimeRel(t time.Time) string {
-	if t.IsZero() {
-		return "unknown"
-	}
+	return timefmt.Relative(t, timefmt.Now())
+}
 
-	d := time.Since(t)
-	if d < 0 {
-		// Future timestamps treated as now
-		return "now"
-	}
-	switch {
-	case d < time.Minute:
-		return "now"
-	case d < time.Hour:
-		return fmt.Sprintf("%dm ago", int(d.Minutes()))
-	case d < 24*time.Hour:
-		return fmt.Sprintf("%dh ago", int(d.Hours()))
-	case d < 7*24*time.Hour:
-		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
-	case d < 30*24*time.Hour:
-		return fmt.Sprintf("%dw ago", int(d.Hours()/(24*7)))
-	default:
-		return fmt.Sprintf("%dmo ago", int(d.Hours()/(24*30)))
-	}
+// FormatTime formats a timestamp in the style chosen with ui.time_format or
+// Ctrl+T: relative ("3d ago") or absolute ("2025-01-02 15:04").
+func FormatTime(t time.Time) string {
+	return timefmt.Format(t)
+}
+
+// formatTimeShort is FormatTime for narrow columns (list rows, board
+// cards): absolute times shrink to the time of day, month and day, or year.
+func formatTimeShort(t time.Time) string {
+	return timefmt.Short(t)
 }
 
 // truncateRunesHelper truncates a string to max visual width (cells), adding suffix if needed.
-// Uses go-runewidth to handle wide characters correctly.
+// Uses displayWidth to handle wide characters correctly.
 func truncateRunesHelper(s string, maxWidth int, suffix string) string {
 	if maxWidth <= 0 {
 		return ""
 	}
 
-	width := runewidth.StringWidth(s)
+	width := displayWidth(s)
 	if width <= maxWidth {
 		return s
 	}
 
-	suffixWidth := runewidth.StringWidth(suffix)
+	suffixWidth := displayWidth(suffix)
 	if suffixWidth > maxWidth {
 		// Even suffix is too wide, truncate suffix
-		return runewidth.Truncate(suffix, maxWidth, "")
+		return truncateWidth(suffix, maxWidth)
 	}
 
 	targetWidth := maxWidth - suffixWidth
-	return runewidth.Truncate(s, targetWidth, "") + suffix
+	return truncateWidth(s, targetWidth) + suffix
 }
 
 // padRight pads string s with spaces on the right to reach visual width.
-// Uses go-runewidth to handle wide characters (emojis, CJK) correctly,
+// Uses displayWidth to handle wide characters (emojis, CJK) correctly,
 // consistent with truncateRunesHelper which also uses visual width.
 func padRight(s string, width int) string {
-	visualWidth := runewidth.StringWidth(s)
+	visualWidth := displayWidth(s)
 	if visualWidth >= width {
 		return s
 	}
 	return s + strings.Repeat(" ", width-visualWidth)
 }
 
+// styledMessage renders a catalog message whose %s placeholders are keys
+// or other highlighted values: the text in text, each arg in arg. Indexed
+// placeholders (%[2]s) let translations reorder the args.
+func styledMessage(msg string, text, arg lipgloss.Style, args ...string) string {
+	var sb strings.Builder
+	last, next := 0, 0
+	for _, loc := range styledPlaceholder.FindAllStringSubmatchIndex(msg, -1) {
+		sb.WriteString(text.Render(msg[last:loc[0]]))
+		i := next
+		if loc[2] >= 0 {
+			n, _ := strconv.Atoi(msg[loc[2]:loc[3]])
+			i = n - 1
+		}
+		if i >= 0 && i < len(args) {
+			sb.WriteString(arg.Render(args[i]))
+		}
+		next, last = i+1, loc[1]
+	}
+	sb.WriteString(text.Render(msg[last:]))
+	return sb.String()
+}
+
+var styledPlaceholder = regexp.MustCompile(`%(?:\[(\d+)\])?s`)
+
 // truncate truncates string s to maxRunes
 func truncate(s string, maxRunes int) string {
 	return truncateRunesHelper(s, maxRunes, "…")
@@ -221,6 +237,9 @@ func getDepTypeIcon(depType string) string {
 
 // GetStatusIcon returns a colored icon for a status
 func GetStatusIcon(s string) string {
+	if ASCIIIcons() {
+		return asciiStatusTag(s)
+	}
 	switch s {
 	case "open":
 		return "🟢"
@@ -237,6 +256,9 @@ func GetStatusIcon(s string) string {
 
 // GetPriorityIcon returns the emoji for a priority level
 func GetPriorityIcon(priority int) string {
+	if ASCIIIcons() {
+		return asciiPriorityTag(priority)
+	}
 	switch priority {
 	case 0:
 		return "🔥" // Critical
@@ -3,6 +3,7 @@ package ui
 
 import (
 	"fmt"
+	"slices"
 	"sort"
 	"strings"
 	"time"
@@ -53,7 +54,41 @@ type CommitListEntry struct {
 	Author    string
 	Timestamp string
 	FileCount int
-	BeadIDs   []string // Beads related to this commit
+	BeadIDs   []string                // Beads related to this commit
+	Events    []correlation.BeadEvent // Issue-level changes this commit made to the beads file
+}
+
+// EventCounts summarizes the commit's issue-level events. Claims, reopens,
+// and other field changes all count as edits.
+func (c CommitListEntry) EventCounts() (created, closed, edited int) {
+	for _, e := range c.Events {
+		switch e.EventType {
+		case correlation.EventCreated:
+			created++
+		case correlation.EventClosed:
+			closed++
+		default:
+			edited++
+		}
+	}
+	return created, closed, edited
+}
+
+// EventBadge renders the event summary compactly, e.g. "+2 ✓1 ~3".
+// Returns "" for commits that didn't touch the beads file.
+func (c CommitListEntry) EventBadge() string {
+	created, closed, edited := c.EventCounts()
+	var parts []string
+	if created > 0 {
+		parts = append(parts, fmt.Sprintf("+%d", created))
+	}
+	if closed > 0 {
+		parts = append(parts, fmt.Sprintf("✓%d", closed))
+	}
+	if edited > 0 {
+		parts = append(parts, fmt.Sprintf("~%d", edited))
+	}
+	return strings.Join(parts, " ")
 }
 
 // historySearchMode tracks what type of search is active (bv-nkrj)
@@ -1036,6 +1071,45 @@ func (h *HistoryModel) buildCommitList() {
 		}
 	}
 
+	// Fold in lifecycle events parsed from the beads file history so commits
+	// that only touched .beads/ show up with their issue-level changes.
+	index := make(map[string]int, len(entries))
+	for i := range entries {
+		index[entries[i].SHA] = i
+	}
+	for beadID, hist := range h.report.Histories {
+		for _, ev := range hist.Events {
+			if ev.CommitSHA == "" {
+				continue
+			}
+			i, ok := index[ev.CommitSHA]
+			if !ok {
+				shortSHA := ev.CommitSHA
+				if len(shortSHA) > 7 {
+					shortSHA = shortSHA[:7]
+				}
+				entries = append(entries, CommitListEntry{
+					SHA:       ev.CommitSHA,
+					ShortSHA:  shortSHA,
+					Message:   ev.CommitMsg,
+					Author:    ev.Author,
+					Timestamp: ev.Timestamp.Format("2006-01-02 15:04"),
+				})
+				i = len(entries) - 1
+				index[ev.CommitSHA] = i
+			}
+			entries[i].Events = append(entries[i].Events, ev)
+			if !slices.Contains(entries[i].BeadIDs, beadID) {
+				entries[i].BeadIDs = append(entries[i].BeadIDs, beadID)
+			}
+		}
+	}
+	for i := range entries {
+		sort.SliceStable(entries[i].Events, func(a, b int) bool {
+			return entries[i].Events[a].BeadID < entries[i].Events[b].BeadID
+		})
+	}
+
 	// Sort by timestamp descending (most recent first)
 	// Note: We parse from formatted string since we stored it that way
 	sort.Slice(entries, func(i, j int) bool {
@@ -3077,11 +3151,14 @@ func (h *HistoryModel) renderGitCommitLine(idx int, commit CommitListEntry, widt
 		indicator = "▸ "
 	}
 
-	// Bead count badge
+	// Bead count badge, prefixed by the issue-level event summary
 	beadCount := fmt.Sprintf("[%d]", len(commit.BeadIDs))
+	if badge := commit.EventBadge(); badge != "" {
+		beadCount = badge + " " + beadCount
+	}
 
 	// Truncate message
-	maxMsgLen := width - len(indicator) - len(commit.ShortSHA) - len(beadCount) - 6
+	maxMsgLen := width - len(indicator) - len(commit.ShortSHA) - lipgloss.Width(beadCount) - 6
 	if maxMsgLen < 10 {
 		maxMsgLen = 10
 	}
@@ -3188,6 +3265,22 @@ func (h *HistoryModel) renderGitDetailPanel(width, height int) string {
 		lines = append(lines, beadLine)
 	}
 
+	// Issue-level events recorded in the beads file by this commit
+	if len(commit.Events) > 0 {
+		lines = append(lines, "")
+		lines = append(lines, strings.Repeat("─", detailSepWidth))
+		lines = append(lines, headerStyle.Render("ISSUE EVENTS"))
+		lines = append(lines, strings.Repeat("─", detailSepWidth))
+		for _, ev := range commit.Events {
+			icon, style := commitEventStyle(t, ev.EventType)
+			evLine := fmt.Sprintf("%s %-8s %s", icon, ev.EventType, ev.BeadID)
+			if width > 10 && lipgloss.Width(evLine) > width-6 {
+				evLine = truncateRunesHelper(evLine, width-6, "…")
+			}
+			lines = append(lines, style.Render(evLine))
+		}
+	}
+
 	// Add separator before commit details
 	lines = append(lines, "")
 	lines = append(lines, strings.Repeat("─", detailSepWidth))
@@ -3244,12 +3337,29 @@ func (h *HistoryModel) renderGitDetailPanel(width, height int) string {
 	// Add footer hint (bv-xf4p)
 	lines = append(lines, strings.Repeat("─", detailSepWidth))
 	hintStyle := t.Renderer.NewStyle().Foreground(t.Muted).Italic(true)
-	lines = append(lines, hintStyle.Render("J/K:bead  y:copy  o:open  g:graph"))
+	lines = append(lines, hintStyle.Render("J/K:bead  y:copy  o:open  g:graph  t:time-travel"))
 
 	content := strings.Join(lines, "\n")
 	return panelStyle.Render(content)
 }
 
+// commitEventStyle returns the icon and style for an issue-level event.
+func commitEventStyle(t Theme, et correlation.EventType) (string, lipgloss.Style) {
+	style := t.Renderer.NewStyle()
+	switch et {
+	case correlation.EventCreated:
+		return "+", style.Foreground(t.Open)
+	case correlation.EventClosed:
+		return "✓", style.Foreground(t.Closed)
+	case correlation.EventReopened:
+		return "↺", style.Foreground(t.Blocked)
+	case correlation.EventClaimed:
+		return "●", style.Foreground(t.InProgress)
+	default:
+		return "~", style.Foreground(t.Secondary)
+	}
+}
+
 // renderCommitMiddlePanel renders commits for selected bead in middle pane (bv-xrfh)
 func (h *HistoryModel) renderCommitMiddlePanel(width, height int) string {
 	t := h.theme
@@ -2796,3 +2796,54 @@ func TestFileTree_JumpToFile(t *testing.T) {
 		t.Errorf("Expected at least 4 .go files in test data, got %d", goFileCount)
 	}
 }
+
+func TestHistoryModel_CommitListIncludesBeadsFileEvents(t *testing.T) {
+	report := createTestHistoryReport()
+	now := time.Now()
+
+	// bv-1 was created in a beads-only commit and closed alongside its fix
+	hist := report.Histories["bv-1"]
+	hist.Events = []correlation.BeadEvent{
+		{BeadID: "bv-1", EventType: correlation.EventCreated, CommitSHA: "fff000aaa111", CommitMsg: "chore: file bugs", Author: "Dev One", Timestamp: now.Add(-5 * time.Hour)},
+		{BeadID: "bv-1", EventType: correlation.EventClosed, CommitSHA: "abc123def456", CommitMsg: "fix: auth bug", Author: "Dev One", Timestamp: now},
+	}
+	report.Histories["bv-1"] = hist
+	hist2 := report.Histories["bv-2"]
+	hist2.Events = []correlation.BeadEvent{
+		{BeadID: "bv-2", EventType: correlation.EventCreated, CommitSHA: "fff000aaa111", CommitMsg: "chore: file bugs", Author: "Dev One", Timestamp: now.Add(-5 * time.Hour)},
+		{BeadID: "bv-2", EventType: correlation.EventModified, CommitSHA: "abc123def456", CommitMsg: "fix: auth bug", Author: "Dev One", Timestamp: now},
+	}
+	report.Histories["bv-2"] = hist2
+
+	h := NewHistoryModel(report, testTheme())
+	h.buildCommitList()
+
+	byShort := make(map[string]CommitListEntry)
+	for _, c := range h.commitList {
+		byShort[c.ShortSHA] = c
+	}
+
+	beadsOnly, ok := byShort["fff000a"]
+	if !ok {
+		t.Fatalf("expected beads-only commit in git mode list, got %d commits", len(h.commitList))
+	}
+	if created, closed, edited := beadsOnly.EventCounts(); created != 2 || closed != 0 || edited != 0 {
+		t.Errorf("beads-only commit counts = (%d, %d, %d), want (2, 0, 0)", created, closed, edited)
+	}
+	if len(beadsOnly.BeadIDs) != 2 {
+		t.Errorf("beads-only commit should reference both beads, got %v", beadsOnly.BeadIDs)
+	}
+
+	fix := byShort["abc123d"]
+	if got := fix.EventBadge(); got != "✓1 ~1" {
+		t.Errorf("EventBadge() = %q, want %q", got, "✓1 ~1")
+	}
+	if got := byShort["def456g"].EventBadge(); got != "" {
+		t.Errorf("code-only commit should have no badge, got %q", got)
+	}
+
+	// Oldest commit sorts last
+	if last := h.commitList[len(h.commitList)-1]; last.ShortSHA != "fff000a" {
+		t.Errorf("expected oldest commit last, got %s", last.ShortSHA)
+	}
+}
@@ -0,0 +1,50 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
+	"github.com/charmbracelet/lipgloss"
+)
+
+func TestStyledMessage(t *testing.T) {
+	text := lipgloss.NewStyle()
+	arg := lipgloss.NewStyle()
+	tests := []struct {
+		msg  string
+		args []string
+		want string
+	}{
+		{"Press %s or %s to quit", []string{"Esc", "Y"}, "Press Esc or Y to quit"},
+		{"%[2]s oder %[1]s zum Beenden", []string{"Esc", "Y"}, "Y oder Esc zum Beenden"},
+		{"no placeholders", nil, "no placeholders"},
+		{"%s", []string{"only"}, "only"},
+		{"missing %s", nil, "missing "},
+	}
+	for _, tt := range tests {
+		if got := styledMessage(tt.msg, text, arg, tt.args...); got != tt.want {
+			t.Errorf("styledMessage(%q) = %q, want %q", tt.msg, got, tt.want)
+		}
+	}
+}
+
+func TestQuitConfirmTranslated(t *testing.T) {
+	if err := i18n.Register("zz", i18n.Catalog{
+		"quit.title":   "Beenden?",
+		"quit.confirm": "%s oder %s drücken",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	i18n.SetLocale("zz")
+	t.Cleanup(func() { i18n.SetLocale(i18n.DefaultLocale) })
+
+	m := newTestModel()
+	m.width, m.height = 80, 20
+	out := m.renderQuitConfirm()
+	for _, want := range []string{"Beenden?", "drücken", "Esc", "Press any other key to cancel"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("quit dialog lacks %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,117 @@
+package ui
+
+import (
+	"strings"
+	"sync/atomic"
+)
+
+// asciiIcons switches every icon helper in the package from emoji to
+// bracketed ASCII tags. Some terminals and most screen readers mangle emoji
+// (wrong cell widths, "rocket" read aloud in place of "epic"), so users can opt
+// into plain text via --ascii, BV_ASCII=1, or `ui.ascii: true` in config.yaml.
+var asciiIcons atomic.Bool
+
+// SetASCIIIcons enables or disables ASCII-only icons for all views.
+// Call before constructing the Model so the first frame is rendered correctly.
+func SetASCIIIcons(enabled bool) {
+	asciiIcons.Store(enabled)
+}
+
+// ASCIIIcons reports whether ASCII-only icons are enabled.
+func ASCIIIcons() bool {
+	return asciiIcons.Load()
+}
+
+// asciiTypeTag returns the bracketed tag for an issue type (e.g., "[BUG]").
+func asciiTypeTag(t string) string {
+	switch t {
+	case "bug":
+		return "[BUG]"
+	case "feature":
+		return "[FEAT]"
+	case "task":
+		return "[TASK]"
+	case "epic":
+		return "[EPIC]"
+	case "chore":
+		return "[CHORE]"
+	case "":
+		return "[-]"
+	default:
+		return "[" + strings.ToUpper(t) + "]"
+	}
+}
+
+// asciiStatusTag returns the bracketed tag for a status (e.g., "[OPEN]").
+func asciiStatusTag(s string) string {
+	switch s {
+	case "open":
+		return "[OPEN]"
+	case "in_progress":
+		return "[WIP]"
+	case "blocked":
+		return "[BLOCKED]"
+	case "deferred":
+		return "[DEFER]"
+	case "pinned":
+		return "[PIN]"
+	case "hooked":
+		return "[HOOK]"
+	case "closed":
+		return "[DONE]"
+	case "tombstone":
+		return "[DEL]"
+	default:
+		return "[?]"
+	}
+}
+
+// asciiPriorityTag returns the bracketed tag for a priority (e.g., "[P0]").
+func asciiPriorityTag(priority int) string {
+	if priority >= 0 && priority <= 4 {
+		return "[" + GetPriorityLabel(priority) + "]"
+	}
+	return "[P?]"
+}
+
+// commentIcon returns the prefix used for comment counts in list rows.
+func commentIcon() string {
+	if ASCIIIcons() {
+		return "c"
+	}
+	return "💬"
+}
+
+// quickWinIcon marks quick-win issues in list rows (bv-151).
+func quickWinIcon() string {
+	if ASCIIIcons() {
+		return "*"
+	}
+	return "⭐"
+}
+
+// unblocksIcon prefixes the unblocks count on blocker issues (bv-151).
+func unblocksIcon() string {
+	if ASCIIIcons() {
+		return "+"
+	}
+	return "🔓"
+}
+
+// pinIcon marks starred issues in list rows.
+func pinIcon() string {
+	if ASCIIIcons() {
+		return "^"
+	}
+	return "📌"
+}
+
+// glyph returns emoji normally and ascii when ASCII-only icons are enabled.
+// Use it for one-off decorations (column headers, badges, panel titles) that
+// do not warrant a dedicated helper.
+func glyph(emoji, ascii string) string {
+	if ASCIIIcons() {
+		return ascii
+	}
+	return emoji
+}
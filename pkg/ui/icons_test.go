@@ -0,0 +1,112 @@
+package ui_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+)
+
+func TestASCIIIcons(t *testing.T) {
+	ui.SetASCIIIcons(true)
+	t.Cleanup(func() { ui.SetASCIIIcons(false) })
+
+	theme := createTheme()
+	typeTests := map[string]string{
+		"bug":     "[BUG]",
+		"feature": "[FEAT]",
+		"task":    "[TASK]",
+		"epic":    "[EPIC]",
+		"chore":   "[CHORE]",
+		"spike":   "[SPIKE]",
+	}
+	for typ, want := range typeTests {
+		if got, _ := theme.GetTypeIcon(typ); got != want {
+			t.Errorf("GetTypeIcon(%q) = %q, want %q", typ, got, want)
+		}
+		if got := ui.GetTypeIconMD(typ); got != want {
+			t.Errorf("GetTypeIconMD(%q) = %q, want %q", typ, got, want)
+		}
+	}
+
+	statusTests := map[model.Status]string{
+		model.StatusOpen:       "[OPEN]",
+		model.StatusInProgress: "[WIP]",
+		model.StatusBlocked:    "[BLOCKED]",
+		model.StatusClosed:     "[DONE]",
+	}
+	for status, want := range statusTests {
+		if got := ui.GetStatusIcon(string(status)); got != want {
+			t.Errorf("GetStatusIcon(%q) = %q, want %q", status, got, want)
+		}
+	}
+
+	if got := ui.GetPriorityIcon(0); got != "[P0]" {
+		t.Errorf("GetPriorityIcon(0) = %q, want [P0]", got)
+	}
+	if got := ui.GetPriorityIcon(9); got != "[P?]" {
+		t.Errorf("GetPriorityIcon(9) = %q, want [P?]", got)
+	}
+}
+
+func TestASCIIIconsAreASCII(t *testing.T) {
+	ui.SetASCIIIcons(true)
+	t.Cleanup(func() { ui.SetASCIIIcons(false) })
+
+	theme := createTheme()
+	for _, typ := range []string{"bug", "feature", "task", "epic", "chore", ""} {
+		icon, _ := theme.GetTypeIcon(typ)
+		for _, r := range icon {
+			if r > 127 {
+				t.Errorf("type icon %q for %q contains non-ASCII rune %q", icon, typ, r)
+			}
+		}
+	}
+}
+
+func TestASCIIIconsDisabledByDefault(t *testing.T) {
+	if ui.ASCIIIcons() {
+		t.Fatal("ASCII icons should be disabled by default")
+	}
+	theme := createTheme()
+	if icon, _ := theme.GetTypeIcon("bug"); strings.HasPrefix(icon, "[") {
+		t.Errorf("expected emoji icon by default, got %q", icon)
+	}
+}
+
+func TestASCIIIconsReachBoardAndGraph(t *testing.T) {
+	ui.SetASCIIIcons(true)
+	t.Cleanup(func() { ui.SetASCIIIcons(false) })
+
+	theme := createTheme()
+	now := time.Now()
+	issues := []model.Issue{
+		{ID: "A", Title: "Critical blocker", Status: model.StatusInProgress, Priority: 0, IssueType: model.TypeBug, Labels: []string{"api"}, CreatedAt: now.Add(-72 * time.Hour)},
+		{ID: "B", Title: "Blocked task", Status: model.StatusBlocked, Priority: 1, IssueType: model.TypeTask, CreatedAt: now.Add(-48 * time.Hour),
+			Dependencies: []*model.Dependency{{IssueID: "B", DependsOnID: "A", Type: model.DepBlocks}}},
+		{ID: "C", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeFeature, CreatedAt: now},
+	}
+
+	board := ui.NewBoardModel(issues, theme)
+	graph := ui.NewGraphModel(issues, nil, theme)
+	views := map[string]string{
+		"board": board.View(200, 40),
+		"graph": graph.View(200, 40),
+	}
+	for name, out := range views {
+		for _, r := range out {
+			if isEmojiRune(r) {
+				t.Errorf("%s view contains emoji %q in ASCII mode", name, r)
+				break
+			}
+		}
+	}
+}
+
+// isEmojiRune reports whether r falls in the pictographic ranges the views
+// use for icons. Box-drawing and arrow runes are intentionally allowed.
+func isEmojiRune(r rune) bool {
+	return (r >= 0x1F000 && r <= 0x1FAFF) || (r >= 0x2600 && r <= 0x27BF) || r == 0x2B50 || (r >= 0x23E9 && r <= 0x23FA)
+}
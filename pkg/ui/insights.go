@@ -2,6 +2,7 @@ package ui
 
 import (
 	"fmt"
+	"math"
 	"strings"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
@@ -149,6 +150,11 @@ type InsightsModel struct {
 	recommendationMap  map[string]*analysis.Recommendation // ID -> Recommendation for quick lookup
 	triageDataHash     string                              // Hash of data used for triage
 
+	// Graph health now and over past revisions of the beads file
+	health        *analysis.HealthScore
+	healthHistory *analysis.HealthHistory
+	blockedTime   *analysis.BlockedTime // Over the same revisions; nil until loaded
+
 	// Navigation state
 	focusedPanel  MetricPanel
 	selectedIndex [PanelCount]int // Selection per panel
@@ -174,6 +180,10 @@ type InsightsModel struct {
 	detailVP      viewport.Model
 	detailContent string // cached markdown content
 
+	// Phase 2 progress while graph metrics compute in the background
+	phase2Done  int
+	phase2Total int
+
 	// Dimensions
 	width  int
 	height int
@@ -229,6 +239,88 @@ func (m *InsightsModel) SetTopPicks(picks []analysis.TopPick) {
 	m.topPicks = picks
 }
 
+// SetHealth sets the current graph health and, when loaded, its history
+// over git revisions of the beads file (nil until then).
+func (m *InsightsModel) SetHealth(current analysis.HealthScore, history *analysis.HealthHistory) {
+	m.health = &current
+	m.healthHistory = history
+}
+
+// renderHealthLine renders the graph health score with a trend sparkline.
+func (m *InsightsModel) renderHealthLine(t Theme) string {
+	if m.health == nil {
+		return ""
+	}
+	h := m.health
+	trend := ""
+	if m.healthHistory != nil && len(m.healthHistory.Points) > 1 {
+		points := m.healthHistory.Points
+		lo, hi := points[0].Score, points[0].Score
+		for _, p := range points {
+			lo = math.Min(lo, p.Score)
+			hi = math.Max(hi, p.Score)
+		}
+		blocks := []rune{'▁', '▂', '▃', '▄', '▅', '▆', '▇', '█'}
+		var spark strings.Builder
+		for _, p := range points {
+			level := len(blocks) / 2
+			if hi > lo {
+				level = int((p.Score - lo) / (hi - lo) * float64(len(blocks)-1))
+			}
+			spark.WriteRune(blocks[level])
+		}
+		trend = fmt.Sprintf(" %s %+.1f over %d revisions", spark.String(), m.healthHistory.ScoreChange, len(points))
+	}
+	return t.Base.Render(fmt.Sprintf("Graph health: %.1f/100%s • cycles %d • orphans %.0f%% • avg depth %.1f • %.2f deps/issue",
+		h.Score, trend, h.CycleCount, h.OrphanRatio*100, h.AvgDepth, h.EdgesPerIssue))
+}
+
+// SetPhase2Progress records how many Phase 2 steps have finished, out of
+// total. While done < total the dashboard shows a progress line.
+func (m *InsightsModel) SetPhase2Progress(done, total int) {
+	m.phase2Done, m.phase2Total = done, total
+}
+
+// renderPhase2Line renders Phase 2 progress, or "" once it is complete.
+func (m *InsightsModel) renderPhase2Line(t Theme) string {
+	if m.phase2Total == 0 || m.phase2Done >= m.phase2Total {
+		return ""
+	}
+	const barWidth = 16
+	filled := barWidth * m.phase2Done / m.phase2Total
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
+	return t.Renderer.NewStyle().Foreground(t.Secondary).Render(
+		fmt.Sprintf("◌ Computing graph metrics %s %d/%d", bar, m.phase2Done, m.phase2Total))
+}
+
+// SetBlockedTime sets the time issues spent blocked (nil until loaded).
+func (m *InsightsModel) SetBlockedTime(blocked *analysis.BlockedTime) {
+	m.blockedTime = blocked
+}
+
+// renderBlockedLine renders total blocked time with the worst issues, label
+// and epic, so coordination cost has a number.
+func (m *InsightsModel) renderBlockedLine(t Theme) string {
+	b := m.blockedTime
+	if b == nil || len(b.Issues) == 0 {
+		return ""
+	}
+	limit := min(3, len(b.Issues))
+	worst := make([]string, 0, limit)
+	for _, issue := range b.Issues[:limit] {
+		worst = append(worst, fmt.Sprintf("%s %.1fd", issue.ID, issue.BlockedDays))
+	}
+	line := fmt.Sprintf("Blocked time: %.1fd since %s • worst: %s",
+		b.TotalBlockedDays, b.Since.Format("Jan 2"), strings.Join(worst, ", "))
+	if len(b.ByLabel) > 0 {
+		line += fmt.Sprintf(" • label %s %.1fd", b.ByLabel[0].Key, b.ByLabel[0].BlockedDays)
+	}
+	if len(b.ByEpic) > 0 {
+		line += fmt.Sprintf(" • epic %s %.1fd", b.ByEpic[0].Key, b.ByEpic[0].BlockedDays)
+	}
+	return t.Base.Render(line)
+}
+
 // SetRecommendations sets the full recommendations with breakdown data (bv-93)
 func (m *InsightsModel) SetRecommendations(recs []analysis.Recommendation, dataHash string) {
 	m.recommendations = recs
@@ -664,6 +756,17 @@ func (m *InsightsModel) View() string {
 			v.Closed7, v.Closed30, v.AvgDays, weekly, estimate))
 	}
 
+	for _, line := range []string{m.renderPhase2Line(t), m.renderHealthLine(t), m.renderBlockedLine(t)} {
+		if line == "" {
+			continue
+		}
+		if velocityLine != "" {
+			velocityLine = lipgloss.JoinVertical(lipgloss.Left, velocityLine, line)
+		} else {
+			velocityLine = line
+		}
+	}
+
 	// Calculate layout dimensions
 	mainWidth := m.width
 	detailWidth := 0
@@ -1,7 +1,9 @@
 package ui_test
 
 import (
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
@@ -117,6 +119,55 @@ func TestInsightsModelEmpty(t *testing.T) {
 	_ = m.View()
 }
 
+// TestInsightsModelHealthLine verifies the graph health score and trend
+func TestInsightsModelHealthLine(t *testing.T) {
+	m := ui.NewInsightsModel(analysis.Insights{}, make(map[string]*model.Issue), createTheme())
+	m.SetSize(120, 40)
+
+	current := analysis.HealthScore{Score: 81.5, CycleCount: 1, OrphanRatio: 0.25, AvgDepth: 1.5, EdgesPerIssue: 1.2}
+	m.SetHealth(current, nil)
+	if out := m.View(); !strings.Contains(out, "Graph health: 81.5/100 • cycles 1 • orphans 25%") {
+		t.Errorf("missing health line without history:\n%s", out)
+	}
+
+	history := &analysis.HealthHistory{
+		Points: []analysis.HealthPoint{
+			{HealthScore: analysis.HealthScore{Score: 70}},
+			{HealthScore: analysis.HealthScore{Score: 75}},
+			{HealthScore: analysis.HealthScore{Score: 81.5}},
+		},
+		ScoreChange: 11.5,
+	}
+	m.SetHealth(current, history)
+	if out := m.View(); !strings.Contains(out, "▁▄█ +11.5 over 3 revisions") {
+		t.Errorf("missing health trend:\n%s", out)
+	}
+}
+
+func TestInsightsModelBlockedTimeLine(t *testing.T) {
+	m := ui.NewInsightsModel(analysis.Insights{}, make(map[string]*model.Issue), createTheme())
+	m.SetSize(160, 40)
+
+	m.SetBlockedTime(&analysis.BlockedTime{})
+	if out := m.View(); strings.Contains(out, "Blocked time") {
+		t.Errorf("blocked time line shown with nothing blocked:\n%s", out)
+	}
+
+	m.SetBlockedTime(&analysis.BlockedTime{
+		Since:            time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		TotalBlockedDays: 12.5,
+		Issues: []analysis.BlockedIssueTime{
+			{ID: "B", BlockedDays: 8}, {ID: "C", BlockedDays: 4.5},
+		},
+		ByLabel: []analysis.BlockedTimeGroup{{Key: "api", BlockedDays: 12.5}},
+		ByEpic:  []analysis.BlockedTimeGroup{{Key: "EPIC", BlockedDays: 8}},
+	})
+	want := "Blocked time: 12.5d since Mar 1 • worst: B 8.0d, C 4.5d • label api 12.5d • epic EPIC 8.0d"
+	if out := m.View(); !strings.Contains(out, want) {
+		t.Errorf("missing %q:\n%s", want, out)
+	}
+}
+
 // TestInsightsModelPanelNavigation verifies panel navigation
 func TestInsightsModelPanelNavigation(t *testing.T) {
 	theme := createTheme()
@@ -0,0 +1,142 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// lockPromptState tracks the secondary-instance prompt shown at startup.
+type lockPromptState int
+
+const (
+	lockPromptHidden       lockPromptState = iota
+	lockPromptOffer                        // read-only or steal?
+	lockPromptConfirmSteal                 // are you sure?
+)
+
+// lockCheckInterval is how often the TUI re-reads the instance lock to
+// notice a departed primary (promotion) or a stolen lock (demotion).
+const lockCheckInterval = 2 * time.Second
+
+// lockCheckTickMsg drives periodic instance lock checks.
+type lockCheckTickMsg struct{}
+
+func lockCheckTickCmd() tea.Cmd {
+	return tea.Tick(lockCheckInterval, func(time.Time) tea.Msg {
+		return lockCheckTickMsg{}
+	})
+}
+
+// setReadOnly switches whether this instance may persist shared repository
+// state (tree expand/collapse state, semantic index). Secondaries are
+// read-only so they don't race the primary's writes.
+func (m *Model) setReadOnly(readOnly bool) {
+	m.readOnly = readOnly
+	m.tree.readOnly = readOnly
+	if m.instanceReg != nil {
+		m.instanceReg.SetPrimary(!readOnly)
+	}
+}
+
+// handleLockCheck reconciles this instance's role with the lock file.
+func (m Model) handleLockCheck() (Model, tea.Cmd) {
+	if m.instanceLock == nil {
+		return m, nil
+	}
+	switch m.instanceLock.Refresh() {
+	case instance.LockPromoted:
+		m.setReadOnly(false)
+		m.lockPrompt = lockPromptHidden
+		m.statusMsg = "Primary bv exited; this instance is now primary"
+		m.statusIsError = false
+	case instance.LockDemoted:
+		m.setReadOnly(true)
+		m.statusMsg = fmt.Sprintf("Lock taken over by PID %d; now read-only", m.instanceLock.HolderPID())
+		m.statusIsError = true
+	}
+	return m, lockCheckTickCmd()
+}
+
+// handleLockPromptKeys handles keys while the secondary-instance prompt is up.
+func (m Model) handleLockPromptKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch m.lockPrompt {
+	case lockPromptOffer:
+		switch msg.String() {
+		case "s", "S":
+			m.lockPrompt = lockPromptConfirmSteal
+		case "q", "ctrl+c":
+			return m, tea.Quit
+		default: // r, enter, esc: continue read-only
+			m.lockPrompt = lockPromptHidden
+			m.statusMsg = "Read-only: another bv is primary for this repository"
+			m.statusIsError = false
+		}
+	case lockPromptConfirmSteal:
+		switch msg.String() {
+		case "y", "Y":
+			m.lockPrompt = lockPromptHidden
+			holder := m.instanceLock.HolderPID()
+			if err := m.instanceLock.Steal(); err != nil {
+				m.statusMsg = fmt.Sprintf("Steal lock failed: %v", err)
+				m.statusIsError = true
+				return m, nil
+			}
+			m.setReadOnly(false)
+			m.statusMsg = fmt.Sprintf("Took over the lock from PID %d", holder)
+			m.statusIsError = false
+		default:
+			m.lockPrompt = lockPromptOffer
+		}
+	}
+	return m, nil
+}
+
+// renderLockPrompt renders the secondary-instance prompt.
+func (m Model) renderLockPrompt() string {
+	t := m.theme
+
+	boxStyle := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Feature).
+		Padding(1, 3)
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Feature).Bold(true)
+	textStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+	keyStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+
+	holder := "PID " + fmt.Sprint(m.instanceLock.HolderPID())
+	if info := m.instanceLock.Holder(); info != nil {
+		if info.Hostname != "" {
+			holder += " on " + info.Hostname
+		}
+		if !info.StartedAt.IsZero() {
+			holder += fmt.Sprintf(", started %s (%s)", info.StartedAt.Local().Format("15:04"), FormatTimeRel(info.StartedAt))
+		}
+	}
+
+	var content string
+	if m.lockPrompt == lockPromptConfirmSteal {
+		content = titleStyle.Render("Steal the lock?") + "\n\n" +
+			textStyle.Render("The other instance ("+holder+")\nwill drop to read-only.") + "\n\n" +
+			keyStyle.Render("y") + textStyle.Render(" steal   ") +
+			keyStyle.Render("any other key") + textStyle.Render(" back")
+	} else {
+		content = titleStyle.Render("Another bv is already open here") + "\n\n" +
+			textStyle.Render("Primary: "+holder) + "\n" +
+			textStyle.Render("This instance won't save shared state while secondary,\nand takes over automatically when the primary exits.") + "\n\n" +
+			keyStyle.Render("r") + textStyle.Render(" read-only   ") +
+			keyStyle.Render("s") + textStyle.Render(" steal lock   ") +
+			keyStyle.Render("q") + textStyle.Render(" quit")
+	}
+
+	return lipgloss.Place(
+		m.width,
+		m.height-1,
+		lipgloss.Center,
+		lipgloss.Center,
+		boxStyle.Render(content),
+	)
+}
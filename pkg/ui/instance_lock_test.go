@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// writeForeignLock makes the parent process (go test, alive) the primary.
+func writeForeignLock(t *testing.T, beadsDir string) {
+	t.Helper()
+	hostname, _ := os.Hostname()
+	data, _ := json.Marshal(instance.LockInfo{PID: os.Getppid(), StartedAt: time.Now().Add(-time.Hour), Hostname: hostname})
+	if err := os.WriteFile(filepath.Join(beadsDir, instance.LockFileName), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestSecondaryInstancePromptAndSteal(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeForeignLock(t, beadsDir)
+
+	issues := []model.Issue{{ID: "bv-1", Title: "x", Status: model.StatusOpen, IssueType: model.TypeTask}}
+	m := NewModel(issues, nil, filepath.Join(beadsDir, "issues.jsonl"))
+	defer m.Stop()
+
+	if m.lockPrompt != lockPromptOffer || !m.readOnly || !m.tree.readOnly {
+		t.Fatalf("secondary should start read-only with a prompt (prompt=%v readOnly=%v)", m.lockPrompt, m.readOnly)
+	}
+	view := m.View()
+	for _, want := range []string{"Another bv is already open here", fmt.Sprintf("PID %d", os.Getppid()), "steal lock"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("prompt missing %q", want)
+		}
+	}
+
+	// Declining the confirmation returns to the offer.
+	m, _ = m.handleLockPromptKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	if m.lockPrompt != lockPromptConfirmSteal {
+		t.Fatalf("expected steal confirmation, got %v", m.lockPrompt)
+	}
+	m, _ = m.handleLockPromptKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.lockPrompt != lockPromptOffer {
+		t.Fatalf("expected back to offer, got %v", m.lockPrompt)
+	}
+
+	m, _ = m.handleLockPromptKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("s")})
+	m, _ = m.handleLockPromptKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	if m.lockPrompt != lockPromptHidden || m.readOnly || m.tree.readOnly {
+		t.Fatal("stealing should hide the prompt and leave read-only mode")
+	}
+	if !m.instanceLock.IsFirstInstance() {
+		t.Error("expected to hold the lock after stealing")
+	}
+
+	// The other instance takes it back: this one drops to read-only.
+	writeForeignLock(t, beadsDir)
+	m, cmd := m.handleLockCheck()
+	if cmd == nil {
+		t.Error("lock check must re-arm")
+	}
+	if !m.readOnly || !strings.Contains(m.statusMsg, "now read-only") {
+		t.Errorf("expected demotion, readOnly=%v status=%q", m.readOnly, m.statusMsg)
+	}
+}
+
+func TestSecondaryInstancePromotedWhenPrimaryExits(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writeForeignLock(t, beadsDir)
+
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "x", Status: model.StatusOpen, IssueType: model.TypeTask}}, nil, filepath.Join(beadsDir, "issues.jsonl"))
+	defer m.Stop()
+	m, _ = m.handleLockPromptKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("r")})
+	if m.lockPrompt != lockPromptHidden || !m.readOnly {
+		t.Fatal("choosing read-only should dismiss the prompt and stay read-only")
+	}
+
+	os.Remove(filepath.Join(beadsDir, instance.LockFileName))
+	m, _ = m.handleLockCheck()
+	if m.readOnly || !m.instanceLock.IsFirstInstance() {
+		t.Error("expected automatic promotion after the primary exits")
+	}
+	if !strings.Contains(m.statusMsg, "now primary") {
+		t.Errorf("unexpected status %q", m.statusMsg)
+	}
+}
+
+func TestInstancePeersLabel_ASCII(t *testing.T) {
+	peers := []instance.InstanceInfo{{Mode: instance.ModeTUI}, {Mode: instance.ModeServe}, {Mode: instance.ModeTUI}}
+	if got := instancePeersLabel(peers); got != "⧉ +3 tui,serve" {
+		t.Errorf("instancePeersLabel = %q", got)
+	}
+	SetASCIIIcons(true)
+	t.Cleanup(func() { SetASCIIIcons(false) })
+	if got := instancePeersLabel(peers); got != "[peers] +3 tui,serve" {
+		t.Errorf("ASCII instancePeersLabel = %q", got)
+	}
+}
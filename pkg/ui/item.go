@@ -52,6 +52,8 @@ type IssueItem struct {
 	IsQuickWin    bool     // True if identified as a quick win
 	IsBlocker     bool     // True if this item blocks significant downstream work
 	UnblocksCount int      // Number of items this unblocks
+
+	Pinned bool // Starred by the user (*); set by pinToTop
 }
 
 func (i IssueItem) Title() string {
@@ -0,0 +1,272 @@
+package ui
+
+import (
+	"slices"
+	"strings"
+)
+
+// keyBinding is a single entry in the keymap registry.
+//
+// The registry is the one place that documents what each key does. Both the
+// '?' help overlay and the ';' shortcuts sidebar are generated from it, so
+// adding a key handler without a matching entry here shows up as missing help
+// rather than stale help.
+type keyBinding struct {
+	Keys     string   // Display form, e.g. "j / ↓"
+	Desc     string   // Short description (fits the 34-col sidebar)
+	Section  string   // Grouping heading
+	Contexts []string // Focus contexts where the binding is live; nil = everywhere
+	Action   string   // Name users remap it by in config.yaml; "" = fixed
+}
+
+// Focus contexts used by the registry. These match ContextFromFocus.
+const (
+	ctxList       = "list"
+	ctxDetail     = "detail"
+	ctxBoard      = "board"
+	ctxGraph      = "graph"
+	ctxTree       = "tree"
+	ctxInsights   = "insights"
+	ctxHistory    = "history"
+	ctxActionable = "actionable"
+	ctxLabel      = "label"
+	ctxFlow       = "flow"
+)
+
+// keymapSections fixes the display order of sections.
+var keymapSections = []string{
+	"Navigation",
+	"Vim",
+	"Views",
+	"Global",
+	"Filters & Sort",
+	"Board",
+	"Graph",
+	"Tree",
+	"Insights",
+	"History",
+	"Flow Matrix",
+	"Label Dashboard",
+	"Actions",
+}
+
+// keymapSectionIcons decorates section headers in the help overlay.
+var keymapSectionIcons = map[string]string{
+	"Navigation":      "🧭",
+	"Vim":             "🔢",
+	"Views":           "👁",
+	"Global":          "🌐",
+	"Filters & Sort":  "🔍",
+	"Board":           "📋",
+	"Graph":           "📊",
+	"Tree":            "🌳",
+	"Insights":        "💡",
+	"History":         "📜",
+	"Flow Matrix":     "🔀",
+	"Label Dashboard": "🏷",
+	"Actions":         "⚡",
+}
+
+// keymap is the registry of every documented key binding.
+var keymap = []keyBinding{
+	// Navigation
+	{"j / ↓", "Move down", "Navigation", nil, "move_down"},
+	{"k / ↑", "Move up", "Navigation", nil, "move_up"},
+	{"G / end", "Go to last", "Navigation", []string{ctxList, ctxBoard, ctxFlow}, "go_last"},
+	{"home", "Go to first", "Navigation", []string{ctxList, ctxBoard, ctxFlow}, "go_first"},
+	{"Ctrl+d", "Page down", "Navigation", []string{ctxList, ctxBoard, ctxGraph, ctxTree}, "page_down"},
+	{"Ctrl+u", "Page up", "Navigation", []string{ctxList, ctxBoard, ctxGraph, ctxTree}, "page_up"},
+	{"Tab", "Switch focus", "Navigation", []string{ctxList, ctxDetail, ctxTree, ctxHistory, ctxFlow}, "switch_focus"},
+	{"Enter", "View details", "Navigation", []string{ctxList, ctxActionable}, "open_details"},
+	{"Esc", "Back / close", "Navigation", nil, "back"},
+
+	// Views (handled before any focus-specific keys, so live everywhere)
+	{"b", "Kanban board", "Views", nil, "board_view"},
+	{"g", "Graph view", "Views", nil, "graph_view"},
+	{"i", "Insights", "Views", nil, "insights_view"},
+	{"h", "History view", "Views", nil, "history_view"},
+	{"a", "Actionable", "Views", nil, "actionable_view"},
+	{"E", "Tree view", "Views", nil, "tree_view"},
+	{"f", "Flow matrix", "Views", nil, "flow_matrix"},
+	{"[ / F3", "Label dashboard", "Views", nil, "label_dashboard"},
+	{"] / F4", "Attention view", "Views", nil, "attention_view"},
+	{"W", "Team workload", "Views", nil, "team_workload"},
+	{"Z", "Stale sweep (batch close/deprioritize/ping)", "Views", nil, "stale_sweep"},
+
+	// Global
+	{"?", "This help", "Global", nil, "help"},
+	{"`", "Tutorial", "Global", nil, "tutorial"},
+	{";", "Shortcuts bar", "Global", nil, "shortcuts"},
+	{"!", "Alerts panel", "Global", nil, "alerts"},
+	{"'", "Recipes", "Global", nil, "recipes"},
+	{"w", "Repo picker", "Global", nil, "repo_picker"},
+	{"p", "Priority hints", "Global", nil, "priority_hints"},
+	{"Ctrl+T", "Relative / absolute times", "Global", nil, "toggle_times"},
+	{"Ctrl+O", "Recent projects", "Global", nil, "project_switcher"},
+	{"Ctrl+R / F5", "Force refresh", "Global", nil, "refresh"},
+	{"q", "Back / Quit", "Global", nil, "quit"},
+	{"Ctrl+c", "Force quit", "Global", nil, "force_quit"},
+
+	// Filters & Sort
+	{"/", "Fuzzy search", "Filters & Sort", []string{ctxList}, "fuzzy_search"},
+	{"Ctrl+S", "Semantic search", "Filters & Sort", []string{ctxList}, "semantic_search"},
+	{"H", "Hybrid ranking", "Filters & Sort", []string{ctxList}, "hybrid_ranking"},
+	{"Alt+H", "Hybrid preset", "Filters & Sort", []string{ctxList}, "hybrid_preset"},
+	{"o", "Open issues", "Filters & Sort", []string{ctxList, ctxBoard}, "filter_open"},
+	{"c", "Closed issues", "Filters & Sort", []string{ctxList, ctxBoard}, "filter_closed"},
+	{"r", "Ready (unblocked)", "Filters & Sort", []string{ctxList, ctxBoard}, "filter_ready"},
+	{"R", "Frontier (startable now)", "Filters & Sort", []string{ctxList, ctxBoard}, "filter_frontier"},
+	{"l", "Filter by label", "Filters & Sort", nil, "filter_label"},
+	{"@", "Filter by owner (CODEOWNERS)", "Filters & Sort", nil, "filter_owner"},
+	{"s", "Cycle sort", "Filters & Sort", []string{ctxList}, "cycle_sort"},
+	{"S", "Triage sort", "Filters & Sort", []string{ctxList}, "triage_sort"},
+
+	// Board
+	{"← / →", "Columns", "Board", []string{ctxBoard}, ""},
+	{"1-4", "Jump to column", "Board", []string{ctxBoard}, ""},
+	{"H / L", "First / last column", "Board", []string{ctxBoard}, ""},
+	{"< / >", "Move column left / right", "Board", []string{ctxBoard}, ""},
+	{"0 / $", "First / last item", "Board", []string{ctxBoard}, ""},
+	{"/", "Search cards", "Board", []string{ctxBoard}, "board_search"},
+	{"n / N", "Next / prev match", "Board", []string{ctxBoard}, ""},
+	{"y", "Copy issue ID", "Board", []string{ctxBoard}, "board_copy_id"},
+	{"s", "Cycle swimlanes", "Board", []string{ctxBoard}, "cycle_swimlanes"},
+	{"e", "Toggle empty columns", "Board", []string{ctxBoard}, "toggle_empty_columns"},
+	{"d", "Expand card", "Board", []string{ctxBoard}, "expand_card"},
+	{"Tab", "Toggle detail", "Board", []string{ctxBoard}, "board_detail"},
+	{"Ctrl+j/k", "Scroll detail", "Board", []string{ctxBoard}, ""},
+	{"Enter", "Full view", "Board", []string{ctxBoard}, "board_full_view"},
+
+	// Graph
+	{"←↓↑→", "Navigate nodes", "Graph", []string{ctxGraph}, ""},
+	{"H / L", "Scroll left/right", "Graph", []string{ctxGraph}, ""},
+	{"PgUp/Dn", "Scroll up/down", "Graph", []string{ctxGraph}, ""},
+	{"Enter", "Jump to issue", "Graph", []string{ctxGraph}, "graph_jump"},
+
+	// Tree
+	{"← / →", "Collapse / expand", "Tree", []string{ctxTree}, ""},
+	{"Enter/Space", "Toggle node", "Tree", []string{ctxTree}, ""},
+	{"o / O", "Expand / collapse all", "Tree", []string{ctxTree}, ""},
+	{"G", "Jump to bottom", "Tree", []string{ctxTree}, "tree_bottom"},
+
+	// Insights
+	{"←/→/Tab", "Switch panels", "Insights", []string{ctxInsights}, ""},
+	{"Ctrl+j/k", "Scroll detail", "Insights", []string{ctxInsights}, ""},
+	{"e", "Explanations", "Insights", []string{ctxInsights}, "explanations"},
+	{"m", "Toggle heatmap", "Insights", []string{ctxInsights}, "toggle_heatmap"},
+	{"Enter", "Jump to issue", "Insights", []string{ctxInsights}, "insights_jump"},
+
+	// History
+	{"v", "Git/Bead mode", "History", []string{ctxHistory}, "history_mode"},
+	{"/", "Search", "History", []string{ctxHistory}, "history_search"},
+	{"J / K", "Navigate commits", "History", []string{ctxHistory}, ""},
+	{"y", "Copy SHA", "History", []string{ctxHistory}, "copy_sha"},
+	{"t", "Time-travel to commit", "History", []string{ctxHistory}, "history_time_travel"},
+	{"o", "Open in browser", "History", []string{ctxHistory}, "open_commit"},
+	{"c", "Confidence filter", "History", []string{ctxHistory}, "confidence_filter"},
+	{"F", "File tree", "History", []string{ctxHistory}, "file_tree"},
+
+	// Flow matrix
+	{"Enter", "Drill down", "Flow Matrix", []string{ctxFlow}, "flow_drill_down"},
+
+	// Label dashboard
+	{"Enter", "Filter by label", "Label Dashboard", []string{ctxLabel}, "label_filter"},
+	{"d", "Drilldown", "Label Dashboard", []string{ctxLabel}, "label_drilldown"},
+	{"t", "Label taxonomy (variants, slow labels)", "Label Dashboard", []string{ctxLabel}, "label_taxonomy"},
+
+	// Actions
+	{"t", "Time-travel", "Actions", []string{ctxList}, "time_travel"},
+	{"T", "Quick time-travel", "Actions", []string{ctxList}, "quick_time_travel"},
+	{"x", "Export markdown", "Actions", nil, "export_markdown"},
+	{"C", "Copy to clipboard", "Actions", []string{ctxList}, "copy_clipboard"},
+	{"y", "Copy issue ID", "Actions", []string{ctxList, ctxDetail}, "copy_id"},
+	{"Y", "Copy bd command", "Actions", []string{ctxList, ctxDetail}, "copy_bd_command"},
+	{"*", "Star / unstar (listed first)", "Actions", []string{ctxList, ctxDetail, ctxBoard}, "toggle_star"},
+	{"M", "Claim / unclaim (focus timer)", "Actions", []string{ctxList, ctxDetail, ctxBoard}, "toggle_claim"},
+	{"n", "Edit local notes", "Actions", []string{ctxList, ctxDetail}, "edit_notes"},
+	{"O", "Open in editor", "Actions", []string{ctxList}, "open_editor"},
+	{"e", "Open referenced file", "Actions", []string{ctxList, ctxDetail}, "open_file"},
+	{"B", "Open referenced URL", "Actions", []string{ctxList, ctxDetail}, "open_url"},
+	{"V", "Cass sessions", "Actions", []string{ctxList}, "cass_sessions"},
+	{"U", "Self-update", "Actions", []string{ctxList}, "self_update"},
+}
+
+// statusIndicators documents footer badges. They aren't key bindings, but
+// the help overlay is where users look them up.
+var statusIndicators = []struct{ key, desc string }{
+	{"◌ metrics", "Phase 2 metrics computing"},
+	{"⚠ age", "Snapshot getting stale"},
+	{"⚠ STALE", "Snapshot is stale"},
+	{"✗ bg", "Background worker errors"},
+	{"↻ recov", "Worker self-healed"},
+	{"⚠ dead", "Worker unresponsive"},
+	{"polling", "Live reload uses polling"},
+}
+
+// appliesTo reports whether the binding is live in the given context.
+func (b keyBinding) appliesTo(ctx string) bool {
+	if len(b.Contexts) == 0 {
+		return true
+	}
+	for _, c := range b.Contexts {
+		if c == ctx {
+			return true
+		}
+	}
+	return false
+}
+
+// matches reports whether the binding matches a case-insensitive search
+// query against its keys, description, or section. An empty query matches.
+func (b keyBinding) matches(query string) bool {
+	q := strings.ToLower(strings.TrimSpace(query))
+	if q == "" {
+		return true
+	}
+	return strings.Contains(strings.ToLower(b.Keys), q) ||
+		strings.Contains(strings.ToLower(b.Desc), q) ||
+		strings.Contains(strings.ToLower(b.Section), q)
+}
+
+// vimKeymap documents the vim key layer (see vim_keys.go), listed only
+// while it is enabled. Its keys are sequences and can't be remapped.
+var vimKeymap = []keyBinding{
+	{"5j / 5k", "Move by a count", "Vim", []string{ctxList, ctxBoard, ctxTree}, ""},
+	{"gg / 5G", "First / Nth item", "Vim", []string{ctxList, ctxBoard, ctxTree}, ""},
+	{"m a-z", "Mark the issue", "Vim", []string{ctxList, ctxBoard, ctxTree}, ""},
+	{"' a-z", "Jump to mark", "Vim", []string{ctxList, ctxBoard, ctxTree}, ""},
+	{"''", "Jump back", "Vim", []string{ctxList, ctxBoard, ctxTree}, ""},
+}
+
+// keymapGroup is a titled slice of bindings in registry order.
+type keymapGroup struct {
+	Section  string
+	Bindings []keyBinding
+}
+
+// bindingsFor returns the bindings live in ctx that match query, grouped by
+// section in display order, with the user's remapped keys and, if enabled,
+// the vim keys. Empty sections are omitted.
+func bindingsFor(ctx, query string) []keymapGroup {
+	remap := currentKeyRemap()
+	bySection := make(map[string][]keyBinding, len(keymapSections))
+	bindings := keymap
+	if vimKeysEnabled.Load() {
+		bindings = append(slices.Clip(keymap), vimKeymap...)
+	}
+	for _, b := range bindings {
+		if keys, ok := remap[b.Action]; ok {
+			b.Keys = keysLabel(keys)
+		}
+		if b.appliesTo(ctx) && b.matches(query) {
+			bySection[b.Section] = append(bySection[b.Section], b)
+		}
+	}
+	groups := make([]keymapGroup, 0, len(bySection))
+	for _, section := range keymapSections {
+		if bindings := bySection[section]; len(bindings) > 0 {
+			groups = append(groups, keymapGroup{Section: section, Bindings: bindings})
+		}
+	}
+	return groups
+}
@@ -0,0 +1,134 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestBindingsForFiltersByContext(t *testing.T) {
+	has := func(groups []keymapGroup, section, keys string) bool {
+		for _, g := range groups {
+			if g.Section != section {
+				continue
+			}
+			for _, b := range g.Bindings {
+				if b.Keys == keys {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	board := bindingsFor(ctxBoard, "")
+	if !has(board, "Board", "1-4") {
+		t.Error("board context should include column jumps")
+	}
+	if has(board, "Graph", "H / L") {
+		t.Error("board context should not include graph bindings")
+	}
+	if !has(board, "Views", "g") {
+		t.Error("global view toggles should apply in every context")
+	}
+
+	list := bindingsFor(ctxList, "")
+	if has(list, "Board", "1-4") {
+		t.Error("list context should not include board bindings")
+	}
+	if !has(list, "Filters & Sort", "Ctrl+S") {
+		t.Error("list context should include semantic search")
+	}
+}
+
+func TestBindingsForSearch(t *testing.T) {
+	groups := bindingsFor(ctxList, "SEMANTIC")
+	if len(groups) != 1 || len(groups[0].Bindings) != 1 || groups[0].Bindings[0].Keys != "Ctrl+S" {
+		t.Fatalf("expected only Ctrl+S to match 'SEMANTIC', got %+v", groups)
+	}
+
+	if groups := bindingsFor(ctxList, "no-such-binding"); len(groups) != 0 {
+		t.Fatalf("expected no matches, got %+v", groups)
+	}
+}
+
+func TestKeymapSectionsAndUniqueness(t *testing.T) {
+	known := make(map[string]bool, len(keymapSections))
+	for _, s := range keymapSections {
+		known[s] = true
+	}
+	contexts := []string{ctxList, ctxDetail, ctxBoard, ctxGraph, ctxTree, ctxInsights, ctxHistory, ctxActionable, ctxLabel, ctxFlow}
+	for _, b := range append(keymap[:len(keymap):len(keymap)], vimKeymap...) {
+		if !known[b.Section] {
+			t.Errorf("binding %q uses unknown section %q", b.Keys, b.Section)
+		}
+	}
+	// A key shown twice in one context would mean the help contradicts itself.
+	for _, ctx := range contexts {
+		seen := make(map[string]string)
+		for _, b := range keymap {
+			if !b.appliesTo(ctx) {
+				continue
+			}
+			if prev, dup := seen[b.Keys]; dup {
+				t.Errorf("context %q: %q documented twice (%s, %s)", ctx, b.Keys, prev, b.Section)
+			}
+			seen[b.Keys] = b.Section
+		}
+	}
+}
+
+func TestHelpOverlaySearchAndContext(t *testing.T) {
+	issues := []model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}
+	m := NewModel(issues, nil, "")
+	m.width, m.height = 140, 60
+	m.showHelp = true
+	m.focusBeforeHelp = focusBoard
+	m.isBoardView = true
+	m.focused = focusHelp
+
+	out := m.renderHelpOverlay()
+	if !strings.Contains(out, "Jump to column") {
+		t.Error("help opened from board should list board bindings")
+	}
+	if strings.Contains(out, "Scroll left/right") {
+		t.Error("help opened from board should not list graph bindings")
+	}
+
+	// "/" starts search; typed keys (even ones that are normally global) feed the query
+	m = m.handleHelpKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("/")})
+	if !m.helpSearching {
+		t.Fatal("expected / to start help search")
+	}
+	for _, r := range "swim" {
+		m = m.handleHelpKeys(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+	}
+	m = m.handleHelpKeys(tea.KeyMsg{Type: tea.KeyBackspace})
+	if m.helpQuery != "swi" {
+		t.Fatalf("expected query 'swi', got %q", m.helpQuery)
+	}
+	m = m.handleHelpKeys(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.helpSearching || !m.showHelp {
+		t.Fatal("enter should stop editing but keep help open")
+	}
+
+	out = m.renderHelpOverlay()
+	if !strings.Contains(out, "Cycle swimlanes") || strings.Contains(out, "Jump to column") {
+		t.Error("search should narrow help to matching bindings")
+	}
+
+	// First esc clears the query, second closes help
+	m = m.handleHelpKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.helpQuery != "" || !m.showHelp {
+		t.Fatal("first esc should clear the search query")
+	}
+	m = m.handleHelpKeys(tea.KeyMsg{Type: tea.KeyEsc})
+	if m.showHelp {
+		t.Fatal("second esc should close help")
+	}
+	if m.focused != focusBoard {
+		t.Fatalf("closing help should restore board focus, got %v", m.focused)
+	}
+}
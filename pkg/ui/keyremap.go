@@ -0,0 +1,326 @@
+package ui
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync/atomic"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// User key remapping. Registry bindings with an Action name can be moved to
+// other keys in config.yaml:
+//
+//	keys:
+//	  board_view: B
+//	  move_down: [n, down]
+//
+// The handlers keep matching the default keys: Update translates a remapped
+// key into the action's first default key before dispatching it, and drops
+// a default key the user moved away unless another binding live in the view
+// still uses it. Remaps apply in the main views; dialogs, pickers and text
+// inputs keep their own keys.
+
+// keyRemaps holds the validated remaps, action → keys; nil means defaults.
+var keyRemaps atomic.Pointer[map[string][]string]
+
+// SetKeyRemap binds actions to new keys, replacing their default keys. Keys
+// are single characters or bubbletea key names ("enter", "ctrl+t", "f1",
+// "alt+x", "space"). If an action is unknown, a key is invalid, or a key
+// would trigger two bindings in the same view, nothing changes and the error
+// names every problem, one per line, including both colliding actions. An empty remap
+// restores the defaults.
+func SetKeyRemap(remap map[string][]string) error {
+	actions := make(map[string]bool)
+	for _, b := range keymap {
+		if b.Action != "" {
+			actions[b.Action] = true
+		}
+	}
+
+	names := make([]string, 0, len(remap))
+	for action := range remap {
+		names = append(names, action)
+	}
+	sort.Strings(names)
+
+	var problems []error
+	resolved := make(map[string][]string, len(remap))
+	for _, action := range names {
+		if !actions[action] {
+			problems = append(problems, fmt.Errorf("unknown action %q", action))
+			continue
+		}
+		if len(remap[action]) == 0 {
+			problems = append(problems, fmt.Errorf("%s: no keys given", action))
+			continue
+		}
+		var keys []string
+		for _, k := range remap[action] {
+			msg, err := parseKey(k)
+			if err != nil {
+				problems = append(problems, fmt.Errorf("%s: %w", action, err))
+				continue
+			}
+			if key := msg.String(); !slices.Contains(keys, key) {
+				keys = append(keys, key)
+			}
+		}
+		resolved[action] = keys
+	}
+	problems = append(problems, keyConflicts(resolved)...)
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+
+	if len(resolved) == 0 {
+		keyRemaps.Store(nil)
+	} else {
+		keyRemaps.Store(&resolved)
+	}
+	return nil
+}
+
+// currentKeyRemap returns the remaps set by SetKeyRemap, or nil.
+func currentKeyRemap() map[string][]string {
+	if r := keyRemaps.Load(); r != nil {
+		return *r
+	}
+	return nil
+}
+
+// keyConflicts reports keys that would trigger two bindings live in the
+// same view. Keys the registry already gives two bindings by default never
+// conflict: the handlers decide between them.
+func keyConflicts(remap map[string][]string) []error {
+	effective := func(b keyBinding) ([]string, bool) {
+		if keys, ok := remap[b.Action]; ok && b.Action != "" {
+			return keys, true
+		}
+		return b.defaultKeys(), false
+	}
+	var conflicts []error
+	for i, a := range keymap {
+		aKeys, aRemapped := effective(a)
+		for _, b := range keymap[i+1:] {
+			bKeys, bRemapped := effective(b)
+			if !aRemapped && !bRemapped {
+				continue
+			}
+			where, ok := sharedContexts(a, b)
+			if !ok {
+				continue
+			}
+			for _, k := range aKeys {
+				if !slices.Contains(bKeys, k) {
+					continue
+				}
+				// Keeping a key both defaults share is not a new conflict
+				if slices.Contains(a.defaultKeys(), k) && slices.Contains(b.defaultKeys(), k) {
+					continue
+				}
+				conflicts = append(conflicts, fmt.Errorf("%q is bound to both %s and %s %s",
+					k, a.actionName(), b.actionName(), where))
+			}
+		}
+	}
+	return conflicts
+}
+
+// sharedContexts describes where both bindings are live, or reports false
+// if nowhere.
+func sharedContexts(a, b keyBinding) (string, bool) {
+	switch {
+	case len(a.Contexts) == 0 && len(b.Contexts) == 0:
+		return "in every view", true
+	case len(a.Contexts) == 0:
+		return "in " + strings.Join(b.Contexts, ", "), true
+	case len(b.Contexts) == 0:
+		return "in " + strings.Join(a.Contexts, ", "), true
+	}
+	var shared []string
+	for _, c := range a.Contexts {
+		if slices.Contains(b.Contexts, c) {
+			shared = append(shared, c)
+		}
+	}
+	if len(shared) == 0 {
+		return "", false
+	}
+	return "in " + strings.Join(shared, ", "), true
+}
+
+// actionName names the binding in error messages: its action, or its
+// description and section if it can't be remapped.
+func (b keyBinding) actionName() string {
+	if b.Action != "" {
+		return b.Action
+	}
+	return fmt.Sprintf("%q (%s)", b.Desc, b.Section)
+}
+
+// remapKey translates key, pressed in focus context ctx, under the user's
+// remaps: a key bound to a remapped action becomes that action's default
+// key, and a default key the user moved elsewhere is dropped (ok=false).
+// Other keys pass through.
+func remapKey(ctx, key string) (string, bool) {
+	remap := currentKeyRemap()
+	if remap == nil {
+		return key, true
+	}
+	moved, used := false, false
+	for _, b := range keymap {
+		if !b.appliesTo(ctx) {
+			continue
+		}
+		if keys, ok := remap[b.Action]; ok && b.Action != "" {
+			if slices.Contains(keys, key) {
+				return b.defaultKeys()[0], true
+			}
+			if slices.Contains(b.defaultKeys(), key) {
+				moved = true
+			}
+			continue
+		}
+		if slices.Contains(b.defaultKeys(), key) {
+			used = true
+		}
+	}
+	if moved && !used {
+		return "", false
+	}
+	return key, true
+}
+
+// keyNameAliases spells display names the registry uses as key names.
+var keyNameAliases = map[string]string{
+	"↑":     "up",
+	"↓":     "down",
+	"←":     "left",
+	"→":     "right",
+	"Space": " ",
+	"PgUp":  "pgup",
+	"PgDn":  "pgdown",
+	"Dn":    "pgdown", // "PgUp/Dn"
+}
+
+// defaultKeys parses the binding's display keys into the key names its
+// handler matches: "G / end" is G and end, "Ctrl+j/k" is ctrl+j and
+// ctrl+k, "1-4" is 1 through 4, "←↓↑→" is the four arrows.
+func (b keyBinding) defaultKeys() []string {
+	var keys []string
+	for _, part := range strings.Split(b.Keys, " / ") {
+		mod := ""
+		for _, tok := range splitKeyPart(part) {
+			if m, rest, ok := strings.Cut(tok, "+"); ok && rest != "" {
+				mod, tok = strings.ToLower(m)+"+", rest
+			}
+			if alias, ok := keyNameAliases[tok]; ok {
+				tok = alias
+			} else if len([]rune(tok)) > 1 || mod == "ctrl+" {
+				tok = strings.ToLower(tok)
+			}
+			keys = append(keys, mod+tok)
+		}
+	}
+	return keys
+}
+
+// splitKeyPart splits one " / "-separated part of a display key into keys.
+func splitKeyPart(part string) []string {
+	runes := []rune(part)
+	switch {
+	case part == "/":
+		return []string{part}
+	case strings.Contains(part, "/"):
+		return strings.Split(part, "/")
+	case len(runes) == 3 && runes[1] == '-' && runes[0] < runes[2]:
+		var keys []string
+		for r := runes[0]; r <= runes[2]; r++ {
+			keys = append(keys, string(r))
+		}
+		return keys
+	case len(runes) > 1 && strings.Trim(part, "←↓↑→") == "":
+		keys := make([]string, len(runes))
+		for i, r := range runes {
+			keys[i] = string(r)
+		}
+		return keys
+	}
+	return []string{part}
+}
+
+// keysLabel renders remapped keys for the help overlay and sidebar.
+func keysLabel(keys []string) string {
+	labels := make([]string, len(keys))
+	for i, k := range keys {
+		labels[i] = keyLabel(k)
+	}
+	return strings.Join(labels, " / ")
+}
+
+// keyLabel renders a key name the way the registry writes keys: "ctrl+t"
+// as "Ctrl+T", "down" as "↓", " " as "Space".
+func keyLabel(key string) string {
+	switch key {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	}
+	if mod, rest, ok := strings.Cut(key, "+"); ok && rest != "" {
+		if mod == "ctrl" && len([]rune(rest)) == 1 {
+			rest = strings.ToUpper(rest)
+		}
+		return strings.ToUpper(mod[:1]) + mod[1:] + "+" + keyLabel(rest)
+	}
+	if len([]rune(key)) > 1 {
+		return strings.ToUpper(key[:1]) + key[1:]
+	}
+	return key
+}
+
+// keyTypes maps bubbletea key names to key types.
+var keyTypes = func() map[string]tea.KeyType {
+	keys := map[string]tea.KeyType{"space": tea.KeySpace}
+	for k := tea.KeyType(-128); k < 128; k++ {
+		if name := k.String(); name != "" && k != tea.KeyRunes {
+			keys[name] = k
+		}
+	}
+	return keys
+}()
+
+// parseKey turns a key name or character into a key press. Names are
+// case-insensitive ("F9", "Ctrl+T"); single characters are not.
+func parseKey(s string) (tea.KeyMsg, error) {
+	var key tea.Key
+	name := s
+	if len(name) > len("alt+") && strings.EqualFold(name[:len("alt+")], "alt+") {
+		key.Alt = true
+		name = name[len("alt+"):]
+	}
+	if t, ok := keyTypes[name]; ok {
+		key.Type = t
+		return tea.KeyMsg(key), nil
+	}
+	if t, ok := keyTypes[strings.ToLower(name)]; ok && len([]rune(name)) > 1 {
+		key.Type = t
+		return tea.KeyMsg(key), nil
+	}
+	if runes := []rune(name); len(runes) == 1 {
+		key.Type = tea.KeyRunes
+		key.Runes = runes
+		return tea.KeyMsg(key), nil
+	}
+	return tea.KeyMsg{}, fmt.Errorf("unknown key %q", s)
+}
@@ -0,0 +1,218 @@
+package ui
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestKeymapDefaultKeys(t *testing.T) {
+	actions := make(map[string]bool)
+	for _, b := range keymap {
+		keys := b.defaultKeys()
+		if len(keys) == 0 {
+			t.Errorf("%q: no keys", b.Keys)
+		}
+		for _, k := range keys {
+			msg, err := parseKey(k)
+			if err != nil {
+				t.Errorf("%q: %v", b.Keys, err)
+			} else if msg.String() != k {
+				t.Errorf("%q: key %q reads back as %q", b.Keys, k, msg.String())
+			}
+		}
+		if b.Action == "" {
+			continue
+		}
+		if actions[b.Action] {
+			t.Errorf("action %q used twice", b.Action)
+		}
+		actions[b.Action] = true
+	}
+
+	tests := map[string][]string{
+		"G / end":     {"G", "end"},
+		"Ctrl+T":      {"ctrl+t"},
+		"Alt+H":       {"alt+H"},
+		"Ctrl+j/k":    {"ctrl+j", "ctrl+k"},
+		"1-4":         {"1", "2", "3", "4"},
+		"←↓↑→":        {"left", "down", "up", "right"},
+		"PgUp/Dn":     {"pgup", "pgdown"},
+		"Enter/Space": {"enter", " "},
+		"[ / F3":      {"[", "f3"},
+		"/":           {"/"},
+	}
+	for display, want := range tests {
+		if got := (keyBinding{Keys: display}).defaultKeys(); !reflect.DeepEqual(got, want) {
+			t.Errorf("defaultKeys(%q) = %q, want %q", display, got, want)
+		}
+	}
+}
+
+func TestParseKey(t *testing.T) {
+	tests := map[string]string{
+		"j":         "j",
+		"enter":     "enter",
+		"esc":       "esc",
+		"ctrl+t":    "ctrl+t",
+		"shift+tab": "shift+tab",
+		"space":     " ",
+		" ":         " ",
+		"alt+x":     "alt+x",
+		"é":         "é",
+		"F9":        "f9",
+		"Ctrl+T":    "ctrl+t",
+		"Alt+H":     "alt+H",
+	}
+	for in, want := range tests {
+		msg, err := parseKey(in)
+		if err != nil {
+			t.Errorf("parseKey(%q): %v", in, err)
+			continue
+		}
+		if got := msg.String(); got != want {
+			t.Errorf("parseKey(%q) = %q, want %q", in, got, want)
+		}
+	}
+	for _, bad := range []string{"", "jk", "alt+", "hyper+x"} {
+		if _, err := parseKey(bad); err == nil {
+			t.Errorf("parseKey(%q) should fail", bad)
+		}
+	}
+}
+
+func TestSetKeyRemapValidation(t *testing.T) {
+	t.Cleanup(func() { _ = SetKeyRemap(nil) })
+
+	err := SetKeyRemap(map[string][]string{
+		"board_vew":  {"B"},
+		"help":       {"hyper+h"},
+		"move_down":  {"n"},
+		"graph_view": {"b"},
+	})
+	if err == nil {
+		t.Fatal("expected errors")
+	}
+	for _, want := range []string{
+		`unknown action "board_vew"`,
+		`help: unknown key "hyper+h"`,
+		`"n" is bound to both move_down and "Next / prev match" (Board) in board`,
+		`"b" is bound to both board_view and graph_view in every view`,
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error should contain %q:\n%v", want, err)
+		}
+	}
+	if currentKeyRemap() != nil {
+		t.Error("a rejected remap must leave the defaults in place")
+	}
+
+	// Moving both actions of a would-be collision is fine
+	if err := SetKeyRemap(map[string][]string{"board_view": {"g"}, "graph_view": {"b"}}); err != nil {
+		t.Fatalf("swap: %v", err)
+	}
+	if err := SetKeyRemap(map[string][]string{"toggle_times": {"f9", "F9", "Ctrl+Y"}}); err != nil {
+		t.Fatal(err)
+	}
+	if got := currentKeyRemap()["toggle_times"]; !reflect.DeepEqual(got, []string{"f9", "ctrl+y"}) {
+		t.Errorf("remap = %q", got)
+	}
+}
+
+func TestRemapKey(t *testing.T) {
+	t.Cleanup(func() { _ = SetKeyRemap(nil) })
+	if err := SetKeyRemap(map[string][]string{"move_down": {"ctrl+n"}, "board_search": {"f"}}); err == nil {
+		t.Fatal(`"f" is the flow matrix everywhere; binding board search to it should conflict`)
+	}
+	if err := SetKeyRemap(map[string][]string{"move_down": {"ctrl+n"}, "cycle_swimlanes": {"w"}}); err == nil {
+		t.Fatal(`"w" is the repo picker everywhere; binding swimlanes to it should conflict`)
+	}
+	if err := SetKeyRemap(map[string][]string{"move_down": {"ctrl+n"}}); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		ctx, key, want string
+		live           bool
+	}{
+		{ctxList, "ctrl+n", "j", true},
+		{ctxList, "j", "", false},        // moved away
+		{ctxList, "down", "", false},     // both default keys moved
+		{ctxGraph, "down", "down", true}, // still graph navigation
+		{ctxList, "k", "k", true},
+	}
+	for _, tt := range tests {
+		got, live := remapKey(tt.ctx, tt.key)
+		if got != tt.want || live != tt.live {
+			t.Errorf("remapKey(%s, %q) = %q, %v; want %q, %v", tt.ctx, tt.key, got, live, tt.want, tt.live)
+		}
+	}
+}
+
+func TestKeyRemapInTUI(t *testing.T) {
+	t.Cleanup(func() { _ = SetKeyRemap(nil) })
+	if err := SetKeyRemap(map[string][]string{"board_view": {"f9", "K"}}); err == nil {
+		t.Fatal(`"K" moves up everywhere; expected a conflict`)
+	}
+	if err := SetKeyRemap(map[string][]string{"board_view": {"f9"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	issues := []model.Issue{
+		{ID: "km-1", Title: "Board me", Status: model.StatusOpen, IssueType: model.TypeTask},
+		{ID: "km-2", Title: "bbb", Status: model.StatusOpen, IssueType: model.TypeTask},
+	}
+	d := NewDriver(NewModel(issues, nil, ""), 80, 40)
+	defer d.Close(time.Second)
+
+	if err := d.SendKeys("b"); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.CurrentView(); got != "list" {
+		t.Errorf("b after moving the board away: view %q, want list", got)
+	}
+	if err := d.SendKeys("f9"); err != nil {
+		t.Fatal(err)
+	}
+	if got := d.CurrentView(); got != "board" {
+		t.Fatalf("f9: view %q, want board", got)
+	}
+	if err := d.SendKeys("f9", "/"); err != nil {
+		t.Fatal(err)
+	}
+	// Typing a filter is never remapped
+	d.Type("bbb")
+	if got := d.Model().list.FilterInput.Value(); got != "bbb" {
+		t.Errorf("filter input = %q, want bbb", got)
+	}
+	d.Resize(160, 60)
+	if err := d.SendKeys("esc", "?"); err != nil {
+		t.Fatal(err)
+	}
+	if frame := d.CapturedFrame(); !strings.Contains(frame, "F9") || !strings.Contains(frame, "Kanban board") {
+		t.Errorf("help should show the remapped key:\n%s", frame)
+	}
+	groups := bindingsFor(ctxList, "kanban")
+	if len(groups) != 1 || groups[0].Bindings[0].Keys != "F9" {
+		t.Errorf("bindingsFor = %+v, want the board on F9", groups)
+	}
+}
+
+func TestKeyLabel(t *testing.T) {
+	tests := map[string]string{
+		"ctrl+t":    "Ctrl+T",
+		"alt+x":     "Alt+x",
+		"f9":        "F9",
+		" ":         "Space",
+		"down":      "↓",
+		"shift+tab": "Shift+Tab",
+		"B":         "B",
+	}
+	for in, want := range tests {
+		if got := keyLabel(in); got != want {
+			t.Errorf("keyLabel(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
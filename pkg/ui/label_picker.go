@@ -11,6 +11,7 @@ import (
 
 // LabelPickerModel provides a fuzzy search popup for quick label filtering
 type LabelPickerModel struct {
+	title         string // "" means "Filter by Label"
 	allLabels     []string
 	labelCounts   map[string]int // count of issues per label
 	filtered      []string
@@ -71,6 +72,11 @@ func (m *LabelPickerModel) SetLabels(labels []string, counts map[string]int) {
 	m.filterLabels()
 }
 
+// SetTitle sets the popup's title, for listing something other than labels.
+func (m *LabelPickerModel) SetTitle(title string) {
+	m.title = title
+}
+
 // MoveUp moves selection up
 func (m *LabelPickerModel) MoveUp() {
 	if m.selectedIndex > 0 {
@@ -241,7 +247,11 @@ func (m *LabelPickerModel) View() string {
 		Foreground(t.Primary).
 		Bold(true).
 		MarginBottom(1)
-	lines = append(lines, titleStyle.Render("Filter by Label"))
+	title := m.title
+	if title == "" {
+		title = "Filter by Label"
+	}
+	lines = append(lines, titleStyle.Render(title))
 	lines = append(lines, "")
 
 	// Search input
@@ -0,0 +1,75 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// labelTaxonomyTopN caps the usage and pair lists in the taxonomy panel.
+const labelTaxonomyTopN = 10
+
+// ComputeLabelTaxonomyView builds the pre-rendered label taxonomy panel:
+// probable typo variants first, since those are the fixes, then slow
+// labels, clusters, and usage.
+func ComputeLabelTaxonomyView(issues []model.Issue, width int) string {
+	tax := analysis.ComputeLabelTaxonomy(issues)
+	if tax.TotalLabels == 0 {
+		return "No labels in use.\n"
+	}
+	line := func(b *strings.Builder, s string) {
+		b.WriteString(truncate(s, max(20, width)))
+		b.WriteString("\n")
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d labels on %d issues • %d unlabeled • %d used once\n",
+		tax.TotalLabels, tax.LabeledIssues, tax.UnlabeledIssues, len(tax.Singletons))
+
+	b.WriteString("\nProbable variants:\n")
+	if len(tax.Variants) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, v := range tax.Variants {
+		line(&b, fmt.Sprintf("  %s ← %s (%s, %d issues)", v.Canonical, strings.Join(v.Variants, ", "), v.Reason, v.Issues))
+	}
+
+	fmt.Fprintf(&b, "\nSlow labels (median cycle %.1fd overall):\n", tax.MedianCycleDays)
+	if len(tax.SlowLabels) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, s := range tax.SlowLabels {
+		line(&b, fmt.Sprintf("  %-20s %6.1fd  %.1fx  (%d closed)", truncate(s.Label, 20), s.MedianDays, s.Ratio, s.Samples))
+	}
+
+	b.WriteString("\nClusters:\n")
+	if len(tax.Clusters) == 0 {
+		b.WriteString("  none\n")
+	}
+	for _, c := range tax.Clusters {
+		line(&b, fmt.Sprintf("  %s (%d issues)", strings.Join(c.Labels, " + "), c.Issues))
+	}
+
+	b.WriteString("\nTop pairs:\n")
+	for i, p := range tax.Pairs {
+		if i == labelTaxonomyTopN {
+			break
+		}
+		line(&b, fmt.Sprintf("  %s + %s  ×%d  (%.0f%% overlap)", p.A, p.B, p.Count, p.Jaccard*100))
+	}
+	if len(tax.Pairs) == 0 {
+		b.WriteString("  none\n")
+	}
+
+	b.WriteString("\nMost used:\n")
+	for i, u := range tax.Usage {
+		if i == labelTaxonomyTopN {
+			fmt.Fprintf(&b, "  … %d more\n", len(tax.Usage)-i)
+			break
+		}
+		line(&b, fmt.Sprintf("  %-20s %4d  (%d open, %d closed)", truncate(u.Label, 20), u.Count, u.Open, u.Closed))
+	}
+	return b.String()
+}
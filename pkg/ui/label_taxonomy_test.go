@@ -0,0 +1,44 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeLabelTaxonomyView(t *testing.T) {
+	if out := ComputeLabelTaxonomyView([]model.Issue{{ID: "A", Status: model.StatusOpen}}, 80); out != "No labels in use.\n" {
+		t.Errorf("no labels: got %q", out)
+	}
+	issues := []model.Issue{
+		{ID: "A", Status: model.StatusOpen, Labels: []string{"backend", "api"}},
+		{ID: "B", Status: model.StatusOpen, Labels: []string{"backend", "api"}},
+		{ID: "C", Status: model.StatusOpen, Labels: []string{"back-end"}},
+	}
+	out := ComputeLabelTaxonomyView(issues, 80)
+	for _, want := range []string{"3 labels on 3 issues", "backend ← back-end (spelling, 3 issues)", "api + backend (2 issues)", "api + backend  ×2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("missing %q in:\n%s", want, out)
+		}
+	}
+}
+
+func TestLabelTaxonomyPanelFromDashboard(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "A", Title: "A", Status: model.StatusOpen, Labels: []string{"ui"}}}, nil, "")
+	m.focused = focusLabelDashboard
+
+	key := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("t")}
+	next, _ := m.Update(key)
+	m = next.(Model)
+	if !m.showLabelTaxonomy || m.focused != focusInsights || !strings.Contains(m.insightsPanel.extraText, "1 labels") {
+		t.Fatalf("t should open the taxonomy panel: show=%v focus=%v", m.showLabelTaxonomy, m.focused)
+	}
+	next, _ = m.Update(key)
+	m = next.(Model)
+	if m.showLabelTaxonomy || m.focused != focusLabelDashboard {
+		t.Errorf("t again should return to the label dashboard: show=%v focus=%v", m.showLabelTaxonomy, m.focused)
+	}
+}
@@ -0,0 +1,86 @@
+package ui
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// The list itself is already windowed: bubbles renders only the rows of the
+// current page, so drawing costs the same for 100 issues or 100k. What grows
+// with the dataset is everything done per keystroke, which this file keeps
+// off the hot path:
+//   - filter matching over every item is debounced on large lists, and
+//   - the detail pane is re-rendered only when what it shows changed.
+
+// largeListThreshold is the item count from which list filtering is
+// debounced instead of re-run on every keystroke.
+const largeListThreshold = 5000
+
+// filterDebounceDelay is how long typing must pause before a large list is
+// matched against the filter text.
+const filterDebounceDelay = 120 * time.Millisecond
+
+// listFilterDebounceMsg fires filterDebounceDelay after a filter edit on a
+// large list. Only the most recent edit (seq) triggers matching.
+type listFilterDebounceMsg struct {
+	seq int
+}
+
+// updateList forwards msg to the list. On a large list, an edit to the
+// filter text doesn't start matching right away: the list's own match
+// command is dropped and a debounce tick scheduled, so holding a key down
+// runs one match instead of one per character. The previous matches stay on
+// screen meanwhile.
+func (m *Model) updateList(msg tea.Msg) tea.Cmd {
+	before := m.list.FilterValue()
+	wasFiltering := m.list.FilterState() == list.Filtering
+	var cmd tea.Cmd
+	m.list, cmd = m.list.Update(msg)
+	if !wasFiltering || len(m.list.Items()) < largeListThreshold || m.list.FilterValue() == before {
+		return cmd
+	}
+	m.filterDebounceSeq++
+	seq := m.filterDebounceSeq
+	return tea.Tick(filterDebounceDelay, func(time.Time) tea.Msg {
+		return listFilterDebounceMsg{seq: seq}
+	})
+}
+
+// handleListFilterDebounce runs the match for the latest filter edit, in the
+// background like bubbles does for small lists. Stale ticks, and ticks after
+// the filter was cleared, do nothing.
+func (m *Model) handleListFilterDebounce(msg listFilterDebounceMsg) tea.Cmd {
+	if msg.seq != m.filterDebounceSeq || m.list.FilterState() == list.Unfiltered {
+		return nil
+	}
+	// Setting the same items re-runs the match for the current filter text
+	return m.list.SetItems(m.list.Items())
+}
+
+// detailKey identifies what the detail pane shows for the current
+// selection: the issue, its search scores, and the data feeding the
+// metrics and history sections.
+func (m *Model) detailKey() string {
+	item, ok := m.list.SelectedItem().(IssueItem)
+	if !ok {
+		return "\x00none"
+	}
+	phase2 := m.analysis != nil && m.analysis.IsPhase2Ready()
+	return fmt.Sprintf("%s|%d|%t|%g|%g|%d|%p|%t|%t|%t",
+		item.Issue.ID, item.Issue.UpdatedAt.UnixNano(),
+		item.SearchScoreSet, item.SearchScore, item.SearchTextScore, m.list.FilterState(),
+		m.analysis, phase2, m.historyView.HasReport(), m.updateAvailable)
+}
+
+// refreshDetailIfChanged re-renders the detail pane only if detailKey moved
+// since the last render. Rendering builds the dependency tree, activity and
+// history sections and runs the markdown renderer, which is too much to
+// repeat for every message that leaves the selection alone.
+func (m *Model) refreshDetailIfChanged() {
+	if m.detailRenderedFor != m.detailKey() {
+		m.updateViewportContent()
+	}
+}
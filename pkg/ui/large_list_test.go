@@ -0,0 +1,99 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/charmbracelet/bubbles/list"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func manyIssues(n int) []model.Issue {
+	issues := make([]model.Issue, n)
+	for i := range issues {
+		issues[i] = model.Issue{
+			ID:        fmt.Sprintf("big-%05d", i),
+			Title:     fmt.Sprintf("Issue number %d", i),
+			Status:    model.StatusOpen,
+			IssueType: model.TypeTask,
+		}
+	}
+	return issues
+}
+
+func typeKeys(t *testing.T, m Model, keys string) (Model, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, r := range keys {
+		var next tea.Model
+		next, cmd = m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune{r}})
+		m = next.(Model)
+	}
+	return m, cmd
+}
+
+func TestLargeListFilterIsDebounced(t *testing.T) {
+	m := NewModel(manyIssues(largeListThreshold+100), nil, "")
+	m, _ = typeKeys(t, m, "/")
+	if m.list.FilterState() != list.Filtering {
+		t.Fatalf("filter state = %v, want filtering", m.list.FilterState())
+	}
+	m, _ = typeKeys(t, m, "00042")
+	if m.filterDebounceSeq != 5 {
+		t.Fatalf("debounce seq = %d, want one per edit", m.filterDebounceSeq)
+	}
+	if got := len(m.list.VisibleItems()); got != largeListThreshold+100 {
+		t.Fatalf("matching ran before the debounce fired: %d visible", got)
+	}
+
+	// A stale tick is ignored; the latest one runs the match
+	next, cmd := m.Update(listFilterDebounceMsg{seq: 2})
+	m = next.(Model)
+	if cmd != nil {
+		t.Fatal("stale debounce tick started a match")
+	}
+	next, cmd = m.Update(listFilterDebounceMsg{seq: 5})
+	m = next.(Model)
+	if cmd == nil {
+		t.Fatal("latest debounce tick did not start a match")
+	}
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	visible := m.list.VisibleItems()
+	if len(visible) == 0 || len(visible) > 100 {
+		t.Fatalf("%d issues match 00042", len(visible))
+	}
+	if id := visible[0].(IssueItem).Issue.ID; id != "big-00042" {
+		t.Errorf("best match = %s", id)
+	}
+}
+
+func TestSmallListFilterIsNotDebounced(t *testing.T) {
+	m := NewModel(manyIssues(20), nil, "")
+	m, _ = typeKeys(t, m, "/7")
+	if m.filterDebounceSeq != 0 {
+		t.Errorf("small list debounced its filter")
+	}
+}
+
+func TestDetailPaneRendersOnlyOnChange(t *testing.T) {
+	m := NewModel(manyIssues(30), nil, "")
+	m.analysis.WaitForPhase2() // Phase 2 finishing is a change too
+	m.updateViewportContent()
+
+	const sentinel = "sentinel: not re-rendered"
+	m.viewport.SetContent(sentinel)
+	m.refreshDetailIfChanged()
+	if !strings.Contains(m.viewport.View(), sentinel) {
+		t.Fatal("detail pane re-rendered with the selection unchanged")
+	}
+
+	m.list.Select(3)
+	m.refreshDetailIfChanged()
+	if strings.Contains(m.viewport.View(), sentinel) {
+		t.Fatal("detail pane not re-rendered after the selection moved")
+	}
+}
@@ -6,6 +6,8 @@ import (
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+	tea "github.com/charmbracelet/bubbletea"
 )
 
 // White-box testing of UI model logic
@@ -181,3 +183,30 @@ func TestFormatTimeRel(t *testing.T) {
 		}
 	}
 }
+
+func TestTimestampToggle(t *testing.T) {
+	timefmt.Set(timefmt.Options{Style: timefmt.StyleRelative, Location: time.UTC})
+	t.Cleanup(func() { timefmt.Set(timefmt.Options{Style: timefmt.StyleRelative}) })
+
+	created := time.Date(2024, 3, 5, 14, 7, 0, 0, time.UTC)
+	if got := FormatTime(created); got == "2024-03-05 14:07 UTC" {
+		t.Fatalf("relative style printed an absolute time")
+	}
+
+	m := NewModel([]model.Issue{{ID: "a", Title: "A", Status: model.StatusOpen, CreatedAt: created}}, nil, "")
+	updated, _ := m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	m = updated.(Model)
+	if got := FormatTime(created); got != "2024-03-05 14:07 UTC" {
+		t.Errorf("after Ctrl+T FormatTime = %q", got)
+	}
+	if got := formatTimeShort(created); got != "2024" && got != "03-05" {
+		t.Errorf("formatTimeShort = %q", got)
+	}
+	if m.statusMsg != "Timestamps: absolute (UTC)" {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	m.Update(tea.KeyMsg{Type: tea.KeyCtrlT})
+	if timefmt.Current().Style != timefmt.StyleRelative {
+		t.Error("second Ctrl+T did not switch back to relative")
+	}
+}
@@ -10,5 +10,15 @@ func TestMain(m *testing.M) {
 	os.Setenv("BV_NO_BROWSER", "1")
 	os.Setenv("BV_TEST_MODE", "1")
 
-	os.Exit(m.Run())
+	// Keep saved UI state out of the user's state directory
+	stateDir, err := os.MkdirTemp("", "bv-ui-state-*")
+	if err == nil {
+		os.Setenv("XDG_STATE_HOME", stateDir)
+	}
+
+	code := m.Run()
+	if stateDir != "" {
+		_ = os.RemoveAll(stateDir)
+	}
+	os.Exit(code)
 }
@@ -77,7 +77,9 @@ func NewMarkdownRendererWithTheme(width int, theme Theme) *MarkdownRenderer {
 }
 
 // Render converts markdown content to styled terminal output.
+// CRLF line endings are normalized first (see normalizeNewlines).
 func (mr *MarkdownRenderer) Render(markdown string) (string, error) {
+	markdown = normalizeNewlines(markdown)
 	if mr.renderer == nil {
 		return markdown, nil
 	}
@@ -15,23 +15,28 @@ import (
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/baseline"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/cass"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/codeowners"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/correlation"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/export"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/rules"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/search"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/watcher"
 
-	"github.com/atotto/clipboard"
 	"github.com/charmbracelet/bubbles/list"
 	"github.com/charmbracelet/bubbles/textinput"
 	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
 )
 
 // View width thresholds for adaptive layout
@@ -73,12 +78,15 @@ const (
 type SortMode int
 
 const (
-	SortDefault     SortMode = iota // Priority asc, then created desc (original default)
-	SortCreatedAsc                  // By creation date, oldest first
-	SortCreatedDesc                 // By creation date, newest first
-	SortPriority                    // By priority only (ascending)
-	SortUpdated                     // By last update, newest first
-	numSortModes                    // Keep this last - used for cycling
+	SortDefault      SortMode = iota // Priority asc, then created desc (original default)
+	SortCreatedAsc                   // By creation date, oldest first
+	SortCreatedDesc                  // By creation date, newest first
+	SortPriority                     // By priority only (ascending)
+	SortUpdated                      // By last update, newest first
+	SortDepth                        // By dependency depth, deepest first
+	SortDependents                   // By direct dependents (fan-in), most first
+	SortDependencies                 // By direct dependencies (fan-out), most first
+	numSortModes                     // Keep this last - used for cycling
 )
 
 // String returns a human-readable label for the sort mode
@@ -92,6 +100,12 @@ func (s SortMode) String() string {
 		return "Priority"
 	case SortUpdated:
 		return "Updated"
+	case SortDepth:
+		return "Depth"
+	case SortDependents:
+		return "Fan-in"
+	case SortDependencies:
+		return "Fan-out"
 	default:
 		return "Default"
 	}
@@ -109,6 +123,7 @@ type LabelGraphAnalysisResult struct {
 type UpdateMsg struct {
 	TagName string
 	URL     string
+	Channel updater.Channel // release channel the check ran against
 }
 
 // Phase2ReadyMsg is sent when async graph analysis Phase 2 completes
@@ -213,7 +228,7 @@ func CheckUpdateCmd() tea.Cmd {
 	return func() tea.Msg {
 		tag, url, err := updater.CheckForUpdates()
 		if err == nil && tag != "" {
-			return UpdateMsg{TagName: tag, URL: url}
+			return UpdateMsg{TagName: tag, URL: url, Channel: updater.CurrentCheckConfig().Channel}
 		}
 		return nil
 	}
@@ -266,20 +281,8 @@ func LoadHistoryCmd(issues []model.Issue, beadsPath string) tea.Cmd {
 		var repoPath string
 		var err error
 
-		if beadsPath != "" {
-			// If beadsPath is provided (single-repo mode), derive repo root from it.
-			// Try to resolve absolute path first.
-			if absPath, e := filepath.Abs(beadsPath); e == nil {
-				dir := filepath.Dir(absPath)
-				// Standard layout: <repo_root>/.beads/<file.jsonl>
-				if filepath.Base(dir) == ".beads" {
-					repoPath = filepath.Dir(dir)
-				} else {
-					// Legacy/Flat layout: <repo_root>/<file.jsonl>
-					repoPath = dir
-				}
-			}
-		}
+		// If beadsPath is provided (single-repo mode), derive repo root from it.
+		repoPath = repoRootFromBeadsPath(beadsPath)
 
 		// Fallback to CWD if beadsPath is empty (workspace mode) or Abs failed
 		if repoPath == "" {
@@ -327,9 +330,28 @@ type Model struct {
 	issueMap     map[string]*model.Issue
 	analyzer     *analysis.Analyzer
 	analysis     *analysis.GraphStats
-	beadsPath    string           // Path to beads.jsonl for reloading
-	watcher      *watcher.Watcher // File watcher for live reload
-	instanceLock *instance.Lock   // Multi-instance coordination lock
+	beadsPath    string                  // Path to beads.jsonl for reloading
+	bdRunner     BdRunner                // Runs bd for write actions; nil means bd on PATH
+	pinned       map[string]bool         // Starred issue IDs, listed first
+	pinsPath     string                  // .bv-state.json the stars are saved in; "" = not saved
+	focus        focusTimer              // Claims made with M and the time logged on them
+	codeRefs     *correlation.XRefReport // Commits and branches mentioning issues; nil until scanned
+	codeOwners   *codeowners.File        // The project's CODEOWNERS; nil without one
+	localNotes   map[string]string       // Local notes by issue ID (n)
+	notesDir     string                  // .beads/.bv-notes the notes are kept in; "" = none
+	uiStatePath  string                  // Per-user ui.json restored at startup; "" = not saved
+	watcher      *watcher.Watcher        // File watcher for live reload
+	instanceLock *instance.Lock          // Multi-instance coordination lock
+	instanceReg  *instance.Registration  // Entry in .bv.instances.json (heartbeat)
+	// cacheSignalWatcher watches .bv.cache-signal for analysis refreshes made
+	// by other bv processes (robot commands) while this TUI is live-reloading.
+	cacheSignalWatcher *watcher.Watcher
+	// dataFile is the version of the beads file on screen, compared with
+	// the one a cache signal names
+	dataFile instance.FileStamp
+	// Secondary-instance handling (see instance_lock.go)
+	lockPrompt lockPromptState
+	readOnly   bool // secondary: don't persist shared repository state
 
 	// Background Worker (Phase 2 architecture - bv-m7v8)
 	// snapshot is the current immutable data snapshot from BackgroundWorker.
@@ -362,6 +384,7 @@ type Model struct {
 	updateAvailable bool
 	updateTag       string
 	updateURL       string
+	updateChannel   updater.Channel
 
 	// Focus and View State
 	focused                  focus
@@ -373,7 +396,9 @@ type Model struct {
 	isHistoryView            bool
 	showDetails              bool
 	showHelp                 bool
-	helpScroll               int // Scroll offset for help overlay
+	helpScroll               int    // Scroll offset for help overlay
+	helpQuery                string // Search query for help overlay ("/" to edit)
+	helpSearching            bool   // True while typing into the help search box
 	showQuitConfirm          bool
 	ready                    bool
 	width                    int
@@ -388,12 +413,17 @@ type Model struct {
 	showLabelGraphAnalysis   bool
 	labelGraphAnalysisResult *LabelGraphAnalysisResult
 	showAttentionView        bool
+	showTeamView             bool
+	showLabelTaxonomy        bool
 	showShortcutsSidebar     bool // bv-3qi5 toggleable shortcuts sidebar
 	labelHealthCached        bool
 	labelHealthCache         analysis.LabelAnalysisResult
 	attentionCached          bool
 	attentionCache           analysis.LabelAttentionResult
 
+	// Background pre-computation of deferred views (see precompute.go)
+	scheduler *taskScheduler
+
 	// Actionable view
 	actionableView ActionableModel
 
@@ -402,6 +432,11 @@ type Model struct {
 	historyLoading    bool // True while history is being loaded in background
 	historyLoadFailed bool // True if history loading failed
 
+	// Graph health over git revisions of the beads file, loaded on first use
+	healthHistory        *analysis.HealthHistory
+	healthHistoryLoading bool
+	blockedTime          *analysis.BlockedTime // Loaded with healthHistory
+
 	// Filter and sort state
 	currentFilter          string
 	sortMode               SortMode // bv-3ita: current sort mode
@@ -413,6 +448,9 @@ type Model struct {
 	semanticHybridBuilding bool
 	semanticHybridReady    bool
 	lastSearchTerm         string
+	filterDebounceSeq      int      // Latest debounced filter edit (large lists)
+	detailRenderedFor      string   // detailKey of the last detail pane render
+	vim                    vimState // Counts, gg and marks (ui.vim_keys)
 
 	// Stats (cached)
 	countOpen    int
@@ -425,11 +463,12 @@ type Model struct {
 	priorityHints     map[string]*analysis.PriorityRecommendation // issueID -> recommendation
 
 	// Triage insights (bv-151)
-	triageScores  map[string]float64                // issueID -> triage score
-	triageReasons map[string]analysis.TriageReasons // issueID -> reasons
-	unblocksMap   map[string][]string               // issueID -> IDs that would be unblocked
-	quickWinSet   map[string]bool                   // issueID -> true if quick win
-	blockerSet    map[string]bool                   // issueID -> true if significant blocker
+	triageScores  map[string]float64                  // issueID -> triage score
+	triageReasons map[string]analysis.TriageReasons   // issueID -> reasons
+	unblocksMap   map[string][]string                 // issueID -> IDs that would be unblocked
+	depShapes     map[string]analysis.DependencyShape // issueID -> dependency depth and fan-in/fan-out
+	quickWinSet   map[string]bool                     // issueID -> true if quick win
+	blockerSet    map[string]bool                     // issueID -> true if significant blocker
 
 	// Recipe picker
 	showRecipePicker bool
@@ -437,14 +476,27 @@ type Model struct {
 	activeRecipe     *recipe.Recipe
 	recipeLoader     *recipe.Loader
 
-	// Label picker (bv-126)
+	// Label picker (bv-126), also listing owners for @
 	showLabelPicker bool
 	labelPicker     LabelPickerModel
+	pickingOwner    bool
 
 	// Repo picker (workspace mode)
 	showRepoPicker bool
 	repoPicker     RepoPickerModel
 
+	// Stale sweep overlay (Z)
+	showStaleSweep bool
+	staleSweep     StaleSweepModel
+
+	// Recent projects overlay (Ctrl+O)
+	showProjectSwitcher bool
+	projectSwitcher     ProjectSwitcherModel
+
+	// Local notes editor (n)
+	showNotesEditor bool
+	notesEditor     NotesEditorModel
+
 	// Time-travel mode
 	timeTravelMode   bool
 	timeTravelDiff   *analysis.SnapshotDiff
@@ -461,6 +513,10 @@ type Model struct {
 	statusMsg     string
 	statusIsError bool
 
+	// Reference cycling for e/B (open file/URL from issue text)
+	refCycleKey string // issue ID + kind of the last opened reference
+	refCycleIdx int
+
 	// Workspace mode state
 	workspaceMode    bool            // True when viewing multiple repos
 	availableRepos   []string        // List of repo prefixes available
@@ -475,6 +531,8 @@ type Model struct {
 	showAlertsPanel bool
 	alertsCursor    int
 	dismissedAlerts map[string]bool
+	notifiedAlerts  map[string]bool // Critical alert keys seen on the last refresh (OSC 9 dedupe)
+	alertsWatching  bool            // Set after the first reload; notifications fire only from then on
 
 	// Sprint view (bv-161)
 	sprints        []model.Sprint
@@ -633,6 +691,7 @@ func (m *Model) updateListDelegate() {
 		PriorityHints:     m.priorityHints,
 		WorkspaceMode:     m.workspaceMode,
 		ShowSearchScores:  m.shouldShowSearchScores(),
+		DepShapes:         m.depShapes,
 	})
 }
 
@@ -706,7 +765,7 @@ func (m *Model) issuesForAsync() []model.Issue {
 // beadsPath is the path to the beads.jsonl file for live reload support
 func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath string) Model {
 	// Graph Analysis - Phase 1 is instant, Phase 2 runs in background
-	analyzer := analysis.NewAnalyzer(issues)
+	analyzer := newProjectAnalyzer(issues)
 	graphStats := analyzer.AnalyzeAsync(context.Background())
 
 	// Sort issues
@@ -766,6 +825,10 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		}
 	}
 
+	pinned, pinsPath := loadPins(beadsPath)
+	localNotes, notesDir := loadLocalNotes(beadsPath)
+	pinToTop(items, pinned)
+
 	// Compute stats
 	cOpen, cReady, cBlocked, cClosed := 0, 0, 0, 0
 	for i := range issues {
@@ -950,8 +1013,17 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		}
 	}
 
+	// Watch for cache refreshes by other bv processes alongside live reload
+	var cacheSignalWatcher *watcher.Watcher
+	var dataFile instance.FileStamp
+	if fileWatcher != nil || backgroundWorker != nil {
+		cacheSignalWatcher = newCacheSignalWatcher(beadsPath)
+		dataFile, _ = instance.StatFile(beadsPath)
+	}
+
 	// Initialize instance lock for multi-instance coordination (bv-vrvn)
 	var instLock *instance.Lock
+	var instReg *instance.Registration
 	if beadsPath != "" {
 		beadsDir := filepath.Dir(beadsPath)
 		lock, err := instance.NewLock(beadsDir)
@@ -959,6 +1031,13 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 			instLock = lock
 		}
 		// Lock creation failure is non-fatal - we just won't have coordination
+
+		// Register in the instance registry so other bv processes can see us
+		reg, err := instance.Register(beadsDir, instance.ModeTUI, instLock != nil && instLock.IsFirstInstance())
+		if err == nil {
+			reg.Start(0)
+			instReg = reg
+		}
 	}
 
 	// Semantic search (bv-9gf.3): initialized lazily on first toggle.
@@ -1002,17 +1081,40 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 	if beadsPath != "" {
 		treeModel.SetBeadsDir(filepath.Dir(beadsPath))
 	}
+	stateDir := uiStateDir(beadsPath)
+	if stateDir != "" {
+		treeModel.SetStatePath(filepath.Join(stateDir, uistate.TreeFilename))
+	}
+
+	// A secondary instance starts read-only and asks whether to steal the lock
+	secondary := instLock != nil && !instLock.IsFirstInstance()
+	treeModel.readOnly = secondary
+	initialLockPrompt := lockPromptHidden
+	if secondary {
+		initialLockPrompt = lockPromptOffer
+	}
 
-	return Model{
+	m := Model{
 		issues:                 issues,
 		issueMap:               issueMap,
 		analyzer:               analyzer,
 		analysis:               graphStats,
 		beadsPath:              beadsPath,
+		pinned:                 pinned,
+		pinsPath:               pinsPath,
+		focus:                  newFocusTimer(beadsPath),
+		localNotes:             localNotes,
+		notesDir:               notesDir,
+		uiStatePath:            uiStatePath(stateDir),
 		watcher:                fileWatcher,
 		snapshotInitPending:    backgroundWorker != nil,
 		backgroundWorker:       backgroundWorker,
 		instanceLock:           instLock,
+		instanceReg:            instReg,
+		cacheSignalWatcher:     cacheSignalWatcher,
+		dataFile:               dataFile,
+		lockPrompt:             initialLockPrompt,
+		readOnly:               secondary,
 		list:                   l,
 		viewport:               vp,
 		renderer:               renderer,
@@ -1045,6 +1147,7 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		triageScores:        triageScores,
 		triageReasons:       triageReasons,
 		unblocksMap:         unblocksMap,
+		depShapes:           analysis.ComputeDependencyShapes(issues),
 		quickWinSet:         quickWinSet,
 		blockerSet:          blockerSet,
 		recipeLoader:        recipeLoader,
@@ -1075,7 +1178,13 @@ func NewModel(issues []model.Issue, activeRecipe *recipe.Recipe, beadsPath strin
 		}(),
 		// Tutorial integration (bv-8y31)
 		tutorialModel: NewTutorialModel(theme),
+		scheduler:     newTaskScheduler(),
 	}
+	m.restoreUIState()
+	m.settleClaims()
+	m.focus.ticking = len(m.focus.claims) > 0 // Init starts the tick
+	recordRecentProject(beadsPath)
+	return m
 }
 
 func (m Model) Init() tea.Cmd {
@@ -1085,6 +1194,8 @@ func (m Model) Init() tea.Cmd {
 	cmds := []tea.Cmd{
 		CheckUpdateCmd(),
 		WaitForPhase2Cmd(m.analysis),
+		Phase2ProgressCmd(m.analysis),
+		WaitForSchedulerMsgCmd(m.scheduler),
 	}
 	if m.backgroundWorker != nil {
 		cmds = append(cmds, StartBackgroundWorkerCmd(m.backgroundWorker))
@@ -1093,9 +1204,20 @@ func (m Model) Init() tea.Cmd {
 	} else if m.watcher != nil {
 		cmds = append(cmds, WatchFileCmd(m.watcher))
 	}
+	if m.cacheSignalWatcher != nil {
+		cmds = append(cmds, WatchCacheSignalCmd(m.cacheSignalWatcher))
+	}
+	if m.instanceLock != nil {
+		cmds = append(cmds, lockCheckTickCmd())
+	}
+	if m.focus.ticking {
+		cmds = append(cmds, focusTickCmd())
+	}
+	cmds = append(cmds, m.terminalTitleCmd())
 	// Start loading history in background
 	if len(m.issues) > 0 {
 		cmds = append(cmds, LoadHistoryCmd(m.issuesForAsync(), m.beadsPath))
+		cmds = append(cmds, m.loadCodeRefsCmd())
 	}
 	// Check for AGENTS.md integration prompt (bv-i8dk)
 	if m.workDir != "" && !m.workspaceMode {
@@ -1120,6 +1242,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.updateAvailable = true
 		m.updateTag = msg.TagName
 		m.updateURL = msg.URL
+		m.updateChannel = msg.Channel
 
 	case UpdateCompleteMsg:
 		// Forward to the update modal
@@ -1128,6 +1251,17 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, cmd)
 		}
 
+	case StaleSweepDoneMsg:
+		m.statusMsg, m.statusIsError = staleSweepStatus(msg)
+		m.staleSweep.Remove(msg.DoneIDs)
+
+	case projectHealthMsg:
+		m.projectSwitcher.SetHealth(msg.health)
+		return m, nil
+
+	case projectLoadedMsg:
+		return m.switchProject(msg)
+
 	case UpdateProgressMsg:
 		// Forward to the update modal
 		if m.showUpdateModal {
@@ -1249,6 +1383,19 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+	case listFilterDebounceMsg:
+		return m, m.handleListFilterDebounce(msg)
+
+	case vimPrefixTimeoutMsg:
+		return m.handleVimPrefixTimeout(msg)
+
+	case phase2ProgressMsg:
+		return m, m.handlePhase2Progress(msg)
+
+	case schedulerResultMsg:
+		m.handleSchedulerResult(msg.msg)
+		return m, WaitForSchedulerMsgCmd(m.scheduler)
+
 	case Phase2ReadyMsg:
 		// Ignore stale Phase2 completions (from before a file reload)
 		if msg.Stats != m.analysis {
@@ -1298,16 +1445,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Refresh alerts now that full Phase 2 metrics (cycles, etc.) are available
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
+		cmds = append(cmds, m.alertsRefreshedCmd())
 
 		// Invalidate label health cache since we have new graph metrics (criticality)
 		m.labelHealthCached = false
+		m.insightsPanel.SetPhase2Progress(msg.Stats.Phase2Progress())
 		if m.focused == focusLabelDashboard {
-			cfg := analysis.DefaultLabelHealthConfig()
-			m.labelHealthCache = analysis.ComputeAllLabelHealth(m.issues, cfg, time.Now().UTC(), m.analysis)
-			m.labelHealthCached = true
+			m.ensureLabelHealth()
 			m.labelDashboard.SetData(m.labelHealthCache.Labels)
 			m.statusMsg = fmt.Sprintf("Labels: %d total • critical %d • warning %d", m.labelHealthCache.TotalLabels, m.labelHealthCache.CriticalCount, m.labelHealthCache.WarningCount)
 		}
+		// Compute the remaining label views while the user isn't looking
+		m.schedulePrecompute()
 
 		// Re-sort issues if sorting by Phase 2 metrics (impact/pagerank)
 		if m.activeRecipe != nil {
@@ -1384,6 +1533,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case healthHistoryMsg:
+		m = m.handleHealthHistory(msg)
+		return m, nil
+
 	case HistoryLoadedMsg:
 		// Background history loading completed
 		m.historyLoading = false
@@ -1470,6 +1623,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Update legacy fields for backwards compatibility during migration
 		// Eventually these will be removed when all code reads from snapshot
 		m.issues = msg.Snapshot.Issues
+		m.settleClaims()
+		m.dataFile = msg.Snapshot.DataFile
 		m.issueMap = msg.Snapshot.IssueMap
 		m.analyzer = msg.Snapshot.Analyzer
 		m.analysis = msg.Snapshot.Analysis
@@ -1480,6 +1635,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.triageScores = msg.Snapshot.TriageScores
 		m.triageReasons = msg.Snapshot.TriageReasons
 		m.unblocksMap = msg.Snapshot.UnblocksMap
+		m.depShapes = msg.Snapshot.DepShapes
 		m.quickWinSet = msg.Snapshot.QuickWinSet
 		m.blockerSet = msg.Snapshot.BlockerSet
 
@@ -1493,6 +1649,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
 		m.dismissedAlerts = make(map[string]bool)
 		m.showAlertsPanel = false
+		m.alertsWatching = true
+		cmds = append(cmds, m.alertsRefreshedCmd())
+		cmds = append(cmds, m.loadCodeRefsCmd())
 
 		// Reset semantic caches for the new dataset.
 		if m.semanticSearch != nil {
@@ -1537,6 +1696,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					filteredIssues = append(filteredIssues, issue)
 				}
 
+				pinToTop(filteredItems, m.pinned)
 				m.list.SetItems(filteredItems)
 				m.updateSemanticIDs(filteredItems)
 				m.board.SetIssues(filteredIssues)
@@ -1562,6 +1722,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			filteredItems = make([]list.Item, 0, len(msg.Snapshot.ListItems))
 			filteredIssues = make([]model.Issue, 0, len(msg.Snapshot.ListItems))
+			frontier := m.frontierSet()
 
 			for _, item := range msg.Snapshot.ListItems {
 				issue := item.Issue
@@ -1582,6 +1743,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					include = !isClosedLikeStatus(issue.Status)
 				case "closed":
 					include = isClosedLikeStatus(issue.Status)
+				case "frontier":
+					include = frontier[issue.ID]
 				case "ready":
 					// Ready = Open/InProgress AND NO Open Blockers
 					if !isClosedLikeStatus(issue.Status) && issue.Status != model.StatusBlocked {
@@ -1606,6 +1769,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 								break
 							}
 						}
+					} else if owner, ok := strings.CutPrefix(m.currentFilter, "owner:"); ok {
+						include = codeowners.Match(m.issueOwners(issue), owner)
 					}
 				}
 
@@ -1616,6 +1781,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 
 			m.sortFilteredItems(filteredItems, filteredIssues)
+			pinToTop(filteredItems, m.pinned)
 			m.list.SetItems(filteredItems)
 			m.updateSemanticIDs(filteredItems)
 			if m.snapshot != nil && m.snapshot.BoardState != nil && (!m.workspaceMode || m.activeRepos == nil) && len(filteredIssues) == len(m.snapshot.Issues) {
@@ -1676,7 +1842,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Keep semantic index current when enabled.
 		if m.semanticSearchEnabled && !m.semanticIndexBuilding {
 			m.semanticIndexBuilding = true
-			cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+			cmds = append(cmds, buildSemanticIndexCmd(m.issuesForAsync(), !m.readOnly))
 		}
 
 		// Reload sprints (bv-161)
@@ -1720,6 +1886,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Wait for Phase 2 if not ready
 		if msg.Snapshot.Analysis != nil {
 			cmds = append(cmds, WaitForPhase2Cmd(msg.Snapshot.Analysis))
+			cmds = append(cmds, Phase2ProgressCmd(msg.Snapshot.Analysis))
 		}
 
 		if m.backgroundWorker != nil {
@@ -1747,6 +1914,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, tea.Batch(cmds...)
 
+	case CacheSignalMsg:
+		return m.handleCacheSignal()
+
+	case cacheSignalReloadMsg:
+		return m.handleCacheSignalReload(msg)
+
+	case lockCheckTickMsg:
+		return m.handleLockCheck()
+
+	case focusTickMsg:
+		return m.handleFocusTick()
+
+	case claimDoneMsg:
+		return m.handleClaimDone(msg)
+	case codeRefsMsg:
+		return m.handleCodeRefs(msg)
+
 	case FileChangedMsg:
 		// File changed on disk - reload issues and recompute analysis
 		// In background mode the BackgroundWorker owns file watching and snapshot building.
@@ -1780,6 +1964,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Reload issues from disk
 		// Use custom warning handler to prevent stderr pollution during TUI render (bv-fix)
 		var reloadWarnings []string
+		dataFile, _ := instance.StatFile(m.beadsPath)
 		newIssues, err := loader.LoadIssuesFromFileWithOptions(m.beadsPath, loader.ParseOptions{
 			WarningHandler: func(msg string) {
 				reloadWarnings = append(reloadWarnings, msg)
@@ -1818,7 +2003,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 		// Recompute analysis (async Phase 1/Phase 2) with caching
 		m.issues = newIssues
+		m.settleClaims()
+		m.dataFile = dataFile
+		m.depShapes = analysis.ComputeDependencyShapes(newIssues)
 		cachedAnalyzer := analysis.NewCachedAnalyzer(newIssues, nil)
+		applyProjectRules(cachedAnalyzer.Analyzer)
 		m.analyzer = cachedAnalyzer.Analyzer
 		m.analysis = cachedAnalyzer.AnalyzeAsync(context.Background())
 		cacheHit := cachedAnalyzer.WasCacheHit()
@@ -1866,6 +2055,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.alerts, m.alertsCritical, m.alertsWarning, m.alertsInfo = computeAlerts(m.issues, m.analysis, m.analyzer)
 		m.dismissedAlerts = make(map[string]bool)
 		m.showAlertsPanel = false
+		m.alertsWatching = true
+		cmds = append(cmds, m.alertsRefreshedCmd())
+		cmds = append(cmds, m.loadCodeRefsCmd())
 
 		// Rebuild list items
 		items := make([]list.Item, len(m.issues))
@@ -1889,6 +2081,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.semanticHybridBuilding = true
 			cmds = append(cmds, BuildHybridMetricsCmd(m.issuesForAsync()))
 		}
+		pinToTop(items, m.pinned)
 		m.list.SetItems(items)
 
 		// Restore selection position
@@ -1909,6 +2102,10 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			bodyHeight = 5
 		}
 		m.insightsPanel.SetSize(m.width, bodyHeight)
+		if m.focused == focusInsights {
+			m.insightsPanel.SetHealth(analysis.ComputeHealthScore(m.issues), m.healthHistory)
+			m.insightsPanel.SetBlockedTime(m.blockedTime)
+		}
 		m.graphView.SetIssues(m.issues, &ins)
 
 		// Generate priority recommendations now that Phase 2 is ready
@@ -1946,7 +2143,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Keep semantic index current when enabled.
 		if m.semanticSearchEnabled && !m.semanticIndexBuilding {
 			m.semanticIndexBuilding = true
-			cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+			cmds = append(cmds, buildSemanticIndexCmd(m.issuesForAsync(), !m.readOnly))
 		}
 
 		if cacheHit {
@@ -1968,6 +2165,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			cmds = append(cmds, WatchFileCmd(m.watcher))
 		}
 		cmds = append(cmds, WaitForPhase2Cmd(m.analysis))
+		cmds = append(cmds, Phase2ProgressCmd(m.analysis))
 		return m, tea.Batch(cmds...)
 
 	case tea.KeyMsg:
@@ -2152,6 +2350,27 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Handle team workload view
+		if m.showTeamView {
+			switch msg.String() {
+			case "esc", "q", "W":
+				m.showTeamView = false
+				m.insightsPanel.extraText = ""
+				return m, nil
+			}
+		}
+
+		// Handle label taxonomy panel; closing returns to the label dashboard
+		if m.showLabelTaxonomy {
+			switch msg.String() {
+			case "esc", "q", "t":
+				m.showLabelTaxonomy = false
+				m.insightsPanel.extraText = ""
+				m.focused = focusLabelDashboard
+				return m, nil
+			}
+		}
+
 		// Handle alerts panel modal if open (bv-168)
 		if m.showAlertsPanel {
 			// Build list of active (non-dismissed) alerts
@@ -2229,6 +2448,30 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, nil
 		}
 
+		// Handle stale sweep overlay before global keys (esc/q/etc.)
+		if m.showStaleSweep {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleStaleSweepKeys(msg)
+		}
+
+		// Handle notes editor before global keys: every key is text
+		if m.showNotesEditor {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleNotesEditorKeys(msg)
+		}
+
+		// Handle project switcher overlay before global keys (esc/q/etc.)
+		if m.showProjectSwitcher {
+			if msg.String() == "ctrl+c" {
+				return m, tea.Quit
+			}
+			return m.handleProjectSwitcherKeys(msg)
+		}
+
 		// Handle recipe picker overlay before global keys (esc/q/etc.)
 		if m.showRecipePicker {
 			if msg.String() == "ctrl+c" {
@@ -2250,6 +2493,37 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		}
 
+		// Secondary-instance prompt: read-only or steal the lock
+		if m.lockPrompt != lockPromptHidden {
+			return m.handleLockPromptKeys(msg)
+		}
+
+		// Help search box captures every key (including ? and `) while typing
+		if m.focused == focusHelp && m.helpSearching {
+			m = m.handleHelpKeys(msg)
+			return m, nil
+		}
+
+		// Keys remapped in config.yaml become the default key of their
+		// action, so the handlers below only ever see default keys
+		if ctx, ok := m.keyRemapContext(); ok && !m.vim.replaying {
+			key, live := remapKey(ctx, msg.String())
+			if !live {
+				return m, nil
+			}
+			if key != msg.String() {
+				msg, _ = parseKey(key)
+			}
+		}
+
+		// Vim-style counts, gg and marks in the list, board and tree
+		if m.vimKeysLive() {
+			var handled bool
+			if m, cmd, handled = m.handleVimKey(msg); handled {
+				return m, cmd
+			}
+		}
+
 		// Handle help overlay toggle (? or F1)
 		if (msg.String() == "?" || msg.String() == "f1") && m.list.FilterState() != list.Filtering {
 			m.showHelp = !m.showHelp
@@ -2257,6 +2531,8 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.focusBeforeHelp = m.focused // Store current focus before switching to help
 				m.focused = focusHelp
 				m.helpScroll = 0 // Reset scroll position when opening help
+				m.helpQuery = ""
+				m.helpSearching = false
 			} else {
 				m.focused = m.restoreFocusFromHelp()
 			}
@@ -2303,6 +2579,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			return m, tea.Batch(cmds...)
 		}
 
+		// Relative/absolute timestamp toggle (Ctrl+T)
+		if msg.String() == "ctrl+t" && m.list.FilterState() != list.Filtering {
+			o := timefmt.Toggle()
+			m.statusMsg = fmt.Sprintf("Timestamps: %s", o.Style)
+			if o.Style == timefmt.StyleAbsolute && o.Zone() != "local" {
+				m.statusMsg += " (" + o.Zone() + ")"
+			}
+			m.statusIsError = false
+			m.updateViewportContent()
+			return m, nil
+		}
+
+		// Recent projects (Ctrl+O)
+		if msg.String() == "ctrl+o" && m.list.FilterState() != list.Filtering {
+			return m.openProjectSwitcher()
+		}
+
 		// Handle shortcuts sidebar toggle (; or F2) - bv-3qi5
 		if (msg.String() == ";" || msg.String() == "f2") && m.list.FilterState() != list.Filtering {
 			m.showShortcutsSidebar = !m.showShortcutsSidebar
@@ -2394,7 +2687,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 					if !m.semanticSearch.Snapshot().Ready && !m.semanticIndexBuilding {
 						m.semanticIndexBuilding = true
 						m.statusMsg = "Semantic search: building index…"
-						cmds = append(cmds, BuildSemanticIndexCmd(m.issuesForAsync()))
+						cmds = append(cmds, buildSemanticIndexCmd(m.issuesForAsync(), !m.readOnly))
 					} else if !m.semanticSearch.Snapshot().Ready && m.semanticIndexBuilding {
 						m.statusMsg = "Semantic search: indexing…"
 					} else {
@@ -2660,11 +2953,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						// Set full recommendations with breakdown for priority radar (bv-93)
 						dataHash := fmt.Sprintf("v%s@%s#%d", triage.Meta.Version, triage.Meta.GeneratedAt.Format("15:04:05"), triage.Meta.IssueCount)
 						m.insightsPanel.SetRecommendations(triage.Recommendations, dataHash)
+						if m.analysis != nil {
+							m.insightsPanel.SetPhase2Progress(m.analysis.Phase2Progress())
+						}
 						panelHeight := m.height - 2
 						if panelHeight < 3 {
 							panelHeight = 3
 						}
 						m.insightsPanel.SetSize(m.width, panelHeight)
+						return m, m.insightsHealthCmd()
 					}
 				}
 				return m, nil
@@ -2715,12 +3012,9 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.isActionableView = false
 				m.isHistoryView = false
 				m.focused = focusLabelDashboard
-				// Compute label health (fast; phase1 metrics only needed) with caching
-				if !m.labelHealthCached {
-					cfg := analysis.DefaultLabelHealthConfig()
-					m.labelHealthCache = analysis.ComputeAllLabelHealth(m.issues, cfg, time.Now().UTC(), m.analysis)
-					m.labelHealthCached = true
-				}
+				// Compute label health (fast; phase1 metrics only needed) unless
+				// the background pre-computation already has
+				m.ensureLabelHealth()
 				m.labelDashboard.SetData(m.labelHealthCache.Labels)
 				m.labelDashboard.SetSize(m.width, m.height-1)
 				m.statusMsg = fmt.Sprintf("Labels: %d total • critical %d • warning %d", m.labelHealthCache.TotalLabels, m.labelHealthCache.CriticalCount, m.labelHealthCache.WarningCount)
@@ -2729,11 +3023,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 			case "]", "f4":
 				// Attention view: compute attention scores (cached) and render as text
-				if !m.attentionCached {
-					cfg := analysis.DefaultLabelHealthConfig()
-					m.attentionCache = analysis.ComputeLabelAttentionScores(m.issues, cfg, time.Now().UTC())
-					m.attentionCached = true
-				}
+				m.ensureAttention()
 				attText, _ := ComputeAttentionView(m.issues, max(40, m.width-4))
 				m.isGraphView = false
 				m.isBoardView = false
@@ -2741,6 +3031,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.isHistoryView = false
 				m.focused = focusInsights
 				m.showAttentionView = true
+				m.showTeamView = false
 				m.insightsPanel = NewInsightsModel(analysis.Insights{}, m.issueMap, m.theme)
 				m.insightsPanel.labelAttention = m.attentionCache.Labels
 				m.insightsPanel.extraText = attText
@@ -2751,6 +3042,31 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.insightsPanel.SetSize(m.width, panelHeight)
 				return m, nil
 
+			case "W":
+				// Team workload view: per-assignee load and suggested reassignments
+				m.clearAttentionOverlay()
+				m.isGraphView = false
+				m.isBoardView = false
+				m.isActionableView = false
+				m.isHistoryView = false
+				m.focused = focusInsights
+				m.showTeamView = true
+				m.insightsPanel = NewInsightsModel(analysis.Insights{}, m.issueMap, m.theme)
+				m.insightsPanel.extraText = ComputeTeamView(m.issues, max(40, m.width-4))
+				panelHeight := m.height - 2
+				if panelHeight < 3 {
+					panelHeight = 3
+				}
+				m.insightsPanel.SetSize(m.width, panelHeight)
+				return m, nil
+
+			case "Z":
+				// Stale sweep: guided cleanup of issues nobody has touched
+				m.staleSweep = NewStaleSweepModel(analysis.ComputeStaleSweep(m.issues, time.Now(), analysis.DefaultStaleSweepDays), m.theme)
+				m.staleSweep.SetSize(m.width, m.height-1)
+				m.showStaleSweep = true
+				return m, nil
+
 			case "f":
 				// Flow matrix view (cross-label dependencies)
 				m.clearAttentionOverlay()
@@ -2822,6 +3138,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.exportToMarkdown()
 				return m, nil
 
+			case "n":
+				// Edit the selected issue's local notes
+				if m.focused == focusList || m.focused == focusDetail {
+					m.openNotesEditor(m.selectedListIssue())
+					return m, nil
+				}
+
+			case "M":
+				// Claim / unclaim the selected issue: the footer times the work
+				switch m.focused {
+				case focusList, focusDetail:
+					return m, m.toggleClaim(m.selectedListIssue())
+				case focusBoard:
+					if !m.board.IsSearchMode() {
+						return m, m.toggleClaim(m.board.SelectedIssue())
+					}
+				}
+
 			case "l":
 				// Open label picker for quick filter (bv-126)
 				if len(m.issues) == 0 {
@@ -2831,12 +3165,18 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				labelExtraction := analysis.ExtractLabels(m.issues)
 				labelCounts := extractLabelCounts(labelExtraction.Stats)
 				m.labelPicker.SetLabels(labelExtraction.Labels, labelCounts)
+				m.labelPicker.SetTitle("Filter by Label")
 				m.labelPicker.Reset()
 				m.labelPicker.SetSize(m.width, m.height-1)
 				m.showLabelPicker = true
+				m.pickingOwner = false
 				m.focused = focusLabelPicker
 				return m, nil
 
+			case "@":
+				// Owner picker: CODEOWNERS owners of the issues' likely files
+				return m.openOwnerPicker(), nil
+
 			}
 
 			// Focus-specific key handling
@@ -2876,6 +3216,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 						return m, nil
 					}
 				}
+				// Label taxonomy panel on 't': variants, slow labels, clusters
+				if msg.String() == "t" {
+					m.focused = focusInsights
+					m.showLabelTaxonomy = true
+					m.insightsPanel = NewInsightsModel(analysis.Insights{}, m.issueMap, m.theme)
+					m.insightsPanel.extraText = ComputeLabelTaxonomyView(m.issues, max(40, m.width-4))
+					m.insightsPanel.SetSize(m.width, max(3, m.height-2))
+					return m, nil
+				}
 				// Open drilldown overlay on 'd'
 				if msg.String() == "d" && len(m.labelDashboard.labels) > 0 {
 					idx := m.labelDashboard.cursor
@@ -2910,8 +3259,23 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m = m.handleListKeys(msg)
 
 			case focusDetail:
-				m.viewport, cmd = m.viewport.Update(msg)
-				cmds = append(cmds, cmd)
+				switch msg.String() {
+				case "e":
+					m.openIssueReference(referenceFile)
+				case "B":
+					m.openIssueReference(referenceURL)
+				case "y":
+					m.copySelectedIssueID()
+				case "Y":
+					m.copySelectedBdCommand()
+				case "*":
+					if issue := m.selectedListIssue(); issue != nil {
+						m.togglePin(issue.ID)
+					}
+				default:
+					m.viewport, cmd = m.viewport.Update(msg)
+					cmds = append(cmds, cmd)
+				}
 			}
 		}
 
@@ -3036,8 +3400,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	// This prevents j/k keys in detail view from changing list selection
 	if m.focused == focusList {
 		if _, isWindowSize := msg.(tea.WindowSizeMsg); !isWindowSize {
-			m.list, cmd = m.list.Update(msg)
-			cmds = append(cmds, cmd)
+			cmds = append(cmds, m.updateList(msg))
 		}
 		currentTerm := m.list.FilterInput.Value()
 		if currentTerm != m.lastSearchTerm {
@@ -3056,7 +3419,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	// Update viewport if list selection changed in split view
 	if m.isSplitView && m.focused == focusList {
-		m.updateViewportContent()
+		m.refreshDetailIfChanged()
 	}
 
 	// Trigger async semantic computation if needed (debounced)
@@ -3154,6 +3517,10 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 		m.board.JumpToFirstColumn()
 	case "L":
 		m.board.JumpToLastColumn()
+	case "<":
+		m.board.MoveFocusedColumn(-1)
+	case ">":
+		m.board.MoveFocusedColumn(1)
 
 	// Vim-style navigation (bv-yg39)
 	case "g":
@@ -3180,13 +3547,11 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 	// Copy ID to clipboard (bv-yg39)
 	case "y":
 		if selected := m.board.SelectedIssue(); selected != nil {
-			if err := clipboard.WriteAll(selected.ID); err != nil {
-				m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-				m.statusIsError = true
-			} else {
-				m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", selected.ID)
-				m.statusIsError = false
-			}
+			m.copyWithStatus(selected.ID, selected.ID)
+		}
+	case "*":
+		if selected := m.board.SelectedIssue(); selected != nil {
+			m.togglePin(selected.ID)
 		}
 
 	// Global filter keys (bv-naov) - consistent with list view
@@ -3205,6 +3570,11 @@ func (m Model) handleBoardKeys(msg tea.KeyMsg) Model {
 		m.applyFilter()
 		m.statusMsg = "Filter: Ready (no blockers)"
 		m.statusIsError = false
+	case "R":
+		m.currentFilter = "frontier"
+		m.applyFilter()
+		m.statusMsg = frontierStatus(computeFrontier(m.issues))
+		m.statusIsError = false
 
 	// Swimlane mode cycling (bv-wjs0)
 	case "s":
@@ -3290,6 +3660,13 @@ func (m Model) handleGraphKeys(msg tea.KeyMsg) Model {
 		m.graphView.ScrollLeft()
 	case "L":
 		m.graphView.ScrollRight()
+	case "c":
+		if m.graphView.ToggleCondensed() {
+			m.statusMsg = "Graph: each dependency cycle collapsed into one node"
+		} else {
+			m.statusMsg = "Graph: cycles expanded"
+		}
+		m.statusIsError = false
 	case "enter":
 		if selected := m.graphView.SelectedIssue(); selected != nil {
 			// Find and select in list
@@ -3565,13 +3942,7 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			}
 		}
 		if sha != "" {
-			if err := clipboard.WriteAll(sha); err != nil {
-				m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-				m.statusIsError = true
-			} else {
-				m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", shortSHA)
-				m.statusIsError = false
-			}
+			m.copyWithStatus(sha, shortSHA)
 		} else {
 			m.statusMsg = "❌ No commit selected"
 			m.statusIsError = true
@@ -3658,6 +4029,29 @@ func (m Model) handleHistoryKeys(msg tea.KeyMsg) Model {
 			m.statusMsg = "❌ No bead selected"
 			m.statusIsError = true
 		}
+	case "t":
+		// Time-travel to the selected commit: compare the current issues
+		// against the beads file as it was at that commit.
+		var sha string
+		if m.historyView.IsGitMode() {
+			if commit := m.historyView.SelectedGitCommit(); commit != nil {
+				sha = commit.SHA
+			}
+		} else {
+			if commit := m.historyView.SelectedCommit(); commit != nil {
+				sha = commit.SHA
+			}
+		}
+		if sha == "" {
+			m.statusMsg = "❌ No commit selected"
+			m.statusIsError = true
+			return m
+		}
+		m.enterTimeTravelMode(sha)
+		if m.timeTravelMode {
+			m.isHistoryView = false
+			m.focused = focusList
+		}
 	case "h", "esc":
 		// Exit history view
 		m.isHistoryView = false
@@ -3845,6 +4239,53 @@ func (m Model) handleRepoPickerKeys(msg tea.KeyMsg) Model {
 	return m
 }
 
+// handleStaleSweepKeys handles keyboard input in the stale sweep overlay.
+// Batch actions ask y/N first, then run in the background; the file
+// watcher picks up bd's changes.
+func (m Model) handleStaleSweepKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	if m.staleSweep.Confirming() {
+		action, targets, ok := m.staleSweep.Resolve(msg.String() == "y" || msg.String() == "Y")
+		if !ok {
+			m.statusMsg = "Stale sweep: cancelled"
+			m.statusIsError = false
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Stale sweep: working on %d issue(s)...", len(targets))
+		m.statusIsError = false
+		return m, staleSweepCmd(targets, action, repoRootFromBeadsPath(m.beadsPath), m.bdRunner)
+	}
+
+	action := ""
+	switch msg.String() {
+	case "j", "down":
+		m.staleSweep.MoveDown()
+		return m, nil
+	case "k", "up":
+		m.staleSweep.MoveUp()
+		return m, nil
+	case " ", "space":
+		m.staleSweep.ToggleMarked()
+		return m, nil
+	case "a":
+		m.staleSweep.ToggleAll()
+		return m, nil
+	case "esc", "q", "Z":
+		m.showStaleSweep = false
+		return m, nil
+	case "enter":
+	case "x":
+		action = analysis.StaleActionClose
+	case "d":
+		action = analysis.StaleActionDeprioritize
+	case "p":
+		action = analysis.StaleActionPing
+	default:
+		return m, nil
+	}
+	m.staleSweep.Confirm(action)
+	return m, nil
+}
+
 // handleLabelPickerKeys handles keyboard input when label picker is focused (bv-126)
 func (m Model) handleLabelPickerKeys(msg tea.KeyMsg) Model {
 	switch msg.String() {
@@ -3856,7 +4297,12 @@ func (m Model) handleLabelPickerKeys(msg tea.KeyMsg) Model {
 	case "k", "up", "ctrl+p":
 		m.labelPicker.MoveUp()
 	case "enter":
-		if selected := m.labelPicker.SelectedLabel(); selected != "" {
+		if selected := m.labelPicker.SelectedLabel(); selected != "" && m.pickingOwner {
+			m.currentFilter = "owner:" + selected
+			m.applyFilter()
+			m.statusMsg = fmt.Sprintf("Filtered by owner: %s", selected)
+			m.statusIsError = false
+		} else if selected != "" {
 			m.currentFilter = "label:" + selected
 			m.applyFilter()
 			m.statusMsg = fmt.Sprintf("Filtered by label: %s", selected)
@@ -3969,6 +4415,11 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "r":
 		m.currentFilter = "ready"
 		m.applyFilter()
+	case "R":
+		m.currentFilter = "frontier"
+		m.applyFilter()
+		m.statusMsg = frontierStatus(computeFrontier(m.issues))
+		m.statusIsError = false
 	case "a":
 		m.currentFilter = "all"
 		m.applyFilter()
@@ -3993,9 +4444,26 @@ func (m Model) handleListKeys(msg tea.KeyMsg) Model {
 	case "C":
 		// Copy selected issue to clipboard
 		m.copyIssueToClipboard()
+	case "y":
+		// Copy selected issue ID
+		m.copySelectedIssueID()
+	case "Y":
+		// Copy the suggested bd command (claim/close/reopen)
+		m.copySelectedBdCommand()
+	case "*":
+		// Star / unstar: starred issues list first
+		if issue := m.selectedListIssue(); issue != nil {
+			m.togglePin(issue.ID)
+		}
 	case "O":
 		// Open beads.jsonl in editor
 		m.openInEditor()
+	case "e":
+		// Open a file path referenced in the issue text
+		m.openIssueReference(referenceFile)
+	case "B":
+		// Open a URL referenced in the issue text
+		m.openIssueReference(referenceURL)
 	case "h":
 		// Toggle history view
 		if !m.isHistoryView {
@@ -4095,7 +4563,33 @@ func (m Model) restoreFocusFromHelp() focus {
 
 // handleHelpKeys handles keyboard input when the help overlay is focused
 func (m Model) handleHelpKeys(msg tea.KeyMsg) Model {
+	if m.helpSearching {
+		switch msg.Type {
+		case tea.KeyCtrlC:
+			m.closeHelp()
+			return m
+		case tea.KeyEsc:
+			m.helpSearching = false
+			m.helpQuery = ""
+		case tea.KeyEnter:
+			m.helpSearching = false
+		case tea.KeyBackspace:
+			if r := []rune(m.helpQuery); len(r) > 0 {
+				m.helpQuery = string(r[:len(r)-1])
+			}
+		case tea.KeySpace:
+			m.helpQuery += " "
+		case tea.KeyRunes:
+			m.helpQuery += string(msg.Runes)
+		}
+		m.helpScroll = 0
+		return m
+	}
+
 	switch msg.String() {
+	case "/":
+		m.helpSearching = true
+		m.helpScroll = 0
 	case "j", "down":
 		m.helpScroll++
 	case "k", "up":
@@ -4114,26 +4608,37 @@ func (m Model) handleHelpKeys(msg tea.KeyMsg) Model {
 	case "G", "end":
 		// Will be clamped in render
 		m.helpScroll = 999
-	case "q", "esc", "?", "f1":
-		// Close help overlay and restore previous focus
-		m.showHelp = false
-		m.helpScroll = 0
-		m.focused = m.restoreFocusFromHelp()
+	case "esc":
+		// First Esc clears an active search, second closes help
+		if m.helpQuery != "" {
+			m.helpQuery = ""
+			m.helpScroll = 0
+			return m
+		}
+		m.closeHelp()
+	case "q", "?", "f1":
+		m.closeHelp()
 	case " ": // Space opens interactive tutorial (bv-0trk, bv-8y31)
-		m.showHelp = false
-		m.helpScroll = 0
+		m.closeHelp()
 		m.showTutorial = true
 		m.tutorialModel.SetSize(m.width, m.height)
 		m.focused = focusTutorial
 	default:
 		// Any other key dismisses help and restores previous focus
-		m.showHelp = false
-		m.helpScroll = 0
-		m.focused = m.restoreFocusFromHelp()
+		m.closeHelp()
 	}
 	return m
 }
 
+// closeHelp hides the help overlay, resets its state, and restores focus.
+func (m *Model) closeHelp() {
+	m.showHelp = false
+	m.helpScroll = 0
+	m.helpQuery = ""
+	m.helpSearching = false
+	m.focused = m.restoreFocusFromHelp()
+}
+
 func (m Model) renderLoadingScreen() string {
 	frame := workerSpinnerFrames[0]
 	if m.backgroundWorker != nil && m.backgroundWorker.State() == WorkerProcessing {
@@ -4167,6 +4672,8 @@ func (m Model) View() string {
 	// Quit confirmation overlay takes highest priority
 	if m.showQuitConfirm {
 		body = m.renderQuitConfirm()
+	} else if m.lockPrompt != lockPromptHidden {
+		body = m.renderLockPrompt()
 	} else if m.showAgentPrompt {
 		// AGENTS.md prompt modal (bv-i8dk)
 		body = m.agentPromptModal.CenterModal(m.width, m.height-1)
@@ -4190,6 +4697,12 @@ func (m Model) View() string {
 		body = m.recipePicker.View()
 	} else if m.showRepoPicker {
 		body = m.repoPicker.View()
+	} else if m.showStaleSweep {
+		body = m.staleSweep.View()
+	} else if m.showProjectSwitcher {
+		body = m.projectSwitcher.View()
+	} else if m.showNotesEditor {
+		body = m.notesEditor.View()
 	} else if m.showLabelPicker {
 		body = m.labelPicker.View()
 	} else if m.showHelp {
@@ -4276,9 +4789,9 @@ func (m Model) renderQuitConfirm() string {
 		Foreground(t.Primary).
 		Bold(true)
 
-	content := titleStyle.Render("Quit bv?") + "\n\n" +
-		textStyle.Render("Press ") + keyStyle.Render("Esc") + textStyle.Render(" or ") + keyStyle.Render("Y") + textStyle.Render(" to quit\n") +
-		textStyle.Render("Press any other key to cancel")
+	content := titleStyle.Render(i18n.T("quit.title")) + "\n\n" +
+		styledMessage(i18n.T("quit.confirm"), textStyle, keyStyle, "Esc", "Y") + "\n" +
+		textStyle.Render(i18n.T("quit.cancel"))
 
 	box := boxStyle.Render(content)
 
@@ -4525,108 +5038,21 @@ func (m *Model) renderHelpOverlay() string {
 		return panelStyle.Render(content.String())
 	}
 
-	// Define all sections
-	navSection := []struct{ key, desc string }{
-		{"j / ↓", "Move down"},
-		{"k / ↑", "Move up"},
-		{"G/end", "Go to last"},
-		{"Ctrl+d", "Page down"},
-		{"Ctrl+u", "Page up"},
-		{"Tab", "Switch focus"},
-		{"Enter", "View details"},
-		{"Esc", "Back / close"},
-	}
-
-	viewsSection := []struct{ key, desc string }{
-		{"b", "Kanban board"},
-		{"g", "Graph view"},
-		{"i", "Insights"},
-		{"h", "History view"},
-		{"a", "Actionable"},
-		{"f", "Flow matrix"},
-		{"[", "Label dashboard"},
-		{"]", "Attention view"},
-	}
-
-	globalSection := []struct{ key, desc string }{
-		{"?", "This help"},
-		{";", "Shortcuts bar"},
-		{"!", "Alerts panel"},
-		{"'", "Recipes"},
-		{"w", "Repo picker"},
-		{"q", "Back / Quit"},
-		{"Ctrl+c", "Force quit"},
-	}
-
-	filterSection := []struct{ key, desc string }{
-		{"/", "Fuzzy search"},
-		{"Ctrl+S", "Semantic search"},
-		{"H", "Hybrid ranking"},
-		{"Alt+H", "Hybrid preset"},
-		{"o", "Open issues"},
-		{"c", "Closed issues"},
-		{"r", "Ready (unblocked)"},
-		{"l", "Filter by label"},
-		{"s", "Cycle sort"},
-		{"S", "Triage sort"},
-	}
-
-	graphSection := []struct{ key, desc string }{
-		{"hjkl", "Navigate nodes"},
-		{"H/L", "Scroll left/right"},
-		{"PgUp/Dn", "Scroll up/down"},
-		{"Enter", "Jump to issue"},
-	}
-
-	insightsSection := []struct{ key, desc string }{
-		{"h/l/Tab", "Switch panels"},
-		{"j/k", "Navigate items"},
-		{"e", "Explanations"},
-		{"x", "Calc details"},
-		{"m", "Toggle heatmap"},
-		{"Enter", "Jump to issue"},
-	}
-
-	historySection := []struct{ key, desc string }{
-		{"j/k", "Navigate beads"},
-		{"J/K", "Navigate commits"},
-		{"Tab", "Toggle focus"},
-		{"y", "Copy SHA"},
-		{"c", "Confidence filter"},
-	}
-
-	actionsSection := []struct{ key, desc string }{
-		{"p", "Priority hints"},
-		{"Ctrl+R", "Force refresh"},
-		{"F5", "Force refresh"},
-		{"t", "Time-travel"},
-		{"T", "Quick time-travel"},
-		{"x", "Export markdown"},
-		{"C", "Copy to clipboard"},
-		{"O", "Open in editor"},
-	}
-
-	statusSection := []struct{ key, desc string }{
-		{"◌ metrics", "Phase 2 metrics computing"},
-		{"⚠ age", "Snapshot getting stale"},
-		{"⚠ STALE", "Snapshot is stale"},
-		{"✗ bg", "Background worker errors"},
-		{"↻ recov", "Worker self-healed"},
-		{"⚠ dead", "Worker unresponsive"},
-		{"polling", "Live reload uses polling"},
-	}
-
-	// Build panels
-	panels := []string{
-		renderPanel("Navigation", "🧭", 0, navSection),
-		renderPanel("Views", "👁", 1, viewsSection),
-		renderPanel("Global", "🌐", 2, globalSection),
-		renderPanel("Filters & Sort", "🔍", 3, filterSection),
-		renderPanel("Graph View", "📊", 4, graphSection),
-		renderPanel("Insights", "💡", 5, insightsSection),
-		renderPanel("Status", "🩺", 2, statusSection),
-		renderPanel("History", "📜", 0, historySection),
-		renderPanel("Actions", "⚡", 1, actionsSection),
+	// Build panels from the keymap registry, limited to bindings that are
+	// live in the view the help was opened from.
+	ctx := ContextFromFocus(m.focusBeforeHelp)
+	groups := bindingsFor(ctx, m.helpQuery)
+
+	var panels []string
+	for i, g := range groups {
+		shortcuts := make([]struct{ key, desc string }, 0, len(g.Bindings))
+		for _, b := range g.Bindings {
+			shortcuts = append(shortcuts, struct{ key, desc string }{b.Keys, b.Desc})
+		}
+		panels = append(panels, renderPanel(g.Section, keymapSectionIcons[g.Section], i, shortcuts))
+	}
+	if m.helpQuery == "" {
+		panels = append(panels, renderPanel("Status", "🩺", len(panels), statusIndicators))
 	}
 
 	// Arrange panels into columns
@@ -4649,6 +5075,29 @@ func (m *Model) renderHelpOverlay() string {
 
 	// Join columns horizontally
 	body := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	if len(panels) == 0 {
+		body = t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).
+			Render(i18n.T("help.no_match", m.helpQuery))
+	}
+
+	// Scroll the body to fit the terminal: title, search line, border (2)
+	// and padding (2) take 6 rows, plus the footer row.
+	bodyLines := strings.Split(body, "\n")
+	visibleRows := m.height - 1 - 6
+	if visibleRows < 3 {
+		visibleRows = 3
+	}
+	maxScroll := len(bodyLines) - visibleRows
+	if maxScroll < 0 {
+		maxScroll = 0
+	}
+	if m.helpScroll > maxScroll {
+		m.helpScroll = maxScroll
+	}
+	if maxScroll > 0 {
+		bodyLines = bodyLines[m.helpScroll:min(m.helpScroll+visibleRows, len(bodyLines))]
+		body = strings.Join(bodyLines, "\n")
+	}
 
 	// Title bar
 	titleStyle := t.Renderer.NewStyle().
@@ -4660,12 +5109,28 @@ func (m *Model) renderHelpOverlay() string {
 		Foreground(t.Secondary).
 		Italic(true)
 
-	title := titleStyle.Render("⌨️  Keyboard Shortcuts")
-	subtitle := subtitleStyle.Render("Space: Tutorial │ ? or Esc to close")
+	title := titleStyle.Render("⌨️  Keyboard Shortcuts · " + ctx)
+	hint := "/ search │ Space: Tutorial │ ? or Esc to close"
+	if maxScroll > 0 {
+		hint = i18n.T("help.scroll", m.helpScroll*100/maxScroll) + " │ " + hint
+	}
+	subtitle := subtitleStyle.Render(hint)
 	titleBar := lipgloss.JoinHorizontal(lipgloss.Center, title, "  ", subtitle)
 
+	// Search line
+	searchStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+	searchLine := searchStyle.Render("/ to search shortcuts")
+	if m.helpSearching || m.helpQuery != "" {
+		cursor := ""
+		if m.helpSearching {
+			cursor = "█"
+		}
+		searchLine = t.Renderer.NewStyle().Foreground(t.Primary).Bold(true).Render("/ ") +
+			m.helpQuery + cursor
+	}
+
 	// Combine title and body
-	content := lipgloss.JoinVertical(lipgloss.Center, titleBar, "", body)
+	content := lipgloss.JoinVertical(lipgloss.Center, titleBar, searchLine, body)
 
 	// Outer container
 	containerStyle := t.Renderer.NewStyle().
@@ -4806,7 +5271,7 @@ func (m Model) renderLabelHealthDetail(lh analysis.LabelHealth) string {
 		}
 	}
 
-	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Render("Press Esc to close"))
+	sb.WriteString(t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true).Render(i18n.T("overlay.esc_close")))
 
 	content := boxStyle.Render(sb.String())
 
@@ -5142,6 +5607,20 @@ func (m Model) renderLabelGraphAnalysis() string {
 	)
 }
 
+// instancePeersLabel summarizes other bv processes on this repo for the
+// footer, e.g. "⧉ +2 tui,serve".
+func instancePeersLabel(peers []instance.InstanceInfo) string {
+	var modes []string
+	seen := make(map[instance.Mode]bool)
+	for _, p := range peers {
+		if !seen[p.Mode] {
+			seen[p.Mode] = true
+			modes = append(modes, string(p.Mode))
+		}
+	}
+	return fmt.Sprintf("%s +%d %s", glyph("⧉", "[peers]"), len(peers), strings.Join(modes, ","))
+}
+
 func (m *Model) renderFooter() string {
 	// ══════════════════════════════════════════════════════════════════════════
 	// POLISHED FOOTER - Stripe-level status bar with visual hierarchy
@@ -5182,7 +5661,7 @@ func (m *Model) renderFooter() string {
 	var filterTxt string
 	var filterIcon string
 	if m.focused == focusLabelDashboard {
-		filterTxt = "LABELS: j/k nav • h detail • d drilldown • enter filter"
+		filterTxt = "LABELS: j/k nav • h detail • d drilldown • t taxonomy • enter filter"
 		filterIcon = "🏷️"
 	} else if m.showLabelGraphAnalysis && m.labelGraphAnalysisResult != nil {
 		filterTxt = fmt.Sprintf("GRAPH %s: esc/q/g close", m.labelGraphAnalysisResult.Label)
@@ -5204,6 +5683,9 @@ func (m *Model) renderFooter() string {
 		case "ready":
 			filterTxt = "READY"
 			filterIcon = "🚀"
+		case "frontier":
+			filterTxt = "FRONTIER"
+			filterIcon = "🎯"
 		default:
 			if strings.HasPrefix(m.currentFilter, "recipe:") {
 				filterTxt = strings.ToUpper(m.currentFilter[7:])
@@ -5294,6 +5776,18 @@ func (m *Model) renderFooter() string {
 			Background(ColorBgDark).
 			Padding(0, 1).
 			Render("A:attention • 1-9 filter • esc close")
+	} else if m.showTeamView {
+		labelHint = lipgloss.NewStyle().
+			Foreground(ColorMuted).
+			Background(ColorBgDark).
+			Padding(0, 1).
+			Render("W:team workload • esc close")
+	} else if m.showLabelTaxonomy {
+		labelHint = lipgloss.NewStyle().
+			Foreground(ColorMuted).
+			Background(ColorBgDark).
+			Padding(0, 1).
+			Render("label taxonomy • esc back to labels")
 	}
 
 	// ─────────────────────────────────────────────────────────────────────────
@@ -5490,7 +5984,7 @@ func (m *Model) renderFooter() string {
 			Foreground(ColorBg).
 			Bold(true).
 			Padding(0, 1)
-		updateSection = updateStyle.Render(fmt.Sprintf("⭐ %s", m.updateTag))
+		updateSection = updateStyle.Render(fmt.Sprintf("⭐ %s%s", m.updateTag, m.updateChannelLabel()))
 	}
 
 	// ─────────────────────────────────────────────────────────────────────────
@@ -5568,7 +6062,15 @@ func (m *Model) renderFooter() string {
 			Foreground(ColorWarning).
 			Bold(true).
 			Padding(0, 1)
-		instanceSection = instanceStyle.Render(fmt.Sprintf("⚠ PID %d", m.instanceLock.HolderPID()))
+		instanceSection = instanceStyle.Render(fmt.Sprintf("⚠ RO · PID %d", m.instanceLock.HolderPID()))
+	} else if m.instanceReg != nil {
+		if peers := m.instanceReg.Peers(); len(peers) > 0 {
+			peerStyle := lipgloss.NewStyle().
+				Background(ColorBgHighlight).
+				Foreground(ColorInfo).
+				Padding(0, 1)
+			instanceSection = peerStyle.Render(instancePeersLabel(peers))
+		}
 	}
 
 	// ─────────────────────────────────────────────────────────────────────────
@@ -5587,6 +6089,9 @@ func (m *Model) renderFooter() string {
 		sessionSection = sessionStyle.Render(fmt.Sprintf("📎%s", countStr))
 	}
 
+	// Focus timer - the latest issue claimed with M
+	focusSection := m.focusTimerSection()
+
 	// ─────────────────────────────────────────────────────────────────────────
 	// WORKSPACE BADGE - Multi-repo mode indicator
 	// ─────────────────────────────────────────────────────────────────────────
@@ -5625,53 +6130,63 @@ func (m *Model) renderFooter() string {
 	sepStyle := lipgloss.NewStyle().Foreground(ColorMuted)
 	sep := sepStyle.Render(" │ ")
 
+	// hint renders a key and its label from the message catalog
+	hint := func(key, label string) string {
+		return keyStyle.Render(key) + " " + i18n.T("hint."+label)
+	}
 	var keyHints []string
 	if m.showHelp {
-		keyHints = append(keyHints, "Press any key to close")
+		keyHints = append(keyHints, i18n.T("hint.press_any_key"))
 	} else if m.showRecipePicker {
-		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
+		keyHints = append(keyHints, hint("j/k", "nav"), hint("⏎", "apply"), hint("esc", "cancel"))
 	} else if m.showRepoPicker {
-		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("space")+" toggle", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
+		keyHints = append(keyHints, hint("j/k", "nav"), hint("space", "toggle"), hint("⏎", "apply"), hint("esc", "cancel"))
+	} else if m.showStaleSweep {
+		keyHints = append(keyHints, hint("space", "mark"), hint("x/d/p", "close_deprio_ping"), hint("⏎", "suggested"), hint("esc", "done"))
+	} else if m.showProjectSwitcher {
+		keyHints = append(keyHints, hint("j/k", "nav"), hint("⏎", "select"), hint("esc", "cancel"))
+	} else if m.showNotesEditor {
+		keyHints = append(keyHints, hint("ctrl+s", "save"), hint("esc", "cancel"))
 	} else if m.showLabelPicker {
-		keyHints = append(keyHints, "type to filter", keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" apply", keyStyle.Render("esc")+" cancel")
+		keyHints = append(keyHints, i18n.T("hint.type_to_filter"), hint("j/k", "nav"), hint("⏎", "apply"), hint("esc", "cancel"))
 	} else if m.focused == focusInsights {
-		keyHints = append(keyHints, keyStyle.Render("h/l")+" panels", keyStyle.Render("e")+" explain", keyStyle.Render("⏎")+" jump", keyStyle.Render("?")+" help")
-		keyHints = append(keyHints, keyStyle.Render("A")+" attention", keyStyle.Render("F")+" flow")
+		keyHints = append(keyHints, hint("h/l", "panels"), hint("e", "explain"), hint("⏎", "jump"), hint("?", "help"))
+		keyHints = append(keyHints, hint("A", "attention"), hint("F", "flow"))
 	} else if m.focused == focusFlowMatrix {
-		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" panel", keyStyle.Render("⏎")+" drill", keyStyle.Render("esc")+" back", keyStyle.Render("f")+" close")
+		keyHints = append(keyHints, hint("j/k", "nav"), hint("tab", "panel"), hint("⏎", "drill"), hint("esc", "back"), hint("f", "close"))
 	} else if m.isGraphView {
-		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("H/L")+" scroll", keyStyle.Render("⏎")+" view", keyStyle.Render("g")+" list")
+		keyHints = append(keyHints, hint("hjkl", "nav"), hint("H/L", "scroll"), hint("⏎", "view"), hint("c", "condense"), hint("g", "list"))
 	} else if m.isBoardView {
-		keyHints = append(keyHints, keyStyle.Render("hjkl")+" nav", keyStyle.Render("G")+" bottom", keyStyle.Render("⏎")+" view", keyStyle.Render("b")+" list")
+		keyHints = append(keyHints, hint("hjkl", "nav"), hint("G", "bottom"), hint("⏎", "view"), hint("b", "list"))
 	} else if m.isActionableView {
-		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("⏎")+" view", keyStyle.Render("a")+" list", keyStyle.Render("?")+" help")
+		keyHints = append(keyHints, hint("j/k", "nav"), hint("⏎", "view"), hint("a", "list"), hint("?", "help"))
 	} else if m.isHistoryView {
-		keyHints = append(keyHints, keyStyle.Render("j/k")+" nav", keyStyle.Render("tab")+" focus", keyStyle.Render("⏎")+" jump", keyStyle.Render("H")+" close")
+		keyHints = append(keyHints, hint("j/k", "nav"), hint("tab", "focus"), hint("⏎", "jump"), hint("H", "close"))
 	} else if m.list.FilterState() == list.Filtering {
-		mode := "fuzzy"
+		mode := i18n.T("search.mode.fuzzy")
 		if m.semanticSearchEnabled {
-			mode = "semantic"
+			mode = i18n.T("search.mode.semantic")
 			if m.semanticIndexBuilding {
-				mode = "semantic (indexing)"
+				mode = i18n.T("search.mode.semantic_idx")
 			}
 		}
-		keyHints = append(keyHints, keyStyle.Render("esc")+" cancel", keyStyle.Render("ctrl+s")+" "+mode, keyStyle.Render("⏎")+" select")
+		keyHints = append(keyHints, hint("esc", "cancel"), keyStyle.Render("ctrl+s")+" "+mode, hint("⏎", "select"))
 		if m.semanticSearchEnabled {
-			keyHints = append(keyHints, keyStyle.Render("H")+" hybrid", keyStyle.Render("alt+h")+" preset")
+			keyHints = append(keyHints, hint("H", "hybrid"), hint("alt+h", "preset"))
 		}
 	} else if m.showTimeTravelPrompt {
-		keyHints = append(keyHints, keyStyle.Render("⏎")+" compare", keyStyle.Render("esc")+" cancel")
+		keyHints = append(keyHints, hint("⏎", "compare"), hint("esc", "cancel"))
 	} else {
 		if m.timeTravelMode {
-			keyHints = append(keyHints, keyStyle.Render("t")+" exit diff", keyStyle.Render("C")+" copy", keyStyle.Render("abgi")+" views", keyStyle.Render("?")+" help")
+			keyHints = append(keyHints, hint("t", "exit_diff"), hint("C", "copy"), hint("abgi", "views"), hint("?", "help"))
 		} else if m.isSplitView {
-			keyHints = append(keyHints, keyStyle.Render("tab")+" focus", keyStyle.Render("C")+" copy", keyStyle.Render("x")+" export", keyStyle.Render("Ctrl+R")+" refresh", keyStyle.Render("?")+" help")
+			keyHints = append(keyHints, hint("tab", "focus"), hint("C", "copy"), hint("x", "export"), hint("Ctrl+R", "refresh"), hint("?", "help"))
 		} else if m.showDetails {
-			keyHints = append(keyHints, keyStyle.Render("esc")+" back", keyStyle.Render("C")+" copy", keyStyle.Render("O")+" edit", keyStyle.Render("Ctrl+R")+" refresh", keyStyle.Render("?")+" help")
+			keyHints = append(keyHints, hint("esc", "back"), hint("C", "copy"), hint("O", "edit"), hint("Ctrl+R", "refresh"), hint("?", "help"))
 		} else {
-			keyHints = append(keyHints, keyStyle.Render("⏎")+" details", keyStyle.Render("t")+" diff", keyStyle.Render("S")+" triage", keyStyle.Render("l")+" labels", keyStyle.Render("Ctrl+R")+" refresh", keyStyle.Render("?")+" help")
+			keyHints = append(keyHints, hint("⏎", "details"), hint("t", "diff"), hint("S", "triage"), hint("l", "labels"), hint("Ctrl+R", "refresh"), hint("?", "help"))
 			if m.workspaceMode {
-				keyHints = append(keyHints, keyStyle.Render("w")+" repos")
+				keyHints = append(keyHints, hint("w", "repos"))
 			}
 		}
 	}
@@ -5717,6 +6232,9 @@ func (m *Model) renderFooter() string {
 	if sessionSection != "" {
 		leftWidth += lipgloss.Width(sessionSection) + 1
 	}
+	if focusSection != "" {
+		leftWidth += lipgloss.Width(focusSection) + 1
+	}
 	if workspaceSection != "" {
 		leftWidth += lipgloss.Width(workspaceSection) + 1
 	}
@@ -5756,6 +6274,9 @@ func (m *Model) renderFooter() string {
 	if sessionSection != "" {
 		parts = append(parts, sessionSection)
 	}
+	if focusSection != "" {
+		parts = append(parts, focusSection)
+	}
 	if workspaceSection != "" {
 		parts = append(parts, workspaceSection)
 	}
@@ -5846,6 +6367,7 @@ func (m *Model) setActiveRecipe(r *recipe.Recipe) {
 func (m *Model) applyFilter() {
 	var filteredItems []list.Item
 	var filteredIssues []model.Issue
+	frontier := m.frontierSet()
 
 	for _, issue := range m.issues {
 		// Workspace repo filter (nil = all repos)
@@ -5864,6 +6386,8 @@ func (m *Model) applyFilter() {
 			include = !isClosedLikeStatus(issue.Status)
 		case "closed":
 			include = isClosedLikeStatus(issue.Status)
+		case "frontier":
+			include = frontier[issue.ID]
 		case "ready":
 			// Ready = Open/InProgress AND NO Open Blockers
 			if !isClosedLikeStatus(issue.Status) && issue.Status != model.StatusBlocked {
@@ -5888,6 +6412,8 @@ func (m *Model) applyFilter() {
 						break
 					}
 				}
+			} else if owner, ok := strings.CutPrefix(m.currentFilter, "owner:"); ok {
+				include = codeowners.Match(m.issueOwners(issue), owner)
 			}
 		}
 
@@ -5917,6 +6443,7 @@ func (m *Model) applyFilter() {
 	// Apply sort mode (bv-3ita)
 	m.sortFilteredItems(filteredItems, filteredIssues)
 
+	pinToTop(filteredItems, m.pinned)
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
 	if m.snapshot != nil && m.snapshot.BoardState != nil && m.currentFilter == "all" && (!m.workspaceMode || m.activeRepos == nil) && len(filteredIssues) == len(m.snapshot.Issues) {
@@ -5974,6 +6501,13 @@ func (m *Model) sortFilteredItems(items []list.Item, issues []model.Issue) {
 		case SortUpdated:
 			// Most recently updated first
 			return iItem.Issue.UpdatedAt.After(jItem.Issue.UpdatedAt)
+		case SortDepth, SortDependents, SortDependencies:
+			// Largest first, then by ID for a stable order
+			ki, kj := m.depShapeKey(iItem.Issue.ID), m.depShapeKey(jItem.Issue.ID)
+			if ki != kj {
+				return ki > kj
+			}
+			return iItem.Issue.ID < jItem.Issue.ID
 		default:
 			// Default: Open first, then priority, then newest
 			iClosed := isClosedLikeStatus(iItem.Issue.Status)
@@ -5999,6 +6533,19 @@ func (m *Model) sortFilteredItems(items []list.Item, issues []model.Issue) {
 	copy(issues, sortedIssues)
 }
 
+// depShapeKey is the value the depth and fan sort modes order an issue by.
+func (m *Model) depShapeKey(id string) int {
+	shape := m.depShapes[id]
+	switch m.sortMode {
+	case SortDependents:
+		return shape.Dependents
+	case SortDependencies:
+		return shape.Dependencies
+	default:
+		return shape.Depth
+	}
+}
+
 func matchesRecipeStatus(status model.Status, filter string) bool {
 	normalized := strings.ToLower(strings.TrimSpace(filter))
 	statusKey := strings.ToLower(string(status))
@@ -6231,6 +6778,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 		})
 	}
 
+	pinToTop(filteredItems, m.pinned)
 	m.list.SetItems(filteredItems)
 	m.updateSemanticIDs(filteredItems)
 	m.board.SetIssues(filteredIssues)
@@ -6249,6 +6797,7 @@ func (m *Model) applyRecipe(r *recipe.Recipe) {
 }
 
 func (m *Model) updateViewportContent() {
+	m.detailRenderedFor = m.detailKey()
 	selectedItem := m.list.SelectedItem()
 	if selectedItem == nil {
 		m.viewport.SetContent("No issues selected")
@@ -6266,7 +6815,7 @@ func (m *Model) updateViewportContent() {
 	var sb strings.Builder
 
 	if m.updateAvailable {
-		sb.WriteString(fmt.Sprintf("⭐ **Update Available:** [%s](%s)\n\n", m.updateTag, m.updateURL))
+		sb.WriteString(fmt.Sprintf("⭐ **Update Available%s:** [%s](%s) — press `U` or run `bv self-update`\n\n", m.updateChannelLabel(), m.updateTag, m.updateURL))
 	}
 
 	// Title Block
@@ -6279,13 +6828,14 @@ func (m *Model) updateViewportContent() {
 		strings.ToUpper(string(item.Status)),
 		GetPriorityIcon(item.Priority),
 		item.Assignee,
-		item.CreatedAt.Format("2006-01-02"),
+		FormatTime(item.CreatedAt),
 	))
 
 	// Labels (bv-f103 fix: display labels in detail view)
 	if len(item.Labels) > 0 {
 		sb.WriteString(fmt.Sprintf("**Labels:** %s\n\n", strings.Join(item.Labels, ", ")))
 	}
+	sb.WriteString(m.focusDetail(item.ID))
 
 	// Triage Insights (bv-151)
 	if issueItem.TriageScore > 0 || issueItem.TriageReason != "" || issueItem.UnblocksCount > 0 || issueItem.IsQuickWin || issueItem.IsBlocker {
@@ -6383,6 +6933,13 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString(item.Notes + "\n\n")
 	}
 
+	// Local notes: the user's scratchpad, not in the beads data
+	if text := m.localNotes[item.ID]; text != "" {
+		sb.WriteString("### 📝 Local Notes\n")
+		sb.WriteString("_Only on this machine; `n` to edit._\n\n")
+		sb.WriteString(text + "\n")
+	}
+
 	// Dependency Graph (Tree)
 	if len(item.Dependencies) > 0 {
 		rootNode := BuildDependencyTree(item.ID, m.issueMap, 3) // Max depth 3
@@ -6390,17 +6947,28 @@ func (m *Model) updateViewportContent() {
 		sb.WriteString("```\n" + treeStr + "```\n\n")
 	}
 
+	// Commits and branches that mention the issue
+	sb.WriteString(m.codeRefsDetail(item.ID))
+	sb.WriteString(m.likelyFilesDetail(item))
+
 	// Comments
 	if len(item.Comments) > 0 {
 		sb.WriteString(fmt.Sprintf("### Comments (%d)\n", len(item.Comments)))
 		for _, comment := range item.Comments {
 			sb.WriteString(fmt.Sprintf("> **%s** (%s)\n> \n> %s\n\n",
 				comment.Author,
-				FormatTimeRel(comment.CreatedAt),
+				FormatTime(comment.CreatedAt),
 				strings.ReplaceAll(comment.Text, "\n", "\n> ")))
 		}
 	}
 
+	// Activity timeline: git lifecycle events plus field timestamps
+	var beadHist *correlation.BeadHistory
+	if m.historyView.HasReport() {
+		beadHist = m.historyView.GetHistoryForBead(item.ID)
+	}
+	sb.WriteString(renderActivityMD(BuildIssueActivity(item, beadHist), 15))
+
 	// History Section (if data is loaded)
 	if m.historyView.HasReport() {
 		historyMD := m.renderBeadHistoryMD(item.ID)
@@ -6427,21 +6995,6 @@ func (m *Model) renderBeadHistoryMD(beadID string) string {
 	var sb strings.Builder
 	sb.WriteString("### 📜 History\n\n")
 
-	// Lifecycle milestones from events
-	if len(hist.Events) > 0 {
-		sb.WriteString("**Lifecycle:**\n")
-		for _, event := range hist.Events {
-			icon := getEventIcon(event.EventType)
-			sb.WriteString(fmt.Sprintf("- %s **%s** %s by %s\n",
-				icon,
-				event.EventType,
-				event.Timestamp.Format("Jan 02 15:04"),
-				event.Author,
-			))
-		}
-		sb.WriteString("\n")
-	}
-
 	// Correlated commits
 	sb.WriteString(fmt.Sprintf("**Related Commits (%d):**\n", len(hist.Commits)))
 	for i, commit := range hist.Commits {
@@ -6513,6 +7066,9 @@ func truncateString(s string, maxLen int) string {
 
 // GetTypeIconMD returns the emoji icon for an issue type (for markdown)
 func GetTypeIconMD(t string) string {
+	if ASCIIIcons() {
+		return asciiTypeTag(t)
+	}
 	switch t {
 	case "bug":
 		return "🐛"
@@ -6716,6 +7272,25 @@ func (m Model) TimeTravelDiff() *analysis.SnapshotDiff {
 	return m.timeTravelDiff
 }
 
+// keyRemapContext returns the keymap context that user key remaps apply
+// in, or false while a text input, picker or tutorial has the keyboard.
+func (m Model) keyRemapContext() (string, bool) {
+	switch {
+	case m.list.FilterState() == list.Filtering,
+		m.isBoardView && m.board.IsSearchMode(),
+		m.isHistoryView && m.historyView.IsSearchActive():
+		return "", false
+	}
+	switch m.focused {
+	case focusHelp:
+		return ContextFromFocus(m.focusBeforeHelp), true
+	case focusList, focusDetail, focusBoard, focusGraph, focusTree, focusInsights,
+		focusHistory, focusActionable, focusLabelDashboard, focusFlowMatrix, focusSprint:
+		return ContextFromFocus(m.focused), true
+	}
+	return "", false
+}
+
 // FocusState returns the current focus state as a string for testing (bv-5e5q).
 // This enables testing focus transitions without exposing the internal focus type.
 func (m Model) FocusState() string {
@@ -6926,15 +7501,7 @@ func (m *Model) copyIssueToClipboard() {
 	}
 
 	// Copy to clipboard
-	err := clipboard.WriteAll(sb.String())
-	if err != nil {
-		m.statusMsg = fmt.Sprintf("❌ Clipboard error: %v", err)
-		m.statusIsError = true
-		return
-	}
-
-	m.statusMsg = fmt.Sprintf("📋 Copied %s to clipboard", issue.ID)
-	m.statusIsError = false
+	m.copyWithStatus(sb.String(), issue.ID)
 }
 
 // showCassSessionModal shows the cass session preview modal for the selected issue (bv-5bqh)
@@ -6985,6 +7552,15 @@ func (m *Model) showCassSessionModal() {
 	m.focused = focusCassModal
 }
 
+// updateChannelLabel returns " (beta)" etc. when the available update came
+// from a non-stable release channel, so the banner says what it's offering.
+func (m Model) updateChannelLabel() string {
+	if m.updateChannel == "" || m.updateChannel == updater.ChannelStable {
+		return ""
+	}
+	return fmt.Sprintf(" (%s)", m.updateChannel)
+}
+
 // showSelfUpdateModal shows the self-update modal (bv-182)
 func (m *Model) showSelfUpdateModal() {
 	// Check if an update is available
@@ -7338,6 +7914,13 @@ func (m *Model) openInEditor() {
 		return
 	}
 
+	m.openFileInEditor(beadsFile)
+}
+
+// openFileInEditor opens targetFile in a GUI editor, honoring $EDITOR/$VISUAL
+// when it names an allowlisted editor. Terminal editors are refused because
+// they would fight Bubble Tea for the TTY.
+func (m *Model) openFileInEditor(targetFile string) {
 	// Determine editor - prefer GUI editors that work in background
 	editor := os.Getenv("EDITOR")
 	if editor == "" {
@@ -7401,7 +7984,7 @@ func (m *Model) openInEditor() {
 		return
 	}
 
-	actualKind, err := startAllowlistedGUIEditor(requestedEditorKind, beadsFile)
+	actualKind, err := startAllowlistedGUIEditor(requestedEditorKind, targetFile)
 	if err != nil {
 		m.statusMsg = fmt.Sprintf("❌ Failed to open editor: %v", err)
 		m.statusIsError = true
@@ -7420,21 +8003,31 @@ func (m *Model) openInEditor() {
 // Stop cleans up resources (file watcher, instance lock, background worker, etc.)
 // Should be called when the program exits
 func (m *Model) Stop() {
+	m.scheduler.Stop()
 	if m.backgroundWorker != nil {
 		m.backgroundWorker.Stop()
 	}
 	if m.watcher != nil {
 		m.watcher.Stop()
 	}
+	if m.cacheSignalWatcher != nil {
+		m.cacheSignalWatcher.Stop()
+	}
+	if m.instanceReg != nil {
+		m.instanceReg.Close()
+	}
 	if m.instanceLock != nil {
 		m.instanceLock.Release()
 	}
 }
 
-// clearAttentionOverlay hides the attention overlay and clears its rendered text.
+// clearAttentionOverlay hides the attention, team, or label taxonomy overlay
+// and clears its rendered text.
 func (m *Model) clearAttentionOverlay() {
-	if m.showAttentionView {
+	if m.showAttentionView || m.showTeamView || m.showLabelTaxonomy {
 		m.showAttentionView = false
+		m.showTeamView = false
+		m.showLabelTaxonomy = false
 		m.insightsPanel.extraText = ""
 	}
 }
@@ -7443,6 +8036,27 @@ func (m *Model) clearAttentionOverlay() {
 // ALERTS PANEL (bv-168)
 // ════════════════════════════════════════════════════════════════════════════
 
+// newProjectAnalyzer returns an analyzer for issues whose impact scores
+// apply the project's score rules.
+func newProjectAnalyzer(issues []model.Issue) *analysis.Analyzer {
+	analyzer := analysis.NewAnalyzer(issues)
+	applyProjectRules(analyzer)
+	return analyzer
+}
+
+// applyProjectRules makes analyzer's impact scores apply the project's
+// score rules. The rules don't change graph metrics, so cached stats stay
+// valid. A rules file that doesn't load is reported in the alerts panel
+// (see computeAlerts) and otherwise ignored.
+func applyProjectRules(analyzer *analysis.Analyzer) {
+	projectDir, _ := os.Getwd()
+	if ruleSet, err := rules.Load(projectDir); err == nil && !ruleSet.Empty() {
+		cfg := analyzer.Config()
+		cfg.ScoreRules = ruleSet
+		analyzer.SetConfig(&cfg)
+	}
+}
+
 // computeAlerts calculates drift alerts for the current issues using the
 // already-computed graph stats/analyzer to avoid redundant work.
 func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *analysis.Analyzer) ([]drift.Alert, int, int, int) {
@@ -7484,7 +8098,17 @@ func computeAlerts(issues []model.Issue, stats *analysis.GraphStats, analyzer *a
 
 	calc := drift.NewCalculator(bl, cur, driftConfig)
 	calc.SetIssues(issues)
+	ruleSet, rulesErr := rules.Load(projectDir)
+	calc.SetRules(ruleSet)
 	result := calc.Calculate()
+	if rulesErr != nil {
+		// The rules file is broken: say so here, where the rule alerts would be
+		result.Alerts = append(result.Alerts, drift.Alert{
+			Type:     drift.AlertRule,
+			Severity: drift.SeverityWarning,
+			Message:  fmt.Sprintf("%v; no score or alert rules are applied", rulesErr),
+		})
+	}
 
 	critical, warning, info := 0, 0, 0
 	for _, a := range result.Alerts {
@@ -7635,7 +8259,31 @@ func (m *Model) RenderDebugView(viewName string, width, height int) string {
 	case "history":
 		m.historyView.SetSize(width, height-1)
 		return m.historyView.View()
+	case "tree":
+		if m.snapshot != nil {
+			m.tree.BuildFromSnapshot(m.snapshot)
+		} else {
+			m.tree.Build(m.issues)
+		}
+		m.tree.SetSize(width, height-1)
+		return m.tree.View()
+	case "graph":
+		return m.graphView.View(width, height-1)
 	default:
 		return "Unknown view: " + viewName
 	}
 }
+
+// SetBdRunner replaces how the TUI runs bd for write actions such as the
+// stale sweep. Tests and automation use it to record or fake bd; nil
+// restores the bd on PATH.
+func (m *Model) SetBdRunner(run BdRunner) {
+	m.bdRunner = run
+}
+
+// SetColorProfile overrides the detected terminal color profile. Static
+// rendering (bv print) uses this to force or suppress ANSI styling regardless
+// of whether stdout is a terminal.
+func (m *Model) SetColorProfile(p termenv.Profile) {
+	m.theme.Renderer.SetColorProfile(p)
+}
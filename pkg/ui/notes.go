@@ -0,0 +1,152 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notes"
+
+	"github.com/charmbracelet/bubbles/cursor"
+	"github.com/charmbracelet/bubbles/textarea"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// Local notes (n) are a markdown scratchpad per issue, kept in
+// .beads/.bv-notes/ rather than the beads data (see pkg/notes). The detail
+// pane shows them under the issue's own notes.
+
+// loadLocalNotes reads the notes kept next to beadsPath. It returns "" for
+// the directory when there is no beads directory to keep them in.
+func loadLocalNotes(beadsPath string) (map[string]string, string) {
+	if beadsPath == "" {
+		return map[string]string{}, ""
+	}
+	dir := notes.Dir(filepath.Dir(beadsPath))
+	all, err := notes.LoadAll(dir)
+	if err != nil {
+		return map[string]string{}, dir
+	}
+	return all, dir
+}
+
+// NotesEditorModel is the overlay for editing an issue's local notes.
+type NotesEditorModel struct {
+	issueID string
+	title   string
+	area    textarea.Model
+	width   int
+	height  int
+	theme   Theme
+}
+
+// NewNotesEditorModel opens the editor on issue's notes, text.
+func NewNotesEditorModel(issue model.Issue, text string, theme Theme) NotesEditorModel {
+	area := textarea.New()
+	area.Placeholder = "Notes on this issue, in markdown. Only you see them."
+	area.ShowLineNumbers = false
+	area.CharLimit = 0
+	area.Cursor.SetMode(cursor.CursorStatic)
+	area.SetValue(strings.TrimSuffix(text, "\n"))
+	area.Focus()
+	return NotesEditorModel{issueID: issue.ID, title: issue.Title, area: area, theme: theme}
+}
+
+// SetSize updates the overlay dimensions.
+func (m *NotesEditorModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+	boxWidth := min(100, max(40, width-6))
+	m.area.SetWidth(boxWidth - 6)
+	m.area.SetHeight(max(5, height-12))
+}
+
+// Update passes keys to the text area.
+func (m *NotesEditorModel) Update(msg tea.Msg) tea.Cmd {
+	var cmd tea.Cmd
+	m.area, cmd = m.area.Update(msg)
+	return cmd
+}
+
+// Value returns the edited notes.
+func (m NotesEditorModel) Value() string {
+	return m.area.Value()
+}
+
+// View renders the overlay.
+func (m *NotesEditorModel) View() string {
+	if m.width == 0 {
+		m.SetSize(100, 30)
+	}
+	t := m.theme
+	boxWidth := min(100, max(40, m.width-6))
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	lines := []string{
+		titleStyle.Render("📝 Local notes · "+m.issueID) + "  " + mutedStyle.Render(truncate(m.title, boxWidth/2)),
+		mutedStyle.Render("Kept in .beads/" + notes.DirName + "/, not in the beads data"),
+		"",
+		m.area.View(),
+		"",
+		mutedStyle.Render("ctrl+s: save • esc: cancel • empty notes are removed"),
+	}
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openNotesEditor opens the notes editor on issue.
+func (m *Model) openNotesEditor(issue *model.Issue) {
+	if issue == nil {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return
+	}
+	if m.notesDir == "" {
+		m.statusMsg = "Notes need a beads project"
+		m.statusIsError = true
+		return
+	}
+	m.notesEditor = NewNotesEditorModel(*issue, m.localNotes[issue.ID], m.theme)
+	m.notesEditor.SetSize(m.width, m.height-1)
+	m.showNotesEditor = true
+}
+
+// handleNotesEditorKeys handles keyboard input in the notes editor.
+func (m Model) handleNotesEditorKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.showNotesEditor = false
+		m.statusMsg = "Notes not saved"
+		m.statusIsError = false
+		return m, nil
+	case "ctrl+s":
+		id, text := m.notesEditor.issueID, m.notesEditor.Value()
+		if err := notes.Save(m.notesDir, id, text); err != nil {
+			m.statusMsg = fmt.Sprintf("Can't save notes: %v", err)
+			m.statusIsError = true
+			return m, nil
+		}
+		m.showNotesEditor = false
+		if strings.TrimSpace(text) == "" {
+			delete(m.localNotes, id)
+			m.statusMsg = fmt.Sprintf("Removed notes on %s", id)
+		} else {
+			m.localNotes[id], _ = notes.Load(m.notesDir, id)
+			m.statusMsg = fmt.Sprintf("Saved notes on %s", id)
+		}
+		m.statusIsError = false
+		m.updateViewportContent()
+		return m, nil
+	}
+	return m, m.notesEditor.Update(msg)
+}
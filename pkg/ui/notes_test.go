@@ -0,0 +1,60 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/notes"
+
+	"github.com/charmbracelet/x/ansi"
+)
+
+func TestLocalNotesEditor(t *testing.T) {
+	beadsPath := writeTestRepo(t)
+	dir := notes.Dir(filepath.Dir(beadsPath))
+	issues := []model.Issue{
+		{ID: "ln-1", Title: "First", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeTask},
+	}
+	m := NewModel(issues, nil, beadsPath)
+	t.Cleanup(func() { m.Stop() })
+
+	m, _ = pressVim(t, m, "n")
+	if !m.showNotesEditor || m.CurrentContext() != ContextNotesEditor {
+		t.Fatal("n should open the notes editor")
+	}
+	// Keys are text while editing: q doesn't quit, n doesn't reopen
+	m, _ = pressVim(t, m, "q", "n", "enter", "-", "x")
+	if got := m.notesEditor.Value(); got != "qn\n-x" {
+		t.Fatalf("editor holds %q", got)
+	}
+	m, _ = pressVim(t, m, "ctrl+s")
+	if m.showNotesEditor {
+		t.Fatal("ctrl+s should close the editor")
+	}
+	if text, _ := notes.Load(dir, "ln-1"); text != "qn\n-x\n" {
+		t.Errorf("saved %q", text)
+	}
+	m.viewport.Height = 500
+	if !strings.Contains(ansi.Strip(m.viewport.View()), "Local Notes") {
+		t.Error("detail pane doesn't show the notes")
+	}
+
+	// A new bv reads them back; esc discards an edit
+	m.Stop()
+	m = NewModel(issues, nil, beadsPath)
+	m, _ = pressVim(t, m, "n", "!", "esc")
+	if m.showNotesEditor || m.localNotes["ln-1"] != "qn\n-x\n" {
+		t.Errorf("notes after esc: %q", m.localNotes["ln-1"])
+	}
+
+	// Saving them empty removes the file
+	m, _ = pressVim(t, m, "n")
+	m.notesEditor.area.SetValue("")
+	m, _ = pressVim(t, m, "ctrl+s")
+	if _, err := os.Stat(notes.Path(dir, "ln-1")); !os.IsNotExist(err) {
+		t.Errorf("emptied notes still on disk: %v", err)
+	}
+}
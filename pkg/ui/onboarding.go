@@ -0,0 +1,452 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/agents"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// onboardingStep identifies the current screen of the first-run wizard.
+type onboardingStep int
+
+const (
+	onboardingWelcome onboardingStep = iota
+	onboardingLocate
+	onboardingBlurb
+	onboardingTour
+	onboardingDone
+)
+
+// onboardingChoice indexes the options on the welcome screen.
+const (
+	onboardingChoiceLocate = iota
+	onboardingChoiceInit
+	onboardingChoiceQuit
+)
+
+// onboardingTourPages is the quick keybinding tour shown at the end of the
+// wizard. It deliberately covers only the essentials; the full tutorial is
+// one backtick away once the main TUI is running.
+var onboardingTourPages = []struct {
+	title string
+	keys  [][2]string
+}{
+	{
+		title: "Moving around",
+		keys: [][2]string{
+			{"j / k", "Move down / up"},
+			{"enter", "Open issue details"},
+			{"tab", "Switch between list and details"},
+			{"/", "Filter issues"},
+			{"q / esc", "Back / quit"},
+		},
+	},
+	{
+		title: "Views",
+		keys: [][2]string{
+			{"b", "Kanban board"},
+			{"g", "Dependency graph"},
+			{"i", "Insights dashboard"},
+			{"h", "History view"},
+			{"E", "Tree view"},
+			{"a", "Actionable view"},
+		},
+	},
+	{
+		title: "Getting help",
+		keys: [][2]string{
+			{"?", "Keybinding reference"},
+			{"`", "Interactive tutorial"},
+			{";", "Shortcuts sidebar"},
+			{"o / c / r", "Filter open / closed / ready"},
+		},
+	},
+}
+
+// bdInitFinishedMsg reports the outcome of running `bd init`.
+type bdInitFinishedMsg struct {
+	err error
+}
+
+// OnboardingResult is what the first-run wizard resolved.
+type OnboardingResult struct {
+	// BeadsDir is the beads directory to load. Empty when the user quit.
+	BeadsDir string
+	// Completed is true when the user reached the end of the wizard.
+	Completed bool
+}
+
+// OnboardingModel is a standalone Bubble Tea program shown when bv starts in
+// a directory without beads data. It helps the user locate existing data or
+// run `bd init`, offers to install the agent blurb, and gives a short tour of
+// the keybindings before the main TUI starts.
+type OnboardingModel struct {
+	theme    Theme
+	workDir  string
+	step     onboardingStep
+	choice   int
+	hasBd    bool
+	input    textinput.Model
+	beadsDir string
+	tourPage int
+	blurbMsg string
+	errMsg   string
+	quitting bool
+	width    int
+	height   int
+}
+
+// NewOnboardingModel creates the first-run wizard for workDir.
+func NewOnboardingModel(workDir string, theme Theme) OnboardingModel {
+	ti := textinput.New()
+	ti.Placeholder = "path to a project, .beads directory, or issues.jsonl"
+	ti.CharLimit = 4096
+	ti.Width = 56
+
+	_, err := exec.LookPath("bd")
+
+	return OnboardingModel{
+		theme:   theme,
+		workDir: workDir,
+		step:    onboardingWelcome,
+		hasBd:   err == nil,
+		input:   ti,
+		width:   80,
+		height:  24,
+	}
+}
+
+// Init implements tea.Model.
+func (m OnboardingModel) Init() tea.Cmd {
+	return nil
+}
+
+// Result returns the outcome of the wizard.
+func (m OnboardingModel) Result() OnboardingResult {
+	if m.quitting {
+		return OnboardingResult{}
+	}
+	return OnboardingResult{BeadsDir: m.beadsDir, Completed: m.step == onboardingDone}
+}
+
+// Update implements tea.Model.
+func (m OnboardingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.width = msg.Width
+		m.height = msg.Height
+		return m, nil
+
+	case bdInitFinishedMsg:
+		if msg.err != nil {
+			m.errMsg = fmt.Sprintf("bd init failed: %v", msg.err)
+			m.step = onboardingWelcome
+			return m, nil
+		}
+		beadsDir := filepath.Join(m.workDir, ".beads")
+		if _, err := os.Stat(beadsDir); err != nil {
+			m.errMsg = "bd init finished but no .beads directory was created"
+			m.step = onboardingWelcome
+			return m, nil
+		}
+		// Newer bd versions only export JSONL after the first write; seed an
+		// empty file so the loader (and live reload) have something to watch.
+		if _, err := loader.FindJSONLPath(beadsDir); err != nil {
+			seed := filepath.Join(beadsDir, loader.PreferredJSONLNames[0])
+			if err := os.WriteFile(seed, nil, 0o644); err != nil {
+				m.errMsg = fmt.Sprintf("could not create %s: %v", seed, err)
+				m.step = onboardingWelcome
+				return m, nil
+			}
+		}
+		m.errMsg = ""
+		m.beadsDir = beadsDir
+		m.step = onboardingBlurb
+		return m, nil
+
+	case tea.KeyMsg:
+		if msg.String() == "ctrl+c" {
+			m.quitting = true
+			return m, tea.Quit
+		}
+		switch m.step {
+		case onboardingWelcome:
+			return m.updateWelcome(msg)
+		case onboardingLocate:
+			return m.updateLocate(msg)
+		case onboardingBlurb:
+			return m.updateBlurb(msg)
+		case onboardingTour:
+			return m.updateTour(msg)
+		}
+	}
+	return m, nil
+}
+
+func (m OnboardingModel) updateWelcome(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "up", "k":
+		m.choice = m.prevChoice(m.choice)
+	case "down", "j":
+		m.choice = m.nextChoice(m.choice)
+	case "q", "esc":
+		m.quitting = true
+		return m, tea.Quit
+	case "enter", " ":
+		switch m.choice {
+		case onboardingChoiceLocate:
+			m.errMsg = ""
+			m.step = onboardingLocate
+			m.input.SetValue("")
+			return m, m.input.Focus()
+		case onboardingChoiceInit:
+			m.errMsg = ""
+			return m, runBdInit(m.workDir)
+		case onboardingChoiceQuit:
+			m.quitting = true
+			return m, tea.Quit
+		}
+	}
+	return m, nil
+}
+
+// nextChoice/prevChoice skip the `bd init` option when bd isn't installed.
+func (m OnboardingModel) nextChoice(c int) int {
+	c = (c + 1) % 3
+	if c == onboardingChoiceInit && !m.hasBd {
+		c++
+	}
+	return c
+}
+
+func (m OnboardingModel) prevChoice(c int) int {
+	c = (c + 2) % 3
+	if c == onboardingChoiceInit && !m.hasBd {
+		c--
+	}
+	return c
+}
+
+func (m OnboardingModel) updateLocate(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "esc":
+		m.input.Blur()
+		m.errMsg = ""
+		m.step = onboardingWelcome
+		return m, nil
+	case "enter":
+		dir, err := ResolveBeadsDir(m.input.Value(), m.workDir)
+		if err != nil {
+			m.errMsg = err.Error()
+			return m, nil
+		}
+		m.input.Blur()
+		m.errMsg = ""
+		m.beadsDir = dir
+		m.step = onboardingBlurb
+		return m, nil
+	}
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	return m, cmd
+}
+
+func (m OnboardingModel) updateBlurb(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "y", "Y", "enter":
+		projectDir := filepath.Dir(m.beadsDir)
+		if err := agents.EnsureBlurb(projectDir); err != nil {
+			m.blurbMsg = fmt.Sprintf("Could not update agent file: %v", err)
+		} else {
+			m.blurbMsg = "Added bv instructions to " + filepath.Base(agents.DetectAgentFile(projectDir).FilePath)
+		}
+		m.step = onboardingTour
+	case "n", "N", "esc":
+		m.step = onboardingTour
+	}
+	return m, nil
+}
+
+func (m OnboardingModel) updateTour(msg tea.KeyMsg) (tea.Model, tea.Cmd) {
+	switch msg.String() {
+	case "right", "l", "n", "enter", " ":
+		if m.tourPage < len(onboardingTourPages)-1 {
+			m.tourPage++
+			return m, nil
+		}
+		m.step = onboardingDone
+		return m, tea.Quit
+	case "left", "h", "p":
+		if m.tourPage > 0 {
+			m.tourPage--
+		}
+	case "s", "esc", "q":
+		m.step = onboardingDone
+		return m, tea.Quit
+	}
+	return m, nil
+}
+
+// runBdInit hands the terminal to `bd init` so it can prompt if it needs to.
+func runBdInit(workDir string) tea.Cmd {
+	cmd := exec.Command("bd", "init")
+	cmd.Dir = workDir
+	return tea.ExecProcess(cmd, func(err error) tea.Msg {
+		return bdInitFinishedMsg{err: err}
+	})
+}
+
+// ResolveBeadsDir turns user input into a beads directory containing a JSONL
+// data file. It accepts a project directory (containing .beads/), a beads
+// directory, or the path of a JSONL file. Relative paths resolve against base.
+func ResolveBeadsDir(input, base string) (string, error) {
+	p := strings.TrimSpace(input)
+	if p == "" {
+		return "", fmt.Errorf("enter a path")
+	}
+	if strings.HasPrefix(p, "~") {
+		if home, err := os.UserHomeDir(); err == nil {
+			p = filepath.Join(home, strings.TrimPrefix(p, "~"))
+		}
+	}
+	if !filepath.IsAbs(p) {
+		p = filepath.Join(base, p)
+	}
+	p = filepath.Clean(p)
+
+	info, err := os.Stat(p)
+	if err != nil {
+		return "", fmt.Errorf("%s does not exist", p)
+	}
+	if !info.IsDir() {
+		if !strings.HasSuffix(p, ".jsonl") {
+			return "", fmt.Errorf("%s is not a .jsonl file", filepath.Base(p))
+		}
+		return filepath.Dir(p), nil
+	}
+
+	for _, candidate := range []string{filepath.Join(p, ".beads"), p} {
+		if _, err := loader.FindJSONLPath(candidate); err == nil {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no beads data found in %s", p)
+}
+
+// View implements tea.Model.
+func (m OnboardingModel) View() string {
+	if m.quitting || m.step == onboardingDone {
+		return ""
+	}
+
+	r := m.theme.Renderer
+	boxWidth := 64
+	if m.width > 0 && m.width-4 < boxWidth {
+		boxWidth = max(m.width-4, 30)
+	}
+
+	titleStyle := r.NewStyle().Bold(true).Foreground(m.theme.Primary)
+	mutedStyle := r.NewStyle().Foreground(m.theme.Subtext)
+	errStyle := r.NewStyle().Foreground(m.theme.Blocked).Bold(true)
+	okStyle := r.NewStyle().Foreground(m.theme.Open)
+
+	var b strings.Builder
+	var hint string
+
+	switch m.step {
+	case onboardingWelcome:
+		b.WriteString(titleStyle.Render("Welcome to beads_viewer"))
+		b.WriteString("\n\n")
+		b.WriteString("No beads data was found in\n")
+		b.WriteString(mutedStyle.Render(m.workDir))
+		b.WriteString("\n\nHow would you like to get started?\n\n")
+
+		options := []string{
+			"Open existing beads data elsewhere",
+			"Initialize beads here (bd init)",
+			"Quit",
+		}
+		for i, opt := range options {
+			if i == onboardingChoiceInit && !m.hasBd {
+				b.WriteString(mutedStyle.Render("    " + opt + "  (bd not found in PATH)"))
+				b.WriteString("\n")
+				continue
+			}
+			if i == m.choice {
+				b.WriteString(titleStyle.Render("  ▸ " + opt))
+			} else {
+				b.WriteString("    " + opt)
+			}
+			b.WriteString("\n")
+		}
+		hint = "↑/↓ select • enter confirm • q quit"
+
+	case onboardingLocate:
+		b.WriteString(titleStyle.Render("Locate beads data"))
+		b.WriteString("\n\n")
+		b.WriteString("Enter a project directory, a .beads directory,\nor the path of an issues.jsonl file:\n\n")
+		b.WriteString(m.input.View())
+		b.WriteString("\n")
+		hint = "enter confirm • esc back"
+
+	case onboardingBlurb:
+		b.WriteString(titleStyle.Render("AI agent instructions"))
+		b.WriteString("\n\n")
+		b.WriteString(okStyle.Render("✓ Using " + m.beadsDir))
+		b.WriteString("\n\n")
+		detection := agents.DetectAgentFile(filepath.Dir(m.beadsDir))
+		switch {
+		case !detection.Found():
+			b.WriteString("Create AGENTS.md with instructions that teach AI\ncoding agents how to use bv's robot commands?\n")
+		case detection.NeedsBlurb() || detection.NeedsUpgrade():
+			b.WriteString("Add bv instructions to " + detection.FileType + " so AI\ncoding agents know how to use bv's robot commands?\n")
+		default:
+			b.WriteString(detection.FileType + " already includes bv instructions.\n")
+		}
+		hint = "y yes • n skip"
+
+	case onboardingTour:
+		page := onboardingTourPages[m.tourPage]
+		b.WriteString(titleStyle.Render(fmt.Sprintf("Quick tour (%d/%d): %s", m.tourPage+1, len(onboardingTourPages), page.title)))
+		b.WriteString("\n\n")
+		if m.blurbMsg != "" && m.tourPage == 0 {
+			b.WriteString(mutedStyle.Render(m.blurbMsg))
+			b.WriteString("\n\n")
+		}
+		keyStyle := r.NewStyle().Foreground(m.theme.Secondary).Bold(true).Width(16)
+		for _, kv := range page.keys {
+			b.WriteString("  " + keyStyle.Render(kv[0]) + kv[1] + "\n")
+		}
+		if m.tourPage == len(onboardingTourPages)-1 {
+			hint = "enter start bv • ← back"
+		} else {
+			hint = "→/enter next • ← back • s skip"
+		}
+	}
+
+	if m.errMsg != "" {
+		b.WriteString("\n")
+		b.WriteString(errStyle.Render(m.errMsg))
+		b.WriteString("\n")
+	}
+	b.WriteString("\n")
+	b.WriteString(mutedStyle.Italic(true).Render(hint))
+
+	box := r.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(m.theme.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(b.String())
+
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func writeBeadsFixture(t *testing.T, root string) string {
+	t.Helper()
+	beadsDir := filepath.Join(root, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), []byte(`{"id":"x-1","title":"t","status":"open","issue_type":"task"}`+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return beadsDir
+}
+
+func TestResolveBeadsDir(t *testing.T) {
+	root := t.TempDir()
+	beadsDir := writeBeadsFixture(t, root)
+
+	tests := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{"project dir", root, beadsDir},
+		{"beads dir", beadsDir, beadsDir},
+		{"jsonl file", filepath.Join(beadsDir, "issues.jsonl"), beadsDir},
+		{"relative", ".beads", beadsDir},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveBeadsDir(tt.input, root)
+			if err != nil {
+				t.Fatalf("ResolveBeadsDir(%q) error: %v", tt.input, err)
+			}
+			if got != tt.want {
+				t.Errorf("ResolveBeadsDir(%q) = %q, want %q", tt.input, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResolveBeadsDirErrors(t *testing.T) {
+	root := t.TempDir()
+	notJSONL := filepath.Join(root, "notes.txt")
+	if err := os.WriteFile(notJSONL, []byte("hi"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, input := range []string{"", filepath.Join(root, "missing"), root, notJSONL} {
+		if _, err := ResolveBeadsDir(input, root); err == nil {
+			t.Errorf("ResolveBeadsDir(%q) expected error", input)
+		}
+	}
+}
+
+func TestOnboardingLocateFlow(t *testing.T) {
+	root := t.TempDir()
+	beadsDir := writeBeadsFixture(t, root)
+
+	m := NewOnboardingModel(t.TempDir(), createTheme())
+	step := func(msg tea.Msg) {
+		t.Helper()
+		next, _ := m.Update(msg)
+		m = next.(OnboardingModel)
+	}
+
+	// Welcome -> locate
+	step(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != onboardingLocate {
+		t.Fatalf("expected locate step, got %d", m.step)
+	}
+
+	// Invalid path keeps us on the locate step with an error
+	m.input.SetValue(filepath.Join(root, "nope"))
+	step(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != onboardingLocate || m.errMsg == "" {
+		t.Fatalf("expected error on locate step, got step=%d err=%q", m.step, m.errMsg)
+	}
+
+	m.input.SetValue(root)
+	step(tea.KeyMsg{Type: tea.KeyEnter})
+	if m.step != onboardingBlurb {
+		t.Fatalf("expected blurb step, got %d", m.step)
+	}
+
+	// Skip the blurb, then page through the tour
+	step(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("n")})
+	if m.step != onboardingTour {
+		t.Fatalf("expected tour step, got %d", m.step)
+	}
+	if !strings.Contains(m.View(), "Quick tour") {
+		t.Error("tour view should render the quick tour header")
+	}
+	for i := 0; i < len(onboardingTourPages); i++ {
+		step(tea.KeyMsg{Type: tea.KeyEnter})
+	}
+
+	res := m.Result()
+	if !res.Completed || res.BeadsDir != beadsDir {
+		t.Errorf("Result() = %+v, want completed with %q", res, beadsDir)
+	}
+	if _, err := os.Stat(filepath.Join(root, "AGENTS.md")); !os.IsNotExist(err) {
+		t.Error("declining the blurb must not create AGENTS.md")
+	}
+}
+
+func TestOnboardingInstallsBlurb(t *testing.T) {
+	root := t.TempDir()
+	writeBeadsFixture(t, root)
+
+	m := NewOnboardingModel(root, createTheme())
+	m.beadsDir = filepath.Join(root, ".beads")
+	m.step = onboardingBlurb
+
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	m = next.(OnboardingModel)
+	if m.step != onboardingTour {
+		t.Fatalf("expected tour step, got %d", m.step)
+	}
+	if _, err := os.Stat(filepath.Join(root, "AGENTS.md")); err != nil {
+		t.Errorf("expected AGENTS.md to be created: %v", err)
+	}
+}
+
+func TestOnboardingQuit(t *testing.T) {
+	m := NewOnboardingModel(t.TempDir(), createTheme())
+	next, _ := m.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("q")})
+	m = next.(OnboardingModel)
+	if res := m.Result(); res.BeadsDir != "" || res.Completed {
+		t.Errorf("quitting should yield an empty result, got %+v", res)
+	}
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/pins"
+	"github.com/charmbracelet/bubbles/list"
+)
+
+// Starred issues (*) sort to the top of the list whatever the sort mode or
+// recipe, and are remembered in .beads/.bv-state.json (see pkg/pins).
+
+// loadPins reads the stars saved next to beadsPath. It returns "" for the
+// path when there is no beads directory to save them in.
+func loadPins(beadsPath string) (map[string]bool, string) {
+	if beadsPath == "" {
+		return map[string]bool{}, ""
+	}
+	path := pins.DefaultPath(filepath.Dir(beadsPath))
+	state, err := pins.Load(path)
+	if err != nil {
+		return map[string]bool{}, path
+	}
+	return state.Set(), path
+}
+
+// pinToTop moves starred issues to the front of items, keeping the order
+// within both groups, and flags each item for the row marker.
+func pinToTop(items []list.Item, pinned map[string]bool) {
+	starred := make([]list.Item, 0, len(pinned))
+	rest := make([]list.Item, 0, len(items))
+	for _, item := range items {
+		issueItem, ok := item.(IssueItem)
+		if !ok {
+			rest = append(rest, item)
+			continue
+		}
+		issueItem.Pinned = pinned[issueItem.Issue.ID]
+		if issueItem.Pinned {
+			starred = append(starred, issueItem)
+		} else {
+			rest = append(rest, issueItem)
+		}
+	}
+	copy(items, append(starred, rest...))
+}
+
+// togglePin stars or unstars issue id, saves the change, and re-sorts the
+// list with the selection kept on the issue.
+func (m *Model) togglePin(id string) {
+	pinned := !m.pinned[id]
+	if m.pinsPath != "" {
+		state, now, err := pins.Toggle(m.pinsPath, id)
+		if err != nil {
+			m.statusMsg = fmt.Sprintf("Can't save star: %v", err)
+			m.statusIsError = true
+			return
+		}
+		m.pinned, pinned = state.Set(), now
+	} else {
+		if m.pinned == nil {
+			m.pinned = make(map[string]bool)
+		}
+		if pinned {
+			m.pinned[id] = true
+		} else {
+			delete(m.pinned, id)
+		}
+	}
+
+	if m.activeRecipe != nil {
+		m.applyRecipe(m.activeRecipe)
+	} else {
+		m.applyFilter()
+	}
+	for i, item := range m.list.Items() {
+		if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+			m.list.Select(i)
+			break
+		}
+	}
+	m.updateViewportContent()
+	if m.isBoardView {
+		m.board.SelectIssueByID(id)
+	}
+
+	if pinned {
+		m.statusMsg = fmt.Sprintf("%s Starred %s", pinIcon(), id)
+	} else {
+		m.statusMsg = fmt.Sprintf("Unstarred %s", id)
+	}
+	m.statusIsError = false
+}
@@ -0,0 +1,80 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/pins"
+)
+
+func listIDs(m Model) []string {
+	var ids []string
+	for _, item := range m.list.Items() {
+		ids = append(ids, item.(IssueItem).Issue.ID)
+	}
+	return ids
+}
+
+func TestStarredIssuesListFirstAndPersist(t *testing.T) {
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	beadsPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(beadsPath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{
+		{ID: "st-1", Title: "Urgent", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug},
+		{ID: "st-2", Title: "Normal", Status: model.StatusOpen, Priority: 2, IssueType: model.TypeTask},
+		{ID: "st-3", Title: "Someday", Status: model.StatusOpen, Priority: 4, IssueType: model.TypeTask},
+	}
+	newModel := func() Model {
+		m := NewModel(append([]model.Issue(nil), issues...), nil, beadsPath)
+		t.Cleanup(m.Stop)
+		return m
+	}
+
+	m := newModel()
+	m, _ = pressVim(t, m, "j", "j", "*")
+	if got := listIDs(m); strings.Join(got, ",") != "st-3,st-1,st-2" {
+		t.Fatalf("after starring st-3: %v", got)
+	}
+	if sel := m.selectedListIssue(); sel == nil || sel.ID != "st-3" {
+		t.Errorf("selection should follow the starred issue, got %v", sel)
+	}
+	if !strings.Contains(m.statusMsg, "Starred st-3") {
+		t.Errorf("status = %q", m.statusMsg)
+	}
+	if row := m.list.Items()[0].(IssueItem); !row.Pinned {
+		t.Error("starred row should be flagged for the marker")
+	}
+
+	// Any sort mode keeps stars on top
+	m, _ = pressVim(t, m, "s")
+	if got := listIDs(m); got[0] != "st-3" {
+		t.Errorf("sort %v: %v", m.sortMode, got)
+	}
+
+	state, err := pins.Load(pins.DefaultPath(beadsDir))
+	if err != nil || !state.Has("st-3") {
+		t.Fatalf("star not saved: %+v, %v", state, err)
+	}
+
+	// A restart keeps the star; * again removes it
+	m.Stop()
+	m = newModel()
+	if got := listIDs(m); got[0] != "st-3" {
+		t.Fatalf("after restart: %v", got)
+	}
+	m, _ = pressVim(t, m, "*")
+	if got := listIDs(m); strings.Join(got, ",") != "st-1,st-2,st-3" {
+		t.Errorf("after unstarring: %v", got)
+	}
+	if state, _ := pins.Load(pins.DefaultPath(beadsDir)); state.Has("st-3") {
+		t.Error("unstar not saved")
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+)
+
+// Background pre-computation: while the user reads the list, Phase 2 runs
+// (with its progress shown in the insights view) and, once it is done, the
+// label views are computed ahead of time on the task scheduler. Opening one
+// of those views before its task ran computes it right away as visible
+// work, which pauses the rest.
+
+const (
+	taskLabelHealth = "label-health"
+	taskAttention   = "attention"
+)
+
+// phase2ProgressInterval is how often the insights view's Phase 2 progress
+// line is refreshed.
+const phase2ProgressInterval = 200 * time.Millisecond
+
+// phase2ProgressMsg asks Update to refresh the Phase 2 progress of stats.
+type phase2ProgressMsg struct {
+	stats *analysis.GraphStats
+}
+
+// labelHealthReadyMsg delivers label health computed in the background for
+// the analysis stats.
+type labelHealthReadyMsg struct {
+	stats  *analysis.GraphStats
+	result analysis.LabelAnalysisResult
+}
+
+// attentionReadyMsg delivers label attention scores computed in the
+// background for the analysis stats.
+type attentionReadyMsg struct {
+	stats  *analysis.GraphStats
+	result analysis.LabelAttentionResult
+}
+
+// Phase2ProgressCmd ticks until Phase 2 of stats is done, so the insights
+// view can show how far it got.
+func Phase2ProgressCmd(stats *analysis.GraphStats) tea.Cmd {
+	if stats == nil || stats.IsPhase2Ready() {
+		return nil
+	}
+	return tea.Tick(phase2ProgressInterval, func(time.Time) tea.Msg {
+		return phase2ProgressMsg{stats: stats}
+	})
+}
+
+// handlePhase2Progress updates the insights view's progress line and keeps
+// ticking while Phase 2 of the current analysis runs.
+func (m *Model) handlePhase2Progress(msg phase2ProgressMsg) tea.Cmd {
+	if msg.stats != m.analysis {
+		return nil
+	}
+	m.insightsPanel.SetPhase2Progress(msg.stats.Phase2Progress())
+	return Phase2ProgressCmd(msg.stats)
+}
+
+// schedulePrecompute queues the label views that aren't cached yet. The
+// tasks work on a copy of the issues, since m.issues may be re-sorted in
+// place meanwhile, and their results are dropped if the analysis changed.
+func (m *Model) schedulePrecompute() {
+	stats := m.analysis
+	issues := slices.Clone(m.issues)
+	cfg := analysis.DefaultLabelHealthConfig()
+	if !m.labelHealthCached {
+		m.scheduler.Submit(taskLabelHealth, func(ctx context.Context) tea.Msg {
+			result := analysis.ComputeAllLabelHealth(issues, cfg, time.Now().UTC(), stats)
+			if ctx.Err() != nil {
+				return nil
+			}
+			return labelHealthReadyMsg{stats: stats, result: result}
+		})
+	}
+	if !m.attentionCached {
+		m.scheduler.Submit(taskAttention, func(ctx context.Context) tea.Msg {
+			result := analysis.ComputeLabelAttentionScores(issues, cfg, time.Now().UTC())
+			if ctx.Err() != nil {
+				return nil
+			}
+			return attentionReadyMsg{stats: stats, result: result}
+		})
+	}
+}
+
+// ensureLabelHealth computes label health now if no cached result exists.
+func (m *Model) ensureLabelHealth() {
+	if m.labelHealthCached {
+		return
+	}
+	m.scheduler.RunVisible(taskLabelHealth, func() {
+		cfg := analysis.DefaultLabelHealthConfig()
+		m.labelHealthCache = analysis.ComputeAllLabelHealth(m.issues, cfg, time.Now().UTC(), m.analysis)
+		m.labelHealthCached = true
+	})
+}
+
+// ensureAttention computes label attention scores now if no cached result
+// exists.
+func (m *Model) ensureAttention() {
+	if m.attentionCached {
+		return
+	}
+	m.scheduler.RunVisible(taskAttention, func() {
+		cfg := analysis.DefaultLabelHealthConfig()
+		m.attentionCache = analysis.ComputeLabelAttentionScores(m.issues, cfg, time.Now().UTC())
+		m.attentionCached = true
+	})
+}
+
+// handleSchedulerResult applies a background task's result, unless it is
+// for a previous analysis or the view computed it first.
+func (m *Model) handleSchedulerResult(msg tea.Msg) {
+	switch msg := msg.(type) {
+	case labelHealthReadyMsg:
+		if msg.stats == m.analysis && !m.labelHealthCached {
+			m.labelHealthCache = msg.result
+			m.labelHealthCached = true
+		}
+	case attentionReadyMsg:
+		if msg.stats == m.analysis && !m.attentionCached {
+			m.attentionCache = msg.result
+			m.attentionCached = true
+		}
+	}
+}
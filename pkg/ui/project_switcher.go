@@ -0,0 +1,342 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// The project switcher (Ctrl+O) lists the beads repositories bv has opened,
+// most recent first, each with a health badge, and reopens bv on the one
+// picked without leaving the TUI. The list is per-user state (see
+// pkg/uistate); NewModel adds every project it opens.
+
+// projectHealth is a recent project's health badge.
+type projectHealth struct {
+	err     error   // Issues didn't load: repository moved, file unreadable
+	open    int     // Not closed
+	blocked int     // Open with an open blocker, or status blocked
+	score   float64 // analysis.ComputeHealthScore, 0-100
+}
+
+// projectHealthMsg carries the badges for the switcher, by beads path.
+type projectHealthMsg struct {
+	health map[string]projectHealth
+}
+
+// projectLoadedMsg carries the issues of the project picked in the switcher.
+type projectLoadedMsg struct {
+	project uistate.Project
+	issues  []model.Issue
+	err     error
+}
+
+// recordRecentProject adds the project at beadsPath to the front of the
+// switcher's list. Failures only cost the list an entry.
+func recordRecentProject(beadsPath string) {
+	if beadsPath == "" {
+		return
+	}
+	if path, err := uistate.RecentPath(); err == nil {
+		_ = uistate.RecordOpen(path, beadsPath, time.Now())
+	}
+}
+
+// loadProjectHealthCmd loads each project's issues and scores them, off
+// the UI goroutine.
+func loadProjectHealthCmd(projects []uistate.Project) tea.Cmd {
+	return func() tea.Msg {
+		health := make(map[string]projectHealth, len(projects))
+		for _, p := range projects {
+			issues, err := loader.LoadIssuesFromFile(p.BeadsPath)
+			if err != nil {
+				health[p.BeadsPath] = projectHealth{err: err}
+				continue
+			}
+			health[p.BeadsPath] = computeProjectHealth(issues)
+		}
+		return projectHealthMsg{health: health}
+	}
+}
+
+// computeProjectHealth counts open and blocked issues and scores the graph.
+func computeProjectHealth(issues []model.Issue) projectHealth {
+	h := projectHealth{score: analysis.ComputeHealthScore(issues).Score}
+	byID := make(map[string]*model.Issue, len(issues))
+	for i := range issues {
+		byID[issues[i].ID] = &issues[i]
+	}
+	for _, issue := range issues {
+		if isClosedLikeStatus(issue.Status) {
+			continue
+		}
+		h.open++
+		blocked := issue.Status == model.StatusBlocked
+		for _, dep := range issue.Dependencies {
+			if blocked {
+				break
+			}
+			if dep == nil || !dep.Type.IsBlocking() {
+				continue
+			}
+			if blocker, ok := byID[dep.DependsOnID]; ok && !isClosedLikeStatus(blocker.Status) {
+				blocked = true
+			}
+		}
+		if blocked {
+			h.blocked++
+		}
+	}
+	return h
+}
+
+// loadProjectCmd reads the issues of project p for switching to it.
+func loadProjectCmd(p uistate.Project) tea.Cmd {
+	return func() tea.Msg {
+		issues, err := loader.LoadIssuesFromFile(p.BeadsPath)
+		return projectLoadedMsg{project: p, issues: issues, err: err}
+	}
+}
+
+// ProjectSwitcherModel is the recent projects overlay.
+type ProjectSwitcherModel struct {
+	projects []uistate.Project
+	health   map[string]projectHealth // nil until loaded
+	current  string                   // Beads path of the open project
+	cursor   int
+	width    int
+	height   int
+	theme    Theme
+}
+
+// NewProjectSwitcherModel lists projects, with the cursor on the most
+// recent one that isn't currentBeadsPath.
+func NewProjectSwitcherModel(projects []uistate.Project, currentBeadsPath string, theme Theme) ProjectSwitcherModel {
+	m := ProjectSwitcherModel{
+		projects: projects,
+		current:  currentBeadsPath,
+		theme:    theme,
+	}
+	if len(projects) > 1 && projects[0].BeadsPath == currentBeadsPath {
+		m.cursor = 1
+	}
+	return m
+}
+
+// SetSize updates the overlay dimensions.
+func (m *ProjectSwitcherModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// SetHealth fills in the health badges.
+func (m *ProjectSwitcherModel) SetHealth(health map[string]projectHealth) {
+	m.health = health
+}
+
+// MoveUp moves the cursor up.
+func (m *ProjectSwitcherModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *ProjectSwitcherModel) MoveDown() {
+	if m.cursor < len(m.projects)-1 {
+		m.cursor++
+	}
+}
+
+// Selected returns the project under the cursor.
+func (m ProjectSwitcherModel) Selected() (uistate.Project, bool) {
+	if m.cursor < 0 || m.cursor >= len(m.projects) {
+		return uistate.Project{}, false
+	}
+	return m.projects[m.cursor], true
+}
+
+// badge renders a project's health: score, open and blocked counts.
+func (m ProjectSwitcherModel) badge(p uistate.Project) string {
+	t := m.theme
+	muted := t.Renderer.NewStyle().Foreground(t.Secondary)
+	h, ok := m.health[p.BeadsPath]
+	switch {
+	case m.health == nil:
+		return muted.Render("…")
+	case !ok:
+		return ""
+	case h.err != nil:
+		return t.Renderer.NewStyle().Foreground(t.Blocked).Render("✗ unavailable")
+	}
+	style := t.Renderer.NewStyle().Foreground(t.Blocked)
+	switch analysis.HealthLevelFromScore(int(h.score)) {
+	case analysis.HealthLevelHealthy:
+		style = style.Foreground(t.Open)
+	case analysis.HealthLevelWarning:
+		style = style.Foreground(t.Feature)
+	}
+	counts := fmt.Sprintf("%d open", h.open)
+	if h.blocked > 0 {
+		counts += fmt.Sprintf(" · %d blocked", h.blocked)
+	}
+	return style.Render(fmt.Sprintf("● %3.0f", h.score)) + " " + muted.Render(counts)
+}
+
+// View renders the overlay.
+func (m *ProjectSwitcherModel) View() string {
+	if m.width == 0 {
+		m.width = 100
+	}
+	if m.height == 0 {
+		m.height = 30
+	}
+	t := m.theme
+	boxWidth := min(100, max(40, m.width-6))
+	inner := boxWidth - 6
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+	pathStyle := t.Renderer.NewStyle().Foreground(t.Secondary)
+
+	lines := []string{titleStyle.Render("Recent Projects"), ""}
+	if len(m.projects) == 0 {
+		lines = append(lines, mutedStyle.Render("No projects yet: each repository you open in bv is added here."))
+	}
+
+	visible := max(3, (m.height-10)/2)
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	end := min(len(m.projects), start+visible)
+	home, _ := os.UserHomeDir()
+	for i := start; i < end; i++ {
+		p := m.projects[i]
+		prefix := "  "
+		nameStyle := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+		if i == m.cursor {
+			prefix = "▸ "
+			nameStyle = nameStyle.Foreground(t.Primary).Bold(true)
+		}
+		name := filepath.Base(p.Path)
+		if p.BeadsPath == m.current {
+			name += " (current)"
+		}
+		lines = append(lines, nameStyle.Render(prefix+truncate(name, inner/2))+"  "+m.badge(p))
+
+		where := p.Path
+		if home != "" && strings.HasPrefix(where, home+string(filepath.Separator)) {
+			where = "~" + where[len(home):]
+		}
+		lines = append(lines, pathStyle.Render("  "+truncate(where, inner-2)))
+	}
+	if end < len(m.projects) {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  … %d more", len(m.projects)-end)))
+	}
+
+	lines = append(lines, "", mutedStyle.Render("j/k: navigate • ⏎: open • esc: cancel"))
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+// openProjectSwitcher shows the recent projects and starts loading their
+// health badges.
+func (m Model) openProjectSwitcher() (Model, tea.Cmd) {
+	path, err := uistate.RecentPath()
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Recent projects unavailable: %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	recent, err := uistate.LoadRecent(path)
+	if err != nil {
+		m.statusMsg = fmt.Sprintf("Recent projects unavailable: %v", err)
+		m.statusIsError = true
+		return m, nil
+	}
+	current, _ := filepath.Abs(m.beadsPath)
+	if m.beadsPath == "" {
+		current = ""
+	}
+	m.projectSwitcher = NewProjectSwitcherModel(recent.Projects, current, m.theme)
+	m.projectSwitcher.SetSize(m.width, m.height-1)
+	m.showProjectSwitcher = true
+	return m, loadProjectHealthCmd(recent.Projects)
+}
+
+// handleProjectSwitcherKeys handles keyboard input in the project switcher.
+func (m Model) handleProjectSwitcherKeys(msg tea.KeyMsg) (Model, tea.Cmd) {
+	switch msg.String() {
+	case "j", "down":
+		m.projectSwitcher.MoveDown()
+	case "k", "up":
+		m.projectSwitcher.MoveUp()
+	case "esc", "q", "ctrl+o":
+		m.showProjectSwitcher = false
+	case "enter":
+		p, ok := m.projectSwitcher.Selected()
+		if !ok {
+			return m, nil
+		}
+		m.showProjectSwitcher = false
+		if p.BeadsPath == m.projectSwitcher.current {
+			m.statusMsg = fmt.Sprintf("Already in %s", filepath.Base(p.Path))
+			m.statusIsError = false
+			return m, nil
+		}
+		m.statusMsg = fmt.Sprintf("Opening %s…", filepath.Base(p.Path))
+		m.statusIsError = false
+		return m, loadProjectCmd(p)
+	}
+	return m, nil
+}
+
+// switchProject replaces the model with one for the loaded project, as if
+// bv had been started there: the working directory moves to the project,
+// and this project's UI state is saved first.
+func (m Model) switchProject(msg projectLoadedMsg) (tea.Model, tea.Cmd) {
+	name := filepath.Base(msg.project.Path)
+	if msg.err != nil {
+		m.statusMsg = fmt.Sprintf("Can't open %s: %v", name, msg.err)
+		m.statusIsError = true
+		return m, nil
+	}
+	if err := os.Chdir(msg.project.Path); err != nil {
+		m.statusMsg = fmt.Sprintf("Can't open %s: %v", name, err)
+		m.statusIsError = true
+		return m, nil
+	}
+	// bd would keep writing to the old project otherwise
+	if _, ok := os.LookupEnv(loader.BeadsDirEnvVar); ok {
+		_ = os.Setenv(loader.BeadsDirEnvVar, filepath.Dir(msg.project.BeadsPath))
+	}
+	_ = m.SaveUIState()
+	m.Stop()
+
+	next := NewModel(msg.issues, nil, msg.project.BeadsPath)
+	next.bdRunner = m.bdRunner
+	sized, sizeCmd := next.Update(tea.WindowSizeMsg{Width: m.width, Height: m.height})
+	next = sized.(Model)
+	if next.statusMsg == "" {
+		next.statusMsg = fmt.Sprintf("Switched to %s", name)
+		next.statusIsError = false
+	}
+	return next, tea.Batch(next.Init(), sizeCmd)
+}
@@ -0,0 +1,106 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+)
+
+// writeTestRepo creates a repository holding issues as JSONL lines and
+// returns its beads path.
+func writeTestRepo(t *testing.T, lines ...string) string {
+	t.Helper()
+	repo := t.TempDir()
+	beadsDir := filepath.Join(repo, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestProjectSwitcher(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	api := writeTestRepo(t,
+		`{"id":"api-1","title":"Auth","status":"open","priority":1,"issue_type":"task"}`)
+	web := writeTestRepo(t,
+		`{"id":"web-1","title":"Login page","status":"open","priority":1,"issue_type":"task"}`,
+		`{"id":"web-2","title":"Styles","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"web-2","depends_on_id":"web-1","type":"blocks"}]}`)
+	gone := writeTestRepo(t)
+
+	// Opening each project lists it, most recent first
+	for _, path := range []string{gone, web, api} {
+		issues, err := loader.LoadIssuesFromFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := NewModel(issues, nil, path)
+		m.Stop()
+	}
+	if err := os.Remove(gone); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(filepath.Dir(filepath.Dir(api)))
+	issues, _ := loader.LoadIssuesFromFile(api)
+	m := NewModel(issues, nil, api)
+	t.Cleanup(func() { m.Stop() })
+	m, _ = pressVim(t, m, "ctrl+o")
+	if !m.showProjectSwitcher || m.CurrentContext() != ContextProjectSwitcher {
+		t.Fatal("ctrl+o should open the project switcher")
+	}
+	if n := len(m.projectSwitcher.projects); n != 3 {
+		t.Fatalf("%d projects listed, want 3", n)
+	}
+	if p, _ := m.projectSwitcher.Selected(); p.BeadsPath != web {
+		t.Errorf("cursor on %s, want the previous project %s", p.BeadsPath, web)
+	}
+
+	health := loadProjectHealthCmd(m.projectSwitcher.projects)().(projectHealthMsg)
+	if h := health.health[web]; h.err != nil || h.open != 2 || h.blocked != 1 {
+		t.Errorf("web health = %+v, want 2 open, 1 blocked", h)
+	}
+	if h := health.health[gone]; h.err == nil {
+		t.Error("a removed project should be unavailable")
+	}
+	next, _ := m.Update(health)
+	m = next.(Model)
+	view := m.projectSwitcher.View()
+	for _, want := range []string{"(current)", "2 open · 1 blocked", "unavailable"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("switcher view missing %q", want)
+		}
+	}
+
+	// Enter loads the project, then bv reopens there
+	m, cmd := pressVim(t, m, "enter")
+	if m.showProjectSwitcher || cmd == nil {
+		t.Fatal("enter should close the switcher and load the project")
+	}
+	next, _ = m.Update(cmd())
+	m = next.(Model)
+	if m.beadsPath != web {
+		t.Fatalf("beads path %s, want %s", m.beadsPath, web)
+	}
+	if len(m.issues) != 2 {
+		t.Errorf("%d issues, want web's 2", len(m.issues))
+	}
+	if wd, _ := os.Getwd(); wd != filepath.Dir(filepath.Dir(web)) {
+		t.Errorf("working directory %s, want the web repository", wd)
+	}
+
+	// The switch moved web to the front
+	m, _ = pressVim(t, m, "ctrl+o")
+	if p := m.projectSwitcher.projects[0]; p.BeadsPath != web {
+		t.Errorf("most recent project %s, want %s", p.BeadsPath, web)
+	}
+	m, _ = pressVim(t, m, "esc")
+	if m.showProjectSwitcher {
+		t.Error("esc should close the switcher")
+	}
+}
@@ -0,0 +1,170 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// referenceKind distinguishes what an issue reference points at.
+type referenceKind int
+
+const (
+	referenceURL referenceKind = iota
+	referenceFile
+)
+
+// issueReference is a URL or file path mentioned in an issue's text.
+type issueReference struct {
+	Kind   referenceKind
+	Target string // URL, or path relative to the repo root (may be absolute)
+}
+
+var (
+	// referenceURLPattern matches http(s) links up to whitespace or common
+	// markdown delimiters.
+	referenceURLPattern = regexp.MustCompile("https?://[^\\s<>()\\[\\]\"'`]+")
+
+	// referencePathPattern matches path-like tokens that have a directory
+	// component and a file extension, with an optional :line suffix, e.g.
+	// "pkg/ui/model.go", "./docs/design.md:12", "/etc/app.conf". A trailing
+	// :line is consumed but not kept.
+	referencePathPattern = regexp.MustCompile(`(?:^|[\s(\[` + "`" + `"'])((?:\.{0,2}/)?(?:[\w.-]+/)+[\w.-]+\.[A-Za-z0-9]+)(?::\d+)?`)
+)
+
+// extractReferences returns the URLs and file paths mentioned in text, in
+// order of appearance and without duplicates.
+func extractReferences(text string) []issueReference {
+	var refs []issueReference
+	seen := make(map[string]bool)
+
+	for _, raw := range referenceURLPattern.FindAllString(text, -1) {
+		url := strings.TrimRight(raw, ".,;:!?")
+		if url == "" || seen[url] {
+			continue
+		}
+		seen[url] = true
+		refs = append(refs, issueReference{Kind: referenceURL, Target: url})
+	}
+
+	// Blank out URLs so their path segments aren't picked up as files.
+	stripped := referenceURLPattern.ReplaceAllStringFunc(text, func(s string) string {
+		return strings.Repeat(" ", len(s))
+	})
+	for _, m := range referencePathPattern.FindAllStringSubmatch(stripped, -1) {
+		path := m[1]
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		refs = append(refs, issueReference{Kind: referenceFile, Target: path})
+	}
+
+	return refs
+}
+
+// issueReferences collects references from every free-text field of an issue.
+// The external ref comes first since it is the issue's canonical source.
+func issueReferences(issue model.Issue) []issueReference {
+	var parts []string
+	if issue.ExternalRef != nil {
+		parts = append(parts, *issue.ExternalRef)
+	}
+	parts = append(parts, issue.Description, issue.Design, issue.AcceptanceCriteria, issue.Notes)
+	return extractReferences(strings.Join(parts, "\n"))
+}
+
+// repoRootFromBeadsPath derives the repository root from the beads file path.
+// Standard layout is <repo_root>/.beads/<file.jsonl>; legacy flat layout is
+// <repo_root>/<file.jsonl>. Returns "" if beadsPath is empty.
+func repoRootFromBeadsPath(beadsPath string) string {
+	if beadsPath == "" {
+		return ""
+	}
+	absPath, err := filepath.Abs(beadsPath)
+	if err != nil {
+		return ""
+	}
+	dir := filepath.Dir(absPath)
+	if filepath.Base(dir) == ".beads" {
+		return filepath.Dir(dir)
+	}
+	return dir
+}
+
+// resolveReferencePath resolves a file reference against the repo root.
+func resolveReferencePath(target, repoRoot string) string {
+	if filepath.IsAbs(target) || repoRoot == "" {
+		return filepath.Clean(target)
+	}
+	return filepath.Join(repoRoot, target)
+}
+
+// openIssueReference opens the selected issue's next reference of the given
+// kind: URLs in the browser, file paths in the GUI editor. Pressing the key
+// again on the same issue cycles through the remaining references.
+func (m *Model) openIssueReference(kind referenceKind) {
+	selectedItem := m.list.SelectedItem()
+	issueItem, ok := selectedItem.(IssueItem)
+	if selectedItem == nil || !ok {
+		m.statusMsg = "❌ No issue selected"
+		m.statusIsError = true
+		return
+	}
+	issue := issueItem.Issue
+
+	var refs []issueReference
+	for _, ref := range issueReferences(issue) {
+		if ref.Kind == kind {
+			refs = append(refs, ref)
+		}
+	}
+	noun := "URL"
+	if kind == referenceFile {
+		noun = "file path"
+	}
+	if len(refs) == 0 {
+		m.statusMsg = fmt.Sprintf("No %s found in %s", noun, issue.ID)
+		m.statusIsError = false
+		return
+	}
+
+	cycleKey := fmt.Sprintf("%s/%d", issue.ID, kind)
+	if m.refCycleKey != cycleKey {
+		m.refCycleKey = cycleKey
+		m.refCycleIdx = 0
+	}
+	ref := refs[m.refCycleIdx%len(refs)]
+	m.refCycleIdx++
+
+	position := ""
+	if len(refs) > 1 {
+		position = fmt.Sprintf(" [%d/%d]", (m.refCycleIdx-1)%len(refs)+1, len(refs))
+	}
+
+	switch kind {
+	case referenceURL:
+		if err := openBrowserURL(ref.Target); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Failed to open browser: %v", err)
+			m.statusIsError = true
+			return
+		}
+		m.statusMsg = fmt.Sprintf("🌐 Opened %s%s", ref.Target, position)
+		m.statusIsError = false
+	case referenceFile:
+		path := resolveReferencePath(ref.Target, repoRootFromBeadsPath(m.beadsPath))
+		if _, err := os.Stat(path); err != nil {
+			m.statusMsg = fmt.Sprintf("❌ Referenced file not found: %s%s", ref.Target, position)
+			m.statusIsError = true
+			return
+		}
+		m.openFileInEditor(path)
+		if !m.statusIsError {
+			m.statusMsg += fmt.Sprintf(": %s%s", ref.Target, position)
+		}
+	}
+}
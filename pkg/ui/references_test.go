@@ -0,0 +1,112 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestExtractReferences(t *testing.T) {
+	text := "See the design doc (https://example.com/docs/design.md#auth), " +
+		"and `pkg/ui/model.go:120` plus ./docs/plan.md.\n" +
+		"Again: https://example.com/docs/design.md#auth and pkg/ui/model.go"
+
+	refs := extractReferences(text)
+	var got []string
+	for _, r := range refs {
+		prefix := "url:"
+		if r.Kind == referenceFile {
+			prefix = "file:"
+		}
+		got = append(got, prefix+r.Target)
+	}
+	want := []string{
+		"url:https://example.com/docs/design.md#auth",
+		"file:pkg/ui/model.go",
+		"file:./docs/plan.md",
+	}
+	if strings.Join(got, "|") != strings.Join(want, "|") {
+		t.Fatalf("extractReferences = %v, want %v", got, want)
+	}
+}
+
+func TestExtractReferences_IgnoresPlainWords(t *testing.T) {
+	if refs := extractReferences("Fix the bug in v1.2 and/or e.g. retry"); len(refs) != 0 {
+		t.Fatalf("expected no references, got %+v", refs)
+	}
+}
+
+func TestIssueReferences_ExternalRefFirst(t *testing.T) {
+	ext := "https://tracker.example.com/T-1"
+	issue := model.Issue{
+		ID:          "bv-1",
+		Description: "Spec at https://example.com/spec",
+		ExternalRef: &ext,
+	}
+	refs := issueReferences(issue)
+	if len(refs) != 2 || refs[0].Target != ext {
+		t.Fatalf("expected external ref first, got %+v", refs)
+	}
+}
+
+func TestRepoRootFromBeadsPath(t *testing.T) {
+	tmp := t.TempDir()
+	if got := repoRootFromBeadsPath(filepath.Join(tmp, ".beads", "issues.jsonl")); got != tmp {
+		t.Errorf("standard layout: got %q, want %q", got, tmp)
+	}
+	if got := repoRootFromBeadsPath(filepath.Join(tmp, "issues.jsonl")); got != tmp {
+		t.Errorf("flat layout: got %q, want %q", got, tmp)
+	}
+	if got := repoRootFromBeadsPath(""); got != "" {
+		t.Errorf("empty path: got %q", got)
+	}
+}
+
+func TestOpenIssueReference(t *testing.T) {
+	t.Setenv("BV_NO_BROWSER", "1")
+	t.Setenv("EDITOR", "vim") // terminal editor guard: no exec
+
+	tmp := t.TempDir()
+	beadsPath := filepath.Join(tmp, ".beads", "issues.jsonl")
+	_ = os.MkdirAll(filepath.Join(tmp, "docs"), 0o755)
+	_ = os.WriteFile(filepath.Join(tmp, "docs", "plan.md"), []byte("# plan"), 0o644)
+
+	issues := []model.Issue{{
+		ID:          "bv-1",
+		Title:       "x",
+		Status:      model.StatusOpen,
+		Description: "https://a.example.com/1 https://b.example.com/2 docs/plan.md docs/missing.md",
+	}}
+	m := NewModel(issues, nil, beadsPath)
+
+	m.openIssueReference(referenceURL)
+	if m.statusIsError || !strings.Contains(m.statusMsg, "a.example.com") || !strings.Contains(m.statusMsg, "[1/2]") {
+		t.Fatalf("first URL: got %q", m.statusMsg)
+	}
+	m.openIssueReference(referenceURL)
+	if !strings.Contains(m.statusMsg, "b.example.com") || !strings.Contains(m.statusMsg, "[2/2]") {
+		t.Fatalf("second URL should cycle, got %q", m.statusMsg)
+	}
+
+	// The existing file resolves against the repo root and reaches the
+	// editor guard; the missing one reports not found.
+	m.openIssueReference(referenceFile)
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "terminal editor") {
+		t.Fatalf("expected editor guard for docs/plan.md, got %q", m.statusMsg)
+	}
+	m.openIssueReference(referenceFile)
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "not found") {
+		t.Fatalf("expected not found for docs/missing.md, got %q", m.statusMsg)
+	}
+}
+
+func TestOpenIssueReference_NoneFound(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "bv-1", Title: "x", Status: model.StatusOpen}}, nil, "")
+	m.openIssueReference(referenceURL)
+	if m.statusIsError || !strings.Contains(m.statusMsg, "No URL found") {
+		t.Fatalf("got %q", m.statusMsg)
+	}
+}
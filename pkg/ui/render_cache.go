@@ -0,0 +1,57 @@
+package ui
+
+import (
+	"fmt"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+)
+
+// renderCache remembers a view's last rendered output and the state it was
+// rendered for. The TUI redraws after every message, most of which (worker
+// polls, ticks, status updates) leave the board, graph and tree exactly as
+// they were; with the cache those redraws cost a key comparison instead of
+// a full re-layout. Hits and misses are counted in metrics.RenderCache.
+//
+// Views hold the cache by pointer so value-receiver View methods can fill
+// it; a nil cache renders every time.
+type renderCache struct {
+	key    string
+	output string
+}
+
+func newRenderCache() *renderCache {
+	return &renderCache{}
+}
+
+// render returns the cached output if key matches the last render, and
+// otherwise calls draw and caches its result under key.
+func (c *renderCache) render(key string, draw func() string) string {
+	if c == nil {
+		return draw()
+	}
+	if c.key == key {
+		metrics.RenderCache.Hit()
+		return c.output
+	}
+	metrics.RenderCache.Miss()
+	c.output = draw()
+	c.key = key
+	return c.output
+}
+
+// issuesRenderHash identifies the content of the issues a view shows. Views
+// compute it when their data is set, not per frame.
+func issuesRenderHash(issues []model.Issue) string {
+	return analysis.ComputeDataHash(issues)
+}
+
+// renderClock is the wall-clock minute and the timestamp style, for keys of
+// views that print times ("5m ago"), so their cached output ages with the
+// clock and follows the relative/absolute toggle.
+func renderClock() string {
+	o := timefmt.Current()
+	return fmt.Sprintf("%d|%s|%s", timefmt.Now().Unix()/60, o.Style, o.Zone())
+}
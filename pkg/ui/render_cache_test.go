@@ -0,0 +1,84 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/metrics"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func renderCacheIssues() []model.Issue {
+	return []model.Issue{
+		{ID: "rc-1", Title: "Epic", Status: model.StatusOpen, IssueType: model.TypeEpic},
+		{ID: "rc-2", Title: "Child", Status: model.StatusInProgress, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "rc-2", DependsOnID: "rc-1", Type: model.DepParentChild}}},
+		{ID: "rc-3", Title: "Blocked", Status: model.StatusBlocked, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "rc-3", DependsOnID: "rc-2", Type: model.DepBlocks}}},
+	}
+}
+
+func enableMetrics(t *testing.T) {
+	was := metrics.Enabled()
+	metrics.SetEnabled(true)
+	t.Cleanup(func() { metrics.SetEnabled(was) })
+}
+
+// expectRender calls view and checks whether it was served from the cache.
+func expectRender(t *testing.T, what string, wantHit bool, view func() string) string {
+	t.Helper()
+	hits, misses := metrics.RenderCache.Hits(), metrics.RenderCache.Misses()
+	out := view()
+	gotHit := metrics.RenderCache.Hits() == hits+1 && metrics.RenderCache.Misses() == misses
+	if gotHit != wantHit {
+		t.Errorf("%s: cache hit = %t, want %t", what, gotHit, wantHit)
+	}
+	return out
+}
+
+func TestRenderCache_Board(t *testing.T) {
+	enableMetrics(t)
+	b := NewBoardModel(renderCacheIssues(), newTestTheme())
+
+	first := expectRender(t, "first render", false, func() string { return b.View(120, 30) })
+	again := expectRender(t, "idle redraw", true, func() string { return b.View(120, 30) })
+	if again != first {
+		t.Error("cached output differs from the first render")
+	}
+	expectRender(t, "resize", false, func() string { return b.View(100, 30) })
+	b.MoveRight()
+	expectRender(t, "selection moved", false, func() string { return b.View(100, 30) })
+
+	issues := renderCacheIssues()
+	issues[0].Title = "Renamed epic"
+	b.SetIssues(issues)
+	expectRender(t, "issue edited", false, func() string { return b.View(100, 30) })
+	b.SetIssues(issues)
+	expectRender(t, "same issues set again", true, func() string { return b.View(100, 30) })
+}
+
+func TestRenderCache_Graph(t *testing.T) {
+	enableMetrics(t)
+	g := NewGraphModel(renderCacheIssues(), nil, newTestTheme())
+
+	expectRender(t, "first render", false, func() string { return g.View(120, 30) })
+	expectRender(t, "idle redraw", true, func() string { return g.View(120, 30) })
+	g.MoveDown()
+	expectRender(t, "selection moved", false, func() string { return g.View(120, 30) })
+	g.ToggleCondensed()
+	expectRender(t, "condensed", false, func() string { return g.View(120, 30) })
+}
+
+func TestRenderCache_Tree(t *testing.T) {
+	enableMetrics(t)
+	tree := NewTreeModel(newTestTheme())
+	tree.SetBeadsDir(t.TempDir())
+	tree.Build(renderCacheIssues())
+	tree.SetSize(80, 20)
+
+	expectRender(t, "first render", false, tree.View)
+	expectRender(t, "idle redraw", true, tree.View)
+	tree.ToggleExpand()
+	expectRender(t, "node toggled", false, tree.View)
+	tree.MoveDown()
+	expectRender(t, "cursor moved", false, tree.View)
+}
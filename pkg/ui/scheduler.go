@@ -0,0 +1,199 @@
+package ui
+
+import (
+	"context"
+	"sync"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// taskScheduler runs the TUI's deferred computations one at a time, so
+// precomputing views in the background never competes with each other for
+// the CPU. Work the user is waiting on (RunVisible) preempts it: a running
+// background task is canceled and requeued, and nothing new starts until
+// the visible work is done.
+//
+// Tasks are keyed by name. Submitting a name that is already queued
+// replaces the queued task, and a visible run of a name drops its queued
+// background task, since the caller just computed the same thing.
+type taskScheduler struct {
+	mu      sync.Mutex
+	queue   []*scheduledTask
+	running *scheduledTask
+	cancel  context.CancelFunc
+	visible int  // RunVisible calls in progress
+	started bool // worker goroutine launched
+	stopped bool
+	wake    chan struct{}
+	out     chan tea.Msg
+}
+
+// scheduledTask is one unit of background work. run should check ctx and
+// return nil once it is canceled. A preempted task's message is discarded
+// and the task runs again later.
+type scheduledTask struct {
+	name string
+	run  func(ctx context.Context) tea.Msg
+}
+
+// schedulerResultMsg carries a background task's message to Update, which
+// handles the inner message and waits for the next result.
+type schedulerResultMsg struct {
+	msg tea.Msg
+}
+
+func newTaskScheduler() *taskScheduler {
+	return &taskScheduler{
+		wake: make(chan struct{}, 1),
+		out:  make(chan tea.Msg, 16),
+	}
+}
+
+// Submit queues a background task, replacing a queued task of the same name.
+// The worker goroutine starts on the first call.
+func (s *taskScheduler) Submit(name string, run func(ctx context.Context) tea.Msg) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return
+	}
+	task := &scheduledTask{name: name, run: run}
+	replaced := false
+	for i, queued := range s.queue {
+		if queued.name == name {
+			s.queue[i] = task
+			replaced = true
+			break
+		}
+	}
+	if !replaced {
+		s.queue = append(s.queue, task)
+	}
+	if !s.started {
+		s.started = true
+		go s.loop()
+	}
+	s.signal()
+}
+
+// RunVisible runs fn on the calling goroutine with background work paused.
+// A queued or running background task of the same name is dropped.
+func (s *taskScheduler) RunVisible(name string, fn func()) {
+	if s == nil {
+		fn()
+		return
+	}
+	s.mu.Lock()
+	s.visible++
+	s.remove(name)
+	if s.running != nil {
+		if s.running.name != name {
+			s.queue = append([]*scheduledTask{s.running}, s.queue...)
+		}
+		s.running = nil
+		s.cancel()
+	}
+	s.mu.Unlock()
+
+	defer func() {
+		s.mu.Lock()
+		s.visible--
+		s.signal()
+		s.mu.Unlock()
+	}()
+	fn()
+}
+
+// Pending reports how many background tasks are queued or running.
+func (s *taskScheduler) Pending() int {
+	if s == nil {
+		return 0
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	n := len(s.queue)
+	if s.running != nil {
+		n++
+	}
+	return n
+}
+
+// Stop cancels the running task and drops the queue.
+func (s *taskScheduler) Stop() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+	s.queue = nil
+	if s.running != nil {
+		s.running = nil
+		s.cancel()
+	}
+	s.signal()
+}
+
+// remove drops the queued task called name. Callers hold s.mu.
+func (s *taskScheduler) remove(name string) {
+	for i, queued := range s.queue {
+		if queued.name == name {
+			s.queue = append(s.queue[:i], s.queue[i+1:]...)
+			return
+		}
+	}
+}
+
+// signal wakes the worker without blocking. Callers hold s.mu.
+func (s *taskScheduler) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (s *taskScheduler) loop() {
+	for {
+		s.mu.Lock()
+		if s.stopped {
+			s.mu.Unlock()
+			return
+		}
+		if s.visible > 0 || len(s.queue) == 0 {
+			s.mu.Unlock()
+			<-s.wake
+			continue
+		}
+		task := s.queue[0]
+		s.queue = s.queue[1:]
+		ctx, cancel := context.WithCancel(context.Background())
+		s.running, s.cancel = task, cancel
+		s.mu.Unlock()
+
+		msg := task.run(ctx)
+
+		s.mu.Lock()
+		preempted := s.running != task
+		if !preempted {
+			s.running = nil
+		}
+		s.mu.Unlock()
+		cancel()
+		if !preempted && msg != nil {
+			s.out <- msg
+		}
+	}
+}
+
+// WaitForSchedulerMsgCmd waits for the next background task result.
+func WaitForSchedulerMsgCmd(s *taskScheduler) tea.Cmd {
+	if s == nil {
+		return nil
+	}
+	return func() tea.Msg {
+		return schedulerResultMsg{msg: <-s.out}
+	}
+}
@@ -0,0 +1,135 @@
+package ui
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func nextResult(t *testing.T, s *taskScheduler) tea.Msg {
+	t.Helper()
+	select {
+	case msg := <-s.out:
+		return msg
+	case <-time.After(5 * time.Second):
+		t.Fatal("no task result")
+		return nil
+	}
+}
+
+func TestSchedulerVisibleWorkPreemptsBackground(t *testing.T) {
+	s := newTaskScheduler()
+	defer s.Stop()
+
+	started := make(chan struct{}, 2)
+	runs := 0
+	s.Submit("slow", func(ctx context.Context) tea.Msg {
+		runs++
+		started <- struct{}{}
+		if runs == 1 {
+			<-ctx.Done() // the first run is preempted
+			return nil
+		}
+		return "slow done"
+	})
+	s.Submit("other", func(context.Context) tea.Msg { return "other done" })
+	<-started
+
+	ranVisible := false
+	s.RunVisible("lookup", func() {
+		ranVisible = true
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		if s.running != nil {
+			t.Error("background task still running during visible work")
+		}
+	})
+	if !ranVisible {
+		t.Fatal("visible work did not run")
+	}
+
+	// The preempted task runs again first, then the rest of the queue
+	if got := nextResult(t, s); got != "slow done" {
+		t.Errorf("first result = %v", got)
+	}
+	if got := nextResult(t, s); got != "other done" {
+		t.Errorf("second result = %v", got)
+	}
+	if runs != 2 {
+		t.Errorf("slow task ran %d times, want 2", runs)
+	}
+}
+
+func TestSchedulerDeduplicatesByName(t *testing.T) {
+	s := newTaskScheduler()
+	defer s.Stop()
+
+	block := make(chan struct{})
+	s.Submit("first", func(context.Context) tea.Msg { <-block; return "first" })
+	s.Submit("labels", func(context.Context) tea.Msg { return "stale" })
+	s.Submit("labels", func(context.Context) tea.Msg { return "fresh" })
+	s.Submit("attention", func(context.Context) tea.Msg { return "attention" })
+	s.RunVisible("attention", func() {}) // computed by the view: drop it
+	close(block)
+
+	if got := nextResult(t, s); got != "first" {
+		t.Errorf("result = %v", got)
+	}
+	if got := nextResult(t, s); got != "fresh" {
+		t.Errorf("result = %v, want the replacement task's", got)
+	}
+	select {
+	case msg := <-s.out:
+		t.Errorf("unexpected result %v", msg)
+	case <-time.After(50 * time.Millisecond):
+	}
+	if n := s.Pending(); n != 0 {
+		t.Errorf("%d tasks pending", n)
+	}
+}
+
+func TestPrecomputeFillsLabelCachesInBackground(t *testing.T) {
+	issues := []model.Issue{
+		{ID: "a-1", Title: "One", Status: model.StatusOpen, IssueType: model.TypeTask, Labels: []string{"api"}},
+		{ID: "a-2", Title: "Two", Status: model.StatusOpen, IssueType: model.TypeTask, Labels: []string{"ui"}},
+	}
+	m := NewModel(issues, nil, "")
+	defer m.Stop()
+	m.analysis.WaitForPhase2()
+	next, _ := m.Update(Phase2ReadyMsg{Stats: m.analysis})
+	m = next.(Model)
+
+	for !m.labelHealthCached || !m.attentionCached {
+		next, _ = m.Update(WaitForSchedulerMsgCmd(m.scheduler)())
+		m = next.(Model)
+	}
+	if m.labelHealthCache.TotalLabels != 2 {
+		t.Errorf("label health covers %d labels", m.labelHealthCache.TotalLabels)
+	}
+
+	// Results for an earlier analysis are dropped
+	m.labelHealthCached = false
+	m.handleSchedulerResult(labelHealthReadyMsg{stats: nil})
+	if m.labelHealthCached {
+		t.Error("stale label health was applied")
+	}
+}
+
+func TestInsightsShowPhase2Progress(t *testing.T) {
+	m := NewInsightsModel(analysis.Insights{}, nil, newTestTheme())
+	m.SetSize(140, 40)
+	m.SetPhase2Progress(3, 8)
+	if !strings.Contains(m.View(), "Computing graph metrics") {
+		t.Error("progress line missing while Phase 2 runs")
+	}
+	m.SetPhase2Progress(8, 8)
+	if strings.Contains(m.View(), "Computing graph metrics") {
+		t.Error("progress line shown after Phase 2 finished")
+	}
+}
@@ -478,6 +478,12 @@ func BuildHybridMetricsCmd(issues []model.Issue) tea.Cmd {
 
 // BuildSemanticIndexCmd builds or updates the semantic index for the given issues.
 func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
+	return buildSemanticIndexCmd(issues, true)
+}
+
+// buildSemanticIndexCmd is BuildSemanticIndexCmd with control over whether
+// an updated index is written back to disk (read-only instances don't).
+func buildSemanticIndexCmd(issues []model.Issue, persist bool) tea.Cmd {
 	return func() tea.Msg {
 		cfg := search.EmbeddingConfigFromEnv()
 		embedder, err := search.NewEmbedderFromConfig(cfg)
@@ -504,7 +510,7 @@ func BuildSemanticIndexCmd(issues []model.Issue) tea.Cmd {
 		if err != nil {
 			return SemanticIndexReadyMsg{Error: err}
 		}
-		if !loaded || stats.Changed() {
+		if persist && (!loaded || stats.Changed()) {
 			if err := idx.Save(indexPath); err != nil {
 				return SemanticIndexReadyMsg{Error: fmt.Errorf("save semantic index: %w", err)}
 			}
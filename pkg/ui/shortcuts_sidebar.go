@@ -1,9 +1,9 @@
 package ui
 
 import (
-	"fmt"
 	"strings"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/i18n"
 	"github.com/charmbracelet/lipgloss"
 )
 
@@ -25,9 +25,8 @@ type shortcutItem struct {
 
 // shortcutSection groups shortcuts by category
 type shortcutSection struct {
-	title    string
-	items    []shortcutItem
-	contexts []string // Which contexts this section applies to (empty = all)
+	title string
+	items []shortcutItem
 }
 
 // NewShortcutsSidebar creates a new shortcuts sidebar
@@ -85,108 +84,19 @@ func (s *ShortcutsSidebar) Width() int {
 	return s.width
 }
 
-// allSections returns all shortcut sections with their contexts
-func (s *ShortcutsSidebar) allSections() []shortcutSection {
-	return []shortcutSection{
-		{
-			title:    "Navigation",
-			contexts: []string{}, // All contexts
-			items: []shortcutItem{
-				{"j/k", "Move ↓/↑"},
-				{"G/gg", "End/Start"},
-				{"^d/^u", "Page ↓/↑"},
-				{"Enter", "Details"},
-				{"Esc", "Back"},
-			},
-		},
-		{
-			title:    "Views",
-			contexts: []string{"list", "detail", "split"},
-			items: []shortcutItem{
-				{"a", "Actionable"},
-				{"b", "Board"},
-				{"g", "Graph"},
-				{"h", "History"},
-				{"i", "Insights"},
-				{"?", "Help"},
-				{";", "This sidebar"},
-				{"p", "Priority hints"},
-			},
-		},
-		{
-			title:    "Graph",
-			contexts: []string{"graph"},
-			items: []shortcutItem{
-				{"hjkl", "Navigate"},
-				{"H/L", "Scroll ←/→"},
-				{"PgUp/Dn", "Scroll ↑/↓"},
-				{"Enter", "Jump to issue"},
-			},
-		},
-		{
-			title:    "Insights",
-			contexts: []string{"insights"},
-			items: []shortcutItem{
-				{"h/l", "Switch panel"},
-				{"j/k", "Select item"},
-				{"^j/^k", "Scroll detail"},
-				{"e", "Explanations"},
-				{"x", "Calc proof"},
-				{"m", "Heatmap"},
-				{"Enter", "Jump to issue"},
-			},
-		},
-		{
-			title:    "History",
-			contexts: []string{"history"},
-			items: []shortcutItem{
-				{"v", "Git/Bead mode"},
-				{"/", "Search"},
-				{"j/k", "Navigate ↓/↑"},
-				{"J/K", "Detail ↓/↑"},
-				{"Tab", "Focus toggle"},
-				{"y", "Copy SHA"},
-				{"o", "Open in browser"},
-				{"g", "Graph view"},
-				{"c", "Cycle filter"},
-			},
-		},
-		{
-			title:    "Board",
-			contexts: []string{"board"},
-			items: []shortcutItem{
-				{"h/l", "Columns ←/→"},
-				{"j/k", "Items ↓/↑"},
-				{"Tab", "Toggle detail"},
-				{"^j/^k", "Scroll detail"},
-				{"Enter", "Full view"},
-			},
-		},
-		{
-			title:    "Filters",
-			contexts: []string{"list", "split"},
-			items: []shortcutItem{
-				{"o", "Open only"},
-				{"c", "Closed only"},
-				{"r", "Ready (no blocks)"},
-				{"l", "Label picker"},
-				{"/", "Search"},
-			},
-		},
-		{
-			title:    "Actions",
-			contexts: []string{"list", "detail", "split"},
-			items: []shortcutItem{
-				{"t/T", "Time-travel"},
-				{"x", "Export .md"},
-				{"C", "Copy"},
-				{"O", "Open in $EDITOR"},
-				{"'", "Recipe picker"},
-				{"U", "Self-update"},
-				{"V", "Cass sessions"},
-			},
-		},
+// sectionsFor returns the shortcut sections for a context, generated from
+// the keymap registry so the sidebar and the help overlay never disagree.
+func (s *ShortcutsSidebar) sectionsFor(ctx string) []shortcutSection {
+	groups := bindingsFor(ctx, "")
+	sections := make([]shortcutSection, 0, len(groups))
+	for _, g := range groups {
+		items := make([]shortcutItem, 0, len(g.Bindings))
+		for _, b := range g.Bindings {
+			items = append(items, shortcutItem{b.Keys, b.Desc})
+		}
+		sections = append(sections, shortcutSection{title: g.Section, items: items})
 	}
+	return sections
 }
 
 // View renders the sidebar
@@ -223,23 +133,8 @@ func (s *ShortcutsSidebar) View() string {
 	sb.WriteString(titleStyle.Render("Shortcuts"))
 	sb.WriteString("\n")
 
-	// Filter sections by context
-	sections := s.allSections()
-	for _, section := range sections {
-		// Check if this section applies to current context
-		if len(section.contexts) > 0 {
-			found := false
-			for _, ctx := range section.contexts {
-				if ctx == s.context {
-					found = true
-					break
-				}
-			}
-			if !found {
-				continue
-			}
-		}
-
+	// Sections are already filtered to the current context
+	for _, section := range s.sectionsFor(s.context) {
 		sb.WriteString(sectionStyle.Render(section.title))
 		sb.WriteString("\n")
 
@@ -285,7 +180,7 @@ func (s *ShortcutsSidebar) View() string {
 		if maxScroll > 0 {
 			scrollPercent = s.scrollOffset * 100 / maxScroll
 		}
-		footer = dimStyle.Render(fmt.Sprintf("j/k scroll %d%%", scrollPercent))
+		footer = dimStyle.Render(i18n.T("help.scroll", scrollPercent))
 	} else {
 		footer = dimStyle.Render("; hide")
 	}
@@ -324,6 +219,10 @@ func ContextFromFocus(f focus) string {
 		return "actionable"
 	case focusLabelDashboard:
 		return "label"
+	case focusTree:
+		return "tree"
+	case focusFlowMatrix:
+		return "flow"
 	default:
 		return "list"
 	}
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/instance"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/recipe"
 )
@@ -153,6 +154,9 @@ type DataSnapshot struct {
 	QuickWinSet   map[string]bool
 	BlockerSet    map[string]bool
 	UnblocksMap   map[string][]string
+	// DepShapes holds each issue's dependency depth and fan-in/fan-out for
+	// the list columns and depth/fan sorts.
+	DepShapes map[string]analysis.DependencyShape
 	// TreeRoots and TreeNodeMap contain a pre-built parent/child tree for the Tree view.
 	// These are computed off-thread by SnapshotBuilder to avoid UI-thread work when
 	// entering the tree view for large datasets.
@@ -167,6 +171,7 @@ type DataSnapshot struct {
 	// Metadata
 	CreatedAt  time.Time // When this snapshot was built
 	DataHash   string    // Hash of source data for cache validation
+	DataFile   instance.FileStamp // Version of the beads file the issues came from
 	RecipeName string    // Active recipe name for this snapshot (bv-2h40)
 	RecipeHash string    // Fingerprint of active recipe for this snapshot (bv-4ilb)
 	// DatasetTier is a tiered performance mode for large datasets (bv-9thm).
@@ -283,7 +288,7 @@ type SnapshotBuilder struct {
 func NewSnapshotBuilder(issues []model.Issue) *SnapshotBuilder {
 	return &SnapshotBuilder{
 		issues:   issues,
-		analyzer: analysis.NewAnalyzer(issues),
+		analyzer: newProjectAnalyzer(issues),
 		cfg:      snapshotBuildConfigDefault(),
 	}
 }
@@ -504,6 +509,7 @@ func (b *SnapshotBuilder) Build() *DataSnapshot {
 		QuickWinSet:   quickWinSet,
 		BlockerSet:    blockerSet,
 		UnblocksMap:   unblocksMap,
+		DepShapes:     analysis.ComputeDependencyShapes(issues),
 		TreeRoots:     treeRoots,
 		TreeNodeMap:   treeNodeMap,
 		BoardState:    boardState,
@@ -0,0 +1,365 @@
+package ui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/hooks"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// BdRunner runs bd with args in dir and returns its combined output. The
+// TUI shells out to bd for the stale sweep's close and deprioritize
+// actions and for claims (M); Model.SetBdRunner swaps the runner, e.g. to
+// record the calls when a test drives the TUI.
+type BdRunner func(dir string, args ...string) ([]byte, error)
+
+// staleBdRunner runs the bd on PATH in dir; tests replace it.
+var staleBdRunner BdRunner = func(dir string, args ...string) ([]byte, error) {
+	cmd := exec.Command("bd", args...)
+	cmd.Dir = dir
+	return cmd.CombinedOutput()
+}
+
+// StaleSweepModel is the guided stale-issue cleanup overlay: issues
+// untouched for the threshold, grouped by type, with batch actions.
+type StaleSweepModel struct {
+	sweep  analysis.StaleSweep
+	rows   []analysis.StaleIssue // flattened in group order
+	cursor int
+	marked map[string]bool
+
+	// confirming is a batch action waiting for y/N; nil otherwise
+	confirming *staleSweepConfirm
+
+	width  int
+	height int
+	theme  Theme
+}
+
+// NewStaleSweepModel creates the overlay for sweep.
+func NewStaleSweepModel(sweep analysis.StaleSweep, theme Theme) StaleSweepModel {
+	m := StaleSweepModel{sweep: sweep, marked: make(map[string]bool), theme: theme}
+	for _, g := range sweep.Groups {
+		m.rows = append(m.rows, g.Issues...)
+	}
+	return m
+}
+
+// SetSize updates the overlay dimensions.
+func (m *StaleSweepModel) SetSize(width, height int) {
+	m.width = width
+	m.height = height
+}
+
+// MoveUp moves the cursor up.
+func (m *StaleSweepModel) MoveUp() {
+	if m.cursor > 0 {
+		m.cursor--
+	}
+}
+
+// MoveDown moves the cursor down.
+func (m *StaleSweepModel) MoveDown() {
+	if m.cursor < len(m.rows)-1 {
+		m.cursor++
+	}
+}
+
+// ToggleMarked marks or unmarks the issue under the cursor.
+func (m *StaleSweepModel) ToggleMarked() {
+	if m.cursor < len(m.rows) {
+		id := m.rows[m.cursor].ID
+		m.marked[id] = !m.marked[id]
+	}
+}
+
+// ToggleAll marks every issue, or clears the marks if all are marked.
+func (m *StaleSweepModel) ToggleAll() {
+	all := len(m.rows) > 0
+	for _, r := range m.rows {
+		if !m.marked[r.ID] {
+			all = false
+			break
+		}
+	}
+	for _, r := range m.rows {
+		m.marked[r.ID] = !all
+	}
+}
+
+// Targets returns the marked issues, or the one under the cursor when
+// nothing is marked.
+func (m StaleSweepModel) Targets() []analysis.StaleIssue {
+	var out []analysis.StaleIssue
+	for _, r := range m.rows {
+		if m.marked[r.ID] {
+			out = append(out, r)
+		}
+	}
+	if len(out) == 0 && m.cursor < len(m.rows) {
+		out = append(out, m.rows[m.cursor])
+	}
+	return out
+}
+
+// staleSweepConfirm is a batch action the user has asked for but not yet
+// confirmed.
+type staleSweepConfirm struct {
+	action  string // "" for each issue's suggested action
+	targets []analysis.StaleIssue
+}
+
+// Confirm asks for confirmation of action on the current targets before
+// anything runs. It reports false if there is nothing to act on.
+func (m *StaleSweepModel) Confirm(action string) bool {
+	targets := m.Targets()
+	if len(targets) == 0 {
+		return false
+	}
+	m.confirming = &staleSweepConfirm{action: action, targets: targets}
+	return true
+}
+
+// Confirming reports whether a batch action is waiting for y/N.
+func (m StaleSweepModel) Confirming() bool {
+	return m.confirming != nil
+}
+
+// Resolve answers the pending confirmation: yes returns the action and its
+// targets to run, no drops it.
+func (m *StaleSweepModel) Resolve(yes bool) (string, []analysis.StaleIssue, bool) {
+	c := m.confirming
+	m.confirming = nil
+	if c == nil || !yes {
+		return "", nil, false
+	}
+	return c.action, c.targets, true
+}
+
+// prompt names the pending action and how many issues it touches.
+func (c staleSweepConfirm) prompt() string {
+	n := len(c.targets)
+	switch c.action {
+	case analysis.StaleActionClose:
+		return fmt.Sprintf("Close %d issue(s) as stale? [y/N]", n)
+	case analysis.StaleActionDeprioritize:
+		return fmt.Sprintf("Deprioritize %d issue(s)? [y/N]", n)
+	case analysis.StaleActionPing:
+		return fmt.Sprintf("Ping the assignees of %d issue(s)? [y/N]", n)
+	}
+	return fmt.Sprintf("Apply the suggested action to %d issue(s)? [y/N]", n)
+}
+
+// Remove drops issues that have been dealt with.
+func (m *StaleSweepModel) Remove(ids []string) {
+	done := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		done[id] = true
+		delete(m.marked, id)
+	}
+	rows := m.rows[:0]
+	for _, r := range m.rows {
+		if !done[r.ID] {
+			rows = append(rows, r)
+		}
+	}
+	m.rows = rows
+	if m.cursor >= len(m.rows) {
+		m.cursor = max(0, len(m.rows)-1)
+	}
+}
+
+// View renders the stale sweep overlay.
+func (m *StaleSweepModel) View() string {
+	if m.width == 0 {
+		m.width = 100
+	}
+	if m.height == 0 {
+		m.height = 30
+	}
+	t := m.theme
+	boxWidth := min(110, max(40, m.width-6))
+	inner := boxWidth - 6
+
+	titleStyle := t.Renderer.NewStyle().Foreground(t.Primary).Bold(true)
+	headStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Bold(true)
+	mutedStyle := t.Renderer.NewStyle().Foreground(t.Secondary).Italic(true)
+
+	lines := []string{
+		titleStyle.Render(fmt.Sprintf("Stale Sweep • untouched %d+ days • %d issues", m.sweep.ThresholdDays, len(m.rows))),
+		"",
+	}
+	if len(m.rows) == 0 {
+		lines = append(lines, mutedStyle.Render("Nothing stale. 🎉"))
+	}
+
+	// Window the rows around the cursor; group headings take a line each.
+	visible := max(5, m.height-12)
+	start := 0
+	if m.cursor >= visible {
+		start = m.cursor - visible + 1
+	}
+	end := min(len(m.rows), start+visible)
+	for i := start; i < end; i++ {
+		r := m.rows[i]
+		if i == start || r.IssueType != m.rows[i-1].IssueType {
+			lines = append(lines, headStyle.Render(fmt.Sprintf("%s (%d)", r.IssueType, m.groupCount(r.IssueType))))
+		}
+		prefix := "  "
+		if i == m.cursor {
+			prefix = "▸ "
+		}
+		check := "[ ]"
+		if m.marked[r.ID] {
+			check = "[x]"
+		}
+		owner := "-"
+		if r.Assignee != "" {
+			owner = "@" + r.Assignee
+		}
+		line := fmt.Sprintf("%s%s %-10s P%d %4dd %-12s → %-12s %s",
+			prefix, check, truncate(r.ID, 10), r.Priority, r.IdleDays, truncate(owner, 12), r.Suggested, r.Title)
+		style := t.Renderer.NewStyle().Foreground(t.Base.GetForeground())
+		if i == m.cursor {
+			style = style.Foreground(t.Primary).Bold(true)
+		}
+		lines = append(lines, style.Render(truncate(line, inner)))
+	}
+	if end < len(m.rows) {
+		lines = append(lines, mutedStyle.Render(fmt.Sprintf("  … %d more", len(m.rows)-end)))
+	}
+
+	lines = append(lines, "")
+	if m.confirming != nil {
+		lines = append(lines, titleStyle.Render(m.confirming.prompt()))
+	} else {
+		lines = append(lines, mutedStyle.Render("space: mark • a: all • ⏎: suggested • x: close • d: deprioritize • p: ping • esc: done"))
+	}
+
+	box := t.Renderer.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(t.Primary).
+		Padding(1, 2).
+		Width(boxWidth).
+		Render(strings.Join(lines, "\n"))
+	return lipgloss.Place(m.width, m.height, lipgloss.Center, lipgloss.Center, box)
+}
+
+func (m StaleSweepModel) groupCount(issueType string) int {
+	n := 0
+	for _, r := range m.rows {
+		if r.IssueType == issueType {
+			n++
+		}
+	}
+	return n
+}
+
+// StaleSweepDoneMsg reports the outcome of a stale sweep batch action.
+type StaleSweepDoneMsg struct {
+	DoneIDs []string
+	Skipped int      // nothing to do, e.g. a ping with no assignee
+	Failed  []string // "ID: error"
+	Err     error    // the batch could not run at all
+}
+
+// staleSweepCmd applies action to targets in the background: close and
+// deprioritize run bd in dir, ping runs the notify hooks from
+// dir/.bv/hooks.yaml. An empty action means each issue's suggested one.
+// A nil run means staleBdRunner.
+func staleSweepCmd(targets []analysis.StaleIssue, action, dir string, run BdRunner) tea.Cmd {
+	return func() tea.Msg {
+		if run == nil {
+			run = staleBdRunner
+		}
+		var msg StaleSweepDoneMsg
+		var notifier *hooks.Executor
+		for _, s := range targets {
+			act := action
+			if act == "" {
+				act = s.Suggested
+			}
+			if act != analysis.StaleActionPing {
+				args := analysis.StaleActionArgs(s, act)
+				if args == nil {
+					msg.Skipped++
+					continue
+				}
+				if out, err := run(dir, args...); err != nil {
+					msg.Failed = append(msg.Failed, fmt.Sprintf("%s: %s", s.ID, firstLine(string(out), err)))
+					continue
+				}
+				msg.DoneIDs = append(msg.DoneIDs, s.ID)
+				continue
+			}
+
+			if s.Assignee == "" {
+				msg.Skipped++
+				continue
+			}
+			if notifier == nil {
+				loader := hooks.NewLoader(hooks.WithProjectDir(dir))
+				if err := loader.Load(); err != nil {
+					msg.Err = err
+					return msg
+				}
+				if len(loader.GetHooks(hooks.Notify)) == 0 {
+					msg.Err = fmt.Errorf("no notify hooks in .bv/hooks.yaml to ping with")
+					return msg
+				}
+				notifier = hooks.NewExecutor(loader.Config(), hooks.ExportContext{})
+			}
+			before := len(notifier.Results())
+			_ = notifier.RunNotify(hooks.Notification{
+				Kind:     "stale",
+				IssueID:  s.ID,
+				Title:    s.Title,
+				Assignee: s.Assignee,
+				Message:  fmt.Sprintf("%s %q has had no activity in %d days. Still on it?", s.ID, s.Title, s.IdleDays),
+			})
+			if failed := firstFailedHook(notifier.Results()[before:]); failed != "" {
+				msg.Failed = append(msg.Failed, fmt.Sprintf("%s: %s", s.ID, failed))
+				continue
+			}
+			msg.DoneIDs = append(msg.DoneIDs, s.ID)
+		}
+		return msg
+	}
+}
+
+func firstFailedHook(results []hooks.HookResult) string {
+	for _, r := range results {
+		if !r.Success {
+			return fmt.Sprintf("%s: %v", r.Hook.Name, r.Error)
+		}
+	}
+	return ""
+}
+
+// firstLine is the first line of a command's output, or err when silent.
+func firstLine(out string, err error) string {
+	if line, _, _ := strings.Cut(strings.TrimSpace(out), "\n"); line != "" {
+		return line
+	}
+	return err.Error()
+}
+
+// staleSweepStatus summarizes a batch action for the status bar.
+func staleSweepStatus(msg StaleSweepDoneMsg) (string, bool) {
+	if msg.Err != nil {
+		return fmt.Sprintf("❌ Stale sweep: %v", msg.Err), true
+	}
+	status := fmt.Sprintf("Stale sweep: %d done", len(msg.DoneIDs))
+	if msg.Skipped > 0 {
+		status += fmt.Sprintf(", %d skipped", msg.Skipped)
+	}
+	if len(msg.Failed) > 0 {
+		status += fmt.Sprintf(", %d failed (%s)", len(msg.Failed), msg.Failed[0])
+	}
+	return status, len(msg.Failed) > 0
+}
@@ -0,0 +1,147 @@
+package ui
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func TestStaleSweepModelMarking(t *testing.T) {
+	sweep := analysis.StaleSweep{ThresholdDays: 30, Total: 3, Groups: []analysis.StaleGroup{
+		{IssueType: "task", Count: 2, Issues: []analysis.StaleIssue{{ID: "T1", IssueType: "task"}, {ID: "T2", IssueType: "task"}}},
+		{IssueType: "bug", Count: 1, Issues: []analysis.StaleIssue{{ID: "B1", IssueType: "bug"}}},
+	}}
+	m := NewStaleSweepModel(sweep, DefaultTheme(nil))
+	if got := m.Targets(); len(got) != 1 || got[0].ID != "T1" {
+		t.Fatalf("unmarked targets = %v, want the cursor row", got)
+	}
+	m.MoveDown()
+	m.MoveDown()
+	m.ToggleMarked()
+	m.MoveUp()
+	m.ToggleMarked()
+	if got := ids(m.Targets()); !reflect.DeepEqual(got, []string{"T2", "B1"}) {
+		t.Errorf("marked targets = %v", got)
+	}
+	m.ToggleAll()
+	if len(m.Targets()) != 3 {
+		t.Errorf("all marked: %v", ids(m.Targets()))
+	}
+	m.Remove([]string{"T1", "B1"})
+	if got := ids(m.Targets()); !reflect.DeepEqual(got, []string{"T2"}) {
+		t.Errorf("after remove = %v", got)
+	}
+	if view := m.View(); !strings.Contains(view, "task (1)") || strings.Contains(view, "B1") {
+		t.Errorf("view should show the remaining task only:\n%s", view)
+	}
+}
+
+func ids(issues []analysis.StaleIssue) []string {
+	var out []string
+	for _, s := range issues {
+		out = append(out, s.ID)
+	}
+	return out
+}
+
+func TestStaleSweepCmd(t *testing.T) {
+	var ran []string
+	orig := staleBdRunner
+	staleBdRunner = func(dir string, args ...string) ([]byte, error) {
+		ran = append(ran, strings.Join(args, " "))
+		if args[1] == "BAD" {
+			return []byte("Error: no such issue\n"), errors.New("exit status 1")
+		}
+		return nil, nil
+	}
+	defer func() { staleBdRunner = orig }()
+
+	dir := t.TempDir()
+	targets := []analysis.StaleIssue{
+		{ID: "A", Priority: 1, IdleDays: 40, Suggested: analysis.StaleActionDeprioritize},
+		{ID: "BAD", Priority: 3, IdleDays: 40, Suggested: analysis.StaleActionClose},
+		{ID: "C", Priority: 4, IdleDays: 40, Suggested: analysis.StaleActionDeprioritize},
+	}
+	msg := staleSweepCmd(targets, "", dir, nil)().(StaleSweepDoneMsg)
+	if want := []string{"update A --priority=2", "close BAD --reason stale: no activity in 40 days"}; !reflect.DeepEqual(ran, want) {
+		t.Errorf("ran %v, want %v", ran, want)
+	}
+	if !reflect.DeepEqual(msg.DoneIDs, []string{"A"}) || msg.Skipped != 1 || len(msg.Failed) != 1 {
+		t.Errorf("msg = %+v", msg)
+	}
+	if status, isErr := staleSweepStatus(msg); !isErr || !strings.Contains(status, "BAD: Error: no such issue") {
+		t.Errorf("status = %q", status)
+	}
+
+	// Pings need notify hooks.
+	owned := []analysis.StaleIssue{{ID: "O", Assignee: "alice", IdleDays: 40}, {ID: "U", IdleDays: 40}}
+	if msg := staleSweepCmd(owned, analysis.StaleActionPing, dir, nil)().(StaleSweepDoneMsg); msg.Err == nil {
+		t.Errorf("ping without notify hooks should fail, got %+v", msg)
+	}
+	if err := os.MkdirAll(filepath.Join(dir, ".bv"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	hook := "hooks:\n  notify:\n    - command: echo \"$BV_NOTIFY_ASSIGNEE $BV_NOTIFY_ISSUE_ID\" >> pings.txt\n"
+	if err := os.WriteFile(filepath.Join(dir, ".bv", "hooks.yaml"), []byte(hook), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	t.Chdir(dir)
+	msg = staleSweepCmd(owned, analysis.StaleActionPing, dir, nil)().(StaleSweepDoneMsg)
+	if !reflect.DeepEqual(msg.DoneIDs, []string{"O"}) || msg.Skipped != 1 || msg.Err != nil {
+		t.Errorf("ping msg = %+v", msg)
+	}
+	if data, _ := os.ReadFile(filepath.Join(dir, "pings.txt")); string(data) != "alice O\n" {
+		t.Errorf("pings.txt = %q", data)
+	}
+}
+
+func TestStaleSweepKeysConfirmBeforeActing(t *testing.T) {
+	var ran []string
+	orig := staleBdRunner
+	staleBdRunner = func(dir string, args ...string) ([]byte, error) {
+		ran = append(ran, strings.Join(args, " "))
+		return nil, nil
+	}
+	defer func() { staleBdRunner = orig }()
+
+	sweep := analysis.StaleSweep{ThresholdDays: 30, Total: 2, Groups: []analysis.StaleGroup{
+		{IssueType: "task", Count: 2, Issues: []analysis.StaleIssue{{ID: "T1", IssueType: "task", IdleDays: 40}, {ID: "T2", IssueType: "task", IdleDays: 40}}},
+	}}
+	m := NewModel(nil, nil, "")
+	m.staleSweep = NewStaleSweepModel(sweep, m.theme)
+	key := func(k string) tea.Cmd {
+		t.Helper()
+		msg := tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(k)}
+		var cmd tea.Cmd
+		m, cmd = m.handleStaleSweepKeys(msg)
+		return cmd
+	}
+
+	key("a")
+	if cmd := key("x"); cmd != nil || !m.staleSweep.Confirming() {
+		t.Fatal("x should ask for confirmation, not act")
+	}
+	if view := m.staleSweep.View(); !strings.Contains(view, "Close 2 issue(s) as stale? [y/N]") {
+		t.Errorf("view should show the prompt:\n%s", view)
+	}
+	if cmd := key("n"); cmd != nil || m.staleSweep.Confirming() || m.statusMsg != "Stale sweep: cancelled" {
+		t.Errorf("n should cancel: status %q", m.statusMsg)
+	}
+
+	key("x")
+	cmd := key("y")
+	if cmd == nil {
+		t.Fatal("y should run the action")
+	}
+	cmd()
+	if len(ran) != 2 || !strings.HasPrefix(ran[0], "close T1") {
+		t.Errorf("ran %v, want both issues closed", ran)
+	}
+}
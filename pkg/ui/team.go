@@ -0,0 +1,76 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+// ComputeTeamView builds a pre-rendered team workload table: open work per
+// assignee with its balance status, then the suggested reassignments.
+func ComputeTeamView(issues []model.Issue, width int) string {
+	report := analysis.ComputeWorkload(issues)
+	if report == nil {
+		return "No issues have an assignee; the team view needs assignee data.\n"
+	}
+
+	headers := []string{"Assignee", "Open", "Active", "Blocked", "Effort", "Load", "Status"}
+	colWidths := []int{0, 5, 6, 8, 8, 5, 12}
+	fixed := len(" | ") * (len(headers) - 1)
+	for _, w := range colWidths[1:] {
+		fixed += w
+	}
+	colWidths[0] = max(12, width-fixed)
+
+	var b strings.Builder
+	row := func(cells []string) {
+		var parts []string
+		for i, c := range cells {
+			parts = append(parts, padRight(truncate(c, colWidths[i]), colWidths[i]))
+		}
+		b.WriteString(strings.Join(parts, " | "))
+		b.WriteString("\n")
+	}
+
+	row(headers)
+	for _, a := range report.Assignees {
+		status := a.Status
+		if a.Status == analysis.WorkloadOverloaded {
+			status = "⚠ " + status
+		}
+		row([]string{
+			a.Assignee,
+			fmt.Sprintf("%d", a.Open),
+			fmt.Sprintf("%d", a.InProgress),
+			fmt.Sprintf("%d", a.Blocked),
+			formatMinutes(a.EstimatedMinutes),
+			fmt.Sprintf("%.1fx", a.LoadFactor),
+			status,
+		})
+	}
+
+	b.WriteString("\n")
+	fmt.Fprintf(&b, "Mean effort %s per assignee • %d open unassigned", formatMinutes(report.MeanMinutes), report.UnassignedOpen)
+	b.WriteString("\n")
+	if len(report.Reassignments) == 0 {
+		b.WriteString("No reassignments suggested.\n")
+		return b.String()
+	}
+	b.WriteString("\nSuggested reassignments:\n")
+	for _, r := range report.Reassignments {
+		line := fmt.Sprintf("  %s %s → %s (%s) %s", r.IssueID, r.From, r.To, formatMinutes(r.EstimatedMinutes), r.Title)
+		b.WriteString(truncate(line, max(20, width)))
+		b.WriteString("\n")
+	}
+	return b.String()
+}
+
+// formatMinutes renders an effort as hours, or minutes under an hour.
+func formatMinutes(minutes int) string {
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%.1fh", float64(minutes)/60)
+}
@@ -0,0 +1,27 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+)
+
+func TestComputeTeamView(t *testing.T) {
+	if out := ComputeTeamView([]model.Issue{{ID: "A", Status: model.StatusOpen}}, 80); !strings.Contains(out, "needs assignee data") {
+		t.Errorf("no assignees: got %q", out)
+	}
+
+	est := 600
+	issues := []model.Issue{
+		{ID: "A", Title: "Big", Status: model.StatusOpen, Assignee: "alice", EstimatedMinutes: &est},
+		{ID: "B", Title: "Also big", Status: model.StatusOpen, Priority: 3, Assignee: "alice", EstimatedMinutes: &est},
+		{ID: "C", Status: model.StatusClosed, Assignee: "bob"},
+	}
+	out := ComputeTeamView(issues, 80)
+	for _, want := range []string{"Assignee", "alice", "overloaded", "bob", "starved", "Suggested reassignments", "B alice → bob (10.0h)"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("team view missing %q:\n%s", want, out)
+		}
+	}
+}
@@ -0,0 +1,179 @@
+package ui
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"sync/atomic"
+
+	"github.com/mattn/go-runewidth"
+)
+
+// Terminal compatibility. Terminals disagree on how to draw emoji: the
+// legacy Windows console host (conhost) shows most of them as boxes one cell
+// wide, Linux virtual consoles have no emoji font at all, and emoji written
+// as a text symbol plus the emoji presentation selector (U+FE0F, as in "⚠️")
+// take two cells in modern terminals while go-runewidth counts one, which
+// shifts every column after them. ProbeTerminal runs once at startup; bv
+// falls back to ASCII icons when the terminal can't show emoji, and
+// displayWidth corrects the measurements for the selector sequences.
+
+// TerminalCaps is what bv knows about the terminal it draws on.
+type TerminalCaps struct {
+	// Name identifies the terminal for diagnostics ("Windows Terminal",
+	// "conhost", "linux console", ...); empty if nothing specific was found.
+	Name string
+	// UTF8 is false when the locale or terminal can't show non-ASCII text.
+	UTF8 bool
+	// LegacyConsole is set for the Windows console host outside Windows
+	// Terminal and other modern hosts.
+	LegacyConsole bool
+	// Emoji is false when emoji render wrong or not at all.
+	Emoji bool
+}
+
+var terminalCaps atomic.Pointer[TerminalCaps]
+
+// SetTerminalCaps records the probed capabilities. Call before constructing
+// the Model.
+func SetTerminalCaps(caps TerminalCaps) {
+	terminalCaps.Store(&caps)
+}
+
+// TerminalCapabilities returns the capabilities set by SetTerminalCaps, or
+// a fully capable terminal if they were never set.
+func TerminalCapabilities() TerminalCaps {
+	if caps := terminalCaps.Load(); caps != nil {
+		return *caps
+	}
+	return TerminalCaps{UTF8: true, Emoji: true}
+}
+
+// ProbeTerminal inspects the environment and, on Windows, the console API
+// to find out what the terminal can display.
+func ProbeTerminal() TerminalCaps {
+	return probeTerminal(runtime.GOOS, os.Getenv, consoleAttached())
+}
+
+// probeTerminal is ProbeTerminal with its inputs passed in. console reports
+// whether stdout is a Windows console buffer, as opposed to a pipe or a pty
+// (mintty and other Cygwin-style terminals).
+func probeTerminal(goos string, getenv func(string) string, console bool) TerminalCaps {
+	caps := TerminalCaps{UTF8: true, Emoji: true}
+
+	switch term := getenv("TERM"); {
+	case term == "dumb":
+		return TerminalCaps{Name: "dumb"}
+	case term == "linux":
+		caps.Name, caps.Emoji = "linux console", false
+	}
+
+	// An explicit non-UTF-8 locale means multi-byte glyphs come out as
+	// mojibake. No locale at all is common in containers whose terminal is
+	// fine, so it doesn't count.
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale := getenv(name); locale != "" {
+			normalized := strings.ToLower(strings.ReplaceAll(locale, "-", ""))
+			if !strings.Contains(normalized, "utf8") {
+				caps.UTF8, caps.Emoji = false, false
+			}
+			break
+		}
+	}
+
+	if goos != "windows" {
+		return caps
+	}
+	switch {
+	case getenv("WT_SESSION") != "":
+		caps.Name = "Windows Terminal"
+	case getenv("TERM_PROGRAM") != "":
+		caps.Name = getenv("TERM_PROGRAM") // vscode, WezTerm, ...
+	case strings.EqualFold(getenv("ConEmuANSI"), "ON"):
+		caps.Name = "ConEmu"
+	case console:
+		// The console API is only reachable through conhost's buffer: every
+		// modern host above sets its own variable.
+		caps.Name, caps.LegacyConsole, caps.Emoji = "conhost", true, false
+	}
+	// Windows writes to the console in UTF-16, whatever the locale says
+	caps.UTF8 = true
+	return caps
+}
+
+// vs16 is the emoji presentation selector.
+const vs16 = '\uFE0F'
+
+// emojiPresentationWidths lists symbols that are text by default but that
+// bv and issue text use followed by vs16 ("⚠️", "⏱️", "🏷️"), with the cell
+// width terminals give the pair. go-runewidth measures the base symbol
+// alone (one cell), while lipgloss and terminals that honor the selector
+// use two.
+var emojiPresentationWidths = map[rune]int{
+	'⚠': 2, '⏱': 2, '⏸': 2, '⌨': 2, '♻': 2, '✔': 2, '❄': 2, '☠': 2, '↩': 2,
+	'🏷': 2, '🏛': 2, '🏔': 2, '🛰': 2, '🛤': 2, '🗂': 2, '👁': 2,
+}
+
+// glyphWidth returns the cells taken by r, given the rune after it.
+func glyphWidth(r, next rune) int {
+	if next == vs16 {
+		if w, ok := emojiPresentationWidths[r]; ok {
+			return w
+		}
+	}
+	return runewidth.RuneWidth(r)
+}
+
+// displayWidth is runewidth.StringWidth corrected with
+// emojiPresentationWidths.
+func displayWidth(s string) int {
+	width := runewidth.StringWidth(s)
+	if !strings.ContainsRune(s, vs16) {
+		return width
+	}
+	prev := rune(-1)
+	for _, r := range s {
+		if r == vs16 {
+			if w, ok := emojiPresentationWidths[prev]; ok {
+				width += w - runewidth.RuneWidth(prev)
+			}
+		}
+		prev = r
+	}
+	return width
+}
+
+// truncateWidth is runewidth.Truncate(s, w, "") measuring with
+// displayWidth. A selector stays with the glyph before it.
+func truncateWidth(s string, w int) string {
+	if !strings.ContainsRune(s, vs16) {
+		return runewidth.Truncate(s, w, "")
+	}
+	runes := []rune(s)
+	width := 0
+	for i, r := range runes {
+		next := rune(-1)
+		if i+1 < len(runes) {
+			next = runes[i+1]
+		}
+		cw := glyphWidth(r, next)
+		if r == vs16 {
+			cw = 0
+		}
+		if width+cw > w {
+			return string(runes[:i])
+		}
+		width += cw
+	}
+	return s
+}
+
+// normalizeNewlines turns CRLF and lone CR line breaks, from files written
+// on Windows, into LF. A carriage return that reaches the terminal moves
+// the cursor back to the start of the line and garbles the frame.
+func normalizeNewlines(s string) string {
+	if !strings.Contains(s, "\r") {
+		return s
+	}
+	return strings.ReplaceAll(strings.ReplaceAll(s, "\r\n", "\n"), "\r", "\n")
+}
@@ -0,0 +1,9 @@
+//go:build !windows
+
+package ui
+
+// consoleAttached reports whether stdout is a Windows console; never
+// outside Windows.
+func consoleAttached() bool {
+	return false
+}
@@ -0,0 +1,165 @@
+package ui
+
+import (
+	"testing"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/mattn/go-runewidth"
+)
+
+func TestProbeTerminal(t *testing.T) {
+	tests := []struct {
+		name    string
+		goos    string
+		env     map[string]string
+		console bool
+		want    TerminalCaps
+	}{
+		{"unix default", "linux", map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"}, false,
+			TerminalCaps{UTF8: true, Emoji: true}},
+		{"no locale", "darwin", map[string]string{"TERM": "xterm-256color"}, false,
+			TerminalCaps{UTF8: true, Emoji: true}},
+		{"C locale", "linux", map[string]string{"TERM": "xterm", "LC_ALL": "C", "LANG": "en_US.UTF-8"}, false,
+			TerminalCaps{}},
+		{"utf-8 spelled with dash", "linux", map[string]string{"LC_CTYPE": "C.utf-8"}, false,
+			TerminalCaps{UTF8: true, Emoji: true}},
+		{"linux console", "linux", map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, false,
+			TerminalCaps{Name: "linux console", UTF8: true}},
+		{"dumb", "linux", map[string]string{"TERM": "dumb"}, false,
+			TerminalCaps{Name: "dumb"}},
+		{"windows terminal", "windows", map[string]string{"WT_SESSION": "abc"}, true,
+			TerminalCaps{Name: "Windows Terminal", UTF8: true, Emoji: true}},
+		{"vscode on windows", "windows", map[string]string{"TERM_PROGRAM": "vscode"}, true,
+			TerminalCaps{Name: "vscode", UTF8: true, Emoji: true}},
+		{"conemu", "windows", map[string]string{"ConEmuANSI": "ON"}, true,
+			TerminalCaps{Name: "ConEmu", UTF8: true, Emoji: true}},
+		{"conhost", "windows", nil, true,
+			TerminalCaps{Name: "conhost", UTF8: true, LegacyConsole: true}},
+		{"conhost with cp1252 locale", "windows", map[string]string{"LANG": "en_US.CP1252"}, true,
+			TerminalCaps{Name: "conhost", UTF8: true, LegacyConsole: true}},
+		{"mintty pty", "windows", map[string]string{"TERM": "xterm-256color"}, false,
+			TerminalCaps{UTF8: true, Emoji: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := probeTerminal(tt.goos, func(k string) string { return tt.env[k] }, tt.console)
+			if got != tt.want {
+				t.Errorf("probeTerminal = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTerminalCapabilitiesDefault(t *testing.T) {
+	prev := terminalCaps.Swap(nil)
+	t.Cleanup(func() { terminalCaps.Store(prev) })
+	if caps := TerminalCapabilities(); !caps.Emoji || !caps.UTF8 {
+		t.Errorf("unset capabilities = %+v, want a capable terminal", caps)
+	}
+	SetTerminalCaps(TerminalCaps{Name: "conhost", LegacyConsole: true})
+	if caps := TerminalCapabilities(); caps.Name != "conhost" || caps.Emoji {
+		t.Errorf("capabilities = %+v after SetTerminalCaps", caps)
+	}
+}
+
+// The width table must agree with lipgloss, which lays out every view:
+// otherwise padding computed by padRight and borders drawn by lipgloss
+// disagree by a cell per glyph.
+func TestEmojiPresentationWidthsMatchLipgloss(t *testing.T) {
+	for r, w := range emojiPresentationWidths {
+		seq := string(r) + string(vs16)
+		if got := lipgloss.Width(seq); got != w {
+			t.Errorf("%q: table %d, lipgloss %d", seq, w, got)
+		}
+		if got := displayWidth(seq); got != w {
+			t.Errorf("%q: displayWidth %d, want %d", seq, got, w)
+		}
+		if runewidth.StringWidth(seq) == w {
+			t.Errorf("%q: runewidth already measures %d; the override is unneeded", seq, w)
+		}
+		// Without the selector, the symbol keeps its text width
+		if got := displayWidth(string(r)); got != runewidth.RuneWidth(r) {
+			t.Errorf("%q alone: displayWidth %d, want %d", string(r), got, runewidth.RuneWidth(r))
+		}
+	}
+}
+
+func TestDisplayWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		want int
+	}{
+		{"", 0},
+		{"plain", 5},
+		{"⚠️ alert", 8},
+		{"⚠ alert", 7},
+		{"📋 list", 7},
+		{"🏷️ a ⏱️ b", 9},
+		{"日本", 4},
+		{"x️", 1}, // selector after a symbol not in the table
+	}
+	for _, tt := range tests {
+		if got := displayWidth(tt.s); got != tt.want {
+			t.Errorf("displayWidth(%q) = %d, want %d", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	tests := []struct {
+		s    string
+		w    int
+		want string
+	}{
+		{"hello", 3, "hel"},
+		{"⚠️ alert", 1, ""},
+		{"⚠️ alert", 2, "⚠️"},
+		{"⚠️ alert", 4, "⚠️ a"},
+		{"a⚠️b", 2, "a"},
+		{"a⚠️b", 3, "a⚠️"},
+		{"⚠️", 5, "⚠️"},
+	}
+	for _, tt := range tests {
+		got := truncateWidth(tt.s, tt.w)
+		if got != tt.want {
+			t.Errorf("truncateWidth(%q, %d) = %q, want %q", tt.s, tt.w, got, tt.want)
+		}
+		if displayWidth(got) > tt.w {
+			t.Errorf("truncateWidth(%q, %d) is %d cells wide", tt.s, tt.w, displayWidth(got))
+		}
+	}
+}
+
+func TestPadRightWithPresentationSelector(t *testing.T) {
+	padded := padRight("⚠️ x", 8)
+	if got := lipgloss.Width(padded); got != 8 {
+		t.Errorf("padRight width per lipgloss = %d, want 8", got)
+	}
+	if got := truncateRunesHelper("⚠️ warning text", 6, "…"); lipgloss.Width(got) > 6 {
+		t.Errorf("truncateRunesHelper = %q, %d cells", got, lipgloss.Width(got))
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	tests := map[string]string{
+		"a\nb":       "a\nb",
+		"a\r\nb\r\n": "a\nb\n",
+		"a\rb":       "a\nb",
+		"a\r\n\rb":   "a\n\nb",
+	}
+	for in, want := range tests {
+		if got := normalizeNewlines(in); got != want {
+			t.Errorf("normalizeNewlines(%q) = %q, want %q", in, got, want)
+		}
+	}
+	r := NewMarkdownRenderer(40)
+	out, err := r.Render("line one\r\nline two\r\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, c := range out {
+		if c == '\r' {
+			t.Fatalf("rendered markdown contains a carriage return: %q", out)
+		}
+	}
+}
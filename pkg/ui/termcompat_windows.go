@@ -0,0 +1,15 @@
+//go:build windows
+
+package ui
+
+import (
+	"os"
+
+	"golang.org/x/sys/windows"
+)
+
+// consoleAttached reports whether stdout is a console screen buffer.
+func consoleAttached() bool {
+	var mode uint32
+	return windows.GetConsoleMode(windows.Handle(os.Stdout.Fd()), &mode) == nil
+}
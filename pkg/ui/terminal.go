@@ -0,0 +1,166 @@
+package ui
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Terminal integration: the window/tab title shows the project and alert
+// count, and critical alerts that appear while watching for changes raise an
+// OSC 9 desktop notification (iTerm2, kitty, WezTerm, Windows Terminal, ...).
+// Configured via BV_TERM_TITLE / BV_NOTIFY or `ui.terminal_title` /
+// `ui.notify` in config.yaml.
+var (
+	terminalTitleEnabled  atomic.Bool
+	terminalNotifyEnabled atomic.Bool
+)
+
+// terminalSequenceWrite is swapped out by tests.
+var terminalSequenceWrite = writeTerminalSequence
+
+// SetTerminalIntegration enables or disables the terminal title and OSC 9
+// notifications. Call before constructing the Model.
+func SetTerminalIntegration(title, notify bool) {
+	terminalTitleEnabled.Store(title)
+	terminalNotifyEnabled.Store(notify)
+}
+
+// SaveTerminalTitle pushes the current window title onto the terminal's title
+// stack (XTWINOPS 22) so RestoreTerminalTitle can put it back on exit.
+func SaveTerminalTitle() {
+	if terminalTitleEnabled.Load() {
+		_ = terminalSequenceWrite("\x1b[22;0t")
+	}
+}
+
+// RestoreTerminalTitle pops the title saved by SaveTerminalTitle.
+func RestoreTerminalTitle() {
+	if terminalTitleEnabled.Load() {
+		_ = terminalSequenceWrite("\x1b[23;0t")
+	}
+}
+
+// tmuxPassthrough wraps an escape sequence in tmux's DCS passthrough so it
+// reaches the outer terminal. Outside tmux the sequence is returned unchanged.
+func tmuxPassthrough(seq string) string {
+	if os.Getenv("TMUX") == "" {
+		return seq
+	}
+	return "\x1bPtmux;" + strings.ReplaceAll(seq, "\x1b", "\x1b\x1b") + "\x1b\\"
+}
+
+// writeTerminalSequence writes an escape sequence straight to the controlling
+// terminal, bypassing Bubble Tea's renderer (the sequences are invisible).
+func writeTerminalSequence(seq string) error {
+	if os.Getenv("BV_TEST_MODE") != "" {
+		return nil
+	}
+	tty, err := os.OpenFile("/dev/tty", os.O_WRONLY, 0)
+	if err != nil {
+		_, err = os.Stdout.WriteString(seq)
+		return err
+	}
+	defer tty.Close()
+	_, err = tty.WriteString(seq)
+	return err
+}
+
+// osc9Sequence builds an OSC 9 notification. Control characters are dropped
+// so the message can't terminate the sequence early.
+func osc9Sequence(message string) string {
+	clean := strings.Map(func(r rune) rune {
+		if r < 0x20 || r == 0x7f {
+			return ' '
+		}
+		return r
+	}, message)
+	return tmuxPassthrough("\x1b]9;" + clean + "\a")
+}
+
+// projectName returns the name shown in the terminal title.
+func (m Model) projectName() string {
+	if m.workspaceMode {
+		if m.workspaceSummary != "" {
+			return "workspace (" + m.workspaceSummary + ")"
+		}
+		return "workspace"
+	}
+	if m.workDir != "" {
+		return filepath.Base(m.workDir)
+	}
+	if cwd, err := os.Getwd(); err == nil {
+		return filepath.Base(cwd)
+	}
+	return ""
+}
+
+// terminalTitle formats the window title, e.g. "bv · myproj · 3 alerts (1 critical)".
+func terminalTitle(project string, critical, total int) string {
+	title := "bv"
+	if project != "" {
+		title += " · " + project
+	}
+	switch {
+	case total == 1:
+		title += " · 1 alert"
+	case total > 1:
+		title += fmt.Sprintf(" · %d alerts", total)
+	}
+	if critical > 0 {
+		title += fmt.Sprintf(" (%d critical)", critical)
+	}
+	return title
+}
+
+// terminalTitleCmd sets the window title from the current alert counts.
+func (m Model) terminalTitleCmd() tea.Cmd {
+	if !terminalTitleEnabled.Load() {
+		return nil
+	}
+	total := m.alertsCritical + m.alertsWarning + m.alertsInfo
+	return tea.SetWindowTitle(terminalTitle(m.projectName(), m.alertsCritical, total))
+}
+
+// alertsRefreshedCmd runs after every alert recompute: it refreshes the
+// window title and, once live reload has delivered new data, notifies about
+// critical alerts that weren't present on the previous refresh.
+//
+// Only recomputes over full Phase 2 metrics are diffed. A reload first
+// recomputes with Phase 1 data, which lacks cycle alerts; diffing that would
+// drop the cycle keys and re-announce them when Phase 2 lands.
+func (m *Model) alertsRefreshedCmd() tea.Cmd {
+	if m.analysis != nil && !m.analysis.IsPhase2Ready() {
+		return m.terminalTitleCmd()
+	}
+	current := make(map[string]bool)
+	var fresh []drift.Alert
+	for _, a := range m.alerts {
+		if a.Severity != drift.SeverityCritical {
+			continue
+		}
+		key := alertKey(a)
+		current[key] = true
+		if m.alertsWatching && !m.notifiedAlerts[key] {
+			fresh = append(fresh, a)
+		}
+	}
+	m.notifiedAlerts = current
+
+	cmds := []tea.Cmd{m.terminalTitleCmd()}
+	if terminalNotifyEnabled.Load() && len(fresh) > 0 {
+		project := m.projectName()
+		cmds = append(cmds, func() tea.Msg {
+			for _, a := range fresh {
+				_ = terminalSequenceWrite(osc9Sequence(fmt.Sprintf("bv %s: %s", project, a.Message)))
+			}
+			return nil
+		})
+	}
+	return tea.Batch(cmds...)
+}
@@ -0,0 +1,127 @@
+package ui
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/analysis"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/drift"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runCmd executes cmd and any batched children, discarding messages.
+func runCmd(cmd tea.Cmd) {
+	if cmd == nil {
+		return
+	}
+	if batch, ok := cmd().(tea.BatchMsg); ok {
+		for _, c := range batch {
+			runCmd(c)
+		}
+	}
+}
+
+func TestTerminalTitle(t *testing.T) {
+	tests := []struct {
+		project         string
+		critical, total int
+		want            string
+	}{
+		{"proj", 0, 0, "bv · proj"},
+		{"proj", 0, 1, "bv · proj · 1 alert"},
+		{"proj", 2, 5, "bv · proj · 5 alerts (2 critical)"},
+		{"", 0, 0, "bv"},
+	}
+	for _, tt := range tests {
+		if got := terminalTitle(tt.project, tt.critical, tt.total); got != tt.want {
+			t.Errorf("terminalTitle(%q, %d, %d) = %q, want %q", tt.project, tt.critical, tt.total, got, tt.want)
+		}
+	}
+}
+
+func TestOSC9Sequence(t *testing.T) {
+	t.Setenv("TMUX", "")
+	got := osc9Sequence("cycle\x07 detected\n")
+	if got != "\x1b]9;cycle  detected \a" {
+		t.Fatalf("osc9Sequence = %q", got)
+	}
+
+	t.Setenv("TMUX", "/tmp/tmux-1000/default,1,0")
+	if got := osc9Sequence("x"); !strings.HasPrefix(got, "\x1bPtmux;") {
+		t.Fatalf("expected tmux passthrough, got %q", got)
+	}
+}
+
+func TestAlertsRefreshedCmd_NotifiesOnlyNewCriticalAfterReload(t *testing.T) {
+	SetTerminalIntegration(true, true)
+	t.Cleanup(func() { SetTerminalIntegration(false, false) })
+
+	var sent []string
+	orig := terminalSequenceWrite
+	terminalSequenceWrite = func(seq string) error {
+		sent = append(sent, seq)
+		return nil
+	}
+	t.Cleanup(func() { terminalSequenceWrite = orig })
+
+	cycle := drift.Alert{Type: drift.AlertNewCycle, Severity: drift.SeverityCritical, Message: "new cycle"}
+	warn := drift.Alert{Type: drift.AlertStaleIssue, Severity: drift.SeverityWarning, IssueID: "bv-1", Message: "stale"}
+
+	m := NewModel(nil, nil, "")
+
+	// Initial load: remembered, not announced.
+	m.alerts = []drift.Alert{cycle}
+	runCmd(m.alertsRefreshedCmd())
+	if len(sent) != 0 {
+		t.Fatalf("expected no notification before the first reload, got %q", sent)
+	}
+
+	// Reload with the same critical alert plus a warning: nothing new.
+	m.alertsWatching = true
+	m.alerts = []drift.Alert{cycle, warn}
+	runCmd(m.alertsRefreshedCmd())
+	if len(sent) != 0 {
+		t.Fatalf("expected no notification for known/non-critical alerts, got %q", sent)
+	}
+
+	// Critical alert clears, then reappears: announced once.
+	m.alerts = nil
+	runCmd(m.alertsRefreshedCmd())
+	m.alerts = []drift.Alert{cycle}
+	runCmd(m.alertsRefreshedCmd())
+	runCmd(m.alertsRefreshedCmd())
+	if len(sent) != 1 || !strings.Contains(sent[0], "new cycle") {
+		t.Fatalf("expected one notification for the reappearing alert, got %q", sent)
+	}
+}
+
+func TestAlertsRefreshedCmd_ReloadsNotifyOnce(t *testing.T) {
+	SetTerminalIntegration(true, true)
+	t.Cleanup(func() { SetTerminalIntegration(false, false) })
+
+	var sent []string
+	orig := terminalSequenceWrite
+	terminalSequenceWrite = func(seq string) error {
+		sent = append(sent, seq)
+		return nil
+	}
+	t.Cleanup(func() { terminalSequenceWrite = orig })
+
+	cycle := drift.Alert{Type: drift.AlertNewCycle, Severity: drift.SeverityCritical, Message: "new cycle"}
+	phase1 := &analysis.GraphStats{}
+	phase2 := analysis.NewGraphStatsForTest(nil, nil, nil, nil, nil, nil, nil, nil, [][]string{{"a", "b"}}, 0, nil)
+
+	m := NewModel(nil, nil, "")
+	m.alertsWatching = true
+
+	// Each reload recomputes with Phase 1 data (no cycles), then Phase 2.
+	for i := 0; i < 2; i++ {
+		m.analysis, m.alerts = phase1, nil
+		runCmd(m.alertsRefreshedCmd())
+		m.analysis, m.alerts = phase2, []drift.Alert{cycle}
+		runCmd(m.alertsRefreshedCmd())
+	}
+	if len(sent) != 1 {
+		t.Fatalf("expected one notification across two reloads, got %d: %q", len(sent), sent)
+	}
+}
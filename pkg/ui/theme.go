@@ -132,6 +132,9 @@ func (t Theme) GetStatusColor(s string) lipgloss.AdaptiveColor {
 }
 
 func (t Theme) GetTypeIcon(typ string) (string, lipgloss.AdaptiveColor) {
+	if ASCIIIcons() {
+		return asciiTypeTag(typ), t.typeColor(typ)
+	}
 	switch typ {
 	case "bug":
 		return "🐛", t.Bug
@@ -150,3 +153,20 @@ func (t Theme) GetTypeIcon(typ string) (string, lipgloss.AdaptiveColor) {
 	}
 }
 
+// typeColor returns the accent color for an issue type.
+func (t Theme) typeColor(typ string) lipgloss.AdaptiveColor {
+	switch typ {
+	case "bug":
+		return t.Bug
+	case "feature":
+		return t.Feature
+	case "task":
+		return t.Task
+	case "epic":
+		return t.Epic
+	case "chore":
+		return t.Chore
+	default:
+		return t.Subtext
+	}
+}
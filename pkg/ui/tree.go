@@ -16,8 +16,8 @@ import (
 )
 
 // TreeState represents the persistent state of the tree view (bv-zv7p).
-// This is saved to .beads/tree-state.json to preserve expand/collapse state
-// across sessions.
+// This is saved to .beads/tree-state.json, or to the user's state directory
+// when SetStatePath is used, to preserve expand/collapse state across sessions.
 //
 // File format (JSON):
 //
@@ -64,6 +64,13 @@ func TreeStatePath(beadsDir string) string {
 	return filepath.Join(beadsDir, treeStateFileName)
 }
 
+// SetStatePath makes the tree save its expand/collapse state to path, in
+// the user's state directory, instead of TreeStatePath(beadsDir). Until the
+// first save there, the state still loads from the beads directory.
+func (t *TreeModel) SetStatePath(path string) {
+	t.statePath = path
+}
+
 // SetBeadsDir sets the beads directory for persistence (bv-19vz).
 // This should be called before any expand/collapse operations if a custom
 // beads directory is desired. If not called, defaults to ".beads" in cwd.
@@ -75,6 +82,9 @@ func (t *TreeModel) SetBeadsDir(dir string) {
 // Only stores explicit user changes; nodes not in the map use default behavior.
 // Errors are logged but do not interrupt the user experience.
 func (t *TreeModel) saveState() {
+	if t.readOnly {
+		return
+	}
 	state := &TreeState{
 		Version:  TreeStateVersion,
 		Expanded: make(map[string]bool),
@@ -110,6 +120,9 @@ func (t *TreeModel) saveState() {
 	}
 
 	path := TreeStatePath(t.beadsDir)
+	if t.statePath != "" {
+		path = t.statePath
+	}
 	dir := filepath.Dir(path)
 	if err := os.MkdirAll(dir, 0755); err != nil {
 		log.Printf("warning: failed to create state directory %s: %v", dir, err)
@@ -126,6 +139,11 @@ func (t *TreeModel) saveState() {
 // If the file doesn't exist or is corrupted, defaults are used silently.
 func (t *TreeModel) loadState() {
 	path := TreeStatePath(t.beadsDir)
+	if t.statePath != "" {
+		if _, err := os.Stat(t.statePath); err == nil {
+			path = t.statePath
+		}
+	}
 	data, err := os.ReadFile(path)
 	if err != nil {
 		// File doesn't exist = first run, use defaults
@@ -190,9 +208,15 @@ type TreeModel struct {
 	// Build state
 	built    bool   // Has tree been built?
 	lastHash string // Hash of issues for cache invalidation
+	flatGen  int    // Bumped whenever flatList is rebuilt
+
+	// Render caching (see renderKey)
+	render *renderCache
 
 	// Persistence state (bv-19vz)
-	beadsDir string // Directory containing .beads (for tree-state.json)
+	beadsDir  string // Directory containing .beads (for tree-state.json)
+	statePath string // Per-user state file overriding tree-state.json (see SetStatePath)
+	readOnly  bool   // Secondary instance: don't write tree-state.json
 }
 
 // NewTreeModel creates an empty tree model
@@ -201,6 +225,7 @@ func NewTreeModel(theme Theme) TreeModel {
 		theme:    theme,
 		mode:     TreeModeHierarchy,
 		issueMap: make(map[string]*IssueTreeNode),
+		render:   newRenderCache(),
 	}
 }
 
@@ -293,6 +318,9 @@ func (t *TreeModel) Build(issues []model.Issue) {
 	t.issueMap = make(map[string]*IssueTreeNode)
 	t.cursor = 0
 
+	t.lastHash = issuesRenderHash(issues)
+	t.flatGen++
+
 	if len(issues) == 0 {
 		t.built = true
 		return
@@ -475,6 +503,21 @@ func issueTypeOrder(t model.IssueType) int {
 // Only renders visible nodes based on viewportOffset and height for O(viewport)
 // performance instead of O(n) where n is total nodes.
 func (t *TreeModel) View() string {
+	return t.render.render(t.renderKey(), t.renderTree)
+}
+
+// renderKey captures everything the tree's output depends on: the issues,
+// the visible nodes (expanding or collapsing rebuilds the flat list), the
+// selection, the scroll position and the size.
+func (t *TreeModel) renderKey() string {
+	return fmt.Sprintf("%s|%t|%d|%d|%d|%d|%dx%d|%d",
+		t.lastHash, t.built, t.flatGen, len(t.flatList), t.cursor, t.viewportOffset,
+		t.width, t.height, t.mode)
+}
+
+// renderTree renders the visible window of the tree without consulting the
+// cache.
+func (t *TreeModel) renderTree() string {
 	if !t.built || len(t.flatList) == 0 {
 		return t.renderEmptyState()
 	}
@@ -959,6 +1002,26 @@ func (t *TreeModel) SelectByID(id string) bool {
 	return false
 }
 
+// Reveal selects the node with the given issue ID, expanding its ancestors
+// and the node itself so its parent and children are on screen. Returns
+// false if the issue is not in the tree.
+func (t *TreeModel) Reveal(id string) bool {
+	node, ok := t.issueMap[id]
+	if !ok || node == nil {
+		return false
+	}
+	node.Expanded = true
+	for p := node.Parent; p != nil; p = p.Parent {
+		p.Expanded = true
+	}
+	t.rebuildFlatList()
+	if !t.SelectByID(id) {
+		return false
+	}
+	t.ensureCursorVisible()
+	return true
+}
+
 // GetSelectedID returns the ID of the currently selected issue, or empty string.
 func (t *TreeModel) GetSelectedID() string {
 	if issue := t.SelectedIssue(); issue != nil {
@@ -980,6 +1043,7 @@ func (t *TreeModel) setExpandedRecursive(node *IssueTreeNode, expanded bool) {
 
 // rebuildFlatList rebuilds the flattened list of visible nodes.
 func (t *TreeModel) rebuildFlatList() {
+	t.flatGen++
 	t.flatList = t.flatList[:0]
 	for _, root := range t.roots {
 		t.appendVisible(root)
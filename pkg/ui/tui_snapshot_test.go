@@ -0,0 +1,133 @@
+package ui
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/timefmt"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/x/ansi"
+	"github.com/charmbracelet/x/exp/teatest"
+)
+
+// TUI snapshot tests render each view at a fixed size and compare the text
+// frame (ANSI stripped) with testdata/golden/tui. They catch layout
+// regressions such as an icon whose cell width differs from what the
+// padding assumed. Regenerate after an intended change with
+//
+//	GENERATE_GOLDEN=1 go test ./pkg/ui -run TestTUISnapshots
+//
+// and review the diff.
+
+// snapshotModel runs a Model under teatest without executing its commands.
+// Background loads, phase 2 analysis, update checks and ticks complete at
+// unpredictable times; dropping them leaves only the messages the test
+// sends, so every run renders the same frame.
+type snapshotModel struct {
+	m Model
+}
+
+func (s snapshotModel) Init() tea.Cmd { return nil }
+
+func (s snapshotModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	next, _ := s.m.Update(msg)
+	s.m = next.(Model)
+	return s, nil
+}
+
+func (s snapshotModel) View() string { return s.m.View() }
+
+// snapshotIssues is the fixture: every issue type, status and priority,
+// labels, comments and a dependency chain, with timestamps relative to ref.
+func snapshotIssues(ref time.Time) []model.Issue {
+	day := 24 * time.Hour
+	dep := func(from, to string) []*model.Dependency {
+		return []*model.Dependency{{IssueID: from, DependsOnID: to, Type: model.DepBlocks}}
+	}
+	return []model.Issue{
+		{ID: "snap-1", Title: "Platform migration", IssueType: model.TypeEpic, Status: model.StatusOpen, Priority: 0,
+			Description: "Move every service to the new platform.", Labels: []string{"infra"},
+			CreatedAt: ref.Add(-60 * day), UpdatedAt: ref.Add(-2 * day)},
+		{ID: "snap-2", Title: "Provision clusters", IssueType: model.TypeTask, Status: model.StatusInProgress, Priority: 1,
+			Assignee: "dana", Labels: []string{"infra", "ops"}, Dependencies: dep("snap-2", "snap-1"),
+			CreatedAt: ref.Add(-40 * day), UpdatedAt: ref.Add(-3 * time.Hour)},
+		{ID: "snap-3", Title: "Login fails with expired token", IssueType: model.TypeBug, Status: model.StatusOpen, Priority: 0,
+			Labels: []string{"auth"}, CreatedAt: ref.Add(-9 * day), UpdatedAt: ref.Add(-9 * day),
+			Comments: []*model.Comment{{ID: 1, IssueID: "snap-3", Author: "lee", Text: "Reproduced on staging.", CreatedAt: ref.Add(-8 * day)}}},
+		{ID: "snap-4", Title: "Dark mode for settings", IssueType: model.TypeFeature, Status: model.StatusBlocked, Priority: 2,
+			Dependencies: dep("snap-4", "snap-2"), CreatedAt: ref.Add(-20 * day), UpdatedAt: ref.Add(-15 * day)},
+		{ID: "snap-5", Title: "Bump linter", IssueType: model.TypeChore, Status: model.StatusOpen, Priority: 4,
+			CreatedAt: ref.Add(-400 * day), UpdatedAt: ref.Add(-200 * day)},
+		{ID: "snap-6", Title: "Write runbook", IssueType: model.TypeTask, Status: model.StatusClosed, Priority: 3,
+			Dependencies: dep("snap-6", "snap-2"), CreatedAt: ref.Add(-30 * day), UpdatedAt: ref.Add(-1 * day),
+			ClosedAt: timePtr(ref.Add(-1 * day))},
+	}
+}
+
+// renderSnapshot runs the fixture through teatest at width×height, sends
+// keys, and returns the final frame without ANSI sequences.
+func renderSnapshot(t *testing.T, width, height int, keys ...tea.KeyMsg) string {
+	t.Helper()
+	m := NewModel(snapshotIssues(snapshotRef), nil, "")
+	// Phase 2 runs in the background; wait for it so the metrics it fills
+	// in are always there.
+	next, _ := m.Update(WaitForPhase2Cmd(m.analysis)())
+	m = next.(Model)
+
+	tm := teatest.NewTestModel(t, snapshotModel{m: m}, teatest.WithInitialTermSize(width, height))
+	for _, k := range keys {
+		tm.Send(k)
+	}
+	if err := tm.Quit(); err != nil {
+		t.Fatal(err)
+	}
+	final := tm.FinalModel(t, teatest.WithFinalTimeout(10*time.Second)).(snapshotModel)
+	return ansi.Strip(final.View())
+}
+
+// snapshotRef is the frozen clock for relative times and the anchor of the
+// fixture's timestamps. It is taken from the real clock at test start
+// because analysis reads the real clock for staleness; pinning the display
+// clock to it keeps "3d ago" exact however long the tests run.
+var snapshotRef = time.Now().Truncate(time.Minute)
+
+func runeKey(s string) tea.KeyMsg {
+	return tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(s)}
+}
+
+func TestTUISnapshots(t *testing.T) {
+	prevIcons := ASCIIIcons()
+	SetASCIIIcons(false)
+	timefmt.Set(timefmt.Options{Style: timefmt.StyleRelative, Location: time.UTC, Clock: func() time.Time { return snapshotRef }})
+	t.Cleanup(func() {
+		SetASCIIIcons(prevIcons)
+		timefmt.Set(timefmt.Options{Style: timefmt.StyleRelative})
+	})
+
+	cases := []struct {
+		name          string
+		width, height int
+		keys          []tea.KeyMsg
+		ascii         bool
+	}{
+		{"list_80x24", 80, 24, nil, false},
+		{"list_split_140x40", 140, 40, nil, false},
+		{"detail_100x40", 100, 40, []tea.KeyMsg{{Type: tea.KeyEnter}}, false},
+		{"board_120x30", 120, 30, []tea.KeyMsg{runeKey("b")}, false},
+		{"graph_120x30", 120, 30, []tea.KeyMsg{runeKey("g")}, false},
+		{"tree_100x30", 100, 30, []tea.KeyMsg{runeKey("E")}, false},
+		{"help_120x40", 120, 40, []tea.KeyMsg{runeKey("?")}, false},
+		{"list_ascii_80x24", 80, 24, nil, true},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetASCIIIcons(tc.ascii)
+			frame := renderSnapshot(t, tc.width, tc.height, tc.keys...)
+			golden := testutil.NewGoldenFile(t, filepath.Join("..", "..", "testdata", "golden", "tui"), tc.name+".golden")
+			golden.Assert(frame)
+		})
+	}
+}
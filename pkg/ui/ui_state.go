@@ -0,0 +1,141 @@
+package ui
+
+import (
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
+)
+
+// Reopening bv on a repository restores the view, filter, selected issue
+// and board layout it was closed with, from the user's state directory
+// (see pkg/uistate). The tree saves its expanded nodes there as it goes.
+
+// uiStateDir returns the user's state directory for the repository holding
+// beadsPath, or "" when there is none.
+func uiStateDir(beadsPath string) string {
+	if beadsPath == "" {
+		return ""
+	}
+	dir, err := uistate.RepoDir(filepath.Dir(filepath.Dir(beadsPath)))
+	if err != nil {
+		return ""
+	}
+	return dir
+}
+
+// uiStatePath returns the UI state file in stateDir, or "" without one.
+func uiStatePath(stateDir string) string {
+	if stateDir == "" {
+		return ""
+	}
+	return filepath.Join(stateDir, uistate.StateFilename)
+}
+
+// restoreUIState puts the model back where the user left the repository.
+// A saved filter gives way to a recipe given on the command line, and a
+// selected issue that no longer exists is skipped.
+func (m *Model) restoreUIState() {
+	if m.uiStatePath == "" {
+		return
+	}
+	state, err := uistate.Load(m.uiStatePath)
+	if err != nil {
+		return
+	}
+
+	for mode := SwimLaneMode(0); mode < SwimLaneModeCount; mode++ {
+		if strings.ToLower(swimLaneModeName(mode)) == state.SwimLane {
+			m.board.SetSwimLaneMode(mode)
+			break
+		}
+	}
+	if len(state.BoardColumns) > 0 {
+		m.board.SetColumnOrder(state.BoardColumns)
+	}
+
+	if m.activeRecipe == nil {
+		m.restoreFilter(state.Filter)
+	}
+
+	switch state.View {
+	case "board":
+		m.isBoardView = true
+		m.focused = focusBoard
+	case "graph":
+		m.isGraphView = true
+		m.focused = focusGraph
+	case "tree":
+		if m.snapshot != nil {
+			m.tree.BuildFromSnapshot(m.snapshot)
+		} else {
+			m.tree.Build(m.issues)
+		}
+		m.tree.SetSize(m.width, m.height-2)
+		m.focused = focusTree
+	}
+
+	if id := state.Selected; id != "" {
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+				m.list.Select(i)
+				break
+			}
+		}
+		m.board.SelectIssueByID(id)
+		if m.focused == focusTree {
+			m.tree.SelectByID(id)
+		}
+		m.updateViewportContent()
+	}
+}
+
+// restoreFilter re-applies a filter saved by SaveUIState.
+func (m *Model) restoreFilter(filter string) {
+	switch {
+	case strings.HasPrefix(filter, "recipe:"):
+		if m.recipeLoader == nil {
+			return
+		}
+		if r := m.recipeLoader.Get(strings.TrimPrefix(filter, "recipe:")); r != nil {
+			m.setActiveRecipe(r)
+			m.applyRecipe(r)
+		}
+	case filter == "open", filter == "closed", filter == "ready", filter == "frontier",
+		strings.HasPrefix(filter, "label:"):
+		m.currentFilter = filter
+		m.applyFilter()
+	}
+}
+
+// SaveUIState records the view, filter, selected issue and board layout,
+// for the next bv opened on this repository. A secondary instance (see the
+// instance lock) leaves the saved state to the primary.
+func (m Model) SaveUIState() error {
+	if m.uiStatePath == "" || m.readOnly {
+		return nil
+	}
+	state := uistate.State{
+		View:     "list",
+		Selected: m.focusedIssueID(),
+		SwimLane: strings.ToLower(m.board.GetSwimLaneModeName()),
+		SavedAt:  time.Now(),
+	}
+	state.RepoPath, _ = filepath.Abs(m.workDir)
+	switch {
+	case m.focused == focusTree:
+		state.View = "tree"
+	case m.isBoardView:
+		state.View = "board"
+	case m.isGraphView:
+		state.View = "graph"
+	}
+	if m.currentFilter != "all" {
+		state.Filter = m.currentFilter
+	}
+	if order := m.board.ColumnOrder(); order != defaultColumnOrder {
+		state.BoardColumns = order[:]
+	}
+	return state.Save(m.uiStatePath)
+}
@@ -0,0 +1,94 @@
+package ui
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/uistate"
+)
+
+func TestUIStateRestoredOnReopen(t *testing.T) {
+	t.Setenv("XDG_STATE_HOME", t.TempDir())
+	beadsDir := filepath.Join(t.TempDir(), ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	beadsPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(beadsPath, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	issues := []model.Issue{
+		{ID: "us-1", Title: "Epic", Status: model.StatusOpen, Priority: 1, IssueType: model.TypeEpic},
+		{ID: "us-2", Title: "Child", Status: model.StatusInProgress, Priority: 2, IssueType: model.TypeTask,
+			Dependencies: []*model.Dependency{{IssueID: "us-2", DependsOnID: "us-1", Type: model.DepParentChild}}},
+		{ID: "us-3", Title: "Bug", Status: model.StatusOpen, Priority: 0, IssueType: model.TypeBug},
+		{ID: "us-4", Title: "Done", Status: model.StatusClosed, Priority: 2, IssueType: model.TypeTask},
+	}
+	newModel := func() Model {
+		m := NewModel(append([]model.Issue(nil), issues...), nil, beadsPath)
+		t.Cleanup(m.Stop)
+		return m
+	}
+
+	// Board: open issues only, priority lanes, first two columns swapped
+	m := newModel()
+	m, _ = pressVim(t, m, "o", "b", ">", "s", "j")
+	selected := m.focusedIssueID()
+	if selected == "" {
+		t.Fatal("nothing selected on the board")
+	}
+	order := m.board.ColumnOrder()
+	if order == defaultColumnOrder {
+		t.Fatal("> should move the focused column")
+	}
+	if err := m.SaveUIState(); err != nil {
+		t.Fatal(err)
+	}
+	m.Stop()
+
+	m = newModel()
+	if m.focused != focusBoard || !m.isBoardView {
+		t.Errorf("view not restored: focus %v", m.focused)
+	}
+	if m.currentFilter != "open" || len(m.list.Items()) != 3 {
+		t.Errorf("filter %q with %d items, want open with 3", m.currentFilter, len(m.list.Items()))
+	}
+	if m.board.GetSwimLaneMode() != SwimByPriority || m.board.ColumnOrder() != order {
+		t.Errorf("board layout: %s lanes, order %v, want Priority, %v",
+			m.board.GetSwimLaneModeName(), m.board.ColumnOrder(), order)
+	}
+	if got := m.board.SelectedIssue(); got == nil || got.ID != selected {
+		t.Errorf("selected %v, want %s", got, selected)
+	}
+
+	// Tree: the collapsed epic is saved per user, not in .beads
+	m, _ = pressVim(t, m, "E")
+	if !m.tree.SelectByID("us-1") {
+		t.Fatal("us-1 not in the tree")
+	}
+	m, _ = pressVim(t, m, "enter")
+	if err := m.SaveUIState(); err != nil {
+		t.Fatal(err)
+	}
+	m.Stop()
+	stateDir := uiStateDir(beadsPath)
+	if _, err := os.Stat(filepath.Join(stateDir, uistate.TreeFilename)); err != nil {
+		t.Errorf("tree state not in the state directory: %v", err)
+	}
+	if _, err := os.Stat(TreeStatePath(beadsDir)); !os.IsNotExist(err) {
+		t.Errorf("tree state written to .beads: %v", err)
+	}
+
+	m = newModel()
+	if m.focused != focusTree {
+		t.Fatalf("focus %v, want the tree", m.focused)
+	}
+	if node := m.tree.issueMap["us-1"]; node == nil || node.Expanded {
+		t.Error("us-1 should still be collapsed")
+	}
+	if got := m.tree.SelectedIssue(); got == nil || got.ID != "us-1" {
+		t.Errorf("tree selection %v, want us-1", got)
+	}
+}
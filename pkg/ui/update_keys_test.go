@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/updater"
 	tea "github.com/charmbracelet/bubbletea"
 )
 
@@ -66,6 +67,21 @@ func TestUpdateMsgSetsUpdateAvailable(t *testing.T) {
 	}
 }
 
+func TestUpdateMsgChannelLabel(t *testing.T) {
+	m := NewModel([]model.Issue{{ID: "1", Title: "One", Status: model.StatusOpen}}, nil, "")
+	updated, _ := m.Update(UpdateMsg{TagName: "v9.9.9", URL: "https://example", Channel: updater.ChannelStable})
+	m = updated.(Model)
+	if got := m.updateChannelLabel(); got != "" {
+		t.Errorf("stable channel label = %q, want empty", got)
+	}
+
+	updated, _ = m.Update(UpdateMsg{TagName: "v9.9.9-beta.1", URL: "https://example", Channel: updater.ChannelBeta})
+	m = updated.(Model)
+	if got := m.updateChannelLabel(); got != " (beta)" {
+		t.Errorf("beta channel label = %q, want \" (beta)\"", got)
+	}
+}
+
 func TestHistoryViewToggle(t *testing.T) {
 	issues := []model.Issue{
 		{ID: "bv-1", Title: "Test Issue", Status: model.StatusOpen},
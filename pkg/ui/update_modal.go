@@ -95,7 +95,7 @@ func PerformUpdateCmd() tea.Cmd {
 			if result != nil {
 				if result.RequireRoot {
 					requireRoot = true
-					msg = "Update requires elevated permissions. Run: sudo bv --update"
+					msg = "Update requires elevated permissions. Run: sudo bv self-update"
 				}
 			}
 			return UpdateCompleteMsg{
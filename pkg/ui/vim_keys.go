@@ -0,0 +1,319 @@
+package ui
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// Vim-style keys for the list, board and tree views, enabled with
+// `ui.vim_keys: true` in config.yaml or BV_VIM_KEYS=1:
+//
+//	5j, 3ctrl+d   repeat a motion (j k ↓ ↑ Ctrl+d Ctrl+u PgDn PgUp)
+//	gg, 12gg, 12G first / Nth item
+//	ma            mark the selected issue as a
+//	'a            jump to mark a; '' jumps back
+//
+// They are opt-in because the prefixes shadow default keys: g (graph) and '
+// (recipes) wait for a second key, and on the board 1-4 are counts rather
+// than column jumps. A lone g or ' still does its usual job once
+// vimPrefixTimeout passes, or as soon as the next key isn't part of a
+// sequence; esc cancels.
+
+// vimKeysEnabled turns the vim key layer on; set by SetVimKeys.
+var vimKeysEnabled atomic.Bool
+
+// SetVimKeys enables or disables vim-style counts, gg and marks. Call before
+// constructing the Model.
+func SetVimKeys(enabled bool) {
+	vimKeysEnabled.Store(enabled)
+}
+
+// vimPrefixTimeout is how long a pending g or ' waits for its second key.
+const vimPrefixTimeout = 500 * time.Millisecond
+
+// vimMaxCount caps count prefixes, so a stray run of digits can't replay a
+// motion forever.
+const vimMaxCount = 999
+
+// vimState is the vim key layer's state: the count and prefix typed so far,
+// and the marks set this session.
+type vimState struct {
+	count     int             // Count prefix typed so far; 0 = none
+	pending   string          // "g", "m" or "'" while waiting for the second key
+	seq       int             // Identifies the pending prefix for its timeout
+	marks     map[rune]string // Mark → issue ID
+	lastJump  string          // Issue selected before the latest jump, for ''
+	replaying bool            // Keys are being replayed through Update
+}
+
+// vimPrefixTimeoutMsg resolves a pending g or ' left without a second key.
+type vimPrefixTimeoutMsg struct {
+	seq int
+}
+
+// vimKeysLive reports whether the vim layer applies to the current key:
+// it's enabled, a list, board or tree has focus, and no text is being typed.
+func (m Model) vimKeysLive() bool {
+	if !vimKeysEnabled.Load() || m.vim.replaying {
+		return false
+	}
+	if _, ok := m.keyRemapContext(); !ok {
+		return false
+	}
+	return m.focused == focusList || m.focused == focusBoard || m.focused == focusTree
+}
+
+// handleVimKey runs msg through the vim key layer. When handled is false
+// the key still needs its normal handling.
+func (m Model) handleVimKey(msg tea.KeyMsg) (_ Model, cmd tea.Cmd, handled bool) {
+	key := msg.String()
+
+	switch pending := m.vim.pending; pending {
+	case "m":
+		m.vim.pending = ""
+		if r, ok := markRune(key); ok {
+			m.setMark(r)
+		}
+		return m, nil, true
+	case "g", "'":
+		m.vim.pending = ""
+		if key == "esc" {
+			m.vim.count = 0
+			return m, nil, true
+		}
+		if pending == "g" && key == "g" {
+			from := m.focusedIssueID()
+			m.gotoItem(m.takeCount())
+			m.noteJump(from)
+			return m, nil, true
+		}
+		if pending == "'" {
+			if key == "'" {
+				m.vim.count = 0
+				m.jumpToIssue(m.vim.lastJump, "''")
+				return m, nil, true
+			}
+			if r, ok := markRune(key); ok {
+				m.vim.count = 0
+				m.jumpToMark(r)
+				return m, nil, true
+			}
+		}
+		// Not a sequence: the prefix does its usual job, then the key
+		// gets handled as if typed on its own
+		m.vim.count = 0
+		prefix, _ := parseKey(pending)
+		m, cmd = m.replayKey(prefix, 1)
+		var keyCmd tea.Cmd
+		m, keyCmd = m.replayKey(msg, 1)
+		return m, tea.Batch(cmd, keyCmd), true
+	}
+
+	switch {
+	case len(key) == 1 && key >= "1" && key <= "9", key == "0" && m.vim.count > 0:
+		m.vim.count = min(m.vim.count*10+int(key[0]-'0'), vimMaxCount)
+		m.statusMsg = strconv.Itoa(m.vim.count)
+		return m, nil, true
+	case key == "g", key == "'", key == "m":
+		m.vim.pending = key
+		m.statusMsg = m.vimShowCmd()
+		if key == "m" {
+			return m, nil, true
+		}
+		m.vim.seq++
+		seq := m.vim.seq
+		return m, tea.Tick(vimPrefixTimeout, func(time.Time) tea.Msg {
+			return vimPrefixTimeoutMsg{seq: seq}
+		}), true
+	case key == "G" && m.vim.count > 0:
+		from := m.focusedIssueID()
+		m.gotoItem(m.takeCount())
+		m.noteJump(from)
+		return m, nil, true
+	case key == "esc" && m.vim.count > 0:
+		m.vim.count = 0
+		return m, nil, true
+	}
+
+	switch key {
+	case "j", "k", "down", "up", "ctrl+d", "ctrl+u", "pgdown", "pgup":
+		if n := m.takeCount(); n > 1 {
+			m, cmd = m.replayKey(msg, n)
+			return m, cmd, true
+		}
+	case "G", "end":
+		from := m.focusedIssueID()
+		m, cmd = m.replayKey(msg, 1)
+		m.noteJump(from)
+		return m, cmd, true
+	}
+	m.vim.count = 0
+	return m, nil, false
+}
+
+// handleVimPrefixTimeout resolves a g or ' that got no second key.
+func (m Model) handleVimPrefixTimeout(msg vimPrefixTimeoutMsg) (Model, tea.Cmd) {
+	if msg.seq != m.vim.seq || (m.vim.pending != "g" && m.vim.pending != "'") {
+		return m, nil
+	}
+	pending := m.vim.pending
+	m.vim.pending = ""
+	m.vim.count = 0
+	m.statusMsg = ""
+	prefix, _ := parseKey(pending)
+	return m.replayKey(prefix, 1)
+}
+
+// replayKey sends msg through Update n times, bypassing the vim layer and
+// key remaps (msg is already a default key).
+func (m Model) replayKey(msg tea.KeyMsg, n int) (Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	m.vim.replaying = true
+	for i := 0; i < n; i++ {
+		next, cmd := m.Update(msg)
+		m = next.(Model)
+		cmds = append(cmds, cmd)
+	}
+	m.vim.replaying = false
+	return m, tea.Batch(cmds...)
+}
+
+// takeCount returns the count prefix, or 0 if none, and resets it.
+func (m *Model) takeCount() int {
+	n := m.vim.count
+	m.vim.count = 0
+	return n
+}
+
+// vimShowCmd renders the count and prefix typed so far, like vim's showcmd.
+func (m Model) vimShowCmd() string {
+	if m.vim.count > 0 {
+		return strconv.Itoa(m.vim.count) + m.vim.pending
+	}
+	return m.vim.pending
+}
+
+// markRune reports whether key names a mark (a-z).
+func markRune(key string) (rune, bool) {
+	if len(key) == 1 && key[0] >= 'a' && key[0] <= 'z' {
+		return rune(key[0]), true
+	}
+	return 0, false
+}
+
+// focusedIssueID returns the ID of the issue selected in the focused view.
+func (m *Model) focusedIssueID() string {
+	switch m.focused {
+	case focusBoard:
+		if issue := m.board.SelectedIssue(); issue != nil {
+			return issue.ID
+		}
+	case focusTree:
+		if issue := m.tree.SelectedIssue(); issue != nil {
+			return issue.ID
+		}
+	default:
+		if issue := m.selectedListIssue(); issue != nil {
+			return issue.ID
+		}
+	}
+	return ""
+}
+
+// noteJump remembers where a jump started, so that pressing ' twice can go
+// back to it.
+func (m *Model) noteJump(from string) {
+	if from != "" && from != m.focusedIssueID() {
+		m.vim.lastJump = from
+	}
+}
+
+// gotoItem selects the nth item of the focused view (1-based), or the first
+// if n is 0.
+func (m *Model) gotoItem(n int) {
+	switch m.focused {
+	case focusBoard:
+		m.board.MoveToTop()
+		for i := 1; i < n; i++ {
+			m.board.MoveDown()
+		}
+	case focusTree:
+		m.tree.JumpToTop()
+		for i := 1; i < n; i++ {
+			m.tree.MoveDown()
+		}
+	default:
+		count := len(m.list.Items())
+		if count == 0 {
+			return
+		}
+		m.list.Select(min(max(n, 1), count) - 1)
+		if m.isSplitView {
+			m.updateViewportContent()
+		}
+	}
+}
+
+// setMark marks the selected issue.
+func (m *Model) setMark(r rune) {
+	id := m.focusedIssueID()
+	if id == "" {
+		m.statusMsg = "No issue selected"
+		m.statusIsError = true
+		return
+	}
+	if m.vim.marks == nil {
+		m.vim.marks = make(map[rune]string)
+	}
+	m.vim.marks[r] = id
+	m.statusMsg = fmt.Sprintf("Mark '%c: %s", r, id)
+}
+
+// jumpToMark selects the issue marked r.
+func (m *Model) jumpToMark(r rune) {
+	id, ok := m.vim.marks[r]
+	if !ok {
+		m.statusMsg = fmt.Sprintf("Mark '%c not set", r)
+		m.statusIsError = true
+		return
+	}
+	m.jumpToIssue(id, fmt.Sprintf("'%c", r))
+}
+
+// jumpToIssue selects issue id in the focused view; what names the jump in
+// the status line if the issue isn't shown there.
+func (m *Model) jumpToIssue(id, what string) {
+	if id == "" {
+		m.statusMsg = "No previous position"
+		m.statusIsError = true
+		return
+	}
+	from := m.focusedIssueID()
+	found := false
+	switch m.focused {
+	case focusBoard:
+		found = m.board.SelectIssueByID(id)
+	case focusTree:
+		found = m.tree.SelectByID(id)
+	default:
+		for i, item := range m.list.Items() {
+			if issueItem, ok := item.(IssueItem); ok && issueItem.Issue.ID == id {
+				m.list.Select(i)
+				found = true
+				break
+			}
+		}
+		if found && m.isSplitView {
+			m.updateViewportContent()
+		}
+	}
+	m.noteJump(from)
+	if !found {
+		m.statusMsg = fmt.Sprintf("%s: %s is not in this view", what, id)
+		m.statusIsError = true
+	}
+}
@@ -0,0 +1,157 @@
+package ui
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+func vimTestModel(t *testing.T, n int) Model {
+	t.Helper()
+	SetVimKeys(true)
+	t.Cleanup(func() { SetVimKeys(false) })
+	issues := make([]model.Issue, n)
+	for i := range issues {
+		issues[i] = model.Issue{ID: fmt.Sprintf("vim-%02d", i), Title: fmt.Sprintf("Issue %d", i),
+			Status: model.StatusOpen, IssueType: model.TypeTask, Priority: 2}
+	}
+	next, _ := NewModel(issues, nil, "").Update(tea.WindowSizeMsg{Width: 100, Height: 40})
+	return next.(Model)
+}
+
+// pressVim sends keys one at a time and returns the last command.
+func pressVim(t *testing.T, m Model, keys ...string) (Model, tea.Cmd) {
+	t.Helper()
+	var cmd tea.Cmd
+	for _, k := range keys {
+		msg, err := parseKey(k)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var next tea.Model
+		next, cmd = m.Update(msg)
+		m = next.(Model)
+	}
+	return m, cmd
+}
+
+func TestVimKeysList(t *testing.T) {
+	m := vimTestModel(t, 10)
+	steps := []struct {
+		keys []string
+		want int
+	}{
+		{[]string{"5", "j"}, 5},
+		{[]string{"g", "g"}, 0},
+		{[]string{"'", "'"}, 5}, // back to where gg started
+		{[]string{"3", "G"}, 2},
+		{[]string{"1", "2", "G"}, 9}, // past the end
+		{[]string{"2", "k"}, 7},
+		{[]string{"m", "a", "G"}, 9},
+		{[]string{"'", "a"}, 7},
+		{[]string{"4", "g", "g"}, 3},
+		{[]string{"3", "esc", "j"}, 4}, // esc drops the count
+	}
+	for _, s := range steps {
+		m, _ = pressVim(t, m, s.keys...)
+		if got := m.list.Index(); got != s.want {
+			t.Fatalf("%v: index %d, want %d", s.keys, got, s.want)
+		}
+	}
+	if m.focused != focusList {
+		t.Fatalf("focus %v, want the list", m.focused)
+	}
+
+	m, _ = pressVim(t, m, "'", "z")
+	if !m.statusIsError || !strings.Contains(m.statusMsg, "'z not set") {
+		t.Errorf("unset mark: status %q", m.statusMsg)
+	}
+}
+
+func TestVimKeysPrefixFallback(t *testing.T) {
+	m := vimTestModel(t, 3)
+
+	// A lone g opens the graph once the timeout passes
+	m, cmd := pressVim(t, m, "g")
+	if m.focused != focusList || cmd == nil || m.statusMsg != "g" {
+		t.Fatalf("g should wait for a second key: focus %v, status %q", m.focused, m.statusMsg)
+	}
+	next, _ := m.Update(vimPrefixTimeoutMsg{seq: m.vim.seq - 1})
+	if m = next.(Model); m.focused != focusList {
+		t.Fatal("a stale timeout must not resolve the prefix")
+	}
+	next, _ = m.Update(vimPrefixTimeoutMsg{seq: m.vim.seq})
+	if m = next.(Model); m.focused != focusGraph {
+		t.Fatalf("timeout: focus %v, want the graph", m.focused)
+	}
+
+	// ... or as soon as the next key isn't part of a sequence, which then
+	// gets its usual handling
+	m = vimTestModel(t, 3)
+	m, _ = pressVim(t, m, "g", "b")
+	if m.focused != focusBoard {
+		t.Fatalf("g b: focus %v, want the board (g opened the graph, b the board)", m.focused)
+	}
+
+	m = vimTestModel(t, 3)
+	m, _ = pressVim(t, m, "'", "esc")
+	if m.showRecipePicker || m.focused != focusList || m.vim.pending != "" {
+		t.Error("esc should cancel a pending '")
+	}
+	m, _ = pressVim(t, m, "'", "down")
+	if !m.showRecipePicker {
+		t.Error("' ↓: the recipe picker should open")
+	}
+}
+
+func TestVimKeysBoardAndTree(t *testing.T) {
+	m := vimTestModel(t, 6)
+	m, _ = pressVim(t, m, "b")
+	if m.focused != focusBoard {
+		t.Fatalf("focus %v, want the board", m.focused)
+	}
+	m, _ = pressVim(t, m, "3", "j", "m", "b", "g", "g")
+	top := m.board.SelectedIssue()
+	if top == nil || top.ID == m.vim.marks['b'] {
+		t.Fatalf("3j then gg: selected %v, marked %q", top, m.vim.marks['b'])
+	}
+	m, _ = pressVim(t, m, "'", "b")
+	if got := m.board.SelectedIssue(); got == nil || got.ID != m.vim.marks['b'] {
+		t.Errorf("'b on the board selected %v, want %s", got, m.vim.marks['b'])
+	}
+
+	m, _ = pressVim(t, m, "E")
+	if m.focused != focusTree {
+		t.Fatalf("focus %v, want the tree", m.focused)
+	}
+	m, _ = pressVim(t, m, "G", "g", "g")
+	first := m.tree.SelectedIssue()
+	m, _ = pressVim(t, m, "'", "'")
+	if last := m.tree.SelectedIssue(); first == nil || last == nil || last.ID == first.ID {
+		t.Errorf("'' in the tree should go back to the last issue")
+	}
+}
+
+func TestVimKeysOff(t *testing.T) {
+	m := vimTestModel(t, 5)
+	SetVimKeys(false)
+	m, _ = pressVim(t, m, "2", "j")
+	if m.list.Index() != 1 {
+		t.Errorf("without vim keys a count is ignored: index %d", m.list.Index())
+	}
+	if m, _ = pressVim(t, m, "g"); m.focused != focusGraph {
+		t.Errorf("without vim keys g opens the graph right away, focus %v", m.focused)
+	}
+	for _, g := range bindingsFor(ctxList, "") {
+		if g.Section == "Vim" {
+			t.Error("help should list vim keys only when they're enabled")
+		}
+	}
+	SetVimKeys(true)
+	if groups := bindingsFor(ctxList, "jump back"); len(groups) != 1 || groups[0].Section != "Vim" {
+		t.Errorf("help search for '': %+v", groups)
+	}
+}
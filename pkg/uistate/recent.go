@@ -0,0 +1,91 @@
+package uistate
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// RecentFilename is the recently opened projects file in BaseDir.
+const RecentFilename = "recent.json"
+
+// MaxRecent is how many projects the recent list keeps.
+const MaxRecent = 20
+
+// Project is a beads repository bv has opened.
+type Project struct {
+	Path      string    `json:"path"`       // Repository root
+	BeadsPath string    `json:"beads_path"` // Issues file bv read
+	OpenedAt  time.Time `json:"opened_at"`
+}
+
+// Recent is the projects bv has opened, most recent first.
+type Recent struct {
+	Projects []Project `json:"projects"`
+}
+
+// RecentPath returns the recent projects file path.
+func RecentPath() (string, error) {
+	base, err := BaseDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(base, RecentFilename), nil
+}
+
+// LoadRecent reads the recent projects at path. A missing file is an empty
+// list.
+func LoadRecent(path string) (*Recent, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &Recent{Projects: []Project{}}, nil
+		}
+		return nil, fmt.Errorf("reading recent projects: %w", err)
+	}
+	var r Recent
+	if err := json.Unmarshal(data, &r); err != nil {
+		return nil, fmt.Errorf("parsing recent projects: %w", err)
+	}
+	if r.Projects == nil {
+		r.Projects = []Project{}
+	}
+	return &r, nil
+}
+
+// Save writes the recent projects to path, replacing it atomically.
+func (r *Recent) Save(path string) error {
+	if err := writeJSON(path, r); err != nil {
+		return fmt.Errorf("writing recent projects: %w", err)
+	}
+	return nil
+}
+
+// Touch moves p to the front of the list, adding it if new and dropping
+// the oldest past MaxRecent.
+func (r *Recent) Touch(p Project) {
+	projects := []Project{p}
+	for _, old := range r.Projects {
+		if old.Path != p.Path && len(projects) < MaxRecent {
+			projects = append(projects, old)
+		}
+	}
+	r.Projects = projects
+}
+
+// RecordOpen notes that bv opened the project whose issues are at
+// beadsPath, in the recent projects file at path.
+func RecordOpen(path, beadsPath string, now time.Time) error {
+	abs, err := filepath.Abs(beadsPath)
+	if err != nil {
+		return err
+	}
+	r, err := LoadRecent(path)
+	if err != nil {
+		return err
+	}
+	r.Touch(Project{Path: filepath.Dir(filepath.Dir(abs)), BeadsPath: abs, OpenedAt: now})
+	return r.Save(path)
+}
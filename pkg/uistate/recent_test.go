@@ -0,0 +1,54 @@
+package uistate
+
+import (
+	"fmt"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestRecentTouch(t *testing.T) {
+	var r Recent
+	for i := 0; i < MaxRecent+5; i++ {
+		r.Touch(Project{Path: fmt.Sprintf("/work/p%d", i)})
+	}
+	if len(r.Projects) != MaxRecent {
+		t.Fatalf("%d projects, want %d", len(r.Projects), MaxRecent)
+	}
+	r.Touch(Project{Path: "/work/p10"})
+	if r.Projects[0].Path != "/work/p10" || r.Projects[1].Path != fmt.Sprintf("/work/p%d", MaxRecent+4) {
+		t.Errorf("reopened project not moved to the front: %v", r.Projects[:2])
+	}
+	seen := map[string]bool{}
+	for _, p := range r.Projects {
+		if seen[p.Path] {
+			t.Errorf("%s listed twice", p.Path)
+		}
+		seen[p.Path] = true
+	}
+}
+
+func TestRecordOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), RecentFilename)
+	repo := t.TempDir()
+	beadsPath := filepath.Join(repo, ".beads", "issues.jsonl")
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	if err := RecordOpen(path, beadsPath, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := RecordOpen(path, beadsPath, now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+	r, err := LoadRecent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(r.Projects) != 1 {
+		t.Fatalf("projects = %+v, want one", r.Projects)
+	}
+	p := r.Projects[0]
+	if p.Path != repo || p.BeadsPath != beadsPath || !p.OpenedAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("project = %+v", p)
+	}
+}
@@ -0,0 +1,136 @@
+// Package uistate remembers where the user left bv in each repository: the
+// view, filter and selected issue, the board's column order and the tree's
+// expanded nodes. It is per-user state, so it lives under $XDG_STATE_HOME/bv
+// (~/.local/state/bv by default) rather than in the repository, in one
+// directory per repository keyed by its path, next to the list of recently
+// opened projects the project switcher offers.
+package uistate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"time"
+)
+
+// StateFilename is the UI state file's name in a repository's state directory.
+const StateFilename = "ui.json"
+
+// TreeFilename is the tree view's expand/collapse state file in a
+// repository's state directory (see ui.TreeState).
+const TreeFilename = "tree.json"
+
+// Version is the current schema version of State.
+const Version = 1
+
+// State is what bv restores when it reopens a repository.
+type State struct {
+	Version      int       `json:"version"`
+	RepoPath     string    `json:"repo_path"`               // Absolute path, for finding the file by hand
+	View         string    `json:"view,omitempty"`          // list, board, graph or tree
+	Filter       string    `json:"filter,omitempty"`        // open, ready, label:X, recipe:X, ...
+	Selected     string    `json:"selected,omitempty"`      // Selected issue ID
+	SwimLane     string    `json:"swimlane,omitempty"`      // Board grouping: status, priority or type
+	BoardColumns []int     `json:"board_columns,omitempty"` // Board column order, left to right
+	SavedAt      time.Time `json:"saved_at"`
+}
+
+// BaseDir returns bv's state directory: $XDG_STATE_HOME/bv, falling back to
+// ~/.local/state/bv (%LocalAppData%\bv\state on Windows).
+func BaseDir() (string, error) {
+	if dir := os.Getenv("XDG_STATE_HOME"); filepath.IsAbs(dir) {
+		return filepath.Join(dir, "bv"), nil
+	}
+	if runtime.GOOS == "windows" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		return filepath.Join(dir, "bv", "state"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".local", "state", "bv"), nil
+}
+
+// RepoDir returns the state directory for the repository at repoPath. The
+// name is the repository's base name plus a hash of its absolute path, so
+// two checkouts of the same project keep separate state.
+func RepoDir(repoPath string) (string, error) {
+	base, err := BaseDir()
+	if err != nil {
+		return "", err
+	}
+	abs, err := filepath.Abs(repoPath)
+	if err != nil {
+		return "", err
+	}
+	hash := sha256.Sum256([]byte(abs))
+	name := strings.Trim(filepath.Base(abs), `.\/`)
+	if name == "" {
+		name = "root"
+	}
+	return filepath.Join(base, "repos", name+"-"+hex.EncodeToString(hash[:8])), nil
+}
+
+// Load reads the state at path. A missing file is an empty state.
+func Load(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return &State{Version: Version}, nil
+		}
+		return nil, fmt.Errorf("reading ui state: %w", err)
+	}
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("parsing ui state: %w", err)
+	}
+	if s.Version > Version {
+		return nil, fmt.Errorf("ui state version %d is newer than this bv (%d)", s.Version, Version)
+	}
+	return &s, nil
+}
+
+// Save writes the state to path, creating its directory and replacing the
+// file atomically.
+func (s *State) Save(path string) error {
+	s.Version = Version
+	if err := writeJSON(path, s); err != nil {
+		return fmt.Errorf("writing ui state: %w", err)
+	}
+	return nil
+}
+
+// writeJSON writes v to path as indented JSON, creating the directory and
+// replacing the file atomically.
+func writeJSON(path string, v any) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
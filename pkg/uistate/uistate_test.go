@@ -0,0 +1,66 @@
+package uistate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestRepoDir(t *testing.T) {
+	stateHome := t.TempDir()
+	t.Setenv("XDG_STATE_HOME", stateHome)
+
+	a, err := RepoDir("/work/api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := RepoDir("/other/api")
+	if a == b {
+		t.Errorf("checkouts at different paths share %s", a)
+	}
+	if again, _ := RepoDir("/work/api/"); again != a {
+		t.Errorf("same repo, different dirs: %s, %s", a, again)
+	}
+	if want := filepath.Join(stateHome, "bv", "repos") + string(filepath.Separator) + "api-"; !strings.HasPrefix(a, want) {
+		t.Errorf("RepoDir = %s, want it under %s", a, want)
+	}
+
+	// A relative XDG_STATE_HOME is invalid per the spec and ignored
+	t.Setenv("XDG_STATE_HOME", "relative")
+	t.Setenv("HOME", stateHome)
+	if dir, err := BaseDir(); err != nil || !filepath.IsAbs(dir) || strings.Contains(dir, "relative") {
+		t.Errorf("BaseDir = %q, %v", dir, err)
+	}
+}
+
+func TestSaveLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "repos", "api-0123", StateFilename)
+
+	s, err := Load(path)
+	if err != nil || s.View != "" || s.Version != Version {
+		t.Fatalf("missing file: %+v, %v", s, err)
+	}
+
+	want := State{RepoPath: "/work/api", View: "board", Filter: "label:api", Selected: "api-7",
+		SwimLane: "priority", BoardColumns: []int{3, 0, 1, 2}}
+	if err := want.Save(path); err != nil {
+		t.Fatal(err)
+	}
+	got, err := Load(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want.Version = Version
+	if !reflect.DeepEqual(*got, want) {
+		t.Errorf("round trip:\n got %+v\nwant %+v", *got, want)
+	}
+
+	if err := os.WriteFile(path, []byte(`{"version": 99}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(path); err == nil {
+		t.Error("a newer schema should not load")
+	}
+}
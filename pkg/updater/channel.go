@@ -0,0 +1,211 @@
+package updater
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// Channel selects which releases the update check considers.
+type Channel string
+
+const (
+	// ChannelStable follows GitHub's "latest" release (no pre-releases).
+	ChannelStable Channel = "stable"
+	// ChannelBeta also offers pre-releases (e.g. v1.4.0-beta.1).
+	ChannelBeta Channel = "beta"
+)
+
+// DefaultCheckInterval is how long a cached update check stays fresh.
+const DefaultCheckInterval = 24 * time.Hour
+
+// CheckConfig controls the automatic update check the TUI runs on startup.
+// Explicit commands (--check-update, bv self-update) ignore Disabled and the
+// cache but still honor Channel.
+type CheckConfig struct {
+	Channel  Channel
+	Interval time.Duration // 0 checks on every launch
+	Disabled bool          // offline opt-out: never contact GitHub automatically
+}
+
+// DefaultCheckConfig returns the configuration used when nothing is set.
+func DefaultCheckConfig() CheckConfig {
+	return CheckConfig{Channel: ChannelStable, Interval: DefaultCheckInterval}
+}
+
+var checkConfig atomic.Pointer[CheckConfig]
+
+// SetCheckConfig installs the update-check configuration. Call before the
+// first check.
+func SetCheckConfig(cfg CheckConfig) {
+	if cfg.Channel == "" {
+		cfg.Channel = ChannelStable
+	}
+	checkConfig.Store(&cfg)
+}
+
+// CurrentCheckConfig returns the active update-check configuration.
+func CurrentCheckConfig() CheckConfig {
+	if cfg := checkConfig.Load(); cfg != nil {
+		return *cfg
+	}
+	return DefaultCheckConfig()
+}
+
+// ParseChannel parses a release channel name.
+func ParseChannel(s string) (Channel, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "", "stable":
+		return ChannelStable, nil
+	case "beta":
+		return ChannelBeta, nil
+	default:
+		return "", fmt.Errorf("unknown release channel %q (want stable or beta)", s)
+	}
+}
+
+// ParseCheckInterval parses a check frequency: a Go duration ("12h"), a
+// number of days ("7d"), or "always" to check on every launch.
+func ParseCheckInterval(s string) (time.Duration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	switch s {
+	case "always":
+		return 0, nil
+	case "daily":
+		return 24 * time.Hour, nil
+	case "weekly":
+		return 7 * 24 * time.Hour, nil
+	}
+	if days, ok := strings.CutSuffix(s, "d"); ok {
+		n, err := strconv.Atoi(days)
+		if err != nil || n < 0 {
+			return 0, fmt.Errorf("invalid check interval %q", s)
+		}
+		return time.Duration(n) * 24 * time.Hour, nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < 0 {
+		return 0, fmt.Errorf("invalid check interval %q", s)
+	}
+	return d, nil
+}
+
+// releasesEndpoint returns the GitHub API URL that lists candidate releases
+// for a channel.
+func releasesEndpoint(apiBase string, channel Channel) string {
+	if channel == ChannelBeta {
+		return apiBase + "/releases?per_page=30"
+	}
+	return apiBase + "/releases/latest"
+}
+
+// decodeRelease reads either a single release object (/releases/latest) or a
+// release list (/releases), returning the newest non-draft release in the
+// latter case.
+func decodeRelease(r io.Reader) (*Release, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	trimmed := strings.TrimSpace(string(data))
+	if !strings.HasPrefix(trimmed, "[") {
+		var rel Release
+		if err := json.Unmarshal(data, &rel); err != nil {
+			return nil, err
+		}
+		return &rel, nil
+	}
+
+	var rels []Release
+	if err := json.Unmarshal(data, &rels); err != nil {
+		return nil, err
+	}
+	var best *Release
+	for i := range rels {
+		if rels[i].Draft || rels[i].TagName == "" {
+			continue
+		}
+		if best == nil || compareVersions(rels[i].TagName, best.TagName) > 0 {
+			best = &rels[i]
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no published releases found")
+	}
+	return best, nil
+}
+
+// checkCache is the last update-check result, persisted so bv doesn't hit
+// the GitHub API on every launch.
+type checkCache struct {
+	CheckedAt time.Time `json:"checked_at"`
+	Channel   Channel   `json:"channel"`
+	LatestTag string    `json:"latest_tag"`
+	LatestURL string    `json:"latest_url"`
+}
+
+// checkCachePath returns $BV_CACHE_DIR/update-check.json, defaulting to the
+// user cache dir (e.g. ~/.cache/bv/update-check.json).
+func checkCachePath() (string, error) {
+	base := os.Getenv("BV_CACHE_DIR")
+	if base == "" {
+		dir, err := os.UserCacheDir()
+		if err != nil {
+			return "", err
+		}
+		base = filepath.Join(dir, "bv")
+	}
+	return filepath.Join(base, "update-check.json"), nil
+}
+
+func readCheckCache() (checkCache, bool) {
+	var c checkCache
+	path, err := checkCachePath()
+	if err != nil {
+		return c, false
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return c, false
+	}
+	if err := json.Unmarshal(data, &c); err != nil {
+		return checkCache{}, false
+	}
+	return c, true
+}
+
+// writeCheckCache records a check result. Failures are ignored: the cache is
+// only an optimization.
+func writeCheckCache(c checkCache) {
+	path, err := checkCachePath()
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return
+	}
+	data, err := json.Marshal(c)
+	if err != nil {
+		return
+	}
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return
+	}
+	_ = os.Rename(tmp, path)
+}
+
+// fresh reports whether a cached result can stand in for a network check.
+func (c checkCache) fresh(cfg CheckConfig, now time.Time) bool {
+	if c.Channel != cfg.Channel || c.LatestTag == "" || cfg.Interval <= 0 {
+		return false
+	}
+	age := now.Sub(c.CheckedAt)
+	return age >= 0 && age < cfg.Interval
+}
@@ -0,0 +1,151 @@
+package updater
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseChannel(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    Channel
+		wantErr bool
+	}{
+		{"", ChannelStable, false},
+		{"stable", ChannelStable, false},
+		{" Beta ", ChannelBeta, false},
+		{"nightly", "", true},
+	}
+	for _, tt := range tests {
+		got, err := ParseChannel(tt.in)
+		if (err != nil) != tt.wantErr || got != tt.want {
+			t.Errorf("ParseChannel(%q) = (%q, %v), want (%q, err=%v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestParseCheckInterval(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    time.Duration
+		wantErr bool
+	}{
+		{"always", 0, false},
+		{"daily", 24 * time.Hour, false},
+		{"weekly", 7 * 24 * time.Hour, false},
+		{"3d", 72 * time.Hour, false},
+		{"90m", 90 * time.Minute, false},
+		{"-1h", 0, true},
+		{"xd", 0, true},
+		{"soon", 0, true},
+	}
+	for _, tt := range tests {
+		got, err := ParseCheckInterval(tt.in)
+		if (err != nil) != tt.wantErr || (!tt.wantErr && got != tt.want) {
+			t.Errorf("ParseCheckInterval(%q) = (%v, %v), want (%v, err=%v)", tt.in, got, err, tt.want, tt.wantErr)
+		}
+	}
+}
+
+func TestReleasesEndpoint(t *testing.T) {
+	if got := releasesEndpoint("http://api", ChannelStable); got != "http://api/releases/latest" {
+		t.Errorf("stable endpoint = %q", got)
+	}
+	if got := releasesEndpoint("http://api", ChannelBeta); !strings.HasPrefix(got, "http://api/releases?") {
+		t.Errorf("beta endpoint = %q", got)
+	}
+}
+
+func TestDecodeRelease_ListPicksNewestPublished(t *testing.T) {
+	rels := []Release{
+		{TagName: "v1.2.0"},
+		{TagName: "v1.4.0", Draft: true},
+		{TagName: "v1.3.0-beta.2", Prerelease: true},
+		{TagName: "v1.3.0-beta.1", Prerelease: true},
+	}
+	data, _ := json.Marshal(rels)
+	rel, err := decodeRelease(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("decodeRelease: %v", err)
+	}
+	if rel.TagName != "v1.3.0-beta.2" {
+		t.Errorf("expected v1.3.0-beta.2 (drafts skipped), got %s", rel.TagName)
+	}
+
+	if _, err := decodeRelease(strings.NewReader(`[{"tag_name":"v2.0.0","draft":true}]`)); err == nil {
+		t.Error("expected error when only drafts are listed")
+	}
+}
+
+func TestCheckForUpdates_BetaChannelSeesPrereleases(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode([]Release{
+			{TagName: "v98.0.0", HTMLURL: "http://example.com/stable"},
+			{TagName: "v99.0.0-beta.1", HTMLURL: "http://example.com/beta", Prerelease: true},
+		})
+	}))
+	defer server.Close()
+
+	tag, url, err := checkForUpdates(server.Client(), releasesEndpoint(server.URL, ChannelBeta))
+	if err != nil {
+		t.Fatalf("checkForUpdates: %v", err)
+	}
+	if tag != "v99.0.0-beta.1" || url != "http://example.com/beta" {
+		t.Errorf("got (%s, %s), want the beta release", tag, url)
+	}
+}
+
+func TestCheckCache_Fresh(t *testing.T) {
+	now := time.Now()
+	cfg := CheckConfig{Channel: ChannelStable, Interval: time.Hour}
+	c := checkCache{CheckedAt: now.Add(-30 * time.Minute), Channel: ChannelStable, LatestTag: "v1.0.0"}
+
+	if !c.fresh(cfg, now) {
+		t.Error("expected 30m-old cache to be fresh with a 1h interval")
+	}
+	if c.fresh(CheckConfig{Channel: ChannelBeta, Interval: time.Hour}, now) {
+		t.Error("cache from another channel must not be reused")
+	}
+	if c.fresh(CheckConfig{Channel: ChannelStable}, now) {
+		t.Error("interval 0 must always check")
+	}
+	if c.fresh(cfg, now.Add(time.Hour)) {
+		t.Error("expected cache to expire after the interval")
+	}
+	future := checkCache{CheckedAt: now.Add(time.Hour), Channel: ChannelStable, LatestTag: "v1.0.0"}
+	if future.fresh(cfg, now) {
+		t.Error("cache timestamped in the future (clock skew) must not be trusted")
+	}
+}
+
+func TestCheckForUpdates_UsesCacheAndOptOut(t *testing.T) {
+	t.Setenv("BV_CACHE_DIR", t.TempDir())
+	prev := CurrentCheckConfig()
+	t.Cleanup(func() { SetCheckConfig(prev) })
+
+	writeCheckCache(checkCache{
+		CheckedAt: time.Now(),
+		Channel:   ChannelStable,
+		LatestTag: "v99.0.0",
+		LatestURL: "http://example.com/cached",
+	})
+	if c, ok := readCheckCache(); !ok || c.LatestTag != "v99.0.0" {
+		t.Fatalf("readCheckCache = (%+v, %v)", c, ok)
+	}
+
+	// A fresh cache answers without touching the network.
+	SetCheckConfig(CheckConfig{Channel: ChannelStable, Interval: time.Hour})
+	tag, url, err := CheckForUpdates()
+	if err != nil || tag != "v99.0.0" || url != "http://example.com/cached" {
+		t.Errorf("cached check = (%q, %q, %v)", tag, url, err)
+	}
+
+	SetCheckConfig(CheckConfig{Channel: ChannelStable, Interval: time.Hour, Disabled: true})
+	if tag, _, err := CheckForUpdates(); tag != "" || err != nil {
+		t.Errorf("disabled check = (%q, %v), want nothing", tag, err)
+	}
+}
@@ -102,3 +102,51 @@ func TestRollback_NoBackup(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 }
+
+func TestReplaceBinary_SwapsInPlace(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "bv")
+	newBin := filepath.Join(t.TempDir(), "bv-new") // different dir, like the download temp dir
+
+	if err := os.WriteFile(target, []byte("old"), 0o755); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+	if err := os.WriteFile(newBin, []byte("new"), 0o644); err != nil {
+		t.Fatalf("write new: %v", err)
+	}
+
+	if err := replaceBinary(newBin, target); err != nil {
+		t.Fatalf("replaceBinary failed: %v", err)
+	}
+
+	got, err := os.ReadFile(target)
+	if err != nil || string(got) != "new" {
+		t.Fatalf("target content = %q, %v; want %q", got, err, "new")
+	}
+	if info, err := os.Stat(target); err != nil || info.Mode().Perm()&0o100 == 0 {
+		t.Fatalf("expected executable target, got %v (%v)", info.Mode(), err)
+	}
+
+	// No staging files left behind.
+	entries, _ := os.ReadDir(tmpDir)
+	for _, e := range entries {
+		if strings.HasPrefix(e.Name(), ".bv-new-") {
+			t.Fatalf("staging file left behind: %s", e.Name())
+		}
+	}
+}
+
+func TestReplaceBinary_MissingSourceLeavesTarget(t *testing.T) {
+	tmpDir := t.TempDir()
+	target := filepath.Join(tmpDir, "bv")
+	if err := os.WriteFile(target, []byte("old"), 0o755); err != nil {
+		t.Fatalf("write target: %v", err)
+	}
+
+	if err := replaceBinary(filepath.Join(tmpDir, "missing"), target); err == nil {
+		t.Fatal("expected error for missing source")
+	}
+	if got, _ := os.ReadFile(target); string(got) != "old" {
+		t.Fatalf("target modified on failure: %q", got)
+	}
+}
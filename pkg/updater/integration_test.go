@@ -269,3 +269,22 @@ func TestRelease_FindPlatformAsset_NoAssets(t *testing.T) {
 		t.Errorf("expected nil for empty assets, got %+v", asset)
 	}
 }
+
+func TestPerformUpdate_RefusesWithoutChecksums(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("archive"))
+	}))
+	defer server.Close()
+
+	release := &Release{
+		TagName: "v99.0.0",
+		Assets: []Asset{
+			{Name: getAssetName("v99.0.0"), BrowserDownloadURL: server.URL + "/bv.tar.gz"},
+		},
+	}
+
+	_, err := PerformUpdate(release, true)
+	if err == nil || !strings.Contains(err.Error(), "refusing to install an unverified binary") {
+		t.Fatalf("expected refusal without checksums.txt, got %v", err)
+	}
+}
@@ -5,7 +5,6 @@ import (
 	"compress/gzip"
 	"crypto/sha256"
 	"encoding/hex"
-	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -17,9 +16,12 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Dicklesworthstone/beads_viewer/pkg/debug"
 	"github.com/Dicklesworthstone/beads_viewer/pkg/version"
 )
 
+var logger = debug.For("updater")
+
 const (
 	repoOwner = "Dicklesworthstone"
 	repoName  = "beads_viewer"
@@ -28,9 +30,11 @@ const (
 
 // Release represents a GitHub release
 type Release struct {
-	TagName string  `json:"tag_name"`
-	HTMLURL string  `json:"html_url"`
-	Assets  []Asset `json:"assets"`
+	TagName    string  `json:"tag_name"`
+	HTMLURL    string  `json:"html_url"`
+	Draft      bool    `json:"draft"`
+	Prerelease bool    `json:"prerelease"`
+	Assets     []Asset `json:"assets"`
 }
 
 // Asset represents a release asset (binary, checksum file, etc.)
@@ -50,17 +54,60 @@ type UpdateResult struct {
 	RequireRoot bool   `json:"require_root,omitempty"`
 }
 
-// CheckForUpdates queries GitHub for the latest release.
+// CheckForUpdates is the automatic startup check. It honors the configured
+// channel, the offline opt-out, and the cached result from the last check.
 // Returns the new version tag if an update is available, empty string otherwise.
 func CheckForUpdates() (string, string, error) {
+	cfg := CurrentCheckConfig()
+	if cfg.Disabled {
+		logger.Debug("update check disabled")
+		return "", "", nil
+	}
+	if c, ok := readCheckCache(); ok && c.fresh(cfg, time.Now()) {
+		logger.Debug("using cached update check", "channel", c.Channel, "latest", c.LatestTag, "checked_at", c.CheckedAt)
+		return newerThanCurrent(c.LatestTag, c.LatestURL)
+	}
+	return checkNow(cfg)
+}
+
+// checkNow queries GitHub for the channel's latest release and caches it.
+func checkNow(cfg CheckConfig) (string, string, error) {
 	// Set a short timeout to avoid blocking startup for too long
 	client := &http.Client{
 		Timeout: 2 * time.Second,
 	}
-	return checkForUpdates(client, "https://api.github.com/repos/Dicklesworthstone/beads_viewer/releases/latest")
+	tag, url, err := fetchLatestTag(client, releasesEndpoint(baseURL, cfg.Channel))
+	if err != nil {
+		logger.Info("update check failed", "channel", cfg.Channel, "err", err)
+		return "", "", err
+	}
+	logger.Debug("update check", "channel", cfg.Channel, "latest", tag)
+	if tag != "" {
+		writeCheckCache(checkCache{CheckedAt: time.Now(), Channel: cfg.Channel, LatestTag: tag, LatestURL: url})
+	}
+	return newerThanCurrent(tag, url)
+}
+
+// newerThanCurrent returns tag and url if tag is newer than the running
+// version, empty strings otherwise.
+func newerThanCurrent(tag, url string) (string, string, error) {
+	if tag != "" && compareVersions(tag, version.Version) > 0 {
+		return tag, url, nil
+	}
+	return "", "", nil
 }
 
 func checkForUpdates(client *http.Client, url string) (string, string, error) {
+	tag, htmlURL, err := fetchLatestTag(client, url)
+	if err != nil {
+		return "", "", err
+	}
+	return newerThanCurrent(tag, htmlURL)
+}
+
+// fetchLatestTag returns the tag and page URL of the newest release at url.
+// Rate limiting yields empty strings and no error.
+func fetchLatestTag(client *http.Client, url string) (string, string, error) {
 	req, err := http.NewRequest(http.MethodGet, url, nil)
 	if err != nil {
 		return "", "", err
@@ -82,18 +129,11 @@ func checkForUpdates(client *http.Client, url string) (string, string, error) {
 		return "", "", fmt.Errorf("github api returned status: %s", resp.Status)
 	}
 
-	var rel Release
-	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+	rel, err := decodeRelease(resp.Body)
+	if err != nil {
 		return "", "", err
 	}
-
-	// Compare versions
-	// Assumes SemVer with 'v' prefix
-	if compareVersions(rel.TagName, version.Version) > 0 {
-		return rel.TagName, rel.HTMLURL, nil
-	}
-
-	return "", "", nil
+	return rel.TagName, rel.HTMLURL, nil
 }
 
 // compareVersions compares semver-ish strings with optional leading 'v' and optional pre-release
@@ -309,10 +349,11 @@ func compareVersions(v1, v2 string) int {
 	return 0
 }
 
-// GetLatestRelease fetches full release info including assets
+// GetLatestRelease fetches full release info, including assets, for the
+// newest release on the configured channel.
 func GetLatestRelease() (*Release, error) {
 	client := &http.Client{Timeout: 30 * time.Second}
-	req, err := http.NewRequest(http.MethodGet, baseURL+"/releases/latest", nil)
+	req, err := http.NewRequest(http.MethodGet, releasesEndpoint(baseURL, CurrentCheckConfig().Channel), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -328,12 +369,12 @@ func GetLatestRelease() (*Release, error) {
 		return nil, fmt.Errorf("github api returned status: %s", resp.Status)
 	}
 
-	var rel Release
-	if err := json.NewDecoder(resp.Body).Decode(&rel); err != nil {
+	rel, err := decodeRelease(resp.Body)
+	if err != nil {
 		return nil, fmt.Errorf("failed to parse release info: %w", err)
 	}
 
-	return &rel, nil
+	return rel, nil
 }
 
 // getAssetName returns the expected asset name for the current platform
@@ -573,28 +614,30 @@ func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
 		return nil, fmt.Errorf("download failed: %w", err)
 	}
 
-	// Download and verify checksum
+	// Download and verify checksum. Releases don't publish signatures, so the
+	// checksum is the only integrity check we have; refuse to install without it.
 	checksumAsset := release.FindChecksumAsset()
-	if checksumAsset != nil {
-		checksumPath := filepath.Join(tmpDir, "checksums.txt")
-		if err := downloadFile(checksumAsset.BrowserDownloadURL, checksumPath, checksumAsset.Size); err != nil {
-			return nil, fmt.Errorf("checksum download failed: %w", err)
-		}
+	if checksumAsset == nil {
+		return nil, fmt.Errorf("release %s has no checksums.txt; refusing to install an unverified binary", release.TagName)
+	}
+	checksumPath := filepath.Join(tmpDir, "checksums.txt")
+	if err := downloadFile(checksumAsset.BrowserDownloadURL, checksumPath, checksumAsset.Size); err != nil {
+		return nil, fmt.Errorf("checksum download failed: %w", err)
+	}
 
-		checksums, err := parseChecksums(checksumPath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to parse checksums: %w", err)
-		}
+	checksums, err := parseChecksums(checksumPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse checksums: %w", err)
+	}
 
-		expectedHash, ok := checksums[asset.Name]
-		if !ok {
-			return nil, fmt.Errorf("no checksum found for %s", asset.Name)
-		}
+	expectedHash, ok := checksums[asset.Name]
+	if !ok {
+		return nil, fmt.Errorf("no checksum found for %s", asset.Name)
+	}
 
-		fmt.Println("Verifying checksum...")
-		if err := verifyChecksum(archivePath, expectedHash); err != nil {
-			return nil, fmt.Errorf("checksum verification failed: %w", err)
-		}
+	fmt.Println("Verifying checksum...")
+	if err := verifyChecksum(archivePath, expectedHash); err != nil {
+		return nil, fmt.Errorf("checksum verification failed: %w", err)
 	}
 
 	// Extract binary to temp location
@@ -623,21 +666,8 @@ func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
 
 	// Replace binary
 	fmt.Println("Installing new version...")
-	if err := os.Rename(newBinaryPath, binaryPath); err != nil {
-		// On some systems, rename across filesystems doesn't work
-		if err := copyFile(newBinaryPath, binaryPath); err != nil {
-			// Restore from backup
-			if restoreErr := copyFile(backupPath, binaryPath); restoreErr != nil {
-				return nil, fmt.Errorf("installation failed: %w (restore also failed: %v; manual recovery: cp %s %s)", err, restoreErr, backupPath, binaryPath)
-			}
-			return nil, fmt.Errorf("installation failed (restored from backup): %w", err)
-		}
-	}
-
-	// Ensure executable permissions
-	if err := os.Chmod(binaryPath, 0755); err != nil {
-		// Not fatal, but log it
-		fmt.Fprintf(os.Stderr, "Warning: could not set permissions: %v\n", err)
+	if err := replaceBinary(newBinaryPath, binaryPath); err != nil {
+		return nil, fmt.Errorf("installation failed (current binary unchanged): %w", err)
 	}
 
 	result.Success = true
@@ -645,6 +675,56 @@ func PerformUpdate(release *Release, skipConfirm bool) (*UpdateResult, error) {
 	return result, nil
 }
 
+// replaceBinary atomically swaps binaryPath for newBinaryPath. The new binary
+// is first staged next to the target so the final step is a same-filesystem
+// rename: readers see either the old or the new executable, never a partial
+// one. Windows can't replace a running executable, so there the current one is
+// moved aside first (and moved back if the swap fails).
+func replaceBinary(newBinaryPath, binaryPath string) error {
+	dir := filepath.Dir(binaryPath)
+	staged, err := os.CreateTemp(dir, ".bv-new-*")
+	if err != nil {
+		return fmt.Errorf("staging new binary: %w", err)
+	}
+	stagedPath := staged.Name()
+	defer os.Remove(stagedPath) // no-op after a successful rename
+
+	src, err := os.Open(newBinaryPath)
+	if err != nil {
+		staged.Close()
+		return err
+	}
+	_, err = io.Copy(staged, src)
+	src.Close()
+	if err == nil {
+		err = staged.Chmod(0755)
+	}
+	if err == nil {
+		err = staged.Sync()
+	}
+	if closeErr := staged.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("staging new binary: %w", err)
+	}
+
+	if runtime.GOOS == "windows" {
+		oldPath := binaryPath + ".old"
+		_ = os.Remove(oldPath)
+		if err := os.Rename(binaryPath, oldPath); err != nil {
+			return fmt.Errorf("moving current binary aside: %w", err)
+		}
+		if err := os.Rename(stagedPath, binaryPath); err != nil {
+			_ = os.Rename(oldPath, binaryPath)
+			return err
+		}
+		return nil
+	}
+
+	return os.Rename(stagedPath, binaryPath)
+}
+
 // copyFile copies a file from src to dst
 func copyFile(src, dst string) error {
 	sourceFile, err := os.Open(src)
@@ -695,9 +775,11 @@ func Rollback() error {
 	return nil
 }
 
-// CheckUpdateAvailable is a convenience wrapper that checks and returns update info
+// CheckUpdateAvailable is a convenience wrapper that checks and returns update
+// info. Unlike CheckForUpdates it always contacts GitHub, ignoring the offline
+// opt-out and the cached result, since the user asked explicitly.
 func CheckUpdateAvailable() (available bool, newVersion string, releaseURL string, err error) {
-	newVersion, releaseURL, err = CheckForUpdates()
+	newVersion, releaseURL, err = checkNow(CurrentCheckConfig())
 	if err != nil {
 		return false, "", "", err
 	}
@@ -0,0 +1,49 @@
+// Package jsonnorm normalizes decoded JSON (the map[string]any / []any trees
+// encoding/json produces) so two outputs can be compared for meaningful
+// differences only. It backs both --verify-determinism and the robot golden
+// tests, which must agree on what counts as noise.
+package jsonnorm
+
+import "strconv"
+
+// Options controls Normalize.
+type Options struct {
+	// DropKeys are object keys removed at every depth (timestamps, timings).
+	DropKeys map[string]bool
+
+	// FloatDigits rounds numbers to this many significant digits, absorbing
+	// last-bit summation noise. Zero leaves numbers as they are.
+	FloatDigits int
+
+	// String, if set, rewrites every string value.
+	String func(string) string
+}
+
+// Normalize applies opts to v in place and returns the result. Maps and
+// slices are modified; scalars are returned rewritten.
+func Normalize(v any, opts Options) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, child := range t {
+			if opts.DropKeys[k] {
+				delete(t, k)
+				continue
+			}
+			t[k] = Normalize(child, opts)
+		}
+	case []any:
+		for i := range t {
+			t[i] = Normalize(t[i], opts)
+		}
+	case string:
+		if opts.String != nil {
+			return opts.String(t)
+		}
+	case float64:
+		if opts.FloatDigits > 0 {
+			rounded, _ := strconv.ParseFloat(strconv.FormatFloat(t, 'g', opts.FloatDigits, 64), 64)
+			return rounded
+		}
+	}
+	return v
+}
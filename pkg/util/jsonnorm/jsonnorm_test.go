@@ -0,0 +1,36 @@
+package jsonnorm
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	opts := Options{
+		DropKeys:    map[string]bool{"ms": true},
+		FloatDigits: 10,
+		String:      strings.ToUpper,
+	}
+	v := Normalize(map[string]any{
+		"ms":    1.5,
+		"name":  "a",
+		"items": []any{map[string]any{"ms": 2.0, "score": 0.9115466608943957}},
+	}, opts).(map[string]any)
+
+	if _, ok := v["ms"]; ok {
+		t.Error("top-level dropped key survived")
+	}
+	if v["name"] != "A" {
+		t.Errorf("string not rewritten: %v", v["name"])
+	}
+	item := v["items"].([]any)[0].(map[string]any)
+	if _, ok := item["ms"]; ok {
+		t.Error("nested dropped key survived")
+	}
+	if item["score"] != Normalize(0.911546660894396, opts) {
+		t.Errorf("last-bit float noise not normalized: %v", item["score"])
+	}
+	if got := Normalize(0.1234567890123, Options{}); got != 0.1234567890123 {
+		t.Errorf("zero options changed value: %v", got)
+	}
+}
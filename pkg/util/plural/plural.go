@@ -0,0 +1,11 @@
+// Package plural picks the singular or plural form of an English noun for
+// counts in messages ("1 commit", "3 commits").
+package plural
+
+// Word returns singular when count is 1 and singular+"s" otherwise.
+func Word(count int, singular string) string {
+	if count == 1 {
+		return singular
+	}
+	return singular + "s"
+}
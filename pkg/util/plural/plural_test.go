@@ -0,0 +1,19 @@
+package plural
+
+import "testing"
+
+func TestWord(t *testing.T) {
+	tests := []struct {
+		count int
+		want  string
+	}{
+		{0, "commits"},
+		{1, "commit"},
+		{2, "commits"},
+	}
+	for _, tt := range tests {
+		if got := Word(tt.count, "commit"); got != tt.want {
+			t.Errorf("Word(%d, commit) = %q, want %q", tt.count, got, tt.want)
+		}
+	}
+}
@@ -29,6 +29,12 @@ func TestMain(m *testing.M) {
 	os.Setenv("BV_NO_BROWSER", "1")
 	os.Setenv("BV_TEST_MODE", "1")
 
+	// Keep UI state saved by TUI runs out of the user's state directory
+	stateDir, err := os.MkdirTemp("", "bv-e2e-state-*")
+	if err == nil {
+		os.Setenv("XDG_STATE_HOME", stateDir)
+	}
+
 	// Build the binary once for all tests
 	if err := buildBvOnce(); err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to build bv binary: %v\n", err)
@@ -41,6 +47,9 @@ func TestMain(m *testing.M) {
 	if bvBinaryDir != "" {
 		_ = os.RemoveAll(bvBinaryDir)
 	}
+	if stateDir != "" {
+		_ = os.RemoveAll(stateDir)
+	}
 	os.Exit(code)
 }
 
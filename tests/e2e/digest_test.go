@@ -0,0 +1,83 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDigestSinceRevision(t *testing.T) {
+	bv := buildBvBinary(t)
+	repoDir, _ := initGitRepo(t)
+
+	// Close A in the working copy, on top of the commit that added B
+	current := `{"id":"A","title":"Alpha","status":"closed","priority":1,"issue_type":"task"}` + "\n" +
+		`{"id":"B","title":"Beta","status":"open","priority":2,"issue_type":"task"}`
+	if err := os.WriteFile(filepath.Join(repoDir, ".beads", "beads.jsonl"), []byte(current), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+
+	cmd := exec.Command(bv, "digest", "--since", "HEAD~1", "--format", "json")
+	cmd.Dir = repoDir
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("bv digest --format json failed: %v\n%s", err, out)
+	}
+	var report struct {
+		FromRevision string `json:"from_revision"`
+		Closed       []struct {
+			ID string `json:"id"`
+		} `json:"closed"`
+		New []struct {
+			ID string `json:"id"`
+		} `json:"new"`
+		TopPicks []struct {
+			ID  string `json:"id"`
+			New bool   `json:"new"`
+		} `json:"top_picks"`
+		NewAlerts []json.RawMessage `json:"new_alerts"`
+	}
+	if err := json.Unmarshal(out, &report); err != nil {
+		t.Fatalf("json decode: %v\n%s", err, out)
+	}
+	if report.FromRevision == "" {
+		t.Error("from_revision is empty")
+	}
+	if len(report.Closed) != 1 || report.Closed[0].ID != "A" {
+		t.Errorf("closed = %+v, want [A]", report.Closed)
+	}
+	if len(report.New) != 1 || report.New[0].ID != "B" {
+		t.Errorf("new = %+v, want [B]", report.New)
+	}
+	if len(report.TopPicks) == 0 || report.TopPicks[0].ID != "B" || !report.TopPicks[0].New {
+		t.Errorf("top picks = %+v, want B marked new", report.TopPicks)
+	}
+	if report.NewAlerts == nil {
+		t.Error("new_alerts should be an empty array, not null")
+	}
+
+	cmd = exec.Command(bv, "digest", "--since", "HEAD~1")
+	cmd.Dir = repoDir
+	out, err = cmd.Output()
+	if err != nil {
+		t.Fatalf("bv digest failed: %v\n%s", err, out)
+	}
+	md := string(out)
+	for _, want := range []string{"## bv digest: changes since HEAD~1", "### Closed (1)", "- `A` Alpha", "### New (1)", "- `B` Beta (P2)", "### Top picks"} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestDigestRejectsUnknownFormat(t *testing.T) {
+	bv := buildBvBinary(t)
+	cmd := exec.Command(bv, "digest", "--format", "html")
+	err := cmd.Run()
+	if exitErr, ok := err.(*exec.ExitError); !ok || exitErr.ExitCode() != 2 {
+		t.Fatalf("expected exit code 2, got %v", err)
+	}
+}
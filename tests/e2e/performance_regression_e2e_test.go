@@ -3,13 +3,15 @@ package main_test
 import (
 	"bytes"
 	"encoding/json"
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/datagen"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/model"
 )
 
 // Performance Regression Tests for bv-ut9x
@@ -337,86 +339,43 @@ func TestPerf_ProfileStartup(t *testing.T) {
 // Test Data Generators
 // =============================================================================
 
-// createTestDataset creates a test dataset with the specified number of issues.
+// createTestDataset creates a chain of count issues, each blocked by the
+// previous one.
 func createTestDataset(t *testing.T, dir string, count int) {
 	t.Helper()
-
-	beadsDir := filepath.Join(dir, ".beads")
-	if err := os.MkdirAll(beadsDir, 0755); err != nil {
-		t.Fatalf("failed to create .beads dir: %v", err)
-	}
-
-	var lines []string
-	for i := 0; i < count; i++ {
-		var deps string
-		// Create chain dependencies (each depends on previous)
-		if i > 0 {
-			deps = fmt.Sprintf(`,"dependencies":[{"depends_on_id":"perf-%d","type":"blocks"}]`, i-1)
-		}
-		line := fmt.Sprintf(`{"id":"perf-%d","title":"Performance Test Issue %d","status":"open","priority":%d%s}`,
-			i, i, i%5, deps)
-		lines = append(lines, line)
-	}
-
-	issuesPath := filepath.Join(beadsDir, "issues.jsonl")
-	if err := os.WriteFile(issuesPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
-		t.Fatalf("failed to write issues.jsonl: %v", err)
-	}
+	writeGeneratedDataset(t, dir, datagen.Config{Nodes: count, Shape: datagen.ShapeChain, Seed: 1, Prefix: "perf"})
 }
 
-// createCyclicDataset creates a dataset with cycles.
+// createCyclicDataset creates a dataset that is one dependency ring.
 func createCyclicDataset(t *testing.T, dir string, count int) {
 	t.Helper()
-
-	beadsDir := filepath.Join(dir, ".beads")
-	if err := os.MkdirAll(beadsDir, 0755); err != nil {
-		t.Fatalf("failed to create .beads dir: %v", err)
-	}
-
-	var lines []string
-	for i := 0; i < count; i++ {
-		// Create cycles: each node depends on the next, last depends on first
-		nextIdx := (i + 1) % count
-		line := fmt.Sprintf(`{"id":"cycle-%d","title":"Cyclic Issue %d","status":"open","priority":%d,"dependencies":[{"depends_on_id":"cycle-%d","type":"blocks"}]}`,
-			i, i, i%5, nextIdx)
-		lines = append(lines, line)
-	}
-
-	issuesPath := filepath.Join(beadsDir, "issues.jsonl")
-	if err := os.WriteFile(issuesPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
-		t.Fatalf("failed to write issues.jsonl: %v", err)
-	}
+	writeGeneratedDataset(t, dir, datagen.Config{Nodes: count, Shape: datagen.ShapeCyclic, Seed: 1, Prefix: "cycle"})
 }
 
 // createDenseDataset creates a dataset with many dependencies (dense graph).
 func createDenseDataset(t *testing.T, dir string, count int) {
 	t.Helper()
+	writeGeneratedDataset(t, dir, datagen.Config{Nodes: count, Shape: datagen.ShapeDense, Seed: 1, Prefix: "dense"})
+}
+
+// writeGeneratedDataset writes the dataset `bv generate` would produce for
+// cfg to dir/.beads/issues.jsonl.
+func writeGeneratedDataset(t *testing.T, dir string, cfg datagen.Config) {
+	t.Helper()
 
+	issues, err := datagen.Generate(cfg)
+	if err != nil {
+		t.Fatalf("generate dataset: %v", err)
+	}
+	data, err := model.MarshalIssues(issues)
+	if err != nil {
+		t.Fatalf("marshal dataset: %v", err)
+	}
 	beadsDir := filepath.Join(dir, ".beads")
 	if err := os.MkdirAll(beadsDir, 0755); err != nil {
 		t.Fatalf("failed to create .beads dir: %v", err)
 	}
-
-	var lines []string
-	for i := 0; i < count; i++ {
-		var deps []string
-		// Each node depends on ~5 previous nodes
-		for j := 1; j <= 5 && i-j >= 0; j++ {
-			deps = append(deps, fmt.Sprintf(`{"depends_on_id":"dense-%d","type":"blocks"}`, i-j))
-		}
-
-		var depsJSON string
-		if len(deps) > 0 {
-			depsJSON = fmt.Sprintf(`,"dependencies":[%s]`, strings.Join(deps, ","))
-		}
-
-		line := fmt.Sprintf(`{"id":"dense-%d","title":"Dense Issue %d","status":"open","priority":%d%s}`,
-			i, i, i%5, depsJSON)
-		lines = append(lines, line)
-	}
-
-	issuesPath := filepath.Join(beadsDir, "issues.jsonl")
-	if err := os.WriteFile(issuesPath, []byte(strings.Join(lines, "\n")), 0644); err != nil {
+	if err := os.WriteFile(filepath.Join(beadsDir, "issues.jsonl"), data, 0644); err != nil {
 		t.Fatalf("failed to write issues.jsonl: %v", err)
 	}
 }
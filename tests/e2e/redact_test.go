@@ -0,0 +1,242 @@
+package main_test
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRedactHidesContentButKeepsStructure(t *testing.T) {
+	bv := buildBvBinary(t)
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"id":"A","title":"Acquire Initech","description":"Secret merger","status":"open","priority":1,"issue_type":"task","assignee":"alice@example.com"}` + "\n" +
+		`{"id":"B","title":"Announce merger","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}`
+	if err := os.WriteFile(filepath.Join(dir, ".beads", "beads.jsonl"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	run := func(args ...string) (string, error) {
+		cmd := exec.Command(bv, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "BEADS_DIR=")
+		out, err := cmd.CombinedOutput()
+		return string(out), err
+	}
+
+	plain, err := run("--robot-triage")
+	if err != nil || !strings.Contains(plain, "Acquire Initech") {
+		t.Fatalf("unredacted triage: %v\n%s", err, plain)
+	}
+	redacted, err := run("--robot-triage", "--redact")
+	if err != nil {
+		t.Fatalf("--redact triage: %v\n%s", err, redacted)
+	}
+	for _, secret := range []string{"Initech", "merger", "alice"} {
+		if strings.Contains(redacted, secret) {
+			t.Errorf("redacted output leaks %q:\n%s", secret, redacted)
+		}
+	}
+	if !strings.Contains(redacted, `"A"`) || !strings.Contains(redacted, "redacted-") {
+		t.Errorf("redacted output lost IDs or hashes:\n%s", redacted)
+	}
+
+	md := filepath.Join(dir, "report.md")
+	if out, err := run("--export-md", md, "--redact", "--no-hooks"); err != nil {
+		t.Fatalf("--export-md --redact: %v\n%s", err, out)
+	}
+	if report, _ := os.ReadFile(md); strings.Contains(string(report), "Initech") || !strings.Contains(string(report), "redacted-") {
+		t.Errorf("redacted markdown export:\n%s", report)
+	}
+}
+
+// redactSecrets are the fixture's git authors and commit subjects, which no
+// --redact output may contain.
+var redactSecrets = []string{"Alice", "Secret", "alice.secret", "Zanzibar", "Quixotic"}
+
+// createRedactRepo returns a repository whose commits, by Alice Secret,
+// touch the beads and a source file under subjects naming the issues. RD-2
+// is in review, and Alice owns its file per CODEOWNERS.
+func createRedactRepo(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	git := func(args ...string) {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(),
+			"GIT_AUTHOR_NAME=Alice Secret",
+			"GIT_AUTHOR_EMAIL=alice.secret@example.com",
+			"GIT_COMMITTER_NAME=Alice Secret",
+			"GIT_COMMITTER_EMAIL=alice.secret@example.com",
+		)
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+	write := func(rel, content string) {
+		path := filepath.Join(dir, rel)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	git("init")
+	write(".beads/beads.jsonl", `{"id":"RD-1","title":"Ledger","status":"open","priority":1,"issue_type":"feature"}
+{"id":"RD-2","title":"Rounding","status":"open","priority":2,"issue_type":"bug","dependencies":[{"issue_id":"RD-2","depends_on_id":"RD-1","type":"blocks"}]}`)
+	write("pkg/ledger/ledger.go", "package ledger\n")
+	git("add", ".")
+	git("commit", "-m", "RD-1: Wire the Zanzibar ledger")
+
+	write(".beads/beads.jsonl", `{"id":"RD-1","title":"Ledger","status":"in_progress","priority":1,"issue_type":"feature"}
+{"id":"RD-2","title":"Rounding","status":"in_progress","priority":2,"issue_type":"bug","labels":["review"],"dependencies":[{"issue_id":"RD-2","depends_on_id":"RD-1","type":"blocks"}]}`)
+	write("pkg/ledger/ledger.go", "package ledger\n\nfunc Round() {}\n")
+	write("CODEOWNERS", "pkg/ @alice.secret\n")
+	git("add", ".")
+	git("commit", "-m", "RD-2: Fix Quixotic rounding in the ledger")
+	return dir
+}
+
+// assertNoRedactSecrets fails if out mentions any fixture author or subject.
+func assertNoRedactSecrets(t *testing.T, what, out string) {
+	t.Helper()
+	for _, secret := range redactSecrets {
+		if strings.Contains(out, secret) {
+			t.Errorf("%s leaks %q:\n%s", what, secret, out)
+		}
+	}
+}
+
+func TestRedactRobotShowCodeRefs(t *testing.T) {
+	bv := buildBvBinary(t)
+	dir := createRedactRepo(t)
+	cmd := exec.Command(bv, "--robot-show", "RD-2", "--redact")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "BEADS_DIR=")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-show --redact: %v\n%s", err, out)
+	}
+	if !strings.Contains(string(out), `"code_refs":{"commits":[{`) {
+		t.Fatalf("fixture commit missing from code_refs:\n%s", out)
+	}
+	assertNoRedactSecrets(t, "--robot-show --redact", string(out))
+	if strings.Contains(string(out), "pkg/ledger") {
+		t.Errorf("--robot-show --redact leaks commit paths:\n%s", out)
+	}
+}
+
+func TestRedactTriageReviewers(t *testing.T) {
+	bv := buildBvBinary(t)
+	dir := createRedactRepo(t)
+	cmd := exec.Command(bv, "--robot-triage", "--redact")
+	cmd.Dir = dir
+	cmd.Env = append(os.Environ(), "BEADS_DIR=")
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-triage --redact: %v\n%s", err, out)
+	}
+	assertNoRedactSecrets(t, "--robot-triage --redact", string(out))
+
+	var payload struct {
+		Triage struct {
+			Recommendations []struct {
+				ID                 string   `json:"id"`
+				Reasons            []string `json:"reasons"`
+				Owners             []string `json:"owners"`
+				SuggestedReviewers []struct {
+					Reviewer  string   `json:"reviewer"`
+					Rationale []string `json:"rationale"`
+				} `json:"suggested_reviewers"`
+			} `json:"recommendations"`
+		} `json:"triage"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("decode: %v\n%s", err, out)
+	}
+	for _, rec := range payload.Triage.Recommendations {
+		if rec.ID != "RD-2" {
+			continue
+		}
+		if len(rec.SuggestedReviewers) != 1 || len(rec.Owners) != 1 {
+			t.Fatalf("RD-2 reviewers = %+v, owners = %v", rec.SuggestedReviewers, rec.Owners)
+		}
+		// The owner and the committer are still recognized as one person
+		reviewer := rec.SuggestedReviewers[0]
+		if !strings.HasPrefix(reviewer.Reviewer, "user-") || reviewer.Reviewer != rec.Owners[0] || len(reviewer.Rationale) != 2 {
+			t.Errorf("RD-2 reviewer = %+v, owners = %v", reviewer, rec.Owners)
+		}
+		if !strings.Contains(strings.Join(rec.Reasons, "\n"), "suggest "+reviewer.Reviewer) {
+			t.Errorf("RD-2 reasons don't name the redacted reviewer: %q", rec.Reasons)
+		}
+		return
+	}
+	t.Fatalf("RD-2 missing from triage:\n%s", out)
+}
+
+// TestRedactCoversEveryRobotCommand runs each robot command the binary
+// advertises with --redact and checks that no git author or commit subject
+// gets through, including from commands added after --redact.
+func TestRedactCoversEveryRobotCommand(t *testing.T) {
+	bv := buildBvBinary(t)
+	dir := createRedactRepo(t)
+
+	run := func(args ...string) string {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		cmd := exec.CommandContext(ctx, bv, args...)
+		cmd.Dir = dir
+		cmd.Env = append(os.Environ(), "BEADS_DIR=", "BV_ROBOT=1")
+		out, _ := cmd.Output()
+		return string(out)
+	}
+
+	// The unredacted history must mention the secrets, or the test proves nothing
+	if out := run("--robot-history"); !strings.Contains(out, "Zanzibar") {
+		t.Fatalf("fixture history not visible without --redact:\n%s", out)
+	}
+
+	var caps struct {
+		RobotCommands []struct {
+			Flag       string `json:"flag"`
+			TakesValue bool   `json:"takes_value"`
+		} `json:"robot_commands"`
+	}
+	if err := json.Unmarshal([]byte(run("--robot-capabilities")), &caps); err != nil || len(caps.RobotCommands) == 0 {
+		t.Fatalf("--robot-capabilities: %v", err)
+	}
+
+	values := map[string]string{
+		"--robot-burndown":            "current",
+		"--robot-file-beads":          "pkg/ledger/ledger.go",
+		"--robot-file-relations":      "pkg/ledger/ledger.go",
+		"--robot-confirm-correlation": "HEAD:RD-2",
+		"--robot-reject-correlation":  "HEAD:RD-2",
+	}
+	extra := map[string][]string{
+		"--robot-diff":   {"--diff-since", "HEAD~1"},
+		"--robot-search": {"--search", "ledger"},
+	}
+	for _, c := range caps.RobotCommands {
+		args := []string{c.Flag}
+		if c.TakesValue {
+			v, ok := values[c.Flag]
+			if !ok {
+				v = "RD-2"
+			}
+			args = append(args, v)
+		}
+		args = append(args, extra[c.Flag]...)
+		args = append(args, "--redact")
+		assertNoRedactSecrets(t, strings.Join(args, " "), run(args...))
+	}
+}
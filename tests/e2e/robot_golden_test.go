@@ -0,0 +1,128 @@
+package main_test
+
+import (
+	"encoding/json"
+	"flag"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/testutil"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/util/jsonnorm"
+)
+
+// Golden-file regression tests for robot output. Each fixture dataset is run
+// through every command below and the normalized JSON is compared against
+// testdata/golden/robot/<fixture>/<command>.json. To record new goldens after
+// an intended contract change:
+//
+//	go test ./tests/e2e -run TestRobotGolden -update
+//
+// (GENERATE_GOLDEN=1 works too, as for the other golden tests.)
+var updateGolden = flag.Bool("update", false, "rewrite robot golden files in testdata/golden/robot")
+
+// robotGoldenFixture is a dataset plus the instant it treats as "now".
+// Fixture timestamps are shifted so that instant becomes the real current
+// time, which keeps ages ("inactive for N days") identical on every run.
+type robotGoldenFixture struct {
+	name string
+	path string
+	now  time.Time
+}
+
+var robotGoldenFixtures = []robotGoldenFixture{
+	{name: "minimal", path: "../testdata/minimal.jsonl", now: time.Date(2024, 1, 15, 12, 0, 0, 0, time.UTC)},
+	{name: "synthetic_complex", path: "../testdata/synthetic_complex.jsonl", now: time.Date(2023, 11, 15, 12, 0, 0, 0, time.UTC)},
+}
+
+var robotGoldenCommands = []string{
+	"--robot-insights",
+	"--robot-plan",
+	"--robot-priority",
+	"--robot-triage",
+	"--robot-next",
+	"--robot-alerts",
+	"--robot-suggest",
+	"--robot-label-health",
+	"--robot-graph",
+}
+
+// robotGoldenVolatileKeys hold wall-clock values, timings, calendar-aligned
+// buckets, and data hashes (which cover the shifted timestamps).
+var robotGoldenVolatileKeys = map[string]bool{
+	"generated_at":    true,
+	"detected_at":     true,
+	"compute_time_ms": true,
+	"ms":              true,
+	"week_start":      true,
+	"week_starts":     true,
+	"computed_at":     true,
+	"data_hash":       true,
+}
+
+// robotGoldenTolerance absorbs drift in scores that decay with elapsed time:
+// a few seconds pass between shifting the fixture and running bv.
+const robotGoldenTolerance = 1e-6
+
+var rfc3339Pattern = regexp.MustCompile(`\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})`)
+
+func TestRobotGolden(t *testing.T) {
+	bv := buildBvBinary(t)
+	goldenRoot := filepath.Join("..", "..", "testdata", "golden", "robot")
+
+	for _, fx := range robotGoldenFixtures {
+		t.Run(fx.name, func(t *testing.T) {
+			shift := time.Now().UTC().Sub(fx.now)
+			env := t.TempDir()
+			data, err := os.ReadFile(fx.path)
+			if err != nil {
+				t.Fatalf("read fixture: %v", err)
+			}
+			writeBeads(t, env, shiftTimestamps(string(data), shift))
+
+			for _, command := range robotGoldenCommands {
+				t.Run(strings.TrimPrefix(command, "--"), func(t *testing.T) {
+					cmd := execCommand(bv, command)
+					cmd.Dir = env
+					cmd.Env = append(os.Environ(), "BV_ROBOT=1", "BV_PRETTY_JSON=0", "BV_CACHE_DIR="+t.TempDir())
+					out, err := cmd.Output()
+					if err != nil {
+						t.Fatalf("%s failed: %v\n%s", command, err, out)
+					}
+					var payload any
+					if err := json.Unmarshal(out, &payload); err != nil {
+						t.Fatalf("%s json decode: %v\nout=%s", command, err, out)
+					}
+					normalized := normalizeRobotGolden(payload, shift)
+					golden := testutil.NewGoldenFile(t, filepath.Join(goldenRoot, fx.name), strings.TrimPrefix(command, "--")+".json")
+					golden.SetUpdate(*updateGolden).AssertJSONWithin(normalized, robotGoldenTolerance)
+				})
+			}
+		})
+	}
+}
+
+// shiftTimestamps moves every RFC3339 timestamp in s by d.
+func shiftTimestamps(s string, d time.Duration) string {
+	return rfc3339Pattern.ReplaceAllStringFunc(s, func(ts string) string {
+		parsed, err := time.Parse(time.RFC3339Nano, ts)
+		if err != nil {
+			return ts
+		}
+		return parsed.Add(d).UTC().Format(time.RFC3339)
+	})
+}
+
+// normalizeRobotGolden drops volatile keys, shifts timestamps back to the
+// fixture's frame, and rounds floats so last-bit summation noise does not
+// churn the goldens.
+func normalizeRobotGolden(v any, shift time.Duration) any {
+	return jsonnorm.Normalize(v, jsonnorm.Options{
+		DropKeys:    robotGoldenVolatileKeys,
+		FloatDigits: 9,
+		String:      func(s string) string { return shiftTimestamps(s, -shift) },
+	})
+}
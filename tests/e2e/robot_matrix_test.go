@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"os/exec"
+	"path/filepath"
 	"strings"
 	"testing"
 	"time"
@@ -436,6 +437,38 @@ func TestRobotTriageBlockersToClear(t *testing.T) {
 	}
 }
 
+// TestRobotTriagePinned verifies issues starred in the TUI come back in the
+// pinned section, in star order, skipping ones no longer in the data.
+func TestRobotTriagePinned(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"Alpha","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"Beta","status":"open","priority":3,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}`)
+	state := `{"pinned":["B","GONE","A"]}`
+	if err := os.WriteFile(filepath.Join(env, ".beads", ".bv-state.json"), []byte(state), 0o644); err != nil {
+		t.Fatalf("write state: %v", err)
+	}
+
+	var payload struct {
+		Triage struct {
+			Pinned []struct {
+				ID         string   `json:"id"`
+				Actionable bool     `json:"actionable"`
+				BlockedBy  []string `json:"blocked_by"`
+			} `json:"pinned"`
+		} `json:"triage"`
+	}
+	runRobotJSON(t, bv, env, "--robot-triage", &payload)
+
+	pinned := payload.Triage.Pinned
+	if len(pinned) != 2 || pinned[0].ID != "B" || pinned[1].ID != "A" {
+		t.Fatalf("pinned = %+v, want B then A", pinned)
+	}
+	if pinned[0].Actionable || len(pinned[0].BlockedBy) != 1 || pinned[0].BlockedBy[0] != "A" {
+		t.Errorf("B should be blocked by A: %+v", pinned[0])
+	}
+}
+
 func TestRobotMode_IgnoresBackgroundModeFlagAndEnv(t *testing.T) {
 	bv := buildBvBinary(t)
 	env := t.TempDir()
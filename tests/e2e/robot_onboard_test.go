@@ -0,0 +1,78 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRobotOnboard(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	writeBeads(t, env, `{"id":"acme-1","title":"Ready work","status":"open","priority":1,"issue_type":"task"}
+{"id":"acme-2","title":"Blocked work","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"acme-2","depends_on_id":"acme-1","type":"blocks"}]}
+{"id":"acme-3","title":"Someone's on it","status":"in_progress","priority":1,"issue_type":"bug","assignee":"agent-7"}
+{"id":"acme-4","title":"Done","status":"closed","priority":3,"issue_type":"task"}`)
+	agentsMD := "# House rules\n\n## Style\n\n- Run `make lint` before closing\n\n```bash\n- not a rule\n```\n"
+	if err := os.WriteFile(filepath.Join(env, "AGENTS.md"), []byte(agentsMD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "--robot-onboard")
+	cmd.Dir = env
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-onboard failed: %v\n%s", err, out)
+	}
+	var payload struct {
+		Project struct {
+			IssuePrefix string `json:"issue_prefix"`
+			IssueCount  int    `json:"issue_count"`
+			ClosedCount int    `json:"closed_count"`
+		} `json:"project"`
+		TopPick *struct {
+			ID       string `json:"id"`
+			ClaimCmd string `json:"claim_command"`
+		} `json:"top_pick"`
+		Claims []struct {
+			ID       string `json:"id"`
+			Assignee string `json:"assignee"`
+		} `json:"claims"`
+		Conventions *struct {
+			HasBvBlurb bool     `json:"has_bv_blurb"`
+			Headings   []string `json:"headings"`
+			Rules      []string `json:"rules"`
+		} `json:"conventions"`
+		Commands []struct {
+			Command string `json:"command"`
+			When    string `json:"when"`
+		} `json:"commands"`
+		NextSteps []string `json:"next_steps"`
+	}
+	if err := json.Unmarshal(out, &payload); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+
+	if payload.Project.IssuePrefix != "acme" || payload.Project.IssueCount != 4 || payload.Project.ClosedCount != 1 {
+		t.Errorf("project summary: %+v", payload.Project)
+	}
+	if payload.TopPick == nil || payload.TopPick.ID != "acme-1" || !strings.Contains(payload.TopPick.ClaimCmd, "acme-1") {
+		t.Errorf("top pick: %+v", payload.TopPick)
+	}
+	if len(payload.Claims) != 1 || payload.Claims[0].ID != "acme-3" || payload.Claims[0].Assignee != "agent-7" {
+		t.Errorf("claims: %+v", payload.Claims)
+	}
+	if c := payload.Conventions; c == nil || c.HasBvBlurb || len(c.Rules) != 1 || c.Rules[0] != "Run `make lint` before closing" ||
+		strings.Join(c.Headings, "|") != "House rules|Style" {
+		t.Errorf("conventions: %+v", payload.Conventions)
+	}
+	if len(payload.Commands) == 0 || payload.Commands[0].Command != "bv --robot-next" || payload.Commands[0].When == "" {
+		t.Errorf("commands: %+v", payload.Commands)
+	}
+	if len(payload.NextSteps) == 0 {
+		t.Error("expected next steps")
+	}
+}
@@ -0,0 +1,108 @@
+package main_test
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+type robotStatusPayload struct {
+	Healthy       bool `json:"healthy"`
+	InstanceCount int  `json:"instance_count"`
+	Lock          struct {
+		State string `json:"state"`
+	} `json:"lock"`
+	Data struct {
+		Path       string `json:"path"`
+		SizeBytes  int64  `json:"size_bytes"`
+		ModifiedAt string `json:"modified_at"`
+		IssueCount int    `json:"issue_count"`
+		DataHash   string `json:"data_hash"`
+		Error      string `json:"error"`
+	} `json:"data"`
+	Cache struct {
+		Fresh         bool `json:"fresh"`
+		SignalMatches bool `json:"signal_matches_data"`
+	} `json:"cache"`
+	Analysis struct {
+		Phase1 string `json:"phase1"`
+		Phase2 string `json:"phase2"`
+	} `json:"analysis"`
+}
+
+func TestRobotStatus_DataAndCacheReadiness(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	cacheDir := t.TempDir()
+	writeBeads(t, env, `{"id":"A","title":"A","status":"open","priority":1,"issue_type":"task"}
+{"id":"B","title":"B","status":"open","priority":2,"issue_type":"task","dependencies":[{"issue_id":"B","depends_on_id":"A","type":"blocks"}]}`)
+
+	run := func(args ...string) []byte {
+		t.Helper()
+		cmd := exec.Command(bv, args...)
+		cmd.Dir = env
+		cmd.Env = append(os.Environ(), "BV_CACHE_DIR="+cacheDir)
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("%v failed: %v\n%s", args, err, out)
+		}
+		return out
+	}
+	status := func() robotStatusPayload {
+		t.Helper()
+		var s robotStatusPayload
+		out := run("--robot-status")
+		if err := json.Unmarshal(out, &s); err != nil {
+			t.Fatalf("json decode: %v\nout=%s", err, out)
+		}
+		return s
+	}
+
+	cold := status()
+	if !cold.Healthy || cold.Data.Error != "" {
+		t.Fatalf("expected healthy status, got %+v", cold)
+	}
+	if cold.Data.Path != filepath.Join(env, ".beads", "beads.jsonl") || cold.Data.IssueCount != 2 || cold.Data.SizeBytes == 0 || cold.Data.ModifiedAt == "" || cold.Data.DataHash == "" {
+		t.Errorf("data section incomplete: %+v", cold.Data)
+	}
+	if cold.Lock.State != "none" || cold.InstanceCount != 0 {
+		t.Errorf("status must not register or lock: lock=%s instances=%d", cold.Lock.State, cold.InstanceCount)
+	}
+	if cold.Cache.Fresh || cold.Analysis.Phase1 != "ready" || cold.Analysis.Phase2 != "cold" {
+		t.Errorf("expected cold cache before any robot query: cache=%+v analysis=%+v", cold.Cache, cold.Analysis)
+	}
+
+	// A robot query fills the disk cache; status should now report it warm.
+	run("--robot-insights")
+	warm := status()
+	if !warm.Cache.Fresh || !warm.Cache.SignalMatches || warm.Analysis.Phase2 != "cached" {
+		t.Errorf("expected warm cache after --robot-insights: cache=%+v analysis=%+v", warm.Cache, warm.Analysis)
+	}
+	if warm.Data.DataHash != cold.Data.DataHash {
+		t.Errorf("data hash changed without a data change: %s vs %s", cold.Data.DataHash, warm.Data.DataHash)
+	}
+}
+
+func TestRobotStatus_ReportsMissingData(t *testing.T) {
+	bv := buildBvBinary(t)
+	env := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(env, ".beads"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	cmd := exec.Command(bv, "--robot-status")
+	cmd.Dir = env
+	out, err := cmd.Output()
+	if err != nil {
+		t.Fatalf("--robot-status should succeed without data: %v\n%s", err, out)
+	}
+	var s robotStatusPayload
+	if err := json.Unmarshal(out, &s); err != nil {
+		t.Fatalf("json decode: %v\nout=%s", err, out)
+	}
+	if s.Healthy || s.Data.Error == "" || s.Analysis.Phase1 != "unavailable" {
+		t.Errorf("expected unhealthy status with a data error, got %+v", s)
+	}
+}
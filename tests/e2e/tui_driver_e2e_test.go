@@ -0,0 +1,80 @@
+package main_test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Dicklesworthstone/beads_viewer/pkg/loader"
+	"github.com/Dicklesworthstone/beads_viewer/pkg/ui"
+)
+
+// TestTUIDriverStaleSweepScenario drives the TUI headlessly over a beads
+// file on disk: filter to an issue, open it, close it from the stale sweep,
+// and check that bd was run in the repository root.
+func TestTUIDriverStaleSweepScenario(t *testing.T) {
+	tempDir := t.TempDir()
+	beadsDir := filepath.Join(tempDir, ".beads")
+	if err := os.MkdirAll(beadsDir, 0o755); err != nil {
+		t.Fatalf("mkdir beads: %v", err)
+	}
+	old := time.Now().Add(-90 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	recent := time.Now().Add(-time.Hour).UTC().Format(time.RFC3339)
+	beads := `{"id":"S1","title":"Forgotten cache flag","status":"open","priority":2,"issue_type":"task","created_at":"` + old + `","updated_at":"` + old + `"}
+{"id":"A1","title":"Active work","status":"in_progress","priority":1,"issue_type":"task","created_at":"` + recent + `","updated_at":"` + recent + `"}`
+	beadsPath := filepath.Join(beadsDir, "issues.jsonl")
+	if err := os.WriteFile(beadsPath, []byte(beads), 0o644); err != nil {
+		t.Fatalf("write beads: %v", err)
+	}
+	issues, err := loader.LoadIssuesFromFile(beadsPath)
+	if err != nil {
+		t.Fatalf("load beads: %v", err)
+	}
+
+	var mu sync.Mutex
+	var calls []string
+	m := ui.NewModel(issues, nil, beadsPath)
+	m.SetBdRunner(func(dir string, args ...string) ([]byte, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, dir+": bd "+strings.Join(args, " "))
+		return []byte("✓ Closed S1\n"), nil
+	})
+	d := ui.NewDriver(m, 100, 30)
+	defer d.Close(time.Second)
+
+	if err := d.SendKeys("/"); err != nil {
+		t.Fatal(err)
+	}
+	d.Type("cache")
+	if err := d.SendKeys("enter", "enter"); err != nil {
+		t.Fatal(err)
+	}
+	if view := d.CurrentView(); view != "detail" && view != "split" {
+		t.Fatalf("view after opening the issue = %q\n%s", view, d.CapturedFrame())
+	}
+	if !strings.Contains(d.CapturedFrame(), "Forgotten cache flag") {
+		t.Fatalf("issue not shown:\n%s", d.CapturedFrame())
+	}
+
+	if err := d.SendKeys("esc", "Z", "x", "y"); err != nil {
+		t.Fatal(err)
+	}
+	if !d.WaitFor(func(d *ui.Driver) bool { return strings.Contains(d.CapturedFrame(), "Stale sweep: 1 done") }, 5*time.Second) {
+		t.Fatalf("stale sweep did not finish:\n%s", d.CapturedFrame())
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	root, err := filepath.Abs(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := root + ": bd close S1 --reason stale: no activity in 90 days"
+	if len(calls) != 1 || calls[0] != want {
+		t.Errorf("bd calls = %q, want [%q]", calls, want)
+	}
+}